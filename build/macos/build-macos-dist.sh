@@ -14,7 +14,7 @@ echo "Building executable..."
 export CGO_ENABLED=1
 export GOOS=darwin
 export GOARCH=amd64
-go build -ldflags="-s -w" -o macos-dist/board-game-library ./cmd/server
+go build -tags sqlite_fts5 -ldflags="-s -w" -o macos-dist/board-game-library ./cmd/server
 
 # Make the executable... executable
 chmod +x macos-dist/board-game-library
@@ -7,7 +7,7 @@ mkdir -p dist/web
 mkdir -p dist/docs
 
 # Build the executable
-go build -o dist/board-game-library ./cmd/server
+go build -tags sqlite_fts5 -o dist/board-game-library ./cmd/server
 
 # Make executable
 chmod +x dist/board-game-library
@@ -16,7 +16,7 @@ mkdir -p "$BUNDLE_DIR/Resources"
 
 # Build the executable
 echo "Building executable..."
-go build -ldflags="-s -w" -o "$BUNDLE_DIR/MacOS/BoardGameLibrary" ./cmd/server
+go build -tags sqlite_fts5 -ldflags="-s -w" -o "$BUNDLE_DIR/MacOS/BoardGameLibrary" ./cmd/server
 
 # Make executable
 chmod +x "$BUNDLE_DIR/MacOS/BoardGameLibrary"
@@ -15,7 +15,7 @@ echo Building executable...
 set CGO_ENABLED=1
 set GOOS=windows
 set GOARCH=amd64
-go build -ldflags="-s -w -H=windowsgui" -o windows-dist\board-game-library.exe ./cmd/server
+go build -tags sqlite_fts5 -ldflags="-s -w -H=windowsgui" -o windows-dist\board-game-library.exe ./cmd/server
 
 REM Copy required assets
 echo Copying assets...
@@ -8,7 +8,7 @@ if not exist "dist\web" mkdir dist\web
 if not exist "dist\docs" mkdir dist\docs
 
 REM Build the executable
-go build -o dist\board-game-library.exe ./cmd/server
+go build -tags sqlite_fts5 -o dist\board-game-library.exe ./cmd/server
 
 REM Copy web assets and docs
 xcopy /E /I web dist\web
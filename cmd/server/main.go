@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"board-game-library/internal/app"
+	"board-game-library/internal/cli"
 	_ "board-game-library/docs" // This line is needed for go-swagger to find your docs!
 )
 
@@ -25,6 +26,12 @@ import (
 // @schemes http
 
 func main() {
+	// Subcommands such as import and export work on the database and exit
+	// without starting the server
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	// Create and initialize application
 	application, err := app.New()
 	if err != nil {
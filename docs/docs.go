@@ -1,3 +1,5 @@
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
@@ -23,80 +25,125 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/games": {
+        "/alerts": {
             "get": {
-                "description": "Récupère la liste de tous les jeux avec recherche optionnelle",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["games"],
-                "summary": "Lister tous les jeux",
+                "description": "Récupère une page d'alertes, filtrée et triée; seules les alertes non lues sont renvoyées par défaut",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alerts"
+                ],
+                "summary": "Lister les alertes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Terme de recherche",
+                        "default": "unread",
+                        "description": "Statut de lecture (unread, read, all)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rechercher dans le message, les noms de l'utilisateur et du jeu et l'email de l'utilisateur",
                         "name": "search",
                         "in": "query"
                     },
                     {
-                        "type": "boolean",
-                        "description": "Filtrer par disponibilité",
-                        "name": "available",
+                        "type": "integer",
+                        "description": "Filtrer par utilisateur",
+                        "name": "user_id",
                         "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Liste des jeux",
-                        "schema": {"type": "object"}
                     },
-                    "500": {
-                        "description": "Erreur serveur",
-                        "schema": {"type": "object"}
-                    }
-                }
-            },
-            "post": {
-                "description": "Ajoute un nouveau jeu à la bibliothèque",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["games"],
-                "summary": "Ajouter un nouveau jeu",
-                "parameters": [
                     {
-                        "description": "Informations du jeu",
-                        "name": "game",
-                        "in": "body",
-                        "required": true,
-                        "schema": {"$ref": "#/definitions/handlers.AddGameRequest"}
+                        "type": "integer",
+                        "description": "Filtrer par jeu",
+                        "name": "game_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par type (overdue, reminder...)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Créées depuis cette date (AAAA-MM-JJ ou RFC 3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Créées jusqu'à cette date incluse (AAAA-MM-JJ ou RFC 3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Tri (created_at, type)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sens du tri (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Numéro de page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Alertes par page (100 au maximum)",
+                        "name": "per_page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Jeu créé avec succès",
-                        "schema": {"type": "object"}
+                    "200": {
+                        "description": "Page d'alertes",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "400": {
-                        "description": "Données invalides",
-                        "schema": {"type": "object"}
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     },
                     "500": {
                         "description": "Erreur serveur",
-                        "schema": {"type": "object"}
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
             }
         },
-        "/games/{id}": {
+        "/alerts/{id}/notification": {
             "get": {
-                "description": "Récupère les détails d'un jeu spécifique",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["games"],
-                "summary": "Obtenir un jeu par ID",
+                "description": "Récupère l'état de l'envoi par email d'une alerte : en attente, envoyé, échoué ou ignoré, avec le nombre de tentatives et la dernière erreur",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Envoi de l'email d'une alerte",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID du jeu",
+                        "description": "ID de l'alerte",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -104,271 +151,326 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Détails du jeu",
-                        "schema": {"type": "object"}
+                        "description": "État de l'envoi",
+                        "schema": {
+                            "$ref": "#/definitions/models.AlertNotification"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     },
                     "404": {
-                        "description": "Jeu non trouvé",
-                        "schema": {"type": "object"}
+                        "description": "Aucun envoi pour cette alerte",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Met à jour les informations d'un jeu",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["games"],
-                "summary": "Mettre à jour un jeu",
+            }
+        },
+        "/audit": {
+            "get": {
+                "description": "Récupère les modifications enregistrées dans le journal d'audit, les plus récentes en premier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Journal d'audit",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID du jeu",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filtrer par auteur",
+                        "name": "actor_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Nouvelles informations du jeu",
-                        "name": "game",
-                        "in": "body",
-                        "required": true,
-                        "schema": {"$ref": "#/definitions/handlers.AddGameRequest"}
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Jeu mis à jour avec succès",
-                        "schema": {"type": "object"}
+                        "type": "string",
+                        "description": "Filtrer par action (create, update, delete, borrow, return, extend...)",
+                        "name": "action",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Données invalides",
-                        "schema": {"type": "object"}
+                    {
+                        "type": "string",
+                        "description": "Filtrer par type d'entité (game, user, borrowing, alert...)",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par identifiant d'entité",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Depuis cette date (AAAA-MM-JJ ou RFC 3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jusqu'à cette date incluse (AAAA-MM-JJ ou RFC 3339)",
+                        "name": "until",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Jeu non trouvé",
-                        "schema": {"type": "object"}
-                    }
-                }
-            },
-            "delete": {
-                "description": "Supprime un jeu de la bibliothèque",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["games"],
-                "summary": "Supprimer un jeu",
-                "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID du jeu",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 50,
+                        "description": "Nombre maximum d'événements",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Nombre d'événements à ignorer",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Jeu supprimé avec succès",
-                        "schema": {"type": "object"}
-                    },
-                    "404": {
-                        "description": "Jeu non trouvé",
-                        "schema": {"type": "object"}
+                        "description": "Événements d'audit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    "409": {
-                        "description": "Impossible de supprimer (contraintes)",
-                        "schema": {"type": "object"}
-                    }
-                }
-            }
-        },
-        "/users": {
-            "get": {
-                "description": "Récupère la liste de tous les utilisateurs",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["users"],
-                "summary": "Lister tous les utilisateurs",
-                "responses": {
-                    "200": {
-                        "description": "Liste des utilisateurs",
-                        "schema": {"type": "object"}
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     },
                     "500": {
                         "description": "Erreur serveur",
-                        "schema": {"type": "object"}
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/auth/login": {
             "post": {
-                "description": "Inscrit un nouvel utilisateur",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["users"],
-                "summary": "Inscrire un nouvel utilisateur",
+                "description": "Vérifie les identifiants et ouvre une session (cookie HttpOnly)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Se connecter",
                 "parameters": [
                     {
-                        "description": "Informations de l'utilisateur",
-                        "name": "user",
+                        "description": "Identifiants",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
-                        "schema": {"$ref": "#/definitions/handlers.RegisterUserRequest"}
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Utilisateur créé avec succès",
-                        "schema": {"type": "object"}
-                    },
-                    "400": {
-                        "description": "Données invalides",
-                        "schema": {"type": "object"}
+                    "200": {
+                        "description": "Connecté",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    "500": {
-                        "description": "Erreur serveur",
-                        "schema": {"type": "object"}
+                    "401": {
+                        "description": "Identifiants invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
             }
         },
-        "/users/{id}": {
-            "get": {
-                "description": "Récupère les détails d'un utilisateur spécifique",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["users"],
-                "summary": "Obtenir un utilisateur par ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID de l'utilisateur",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+        "/auth/logout": {
+            "post": {
+                "description": "Ferme la session du navigateur",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Se déconnecter",
+                "responses": {
+                    "200": {
+                        "description": "Déconnecté",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "description": "Récupère le compte de l'utilisateur authentifié",
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Utilisateur connecté",
                 "responses": {
                     "200": {
-                        "description": "Détails de l'utilisateur",
-                        "schema": {"type": "object"}
+                        "description": "Utilisateur connecté",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
                     },
-                    "404": {
-                        "description": "Utilisateur non trouvé",
-                        "schema": {"type": "object"}
+                    "401": {
+                        "description": "Non authentifié",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Met à jour les informations d'un utilisateur",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["users"],
-                "summary": "Mettre à jour un utilisateur",
+            }
+        },
+        "/auth/setup": {
+            "post": {
+                "description": "Crée le compte administrateur initial ; possible uniquement tant qu'aucun administrateur n'existe",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Créer le premier administrateur",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID de l'utilisateur",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Nouvelles informations de l'utilisateur",
-                        "name": "user",
+                        "description": "Compte administrateur",
+                        "name": "account",
                         "in": "body",
                         "required": true,
-                        "schema": {"$ref": "#/definitions/handlers.RegisterUserRequest"}
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetupRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Utilisateur mis à jour avec succès",
-                        "schema": {"type": "object"}
+                    "201": {
+                        "description": "Administrateur créé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "400": {
                         "description": "Données invalides",
-                        "schema": {"type": "object"}
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     },
-                    "404": {
-                        "description": "Utilisateur non trouvé",
-                        "schema": {"type": "object"}
+                    "409": {
+                        "description": "Configuration déjà effectuée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
             }
         },
-        "/borrowings": {
+        "/auth/tokens": {
             "get": {
-                "description": "Récupère la liste de tous les emprunts",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["borrowings"],
-                "summary": "Lister tous les emprunts",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID de l'utilisateur pour filtrer",
-                        "name": "user_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filtrer par emprunts actifs seulement",
-                        "name": "active_only",
-                        "in": "query"
-                    }
+                "description": "Récupère les jetons d'API de l'utilisateur connecté (sans leur valeur)",
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Lister mes jetons d'API",
                 "responses": {
                     "200": {
-                        "description": "Liste des emprunts",
-                        "schema": {"type": "object"}
+                        "description": "Jetons d'API",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
-                    "500": {
-                        "description": "Erreur serveur",
-                        "schema": {"type": "object"}
+                    "401": {
+                        "description": "Non authentifié",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
             },
             "post": {
-                "description": "Crée un nouvel emprunt",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["borrowings"],
-                "summary": "Créer un nouvel emprunt",
+                "description": "Crée un jeton d'API pour les scripts ; sa valeur n'est affichée qu'une seule fois",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Créer un jeton d'API",
                 "parameters": [
                     {
-                        "description": "Informations de l'emprunt",
-                        "name": "borrowing",
+                        "description": "Nom du jeton",
+                        "name": "token",
                         "in": "body",
                         "required": true,
-                        "schema": {"$ref": "#/definitions/handlers.BorrowGameRequest"}
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Emprunt créé avec succès",
-                        "schema": {"type": "object"}
+                        "description": "Jeton créé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "400": {
                         "description": "Données invalides",
-                        "schema": {"type": "object"}
-                    },
-                    "500": {
-                        "description": "Erreur serveur",
-                        "schema": {"type": "object"}
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
             }
         },
-        "/borrowings/{id}": {
-            "get": {
-                "description": "Récupère les détails d'un emprunt spécifique",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["borrowings"],
-                "summary": "Obtenir un emprunt par ID",
+        "/auth/tokens/{id}": {
+            "delete": {
+                "description": "Supprime un jeton d'API ; les administrateurs peuvent révoquer ceux des autres",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Révoquer un jeton d'API",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de l'emprunt",
+                        "description": "ID du jeton",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -376,163 +478,2674 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Détails de l'emprunt",
-                        "schema": {"type": "object"}
+                        "description": "Jeton révoqué",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "404": {
-                        "description": "Emprunt non trouvé",
-                        "schema": {"type": "object"}
+                        "description": "Jeton non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
             }
         },
-        "/borrowings/{id}/return": {
-            "post": {
-                "description": "Marque un emprunt comme retourné",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["borrowings"],
-                "summary": "Retourner un jeu emprunté",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID de l'emprunt",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+        "/backup": {
+            "get": {
+                "description": "Télécharge une copie cohérente de la base de données SQLite, prise sans interrompre le serveur. Le fichier peut être restauré avec la commande restore.",
+                "produces": [
+                    "application/vnd.sqlite3"
                 ],
+                "tags": [
+                    "backup"
+                ],
+                "summary": "Télécharger une sauvegarde",
                 "responses": {
                     "200": {
-                        "description": "Jeu retourné avec succès",
-                        "schema": {"type": "object"}
+                        "description": "Sauvegarde de la base de données",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
-                    "404": {
-                        "description": "Emprunt non trouvé",
-                        "schema": {"type": "object"}
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     },
-                    "400": {
-                        "description": "Emprunt déjà retourné",
-                        "schema": {"type": "object"}
+                    "503": {
+                        "description": "Sauvegardes non disponibles avec PostgreSQL",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
             }
         },
-        "/alerts": {
+        "/borrowings": {
             "get": {
-                "description": "Récupère la liste de toutes les alertes",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["alerts"],
-                "summary": "Lister toutes les alertes",
+                "description": "Récupère une page d'emprunts, filtrée et triée, les plus récents en premier par défaut",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "borrowings"
+                ],
+                "summary": "Lister les emprunts",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Filtrer par alertes non lues seulement",
-                        "name": "unread_only",
+                        "type": "string",
+                        "description": "Rechercher dans les noms de l'utilisateur et du jeu et l'email de l'utilisateur",
+                        "name": "search",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "ID de l'utilisateur pour filtrer",
+                        "description": "Filtrer par utilisateur",
                         "name": "user_id",
                         "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Liste des alertes",
-                        "schema": {"type": "object"}
                     },
-                    "500": {
-                        "description": "Erreur serveur",
-                        "schema": {"type": "object"}
-                    }
-                }
-            },
-            "post": {
-                "description": "Crée une nouvelle alerte personnalisée",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["alerts"],
-                "summary": "Créer une nouvelle alerte",
-                "parameters": [
                     {
-                        "description": "Informations de l'alerte",
-                        "name": "alert",
-                        "in": "body",
-                        "required": true,
-                        "schema": {"$ref": "#/definitions/handlers.CreateAlertRequest"}
+                        "type": "integer",
+                        "description": "Filtrer par jeu",
+                        "name": "game_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par statut (active, returned, overdue)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Empruntés depuis cette date (AAAA-MM-JJ ou RFC 3339)",
+                        "name": "borrowed_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Empruntés jusqu'à cette date incluse (AAAA-MM-JJ ou RFC 3339)",
+                        "name": "borrowed_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "À rendre à partir de cette date (AAAA-MM-JJ ou RFC 3339)",
+                        "name": "due_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "À rendre jusqu'à cette date incluse (AAAA-MM-JJ ou RFC 3339)",
+                        "name": "due_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "borrowed_at",
+                        "description": "Tri (borrowed_at, due_date, returned_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sens du tri (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Numéro de page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Emprunts par page (100 au maximum)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page d'emprunts",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/borrowings/{id}/return": {
+            "put": {
+                "description": "Enregistre le retour d'un emprunt. L'état du jeu au retour et des remarques sur les pièces manquantes ou les dégâts peuvent être indiqués ; ils sont conservés dans l'historique de l'état du jeu, et une dégradation alerte les bibliothécaires. Un exemplaire en mauvais état ou incomplet est retiré du prêt jusqu'à sa réparation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "borrowings"
+                ],
+                "summary": "Rendre un jeu",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'emprunt",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "État du jeu au retour",
+                        "name": "report",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReturnGameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Jeu rendu",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Emprunt non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Jeu déjà rendu",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/export/{table}": {
+            "get": {
+                "description": "Télécharge tous les jeux, utilisateurs ou emprunts au format CSV ou JSON, relisible par l'import. Les lignes sont envoyées au fur et à mesure de leur lecture.",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "import-export"
+                ],
+                "summary": "Exporter des données en masse",
+                "parameters": [
+                    {
+                        "enum": [
+                            "games",
+                            "users",
+                            "borrowings"
+                        ],
+                        "type": "string",
+                        "description": "Table",
+                        "name": "table",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Format du fichier",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fichier exporté",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/games": {
+            "get": {
+                "description": "Récupère une page de jeux, filtrée et triée",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Lister les jeux",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Terme de recherche (nom, description, étiquettes), insensible aux accents, par préfixe",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filtrer par étiquette (nom ou slug), répétable : les jeux doivent porter toutes les étiquettes",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par état (excellent, good, fair, poor)",
+                        "name": "condition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filtrer par disponibilité",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jouable à ce nombre de joueurs",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Durée de partie maximale en minutes",
+                        "name": "max_play_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Âge des joueurs (jeux dont l'âge minimum est inférieur ou égal)",
+                        "name": "age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par éditeur (contient)",
+                        "name": "publisher",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par auteur (contient)",
+                        "name": "designer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Publiés à partir de cette année",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Publiés jusqu'à cette année",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Complexité minimale (1 à 5)",
+                        "name": "min_complexity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Complexité maximale (1 à 5)",
+                        "name": "max_complexity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "name",
+                        "description": "Tri (relevance si search est renseigné, name, condition, entry_date, available_copies, year_published, play_time, complexity)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sens du tri (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Numéro de page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Jeux par page (100 au maximum)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page de jeux",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Ajoute un nouveau jeu à la bibliothèque",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Ajouter un nouveau jeu",
+                "parameters": [
+                    {
+                        "description": "Informations du jeu",
+                        "name": "game",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddGameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Jeu créé avec succès",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/bgg/import": {
+            "post": {
+                "description": "Ajoute à la bibliothèque un jeu décrit par BoardGameGeek (description, joueurs, durée, année, image, auteurs, éditeur, complexité), ses catégories devenant des étiquettes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Importer un jeu depuis BoardGameGeek",
+                "parameters": [
+                    {
+                        "description": "Identifiant BoardGameGeek et état de l'exemplaire (good par défaut)",
+                        "name": "import",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportBGGGameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Jeu importé",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Jeu inconnu de BoardGameGeek",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "502": {
+                        "description": "BoardGameGeek injoignable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Import désactivé ou BoardGameGeek occupé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/bgg/search": {
+            "get": {
+                "description": "Recherche des jeux sur BoardGameGeek par nom, ou par identifiant BoardGameGeek si q est un nombre",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Rechercher sur BoardGameGeek",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nom ou identifiant BoardGameGeek",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Jeux trouvés",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Recherche invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "502": {
+                        "description": "BoardGameGeek injoignable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Import désactivé ou BoardGameGeek occupé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/bgg/{bggId}": {
+            "get": {
+                "description": "Renvoie le jeu tel qu'il serait importé depuis BoardGameGeek, sans l'ajouter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Prévisualiser un jeu BoardGameGeek",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Identifiant BoardGameGeek",
+                        "name": "bggId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Jeu à importer",
+                        "schema": {
+                            "$ref": "#/definitions/models.Game"
+                        }
+                    },
+                    "400": {
+                        "description": "Identifiant invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Jeu inconnu de BoardGameGeek",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "502": {
+                        "description": "BoardGameGeek injoignable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Import désactivé ou BoardGameGeek occupé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/search": {
+            "get": {
+                "description": "Recherche plein texte des jeux par nom, description ou étiquette, insensible à la casse et aux accents, chaque mot étant cherché comme préfixe. Les résultats sont classés par pertinence et chaque jeu porte un champ match avec le nom et un extrait de la description, en texte brut, accompagnés des positions des termes trouvés (en caractères Unicode, fin exclue). Accepte les mêmes filtres que la liste des jeux.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Rechercher des jeux",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Terme de recherche",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filtrer par étiquette (nom ou slug), répétable : les jeux doivent porter toutes les étiquettes",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par état (excellent, good, fair, poor)",
+                        "name": "condition",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filtrer par disponibilité",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jouable à ce nombre de joueurs",
+                        "name": "players",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Durée de partie maximale en minutes",
+                        "name": "max_play_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Âge des joueurs (jeux dont l'âge minimum est inférieur ou égal)",
+                        "name": "age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par éditeur (contient)",
+                        "name": "publisher",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par auteur (contient)",
+                        "name": "designer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Publiés à partir de cette année",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Publiés jusqu'à cette année",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Complexité minimale (1 à 5)",
+                        "name": "min_complexity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Complexité maximale (1 à 5)",
+                        "name": "max_complexity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "relevance",
+                        "description": "Tri (relevance, name, condition, entry_date, available_copies, year_published, play_time, complexity)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sens du tri (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Numéro de page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Jeux par page (100 au maximum)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page de jeux trouvés",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/availability": {
+            "get": {
+                "description": "Indique le nombre d'exemplaires libres d'un jeu et les emprunts en cours",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Disponibilité d'un jeu",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du jeu",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Disponibilité du jeu",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Jeu non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/condition-history": {
+            "get": {
+                "description": "Liste les changements d'état d'un jeu et de ses exemplaires, les plus récents en premier, relevés aux retours et lors des inspections",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Historique de l'état d'un jeu",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du jeu",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Historique de l'état",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Jeu non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/copies": {
+            "get": {
+                "description": "Récupère les exemplaires physiques d'un jeu avec leur état et leur disponibilité",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Lister les exemplaires d'un jeu",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du jeu",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Liste des exemplaires",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Jeu non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Ajoute un exemplaire physique à un jeu existant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Ajouter un exemplaire",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du jeu",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Informations de l'exemplaire",
+                        "name": "copy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GameCopyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Exemplaire ajouté",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Jeu non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Code-barres déjà utilisé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/copies/{copyId}": {
+            "put": {
+                "description": "Modifie le code-barres et l'état d'un exemplaire",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Modifier un exemplaire",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du jeu",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de l'exemplaire",
+                        "name": "copyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Informations de l'exemplaire",
+                        "name": "copy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GameCopyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exemplaire modifié",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Exemplaire non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Retire un exemplaire de la bibliothèque (impossible s'il est emprunté ou si c'est le dernier)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Retirer un exemplaire",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du jeu",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de l'exemplaire",
+                        "name": "copyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exemplaire retiré",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Exemplaire non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Exemplaire non supprimable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/copies/{copyId}/condition": {
+            "post": {
+                "description": "Enregistre l'état constaté d'un exemplaire, avec des remarques facultatives sur les pièces manquantes ou les dégâts. Un exemplaire en mauvais état ou incomplet est retiré du prêt ; il y revient quand un nouvel état le déclare réparé. Une dégradation alerte les bibliothécaires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Signaler l'état d'un exemplaire",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du jeu",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID de l'exemplaire",
+                        "name": "copyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "État de l'exemplaire",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ConditionReportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "État enregistré",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Exemplaire non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/import/{table}": {
+            "post": {
+                "description": "Importe des jeux, des utilisateurs ou un historique d'emprunts depuis un fichier CSV (avec ligne d'en-tête) ou JSON (tableau d'objets), envoyé tel quel ou dans le champ \"file\" d'un formulaire. Chaque ligne est validée ; l'import est entièrement annulé si une ligne est invalide, et le problème renvoyé liste les erreurs de chaque ligne. En mode dry_run, rien n'est enregistré.",
+                "consumes": [
+                    "text/csv",
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import-export"
+                ],
+                "summary": "Importer des données en masse",
+                "parameters": [
+                    {
+                        "enum": [
+                            "games",
+                            "users",
+                            "borrowings"
+                        ],
+                        "type": "string",
+                        "description": "Table",
+                        "name": "table",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "Format du fichier, déduit de son nom ou de son type sinon",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Valider sans enregistrer",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Fichier à importer",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Fichier valide (dry_run)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "201": {
+                        "description": "Lignes importées",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Fichier illisible, paramètres ou lignes invalides, rien n'a été importé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Fichier trop volumineux",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "description": "Récupère la liste des tâches en arrière-plan et l'état du planificateur",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Lister les tâches planifiées",
+                "responses": {
+                    "200": {
+                        "description": "Liste des tâches",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/executions": {
+            "get": {
+                "description": "Récupère l'historique paginé des exécutions des tâches, les plus récentes en premier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Historique des exécutions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Nombre maximum d'exécutions",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Nombre d'exécutions à ignorer",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par nom de tâche",
+                        "name": "job",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Historique des exécutions",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/statistics": {
+            "get": {
+                "description": "Récupère les statistiques de réussite des tâches en arrière-plan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Statistiques des tâches",
+                "responses": {
+                    "200": {
+                        "description": "Statistiques",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{name}": {
+            "get": {
+                "description": "Récupère l'état d'une tâche en arrière-plan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Obtenir une tâche planifiée",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nom de la tâche",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tâche",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Tâche non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{name}/disable": {
+            "put": {
+                "description": "Désactive une tâche en arrière-plan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Désactiver une tâche",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nom de la tâche",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tâche désactivée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Tâche non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{name}/enable": {
+            "put": {
+                "description": "Active une tâche en arrière-plan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Activer une tâche",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nom de la tâche",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tâche activée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Tâche non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{name}/run": {
+            "post": {
+                "description": "Lance immédiatement une tâche en arrière-plan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Exécuter une tâche",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nom de la tâche",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Tâche lancée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Tâche non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Tâche désactivée ou déjà en cours",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Planificateur arrêté",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{name}/schedule": {
+            "put": {
+                "description": "Modifie la planification d'une tâche : durée (\"6h\") ou expression cron (\"0 8 * * *\")",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Planifier une tâche",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Nom de la tâche",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nouvelle planification",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RescheduleJobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tâche replanifiée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Planification invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Tâche non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/loan-policies": {
+            "get": {
+                "description": "Récupère les limites d'emprunt de chaque niveau d'adhésion",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loan-policies"
+                ],
+                "summary": "Lister les politiques de prêt",
+                "responses": {
+                    "200": {
+                        "description": "Politiques de prêt",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Ajoute un niveau d'adhésion avec ses limites d'emprunt et ses pénalités de retard, en centimes : montant par jour de retard au-delà du délai de grâce, plafond par retour (0 pour aucun) et solde d'amendes au-delà duquel l'emprunt est bloqué (null pour aucun)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loan-policies"
+                ],
+                "summary": "Créer une politique de prêt",
+                "parameters": [
+                    {
+                        "description": "Limites du niveau",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoanPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Politique créée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Niveau déjà existant",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/loan-policies/{tier}": {
+            "get": {
+                "description": "Récupère les limites d'emprunt d'un niveau d'adhésion",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loan-policies"
+                ],
+                "summary": "Obtenir une politique de prêt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Niveau d'adhésion",
+                        "name": "tier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Politique de prêt",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoanPolicy"
+                        }
+                    },
+                    "404": {
+                        "description": "Niveau non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Modifie les limites d'emprunt et les pénalités de retard d'un niveau d'adhésion existant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loan-policies"
+                ],
+                "summary": "Modifier une politique de prêt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Niveau d'adhésion",
+                        "name": "tier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nouvelles limites",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoanPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Politique modifiée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Niveau non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations": {
+            "get": {
+                "description": "Récupère les réservations en attente ou prêtes, groupées par jeu dans l'ordre de la file",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Lister les réservations actives",
+                "responses": {
+                    "200": {
+                        "description": "Réservations actives",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Ajoute l'utilisateur à la file d'attente d'un jeu actuellement emprunté",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Réserver un jeu",
+                "parameters": [
+                    {
+                        "description": "Utilisateur et jeu",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PlaceHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Réservation créée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Utilisateur ou jeu non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Réservation impossible",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/expire": {
+            "post": {
+                "description": "Clôture les réservations non retirées à temps et passe l'exemplaire à la personne suivante",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Expirer les réservations",
+                "responses": {
+                    "200": {
+                        "description": "Réservations expirées",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/game/{id}": {
+            "get": {
+                "description": "Récupère les réservations actives d'un jeu dans l'ordre d'arrivée",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "File d'attente d'un jeu",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID du jeu",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File d'attente",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/user/{id}": {
+            "get": {
+                "description": "Récupère toutes les réservations d'un utilisateur, les plus récentes en premier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Réservations d'un utilisateur",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Réservations",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Utilisateur non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}": {
+            "get": {
+                "description": "Récupère une réservation et sa position dans la file",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Obtenir une réservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la réservation",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Réservation",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Réservation non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/reservations/{id}/cancel": {
+            "put": {
+                "description": "Retire la réservation de la file ; un exemplaire mis de côté passe à la personne suivante",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reservations"
+                ],
+                "summary": "Annuler une réservation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de la réservation",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Réservation annulée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Réservation non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Réservation déjà clôturée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Récupère les étiquettes avec leur nombre de jeux. Avec q, renvoie les étiquettes contenant le texte saisi (insensible à la casse et aux accents), celles qui commencent par ce texte et les plus utilisées en premier.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Lister les étiquettes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Texte saisi",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Nombre maximal d'étiquettes (500 au maximum)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Étiquettes",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Ajoute une étiquette, qui peut ensuite être donnée aux jeux. Les jeux créent aussi les étiquettes qu'ils utilisent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Créer une étiquette",
+                "parameters": [
+                    {
+                        "description": "Nom de l'étiquette",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Étiquette créée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Étiquette déjà existante",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "get": {
+                "description": "Récupère une étiquette avec son nombre de jeux",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Obtenir une étiquette",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'étiquette",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Étiquette",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Étiquette non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Renomme une étiquette sur tous ses jeux. Renommer vers le nom d'une autre étiquette échoue : il faut alors les fusionner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Renommer une étiquette",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'étiquette",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nouveau nom",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Étiquette renommée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Étiquette non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Nom déjà utilisé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Retire l'étiquette de tous les jeux et la supprime",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Supprimer une étiquette",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'étiquette",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Étiquette supprimée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Étiquette non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/merge": {
+            "post": {
+                "description": "Donne l'étiquette cible à tous les jeux de l'étiquette, puis supprime celle-ci",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Fusionner des étiquettes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'étiquette à fusionner",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Étiquette cible",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MergeTagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Étiquettes fusionnées",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Étiquette non trouvée",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Récupère une page d'utilisateurs, filtrée et triée",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Lister les utilisateurs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Terme de recherche (nom, e-mail)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par niveau d'adhésion",
+                        "name": "membership_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtrer par rôle (member, librarian, admin)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filtrer par statut actif",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filtrer les utilisateurs ayant des jeux non rendus",
+                        "name": "has_loans",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "name",
+                        "description": "Tri (name, email, registered_at, membership_tier)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sens du tri (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Numéro de page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Utilisateurs par page (100 au maximum)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page d'utilisateurs",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Paramètres invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Erreur serveur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/fines": {
+            "get": {
+                "description": "Récupère le solde des amendes d'un utilisateur, en centimes, et l'historique des amendes, paiements et remises, du plus récent au plus ancien",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fines"
+                ],
+                "summary": "Amendes d'un utilisateur",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Amendes",
+                        "schema": {
+                            "$ref": "#/definitions/models.FineAccount"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Utilisateur non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/fines/payments": {
+            "post": {
+                "description": "Enregistre un paiement, en centimes, qui réduit le solde des amendes de l'utilisateur. Le motif est obligatoire et le montant ne peut pas dépasser le solde.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fines"
+                ],
+                "summary": "Enregistrer un paiement d'amendes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Paiement",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FineSettlementRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Alerte créée avec succès",
-                        "schema": {"type": "object"}
+                        "description": "Paiement enregistré",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "400": {
-                        "description": "Données invalides",
-                        "schema": {"type": "object"}
+                        "description": "Données invalides ou montant supérieur au solde",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     },
-                    "500": {
-                        "description": "Erreur serveur",
-                        "schema": {"type": "object"}
+                    "404": {
+                        "description": "Utilisateur non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
             }
         },
-        "/alerts/{id}": {
-            "delete": {
-                "description": "Supprime une alerte",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["alerts"],
-                "summary": "Supprimer une alerte",
+        "/users/{id}/fines/waivers": {
+            "post": {
+                "description": "Annule tout ou partie du solde des amendes de l'utilisateur, en centimes. Le motif est obligatoire et le montant ne peut pas dépasser le solde.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fines"
+                ],
+                "summary": "Remettre des amendes",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de l'alerte",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Remise",
+                        "name": "waiver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.FineSettlementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Remise enregistrée",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides ou montant supérieur au solde",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Utilisateur non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/notifications": {
+            "get": {
+                "description": "Indique si l'utilisateur reçoit ses alertes par email et dans quelle langue (vide pour la langue de la bibliothèque)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Préférences de notification d'un utilisateur",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Préférences",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotificationPreferences"
+                        }
+                    },
+                    "400": {
+                        "description": "ID invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Utilisateur non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Active ou désactive l'envoi des alertes par email et choisit leur langue (fr ou en, vide pour la langue de la bibliothèque). La désactivation s'applique aux alertes pas encore envoyées.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Modifier les préférences de notification",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Préférences",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.NotificationPreferencesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Alerte supprimée avec succès",
-                        "schema": {"type": "object"}
+                        "description": "Préférences mises à jour",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     },
                     "404": {
-                        "description": "Alerte non trouvée",
-                        "schema": {"type": "object"}
+                        "description": "Utilisateur non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
             }
         },
-        "/alerts/{id}/read": {
-            "post": {
-                "description": "Marque une alerte comme lue",
-                "consumes": ["application/json"],
-                "produces": ["application/json"],
-                "tags": ["alerts"],
-                "summary": "Marquer une alerte comme lue",
+        "/users/{id}/password": {
+            "put": {
+                "description": "Change son propre mot de passe (mot de passe actuel requis) ou réinitialise celui d'un utilisateur (administrateur)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Modifier un mot de passe",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID de l'alerte",
+                        "description": "ID de l'utilisateur",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nouveau mot de passe",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Mot de passe modifié",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Données invalides",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Mot de passe actuel incorrect",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "description": "Attribue le rôle member, librarian ou admin à un utilisateur",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Modifier le rôle d'un utilisateur",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID de l'utilisateur",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Nouveau rôle",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetRoleRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Alerte marquée comme lue",
-                        "schema": {"type": "object"}
+                        "description": "Rôle modifié",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Rôle invalide",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     },
                     "404": {
-                        "description": "Alerte non trouvée",
-                        "schema": {"type": "object"}
+                        "description": "Utilisateur non trouvé",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Dernier administrateur",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Problem"
+                        }
                     }
                 }
             }
@@ -541,39 +3154,641 @@ const docTemplate = `{
     "definitions": {
         "handlers.AddGameRequest": {
             "type": "object",
-            "required": ["name"],
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "bgg_id": {
+                    "description": "BoardGameGeek ID of imported games",
+                    "type": "integer"
+                },
+                "complexity": {
+                    "description": "MinComplexity (light) to MaxComplexity (heavy)",
+                    "type": "number"
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "designers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "image_url": {
+                    "description": "picture of the box",
+                    "type": "string"
+                },
+                "max_players": {
+                    "type": "integer"
+                },
+                "min_age": {
+                    "type": "integer"
+                },
+                "min_players": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "play_time": {
+                    "description": "minutes",
+                    "type": "integer"
+                },
+                "publisher": {
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "tag names, created when new",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "year_published": {
+                    "description": "negative for BC",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ConditionReportRequest": {
+            "type": "object",
+            "required": [
+                "condition"
+            ],
+            "properties": {
+                "condition": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateTokenRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.FieldProblem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.FineSettlementRequest": {
+            "type": "object",
+            "required": [
+                "amount_cents",
+                "reason"
+            ],
+            "properties": {
+                "amount_cents": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.GameCopyRequest": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ImportBGGGameRequest": {
+            "type": "object",
+            "required": [
+                "bgg_id"
+            ],
+            "properties": {
+                "bgg_id": {
+                    "type": "integer"
+                },
+                "condition": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LoanPolicyRequest": {
+            "type": "object",
+            "required": [
+                "default_loan_days",
+                "max_loan_days",
+                "max_loans"
+            ],
+            "properties": {
+                "default_loan_days": {
+                    "type": "integer"
+                },
+                "fine_cap_cents": {
+                    "type": "integer"
+                },
+                "fine_grace_days": {
+                    "type": "integer"
+                },
+                "fine_per_day_cents": {
+                    "type": "integer"
+                },
+                "max_extensions": {
+                    "type": "integer"
+                },
+                "max_fine_balance_cents": {
+                    "description": "null for no limit",
+                    "type": "integer"
+                },
+                "max_loan_days": {
+                    "type": "integer"
+                },
+                "max_loans": {
+                    "type": "integer"
+                },
+                "tier": {
+                    "description": "Required on creation, taken from the URL on update",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.MergeTagRequest": {
+            "type": "object",
+            "required": [
+                "target_id"
+            ],
+            "properties": {
+                "target_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.NotificationPreferencesRequest": {
+            "type": "object",
+            "required": [
+                "email_enabled"
+            ],
+            "properties": {
+                "email_enabled": {
+                    "type": "boolean"
+                },
+                "language": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PlaceHoldRequest": {
+            "type": "object",
+            "required": [
+                "game_id",
+                "user_id"
+            ],
+            "properties": {
+                "game_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FieldProblem"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RescheduleJobRequest": {
+            "type": "object",
+            "required": [
+                "schedule"
+            ],
+            "properties": {
+                "schedule": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ReturnGameRequest": {
+            "type": "object",
+            "properties": {
+                "condition": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SetRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SetupRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.TagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AlertNotification": {
+            "type": "object",
+            "properties": {
+                "alert_id": {
+                    "type": "integer"
+                },
+                "attempts": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FineAccount": {
+            "type": "object",
+            "properties": {
+                "balance_cents": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FineEntry"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FineEntry": {
+            "type": "object",
+            "properties": {
+                "amount_cents": {
+                    "type": "integer"
+                },
+                "borrowing_id": {
+                    "description": "the late borrowing of a fine",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Game": {
+            "type": "object",
+            "properties": {
+                "available_copies": {
+                    "type": "integer"
+                },
+                "bgg_id": {
+                    "description": "BoardGameGeek ID of imported games",
+                    "type": "integer"
+                },
+                "complexity": {
+                    "description": "MinComplexity (light) to MaxComplexity (heavy)",
+                    "type": "number"
+                },
+                "condition": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "designers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "entry_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "description": "picture of the box",
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "match": {
+                    "description": "Match is set on full-text search results only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GameSearchMatch"
+                        }
+                    ]
+                },
+                "max_players": {
+                    "type": "integer"
+                },
+                "min_age": {
+                    "type": "integer"
+                },
+                "min_players": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "play_time": {
+                    "description": "minutes",
+                    "type": "integer"
+                },
+                "publisher": {
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "tag names, see Tag",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_copies": {
+                    "type": "integer"
+                },
+                "year_published": {
+                    "description": "negative for BC",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GameSearchMatch": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "name of the game",
+                    "type": "string"
+                },
+                "name_highlights": {
+                    "description": "matched terms of the name",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TextRange"
+                    }
+                },
+                "rank": {
+                    "description": "lower is more relevant",
+                    "type": "number"
+                },
+                "snippet": {
+                    "description": "excerpt of the description",
+                    "type": "string"
+                },
+                "snippet_highlights": {
+                    "description": "matched terms of the snippet",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TextRange"
+                    }
+                }
+            }
+        },
+        "models.LoanPolicy": {
+            "type": "object",
+            "properties": {
+                "default_loan_days": {
+                    "description": "used when no due date is given",
+                    "type": "integer"
+                },
+                "fine_cap_cents": {
+                    "type": "integer"
+                },
+                "fine_grace_days": {
+                    "type": "integer"
+                },
+                "fine_per_day_cents": {
+                    "description": "Late fees, in cents. A late return is charged FinePerDayCents for each\nday late beyond FineGraceDays, up to FineCapCents (0 for no cap).",
+                    "type": "integer"
+                },
+                "max_extensions": {
+                    "type": "integer"
+                },
+                "max_fine_balance_cents": {
+                    "description": "Users owing more than MaxFineBalanceCents cannot borrow; nil for no limit",
+                    "type": "integer"
+                },
+                "max_loan_days": {
+                    "description": "from the borrowing date, extensions included",
+                    "type": "integer"
+                },
+                "max_loans": {
+                    "description": "concurrent loans",
+                    "type": "integer"
+                },
+                "tier": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NotificationPreferences": {
+            "type": "object",
             "properties": {
-                "category": {"type": "string", "description": "Catégorie du jeu (ex: Stratégie, Famille)"},
-                "condition": {"type": "string", "description": "État du jeu (excellent, good, fair, poor)"},
-                "description": {"type": "string", "description": "Description du jeu"},
-                "name": {"type": "string", "description": "Nom du jeu"}
+                "email_enabled": {
+                    "type": "boolean"
+                },
+                "language": {
+                    "description": "empty for the library's default language",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
             }
         },
-        "handlers.RegisterUserRequest": {
+        "models.Tag": {
             "type": "object",
-            "required": ["name", "email"],
             "properties": {
-                "name": {"type": "string", "description": "Nom complet de l'utilisateur"},
-                "email": {"type": "string", "description": "Adresse email de l'utilisateur"}
+                "created_at": {
+                    "type": "string"
+                },
+                "game_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
             }
         },
-        "handlers.BorrowGameRequest": {
+        "models.TextRange": {
             "type": "object",
-            "required": ["user_id", "game_id"],
             "properties": {
-                "user_id": {"type": "integer", "description": "ID de l'utilisateur qui emprunte"},
-                "game_id": {"type": "integer", "description": "ID du jeu à emprunter"},
-                "due_date": {"type": "string", "format": "date", "description": "Date d'échéance (optionnel, 14 jours par défaut)"}
+                "end": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "integer"
+                }
             }
         },
-        "handlers.CreateAlertRequest": {
+        "models.User": {
             "type": "object",
-            "required": ["user_id", "game_id", "type", "message"],
             "properties": {
-                "user_id": {"type": "integer", "description": "ID de l'utilisateur concerné"},
-                "game_id": {"type": "integer", "description": "ID du jeu concerné"},
-                "type": {"type": "string", "enum": ["overdue", "reminder", "custom"], "description": "Type d'alerte"},
-                "message": {"type": "string", "description": "Message de l'alerte"}
+                "current_loans": {
+                    "description": "Not stored in DB, calculated at runtime",
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "membership_tier": {
+                    "description": "selects the user's loan policy",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "registered_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "what the user may do, see ValidRoles",
+                    "type": "string"
+                }
             }
         }
     }
@@ -593,4 +3808,4 @@ var SwaggerInfo = &swag.Spec{
 
 func init() {
 	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
-}
\ No newline at end of file
+}
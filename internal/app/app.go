@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,10 +12,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"board-game-library/internal/assets"
 	"board-game-library/internal/config"
+	"board-game-library/internal/handlers"
+	"board-game-library/internal/jobs"
 	"board-game-library/internal/logging"
+	"board-game-library/internal/repositories"
 	"board-game-library/internal/routes"
+	"board-game-library/internal/services"
 	"board-game-library/pkg/database"
+	"board-game-library/pkg/mailer"
 )
 
 // App represents the main application
@@ -23,7 +30,10 @@ type App struct {
 	logger *logging.Logger
 	db     *database.DB
 	server *http.Server
-	router *gin.Engine
+	router http.Handler
+
+	jobManager *jobs.Manager
+	jobsCancel context.CancelFunc
 }
 
 // New creates a new application instance
@@ -64,6 +74,11 @@ func (a *App) Initialize() error {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 
+	// Initialize background jobs
+	if err := a.initializeJobs(); err != nil {
+		return fmt.Errorf("failed to initialize jobs: %w", err)
+	}
+
 	// Initialize router
 	if err := a.initializeRouter(); err != nil {
 		return fmt.Errorf("failed to initialize router: %w", err)
@@ -77,6 +92,11 @@ func (a *App) Initialize() error {
 
 // Run starts the application and blocks until shutdown
 func (a *App) Run() error {
+	// Start background jobs
+	if err := a.startJobs(); err != nil {
+		return fmt.Errorf("failed to start job manager: %w", err)
+	}
+
 	// Start server in a goroutine
 	serverErrors := make(chan error, 1)
 	go func() {
@@ -115,6 +135,9 @@ func (a *App) Shutdown() error {
 		}
 	}
 
+	// Stop background jobs before closing the database they use
+	a.stopJobs()
+
 	// Close database connection
 	if a.db != nil {
 		a.logger.LogDatabaseDisconnection()
@@ -130,11 +153,7 @@ func (a *App) Shutdown() error {
 
 // initializeDatabase initializes the database connection
 func (a *App) initializeDatabase() error {
-	dbConfig := database.Config{
-		DatabasePath: a.config.Database.Path,
-	}
-
-	db, err := database.Initialize(dbConfig)
+	db, err := database.Initialize(a.config.Database.Connection())
 	if err != nil {
 		return err
 	}
@@ -145,17 +164,159 @@ func (a *App) initializeDatabase() error {
 	db.SetConnMaxLifetime(a.config.Database.ConnMaxLifetime)
 
 	a.db = db
-	a.logger.LogDatabaseConnection(a.config.Database.Path)
+	location := a.config.Database.Path
+	if db.Driver() == database.DriverPostgres {
+		location = "PostgreSQL"
+	}
+	a.logger.LogDatabaseConnection(location)
+	return nil
+}
+
+// initializeJobs creates the background job manager from the alerts configuration
+func (a *App) initializeJobs() error {
+	// The jobs go through every library in turn
+	libraries := &libraryJobs{db: a.db, holdDays: a.config.Reservations.HoldDays}
+
+	jobConfig := jobs.DefaultConfig()
+	jobConfig.EnableOverdueAlerts = a.config.Alerts.EnableOverdue
+	jobConfig.EnableReminderAlerts = a.config.Alerts.EnableReminders
+	jobConfig.OverdueAlertSchedule = a.config.Alerts.CheckInterval
+	jobConfig.ReminderAlertSchedule = a.config.Alerts.CheckInterval
+	jobConfig.OverdueAlertCron = a.config.Alerts.Schedule
+	jobConfig.ReminderAlertCron = a.config.Alerts.Schedule
+	jobConfig.Timezone = a.config.Alerts.Timezone
+	jobConfig.ExecutionRetention = time.Duration(a.config.Jobs.RunRetentionDays) * 24 * time.Hour
+	jobConfig.Logger = slog.NewLogLogger(a.logger.With("component", "jobs").Handler(), slog.LevelInfo)
+
+	jobManager, err := jobs.NewManager(libraries, jobConfig)
+	if err != nil {
+		return err
+	}
+	a.jobManager = jobManager
+	a.jobManager.AddCustomJob("expire-holds", "Expire reservation holds that were not picked up in time", time.Hour, libraries.ExpireHolds)
+	a.jobManager.AddCustomJob("cleanup-sessions", "Remove expired web sessions", time.Hour, libraries.CleanupExpiredSessions)
+	if err := a.addNotificationJob(libraries); err != nil {
+		return err
+	}
+	if err := a.addBackupJob(); err != nil {
+		return err
+	}
+	if err := a.jobManager.SetExecutionStore(repositories.NewSQLiteJobRunRepository(a.db)); err != nil {
+		return err
+	}
+	a.logger.Info("Job manager initialized",
+		"check_interval", a.config.Alerts.CheckInterval,
+		"schedule", a.config.Alerts.Schedule,
+		"timezone", a.jobManager.Location().String(),
+		"overdue_alerts", a.config.Alerts.EnableOverdue,
+		"reminder_alerts", a.config.Alerts.EnableReminders,
+		"hold_days", a.config.Reservations.HoldDays,
+		"auth_enabled", a.config.Auth.Enabled,
+		"backups", a.config.Backup.Enabled,
+		"email_notifications", a.config.Notifications.Enabled(),
+	)
+	return nil
+}
+
+// addNotificationJob sends the alerts of every library by email when an SMTP
+// server is configured. Failed messages are retried by later runs.
+func (a *App) addNotificationJob(libraries *libraryJobs) error {
+	if !a.config.Notifications.Enabled() {
+		return nil
+	}
+
+	templates, err := services.LoadNotificationTemplates(assets.GetTemplatesFS())
+	if err != nil {
+		return err
+	}
+
+	libraries.notifier = mailer.NewSMTPSender(a.config.Notifications.Mailer())
+	libraries.templates = templates
+	libraries.notifications = services.NotificationSettings{
+		Language:     a.config.Notifications.Language,
+		MaxAttempts:  a.config.Notifications.MaxAttempts,
+		RetryBackoff: a.config.Notifications.RetryBackoff,
+	}
+
+	a.jobManager.AddCustomJob("deliver-notifications", "Email unread alerts to their users, retrying failed messages",
+		a.config.Notifications.Interval, libraries.DeliverNotifications)
 	return nil
 }
 
-// initializeRouter initializes the Gin router with all routes
+// addBackupJob schedules database snapshots into the backup directory,
+// keeping only the most recent ones
+func (a *App) addBackupJob() error {
+	if !a.config.Backup.Enabled {
+		return nil
+	}
+
+	backups := database.NewBackupRotation(a.db, a.config.Backup.Dir, a.config.Backup.Retention)
+	backupDatabase := func(context.Context) error {
+		backup, err := backups.Create()
+		if err != nil {
+			return err
+		}
+		a.logger.Info("Database backup created", "path", backup.Path, "size", backup.Size)
+		return nil
+	}
+
+	description := fmt.Sprintf("Back up the database, keeping the last %d snapshots", a.config.Backup.Retention)
+	if a.config.Backup.Schedule != "" {
+		if err := a.jobManager.AddCustomCronJob("backup-database", description, a.config.Backup.Schedule, backupDatabase); err != nil {
+			return fmt.Errorf("backup schedule: %w", err)
+		}
+		return nil
+	}
+
+	a.jobManager.AddCustomJob("backup-database", description, a.config.Backup.Interval, backupDatabase)
+	return nil
+}
+
+// startJobs starts the background job manager
+func (a *App) startJobs() error {
+	if a.jobManager == nil || a.jobManager.IsStarted() {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := a.jobManager.Start(ctx); err != nil {
+		cancel()
+		return err
+	}
+
+	a.jobsCancel = cancel
+	return nil
+}
+
+// stopJobs stops the background job manager if it is running
+func (a *App) stopJobs() {
+	if a.jobManager != nil && a.jobManager.IsStarted() {
+		if err := a.jobManager.Stop(); err != nil {
+			a.logger.Error("Failed to stop job manager", "error", err)
+		}
+	}
+
+	if a.jobsCancel != nil {
+		a.jobsCancel()
+		a.jobsCancel = nil
+	}
+}
+
+// initializeRouter initializes the router dispatching requests to the Gin
+// router of their library
 func (a *App) initializeRouter() error {
+	a.router = routes.NewLibraryRouter(a.db, a.config.Libraries, a.newLibraryRouter)
+	return nil
+}
+
+// newLibraryRouter creates the Gin router serving the library db is scoped to
+func (a *App) newLibraryRouter(db *database.DB) (http.Handler, error) {
 	router := gin.New()
 
 	// Add middleware
-	router.Use(a.loggingMiddleware())
+	router.Use(a.loggingMiddleware(db.Library()))
 	router.Use(gin.Recovery())
+	router.Use(handlers.RequestTimeout(a.config.Server.RequestTimeout))
 
 	// Basic health check endpoint
 	router.GET("/health", a.healthCheckHandler)
@@ -164,12 +325,11 @@ func (a *App) initializeRouter() error {
 	router.GET("/api/v1/status", a.statusHandler)
 
 	// Setup all application routes
-	if err := routes.SetupRoutes(router, a.db); err != nil {
-		return fmt.Errorf("failed to setup routes: %w", err)
+	if err := routes.SetupRoutes(router, db, a.jobManager, a.config); err != nil {
+		return nil, fmt.Errorf("failed to setup routes: %w", err)
 	}
 
-	a.router = router
-	return nil
+	return router, nil
 }
 
 // initializeServer initializes the HTTP server
@@ -183,15 +343,17 @@ func (a *App) initializeServer() {
 }
 
 // loggingMiddleware creates a Gin middleware for request logging
-func (a *App) loggingMiddleware() gin.HandlerFunc {
+func (a *App) loggingMiddleware(library int) gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
 		a.logger.Info("HTTP Request",
+			"library", library,
 			"method", param.Method,
 			"path", param.Path,
 			"status", param.StatusCode,
 			"latency", param.Latency,
 			"client_ip", param.ClientIP,
 			"user_agent", param.Request.UserAgent(),
+			"error", param.ErrorMessage,
 		)
 		return ""
 	})
@@ -226,6 +388,7 @@ func (a *App) statusHandler(c *gin.Context) {
 			"server_port":     a.config.Server.Port,
 			"database_path":   a.config.Database.Path,
 			"alerts_enabled":  a.config.Alerts.EnableOverdue && a.config.Alerts.EnableReminders,
+			"jobs_running":    a.jobManager != nil && a.jobManager.IsStarted(),
 			"log_level":       a.config.Logging.Level,
 		},
 	})
@@ -246,7 +409,12 @@ func (a *App) GetConfig() *config.Config {
 	return a.config
 }
 
-// GetRouter returns the Gin router (for use by serverless handlers)
-func (a *App) GetRouter() *gin.Engine {
+// GetJobManager returns the background job manager (for use by other components)
+func (a *App) GetJobManager() *jobs.Manager {
+	return a.jobManager
+}
+
+// GetRouter returns the router of every library (for use by serverless handlers)
+func (a *App) GetRouter() http.Handler {
 	return a.router
 }
\ No newline at end of file
@@ -1,10 +1,13 @@
 package app
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNew(t *testing.T) {
@@ -217,6 +220,89 @@ func TestShutdown(t *testing.T) {
 	}
 }
 
+func TestJobManagerLifecycle(t *testing.T) {
+	// Use in-memory database for testing
+	os.Setenv("DATABASE_PATH", ":memory:")
+	os.Setenv("ALERTS_CHECK_INTERVAL", "12h")
+	os.Setenv("ALERTS_ENABLE_REMINDERS", "false")
+	os.Setenv("AUTH_ENABLED", "false")
+	defer os.Unsetenv("DATABASE_PATH")
+	defer os.Unsetenv("ALERTS_CHECK_INTERVAL")
+	defer os.Unsetenv("ALERTS_ENABLE_REMINDERS")
+	defer os.Unsetenv("AUTH_ENABLED")
+
+	app, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	err = app.Initialize()
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	manager := app.GetJobManager()
+	if manager == nil {
+		t.Fatal("Initialize() did not create job manager")
+	}
+
+	jobs := manager.GetJobs(context.Background())
+	overdueJob, ok := jobs["overdue-alerts"]
+	if !ok {
+		t.Fatal("Expected overdue-alerts job to be registered")
+	}
+	if overdueJob.Schedule != 12*time.Hour {
+		t.Errorf("overdue-alerts schedule = %v, want %v", overdueJob.Schedule, 12*time.Hour)
+	}
+	if _, ok := jobs["reminder-alerts"]; ok {
+		t.Error("Expected reminder-alerts job to be disabled by configuration")
+	}
+	if _, ok := jobs["expire-holds"]; !ok {
+		t.Error("Expected expire-holds job to be registered")
+	}
+	if _, ok := jobs["cleanup-sessions"]; !ok {
+		t.Error("Expected cleanup-sessions job to be registered")
+	}
+	if backupJob, ok := jobs["backup-database"]; !ok || backupJob.Schedule != 24*time.Hour {
+		t.Errorf("Expected a daily backup-database job to be registered, got %+v", backupJob)
+	}
+
+	// Jobs endpoint should be registered
+	req, _ := http.NewRequest("GET", "/api/v1/jobs", nil)
+	rr := httptest.NewRecorder()
+	app.router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Errorf("Jobs endpoint returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	if err := app.startJobs(); err != nil {
+		t.Fatalf("startJobs() error = %v", err)
+	}
+	if !manager.IsStarted() {
+		t.Error("Expected job manager to be started")
+	}
+
+	if err := app.Shutdown(); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+	if manager.IsStarted() {
+		t.Error("Expected job manager to be stopped after shutdown")
+	}
+}
+
+func TestNewWithInvalidAlertSchedule(t *testing.T) {
+	os.Setenv("DATABASE_PATH", ":memory:")
+	os.Setenv("ALERTS_SCHEDULE", "every morning")
+	defer os.Unsetenv("DATABASE_PATH")
+	defer os.Unsetenv("ALERTS_SCHEDULE")
+
+	// The configuration is rejected before anything starts
+	_, err := New()
+	if err == nil || !strings.Contains(err.Error(), "alerts schedule") {
+		t.Errorf("New() error = %v, want an invalid alerts schedule", err)
+	}
+}
+
 func TestInitializeWithInvalidDatabase(t *testing.T) {
 	// Set invalid database path
 	os.Setenv("DATABASE_PATH", "/invalid/path/that/does/not/exist/test.db")
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"board-game-library/internal/services"
+	"board-game-library/pkg/database"
+)
+
+// libraryJobs runs the background jobs of every library, each with services
+// scoped to the library. It implements jobs.AlertService.
+type libraryJobs struct {
+	db       *database.DB
+	holdDays int
+
+	// Email notifications, when a notifier is set
+	notifier      services.Notifier
+	templates     *services.NotificationTemplates
+	notifications services.NotificationSettings
+}
+
+// libraryJobServices are the services used by the jobs of one library
+type libraryJobServices struct {
+	alerts        *services.AlertService
+	reservations  *services.ReservationService
+	auth          *services.AuthService
+	notifications *services.NotificationService
+}
+
+// newLibraryJobServices creates the job services of a library from db scoped
+// to it. Their changes are attributed to the system in the audit log.
+func (j *libraryJobs) newLibraryJobServices(library *models.Library, db *database.DB) *libraryJobServices {
+	alertRepo := repositories.NewSQLiteAlertRepository(db)
+	borrowingRepo := repositories.NewSQLiteBorrowingRepository(db)
+	userRepo := repositories.NewSQLiteUserRepository(db)
+	gameRepo := repositories.NewSQLiteGameRepository(db)
+
+	alertService := services.NewAlertService(alertRepo, borrowingRepo, userRepo, gameRepo)
+	reservationService := services.NewReservationService(
+		repositories.NewSQLiteReservationRepository(db),
+		userRepo, gameRepo, borrowingRepo, alertRepo,
+		j.holdDays,
+	)
+	authService := services.NewAuthService(userRepo, repositories.NewSQLiteAuthRepository(db), services.DefaultSessionTTL)
+
+	auditService := services.NewAuditService(repositories.NewSQLiteAuditRepository(db))
+	alertService.SetAuditor(auditService)
+	reservationService.SetAuditor(auditService)
+
+	// Messages are signed with the name of the library
+	settings := j.notifications
+	settings.Library = library.Name
+	notificationService := services.NewNotificationService(
+		repositories.NewSQLiteNotificationRepository(db),
+		borrowingRepo, repositories.NewSQLiteReservationRepository(db),
+		j.notifier, j.templates, settings,
+	)
+
+	return &libraryJobServices{alerts: alertService, reservations: reservationService, auth: authService, notifications: notificationService}
+}
+
+// each runs fn for every library, carrying on after a failure, and returns
+// the errors of all libraries. It stops early when ctx is done.
+func (j *libraryJobs) each(ctx context.Context, fn func(s *libraryJobServices) error) error {
+	libraries, err := services.NewLibraryService(repositories.NewSQLiteLibraryRepository(j.db)).ListLibraries(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, library := range libraries {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := fn(j.newLibraryJobServices(library, j.db.ForLibrary(library.ID))); err != nil {
+			errs = append(errs, fmt.Errorf("library %s: %w", library.Slug, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// GenerateOverdueAlerts creates the overdue alerts of every library
+func (j *libraryJobs) GenerateOverdueAlerts(ctx context.Context) error {
+	return j.each(ctx, func(s *libraryJobServices) error {
+		return s.alerts.GenerateOverdueAlerts(ctx)
+	})
+}
+
+// GenerateReminderAlerts creates the due date reminders of every library
+func (j *libraryJobs) GenerateReminderAlerts(ctx context.Context) error {
+	return j.each(ctx, func(s *libraryJobServices) error {
+		return s.alerts.GenerateReminderAlerts(ctx)
+	})
+}
+
+// CleanupResolvedAlerts removes the resolved alerts of every library
+func (j *libraryJobs) CleanupResolvedAlerts(ctx context.Context) error {
+	return j.each(ctx, func(s *libraryJobServices) error {
+		return s.alerts.CleanupResolvedAlerts(ctx)
+	})
+}
+
+// ExpireHolds expires the reservation holds of every library that were not
+// picked up in time
+func (j *libraryJobs) ExpireHolds(ctx context.Context) error {
+	return j.each(ctx, func(s *libraryJobServices) error {
+		_, err := s.reservations.ExpireHolds(ctx)
+		return err
+	})
+}
+
+// CleanupExpiredSessions removes the expired web sessions of every library
+func (j *libraryJobs) CleanupExpiredSessions(ctx context.Context) error {
+	return j.each(ctx, func(s *libraryJobServices) error {
+		_, err := s.auth.CleanupExpiredSessions(ctx)
+		return err
+	})
+}
+
+// DeliverNotifications emails the due alerts of every library
+func (j *libraryJobs) DeliverNotifications(ctx context.Context) error {
+	return j.each(ctx, func(s *libraryJobServices) error {
+		_, err := s.notifications.DeliverDue(ctx)
+		return err
+	})
+}
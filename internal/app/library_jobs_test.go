@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"board-game-library/internal/assets"
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"board-game-library/internal/services"
+	"board-game-library/pkg/database"
+	"board-game-library/pkg/mailer"
+	"board-game-library/pkg/mailer/mailertest"
+)
+
+func TestLibraryJobsDeliverNotifications(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.InitializeForTesting()
+	if err != nil {
+		t.Fatalf("Failed to initialize test database: %v", err)
+	}
+	defer db.Close()
+
+	ludo := &models.Library{Slug: "ludo", Name: "Ludo Club", CreatedAt: time.Now()}
+	if err := repositories.NewSQLiteLibraryRepository(db).Create(ctx, ludo); err != nil {
+		t.Fatalf("Failed to create library: %v", err)
+	}
+
+	// One overdue alert in each library
+	for i, libraryID := range []int{database.DefaultLibraryID, ludo.ID} {
+		scoped := db.ForLibrary(libraryID)
+		user := &models.User{Name: "Member", Email: []string{"a@example.org", "b@example.org"}[i], RegisteredAt: time.Now(), IsActive: true, MembershipTier: "standard", Role: models.RoleMember}
+		if err := repositories.NewSQLiteUserRepository(scoped).Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+		game := &models.Game{Name: "Azul", EntryDate: time.Now(), Condition: "good", IsAvailable: true}
+		if err := repositories.NewSQLiteGameRepository(scoped).Create(ctx, game); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+		alert := &models.Alert{UserID: user.ID, GameID: game.ID, Type: "overdue", Message: "Game 'Azul' is overdue", CreatedAt: time.Now()}
+		if err := repositories.NewSQLiteAlertRepository(scoped).Create(ctx, alert); err != nil {
+			t.Fatalf("Failed to create alert: %v", err)
+		}
+	}
+
+	server := mailertest.NewServer()
+	defer server.Close()
+	templates, err := services.LoadNotificationTemplates(assets.GetTemplatesFS())
+	if err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+
+	jobs := &libraryJobs{
+		db:        db,
+		holdDays:  services.DefaultHoldDays,
+		notifier:  mailer.NewSMTPSender(mailer.Config{Host: server.Host, Port: server.Port, From: "ludo@example.org", Security: mailer.SecurityNone}),
+		templates: templates,
+	}
+
+	if err := jobs.DeliverNotifications(ctx); err != nil {
+		t.Fatalf("DeliverNotifications() error = %v", err)
+	}
+	// Sent alerts are not sent again
+	if err := jobs.DeliverNotifications(ctx); err != nil {
+		t.Fatalf("DeliverNotifications() error = %v", err)
+	}
+
+	messages := server.Messages()
+	if len(messages) != 2 {
+		t.Fatalf("Expected one message per library, got %d", len(messages))
+	}
+	signatures := map[string]string{"a@example.org": "Bibliothèque", "b@example.org": "Ludo Club"}
+	for _, message := range messages {
+		library := signatures[message.To[0]]
+		if !strings.Contains(message.Body, library) {
+			t.Errorf("Expected the message to %s to be signed by %q, got %q", message.To[0], library, message.Body)
+		}
+	}
+}
@@ -1,25 +1,20 @@
 package assets
 
 import (
-	"embed"
 	"io/fs"
-)
 
-// Static assets embedded at build time
-//go:embed web/templates/* web/static/*
-var embeddedFS embed.FS
+	"board-game-library/web"
+)
 
-// Templates contains all HTML templates
-//go:embed web/templates/*
-var Templates embed.FS
+// Templates contains all HTML templates, embedded at build time
+var Templates = web.Templates
 
-// Static contains all static assets (CSS, JS, images)
-//go:embed web/static/*
-var Static embed.FS
+// Static contains all static assets (CSS, JS, images), embedded at build time
+var Static = web.Static
 
 // GetTemplatesFS returns the embedded templates filesystem
 func GetTemplatesFS() fs.FS {
-	templatesFS, err := fs.Sub(Templates, "web/templates")
+	templatesFS, err := fs.Sub(Templates, "templates")
 	if err != nil {
 		panic("failed to create templates filesystem: " + err.Error())
 	}
@@ -28,14 +23,15 @@ func GetTemplatesFS() fs.FS {
 
 // GetStaticFS returns the embedded static assets filesystem
 func GetStaticFS() fs.FS {
-	staticFS, err := fs.Sub(Static, "web/static")
+	staticFS, err := fs.Sub(Static, "static")
 	if err != nil {
 		panic("failed to create static filesystem: " + err.Error())
 	}
 	return staticFS
 }
 
-// GetEmbeddedFS returns the full embedded filesystem
+// GetEmbeddedFS returns the full embedded filesystem, with the templates
+// and static directories
 func GetEmbeddedFS() fs.FS {
-	return embeddedFS
-}
\ No newline at end of file
+	return web.FS
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"board-game-library/pkg/database"
+)
+
+// runBackup takes a snapshot of the database. Without -o, the snapshot goes
+// to the backup directory, whose oldest snapshots beyond the retention are
+// removed as by the scheduled backups.
+func runBackup(env *environment, args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	output := flags.String("o", "", "write the snapshot to this file instead of the backup directory")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return fmt.Errorf("usage: %s", backupUsage)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Database.Driver == database.DriverPostgres {
+		return fmt.Errorf("%s only supports SQLite databases: use pg_dump and pg_restore for PostgreSQL", flags.Name())
+	}
+	if _, err := os.Stat(cfg.Database.Path); err != nil {
+		return fmt.Errorf("database not found: %w", err)
+	}
+
+	// The database is saved as it is, without running pending migrations
+	db, err := database.NewConnection(cfg.Database.Connection())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	path := *output
+	if path == "" {
+		backup, err := database.NewBackupRotation(db, cfg.Backup.Dir, cfg.Backup.Retention).Create()
+		if err != nil {
+			return err
+		}
+		path = backup.Path
+	} else if err := db.Backup(path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.stdout, "Backed up %s to %s\n", cfg.Database.Path, path)
+	return nil
+}
+
+// runRestore replaces the database with a backup, after checking the backup
+// and saving the current database next to the snapshots
+func runRestore(env *environment, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: %s", restoreUsage)
+	}
+	backupPath := positional[0]
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Database.Driver == database.DriverPostgres {
+		return fmt.Errorf("%s only supports SQLite databases: use pg_dump and pg_restore for PostgreSQL", flags.Name())
+	}
+
+	if _, err := database.CheckBackup(backupPath); err != nil {
+		return err
+	}
+
+	// Snapshots of the rotation are named by date; this one is not, so it is
+	// never removed with them
+	if _, err := os.Stat(cfg.Database.Path); err == nil {
+		db, err := database.NewConnection(cfg.Database.Connection())
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		saved := filepath.Join(cfg.Backup.Dir, time.Now().Format("before-restore-20060102-150405.db"))
+		err = db.Backup(saved)
+		db.Close()
+		if err != nil {
+			return fmt.Errorf("failed to save the current database: %w", err)
+		}
+		fmt.Fprintf(env.stdout, "Saved the current database to %s\n", saved)
+	}
+
+	version, err := database.Restore(backupPath, cfg.Database.Path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.stdout, "Restored %s to %s (schema version %d)\n", backupPath, cfg.Database.Path, version)
+	if latest := database.LatestSchemaVersion(); version < latest {
+		fmt.Fprintf(env.stdout, "The database will be migrated to version %d when the server starts\n", latest)
+	}
+	return nil
+}
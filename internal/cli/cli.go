@@ -0,0 +1,177 @@
+// Package cli implements the subcommands of the server binary, which work on
+// the library database without starting the web server
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+
+	"board-game-library/internal/config"
+	"board-game-library/pkg/database"
+)
+
+// command is a subcommand of the server binary
+type command struct {
+	usage       string
+	description string
+	run         func(env *environment, args []string) error
+}
+
+// Usage lines of the subcommands
+const (
+	importUsage  = "import [-library slug] [-format csv|json] [-dry-run] <games|users|borrowings> <file|->"
+	exportUsage  = "export [-library slug] [-format csv|json] [-o file] <games|users|borrowings>"
+	backupUsage  = "backup [-o file]"
+	restoreUsage = "restore <file>"
+	migrateUsage = "migrate [status | up | down [n] | to <version>]"
+	libraryUsage = "library [list | create -admin-email email -admin-name name <name> [slug] < password | rename <slug> <name> [new-slug]]"
+)
+
+// commands lists the subcommands by name
+var commands = map[string]command{
+	"import": {
+		usage:       importUsage,
+		description: "Import rows from a CSV or JSON file, all or nothing",
+		run:         runImport,
+	},
+	"export": {
+		usage:       exportUsage,
+		description: "Export a whole table as CSV or JSON",
+		run:         runExport,
+	},
+	"library": {
+		usage:       libraryUsage,
+		description: "List, create or rename the libraries sharing the database",
+		run:         runLibrary,
+	},
+	"backup": {
+		usage:       backupUsage,
+		description: "Snapshot the database into the backup directory, or to a file",
+		run:         runBackup,
+	},
+	"restore": {
+		usage:       restoreUsage,
+		description: "Replace the database with a backup; stop the server first",
+		run:         runRestore,
+	},
+	"migrate": {
+		usage:       migrateUsage,
+		description: "Show, apply or roll back schema migrations; stop the server first",
+		run:         runMigrate,
+	},
+}
+
+// environment holds the standard streams of a subcommand and the context
+// of its database work, cancelled by an interrupt
+type environment struct {
+	ctx    context.Context
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// IsCommand reports whether name is a subcommand, or a request for help
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok || name == "help" || name == "-h" || name == "--help"
+}
+
+// Run runs the subcommand named by args[0] with the remaining arguments and
+// returns the exit code of the process
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	env := &environment{ctx: ctx, stdin: stdin, stdout: stdout, stderr: stderr}
+	if len(args) == 0 {
+		printUsage(stderr)
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	switch {
+	case !ok && IsCommand(args[0]):
+		printUsage(stdout)
+		return 0
+	case !ok:
+		fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+		printUsage(stderr)
+		return 2
+	}
+
+	if err := cmd.run(env, args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		fmt.Fprintf(stderr, "%s: %v\n", args[0], err)
+		return 1
+	}
+
+	return 0
+}
+
+// printUsage lists the subcommands
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: board-game-library [command]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Without a command the web server starts. Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\n      %s\n", commands[name].usage, commands[name].description)
+	}
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "The database is the one of the server, set by DATABASE_PATH. Imports and")
+	fmt.Fprintln(w, "exports work on the default library unless -library names another one.")
+}
+
+// parseFlags parses the flags of a subcommand, which may come before, after
+// or between its positional arguments, and returns the positional arguments
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// loadConfig loads the server configuration
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// openDatabase opens the database of the server configuration, running its
+// pending migrations
+func openDatabase() (*database.DB, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := database.Initialize(cfg.Database.Connection())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	return db, nil
+}
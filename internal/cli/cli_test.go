@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// run runs a subcommand and returns its exit code and output
+func run(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := Run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestParseFlags(t *testing.T) {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "")
+	format := flags.String("format", "", "")
+
+	positional, err := parseFlags(flags, []string{"games", "-format", "json", "games.txt", "--dry-run"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if !reflect.DeepEqual(positional, []string{"games", "games.txt"}) || *format != "json" || !*dryRun {
+		t.Errorf("parseFlags() = %v, format %q, dry run %v", positional, *format, *dryRun)
+	}
+}
+
+func TestImportExport(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DATABASE_PATH", filepath.Join(dir, "library.db"))
+
+	games := filepath.Join(dir, "games.csv")
+	if err := os.WriteFile(games, []byte("name,condition,tags\nAzul,good,Abstrait\nHanabi,fair,\"Coopératif, Cartes\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := run(t, "", "import", "--dry-run", "games", games)
+	if code != 0 || !strings.Contains(stdout, "All 2 rows are valid, nothing was imported (dry run)") {
+		t.Fatalf("Dry run exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = run(t, "name,email\nAlice,alice@example.com\nBob,not-an-email\n", "import", "users", "-", "-format", "csv")
+	if code != 1 || !strings.Contains(stdout, "line 3: invalid email format") || !strings.Contains(stderr, "1 of 2 rows are invalid") {
+		t.Fatalf("Invalid import exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = run(t, "", "import", "games", games)
+	if code != 0 || !strings.Contains(stdout, "Imported 2 games") {
+		t.Fatalf("Import exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = run(t, "", "export", "-format", "json", "games")
+	if code != 0 || !strings.Contains(stdout, `"name":"Azul"`) || !strings.Contains(stdout, `"tags":["Cartes","Coopératif"]`) {
+		t.Fatalf("Export exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = run(t, "", "export", "users")
+	if code != 0 || stdout != "id,name,email,registered_at,is_active,membership_tier,role\n" {
+		t.Fatalf("Export of the users rejected by the invalid import exited with %d: %q%s", code, stdout, stderr)
+	}
+
+	output := filepath.Join(dir, "export.json")
+	if code, _, stderr = run(t, "", "export", "-o", output, "games"); code != 0 {
+		t.Fatalf("Export to a file exited with %d: %s", code, stderr)
+	}
+	if data, err := os.ReadFile(output); err != nil || !strings.HasPrefix(string(data), "[\n") {
+		t.Errorf("Expected a JSON export in %s, got %q (%v)", output, data, err)
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DATABASE_PATH", filepath.Join(dir, "library.db"))
+	t.Setenv("BACKUP_DIR", filepath.Join(dir, "backups"))
+
+	if code, _, stderr := run(t, "name,condition\nAzul,good\n", "import", "games", "-", "-format", "csv"); code != 0 {
+		t.Fatalf("Import exited with %d: %s", code, stderr)
+	}
+
+	snapshot := filepath.Join(dir, "snapshot.db")
+	code, stdout, stderr := run(t, "", "backup", "-o", snapshot)
+	if code != 0 || !strings.Contains(stdout, "to "+snapshot) {
+		t.Fatalf("Backup exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	if code, _, stderr := run(t, "name,condition\nHanabi,fair\n", "import", "games", "-", "-format", "csv"); code != 0 {
+		t.Fatalf("Import exited with %d: %s", code, stderr)
+	}
+
+	code, stdout, stderr = run(t, "", "restore", snapshot)
+	if code != 0 || !strings.Contains(stdout, "Saved the current database to "+filepath.Join(dir, "backups", "before-restore-")) {
+		t.Fatalf("Restore exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	_, stdout, _ = run(t, "", "export", "games")
+	if !strings.Contains(stdout, "Azul") || strings.Contains(stdout, "Hanabi") {
+		t.Errorf("Expected the games of the snapshot after the restore, got %s", stdout)
+	}
+
+	if code, stdout, stderr = run(t, "", "backup"); code != 0 || !strings.Contains(stdout, filepath.Join(dir, "backups", "library-")) {
+		t.Errorf("Backup to the backup directory exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	os.WriteFile(snapshot, []byte("not a database"), 0644)
+	if code, _, stderr = run(t, "", "restore", snapshot); code != 1 || !strings.Contains(stderr, "invalid backup") {
+		t.Errorf("Expected an invalid backup to be rejected, got %d: %s", code, stderr)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DATABASE_PATH", filepath.Join(dir, "library.db"))
+	t.Setenv("BACKUP_DIR", filepath.Join(dir, "backups"))
+
+	code, stdout, stderr := run(t, "", "migrate")
+	if code != 0 || !regexp.MustCompile(`\n1 +create_users_table +pending\n`).MatchString(stdout) {
+		t.Fatalf("Status exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	if code, stdout, stderr = run(t, "", "migrate", "to", "9"); code != 0 || !strings.Contains(stdout, "Database at schema version 9") {
+		t.Fatalf("Migrate to 9 exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = run(t, "", "migrate", "down", "2")
+	if code != 0 || !strings.Contains(stdout, "Saved the database to "+filepath.Join(dir, "backups", "before-migrate-")) || !strings.Contains(stdout, "Database at schema version 7") {
+		t.Fatalf("Rollback exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	if code, stdout, stderr = run(t, "", "migrate", "up"); code != 0 {
+		t.Fatalf("Migrate up exited with %d: %s%s", code, stdout, stderr)
+	}
+	if code, stdout, _ = run(t, "", "migrate", "status"); code != 0 || strings.Contains(stdout, "  pending\n") {
+		t.Errorf("Expected every supported migration to be applied, got %d: %s", code, stdout)
+	}
+
+	if code, _, stderr = run(t, "", "migrate", "down", "none"); code != 1 || !strings.Contains(stderr, "invalid number of migrations") {
+		t.Errorf("Expected an invalid number error, got %d: %s", code, stderr)
+	}
+	if code, _, stderr = run(t, "", "migrate", "sideways"); code != 1 || !strings.Contains(stderr, "usage: migrate") {
+		t.Errorf("Expected the usage of migrate, got %d: %s", code, stderr)
+	}
+}
+
+func TestLibrary(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DATABASE_PATH", filepath.Join(dir, "library.db"))
+
+	if code, _, stderr := run(t, "secret-password\n", "library", "create", "Ludo Club de Nantes"); code != 1 || !strings.Contains(stderr, "usage: library") {
+		t.Fatalf("Expected a library without administrator to be refused, got %d: %s", code, stderr)
+	}
+	if code, _, stderr := run(t, "", "library", "create", "-admin-email", "anne@example.com", "-admin-name", "Anne", "Ludo Club de Nantes"); code != 1 || !strings.Contains(stderr, "standard input") {
+		t.Fatalf("Expected a missing password error, got %d: %s", code, stderr)
+	}
+
+	code, stdout, stderr := run(t, "secret-password\n", "library", "create", "-admin-email", "anne@example.com", "-admin-name", "Anne", "Ludo Club de Nantes")
+	if code != 0 || !strings.Contains(stdout, "Created library ludo-club-de-nantes (2) administered by anne@example.com") {
+		t.Fatalf("Create exited with %d: %s%s", code, stdout, stderr)
+	}
+	if _, stdout, _ = run(t, "", "export", "-library", "ludo-club-de-nantes", "users"); !strings.Contains(stdout, "anne@example.com") || !strings.Contains(stdout, "admin") {
+		t.Errorf("Expected the administrator in the new library, got %s", stdout)
+	}
+
+	// The library is not created when its administrator cannot be
+	if code, _, stderr = run(t, "secret-password\n", "library", "create", "-admin-email", "anne@example.com", "-admin-name", "Anne", "Autre Club"); code != 1 {
+		t.Fatalf("Expected a taken email to be refused, got %d: %s", code, stderr)
+	}
+
+	if code, stdout, stderr = run(t, "", "library", "rename", "ludo-club-de-nantes", "Ludo Club", "ludo"); code != 0 || !strings.Contains(stdout, "Renamed library ludo (2) to Ludo Club") {
+		t.Fatalf("Rename exited with %d: %s%s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = run(t, "", "library")
+	if code != 0 || !regexp.MustCompile(`\n1 +default +Bibliothèque\n2 +ludo +Ludo Club\n`).MatchString(stdout) {
+		t.Fatalf("List exited with %d: %s%s", code, stdout, stderr)
+	}
+	if strings.Contains(stdout, "autre-club") {
+		t.Errorf("Expected no library without administrator, got %s", stdout)
+	}
+
+	if code, _, stderr = run(t, "name,condition\nAzul,good\n", "import", "-library", "ludo", "games", "-", "-format", "csv"); code != 0 {
+		t.Fatalf("Import exited with %d: %s", code, stderr)
+	}
+	if _, stdout, _ = run(t, "", "export", "-library", "ludo", "games"); !strings.Contains(stdout, "Azul") {
+		t.Errorf("Expected the game in the library it was imported into, got %s", stdout)
+	}
+	if _, stdout, _ = run(t, "", "export", "games"); strings.Contains(stdout, "Azul") {
+		t.Errorf("Expected the game to be hidden from the default library, got %s", stdout)
+	}
+
+	if code, _, stderr = run(t, "", "export", "-library", "missing", "games"); code != 1 || !strings.Contains(stderr, "not found") {
+		t.Errorf("Expected an unknown library error, got %d: %s", code, stderr)
+	}
+	if code, _, stderr = run(t, "secret-password\n", "library", "create", "-admin-email", "bob@example.com", "-admin-name", "Bob", "Ludo", "Not A Slug"); code != 1 || !strings.Contains(stderr, "library slug must be") {
+		t.Errorf("Expected an invalid slug error, got %d: %s", code, stderr)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	if code, _, stderr := run(t, "", "import", "games"); code != 1 || !strings.Contains(stderr, "usage: import") {
+		t.Errorf("Expected the usage of import, got %d: %s", code, stderr)
+	}
+
+	if code, _, stderr := run(t, "", "import", "alerts", "alerts.csv"); code != 1 || !strings.Contains(stderr, `invalid table "alerts"`) {
+		t.Errorf("Expected an invalid table error, got %d: %s", code, stderr)
+	}
+
+	if code, _, stderr := run(t, "", "serve"); code != 2 || !strings.Contains(stderr, `unknown command "serve"`) {
+		t.Errorf("Expected an unknown command error, got %d: %s", code, stderr)
+	}
+
+	if code, stdout, _ := run(t, "", "help"); code != 0 || !strings.Contains(stdout, exportUsage) {
+		t.Errorf("Expected the usage, got %d: %s", code, stdout)
+	}
+}
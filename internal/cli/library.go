@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"board-game-library/internal/services"
+	"board-game-library/pkg/database"
+)
+
+// runLibrary lists, creates or renames the libraries of the deployment
+func runLibrary(env *environment, args []string) error {
+	flags := flag.NewFlagSet("library", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	adminEmail := flags.String("admin-email", "", "email of the first administrator of a new library")
+	adminName := flags.String("admin-name", "", "name of the first administrator of a new library")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	action := "list"
+	if len(positional) > 0 {
+		action, positional = positional[0], positional[1:]
+	}
+
+	db, err := openDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	libraries := services.NewLibraryService(repositories.NewSQLiteLibraryRepository(db))
+
+	switch {
+	case action == "list" && len(positional) == 0:
+		all, err := libraries.ListLibraries(env.ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tSLUG\tNAME")
+		for _, library := range all {
+			fmt.Fprintf(w, "%d\t%s\t%s\n", library.ID, library.Slug, library.Name)
+		}
+		return w.Flush()
+
+	case action == "create" && (len(positional) == 1 || len(positional) == 2):
+		// Without an administrator, the first visitor of the setup page
+		// of the library would become one
+		if *adminEmail == "" || *adminName == "" {
+			return fmt.Errorf("usage: %s", libraryUsage)
+		}
+		password, err := readPassword(env)
+		if err != nil {
+			return err
+		}
+		slug := ""
+		if len(positional) == 2 {
+			slug = positional[1]
+		}
+		library, err := createLibrary(env.ctx, db, positional[0], slug, *adminName, *adminEmail, password)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "Created library %s (%d) administered by %s\n", library.Slug, library.ID, *adminEmail)
+		return nil
+
+	case action == "rename" && (len(positional) == 2 || len(positional) == 3):
+		library, err := libraries.GetLibraryBySlug(env.ctx, positional[0])
+		if err != nil {
+			return err
+		}
+		slug := ""
+		if len(positional) == 3 {
+			slug = positional[2]
+		}
+		if library, err = libraries.RenameLibrary(env.ctx, library.ID, positional[1], slug); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "Renamed library %s (%d) to %s\n", library.Slug, library.ID, library.Name)
+		return nil
+
+	default:
+		return fmt.Errorf("usage: %s", libraryUsage)
+	}
+}
+
+// createLibrary adds a library and its first administrator in a single
+// transaction, so that no library is left without one
+func createLibrary(ctx context.Context, db *database.DB, name, slug, adminName, adminEmail, password string) (*models.Library, error) {
+	var library *models.Library
+	err := db.WithTxContext(ctx, func(tx database.Querier) error {
+		var err error
+		library, err = services.NewLibraryService(repositories.NewSQLiteLibraryRepository(tx)).CreateLibrary(ctx, name, slug)
+		if err != nil {
+			return err
+		}
+
+		scoped := database.InLibrary(tx, library.ID)
+		auth := services.NewAuthService(repositories.NewSQLiteUserRepository(scoped), repositories.NewSQLiteAuthRepository(scoped), 0)
+		if _, err := auth.CreateFirstAdmin(ctx, adminName, adminEmail, password); err != nil {
+			return fmt.Errorf("failed to create the administrator: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return library, nil
+}
+
+// readPassword reads a password from the first line of the standard input,
+// so that it does not show in the process list or the shell history
+func readPassword(env *environment) (string, error) {
+	line, err := bufio.NewReader(env.stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read the password: %w", err)
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("the password of the administrator must be given on the standard input")
+	}
+	return password, nil
+}
+
+// openLibraryDatabase opens the database of the server configuration scoped
+// to the library with the given slug, or to the default library when slug
+// is empty
+func openLibraryDatabase(ctx context.Context, slug string) (*database.DB, error) {
+	db, err := openDatabase()
+	if err != nil {
+		return nil, err
+	}
+	if slug == "" {
+		return db, nil
+	}
+
+	library, err := services.NewLibraryService(repositories.NewSQLiteLibraryRepository(db)).GetLibraryBySlug(ctx, slug)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db.ForLibrary(library.ID), nil
+}
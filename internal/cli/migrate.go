@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"board-game-library/pkg/database"
+)
+
+// runMigrate shows the schema migrations, or applies or rolls them back.
+// The database is saved in the backup directory before a rollback.
+func runMigrate(env *environment, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	action := "status"
+	if len(positional) > 0 {
+		action, positional = positional[0], positional[1:]
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	db, err := database.NewConnection(cfg.Database.Connection())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+	migrations := database.NewMigrationManager(db)
+
+	switch {
+	case action == "status" && len(positional) == 0:
+		return printMigrationStatus(env, migrations)
+
+	case action == "up" && len(positional) == 0:
+		err = migrations.Migrate()
+
+	case action == "down" && len(positional) <= 1:
+		n := 1
+		if len(positional) == 1 {
+			if n, err = strconv.Atoi(positional[0]); err != nil || n < 1 {
+				return fmt.Errorf("invalid number of migrations: %s", positional[0])
+			}
+		}
+		if err := saveBeforeMigrate(env, db, cfg.Backup.Dir); err != nil {
+			return err
+		}
+		err = migrations.Rollback(n)
+
+	case action == "to" && len(positional) == 1:
+		version, convErr := strconv.Atoi(positional[0])
+		if convErr != nil || version < 0 {
+			return fmt.Errorf("invalid version: %s", positional[0])
+		}
+		if current, err := database.SchemaVersion(db); err == nil && version < current {
+			if err := saveBeforeMigrate(env, db, cfg.Backup.Dir); err != nil {
+				return err
+			}
+		}
+		err = migrations.MigrateTo(version)
+
+	default:
+		return fmt.Errorf("usage: %s", migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, err := database.SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "Database at schema version %d\n", version)
+	return nil
+}
+
+// printMigrationStatus lists the migrations and whether they are applied
+func printMigrationStatus(env *environment, migrations *database.MigrationManager) error {
+	statuses, err := migrations.Status()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS")
+	for _, status := range statuses {
+		state := "pending"
+		switch {
+		case !status.Known:
+			state = "applied, unknown to this build"
+		case status.Modified:
+			state = "applied, modified since"
+		case status.Applied:
+			state = "applied " + status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+		case !status.Supported:
+			state = "pending, not supported by this build"
+		}
+		if status.Known && !status.Reversible {
+			state += " (irreversible)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, state)
+	}
+	return w.Flush()
+}
+
+// saveBeforeMigrate saves the database in the backup directory before
+// migrations are rolled back, since rollbacks drop tables and columns.
+// PostgreSQL databases are left to pg_dump.
+func saveBeforeMigrate(env *environment, db *database.DB, dir string) error {
+	if db.Driver() == database.DriverPostgres {
+		fmt.Fprintln(env.stdout, "Not saving the PostgreSQL database: back it up with pg_dump before rolling back")
+		return nil
+	}
+
+	saved := filepath.Join(dir, time.Now().Format("before-migrate-20060102-150405.db"))
+	if err := db.Backup(saved); err != nil {
+		return fmt.Errorf("failed to save the database before the rollback: %w", err)
+	}
+	fmt.Fprintf(env.stdout, "Saved the database to %s\n", saved)
+	return nil
+}
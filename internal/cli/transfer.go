@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"board-game-library/internal/services"
+	"board-game-library/pkg/database"
+)
+
+// newTransferService returns the bulk import and export service of db,
+// recording imports in the audit log as made by the system
+func newTransferService(db *database.DB) *services.TransferService {
+	service := services.NewTransferService(repositories.NewSQLiteUnitOfWork(db), repositories.NewSQLiteExportRepository(db))
+	service.SetAuditor(services.NewAuditService(repositories.NewSQLiteAuditRepository(db)))
+	return service
+}
+
+// runImport imports a CSV or JSON file into a table. The file "-" is the
+// standard input.
+func runImport(env *environment, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	format := flags.String("format", "", "file format, csv or json (default: from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "validate every row without saving anything")
+	library := flags.String("library", "", "slug of the library to import into (default: the default library)")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return fmt.Errorf("usage: %s", importUsage)
+	}
+	table, path := positional[0], positional[1]
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	if err := models.ValidateTransfer(table, *format); err != nil {
+		return err
+	}
+
+	var file io.Reader = env.stdin
+	if path != "-" {
+		opened, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer opened.Close()
+		file = opened
+	}
+
+	db, err := openLibraryDatabase(env.ctx, *library)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	result, err := newTransferService(db).Import(env.ctx, table, *format, file, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, rowErr := range result.Errors {
+		fmt.Fprintf(env.stdout, "line %d: %s\n", rowErr.Line, rowErr.Message)
+	}
+
+	switch {
+	case len(result.Errors) > 0:
+		return fmt.Errorf("%d of %d rows are invalid, nothing was imported", len(result.Errors), result.Rows)
+	case result.DryRun:
+		fmt.Fprintf(env.stdout, "All %d rows are valid, nothing was imported (dry run)\n", result.Rows)
+	default:
+		fmt.Fprintf(env.stdout, "Imported %d %s\n", result.Imported, table)
+	}
+
+	return nil
+}
+
+// runExport writes a whole table to the standard output or to a file
+func runExport(env *environment, args []string) (err error) {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	format := flags.String("format", "", "file format, csv or json (default: from the output file extension, else csv)")
+	output := flags.String("o", "", "output file (default: the standard output)")
+	library := flags.String("library", "", "slug of the library to export (default: the default library)")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: %s", exportUsage)
+	}
+	table := positional[0]
+
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*output)), ".")
+	}
+	if *format == "" {
+		*format = models.TransferFormatCSV
+	}
+	if err := models.ValidateTransfer(table, *format); err != nil {
+		return err
+	}
+
+	db, err := openLibraryDatabase(env.ctx, *library)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	out := env.stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		out = file
+	}
+
+	return newTransferService(db).Export(env.ctx, table, *format, out)
+}
@@ -2,19 +2,34 @@ package config
 
 import (
 	"fmt"
+	"net/mail"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
+	"board-game-library/internal/jobs"
+	"board-game-library/internal/models"
+	"board-game-library/pkg/bgg"
 	"board-game-library/pkg/database"
+	"board-game-library/pkg/mailer"
 )
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
-	Alerts   AlertsConfig   `json:"alerts"`
-	Logging  LoggingConfig  `json:"logging"`
+	Server        ServerConfig        `json:"server"`
+	Database      DatabaseConfig      `json:"database"`
+	Backup        BackupConfig        `json:"backup"`
+	Alerts        AlertsConfig        `json:"alerts"`
+	Jobs          JobsConfig          `json:"jobs"`
+	Reservations  ReservationsConfig  `json:"reservations"`
+	Notifications NotificationsConfig `json:"notifications"`
+	Auth          AuthConfig          `json:"auth"`
+	Libraries     LibrariesConfig     `json:"libraries"`
+	BGG           BGGConfig           `json:"bgg"`
+	Logging       LoggingConfig       `json:"logging"`
 }
 
 // ServerConfig holds server-related configuration
@@ -24,24 +39,109 @@ type ServerConfig struct {
 	ReadTimeout     time.Duration `json:"read_timeout"`
 	WriteTimeout    time.Duration `json:"write_timeout"`
 	ShutdownTimeout time.Duration `json:"shutdown_timeout"`
+	RequestTimeout  time.Duration `json:"request_timeout"` // deadline of each request, 0 for none
 }
 
 // DatabaseConfig holds database-related configuration
 type DatabaseConfig struct {
-	Path            string        `json:"path"`
+	Driver          string        `json:"driver"` // "sqlite3" or "postgres"
+	Path            string        `json:"path"`   // SQLite database file
+	URL             string        `json:"-"`      // PostgreSQL connection string, which may hold a password
 	MaxOpenConns    int           `json:"max_open_conns"`
 	MaxIdleConns    int           `json:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
 }
 
+// Connection returns the settings used to open the database
+func (c DatabaseConfig) Connection() database.Config {
+	return database.Config{
+		Driver:       c.Driver,
+		DatabasePath: c.Path,
+		URL:          c.URL,
+	}
+}
+
+// BackupConfig holds scheduled database snapshot configuration
+type BackupConfig struct {
+	Enabled   bool          `json:"enabled"`
+	Dir       string        `json:"dir"` // defaults to a backups directory next to the database
+	Interval  time.Duration `json:"interval"`
+	Schedule  string        `json:"schedule"`  // cron expression, overrides Interval when set
+	Retention int           `json:"retention"` // number of snapshots kept
+}
+
 // AlertsConfig holds alert system configuration
 type AlertsConfig struct {
 	CheckInterval    time.Duration `json:"check_interval"`
+	Schedule         string        `json:"schedule"` // cron expression, overrides CheckInterval when set
+	Timezone         string        `json:"timezone"` // timezone for Schedule, e.g. "Europe/Paris"
 	ReminderDays     int           `json:"reminder_days"`
 	EnableReminders  bool          `json:"enable_reminders"`
 	EnableOverdue    bool          `json:"enable_overdue"`
 }
 
+// JobsConfig holds background job configuration
+type JobsConfig struct {
+	RunRetentionDays int `json:"run_retention_days"` // days of job execution history kept, 0 keeps it forever
+}
+
+// ReservationsConfig holds reservation queue configuration
+type ReservationsConfig struct {
+	HoldDays int `json:"hold_days"` // days a returned copy is kept for the first user in line
+}
+
+// NotificationsConfig holds the delivery of alerts to users by email
+type NotificationsConfig struct {
+	SMTPHost     string        `json:"smtp_host"` // empty disables email notifications
+	SMTPPort     int           `json:"smtp_port"`
+	SMTPUsername string        `json:"smtp_username"`
+	SMTPPassword string        `json:"-"`
+	From         string        `json:"from"`          // sender address, e.g. "Ludothèque <ludo@example.org>"
+	Security     string        `json:"security"`      // "starttls", "tls" or "none"
+	Language     string        `json:"language"`      // language of users who did not choose one
+	Interval     time.Duration `json:"interval"`      // how often due messages are sent
+	MaxAttempts  int           `json:"max_attempts"`  // attempts before a message is given up
+	RetryBackoff time.Duration `json:"retry_backoff"` // delay before the first retry, doubled after each failure
+}
+
+// Enabled reports whether alerts are sent by email
+func (c NotificationsConfig) Enabled() bool {
+	return c.SMTPHost != ""
+}
+
+// Mailer returns the settings of the SMTP server
+func (c NotificationsConfig) Mailer() mailer.Config {
+	return mailer.Config{
+		Host:     c.SMTPHost,
+		Port:     c.SMTPPort,
+		Username: c.SMTPUsername,
+		Password: c.SMTPPassword,
+		From:     c.From,
+		Security: c.Security,
+	}
+}
+
+// AuthConfig holds authentication configuration
+type AuthConfig struct {
+	Enabled       bool          `json:"enabled"`        // require sign-in and enforce roles
+	SessionTTL    time.Duration `json:"session_ttl"`    // lifetime of a web session
+	SecureCookies bool          `json:"secure_cookies"` // only send the session cookie over HTTPS
+}
+
+// LibrariesConfig holds how requests select one of the libraries sharing
+// the deployment
+type LibrariesConfig struct {
+	BaseDomain string `json:"base_domain"` // e.g. "ludo.example.org" serves <slug>.ludo.example.org; empty disables subdomains
+	PathPrefix string `json:"path_prefix"` // e.g. "/l" serves /l/<slug>/...; empty disables path prefixes
+}
+
+// BGGConfig holds the BoardGameGeek import configuration
+type BGGConfig struct {
+	BaseURL string        `json:"base_url"` // XML API2 address; empty disables the import
+	Token   string        `json:"-"`        // bearer token of the registered application, if any
+	Timeout time.Duration `json:"timeout"`
+}
+
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
 	Level  string `json:"level"`
@@ -51,6 +151,13 @@ type LoggingConfig struct {
 
 // Load loads configuration from environment variables with defaults
 func Load() (*Config, error) {
+	// SQLite works best with a single connection; PostgreSQL serves several
+	driver := getEnv("DATABASE_DRIVER", database.DriverSQLite)
+	defaultConns := 1
+	if driver == database.DriverPostgres {
+		defaultConns = 10
+	}
+
 	config := &Config{
 		Server: ServerConfig{
 			Port:            getEnvAsInt("SERVER_PORT", 8080),
@@ -58,19 +165,63 @@ func Load() (*Config, error) {
 			ReadTimeout:     getEnvAsDuration("SERVER_READ_TIMEOUT", 30*time.Second),
 			WriteTimeout:    getEnvAsDuration("SERVER_WRITE_TIMEOUT", 30*time.Second),
 			ShutdownTimeout: getEnvAsDuration("SERVER_SHUTDOWN_TIMEOUT", 30*time.Second),
+			RequestTimeout:  getEnvAsDuration("SERVER_REQUEST_TIMEOUT", 20*time.Second),
 		},
 		Database: DatabaseConfig{
+			Driver:          driver,
 			Path:            getEnv("DATABASE_PATH", database.GetDefaultDatabasePath()),
-			MaxOpenConns:    getEnvAsInt("DATABASE_MAX_OPEN_CONNS", 1),
-			MaxIdleConns:    getEnvAsInt("DATABASE_MAX_IDLE_CONNS", 1),
+			URL:             getEnv("DATABASE_URL", ""),
+			MaxOpenConns:    getEnvAsInt("DATABASE_MAX_OPEN_CONNS", defaultConns),
+			MaxIdleConns:    getEnvAsInt("DATABASE_MAX_IDLE_CONNS", defaultConns),
 			ConnMaxLifetime: getEnvAsDuration("DATABASE_CONN_MAX_LIFETIME", time.Hour),
 		},
+		Backup: BackupConfig{
+			Enabled:   getEnvAsBool("BACKUP_ENABLED", driver != database.DriverPostgres),
+			Dir:       getEnv("BACKUP_DIR", ""),
+			Interval:  getEnvAsDuration("BACKUP_INTERVAL", 24*time.Hour),
+			Schedule:  getEnv("BACKUP_SCHEDULE", ""),
+			Retention: getEnvAsInt("BACKUP_RETENTION", 7),
+		},
 		Alerts: AlertsConfig{
 			CheckInterval:   getEnvAsDuration("ALERTS_CHECK_INTERVAL", 24*time.Hour),
+			Schedule:        getEnv("ALERTS_SCHEDULE", ""),
+			Timezone:        getEnv("ALERTS_TIMEZONE", "Local"),
 			ReminderDays:    getEnvAsInt("ALERTS_REMINDER_DAYS", 2),
 			EnableReminders: getEnvAsBool("ALERTS_ENABLE_REMINDERS", true),
 			EnableOverdue:   getEnvAsBool("ALERTS_ENABLE_OVERDUE", true),
 		},
+		Jobs: JobsConfig{
+			RunRetentionDays: getEnvAsInt("JOBS_RUN_RETENTION_DAYS", 90),
+		},
+		Reservations: ReservationsConfig{
+			HoldDays: getEnvAsInt("RESERVATIONS_HOLD_DAYS", 3),
+		},
+		Notifications: NotificationsConfig{
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			From:         getEnv("SMTP_FROM", ""),
+			Security:     getEnv("SMTP_SECURITY", mailer.SecurityStartTLS),
+			Language:     getEnv("NOTIFICATIONS_LANGUAGE", models.LanguageFrench),
+			Interval:     getEnvAsDuration("NOTIFICATIONS_INTERVAL", 5*time.Minute),
+			MaxAttempts:  getEnvAsInt("NOTIFICATIONS_MAX_ATTEMPTS", 5),
+			RetryBackoff: getEnvAsDuration("NOTIFICATIONS_RETRY_BACKOFF", 5*time.Minute),
+		},
+		Auth: AuthConfig{
+			Enabled:       getEnvAsBool("AUTH_ENABLED", true),
+			SessionTTL:    getEnvAsDuration("AUTH_SESSION_TTL", 24*time.Hour),
+			SecureCookies: getEnvAsBool("AUTH_SECURE_COOKIES", false),
+		},
+		Libraries: LibrariesConfig{
+			BaseDomain: getEnv("LIBRARIES_BASE_DOMAIN", ""),
+			PathPrefix: getEnv("LIBRARIES_PATH_PREFIX", "/l"),
+		},
+		BGG: BGGConfig{
+			BaseURL: getEnv("BGG_BASE_URL", bgg.DefaultBaseURL),
+			Token:   getEnv("BGG_TOKEN", ""),
+			Timeout: getEnvAsDuration("BGG_TIMEOUT", 10*time.Second),
+		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
 			Format: getEnv("LOG_FORMAT", "text"),
@@ -78,6 +229,10 @@ func Load() (*Config, error) {
 		},
 	}
 
+	if config.Backup.Dir == "" {
+		config.Backup.Dir = filepath.Join(filepath.Dir(config.Database.Path), "backups")
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
@@ -90,14 +245,80 @@ func (c *Config) Validate() error {
 	if c.Server.Port < 1 || c.Server.Port > 65535 {
 		return fmt.Errorf("invalid server port: %d", c.Server.Port)
 	}
+	if c.Server.RequestTimeout < 0 {
+		return fmt.Errorf("request timeout cannot be negative: %s", c.Server.RequestTimeout)
+	}
 
-	if c.Database.Path == "" {
-		return fmt.Errorf("database path cannot be empty")
+	switch c.Database.Driver {
+	case "", database.DriverSQLite:
+		if c.Database.Path == "" {
+			return fmt.Errorf("database path cannot be empty")
+		}
+	case database.DriverPostgres:
+		if c.Database.URL == "" {
+			return fmt.Errorf("database URL cannot be empty with the postgres driver")
+		}
+		if c.Backup.Enabled {
+			return fmt.Errorf("scheduled backups are only supported on SQLite: back up PostgreSQL databases with pg_dump")
+		}
+	default:
+		return fmt.Errorf("invalid database driver: %s", c.Database.Driver)
+	}
+
+	if c.Backup.Enabled {
+		if c.Backup.Retention < 1 {
+			return fmt.Errorf("backup retention must be at least 1: %d", c.Backup.Retention)
+		}
+		if c.Backup.Interval <= 0 {
+			return fmt.Errorf("backup interval must be positive: %s", c.Backup.Interval)
+		}
+		if c.Backup.Schedule != "" {
+			if _, err := jobs.ParseCronExpression(c.Backup.Schedule); err != nil {
+				return fmt.Errorf("backup schedule: %w", err)
+			}
+		}
 	}
 
 	if c.Alerts.ReminderDays < 0 {
 		return fmt.Errorf("reminder days cannot be negative: %d", c.Alerts.ReminderDays)
 	}
+	if c.Alerts.Schedule != "" {
+		if _, err := jobs.ParseCronExpression(c.Alerts.Schedule); err != nil {
+			return fmt.Errorf("alerts schedule: %w", err)
+		}
+	}
+	if _, err := jobs.LoadLocation(c.Alerts.Timezone); err != nil {
+		return fmt.Errorf("alerts timezone: %w", err)
+	}
+
+	if c.Jobs.RunRetentionDays < 0 {
+		return fmt.Errorf("job run retention days cannot be negative: %d", c.Jobs.RunRetentionDays)
+	}
+
+	if c.Reservations.HoldDays < 1 {
+		return fmt.Errorf("hold days must be at least 1: %d", c.Reservations.HoldDays)
+	}
+
+	if err := c.Notifications.validate(); err != nil {
+		return err
+	}
+
+	if c.Auth.SessionTTL < time.Minute {
+		return fmt.Errorf("session TTL must be at least 1m: %s", c.Auth.SessionTTL)
+	}
+
+	if p := c.Libraries.PathPrefix; p != "" && (!strings.HasPrefix(p, "/") || strings.HasSuffix(p, "/")) {
+		return fmt.Errorf("library path prefix must start and not end with a slash: %s", p)
+	}
+
+	if c.BGG.BaseURL != "" {
+		if u, err := url.Parse(c.BGG.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid BoardGameGeek base URL: %s", c.BGG.BaseURL)
+		}
+		if c.BGG.Timeout <= 0 {
+			return fmt.Errorf("BoardGameGeek timeout must be positive: %s", c.BGG.Timeout)
+		}
+	}
 
 	validLogLevels := map[string]bool{
 		"debug": true, "info": true, "warn": true, "error": true,
@@ -117,6 +338,44 @@ func (c *Config) Validate() error {
 }
 
 // Helper functions for environment variable parsing
+// validate checks the email settings when notifications are enabled
+func (c NotificationsConfig) validate() error {
+	if c.Language != "" && !models.IsValidLanguage(c.Language) {
+		return fmt.Errorf("invalid notifications language: must be one of %v", models.ValidLanguages)
+	}
+
+	if !c.Enabled() {
+		return nil
+	}
+
+	if c.SMTPPort < 1 || c.SMTPPort > 65535 {
+		return fmt.Errorf("invalid SMTP port: %d", c.SMTPPort)
+	}
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return fmt.Errorf("invalid SMTP sender address %q: %w", c.From, err)
+	}
+	validSecurity := false
+	for _, mode := range mailer.ValidSecurityModes {
+		if c.Security == mode {
+			validSecurity = true
+		}
+	}
+	if !validSecurity {
+		return fmt.Errorf("invalid SMTP security: must be one of %v", mailer.ValidSecurityModes)
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("notifications interval must be positive: %s", c.Interval)
+	}
+	if c.MaxAttempts < 1 {
+		return fmt.Errorf("notifications max attempts must be at least 1: %d", c.MaxAttempts)
+	}
+	if c.RetryBackoff <= 0 {
+		return fmt.Errorf("notifications retry backoff must be positive: %s", c.RetryBackoff)
+	}
+
+	return nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
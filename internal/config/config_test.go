@@ -22,6 +22,10 @@ func TestLoad(t *testing.T) {
 		t.Errorf("Expected default host '0.0.0.0', got %s", config.Server.Host)
 	}
 
+	if config.Server.RequestTimeout != 20*time.Second {
+		t.Errorf("Expected default request timeout 20s, got %s", config.Server.RequestTimeout)
+	}
+
 	if config.Database.Path != "./data/library.db" {
 		t.Errorf("Expected default database path './data/library.db', got %s", config.Database.Path)
 	}
@@ -30,6 +34,22 @@ func TestLoad(t *testing.T) {
 		t.Errorf("Expected default reminder days 2, got %d", config.Alerts.ReminderDays)
 	}
 
+	if config.Reservations.HoldDays != 3 {
+		t.Errorf("Expected default hold days 3, got %d", config.Reservations.HoldDays)
+	}
+
+	if config.Jobs.RunRetentionDays != 90 {
+		t.Errorf("Expected default job run retention 90 days, got %d", config.Jobs.RunRetentionDays)
+	}
+
+	if !config.Auth.Enabled || config.Auth.SessionTTL != 24*time.Hour {
+		t.Errorf("Expected auth enabled with 24h sessions by default, got %+v", config.Auth)
+	}
+
+	if config.Libraries.PathPrefix != "/l" || config.Libraries.BaseDomain != "" {
+		t.Errorf("Expected libraries selected by the /l path prefix by default, got %+v", config.Libraries)
+	}
+
 	if config.Logging.Level != "info" {
 		t.Errorf("Expected default log level 'info', got %s", config.Logging.Level)
 	}
@@ -83,6 +103,10 @@ func TestLoadWithEnvironmentVariables(t *testing.T) {
 	if config.Logging.Format != "json" {
 		t.Errorf("Expected log format 'json', got %s", config.Logging.Format)
 	}
+
+	if config.Backup.Dir != "/tmp/backups" {
+		t.Errorf("Expected backups next to the database in '/tmp/backups', got %s", config.Backup.Dir)
+	}
 }
 
 func TestValidate(t *testing.T) {
@@ -104,6 +128,12 @@ func TestValidate(t *testing.T) {
 				Alerts: AlertsConfig{
 					ReminderDays: 2,
 				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: 24 * time.Hour,
+				},
 				Logging: LoggingConfig{
 					Level:  "info",
 					Format: "text",
@@ -111,6 +141,146 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "negative request timeout",
+			config: Config{
+				Server: ServerConfig{
+					Port:           8080,
+					RequestTimeout: -time.Second,
+				},
+				Database: DatabaseConfig{
+					Path: "./test.db",
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: 24 * time.Hour,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "session TTL too short",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Path: "./test.db",
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: time.Second,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "library path prefix with trailing slash",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Path: "./test.db",
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: 24 * time.Hour,
+				},
+				Libraries: LibrariesConfig{
+					PathPrefix: "/l/",
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid BoardGameGeek base URL",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Path: "./test.db",
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: 24 * time.Hour,
+				},
+				BGG: BGGConfig{
+					BaseURL: "boardgamegeek.com/xmlapi2",
+					Timeout: time.Second,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative job run retention",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Path: "./test.db",
+				},
+				Jobs: JobsConfig{
+					RunRetentionDays: -1,
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "zero hold days",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Path: "./test.db",
+				},
+				Alerts: AlertsConfig{
+					ReminderDays: 2,
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 0,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid port - too low",
 			config: Config{
@@ -206,6 +376,78 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "no backup kept",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Path: "./test.db",
+				},
+				Backup: BackupConfig{
+					Enabled:   true,
+					Interval:  24 * time.Hour,
+					Retention: 0,
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: time.Hour,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgres without URL",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Driver: "postgres",
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: time.Hour,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown database driver",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Driver: "mysql",
+					Path:   "./test.db",
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: time.Hour,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid log format",
 			config: Config{
@@ -225,6 +467,84 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid alerts schedule",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Path: "./test.db",
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: 24 * time.Hour,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+				Alerts: AlertsConfig{
+					Schedule: "0 25 * * *",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid alerts timezone",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Path: "./test.db",
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: 24 * time.Hour,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+				Alerts: AlertsConfig{
+					Timezone: "Mars/Olympus_Mons",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid backup schedule",
+			config: Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Database: DatabaseConfig{
+					Path: "./test.db",
+				},
+				Reservations: ReservationsConfig{
+					HoldDays: 3,
+				},
+				Auth: AuthConfig{
+					SessionTTL: 24 * time.Hour,
+				},
+				Logging: LoggingConfig{
+					Level:  "info",
+					Format: "text",
+				},
+				Backup: BackupConfig{
+					Enabled:   true,
+					Interval:  24 * time.Hour,
+					Schedule:  "every night",
+					Retention: 7,
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -264,4 +584,73 @@ func TestGetEnvAsDuration(t *testing.T) {
 	if duration != expected {
 		t.Errorf("Expected %v, got %v", expected, duration)
 	}
-}
\ No newline at end of file
+}
+
+func TestLoadPostgres(t *testing.T) {
+	t.Setenv("DATABASE_DRIVER", "postgres")
+	t.Setenv("DATABASE_URL", "postgres://library@localhost:5432/library")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if config.Database.Connection().URL != "postgres://library@localhost:5432/library" {
+		t.Errorf("Expected the database URL to be used, got %q", config.Database.Connection().URL)
+	}
+	if config.Database.MaxOpenConns != 10 {
+		t.Errorf("Expected 10 connections by default on PostgreSQL, got %d", config.Database.MaxOpenConns)
+	}
+	if config.Backup.Enabled {
+		t.Error("Expected scheduled backups to be disabled by default on PostgreSQL")
+	}
+
+	t.Setenv("BACKUP_ENABLED", "true")
+	if _, err := Load(); err == nil {
+		t.Error("Expected scheduled backups to be rejected on PostgreSQL")
+	}
+}
+
+func TestLoadNotifications(t *testing.T) {
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Notifications.Enabled() {
+		t.Error("Expected email notifications to be disabled without an SMTP host")
+	}
+
+	t.Setenv("SMTP_HOST", "smtp.example.org")
+	t.Setenv("SMTP_FROM", "Ludothèque <ludo@example.org>")
+	t.Setenv("SMTP_PASSWORD", "secret")
+
+	config, err = Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !config.Notifications.Enabled() {
+		t.Error("Expected email notifications to be enabled with an SMTP host")
+	}
+	mailer := config.Notifications.Mailer()
+	if mailer.Port != 587 || mailer.Security != "starttls" || mailer.Password != "secret" {
+		t.Errorf("Expected STARTTLS on port 587 by default, got %+v", mailer)
+	}
+	if config.Notifications.Language != "fr" || config.Notifications.MaxAttempts != 5 {
+		t.Errorf("Expected French messages and 5 attempts by default, got %+v", config.Notifications)
+	}
+
+	invalid := map[string]string{
+		"SMTP_FROM":                  "not an address",
+		"SMTP_SECURITY":              "ssl",
+		"NOTIFICATIONS_LANGUAGE":     "de",
+		"NOTIFICATIONS_MAX_ATTEMPTS": "0",
+	}
+	for key, value := range invalid {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			if _, err := Load(); err == nil {
+				t.Errorf("Expected %s=%q to be rejected", key, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"board-game-library/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The acting* helpers bind the signed-in user to a service for the duration
+// of a request, so that the changes it makes are attributed to them in the
+// audit log. Other implementations, such as test doubles, are returned as is.
+
+func actingGameService(c *gin.Context, service GameServiceInterface) GameServiceInterface {
+	if s, ok := service.(*services.GameService); ok {
+		return s.WithActor(CurrentUser(c))
+	}
+	return service
+}
+
+func actingUserService(c *gin.Context, service UserServiceInterface) UserServiceInterface {
+	if s, ok := service.(*services.UserService); ok {
+		return s.WithActor(CurrentUser(c))
+	}
+	return service
+}
+
+func actingBorrowingService(c *gin.Context, service BorrowingServiceInterface) BorrowingServiceInterface {
+	if s, ok := service.(*services.BorrowingService); ok {
+		return s.WithActor(CurrentUser(c))
+	}
+	return service
+}
+
+func actingAlertService(c *gin.Context, service AlertServiceInterface) AlertServiceInterface {
+	if s, ok := service.(*services.AlertService); ok {
+		return s.WithActor(CurrentUser(c))
+	}
+	return service
+}
+
+func actingReservationService(c *gin.Context, service ReservationServiceInterface) ReservationServiceInterface {
+	if s, ok := service.(*services.ReservationService); ok {
+		return s.WithActor(CurrentUser(c))
+	}
+	return service
+}
+
+func actingLoanPolicyService(c *gin.Context, service LoanPolicyServiceInterface) LoanPolicyServiceInterface {
+	if s, ok := service.(*services.LoanPolicyService); ok {
+		return s.WithActor(CurrentUser(c))
+	}
+	return service
+}
+
+func actingTagService(c *gin.Context, service TagServiceInterface) TagServiceInterface {
+	if s, ok := service.(*services.TagService); ok {
+		return s.WithActor(CurrentUser(c))
+	}
+	return service
+}
+
+func actingAuthService(c *gin.Context, service AuthServiceInterface) AuthServiceInterface {
+	if s, ok := service.(*services.AuthService); ok {
+		return s.WithActor(CurrentUser(c))
+	}
+	return service
+}
+
+func actingTransferService(c *gin.Context, service TransferServiceInterface) TransferServiceInterface {
+	if s, ok := service.(*services.TransferService); ok {
+		return s.WithActor(CurrentUser(c))
+	}
+	return service
+}
+
+func actingFineService(c *gin.Context, service FineServiceInterface) FineServiceInterface {
+	if s, ok := service.(*services.FineService); ok {
+		return s.WithActor(CurrentUser(c))
+	}
+	return service
+}
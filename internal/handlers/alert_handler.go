@@ -3,6 +3,7 @@ package handlers
 import (
 	"board-game-library/internal/models"
 	"board-game-library/internal/services"
+	"context"
 	"net/http"
 	"strconv"
 
@@ -11,16 +12,17 @@ import (
 
 // AlertServiceInterface defines the interface for alert service operations
 type AlertServiceInterface interface {
-	GenerateOverdueAlerts() error
-	GenerateReminderAlerts() error
-	GetActiveAlerts() ([]*models.Alert, error)
-	GetAlertsByUser(userID int) ([]*models.Alert, error)
-	MarkAlertAsRead(alertID int) error
-	MarkAllUserAlertsAsRead(userID int) error
-	DeleteAlert(alertID int) error
-	CleanupResolvedAlerts() error
-	GetAlertsSummaryByUser() (map[int]services.AlertSummary, error)
-	CreateCustomAlert(userID, gameID int, alertType, message string) (*models.Alert, error)
+	GenerateOverdueAlerts(ctx context.Context) error
+	GenerateReminderAlerts(ctx context.Context) error
+	GetActiveAlerts(ctx context.Context) ([]*models.Alert, error)
+	ListAlerts(ctx context.Context, filter models.AlertFilter) ([]*models.Alert, int, error)
+	GetAlertsByUser(ctx context.Context, userID int) ([]*models.Alert, error)
+	MarkAlertAsRead(ctx context.Context, alertID int) error
+	MarkAllUserAlertsAsRead(ctx context.Context, userID int) error
+	DeleteAlert(ctx context.Context, alertID int) error
+	CleanupResolvedAlerts(ctx context.Context) error
+	GetAlertsSummaryByUser(ctx context.Context) (map[int]services.AlertSummary, error)
+	CreateCustomAlert(ctx context.Context, userID, gameID int, alertType, message string) (*models.Alert, error)
 }
 
 // AlertHandler handles HTTP requests for alert management
@@ -35,21 +37,40 @@ func NewAlertHandler(alertService AlertServiceInterface) *AlertHandler {
 	}
 }
 
-// GetActiveAlerts handles GET /api/alerts - get all unread alerts
+// GetActiveAlerts handles GET /api/alerts - list alerts one page at a time, unread ones by default
+// @Summary Lister les alertes
+// @Description Récupère une page d'alertes, filtrée et triée; seules les alertes non lues sont renvoyées par défaut
+// @Tags alerts
+// @Produce json
+// @Param status query string false "Statut de lecture (unread, read, all)" default(unread)
+// @Param search query string false "Rechercher dans le message, les noms de l'utilisateur et du jeu et l'email de l'utilisateur"
+// @Param user_id query int false "Filtrer par utilisateur"
+// @Param game_id query int false "Filtrer par jeu"
+// @Param type query string false "Filtrer par type (overdue, reminder...)"
+// @Param created_from query string false "Créées depuis cette date (AAAA-MM-JJ ou RFC 3339)"
+// @Param created_to query string false "Créées jusqu'à cette date incluse (AAAA-MM-JJ ou RFC 3339)"
+// @Param sort query string false "Tri (created_at, type)" default(created_at)
+// @Param order query string false "Sens du tri (asc, desc)"
+// @Param page query int false "Numéro de page" default(1)
+// @Param per_page query int false "Alertes par page (100 au maximum)" default(20)
+// @Success 200 {object} map[string]interface{} "Page d'alertes"
+// @Failure 400 {object} Problem "Paramètres invalides"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /alerts [get]
 func (h *AlertHandler) GetActiveAlerts(c *gin.Context) {
-	alerts, err := h.alertService.GetActiveAlerts()
+	filter, err := AlertFilterFromQuery(c, AlertStatusUnread)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve active alerts",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"alerts": alerts,
-		"count":  len(alerts),
-	})
+	alerts, total, err := h.alertService.ListAlerts(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, listResponse("alerts", alerts, len(alerts), total, filter.ListOptions))
 }
 
 // GetAlertsByUser handles GET /api/alerts/user/:id - get alerts for a specific user
@@ -57,10 +78,7 @@ func (h *AlertHandler) GetAlertsByUser(c *gin.Context) {
 	idParam := c.Param("id")
 	userID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": "User ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
@@ -68,19 +86,9 @@ func (h *AlertHandler) GetAlertsByUser(c *gin.Context) {
 	unreadOnly := c.Query("unread") == "true"
 	alertType := c.Query("type")
 
-	alerts, err := h.alertService.GetAlertsByUser(userID)
+	alerts, err := h.alertService.GetAlertsByUser(c.Request.Context(), userID)
 	if err != nil {
-		if err.Error() == "user not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "User not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve user alerts",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -110,25 +118,12 @@ func (h *AlertHandler) MarkAlertAsRead(c *gin.Context) {
 	idParam := c.Param("id")
 	alertID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid alert ID",
-			"details": "Alert ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	if err := h.alertService.MarkAlertAsRead(alertID); err != nil {
-		if err.Error() == "alert not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Alert not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to mark alert as read",
-			"details": err.Error(),
-		})
+	if err := actingAlertService(c, h.alertService).MarkAlertAsRead(c.Request.Context(), alertID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -142,25 +137,12 @@ func (h *AlertHandler) MarkAllUserAlertsAsRead(c *gin.Context) {
 	idParam := c.Param("id")
 	userID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": "User ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	if err := h.alertService.MarkAllUserAlertsAsRead(userID); err != nil {
-		if err.Error() == "user not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "User not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to mark alerts as read",
-			"details": err.Error(),
-		})
+	if err := actingAlertService(c, h.alertService).MarkAllUserAlertsAsRead(c.Request.Context(), userID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -174,25 +156,12 @@ func (h *AlertHandler) DeleteAlert(c *gin.Context) {
 	idParam := c.Param("id")
 	alertID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid alert ID",
-			"details": "Alert ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	if err := h.alertService.DeleteAlert(alertID); err != nil {
-		if err.Error() == "alert not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Alert not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to delete alert",
-			"details": err.Error(),
-		})
+	if err := actingAlertService(c, h.alertService).DeleteAlert(c.Request.Context(), alertID); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -203,12 +172,9 @@ func (h *AlertHandler) DeleteAlert(c *gin.Context) {
 
 // GetAlertsSummary handles GET /api/alerts/summary - get alerts summary grouped by user
 func (h *AlertHandler) GetAlertsSummary(c *gin.Context) {
-	summary, err := h.alertService.GetAlertsSummaryByUser()
+	summary, err := h.alertService.GetAlertsSummaryByUser(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve alerts summary",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -221,12 +187,9 @@ func (h *AlertHandler) GetAlertsSummary(c *gin.Context) {
 // GetDashboard handles GET /api/alerts/dashboard - get dashboard with overdue items and upcoming due dates
 func (h *AlertHandler) GetDashboard(c *gin.Context) {
 	// Get alerts summary
-	summary, err := h.alertService.GetAlertsSummaryByUser()
+	summary, err := h.alertService.GetAlertsSummaryByUser(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve dashboard data",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -265,26 +228,13 @@ type CreateCustomAlertRequest struct {
 func (h *AlertHandler) CreateCustomAlert(c *gin.Context) {
 	var req CreateCustomAlertRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
-			"details": err.Error(),
-		})
+		c.Error(BindError(err))
 		return
 	}
 
-	alert, err := h.alertService.CreateCustomAlert(req.UserID, req.GameID, req.Type, req.Message)
+	alert, err := actingAlertService(c, h.alertService).CreateCustomAlert(c.Request.Context(), req.UserID, req.GameID, req.Type, req.Message)
 	if err != nil {
-		if err.Error() == "user not found" || err.Error() == "game not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Resource not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to create alert",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -296,11 +246,8 @@ func (h *AlertHandler) CreateCustomAlert(c *gin.Context) {
 
 // GenerateOverdueAlerts handles POST /api/alerts/generate-overdue - generate overdue alerts
 func (h *AlertHandler) GenerateOverdueAlerts(c *gin.Context) {
-	if err := h.alertService.GenerateOverdueAlerts(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to generate overdue alerts",
-			"details": err.Error(),
-		})
+	if err := h.alertService.GenerateOverdueAlerts(c.Request.Context()); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -311,11 +258,8 @@ func (h *AlertHandler) GenerateOverdueAlerts(c *gin.Context) {
 
 // GenerateReminderAlerts handles POST /api/alerts/generate-reminders - generate reminder alerts
 func (h *AlertHandler) GenerateReminderAlerts(c *gin.Context) {
-	if err := h.alertService.GenerateReminderAlerts(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to generate reminder alerts",
-			"details": err.Error(),
-		})
+	if err := h.alertService.GenerateReminderAlerts(c.Request.Context()); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -326,11 +270,8 @@ func (h *AlertHandler) GenerateReminderAlerts(c *gin.Context) {
 
 // CleanupResolvedAlerts handles POST /api/alerts/cleanup - cleanup resolved alerts
 func (h *AlertHandler) CleanupResolvedAlerts(c *gin.Context) {
-	if err := h.alertService.CleanupResolvedAlerts(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to cleanup resolved alerts",
-			"details": err.Error(),
-		})
+	if err := actingAlertService(c, h.alertService).CleanupResolvedAlerts(c.Request.Context()); err != nil {
+		c.Error(err)
 		return
 	}
 
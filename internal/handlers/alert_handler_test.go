@@ -4,6 +4,7 @@ import (
 	"board-game-library/internal/models"
 	"board-game-library/internal/services"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -21,17 +22,17 @@ type MockAlertService struct {
 	mock.Mock
 }
 
-func (m *MockAlertService) GenerateOverdueAlerts() error {
+func (m *MockAlertService) GenerateOverdueAlerts(ctx context.Context) error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m *MockAlertService) GenerateReminderAlerts() error {
+func (m *MockAlertService) GenerateReminderAlerts(ctx context.Context) error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m *MockAlertService) GetActiveAlerts() ([]*models.Alert, error) {
+func (m *MockAlertService) GetActiveAlerts(ctx context.Context) ([]*models.Alert, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -39,7 +40,15 @@ func (m *MockAlertService) GetActiveAlerts() ([]*models.Alert, error) {
 	return args.Get(0).([]*models.Alert), args.Error(1)
 }
 
-func (m *MockAlertService) GetAlertsByUser(userID int) ([]*models.Alert, error) {
+func (m *MockAlertService) ListAlerts(ctx context.Context, filter models.AlertFilter) ([]*models.Alert, int, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.Alert), args.Int(1), args.Error(2)
+}
+
+func (m *MockAlertService) GetAlertsByUser(ctx context.Context, userID int) ([]*models.Alert, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -47,27 +56,27 @@ func (m *MockAlertService) GetAlertsByUser(userID int) ([]*models.Alert, error)
 	return args.Get(0).([]*models.Alert), args.Error(1)
 }
 
-func (m *MockAlertService) MarkAlertAsRead(alertID int) error {
+func (m *MockAlertService) MarkAlertAsRead(ctx context.Context, alertID int) error {
 	args := m.Called(alertID)
 	return args.Error(0)
 }
 
-func (m *MockAlertService) MarkAllUserAlertsAsRead(userID int) error {
+func (m *MockAlertService) MarkAllUserAlertsAsRead(ctx context.Context, userID int) error {
 	args := m.Called(userID)
 	return args.Error(0)
 }
 
-func (m *MockAlertService) DeleteAlert(alertID int) error {
+func (m *MockAlertService) DeleteAlert(ctx context.Context, alertID int) error {
 	args := m.Called(alertID)
 	return args.Error(0)
 }
 
-func (m *MockAlertService) CleanupResolvedAlerts() error {
+func (m *MockAlertService) CleanupResolvedAlerts(ctx context.Context) error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m *MockAlertService) GetAlertsSummaryByUser() (map[int]services.AlertSummary, error) {
+func (m *MockAlertService) GetAlertsSummaryByUser(ctx context.Context) (map[int]services.AlertSummary, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -75,7 +84,7 @@ func (m *MockAlertService) GetAlertsSummaryByUser() (map[int]services.AlertSumma
 	return args.Get(0).(map[int]services.AlertSummary), args.Error(1)
 }
 
-func (m *MockAlertService) CreateCustomAlert(userID, gameID int, alertType, message string) (*models.Alert, error) {
+func (m *MockAlertService) CreateCustomAlert(ctx context.Context, userID, gameID int, alertType, message string) (*models.Alert, error) {
 	args := m.Called(userID, gameID, alertType, message)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -92,6 +101,7 @@ func setupAlertHandlerTest() (*gin.Engine, *MockAlertService, *AlertHandler) {
 	}
 	
 	router := gin.New()
+	router.Use(Problems())
 	api := router.Group("/api")
 	handler.RegisterRoutes(api)
 	
@@ -107,7 +117,9 @@ func TestAlertHandler_GetActiveAlerts(t *testing.T) {
 			{ID: 2, UserID: 2, GameID: 2, Type: "reminder", IsRead: false},
 		}
 
-		mockService.On("GetActiveAlerts").Return(expectedAlerts, nil)
+		unread := false
+		filter := models.AlertFilter{Read: &unread, ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage}}
+		mockService.On("ListAlerts", filter).Return(expectedAlerts, 2, nil)
 
 		req, _ := http.NewRequest("GET", "/api/alerts", nil)
 		w := httptest.NewRecorder()
@@ -120,6 +132,8 @@ func TestAlertHandler_GetActiveAlerts(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, float64(2), response["count"])
+		assert.Equal(t, float64(2), response["total"])
+		assert.Equal(t, float64(1), response["total_pages"])
 		assert.NotNil(t, response["alerts"])
 
 		mockService.AssertExpectations(t)
@@ -127,7 +141,7 @@ func TestAlertHandler_GetActiveAlerts(t *testing.T) {
 
 	t.Run("service error", func(t *testing.T) {
 		router, mockService, _ := setupAlertHandlerTest()
-		mockService.On("GetActiveAlerts").Return(nil, fmt.Errorf("database error"))
+		mockService.On("ListAlerts", mock.Anything).Return(nil, 0, fmt.Errorf("database error"))
 
 		req, _ := http.NewRequest("GET", "/api/alerts", nil)
 		w := httptest.NewRecorder()
@@ -138,6 +152,38 @@ func TestAlertHandler_GetActiveAlerts(t *testing.T) {
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("all alerts of a user", func(t *testing.T) {
+		router, mockService, _ := setupAlertHandlerTest()
+		filter := models.AlertFilter{UserID: 3, Type: "overdue", ListOptions: models.ListOptions{Page: 2, PerPage: 5, Sort: "type", Order: models.SortAsc}}
+		mockService.On("ListAlerts", filter).Return([]*models.Alert{}, 6, nil)
+
+		req, _ := http.NewRequest("GET", "/api/alerts?status=all&user_id=3&type=overdue&page=2&per_page=5&sort=type&order=asc", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(2), response["total_pages"])
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		router, mockService, _ := setupAlertHandlerTest()
+
+		req, _ := http.NewRequest("GET", "/api/alerts?status=archived", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListAlerts", mock.Anything)
+	})
 }
 
 func TestAlertHandler_GetAlertsByUser(t *testing.T) {
@@ -214,7 +260,7 @@ func TestAlertHandler_GetAlertsByUser(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mockService.On("GetAlertsByUser", 999).Return(nil, fmt.Errorf("user not found"))
+		mockService.On("GetAlertsByUser", 999).Return(nil, models.NotFound("user_not_found", 1))
 
 		req, _ := http.NewRequest("GET", "/api/alerts/user/999", nil)
 		w := httptest.NewRecorder()
@@ -258,7 +304,7 @@ func TestAlertHandler_MarkAlertAsRead(t *testing.T) {
 	})
 
 	t.Run("alert not found", func(t *testing.T) {
-		mockService.On("MarkAlertAsRead", 999).Return(fmt.Errorf("alert not found"))
+		mockService.On("MarkAlertAsRead", 999).Return(models.NotFound("alert_not_found", 1))
 
 		req, _ := http.NewRequest("PUT", "/api/alerts/999/read", nil)
 		w := httptest.NewRecorder()
@@ -302,7 +348,7 @@ func TestAlertHandler_MarkAllUserAlertsAsRead(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mockService.On("MarkAllUserAlertsAsRead", 999).Return(fmt.Errorf("user not found"))
+		mockService.On("MarkAllUserAlertsAsRead", 999).Return(models.NotFound("user_not_found", 1))
 
 		req, _ := http.NewRequest("PUT", "/api/alerts/user/999/read-all", nil)
 		w := httptest.NewRecorder()
@@ -337,7 +383,7 @@ func TestAlertHandler_DeleteAlert(t *testing.T) {
 	})
 
 	t.Run("alert not found", func(t *testing.T) {
-		mockService.On("DeleteAlert", 999).Return(fmt.Errorf("alert not found"))
+		mockService.On("DeleteAlert", 999).Return(models.NotFound("alert_not_found", 1))
 
 		req, _ := http.NewRequest("DELETE", "/api/alerts/999", nil)
 		w := httptest.NewRecorder()
@@ -502,7 +548,7 @@ func TestAlertHandler_CreateCustomAlert(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mockService.On("CreateCustomAlert", 999, 1, "custom", "Custom alert message").Return(nil, fmt.Errorf("user not found"))
+		mockService.On("CreateCustomAlert", 999, 1, "custom", "Custom alert message").Return(nil, models.NotFound("user_not_found", 1))
 
 		reqBody := CreateCustomAlertRequest{
 			UserID:  999,
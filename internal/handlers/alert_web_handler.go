@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"board-game-library/internal/models"
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,277 +13,294 @@ import (
 
 // AlertWebHandler handles web requests for alert management with HTMX support
 type AlertWebHandler struct {
-	alertService AlertServiceInterface
+	alertService     AlertServiceInterface
+	borrowingService BorrowingServiceInterface
+	userService      UserServiceInterface
+	gameService      GameServiceInterface
 }
 
 // NewAlertWebHandler creates a new AlertWebHandler instance
-func NewAlertWebHandler(alertService AlertServiceInterface) *AlertWebHandler {
+func NewAlertWebHandler(alertService AlertServiceInterface, borrowingService BorrowingServiceInterface, userService UserServiceInterface, gameService GameServiceInterface) *AlertWebHandler {
 	return &AlertWebHandler{
-		alertService: alertService,
+		alertService:     alertService,
+		borrowingService: borrowingService,
+		userService:      userService,
+		gameService:      gameService,
 	}
 }
 
-// ShowAlertsList handles GET /alerts - display alerts list page
-func (h *AlertWebHandler) ShowAlertsList(c *gin.Context) {
-	alerts, err := h.alertService.GetActiveAlerts()
+// AlertStats counts the unread alerts shown at the top of the alerts page
+type AlertStats struct {
+	OverdueAlerts  int
+	ReminderAlerts int
+	UnreadAlerts   int
+}
+
+// alertListData loads the alerts selected by the list controls, grouped by
+// user. Unread alerts are shown unless another status is asked for.
+func (h *AlertWebHandler) alertListData(c *gin.Context) (gin.H, error) {
+	ctx := c.Request.Context()
+	options := webListOptions(c)
+	options.PerPage = models.MaxPerPage
+	filter := models.AlertFilter{
+		Search:      webValue(c, "search"),
+		ListOptions: options,
+	}
+	alertType := webValue(c, "type")
+	if alertType != AlertStatusAll {
+		filter.Type = alertType
+	}
+	status := webValue(c, "status")
+	switch status {
+	case "", AlertStatusUnread:
+		status = AlertStatusUnread
+		read := false
+		filter.Read = &read
+	case AlertStatusRead:
+		read := true
+		filter.Read = &read
+	}
+
+	alerts, total, err := h.alertService.ListAlerts(ctx, filter)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to load alerts: " + err.Error(),
-		})
-		return
+		return nil, err
 	}
 
-	// Group alerts by user
-	groupedAlerts := make(map[int][]*models.Alert)
-	stats := struct {
-		OverdueAlerts   int
-		ReminderAlerts  int
-		UnreadAlerts    int
-	}{}
+	return gin.H{
+		"Title":         "Alerts",
+		"GroupedAlerts": newWebNames(h.userService, h.gameService).alertGroups(ctx, alerts, h.borrowingService),
+		"Total":         total,
+		"Search":        filter.Search,
+		"Type":          alertType,
+		"Status":        status,
+		"HasFilters":    filter.Search != "" || filter.Type != "" || status != AlertStatusUnread,
+		"Pagination":    newWebPagination(filter.ListOptions, total, "/alerts/filter", "#alerts-container", "#search, #type, #status"),
+	}, nil
+}
 
-	for _, alert := range alerts {
-		groupedAlerts[alert.UserID] = append(groupedAlerts[alert.UserID], alert)
-		
-		if alert.Type == "overdue" {
-			stats.OverdueAlerts++
-		} else if alert.Type == "reminder" {
-			stats.ReminderAlerts++
-		}
-		
-		if !alert.IsRead {
-			stats.UnreadAlerts++
+// alertStats counts the unread alerts by type
+func (h *AlertWebHandler) alertStats(ctx context.Context) (AlertStats, error) {
+	var stats AlertStats
+	unread := false
+	counts := []struct {
+		alertType string
+		count     *int
+	}{
+		{"overdue", &stats.OverdueAlerts},
+		{"reminder", &stats.ReminderAlerts},
+		{"", &stats.UnreadAlerts},
+	}
+	for _, count := range counts {
+		filter := models.AlertFilter{Type: count.alertType, Read: &unread, ListOptions: models.ListOptions{PerPage: 1}}
+		_, total, err := h.alertService.ListAlerts(ctx, filter)
+		if err != nil {
+			return stats, err
 		}
+		*count.count = total
 	}
+	return stats, nil
+}
 
-	c.HTML(http.StatusOK, "alerts/list.html", gin.H{
-		"GroupedAlerts": groupedAlerts,
-		"Stats":         stats,
-		"HasFilters":    false,
-	})
+// ShowAlertsList handles GET /alerts - display alerts list page
+func (h *AlertWebHandler) ShowAlertsList(c *gin.Context) {
+	data, err := h.alertListData(c)
+	if err != nil {
+		webListError(c, err, "Failed to load alerts")
+		return
+	}
+	if data["Stats"], err = h.alertStats(c.Request.Context()); err != nil {
+		renderWebError(c, http.StatusInternalServerError, "Failed to count alerts: "+err.Error())
+		return
+	}
+
+	renderWeb(c, http.StatusOK, "alerts/list.html", data)
+}
+
+// FilterAlerts handles POST /alerts/search and /alerts/filter - HTMX refresh of the alerts list
+func (h *AlertWebHandler) FilterAlerts(c *gin.Context) {
+	h.renderAlertsList(c)
 }
 
 // MarkAlertAsRead handles POST /alerts/:id/mark-read - mark alert as read with HTMX response
 func (h *AlertWebHandler) MarkAlertAsRead(c *gin.Context) {
-	idParam := c.Param("id")
-	alertID, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid alert ID",
-		})
+	id, ok := webID(c, "alert")
+	if !ok {
 		return
 	}
 
-	if err := h.alertService.MarkAlertAsRead(alertID); err != nil {
-		c.HTML(http.StatusNotFound, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to mark alert as read: " + err.Error(),
-		})
+	if err := actingAlertService(c, h.alertService).MarkAlertAsRead(c.Request.Context(), id); err != nil {
+		renderWebError(c, ErrorStatus(err), "Failed to mark alert as read: "+err.Error())
 		return
 	}
 
-	// Return updated alerts list
 	c.Header("HX-Trigger", "alert-marked-read, refresh-dashboard")
 	h.renderAlertsList(c)
 }
 
 // MarkAllAlertsAsRead handles POST /alerts/mark-all-read - mark all alerts as read
 func (h *AlertWebHandler) MarkAllAlertsAsRead(c *gin.Context) {
-	alerts, err := h.alertService.GetActiveAlerts()
+	ctx := c.Request.Context()
+	alerts, err := h.alertService.GetActiveAlerts(ctx)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to load alerts: " + err.Error(),
-		})
+		renderWebError(c, http.StatusInternalServerError, "Failed to load alerts: "+err.Error())
 		return
 	}
 
-	// Mark all unread alerts as read
+	service := actingAlertService(c, h.alertService)
 	for _, alert := range alerts {
-		if !alert.IsRead {
-			h.alertService.MarkAlertAsRead(alert.ID)
+		if err := service.MarkAlertAsRead(ctx, alert.ID); err != nil {
+			renderWebError(c, ErrorStatus(err), "Failed to mark alert as read: "+err.Error())
+			return
 		}
 	}
 
-	// Return updated alerts list
 	c.Header("HX-Trigger", "all-alerts-marked-read, refresh-dashboard")
 	h.renderAlertsList(c)
 }
 
 // MarkUserAlertsAsRead handles POST /alerts/mark-user-read/:id - mark all user alerts as read
 func (h *AlertWebHandler) MarkUserAlertsAsRead(c *gin.Context) {
-	idParam := c.Param("id")
-	userID, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid user ID",
-		})
+	userID, ok := webID(c, "user")
+	if !ok {
 		return
 	}
 
-	if err := h.alertService.MarkAllUserAlertsAsRead(userID); err != nil {
-		c.HTML(http.StatusNotFound, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to mark user alerts as read: " + err.Error(),
-		})
+	if err := actingAlertService(c, h.alertService).MarkAllUserAlertsAsRead(c.Request.Context(), userID); err != nil {
+		renderWebError(c, ErrorStatus(err), "Failed to mark user alerts as read: "+err.Error())
 		return
 	}
 
-	// Return updated alerts list
 	c.Header("HX-Trigger", "user-alerts-marked-read, refresh-dashboard")
 	h.renderAlertsList(c)
 }
 
+// DeleteAlert handles DELETE /alerts/:id - dismiss an alert
+func (h *AlertWebHandler) DeleteAlert(c *gin.Context) {
+	id, ok := webID(c, "alert")
+	if !ok {
+		return
+	}
+
+	if err := actingAlertService(c, h.alertService).DeleteAlert(c.Request.Context(), id); err != nil {
+		renderWebError(c, ErrorStatus(err), "Failed to delete alert: "+err.Error())
+		return
+	}
+
+	c.Header("HX-Trigger", "alert-deleted, refresh-dashboard")
+	h.renderAlertsList(c)
+}
+
 // GenerateAlerts handles POST /alerts/generate - generate new alerts
 func (h *AlertWebHandler) GenerateAlerts(c *gin.Context) {
-	// Generate overdue alerts
-	if err := h.alertService.GenerateOverdueAlerts(); err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to generate overdue alerts: " + err.Error(),
-		})
+	ctx := c.Request.Context()
+	if err := h.alertService.GenerateOverdueAlerts(ctx); err != nil {
+		renderWebError(c, http.StatusInternalServerError, "Failed to generate overdue alerts: "+err.Error())
 		return
 	}
-
-	// Generate reminder alerts
-	if err := h.alertService.GenerateReminderAlerts(); err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to generate reminder alerts: " + err.Error(),
-		})
+	if err := h.alertService.GenerateReminderAlerts(ctx); err != nil {
+		renderWebError(c, http.StatusInternalServerError, "Failed to generate reminder alerts: "+err.Error())
 		return
 	}
 
-	// Return updated alerts list
 	c.Header("HX-Trigger", "alerts-generated, refresh-dashboard")
 	h.renderAlertsList(c)
 }
 
-// SearchAlerts handles POST /alerts/search - search alerts with HTMX
-func (h *AlertWebHandler) SearchAlerts(c *gin.Context) {
-	query := strings.TrimSpace(c.PostForm("search"))
-	
-	alerts, err := h.alertService.GetActiveAlerts()
-	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Search failed: " + err.Error(),
-		})
+// CleanupAlerts handles POST /alerts/cleanup - remove the alerts about games returned since
+func (h *AlertWebHandler) CleanupAlerts(c *gin.Context) {
+	if err := h.alertService.CleanupResolvedAlerts(c.Request.Context()); err != nil {
+		renderWebError(c, http.StatusInternalServerError, "Failed to clean up alerts: "+err.Error())
 		return
 	}
 
-	// Filter alerts based on search query
-	var filteredAlerts []*models.Alert
-	if query == "" {
-		filteredAlerts = alerts
-	} else {
-		queryLower := strings.ToLower(query)
-		for _, alert := range alerts {
-			if strings.Contains(strings.ToLower(alert.Message), queryLower) {
-				filteredAlerts = append(filteredAlerts, alert)
-			}
-		}
+	c.Header("HX-Trigger", "alerts-cleaned-up, refresh-dashboard")
+	h.renderAlertsList(c)
+}
+
+// newAlertFormData loads the users and games a custom alert can be about
+func (h *AlertWebHandler) newAlertFormData(ctx context.Context) (gin.H, error) {
+	users, err := h.userService.GetAllUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load users: %w", err)
+	}
+	games, err := h.gameService.GetAllGames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load games: %w", err)
 	}
 
-	h.renderFilteredAlertsList(c, filteredAlerts, true)
+	return gin.H{
+		"Title":   "New Alert",
+		"Users":   users,
+		"Games":   games,
+		"UserID":  0,
+		"GameID":  0,
+		"Message": "",
+	}, nil
 }
 
-// FilterAlerts handles POST /alerts/filter - filter alerts with HTMX
-func (h *AlertWebHandler) FilterAlerts(c *gin.Context) {
-	alertType := c.PostForm("type")
-	status := c.PostForm("status")
-	
-	alerts, err := h.alertService.GetActiveAlerts()
+// ShowNewAlertForm handles GET /alerts/new - display the custom alert form modal
+func (h *AlertWebHandler) ShowNewAlertForm(c *gin.Context) {
+	data, err := h.newAlertFormData(c.Request.Context())
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Filter failed: " + err.Error(),
-		})
+		renderWebError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	// Apply filters
-	var filteredAlerts []*models.Alert
-	for _, alert := range alerts {
-		// Filter by type
-		if alertType != "" && alertType != "all" && alert.Type != alertType {
-			continue
-		}
-		
-		// Filter by status
-		if status != "" && status != "all" {
-			if status == "unread" && alert.IsRead {
-				continue
-			}
-			if status == "read" && !alert.IsRead {
-				continue
-			}
+	renderWeb(c, http.StatusOK, "alerts/new.html", data)
+}
+
+// CreateAlert handles POST /alerts - create a custom alert from the form
+func (h *AlertWebHandler) CreateAlert(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, _ := strconv.Atoi(c.PostForm("user_id"))
+	gameID, _ := strconv.Atoi(c.PostForm("game_id"))
+	message := strings.TrimSpace(c.PostForm("message"))
+
+	alert, err := actingAlertService(c, h.alertService).CreateCustomAlert(ctx, userID, gameID, "custom", message)
+	if err != nil {
+		data, loadErr := h.newAlertFormData(ctx)
+		if loadErr != nil {
+			renderWebError(c, http.StatusInternalServerError, loadErr.Error())
+			return
 		}
-		
-		filteredAlerts = append(filteredAlerts, alert)
+		data["UserID"] = userID
+		data["GameID"] = gameID
+		data["Message"] = message
+		data["ErrorMessage"] = "Failed to create alert: " + err.Error()
+		renderWeb(c, ErrorStatus(err), "alerts/new.html", data)
+		return
 	}
 
-	hasFilters := (alertType != "" && alertType != "all") || (status != "" && status != "all")
-	h.renderFilteredAlertsList(c, filteredAlerts, hasFilters)
+	c.Header("HX-Trigger", "alert-created, refresh-alerts, refresh-dashboard")
+	renderWeb(c, http.StatusOK, "success.html", gin.H{
+		"Title":   "Alert Created",
+		"Message": fmt.Sprintf("The alert has been sent to %s.", newWebNames(h.userService, h.gameService).user(ctx, alert.UserID).Name),
+	})
 }
 
 // GetAlertCount handles GET /alerts/count - get alert count for dynamic updates
 func (h *AlertWebHandler) GetAlertCount(c *gin.Context) {
-	alerts, err := h.alertService.GetActiveAlerts()
+	unread := false
+	_, count, err := h.alertService.ListAlerts(c.Request.Context(), models.AlertFilter{Read: &unread, ListOptions: models.ListOptions{PerPage: 1}})
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to get alert count: " + err.Error(),
-		})
+		renderWebError(c, http.StatusInternalServerError, "Failed to get alert count: "+err.Error())
 		return
 	}
 
-	unreadCount := 0
-	for _, alert := range alerts {
-		if !alert.IsRead {
-			unreadCount++
-		}
-	}
-
-	c.HTML(http.StatusOK, "alerts/partials/alert-count.html", gin.H{
-		"UnreadCount": unreadCount,
-		"TotalCount":  len(alerts),
+	renderWeb(c, http.StatusOK, "alerts/partials/alert-count.html", gin.H{
+		"UnreadCount": count,
 	})
 }
 
-// renderAlertsList renders the complete alerts list
+// renderAlertsList renders the alerts selected by the list controls
 func (h *AlertWebHandler) renderAlertsList(c *gin.Context) {
-	alerts, err := h.alertService.GetActiveAlerts()
+	data, err := h.alertListData(c)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to load alerts: " + err.Error(),
-		})
+		webListError(c, err, "Failed to load alerts")
 		return
 	}
 
-	h.renderFilteredAlertsList(c, alerts, false)
-}
-
-// renderFilteredAlertsList renders the filtered alerts list
-func (h *AlertWebHandler) renderFilteredAlertsList(c *gin.Context, alerts []*models.Alert, hasFilters bool) {
-	// Group alerts by user
-	groupedAlerts := make(map[int][]*models.Alert)
-	stats := struct {
-		OverdueAlerts   int
-		ReminderAlerts  int
-		UnreadAlerts    int
-	}{}
-
-	for _, alert := range alerts {
-		groupedAlerts[alert.UserID] = append(groupedAlerts[alert.UserID], alert)
-		
-		if alert.Type == "overdue" {
-			stats.OverdueAlerts++
-		} else if alert.Type == "reminder" {
-			stats.ReminderAlerts++
-		}
-		
-		if !alert.IsRead {
-			stats.UnreadAlerts++
-		}
-	}
-
-	c.HTML(http.StatusOK, "alerts/partials/alerts-list.html", gin.H{
-		"GroupedAlerts": groupedAlerts,
-		"Stats":         stats,
-		"HasFilters":    hasFilters,
-	})
+	renderWeb(c, http.StatusOK, "alerts/partials/alerts-list.html", data)
 }
 
 // RegisterWebRoutes registers all alert web routes
@@ -289,12 +308,16 @@ func (h *AlertWebHandler) RegisterWebRoutes(router *gin.RouterGroup) {
 	alerts := router.Group("/alerts")
 	{
 		alerts.GET("", h.ShowAlertsList)
-		alerts.POST("/search", h.SearchAlerts)
+		alerts.POST("", h.CreateAlert)
+		alerts.GET("/new", h.ShowNewAlertForm)
+		alerts.POST("/search", h.FilterAlerts)
 		alerts.POST("/filter", h.FilterAlerts)
 		alerts.POST("/mark-all-read", h.MarkAllAlertsAsRead)
 		alerts.POST("/mark-user-read/:id", h.MarkUserAlertsAsRead)
 		alerts.POST("/:id/mark-read", h.MarkAlertAsRead)
+		alerts.DELETE("/:id", h.DeleteAlert)
 		alerts.POST("/generate", h.GenerateAlerts)
+		alerts.POST("/cleanup", h.CleanupAlerts)
 		alerts.GET("/count", h.GetAlertCount)
 	}
-}
\ No newline at end of file
+}
@@ -1,25 +1,35 @@
 package handlers
 
 import (
+	"board-game-library/internal/models"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestAlertWebHandler_Creation(t *testing.T) {
 	mockAlertService := new(MockAlertService)
-	handler := NewAlertWebHandler(mockAlertService)
+	mockBorrowingService := new(MockBorrowingService)
+	mockUserService := new(MockUserService)
+	mockGameService := new(MockGameService)
+	handler := NewAlertWebHandler(mockAlertService, mockBorrowingService, mockUserService, mockGameService)
 
 	assert.NotNil(t, handler)
 	assert.NotNil(t, handler.alertService)
+	assert.NotNil(t, handler.borrowingService)
+	assert.NotNil(t, handler.userService)
+	assert.NotNil(t, handler.gameService)
 }
 
 func TestAlertWebHandler_RegisterWebRoutes(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	
 	mockAlertService := new(MockAlertService)
-	handler := NewAlertWebHandler(mockAlertService)
+	handler := NewAlertWebHandler(mockAlertService, new(MockBorrowingService), new(MockUserService), new(MockGameService))
 	
 	router := gin.New()
 	routerGroup := router.Group("/")
@@ -46,7 +56,9 @@ func TestAlertWebHandler_RegisterWebRoutes(t *testing.T) {
 		"/alerts/mark-all-read",
 		"/alerts/mark-user-read/:id",
 		"/alerts/:id/mark-read",
+		"/alerts/new",
 		"/alerts/generate",
+		"/alerts/cleanup",
 		"/alerts/count",
 	}
 	
@@ -60,4 +72,35 @@ func TestAlertWebHandler_RegisterWebRoutes(t *testing.T) {
 		}
 		assert.True(t, found, "Route %s should be registered", expectedRoute)
 	}
-}
\ No newline at end of file
+}
+
+func TestAlertWebHandler_MarkAlertAsRead(t *testing.T) {
+	mockAlertService := new(MockAlertService)
+	mockUserService := new(MockUserService)
+	mockGameService := new(MockGameService)
+	mockBorrowingService := new(MockBorrowingService)
+	router := newWebTestRouter(t)
+	NewAlertWebHandler(mockAlertService, mockBorrowingService, mockUserService, mockGameService).RegisterWebRoutes(router.Group("/"))
+
+	mockAlertService.On("MarkAlertAsRead", 4).Return(nil)
+	mockAlertService.On("ListAlerts", mock.MatchedBy(func(filter models.AlertFilter) bool {
+		return filter.Read != nil && !*filter.Read
+	})).Return([]*models.Alert{{ID: 5, UserID: 1, GameID: 2, Type: "custom", Message: "<script>steal()</script>"}}, 1, nil)
+	mockUserService.On("GetUser", 1).Return(&models.User{ID: 1, Name: "Alice", Email: "alice@example.com"}, nil)
+	mockGameService.On("GetGame", 2).Return(&models.Game{ID: 2, Name: "Azul"}, nil)
+	mockBorrowingService.On("GetActiveBorrowingsByUser", 1).Return([]*models.Borrowing{}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/alerts/4/mark-read", nil)
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "alert-marked-read, refresh-dashboard", w.Header().Get("HX-Trigger"))
+	body := w.Body.String()
+	assert.Contains(t, body, "Alice")
+	assert.Contains(t, body, "&lt;script&gt;steal()&lt;/script&gt;")
+	assert.NotContains(t, body, "<script>steal()")
+	assert.NotContains(t, body, "<!DOCTYPE html>")
+	mockAlertService.AssertExpectations(t)
+}
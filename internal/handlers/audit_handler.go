@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditServiceInterface defines the interface for audit log operations
+type AuditServiceInterface interface {
+	ListEvents(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, int, error)
+}
+
+// AuditHandler handles HTTP requests for the audit log
+type AuditHandler struct {
+	auditService AuditServiceInterface
+}
+
+// NewAuditHandler creates a new AuditHandler instance
+func NewAuditHandler(auditService AuditServiceInterface) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// GetEvents handles GET /api/audit - list audit events
+// @Summary Journal d'audit
+// @Description Récupère les modifications enregistrées dans le journal d'audit, les plus récentes en premier
+// @Tags audit
+// @Produce json
+// @Param actor_id query int false "Filtrer par auteur"
+// @Param action query string false "Filtrer par action (create, update, delete, borrow, return, extend...)"
+// @Param entity_type query string false "Filtrer par type d'entité (game, user, borrowing, alert...)"
+// @Param entity_id query string false "Filtrer par identifiant d'entité"
+// @Param since query string false "Depuis cette date (AAAA-MM-JJ ou RFC 3339)"
+// @Param until query string false "Jusqu'à cette date incluse (AAAA-MM-JJ ou RFC 3339)"
+// @Param limit query int false "Nombre maximum d'événements" default(50)
+// @Param offset query int false "Nombre d'événements à ignorer" default(0)
+// @Success 200 {object} map[string]interface{} "Événements d'audit"
+// @Failure 400 {object} Problem "Paramètres invalides"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /audit [get]
+func (h *AuditHandler) GetEvents(c *gin.Context) {
+	filter, err := AuditFilterFromQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	events, total, err := h.auditService.ListEvents(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+		"count":  len(events),
+		"total":  total,
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	})
+}
+
+// AuditFilterFromQuery reads an audit filter from the query string. Dates are
+// either YYYY-MM-DD or RFC 3339; a plain until date includes the whole day.
+func AuditFilterFromQuery(c *gin.Context) (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+		EntityID:   c.Query("entity_id"),
+		Limit:      models.DefaultAuditLimit,
+	}
+
+	if param := c.Query("actor_id"); param != "" {
+		actorID, err := strconv.Atoi(param)
+		if err != nil || actorID <= 0 {
+			return filter, models.Invalid("actor_id", "invalid_positive_integer", "actor_id")
+		}
+		filter.ActorID = &actorID
+	}
+
+	for _, name := range []string{"limit", "offset"} {
+		param := c.Query(name)
+		if param == "" {
+			continue
+		}
+		value, err := strconv.Atoi(param)
+		if err != nil || value < 0 {
+			return filter, models.Invalid(name, "invalid_non_negative_integer", name)
+		}
+		if name == "limit" {
+			filter.Limit = value
+		} else {
+			filter.Offset = value
+		}
+	}
+	if filter.Limit > models.MaxAuditLimit {
+		filter.Limit = models.MaxAuditLimit
+	}
+
+	var err error
+	if filter.Since, err = parseAuditTime(c, "since", false); err != nil {
+		return filter, err
+	}
+	if filter.Until, err = parseAuditTime(c, "until", true); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// parseAuditTime parses the date filter name of the query string. With
+// endOfDay, a plain date points to the start of the next day so that the
+// whole day is included.
+func parseAuditTime(c *gin.Context, name string, endOfDay bool) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, models.Invalid(name, "invalid_date", name, value)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return &t, nil
+}
+
+// RegisterRoutes registers all audit-related routes
+func (h *AuditHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/audit", h.GetEvents)
+}
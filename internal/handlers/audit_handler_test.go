@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/services"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditService is a mock implementation of AuditServiceInterface
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) ListEvents(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, int, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.AuditEvent), args.Int(1), args.Error(2)
+}
+
+func setupAuditHandlerTest() (*gin.Engine, *MockAuditService) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := &MockAuditService{}
+	handler := NewAuditHandler(mockService)
+
+	router := gin.New()
+	router.Use(Problems())
+	api := router.Group("/api/v1")
+	handler.RegisterRoutes(api)
+
+	return router, mockService
+}
+
+func TestAuditHandler_GetEvents(t *testing.T) {
+	router, mockService := setupAuditHandlerTest()
+
+	actorID := 3
+	events := []*models.AuditEvent{
+		{ID: 9, ActorID: &actorID, ActorName: "Alice", Action: "delete", EntityType: "game", EntityID: "4", Before: json.RawMessage(`{"name":"Catan"}`)},
+	}
+	mockService.On("ListEvents", mock.MatchedBy(func(filter models.AuditFilter) bool {
+		return filter.ActorID != nil && *filter.ActorID == 3 &&
+			filter.Action == "delete" && filter.EntityType == "game" &&
+			filter.Limit == 10 && filter.Offset == 20 &&
+			filter.Since != nil && filter.Until != nil &&
+			filter.Until.Sub(*filter.Since) == 48*time.Hour
+	})).Return(events, 21, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/audit?actor_id=3&action=delete&entity_type=game&since=2026-01-01&until=2026-01-02&limit=10&offset=20", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), response["count"])
+	assert.Equal(t, float64(21), response["total"])
+
+	event := response["events"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Alice", event["actor_name"])
+	assert.Equal(t, map[string]interface{}{"name": "Catan"}, event["before"])
+	assert.NotContains(t, event, "after")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAuditHandler_GetEventsInvalidFilter(t *testing.T) {
+	router, mockService := setupAuditHandlerTest()
+
+	for _, query := range []string{"actor_id=abc", "limit=-1", "since=yesterday", "until=2026-13-01"} {
+		req, _ := http.NewRequest("GET", "/api/v1/audit?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	mockService.AssertNotCalled(t, "ListEvents", mock.Anything)
+}
+
+func TestAuditHandler_GetEventsError(t *testing.T) {
+	router, mockService := setupAuditHandlerTest()
+	mockService.On("ListEvents", mock.AnythingOfType("models.AuditFilter")).Return(nil, 0, errors.New("database is locked"))
+
+	req, _ := http.NewRequest("GET", "/api/v1/audit", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestActingServices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set(currentUserKey, &models.User{ID: 1, Name: "Alice"})
+
+	// Concrete services get a copy bound to the signed-in user
+	gameService := services.NewGameService(nil, nil)
+	bound := actingGameService(c, gameService)
+	assert.NotSame(t, gameService, bound)
+
+	// Test doubles are used as is
+	mockService := &MockGameService{}
+	assert.Same(t, mockService, actingGameService(c, mockService))
+}
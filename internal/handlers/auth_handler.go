@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthServiceInterface defines the interface for authentication service operations
+type AuthServiceInterface interface {
+	SetupRequired(ctx context.Context) (bool, error)
+	CreateFirstAdmin(ctx context.Context, name, email, password string) (*models.User, error)
+	Login(ctx context.Context, email, password string) (string, *models.User, error)
+	Logout(ctx context.Context, token string) error
+	AuthenticateSession(ctx context.Context, token string) (*models.User, error)
+	AuthenticateToken(ctx context.Context, token string) (*models.User, error)
+	SetPassword(ctx context.Context, userID int, password string) error
+	ChangePassword(ctx context.Context, userID int, currentPassword, newPassword string) error
+	SetRole(ctx context.Context, userID int, role string) (*models.User, error)
+	CreateAPIToken(ctx context.Context, userID int, name string) (string, *models.APIToken, error)
+	ListAPITokens(ctx context.Context, userID int) ([]*models.APIToken, error)
+	RevokeAPIToken(ctx context.Context, actor *models.User, tokenID int) error
+}
+
+// AuthHandler handles HTTP requests for sign-in, API tokens and roles
+type AuthHandler struct {
+	authService AuthServiceInterface
+	cookie      SessionCookie
+}
+
+// NewAuthHandler creates a new AuthHandler instance
+func NewAuthHandler(authService AuthServiceInterface, cookie SessionCookie) *AuthHandler {
+	return &AuthHandler{
+		authService: authService,
+		cookie:      cookie,
+	}
+}
+
+// LoginRequest represents the request body for signing in
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// SetupRequest represents the request body for creating the first administrator
+type SetupRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// CreateTokenRequest represents the request body for creating an API token
+type CreateTokenRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// SetPasswordRequest represents the request body for changing a password.
+// CurrentPassword is required when users change their own password.
+type SetPasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	Password        string `json:"password" binding:"required"`
+}
+
+// SetRoleRequest represents the request body for changing a user's role
+type SetRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// Setup handles POST /api/auth/setup - create the first administrator
+// @Summary Créer le premier administrateur
+// @Description Crée le compte administrateur initial ; possible uniquement tant qu'aucun administrateur n'existe
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param account body SetupRequest true "Compte administrateur"
+// @Success 201 {object} map[string]interface{} "Administrateur créé"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 409 {object} Problem "Configuration déjà effectuée"
+// @Router /auth/setup [post]
+func (h *AuthHandler) Setup(c *gin.Context) {
+	var req SetupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	user, err := h.authService.CreateFirstAdmin(c.Request.Context(), req.Name, req.Email, req.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Administrator created successfully",
+		"user":    user,
+	})
+}
+
+// Login handles POST /api/auth/login - sign in and open a session
+// @Summary Se connecter
+// @Description Vérifie les identifiants et ouvre une session (cookie HttpOnly)
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body LoginRequest true "Identifiants"
+// @Success 200 {object} map[string]interface{} "Connecté"
+// @Failure 401 {object} Problem "Identifiants invalides"
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	token, user, err := h.authService.Login(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.cookie.Set(c, token)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged in successfully",
+		"user":    user,
+	})
+}
+
+// Logout handles POST /api/auth/logout - close the current session
+// @Summary Se déconnecter
+// @Description Ferme la session du navigateur
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Déconnecté"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if err := h.authService.Logout(c.Request.Context(), h.cookie.Token(c)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.cookie.Clear(c)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out successfully",
+	})
+}
+
+// Me handles GET /api/auth/me - get the signed-in user
+// @Summary Utilisateur connecté
+// @Description Récupère le compte de l'utilisateur authentifié
+// @Tags auth
+// @Produce json
+// @Success 200 {object} models.User "Utilisateur connecté"
+// @Failure 401 {object} Problem "Non authentifié"
+// @Router /auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	user := h.requireUser(c)
+	if user == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// GetTokens handles GET /api/auth/tokens - list the caller's API tokens
+// @Summary Lister mes jetons d'API
+// @Description Récupère les jetons d'API de l'utilisateur connecté (sans leur valeur)
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Jetons d'API"
+// @Failure 401 {object} Problem "Non authentifié"
+// @Router /auth/tokens [get]
+func (h *AuthHandler) GetTokens(c *gin.Context) {
+	user := h.requireUser(c)
+	if user == nil {
+		return
+	}
+
+	tokens, err := h.authService.ListAPITokens(c.Request.Context(), user.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": tokens,
+		"count":  len(tokens),
+	})
+}
+
+// CreateToken handles POST /api/auth/tokens - issue an API token
+// @Summary Créer un jeton d'API
+// @Description Crée un jeton d'API pour les scripts ; sa valeur n'est affichée qu'une seule fois
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body CreateTokenRequest true "Nom du jeton"
+// @Success 201 {object} map[string]interface{} "Jeton créé"
+// @Failure 400 {object} Problem "Données invalides"
+// @Router /auth/tokens [post]
+func (h *AuthHandler) CreateToken(c *gin.Context) {
+	user := h.requireUser(c)
+	if user == nil {
+		return
+	}
+
+	var req CreateTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	token, apiToken, err := actingAuthService(c, h.authService).CreateAPIToken(c.Request.Context(), user.ID, req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":   "API token created successfully; store it now, it will not be shown again",
+		"token":     token,
+		"api_token": apiToken,
+	})
+}
+
+// RevokeToken handles DELETE /api/auth/tokens/:id - revoke an API token
+// @Summary Révoquer un jeton d'API
+// @Description Supprime un jeton d'API ; les administrateurs peuvent révoquer ceux des autres
+// @Tags auth
+// @Produce json
+// @Param id path int true "ID du jeton"
+// @Success 200 {object} map[string]interface{} "Jeton révoqué"
+// @Failure 404 {object} Problem "Jeton non trouvé"
+// @Router /auth/tokens/{id} [delete]
+func (h *AuthHandler) RevokeToken(c *gin.Context) {
+	user := h.requireUser(c)
+	if user == nil {
+		return
+	}
+
+	tokenID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return
+	}
+
+	if err := actingAuthService(c, h.authService).RevokeAPIToken(c.Request.Context(), user, tokenID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API token revoked successfully",
+	})
+}
+
+// SetPassword handles PUT /api/users/:id/password - change or reset a password
+// @Summary Modifier un mot de passe
+// @Description Change son propre mot de passe (mot de passe actuel requis) ou réinitialise celui d'un utilisateur (administrateur)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de l'utilisateur"
+// @Param password body SetPasswordRequest true "Nouveau mot de passe"
+// @Success 200 {object} map[string]interface{} "Mot de passe modifié"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 401 {object} Problem "Mot de passe actuel incorrect"
+// @Router /users/{id}/password [put]
+func (h *AuthHandler) SetPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return
+	}
+
+	var req SetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	// Users changing their own password must prove they know the current one;
+	// administrators resetting someone else's do not
+	if user := CurrentUser(c); user != nil && user.ID == userID {
+		err = actingAuthService(c, h.authService).ChangePassword(ctx, userID, req.CurrentPassword, req.Password)
+	} else {
+		err = actingAuthService(c, h.authService).SetPassword(ctx, userID, req.Password)
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Password updated successfully",
+	})
+}
+
+// SetRole handles PUT /api/users/:id/role - change a user's role
+// @Summary Modifier le rôle d'un utilisateur
+// @Description Attribue le rôle member, librarian ou admin à un utilisateur
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de l'utilisateur"
+// @Param role body SetRoleRequest true "Nouveau rôle"
+// @Success 200 {object} map[string]interface{} "Rôle modifié"
+// @Failure 400 {object} Problem "Rôle invalide"
+// @Failure 404 {object} Problem "Utilisateur non trouvé"
+// @Failure 409 {object} Problem "Dernier administrateur"
+// @Router /users/{id}/role [put]
+func (h *AuthHandler) SetRole(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return
+	}
+
+	var req SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	user, err := actingAuthService(c, h.authService).SetRole(c.Request.Context(), userID, req.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role updated successfully",
+		"user":    user,
+	})
+}
+
+// requireUser returns the signed-in user or reports an authentication error
+// when there is none
+func (h *AuthHandler) requireUser(c *gin.Context) *models.User {
+	user := CurrentUser(c)
+	if user == nil {
+		c.Error(models.Unauthorized("authentication_required"))
+	}
+	return user
+}
+
+// RegisterRoutes registers all authentication routes
+func (h *AuthHandler) RegisterRoutes(router *gin.RouterGroup) {
+	auth := router.Group("/auth")
+	{
+		auth.POST("/setup", h.Setup)
+		auth.POST("/login", h.Login)
+		auth.POST("/logout", h.Logout)
+		auth.GET("/me", h.Me)
+		auth.GET("/tokens", h.GetTokens)
+		auth.POST("/tokens", h.CreateToken)
+		auth.DELETE("/tokens/:id", h.RevokeToken)
+	}
+
+	users := router.Group("/users")
+	{
+		users.PUT("/:id/password", h.SetPassword)
+		users.PUT("/:id/role", h.SetRole)
+	}
+}
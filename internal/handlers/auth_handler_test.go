@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuthService is a mock implementation of AuthServiceInterface
+type MockAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAuthService) SetupRequired(ctx context.Context) (bool, error) {
+	args := m.Called()
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAuthService) CreateFirstAdmin(ctx context.Context, name, email, password string) (*models.User, error) {
+	args := m.Called(name, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockAuthService) Login(ctx context.Context, email, password string) (string, *models.User, error) {
+	args := m.Called(email, password)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).(*models.User), args.Error(2)
+}
+
+func (m *MockAuthService) Logout(ctx context.Context, token string) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) AuthenticateSession(ctx context.Context, token string) (*models.User, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockAuthService) AuthenticateToken(ctx context.Context, token string) (*models.User, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockAuthService) SetPassword(ctx context.Context, userID int, password string) error {
+	args := m.Called(userID, password)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ChangePassword(ctx context.Context, userID int, currentPassword, newPassword string) error {
+	args := m.Called(userID, currentPassword, newPassword)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) SetRole(ctx context.Context, userID int, role string) (*models.User, error) {
+	args := m.Called(userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockAuthService) CreateAPIToken(ctx context.Context, userID int, name string) (string, *models.APIToken, error) {
+	args := m.Called(userID, name)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).(*models.APIToken), args.Error(2)
+}
+
+func (m *MockAuthService) ListAPITokens(ctx context.Context, userID int) ([]*models.APIToken, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.APIToken), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeAPIToken(ctx context.Context, actor *models.User, tokenID int) error {
+	args := m.Called(actor, tokenID)
+	return args.Error(0)
+}
+
+var testSessionCookie = SessionCookie{TTL: time.Hour}
+
+// setupAuthHandlerTest registers the auth routes behind a middleware that
+// signs in the given user, or nobody when user is nil
+func setupAuthHandlerTest(user *models.User) (*gin.Engine, *MockAuthService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockAuthService)
+	handler := NewAuthHandler(mockService, testSessionCookie)
+
+	router := gin.New()
+	router.Use(Problems())
+	router.Use(func(c *gin.Context) {
+		if user != nil {
+			c.Set(currentUserKey, user)
+		}
+	})
+	api := router.Group("/api")
+	handler.RegisterRoutes(api)
+
+	return router, mockService
+}
+
+func TestAuthHandler_Login(t *testing.T) {
+	t.Run("valid credentials set the session cookie", func(t *testing.T) {
+		router, mockService := setupAuthHandlerTest(nil)
+		user := &models.User{ID: 1, Name: "Alice", Role: models.RoleMember}
+		mockService.On("Login", "alice@example.com", "correct horse").Return("session-token", user, nil)
+
+		body, _ := json.Marshal(LoginRequest{Email: "alice@example.com", Password: "correct horse"})
+		req, _ := http.NewRequest("POST", "/api/auth/login", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		cookie := w.Header().Get("Set-Cookie")
+		assert.Contains(t, cookie, DefaultSessionCookieName+"=session-token")
+		assert.Contains(t, cookie, "HttpOnly")
+		assert.Contains(t, cookie, "SameSite=Lax")
+		assert.NotContains(t, w.Body.String(), "session-token")
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		router, mockService := setupAuthHandlerTest(nil)
+		mockService.On("Login", "alice@example.com", "wrong").Return("", nil, models.Unauthorized("invalid_credentials"))
+
+		body, _ := json.Marshal(LoginRequest{Email: "alice@example.com", Password: "wrong"})
+		req, _ := http.NewRequest("POST", "/api/auth/login", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Empty(t, w.Header().Get("Set-Cookie"))
+	})
+}
+
+func TestAuthHandler_Setup(t *testing.T) {
+	router, mockService := setupAuthHandlerTest(nil)
+	mockService.On("CreateFirstAdmin", "Admin", "admin@example.com", "correct horse").Return(nil, models.Conflict("setup_completed"))
+
+	body, _ := json.Marshal(SetupRequest{Name: "Admin", Email: "admin@example.com", Password: "correct horse"})
+	req, _ := http.NewRequest("POST", "/api/auth/setup", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestAuthHandler_Me(t *testing.T) {
+	t.Run("signed in", func(t *testing.T) {
+		router, _ := setupAuthHandlerTest(&models.User{ID: 3, Name: "Bob", Role: models.RoleLibrarian})
+
+		req, _ := http.NewRequest("GET", "/api/auth/me", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var user models.User
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+		assert.Equal(t, models.RoleLibrarian, user.Role)
+	})
+
+	t.Run("anonymous", func(t *testing.T) {
+		router, _ := setupAuthHandlerTest(nil)
+
+		req, _ := http.NewRequest("GET", "/api/auth/me", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestAuthHandler_CreateToken(t *testing.T) {
+	user := &models.User{ID: 3, Role: models.RoleLibrarian}
+	router, mockService := setupAuthHandlerTest(user)
+	mockService.On("CreateAPIToken", 3, "export").Return("bgl_secret", &models.APIToken{ID: 1, UserID: 3, Name: "export", TokenHash: "hash"}, nil)
+
+	body, _ := json.Marshal(CreateTokenRequest{Name: "export"})
+	req, _ := http.NewRequest("POST", "/api/auth/tokens", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "bgl_secret")
+	assert.NotContains(t, w.Body.String(), "hash")
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_SetPassword(t *testing.T) {
+	t.Run("own password requires the current one", func(t *testing.T) {
+		router, mockService := setupAuthHandlerTest(&models.User{ID: 2, Role: models.RoleMember})
+		mockService.On("ChangePassword", 2, "wrong", "battery staple").Return(models.Unauthorized("current_password_incorrect"))
+
+		body, _ := json.Marshal(SetPasswordRequest{CurrentPassword: "wrong", Password: "battery staple"})
+		req, _ := http.NewRequest("PUT", "/api/users/2/password", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockService.AssertNotCalled(t, "SetPassword", mock.Anything, mock.Anything)
+	})
+
+	t.Run("administrator resets another user's password", func(t *testing.T) {
+		router, mockService := setupAuthHandlerTest(&models.User{ID: 1, Role: models.RoleAdmin})
+		mockService.On("SetPassword", 2, "battery staple").Return(nil)
+
+		body, _ := json.Marshal(SetPasswordRequest{Password: "battery staple"})
+		req, _ := http.NewRequest("PUT", "/api/users/2/password", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestAuthHandler_SetRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"success", nil, http.StatusOK},
+		{"last administrator", models.Conflict("last_administrator"), http.StatusConflict},
+		{"unknown role", models.Invalid("role", "invalid_role", models.ValidRoles), http.StatusBadRequest},
+		{"unknown user", fmt.Errorf("user not found: %w", models.NotFound("user_not_found", 2)), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupAuthHandlerTest(&models.User{ID: 1, Role: models.RoleAdmin})
+			if tt.err != nil {
+				mockService.On("SetRole", 2, models.RoleLibrarian).Return(nil, tt.err)
+			} else {
+				mockService.On("SetRole", 2, models.RoleLibrarian).Return(&models.User{ID: 2, Role: models.RoleLibrarian}, nil)
+			}
+
+			req, _ := http.NewRequest("PUT", "/api/users/2/role", strings.NewReader(`{"role":"librarian"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentUserKey is the gin context key holding the signed-in user
+const currentUserKey = "currentUser"
+
+// AccessRule describes who may call a route
+type AccessRule struct {
+	Public bool   // no sign-in required
+	Role   string // minimum role required, see models.ValidRoles
+	Self   bool   // also allowed when the :id route parameter is the caller's own user ID
+}
+
+// AccessRules maps "METHOD /full/route/:path" to the rule protecting it.
+// Routes without a rule are restricted to administrators.
+type AccessRules map[string]AccessRule
+
+// SessionCookie holds the settings of the web session cookie
+type SessionCookie struct {
+	Name   string
+	TTL    time.Duration
+	Secure bool // only send the cookie over HTTPS
+}
+
+// DefaultSessionCookieName is the name of the web session cookie
+const DefaultSessionCookieName = "bgl_session"
+
+// Set stores a session token in the browser. The cookie is HttpOnly and
+// SameSite=Lax, so other sites cannot submit forms with it.
+func (s SessionCookie) Set(c *gin.Context, token string) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(s.name(), token, int(s.TTL.Seconds()), "/", "", s.Secure, true)
+}
+
+// Clear removes the session token from the browser
+func (s SessionCookie) Clear(c *gin.Context) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(s.name(), "", -1, "/", "", s.Secure, true)
+}
+
+// Token returns the session token sent by the browser, if any
+func (s SessionCookie) Token(c *gin.Context) string {
+	token, err := c.Cookie(s.name())
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+func (s SessionCookie) name() string {
+	if s.Name == "" {
+		return DefaultSessionCookieName
+	}
+	return s.Name
+}
+
+// AuthMiddleware identifies the caller from an API token or a session cookie
+// and enforces the access rules of each route
+type AuthMiddleware struct {
+	authService AuthServiceInterface
+	rules       AccessRules
+	cookie      SessionCookie
+}
+
+// NewAuthMiddleware creates a new AuthMiddleware instance
+func NewAuthMiddleware(authService AuthServiceInterface, rules AccessRules, cookie SessionCookie) *AuthMiddleware {
+	return &AuthMiddleware{
+		authService: authService,
+		rules:       rules,
+		cookie:      cookie,
+	}
+}
+
+// Handler returns the gin middleware. API callers send
+// "Authorization: Bearer <token>"; browsers send the session cookie.
+func (m *AuthMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Unknown routes fall through to the 404 handler
+		if c.FullPath() == "" {
+			c.Next()
+			return
+		}
+
+		rule, ok := m.rules[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			rule = AccessRule{Role: models.RoleAdmin}
+		}
+
+		user, authErr := m.authenticate(c)
+		if user != nil {
+			c.Set(currentUserKey, user)
+		}
+
+		if rule.Public {
+			c.Next()
+			return
+		}
+
+		if user == nil {
+			m.unauthorized(c, authErr)
+			return
+		}
+
+		if !rule.allows(user, c.Param("id")) {
+			m.forbidden(c)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// authenticate returns the caller, or nil and the reason when they are not signed in
+func (m *AuthMiddleware) authenticate(c *gin.Context) (*models.User, error) {
+	ctx := c.Request.Context()
+	if header := c.GetHeader("Authorization"); header != "" {
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found {
+			return nil, errInvalidAuthorization
+		}
+		return m.authService.AuthenticateToken(ctx, strings.TrimSpace(token))
+	}
+
+	token := m.cookie.Token(c)
+	if token == "" {
+		return nil, nil
+	}
+
+	user, err := m.authService.AuthenticateSession(ctx, token)
+	if err != nil {
+		m.cookie.Clear(c)
+	}
+	return user, err
+}
+
+// allows reports whether the rule lets user call a route with the given :id parameter
+func (r AccessRule) allows(user *models.User, idParam string) bool {
+	if user.HasRole(r.Role) {
+		return true
+	}
+	return r.Self && idParam != "" && idParam == strconv.Itoa(user.ID)
+}
+
+// unauthorized answers requests from callers who are not signed in
+func (m *AuthMiddleware) unauthorized(c *gin.Context, err error) {
+	if isAPIRequest(c) {
+		if err == nil {
+			err = models.Unauthorized("authentication_required")
+		}
+		RespondProblem(c, err)
+		return
+	}
+
+	target := "/login"
+	if c.Request.Method == http.MethodGet {
+		target += "?next=" + url.QueryEscape(c.Request.URL.RequestURI())
+	}
+	c.Redirect(http.StatusSeeOther, target)
+	c.Abort()
+}
+
+// forbidden answers signed-in callers whose role does not allow the route
+func (m *AuthMiddleware) forbidden(c *gin.Context) {
+	if isAPIRequest(c) {
+		RespondProblem(c, models.Forbidden("insufficient_role"))
+		return
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(http.StatusForbidden, forbiddenPage)
+	c.Abort()
+}
+
+// CurrentUser returns the signed-in user, or nil when the request is
+// anonymous or authentication is disabled
+func CurrentUser(c *gin.Context) *models.User {
+	value, ok := c.Get(currentUserKey)
+	if !ok {
+		return nil
+	}
+	user, _ := value.(*models.User)
+	return user
+}
+
+// isAPIRequest reports whether the request targets the JSON API
+func isAPIRequest(c *gin.Context) bool {
+	return strings.HasPrefix(c.Request.URL.Path, "/api/")
+}
+
+var errInvalidAuthorization = models.Unauthorized("invalid_authorization_scheme")
+
+const forbiddenPage = `
+<!DOCTYPE html>
+<html lang="fr">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Accès refusé - Bibliothèque de Jeux de Société</title>
+    <script src="https://cdn.tailwindcss.com"></script>
+</head>
+<body class="bg-gray-100">
+    <div class="container mx-auto px-4 py-16">
+        <div class="max-w-md mx-auto bg-white rounded-lg shadow-lg p-8 text-center">
+            <h1 class="text-2xl font-bold text-red-600 mb-4">Accès refusé</h1>
+            <p class="text-gray-600 mb-6">Votre rôle ne permet pas d'accéder à cette page.</p>
+            <a href="/" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
+        </div>
+    </div>
+</body>
+</html>`
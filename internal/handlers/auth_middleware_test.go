@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var testAccessRules = AccessRules{
+	"GET /login":                   {Public: true},
+	"GET /games":                   {Role: models.RoleMember},
+	"GET /api/games":               {Role: models.RoleMember},
+	"POST /api/borrowings":         {Role: models.RoleLibrarian},
+	"GET /api/borrowings/user/:id": {Role: models.RoleLibrarian, Self: true},
+}
+
+func setupAuthMiddlewareTest() (*gin.Engine, *MockAuthService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockAuthService)
+
+	router := gin.New()
+	router.Use(NewAuthMiddleware(mockService, testAccessRules, testSessionCookie).Handler())
+
+	ok := func(c *gin.Context) { c.String(http.StatusOK, "ok") }
+	router.GET("/login", ok)
+	router.GET("/games", ok)
+	router.GET("/api/games", ok)
+	router.POST("/api/borrowings", ok)
+	router.GET("/api/borrowings/user/:id", ok)
+	router.DELETE("/api/games/:id", ok) // no rule: administrators only
+
+	return router, mockService
+}
+
+func TestAuthMiddleware_Anonymous(t *testing.T) {
+	router, _ := setupAuthMiddlewareTest()
+
+	t.Run("public route", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/login", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("API returns 401", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/games", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("web page redirects to sign-in", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/games?page=2", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusSeeOther, w.Code)
+		assert.Equal(t, "/login?next=%2Fgames%3Fpage%3D2", w.Header().Get("Location"))
+	})
+
+	t.Run("unknown route is left to the 404 handler", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/nothing-here", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestAuthMiddleware_Roles(t *testing.T) {
+	member := &models.User{ID: 5, Role: models.RoleMember, IsActive: true}
+	librarian := &models.User{ID: 6, Role: models.RoleLibrarian, IsActive: true}
+	admin := &models.User{ID: 7, Role: models.RoleAdmin, IsActive: true}
+
+	tests := []struct {
+		name           string
+		user           *models.User
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{"member reads catalogue", member, "GET", "/api/games", http.StatusOK},
+		{"member cannot lend", member, "POST", "/api/borrowings", http.StatusForbidden},
+		{"librarian lends", librarian, "POST", "/api/borrowings", http.StatusOK},
+		{"member sees own borrowings", member, "GET", "/api/borrowings/user/5", http.StatusOK},
+		{"member cannot see others' borrowings", member, "GET", "/api/borrowings/user/6", http.StatusForbidden},
+		{"librarian sees anyone's borrowings", librarian, "GET", "/api/borrowings/user/5", http.StatusOK},
+		{"route without rule denied to librarian", librarian, "DELETE", "/api/games/1", http.StatusForbidden},
+		{"route without rule allowed to admin", admin, "DELETE", "/api/games/1", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupAuthMiddlewareTest()
+			mockService.On("AuthenticateToken", "bgl_token").Return(tt.user, nil)
+
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer bgl_token")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthMiddleware_SessionCookie(t *testing.T) {
+	t.Run("valid session", func(t *testing.T) {
+		router, mockService := setupAuthMiddlewareTest()
+		mockService.On("AuthenticateSession", "session-token").Return(&models.User{ID: 5, Role: models.RoleMember}, nil)
+
+		req, _ := http.NewRequest("GET", "/games", nil)
+		req.AddCookie(&http.Cookie{Name: DefaultSessionCookieName, Value: "session-token"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("expired session is cleared", func(t *testing.T) {
+		router, mockService := setupAuthMiddlewareTest()
+		mockService.On("AuthenticateSession", "old-token").Return(nil, models.Unauthorized("session_expired"))
+
+		req, _ := http.NewRequest("GET", "/api/games", nil)
+		req.AddCookie(&http.Cookie{Name: DefaultSessionCookieName, Value: "old-token"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "session expired")
+		assert.Contains(t, w.Header().Get("Set-Cookie"), "Max-Age=0")
+	})
+
+	t.Run("non-bearer authorization header", func(t *testing.T) {
+		router, _ := setupAuthMiddlewareTest()
+
+		req, _ := http.NewRequest("GET", "/api/games", nil)
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
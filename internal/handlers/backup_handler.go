@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DatabaseBackupInterface defines the interface for taking database snapshots
+type DatabaseBackupInterface interface {
+	Backup(path string) error
+}
+
+// BackupHandler handles HTTP requests downloading database snapshots
+type BackupHandler struct {
+	database DatabaseBackupInterface
+	tempDir  string
+}
+
+// NewBackupHandler creates a new BackupHandler instance. Snapshots are
+// written to the system temporary directory while they are sent.
+func NewBackupHandler(database DatabaseBackupInterface) *BackupHandler {
+	return &BackupHandler{
+		database: database,
+		tempDir:  os.TempDir(),
+	}
+}
+
+// DownloadBackup handles GET /api/v1/backup - download a database snapshot
+// @Summary Télécharger une sauvegarde
+// @Description Télécharge une copie cohérente de la base de données SQLite, prise sans interrompre le serveur. Le fichier peut être restauré avec la commande restore.
+// @Tags backup
+// @Produce application/vnd.sqlite3
+// @Success 200 {file} file "Sauvegarde de la base de données"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Failure 503 {object} Problem "Sauvegardes non disponibles avec PostgreSQL"
+// @Router /backup [get]
+func (h *BackupHandler) DownloadBackup(c *gin.Context) {
+	dir, err := os.MkdirTemp(h.tempDir, "board-game-library-backup-")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	name := time.Now().Format(database.BackupFileFormat)
+	path := filepath.Join(dir, name)
+	if err := h.database.Backup(path); err != nil {
+		if errors.Is(err, database.ErrBackupUnsupported) {
+			err = models.Unavailable("backup_unsupported")
+		}
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Type", "application/vnd.sqlite3")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	c.File(path)
+}
+
+// RegisterRoutes registers the backup routes
+func (h *BackupHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/backup", h.DownloadBackup)
+}
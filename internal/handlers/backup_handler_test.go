@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"board-game-library/pkg/database"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDatabaseBackup is a mock implementation of DatabaseBackupInterface
+type MockDatabaseBackup struct {
+	mock.Mock
+}
+
+func (m *MockDatabaseBackup) Backup(path string) error {
+	args := m.Called(path)
+	if data := args.String(0); data != "" {
+		os.WriteFile(path, []byte(data), 0644)
+	}
+	return args.Error(1)
+}
+
+func setupBackupHandlerTest(t *testing.T) (*gin.Engine, *MockDatabaseBackup, string) {
+	gin.SetMode(gin.TestMode)
+	mockDatabase := new(MockDatabaseBackup)
+	handler := NewBackupHandler(mockDatabase)
+	handler.tempDir = t.TempDir()
+
+	router := gin.New()
+	router.Use(Problems())
+	handler.RegisterRoutes(router.Group("/api"))
+
+	return router, mockDatabase, handler.tempDir
+}
+
+func TestBackupHandler_DownloadBackup(t *testing.T) {
+	t.Run("sends the snapshot and removes it", func(t *testing.T) {
+		router, mockDatabase, tempDir := setupBackupHandlerTest(t)
+		mockDatabase.On("Backup", mock.AnythingOfType("string")).Return("SQLite format 3", nil)
+
+		req, _ := http.NewRequest("GET", "/api/backup", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/vnd.sqlite3", w.Header().Get("Content-Type"))
+		assert.Regexp(t, `^attachment; filename="library-\d{8}-\d{6}\.db"$`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "SQLite format 3", w.Body.String())
+
+		entries, _ := os.ReadDir(tempDir)
+		assert.Empty(t, entries, "the snapshot is removed once sent")
+		mockDatabase.AssertExpectations(t)
+	})
+
+	t.Run("backup failure", func(t *testing.T) {
+		router, mockDatabase, _ := setupBackupHandlerTest(t)
+		mockDatabase.On("Backup", mock.AnythingOfType("string")).Return("", errors.New("failed to back up database: disk I/O error"))
+
+		req, _ := http.NewRequest("GET", "/api/backup", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "internal_error")
+		assert.NotContains(t, w.Body.String(), "disk I/O error")
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("PostgreSQL backend", func(t *testing.T) {
+		router, mockDatabase, _ := setupBackupHandlerTest(t)
+		mockDatabase.On("Backup", mock.AnythingOfType("string")).Return("", database.ErrBackupUnsupported)
+
+		req, _ := http.NewRequest("GET", "/api/backup", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), "backup_unsupported")
+	})
+}
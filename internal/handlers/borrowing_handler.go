@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"board-game-library/internal/models"
+	"context"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -11,16 +14,18 @@ import (
 
 // BorrowingServiceInterface defines the interface for borrowing service operations
 type BorrowingServiceInterface interface {
-	BorrowGame(userID, gameID int, dueDate time.Time) (*models.Borrowing, error)
-	BorrowGameWithDefaultDueDate(userID, gameID int) (*models.Borrowing, error)
-	ReturnGame(borrowingID int) error
-	GetOverdueItems() ([]*models.Borrowing, error)
-	ExtendDueDate(borrowingID int, newDueDate time.Time) error
-	GetBorrowingDetails(borrowingID int) (*models.Borrowing, error)
-	GetActiveBorrowingsByUser(userID int) ([]*models.Borrowing, error)
-	GetBorrowingsByGame(gameID int) ([]*models.Borrowing, error)
-	UpdateOverdueStatus() error
-	GetItemsDueSoon(daysAhead int) ([]*models.Borrowing, error)
+	BorrowGame(ctx context.Context, userID, gameID int, dueDate time.Time) (*models.Borrowing, error)
+	BorrowGameWithDefaultDueDate(ctx context.Context, userID, gameID int) (*models.Borrowing, error)
+	BorrowCopy(ctx context.Context, userID, gameID, copyID int, dueDate time.Time) (*models.Borrowing, error)
+	ReturnGame(ctx context.Context, borrowingID int, report *models.ConditionReport) error
+	GetOverdueItems(ctx context.Context) ([]*models.Borrowing, error)
+	ExtendDueDate(ctx context.Context, borrowingID int, newDueDate time.Time) error
+	GetBorrowingDetails(ctx context.Context, borrowingID int) (*models.Borrowing, error)
+	GetActiveBorrowingsByUser(ctx context.Context, userID int) ([]*models.Borrowing, error)
+	GetBorrowingsByGame(ctx context.Context, gameID int) ([]*models.Borrowing, error)
+	UpdateOverdueStatus(ctx context.Context) error
+	GetItemsDueSoon(ctx context.Context, daysAhead int) ([]*models.Borrowing, error)
+	ListBorrowings(ctx context.Context, filter models.BorrowingFilter) ([]*models.Borrowing, int, error)
 }
 
 // BorrowingHandler handles HTTP requests for borrowing workflow
@@ -39,60 +44,49 @@ func NewBorrowingHandler(borrowingService BorrowingServiceInterface) *BorrowingH
 type BorrowGameRequest struct {
 	UserID  int    `json:"user_id" binding:"required"`
 	GameID  int    `json:"game_id" binding:"required"`
+	CopyID  int    `json:"copy_id,omitempty"`  // Optional, lends this specific copy
 	DueDate string `json:"due_date,omitempty"` // Optional, format: "2006-01-02"
 }
 
 // BorrowGame handles POST /api/borrowings - borrow a game
 func (h *BorrowingHandler) BorrowGame(c *gin.Context) {
+	ctx := c.Request.Context()
 	var req BorrowGameRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
-			"details": err.Error(),
-		})
+		c.Error(BindError(err))
 		return
 	}
 
 	var borrowing *models.Borrowing
 	var err error
 
-	if req.DueDate == "" {
-		// Use default due date (14 days)
-		borrowing, err = h.borrowingService.BorrowGameWithDefaultDueDate(req.UserID, req.GameID)
+	if req.CopyID > 0 {
+		// Lend the requested copy, by default for the user's loan duration
+		var dueDate time.Time
+		if req.DueDate != "" {
+			parsed, parseErr := time.Parse("2006-01-02", req.DueDate)
+			if parseErr != nil {
+				c.Error(models.Invalid("due_date", "invalid_date_format", "due_date"))
+				return
+			}
+			dueDate = parsed
+		}
+		borrowing, err = actingBorrowingService(c, h.borrowingService).BorrowCopy(ctx, req.UserID, req.GameID, req.CopyID, dueDate)
+	} else if req.DueDate == "" {
+		// Use the default loan duration of the user's membership tier
+		borrowing, err = actingBorrowingService(c, h.borrowingService).BorrowGameWithDefaultDueDate(ctx, req.UserID, req.GameID)
 	} else {
 		// Parse custom due date
 		dueDate, parseErr := time.Parse("2006-01-02", req.DueDate)
 		if parseErr != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "Invalid due date format",
-				"details": "Due date must be in format YYYY-MM-DD",
-			})
+			c.Error(models.Invalid("due_date", "invalid_date_format", "due_date"))
 			return
 		}
-		borrowing, err = h.borrowingService.BorrowGame(req.UserID, req.GameID, dueDate)
+		borrowing, err = actingBorrowingService(c, h.borrowingService).BorrowGame(ctx, req.UserID, req.GameID, dueDate)
 	}
 
 	if err != nil {
-		if err.Error() == "user not found" || err.Error() == "game not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Resource not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		if err.Error() == "game is not available for borrowing" || 
-		   err.Error() == "user has overdue items and cannot borrow" ||
-		   err.Error() == "user account is inactive" {
-			c.JSON(http.StatusConflict, gin.H{
-				"error":   "Cannot borrow game",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to borrow game",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -102,37 +96,50 @@ func (h *BorrowingHandler) BorrowGame(c *gin.Context) {
 	})
 }
 
+// ReturnGameRequest represents the optional request body for returning a
+// game: the condition it came back in and notes on missing pieces or damage
+type ReturnGameRequest struct {
+	Condition string `json:"condition"`
+	Notes     string `json:"notes"`
+}
+
 // ReturnGame handles PUT /api/borrowings/:id/return - return a borrowed game
+// @Summary Rendre un jeu
+// @Description Enregistre le retour d'un emprunt. L'état du jeu au retour et des remarques sur les pièces manquantes ou les dégâts peuvent être indiqués ; ils sont conservés dans l'historique de l'état du jeu, et une dégradation alerte les bibliothécaires. Un exemplaire en mauvais état ou incomplet est retiré du prêt jusqu'à sa réparation.
+// @Tags borrowings
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de l'emprunt"
+// @Param report body ReturnGameRequest false "État du jeu au retour"
+// @Success 200 {object} map[string]interface{} "Jeu rendu"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 404 {object} Problem "Emprunt non trouvé"
+// @Failure 409 {object} Problem "Jeu déjà rendu"
+// @Router /borrowings/{id}/return [put]
 func (h *BorrowingHandler) ReturnGame(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid borrowing ID",
-			"details": "Borrowing ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	if err := h.borrowingService.ReturnGame(id); err != nil {
-		if err.Error() == "borrowing not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Borrowing not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		if err.Error() == "game has already been returned" {
-			c.JSON(http.StatusConflict, gin.H{
-				"error":   "Game already returned",
-				"details": err.Error(),
-			})
+	// The body is optional: without it the condition of the game is unchanged
+	var req ReturnGameRequest
+	if c.Request.Body != nil && c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			c.Error(BindError(err))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to return game",
-			"details": err.Error(),
-		})
+	}
+
+	var report *models.ConditionReport
+	if req.Condition != "" || req.Notes != "" {
+		report = &models.ConditionReport{Condition: req.Condition, Notes: req.Notes}
+	}
+
+	if err := actingBorrowingService(c, h.borrowingService).ReturnGame(c.Request.Context(), id, report); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -146,26 +153,13 @@ func (h *BorrowingHandler) GetBorrowingDetails(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid borrowing ID",
-			"details": "Borrowing ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	borrowing, err := h.borrowingService.GetBorrowingDetails(id)
+	borrowing, err := h.borrowingService.GetBorrowingDetails(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "borrowing not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Borrowing not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve borrowing details",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -184,53 +178,25 @@ func (h *BorrowingHandler) ExtendDueDate(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid borrowing ID",
-			"details": "Borrowing ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
 	var req ExtendDueDateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
-			"details": err.Error(),
-		})
+		c.Error(BindError(err))
 		return
 	}
 
 	// Parse new due date
 	newDueDate, err := time.Parse("2006-01-02", req.NewDueDate)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid due date format",
-			"details": "Due date must be in format YYYY-MM-DD",
-		})
+		c.Error(models.Invalid("due_date", "invalid_date_format", "due_date"))
 		return
 	}
 
-	if err := h.borrowingService.ExtendDueDate(id, newDueDate); err != nil {
-		if err.Error() == "borrowing not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Borrowing not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		if err.Error() == "cannot extend due date for returned item" ||
-		   err.Error() == "new due date must be after borrowed date" ||
-		   err.Error() == "due date cannot be more than 90 days from borrowed date" {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "Invalid due date extension",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to extend due date",
-			"details": err.Error(),
-		})
+	if err := actingBorrowingService(c, h.borrowingService).ExtendDueDate(c.Request.Context(), id, newDueDate); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -239,14 +205,48 @@ func (h *BorrowingHandler) ExtendDueDate(c *gin.Context) {
 	})
 }
 
+// ListBorrowings handles GET /api/borrowings - list borrowings one page at a time
+// @Summary Lister les emprunts
+// @Description Récupère une page d'emprunts, filtrée et triée, les plus récents en premier par défaut
+// @Tags borrowings
+// @Produce json
+// @Param search query string false "Rechercher dans les noms de l'utilisateur et du jeu et l'email de l'utilisateur"
+// @Param user_id query int false "Filtrer par utilisateur"
+// @Param game_id query int false "Filtrer par jeu"
+// @Param status query string false "Filtrer par statut (active, returned, overdue)"
+// @Param borrowed_from query string false "Empruntés depuis cette date (AAAA-MM-JJ ou RFC 3339)"
+// @Param borrowed_to query string false "Empruntés jusqu'à cette date incluse (AAAA-MM-JJ ou RFC 3339)"
+// @Param due_from query string false "À rendre à partir de cette date (AAAA-MM-JJ ou RFC 3339)"
+// @Param due_to query string false "À rendre jusqu'à cette date incluse (AAAA-MM-JJ ou RFC 3339)"
+// @Param sort query string false "Tri (borrowed_at, due_date, returned_at)" default(borrowed_at)
+// @Param order query string false "Sens du tri (asc, desc)"
+// @Param page query int false "Numéro de page" default(1)
+// @Param per_page query int false "Emprunts par page (100 au maximum)" default(20)
+// @Success 200 {object} map[string]interface{} "Page d'emprunts"
+// @Failure 400 {object} Problem "Paramètres invalides"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /borrowings [get]
+func (h *BorrowingHandler) ListBorrowings(c *gin.Context) {
+	filter, err := BorrowingFilterFromQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	borrowings, total, err := h.borrowingService.ListBorrowings(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, listResponse("borrowings", borrowings, len(borrowings), total, filter.ListOptions))
+}
+
 // GetOverdueItems handles GET /api/borrowings/overdue - get all overdue items
 func (h *BorrowingHandler) GetOverdueItems(c *gin.Context) {
-	overdueItems, err := h.borrowingService.GetOverdueItems()
+	overdueItems, err := h.borrowingService.GetOverdueItems(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve overdue items",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -261,19 +261,13 @@ func (h *BorrowingHandler) GetItemsDueSoon(c *gin.Context) {
 	daysParam := c.DefaultQuery("days", "2") // Default to 2 days
 	days, err := strconv.Atoi(daysParam)
 	if err != nil || days < 0 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid days parameter",
-			"details": "Days must be a non-negative integer",
-		})
+		c.Error(models.Invalid("days", "invalid_non_negative_integer", "days"))
 		return
 	}
 
-	itemsDueSoon, err := h.borrowingService.GetItemsDueSoon(days)
+	itemsDueSoon, err := h.borrowingService.GetItemsDueSoon(c.Request.Context(), days)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve items due soon",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -289,26 +283,13 @@ func (h *BorrowingHandler) GetActiveBorrowingsByUser(c *gin.Context) {
 	idParam := c.Param("id")
 	userID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": "User ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	borrowings, err := h.borrowingService.GetActiveBorrowingsByUser(userID)
+	borrowings, err := h.borrowingService.GetActiveBorrowingsByUser(c.Request.Context(), userID)
 	if err != nil {
-		if err.Error() == "user not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "User not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve user borrowings",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -324,26 +305,13 @@ func (h *BorrowingHandler) GetBorrowingsByGame(c *gin.Context) {
 	idParam := c.Param("id")
 	gameID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid game ID",
-			"details": "Game ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	borrowings, err := h.borrowingService.GetBorrowingsByGame(gameID)
+	borrowings, err := h.borrowingService.GetBorrowingsByGame(c.Request.Context(), gameID)
 	if err != nil {
-		if err.Error() == "game not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Game not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve game borrowings",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -356,11 +324,8 @@ func (h *BorrowingHandler) GetBorrowingsByGame(c *gin.Context) {
 
 // UpdateOverdueStatus handles POST /api/borrowings/update-overdue - update overdue status
 func (h *BorrowingHandler) UpdateOverdueStatus(c *gin.Context) {
-	if err := h.borrowingService.UpdateOverdueStatus(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to update overdue status",
-			"details": err.Error(),
-		})
+	if err := h.borrowingService.UpdateOverdueStatus(c.Request.Context()); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -374,6 +339,7 @@ func (h *BorrowingHandler) RegisterRoutes(router *gin.RouterGroup) {
 	borrowings := router.Group("/borrowings")
 	{
 		borrowings.POST("", h.BorrowGame)
+		borrowings.GET("", h.ListBorrowings)
 		borrowings.GET("/:id", h.GetBorrowingDetails)
 		borrowings.PUT("/:id/return", h.ReturnGame)
 		borrowings.PUT("/:id/extend", h.ExtendDueDate)
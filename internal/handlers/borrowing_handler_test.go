@@ -3,6 +3,7 @@ package handlers
 import (
 	"board-game-library/internal/models"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -20,7 +21,7 @@ type MockBorrowingService struct {
 	mock.Mock
 }
 
-func (m *MockBorrowingService) BorrowGame(userID, gameID int, dueDate time.Time) (*models.Borrowing, error) {
+func (m *MockBorrowingService) BorrowGame(ctx context.Context, userID, gameID int, dueDate time.Time) (*models.Borrowing, error) {
 	args := m.Called(userID, gameID, dueDate)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -28,7 +29,7 @@ func (m *MockBorrowingService) BorrowGame(userID, gameID int, dueDate time.Time)
 	return args.Get(0).(*models.Borrowing), args.Error(1)
 }
 
-func (m *MockBorrowingService) BorrowGameWithDefaultDueDate(userID, gameID int) (*models.Borrowing, error) {
+func (m *MockBorrowingService) BorrowGameWithDefaultDueDate(ctx context.Context, userID, gameID int) (*models.Borrowing, error) {
 	args := m.Called(userID, gameID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -36,12 +37,20 @@ func (m *MockBorrowingService) BorrowGameWithDefaultDueDate(userID, gameID int)
 	return args.Get(0).(*models.Borrowing), args.Error(1)
 }
 
-func (m *MockBorrowingService) ReturnGame(borrowingID int) error {
-	args := m.Called(borrowingID)
+func (m *MockBorrowingService) BorrowCopy(ctx context.Context, userID, gameID, copyID int, dueDate time.Time) (*models.Borrowing, error) {
+	args := m.Called(userID, gameID, copyID, dueDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Borrowing), args.Error(1)
+}
+
+func (m *MockBorrowingService) ReturnGame(ctx context.Context, borrowingID int, report *models.ConditionReport) error {
+	args := m.Called(borrowingID, report)
 	return args.Error(0)
 }
 
-func (m *MockBorrowingService) GetOverdueItems() ([]*models.Borrowing, error) {
+func (m *MockBorrowingService) GetOverdueItems(ctx context.Context) ([]*models.Borrowing, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -49,12 +58,12 @@ func (m *MockBorrowingService) GetOverdueItems() ([]*models.Borrowing, error) {
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockBorrowingService) ExtendDueDate(borrowingID int, newDueDate time.Time) error {
+func (m *MockBorrowingService) ExtendDueDate(ctx context.Context, borrowingID int, newDueDate time.Time) error {
 	args := m.Called(borrowingID, newDueDate)
 	return args.Error(0)
 }
 
-func (m *MockBorrowingService) GetBorrowingDetails(borrowingID int) (*models.Borrowing, error) {
+func (m *MockBorrowingService) GetBorrowingDetails(ctx context.Context, borrowingID int) (*models.Borrowing, error) {
 	args := m.Called(borrowingID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -62,7 +71,7 @@ func (m *MockBorrowingService) GetBorrowingDetails(borrowingID int) (*models.Bor
 	return args.Get(0).(*models.Borrowing), args.Error(1)
 }
 
-func (m *MockBorrowingService) GetActiveBorrowingsByUser(userID int) ([]*models.Borrowing, error) {
+func (m *MockBorrowingService) GetActiveBorrowingsByUser(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -70,7 +79,7 @@ func (m *MockBorrowingService) GetActiveBorrowingsByUser(userID int) ([]*models.
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockBorrowingService) GetBorrowingsByGame(gameID int) ([]*models.Borrowing, error) {
+func (m *MockBorrowingService) GetBorrowingsByGame(ctx context.Context, gameID int) ([]*models.Borrowing, error) {
 	args := m.Called(gameID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -78,12 +87,12 @@ func (m *MockBorrowingService) GetBorrowingsByGame(gameID int) ([]*models.Borrow
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockBorrowingService) UpdateOverdueStatus() error {
+func (m *MockBorrowingService) UpdateOverdueStatus(ctx context.Context) error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m *MockBorrowingService) GetItemsDueSoon(daysAhead int) ([]*models.Borrowing, error) {
+func (m *MockBorrowingService) GetItemsDueSoon(ctx context.Context, daysAhead int) ([]*models.Borrowing, error) {
 	args := m.Called(daysAhead)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -91,6 +100,14 @@ func (m *MockBorrowingService) GetItemsDueSoon(daysAhead int) ([]*models.Borrowi
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
+func (m *MockBorrowingService) ListBorrowings(ctx context.Context, filter models.BorrowingFilter) ([]*models.Borrowing, int, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.Borrowing), args.Int(1), args.Error(2)
+}
+
 func setupBorrowingHandlerTest() (*gin.Engine, *MockBorrowingService, *BorrowingHandler) {
 	gin.SetMode(gin.TestMode)
 	
@@ -100,6 +117,7 @@ func setupBorrowingHandlerTest() (*gin.Engine, *MockBorrowingService, *Borrowing
 	}
 	
 	router := gin.New()
+	router.Use(Problems())
 	api := router.Group("/api")
 	handler.RegisterRoutes(api)
 	
@@ -193,7 +211,7 @@ func TestBorrowingHandler_BorrowGame(t *testing.T) {
 
 	t.Run("game not available", func(t *testing.T) {
 		router, mockService, _ := setupBorrowingHandlerTest()
-		mockService.On("BorrowGameWithDefaultDueDate", 1, 1).Return(nil, fmt.Errorf("game is not available for borrowing"))
+		mockService.On("BorrowGameWithDefaultDueDate", 1, 1).Return(nil, models.Conflict("game_not_available"))
 
 		reqBody := BorrowGameRequest{
 			UserID: 1,
@@ -213,7 +231,7 @@ func TestBorrowingHandler_BorrowGame(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mockService.On("BorrowGameWithDefaultDueDate", 999, 1).Return(nil, fmt.Errorf("user not found"))
+		mockService.On("BorrowGameWithDefaultDueDate", 999, 1).Return(nil, models.NotFound("user_not_found", 1))
 
 		reqBody := BorrowGameRequest{
 			UserID: 999,
@@ -231,13 +249,96 @@ func TestBorrowingHandler_BorrowGame(t *testing.T) {
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("loan limit reached", func(t *testing.T) {
+		router, mockService, _ := setupBorrowingHandlerTest()
+		mockService.On("BorrowGameWithDefaultDueDate", 1, 1).Return(nil, models.PolicyViolation(models.PolicyRuleMaxLoans, 2, "basic"))
+
+		jsonBody, _ := json.Marshal(BorrowGameRequest{UserID: 1, GameID: 1})
+		req, _ := http.NewRequest("POST", "/api/borrowings", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("due date beyond the tier limit", func(t *testing.T) {
+		router, mockService, _ := setupBorrowingHandlerTest()
+		mockService.On("BorrowGame", 1, 1, mock.AnythingOfType("time.Time")).Return(nil, models.Invalid("due_date", models.PolicyRuleMaxLoanDays, 30))
+
+		jsonBody, _ := json.Marshal(BorrowGameRequest{UserID: 1, GameID: 1, DueDate: "2099-01-01"})
+		req, _ := http.NewRequest("POST", "/api/borrowings", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestBorrowingHandler_BorrowCopy(t *testing.T) {
+	t.Run("successful borrowing of a specific copy", func(t *testing.T) {
+		router, mockService, _ := setupBorrowingHandlerTest()
+		copyID := 3
+		expectedBorrowing := &models.Borrowing{ID: 1, UserID: 1, GameID: 1, CopyID: &copyID}
+		mockService.On("BorrowCopy", 1, 1, 3, mock.MatchedBy(func(t time.Time) bool {
+			return t.Format("2006-01-02") == "2030-01-15"
+		})).Return(expectedBorrowing, nil)
+
+		jsonBody, _ := json.Marshal(BorrowGameRequest{UserID: 1, GameID: 1, CopyID: 3, DueDate: "2030-01-15"})
+		req, _ := http.NewRequest("POST", "/api/borrowings", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("copy already borrowed", func(t *testing.T) {
+		router, mockService, _ := setupBorrowingHandlerTest()
+		// Without a due date the service applies the user's default loan duration
+		mockService.On("BorrowCopy", 1, 1, 3, mock.MatchedBy(func(t time.Time) bool {
+			return t.IsZero()
+		})).Return(nil, models.Conflict("copy_not_available"))
+
+		jsonBody, _ := json.Marshal(BorrowGameRequest{UserID: 1, GameID: 1, CopyID: 3})
+		req, _ := http.NewRequest("POST", "/api/borrowings", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("copy of another game", func(t *testing.T) {
+		router, mockService, _ := setupBorrowingHandlerTest()
+		mockService.On("BorrowCopy", 1, 1, 3, mock.AnythingOfType("time.Time")).Return(nil, models.NotFound("copy_not_in_game", 3, 1))
+
+		jsonBody, _ := json.Marshal(BorrowGameRequest{UserID: 1, GameID: 1, CopyID: 3})
+		req, _ := http.NewRequest("POST", "/api/borrowings", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
 }
 
 func TestBorrowingHandler_ReturnGame(t *testing.T) {
 	router, mockService, _ := setupBorrowingHandlerTest()
 
 	t.Run("successful return", func(t *testing.T) {
-		mockService.On("ReturnGame", 1).Return(nil)
+		mockService.On("ReturnGame", 1, (*models.ConditionReport)(nil)).Return(nil)
 
 		req, _ := http.NewRequest("PUT", "/api/borrowings/1/return", nil)
 		w := httptest.NewRecorder()
@@ -254,6 +355,40 @@ func TestBorrowingHandler_ReturnGame(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("return with condition report", func(t *testing.T) {
+		router, mockService, _ := setupBorrowingHandlerTest()
+		report := &models.ConditionReport{Condition: "missing_pieces", Notes: "two meeples missing"}
+		mockService.On("ReturnGame", 1, report).Return(nil)
+
+		body, _ := json.Marshal(ReturnGameRequest{Condition: "missing_pieces", Notes: "two meeples missing"})
+		req, _ := http.NewRequest("PUT", "/api/borrowings/1/return", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid returned condition", func(t *testing.T) {
+		router, mockService, _ := setupBorrowingHandlerTest()
+		report := &models.ConditionReport{Condition: "broken"}
+		mockService.On("ReturnGame", 1, report).Return(fmt.Errorf("validation failed: %w", models.Invalid("condition", "invalid_game_condition", models.ValidConditions)))
+
+		req, _ := http.NewRequest("PUT", "/api/borrowings/1/return", bytes.NewBufferString(`{"condition": "broken"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "invalid_game_condition", response["code"])
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("invalid borrowing ID", func(t *testing.T) {
 		req, _ := http.NewRequest("PUT", "/api/borrowings/invalid/return", nil)
 		w := httptest.NewRecorder()
@@ -264,7 +399,7 @@ func TestBorrowingHandler_ReturnGame(t *testing.T) {
 	})
 
 	t.Run("borrowing not found", func(t *testing.T) {
-		mockService.On("ReturnGame", 999).Return(fmt.Errorf("borrowing not found"))
+		mockService.On("ReturnGame", 999, (*models.ConditionReport)(nil)).Return(models.NotFound("borrowing_not_found", 1))
 
 		req, _ := http.NewRequest("PUT", "/api/borrowings/999/return", nil)
 		w := httptest.NewRecorder()
@@ -278,7 +413,7 @@ func TestBorrowingHandler_ReturnGame(t *testing.T) {
 
 	t.Run("already returned", func(t *testing.T) {
 		router, mockService, _ := setupBorrowingHandlerTest()
-		mockService.On("ReturnGame", 1).Return(fmt.Errorf("game has already been returned"))
+		mockService.On("ReturnGame", 1, (*models.ConditionReport)(nil)).Return(models.Conflict("already_returned"))
 
 		req, _ := http.NewRequest("PUT", "/api/borrowings/1/return", nil)
 		w := httptest.NewRecorder()
@@ -321,7 +456,7 @@ func TestBorrowingHandler_GetBorrowingDetails(t *testing.T) {
 	})
 
 	t.Run("borrowing not found", func(t *testing.T) {
-		mockService.On("GetBorrowingDetails", 999).Return(nil, fmt.Errorf("borrowing not found"))
+		mockService.On("GetBorrowingDetails", 999).Return(nil, models.NotFound("borrowing_not_found", 1))
 
 		req, _ := http.NewRequest("GET", "/api/borrowings/999", nil)
 		w := httptest.NewRecorder()
@@ -381,7 +516,7 @@ func TestBorrowingHandler_ExtendDueDate(t *testing.T) {
 
 	t.Run("borrowing not found", func(t *testing.T) {
 		newDueDate := time.Now().Add(21 * 24 * time.Hour)
-		mockService.On("ExtendDueDate", 999, mock.AnythingOfType("time.Time")).Return(fmt.Errorf("borrowing not found"))
+		mockService.On("ExtendDueDate", 999, mock.AnythingOfType("time.Time")).Return(models.NotFound("borrowing_not_found", 1))
 
 		reqBody := ExtendDueDateRequest{
 			NewDueDate: newDueDate.Format("2006-01-02"),
@@ -398,6 +533,77 @@ func TestBorrowingHandler_ExtendDueDate(t *testing.T) {
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("extension limit reached", func(t *testing.T) {
+		mockService.On("ExtendDueDate", 2, mock.AnythingOfType("time.Time")).Return(models.PolicyViolation(models.PolicyRuleMaxExtensions, 1, "basic"))
+
+		jsonBody, _ := json.Marshal(ExtendDueDateRequest{NewDueDate: time.Now().Add(21 * 24 * time.Hour).Format("2006-01-02")})
+		req, _ := http.NewRequest("PUT", "/api/borrowings/2/extend", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestBorrowingHandler_ListBorrowings(t *testing.T) {
+	t.Run("filtered page", func(t *testing.T) {
+		router, mockService, _ := setupBorrowingHandlerTest()
+		dueFrom := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+		dueTo := time.Date(2024, 4, 1, 0, 0, 0, 0, time.Local)
+		filter := models.BorrowingFilter{
+			UserID:      4,
+			Status:      models.BorrowingStatusActive,
+			DueFrom:     &dueFrom,
+			DueTo:       &dueTo,
+			ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage, Sort: "due_date"},
+		}
+		expected := []*models.Borrowing{{ID: 1, UserID: 4, GameID: 2}}
+		mockService.On("ListBorrowings", filter).Return(expected, 1, nil)
+
+		req, _ := http.NewRequest("GET", "/api/borrowings?user_id=4&status=active&due_from=2024-03-01&due_to=2024-03-31&sort=due_date", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(1), response["count"])
+		assert.Equal(t, float64(1), response["total"])
+		assert.NotNil(t, response["borrowings"])
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		router, mockService, _ := setupBorrowingHandlerTest()
+
+		req, _ := http.NewRequest("GET", "/api/borrowings?borrowed_from=yesterday", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListBorrowings", mock.Anything)
+	})
+
+	t.Run("status rejected by the service", func(t *testing.T) {
+		router, mockService, _ := setupBorrowingHandlerTest()
+		mockService.On("ListBorrowings", mock.Anything).Return(nil, 0, fmt.Errorf("invalid borrowing filter: %w", models.Invalid("status", "invalid_borrowing_status", models.ValidBorrowingStatuses)))
+
+		req, _ := http.NewRequest("GET", "/api/borrowings?status=lost", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestBorrowingHandler_GetOverdueItems(t *testing.T) {
@@ -528,7 +734,7 @@ func TestBorrowingHandler_GetActiveBorrowingsByUser(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mockService.On("GetActiveBorrowingsByUser", 999).Return(nil, fmt.Errorf("user not found"))
+		mockService.On("GetActiveBorrowingsByUser", 999).Return(nil, models.NotFound("user_not_found", 1))
 
 		req, _ := http.NewRequest("GET", "/api/borrowings/user/999", nil)
 		w := httptest.NewRecorder()
@@ -569,7 +775,7 @@ func TestBorrowingHandler_GetBorrowingsByGame(t *testing.T) {
 	})
 
 	t.Run("game not found", func(t *testing.T) {
-		mockService.On("GetBorrowingsByGame", 999).Return(nil, fmt.Errorf("game not found"))
+		mockService.On("GetBorrowingsByGame", 999).Return(nil, models.NotFound("game_not_found", 1))
 
 		req, _ := http.NewRequest("GET", "/api/borrowings/game/999", nil)
 		w := httptest.NewRecorder()
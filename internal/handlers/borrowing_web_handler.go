@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"board-game-library/internal/models"
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -25,259 +28,449 @@ func NewBorrowingWebHandler(borrowingService BorrowingServiceInterface, userServ
 	}
 }
 
-// ShowNewBorrowingForm handles GET /borrowings/new - display new borrowing form modal
-func (h *BorrowingWebHandler) ShowNewBorrowingForm(c *gin.Context) {
-	gameIDParam := c.Query("game_id")
-	
-	// Get available games
-	availableGames, err := h.gameService.GetAvailableGames()
+// BorrowingStats counts the borrowings of the library by status
+type BorrowingStats struct {
+	Active   int
+	Overdue  int
+	DueSoon  int
+	Returned int
+}
+
+// borrowingStatusDueSoon selects the games out and due back within
+// webDueSoonDays; the other statuses are those of models.BorrowingFilter
+const borrowingStatusDueSoon = "due_soon"
+
+// borrowingListFilter reads the list controls of the borrowings page
+func borrowingListFilter(c *gin.Context, now time.Time) models.BorrowingFilter {
+	filter := models.BorrowingFilter{
+		Search:      webValue(c, "search"),
+		Status:      webValue(c, "status"),
+		ListOptions: webListOptions(c),
+	}
+
+	switch filter.Status {
+	case "all":
+		filter.Status = ""
+	case borrowingStatusDueSoon:
+		dueTo := now.AddDate(0, 0, webDueSoonDays)
+		filter.Status = models.BorrowingStatusActive
+		filter.DueFrom = &now
+		filter.DueTo = &dueTo
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var from time.Time
+	switch webValue(c, "date_range") {
+	case "today":
+		from = today
+	case "week":
+		from = today.AddDate(0, 0, -7)
+	case "month":
+		from = today.AddDate(0, -1, 0)
+	case "quarter":
+		from = today.AddDate(0, -3, 0)
+	}
+	if !from.IsZero() {
+		filter.BorrowedFrom = &from
+	}
+
+	return filter
+}
+
+// borrowingListData loads the page of borrowings selected by the list controls
+func (h *BorrowingWebHandler) borrowingListData(c *gin.Context) (gin.H, error) {
+	ctx := c.Request.Context()
+	filter := borrowingListFilter(c, time.Now())
+	borrowings, total, err := h.borrowingService.ListBorrowings(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return gin.H{
+		"Title":      "Borrowings",
+		"Borrowings": newWebNames(h.userService, h.gameService).borrowings(ctx, borrowings),
+		"Total":      total,
+		"Search":     webValue(c, "search"),
+		"Status":     webValue(c, "status"),
+		"DateRange":  webValue(c, "date_range"),
+		"Sort":       filter.Sort,
+		"Order":      filter.Order,
+		"Pagination": newWebPagination(filter.ListOptions, total, "/borrowings/filter", "#borrowings-table", "#search, #status, #date_range, #sort, #order"),
+	}, nil
+}
+
+// borrowingStats counts the borrowings of each status
+func (h *BorrowingWebHandler) borrowingStats(ctx context.Context, now time.Time) (BorrowingStats, error) {
+	var stats BorrowingStats
+	dueTo := now.AddDate(0, 0, webDueSoonDays)
+	counts := []struct {
+		filter models.BorrowingFilter
+		count  *int
+	}{
+		{models.BorrowingFilter{Status: models.BorrowingStatusActive}, &stats.Active},
+		{models.BorrowingFilter{Status: models.BorrowingStatusOverdue}, &stats.Overdue},
+		{models.BorrowingFilter{Status: models.BorrowingStatusActive, DueFrom: &now, DueTo: &dueTo}, &stats.DueSoon},
+		{models.BorrowingFilter{Status: models.BorrowingStatusReturned}, &stats.Returned},
+	}
+	for _, count := range counts {
+		count.filter.PerPage = 1
+		_, total, err := h.borrowingService.ListBorrowings(ctx, count.filter)
+		if err != nil {
+			return stats, err
+		}
+		*count.count = total
+	}
+	return stats, nil
+}
+
+// ListBorrowings handles GET /borrowings - display borrowings list page
+func (h *BorrowingWebHandler) ListBorrowings(c *gin.Context) {
+	data, err := h.borrowingListData(c)
+	if err != nil {
+		webListError(c, err, "Failed to load borrowings")
+		return
+	}
+
+	stats, err := h.borrowingStats(c.Request.Context(), time.Now())
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to load available games: " + err.Error(),
-		})
+		renderWebError(c, http.StatusInternalServerError, "Failed to count borrowings: "+err.Error())
 		return
 	}
+	data["Stats"] = stats
+
+	renderWeb(c, http.StatusOK, "borrowings/list.html", data)
+}
 
-	// Get eligible users (active users without overdue items)
-	allUsers, err := h.userService.GetAllUsers()
+// FilterBorrowings handles POST /borrowings/search, /borrowings/filter and
+// /borrowings/sort - HTMX search, filter and sort of the borrowings table
+func (h *BorrowingWebHandler) FilterBorrowings(c *gin.Context) {
+	data, err := h.borrowingListData(c)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to load users: " + err.Error(),
-		})
+		webListError(c, err, "Failed to load borrowings")
 		return
 	}
 
+	renderWeb(c, http.StatusOK, "borrowings/partials/borrowings-table.html", data)
+}
+
+// newBorrowingFormData loads the games that can be lent and the users who
+// can borrow them; GameID and UserID select the options picked before
+func (h *BorrowingWebHandler) newBorrowingFormData(ctx context.Context) (gin.H, error) {
+	availableGames, err := h.gameService.GetAvailableGames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load available games: %w", err)
+	}
+
+	allUsers, err := h.userService.GetAllUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load users: %w", err)
+	}
 	eligibleUsers := make([]*models.User, 0)
 	for _, user := range allUsers {
-		if canBorrow, _ := h.userService.CanUserBorrow(user.ID); canBorrow {
+		if canBorrow, _ := h.userService.CanUserBorrow(ctx, user.ID); canBorrow {
 			eligibleUsers = append(eligibleUsers, user)
 		}
 	}
 
-	templateData := gin.H{
+	now := time.Now()
+	return gin.H{
+		"Title":          "Borrow Game",
 		"AvailableGames": availableGames,
 		"EligibleUsers":  eligibleUsers,
-		"DefaultDueDate": time.Now().AddDate(0, 0, 14).Format("2006-01-02"),
-		"MinDate":        time.Now().Format("2006-01-02"),
-		"MaxDate":        time.Now().AddDate(0, 0, 90).Format("2006-01-02"),
+		"DefaultDueDate": now.AddDate(0, 0, 14).Format(webDateFormat),
+		"MinDate":        now.AddDate(0, 0, 1).Format(webDateFormat),
+		"MaxDate":        now.AddDate(0, 0, models.MaxLoanDays).Format(webDateFormat),
+		"GameID":         0,
+		"UserID":         0,
+	}, nil
+}
+
+// ShowNewBorrowingForm handles GET /borrowings/new - display new borrowing form modal
+func (h *BorrowingWebHandler) ShowNewBorrowingForm(c *gin.Context) {
+	ctx := c.Request.Context()
+	data, err := h.newBorrowingFormData(ctx)
+	if err != nil {
+		renderWebError(c, http.StatusInternalServerError, err.Error())
+		return
 	}
 
 	// If game_id is provided, pre-select the game
-	if gameIDParam != "" {
-		if gameID, err := strconv.Atoi(gameIDParam); err == nil {
-			if game, err := h.gameService.GetGame(gameID); err == nil && game.IsAvailable {
-				templateData["Game"] = game
-			}
+	if gameID, err := strconv.Atoi(c.Query("game_id")); err == nil {
+		if game, err := h.gameService.GetGame(ctx, gameID); err == nil && game.IsAvailable {
+			data["Game"] = game
 		}
 	}
+	if userID, err := strconv.Atoi(c.Query("user_id")); err == nil {
+		data["UserID"] = userID
+	}
 
-	c.HTML(http.StatusOK, "borrowings/new.html", templateData)
+	renderWeb(c, http.StatusOK, "borrowings/new.html", data)
 }
 
 // CreateBorrowing handles POST /borrowings - create new borrowing with HTMX response
 func (h *BorrowingWebHandler) CreateBorrowing(c *gin.Context) {
-	userIDStr := c.PostForm("user_id")
-	gameIDStr := c.PostForm("game_id")
+	ctx := c.Request.Context()
+	userID, userErr := strconv.Atoi(c.PostForm("user_id"))
+	gameID, gameErr := strconv.Atoi(c.PostForm("game_id"))
 	dueDateStr := c.PostForm("due_date")
 
-	userID, err := strconv.Atoi(userIDStr)
+	var borrowing *models.Borrowing
+	var err error
+	switch {
+	case userErr != nil:
+		err = models.Invalid("user_id", "invalid_integer", "user_id")
+	case gameErr != nil:
+		err = models.Invalid("game_id", "invalid_integer", "game_id")
+	case dueDateStr == "":
+		// Use default due date
+		borrowing, err = actingBorrowingService(c, h.borrowingService).BorrowGameWithDefaultDueDate(ctx, userID, gameID)
+	default:
+		dueDate, parseErr := time.Parse(webDateFormat, dueDateStr)
+		if parseErr != nil {
+			err = models.Invalid("due_date", "invalid_date_format", "due_date")
+			break
+		}
+		borrowing, err = actingBorrowingService(c, h.borrowingService).BorrowGame(ctx, userID, gameID, dueDate)
+	}
+
+	if err != nil {
+		data, loadErr := h.newBorrowingFormData(ctx)
+		if loadErr != nil {
+			renderWebError(c, http.StatusInternalServerError, loadErr.Error())
+			return
+		}
+		data["GameID"] = gameID
+		data["UserID"] = userID
+		if dueDateStr != "" {
+			data["DefaultDueDate"] = dueDateStr
+		}
+		data["ErrorMessage"] = "Failed to borrow game: " + err.Error()
+		renderWeb(c, ErrorStatus(err), "borrowings/new.html", data)
+		return
+	}
+
+	// Return success message with updated game status
+	c.Header("HX-Trigger", "borrowing-created, refresh-games, refresh-dashboard")
+	renderWeb(c, http.StatusOK, "borrowings/success.html", gin.H{
+		"Title":     "Game Borrowed",
+		"Message":   "Game borrowed successfully!",
+		"Borrowing": newWebNames(h.userService, h.gameService).borrowing(ctx, borrowing),
+	})
+}
+
+// ShowUserInfo handles GET /borrowings/user-info - HTMX summary of the borrower picked on the form
+func (h *BorrowingWebHandler) ShowUserInfo(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, err := strconv.Atoi(c.Query("user_id"))
 	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid user ID",
-		})
+		c.Status(http.StatusOK)
 		return
 	}
 
-	gameID, err := strconv.Atoi(gameIDStr)
+	user, err := h.userService.GetUser(ctx, userID)
 	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid game ID",
-		})
+		renderWebError(c, http.StatusNotFound, "User not found")
+		return
+	}
+	eligibility, err := h.userService.CheckEligibility(ctx, userID)
+	if err != nil {
+		renderWebError(c, ErrorStatus(err), "Failed to check eligibility: "+err.Error())
 		return
 	}
 
-	var borrowing *models.Borrowing
-	if dueDateStr == "" {
-		// Use default due date
-		borrowing, err = h.borrowingService.BorrowGameWithDefaultDueDate(userID, gameID)
-	} else {
-		dueDate, parseErr := time.Parse("2006-01-02", dueDateStr)
-		if parseErr != nil {
-			c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-				"ErrorMessage": "Invalid due date format",
-			})
-			return
-		}
-		borrowing, err = h.borrowingService.BorrowGame(userID, gameID, dueDate)
+	renderWeb(c, http.StatusOK, "borrowings/partials/user-info.html", gin.H{
+		"User":        user,
+		"Eligibility": eligibility,
+	})
+}
+
+// ShowGameInfo handles GET /borrowings/game-info - HTMX summary of the game picked on the form
+func (h *BorrowingWebHandler) ShowGameInfo(c *gin.Context) {
+	gameID, err := strconv.Atoi(c.Query("game_id"))
+	if err != nil {
+		c.Status(http.StatusOK)
+		return
 	}
 
+	game, err := h.gameService.GetGame(c.Request.Context(), gameID)
 	if err != nil {
-		c.HTML(http.StatusConflict, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to borrow game: " + err.Error(),
-		})
+		renderWebError(c, http.StatusNotFound, "Game not found")
 		return
 	}
 
-	// Return success message with updated game status
-	c.Header("HX-Trigger", "borrowing-created, refresh-games, refresh-dashboard")
-	c.HTML(http.StatusOK, "borrowings/success.html", gin.H{
-		"Message":   "Game borrowed successfully!",
-		"Borrowing": borrowing,
+	renderWeb(c, http.StatusOK, "borrowings/partials/game-info.html", gin.H{
+		"Game": game,
 	})
 }
 
 // ReturnGame handles POST /borrowings/:id/return - return a borrowed game with HTMX response
 func (h *BorrowingWebHandler) ReturnGame(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid borrowing ID",
-		})
+	ctx := c.Request.Context()
+	id, ok := webID(c, "borrowing")
+	if !ok {
 		return
 	}
 
-	// Get borrowing details before returning
-	borrowing, err := h.borrowingService.GetBorrowingDetails(id)
-	if err != nil {
-		c.HTML(http.StatusNotFound, "partials/error.html", gin.H{
-			"ErrorMessage": "Borrowing not found",
-		})
+	// The return form may report the condition the game came back in
+	var report *models.ConditionReport
+	condition := strings.TrimSpace(c.PostForm("condition"))
+	notes := strings.TrimSpace(c.PostForm("notes"))
+	if condition != "" || notes != "" {
+		report = &models.ConditionReport{Condition: condition, Notes: notes}
+	}
+
+	if err := actingBorrowingService(c, h.borrowingService).ReturnGame(ctx, id, report); err != nil {
+		renderWebError(c, ErrorStatus(err), "Failed to return game: "+err.Error())
 		return
 	}
 
-	if err := h.borrowingService.ReturnGame(id); err != nil {
-		c.HTML(http.StatusConflict, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to return game: " + err.Error(),
-		})
+	borrowing, err := h.borrowingService.GetBorrowingDetails(ctx, id)
+	if err != nil {
+		renderWebError(c, http.StatusInternalServerError, "Failed to load borrowing: "+err.Error())
 		return
 	}
 
 	// Trigger multiple updates
 	c.Header("HX-Trigger", "game-returned, refresh-games, refresh-dashboard, refresh-alerts")
-	c.HTML(http.StatusOK, "borrowings/return-success.html", gin.H{
+	renderWeb(c, http.StatusOK, "borrowings/return-success.html", gin.H{
+		"Title":     "Game Returned",
 		"Message":   "Game returned successfully!",
-		"Borrowing": borrowing,
+		"Borrowing": newWebNames(h.userService, h.gameService).borrowing(ctx, borrowing),
 	})
 }
 
+// extendFormData is the data of the form extending the due date of borrowing
+func (h *BorrowingWebHandler) extendFormData(ctx context.Context, borrowing *models.Borrowing) gin.H {
+	suggested := borrowing.DueDate.AddDate(0, 0, 14)
+	latest := borrowing.BorrowedAt.AddDate(0, 0, models.MaxLoanDays)
+	if suggested.After(latest) {
+		suggested = latest
+	}
+
+	return gin.H{
+		"Title":            "Extend Due Date",
+		"Borrowing":        newWebNames(h.userService, h.gameService).borrowing(ctx, borrowing),
+		"MinDate":          time.Now().AddDate(0, 0, 1).Format(webDateFormat),
+		"MaxDate":          latest.Format(webDateFormat),
+		"SuggestedDueDate": suggested.Format(webDateFormat),
+	}
+}
+
 // ShowExtendForm handles GET /borrowings/:id/extend - display extend due date form
 func (h *BorrowingWebHandler) ShowExtendForm(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid borrowing ID",
-		})
+	ctx := c.Request.Context()
+	id, ok := webID(c, "borrowing")
+	if !ok {
 		return
 	}
 
-	borrowing, err := h.borrowingService.GetBorrowingDetails(id)
+	borrowing, err := h.borrowingService.GetBorrowingDetails(ctx, id)
 	if err != nil {
-		c.HTML(http.StatusNotFound, "partials/error.html", gin.H{
-			"ErrorMessage": "Borrowing not found",
-		})
+		renderWebError(c, http.StatusNotFound, "Borrowing not found")
+		return
+	}
+	if borrowing.ReturnedAt != nil {
+		renderWebError(c, http.StatusConflict, "This game has already been returned")
 		return
 	}
 
-	c.HTML(http.StatusOK, "borrowings/extend.html", gin.H{
-		"Borrowing":    borrowing,
-		"MinDate":      time.Now().Format("2006-01-02"),
-		"MaxDate":      borrowing.BorrowedAt.AddDate(0, 0, 90).Format("2006-01-02"),
-		"SuggestedDate": borrowing.DueDate.AddDate(0, 0, 14).Format("2006-01-02"),
-	})
+	renderWeb(c, http.StatusOK, "borrowings/extend.html", h.extendFormData(ctx, borrowing))
 }
 
 // ExtendDueDate handles POST /borrowings/:id/extend - extend due date with HTMX response
 func (h *BorrowingWebHandler) ExtendDueDate(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid borrowing ID",
-		})
+	ctx := c.Request.Context()
+	id, ok := webID(c, "borrowing")
+	if !ok {
 		return
 	}
 
-	newDueDateStr := c.PostForm("new_due_date")
-	newDueDate, err := time.Parse("2006-01-02", newDueDateStr)
+	borrowing, err := h.borrowingService.GetBorrowingDetails(ctx, id)
 	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid due date format",
-		})
+		renderWebError(c, http.StatusNotFound, "Borrowing not found")
 		return
 	}
 
-	if err := h.borrowingService.ExtendDueDate(id, newDueDate); err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to extend due date: " + err.Error(),
-		})
+	newDueDate, err := time.Parse(webDateFormat, c.PostForm("new_due_date"))
+	if err != nil {
+		err = models.Invalid("new_due_date", "invalid_date_format", "new_due_date")
+	} else {
+		err = actingBorrowingService(c, h.borrowingService).ExtendDueDate(ctx, id, newDueDate)
+	}
+	if err != nil {
+		data := h.extendFormData(ctx, borrowing)
+		data["ErrorMessage"] = "Failed to extend due date: " + err.Error()
+		renderWeb(c, ErrorStatus(err), "borrowings/extend.html", data)
 		return
 	}
 
 	// Get updated borrowing details
-	borrowing, _ := h.borrowingService.GetBorrowingDetails(id)
+	if updated, err := h.borrowingService.GetBorrowingDetails(ctx, id); err == nil {
+		borrowing = updated
+	}
 
 	c.Header("HX-Trigger", "due-date-extended, refresh-dashboard, refresh-alerts")
-	c.HTML(http.StatusOK, "borrowings/extend-success.html", gin.H{
+	renderWeb(c, http.StatusOK, "borrowings/extend-success.html", gin.H{
+		"Title":     "Due Date Extended",
 		"Message":   "Due date extended successfully!",
-		"Borrowing": borrowing,
+		"Borrowing": newWebNames(h.userService, h.gameService).borrowing(ctx, borrowing),
 	})
 }
 
 // ShowBorrowingDetails handles GET /borrowings/:id - display borrowing details modal
 func (h *BorrowingWebHandler) ShowBorrowingDetails(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid borrowing ID",
-		})
+	ctx := c.Request.Context()
+	id, ok := webID(c, "borrowing")
+	if !ok {
 		return
 	}
 
-	borrowing, err := h.borrowingService.GetBorrowingDetails(id)
+	borrowing, err := h.borrowingService.GetBorrowingDetails(ctx, id)
 	if err != nil {
-		c.HTML(http.StatusNotFound, "partials/error.html", gin.H{
-			"ErrorMessage": "Borrowing not found",
-		})
+		renderWebError(c, http.StatusNotFound, "Borrowing not found")
 		return
 	}
 
-	c.HTML(http.StatusOK, "borrowings/detail.html", gin.H{
-		"Borrowing": borrowing,
+	renderWeb(c, http.StatusOK, "borrowings/detail.html", gin.H{
+		"Title":     "Borrowing Details",
+		"Borrowing": newWebNames(h.userService, h.gameService).borrowing(ctx, borrowing),
 	})
 }
 
 // GetGameAvailabilityStatus handles GET /games/:id/availability - return availability status for HTMX updates
 func (h *BorrowingWebHandler) GetGameAvailabilityStatus(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid game ID",
-		})
+	ctx := c.Request.Context()
+	id, ok := webID(c, "game")
+	if !ok {
 		return
 	}
 
-	game, err := h.gameService.GetGame(id)
+	game, err := h.gameService.GetGame(ctx, id)
 	if err != nil {
-		c.HTML(http.StatusNotFound, "partials/error.html", gin.H{
-			"ErrorMessage": "Game not found",
-		})
+		renderWebError(c, http.StatusNotFound, "Game not found")
 		return
 	}
 
-	c.HTML(http.StatusOK, "games/partials/availability-status.html", gin.H{
-		"Game": game,
-	})
+	data := gin.H{"Game": game}
+	if current, err := h.gameService.GetCurrentBorrower(ctx, id); err == nil && current != nil {
+		view := newWebNames(h.userService, h.gameService).borrowing(ctx, current)
+		data["CurrentBorrowing"] = &view
+	}
+
+	renderWeb(c, http.StatusOK, "games/partials/availability-status.html", data)
 }
 
 // RegisterWebRoutes registers all borrowing web routes
 func (h *BorrowingWebHandler) RegisterWebRoutes(router *gin.RouterGroup) {
 	borrowings := router.Group("/borrowings")
 	{
+		borrowings.GET("", h.ListBorrowings)
+		borrowings.POST("/search", h.FilterBorrowings)
+		borrowings.POST("/filter", h.FilterBorrowings)
+		borrowings.POST("/sort", h.FilterBorrowings)
 		borrowings.GET("/new", h.ShowNewBorrowingForm)
+		borrowings.GET("/user-info", h.ShowUserInfo)
+		borrowings.GET("/game-info", h.ShowGameInfo)
 		borrowings.POST("", h.CreateBorrowing)
 		borrowings.GET("/:id", h.ShowBorrowingDetails)
 		borrowings.POST("/:id/return", h.ReturnGame)
@@ -287,4 +480,4 @@ func (h *BorrowingWebHandler) RegisterWebRoutes(router *gin.RouterGroup) {
 
 	// Game availability status endpoint
 	router.GET("/games/:id/availability", h.GetGameAvailabilityStatus)
-}
\ No newline at end of file
+}
@@ -1,7 +1,13 @@
 package handlers
 
 import (
+	"board-game-library/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -66,4 +72,89 @@ func TestBorrowingWebHandler_RegisterWebRoutes(t *testing.T) {
 		}
 		assert.True(t, found, "Route %s should be registered", expectedRoute)
 	}
-}
\ No newline at end of file
+}
+
+// newBorrowingWebTest returns a router serving the borrowing pages from mocks
+// knowing Alice and Azul
+func newBorrowingWebTest(t *testing.T) (*gin.Engine, *MockBorrowingService) {
+	mockBorrowingService := new(MockBorrowingService)
+	mockUserService := new(MockUserService)
+	mockGameService := new(MockGameService)
+	mockUserService.On("GetUser", 1).Return(&models.User{ID: 1, Name: "Alice <admin>", Email: "alice@example.com"}, nil)
+	mockGameService.On("GetGame", 2).Return(&models.Game{ID: 2, Name: "Azul"}, nil)
+
+	router := newWebTestRouter(t)
+	NewBorrowingWebHandler(mockBorrowingService, mockUserService, mockGameService).RegisterWebRoutes(router.Group("/"))
+	return router, mockBorrowingService
+}
+
+func TestBorrowingWebHandler_ShowExtendForm(t *testing.T) {
+	router, mockBorrowingService := newBorrowingWebTest(t)
+
+	borrowedAt := time.Now().AddDate(0, 0, -10)
+	mockBorrowingService.On("GetBorrowingDetails", 5).Return(&models.Borrowing{ID: 5, UserID: 1, GameID: 2, BorrowedAt: borrowedAt, DueDate: borrowedAt.AddDate(0, 0, 14)}, nil)
+	returnedAt := time.Now()
+	mockBorrowingService.On("GetBorrowingDetails", 6).Return(&models.Borrowing{ID: 6, UserID: 1, GameID: 2, BorrowedAt: borrowedAt, DueDate: borrowedAt.AddDate(0, 0, 14), ReturnedAt: &returnedAt}, nil)
+
+	t.Run("borrowing out", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/borrowings/5/extend", nil)
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.Contains(t, body, `hx-post="/borrowings/5/extend"`)
+		assert.Contains(t, body, "Azul")
+		assert.Contains(t, body, "Alice &lt;admin&gt;")
+		assert.Contains(t, body, `value="`+borrowedAt.AddDate(0, 0, 28).Format(webDateFormat)+`"`)
+	})
+
+	t.Run("borrowing returned", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/borrowings/6/extend", nil)
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "already been returned")
+	})
+}
+
+func TestBorrowingWebHandler_ReturnGame(t *testing.T) {
+	router, mockBorrowingService := newBorrowingWebTest(t)
+
+	returnedAt := time.Now()
+	mockBorrowingService.On("ReturnGame", 5, (*models.ConditionReport)(nil)).Return(nil)
+	mockBorrowingService.On("GetBorrowingDetails", 5).Return(&models.Borrowing{ID: 5, UserID: 1, GameID: 2, BorrowedAt: returnedAt.AddDate(0, 0, -3), DueDate: returnedAt.AddDate(0, 0, 11), ReturnedAt: &returnedAt}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/borrowings/5/return", nil)
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "game-returned, refresh-games, refresh-dashboard, refresh-alerts", w.Header().Get("HX-Trigger"))
+	assert.Contains(t, w.Body.String(), "Azul")
+	assert.NotContains(t, w.Body.String(), "<!DOCTYPE html>")
+	mockBorrowingService.AssertExpectations(t)
+}
+
+func TestBorrowingWebHandler_ReturnGameWithCondition(t *testing.T) {
+	router, mockBorrowingService := newBorrowingWebTest(t)
+
+	returnedAt := time.Now()
+	report := &models.ConditionReport{Condition: "poor", Notes: "box corner torn"}
+	mockBorrowingService.On("ReturnGame", 5, report).Return(nil)
+	mockBorrowingService.On("GetBorrowingDetails", 5).Return(&models.Borrowing{ID: 5, UserID: 1, GameID: 2, BorrowedAt: returnedAt.AddDate(0, 0, -3), DueDate: returnedAt.AddDate(0, 0, 11), ReturnedAt: &returnedAt}, nil)
+
+	form := url.Values{"condition": {"poor"}, "notes": {" box corner torn "}}
+	req := httptest.NewRequest(http.MethodPost, "/borrowings/5/return", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockBorrowingService.AssertExpectations(t)
+}
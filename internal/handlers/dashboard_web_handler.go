@@ -1,11 +1,17 @@
 package handlers
 
 import (
+	"board-game-library/internal/models"
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// dashboardActivityLimit is the number of entries of the recent activity
+const dashboardActivityLimit = 5
+
 // DashboardWebHandler handles web requests for dashboard with HTMX support
 type DashboardWebHandler struct {
 	alertService     AlertServiceInterface
@@ -24,142 +30,124 @@ func NewDashboardWebHandler(alertService AlertServiceInterface, borrowingService
 	}
 }
 
+// DashboardStats counts what the library has and lends
+type DashboardStats struct {
+	TotalGames       int
+	ActiveUsers      int
+	ActiveBorrowings int
+	OverdueItems     int
+}
+
+// DashboardActivity is an entry of the recent activity. Icon is "overdue"
+// or "due", for the games due soon.
+type DashboardActivity struct {
+	Description string
+	Timestamp   time.Time
+	Icon        string
+	IsLast      bool
+}
+
 // ShowDashboard handles GET /dashboard - display dashboard page
 func (h *DashboardWebHandler) ShowDashboard(c *gin.Context) {
-	dashboardData, err := h.getDashboardData()
-	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to load dashboard: " + err.Error(),
-		})
-		return
-	}
-
-	c.HTML(http.StatusOK, "dashboard.html", dashboardData)
+	h.renderDashboard(c, "dashboard.html", "Failed to load dashboard")
 }
 
 // GetDashboardStats handles GET /dashboard/stats - get dashboard stats for HTMX updates
 func (h *DashboardWebHandler) GetDashboardStats(c *gin.Context) {
-	dashboardData, err := h.getDashboardData()
-	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to load dashboard stats: " + err.Error(),
-		})
-		return
-	}
-
-	c.HTML(http.StatusOK, "dashboard/partials/stats.html", dashboardData)
+	h.renderDashboard(c, "dashboard/partials/stats.html", "Failed to load dashboard stats")
 }
 
 // GetDashboardAlerts handles GET /dashboard/alerts - get dashboard alerts section for HTMX updates
 func (h *DashboardWebHandler) GetDashboardAlerts(c *gin.Context) {
-	dashboardData, err := h.getDashboardData()
-	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to load dashboard alerts: " + err.Error(),
-		})
-		return
-	}
-
-	c.HTML(http.StatusOK, "dashboard/partials/alerts.html", dashboardData)
+	h.renderDashboard(c, "dashboard/partials/alerts.html", "Failed to load dashboard alerts")
 }
 
 // GetDashboardContent handles GET /dashboard/content - get dashboard main content for HTMX updates
 func (h *DashboardWebHandler) GetDashboardContent(c *gin.Context) {
-	dashboardData, err := h.getDashboardData()
+	h.renderDashboard(c, "dashboard/partials/content.html", "Failed to load dashboard content")
+}
+
+// renderDashboard renders the dashboard template name
+func (h *DashboardWebHandler) renderDashboard(c *gin.Context, name, message string) {
+	data, err := h.getDashboardData(c.Request.Context())
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Failed to load dashboard content: " + err.Error(),
-		})
+		renderWebError(c, http.StatusInternalServerError, message+": "+err.Error())
 		return
 	}
 
-	c.HTML(http.StatusOK, "dashboard/partials/content.html", dashboardData)
+	renderWeb(c, http.StatusOK, name, data)
 }
 
 // getDashboardData collects all dashboard data
-func (h *DashboardWebHandler) getDashboardData() (gin.H, error) {
-	// Get basic stats
-	games, err := h.gameService.GetAllGames()
+func (h *DashboardWebHandler) getDashboardData(ctx context.Context) (gin.H, error) {
+	count := models.ListOptions{PerPage: 1}
+
+	_, totalGames, err := h.gameService.ListGames(ctx, models.GameFilter{ListOptions: count})
 	if err != nil {
 		return nil, err
 	}
 
-	users, err := h.userService.GetAllUsers()
+	hasLoans := true
+	_, activeUsers, err := h.userService.ListUsers(ctx, models.UserFilter{HasLoans: &hasLoans, ListOptions: count})
 	if err != nil {
 		return nil, err
 	}
 
-	// Get overdue items
-	overdueItems, err := h.borrowingService.GetOverdueItems()
+	_, activeBorrowings, err := h.borrowingService.ListBorrowings(ctx, models.BorrowingFilter{Status: models.BorrowingStatusActive, ListOptions: count})
 	if err != nil {
 		return nil, err
 	}
 
-	// Get items due soon (within 2 days)
-	dueSoonItems, err := h.borrowingService.GetItemsDueSoon(2)
+	overdue, err := h.borrowingService.GetOverdueItems(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Count active borrowings
-	activeBorrowings := 0
-	for _, game := range games {
-		if !game.IsAvailable {
-			activeBorrowings++
-		}
+	dueSoon, err := h.borrowingService.GetItemsDueSoon(ctx, webDueSoonDays)
+	if err != nil {
+		return nil, err
 	}
 
-	// Count active users (users with current borrowings)
-	activeUsers := 0
-	for _, user := range users {
-		if userBorrowings, err := h.borrowingService.GetActiveBorrowingsByUser(user.ID); err == nil && len(userBorrowings) > 0 {
-			activeUsers++
-		}
-	}
+	names := newWebNames(h.userService, h.gameService)
+	overdueItems := names.borrowings(ctx, overdue)
+	dueSoonItems := names.borrowings(ctx, dueSoon)
 
-	// Create recent activity (simplified)
-	recentActivity := []gin.H{}
-	
-	// Add recent borrowings to activity
-	for i, borrowing := range overdueItems {
-		if i >= 5 { // Limit to 5 items
+	recentActivity := make([]DashboardActivity, 0, dashboardActivityLimit)
+	for _, item := range overdueItems {
+		if len(recentActivity) == dashboardActivityLimit {
 			break
 		}
-		recentActivity = append(recentActivity, gin.H{
-			"Description":  "Overdue item needs attention",
-			"Timestamp":    borrowing.DueDate,
-			"IconBgColor":  "bg-red-500",
-			"IconColor":    "text-white",
-			"IconPath":     `<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M12 8v4m0 4h.01M21 12a9 9 0 11-18 0 9 9 0 0118 0z"/>`,
-			"IsLast":       i == len(overdueItems)-1 && len(dueSoonItems) == 0,
+		recentActivity = append(recentActivity, DashboardActivity{
+			Description: item.GameName + " is overdue from " + item.UserName,
+			Timestamp:   item.DueDate,
+			Icon:        "overdue",
 		})
 	}
-
-	// Add due soon items to activity
-	for i, borrowing := range dueSoonItems {
-		if len(recentActivity) >= 5 { // Limit total to 5 items
+	for _, item := range dueSoonItems {
+		if len(recentActivity) == dashboardActivityLimit {
 			break
 		}
-		recentActivity = append(recentActivity, gin.H{
-			"Description":  "Item due soon",
-			"Timestamp":    borrowing.DueDate,
-			"IconBgColor":  "bg-yellow-500",
-			"IconColor":    "text-white",
-			"IconPath":     `<path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M12 8v4l3 3m6-3a9 9 0 11-18 0 9 9 0 0118 0z"/>`,
-			"IsLast":       i == len(dueSoonItems)-1,
+		recentActivity = append(recentActivity, DashboardActivity{
+			Description: item.GameName + " is due soon from " + item.UserName,
+			Timestamp:   item.DueDate,
+			Icon:        "due",
 		})
 	}
+	if len(recentActivity) > 0 {
+		recentActivity[len(recentActivity)-1].IsLast = true
+	}
 
 	return gin.H{
-		"Stats": gin.H{
-			"TotalGames":       len(games),
-			"ActiveUsers":      activeUsers,
-			"ActiveBorrowings": activeBorrowings,
-			"OverdueItems":     len(overdueItems),
+		"Title": "Dashboard",
+		"Stats": DashboardStats{
+			TotalGames:       totalGames,
+			ActiveUsers:      activeUsers,
+			ActiveBorrowings: activeBorrowings,
+			OverdueItems:     len(overdueItems),
 		},
-		"OverdueItems":    overdueItems,
-		"DueSoonItems":    dueSoonItems,
-		"RecentActivity":  recentActivity,
+		"OverdueItems":   overdueItems,
+		"DueSoonItems":   dueSoonItems,
+		"RecentActivity": recentActivity,
 	}, nil
 }
 
@@ -172,4 +160,4 @@ func (h *DashboardWebHandler) RegisterWebRoutes(router *gin.RouterGroup) {
 		dashboard.GET("/alerts", h.GetDashboardAlerts)
 		dashboard.GET("/content", h.GetDashboardContent)
 	}
-}
\ No newline at end of file
+}
@@ -1,10 +1,15 @@
 package handlers
 
 import (
+	"board-game-library/internal/models"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestDashboardWebHandler_Creation(t *testing.T) {
@@ -67,4 +72,56 @@ func TestDashboardWebHandler_RegisterWebRoutes(t *testing.T) {
 		}
 		assert.True(t, found, "Route %s should be registered", expectedRoute)
 	}
-}
\ No newline at end of file
+}
+
+func TestDashboardWebHandler_ShowDashboard(t *testing.T) {
+	mockAlertService := new(MockAlertService)
+	mockBorrowingService := new(MockBorrowingService)
+	mockGameService := new(MockGameService)
+	mockUserService := new(MockUserService)
+
+	now := time.Now()
+	overdue := &models.Borrowing{ID: 7, UserID: 1, GameID: 2, BorrowedAt: now.AddDate(0, 0, -20), DueDate: now.AddDate(0, 0, -6)}
+	mockGameService.On("ListGames", mock.Anything).Return([]*models.Game{}, 12, nil)
+	mockGameService.On("GetGame", 2).Return(&models.Game{ID: 2, Name: "<i>Azul</i>"}, nil)
+	mockUserService.On("ListUsers", mock.MatchedBy(func(filter models.UserFilter) bool {
+		return filter.HasLoans != nil && *filter.HasLoans
+	})).Return([]*models.User{}, 3, nil)
+	mockUserService.On("GetUser", 1).Return(&models.User{ID: 1, Name: "Alice"}, nil)
+	mockBorrowingService.On("ListBorrowings", mock.MatchedBy(func(filter models.BorrowingFilter) bool {
+		return filter.Status == models.BorrowingStatusActive
+	})).Return([]*models.Borrowing{}, 4, nil)
+	mockBorrowingService.On("GetOverdueItems").Return([]*models.Borrowing{overdue}, nil)
+	mockBorrowingService.On("GetItemsDueSoon", webDueSoonDays).Return([]*models.Borrowing{}, nil)
+
+	router := newWebTestRouter(t)
+	NewDashboardWebHandler(mockAlertService, mockBorrowingService, mockGameService, mockUserService).RegisterWebRoutes(router.Group("/"))
+
+	t.Run("page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/dashboard", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.Contains(t, body, "<!DOCTYPE html>")
+		assert.Contains(t, body, `id="dashboard-stats"`)
+		assert.Contains(t, body, `id="dashboard-content"`)
+		assert.Contains(t, body, "&lt;i&gt;Azul&lt;/i&gt; is overdue from Alice")
+		assert.NotContains(t, body, "<i>Azul</i>")
+	})
+
+	t.Run("stats refreshed by HTMX", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/dashboard/stats", nil)
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.NotContains(t, body, "<!DOCTYPE html>")
+		assert.NotContains(t, body, `id="dashboard-content"`)
+		for _, count := range []string{">12<", ">3<", ">4<", ">1<"} {
+			assert.Contains(t, body, count)
+		}
+	})
+}
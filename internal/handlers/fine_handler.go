@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FineServiceInterface defines the interface for fine service operations
+type FineServiceInterface interface {
+	GetAccount(ctx context.Context, userID int) (*models.FineAccount, error)
+	PayFine(ctx context.Context, userID, amountCents int, reason string) (*models.FineEntry, error)
+	WaiveFine(ctx context.Context, userID, amountCents int, reason string) (*models.FineEntry, error)
+}
+
+// FineHandler handles HTTP requests for the fines charged for late returns
+type FineHandler struct {
+	fineService FineServiceInterface
+}
+
+// NewFineHandler creates a new FineHandler instance
+func NewFineHandler(fineService FineServiceInterface) *FineHandler {
+	return &FineHandler{
+		fineService: fineService,
+	}
+}
+
+// FineSettlementRequest represents the request body for a payment or a
+// waiver of fines
+type FineSettlementRequest struct {
+	AmountCents int    `json:"amount_cents" binding:"required"`
+	Reason      string `json:"reason" binding:"required"`
+}
+
+// GetFines handles GET /api/users/:id/fines - get a user's fines ledger
+// @Summary Amendes d'un utilisateur
+// @Description Récupère le solde des amendes d'un utilisateur, en centimes, et l'historique des amendes, paiements et remises, du plus récent au plus ancien
+// @Tags fines
+// @Produce json
+// @Param id path int true "ID de l'utilisateur"
+// @Success 200 {object} models.FineAccount "Amendes"
+// @Failure 400 {object} Problem "ID invalide"
+// @Failure 404 {object} Problem "Utilisateur non trouvé"
+// @Router /users/{id}/fines [get]
+func (h *FineHandler) GetFines(c *gin.Context) {
+	userID, ok := parseFineUserID(c)
+	if !ok {
+		return
+	}
+
+	account, err := h.fineService.GetAccount(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"fines": account,
+	})
+}
+
+// PayFine handles POST /api/users/:id/fines/payments - record a payment of fines
+// @Summary Enregistrer un paiement d'amendes
+// @Description Enregistre un paiement, en centimes, qui réduit le solde des amendes de l'utilisateur. Le motif est obligatoire et le montant ne peut pas dépasser le solde.
+// @Tags fines
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de l'utilisateur"
+// @Param payment body FineSettlementRequest true "Paiement"
+// @Success 201 {object} map[string]interface{} "Paiement enregistré"
+// @Failure 400 {object} Problem "Données invalides ou montant supérieur au solde"
+// @Failure 404 {object} Problem "Utilisateur non trouvé"
+// @Router /users/{id}/fines/payments [post]
+func (h *FineHandler) PayFine(c *gin.Context) {
+	h.settle(c, actingFineService(c, h.fineService).PayFine, "Payment recorded successfully")
+}
+
+// WaiveFine handles POST /api/users/:id/fines/waivers - waive fines
+// @Summary Remettre des amendes
+// @Description Annule tout ou partie du solde des amendes de l'utilisateur, en centimes. Le motif est obligatoire et le montant ne peut pas dépasser le solde.
+// @Tags fines
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de l'utilisateur"
+// @Param waiver body FineSettlementRequest true "Remise"
+// @Success 201 {object} map[string]interface{} "Remise enregistrée"
+// @Failure 400 {object} Problem "Données invalides ou montant supérieur au solde"
+// @Failure 404 {object} Problem "Utilisateur non trouvé"
+// @Router /users/{id}/fines/waivers [post]
+func (h *FineHandler) WaiveFine(c *gin.Context) {
+	h.settle(c, actingFineService(c, h.fineService).WaiveFine, "Waiver recorded successfully")
+}
+
+// settle records a payment or a waiver with the given service operation
+func (h *FineHandler) settle(c *gin.Context, operation func(ctx context.Context, userID, amountCents int, reason string) (*models.FineEntry, error), message string) {
+	userID, ok := parseFineUserID(c)
+	if !ok {
+		return
+	}
+
+	var req FineSettlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	entry, err := operation(c.Request.Context(), userID, req.AmountCents, req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message,
+		"entry":   entry,
+	})
+}
+
+// parseFineUserID reads the :id path parameter, reporting a validation error when it is invalid
+func parseFineUserID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// RegisterRoutes registers the fine routes
+func (h *FineHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/:id/fines", h.GetFines)
+	router.POST("/users/:id/fines/payments", h.PayFine)
+	router.POST("/users/:id/fines/waivers", h.WaiveFine)
+}
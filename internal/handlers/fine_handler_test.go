@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFineService is a mock implementation of FineServiceInterface
+type MockFineService struct {
+	mock.Mock
+}
+
+func (m *MockFineService) GetAccount(ctx context.Context, userID int) (*models.FineAccount, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FineAccount), args.Error(1)
+}
+
+func (m *MockFineService) PayFine(ctx context.Context, userID, amountCents int, reason string) (*models.FineEntry, error) {
+	args := m.Called(userID, amountCents, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FineEntry), args.Error(1)
+}
+
+func (m *MockFineService) WaiveFine(ctx context.Context, userID, amountCents int, reason string) (*models.FineEntry, error) {
+	args := m.Called(userID, amountCents, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FineEntry), args.Error(1)
+}
+
+func setupFineHandlerTest() (*gin.Engine, *MockFineService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockFineService)
+	handler := NewFineHandler(mockService)
+
+	router := gin.New()
+	router.Use(Problems())
+	api := router.Group("/api")
+	handler.RegisterRoutes(api)
+
+	return router, mockService
+}
+
+func TestFineHandler_GetFines(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, mockService := setupFineHandlerTest()
+		borrowingID := 12
+		account := &models.FineAccount{
+			UserID:       3,
+			BalanceCents: 150,
+			Entries: []*models.FineEntry{
+				{ID: 2, UserID: 3, Kind: models.FineKindPayment, AmountCents: 50, Reason: "Paid in cash", CreatedAt: time.Now()},
+				{ID: 1, UserID: 3, BorrowingID: &borrowingID, Kind: models.FineKindFine, AmountCents: 200, CreatedAt: time.Now()},
+			},
+		}
+		mockService.On("GetAccount", 3).Return(account, nil)
+
+		req, _ := http.NewRequest("GET", "/api/users/3/fines", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		fines := response["fines"].(map[string]interface{})
+		assert.Equal(t, float64(150), fines["balance_cents"])
+		assert.Len(t, fines["entries"], 2)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		router, mockService := setupFineHandlerTest()
+		mockService.On("GetAccount", 99).Return(nil, fmt.Errorf("user not found: %w", models.NotFound("user_not_found", 99)))
+
+		req, _ := http.NewRequest("GET", "/api/users/99/fines", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		router, _ := setupFineHandlerTest()
+
+		req, _ := http.NewRequest("GET", "/api/users/abc/fines", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestFineHandler_Settle(t *testing.T) {
+	t.Run("payment", func(t *testing.T) {
+		router, mockService := setupFineHandlerTest()
+		entry := &models.FineEntry{ID: 5, UserID: 3, Kind: models.FineKindPayment, AmountCents: 100, Reason: "Paid in cash"}
+		mockService.On("PayFine", 3, 100, "Paid in cash").Return(entry, nil)
+
+		body, _ := json.Marshal(map[string]interface{}{"amount_cents": 100, "reason": "Paid in cash"})
+		req, _ := http.NewRequest("POST", "/api/users/3/fines/payments", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "payment", response["entry"].(map[string]interface{})["kind"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("waiver above the balance", func(t *testing.T) {
+		router, mockService := setupFineHandlerTest()
+		mockService.On("WaiveFine", 3, 500, "Board decision").
+			Return(nil, models.Invalid("amount_cents", "amount_exceeds_balance", 500, 200))
+
+		body, _ := json.Marshal(map[string]interface{}{"amount_cents": 500, "reason": "Board decision"})
+		req, _ := http.NewRequest("POST", "/api/users/3/fines/waivers", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "amount_exceeds_balance", response["code"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("missing reason", func(t *testing.T) {
+		router, mockService := setupFineHandlerTest()
+
+		req, _ := http.NewRequest("POST", "/api/users/3/fines/waivers", bytes.NewBufferString(`{"amount_cents":100}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "field_required", response["code"])
+		mockService.AssertNotCalled(t, "WaiveFine", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -2,25 +2,40 @@ package handlers
 
 import (
 	"board-game-library/internal/models"
+	"board-game-library/pkg/bgg"
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
 // GameServiceInterface defines the interface for game service operations
 type GameServiceInterface interface {
-	AddGame(name, description, category, condition string) (*models.Game, error)
-	GetGame(id int) (*models.Game, error)
-	GetAllGames() ([]*models.Game, error)
-	GetAvailableGames() ([]*models.Game, error)
-	SearchGames(query string) ([]*models.Game, error)
-	UpdateGame(game *models.Game) error
-	SetGameAvailability(gameID int, isAvailable bool) error
-	GetGameBorrowingHistory(gameID int) ([]*models.Borrowing, error)
-	IsGameAvailable(gameID int) (bool, error)
-	GetCurrentBorrower(gameID int) (*models.Borrowing, error)
-	DeleteGame(gameID int) error
+	CreateGame(ctx context.Context, game *models.Game) error
+	GetGame(ctx context.Context, id int) (*models.Game, error)
+	GetAllGames(ctx context.Context) ([]*models.Game, error)
+	ListGames(ctx context.Context, filter models.GameFilter) ([]*models.Game, int, error)
+	GetAvailableGames(ctx context.Context) ([]*models.Game, error)
+	SearchGames(ctx context.Context, query string) ([]*models.Game, error)
+	UpdateGame(ctx context.Context, game *models.Game) error
+	SetGameAvailability(ctx context.Context, gameID int, isAvailable bool) error
+	GetGameBorrowingHistory(ctx context.Context, gameID int) ([]*models.Borrowing, error)
+	IsGameAvailable(ctx context.Context, gameID int) (bool, error)
+	GetCurrentBorrower(ctx context.Context, gameID int) (*models.Borrowing, error)
+	GetCurrentBorrowers(ctx context.Context, gameID int) ([]*models.Borrowing, error)
+	DeleteGame(ctx context.Context, gameID int) error
+	GetGameCopies(ctx context.Context, gameID int) ([]*models.GameCopy, error)
+	AddCopy(ctx context.Context, gameID int, barcode, condition string) (*models.GameCopy, error)
+	UpdateCopy(ctx context.Context, gameID, copyID int, barcode, condition string) (*models.GameCopy, error)
+	RemoveCopy(ctx context.Context, gameID, copyID int) error
+	ReportCopyCondition(ctx context.Context, gameID, copyID int, report *models.ConditionReport) (*models.GameCopy, error)
+	GetConditionHistory(ctx context.Context, gameID int) ([]*models.ConditionChange, error)
+	SearchBGG(ctx context.Context, query string) ([]bgg.SearchResult, error)
+	PreviewBGGGame(ctx context.Context, bggID int) (*models.Game, error)
+	ImportBGGGame(ctx context.Context, bggID int, condition string) (*models.Game, error)
 }
 
 // GameHandler handles HTTP requests for game management
@@ -37,10 +52,11 @@ func NewGameHandler(gameService GameServiceInterface) *GameHandler {
 
 // AddGameRequest represents the request body for adding a new game
 type AddGameRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
-	Category    string `json:"category"`
-	Condition   string `json:"condition"`
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"` // tag names, created when new
+	Condition   string   `json:"condition"`
+	models.GameDetails
 }
 
 // AddGame handles POST /api/games - add a new game
@@ -51,16 +67,13 @@ type AddGameRequest struct {
 // @Produce json
 // @Param game body AddGameRequest true "Informations du jeu"
 // @Success 201 {object} map[string]interface{} "Jeu créé avec succès"
-// @Failure 400 {object} map[string]interface{} "Données invalides"
-// @Failure 500 {object} map[string]interface{} "Erreur serveur"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 500 {object} Problem "Erreur serveur"
 // @Router /games [post]
 func (h *GameHandler) AddGame(c *gin.Context) {
 	var req AddGameRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
-			"details": err.Error(),
-		})
+		c.Error(BindError(err))
 		return
 	}
 
@@ -69,12 +82,15 @@ func (h *GameHandler) AddGame(c *gin.Context) {
 		req.Condition = "good"
 	}
 
-	game, err := h.gameService.AddGame(req.Name, req.Description, req.Category, req.Condition)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to add game",
-			"details": err.Error(),
-		})
+	game := &models.Game{
+		Name:        req.Name,
+		Description: req.Description,
+		Tags:        req.Tags,
+		Condition:   req.Condition,
+		GameDetails: req.GameDetails,
+	}
+	if err := actingGameService(c, h.gameService).CreateGame(c.Request.Context(), game); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -84,44 +100,47 @@ func (h *GameHandler) AddGame(c *gin.Context) {
 	})
 }
 
-// GetAllGames handles GET /api/games - list all games with optional search
-// @Summary Lister tous les jeux
-// @Description Récupère la liste de tous les jeux avec recherche optionnelle
+// GetAllGames handles GET /api/games - list games one page at a time
+// @Summary Lister les jeux
+// @Description Récupère une page de jeux, filtrée et triée
 // @Tags games
 // @Accept json
 // @Produce json
-// @Param search query string false "Terme de recherche"
+// @Param search query string false "Terme de recherche (nom, description, étiquettes), insensible aux accents, par préfixe"
+// @Param tag query []string false "Filtrer par étiquette (nom ou slug), répétable : les jeux doivent porter toutes les étiquettes" collectionFormat(multi)
+// @Param condition query string false "Filtrer par état (excellent, good, fair, poor)"
 // @Param available query boolean false "Filtrer par disponibilité"
-// @Success 200 {object} map[string]interface{} "Liste des jeux"
-// @Failure 500 {object} map[string]interface{} "Erreur serveur"
+// @Param players query int false "Jouable à ce nombre de joueurs"
+// @Param max_play_time query int false "Durée de partie maximale en minutes"
+// @Param age query int false "Âge des joueurs (jeux dont l'âge minimum est inférieur ou égal)"
+// @Param publisher query string false "Filtrer par éditeur (contient)"
+// @Param designer query string false "Filtrer par auteur (contient)"
+// @Param year_from query int false "Publiés à partir de cette année"
+// @Param year_to query int false "Publiés jusqu'à cette année"
+// @Param min_complexity query number false "Complexité minimale (1 à 5)"
+// @Param max_complexity query number false "Complexité maximale (1 à 5)"
+// @Param sort query string false "Tri (relevance si search est renseigné, name, condition, entry_date, available_copies, year_published, play_time, complexity)" default(name)
+// @Param order query string false "Sens du tri (asc, desc)"
+// @Param page query int false "Numéro de page" default(1)
+// @Param per_page query int false "Jeux par page (100 au maximum)" default(20)
+// @Success 200 {object} map[string]interface{} "Page de jeux"
+// @Failure 400 {object} Problem "Paramètres invalides"
+// @Failure 500 {object} Problem "Erreur serveur"
 // @Router /games [get]
 func (h *GameHandler) GetAllGames(c *gin.Context) {
-	query := c.Query("search")
-	availableOnly := c.Query("available") == "true"
-
-	var games []*models.Game
-	var err error
-
-	if availableOnly {
-		games, err = h.gameService.GetAvailableGames()
-	} else if query != "" {
-		games, err = h.gameService.SearchGames(query)
-	} else {
-		games, err = h.gameService.GetAllGames()
+	filter, err := GameFilterFromQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
+	games, total, err := h.gameService.ListGames(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve games",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"games": games,
-		"count": len(games),
-	})
+	c.JSON(http.StatusOK, listResponse("games", games, len(games), total, filter.ListOptions))
 }
 
 // GetGame handles GET /api/games/:id - get game by ID
@@ -129,26 +148,13 @@ func (h *GameHandler) GetGame(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid game ID",
-			"details": "Game ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	game, err := h.gameService.GetGame(id)
+	game, err := h.gameService.GetGame(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "game not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Game not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve game",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -159,66 +165,52 @@ func (h *GameHandler) GetGame(c *gin.Context) {
 
 // UpdateGameRequest represents the request body for updating a game
 type UpdateGameRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Description string `json:"description"`
-	Category    string `json:"category"`
-	Condition   string `json:"condition"`
-	IsAvailable *bool  `json:"is_available"`
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"` // omitted to keep the current tags
+	Condition   string   `json:"condition"`
+	IsAvailable *bool    `json:"is_available"`
+	models.GameDetails
 }
 
 // UpdateGame handles PUT /api/games/:id - update game information
 func (h *GameHandler) UpdateGame(c *gin.Context) {
+	ctx := c.Request.Context()
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid game ID",
-			"details": "Game ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
 	var req UpdateGameRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
-			"details": err.Error(),
-		})
+		c.Error(BindError(err))
 		return
 	}
 
 	// Get existing game
-	existingGame, err := h.gameService.GetGame(id)
+	existingGame, err := h.gameService.GetGame(ctx, id)
 	if err != nil {
-		if err.Error() == "game not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Game not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve game",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	// Update game fields
 	existingGame.Name = req.Name
 	existingGame.Description = req.Description
-	existingGame.Category = req.Category
 	existingGame.Condition = req.Condition
+	existingGame.GameDetails = req.GameDetails
+	if req.Tags != nil {
+		existingGame.Tags = req.Tags
+	}
 	if req.IsAvailable != nil {
 		existingGame.IsAvailable = *req.IsAvailable
 	}
 
 	// Update game
-	if err := h.gameService.UpdateGame(existingGame); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to update game",
-			"details": err.Error(),
-		})
+	if err := actingGameService(c, h.gameService).UpdateGame(ctx, existingGame); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -233,32 +225,12 @@ func (h *GameHandler) DeleteGame(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid game ID",
-			"details": "Game ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	if err := h.gameService.DeleteGame(id); err != nil {
-		if err.Error() == "game not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Game not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		if err.Error() == "cannot delete game: currently borrowed" {
-			c.JSON(http.StatusConflict, gin.H{
-				"error":   "Cannot delete game",
-				"details": "Game is currently borrowed and cannot be deleted",
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to delete game",
-			"details": err.Error(),
-		})
+	if err := actingGameService(c, h.gameService).DeleteGame(c.Request.Context(), id); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -272,26 +244,13 @@ func (h *GameHandler) GetGameBorrowingHistory(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid game ID",
-			"details": "Game ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	borrowings, err := h.gameService.GetGameBorrowingHistory(id)
+	borrowings, err := h.gameService.GetGameBorrowingHistory(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "game not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Game not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve game borrowing history",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -302,73 +261,388 @@ func (h *GameHandler) GetGameBorrowingHistory(c *gin.Context) {
 }
 
 // GetGameAvailability handles GET /api/games/:id/availability - check game availability
+// @Summary Disponibilité d'un jeu
+// @Description Indique le nombre d'exemplaires libres d'un jeu et les emprunts en cours
+// @Tags games
+// @Produce json
+// @Param id path int true "ID du jeu"
+// @Success 200 {object} map[string]interface{} "Disponibilité du jeu"
+// @Failure 404 {object} Problem "Jeu non trouvé"
+// @Router /games/{id}/availability [get]
 func (h *GameHandler) GetGameAvailability(c *gin.Context) {
+	ctx := c.Request.Context()
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid game ID",
-			"details": "Game ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	isAvailable, err := h.gameService.IsGameAvailable(id)
+	game, err := h.gameService.GetGame(ctx, id)
 	if err != nil {
-		if err.Error() == "game not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "Game not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to check game availability",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	response := gin.H{
-		"is_available": isAvailable,
+		"is_available":     game.IsAvailable,
+		"total_copies":     game.TotalCopies,
+		"available_copies": game.AvailableCopies,
 	}
 
-	// If not available, get current borrower info
-	if !isAvailable {
-		currentBorrower, err := h.gameService.GetCurrentBorrower(id)
-		if err == nil && currentBorrower != nil {
-			response["current_borrower"] = currentBorrower
+	// If some copies are out, list the current borrowers
+	if game.AvailableCopies < game.TotalCopies || !game.IsAvailable {
+		currentBorrowers, err := h.gameService.GetCurrentBorrowers(ctx, id)
+		if err == nil && len(currentBorrowers) > 0 {
+			response["current_borrowers"] = currentBorrowers
+			if !game.IsAvailable {
+				response["current_borrower"] = currentBorrowers[0]
+			}
 		}
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// GetGameCopies handles GET /api/games/:id/copies - list the copies of a game
+// @Summary Lister les exemplaires d'un jeu
+// @Description Récupère les exemplaires physiques d'un jeu avec leur état et leur disponibilité
+// @Tags games
+// @Produce json
+// @Param id path int true "ID du jeu"
+// @Success 200 {object} map[string]interface{} "Liste des exemplaires"
+// @Failure 404 {object} Problem "Jeu non trouvé"
+// @Router /games/{id}/copies [get]
+func (h *GameHandler) GetGameCopies(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return
+	}
+
+	copies, err := h.gameService.GetGameCopies(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if copies == nil {
+		copies = []*models.GameCopy{}
+	}
+
+	available := 0
+	for _, gameCopy := range copies {
+		if gameCopy.IsAvailable {
+			available++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"copies":    copies,
+		"count":     len(copies),
+		"available": available,
+	})
+}
+
+// GameCopyRequest represents the request body for adding or updating a game copy
+type GameCopyRequest struct {
+	Barcode   string `json:"barcode"`
+	Condition string `json:"condition"`
+}
+
+// AddCopy handles POST /api/games/:id/copies - add a copy to a game
+// @Summary Ajouter un exemplaire
+// @Description Ajoute un exemplaire physique à un jeu existant
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du jeu"
+// @Param copy body GameCopyRequest true "Informations de l'exemplaire"
+// @Success 201 {object} map[string]interface{} "Exemplaire ajouté"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 404 {object} Problem "Jeu non trouvé"
+// @Failure 409 {object} Problem "Code-barres déjà utilisé"
+// @Router /games/{id}/copies [post]
+func (h *GameHandler) AddCopy(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return
+	}
+
+	var req GameCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	// Set default condition if not provided
+	if req.Condition == "" {
+		req.Condition = "good"
+	}
+
+	gameCopy, err := actingGameService(c, h.gameService).AddCopy(c.Request.Context(), id, req.Barcode, req.Condition)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Game copy added successfully",
+		"copy":    gameCopy,
+	})
+}
+
+// UpdateCopy handles PUT /api/games/:id/copies/:copyId - update a game copy
+// @Summary Modifier un exemplaire
+// @Description Modifie le code-barres et l'état d'un exemplaire
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du jeu"
+// @Param copyId path int true "ID de l'exemplaire"
+// @Param copy body GameCopyRequest true "Informations de l'exemplaire"
+// @Success 200 {object} map[string]interface{} "Exemplaire modifié"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 404 {object} Problem "Exemplaire non trouvé"
+// @Router /games/{id}/copies/{copyId} [put]
+func (h *GameHandler) UpdateCopy(c *gin.Context) {
+	id, copyID, ok := parseGameCopyIDs(c)
+	if !ok {
+		return
+	}
+
+	var req GameCopyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	gameCopy, err := actingGameService(c, h.gameService).UpdateCopy(c.Request.Context(), id, copyID, req.Barcode, req.Condition)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Game copy updated successfully",
+		"copy":    gameCopy,
+	})
+}
+
+// RemoveCopy handles DELETE /api/games/:id/copies/:copyId - remove a game copy
+// @Summary Retirer un exemplaire
+// @Description Retire un exemplaire de la bibliothèque (impossible s'il est emprunté ou si c'est le dernier)
+// @Tags games
+// @Produce json
+// @Param id path int true "ID du jeu"
+// @Param copyId path int true "ID de l'exemplaire"
+// @Success 200 {object} map[string]interface{} "Exemplaire retiré"
+// @Failure 404 {object} Problem "Exemplaire non trouvé"
+// @Failure 409 {object} Problem "Exemplaire non supprimable"
+// @Router /games/{id}/copies/{copyId} [delete]
+func (h *GameHandler) RemoveCopy(c *gin.Context) {
+	id, copyID, ok := parseGameCopyIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := actingGameService(c, h.gameService).RemoveCopy(c.Request.Context(), id, copyID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Game copy removed successfully",
+	})
+}
+
+// ConditionReportRequest represents the request body for reporting the
+// condition of a game copy
+type ConditionReportRequest struct {
+	Condition string `json:"condition" binding:"required"`
+	Notes     string `json:"notes"`
+}
+
+// ReportCopyCondition handles POST /api/games/:id/copies/:copyId/condition - record the condition of a copy
+// @Summary Signaler l'état d'un exemplaire
+// @Description Enregistre l'état constaté d'un exemplaire, avec des remarques facultatives sur les pièces manquantes ou les dégâts. Un exemplaire en mauvais état ou incomplet est retiré du prêt ; il y revient quand un nouvel état le déclare réparé. Une dégradation alerte les bibliothécaires.
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du jeu"
+// @Param copyId path int true "ID de l'exemplaire"
+// @Param report body ConditionReportRequest true "État de l'exemplaire"
+// @Success 200 {object} map[string]interface{} "État enregistré"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 404 {object} Problem "Exemplaire non trouvé"
+// @Router /games/{id}/copies/{copyId}/condition [post]
+func (h *GameHandler) ReportCopyCondition(c *gin.Context) {
+	id, copyID, ok := parseGameCopyIDs(c)
+	if !ok {
+		return
+	}
+
+	var req ConditionReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	report := &models.ConditionReport{Condition: req.Condition, Notes: req.Notes}
+	gameCopy, err := actingGameService(c, h.gameService).ReportCopyCondition(c.Request.Context(), id, copyID, report)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Copy condition recorded successfully",
+		"copy":    gameCopy,
+	})
+}
+
+// GetConditionHistory handles GET /api/games/:id/condition-history - list the condition changes of a game
+// @Summary Historique de l'état d'un jeu
+// @Description Liste les changements d'état d'un jeu et de ses exemplaires, les plus récents en premier, relevés aux retours et lors des inspections
+// @Tags games
+// @Produce json
+// @Param id path int true "ID du jeu"
+// @Success 200 {object} map[string]interface{} "Historique de l'état"
+// @Failure 404 {object} Problem "Jeu non trouvé"
+// @Router /games/{id}/condition-history [get]
+func (h *GameHandler) GetConditionHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return
+	}
+
+	history, err := h.gameService.GetConditionHistory(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"history": history,
+		"count":   len(history),
+	})
+}
+
+// parseGameCopyIDs reads the game and copy IDs from the path, reporting a
+// validation error and returning false when either is invalid
+func parseGameCopyIDs(c *gin.Context) (int, int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return 0, 0, false
+	}
+
+	copyID, err := strconv.Atoi(c.Param("copyId"))
+	if err != nil {
+		c.Error(models.Invalid("copyId", "invalid_integer", "copyId"))
+		return 0, 0, false
+	}
+
+	return id, copyID, true
+}
+
+// GameDetailsFromForm reads the metadata of a game from a submitted form.
+// Empty fields are unknown; designers are separated by commas.
+func GameDetailsFromForm(c *gin.Context) (models.GameDetails, error) {
+	details := models.GameDetails{
+		Publisher: strings.TrimSpace(c.PostForm("publisher")),
+		Designers: []string{},
+	}
+	for _, designer := range strings.Split(c.PostForm("designers"), ",") {
+		if designer = strings.TrimSpace(designer); designer != "" {
+			details.Designers = append(details.Designers, designer)
+		}
+	}
+
+	ints := []struct {
+		name  string
+		value *int
+	}{
+		{"min_players", &details.MinPlayers},
+		{"max_players", &details.MaxPlayers},
+		{"play_time", &details.PlayTime},
+		{"min_age", &details.MinAge},
+		{"year_published", &details.YearPublished},
+	}
+	for _, field := range ints {
+		param := strings.TrimSpace(c.PostForm(field.name))
+		if param == "" {
+			continue
+		}
+		value, err := strconv.Atoi(param)
+		if err != nil {
+			return details, fmt.Errorf("%s must be an integer", field.name)
+		}
+		*field.value = value
+	}
+
+	if param := strings.TrimSpace(c.PostForm("complexity")); param != "" {
+		value, err := strconv.ParseFloat(strings.Replace(param, ",", ".", 1), 64)
+		if err != nil {
+			return details, fmt.Errorf("complexity must be a number")
+		}
+		details.Complexity = value
+	}
+
+	return details, nil
+}
+
 // SearchGames handles GET /api/games/search - search games with query parameters
+// @Summary Rechercher des jeux
+// @Description Recherche plein texte des jeux par nom, description ou étiquette, insensible à la casse et aux accents, chaque mot étant cherché comme préfixe. Les résultats sont classés par pertinence et chaque jeu porte un champ match avec le nom et un extrait de la description, en texte brut, accompagnés des positions des termes trouvés (en caractères Unicode, fin exclue). Accepte les mêmes filtres que la liste des jeux.
+// @Tags games
+// @Produce json
+// @Param q query string true "Terme de recherche"
+// @Param tag query []string false "Filtrer par étiquette (nom ou slug), répétable : les jeux doivent porter toutes les étiquettes" collectionFormat(multi)
+// @Param condition query string false "Filtrer par état (excellent, good, fair, poor)"
+// @Param available query boolean false "Filtrer par disponibilité"
+// @Param players query int false "Jouable à ce nombre de joueurs"
+// @Param max_play_time query int false "Durée de partie maximale en minutes"
+// @Param age query int false "Âge des joueurs (jeux dont l'âge minimum est inférieur ou égal)"
+// @Param publisher query string false "Filtrer par éditeur (contient)"
+// @Param designer query string false "Filtrer par auteur (contient)"
+// @Param year_from query int false "Publiés à partir de cette année"
+// @Param year_to query int false "Publiés jusqu'à cette année"
+// @Param min_complexity query number false "Complexité minimale (1 à 5)"
+// @Param max_complexity query number false "Complexité maximale (1 à 5)"
+// @Param sort query string false "Tri (relevance, name, condition, entry_date, available_copies, year_published, play_time, complexity)" default(relevance)
+// @Param order query string false "Sens du tri (asc, desc)"
+// @Param page query int false "Numéro de page" default(1)
+// @Param per_page query int false "Jeux par page (100 au maximum)" default(20)
+// @Success 200 {object} map[string]interface{} "Page de jeux trouvés"
+// @Failure 400 {object} Problem "Paramètres invalides"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /games/search [get]
 func (h *GameHandler) SearchGames(c *gin.Context) {
 	query := c.Query("q")
 	if query == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Missing search query",
-			"details": "Query parameter 'q' is required",
-		})
+		c.Error(models.Invalid("q", "search_query_required"))
 		return
 	}
 
-	games, err := h.gameService.SearchGames(query)
+	filter, err := GameFilterFromQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to search games",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
+	filter.Search = query
 
-	c.JSON(http.StatusOK, gin.H{
-		"games": games,
-		"count": len(games),
-		"query": query,
-	})
+	games, total, err := h.gameService.ListGames(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := listResponse("games", games, len(games), total, filter.ListOptions)
+	response["query"] = query
+	c.JSON(http.StatusOK, response)
 }
 
 // RegisterRoutes registers all game-related routes
@@ -378,10 +652,19 @@ func (h *GameHandler) RegisterRoutes(router *gin.RouterGroup) {
 		games.POST("", h.AddGame)
 		games.GET("", h.GetAllGames)
 		games.GET("/search", h.SearchGames)
+		games.GET("/bgg/search", h.SearchBGG)
+		games.GET("/bgg/:bggId", h.PreviewBGGGame)
+		games.POST("/bgg/import", h.ImportBGGGame)
 		games.GET("/:id", h.GetGame)
 		games.PUT("/:id", h.UpdateGame)
 		games.DELETE("/:id", h.DeleteGame)
 		games.GET("/:id/borrowings", h.GetGameBorrowingHistory)
 		games.GET("/:id/availability", h.GetGameAvailability)
+		games.GET("/:id/copies", h.GetGameCopies)
+		games.POST("/:id/copies", h.AddCopy)
+		games.PUT("/:id/copies/:copyId", h.UpdateCopy)
+		games.DELETE("/:id/copies/:copyId", h.RemoveCopy)
+		games.POST("/:id/copies/:copyId/condition", h.ReportCopyCondition)
+		games.GET("/:id/condition-history", h.GetConditionHistory)
 	}
 }
\ No newline at end of file
@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"board-game-library/internal/models"
+	"board-game-library/pkg/bgg"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -20,15 +22,12 @@ type MockGameService struct {
 	mock.Mock
 }
 
-func (m *MockGameService) AddGame(name, description, category, condition string) (*models.Game, error) {
-	args := m.Called(name, description, category, condition)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*models.Game), args.Error(1)
+func (m *MockGameService) CreateGame(ctx context.Context, game *models.Game) error {
+	args := m.Called(game)
+	return args.Error(0)
 }
 
-func (m *MockGameService) GetGame(id int) (*models.Game, error) {
+func (m *MockGameService) GetGame(ctx context.Context, id int) (*models.Game, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -36,7 +35,7 @@ func (m *MockGameService) GetGame(id int) (*models.Game, error) {
 	return args.Get(0).(*models.Game), args.Error(1)
 }
 
-func (m *MockGameService) GetAllGames() ([]*models.Game, error) {
+func (m *MockGameService) GetAllGames(ctx context.Context) ([]*models.Game, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -44,7 +43,15 @@ func (m *MockGameService) GetAllGames() ([]*models.Game, error) {
 	return args.Get(0).([]*models.Game), args.Error(1)
 }
 
-func (m *MockGameService) GetAvailableGames() ([]*models.Game, error) {
+func (m *MockGameService) ListGames(ctx context.Context, filter models.GameFilter) ([]*models.Game, int, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.Game), args.Int(1), args.Error(2)
+}
+
+func (m *MockGameService) GetAvailableGames(ctx context.Context) ([]*models.Game, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -52,7 +59,7 @@ func (m *MockGameService) GetAvailableGames() ([]*models.Game, error) {
 	return args.Get(0).([]*models.Game), args.Error(1)
 }
 
-func (m *MockGameService) SearchGames(query string) ([]*models.Game, error) {
+func (m *MockGameService) SearchGames(ctx context.Context, query string) ([]*models.Game, error) {
 	args := m.Called(query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -60,17 +67,17 @@ func (m *MockGameService) SearchGames(query string) ([]*models.Game, error) {
 	return args.Get(0).([]*models.Game), args.Error(1)
 }
 
-func (m *MockGameService) UpdateGame(game *models.Game) error {
+func (m *MockGameService) UpdateGame(ctx context.Context, game *models.Game) error {
 	args := m.Called(game)
 	return args.Error(0)
 }
 
-func (m *MockGameService) SetGameAvailability(gameID int, isAvailable bool) error {
+func (m *MockGameService) SetGameAvailability(ctx context.Context, gameID int, isAvailable bool) error {
 	args := m.Called(gameID, isAvailable)
 	return args.Error(0)
 }
 
-func (m *MockGameService) GetGameBorrowingHistory(gameID int) ([]*models.Borrowing, error) {
+func (m *MockGameService) GetGameBorrowingHistory(ctx context.Context, gameID int) ([]*models.Borrowing, error) {
 	args := m.Called(gameID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -78,12 +85,12 @@ func (m *MockGameService) GetGameBorrowingHistory(gameID int) ([]*models.Borrowi
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockGameService) IsGameAvailable(gameID int) (bool, error) {
+func (m *MockGameService) IsGameAvailable(ctx context.Context, gameID int) (bool, error) {
 	args := m.Called(gameID)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockGameService) GetCurrentBorrower(gameID int) (*models.Borrowing, error) {
+func (m *MockGameService) GetCurrentBorrower(ctx context.Context, gameID int) (*models.Borrowing, error) {
 	args := m.Called(gameID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -91,11 +98,88 @@ func (m *MockGameService) GetCurrentBorrower(gameID int) (*models.Borrowing, err
 	return args.Get(0).(*models.Borrowing), args.Error(1)
 }
 
-func (m *MockGameService) DeleteGame(gameID int) error {
+func (m *MockGameService) DeleteGame(ctx context.Context, gameID int) error {
+	args := m.Called(gameID)
+	return args.Error(0)
+}
+
+func (m *MockGameService) GetCurrentBorrowers(ctx context.Context, gameID int) ([]*models.Borrowing, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Borrowing), args.Error(1)
+}
+
+func (m *MockGameService) GetGameCopies(ctx context.Context, gameID int) ([]*models.GameCopy, error) {
 	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameService) AddCopy(ctx context.Context, gameID int, barcode, condition string) (*models.GameCopy, error) {
+	args := m.Called(gameID, barcode, condition)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameService) UpdateCopy(ctx context.Context, gameID, copyID int, barcode, condition string) (*models.GameCopy, error) {
+	args := m.Called(gameID, copyID, barcode, condition)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameService) RemoveCopy(ctx context.Context, gameID, copyID int) error {
+	args := m.Called(gameID, copyID)
 	return args.Error(0)
 }
 
+func (m *MockGameService) ReportCopyCondition(ctx context.Context, gameID, copyID int, report *models.ConditionReport) (*models.GameCopy, error) {
+	args := m.Called(gameID, copyID, report)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameService) GetConditionHistory(ctx context.Context, gameID int) ([]*models.ConditionChange, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ConditionChange), args.Error(1)
+}
+
+func (m *MockGameService) SearchBGG(ctx context.Context, query string) ([]bgg.SearchResult, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]bgg.SearchResult), args.Error(1)
+}
+
+func (m *MockGameService) PreviewBGGGame(ctx context.Context, bggID int) (*models.Game, error) {
+	args := m.Called(bggID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Game), args.Error(1)
+}
+
+func (m *MockGameService) ImportBGGGame(ctx context.Context, bggID int, condition string) (*models.Game, error) {
+	args := m.Called(bggID, condition)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Game), args.Error(1)
+}
+
 func setupGameHandlerTest() (*gin.Engine, *MockGameService, *GameHandler) {
 	gin.SetMode(gin.TestMode)
 	
@@ -105,6 +189,7 @@ func setupGameHandlerTest() (*gin.Engine, *MockGameService, *GameHandler) {
 	}
 	
 	router := gin.New()
+	router.Use(Problems())
 	api := router.Group("/api")
 	handler.RegisterRoutes(api)
 	
@@ -115,23 +200,20 @@ func TestGameHandler_AddGame(t *testing.T) {
 	router, mockService, _ := setupGameHandlerTest()
 
 	t.Run("successful game addition", func(t *testing.T) {
-		expectedGame := &models.Game{
-			ID:          1,
-			Name:        "Monopoly",
-			Description: "Classic board game",
-			Category:    "Strategy",
-			EntryDate:   time.Now(),
-			Condition:   "good",
-			IsAvailable: true,
-		}
-
-		mockService.On("AddGame", "Monopoly", "Classic board game", "Strategy", "good").Return(expectedGame, nil)
+		details := models.GameDetails{MinPlayers: 2, MaxPlayers: 6, PlayTime: 90, Designers: []string{"Charles Darrow"}}
+		mockService.On("CreateGame", mock.MatchedBy(func(game *models.Game) bool {
+			return game.Name == "Monopoly" && len(game.Tags) == 1 && game.Tags[0] == "Strategy" && game.Condition == "good" &&
+				game.MaxPlayers == 6 && game.PlayTime == 90 && len(game.Designers) == 1
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Game).ID = 1
+		}).Return(nil)
 
 		reqBody := AddGameRequest{
 			Name:        "Monopoly",
 			Description: "Classic board game",
-			Category:    "Strategy",
+			Tags:        []string{"Strategy"},
 			Condition:   "good",
+			GameDetails: details,
 		}
 		jsonBody, _ := json.Marshal(reqBody)
 
@@ -147,7 +229,9 @@ func TestGameHandler_AddGame(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, "Game added successfully", response["message"])
-		assert.NotNil(t, response["game"])
+		game := response["game"].(map[string]interface{})
+		assert.Equal(t, float64(1), game["id"])
+		assert.Equal(t, float64(6), game["max_players"])
 
 		mockService.AssertExpectations(t)
 	})
@@ -169,17 +253,17 @@ func TestGameHandler_AddGame(t *testing.T) {
 		var response map[string]interface{}
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Invalid request data", response["error"])
+		assert.Equal(t, "field_required", response["code"])
 	})
 
 	t.Run("service error", func(t *testing.T) {
 		router, mockService, _ := setupGameHandlerTest()
-		mockService.On("AddGame", "Monopoly", "Classic board game", "Strategy", "good").Return(nil, fmt.Errorf("database error"))
+		mockService.On("CreateGame", mock.AnythingOfType("*models.Game")).Return(fmt.Errorf("database error"))
 
 		reqBody := AddGameRequest{
 			Name:        "Monopoly",
 			Description: "Classic board game",
-			Category:    "Strategy",
+			Tags:        []string{"Strategy"},
 			Condition:   "good",
 		}
 		jsonBody, _ := json.Marshal(reqBody)
@@ -194,6 +278,21 @@ func TestGameHandler_AddGame(t *testing.T) {
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		mockService.On("CreateGame", mock.AnythingOfType("*models.Game")).Return(fmt.Errorf("validation failed: %w", models.Invalid("min_players", "min_players_exceed_max")))
+
+		jsonBody := []byte(`{"name":"Monopoly","min_players":6,"max_players":2}`)
+		req, _ := http.NewRequest("POST", "/api/games", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertExpectations(t)
+	})
 }
 
 func TestGameHandler_GetAllGames(t *testing.T) {
@@ -205,7 +304,8 @@ func TestGameHandler_GetAllGames(t *testing.T) {
 			{ID: 2, Name: "Scrabble", IsAvailable: false},
 		}
 
-		mockService.On("GetAllGames").Return(expectedGames, nil)
+		filter := models.GameFilter{ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage}}
+		mockService.On("ListGames", filter).Return(expectedGames, 2, nil)
 
 		req, _ := http.NewRequest("GET", "/api/games", nil)
 		w := httptest.NewRecorder()
@@ -228,7 +328,9 @@ func TestGameHandler_GetAllGames(t *testing.T) {
 			{ID: 1, Name: "Monopoly", IsAvailable: true},
 		}
 
-		mockService.On("GetAvailableGames").Return(expectedGames, nil)
+		available := true
+		filter := models.GameFilter{Available: &available, ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage}}
+		mockService.On("ListGames", filter).Return(expectedGames, 1, nil)
 
 		req, _ := http.NewRequest("GET", "/api/games?available=true", nil)
 		w := httptest.NewRecorder()
@@ -250,17 +352,72 @@ func TestGameHandler_GetAllGames(t *testing.T) {
 			{ID: 1, Name: "Monopoly", IsAvailable: true},
 		}
 
-		mockService.On("SearchGames", "Monopoly").Return(expectedGames, nil)
+		filter := models.GameFilter{Search: "Monopoly", Tags: []string{"Family"}, ListOptions: models.ListOptions{Page: 3, PerPage: 10, Sort: "entry_date", Order: models.SortDesc}}
+		mockService.On("ListGames", filter).Return(expectedGames, 21, nil)
 
-		req, _ := http.NewRequest("GET", "/api/games?search=Monopoly", nil)
+		req, _ := http.NewRequest("GET", "/api/games?search=Monopoly&tag=Family&page=3&per_page=10&sort=entry_date&order=desc", nil)
 		w := httptest.NewRecorder()
 
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(21), response["total"])
+		assert.Equal(t, float64(3), response["page"])
+		assert.Equal(t, float64(3), response["total_pages"])
+
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("metadata filters", func(t *testing.T) {
+		filter := models.GameFilter{
+			Players: 2, MaxPlayTime: 30, Age: 8, Publisher: "Lookout", Designer: "Rosenberg",
+			YearFrom: -500, YearTo: 2020, MinComplexity: 1.5, MaxComplexity: 2.5,
+			ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage},
+		}
+		mockService.On("ListGames", filter).Return([]*models.Game{}, 0, nil)
+
+		req, _ := http.NewRequest("GET", "/api/games?players=2&max_play_time=30&age=8&publisher=Lookout&designer=Rosenberg&year_from=-500&year_to=2020&min_complexity=1.5&max_complexity=2.5", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid metadata filter", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/games?max_complexity=heavy", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid page", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/api/games?page=0", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("sort field rejected by the service", func(t *testing.T) {
+		filter := models.GameFilter{ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage, Sort: "price"}}
+		mockService.On("ListGames", filter).Return(nil, 0, fmt.Errorf("invalid game filter: %w", models.Invalid("sort", "invalid_sort_field", models.GameSortFields)))
+
+		req, _ := http.NewRequest("GET", "/api/games?sort=price", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestGameHandler_GetGame(t *testing.T) {
@@ -301,7 +458,7 @@ func TestGameHandler_GetGame(t *testing.T) {
 	})
 
 	t.Run("game not found", func(t *testing.T) {
-		mockService.On("GetGame", 999).Return(nil, fmt.Errorf("game not found"))
+		mockService.On("GetGame", 999).Return(nil, models.NotFound("game_not_found", 1))
 
 		req, _ := http.NewRequest("GET", "/api/games/999", nil)
 		w := httptest.NewRecorder()
@@ -322,7 +479,7 @@ func TestGameHandler_UpdateGame(t *testing.T) {
 			ID:          1,
 			Name:        "Monopoly",
 			Description: "Classic board game",
-			Category:    "Strategy",
+			Tags:        []string{"Strategy"},
 			Condition:   "good",
 			IsAvailable: true,
 		}
@@ -336,7 +493,7 @@ func TestGameHandler_UpdateGame(t *testing.T) {
 		reqBody := UpdateGameRequest{
 			Name:        "Monopoly Deluxe",
 			Description: "Deluxe edition",
-			Category:    "Strategy",
+			Tags:        []string{"Strategy"},
 			Condition:   "excellent",
 			IsAvailable: &isAvailable,
 		}
@@ -354,12 +511,12 @@ func TestGameHandler_UpdateGame(t *testing.T) {
 	})
 
 	t.Run("game not found", func(t *testing.T) {
-		mockService.On("GetGame", 999).Return(nil, fmt.Errorf("game not found"))
+		mockService.On("GetGame", 999).Return(nil, models.NotFound("game_not_found", 1))
 
 		reqBody := UpdateGameRequest{
 			Name:        "Monopoly Deluxe",
 			Description: "Deluxe edition",
-			Category:    "Strategy",
+			Tags:        []string{"Strategy"},
 			Condition:   "excellent",
 		}
 		jsonBody, _ := json.Marshal(reqBody)
@@ -398,7 +555,7 @@ func TestGameHandler_DeleteGame(t *testing.T) {
 	})
 
 	t.Run("game not found", func(t *testing.T) {
-		mockService.On("DeleteGame", 999).Return(fmt.Errorf("game not found"))
+		mockService.On("DeleteGame", 999).Return(models.NotFound("game_not_found", 1))
 
 		req, _ := http.NewRequest("DELETE", "/api/games/999", nil)
 		w := httptest.NewRecorder()
@@ -412,7 +569,7 @@ func TestGameHandler_DeleteGame(t *testing.T) {
 
 	t.Run("game currently borrowed", func(t *testing.T) {
 		router, mockService, _ := setupGameHandlerTest()
-		mockService.On("DeleteGame", 1).Return(fmt.Errorf("cannot delete game: currently borrowed"))
+		mockService.On("DeleteGame", 1).Return(models.Conflict("game_borrowed", 2))
 
 		req, _ := http.NewRequest("DELETE", "/api/games/1", nil)
 		w := httptest.NewRecorder()
@@ -452,7 +609,7 @@ func TestGameHandler_GetGameBorrowingHistory(t *testing.T) {
 	})
 
 	t.Run("game not found", func(t *testing.T) {
-		mockService.On("GetGameBorrowingHistory", 999).Return(nil, fmt.Errorf("game not found"))
+		mockService.On("GetGameBorrowingHistory", 999).Return(nil, models.NotFound("game_not_found", 1))
 
 		req, _ := http.NewRequest("GET", "/api/games/999/borrowings", nil)
 		w := httptest.NewRecorder()
@@ -469,7 +626,8 @@ func TestGameHandler_GetGameAvailability(t *testing.T) {
 	router, mockService, _ := setupGameHandlerTest()
 
 	t.Run("game is available", func(t *testing.T) {
-		mockService.On("IsGameAvailable", 1).Return(true, nil)
+		game := &models.Game{ID: 1, Name: "Catan", IsAvailable: true, TotalCopies: 1, AvailableCopies: 1}
+		mockService.On("GetGame", 1).Return(game, nil)
 
 		req, _ := http.NewRequest("GET", "/api/games/1/availability", nil)
 		w := httptest.NewRecorder()
@@ -482,20 +640,53 @@ func TestGameHandler_GetGameAvailability(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, true, response["is_available"])
+		assert.Equal(t, float64(1), response["available_copies"])
+		assert.Nil(t, response["current_borrowers"])
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("some copies are borrowed", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		game := &models.Game{ID: 1, Name: "Catan", IsAvailable: true, TotalCopies: 3, AvailableCopies: 1}
+		borrowers := []*models.Borrowing{
+			{ID: 1, UserID: 1, GameID: 1},
+			{ID: 2, UserID: 2, GameID: 1},
+		}
+
+		mockService.On("GetGame", 1).Return(game, nil)
+		mockService.On("GetCurrentBorrowers", 1).Return(borrowers, nil)
+
+		req, _ := http.NewRequest("GET", "/api/games/1/availability", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, true, response["is_available"])
+		assert.Equal(t, float64(3), response["total_copies"])
+		assert.Equal(t, float64(1), response["available_copies"])
+		assert.Len(t, response["current_borrowers"], 2)
+		assert.Nil(t, response["current_borrower"])
 
 		mockService.AssertExpectations(t)
 	})
 
 	t.Run("game is not available", func(t *testing.T) {
 		router, mockService, _ := setupGameHandlerTest()
+		game := &models.Game{ID: 1, Name: "Catan", IsAvailable: false, TotalCopies: 1, AvailableCopies: 0}
 		currentBorrower := &models.Borrowing{
 			ID:     1,
 			UserID: 1,
 			GameID: 1,
 		}
 
-		mockService.On("IsGameAvailable", 1).Return(false, nil)
-		mockService.On("GetCurrentBorrower", 1).Return(currentBorrower, nil)
+		mockService.On("GetGame", 1).Return(game, nil)
+		mockService.On("GetCurrentBorrowers", 1).Return([]*models.Borrowing{currentBorrower}, nil)
 
 		req, _ := http.NewRequest("GET", "/api/games/1/availability", nil)
 		w := httptest.NewRecorder()
@@ -512,6 +703,186 @@ func TestGameHandler_GetGameAvailability(t *testing.T) {
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("game not found", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		mockService.On("GetGame", 99).Return(nil, fmt.Errorf("failed to get game: %w", models.NotFound("game_not_found", 99)))
+
+		req, _ := http.NewRequest("GET", "/api/games/99/availability", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGameHandler_GameCopies(t *testing.T) {
+	t.Run("list copies", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		copies := []*models.GameCopy{
+			{ID: 1, GameID: 1, Barcode: "BGL-0001", Condition: "good", IsAvailable: true},
+			{ID: 2, GameID: 1, Barcode: "BGL-0002", Condition: "fair", IsAvailable: false},
+		}
+		mockService.On("GetGameCopies", 1).Return(copies, nil)
+
+		req, _ := http.NewRequest("GET", "/api/games/1/copies", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(2), response["count"])
+		assert.Equal(t, float64(1), response["available"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("add copy with default condition", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		gameCopy := &models.GameCopy{ID: 3, GameID: 1, Barcode: "BGL-0003", Condition: "good", IsAvailable: true}
+		mockService.On("AddCopy", 1, "BGL-0003", "good").Return(gameCopy, nil)
+
+		body, _ := json.Marshal(GameCopyRequest{Barcode: "BGL-0003"})
+		req, _ := http.NewRequest("POST", "/api/games/1/copies", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("add copy with duplicate barcode", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		mockService.On("AddCopy", 1, "BGL-0001", "good").Return(nil, fmt.Errorf("failed to create game copy: %w", models.Conflict("copy_barcode_taken", "BGL-0001")))
+
+		body, _ := json.Marshal(GameCopyRequest{Barcode: "BGL-0001", Condition: "good"})
+		req, _ := http.NewRequest("POST", "/api/games/1/copies", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("update copy", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		gameCopy := &models.GameCopy{ID: 2, GameID: 1, Barcode: "BGL-0002", Condition: "poor"}
+		mockService.On("UpdateCopy", 1, 2, "BGL-0002", "poor").Return(gameCopy, nil)
+
+		body, _ := json.Marshal(GameCopyRequest{Barcode: "BGL-0002", Condition: "poor"})
+		req, _ := http.NewRequest("PUT", "/api/games/1/copies/2", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("remove borrowed copy", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		mockService.On("RemoveCopy", 1, 2).Return(models.Conflict("copy_borrowed"))
+
+		req, _ := http.NewRequest("DELETE", "/api/games/1/copies/2", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("remove copy with invalid ID", func(t *testing.T) {
+		router, _, _ := setupGameHandlerTest()
+
+		req, _ := http.NewRequest("DELETE", "/api/games/1/copies/abc", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGameHandler_ConditionTracking(t *testing.T) {
+	t.Run("report copy condition", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		report := &models.ConditionReport{Condition: "missing_pieces", Notes: "Two blue meeples missing"}
+		gameCopy := &models.GameCopy{ID: 2, GameID: 1, Condition: "missing_pieces", InRepair: true}
+		mockService.On("ReportCopyCondition", 1, 2, report).Return(gameCopy, nil)
+
+		body, _ := json.Marshal(ConditionReportRequest{Condition: "missing_pieces", Notes: "Two blue meeples missing"})
+		req, _ := http.NewRequest("POST", "/api/games/1/copies/2/condition", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, true, response["copy"].(map[string]interface{})["in_repair"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("report without condition", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+
+		body, _ := json.Marshal(ConditionReportRequest{Notes: "Box corner torn"})
+		req, _ := http.NewRequest("POST", "/api/games/1/copies/2/condition", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ReportCopyCondition", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("condition history", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		history := []*models.ConditionChange{
+			{ID: 2, GameID: 1, PreviousCondition: "missing_pieces", Condition: "good"},
+			{ID: 1, GameID: 1, PreviousCondition: "good", Condition: "missing_pieces", Notes: "Two blue meeples missing"},
+		}
+		mockService.On("GetConditionHistory", 1).Return(history, nil)
+
+		req, _ := http.NewRequest("GET", "/api/games/1/condition-history", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(2), response["count"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("condition history of unknown game", func(t *testing.T) {
+		router, mockService, _ := setupGameHandlerTest()
+		mockService.On("GetConditionHistory", 99).Return(nil, fmt.Errorf("game not found: %w", models.NotFound("game_not_found", 99)))
+
+		req, _ := http.NewRequest("GET", "/api/games/99/condition-history", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
 }
 
 func TestGameHandler_SearchGames(t *testing.T) {
@@ -519,10 +890,11 @@ func TestGameHandler_SearchGames(t *testing.T) {
 
 	t.Run("successful search", func(t *testing.T) {
 		expectedGames := []*models.Game{
-			{ID: 1, Name: "Monopoly", IsAvailable: true},
+			{ID: 1, Name: "Monopoly", IsAvailable: true, Match: &models.GameSearchMatch{Rank: -1.5, Name: "Monopoly", NameHighlights: []models.TextRange{{Start: 0, End: 8}}}},
 		}
 
-		mockService.On("SearchGames", "Monopoly").Return(expectedGames, nil)
+		filter := models.GameFilter{Search: "Monopoly", ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage}}
+		mockService.On("ListGames", filter).Return(expectedGames, 1, nil)
 
 		req, _ := http.NewRequest("GET", "/api/games/search?q=Monopoly", nil)
 		w := httptest.NewRecorder()
@@ -536,6 +908,10 @@ func TestGameHandler_SearchGames(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, float64(1), response["count"])
 		assert.Equal(t, "Monopoly", response["query"])
+		games := response["games"].([]interface{})
+		match := games[0].(map[string]interface{})["match"].(map[string]interface{})
+		assert.Equal(t, "Monopoly", match["name"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"start": float64(0), "end": float64(8)}}, match["name_highlights"])
 
 		mockService.AssertExpectations(t)
 	})
@@ -551,6 +927,6 @@ func TestGameHandler_SearchGames(t *testing.T) {
 		var response map[string]interface{}
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Missing search query", response["error"])
+		assert.Equal(t, "search_query_required", response["code"])
 	})
 }
\ No newline at end of file
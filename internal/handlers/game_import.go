@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/bgg"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportBGGGameRequest represents the request body for importing a game from
+// BoardGameGeek
+type ImportBGGGameRequest struct {
+	BGGID     int    `json:"bgg_id" binding:"required"`
+	Condition string `json:"condition"`
+}
+
+// SearchBGG handles GET /api/games/bgg/search - search BoardGameGeek
+// @Summary Rechercher sur BoardGameGeek
+// @Description Recherche des jeux sur BoardGameGeek par nom, ou par identifiant BoardGameGeek si q est un nombre
+// @Tags games
+// @Produce json
+// @Param q query string true "Nom ou identifiant BoardGameGeek"
+// @Success 200 {object} map[string]interface{} "Jeux trouvés"
+// @Failure 400 {object} Problem "Recherche invalide"
+// @Failure 502 {object} Problem "BoardGameGeek injoignable"
+// @Failure 503 {object} Problem "Import désactivé ou BoardGameGeek occupé"
+// @Router /games/bgg/search [get]
+func (h *GameHandler) SearchBGG(c *gin.Context) {
+	results, err := h.gameService.SearchBGG(c.Request.Context(), c.Query("q"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if results == nil {
+		results = []bgg.SearchResult{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"count":   len(results),
+	})
+}
+
+// PreviewBGGGame handles GET /api/games/bgg/:bggId - preview an import
+// @Summary Prévisualiser un jeu BoardGameGeek
+// @Description Renvoie le jeu tel qu'il serait importé depuis BoardGameGeek, sans l'ajouter
+// @Tags games
+// @Produce json
+// @Param bggId path int true "Identifiant BoardGameGeek"
+// @Success 200 {object} models.Game "Jeu à importer"
+// @Failure 400 {object} Problem "Identifiant invalide"
+// @Failure 404 {object} Problem "Jeu inconnu de BoardGameGeek"
+// @Failure 502 {object} Problem "BoardGameGeek injoignable"
+// @Failure 503 {object} Problem "Import désactivé ou BoardGameGeek occupé"
+// @Router /games/bgg/{bggId} [get]
+func (h *GameHandler) PreviewBGGGame(c *gin.Context) {
+	bggID, err := strconv.Atoi(c.Param("bggId"))
+	if err != nil {
+		c.Error(models.Invalid("bggId", "invalid_integer", "bggId"))
+		return
+	}
+
+	game, err := h.gameService.PreviewBGGGame(c.Request.Context(), bggID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, game)
+}
+
+// ImportBGGGame handles POST /api/games/bgg/import - import a game
+// @Summary Importer un jeu depuis BoardGameGeek
+// @Description Ajoute à la bibliothèque un jeu décrit par BoardGameGeek (description, joueurs, durée, année, image, auteurs, éditeur, complexité), ses catégories devenant des étiquettes
+// @Tags games
+// @Accept json
+// @Produce json
+// @Param import body ImportBGGGameRequest true "Identifiant BoardGameGeek et état de l'exemplaire (good par défaut)"
+// @Success 201 {object} map[string]interface{} "Jeu importé"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 404 {object} Problem "Jeu inconnu de BoardGameGeek"
+// @Failure 502 {object} Problem "BoardGameGeek injoignable"
+// @Failure 503 {object} Problem "Import désactivé ou BoardGameGeek occupé"
+// @Router /games/bgg/import [post]
+func (h *GameHandler) ImportBGGGame(c *gin.Context) {
+	var req ImportBGGGameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	if req.Condition == "" {
+		req.Condition = "good"
+	}
+
+	game, err := actingGameService(c, h.gameService).ImportBGGGame(c.Request.Context(), req.BGGID, req.Condition)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Game imported successfully",
+		"game":    game,
+	})
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/bgg"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameHandler_SearchBGG(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		results        []bgg.SearchResult
+		err            error
+		expectedStatus int
+	}{
+		{"found", "catan", []bgg.SearchResult{{ID: 13, Name: "CATAN", YearPublished: 1995}}, nil, http.StatusOK},
+		{"no query", "", nil, fmt.Errorf("validation failed: %w", models.Invalid("q", "search_query_required")), http.StatusBadRequest},
+		{"disabled", "catan", nil, models.Unavailable("bgg_disabled"), http.StatusServiceUnavailable},
+		{"busy", "catan", nil, fmt.Errorf("BoardGameGeek lookup failed: %w", models.Unavailable("bgg_busy")), http.StatusServiceUnavailable},
+		{"unreachable", "catan", nil, fmt.Errorf("%w: failed to reach BoardGameGeek: timeout", models.Upstream("bgg_lookup_failed")), http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService, _ := setupGameHandlerTest()
+			mockService.On("SearchBGG", tt.query).Return(tt.results, tt.err)
+
+			req, _ := http.NewRequest("GET", "/api/games/bgg/search?q="+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.err == nil {
+				var response map[string]interface{}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, float64(1), response["count"])
+				result := response["results"].([]interface{})[0].(map[string]interface{})
+				assert.Equal(t, float64(13), result["bgg_id"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGameHandler_PreviewBGGGame(t *testing.T) {
+	router, mockService, _ := setupGameHandlerTest()
+	mockService.On("PreviewBGGGame", 13).Return(&models.Game{Name: "CATAN", GameDetails: models.GameDetails{BGGID: 13}}, nil)
+	mockService.On("PreviewBGGGame", 999).Return(nil, fmt.Errorf("BoardGameGeek lookup failed: %w", models.NotFound("bgg_game_not_found", 999)))
+
+	req, _ := http.NewRequest("GET", "/api/games/bgg/13", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"bgg_id":13`)
+
+	req, _ = http.NewRequest("GET", "/api/games/bgg/999", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest("GET", "/api/games/bgg/catan", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGameHandler_ImportBGGGame(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockGameService)
+		expectedStatus int
+	}{
+		{
+			name: "imported in default condition",
+			body: `{"bgg_id":13}`,
+			setupMock: func(m *MockGameService) {
+				m.On("ImportBGGGame", 13, "good").Return(&models.Game{ID: 1, Name: "CATAN", Condition: "good"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "invalid condition",
+			body: `{"bgg_id":13,"condition":"broken"}`,
+			setupMock: func(m *MockGameService) {
+				m.On("ImportBGGGame", 13, "broken").Return(nil, fmt.Errorf("validation failed: %w", models.Invalid("condition", "invalid_game_condition", models.ValidConditions)))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing ID",
+			body:           `{"condition":"good"}`,
+			setupMock:      func(m *MockGameService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService, _ := setupGameHandlerTest()
+			tt.setupMock(mockService)
+
+			req, _ := http.NewRequest("POST", "/api/games/bgg/import", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
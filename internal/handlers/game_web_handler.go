@@ -2,10 +2,10 @@ package handlers
 
 import (
 	"board-game-library/internal/models"
+	"fmt"
 	"net/http"
-	"sort"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,222 +13,261 @@ import (
 // GameWebHandler handles web requests for game management with HTMX support
 type GameWebHandler struct {
 	gameService GameServiceInterface
+	userService UserServiceInterface
 }
 
 // NewGameWebHandler creates a new GameWebHandler instance
-func NewGameWebHandler(gameService GameServiceInterface) *GameWebHandler {
+func NewGameWebHandler(gameService GameServiceInterface, userService UserServiceInterface) *GameWebHandler {
 	return &GameWebHandler{
 		gameService: gameService,
+		userService: userService,
 	}
 }
 
-// ListGames handles GET /games - display games list page
-func (h *GameWebHandler) ListGames(c *gin.Context) {
-	games, err := h.gameService.GetAllGames()
+// GameStats sums up the loans of a game on its detail page
+type GameStats struct {
+	TotalBorrows  int
+	DaysAvailable int // days on the shelf since the game was added
+	AvgLoanDays   int // average length of the loans returned
+}
+
+// gameListData loads the page of games selected by the list controls
+func (h *GameWebHandler) gameListData(c *gin.Context) (gin.H, error) {
+	filter := models.GameFilter{
+		Search:      webValue(c, "search"),
+		ListOptions: webListOptions(c),
+	}
+	availability := webValue(c, "availability")
+	switch availability {
+	case "available":
+		available := true
+		filter.Available = &available
+	case "borrowed":
+		available := false
+		filter.Available = &available
+	}
+	tag := webValue(c, "tag")
+	if tag != "" {
+		filter.Tags = []string{models.TagSlug(tag)}
+	}
+
+	games, total, err := h.gameService.ListGames(c.Request.Context(), filter)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
-			"Title":        "Error",
-			"ErrorMessage": "Failed to load games: " + err.Error(),
-		})
-		return
+		return nil, err
 	}
 
-	c.HTML(http.StatusOK, "games/list.html", gin.H{
-		"Title":      "Games",
-		"Games":      games,
-		"TotalGames": len(games),
-	})
+	return gin.H{
+		"Title":        "Games",
+		"Games":        games,
+		"TotalGames":   total,
+		"Search":       filter.Search,
+		"Availability": availability,
+		"Tag":          tag,
+		"Sort":         filter.Sort,
+		"Order":        filter.Order,
+		"Pagination":   newWebPagination(filter.ListOptions, total, "/games/search-filter", "#games-container", "#search, #availability, #tag, #sort, #order"),
+	}, nil
 }
 
-// SearchGames handles POST /games/search - HTMX search for games
-func (h *GameWebHandler) SearchGames(c *gin.Context) {
-	query := strings.TrimSpace(c.PostForm("search"))
-	
-	var games []*models.Game
-	var err error
-	
-	if query == "" {
-		games, err = h.gameService.GetAllGames()
-	} else {
-		games, err = h.gameService.SearchGames(query)
-	}
-	
+// renderGameList renders the games selected by the list controls with the
+// template name
+func (h *GameWebHandler) renderGameList(c *gin.Context, name string) {
+	data, err := h.gameListData(c)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Search failed: " + err.Error(),
-		})
+		webListError(c, err, "Failed to load games")
 		return
 	}
 
-	c.HTML(http.StatusOK, "games/partials/games-grid.html", gin.H{
-		"Games": games,
-	})
+	renderWeb(c, http.StatusOK, name, data)
+}
+
+// ListGames handles GET /games - display games list page
+func (h *GameWebHandler) ListGames(c *gin.Context) {
+	h.renderGameList(c, "games/list.html")
+}
+
+// SearchGames handles POST /games/search - HTMX search for games
+func (h *GameWebHandler) SearchGames(c *gin.Context) {
+	h.renderGameList(c, "games/partials/games-grid.html")
 }
 
 // SearchFilterGames handles POST /games/search-filter - Combined HTMX search and filter
 func (h *GameWebHandler) SearchFilterGames(c *gin.Context) {
-	query := strings.TrimSpace(c.PostForm("search"))
-	availability := c.PostForm("availability")
-	
-	var games []*models.Game
-	var err error
-	
-	// First get games based on search query
-	if query == "" {
-		games, err = h.gameService.GetAllGames()
-	} else {
-		games, err = h.gameService.SearchGames(query)
+	h.renderGameList(c, "games/partials/games-container.html")
+}
+
+// FilterGames handles POST /games/filter - HTMX filter for games by availability
+func (h *GameWebHandler) FilterGames(c *gin.Context) {
+	h.renderGameList(c, "games/partials/games-grid.html")
+}
+
+// SortGames handles POST /games/sort - HTMX sort for games
+func (h *GameWebHandler) SortGames(c *gin.Context) {
+	h.renderGameList(c, "games/partials/games-container.html")
+}
+
+// ShowGame handles GET /games/:id - display game details modal
+func (h *GameWebHandler) ShowGame(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, ok := webID(c, "game")
+	if !ok {
+		return
 	}
-	
+
+	game, err := h.gameService.GetGame(ctx, id)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Search failed: " + err.Error(),
-		})
+		renderWebError(c, http.StatusNotFound, "Game not found")
 		return
 	}
-	
-	// Then filter by availability
-	if availability != "" && availability != "all" {
-		filteredGames := make([]*models.Game, 0)
-		for _, game := range games {
-			switch availability {
-			case "available":
-				if game.IsAvailable {
-					filteredGames = append(filteredGames, game)
-				}
-			case "borrowed":
-				if !game.IsAvailable {
-					filteredGames = append(filteredGames, game)
-				}
-			}
+
+	borrowings, err := h.gameService.GetGameBorrowingHistory(ctx, id)
+	if err != nil {
+		renderWebError(c, http.StatusInternalServerError, "Failed to load borrowing history: "+err.Error())
+		return
+	}
+
+	names := newWebNames(h.userService, h.gameService)
+	history := names.borrowings(ctx, borrowings)
+	data := gin.H{
+		"Title":            game.Name,
+		"Game":             game,
+		"Stats":            gameStats(game, history),
+		"BorrowingHistory": history,
+	}
+	for i := range history {
+		if history[i].ReturnedAt == nil {
+			data["CurrentBorrowing"] = &history[i]
+			break
 		}
-		games = filteredGames
 	}
 
-	// Return both grid and table views
-	c.HTML(http.StatusOK, "games/partials/games-container.html", gin.H{
-		"Games": games,
-	})
+	renderWeb(c, http.StatusOK, "games/detail.html", data)
 }
 
-// FilterGames handles POST /games/filter - HTMX filter for games by availability
-func (h *GameWebHandler) FilterGames(c *gin.Context) {
-	availability := c.PostForm("availability")
-	
-	var games []*models.Game
-	var err error
-	
-	switch availability {
-	case "available":
-		games, err = h.gameService.GetAvailableGames()
-	case "borrowed":
-		allGames, getAllErr := h.gameService.GetAllGames()
-		if getAllErr != nil {
-			err = getAllErr
-		} else {
-			games = make([]*models.Game, 0)
-			for _, game := range allGames {
-				if !game.IsAvailable {
-					games = append(games, game)
-				}
-			}
-		}
-	default: // "all"
-		games, err = h.gameService.GetAllGames()
+// GameHistory handles POST /games/:id/history - HTMX filter of the borrowing history of a game
+func (h *GameWebHandler) GameHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, ok := webID(c, "game")
+	if !ok {
+		return
 	}
-	
+
+	borrowings, err := h.gameService.GetGameBorrowingHistory(ctx, id)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Filter failed: " + err.Error(),
-		})
+		renderWebError(c, ErrorStatus(err), "Failed to load borrowing history: "+err.Error())
 		return
 	}
 
-	c.HTML(http.StatusOK, "games/partials/games-grid.html", gin.H{
-		"Games": games,
+	renderWeb(c, http.StatusOK, "games/partials/game-borrowing-history.html", gin.H{
+		"BorrowingHistory": filterBorrowingViews(newWebNames(h.userService, h.gameService).borrowings(ctx, borrowings), c.PostForm("status")),
 	})
 }
 
-// SortGames handles POST /games/sort - HTMX sort for games
-func (h *GameWebHandler) SortGames(c *gin.Context) {
-	field := c.PostForm("field")
-	direction := c.PostForm("direction")
-	
-	games, err := h.gameService.GetAllGames()
+// ShowNewGameForm handles GET /games/new - display new game form modal
+func (h *GameWebHandler) ShowNewGameForm(c *gin.Context) {
+	renderWeb(c, http.StatusOK, "games/new.html", gin.H{
+		"Title": "Add New Game",
+		"Game":  &models.Game{Condition: "good", IsAvailable: true},
+	})
+}
+
+// CreateGame handles POST /games - create a game from the new game form
+func (h *GameWebHandler) CreateGame(c *gin.Context) {
+	game := &models.Game{IsAvailable: true}
+	err := gameFromForm(c, game)
+	if err == nil {
+		err = actingGameService(c, h.gameService).CreateGame(c.Request.Context(), game)
+	}
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Sort failed: " + err.Error(),
+		renderWeb(c, ErrorStatus(err), "games/new.html", gin.H{
+			"Title":        "Add New Game",
+			"Game":         game,
+			"ErrorMessage": "Failed to add game: " + err.Error(),
 		})
 		return
 	}
 
-	// Sort games based on field and direction
-	// This is a simple implementation - in a real app you'd want to do this in the service/repository layer
-	sortGames(games, field, direction)
-
-	c.HTML(http.StatusOK, "games/partials/games-table.html", gin.H{
-		"Games":         games,
-		"SortField":     field,
-		"SortDirection": direction,
+	c.Header("HX-Trigger", "game-created, refresh-games")
+	renderWeb(c, http.StatusOK, "success.html", gin.H{
+		"Title":   "Game Added",
+		"Message": fmt.Sprintf("%s has been added to the library.", game.Name),
+		"Link":    fmt.Sprintf("/games/%d", game.ID),
 	})
 }
 
-// ShowGame handles GET /games/:id - display game details modal
-func (h *GameWebHandler) ShowGame(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid game ID",
-		})
+// ShowEditGameForm handles GET /games/:id/edit - display edit game form modal
+func (h *GameWebHandler) ShowEditGameForm(c *gin.Context) {
+	id, ok := webID(c, "game")
+	if !ok {
 		return
 	}
 
-	game, err := h.gameService.GetGame(id)
+	game, err := h.gameService.GetGame(c.Request.Context(), id)
 	if err != nil {
-		c.HTML(http.StatusNotFound, "partials/error.html", gin.H{
-			"ErrorMessage": "Game not found",
-		})
+		renderWebError(c, http.StatusNotFound, "Game not found")
 		return
 	}
 
-	// Get borrowing history
-	borrowings, _ := h.gameService.GetGameBorrowingHistory(id)
-
-	c.HTML(http.StatusOK, "games/detail.html", gin.H{
-		"Game":       game,
-		"Borrowings": borrowings,
+	renderWeb(c, http.StatusOK, "games/edit.html", gin.H{
+		"Title": "Edit " + game.Name,
+		"Game":  game,
 	})
 }
 
-// ShowNewGameForm handles GET /games/new - display new game form modal
-func (h *GameWebHandler) ShowNewGameForm(c *gin.Context) {
-	c.HTML(http.StatusOK, "games/new.html", gin.H{
-		"Title": "Add New Game",
-	})
-}
+// UpdateGame handles PUT /games/:id - save the edit game form
+func (h *GameWebHandler) UpdateGame(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, ok := webID(c, "game")
+	if !ok {
+		return
+	}
 
-// ShowEditGameForm handles GET /games/:id/edit - display edit game form modal
-func (h *GameWebHandler) ShowEditGameForm(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
+	game, err := h.gameService.GetGame(ctx, id)
 	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid game ID",
-		})
+		renderWebError(c, http.StatusNotFound, "Game not found")
 		return
 	}
 
-	game, err := h.gameService.GetGame(id)
+	err = gameFromForm(c, game)
+	if err == nil {
+		if available := c.PostForm("is_available"); available != "" {
+			game.IsAvailable = available == "true"
+		}
+		err = actingGameService(c, h.gameService).UpdateGame(ctx, game)
+	}
 	if err != nil {
-		c.HTML(http.StatusNotFound, "partials/error.html", gin.H{
-			"ErrorMessage": "Game not found",
+		renderWeb(c, ErrorStatus(err), "games/edit.html", gin.H{
+			"Title":        "Edit " + game.Name,
+			"Game":         game,
+			"ErrorMessage": "Failed to update game: " + err.Error(),
 		})
 		return
 	}
 
-	c.HTML(http.StatusOK, "games/edit.html", gin.H{
-		"Game": game,
+	c.Header("HX-Trigger", "game-updated, refresh-games")
+	renderWeb(c, http.StatusOK, "success.html", gin.H{
+		"Title":   "Game Updated",
+		"Message": fmt.Sprintf("%s has been updated.", game.Name),
+		"Link":    fmt.Sprintf("/games/%d", game.ID),
+	})
+}
+
+// DeleteGame handles DELETE /games/:id - remove a game never borrowed
+func (h *GameWebHandler) DeleteGame(c *gin.Context) {
+	id, ok := webID(c, "game")
+	if !ok {
+		return
+	}
+
+	if err := actingGameService(c, h.gameService).DeleteGame(c.Request.Context(), id); err != nil {
+		renderWebError(c, ErrorStatus(err), "Failed to delete game: "+err.Error())
+		return
+	}
+
+	c.Header("HX-Trigger", "game-deleted, refresh-games")
+	renderWeb(c, http.StatusOK, "success.html", gin.H{
+		"Title":   "Game Deleted",
+		"Message": "The game has been removed from the library.",
 	})
 }
 
@@ -237,39 +276,83 @@ func (h *GameWebHandler) RegisterWebRoutes(router *gin.RouterGroup) {
 	games := router.Group("/games")
 	{
 		games.GET("", h.ListGames)
+		games.POST("", h.CreateGame)
 		games.POST("/search", h.SearchGames)
 		games.POST("/search-filter", h.SearchFilterGames)
 		games.POST("/filter", h.FilterGames)
 		games.POST("/sort", h.SortGames)
 		games.GET("/new", h.ShowNewGameForm)
 		games.GET("/:id", h.ShowGame)
+		games.PUT("/:id", h.UpdateGame)
+		games.DELETE("/:id", h.DeleteGame)
 		games.GET("/:id/edit", h.ShowEditGameForm)
+		games.POST("/:id/history", h.GameHistory)
 	}
 }
 
-// sortGames sorts games slice based on field and direction
-func sortGames(games []*models.Game, field, direction string) {
-	switch field {
-	case "name":
-		sort.Slice(games, func(i, j int) bool {
-			if direction == "desc" {
-				return games[i].Name > games[j].Name
+// gameFromForm copies the fields of the game forms into game. The
+// BoardGameGeek picture and ID are not on the forms and are kept.
+func gameFromForm(c *gin.Context, game *models.Game) error {
+	game.Name = strings.TrimSpace(c.PostForm("name"))
+	game.Description = strings.TrimSpace(c.PostForm("description"))
+	game.Tags = models.ParseTags(c.PostForm("tags"))
+	game.Condition = strings.TrimSpace(c.PostForm("condition"))
+	if game.Condition == "" {
+		game.Condition = "good"
+	}
+
+	details, err := GameDetailsFromForm(c)
+	details.ImageURL = game.ImageURL
+	details.BGGID = game.BGGID
+	game.GameDetails = details
+	if err != nil {
+		return fmt.Errorf("invalid game details: %w", err)
+	}
+	return nil
+}
+
+// gameStats sums up the borrowing history of a game
+func gameStats(game *models.Game, history []BorrowingView) GameStats {
+	stats := GameStats{TotalBorrows: len(history)}
+
+	daysOut, returned, returnedDays := 0, 0, 0
+	for _, borrowing := range history {
+		daysOut += borrowing.Duration()
+		if borrowing.ReturnedAt != nil {
+			returned++
+			returnedDays += borrowing.Duration()
+		}
+	}
+	if returned > 0 {
+		stats.AvgLoanDays = returnedDays / returned
+	}
+	if days := int(time.Since(game.EntryDate).Hours()/24) - daysOut; days > 0 {
+		stats.DaysAvailable = days
+	}
+
+	return stats
+}
+
+// filterBorrowingViews keeps the borrowings with a status: active, returned
+// or overdue; any other status keeps them all
+func filterBorrowingViews(borrowings []BorrowingView, status string) []BorrowingView {
+	filtered := make([]BorrowingView, 0, len(borrowings))
+	for _, borrowing := range borrowings {
+		switch status {
+		case models.BorrowingStatusActive:
+			if borrowing.ReturnedAt != nil {
+				continue
 			}
-			return games[i].Name < games[j].Name
-		})
-	case "entry_date":
-		sort.Slice(games, func(i, j int) bool {
-			if direction == "desc" {
-				return games[i].EntryDate.After(games[j].EntryDate)
+		case models.BorrowingStatusReturned:
+			if borrowing.ReturnedAt == nil {
+				continue
 			}
-			return games[i].EntryDate.Before(games[j].EntryDate)
-		})
-	case "category":
-		sort.Slice(games, func(i, j int) bool {
-			if direction == "desc" {
-				return games[i].Category > games[j].Category
+		case models.BorrowingStatusOverdue:
+			if !borrowing.IsCurrentlyOverdue() {
+				continue
 			}
-			return games[i].Category < games[j].Category
-		})
+		}
+		filtered = append(filtered, borrowing)
 	}
-}
\ No newline at end of file
+	return filtered
+}
@@ -2,6 +2,11 @@ package handlers
 
 import (
 	"board-game-library/internal/models"
+	"board-game-library/pkg/bgg"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,15 +18,12 @@ type MockGameServiceInterface struct {
 	mock.Mock
 }
 
-func (m *MockGameServiceInterface) AddGame(name, description, category, condition string) (*models.Game, error) {
-	args := m.Called(name, description, category, condition)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*models.Game), args.Error(1)
+func (m *MockGameServiceInterface) CreateGame(ctx context.Context, game *models.Game) error {
+	args := m.Called(game)
+	return args.Error(0)
 }
 
-func (m *MockGameServiceInterface) GetGame(id int) (*models.Game, error) {
+func (m *MockGameServiceInterface) GetGame(ctx context.Context, id int) (*models.Game, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -29,7 +31,7 @@ func (m *MockGameServiceInterface) GetGame(id int) (*models.Game, error) {
 	return args.Get(0).(*models.Game), args.Error(1)
 }
 
-func (m *MockGameServiceInterface) GetAllGames() ([]*models.Game, error) {
+func (m *MockGameServiceInterface) GetAllGames(ctx context.Context) ([]*models.Game, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -37,7 +39,15 @@ func (m *MockGameServiceInterface) GetAllGames() ([]*models.Game, error) {
 	return args.Get(0).([]*models.Game), args.Error(1)
 }
 
-func (m *MockGameServiceInterface) GetAvailableGames() ([]*models.Game, error) {
+func (m *MockGameServiceInterface) ListGames(ctx context.Context, filter models.GameFilter) ([]*models.Game, int, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.Game), args.Int(1), args.Error(2)
+}
+
+func (m *MockGameServiceInterface) GetAvailableGames(ctx context.Context) ([]*models.Game, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -45,7 +55,7 @@ func (m *MockGameServiceInterface) GetAvailableGames() ([]*models.Game, error) {
 	return args.Get(0).([]*models.Game), args.Error(1)
 }
 
-func (m *MockGameServiceInterface) SearchGames(query string) ([]*models.Game, error) {
+func (m *MockGameServiceInterface) SearchGames(ctx context.Context, query string) ([]*models.Game, error) {
 	args := m.Called(query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -53,12 +63,12 @@ func (m *MockGameServiceInterface) SearchGames(query string) ([]*models.Game, er
 	return args.Get(0).([]*models.Game), args.Error(1)
 }
 
-func (m *MockGameServiceInterface) UpdateGame(game *models.Game) error {
+func (m *MockGameServiceInterface) UpdateGame(ctx context.Context, game *models.Game) error {
 	args := m.Called(game)
 	return args.Error(0)
 }
 
-func (m *MockGameServiceInterface) GetGameBorrowingHistory(gameID int) ([]*models.Borrowing, error) {
+func (m *MockGameServiceInterface) GetGameBorrowingHistory(ctx context.Context, gameID int) ([]*models.Borrowing, error) {
 	args := m.Called(gameID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -66,17 +76,17 @@ func (m *MockGameServiceInterface) GetGameBorrowingHistory(gameID int) ([]*model
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockGameServiceInterface) SetGameAvailability(gameID int, isAvailable bool) error {
+func (m *MockGameServiceInterface) SetGameAvailability(ctx context.Context, gameID int, isAvailable bool) error {
 	args := m.Called(gameID, isAvailable)
 	return args.Error(0)
 }
 
-func (m *MockGameServiceInterface) IsGameAvailable(gameID int) (bool, error) {
+func (m *MockGameServiceInterface) IsGameAvailable(ctx context.Context, gameID int) (bool, error) {
 	args := m.Called(gameID)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockGameServiceInterface) GetCurrentBorrower(gameID int) (*models.Borrowing, error) {
+func (m *MockGameServiceInterface) GetCurrentBorrower(ctx context.Context, gameID int) (*models.Borrowing, error) {
 	args := m.Called(gameID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -84,11 +94,88 @@ func (m *MockGameServiceInterface) GetCurrentBorrower(gameID int) (*models.Borro
 	return args.Get(0).(*models.Borrowing), args.Error(1)
 }
 
-func (m *MockGameServiceInterface) DeleteGame(gameID int) error {
+func (m *MockGameServiceInterface) DeleteGame(ctx context.Context, gameID int) error {
 	args := m.Called(gameID)
 	return args.Error(0)
 }
 
+func (m *MockGameServiceInterface) GetCurrentBorrowers(ctx context.Context, gameID int) ([]*models.Borrowing, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Borrowing), args.Error(1)
+}
+
+func (m *MockGameServiceInterface) GetGameCopies(ctx context.Context, gameID int) ([]*models.GameCopy, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameServiceInterface) AddCopy(ctx context.Context, gameID int, barcode, condition string) (*models.GameCopy, error) {
+	args := m.Called(gameID, barcode, condition)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameServiceInterface) UpdateCopy(ctx context.Context, gameID, copyID int, barcode, condition string) (*models.GameCopy, error) {
+	args := m.Called(gameID, copyID, barcode, condition)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameServiceInterface) RemoveCopy(ctx context.Context, gameID, copyID int) error {
+	args := m.Called(gameID, copyID)
+	return args.Error(0)
+}
+
+func (m *MockGameServiceInterface) ReportCopyCondition(ctx context.Context, gameID, copyID int, report *models.ConditionReport) (*models.GameCopy, error) {
+	args := m.Called(gameID, copyID, report)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameServiceInterface) GetConditionHistory(ctx context.Context, gameID int) ([]*models.ConditionChange, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ConditionChange), args.Error(1)
+}
+
+func (m *MockGameServiceInterface) SearchBGG(ctx context.Context, query string) ([]bgg.SearchResult, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]bgg.SearchResult), args.Error(1)
+}
+
+func (m *MockGameServiceInterface) PreviewBGGGame(ctx context.Context, bggID int) (*models.Game, error) {
+	args := m.Called(bggID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Game), args.Error(1)
+}
+
+func (m *MockGameServiceInterface) ImportBGGGame(ctx context.Context, bggID int, condition string) (*models.Game, error) {
+	args := m.Called(bggID, condition)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Game), args.Error(1)
+}
+
 func TestGameWebHandler_SearchFilterGames_Logic(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -181,9 +268,105 @@ func TestGameWebHandler_SearchFilterGames_Logic(t *testing.T) {
 func TestGameWebHandler_ServiceIntegration(t *testing.T) {
 	// Test that the handler correctly calls the service methods
 	mockGameService := new(MockGameServiceInterface)
-	handler := NewGameWebHandler(mockGameService)
+	handler := NewGameWebHandler(mockGameService, new(MockUserServiceInterface))
 
 	// Test that the handler is properly initialized
 	assert.NotNil(t, handler)
 	assert.NotNil(t, handler.gameService)
-}
\ No newline at end of file
+}
+
+func TestGameWebHandler_SearchHighlightsMatches(t *testing.T) {
+	mockGameService := new(MockGameServiceInterface)
+	handler := NewGameWebHandler(mockGameService, new(MockUserServiceInterface))
+	router := newWebTestRouter(t)
+	handler.RegisterWebRoutes(router.Group("/"))
+
+	game := &models.Game{
+		ID: 1, Name: "Ticket to Ride", Description: "Build <train> routes", Condition: "good", IsAvailable: true, TotalCopies: 1, AvailableCopies: 1,
+		Match: &models.GameSearchMatch{
+			Name:              "Ticket to Ride",
+			NameHighlights:    []models.TextRange{{Start: 10, End: 14}},
+			Snippet:           "Build <train> routes",
+			SnippetHighlights: []models.TextRange{{Start: 14, End: 20}},
+		},
+	}
+	mockGameService.On("ListGames", mock.MatchedBy(func(f models.GameFilter) bool {
+		return f.Search == "ride"
+	})).Return([]*models.Game{game}, 1, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/games/search-filter", strings.NewReader("search=ride"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, "Ticket to <mark>Ride</mark>")
+	assert.Contains(t, body, "Build &lt;train&gt; <mark>routes</mark>")
+	assert.NotContains(t, body, "<train>")
+	mockGameService.AssertExpectations(t)
+}
+
+func TestGameWebHandler_ShowGame(t *testing.T) {
+	mockGameService := new(MockGameServiceInterface)
+	handler := NewGameWebHandler(mockGameService, new(MockUserServiceInterface))
+	router := newWebTestRouter(t)
+	handler.RegisterWebRoutes(router.Group("/"))
+
+	game := &models.Game{ID: 1, Name: "<script>alert('pwned')</script>", Description: "Trains & <b>tickets</b>", Condition: "good", IsAvailable: true, TotalCopies: 1, AvailableCopies: 1}
+	mockGameService.On("GetGame", 1).Return(game, nil)
+	mockGameService.On("GetGameBorrowingHistory", 1).Return([]*models.Borrowing{}, nil)
+
+	t.Run("escapes the game", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/games/1", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.Contains(t, body, "<!DOCTYPE html>")
+		assert.NotContains(t, body, "<script>alert")
+		assert.Contains(t, body, "&lt;script&gt;")
+		assert.Contains(t, body, "Trains &amp; &lt;b&gt;tickets&lt;/b&gt;")
+	})
+
+	t.Run("modal requested by HTMX", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/games/1", nil)
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "<!DOCTYPE html>")
+		assert.Contains(t, w.Body.String(), `hx-get="/games/1/edit"`)
+	})
+
+	t.Run("unknown game", func(t *testing.T) {
+		mockGameService.On("GetGame", 2).Return(nil, assert.AnError)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/games/2", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Game not found")
+	})
+}
+
+func TestGameWebHandler_ShowEditGameForm(t *testing.T) {
+	mockGameService := new(MockGameServiceInterface)
+	handler := NewGameWebHandler(mockGameService, new(MockUserServiceInterface))
+	router := newWebTestRouter(t)
+	handler.RegisterWebRoutes(router.Group("/"))
+
+	game := &models.Game{ID: 1, Name: `Ticket "to" Ride`, Condition: "fair", Tags: []string{"Famille"}}
+	mockGameService.On("GetGame", 1).Return(game, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/games/1/edit", nil)
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, `hx-put="/games/1"`)
+	assert.Contains(t, body, `value="Ticket &#34;to&#34; Ride"`)
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"board-game-library/internal/jobs"
+	"board-game-library/internal/models"
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobManagerInterface defines the interface for background job management operations
+type JobManagerInterface interface {
+	GetJobs(ctx context.Context) map[string]*jobs.Job
+	GetJobStatus(ctx context.Context, jobName string) (*jobs.Job, error)
+	GetJobExecutionsPage(ctx context.Context, jobName string, limit, offset int) ([]jobs.JobExecution, int, error)
+	GetJobStatistics(ctx context.Context) jobs.JobStatistics
+	GetHealthStatus(ctx context.Context) jobs.HealthStatus
+	RunJobNow(ctx context.Context, jobName string) error
+	EnableJob(ctx context.Context, jobName string) error
+	DisableJob(ctx context.Context, jobName string) error
+	RescheduleJob(ctx context.Context, jobName, spec string) error
+}
+
+// JobHandler handles HTTP requests for background job administration
+type JobHandler struct {
+	jobManager JobManagerInterface
+}
+
+// NewJobHandler creates a new JobHandler instance
+func NewJobHandler(jobManager JobManagerInterface) *JobHandler {
+	return &JobHandler{
+		jobManager: jobManager,
+	}
+}
+
+// GetJobs handles GET /api/jobs - list all registered jobs
+// @Summary Lister les tâches planifiées
+// @Description Récupère la liste des tâches en arrière-plan et l'état du planificateur
+// @Tags jobs
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Liste des tâches"
+// @Router /jobs [get]
+func (h *JobHandler) GetJobs(c *gin.Context) {
+	jobMap := h.jobManager.GetJobs(c.Request.Context())
+
+	jobList := make([]*jobs.Job, 0, len(jobMap))
+	for _, job := range jobMap {
+		jobList = append(jobList, job)
+	}
+	sort.Slice(jobList, func(i, j int) bool {
+		return jobList[i].Name < jobList[j].Name
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":   jobList,
+		"count":  len(jobList),
+		"health": h.jobManager.GetHealthStatus(c.Request.Context()),
+	})
+}
+
+// GetJob handles GET /api/jobs/:name - get a job's status
+// @Summary Obtenir une tâche planifiée
+// @Description Récupère l'état d'une tâche en arrière-plan
+// @Tags jobs
+// @Produce json
+// @Param name path string true "Nom de la tâche"
+// @Success 200 {object} map[string]interface{} "Tâche"
+// @Failure 404 {object} Problem "Tâche non trouvée"
+// @Router /jobs/{name} [get]
+func (h *JobHandler) GetJob(c *gin.Context) {
+	name := c.Param("name")
+
+	job, err := h.jobManager.GetJobStatus(c.Request.Context(), name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job": job,
+	})
+}
+
+// GetJobExecutions handles GET /api/jobs/executions - get job execution history
+// @Summary Historique des exécutions
+// @Description Récupère l'historique paginé des exécutions des tâches, les plus récentes en premier
+// @Tags jobs
+// @Produce json
+// @Param limit query int false "Nombre maximum d'exécutions" default(50)
+// @Param offset query int false "Nombre d'exécutions à ignorer" default(0)
+// @Param job query string false "Filtrer par nom de tâche"
+// @Success 200 {object} map[string]interface{} "Historique des exécutions"
+// @Failure 400 {object} Problem "Paramètres invalides"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /jobs/executions [get]
+func (h *JobHandler) GetJobExecutions(c *gin.Context) {
+	limit, ok := parseNonNegativeQuery(c, "limit", 50)
+	if !ok {
+		return
+	}
+
+	offset, ok := parseNonNegativeQuery(c, "offset", 0)
+	if !ok {
+		return
+	}
+
+	jobName := c.Query("job")
+
+	executions, total, err := h.jobManager.GetJobExecutionsPage(c.Request.Context(), jobName, limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if executions == nil {
+		executions = []jobs.JobExecution{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"executions": executions,
+		"count":      len(executions),
+		"total":      total,
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
+
+// parseNonNegativeQuery reads an optional non-negative integer query parameter,
+// reporting a validation error and returning false when it is invalid
+func parseNonNegativeQuery(c *gin.Context, name string, defaultValue int) (int, bool) {
+	param := c.Query(name)
+	if param == "" {
+		return defaultValue, true
+	}
+
+	value, err := strconv.Atoi(param)
+	if err != nil || value < 0 {
+		c.Error(models.Invalid(name, "invalid_non_negative_integer", name))
+		return 0, false
+	}
+
+	return value, true
+}
+
+// GetJobStatistics handles GET /api/jobs/statistics - get job execution statistics
+// @Summary Statistiques des tâches
+// @Description Récupère les statistiques de réussite des tâches en arrière-plan
+// @Tags jobs
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Statistiques"
+// @Router /jobs/statistics [get]
+func (h *JobHandler) GetJobStatistics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"statistics": h.jobManager.GetJobStatistics(c.Request.Context()),
+	})
+}
+
+// RunJob handles POST /api/jobs/:name/run - trigger a job immediately
+// @Summary Exécuter une tâche
+// @Description Lance immédiatement une tâche en arrière-plan
+// @Tags jobs
+// @Produce json
+// @Param name path string true "Nom de la tâche"
+// @Success 202 {object} map[string]interface{} "Tâche lancée"
+// @Failure 404 {object} Problem "Tâche non trouvée"
+// @Failure 409 {object} Problem "Tâche désactivée ou déjà en cours"
+// @Failure 503 {object} Problem "Planificateur arrêté"
+// @Router /jobs/{name}/run [post]
+func (h *JobHandler) RunJob(c *gin.Context) {
+	name := c.Param("name")
+
+	if err := h.jobManager.RunJobNow(c.Request.Context(), name); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Job started successfully",
+		"job":     name,
+	})
+}
+
+// EnableJob handles PUT /api/jobs/:name/enable - enable a job
+// @Summary Activer une tâche
+// @Description Active une tâche en arrière-plan
+// @Tags jobs
+// @Produce json
+// @Param name path string true "Nom de la tâche"
+// @Success 200 {object} map[string]interface{} "Tâche activée"
+// @Failure 404 {object} Problem "Tâche non trouvée"
+// @Router /jobs/{name}/enable [put]
+func (h *JobHandler) EnableJob(c *gin.Context) {
+	name := c.Param("name")
+
+	if err := h.jobManager.EnableJob(c.Request.Context(), name); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job enabled successfully",
+		"job":     name,
+	})
+}
+
+// DisableJob handles PUT /api/jobs/:name/disable - disable a job
+// @Summary Désactiver une tâche
+// @Description Désactive une tâche en arrière-plan
+// @Tags jobs
+// @Produce json
+// @Param name path string true "Nom de la tâche"
+// @Success 200 {object} map[string]interface{} "Tâche désactivée"
+// @Failure 404 {object} Problem "Tâche non trouvée"
+// @Router /jobs/{name}/disable [put]
+func (h *JobHandler) DisableJob(c *gin.Context) {
+	name := c.Param("name")
+
+	if err := h.jobManager.DisableJob(c.Request.Context(), name); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job disabled successfully",
+		"job":     name,
+	})
+}
+
+// RescheduleJobRequest represents the request body for changing a job's schedule
+type RescheduleJobRequest struct {
+	Schedule string `json:"schedule" binding:"required"`
+}
+
+// RescheduleJob handles PUT /api/jobs/:name/schedule - change a job's schedule
+// @Summary Planifier une tâche
+// @Description Modifie la planification d'une tâche : durée ("6h") ou expression cron ("0 8 * * *")
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Param name path string true "Nom de la tâche"
+// @Param schedule body RescheduleJobRequest true "Nouvelle planification"
+// @Success 200 {object} map[string]interface{} "Tâche replanifiée"
+// @Failure 400 {object} Problem "Planification invalide"
+// @Failure 404 {object} Problem "Tâche non trouvée"
+// @Router /jobs/{name}/schedule [put]
+func (h *JobHandler) RescheduleJob(c *gin.Context) {
+	name := c.Param("name")
+
+	var req RescheduleJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	if err := h.jobManager.RescheduleJob(c.Request.Context(), name, req.Schedule); err != nil {
+		c.Error(err)
+		return
+	}
+
+	job, err := h.jobManager.GetJobStatus(c.Request.Context(), name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job rescheduled successfully",
+		"job":     job,
+	})
+}
+
+// RegisterRoutes registers all job-related routes
+func (h *JobHandler) RegisterRoutes(router *gin.RouterGroup) {
+	jobRoutes := router.Group("/jobs")
+	{
+		jobRoutes.GET("", h.GetJobs)
+		jobRoutes.GET("/executions", h.GetJobExecutions)
+		jobRoutes.GET("/statistics", h.GetJobStatistics)
+		jobRoutes.GET("/:name", h.GetJob)
+		jobRoutes.POST("/:name/run", h.RunJob)
+		jobRoutes.PUT("/:name/enable", h.EnableJob)
+		jobRoutes.PUT("/:name/disable", h.DisableJob)
+		jobRoutes.PUT("/:name/schedule", h.RescheduleJob)
+	}
+}
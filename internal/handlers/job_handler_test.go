@@ -0,0 +1,344 @@
+package handlers
+
+import (
+	"board-game-library/internal/jobs"
+	"board-game-library/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockJobManager is a mock implementation of JobManagerInterface
+type MockJobManager struct {
+	mock.Mock
+}
+
+func (m *MockJobManager) GetJobs(ctx context.Context) map[string]*jobs.Job {
+	args := m.Called()
+	return args.Get(0).(map[string]*jobs.Job)
+}
+
+func (m *MockJobManager) GetJobStatus(ctx context.Context, jobName string) (*jobs.Job, error) {
+	args := m.Called(jobName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*jobs.Job), args.Error(1)
+}
+
+func (m *MockJobManager) GetJobExecutionsPage(ctx context.Context, jobName string, limit, offset int) ([]jobs.JobExecution, int, error) {
+	args := m.Called(jobName, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]jobs.JobExecution), args.Int(1), args.Error(2)
+}
+
+func (m *MockJobManager) GetJobStatistics(ctx context.Context) jobs.JobStatistics {
+	args := m.Called()
+	return args.Get(0).(jobs.JobStatistics)
+}
+
+func (m *MockJobManager) GetHealthStatus(ctx context.Context) jobs.HealthStatus {
+	args := m.Called()
+	return args.Get(0).(jobs.HealthStatus)
+}
+
+func (m *MockJobManager) RunJobNow(ctx context.Context, jobName string) error {
+	args := m.Called(jobName)
+	return args.Error(0)
+}
+
+func (m *MockJobManager) EnableJob(ctx context.Context, jobName string) error {
+	args := m.Called(jobName)
+	return args.Error(0)
+}
+
+func (m *MockJobManager) DisableJob(ctx context.Context, jobName string) error {
+	args := m.Called(jobName)
+	return args.Error(0)
+}
+
+func (m *MockJobManager) RescheduleJob(ctx context.Context, jobName, spec string) error {
+	args := m.Called(jobName, spec)
+	return args.Error(0)
+}
+
+func setupJobHandlerTest() (*gin.Engine, *MockJobManager) {
+	gin.SetMode(gin.TestMode)
+
+	mockManager := &MockJobManager{}
+	handler := NewJobHandler(mockManager)
+
+	router := gin.New()
+	router.Use(Problems())
+	api := router.Group("/api")
+	handler.RegisterRoutes(api)
+
+	return router, mockManager
+}
+
+func TestJobHandler_GetJobs(t *testing.T) {
+	router, mockManager := setupJobHandlerTest()
+
+	mockManager.On("GetJobs").Return(map[string]*jobs.Job{
+		"reminder-alerts": {Name: "reminder-alerts", Schedule: 24 * time.Hour, Enabled: true},
+		"overdue-alerts":  {Name: "overdue-alerts", Schedule: 24 * time.Hour, Enabled: false},
+	})
+	mockManager.On("GetHealthStatus").Return(jobs.HealthStatus{IsRunning: true, TotalJobs: 2, EnabledJobs: 1, DisabledJobs: 1})
+
+	req, _ := http.NewRequest("GET", "/api/jobs", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), response["count"])
+
+	jobList := response["jobs"].([]interface{})
+	assert.Equal(t, "overdue-alerts", jobList[0].(map[string]interface{})["name"])
+	assert.Equal(t, "reminder-alerts", jobList[1].(map[string]interface{})["name"])
+
+	health := response["health"].(map[string]interface{})
+	assert.Equal(t, true, health["is_running"])
+
+	mockManager.AssertExpectations(t)
+}
+
+func TestJobHandler_GetJob(t *testing.T) {
+	t.Run("existing job", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("GetJobStatus", "overdue-alerts").Return(&jobs.Job{Name: "overdue-alerts", Enabled: true}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/jobs/overdue-alerts", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockManager.AssertExpectations(t)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("GetJobStatus", "unknown").Return(nil, models.NotFound("job_not_found", "unknown"))
+
+		req, _ := http.NewRequest("GET", "/api/jobs/unknown", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockManager.AssertExpectations(t)
+	})
+}
+
+func TestJobHandler_GetJobExecutions(t *testing.T) {
+	executions := []jobs.JobExecution{
+		{ID: "3", JobName: "overdue-alerts", Status: jobs.JobStatusCompleted},
+		{ID: "2", JobName: "cleanup-alerts", Status: jobs.JobStatusFailed, Error: "boom"},
+		{ID: "1", JobName: "overdue-alerts", Status: jobs.JobStatusCompleted},
+	}
+
+	t.Run("default pagination", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("GetJobExecutionsPage", "", 50, 0).Return(executions, 3, nil)
+
+		req, _ := http.NewRequest("GET", "/api/jobs/executions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, float64(3), response["count"])
+		assert.Equal(t, float64(3), response["total"])
+		mockManager.AssertExpectations(t)
+	})
+
+	t.Run("filtered page", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("GetJobExecutionsPage", "overdue-alerts", 1, 1).Return(executions[2:], 2, nil)
+
+		req, _ := http.NewRequest("GET", "/api/jobs/executions?job=overdue-alerts&limit=1&offset=1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, float64(1), response["count"])
+		assert.Equal(t, float64(2), response["total"])
+		assert.Equal(t, float64(1), response["offset"])
+		first := response["executions"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "1", first["id"])
+		mockManager.AssertExpectations(t)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		router, _ := setupJobHandlerTest()
+
+		req, _ := http.NewRequest("GET", "/api/jobs/executions?limit=abc", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid offset", func(t *testing.T) {
+		router, _ := setupJobHandlerTest()
+
+		req, _ := http.NewRequest("GET", "/api/jobs/executions?offset=-5", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("store error", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("GetJobExecutionsPage", "", 50, 0).Return(nil, 0, fmt.Errorf("database error"))
+
+		req, _ := http.NewRequest("GET", "/api/jobs/executions", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockManager.AssertExpectations(t)
+	})
+}
+
+func TestJobHandler_RunJob(t *testing.T) {
+	t.Run("successful trigger", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("RunJobNow", "overdue-alerts").Return(nil)
+
+		req, _ := http.NewRequest("POST", "/api/jobs/overdue-alerts/run", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		mockManager.AssertExpectations(t)
+	})
+
+	t.Run("disabled job", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("RunJobNow", "overdue-alerts").Return(models.Conflict("job_disabled", "overdue-alerts"))
+
+		req, _ := http.NewRequest("POST", "/api/jobs/overdue-alerts/run", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockManager.AssertExpectations(t)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("RunJobNow", "unknown").Return(models.NotFound("job_not_found", "unknown"))
+
+		req, _ := http.NewRequest("POST", "/api/jobs/unknown/run", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockManager.AssertExpectations(t)
+	})
+}
+
+func TestJobHandler_EnableDisableJob(t *testing.T) {
+	t.Run("enable", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("EnableJob", "cleanup-alerts").Return(nil)
+
+		req, _ := http.NewRequest("PUT", "/api/jobs/cleanup-alerts/enable", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockManager.AssertExpectations(t)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("DisableJob", "cleanup-alerts").Return(nil)
+
+		req, _ := http.NewRequest("PUT", "/api/jobs/cleanup-alerts/disable", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockManager.AssertExpectations(t)
+	})
+
+	t.Run("disable unknown job", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("DisableJob", "unknown").Return(models.NotFound("job_not_found", "unknown"))
+
+		req, _ := http.NewRequest("PUT", "/api/jobs/unknown/disable", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockManager.AssertExpectations(t)
+	})
+}
+
+func TestJobHandler_RescheduleJob(t *testing.T) {
+	t.Run("cron schedule", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("RescheduleJob", "overdue-alerts", "0 8 * * *").Return(nil)
+		mockManager.On("GetJobStatus", "overdue-alerts").Return(&jobs.Job{Name: "overdue-alerts", Cron: "0 8 * * *", Enabled: true}, nil)
+
+		body, _ := json.Marshal(RescheduleJobRequest{Schedule: "0 8 * * *"})
+		req, _ := http.NewRequest("PUT", "/api/jobs/overdue-alerts/schedule", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		job := response["job"].(map[string]interface{})
+		assert.Equal(t, "0 8 * * *", job["cron"])
+		mockManager.AssertExpectations(t)
+	})
+
+	t.Run("invalid schedule", func(t *testing.T) {
+		router, mockManager := setupJobHandlerTest()
+		mockManager.On("RescheduleJob", "overdue-alerts", "every day").Return(models.Invalid("schedule", "invalid_schedule", "every day", "expected exactly 5 fields"))
+
+		body, _ := json.Marshal(RescheduleJobRequest{Schedule: "every day"})
+		req, _ := http.NewRequest("PUT", "/api/jobs/overdue-alerts/schedule", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockManager.AssertExpectations(t)
+	})
+
+	t.Run("missing schedule", func(t *testing.T) {
+		router, _ := setupJobHandlerTest()
+
+		req, _ := http.NewRequest("PUT", "/api/jobs/overdue-alerts/schedule", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Alert read statuses accepted by the alert list
+const (
+	AlertStatusUnread = "unread"
+	AlertStatusRead   = "read"
+	AlertStatusAll    = "all"
+)
+
+// ListOptionsFromQuery reads page, per_page, sort and order from the query
+// string. The page and page size default to the first page of
+// models.DefaultPerPage rows; sort fields are checked by the services.
+func ListOptionsFromQuery(c *gin.Context) (models.ListOptions, error) {
+	options := models.ListOptions{
+		Page:    1,
+		PerPage: models.DefaultPerPage,
+		Sort:    c.Query("sort"),
+		Order:   strings.ToLower(c.Query("order")),
+	}
+
+	var err error
+	if options.Page, err = queryPositiveInt(c, "page", options.Page); err != nil {
+		return options, err
+	}
+	if options.PerPage, err = queryPositiveInt(c, "per_page", options.PerPage); err != nil {
+		return options, err
+	}
+	if options.PerPage > models.MaxPerPage {
+		options.PerPage = models.MaxPerPage
+	}
+
+	return options, nil
+}
+
+// GameFilterFromQuery reads a game filter from the query string
+func GameFilterFromQuery(c *gin.Context) (models.GameFilter, error) {
+	filter := models.GameFilter{
+		Search:    c.Query("search"),
+		Tags:      c.QueryArray("tag"),
+		Condition: c.Query("condition"),
+		Publisher: c.Query("publisher"),
+		Designer:  c.Query("designer"),
+	}
+
+	var err error
+	if filter.ListOptions, err = ListOptionsFromQuery(c); err != nil {
+		return filter, err
+	}
+	if filter.Available, err = queryBool(c, "available"); err != nil {
+		return filter, err
+	}
+	if filter.Players, err = queryPositiveInt(c, "players", 0); err != nil {
+		return filter, err
+	}
+	if filter.MaxPlayTime, err = queryPositiveInt(c, "max_play_time", 0); err != nil {
+		return filter, err
+	}
+	if filter.Age, err = queryPositiveInt(c, "age", 0); err != nil {
+		return filter, err
+	}
+	if filter.YearFrom, err = queryInt(c, "year_from"); err != nil {
+		return filter, err
+	}
+	if filter.YearTo, err = queryInt(c, "year_to"); err != nil {
+		return filter, err
+	}
+	if filter.MinComplexity, err = queryFloat(c, "min_complexity"); err != nil {
+		return filter, err
+	}
+	if filter.MaxComplexity, err = queryFloat(c, "max_complexity"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// UserFilterFromQuery reads a user filter from the query string
+func UserFilterFromQuery(c *gin.Context) (models.UserFilter, error) {
+	filter := models.UserFilter{
+		Search:         c.Query("search"),
+		MembershipTier: c.Query("membership_tier"),
+		Role:           c.Query("role"),
+	}
+
+	var err error
+	if filter.ListOptions, err = ListOptionsFromQuery(c); err != nil {
+		return filter, err
+	}
+	if filter.Active, err = queryBool(c, "active"); err != nil {
+		return filter, err
+	}
+	if filter.HasLoans, err = queryBool(c, "has_loans"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// BorrowingFilterFromQuery reads a borrowing filter from the query string.
+// Dates are either YYYY-MM-DD or RFC 3339; a plain _to date includes the
+// whole day.
+func BorrowingFilterFromQuery(c *gin.Context) (models.BorrowingFilter, error) {
+	filter := models.BorrowingFilter{
+		Search: strings.TrimSpace(c.Query("search")),
+		Status: c.Query("status"),
+	}
+
+	var err error
+	if filter.ListOptions, err = ListOptionsFromQuery(c); err != nil {
+		return filter, err
+	}
+	if filter.UserID, err = queryPositiveInt(c, "user_id", 0); err != nil {
+		return filter, err
+	}
+	if filter.GameID, err = queryPositiveInt(c, "game_id", 0); err != nil {
+		return filter, err
+	}
+	if filter.BorrowedFrom, err = parseAuditTime(c, "borrowed_from", false); err != nil {
+		return filter, err
+	}
+	if filter.BorrowedTo, err = parseAuditTime(c, "borrowed_to", true); err != nil {
+		return filter, err
+	}
+	if filter.DueFrom, err = parseAuditTime(c, "due_from", false); err != nil {
+		return filter, err
+	}
+	if filter.DueTo, err = parseAuditTime(c, "due_to", true); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// AlertFilterFromQuery reads an alert filter from the query string. The
+// status is unread, read or all and defaults to defaultStatus.
+func AlertFilterFromQuery(c *gin.Context, defaultStatus string) (models.AlertFilter, error) {
+	filter := models.AlertFilter{
+		Search: strings.TrimSpace(c.Query("search")),
+		Type:   c.Query("type"),
+	}
+
+	var err error
+	if filter.ListOptions, err = ListOptionsFromQuery(c); err != nil {
+		return filter, err
+	}
+	if filter.UserID, err = queryPositiveInt(c, "user_id", 0); err != nil {
+		return filter, err
+	}
+	if filter.GameID, err = queryPositiveInt(c, "game_id", 0); err != nil {
+		return filter, err
+	}
+	if filter.CreatedFrom, err = parseAuditTime(c, "created_from", false); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = parseAuditTime(c, "created_to", true); err != nil {
+		return filter, err
+	}
+
+	switch c.DefaultQuery("status", defaultStatus) {
+	case AlertStatusUnread:
+		read := false
+		filter.Read = &read
+	case AlertStatusRead:
+		read := true
+		filter.Read = &read
+	case AlertStatusAll:
+	default:
+		return filter, models.Invalid("status", "invalid_alert_status", AlertStatusUnread, AlertStatusRead, AlertStatusAll)
+	}
+
+	return filter, nil
+}
+
+// listResponse builds the JSON body of one page of a list
+func listResponse(key string, items any, count, total int, options models.ListOptions) gin.H {
+	return gin.H{
+		key:           items,
+		"count":       count,
+		"total":       total,
+		"page":        options.Page,
+		"per_page":    options.PerPage,
+		"total_pages": options.TotalPages(total),
+	}
+}
+
+// queryPositiveInt reads a positive integer query parameter, returning
+// fallback when it is absent
+func queryPositiveInt(c *gin.Context, name string, fallback int) (int, error) {
+	param := c.Query(name)
+	if param == "" {
+		return fallback, nil
+	}
+
+	value, err := strconv.Atoi(param)
+	if err != nil || value <= 0 {
+		return fallback, models.Invalid(name, "invalid_positive_integer", name)
+	}
+
+	return value, nil
+}
+
+// queryInt reads an optional integer query parameter, zero when absent
+func queryInt(c *gin.Context, name string) (int, error) {
+	param := c.Query(name)
+	if param == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.Atoi(param)
+	if err != nil {
+		return 0, models.Invalid(name, "invalid_integer", name)
+	}
+
+	return value, nil
+}
+
+// queryFloat reads an optional decimal query parameter, zero when absent
+func queryFloat(c *gin.Context, name string) (float64, error) {
+	param := c.Query(name)
+	if param == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return 0, models.Invalid(name, "invalid_number", name)
+	}
+
+	return value, nil
+}
+
+// queryBool reads an optional boolean query parameter
+func queryBool(c *gin.Context, name string) (*bool, error) {
+	param := c.Query(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseBool(param)
+	if err != nil {
+		return nil, models.Invalid(name, "invalid_boolean", name)
+	}
+
+	return &value, nil
+}
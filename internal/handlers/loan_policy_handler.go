@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoanPolicyServiceInterface defines the interface for loan policy service operations
+type LoanPolicyServiceInterface interface {
+	GetPolicies(ctx context.Context) ([]*models.LoanPolicy, error)
+	GetPolicy(ctx context.Context, tier string) (*models.LoanPolicy, error)
+	CreatePolicy(ctx context.Context, policy *models.LoanPolicy) error
+	UpdatePolicy(ctx context.Context, policy *models.LoanPolicy) error
+}
+
+// LoanPolicyHandler handles HTTP requests for membership tiers and their limits
+type LoanPolicyHandler struct {
+	policyService LoanPolicyServiceInterface
+}
+
+// NewLoanPolicyHandler creates a new LoanPolicyHandler instance
+func NewLoanPolicyHandler(policyService LoanPolicyServiceInterface) *LoanPolicyHandler {
+	return &LoanPolicyHandler{
+		policyService: policyService,
+	}
+}
+
+// LoanPolicyRequest represents the request body for creating or updating a loan policy
+type LoanPolicyRequest struct {
+	Tier            string `json:"tier"` // Required on creation, taken from the URL on update
+	MaxLoans        int    `json:"max_loans" binding:"required"`
+	MaxLoanDays     int    `json:"max_loan_days" binding:"required"`
+	DefaultLoanDays int    `json:"default_loan_days" binding:"required"`
+	MaxExtensions   int    `json:"max_extensions"`
+
+	FinePerDayCents     int  `json:"fine_per_day_cents"`
+	FineGraceDays       int  `json:"fine_grace_days"`
+	FineCapCents        int  `json:"fine_cap_cents"`
+	MaxFineBalanceCents *int `json:"max_fine_balance_cents"` // null for no limit
+}
+
+// GetPolicies handles GET /api/loan-policies - list membership tiers
+// @Summary Lister les politiques de prêt
+// @Description Récupère les limites d'emprunt de chaque niveau d'adhésion
+// @Tags loan-policies
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Politiques de prêt"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /loan-policies [get]
+func (h *LoanPolicyHandler) GetPolicies(c *gin.Context) {
+	policies, err := h.policyService.GetPolicies(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"policies": policies,
+		"count":    len(policies),
+	})
+}
+
+// GetPolicy handles GET /api/loan-policies/:tier - get the limits of a tier
+// @Summary Obtenir une politique de prêt
+// @Description Récupère les limites d'emprunt d'un niveau d'adhésion
+// @Tags loan-policies
+// @Produce json
+// @Param tier path string true "Niveau d'adhésion"
+// @Success 200 {object} models.LoanPolicy "Politique de prêt"
+// @Failure 404 {object} Problem "Niveau non trouvé"
+// @Router /loan-policies/{tier} [get]
+func (h *LoanPolicyHandler) GetPolicy(c *gin.Context) {
+	policy, err := h.policyService.GetPolicy(c.Request.Context(), c.Param("tier"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// CreatePolicy handles POST /api/loan-policies - add a membership tier
+// @Summary Créer une politique de prêt
+// @Description Ajoute un niveau d'adhésion avec ses limites d'emprunt et ses pénalités de retard, en centimes : montant par jour de retard au-delà du délai de grâce, plafond par retour (0 pour aucun) et solde d'amendes au-delà duquel l'emprunt est bloqué (null pour aucun)
+// @Tags loan-policies
+// @Accept json
+// @Produce json
+// @Param policy body LoanPolicyRequest true "Limites du niveau"
+// @Success 201 {object} map[string]interface{} "Politique créée"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 409 {object} Problem "Niveau déjà existant"
+// @Router /loan-policies [post]
+func (h *LoanPolicyHandler) CreatePolicy(c *gin.Context) {
+	var req LoanPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	policy := req.toPolicy(req.Tier)
+	if err := actingLoanPolicyService(c, h.policyService).CreatePolicy(c.Request.Context(), policy); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Loan policy created successfully",
+		"policy":  policy,
+	})
+}
+
+// UpdatePolicy handles PUT /api/loan-policies/:tier - change the limits of a tier
+// @Summary Modifier une politique de prêt
+// @Description Modifie les limites d'emprunt et les pénalités de retard d'un niveau d'adhésion existant
+// @Tags loan-policies
+// @Accept json
+// @Produce json
+// @Param tier path string true "Niveau d'adhésion"
+// @Param policy body LoanPolicyRequest true "Nouvelles limites"
+// @Success 200 {object} map[string]interface{} "Politique modifiée"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 404 {object} Problem "Niveau non trouvé"
+// @Router /loan-policies/{tier} [put]
+func (h *LoanPolicyHandler) UpdatePolicy(c *gin.Context) {
+	var req LoanPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	policy := req.toPolicy(c.Param("tier"))
+	if err := actingLoanPolicyService(c, h.policyService).UpdatePolicy(c.Request.Context(), policy); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Loan policy updated successfully",
+		"policy":  policy,
+	})
+}
+
+// toPolicy builds the loan policy of a tier from the request body
+func (r *LoanPolicyRequest) toPolicy(tier string) *models.LoanPolicy {
+	return &models.LoanPolicy{
+		Tier:            tier,
+		MaxLoans:        r.MaxLoans,
+		MaxLoanDays:     r.MaxLoanDays,
+		DefaultLoanDays: r.DefaultLoanDays,
+		MaxExtensions:   r.MaxExtensions,
+
+		FinePerDayCents:     r.FinePerDayCents,
+		FineGraceDays:       r.FineGraceDays,
+		FineCapCents:        r.FineCapCents,
+		MaxFineBalanceCents: r.MaxFineBalanceCents,
+	}
+}
+
+// RegisterRoutes registers all loan policy routes
+func (h *LoanPolicyHandler) RegisterRoutes(router *gin.RouterGroup) {
+	policies := router.Group("/loan-policies")
+	{
+		policies.GET("", h.GetPolicies)
+		policies.POST("", h.CreatePolicy)
+		policies.GET("/:tier", h.GetPolicy)
+		policies.PUT("/:tier", h.UpdatePolicy)
+	}
+}
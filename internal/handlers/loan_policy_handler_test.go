@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLoanPolicyService is a mock implementation of LoanPolicyServiceInterface
+type MockLoanPolicyService struct {
+	mock.Mock
+}
+
+func (m *MockLoanPolicyService) GetPolicies(ctx context.Context) ([]*models.LoanPolicy, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LoanPolicy), args.Error(1)
+}
+
+func (m *MockLoanPolicyService) GetPolicy(ctx context.Context, tier string) (*models.LoanPolicy, error) {
+	args := m.Called(tier)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LoanPolicy), args.Error(1)
+}
+
+func (m *MockLoanPolicyService) CreatePolicy(ctx context.Context, policy *models.LoanPolicy) error {
+	args := m.Called(policy)
+	return args.Error(0)
+}
+
+func (m *MockLoanPolicyService) UpdatePolicy(ctx context.Context, policy *models.LoanPolicy) error {
+	args := m.Called(policy)
+	return args.Error(0)
+}
+
+func setupLoanPolicyHandlerTest() (*gin.Engine, *MockLoanPolicyService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockLoanPolicyService)
+	handler := NewLoanPolicyHandler(mockService)
+
+	router := gin.New()
+	router.Use(Problems())
+	api := router.Group("/api")
+	handler.RegisterRoutes(api)
+
+	return router, mockService
+}
+
+func TestLoanPolicyHandler_GetPolicies(t *testing.T) {
+	router, mockService := setupLoanPolicyHandlerTest()
+	mockService.On("GetPolicies").Return([]*models.LoanPolicy{
+		{Tier: "basic", MaxLoans: 2, MaxLoanDays: 30, DefaultLoanDays: 14, MaxExtensions: 1},
+		models.DefaultLoanPolicy(),
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/loan-policies", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), response["count"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestLoanPolicyHandler_GetPolicy(t *testing.T) {
+	t.Run("existing tier", func(t *testing.T) {
+		router, mockService := setupLoanPolicyHandlerTest()
+		mockService.On("GetPolicy", "standard").Return(models.DefaultLoanPolicy(), nil)
+
+		req, _ := http.NewRequest("GET", "/api/loan-policies/standard", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var policy models.LoanPolicy
+		err := json.Unmarshal(w.Body.Bytes(), &policy)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, policy.MaxLoans)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unknown tier", func(t *testing.T) {
+		router, mockService := setupLoanPolicyHandlerTest()
+		mockService.On("GetPolicy", "gold").Return(nil, fmt.Errorf("failed to get loan policy: %w", models.NotFound("loan_policy_not_found", "gold")))
+
+		req, _ := http.NewRequest("GET", "/api/loan-policies/gold", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestLoanPolicyHandler_CreatePolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockLoanPolicyService)
+		expectedStatus int
+	}{
+		{
+			name: "successful creation",
+			body: `{"tier":"staff","max_loans":20,"max_loan_days":180,"default_loan_days":30,"max_extensions":10}`,
+			setupMock: func(m *MockLoanPolicyService) {
+				m.On("CreatePolicy", mock.MatchedBy(func(p *models.LoanPolicy) bool {
+					return p.Tier == "staff" && p.MaxLoans == 20 && p.MaxExtensions == 10
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing limits",
+			body:           `{"tier":"staff"}`,
+			setupMock:      func(m *MockLoanPolicyService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid limits",
+			body: `{"tier":"staff","max_loans":2,"max_loan_days":30,"default_loan_days":60}`,
+			setupMock: func(m *MockLoanPolicyService) {
+				m.On("CreatePolicy", mock.AnythingOfType("*models.LoanPolicy")).Return(fmt.Errorf("validation failed: %w", models.Invalid("default_loan_days", "invalid_default_loan_days")))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "duplicate tier",
+			body: `{"tier":"basic","max_loans":2,"max_loan_days":30,"default_loan_days":14}`,
+			setupMock: func(m *MockLoanPolicyService) {
+				m.On("CreatePolicy", mock.AnythingOfType("*models.LoanPolicy")).Return(fmt.Errorf("failed to create loan policy: %w", models.Conflict("loan_policy_exists", "basic")))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupLoanPolicyHandlerTest()
+			tt.setupMock(mockService)
+
+			req, _ := http.NewRequest("POST", "/api/loan-policies", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLoanPolicyHandler_UpdatePolicy(t *testing.T) {
+	router, mockService := setupLoanPolicyHandlerTest()
+	mockService.On("UpdatePolicy", mock.MatchedBy(func(p *models.LoanPolicy) bool {
+		return p.Tier == "basic" && p.MaxLoans == 3
+	})).Return(nil)
+
+	body := `{"tier":"ignored","max_loans":3,"max_loan_days":30,"default_loan_days":14,"max_extensions":1}`
+	req, _ := http.NewRequest("PUT", "/api/loan-policies/basic", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationServiceInterface defines the interface for notification service operations
+type NotificationServiceInterface interface {
+	GetPreferences(ctx context.Context, userID int) (*models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) error
+	GetAlertNotification(ctx context.Context, alertID int) (*models.AlertNotification, error)
+}
+
+// NotificationHandler handles HTTP requests for the delivery of alerts by
+// email and the notification preferences of users
+type NotificationHandler struct {
+	notificationService NotificationServiceInterface
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance
+func NewNotificationHandler(notificationService NotificationServiceInterface) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// NotificationPreferencesRequest represents the request body for updating
+// the notification preferences of a user
+type NotificationPreferencesRequest struct {
+	EmailEnabled *bool  `json:"email_enabled" binding:"required"`
+	Language     string `json:"language"`
+}
+
+// GetPreferences handles GET /api/users/:id/notifications - get a user's notification preferences
+// @Summary Préférences de notification d'un utilisateur
+// @Description Indique si l'utilisateur reçoit ses alertes par email et dans quelle langue (vide pour la langue de la bibliothèque)
+// @Tags notifications
+// @Produce json
+// @Param id path int true "ID de l'utilisateur"
+// @Success 200 {object} models.NotificationPreferences "Préférences"
+// @Failure 400 {object} Problem "ID invalide"
+// @Failure 404 {object} Problem "Utilisateur non trouvé"
+// @Router /users/{id}/notifications [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID, ok := parseNotificationUserID(c)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.notificationService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preferences": preferences,
+	})
+}
+
+// UpdatePreferences handles PUT /api/users/:id/notifications - update a user's notification preferences
+// @Summary Modifier les préférences de notification
+// @Description Active ou désactive l'envoi des alertes par email et choisit leur langue (fr ou en, vide pour la langue de la bibliothèque). La désactivation s'applique aux alertes pas encore envoyées.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de l'utilisateur"
+// @Param preferences body NotificationPreferencesRequest true "Préférences"
+// @Success 200 {object} map[string]interface{} "Préférences mises à jour"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 404 {object} Problem "Utilisateur non trouvé"
+// @Router /users/{id}/notifications [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID, ok := parseNotificationUserID(c)
+	if !ok {
+		return
+	}
+
+	var req NotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	preferences := &models.NotificationPreferences{
+		UserID:       userID,
+		EmailEnabled: *req.EmailEnabled,
+		Language:     req.Language,
+	}
+	if err := h.notificationService.UpdatePreferences(c.Request.Context(), preferences); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Notification preferences updated successfully",
+		"preferences": preferences,
+	})
+}
+
+// GetAlertNotification handles GET /api/alerts/:id/notification - get the delivery status of an alert's email
+// @Summary Envoi de l'email d'une alerte
+// @Description Récupère l'état de l'envoi par email d'une alerte : en attente, envoyé, échoué ou ignoré, avec le nombre de tentatives et la dernière erreur
+// @Tags notifications
+// @Produce json
+// @Param id path int true "ID de l'alerte"
+// @Success 200 {object} models.AlertNotification "État de l'envoi"
+// @Failure 400 {object} Problem "ID invalide"
+// @Failure 404 {object} Problem "Aucun envoi pour cette alerte"
+// @Router /alerts/{id}/notification [get]
+func (h *NotificationHandler) GetAlertNotification(c *gin.Context) {
+	alertID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return
+	}
+
+	notification, err := h.notificationService.GetAlertNotification(c.Request.Context(), alertID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notification": notification,
+	})
+}
+
+// parseNotificationUserID reads the :id path parameter, reporting a validation error when it is invalid
+func parseNotificationUserID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// RegisterRoutes registers the notification routes
+func (h *NotificationHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/users/:id/notifications", h.GetPreferences)
+	router.PUT("/users/:id/notifications", h.UpdatePreferences)
+	router.GET("/alerts/:id/notification", h.GetAlertNotification)
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationService is a mock implementation of NotificationServiceInterface
+type MockNotificationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationService) GetPreferences(ctx context.Context, userID int) (*models.NotificationPreferences, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockNotificationService) UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	args := m.Called(preferences)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) GetAlertNotification(ctx context.Context, alertID int) (*models.AlertNotification, error) {
+	args := m.Called(alertID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AlertNotification), args.Error(1)
+}
+
+func setupNotificationHandlerTest() (*gin.Engine, *MockNotificationService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockNotificationService)
+	handler := NewNotificationHandler(mockService)
+
+	router := gin.New()
+	router.Use(Problems())
+	api := router.Group("/api")
+	handler.RegisterRoutes(api)
+
+	return router, mockService
+}
+
+func TestNotificationHandler_GetPreferences(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, mockService := setupNotificationHandlerTest()
+		mockService.On("GetPreferences", 3).Return(&models.NotificationPreferences{UserID: 3, EmailEnabled: true, Language: models.LanguageEnglish}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/users/3/notifications", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		preferences := response["preferences"].(map[string]interface{})
+		assert.Equal(t, true, preferences["email_enabled"])
+		assert.Equal(t, "en", preferences["language"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		router, mockService := setupNotificationHandlerTest()
+		mockService.On("GetPreferences", 99).Return(nil, fmt.Errorf("failed to get notification preferences: %w", models.NotFound("user_not_found", 99)))
+
+		req, _ := http.NewRequest("GET", "/api/users/99/notifications", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		router, _ := setupNotificationHandlerTest()
+
+		req, _ := http.NewRequest("GET", "/api/users/abc/notifications", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestNotificationHandler_UpdatePreferences(t *testing.T) {
+	t.Run("opt out", func(t *testing.T) {
+		router, mockService := setupNotificationHandlerTest()
+		mockService.On("UpdatePreferences", &models.NotificationPreferences{UserID: 3, EmailEnabled: false, Language: "fr"}).Return(nil)
+
+		body, _ := json.Marshal(map[string]interface{}{"email_enabled": false, "language": "fr"})
+		req, _ := http.NewRequest("PUT", "/api/users/3/notifications", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("missing email_enabled", func(t *testing.T) {
+		router, _ := setupNotificationHandlerTest()
+
+		req, _ := http.NewRequest("PUT", "/api/users/3/notifications", bytes.NewBufferString(`{"language":"en"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid language", func(t *testing.T) {
+		router, mockService := setupNotificationHandlerTest()
+		mockService.On("UpdatePreferences", mock.Anything).Return(fmt.Errorf("validation failed: %w", models.Invalid("language", "invalid_language", models.ValidLanguages)))
+
+		req, _ := http.NewRequest("PUT", "/api/users/3/notifications", bytes.NewBufferString(`{"email_enabled":true,"language":"de"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestNotificationHandler_GetAlertNotification(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		router, mockService := setupNotificationHandlerTest()
+		next := time.Now().Add(10 * time.Minute)
+		mockService.On("GetAlertNotification", 7).Return(&models.AlertNotification{
+			AlertID: 7, Status: models.NotificationPending, Attempts: 2, LastError: "connection refused", NextAttemptAt: &next,
+		}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/alerts/7/notification", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		notification := response["notification"].(map[string]interface{})
+		assert.Equal(t, "pending", notification["status"])
+		assert.Equal(t, float64(2), notification["attempts"])
+		assert.Equal(t, "connection refused", notification["last_error"])
+	})
+
+	t.Run("never notified", func(t *testing.T) {
+		router, mockService := setupNotificationHandlerTest()
+		mockService.On("GetAlertNotification", 8).Return(nil, fmt.Errorf("failed to get notification: %w", models.NotFound("notification_not_found", 8)))
+
+		req, _ := http.NewRequest("GET", "/api/alerts/8/notification", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
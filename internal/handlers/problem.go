@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// ProblemContentType is the media type of error responses
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypePrefix starts the type of every problem, which ends with its code
+const ProblemTypePrefix = "urn:board-game-library:problem:"
+
+// Problem is the body of error responses, an RFC 7807 problem detail. Code
+// is stable: clients tell errors apart by it rather than by their messages,
+// which follow the Accept-Language of the request.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code"`
+	Errors   []FieldProblem `json:"errors,omitempty"`
+}
+
+// FieldProblem is a field of a request at fault in a validation problem
+type FieldProblem struct {
+	Field  string `json:"field"`
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+// problemKind holds the status and titles of a kind of models.Error
+type problemKind struct {
+	kind    error
+	status  int
+	english string
+	french  string
+}
+
+var problemKinds = []problemKind{
+	{models.ErrValidation, http.StatusBadRequest, "Invalid request", "Requête invalide"},
+	{models.ErrUnauthorized, http.StatusUnauthorized, "Authentication required", "Authentification requise"},
+	{models.ErrForbidden, http.StatusForbidden, "Access denied", "Accès refusé"},
+	{models.ErrNotFound, http.StatusNotFound, "Resource not found", "Ressource introuvable"},
+	{models.ErrConflict, http.StatusConflict, "Conflict", "Conflit"},
+	{models.ErrPolicyViolation, http.StatusConflict, "Loan policy violation", "Politique de prêt non respectée"},
+	{models.ErrUpstream, http.StatusBadGateway, "External service failure", "Échec d'un service externe"},
+	{models.ErrUnavailable, http.StatusServiceUnavailable, "Service unavailable", "Service indisponible"},
+}
+
+var (
+	tooLargeProblemKind = problemKind{nil, http.StatusRequestEntityTooLarge, "Request too large", "Requête trop volumineuse"}
+	internalProblemKind = problemKind{nil, http.StatusInternalServerError, "Internal server error", "Erreur interne du serveur"}
+)
+
+func init() {
+	// Name the fields of validation errors after their JSON or form keys
+	if engine, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		engine.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
+// Problems returns the middleware answering failed requests with a problem.
+// Handlers report a failure with c.Error and return without writing; the
+// last error of the request then becomes the response.
+func Problems() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Written() || len(c.Errors) == 0 {
+			return
+		}
+		RespondProblem(c, c.Errors.Last().Err)
+	}
+}
+
+// RespondProblem writes the problem describing err in the language of the request
+func RespondProblem(c *gin.Context, err error) {
+	problem := NewProblem(err, RequestLanguage(c))
+	problem.Instance = c.Request.URL.Path
+
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// NewProblem describes err in language. Errors that are not a models.Error
+// are failures of the server, whose details are not shown to clients.
+func NewProblem(err error, language string) Problem {
+	kind := problemKindOf(err)
+	problem := Problem{
+		Status: kind.status,
+		Title:  kind.english,
+	}
+	if language == models.LanguageFrench {
+		problem.Title = kind.french
+	}
+
+	var domainErr *models.Error
+	var validationErrs models.ValidationErrors
+	switch {
+	case isBodyTooLarge(err):
+		domainErr = &models.Error{Code: "request_body_too_large"}
+	case errors.As(err, &validationErrs) && len(validationErrs) > 0:
+		// An error wrapping several sums them up, otherwise the first
+		// one describes the problem
+		if !errors.As(err, &domainErr) {
+			domainErr = validationErrs[0]
+		}
+		for _, fieldErr := range validationErrs {
+			problem.Errors = append(problem.Errors, fieldProblem(fieldErr, language))
+		}
+	case errors.As(err, &domainErr):
+		if domainErr.Field != "" {
+			problem.Errors = []FieldProblem{fieldProblem(domainErr, language)}
+		}
+	case errors.Is(err, context.DeadlineExceeded):
+		domainErr = models.Unavailable("request_timeout")
+	default:
+		domainErr = &models.Error{Code: "internal_error"}
+	}
+
+	problem.Code = domainErr.Code
+	problem.Type = ProblemTypePrefix + domainErr.Code
+	problem.Detail = domainErr.Message(language)
+	return problem
+}
+
+// ErrorStatus returns the HTTP status answering err
+func ErrorStatus(err error) int {
+	return problemKindOf(err).status
+}
+
+// problemKindOf returns the kind of err
+func problemKindOf(err error) problemKind {
+	if isBodyTooLarge(err) {
+		return tooLargeProblemKind
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = models.ErrUnavailable
+	}
+	for _, kind := range problemKinds {
+		if errors.Is(err, kind.kind) {
+			return kind
+		}
+	}
+	return internalProblemKind
+}
+
+func fieldProblem(err *models.Error, language string) FieldProblem {
+	return FieldProblem{Field: err.Field, Code: err.Code, Detail: err.Message(language)}
+}
+
+func isBodyTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
+
+// RequestLanguage returns the language of the messages for the request: the
+// supported language the Accept-Language header prefers, or English
+func RequestLanguage(c *gin.Context) string {
+	type preference struct {
+		language string
+		quality  float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if language != models.LanguageEnglish && language != models.LanguageFrench {
+			continue
+		}
+
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			preferences = append(preferences, preference{language, quality})
+		}
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+	if len(preferences) == 0 {
+		return models.LanguageEnglish
+	}
+	return preferences[0].language
+}
+
+// BindError returns the validation error of a request body or form that
+// could not be bound
+func BindError(err error) error {
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		var errs models.ValidationErrors
+		for _, fieldErr := range fieldErrs {
+			if fieldErr.Tag() == "required" {
+				errs = append(errs, models.Invalid(fieldErr.Field(), "field_required", fieldErr.Field()))
+			} else {
+				errs = append(errs, models.Invalid(fieldErr.Field(), "field_invalid", fieldErr.Field(), fieldErr.Tag()))
+			}
+		}
+		return errs
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return models.Invalid(typeErr.Field, "field_type", typeErr.Field, typeErr.Type.String())
+	}
+	if isBodyTooLarge(err) {
+		return err
+	}
+
+	return models.Invalid("", "invalid_request", err.Error())
+}
+
+// requestFieldName returns the JSON or form key of a request field
+func requestFieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"board-game-library/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveProblem answers a request with the error err reported through c.Error
+func serveProblem(t *testing.T, err error, acceptLanguage string) (*httptest.ResponseRecorder, Problem) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Problems())
+	router.GET("/api/games/:id", func(c *gin.Context) {
+		c.Error(err)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/games/7", nil)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	return w, problem
+}
+
+func TestProblems(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{
+			name:       "not found",
+			err:        fmt.Errorf("failed to get game: %w", models.NotFound("game_not_found", 7)),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "game_not_found",
+			wantDetail: "game with id 7 not found",
+		},
+		{
+			name:       "conflict",
+			err:        models.Conflict("game_not_available"),
+			wantStatus: http.StatusConflict,
+			wantCode:   "game_not_available",
+			wantDetail: "game is not available for borrowing",
+		},
+		{
+			name:       "policy violation",
+			err:        models.PolicyViolation(models.PolicyRuleMaxLoans, 2, "basic"),
+			wantStatus: http.StatusConflict,
+			wantCode:   models.PolicyRuleMaxLoans,
+			wantDetail: "user has reached the limit of 2 concurrent loan(s) for the basic tier",
+		},
+		{
+			name:       "unauthorized",
+			err:        models.Unauthorized("session_expired"),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "session_expired",
+			wantDetail: "session expired",
+		},
+		{
+			name:       "upstream failure",
+			err:        fmt.Errorf("%w: timeout", models.Upstream("bgg_lookup_failed")),
+			wantStatus: http.StatusBadGateway,
+			wantCode:   "bgg_lookup_failed",
+			wantDetail: "BoardGameGeek lookup failed",
+		},
+		{
+			name:       "timeout",
+			err:        fmt.Errorf("failed to list games: %w", context.DeadlineExceeded),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   "request_timeout",
+			wantDetail: "the request took too long, try again later",
+		},
+		{
+			name:       "body too large",
+			err:        &http.MaxBytesError{Limit: 1024},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   "request_body_too_large",
+			wantDetail: "request body too large",
+		},
+		{
+			name:       "server failure hides its details",
+			err:        errors.New("database is locked"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal_error",
+			wantDetail: "an unexpected error occurred",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, problem := serveProblem(t, tt.err, "")
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantStatus, problem.Status)
+			assert.Equal(t, tt.wantCode, problem.Code)
+			assert.Equal(t, ProblemTypePrefix+tt.wantCode, problem.Type)
+			assert.Equal(t, tt.wantDetail, problem.Detail)
+			assert.Equal(t, "/api/games/7", problem.Instance)
+			assert.NotEmpty(t, problem.Title)
+			assert.Empty(t, problem.Errors)
+		})
+	}
+}
+
+func TestProblemsFieldErrors(t *testing.T) {
+	err := fmt.Errorf("validation failed: %w", models.Invalid("condition", "invalid_game_condition", models.ValidConditions))
+
+	w, problem := serveProblem(t, err, "")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_game_condition", problem.Code)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "condition", problem.Errors[0].Field)
+	assert.Equal(t, "invalid_game_condition", problem.Errors[0].Code)
+	assert.Equal(t, problem.Detail, problem.Errors[0].Detail)
+}
+
+func TestProblemsLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantTitle      string
+		wantDetail     string
+	}{
+		{"no preference", "", "Resource not found", "user with id 3 not found"},
+		{"French", "fr-FR,fr;q=0.9", "Ressource introuvable", "utilisateur 3 introuvable"},
+		{"English preferred", "fr;q=0.5, en-GB", "Resource not found", "user with id 3 not found"},
+		{"French preferred", "de, en;q=0.4, fr;q=0.8", "Ressource introuvable", "utilisateur 3 introuvable"},
+		{"unsupported", "de-DE", "Resource not found", "user with id 3 not found"},
+		{"refused", "fr;q=0", "Resource not found", "user with id 3 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, problem := serveProblem(t, models.NotFound("user_not_found", 3), tt.acceptLanguage)
+
+			assert.Equal(t, tt.wantTitle, problem.Title)
+			assert.Equal(t, tt.wantDetail, problem.Detail)
+		})
+	}
+}
+
+func TestProblemsLeaveWrittenResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Problems())
+	router.GET("/export", func(c *gin.Context) {
+		c.String(http.StatusOK, "id,name\n")
+		c.Error(errors.New("connection reset"))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "id,name\n", w.Body.String())
+}
+
+func TestBindError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Problems())
+	router.POST("/api/users", func(c *gin.Context) {
+		var req RegisterUserRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.Error(BindError(err))
+			return
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	tests := []struct {
+		name       string
+		body       string
+		wantCode   string
+		wantFields []string
+	}{
+		{"missing fields", `{}`, "field_required", []string{"name", "email"}},
+		{"wrong type", `{"name": 12, "email": "a@example.com"}`, "field_type", []string{"name"}},
+		{"malformed JSON", `{"name":`, "invalid_request", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/users", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var problem Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, tt.wantCode, problem.Code)
+
+			var fields []string
+			for _, fieldErr := range problem.Errors {
+				fields = append(fields, fieldErr.Field)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
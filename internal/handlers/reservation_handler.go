@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReservationServiceInterface defines the interface for reservation service operations
+type ReservationServiceInterface interface {
+	PlaceHold(ctx context.Context, userID, gameID int) (*models.Reservation, error)
+	CancelHold(ctx context.Context, reservationID int) error
+	GetReservation(ctx context.Context, reservationID int) (*models.Reservation, error)
+	GetQueue(ctx context.Context, gameID int) ([]*models.Reservation, error)
+	GetUserReservations(ctx context.Context, userID int) ([]*models.Reservation, error)
+	GetActiveReservations(ctx context.Context) ([]*models.Reservation, error)
+	ExpireHolds(ctx context.Context) (int, error)
+}
+
+// ReservationHandler handles HTTP requests for reservation queues
+type ReservationHandler struct {
+	reservationService ReservationServiceInterface
+}
+
+// NewReservationHandler creates a new ReservationHandler instance
+func NewReservationHandler(reservationService ReservationServiceInterface) *ReservationHandler {
+	return &ReservationHandler{
+		reservationService: reservationService,
+	}
+}
+
+// PlaceHoldRequest represents the request body for placing a hold on a game
+type PlaceHoldRequest struct {
+	UserID int `json:"user_id" binding:"required"`
+	GameID int `json:"game_id" binding:"required"`
+}
+
+// PlaceHold handles POST /api/reservations - place a hold on a borrowed game
+// @Summary Réserver un jeu
+// @Description Ajoute l'utilisateur à la file d'attente d'un jeu actuellement emprunté
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param reservation body PlaceHoldRequest true "Utilisateur et jeu"
+// @Success 201 {object} map[string]interface{} "Réservation créée"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 404 {object} Problem "Utilisateur ou jeu non trouvé"
+// @Failure 409 {object} Problem "Réservation impossible"
+// @Router /reservations [post]
+func (h *ReservationHandler) PlaceHold(c *gin.Context) {
+	var req PlaceHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	reservation, err := actingReservationService(c, h.reservationService).PlaceHold(c.Request.Context(), req.UserID, req.GameID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "Hold placed successfully",
+		"reservation": reservation,
+	})
+}
+
+// GetActiveReservations handles GET /api/reservations - list waiting and ready holds
+// @Summary Lister les réservations actives
+// @Description Récupère les réservations en attente ou prêtes, groupées par jeu dans l'ordre de la file
+// @Tags reservations
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Réservations actives"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /reservations [get]
+func (h *ReservationHandler) GetActiveReservations(c *gin.Context) {
+	reservations, err := h.reservationService.GetActiveReservations(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reservations": reservations,
+		"count":        len(reservations),
+	})
+}
+
+// GetReservation handles GET /api/reservations/:id - get a reservation
+// @Summary Obtenir une réservation
+// @Description Récupère une réservation et sa position dans la file
+// @Tags reservations
+// @Produce json
+// @Param id path int true "ID de la réservation"
+// @Success 200 {object} map[string]interface{} "Réservation"
+// @Failure 400 {object} Problem "ID invalide"
+// @Failure 404 {object} Problem "Réservation non trouvée"
+// @Router /reservations/{id} [get]
+func (h *ReservationHandler) GetReservation(c *gin.Context) {
+	id, ok := parseReservationID(c)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.reservationService.GetReservation(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reservation": reservation,
+	})
+}
+
+// CancelHold handles PUT /api/reservations/:id/cancel - cancel a hold
+// @Summary Annuler une réservation
+// @Description Retire la réservation de la file ; un exemplaire mis de côté passe à la personne suivante
+// @Tags reservations
+// @Produce json
+// @Param id path int true "ID de la réservation"
+// @Success 200 {object} map[string]interface{} "Réservation annulée"
+// @Failure 400 {object} Problem "ID invalide"
+// @Failure 404 {object} Problem "Réservation non trouvée"
+// @Failure 409 {object} Problem "Réservation déjà clôturée"
+// @Router /reservations/{id}/cancel [put]
+func (h *ReservationHandler) CancelHold(c *gin.Context) {
+	id, ok := parseReservationID(c)
+	if !ok {
+		return
+	}
+
+	if err := actingReservationService(c, h.reservationService).CancelHold(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Hold cancelled successfully",
+	})
+}
+
+// GetGameQueue handles GET /api/reservations/game/:id - get a game's queue
+// @Summary File d'attente d'un jeu
+// @Description Récupère les réservations actives d'un jeu dans l'ordre d'arrivée
+// @Tags reservations
+// @Produce json
+// @Param id path int true "ID du jeu"
+// @Success 200 {object} map[string]interface{} "File d'attente"
+// @Failure 400 {object} Problem "ID invalide"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /reservations/game/{id} [get]
+func (h *ReservationHandler) GetGameQueue(c *gin.Context) {
+	gameID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return
+	}
+
+	queue, err := h.reservationService.GetQueue(c.Request.Context(), gameID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"game_id":      gameID,
+		"reservations": queue,
+		"count":        len(queue),
+	})
+}
+
+// GetUserReservations handles GET /api/reservations/user/:id - get a user's holds
+// @Summary Réservations d'un utilisateur
+// @Description Récupère toutes les réservations d'un utilisateur, les plus récentes en premier
+// @Tags reservations
+// @Produce json
+// @Param id path int true "ID de l'utilisateur"
+// @Success 200 {object} map[string]interface{} "Réservations"
+// @Failure 400 {object} Problem "ID invalide"
+// @Failure 404 {object} Problem "Utilisateur non trouvé"
+// @Router /reservations/user/{id} [get]
+func (h *ReservationHandler) GetUserReservations(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return
+	}
+
+	reservations, err := h.reservationService.GetUserReservations(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":      userID,
+		"reservations": reservations,
+		"count":        len(reservations),
+	})
+}
+
+// ExpireHolds handles POST /api/reservations/expire - expire uncollected holds
+// @Summary Expirer les réservations
+// @Description Clôture les réservations non retirées à temps et passe l'exemplaire à la personne suivante
+// @Tags reservations
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Réservations expirées"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /reservations/expire [post]
+func (h *ReservationHandler) ExpireHolds(c *gin.Context) {
+	expired, err := actingReservationService(c, h.reservationService).ExpireHolds(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Expired holds processed successfully",
+		"expired": expired,
+	})
+}
+
+// parseReservationID reads the :id path parameter, reporting a validation error when it is invalid
+func parseReservationID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// RegisterRoutes registers all reservation-related routes
+func (h *ReservationHandler) RegisterRoutes(router *gin.RouterGroup) {
+	reservations := router.Group("/reservations")
+	{
+		reservations.POST("", h.PlaceHold)
+		reservations.GET("", h.GetActiveReservations)
+		reservations.POST("/expire", h.ExpireHolds)
+		reservations.GET("/game/:id", h.GetGameQueue)
+		reservations.GET("/user/:id", h.GetUserReservations)
+		reservations.GET("/:id", h.GetReservation)
+		reservations.PUT("/:id/cancel", h.CancelHold)
+	}
+}
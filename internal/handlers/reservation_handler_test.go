@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReservationService is a mock implementation of ReservationServiceInterface
+type MockReservationService struct {
+	mock.Mock
+}
+
+func (m *MockReservationService) PlaceHold(ctx context.Context, userID, gameID int) (*models.Reservation, error) {
+	args := m.Called(userID, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationService) CancelHold(ctx context.Context, reservationID int) error {
+	args := m.Called(reservationID)
+	return args.Error(0)
+}
+
+func (m *MockReservationService) GetReservation(ctx context.Context, reservationID int) (*models.Reservation, error) {
+	args := m.Called(reservationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationService) GetQueue(ctx context.Context, gameID int) ([]*models.Reservation, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationService) GetUserReservations(ctx context.Context, userID int) ([]*models.Reservation, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationService) GetActiveReservations(ctx context.Context) ([]*models.Reservation, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationService) ExpireHolds(ctx context.Context) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func setupReservationHandlerTest() (*gin.Engine, *MockReservationService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockReservationService)
+	handler := NewReservationHandler(mockService)
+
+	router := gin.New()
+	router.Use(Problems())
+	api := router.Group("/api")
+	handler.RegisterRoutes(api)
+
+	return router, mockService
+}
+
+func TestReservationHandler_PlaceHold(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockReservationService)
+		expectedStatus int
+	}{
+		{
+			name: "hold placed",
+			body: `{"user_id": 2, "game_id": 1}`,
+			setupMock: func(m *MockReservationService) {
+				m.On("PlaceHold", 2, 1).Return(&models.Reservation{
+					ID: 5, UserID: 2, GameID: 1, Status: models.ReservationWaiting, CreatedAt: time.Now(), Position: 1,
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing fields",
+			body:           `{"user_id": 2}`,
+			setupMock:      func(m *MockReservationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "game not found",
+			body: `{"user_id": 2, "game_id": 99}`,
+			setupMock: func(m *MockReservationService) {
+				m.On("PlaceHold", 2, 99).Return(nil, fmt.Errorf("game not found: %w", models.NotFound("game_not_found", 99)))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "game available",
+			body: `{"user_id": 2, "game_id": 1}`,
+			setupMock: func(m *MockReservationService) {
+				m.On("PlaceHold", 2, 1).Return(nil, models.Conflict("game_available"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "duplicate hold",
+			body: `{"user_id": 2, "game_id": 1}`,
+			setupMock: func(m *MockReservationService) {
+				m.On("PlaceHold", 2, 1).Return(nil, models.Conflict("already_on_hold"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupReservationHandlerTest()
+			tt.setupMock(mockService)
+
+			req, _ := http.NewRequest("POST", "/api/reservations", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReservationHandler_GetGameQueue(t *testing.T) {
+	router, mockService := setupReservationHandlerTest()
+	mockService.On("GetQueue", 1).Return([]*models.Reservation{
+		{ID: 5, UserID: 2, GameID: 1, Status: models.ReservationReady, Position: 1},
+		{ID: 6, UserID: 3, GameID: 1, Status: models.ReservationWaiting, Position: 2},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/reservations/game/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), response["count"])
+
+	queue := response["reservations"].([]interface{})
+	assert.Equal(t, float64(1), queue[0].(map[string]interface{})["position"])
+	assert.Equal(t, "ready", queue[0].(map[string]interface{})["status"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestReservationHandler_GetUserReservations(t *testing.T) {
+	t.Run("existing user", func(t *testing.T) {
+		router, mockService := setupReservationHandlerTest()
+		mockService.On("GetUserReservations", 2).Return([]*models.Reservation{
+			{ID: 5, UserID: 2, GameID: 1, Status: models.ReservationWaiting, Position: 1},
+		}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/reservations/user/2", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		router, mockService := setupReservationHandlerTest()
+		mockService.On("GetUserReservations", 99).Return(nil, fmt.Errorf("user not found: %w", models.NotFound("user_not_found", 99)))
+
+		req, _ := http.NewRequest("GET", "/api/reservations/user/99", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestReservationHandler_CancelHold(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockReservationService)
+		expectedStatus int
+	}{
+		{
+			name: "cancelled",
+			path: "/api/reservations/5/cancel",
+			setupMock: func(m *MockReservationService) {
+				m.On("CancelHold", 5).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid ID",
+			path:           "/api/reservations/abc/cancel",
+			setupMock:      func(m *MockReservationService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "already closed",
+			path: "/api/reservations/5/cancel",
+			setupMock: func(m *MockReservationService) {
+				m.On("CancelHold", 5).Return(models.Conflict("reservation_not_active"))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "not found",
+			path: "/api/reservations/99/cancel",
+			setupMock: func(m *MockReservationService) {
+				m.On("CancelHold", 99).Return(fmt.Errorf("reservation not found: %w", models.NotFound("reservation_not_found", 99)))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupReservationHandlerTest()
+			tt.setupMock(mockService)
+
+			req, _ := http.NewRequest("PUT", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReservationHandler_ExpireHolds(t *testing.T) {
+	router, mockService := setupReservationHandlerTest()
+	mockService.On("ExpireHolds").Return(2, nil)
+
+	req, _ := http.NewRequest("POST", "/api/reservations/expire", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), response["expired"])
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagServiceInterface defines the interface for tag service operations
+type TagServiceInterface interface {
+	ListTags(ctx context.Context, search string, limit int) ([]*models.Tag, error)
+	GetTag(ctx context.Context, id int) (*models.Tag, error)
+	CreateTag(ctx context.Context, name string) (*models.Tag, error)
+	RenameTag(ctx context.Context, id int, name string) (*models.Tag, error)
+	MergeTags(ctx context.Context, sourceID, targetID int) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id int) error
+}
+
+// TagHandler handles HTTP requests for the tags labelling games
+type TagHandler struct {
+	tagService TagServiceInterface
+}
+
+// NewTagHandler creates a new TagHandler instance
+func NewTagHandler(tagService TagServiceInterface) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+// TagRequest represents the request body for creating or renaming a tag
+type TagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// MergeTagRequest represents the request body for merging a tag into another
+type MergeTagRequest struct {
+	TargetID int `json:"target_id" binding:"required"`
+}
+
+// GetTags handles GET /api/tags - list or autocomplete tags
+// @Summary Lister les étiquettes
+// @Description Récupère les étiquettes avec leur nombre de jeux. Avec q, renvoie les étiquettes contenant le texte saisi (insensible à la casse et aux accents), celles qui commencent par ce texte et les plus utilisées en premier.
+// @Tags tags
+// @Produce json
+// @Param q query string false "Texte saisi"
+// @Param limit query int false "Nombre maximal d'étiquettes (500 au maximum)" default(20)
+// @Success 200 {object} map[string]interface{} "Étiquettes"
+// @Failure 400 {object} Problem "Paramètres invalides"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /tags [get]
+func (h *TagHandler) GetTags(c *gin.Context) {
+	limit, err := queryPositiveInt(c, "limit", models.DefaultTagLimit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	tags, err := h.tagService.ListTags(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if tags == nil {
+		tags = []*models.Tag{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags":  tags,
+		"count": len(tags),
+	})
+}
+
+// GetTag handles GET /api/tags/:id - get a tag
+// @Summary Obtenir une étiquette
+// @Description Récupère une étiquette avec son nombre de jeux
+// @Tags tags
+// @Produce json
+// @Param id path int true "ID de l'étiquette"
+// @Success 200 {object} models.Tag "Étiquette"
+// @Failure 400 {object} Problem "ID invalide"
+// @Failure 404 {object} Problem "Étiquette non trouvée"
+// @Router /tags/{id} [get]
+func (h *TagHandler) GetTag(c *gin.Context) {
+	id, ok := tagIDParam(c)
+	if !ok {
+		return
+	}
+
+	tag, err := h.tagService.GetTag(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tag)
+}
+
+// CreateTag handles POST /api/tags - add a tag
+// @Summary Créer une étiquette
+// @Description Ajoute une étiquette, qui peut ensuite être donnée aux jeux. Les jeux créent aussi les étiquettes qu'ils utilisent.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param tag body TagRequest true "Nom de l'étiquette"
+// @Success 201 {object} map[string]interface{} "Étiquette créée"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 409 {object} Problem "Étiquette déjà existante"
+// @Router /tags [post]
+func (h *TagHandler) CreateTag(c *gin.Context) {
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	tag, err := actingTagService(c, h.tagService).CreateTag(c.Request.Context(), req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Tag created successfully",
+		"tag":     tag,
+	})
+}
+
+// RenameTag handles PUT /api/tags/:id - rename a tag
+// @Summary Renommer une étiquette
+// @Description Renomme une étiquette sur tous ses jeux. Renommer vers le nom d'une autre étiquette échoue : il faut alors les fusionner.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de l'étiquette"
+// @Param tag body TagRequest true "Nouveau nom"
+// @Success 200 {object} map[string]interface{} "Étiquette renommée"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 404 {object} Problem "Étiquette non trouvée"
+// @Failure 409 {object} Problem "Nom déjà utilisé"
+// @Router /tags/{id} [put]
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	id, ok := tagIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	tag, err := actingTagService(c, h.tagService).RenameTag(c.Request.Context(), id, req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag renamed successfully",
+		"tag":     tag,
+	})
+}
+
+// MergeTag handles POST /api/tags/:id/merge - merge a tag into another
+// @Summary Fusionner des étiquettes
+// @Description Donne l'étiquette cible à tous les jeux de l'étiquette, puis supprime celle-ci
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path int true "ID de l'étiquette à fusionner"
+// @Param merge body MergeTagRequest true "Étiquette cible"
+// @Success 200 {object} map[string]interface{} "Étiquettes fusionnées"
+// @Failure 400 {object} Problem "Données invalides"
+// @Failure 404 {object} Problem "Étiquette non trouvée"
+// @Router /tags/{id}/merge [post]
+func (h *TagHandler) MergeTag(c *gin.Context) {
+	id, ok := tagIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req MergeTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	tag, err := actingTagService(c, h.tagService).MergeTags(c.Request.Context(), id, req.TargetID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tags merged successfully",
+		"tag":     tag,
+	})
+}
+
+// DeleteTag handles DELETE /api/tags/:id - delete a tag
+// @Summary Supprimer une étiquette
+// @Description Retire l'étiquette de tous les jeux et la supprime
+// @Tags tags
+// @Produce json
+// @Param id path int true "ID de l'étiquette"
+// @Success 200 {object} map[string]interface{} "Étiquette supprimée"
+// @Failure 400 {object} Problem "ID invalide"
+// @Failure 404 {object} Problem "Étiquette non trouvée"
+// @Router /tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id, ok := tagIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := actingTagService(c, h.tagService).DeleteTag(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag deleted successfully",
+	})
+}
+
+// tagIDParam reads the tag ID from the URL, reporting a validation error
+// when it is not a number
+func tagIDParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// RegisterRoutes registers all tag routes
+func (h *TagHandler) RegisterRoutes(router *gin.RouterGroup) {
+	tags := router.Group("/tags")
+	{
+		tags.GET("", h.GetTags)
+		tags.POST("", h.CreateTag)
+		tags.GET("/:id", h.GetTag)
+		tags.PUT("/:id", h.RenameTag)
+		tags.POST("/:id/merge", h.MergeTag)
+		tags.DELETE("/:id", h.DeleteTag)
+	}
+}
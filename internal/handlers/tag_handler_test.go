@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTagService is a mock implementation of TagServiceInterface
+type MockTagService struct {
+	mock.Mock
+}
+
+func (m *MockTagService) ListTags(ctx context.Context, search string, limit int) ([]*models.Tag, error) {
+	args := m.Called(search, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *MockTagService) GetTag(ctx context.Context, id int) (*models.Tag, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagService) CreateTag(ctx context.Context, name string) (*models.Tag, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagService) RenameTag(ctx context.Context, id int, name string) (*models.Tag, error) {
+	args := m.Called(id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagService) MergeTags(ctx context.Context, sourceID, targetID int) (*models.Tag, error) {
+	args := m.Called(sourceID, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagService) DeleteTag(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func setupTagHandlerTest() (*gin.Engine, *MockTagService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockTagService)
+	handler := NewTagHandler(mockService)
+
+	router := gin.New()
+	router.Use(Problems())
+	api := router.Group("/api")
+	handler.RegisterRoutes(api)
+
+	return router, mockService
+}
+
+func TestTagHandler_GetTags(t *testing.T) {
+	t.Run("autocomplete", func(t *testing.T) {
+		router, mockService := setupTagHandlerTest()
+		mockService.On("ListTags", "coop", 5).Return([]*models.Tag{
+			{ID: 1, Name: "Coopératif", Slug: "cooperatif", GameCount: 4},
+		}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/tags?q=coop&limit=5", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(1), response["count"])
+		tag := response["tags"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "cooperatif", tag["slug"])
+		assert.Equal(t, float64(4), tag["game_count"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		router, _ := setupTagHandlerTest()
+
+		req, _ := http.NewRequest("GET", "/api/tags?limit=zero", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTagHandler_CreateTag(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockTagService)
+		expectedStatus int
+	}{
+		{
+			name: "successful creation",
+			body: `{"name":"Coopératif"}`,
+			setupMock: func(m *MockTagService) {
+				m.On("CreateTag", "Coopératif").Return(&models.Tag{ID: 1, Name: "Coopératif", Slug: "cooperatif"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "missing name",
+			body:           `{}`,
+			setupMock:      func(m *MockTagService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid name",
+			body: `{"name":"!!"}`,
+			setupMock: func(m *MockTagService) {
+				m.On("CreateTag", "!!").Return(nil, fmt.Errorf("validation failed: %w", models.Invalid("name", "invalid_tag_name")))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "duplicate tag",
+			body: `{"name":"cooperatif"}`,
+			setupMock: func(m *MockTagService) {
+				m.On("CreateTag", "cooperatif").Return(nil, fmt.Errorf("failed to create tag: %w", models.Conflict("tag_slug_taken", "cooperatif")))
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupTagHandlerTest()
+			tt.setupMock(mockService)
+
+			req, _ := http.NewRequest("POST", "/api/tags", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagHandler_RenameTag(t *testing.T) {
+	t.Run("renamed", func(t *testing.T) {
+		router, mockService := setupTagHandlerTest()
+		mockService.On("RenameTag", 1, "Coopération").Return(&models.Tag{ID: 1, Name: "Coopération", Slug: "cooperation"}, nil)
+
+		req, _ := http.NewRequest("PUT", "/api/tags/1", bytes.NewBufferString(`{"name":"Coopération"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unknown tag", func(t *testing.T) {
+		router, mockService := setupTagHandlerTest()
+		mockService.On("RenameTag", 9, "Coop").Return(nil, fmt.Errorf("failed to get tag: %w", models.NotFound("tag_not_found", 9)))
+
+		req, _ := http.NewRequest("PUT", "/api/tags/9", bytes.NewBufferString(`{"name":"Coop"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid ID", func(t *testing.T) {
+		router, _ := setupTagHandlerTest()
+
+		req, _ := http.NewRequest("PUT", "/api/tags/abc", bytes.NewBufferString(`{"name":"Coop"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTagHandler_MergeTag(t *testing.T) {
+	router, mockService := setupTagHandlerTest()
+	mockService.On("MergeTags", 1, 2).Return(&models.Tag{ID: 2, Name: "Coopératif", Slug: "cooperatif", GameCount: 6}, nil)
+	mockService.On("MergeTags", 2, 2).Return(nil, fmt.Errorf("validation failed: %w", models.Invalid("target_id", "tag_merged_into_itself")))
+
+	req, _ := http.NewRequest("POST", "/api/tags/1/merge", bytes.NewBufferString(`{"target_id":2}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, float64(6), response["tag"].(map[string]interface{})["game_count"])
+
+	req, _ = http.NewRequest("POST", "/api/tags/2/merge", bytes.NewBufferString(`{"target_id":2}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTagHandler_DeleteTag(t *testing.T) {
+	router, mockService := setupTagHandlerTest()
+	mockService.On("DeleteTag", 1).Return(nil)
+
+	req, _ := http.NewRequest("DELETE", "/api/tags/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
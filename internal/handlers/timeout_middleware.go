@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// untimedContextKey keeps the context of a request as it was before
+// RequestTimeout gave it a deadline
+const untimedContextKey = "untimed_request_context"
+
+// RequestTimeout returns a middleware giving every request a deadline. The
+// services and repositories work with the context of the request, so the
+// queries still running when the deadline passes, or when the client goes
+// away, are cut off. A timeout of zero or less sets no deadline.
+func RequestTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Set(untimedContextKey, c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// LongRequestTimeout returns a route middleware replacing the deadline set
+// by RequestTimeout with timeout, for the routes streaming whole tables that
+// take longer than the others. The write deadline of the server is pushed
+// back to match, so that the response is not cut off either.
+func LongRequestTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if untimed, ok := c.Get(untimedContextKey); ok {
+			ctx = untimed.(context.Context)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		// Recorders and other writers without deadlines have nothing to
+		// push back
+		deadline, _ := ctx.Deadline()
+		http.NewResponseController(c.Writer).SetWriteDeadline(deadline)
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
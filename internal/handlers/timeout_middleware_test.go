@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		wantDeadline bool
+	}{
+		{name: "deadline set", timeout: time.Minute, wantDeadline: true},
+		{name: "disabled", timeout: 0, wantDeadline: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deadline time.Time
+			var hasDeadline bool
+			router := gin.New()
+			router.Use(RequestTimeout(tt.timeout))
+			router.GET("/slow", func(c *gin.Context) {
+				deadline, hasDeadline = c.Request.Context().Deadline()
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantDeadline, hasDeadline)
+			if tt.wantDeadline {
+				assert.WithinDuration(t, time.Now().Add(tt.timeout), deadline, 5*time.Second)
+			}
+		})
+	}
+}
+
+func TestRequestTimeoutCancelsSlowWork(t *testing.T) {
+	router := newWebTestRouter(t)
+	router.Use(RequestTimeout(10 * time.Millisecond))
+	router.GET("/slow", func(c *gin.Context) {
+		select {
+		case <-c.Request.Context().Done():
+			renderWebError(c, ErrorStatus(c.Request.Context().Err()), "Request cut off")
+		case <-time.After(time.Second):
+			c.Status(http.StatusOK)
+		}
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestLongRequestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var deadline time.Time
+	var hasDeadline bool
+	router := gin.New()
+	router.Use(RequestTimeout(time.Second))
+	router.GET("/export", LongRequestTimeout(time.Hour), func(c *gin.Context) {
+		deadline, hasDeadline = c.Request.Context().Deadline()
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), deadline, 5*time.Second)
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TransferServiceInterface defines the interface for bulk import and export
+// operations
+type TransferServiceInterface interface {
+	Import(ctx context.Context, table, format string, r io.Reader, dryRun bool) (*models.ImportResult, error)
+	Export(ctx context.Context, table, format string, w io.Writer) error
+}
+
+// TransferHandler handles HTTP requests importing and exporting whole tables
+type TransferHandler struct {
+	transferService TransferServiceInterface
+}
+
+// NewTransferHandler creates a new TransferHandler instance
+func NewTransferHandler(transferService TransferServiceInterface) *TransferHandler {
+	return &TransferHandler{
+		transferService: transferService,
+	}
+}
+
+// exportTimeout is the deadline of an export, which streams a whole table
+// and may take longer than the other requests
+const exportTimeout = 10 * time.Minute
+
+// transferContentTypes are the media types of the import and export formats
+var transferContentTypes = map[string]string{
+	models.TransferFormatCSV:  "text/csv; charset=utf-8",
+	models.TransferFormatJSON: "application/json; charset=utf-8",
+}
+
+// ImportTable handles POST /api/import/:table - import games, users or borrowings
+// @Summary Importer des données en masse
+// @Description Importe des jeux, des utilisateurs ou un historique d'emprunts depuis un fichier CSV (avec ligne d'en-tête) ou JSON (tableau d'objets), envoyé tel quel ou dans le champ "file" d'un formulaire. Chaque ligne est validée ; l'import est entièrement annulé si une ligne est invalide, et le problème renvoyé liste les erreurs de chaque ligne. En mode dry_run, rien n'est enregistré.
+// @Tags import-export
+// @Accept text/csv,json,mpfd
+// @Produce json
+// @Param table path string true "Table" Enums(games, users, borrowings)
+// @Param format query string false "Format du fichier, déduit de son nom ou de son type sinon" Enums(csv, json)
+// @Param dry_run query bool false "Valider sans enregistrer"
+// @Param file formData file false "Fichier à importer"
+// @Success 200 {object} map[string]interface{} "Fichier valide (dry_run)"
+// @Success 201 {object} map[string]interface{} "Lignes importées"
+// @Failure 400 {object} Problem "Fichier illisible, paramètres ou lignes invalides, rien n'a été importé"
+// @Failure 413 {object} Problem "Fichier trop volumineux"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /import/{table} [post]
+func (h *TransferHandler) ImportTable(c *gin.Context) {
+	dryRun, err := queryBool(c, "dry_run")
+	if err != nil {
+		c.Error(BindError(err))
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.MaxImportSize)
+	file, format, err := importFile(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer file.Close()
+
+	result, err := actingTransferService(c, h.transferService).Import(c.Request.Context(), c.Param("table"), format, file, dryRun != nil && *dryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := result.Err(); err != nil {
+		c.Error(err)
+		return
+	}
+
+	switch {
+	case result.DryRun:
+		c.JSON(http.StatusOK, gin.H{
+			"message": fmt.Sprintf("All %d rows are valid, nothing was imported (dry run)", result.Rows),
+			"import":  result,
+		})
+	default:
+		c.JSON(http.StatusCreated, gin.H{
+			"message": fmt.Sprintf("%d %s imported successfully", result.Imported, result.Table),
+			"import":  result,
+		})
+	}
+}
+
+// ExportTable handles GET /api/export/:table - export games, users or borrowings
+// @Summary Exporter des données en masse
+// @Description Télécharge tous les jeux, utilisateurs ou emprunts au format CSV ou JSON, relisible par l'import. Les lignes sont envoyées au fur et à mesure de leur lecture.
+// @Tags import-export
+// @Produce text/csv,json
+// @Param table path string true "Table" Enums(games, users, borrowings)
+// @Param format query string false "Format du fichier" Enums(csv, json) default(csv)
+// @Success 200 {file} file "Fichier exporté"
+// @Failure 400 {object} Problem "Paramètres invalides"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /export/{table} [get]
+func (h *TransferHandler) ExportTable(c *gin.Context) {
+	table := c.Param("table")
+	format := strings.ToLower(c.DefaultQuery("format", models.TransferFormatCSV))
+	if err := models.ValidateTransfer(table, format); err != nil {
+		c.Error(err)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", table, time.Now().Format("2006-01-02"), format)
+	c.Header("Content-Type", transferContentTypes[format])
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	if err := h.transferService.Export(c.Request.Context(), table, format, c.Writer); err != nil {
+		// Once rows are sent the status can no longer change; the
+		// truncated file is all the client gets
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+		}
+		c.Error(err)
+	}
+}
+
+// importFile returns the uploaded import file and its format: the format
+// query parameter, or else the one of the file's name or media type. The
+// file is the "file" field of a multipart form, or the request body.
+func importFile(c *gin.Context) (io.ReadCloser, string, error) {
+	var file io.ReadCloser = c.Request.Body
+	name, contentType := "", c.ContentType()
+
+	if contentType == "multipart/form-data" {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", models.Invalid("file", "import_file_required").Wrap(err)
+		}
+		if file, err = header.Open(); err != nil {
+			return nil, "", fmt.Errorf("failed to open the uploaded file: %w", err)
+		}
+		name, contentType = header.Filename, header.Header.Get("Content-Type")
+	}
+
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), ".")
+	}
+	if format == "" {
+		for candidate, mediaType := range transferContentTypes {
+			if strings.HasPrefix(contentType, strings.Split(mediaType, ";")[0]) {
+				format = candidate
+			}
+		}
+	}
+	if format == "" {
+		file.Close()
+		return nil, "", models.Invalid("format", "import_format_required")
+	}
+
+	return file, format, nil
+}
+
+// RegisterRoutes registers the bulk import and export routes
+func (h *TransferHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/import/:table", h.ImportTable)
+	router.GET("/export/:table", LongRequestTimeout(exportTimeout), h.ExportTable)
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransferService is a mock implementation of TransferServiceInterface
+type MockTransferService struct {
+	mock.Mock
+}
+
+func (m *MockTransferService) Import(ctx context.Context, table, format string, r io.Reader, dryRun bool) (*models.ImportResult, error) {
+	data, _ := io.ReadAll(r)
+	args := m.Called(table, format, string(data), dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ImportResult), args.Error(1)
+}
+
+func (m *MockTransferService) Export(ctx context.Context, table, format string, w io.Writer) error {
+	args := m.Called(table, format)
+	if data := args.String(0); data != "" {
+		io.WriteString(w, data)
+	}
+	return args.Error(1)
+}
+
+func setupTransferHandlerTest() (*gin.Engine, *MockTransferService) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockTransferService)
+	handler := NewTransferHandler(mockService)
+
+	router := gin.New()
+	router.Use(Problems())
+	api := router.Group("/api")
+	handler.RegisterRoutes(api)
+
+	return router, mockService
+}
+
+func TestTransferHandler_ImportTable(t *testing.T) {
+	file := "name,condition\nAzul,good\n"
+
+	t.Run("imports a CSV body", func(t *testing.T) {
+		router, mockService := setupTransferHandlerTest()
+		mockService.On("Import", "games", "csv", file, false).Return(&models.ImportResult{
+			Table: "games", Format: "csv", Rows: 1, Imported: 1, Committed: true, Errors: []models.ImportRowError{},
+		}, nil)
+
+		req, _ := http.NewRequest("POST", "/api/import/games", strings.NewReader(file))
+		req.Header.Set("Content-Type", "text/csv")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response map[string]interface{}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "1 games imported successfully", response["message"])
+		assert.Equal(t, true, response["import"].(map[string]interface{})["committed"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("dry run of an uploaded file", func(t *testing.T) {
+		router, mockService := setupTransferHandlerTest()
+		mockService.On("Import", "users", "json", `[]`, true).Return(&models.ImportResult{
+			Table: "users", Format: "json", DryRun: true, Errors: []models.ImportRowError{},
+		}, nil)
+
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("file", "members.JSON")
+		part.Write([]byte(`[]`))
+		form.Close()
+
+		req, _ := http.NewRequest("POST", "/api/import/users?dry_run=true", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "nothing was imported (dry run)")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("rejected rows", func(t *testing.T) {
+		router, mockService := setupTransferHandlerTest()
+		mockService.On("Import", "games", "csv", file, false).Return(&models.ImportResult{
+			Table: "games", Format: "csv", Rows: 1,
+			Errors: []models.ImportRowError{{Line: 2, Message: "game name is required"}},
+		}, nil)
+
+		req, _ := http.NewRequest("POST", "/api/import/games?format=csv", strings.NewReader(file))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var problem Problem
+		json.Unmarshal(w.Body.Bytes(), &problem)
+		assert.Equal(t, "import_rejected", problem.Code)
+		assert.Equal(t, "1 of 1 rows are invalid, nothing was imported", problem.Detail)
+		assert.Equal(t, []FieldProblem{{Field: "file", Code: "invalid_import_row", Detail: "line 2: game name is required"}}, problem.Errors)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		router, _ := setupTransferHandlerTest()
+
+		req, _ := http.NewRequest("POST", "/api/import/games", strings.NewReader(file))
+		req.Header.Set("Content-Type", "text/plain")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "format is required")
+	})
+
+	t.Run("unreadable file", func(t *testing.T) {
+		router, mockService := setupTransferHandlerTest()
+		mockService.On("Import", "games", "json", "{", false).Return(nil, fmt.Errorf("validation failed: %w", models.Invalid("file", "unreadable_import_file", "invalid JSON: unexpected EOF")))
+
+		req, _ := http.NewRequest("POST", "/api/import/games?format=json", strings.NewReader("{"))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("file too large", func(t *testing.T) {
+		router, mockService := setupTransferHandlerTest()
+		tooLarge := &http.MaxBytesError{Limit: 1024}
+		mockService.On("Import", "games", "csv", mock.Anything, false).Return(nil, fmt.Errorf("validation failed: %w", models.Invalid("file", "unreadable_import_file", tooLarge).Wrap(tooLarge)))
+
+		req, _ := http.NewRequest("POST", "/api/import/games?format=csv", strings.NewReader(file))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
+
+func TestTransferHandler_ExportTable(t *testing.T) {
+	t.Run("CSV by default", func(t *testing.T) {
+		router, mockService := setupTransferHandlerTest()
+		mockService.On("Export", "users", "csv").Return("id,name\n1,Alice\n", nil)
+
+		req, _ := http.NewRequest("GET", "/api/export/users", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), `attachment; filename="users-`)
+		assert.Equal(t, "id,name\n1,Alice\n", w.Body.String())
+	})
+
+	t.Run("invalid table", func(t *testing.T) {
+		router, _ := setupTransferHandlerTest()
+
+		req, _ := http.NewRequest("GET", "/api/export/alerts?format=json", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("failure before any row", func(t *testing.T) {
+		router, mockService := setupTransferHandlerTest()
+		mockService.On("Export", "games", "json").Return("", errors.New("failed to export games: database is locked"))
+
+		req, _ := http.NewRequest("GET", "/api/export/games?format=json", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "internal_error")
+		assert.NotContains(t, w.Body.String(), "database is locked")
+	})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"board-game-library/internal/models"
+	"context"
 	"net/http"
 	"strconv"
 
@@ -10,13 +11,17 @@ import (
 
 // UserServiceInterface defines the interface for user service operations
 type UserServiceInterface interface {
-	RegisterUser(name, email string) (*models.User, error)
-	GetUser(id int) (*models.User, error)
-	GetAllUsers() ([]*models.User, error)
-	GetUserBorrowings(userID int) ([]*models.Borrowing, error)
-	CanUserBorrow(userID int) (bool, error)
-	GetActiveUserBorrowings(userID int) ([]*models.Borrowing, error)
-	UpdateUser(user *models.User) error
+	RegisterUser(ctx context.Context, name, email string) (*models.User, error)
+	GetUser(ctx context.Context, id int) (*models.User, error)
+	GetAllUsers(ctx context.Context) ([]*models.User, error)
+	ListUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, int, error)
+	GetUserBorrowings(ctx context.Context, userID int) ([]*models.Borrowing, error)
+	CanUserBorrow(ctx context.Context, userID int) (bool, error)
+	CheckEligibility(ctx context.Context, userID int) (*models.BorrowEligibility, error)
+	GetFineBalance(ctx context.Context, userID int) (int, error)
+	GetActiveUserBorrowings(ctx context.Context, userID int) ([]*models.Borrowing, error)
+	UpdateUser(ctx context.Context, user *models.User) error
+	DeleteUser(ctx context.Context, userID int) error
 }
 
 // UserHandler handles HTTP requests for user management
@@ -41,26 +46,13 @@ type RegisterUserRequest struct {
 func (h *UserHandler) RegisterUser(c *gin.Context) {
 	var req RegisterUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
-			"details": err.Error(),
-		})
+		c.Error(BindError(err))
 		return
 	}
 
-	user, err := h.userService.RegisterUser(req.Name, req.Email)
+	user, err := actingUserService(c, h.userService).RegisterUser(c.Request.Context(), req.Name, req.Email)
 	if err != nil {
-		if err.Error() == "user with email "+req.Email+" already exists" {
-			c.JSON(http.StatusConflict, gin.H{
-				"error":   "User already exists",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to register user",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -70,21 +62,38 @@ func (h *UserHandler) RegisterUser(c *gin.Context) {
 	})
 }
 
-// GetAllUsers handles GET /api/users - list all users
+// GetAllUsers handles GET /api/users - list users one page at a time
+// @Summary Lister les utilisateurs
+// @Description Récupère une page d'utilisateurs, filtrée et triée
+// @Tags users
+// @Produce json
+// @Param search query string false "Terme de recherche (nom, e-mail)"
+// @Param membership_tier query string false "Filtrer par niveau d'adhésion"
+// @Param role query string false "Filtrer par rôle (member, librarian, admin)"
+// @Param active query boolean false "Filtrer par statut actif"
+// @Param has_loans query boolean false "Filtrer les utilisateurs ayant des jeux non rendus"
+// @Param sort query string false "Tri (name, email, registered_at, membership_tier)" default(name)
+// @Param order query string false "Sens du tri (asc, desc)"
+// @Param page query int false "Numéro de page" default(1)
+// @Param per_page query int false "Utilisateurs par page (100 au maximum)" default(20)
+// @Success 200 {object} map[string]interface{} "Page d'utilisateurs"
+// @Failure 400 {object} Problem "Paramètres invalides"
+// @Failure 500 {object} Problem "Erreur serveur"
+// @Router /users [get]
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
-	users, err := h.userService.GetAllUsers()
+	filter, err := UserFilterFromQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve users",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"users": users,
-		"count": len(users),
-	})
+	users, total, err := h.userService.ListUsers(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, listResponse("users", users, len(users), total, filter.ListOptions))
 }
 
 // GetUser handles GET /api/users/:id - get user by ID
@@ -92,31 +101,25 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": "User ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	user, err := h.userService.GetUser(id)
+	user, err := h.userService.GetUser(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "user not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "User not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve user",
-			"details": err.Error(),
-		})
+		c.Error(err)
+		return
+	}
+
+	balance, err := h.userService.GetFineBalance(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"user": user,
+		"user":               user,
+		"fine_balance_cents": balance,
 	})
 }
 
@@ -125,26 +128,13 @@ func (h *UserHandler) GetUserBorrowings(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": "User ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	borrowings, err := h.userService.GetUserBorrowings(id)
+	borrowings, err := h.userService.GetUserBorrowings(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "user not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "User not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve user borrowings",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -159,26 +149,13 @@ func (h *UserHandler) GetUserCurrentLoans(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": "User ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	activeBorrowings, err := h.userService.GetActiveUserBorrowings(id)
+	activeBorrowings, err := h.userService.GetActiveUserBorrowings(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "user not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "User not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve current loans",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -193,43 +170,30 @@ type UpdateUserRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	IsActive *bool  `json:"is_active"`
+	// Optional, one of the tiers listed by GET /api/v1/loan-policies
+	MembershipTier string `json:"membership_tier"`
 }
 
 // UpdateUser handles PUT /api/users/:id - update user information
 func (h *UserHandler) UpdateUser(c *gin.Context) {
+	ctx := c.Request.Context()
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": "User ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
 	var req UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
-			"details": err.Error(),
-		})
+		c.Error(BindError(err))
 		return
 	}
 
 	// Get existing user
-	existingUser, err := h.userService.GetUser(id)
+	existingUser, err := h.userService.GetUser(ctx, id)
 	if err != nil {
-		if err.Error() == "user not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "User not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to retrieve user",
-			"details": err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
@@ -239,13 +203,13 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	if req.IsActive != nil {
 		existingUser.IsActive = *req.IsActive
 	}
+	if req.MembershipTier != "" {
+		existingUser.MembershipTier = req.MembershipTier
+	}
 
 	// Update user
-	if err := h.userService.UpdateUser(existingUser); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to update user",
-			"details": err.Error(),
-		})
+	if err := actingUserService(c, h.userService).UpdateUser(ctx, existingUser); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -256,38 +220,22 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 }
 
 // CheckUserEligibility handles GET /api/users/:id/eligibility - check if user can borrow
+// and, when not, which loan policy rule blocks them
 func (h *UserHandler) CheckUserEligibility(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid user ID",
-			"details": "User ID must be a valid integer",
-		})
+		c.Error(models.Invalid("id", "invalid_integer", "id"))
 		return
 	}
 
-	canBorrow, err := h.userService.CanUserBorrow(id)
+	eligibility, err := h.userService.CheckEligibility(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "user not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "User not found",
-				"details": err.Error(),
-			})
-			return
-		}
-		// For business logic errors (like overdue items), return success with can_borrow=false
-		c.JSON(http.StatusOK, gin.H{
-			"can_borrow": false,
-			"reason":     err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"can_borrow": canBorrow,
-		"reason":     "User is eligible to borrow",
-	})
+	c.JSON(http.StatusOK, eligibility)
 }
 
 // RegisterRoutes registers all user-related routes
@@ -3,6 +3,7 @@ package handlers
 import (
 	"board-game-library/internal/models"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -20,7 +21,7 @@ type MockUserService struct {
 	mock.Mock
 }
 
-func (m *MockUserService) RegisterUser(name, email string) (*models.User, error) {
+func (m *MockUserService) RegisterUser(ctx context.Context, name, email string) (*models.User, error) {
 	args := m.Called(name, email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -28,7 +29,7 @@ func (m *MockUserService) RegisterUser(name, email string) (*models.User, error)
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserService) GetUser(id int) (*models.User, error) {
+func (m *MockUserService) GetUser(ctx context.Context, id int) (*models.User, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -36,7 +37,7 @@ func (m *MockUserService) GetUser(id int) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserService) GetAllUsers() ([]*models.User, error) {
+func (m *MockUserService) GetAllUsers(ctx context.Context) ([]*models.User, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -44,7 +45,15 @@ func (m *MockUserService) GetAllUsers() ([]*models.User, error) {
 	return args.Get(0).([]*models.User), args.Error(1)
 }
 
-func (m *MockUserService) GetUserBorrowings(userID int) ([]*models.Borrowing, error) {
+func (m *MockUserService) ListUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, int, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserService) GetUserBorrowings(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -52,12 +61,25 @@ func (m *MockUserService) GetUserBorrowings(userID int) ([]*models.Borrowing, er
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockUserService) CanUserBorrow(userID int) (bool, error) {
+func (m *MockUserService) CanUserBorrow(ctx context.Context, userID int) (bool, error) {
 	args := m.Called(userID)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockUserService) GetActiveUserBorrowings(userID int) ([]*models.Borrowing, error) {
+func (m *MockUserService) CheckEligibility(ctx context.Context, userID int) (*models.BorrowEligibility, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BorrowEligibility), args.Error(1)
+}
+
+func (m *MockUserService) GetFineBalance(ctx context.Context, userID int) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserService) GetActiveUserBorrowings(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -65,11 +87,16 @@ func (m *MockUserService) GetActiveUserBorrowings(userID int) ([]*models.Borrowi
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockUserService) UpdateUser(user *models.User) error {
+func (m *MockUserService) UpdateUser(ctx context.Context, user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
+func (m *MockUserService) DeleteUser(ctx context.Context, userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
 func setupUserHandlerTest() (*gin.Engine, *MockUserService, *UserHandler) {
 	gin.SetMode(gin.TestMode)
 	
@@ -79,6 +106,7 @@ func setupUserHandlerTest() (*gin.Engine, *MockUserService, *UserHandler) {
 	}
 	
 	router := gin.New()
+	router.Use(Problems())
 	api := router.Group("/api")
 	handler.RegisterRoutes(api)
 	
@@ -140,11 +168,11 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 		var response map[string]interface{}
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Invalid request data", response["error"])
+		assert.Equal(t, "field_required", response["code"])
 	})
 
 	t.Run("user already exists", func(t *testing.T) {
-		mockService.On("RegisterUser", "Jane Doe", "jane@example.com").Return(nil, fmt.Errorf("user with email jane@example.com already exists"))
+		mockService.On("RegisterUser", "Jane Doe", "jane@example.com").Return(nil, models.Conflict("user_email_taken", "jane@example.com"))
 
 		reqBody := RegisterUserRequest{
 			Name:  "Jane Doe",
@@ -163,7 +191,7 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 		var response map[string]interface{}
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "User already exists", response["error"])
+		assert.Equal(t, "user_email_taken", response["code"])
 
 		mockService.AssertExpectations(t)
 	})
@@ -178,7 +206,8 @@ func TestUserHandler_GetAllUsers(t *testing.T) {
 			{ID: 2, Name: "Jane Doe", Email: "jane@example.com", IsActive: true},
 		}
 
-		mockService.On("GetAllUsers").Return(expectedUsers, nil)
+		filter := models.UserFilter{ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage}}
+		mockService.On("ListUsers", filter).Return(expectedUsers, 2, nil)
 
 		req, _ := http.NewRequest("GET", "/api/users", nil)
 		w := httptest.NewRecorder()
@@ -198,7 +227,7 @@ func TestUserHandler_GetAllUsers(t *testing.T) {
 
 	t.Run("service error", func(t *testing.T) {
 		router, mockService, _ := setupUserHandlerTest()
-		mockService.On("GetAllUsers").Return(nil, fmt.Errorf("database error"))
+		mockService.On("ListUsers", mock.Anything).Return(nil, 0, fmt.Errorf("database error"))
 
 		req, _ := http.NewRequest("GET", "/api/users", nil)
 		w := httptest.NewRecorder()
@@ -210,10 +239,37 @@ func TestUserHandler_GetAllUsers(t *testing.T) {
 		var response map[string]interface{}
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Failed to retrieve users", response["error"])
+		assert.Equal(t, "internal_error", response["code"])
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("filtered page", func(t *testing.T) {
+		router, mockService, _ := setupUserHandlerTest()
+		active := true
+		filter := models.UserFilter{Search: "doe", Role: "librarian", Active: &active, ListOptions: models.ListOptions{Page: 1, PerPage: models.MaxPerPage}}
+		mockService.On("ListUsers", filter).Return([]*models.User{}, 0, nil)
+
+		req, _ := http.NewRequest("GET", "/api/users?search=doe&role=librarian&active=true&per_page=1000", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("invalid active flag", func(t *testing.T) {
+		router, _, _ := setupUserHandlerTest()
+
+		req, _ := http.NewRequest("GET", "/api/users?active=maybe", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
 
 func TestUserHandler_GetUser(t *testing.T) {
@@ -228,6 +284,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 		}
 
 		mockService.On("GetUser", 1).Return(expectedUser, nil)
+		mockService.On("GetFineBalance", 1).Return(350, nil)
 
 		req, _ := http.NewRequest("GET", "/api/users/1", nil)
 		w := httptest.NewRecorder()
@@ -240,6 +297,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.NotNil(t, response["user"])
+		assert.Equal(t, float64(350), response["fine_balance_cents"])
 
 		mockService.AssertExpectations(t)
 	})
@@ -255,11 +313,11 @@ func TestUserHandler_GetUser(t *testing.T) {
 		var response map[string]interface{}
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "Invalid user ID", response["error"])
+		assert.Equal(t, "invalid_integer", response["code"])
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mockService.On("GetUser", 999).Return(nil, fmt.Errorf("user not found"))
+		mockService.On("GetUser", 999).Return(nil, models.NotFound("user_not_found", 999))
 
 		req, _ := http.NewRequest("GET", "/api/users/999", nil)
 		w := httptest.NewRecorder()
@@ -271,7 +329,7 @@ func TestUserHandler_GetUser(t *testing.T) {
 		var response map[string]interface{}
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Equal(t, "User not found", response["error"])
+		assert.Equal(t, "user_not_found", response["code"])
 
 		mockService.AssertExpectations(t)
 	})
@@ -305,7 +363,7 @@ func TestUserHandler_GetUserBorrowings(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mockService.On("GetUserBorrowings", 999).Return(nil, fmt.Errorf("user not found"))
+		mockService.On("GetUserBorrowings", 999).Return(nil, models.NotFound("user_not_found", 1))
 
 		req, _ := http.NewRequest("GET", "/api/users/999/borrowings", nil)
 		w := httptest.NewRecorder()
@@ -349,7 +407,13 @@ func TestUserHandler_CheckUserEligibility(t *testing.T) {
 	router, mockService, _ := setupUserHandlerTest()
 
 	t.Run("user can borrow", func(t *testing.T) {
-		mockService.On("CanUserBorrow", 1).Return(true, nil)
+		mockService.On("CheckEligibility", 1).Return(&models.BorrowEligibility{
+			CanBorrow:    true,
+			Reason:       "User is eligible to borrow",
+			Tier:         "standard",
+			CurrentLoans: 1,
+			MaxLoans:     5,
+		}, nil)
 
 		req, _ := http.NewRequest("GET", "/api/users/1/eligibility", nil)
 		w := httptest.NewRecorder()
@@ -362,13 +426,21 @@ func TestUserHandler_CheckUserEligibility(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, true, response["can_borrow"])
+		assert.Equal(t, "standard", response["tier"])
+		assert.Equal(t, float64(1), response["current_loans"])
+		assert.Equal(t, float64(5), response["max_loans"])
+		assert.NotContains(t, response, "rule")
 
 		mockService.AssertExpectations(t)
 	})
 
 	t.Run("user has overdue items", func(t *testing.T) {
 		router, mockService, _ := setupUserHandlerTest()
-		mockService.On("CanUserBorrow", 1).Return(false, fmt.Errorf("user has overdue items"))
+		mockService.On("CheckEligibility", 1).Return(&models.BorrowEligibility{
+			Rule:   models.PolicyRuleOverdueItems,
+			Reason: "user has overdue items and cannot borrow",
+			Tier:   "standard",
+		}, nil)
 
 		req, _ := http.NewRequest("GET", "/api/users/1/eligibility", nil)
 		w := httptest.NewRecorder()
@@ -381,8 +453,49 @@ func TestUserHandler_CheckUserEligibility(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
 		assert.Equal(t, false, response["can_borrow"])
-		assert.Equal(t, "user has overdue items", response["reason"])
+		assert.Equal(t, "overdue_items", response["rule"])
+		assert.Equal(t, "user has overdue items and cannot borrow", response["reason"])
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("loan limit reached", func(t *testing.T) {
+		router, mockService, _ := setupUserHandlerTest()
+		mockService.On("CheckEligibility", 1).Return(&models.BorrowEligibility{
+			Rule:         models.PolicyRuleMaxLoans,
+			Reason:       "user has reached the limit of 2 concurrent loan(s) for the basic tier",
+			Tier:         "basic",
+			CurrentLoans: 2,
+			MaxLoans:     2,
+		}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/users/1/eligibility", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, false, response["can_borrow"])
+		assert.Equal(t, "max_loans", response["rule"])
+		assert.Equal(t, "basic", response["tier"])
+
+		mockService.AssertExpectations(t)
+	})
 
+	t.Run("user not found", func(t *testing.T) {
+		router, mockService, _ := setupUserHandlerTest()
+		mockService.On("CheckEligibility", 999).Return(nil, fmt.Errorf("user not found: %w", models.NotFound("user_not_found", 1)))
+
+		req, _ := http.NewRequest("GET", "/api/users/999/eligibility", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
 		mockService.AssertExpectations(t)
 	})
 }
@@ -428,7 +541,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		mockService.On("GetUser", 999).Return(nil, fmt.Errorf("user not found"))
+		mockService.On("GetUser", 999).Return(nil, models.NotFound("user_not_found", 1))
 
 		reqBody := UpdateUserRequest{
 			Name:  "John Smith",
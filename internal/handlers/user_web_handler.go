@@ -2,9 +2,9 @@ package handlers
 
 import (
 	"board-game-library/internal/models"
+	"context"
+	"fmt"
 	"net/http"
-	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -13,222 +13,278 @@ import (
 // UserWebHandler handles web requests for user management with HTMX support
 type UserWebHandler struct {
 	userService UserServiceInterface
+	gameService GameServiceInterface
 }
 
 // NewUserWebHandler creates a new UserWebHandler instance
-func NewUserWebHandler(userService UserServiceInterface) *UserWebHandler {
+func NewUserWebHandler(userService UserServiceInterface, gameService GameServiceInterface) *UserWebHandler {
 	return &UserWebHandler{
 		userService: userService,
+		gameService: gameService,
 	}
 }
 
-// ListUsers handles GET /users - display users list page
-func (h *UserWebHandler) ListUsers(c *gin.Context) {
-	users, err := h.userService.GetAllUsers()
-	if err != nil {
-		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
-			"Title":        "Error",
-			"ErrorMessage": "Failed to load users: " + err.Error(),
-		})
-		return
+// UserStats sums up the loans of a user on their profile
+type UserStats struct {
+	CurrentLoans  int
+	TotalBorrowed int
+	OverdueItems  int
+}
+
+// userListData loads the page of users selected by the list controls
+func (h *UserWebHandler) userListData(c *gin.Context) (gin.H, error) {
+	ctx := c.Request.Context()
+	filter := models.UserFilter{
+		Search:      webValue(c, "search"),
+		ListOptions: webListOptions(c),
+	}
+	status := webValue(c, "status")
+	switch status {
+	case "active":
+		active := true
+		filter.Active = &active
+	case "inactive":
+		active := false
+		filter.Active = &active
+	case "with-loans":
+		hasLoans := true
+		filter.HasLoans = &hasLoans
 	}
 
-	// Add current loans count for each user
+	users, total, err := h.userService.ListUsers(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
 	for _, user := range users {
-		activeBorrowings, _ := h.userService.GetActiveUserBorrowings(user.ID)
-		user.CurrentLoans = len(activeBorrowings)
+		if active, err := h.userService.GetActiveUserBorrowings(ctx, user.ID); err == nil {
+			user.CurrentLoans = len(active)
+		}
 	}
 
-	c.HTML(http.StatusOK, "users/list.html", gin.H{
-		"Title": "Users",
-		"Users": users,
-	})
+	return gin.H{
+		"Title":      "Users",
+		"Users":      users,
+		"Total":      total,
+		"Search":     filter.Search,
+		"Status":     status,
+		"Sort":       filter.Sort,
+		"Order":      filter.Order,
+		"Pagination": newWebPagination(filter.ListOptions, total, "/users/search-filter", "#users-table", "#search, #status, #sort, #order"),
+	}, nil
 }
 
-// SearchUsers handles POST /users/search - HTMX search for users
-func (h *UserWebHandler) SearchUsers(c *gin.Context) {
-	query := strings.TrimSpace(c.PostForm("search"))
-	
-	users, err := h.userService.GetAllUsers()
+// ListUsers handles GET /users - display users list page
+func (h *UserWebHandler) ListUsers(c *gin.Context) {
+	data, err := h.userListData(c)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Search failed: " + err.Error(),
-		})
+		webListError(c, err, "Failed to load users")
 		return
 	}
 
-	// Filter users based on search query
-	if query != "" {
-		filteredUsers := make([]*models.User, 0)
-		queryLower := strings.ToLower(query)
-		
-		for _, user := range users {
-			if strings.Contains(strings.ToLower(user.Name), queryLower) ||
-			   strings.Contains(strings.ToLower(user.Email), queryLower) {
-				filteredUsers = append(filteredUsers, user)
-			}
-		}
-		users = filteredUsers
-	}
+	renderWeb(c, http.StatusOK, "users/list.html", data)
+}
 
-	// Add current loans count for each user
-	for _, user := range users {
-		activeBorrowings, _ := h.userService.GetActiveUserBorrowings(user.ID)
-		user.CurrentLoans = len(activeBorrowings)
+// FilterUsers handles POST /users/search, /users/search-filter and
+// /users/sort - HTMX refresh of the users table
+func (h *UserWebHandler) FilterUsers(c *gin.Context) {
+	data, err := h.userListData(c)
+	if err != nil {
+		webListError(c, err, "Failed to load users")
+		return
 	}
 
-	c.HTML(http.StatusOK, "users/partials/users-table.html", gin.H{
-		"Users": users,
-	})
+	renderWeb(c, http.StatusOK, "users/partials/users-table.html", data)
 }
 
-// SearchFilterUsers handles POST /users/search-filter - Combined HTMX search and filter
-func (h *UserWebHandler) SearchFilterUsers(c *gin.Context) {
-	query := strings.TrimSpace(c.PostForm("search"))
-	status := c.PostForm("status")
-	
-	users, err := h.userService.GetAllUsers()
+// userHistory loads the borrowing history of a user
+func (h *UserWebHandler) userHistory(ctx context.Context, id int) ([]BorrowingView, error) {
+	borrowings, err := h.userService.GetUserBorrowings(ctx, id)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Search failed: " + err.Error(),
-		})
+		return nil, err
+	}
+	return newWebNames(h.userService, h.gameService).borrowings(ctx, borrowings), nil
+}
+
+// ShowUser handles GET /users/:id - display user details modal
+func (h *UserWebHandler) ShowUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, ok := webID(c, "user")
+	if !ok {
 		return
 	}
 
-	// Add current loans count for each user first
-	for _, user := range users {
-		activeBorrowings, _ := h.userService.GetActiveUserBorrowings(user.ID)
-		user.CurrentLoans = len(activeBorrowings)
+	user, err := h.userService.GetUser(ctx, id)
+	if err != nil {
+		renderWebError(c, http.StatusNotFound, "User not found")
+		return
 	}
 
-	// Filter users based on search query
-	if query != "" {
-		filteredUsers := make([]*models.User, 0)
-		queryLower := strings.ToLower(query)
-		
-		for _, user := range users {
-			if strings.Contains(strings.ToLower(user.Name), queryLower) ||
-			   strings.Contains(strings.ToLower(user.Email), queryLower) {
-				filteredUsers = append(filteredUsers, user)
-			}
-		}
-		users = filteredUsers
+	history, err := h.userHistory(ctx, id)
+	if err != nil {
+		renderWebError(c, http.StatusInternalServerError, "Failed to load borrowing history: "+err.Error())
+		return
 	}
 
-	// Filter by status
-	if status != "" && status != "all" {
-		filteredUsers := make([]*models.User, 0)
-		for _, user := range users {
-			switch status {
-			case "active":
-				if user.IsActive {
-					filteredUsers = append(filteredUsers, user)
-				}
-			case "inactive":
-				if !user.IsActive {
-					filteredUsers = append(filteredUsers, user)
-				}
-			case "with-loans":
-				if user.CurrentLoans > 0 {
-					filteredUsers = append(filteredUsers, user)
-				}
-			}
+	stats := UserStats{TotalBorrowed: len(history)}
+	current := make([]BorrowingView, 0)
+	for _, borrowing := range history {
+		if borrowing.ReturnedAt != nil {
+			continue
+		}
+		current = append(current, borrowing)
+		if borrowing.IsCurrentlyOverdue() {
+			stats.OverdueItems++
 		}
-		users = filteredUsers
 	}
+	stats.CurrentLoans = len(current)
 
-	c.HTML(http.StatusOK, "users/partials/users-table.html", gin.H{
-		"Users": users,
+	renderWeb(c, http.StatusOK, "users/detail.html", gin.H{
+		"Title":             user.Name,
+		"User":              user,
+		"Stats":             stats,
+		"CurrentBorrowings": current,
+		"BorrowingHistory":  history,
 	})
 }
 
-// SortUsers handles POST /users/sort - HTMX sort for users
-func (h *UserWebHandler) SortUsers(c *gin.Context) {
-	field := c.PostForm("field")
-	direction := c.PostForm("direction")
-	
-	users, err := h.userService.GetAllUsers()
-	if err != nil {
-		c.HTML(http.StatusInternalServerError, "partials/error.html", gin.H{
-			"ErrorMessage": "Sort failed: " + err.Error(),
-		})
+// UserHistory handles POST /users/:id/history - HTMX filter of the borrowing history of a user
+func (h *UserWebHandler) UserHistory(c *gin.Context) {
+	id, ok := webID(c, "user")
+	if !ok {
 		return
 	}
 
-	// Add current loans count for each user
-	for _, user := range users {
-		activeBorrowings, _ := h.userService.GetActiveUserBorrowings(user.ID)
-		user.CurrentLoans = len(activeBorrowings)
+	history, err := h.userHistory(c.Request.Context(), id)
+	if err != nil {
+		renderWebError(c, ErrorStatus(err), "Failed to load borrowing history: "+err.Error())
+		return
 	}
 
-	// Sort users based on field and direction
-	sortUsers(users, field, direction)
+	renderWeb(c, http.StatusOK, "users/partials/user-borrowing-history.html", gin.H{
+		"BorrowingHistory": filterBorrowingViews(history, c.PostForm("status")),
+	})
+}
 
-	c.HTML(http.StatusOK, "users/partials/users-table.html", gin.H{
-		"Users":         users,
-		"SortField":     field,
-		"SortDirection": direction,
+// ShowNewUserForm handles GET /users/new - display new user form modal
+func (h *UserWebHandler) ShowNewUserForm(c *gin.Context) {
+	renderWeb(c, http.StatusOK, "users/new.html", gin.H{
+		"Title": "Add New User",
+		"User":  &models.User{IsActive: true},
 	})
 }
 
-// ShowUser handles GET /users/:id - display user details modal
-func (h *UserWebHandler) ShowUser(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
-	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid user ID",
-		})
-		return
+// CreateUser handles POST /users - register a user from the new user form
+func (h *UserWebHandler) CreateUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	form := &models.User{
+		Name:     strings.TrimSpace(c.PostForm("name")),
+		Email:    strings.TrimSpace(c.PostForm("email")),
+		IsActive: c.PostForm("is_active") != "false",
 	}
 
-	user, err := h.userService.GetUser(id)
+	service := actingUserService(c, h.userService)
+	user, err := service.RegisterUser(ctx, form.Name, form.Email)
+	if err == nil && !form.IsActive {
+		user.IsActive = false
+		err = service.UpdateUser(ctx, user)
+	}
 	if err != nil {
-		c.HTML(http.StatusNotFound, "partials/error.html", gin.H{
-			"ErrorMessage": "User not found",
+		renderWeb(c, ErrorStatus(err), "users/new.html", gin.H{
+			"Title":        "Add New User",
+			"User":         form,
+			"ErrorMessage": "Failed to add user: " + err.Error(),
 		})
 		return
 	}
 
-	// Get borrowing history
-	borrowings, _ := h.userService.GetUserBorrowings(id)
-	activeBorrowings, _ := h.userService.GetActiveUserBorrowings(id)
-
-	c.HTML(http.StatusOK, "users/detail.html", gin.H{
-		"User":             user,
-		"Borrowings":       borrowings,
-		"ActiveBorrowings": activeBorrowings,
+	c.Header("HX-Trigger", "user-created, refresh-users")
+	renderWeb(c, http.StatusOK, "success.html", gin.H{
+		"Title":   "User Added",
+		"Message": fmt.Sprintf("%s has been registered.", user.Name),
+		"Link":    fmt.Sprintf("/users/%d", user.ID),
 	})
 }
 
-// ShowNewUserForm handles GET /users/new - display new user form modal
-func (h *UserWebHandler) ShowNewUserForm(c *gin.Context) {
-	c.HTML(http.StatusOK, "users/new.html", gin.H{
-		"Title": "Add New User",
-	})
+// editUserData is the data of the edit user form
+func (h *UserWebHandler) editUserData(ctx context.Context, user *models.User) gin.H {
+	data := gin.H{
+		"Title": "Edit " + user.Name,
+		"User":  user,
+	}
+	if active, err := h.userService.GetActiveUserBorrowings(ctx, user.ID); err == nil && len(active) > 0 {
+		data["HasCurrentBorrowings"] = true
+		data["CurrentBorrowingsCount"] = len(active)
+	}
+	return data
 }
 
 // ShowEditUserForm handles GET /users/:id/edit - display edit user form modal
 func (h *UserWebHandler) ShowEditUserForm(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
+	ctx := c.Request.Context()
+	id, ok := webID(c, "user")
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetUser(ctx, id)
 	if err != nil {
-		c.HTML(http.StatusBadRequest, "partials/error.html", gin.H{
-			"ErrorMessage": "Invalid user ID",
-		})
+		renderWebError(c, http.StatusNotFound, "User not found")
 		return
 	}
 
-	user, err := h.userService.GetUser(id)
+	renderWeb(c, http.StatusOK, "users/edit.html", h.editUserData(ctx, user))
+}
+
+// UpdateUser handles PUT /users/:id - save the edit user form
+func (h *UserWebHandler) UpdateUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, ok := webID(c, "user")
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetUser(ctx, id)
 	if err != nil {
-		c.HTML(http.StatusNotFound, "partials/error.html", gin.H{
-			"ErrorMessage": "User not found",
-		})
+		renderWebError(c, http.StatusNotFound, "User not found")
 		return
 	}
 
-	c.HTML(http.StatusOK, "users/edit.html", gin.H{
-		"User": user,
+	user.Name = strings.TrimSpace(c.PostForm("name"))
+	user.Email = strings.TrimSpace(c.PostForm("email"))
+	if active := c.PostForm("is_active"); active != "" {
+		user.IsActive = active == "true"
+	}
+	if err := actingUserService(c, h.userService).UpdateUser(ctx, user); err != nil {
+		data := h.editUserData(ctx, user)
+		data["ErrorMessage"] = "Failed to update user: " + err.Error()
+		renderWeb(c, ErrorStatus(err), "users/edit.html", data)
+		return
+	}
+
+	c.Header("HX-Trigger", "user-updated, refresh-users")
+	renderWeb(c, http.StatusOK, "success.html", gin.H{
+		"Title":   "User Updated",
+		"Message": fmt.Sprintf("%s has been updated.", user.Name),
+		"Link":    fmt.Sprintf("/users/%d", user.ID),
+	})
+}
+
+// DeleteUser handles DELETE /users/:id - remove a user with no game out
+func (h *UserWebHandler) DeleteUser(c *gin.Context) {
+	id, ok := webID(c, "user")
+	if !ok {
+		return
+	}
+
+	if err := actingUserService(c, h.userService).DeleteUser(c.Request.Context(), id); err != nil {
+		renderWebError(c, ErrorStatus(err), "Failed to delete user: "+err.Error())
+		return
+	}
+
+	c.Header("HX-Trigger", "user-deleted, refresh-users")
+	renderWeb(c, http.StatusOK, "success.html", gin.H{
+		"Title":   "User Deleted",
+		"Message": "The user has been removed from the library.",
 	})
 }
 
@@ -237,38 +293,15 @@ func (h *UserWebHandler) RegisterWebRoutes(router *gin.RouterGroup) {
 	users := router.Group("/users")
 	{
 		users.GET("", h.ListUsers)
-		users.POST("/search", h.SearchUsers)
-		users.POST("/search-filter", h.SearchFilterUsers)
-		users.POST("/sort", h.SortUsers)
+		users.POST("", h.CreateUser)
+		users.POST("/search", h.FilterUsers)
+		users.POST("/search-filter", h.FilterUsers)
+		users.POST("/sort", h.FilterUsers)
 		users.GET("/new", h.ShowNewUserForm)
 		users.GET("/:id", h.ShowUser)
+		users.PUT("/:id", h.UpdateUser)
+		users.DELETE("/:id", h.DeleteUser)
 		users.GET("/:id/edit", h.ShowEditUserForm)
+		users.POST("/:id/history", h.UserHistory)
 	}
 }
-
-// sortUsers sorts users slice based on field and direction
-func sortUsers(users []*models.User, field, direction string) {
-	switch field {
-	case "name":
-		sort.Slice(users, func(i, j int) bool {
-			if direction == "desc" {
-				return users[i].Name > users[j].Name
-			}
-			return users[i].Name < users[j].Name
-		})
-	case "registered_at":
-		sort.Slice(users, func(i, j int) bool {
-			if direction == "desc" {
-				return users[i].RegisteredAt.After(users[j].RegisteredAt)
-			}
-			return users[i].RegisteredAt.Before(users[j].RegisteredAt)
-		})
-	case "email":
-		sort.Slice(users, func(i, j int) bool {
-			if direction == "desc" {
-				return users[i].Email > users[j].Email
-			}
-			return users[i].Email < users[j].Email
-		})
-	}
-}
\ No newline at end of file
@@ -2,6 +2,10 @@ package handlers
 
 import (
 	"board-game-library/internal/models"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -14,7 +18,7 @@ type MockUserServiceInterface struct {
 	mock.Mock
 }
 
-func (m *MockUserServiceInterface) RegisterUser(name, email string) (*models.User, error) {
+func (m *MockUserServiceInterface) RegisterUser(ctx context.Context, name, email string) (*models.User, error) {
 	args := m.Called(name, email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -22,7 +26,7 @@ func (m *MockUserServiceInterface) RegisterUser(name, email string) (*models.Use
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserServiceInterface) GetUser(id int) (*models.User, error) {
+func (m *MockUserServiceInterface) GetUser(ctx context.Context, id int) (*models.User, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -30,7 +34,7 @@ func (m *MockUserServiceInterface) GetUser(id int) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserServiceInterface) GetAllUsers() ([]*models.User, error) {
+func (m *MockUserServiceInterface) GetAllUsers(ctx context.Context) ([]*models.User, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -38,7 +42,15 @@ func (m *MockUserServiceInterface) GetAllUsers() ([]*models.User, error) {
 	return args.Get(0).([]*models.User), args.Error(1)
 }
 
-func (m *MockUserServiceInterface) GetUserBorrowings(userID int) ([]*models.Borrowing, error) {
+func (m *MockUserServiceInterface) ListUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, int, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserServiceInterface) GetUserBorrowings(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -46,7 +58,7 @@ func (m *MockUserServiceInterface) GetUserBorrowings(userID int) ([]*models.Borr
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockUserServiceInterface) GetActiveUserBorrowings(userID int) ([]*models.Borrowing, error) {
+func (m *MockUserServiceInterface) GetActiveUserBorrowings(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -54,16 +66,34 @@ func (m *MockUserServiceInterface) GetActiveUserBorrowings(userID int) ([]*model
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockUserServiceInterface) CanUserBorrow(userID int) (bool, error) {
+func (m *MockUserServiceInterface) CanUserBorrow(ctx context.Context, userID int) (bool, error) {
 	args := m.Called(userID)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockUserServiceInterface) UpdateUser(user *models.User) error {
+func (m *MockUserServiceInterface) CheckEligibility(ctx context.Context, userID int) (*models.BorrowEligibility, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BorrowEligibility), args.Error(1)
+}
+
+func (m *MockUserServiceInterface) GetFineBalance(ctx context.Context, userID int) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserServiceInterface) UpdateUser(ctx context.Context, user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
+func (m *MockUserServiceInterface) DeleteUser(ctx context.Context, userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
 func TestUserWebHandler_SearchFilterUsers_Logic(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -162,9 +192,88 @@ func TestUserWebHandler_SearchFilterUsers_Logic(t *testing.T) {
 func TestUserWebHandler_ServiceIntegration(t *testing.T) {
 	// Test that the handler correctly calls the service methods
 	mockUserService := new(MockUserServiceInterface)
-	handler := NewUserWebHandler(mockUserService)
+	handler := NewUserWebHandler(mockUserService, new(MockGameServiceInterface))
 
 	// Test that the handler is properly initialized
 	assert.NotNil(t, handler)
 	assert.NotNil(t, handler.userService)
-}
\ No newline at end of file
+}
+
+func TestUserWebHandler_ShowEditUserForm(t *testing.T) {
+	mockUserService := new(MockUserServiceInterface)
+	handler := NewUserWebHandler(mockUserService, new(MockGameServiceInterface))
+	router := newWebTestRouter(t)
+	handler.RegisterWebRoutes(router.Group("/"))
+
+	user := &models.User{ID: 1, Name: "<img src=x onerror=alert(1)>", Email: "alice@example.com", IsActive: true}
+	mockUserService.On("GetUser", 1).Return(user, nil)
+	mockUserService.On("GetActiveUserBorrowings", 1).Return([]*models.Borrowing{{ID: 3, UserID: 1, GameID: 2}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1/edit", nil)
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, `hx-put="/users/1"`)
+	assert.Contains(t, body, `value="alice@example.com"`)
+	assert.NotContains(t, body, "<img src=x")
+	assert.NotContains(t, body, "<!DOCTYPE html>")
+}
+
+func TestUserWebHandler_UpdateUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		updateErr      error
+		expectedStatus int
+		expectedBody   string
+		expectedEvents string
+	}{
+		{
+			name:           "updated",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "Alice Martin has been updated.",
+			expectedEvents: "user-updated, refresh-users",
+		},
+		{
+			name:           "rejected",
+			updateErr:      assert.AnError,
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Failed to update user",
+		},
+		{
+			name:           "duplicate email",
+			updateErr:      models.Conflict("user_email_taken", "alice@example.com"),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserService := new(MockUserServiceInterface)
+			handler := NewUserWebHandler(mockUserService, new(MockGameServiceInterface))
+			router := newWebTestRouter(t)
+			handler.RegisterWebRoutes(router.Group("/"))
+
+			mockUserService.On("GetUser", 1).Return(&models.User{ID: 1, Name: "Alice", Email: "old@example.com", IsActive: true}, nil)
+			mockUserService.On("GetActiveUserBorrowings", 1).Return([]*models.Borrowing{}, nil).Maybe()
+			mockUserService.On("UpdateUser", mock.MatchedBy(func(user *models.User) bool {
+				return user.Name == "Alice Martin" && user.Email == "alice@example.com" && !user.IsActive
+			})).Return(tt.updateErr)
+
+			form := url.Values{"name": {" Alice Martin "}, "email": {"alice@example.com"}, "is_active": {"false"}}
+			req := httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set("HX-Request", "true")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			assert.Equal(t, tt.expectedEvents, w.Header().Get("HX-Trigger"))
+			mockUserService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+)
+
+// webLayout is the template wrapping the "content" of every page
+const webLayout = "base.html"
+
+// partialKey marks the data of a page rendered without its layout
+const partialKey = "Partial"
+
+// webTemplateFuncs are the functions available to the web templates
+var webTemplateFuncs = template.FuncMap{
+	"substr": func(s string, start, length int) string {
+		runes := []rune(s)
+		if start < 0 || start >= len(runes) {
+			return ""
+		}
+		end := start + length
+		if end > len(runes) {
+			end = len(runes)
+		}
+		return string(runes[start:end])
+	},
+	"title":     strings.Title,
+	"upper":     strings.ToUpper,
+	"lower":     strings.ToLower,
+	"contains":  strings.Contains,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"join":      strings.Join,
+	"split":     strings.Split,
+	"replace":   strings.ReplaceAll,
+	"trim":      strings.TrimSpace,
+	"tagSlug":   models.TagSlug,
+	// highlighted escapes a search match and marks its highlighted ranges
+	"highlighted": func(text string, highlights []models.TextRange) template.HTML {
+		return template.HTML(models.HighlightHTML(text, highlights))
+	},
+}
+
+// WebTemplates renders the pages and HTMX partials of the web interface. It
+// is gin's HTML renderer: a page, such as "games/list.html", is rendered
+// inside the layout, or alone when HTMX asks for it; a partial, such as
+// "games/partials/games-grid.html", renders the template it defines.
+type WebTemplates struct {
+	layout   *template.Template
+	pages    map[string]*template.Template
+	partials map[string]string // file name to the name of the template it defines
+}
+
+// LoadWebTemplates parses the web templates of fsys: the layout base.html,
+// the partials under partials directories, shared by every page, and the
+// pages, any other .html file.
+func LoadWebTemplates(fsys fs.FS) (*WebTemplates, error) {
+	var pages, partials []string
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch {
+		case entry.IsDir() || path.Ext(name) != ".html" || name == webLayout:
+		case path.Base(path.Dir(name)) == "partials":
+			partials = append(partials, name)
+		default:
+			pages = append(pages, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list web templates: %w", err)
+	}
+
+	templates := &WebTemplates{
+		layout:   template.New(webLayout).Funcs(webTemplateFuncs),
+		pages:    make(map[string]*template.Template),
+		partials: make(map[string]string),
+	}
+	if err := parseWebTemplate(templates.layout, fsys, webLayout); err != nil {
+		return nil, err
+	}
+	for _, name := range partials {
+		defined, err := definedTemplate(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := parseWebTemplate(templates.layout, fsys, name); err != nil {
+			return nil, err
+		}
+		templates.partials[name] = defined
+	}
+
+	for _, name := range pages {
+		page, err := templates.layout.Clone()
+		if err != nil {
+			return nil, fmt.Errorf("failed to clone web layout: %w", err)
+		}
+		if err := parseWebTemplate(page, fsys, name); err != nil {
+			return nil, err
+		}
+		if page.Lookup("content") == nil {
+			return nil, fmt.Errorf("web page %s does not define content", name)
+		}
+		templates.pages[name] = page
+	}
+
+	return templates, nil
+}
+
+// parseWebTemplate adds the templates of the file name to set
+func parseWebTemplate(set *template.Template, fsys fs.FS, name string) error {
+	text, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to read web template %s: %w", name, err)
+	}
+	tmpl := set
+	if name != set.Name() {
+		tmpl = set.New(name)
+	}
+	if _, err := tmpl.Parse(string(text)); err != nil {
+		return fmt.Errorf("failed to parse web template %s: %w", name, err)
+	}
+	return nil
+}
+
+// definedTemplate returns the name of the only template a partial file defines
+func definedTemplate(fsys fs.FS, name string) (string, error) {
+	set := template.New(name).Funcs(webTemplateFuncs)
+	if err := parseWebTemplate(set, fsys, name); err != nil {
+		return "", err
+	}
+
+	var defined []string
+	for _, tmpl := range set.Templates() {
+		if tmpl.Name() != name {
+			defined = append(defined, tmpl.Name())
+		}
+	}
+	if len(defined) != 1 {
+		return "", fmt.Errorf("web partial %s must define exactly one template, found %d", name, len(defined))
+	}
+
+	return defined[0], nil
+}
+
+// Instance implements render.HTMLRender
+func (t *WebTemplates) Instance(name string, data any) render.Render {
+	if page, ok := t.pages[name]; ok {
+		entry := webLayout
+		if values, ok := data.(gin.H); ok && values[partialKey] == true {
+			entry = "content"
+		}
+		return render.HTML{Template: page, Name: entry, Data: data}
+	}
+
+	if defined, ok := t.partials[name]; ok {
+		name = defined
+	}
+	return render.HTML{Template: t.layout, Name: name, Data: data}
+}
+
+// renderWeb renders a page or partial of the web interface. Pages requested
+// by HTMX, such as the modals and the refreshed lists, are rendered without
+// the layout. The data tells the templates what the signed-in user may do;
+// when authentication is disabled, everyone may do everything.
+func renderWeb(c *gin.Context, status int, name string, data gin.H) {
+	if data == nil {
+		data = gin.H{}
+	}
+	if c.GetHeader("HX-Request") == "true" && c.GetHeader("HX-Boosted") != "true" {
+		data[partialKey] = true
+	}
+
+	user := CurrentUser(c)
+	data["CurrentUser"] = user
+	data["CanManage"] = user == nil || user.HasRole(models.RoleLibrarian)
+	data["CanDelete"] = user == nil || user.HasRole(models.RoleAdmin)
+
+	c.HTML(status, name, data)
+}
+
+// renderWebError renders an error message: in place of the element HTMX
+// targeted, or as a page of its own
+func renderWebError(c *gin.Context, status int, message string) {
+	data := gin.H{
+		"Title":        "Error",
+		"ErrorMessage": message,
+	}
+	if c.GetHeader("HX-Request") == "true" {
+		renderWeb(c, status, "partials/error.html", data)
+		return
+	}
+	renderWeb(c, status, "error.html", data)
+}
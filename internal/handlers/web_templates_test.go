@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// newWebTestRouter returns a router rendering the templates of web/templates
+func newWebTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	templates, err := LoadWebTemplates(os.DirFS("../../web/templates"))
+	if err != nil {
+		t.Fatalf("LoadWebTemplates() error = %v", err)
+	}
+
+	router := gin.New()
+	router.HTMLRender = templates
+	return router
+}
+
+func TestLoadWebTemplates(t *testing.T) {
+	router := newWebTestRouter(t)
+	router.GET("/page", func(c *gin.Context) {
+		renderWeb(c, http.StatusOK, "success.html", gin.H{"Title": "Done", "Message": "<b>saved</b>"})
+	})
+	router.GET("/partial", func(c *gin.Context) {
+		renderWeb(c, http.StatusOK, "alerts/partials/alert-count.html", gin.H{"UnreadCount": 3})
+	})
+
+	t.Run("page in layout", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/page", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<!DOCTYPE html>")
+		assert.Contains(t, w.Body.String(), "&lt;b&gt;saved&lt;/b&gt;")
+	})
+
+	t.Run("page requested by HTMX", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/page", nil)
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "<!DOCTYPE html>")
+		assert.Contains(t, w.Body.String(), "&lt;b&gt;saved&lt;/b&gt;")
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/partial", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "<!DOCTYPE html>")
+		assert.Contains(t, w.Body.String(), `id="alert-count"`)
+	})
+}
+
+func TestLoadWebTemplatesErrors(t *testing.T) {
+	layout := &fstest.MapFile{Data: []byte(`<html>{{template "content" .}}</html>`)}
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "missing layout",
+			fsys: fstest.MapFS{"page.html": {Data: []byte(`{{define "content"}}page{{end}}`)}},
+		},
+		{
+			name: "page without content",
+			fsys: fstest.MapFS{"base.html": layout, "page.html": {Data: []byte(`page`)}},
+		},
+		{
+			name: "partial defining two templates",
+			fsys: fstest.MapFS{"base.html": layout, "partials/two.html": {Data: []byte(`{{define "one"}}1{{end}}{{define "two"}}2{{end}}`)}},
+		},
+		{
+			name: "syntax error",
+			fsys: fstest.MapFS{"base.html": layout, "page.html": {Data: []byte(`{{define "content"}}{{.Title}{{end}}`)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadWebTemplates(tt.fsys)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// webDueSoonDays is how many days before their due date loans are shown as
+// due soon
+const webDueSoonDays = 2
+
+// webDateFormat is the format of the date inputs of the web forms
+const webDateFormat = "2006-01-02"
+
+// BorrowingView is a borrowing as the web pages show it, with the names of
+// its user and game
+type BorrowingView struct {
+	*models.Borrowing
+	UserName  string
+	UserEmail string
+	GameName  string
+}
+
+// DaysUntilDue returns the number of whole days left before the game is due
+// back, 0 once returned or overdue
+func (b BorrowingView) DaysUntilDue() int {
+	if b.ReturnedAt != nil || b.IsCurrentlyOverdue() {
+		return 0
+	}
+	return int(time.Until(b.DueDate).Hours() / 24)
+}
+
+// DueSoon reports whether the game is still out and due back within
+// webDueSoonDays
+func (b BorrowingView) DueSoon() bool {
+	return b.ReturnedAt == nil && !b.IsCurrentlyOverdue() && time.Until(b.DueDate) <= webDueSoonDays*24*time.Hour
+}
+
+// Duration returns the number of days the game was out, until today for
+// games not returned yet
+func (b BorrowingView) Duration() int {
+	end := time.Now()
+	if b.ReturnedAt != nil {
+		end = *b.ReturnedAt
+	}
+	return int(end.Sub(b.BorrowedAt).Hours() / 24)
+}
+
+// AlertView is an alert as the web pages show it, with the names of its
+// user and game
+type AlertView struct {
+	*models.Alert
+	UserName  string
+	UserEmail string
+	GameName  string
+	// BorrowingID is the loan of the game to the user still out, 0 if none
+	BorrowingID int
+}
+
+// AlertGroup gathers the alerts of one user
+type AlertGroup struct {
+	UserID    int
+	UserName  string
+	UserEmail string
+	Alerts    []AlertView
+}
+
+// webNames looks up the users and games shown on a page, each one once.
+// Records that cannot be found are shown by their ID rather than failing
+// the page.
+type webNames struct {
+	userService UserServiceInterface
+	gameService GameServiceInterface
+	users       map[int]*models.User
+	games       map[int]*models.Game
+}
+
+func newWebNames(userService UserServiceInterface, gameService GameServiceInterface) *webNames {
+	return &webNames{
+		userService: userService,
+		gameService: gameService,
+		users:       make(map[int]*models.User),
+		games:       make(map[int]*models.Game),
+	}
+}
+
+func (n *webNames) user(ctx context.Context, id int) *models.User {
+	if user, ok := n.users[id]; ok {
+		return user
+	}
+	user, err := n.userService.GetUser(ctx, id)
+	if err != nil {
+		user = &models.User{ID: id, Name: fmt.Sprintf("User #%d", id)}
+	}
+	n.users[id] = user
+	return user
+}
+
+func (n *webNames) game(ctx context.Context, id int) *models.Game {
+	if game, ok := n.games[id]; ok {
+		return game
+	}
+	game, err := n.gameService.GetGame(ctx, id)
+	if err != nil {
+		game = &models.Game{ID: id, Name: fmt.Sprintf("Game #%d", id)}
+	}
+	n.games[id] = game
+	return game
+}
+
+// borrowing returns the view of a borrowing
+func (n *webNames) borrowing(ctx context.Context, borrowing *models.Borrowing) BorrowingView {
+	user := n.user(ctx, borrowing.UserID)
+	return BorrowingView{
+		Borrowing: borrowing,
+		UserName:  user.Name,
+		UserEmail: user.Email,
+		GameName:  n.game(ctx, borrowing.GameID).Name,
+	}
+}
+
+// borrowings returns the views of borrowings
+func (n *webNames) borrowings(ctx context.Context, borrowings []*models.Borrowing) []BorrowingView {
+	views := make([]BorrowingView, 0, len(borrowings))
+	for _, borrowing := range borrowings {
+		views = append(views, n.borrowing(ctx, borrowing))
+	}
+	return views
+}
+
+// alertGroups groups the views of alerts by user, ordered by user name.
+// Each alert links to the loan it is about, found among the active
+// borrowings of its user.
+func (n *webNames) alertGroups(ctx context.Context, alerts []*models.Alert, borrowingService BorrowingServiceInterface) []AlertGroup {
+	groups := make(map[int]*AlertGroup)
+	var order []*AlertGroup
+	for _, alert := range alerts {
+		group, ok := groups[alert.UserID]
+		if !ok {
+			user := n.user(ctx, alert.UserID)
+			group = &AlertGroup{UserID: user.ID, UserName: user.Name, UserEmail: user.Email}
+			groups[alert.UserID] = group
+			order = append(order, group)
+		}
+		group.Alerts = append(group.Alerts, AlertView{
+			Alert:     alert,
+			UserName:  group.UserName,
+			UserEmail: group.UserEmail,
+			GameName:  n.game(ctx, alert.GameID).Name,
+		})
+	}
+
+	result := make([]AlertGroup, 0, len(order))
+	for _, group := range order {
+		if borrowings, err := borrowingService.GetActiveBorrowingsByUser(ctx, group.UserID); err == nil {
+			for i := range group.Alerts {
+				for _, borrowing := range borrowings {
+					if borrowing.GameID == group.Alerts[i].GameID {
+						group.Alerts[i].BorrowingID = borrowing.ID
+						break
+					}
+				}
+			}
+		}
+		result = append(result, *group)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return strings.ToLower(result[i].UserName) < strings.ToLower(result[j].UserName)
+	})
+
+	return result
+}
+
+// webPagination describes the pages of a list shown on a web page. Its
+// buttons post the list controls again, asking for another page.
+type webPagination struct {
+	Page       int
+	TotalPages int
+	Total      int
+	URL        string // where the list controls are posted
+	Target     string // element replaced by the list
+	Include    string // selector of the list controls
+}
+
+func newWebPagination(options models.ListOptions, total int, url, target, include string) webPagination {
+	return webPagination{
+		Page:       options.Page,
+		TotalPages: options.TotalPages(total),
+		Total:      total,
+		URL:        url,
+		Target:     target,
+		Include:    include,
+	}
+}
+
+// HasPrev reports whether there is a page before this one
+func (p webPagination) HasPrev() bool {
+	return p.Page > 1
+}
+
+// HasNext reports whether there is a page after this one
+func (p webPagination) HasNext() bool {
+	return p.Page < p.TotalPages
+}
+
+// PrevPage returns the number of the page before this one
+func (p webPagination) PrevPage() int {
+	return p.Page - 1
+}
+
+// NextPage returns the number of the page after this one
+func (p webPagination) NextPage() int {
+	return p.Page + 1
+}
+
+// webValue reads a list control of a web page: from the query string when
+// the page is loaded, from the form posted by its HTMX controls afterwards
+func webValue(c *gin.Context, name string) string {
+	if c.Request.Method == http.MethodGet {
+		return strings.TrimSpace(c.Query(name))
+	}
+	return strings.TrimSpace(c.PostForm(name))
+}
+
+// webListOptions reads the page and order of a list shown on a web page. An
+// invalid page shows the first one.
+func webListOptions(c *gin.Context) models.ListOptions {
+	page, err := strconv.Atoi(webValue(c, "page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	return models.ListOptions{
+		Page:    page,
+		PerPage: models.DefaultPerPage,
+		Sort:    webValue(c, "sort"),
+		Order:   strings.ToLower(webValue(c, "order")),
+	}
+}
+
+// webID reads the ID path parameter of a web route, rendering an error when
+// it is invalid
+func webID(c *gin.Context, what string) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		renderWebError(c, http.StatusBadRequest, "Invalid "+what+" ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// webListError renders the failure to load a list; filters the service
+// rejects are the user's fault
+func webListError(c *gin.Context, err error, message string) {
+	renderWebError(c, ErrorStatus(err), message+": "+err.Error())
+}
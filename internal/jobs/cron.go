@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // Portable builds may run on systems without a zoneinfo database
+
+	"board-game-library/internal/models"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronParser accepts standard 5-field cron expressions and descriptors such as @daily
+var cronParser = cron.NewParser(
+	cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// ParseCronExpression parses a cron expression such as "0 8 * * *" or "@daily"
+func ParseCronExpression(expr string) (cron.Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, models.Invalid("schedule", "schedule_required")
+	}
+
+	schedule, err := cronParser.Parse(expr)
+	if err != nil {
+		return nil, models.Invalid("schedule", "invalid_schedule", expr, err).Wrap(err)
+	}
+
+	return schedule, nil
+}
+
+// ValidateSchedule checks that a schedule is either a positive duration ("6h")
+// or a valid cron expression ("0 8 * * *")
+func ValidateSchedule(spec string) error {
+	_, _, err := parseScheduleSpec(spec)
+	return err
+}
+
+// LoadLocation resolves a timezone name, defaulting to the local timezone
+func LoadLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+
+	return location, nil
+}
+
+// parseScheduleSpec parses a schedule given either as a duration or a cron expression
+func parseScheduleSpec(spec string) (time.Duration, cron.Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return 0, nil, models.Invalid("schedule", "schedule_required")
+	}
+
+	if interval, err := time.ParseDuration(spec); err == nil {
+		if interval <= 0 {
+			return 0, nil, models.Invalid("schedule", "invalid_schedule_interval", spec)
+		}
+		return interval, nil, nil
+	}
+
+	schedule, err := ParseCronExpression(spec)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return 0, schedule, nil
+}
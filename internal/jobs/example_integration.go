@@ -28,7 +28,8 @@ func ExampleIntegration() {
 	}
 
 	// 3. Create job manager
-	// manager := NewManager(alertService, config)
+	// manager, err := NewManager(alertService, config)
+	_ = config
 
 	// 4. Start the job manager with application context
 	// ctx, cancel := context.WithCancel(context.Background())
@@ -41,11 +42,11 @@ func ExampleIntegration() {
 
 	// 5. The job manager will now run in the background
 	// You can also manually trigger jobs if needed:
-	// manager.RunJobNow("overdue-alerts")
-	// manager.GenerateAllAlerts()
+	// manager.RunJobNow(ctx, "overdue-alerts")
+	// manager.GenerateAllAlerts(ctx)
 
 	// 6. Get job status and health information
-	// health := manager.GetHealthStatus()
+	// health := manager.GetHealthStatus(ctx)
 	// log.Printf("Job manager health: %+v", health)
 
 	// 7. When shutting down your application, stop the job manager
@@ -53,7 +54,7 @@ func ExampleIntegration() {
 }
 
 // IntegrateWithAlertService shows how to integrate with the actual alert service
-func IntegrateWithAlertService(alertService *services.AlertService) *Manager {
+func IntegrateWithAlertService(alertService *services.AlertService) (*Manager, error) {
 	// Create a wrapper that implements our AlertService interface
 	wrapper := &AlertServiceWrapper{alertService: alertService}
 	
@@ -73,16 +74,16 @@ type AlertServiceWrapper struct {
 	alertService *services.AlertService
 }
 
-func (w *AlertServiceWrapper) GenerateOverdueAlerts() error {
-	return w.alertService.GenerateOverdueAlerts()
+func (w *AlertServiceWrapper) GenerateOverdueAlerts(ctx context.Context) error {
+	return w.alertService.GenerateOverdueAlerts(ctx)
 }
 
-func (w *AlertServiceWrapper) GenerateReminderAlerts() error {
-	return w.alertService.GenerateReminderAlerts()
+func (w *AlertServiceWrapper) GenerateReminderAlerts(ctx context.Context) error {
+	return w.alertService.GenerateReminderAlerts(ctx)
 }
 
-func (w *AlertServiceWrapper) CleanupResolvedAlerts() error {
-	return w.alertService.CleanupResolvedAlerts()
+func (w *AlertServiceWrapper) CleanupResolvedAlerts(ctx context.Context) error {
+	return w.alertService.CleanupResolvedAlerts(ctx)
 }
 
 // StartJobManagerWithGracefulShutdown demonstrates how to start the job manager
@@ -116,7 +117,7 @@ func StartJobManagerWithGracefulShutdown(manager *Manager) {
 // CustomJobExample shows how to add custom jobs to the manager
 func CustomJobExample(manager *Manager) {
 	// Add a custom job that runs every hour
-	manager.AddCustomJob("custom-maintenance", "Custom maintenance task", 1*time.Hour, func() error {
+	manager.AddCustomJob("custom-maintenance", "Custom maintenance task", 1*time.Hour, func(ctx context.Context) error {
 		log.Println("Running custom maintenance task")
 		
 		// Your custom logic here
@@ -126,7 +127,7 @@ func CustomJobExample(manager *Manager) {
 	})
 	
 	// Add a job that runs every 30 minutes
-	manager.AddCustomJob("health-check", "System health check", 30*time.Minute, func() error {
+	manager.AddCustomJob("health-check", "System health check", 30*time.Minute, func(ctx context.Context) error {
 		log.Println("Running system health check")
 		
 		// Your health check logic here
@@ -137,14 +138,14 @@ func CustomJobExample(manager *Manager) {
 }
 
 // MonitoringExample shows how to monitor job execution
-func MonitoringExample(manager *Manager) {
+func MonitoringExample(ctx context.Context, manager *Manager) {
 	// Get current health status
-	health := manager.GetHealthStatus()
+	health := manager.GetHealthStatus(ctx)
 	log.Printf("Job Manager Health: Running=%v, Total Jobs=%d, Enabled=%d, Disabled=%d", 
 		health.IsRunning, health.TotalJobs, health.EnabledJobs, health.DisabledJobs)
 	
 	// Get job statistics
-	stats := manager.GetJobStatistics()
+	stats := manager.GetJobStatistics(ctx)
 	log.Printf("Job Statistics: Total Executions=%d, Successful=%d, Failed=%d", 
 		stats.TotalExecutions, stats.SuccessfulExecutions, stats.FailedExecutions)
 	
@@ -154,7 +155,7 @@ func MonitoringExample(manager *Manager) {
 	}
 	
 	// Get recent job executions
-	executions := manager.GetJobExecutions(10)
+	executions := manager.GetJobExecutions(ctx, 10)
 	log.Printf("Recent executions (%d):", len(executions))
 	for _, exec := range executions {
 		log.Printf("  %s: %s (%s) - %s", exec.JobName, exec.Status, exec.Duration, exec.StartTime.Format(time.RFC3339))
@@ -1,8 +1,25 @@
 package jobs
 
+import (
+	"board-game-library/internal/models"
+	"context"
+	"time"
+)
+
 // AlertService defines the interface for alert service operations needed by the job system
 type AlertService interface {
-	GenerateOverdueAlerts() error
-	GenerateReminderAlerts() error
-	CleanupResolvedAlerts() error
-}
\ No newline at end of file
+	GenerateOverdueAlerts(ctx context.Context) error
+	GenerateReminderAlerts(ctx context.Context) error
+	CleanupResolvedAlerts(ctx context.Context) error
+}
+
+// ExecutionStore defines the interface for persisting job execution history
+type ExecutionStore interface {
+	Create(ctx context.Context, run *models.JobRun) error
+	Update(ctx context.Context, run *models.JobRun) error
+	List(ctx context.Context, jobName string, limit, offset int) ([]*models.JobRun, error)
+	Count(ctx context.Context, jobName string) (int, error)
+	CountByStatus(ctx context.Context) ([]models.JobRunCount, error)
+	GetLastRuns(ctx context.Context) (map[string]time.Time, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
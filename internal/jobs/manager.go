@@ -37,6 +37,25 @@ type Config struct {
 	// CleanupSchedule sets the schedule for alert cleanup (default: 6h)
 	CleanupSchedule time.Duration
 	
+	// OverdueAlertCron runs overdue alert generation at wall-clock times
+	// (e.g. "0 8 * * *"); takes precedence over OverdueAlertSchedule when set
+	OverdueAlertCron string
+	
+	// ReminderAlertCron runs reminder alert generation at wall-clock times;
+	// takes precedence over ReminderAlertSchedule when set
+	ReminderAlertCron string
+	
+	// CleanupCron runs alert cleanup at wall-clock times; takes precedence
+	// over CleanupSchedule when set
+	CleanupCron string
+	
+	// ExecutionRetention sets how long the execution history is kept before
+	// the daily prune-job-runs job removes it (default: 90 days, 0 keeps it forever)
+	ExecutionRetention time.Duration
+	
+	// Timezone used to evaluate cron expressions (default: local timezone)
+	Timezone string
+	
 	// Logger for job operations
 	Logger *log.Logger
 }
@@ -50,22 +69,57 @@ func DefaultConfig() *Config {
 		OverdueAlertSchedule:  24 * time.Hour,
 		ReminderAlertSchedule: 24 * time.Hour,
 		CleanupSchedule:       6 * time.Hour,
+		ExecutionRetention:    90 * 24 * time.Hour,
 		Logger:                log.New(log.Writer(), "[JOB-MANAGER] ", log.LstdFlags),
 	}
 }
 
-// NewManager creates a new job manager
-func NewManager(alertService AlertService, config *Config) *Manager {
+// Validate checks the configured cron expressions and timezone
+func (c *Config) Validate() error {
+	if _, err := LoadLocation(c.Timezone); err != nil {
+		return err
+	}
+	
+	crons := map[string]string{
+		"overdue alert":  c.OverdueAlertCron,
+		"reminder alert": c.ReminderAlertCron,
+		"cleanup":        c.CleanupCron,
+	}
+	for name, expr := range crons {
+		if expr == "" {
+			continue
+		}
+		if _, err := ParseCronExpression(expr); err != nil {
+			return fmt.Errorf("%s schedule: %w", name, err)
+		}
+	}
+	
+	return nil
+}
+
+// NewManager creates a new job manager. It fails when the configuration is
+// not valid, rather than running the jobs at other times than configured.
+func NewManager(alertService AlertService, config *Config) (*Manager, error) {
 	if config == nil {
 		config = DefaultConfig()
 	}
 	
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	
 	if config.Logger == nil {
 		config.Logger = log.New(log.Writer(), "[JOB-MANAGER] ", log.LstdFlags)
 	}
 	
 	scheduler := NewScheduler(alertService, config.Logger)
 	
+	location, err := LoadLocation(config.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	scheduler.SetLocation(location)
+	
 	manager := &Manager{
 		scheduler:    scheduler,
 		alertService: alertService,
@@ -74,13 +128,15 @@ func NewManager(alertService AlertService, config *Config) *Manager {
 	}
 	
 	// Configure jobs based on config
-	manager.configureJobs(config)
+	if err := manager.configureJobs(config); err != nil {
+		return nil, err
+	}
 	
-	return manager
+	return manager, nil
 }
 
 // configureJobs configures the scheduler based on the provided configuration
-func (m *Manager) configureJobs(config *Config) {
+func (m *Manager) configureJobs(config *Config) error {
 	// Remove default jobs first
 	m.scheduler.RemoveJob("overdue-alerts")
 	m.scheduler.RemoveJob("reminder-alerts")
@@ -88,9 +144,9 @@ func (m *Manager) configureJobs(config *Config) {
 	
 	// Add jobs based on configuration
 	if config.EnableOverdueAlerts {
-		m.scheduler.AddJob("overdue-alerts", "Generate alerts for overdue items", config.OverdueAlertSchedule, func() error {
+		err := m.addConfiguredJob("overdue-alerts", "Generate alerts for overdue items", config.OverdueAlertCron, config.OverdueAlertSchedule, func(ctx context.Context) error {
 			m.logger.Println("Starting overdue alert generation")
-			err := m.alertService.GenerateOverdueAlerts()
+			err := m.alertService.GenerateOverdueAlerts(ctx)
 			if err != nil {
 				m.logger.Printf("Failed to generate overdue alerts: %v", err)
 				return fmt.Errorf("overdue alert generation failed: %w", err)
@@ -98,12 +154,15 @@ func (m *Manager) configureJobs(config *Config) {
 			m.logger.Println("Overdue alert generation completed successfully")
 			return nil
 		})
+		if err != nil {
+			return err
+		}
 	}
 	
 	if config.EnableReminderAlerts {
-		m.scheduler.AddJob("reminder-alerts", "Generate reminder alerts for items due soon", config.ReminderAlertSchedule, func() error {
+		err := m.addConfiguredJob("reminder-alerts", "Generate reminder alerts for items due soon", config.ReminderAlertCron, config.ReminderAlertSchedule, func(ctx context.Context) error {
 			m.logger.Println("Starting reminder alert generation")
-			err := m.alertService.GenerateReminderAlerts()
+			err := m.alertService.GenerateReminderAlerts(ctx)
 			if err != nil {
 				m.logger.Printf("Failed to generate reminder alerts: %v", err)
 				return fmt.Errorf("reminder alert generation failed: %w", err)
@@ -111,12 +170,15 @@ func (m *Manager) configureJobs(config *Config) {
 			m.logger.Println("Reminder alert generation completed successfully")
 			return nil
 		})
+		if err != nil {
+			return err
+		}
 	}
 	
 	if config.EnableAlertCleanup {
-		m.scheduler.AddJob("cleanup-alerts", "Clean up alerts for returned items", config.CleanupSchedule, func() error {
+		err := m.addConfiguredJob("cleanup-alerts", "Clean up alerts for returned items", config.CleanupCron, config.CleanupSchedule, func(ctx context.Context) error {
 			m.logger.Println("Starting alert cleanup")
-			err := m.alertService.CleanupResolvedAlerts()
+			err := m.alertService.CleanupResolvedAlerts(ctx)
 			if err != nil {
 				m.logger.Printf("Failed to cleanup resolved alerts: %v", err)
 				return fmt.Errorf("alert cleanup failed: %w", err)
@@ -124,7 +186,38 @@ func (m *Manager) configureJobs(config *Config) {
 			m.logger.Println("Alert cleanup completed successfully")
 			return nil
 		})
+		if err != nil {
+			return err
+		}
 	}
+	
+	if config.ExecutionRetention > 0 {
+		retention := config.ExecutionRetention
+		m.scheduler.AddJob("prune-job-runs", "Remove old job executions from the history", 24*time.Hour, func(ctx context.Context) error {
+			removed, err := m.scheduler.PruneExecutions(ctx, time.Now().Add(-retention))
+			if err != nil {
+				return err
+			}
+			m.logger.Printf("Pruned %d job executions older than %v", removed, retention)
+			return nil
+		})
+	}
+	
+	return nil
+}
+
+// addConfiguredJob registers a job with its cron expression when one is
+// configured, and with the interval schedule otherwise
+func (m *Manager) addConfiguredJob(name, description, cronExpr string, interval time.Duration, handler func(ctx context.Context) error) error {
+	if cronExpr != "" {
+		if err := m.scheduler.AddCronJob(name, description, cronExpr, handler); err != nil {
+			return fmt.Errorf("job '%s': %w", name, err)
+		}
+		return nil
+	}
+	
+	m.scheduler.AddJob(name, description, interval, handler)
+	return nil
 }
 
 // Start starts the job manager and scheduler
@@ -175,47 +268,73 @@ func (m *Manager) IsStarted() bool {
 }
 
 // RunJobNow executes a job immediately
-func (m *Manager) RunJobNow(jobName string) error {
+func (m *Manager) RunJobNow(ctx context.Context, jobName string) error {
 	return m.scheduler.RunJobNow(jobName)
 }
 
 // GetJobs returns all registered jobs
-func (m *Manager) GetJobs() map[string]*Job {
+func (m *Manager) GetJobs(ctx context.Context) map[string]*Job {
 	return m.scheduler.GetJobs()
 }
 
 // GetJobExecutions returns recent job executions
-func (m *Manager) GetJobExecutions(limit int) []JobExecution {
-	return m.scheduler.GetJobExecutions(limit)
+func (m *Manager) GetJobExecutions(ctx context.Context, limit int) []JobExecution {
+	return m.scheduler.GetJobExecutions(ctx, limit)
+}
+
+// GetJobExecutionsPage returns a page of job executions and the total number
+// of matching executions; an empty job name matches every job
+func (m *Manager) GetJobExecutionsPage(ctx context.Context, jobName string, limit, offset int) ([]JobExecution, int, error) {
+	return m.scheduler.GetJobExecutionsPage(ctx, jobName, limit, offset)
+}
+
+// SetExecutionStore persists job execution history and restores each job's last run
+func (m *Manager) SetExecutionStore(store ExecutionStore) error {
+	return m.scheduler.SetExecutionStore(store)
 }
 
 // GetJobStatus returns the status of a specific job
-func (m *Manager) GetJobStatus(jobName string) (*Job, error) {
+func (m *Manager) GetJobStatus(ctx context.Context, jobName string) (*Job, error) {
 	return m.scheduler.GetJobStatus(jobName)
 }
 
 // EnableJob enables a job
-func (m *Manager) EnableJob(jobName string) error {
+func (m *Manager) EnableJob(ctx context.Context, jobName string) error {
 	return m.scheduler.EnableJob(jobName)
 }
 
 // DisableJob disables a job
-func (m *Manager) DisableJob(jobName string) error {
+func (m *Manager) DisableJob(ctx context.Context, jobName string) error {
 	return m.scheduler.DisableJob(jobName)
 }
 
 // AddCustomJob adds a custom job to the scheduler
-func (m *Manager) AddCustomJob(name, description string, schedule time.Duration, handler func() error) {
+func (m *Manager) AddCustomJob(name, description string, schedule time.Duration, handler func(ctx context.Context) error) {
 	m.scheduler.AddJob(name, description, schedule, handler)
 }
 
+// AddCustomCronJob adds a custom job scheduled by a cron expression
+func (m *Manager) AddCustomCronJob(name, description, cronExpr string, handler func(ctx context.Context) error) error {
+	return m.scheduler.AddCronJob(name, description, cronExpr, handler)
+}
+
+// RescheduleJob changes a job's schedule to a duration ("6h") or a cron expression ("0 8 * * *")
+func (m *Manager) RescheduleJob(ctx context.Context, jobName, spec string) error {
+	return m.scheduler.RescheduleJob(jobName, spec)
+}
+
+// Location returns the timezone used to evaluate cron schedules
+func (m *Manager) Location() *time.Location {
+	return m.scheduler.Location()
+}
+
 // RemoveJob removes a job from the scheduler
 func (m *Manager) RemoveJob(jobName string) {
 	m.scheduler.RemoveJob(jobName)
 }
 
 // GetHealthStatus returns the health status of the job manager
-func (m *Manager) GetHealthStatus() HealthStatus {
+func (m *Manager) GetHealthStatus(ctx context.Context) HealthStatus {
 	m.mu.RLock()
 	started := m.started
 	m.mu.RUnlock()
@@ -240,7 +359,7 @@ func (m *Manager) GetHealthStatus() HealthStatus {
 	}
 	
 	// Get the most recent execution
-	executions := m.scheduler.GetJobExecutions(1)
+	executions := m.scheduler.GetJobExecutions(ctx, 1)
 	if len(executions) > 0 {
 		status.LastExecution = &executions[0]
 	}
@@ -258,11 +377,11 @@ type HealthStatus struct {
 }
 
 // GenerateAllAlerts runs all alert generation jobs immediately
-func (m *Manager) GenerateAllAlerts() error {
+func (m *Manager) GenerateAllAlerts(ctx context.Context) error {
 	jobs := []string{"overdue-alerts", "reminder-alerts"}
 	
 	for _, jobName := range jobs {
-		if err := m.RunJobNow(jobName); err != nil {
+		if err := m.RunJobNow(ctx, jobName); err != nil {
 			return fmt.Errorf("failed to run job %s: %w", jobName, err)
 		}
 	}
@@ -271,47 +390,42 @@ func (m *Manager) GenerateAllAlerts() error {
 }
 
 // CleanupAlerts runs the alert cleanup job immediately
-func (m *Manager) CleanupAlerts() error {
-	return m.RunJobNow("cleanup-alerts")
+func (m *Manager) CleanupAlerts(ctx context.Context) error {
+	return m.RunJobNow(ctx, "cleanup-alerts")
 }
 
-// GetJobStatistics returns statistics about job executions
-func (m *Manager) GetJobStatistics() JobStatistics {
-	executions := m.scheduler.GetJobExecutions(0) // Get all executions
-	
+// GetJobStatistics returns statistics about job executions, aggregated from
+// the per-status counts of each job
+func (m *Manager) GetJobStatistics(ctx context.Context) JobStatistics {
 	stats := JobStatistics{
-		TotalExecutions:    len(executions),
-		SuccessfulExecutions: 0,
-		FailedExecutions:   0,
 		JobExecutionCounts: make(map[string]int),
 		JobSuccessRates:    make(map[string]float64),
 	}
 	
-	jobStats := make(map[string]struct {
-		total   int
-		success int
-	})
+	counts, err := m.scheduler.GetExecutionCounts(ctx)
+	if err != nil {
+		m.logger.Printf("Failed to get job statistics: %v", err)
+		return stats
+	}
 	
-	for _, exec := range executions {
-		stats.JobExecutionCounts[exec.JobName]++
-		
-		jobStat := jobStats[exec.JobName]
-		jobStat.total++
+	successes := make(map[string]int)
+	for _, count := range counts {
+		stats.TotalExecutions += count.Count
+		stats.JobExecutionCounts[count.JobName] += count.Count
 		
-		if exec.Status == JobStatusCompleted {
-			stats.SuccessfulExecutions++
-			jobStat.success++
-		} else if exec.Status == JobStatusFailed {
-			stats.FailedExecutions++
+		switch JobStatus(count.Status) {
+		case JobStatusCompleted:
+			stats.SuccessfulExecutions += count.Count
+			successes[count.JobName] += count.Count
+		case JobStatusFailed:
+			stats.FailedExecutions += count.Count
 		}
-		
-		jobStats[exec.JobName] = jobStat
 	}
 	
 	// Calculate success rates
-	for jobName, stat := range jobStats {
-		if stat.total > 0 {
-			stats.JobSuccessRates[jobName] = float64(stat.success) / float64(stat.total) * 100
+	for jobName, total := range stats.JobExecutionCounts {
+		if total > 0 {
+			stats.JobSuccessRates[jobName] = float64(successes[jobName]) / float64(total) * 100
 		}
 	}
 	
@@ -7,7 +7,10 @@ import (
 	"testing"
 	"time"
 
+	"board-game-library/internal/models"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -19,6 +22,7 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, 24*time.Hour, config.OverdueAlertSchedule)
 	assert.Equal(t, 24*time.Hour, config.ReminderAlertSchedule)
 	assert.Equal(t, 6*time.Hour, config.CleanupSchedule)
+	assert.Equal(t, 90*24*time.Hour, config.ExecutionRetention)
 	assert.NotNil(t, config.Logger)
 }
 
@@ -26,7 +30,8 @@ func TestNewManager(t *testing.T) {
 	mockAlertService := &MockAlertService{}
 	
 	// Test with default config
-	manager := NewManager(mockAlertService, nil)
+	manager, err := NewManager(mockAlertService, nil)
+	require.NoError(t, err)
 	assert.NotNil(t, manager)
 	assert.Equal(t, mockAlertService, manager.alertService)
 	assert.NotNil(t, manager.scheduler)
@@ -34,7 +39,7 @@ func TestNewManager(t *testing.T) {
 	assert.False(t, manager.started)
 	
 	// Verify default jobs are configured
-	jobs := manager.GetJobs()
+	jobs := manager.GetJobs(context.Background())
 	assert.Contains(t, jobs, "overdue-alerts")
 	assert.Contains(t, jobs, "reminder-alerts")
 	assert.Contains(t, jobs, "cleanup-alerts")
@@ -53,10 +58,11 @@ func TestNewManagerWithCustomConfig(t *testing.T) {
 		Logger:                log.New(os.Stdout, "[TEST] ", log.LstdFlags),
 	}
 	
-	manager := NewManager(mockAlertService, config)
+	manager, err := NewManager(mockAlertService, config)
+	require.NoError(t, err)
 	assert.NotNil(t, manager)
 	
-	jobs := manager.GetJobs()
+	jobs := manager.GetJobs(context.Background())
 	assert.Contains(t, jobs, "overdue-alerts")
 	assert.NotContains(t, jobs, "reminder-alerts") // Disabled in config
 	assert.Contains(t, jobs, "cleanup-alerts")
@@ -71,7 +77,8 @@ func TestNewManagerWithCustomConfig(t *testing.T) {
 
 func TestManagerStartStop(t *testing.T) {
 	mockAlertService := &MockAlertService{}
-	manager := NewManager(mockAlertService, nil)
+	manager, err := NewManager(mockAlertService, nil)
+	require.NoError(t, err)
 	
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -80,7 +87,7 @@ func TestManagerStartStop(t *testing.T) {
 	assert.False(t, manager.IsStarted())
 	
 	// Test starting
-	err := manager.Start(ctx)
+	err = manager.Start(ctx)
 	assert.NoError(t, err)
 	assert.True(t, manager.IsStarted())
 	
@@ -102,12 +109,13 @@ func TestManagerStartStop(t *testing.T) {
 
 func TestManagerContextCancellation(t *testing.T) {
 	mockAlertService := &MockAlertService{}
-	manager := NewManager(mockAlertService, nil)
+	manager, err := NewManager(mockAlertService, nil)
+	require.NoError(t, err)
 	
 	ctx, cancel := context.WithCancel(context.Background())
 	
 	// Start manager
-	err := manager.Start(ctx)
+	err = manager.Start(ctx)
 	assert.NoError(t, err)
 	assert.True(t, manager.IsStarted())
 	
@@ -123,20 +131,21 @@ func TestManagerContextCancellation(t *testing.T) {
 
 func TestManagerJobOperations(t *testing.T) {
 	mockAlertService := &MockAlertService{}
-	manager := NewManager(mockAlertService, nil)
+	manager, err := NewManager(mockAlertService, nil)
+	require.NoError(t, err)
 	
 	// Test adding custom job
 	jobExecuted := false
-	manager.AddCustomJob("test-job", "Test job", 1*time.Hour, func() error {
+	manager.AddCustomJob("test-job", "Test job", 1*time.Hour, func(ctx context.Context) error {
 		jobExecuted = true
 		return nil
 	})
 	
-	jobs := manager.GetJobs()
+	jobs := manager.GetJobs(context.Background())
 	assert.Contains(t, jobs, "test-job")
 	
 	// Test running job now
-	err := manager.RunJobNow("test-job")
+	err = manager.RunJobNow(context.Background(), "test-job")
 	assert.NoError(t, err)
 	
 	// Give time for job to execute
@@ -144,43 +153,44 @@ func TestManagerJobOperations(t *testing.T) {
 	assert.True(t, jobExecuted)
 	
 	// Test getting job status
-	job, err := manager.GetJobStatus("test-job")
+	job, err := manager.GetJobStatus(context.Background(), "test-job")
 	assert.NoError(t, err)
 	assert.NotNil(t, job)
 	assert.Equal(t, "test-job", job.Name)
 	
 	// Test disabling job
-	err = manager.DisableJob("test-job")
+	err = manager.DisableJob(context.Background(), "test-job")
 	assert.NoError(t, err)
 	
-	job, err = manager.GetJobStatus("test-job")
+	job, err = manager.GetJobStatus(context.Background(), "test-job")
 	assert.NoError(t, err)
 	assert.False(t, job.Enabled)
 	
 	// Test enabling job
-	err = manager.EnableJob("test-job")
+	err = manager.EnableJob(context.Background(), "test-job")
 	assert.NoError(t, err)
 	
-	job, err = manager.GetJobStatus("test-job")
+	job, err = manager.GetJobStatus(context.Background(), "test-job")
 	assert.NoError(t, err)
 	assert.True(t, job.Enabled)
 	
 	// Test removing job
 	manager.RemoveJob("test-job")
 	
-	jobs = manager.GetJobs()
+	jobs = manager.GetJobs(context.Background())
 	assert.NotContains(t, jobs, "test-job")
 }
 
 func TestManagerGetHealthStatus(t *testing.T) {
 	mockAlertService := &MockAlertService{}
-	manager := NewManager(mockAlertService, nil)
+	manager, err := NewManager(mockAlertService, nil)
+	require.NoError(t, err)
 	
 	// Test health status when not started
-	health := manager.GetHealthStatus()
+	health := manager.GetHealthStatus(context.Background())
 	assert.False(t, health.IsRunning)
-	assert.Equal(t, 3, health.TotalJobs) // Default jobs: overdue, reminder, cleanup
-	assert.Equal(t, 3, health.EnabledJobs)
+	assert.Equal(t, 4, health.TotalJobs) // Default jobs: overdue, reminder, cleanup, prune
+	assert.Equal(t, 4, health.EnabledJobs)
 	assert.Equal(t, 0, health.DisabledJobs)
 	assert.Nil(t, health.LastExecution)
 	
@@ -188,48 +198,49 @@ func TestManagerGetHealthStatus(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	
-	err := manager.Start(ctx)
+	err = manager.Start(ctx)
 	assert.NoError(t, err)
 	
 	// Add and run a test job
-	manager.AddCustomJob("health-test", "Health test job", 1*time.Hour, func() error {
+	manager.AddCustomJob("health-test", "Health test job", 1*time.Hour, func(ctx context.Context) error {
 		return nil
 	})
 	
-	err = manager.RunJobNow("health-test")
+	err = manager.RunJobNow(context.Background(), "health-test")
 	assert.NoError(t, err)
 	
 	// Give time for job to execute
 	time.Sleep(100 * time.Millisecond)
 	
 	// Test health status when started
-	health = manager.GetHealthStatus()
+	health = manager.GetHealthStatus(context.Background())
 	assert.True(t, health.IsRunning)
-	assert.Equal(t, 4, health.TotalJobs) // 3 default + 1 custom
-	assert.Equal(t, 4, health.EnabledJobs)
+	assert.Equal(t, 5, health.TotalJobs) // 4 default + 1 custom
+	assert.Equal(t, 5, health.EnabledJobs)
 	assert.Equal(t, 0, health.DisabledJobs)
 	assert.NotNil(t, health.LastExecution)
 	assert.Equal(t, "health-test", health.LastExecution.JobName)
 	
 	// Disable a job and check health
-	err = manager.DisableJob("health-test")
+	err = manager.DisableJob(context.Background(), "health-test")
 	assert.NoError(t, err)
 	
-	health = manager.GetHealthStatus()
-	assert.Equal(t, 3, health.EnabledJobs)
+	health = manager.GetHealthStatus(context.Background())
+	assert.Equal(t, 4, health.EnabledJobs)
 	assert.Equal(t, 1, health.DisabledJobs)
 }
 
 func TestManagerGenerateAllAlerts(t *testing.T) {
 	mockAlertService := &MockAlertService{}
-	manager := NewManager(mockAlertService, nil)
+	manager, err := NewManager(mockAlertService, nil)
+	require.NoError(t, err)
 	
 	// Set up mock expectations
 	mockAlertService.On("GenerateOverdueAlerts").Return(nil)
 	mockAlertService.On("GenerateReminderAlerts").Return(nil)
 	
 	// Test generating all alerts
-	err := manager.GenerateAllAlerts()
+	err = manager.GenerateAllAlerts(context.Background())
 	assert.NoError(t, err)
 	
 	// Give time for jobs to execute
@@ -241,13 +252,14 @@ func TestManagerGenerateAllAlerts(t *testing.T) {
 
 func TestManagerCleanupAlerts(t *testing.T) {
 	mockAlertService := &MockAlertService{}
-	manager := NewManager(mockAlertService, nil)
+	manager, err := NewManager(mockAlertService, nil)
+	require.NoError(t, err)
 	
 	// Set up mock expectations
 	mockAlertService.On("CleanupResolvedAlerts").Return(nil)
 	
 	// Test cleanup alerts
-	err := manager.CleanupAlerts()
+	err = manager.CleanupAlerts(context.Background())
 	assert.NoError(t, err)
 	
 	// Give time for job to execute
@@ -259,28 +271,29 @@ func TestManagerCleanupAlerts(t *testing.T) {
 
 func TestManagerGetJobStatistics(t *testing.T) {
 	mockAlertService := &MockAlertService{}
-	manager := NewManager(mockAlertService, nil)
+	manager, err := NewManager(mockAlertService, nil)
+	require.NoError(t, err)
 	
 	// Add test jobs with different outcomes
-	manager.AddCustomJob("success-job", "Always succeeds", 1*time.Hour, func() error {
+	manager.AddCustomJob("success-job", "Always succeeds", 1*time.Hour, func(ctx context.Context) error {
 		return nil
 	})
 	
-	manager.AddCustomJob("fail-job", "Always fails", 1*time.Hour, func() error {
+	manager.AddCustomJob("fail-job", "Always fails", 1*time.Hour, func(ctx context.Context) error {
 		return assert.AnError
 	})
 	
 	// Run jobs multiple times
 	for i := 0; i < 3; i++ {
-		manager.RunJobNow("success-job")
-		manager.RunJobNow("fail-job")
+		manager.RunJobNow(context.Background(), "success-job")
+		manager.RunJobNow(context.Background(), "fail-job")
 	}
 	
 	// Give time for jobs to execute
 	time.Sleep(200 * time.Millisecond)
 	
 	// Get statistics
-	stats := manager.GetJobStatistics()
+	stats := manager.GetJobStatistics(context.Background())
 	
 	assert.Greater(t, stats.TotalExecutions, 0)
 	assert.Greater(t, stats.SuccessfulExecutions, 0)
@@ -297,28 +310,56 @@ func TestManagerGetJobStatistics(t *testing.T) {
 	assert.Equal(t, 0.0, stats.JobSuccessRates["fail-job"])
 }
 
+func TestManagerGetJobStatisticsFromStore(t *testing.T) {
+	manager, err := NewManager(&MockAlertService{}, nil)
+	require.NoError(t, err)
+	store := newMemoryExecutionStore()
+	assert.NoError(t, manager.SetExecutionStore(store))
+	
+	now := time.Now()
+	for _, run := range []models.JobRun{
+		{JobName: "overdue-alerts", Status: "completed", StartedAt: now},
+		{JobName: "overdue-alerts", Status: "completed", StartedAt: now},
+		{JobName: "overdue-alerts", Status: "failed", StartedAt: now},
+		{JobName: "cleanup-alerts", Status: "failed", StartedAt: now},
+		{JobName: "cleanup-alerts", Status: "running", StartedAt: now},
+	} {
+		assert.NoError(t, store.Create(context.Background(), &run))
+	}
+	
+	stats := manager.GetJobStatistics(context.Background())
+	
+	assert.Equal(t, 5, stats.TotalExecutions)
+	assert.Equal(t, 2, stats.SuccessfulExecutions)
+	assert.Equal(t, 2, stats.FailedExecutions)
+	assert.Equal(t, map[string]int{"overdue-alerts": 3, "cleanup-alerts": 2}, stats.JobExecutionCounts)
+	assert.InDelta(t, 66.67, stats.JobSuccessRates["overdue-alerts"], 0.01)
+	assert.Equal(t, 0.0, stats.JobSuccessRates["cleanup-alerts"])
+}
+
 func TestManagerGetJobExecutions(t *testing.T) {
 	mockAlertService := &MockAlertService{}
-	manager := NewManager(mockAlertService, nil)
+	manager, err := NewManager(mockAlertService, nil)
+	require.NoError(t, err)
 	
 	// Add and run test jobs
-	manager.AddCustomJob("exec-test-1", "Execution test 1", 1*time.Hour, func() error {
+	manager.AddCustomJob("exec-test-1", "Execution test 1", 1*time.Hour, func(ctx context.Context) error {
 		return nil
 	})
 	
-	manager.AddCustomJob("exec-test-2", "Execution test 2", 1*time.Hour, func() error {
+	manager.AddCustomJob("exec-test-2", "Execution test 2", 1*time.Hour, func(ctx context.Context) error {
 		return nil
 	})
 	
 	// Run jobs
-	manager.RunJobNow("exec-test-1")
-	manager.RunJobNow("exec-test-2")
+	manager.RunJobNow(context.Background(), "exec-test-1")
+	manager.RunJobNow(context.Background(), "exec-test-2")
 	
 	// Give time for jobs to execute
 	time.Sleep(100 * time.Millisecond)
 	
 	// Test getting executions
-	executions := manager.GetJobExecutions(10)
+	executions := manager.GetJobExecutions(context.Background(), 10)
 	assert.Greater(t, len(executions), 0)
 	
 	// Verify execution details
@@ -342,10 +383,11 @@ func TestManagerConfigureJobsDisabled(t *testing.T) {
 		Logger:               log.New(os.Stdout, "[TEST] ", log.LstdFlags),
 	}
 	
-	manager := NewManager(mockAlertService, config)
+	manager, err := NewManager(mockAlertService, config)
+	require.NoError(t, err)
 	
 	// Verify no default jobs are configured
-	jobs := manager.GetJobs()
+	jobs := manager.GetJobs(context.Background())
 	assert.NotContains(t, jobs, "overdue-alerts")
 	assert.NotContains(t, jobs, "reminder-alerts")
 	assert.NotContains(t, jobs, "cleanup-alerts")
@@ -354,26 +396,81 @@ func TestManagerConfigureJobsDisabled(t *testing.T) {
 
 func TestManagerJobOperationsErrors(t *testing.T) {
 	mockAlertService := &MockAlertService{}
-	manager := NewManager(mockAlertService, nil)
+	manager, err := NewManager(mockAlertService, nil)
+	require.NoError(t, err)
 	
 	// Test running non-existent job
-	err := manager.RunJobNow("non-existent")
+	err = manager.RunJobNow(context.Background(), "non-existent")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 	
 	// Test getting status of non-existent job
-	job, err := manager.GetJobStatus("non-existent")
+	job, err := manager.GetJobStatus(context.Background(), "non-existent")
 	assert.Error(t, err)
 	assert.Nil(t, job)
 	assert.Contains(t, err.Error(), "not found")
 	
 	// Test enabling non-existent job
-	err = manager.EnableJob("non-existent")
+	err = manager.EnableJob(context.Background(), "non-existent")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 	
 	// Test disabling non-existent job
-	err = manager.DisableJob("non-existent")
+	err = manager.DisableJob(context.Background(), "non-existent")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
-}
\ No newline at end of file
+}
+func TestNewManagerWithCronConfig(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+
+	config := DefaultConfig()
+	config.OverdueAlertCron = "0 8 * * *"
+	config.Timezone = "Europe/Paris"
+
+	manager, err := NewManager(mockAlertService, config)
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Paris", manager.Location().String())
+
+	jobs := manager.GetJobs(context.Background())
+	assert.Equal(t, "0 8 * * *", jobs["overdue-alerts"].Cron)
+	assert.Equal(t, 8, jobs["overdue-alerts"].NextRun.In(manager.Location()).Hour())
+
+	// Jobs without a cron expression keep their interval
+	assert.Equal(t, "", jobs["reminder-alerts"].Cron)
+	assert.Equal(t, 24*time.Hour, jobs["reminder-alerts"].Schedule)
+}
+
+func TestNewManagerInvalidConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.CleanupCron = "every morning"
+
+	manager, err := NewManager(&MockAlertService{}, config)
+	assert.Nil(t, manager)
+	assert.ErrorIs(t, err, models.ErrValidation)
+	assert.Contains(t, err.Error(), "cleanup schedule")
+
+	config = DefaultConfig()
+	config.Timezone = "Mars/Olympus_Mons"
+	_, err = NewManager(&MockAlertService{}, config)
+	assert.ErrorContains(t, err, "invalid timezone")
+}
+
+func TestConfigValidate(t *testing.T) {
+	config := DefaultConfig()
+	assert.NoError(t, config.Validate())
+
+	config.ReminderAlertCron = "0 18 * * *"
+	config.Timezone = "Europe/Paris"
+	assert.NoError(t, config.Validate())
+
+	config.OverdueAlertCron = "0 25 * * *"
+	err := config.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "overdue alert schedule")
+
+	config.OverdueAlertCron = ""
+	config.Timezone = "Nowhere/Invalid"
+	err = config.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid timezone")
+}
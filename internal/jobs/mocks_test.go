@@ -1,6 +1,12 @@
 package jobs
 
 import (
+	"context"
+	"sync"
+	"time"
+
+	"board-game-library/internal/models"
+
 	"github.com/stretchr/testify/mock"
 )
 
@@ -9,17 +15,111 @@ type MockAlertService struct {
 	mock.Mock
 }
 
-func (m *MockAlertService) GenerateOverdueAlerts() error {
+func (m *MockAlertService) GenerateOverdueAlerts(ctx context.Context) error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m *MockAlertService) GenerateReminderAlerts() error {
+func (m *MockAlertService) GenerateReminderAlerts(ctx context.Context) error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m *MockAlertService) CleanupResolvedAlerts() error {
+func (m *MockAlertService) CleanupResolvedAlerts(ctx context.Context) error {
 	args := m.Called()
 	return args.Error(0)
-}
\ No newline at end of file
+}
+// memoryExecutionStore is an in-memory ExecutionStore for testing
+type memoryExecutionStore struct {
+	mu       sync.Mutex
+	runs     []*models.JobRun
+	nextID   int
+	lastRuns map[string]time.Time
+}
+
+func newMemoryExecutionStore() *memoryExecutionStore {
+	return &memoryExecutionStore{lastRuns: make(map[string]time.Time)}
+}
+
+func (s *memoryExecutionStore) Create(ctx context.Context, run *models.JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	run.ID = s.nextID
+	stored := *run
+	s.runs = append(s.runs, &stored)
+	return nil
+}
+
+func (s *memoryExecutionStore) Update(ctx context.Context, run *models.JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *run
+	for i := range s.runs {
+		if s.runs[i].ID == run.ID {
+			s.runs[i] = &stored
+		}
+	}
+	return nil
+}
+
+func (s *memoryExecutionStore) List(ctx context.Context, jobName string, limit, offset int) ([]*models.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matching []*models.JobRun
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		if jobName == "" || s.runs[i].JobName == jobName {
+			matching = append(matching, s.runs[i])
+		}
+	}
+	if offset >= len(matching) {
+		return nil, nil
+	}
+	matching = matching[offset:]
+	if limit > 0 && limit < len(matching) {
+		matching = matching[:limit]
+	}
+	return matching, nil
+}
+
+func (s *memoryExecutionStore) Count(ctx context.Context, jobName string) (int, error) {
+	runs, _ := s.List(ctx, jobName, 0, 0)
+	return len(runs), nil
+}
+
+func (s *memoryExecutionStore) CountByStatus(ctx context.Context) ([]models.JobRunCount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var counts []models.JobRunCount
+	for _, run := range s.runs {
+		found := false
+		for i := range counts {
+			if counts[i].JobName == run.JobName && counts[i].Status == run.Status {
+				counts[i].Count++
+				found = true
+			}
+		}
+		if !found {
+			counts = append(counts, models.JobRunCount{JobName: run.JobName, Status: run.Status, Count: 1})
+		}
+	}
+	return counts, nil
+}
+
+func (s *memoryExecutionStore) GetLastRuns(ctx context.Context) (map[string]time.Time, error) {
+	return s.lastRuns, nil
+}
+
+func (s *memoryExecutionStore) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.runs[:0]
+	for _, run := range s.runs {
+		if !run.StartedAt.Before(before) {
+			kept = append(kept, run)
+		}
+	}
+	removed := len(s.runs) - len(kept)
+	s.runs = kept
+	return removed, nil
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"sync"
 	"time"
+
+	"board-game-library/internal/models"
+
+	"github.com/robfig/cron/v3"
 )
 
 // JobStatus represents the status of a job execution
@@ -29,15 +34,29 @@ type JobExecution struct {
 	Duration  string    `json:"duration"`
 }
 
-// Job represents a scheduled job
+// Job represents a scheduled job. A job runs either at a fixed interval
+// (Schedule) or at the wall-clock times described by a cron expression (Cron).
+// Its handler is given a context cancelled when the scheduler stops.
 type Job struct {
-	Name        string
-	Description string
-	Schedule    time.Duration
-	Handler     func() error
-	LastRun     time.Time
-	NextRun     time.Time
-	Enabled     bool
+	Name        string                          `json:"name"`
+	Description string                          `json:"description"`
+	Schedule    time.Duration                   `json:"schedule"`
+	Cron        string                          `json:"cron,omitempty"`
+	Handler     func(ctx context.Context) error `json:"-"`
+	LastRun     time.Time                       `json:"last_run"`
+	NextRun     time.Time                       `json:"next_run"`
+	Enabled     bool                            `json:"enabled"`
+	Running     bool                            `json:"running"`
+
+	cronSchedule cron.Schedule
+}
+
+// nextRunAfter computes the next run time following t in the given location
+func (j *Job) nextRunAfter(t time.Time, location *time.Location) time.Time {
+	if j.cronSchedule != nil {
+		return j.cronSchedule.Next(t.In(location))
+	}
+	return t.Add(j.Schedule)
 }
 
 // Scheduler manages background jobs
@@ -50,6 +69,9 @@ type Scheduler struct {
 	wg          sync.WaitGroup
 	mu          sync.RWMutex
 	logger      *log.Logger
+	location    *time.Location
+	store       ExecutionStore
+	lastRuns    map[string]time.Time
 }
 
 // NewScheduler creates a new job scheduler
@@ -67,6 +89,7 @@ func NewScheduler(alertService AlertService, logger *log.Logger) *Scheduler {
 		ctx:          ctx,
 		cancel:       cancel,
 		logger:       logger,
+		location:     time.Local,
 	}
 	
 	// Register default alert jobs
@@ -78,9 +101,9 @@ func NewScheduler(alertService AlertService, logger *log.Logger) *Scheduler {
 // registerAlertJobs registers the default alert generation jobs
 func (s *Scheduler) registerAlertJobs() {
 	// Daily overdue alert generation job
-	s.AddJob("overdue-alerts", "Generate alerts for overdue items", 24*time.Hour, func() error {
+	s.AddJob("overdue-alerts", "Generate alerts for overdue items", 24*time.Hour, func(ctx context.Context) error {
 		s.logger.Println("Starting overdue alert generation")
-		err := s.alertService.GenerateOverdueAlerts()
+		err := s.alertService.GenerateOverdueAlerts(ctx)
 		if err != nil {
 			s.logger.Printf("Failed to generate overdue alerts: %v", err)
 			return fmt.Errorf("overdue alert generation failed: %w", err)
@@ -90,9 +113,9 @@ func (s *Scheduler) registerAlertJobs() {
 	})
 	
 	// Daily reminder alert generation job
-	s.AddJob("reminder-alerts", "Generate reminder alerts for items due soon", 24*time.Hour, func() error {
+	s.AddJob("reminder-alerts", "Generate reminder alerts for items due soon", 24*time.Hour, func(ctx context.Context) error {
 		s.logger.Println("Starting reminder alert generation")
-		err := s.alertService.GenerateReminderAlerts()
+		err := s.alertService.GenerateReminderAlerts(ctx)
 		if err != nil {
 			s.logger.Printf("Failed to generate reminder alerts: %v", err)
 			return fmt.Errorf("reminder alert generation failed: %w", err)
@@ -102,9 +125,9 @@ func (s *Scheduler) registerAlertJobs() {
 	})
 	
 	// Cleanup resolved alerts job (runs every 6 hours)
-	s.AddJob("cleanup-alerts", "Clean up alerts for returned items", 6*time.Hour, func() error {
+	s.AddJob("cleanup-alerts", "Clean up alerts for returned items", 6*time.Hour, func(ctx context.Context) error {
 		s.logger.Println("Starting alert cleanup")
-		err := s.alertService.CleanupResolvedAlerts()
+		err := s.alertService.CleanupResolvedAlerts(ctx)
 		if err != nil {
 			s.logger.Printf("Failed to cleanup resolved alerts: %v", err)
 			return fmt.Errorf("alert cleanup failed: %w", err)
@@ -115,7 +138,7 @@ func (s *Scheduler) registerAlertJobs() {
 }
 
 // AddJob adds a new job to the scheduler
-func (s *Scheduler) AddJob(name, description string, schedule time.Duration, handler func() error) {
+func (s *Scheduler) AddJob(name, description string, schedule time.Duration, handler func(ctx context.Context) error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	
@@ -127,11 +150,132 @@ func (s *Scheduler) AddJob(name, description string, schedule time.Duration, han
 		NextRun:     time.Now().Add(schedule),
 		Enabled:     true,
 	}
+	s.restoreLastRun(job)
 	
 	s.jobs[name] = job
 	s.logger.Printf("Added job '%s' with schedule %v", name, schedule)
 }
 
+// AddCronJob adds a new job that runs at the times described by a cron
+// expression, evaluated in the scheduler's timezone
+func (s *Scheduler) AddCronJob(name, description, expr string, handler func(ctx context.Context) error) error {
+	schedule, err := ParseCronExpression(expr)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := &Job{
+		Name:         name,
+		Description:  description,
+		Cron:         expr,
+		Handler:      handler,
+		Enabled:      true,
+		cronSchedule: schedule,
+	}
+	job.NextRun = job.nextRunAfter(time.Now(), s.location)
+	s.restoreLastRun(job)
+
+	s.jobs[name] = job
+	s.logger.Printf("Added job '%s' with cron schedule '%s' (%s)", name, expr, s.location)
+	return nil
+}
+
+// SetExecutionStore enables persistent execution history. The last run of
+// every job is restored from the store so interval jobs resume their
+// schedule instead of restarting it from process start.
+func (s *Scheduler) SetExecutionStore(store ExecutionStore) error {
+	lastRuns, err := store.GetLastRuns(s.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to restore job last runs: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.store = store
+	s.lastRuns = lastRuns
+	for _, job := range s.jobs {
+		s.restoreLastRun(job)
+	}
+
+	return nil
+}
+
+// restoreLastRun applies a persisted last run to a job. Must be called with the lock held.
+func (s *Scheduler) restoreLastRun(job *Job) {
+	lastRun, ok := s.lastRuns[job.Name]
+	if !ok || !job.LastRun.IsZero() {
+		return
+	}
+
+	job.LastRun = lastRun
+	job.NextRun = job.nextRunAfter(lastRun, s.location)
+	s.logger.Printf("Restored job '%s' last run %s, next run at %s", job.Name,
+		lastRun.Format(time.RFC3339), job.NextRun.Format(time.RFC3339))
+}
+
+// RescheduleJob changes the schedule of an existing job. The spec is either a
+// duration such as "6h" or a cron expression such as "0 8 * * *".
+func (s *Scheduler) RescheduleJob(name, spec string) error {
+	interval, schedule, err := parseScheduleSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, exists := s.jobs[name]
+	if !exists {
+		return models.NotFound("job_not_found", name)
+	}
+
+	job.Schedule = interval
+	job.cronSchedule = schedule
+	job.Cron = ""
+	if schedule != nil {
+		job.Cron = spec
+	}
+
+	from := time.Now()
+	if schedule == nil && !job.LastRun.IsZero() {
+		from = job.LastRun
+	}
+	job.NextRun = job.nextRunAfter(from, s.location)
+
+	s.logger.Printf("Rescheduled job '%s' with schedule '%s', next run at %s", name, spec, job.NextRun.Format(time.RFC3339))
+	return nil
+}
+
+// SetLocation sets the timezone used to evaluate cron schedules and
+// recomputes the next run of every cron job
+func (s *Scheduler) SetLocation(location *time.Location) {
+	if location == nil {
+		location = time.Local
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.location = location
+	now := time.Now()
+	for _, job := range s.jobs {
+		if job.cronSchedule != nil {
+			job.NextRun = job.nextRunAfter(now, location)
+		}
+	}
+}
+
+// Location returns the timezone used to evaluate cron schedules
+func (s *Scheduler) Location() *time.Location {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.location
+}
+
 // RemoveJob removes a job from the scheduler
 func (s *Scheduler) RemoveJob(name string) {
 	s.mu.Lock()
@@ -148,7 +292,7 @@ func (s *Scheduler) EnableJob(name string) error {
 	
 	job, exists := s.jobs[name]
 	if !exists {
-		return fmt.Errorf("job '%s' not found", name)
+		return models.NotFound("job_not_found", name)
 	}
 	
 	job.Enabled = true
@@ -163,7 +307,7 @@ func (s *Scheduler) DisableJob(name string) error {
 	
 	job, exists := s.jobs[name]
 	if !exists {
-		return fmt.Errorf("job '%s' not found", name)
+		return models.NotFound("job_not_found", name)
 	}
 	
 	job.Enabled = false
@@ -182,7 +326,11 @@ func (s *Scheduler) Start() {
 // Stop stops the scheduler
 func (s *Scheduler) Stop() {
 	s.logger.Println("Stopping job scheduler")
+	// Cancel under the lock so RunJobNow either sees the scheduler stopped
+	// or adds its run before Wait
+	s.mu.Lock()
 	s.cancel()
+	s.mu.Unlock()
 	s.wg.Wait()
 	s.logger.Println("Job scheduler stopped")
 }
@@ -204,18 +352,20 @@ func (s *Scheduler) run() {
 	}
 }
 
-// checkAndRunJobs checks if any jobs need to run and executes them
+// checkAndRunJobs checks if any jobs need to run and executes them. Jobs
+// started by RunJobNow and still running are left for the next check.
 func (s *Scheduler) checkAndRunJobs() {
-	s.mu.RLock()
+	s.mu.Lock()
 	jobsToRun := make([]*Job, 0)
 	now := time.Now()
 	
 	for _, job := range s.jobs {
-		if job.Enabled && now.After(job.NextRun) {
+		if job.Enabled && !job.Running && now.After(job.NextRun) {
+			job.Running = true
 			jobsToRun = append(jobsToRun, job)
 		}
 	}
-	s.mu.RUnlock()
+	s.mu.Unlock()
 	
 	// Run jobs outside of the lock to avoid blocking
 	for _, job := range jobsToRun {
@@ -223,46 +373,81 @@ func (s *Scheduler) checkAndRunJobs() {
 	}
 }
 
-// runJob executes a single job
+// runJob executes a single job, which the caller marked as running
 func (s *Scheduler) runJob(job *Job) {
-	executionID := fmt.Sprintf("%s-%d", job.Name, time.Now().Unix())
-	
 	execution := JobExecution{
-		ID:        executionID,
+		ID:        fmt.Sprintf("%s-%d", job.Name, time.Now().Unix()),
 		JobName:   job.Name,
 		Status:    JobStatusRunning,
 		StartTime: time.Now(),
 	}
 	
+	s.mu.RLock()
+	store := s.store
+	s.mu.RUnlock()
+	
+	// Record the execution as running so it is visible while the job works
+	var run *models.JobRun
+	if store != nil {
+		run = &models.JobRun{
+			JobName:   execution.JobName,
+			Status:    string(execution.Status),
+			StartedAt: execution.StartTime,
+		}
+		if err := store.Create(s.ctx, run); err != nil {
+			s.logger.Printf("Failed to record job execution for '%s': %v", job.Name, err)
+			run = nil
+		} else {
+			execution.ID = strconv.Itoa(run.ID)
+		}
+	}
+	
 	s.mu.Lock()
 	s.executions = append(s.executions, execution)
-	executionIndex := len(s.executions) - 1
 	s.mu.Unlock()
 	
-	s.logger.Printf("Starting job execution: %s", executionID)
+	s.logger.Printf("Starting job execution: %s", execution.ID)
 	
 	// Run the job
-	err := job.Handler()
-	
-	// Update execution record
-	s.mu.Lock()
-	s.executions[executionIndex].EndTime = time.Now()
-	s.executions[executionIndex].Duration = s.executions[executionIndex].EndTime.Sub(s.executions[executionIndex].StartTime).String()
+	err := job.Handler(s.ctx)
 	
+	execution.EndTime = time.Now()
+	execution.Duration = execution.EndTime.Sub(execution.StartTime).String()
 	if err != nil {
-		s.executions[executionIndex].Status = JobStatusFailed
-		s.executions[executionIndex].Error = err.Error()
-		s.logger.Printf("Job execution failed: %s - %v", executionID, err)
+		execution.Status = JobStatusFailed
+		execution.Error = err.Error()
+		s.logger.Printf("Job execution failed: %s - %v", execution.ID, err)
 	} else {
-		s.executions[executionIndex].Status = JobStatusCompleted
-		s.logger.Printf("Job execution completed: %s", executionID)
+		execution.Status = JobStatusCompleted
+		s.logger.Printf("Job execution completed: %s", execution.ID)
+	}
+	
+	if run != nil {
+		run.Status = string(execution.Status)
+		run.FinishedAt = &execution.EndTime
+		run.DurationMs = execution.EndTime.Sub(execution.StartTime).Milliseconds()
+		run.Error = execution.Error
+		// Record the result even when the scheduler is stopping
+		if err := store.Update(context.WithoutCancel(s.ctx), run); err != nil {
+			s.logger.Printf("Failed to record job execution result for '%s': %v", job.Name, err)
+		}
+	}
+	
+	// Update execution record
+	s.mu.Lock()
+	for i := len(s.executions) - 1; i >= 0; i-- {
+		if s.executions[i].ID == execution.ID && s.executions[i].StartTime.Equal(execution.StartTime) {
+			s.executions[i] = execution
+			break
+		}
 	}
 	
 	// Update job's next run time
-	job.LastRun = time.Now()
-	job.NextRun = job.LastRun.Add(job.Schedule)
+	job.Running = false
+	job.LastRun = execution.EndTime
+	job.NextRun = job.nextRunAfter(job.LastRun, s.location)
 	
-	// Keep only the last 100 executions to prevent memory growth
+	// Keep only the last 100 executions in memory; the store keeps the full history
 	if len(s.executions) > 100 {
 		s.executions = s.executions[len(s.executions)-100:]
 	}
@@ -270,21 +455,32 @@ func (s *Scheduler) runJob(job *Job) {
 	s.mu.Unlock()
 }
 
-// RunJobNow executes a job immediately, regardless of schedule
+// RunJobNow executes a job immediately, regardless of schedule. It does not
+// wait for the job, which Stop does; a job still running is not started again.
 func (s *Scheduler) RunJobNow(name string) error {
-	s.mu.RLock()
-	job, exists := s.jobs[name]
-	s.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	
+	job, exists := s.jobs[name]
 	if !exists {
-		return fmt.Errorf("job '%s' not found", name)
+		return models.NotFound("job_not_found", name)
 	}
 	
-	if !job.Enabled {
-		return fmt.Errorf("job '%s' is disabled", name)
+	switch {
+	case s.ctx.Err() != nil:
+		return models.Unavailable("scheduler_stopped")
+	case !job.Enabled:
+		return models.Conflict("job_disabled", name)
+	case job.Running:
+		return models.Conflict("job_running", name)
 	}
 	
-	go s.runJob(job)
+	job.Running = true
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.runJob(job)
+	}()
 	return nil
 }
 
@@ -303,8 +499,20 @@ func (s *Scheduler) GetJobs() map[string]*Job {
 	return jobs
 }
 
-// GetJobExecutions returns recent job executions
-func (s *Scheduler) GetJobExecutions(limit int) []JobExecution {
+// GetJobExecutions returns recent job executions, most recent first
+func (s *Scheduler) GetJobExecutions(ctx context.Context, limit int) []JobExecution {
+	s.mu.RLock()
+	store := s.store
+	s.mu.RUnlock()
+	
+	if store != nil {
+		executions, _, err := s.GetJobExecutionsPage(ctx, "", limit, 0)
+		if err == nil {
+			return executions
+		}
+		s.logger.Printf("Falling back to in-memory job executions: %v", err)
+	}
+	
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	
@@ -329,6 +537,59 @@ func (s *Scheduler) GetJobExecutions(limit int) []JobExecution {
 	return executions
 }
 
+// GetExecutionCounts returns the number of executions of each job in each
+// status, counted by the store when one is set
+func (s *Scheduler) GetExecutionCounts(ctx context.Context) ([]models.JobRunCount, error) {
+	s.mu.RLock()
+	store := s.store
+	s.mu.RUnlock()
+	
+	if store != nil {
+		counts, err := store.CountByStatus(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count job executions: %w", err)
+		}
+		return counts, nil
+	}
+	
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	index := make(map[models.JobRunCount]int)
+	counts := make([]models.JobRunCount, 0)
+	for _, execution := range s.executions {
+		key := models.JobRunCount{JobName: execution.JobName, Status: string(execution.Status)}
+		i, exists := index[key]
+		if !exists {
+			i = len(counts)
+			index[key] = i
+			counts = append(counts, key)
+		}
+		counts[i].Count++
+	}
+	
+	return counts, nil
+}
+
+// PruneExecutions removes the stored executions started before the given
+// time and returns how many were removed. The in-memory history is already
+// bounded, so nothing is pruned without a store.
+func (s *Scheduler) PruneExecutions(ctx context.Context, before time.Time) (int, error) {
+	s.mu.RLock()
+	store := s.store
+	s.mu.RUnlock()
+	
+	if store == nil {
+		return 0, nil
+	}
+	
+	removed, err := store.DeleteBefore(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune job executions: %w", err)
+	}
+	return removed, nil
+}
+
 // GetJobStatus returns the status of a specific job
 func (s *Scheduler) GetJobStatus(name string) (*Job, error) {
 	s.mu.RLock()
@@ -336,10 +597,82 @@ func (s *Scheduler) GetJobStatus(name string) (*Job, error) {
 	
 	job, exists := s.jobs[name]
 	if !exists {
-		return nil, fmt.Errorf("job '%s' not found", name)
+		return nil, models.NotFound("job_not_found", name)
 	}
 	
 	// Return a copy to avoid race conditions
 	jobCopy := *job
 	return &jobCopy, nil
-}
\ No newline at end of file
+}
+
+// GetJobExecutionsPage returns a page of job executions, most recent first,
+// together with the total number of matching executions. An empty job name
+// matches every job and a limit of zero or less returns all executions.
+func (s *Scheduler) GetJobExecutionsPage(ctx context.Context, jobName string, limit, offset int) ([]JobExecution, int, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	
+	s.mu.RLock()
+	store := s.store
+	s.mu.RUnlock()
+	
+	if store != nil {
+		runs, err := store.List(ctx, jobName, limit, offset)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get job executions: %w", err)
+		}
+		
+		total, err := store.Count(ctx, jobName)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to count job executions: %w", err)
+		}
+		
+		executions := make([]JobExecution, 0, len(runs))
+		for _, run := range runs {
+			executions = append(executions, executionFromRun(run))
+		}
+		return executions, total, nil
+	}
+	
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	// Walk the in-memory history from the most recent execution
+	matching := make([]JobExecution, 0)
+	for i := len(s.executions) - 1; i >= 0; i-- {
+		if jobName == "" || s.executions[i].JobName == jobName {
+			matching = append(matching, s.executions[i])
+		}
+	}
+	
+	total := len(matching)
+	if offset >= total {
+		return []JobExecution{}, total, nil
+	}
+	
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+	
+	return matching[offset:end], total, nil
+}
+
+// executionFromRun converts a persisted job run into a JobExecution
+func executionFromRun(run *models.JobRun) JobExecution {
+	execution := JobExecution{
+		ID:        strconv.Itoa(run.ID),
+		JobName:   run.JobName,
+		Status:    JobStatus(run.Status),
+		StartTime: run.StartedAt,
+		Error:     run.Error,
+	}
+	
+	if run.FinishedAt != nil {
+		execution.EndTime = *run.FinishedAt
+		execution.Duration = (time.Duration(run.DurationMs) * time.Millisecond).String()
+	}
+	
+	return execution
+}
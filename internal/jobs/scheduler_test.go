@@ -1,6 +1,7 @@
 package jobs
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -8,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"board-game-library/internal/models"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,7 +56,7 @@ func TestScheduler_AddJob(t *testing.T) {
 	scheduler := NewScheduler(mockAlertService, nil)
 	
 	jobExecuted := false
-	handler := func() error {
+	handler := func(ctx context.Context) error {
 		jobExecuted = true
 		return nil
 	}
@@ -71,7 +74,7 @@ func TestScheduler_AddJob(t *testing.T) {
 	assert.NotNil(t, testJob.Handler)
 	
 	// Test that handler works
-	err := testJob.Handler()
+	err := testJob.Handler(context.Background())
 	assert.NoError(t, err)
 	assert.True(t, jobExecuted)
 }
@@ -81,7 +84,7 @@ func TestScheduler_RemoveJob(t *testing.T) {
 	scheduler := NewScheduler(mockAlertService, nil)
 	
 	// Add a test job
-	scheduler.AddJob("test-job", "Test job", 1*time.Hour, func() error { return nil })
+	scheduler.AddJob("test-job", "Test job", 1*time.Hour, func(ctx context.Context) error { return nil })
 	
 	// Verify job exists
 	jobs := scheduler.GetJobs()
@@ -129,7 +132,7 @@ func TestScheduler_RunJobNow(t *testing.T) {
 	scheduler := NewScheduler(mockAlertService, nil)
 	
 	jobExecuted := false
-	scheduler.AddJob("test-job", "Test job", 1*time.Hour, func() error {
+	scheduler.AddJob("test-job", "Test job", 1*time.Hour, func(ctx context.Context) error {
 		jobExecuted = true
 		return nil
 	})
@@ -177,7 +180,7 @@ func TestScheduler_JobExecution(t *testing.T) {
 	scheduler := NewScheduler(mockAlertService, nil)
 	
 	executionCount := 0
-	scheduler.AddJob("test-job", "Test job", 1*time.Hour, func() error {
+	scheduler.AddJob("test-job", "Test job", 1*time.Hour, func(ctx context.Context) error {
 		executionCount++
 		return nil
 	})
@@ -193,7 +196,7 @@ func TestScheduler_JobExecution(t *testing.T) {
 	assert.Greater(t, executionCount, 0)
 	
 	// Check execution history
-	executions := scheduler.GetJobExecutions(10)
+	executions := scheduler.GetJobExecutions(context.Background(), 10)
 	assert.Greater(t, len(executions), 0)
 	
 	// Verify execution details
@@ -207,12 +210,95 @@ func TestScheduler_JobExecution(t *testing.T) {
 	assert.Empty(t, execution.Error)
 }
 
+func TestScheduler_StopCancelsRunningJob(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	scheduler := NewScheduler(mockAlertService, nil)
+
+	started := make(chan struct{})
+	finished := make(chan error, 1)
+	scheduler.AddJob("slow-job", "Slow job", 1*time.Hour, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		finished <- ctx.Err()
+		return ctx.Err()
+	})
+
+	assert.NoError(t, scheduler.RunJobNow("slow-job"))
+	<-started
+	scheduler.Stop()
+
+	select {
+	case err := <-finished:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("job was not cancelled by Stop")
+	}
+}
+
+func TestScheduler_RunJobNowWhileRunning(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	scheduler := NewScheduler(mockAlertService, nil)
+
+	release := make(chan struct{})
+	scheduler.AddJob("slow-job", "Slow job", 1*time.Hour, func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	assert.NoError(t, scheduler.RunJobNow("slow-job"))
+	job, _ := scheduler.GetJobStatus("slow-job")
+	assert.True(t, job.Running)
+
+	err := scheduler.RunJobNow("slow-job")
+	assert.True(t, errors.Is(err, models.ErrConflict))
+	assert.EqualError(t, err, "job 'slow-job' is already running")
+
+	// The scheduled check leaves the running job alone
+	scheduler.mu.Lock()
+	scheduler.jobs["slow-job"].NextRun = time.Now().Add(-time.Minute)
+	scheduler.mu.Unlock()
+	scheduler.checkAndRunJobs()
+
+	close(release)
+	scheduler.Stop()
+
+	executions := scheduler.GetJobExecutions(context.Background(), 0)
+	assert.Len(t, executions, 1)
+	job, _ = scheduler.GetJobStatus("slow-job")
+	assert.False(t, job.Running)
+}
+
+func TestScheduler_StopWaitsForManualRuns(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	scheduler := NewScheduler(mockAlertService, nil)
+
+	started := make(chan struct{})
+	scheduler.AddJob("slow-job", "Slow job", 1*time.Hour, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		return ctx.Err()
+	})
+
+	assert.NoError(t, scheduler.RunJobNow("slow-job"))
+	<-started
+	scheduler.Stop()
+
+	executions := scheduler.GetJobExecutions(context.Background(), 0)
+	if assert.Len(t, executions, 1) {
+		assert.Equal(t, JobStatusFailed, executions[0].Status)
+	}
+
+	err := scheduler.RunJobNow("slow-job")
+	assert.True(t, errors.Is(err, models.ErrUnavailable))
+}
+
 func TestScheduler_JobExecutionWithError(t *testing.T) {
 	mockAlertService := &MockAlertService{}
 	scheduler := NewScheduler(mockAlertService, nil)
 	
 	testError := errors.New("test error")
-	scheduler.AddJob("failing-job", "Failing job", 1*time.Hour, func() error {
+	scheduler.AddJob("failing-job", "Failing job", 1*time.Hour, func(ctx context.Context) error {
 		return testError
 	})
 	
@@ -224,7 +310,7 @@ func TestScheduler_JobExecutionWithError(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	
 	// Check execution history
-	executions := scheduler.GetJobExecutions(10)
+	executions := scheduler.GetJobExecutions(context.Background(), 10)
 	assert.Greater(t, len(executions), 0)
 	
 	// Find the failing job execution
@@ -292,7 +378,7 @@ func TestScheduler_AlertJobsExecutionWithErrors(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	
 	// Check execution history for errors
-	executions := scheduler.GetJobExecutions(10)
+	executions := scheduler.GetJobExecutions(context.Background(), 10)
 	assert.Greater(t, len(executions), 0)
 	
 	// Verify that jobs failed
@@ -318,7 +404,7 @@ func TestScheduler_GetJobExecutions(t *testing.T) {
 	// Add multiple jobs and run them
 	for i := 0; i < 5; i++ {
 		jobName := fmt.Sprintf("test-job-%d", i)
-		scheduler.AddJob(jobName, "Test job", 1*time.Hour, func() error {
+		scheduler.AddJob(jobName, "Test job", 1*time.Hour, func(ctx context.Context) error {
 			return nil
 		})
 		scheduler.RunJobNow(jobName)
@@ -328,15 +414,15 @@ func TestScheduler_GetJobExecutions(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 	
 	// Test getting limited executions
-	executions := scheduler.GetJobExecutions(3)
+	executions := scheduler.GetJobExecutions(context.Background(), 3)
 	assert.Len(t, executions, 3)
 	
 	// Test getting all executions
-	allExecutions := scheduler.GetJobExecutions(0)
+	allExecutions := scheduler.GetJobExecutions(context.Background(), 0)
 	assert.GreaterOrEqual(t, len(allExecutions), 5)
 	
 	// Test getting more executions than available
-	moreExecutions := scheduler.GetJobExecutions(100)
+	moreExecutions := scheduler.GetJobExecutions(context.Background(), 100)
 	assert.Equal(t, len(allExecutions), len(moreExecutions))
 }
 
@@ -378,7 +464,7 @@ func TestScheduler_ConcurrentAccess(t *testing.T) {
 	for i := 0; i < 5; i++ {
 		go func(id int) {
 			jobName := fmt.Sprintf("concurrent-job-%d", id)
-			scheduler.AddJob(jobName, "Concurrent job", 1*time.Hour, func() error {
+			scheduler.AddJob(jobName, "Concurrent job", 1*time.Hour, func(ctx context.Context) error {
 				return nil
 			})
 			done <- true
@@ -410,7 +496,7 @@ func TestScheduler_ExecutionHistoryLimit(t *testing.T) {
 	scheduler := NewScheduler(mockAlertService, nil)
 	
 	// Add a job that runs quickly
-	scheduler.AddJob("history-test", "History test job", 1*time.Millisecond, func() error {
+	scheduler.AddJob("history-test", "History test job", 1*time.Millisecond, func(ctx context.Context) error {
 		return nil
 	})
 	
@@ -420,6 +506,274 @@ func TestScheduler_ExecutionHistoryLimit(t *testing.T) {
 	scheduler.Stop()
 	
 	// Check that execution history is limited
-	executions := scheduler.GetJobExecutions(0)
+	executions := scheduler.GetJobExecutions(context.Background(), 0)
 	assert.LessOrEqual(t, len(executions), 100, "Execution history should be limited to 100 entries")
-}
\ No newline at end of file
+}
+func TestScheduler_AddCronJob(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	scheduler := NewScheduler(mockAlertService, nil)
+
+	paris, err := LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+	scheduler.SetLocation(paris)
+
+	err = scheduler.AddCronJob("morning-job", "Runs every morning", "0 8 * * *", func(ctx context.Context) error { return nil })
+	assert.NoError(t, err)
+
+	job, err := scheduler.GetJobStatus("morning-job")
+	assert.NoError(t, err)
+	assert.Equal(t, "0 8 * * *", job.Cron)
+	assert.Equal(t, time.Duration(0), job.Schedule)
+
+	// Next run is at 08:00 wall-clock time in the scheduler's timezone
+	nextRun := job.NextRun.In(paris)
+	assert.Equal(t, 8, nextRun.Hour())
+	assert.Equal(t, 0, nextRun.Minute())
+	assert.True(t, nextRun.After(time.Now()))
+	assert.True(t, nextRun.Before(time.Now().Add(25*time.Hour)))
+}
+
+func TestScheduler_AddCronJobInvalidExpression(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	scheduler := NewScheduler(mockAlertService, nil)
+
+	err := scheduler.AddCronJob("bad-job", "Invalid schedule", "every morning", func(ctx context.Context) error { return nil })
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid schedule")
+
+	_, err = scheduler.GetJobStatus("bad-job")
+	assert.Error(t, err)
+}
+
+func TestScheduler_CronNextRunIsStableAcrossRestarts(t *testing.T) {
+	handler := func(ctx context.Context) error { return nil }
+
+	first := NewScheduler(&MockAlertService{}, nil)
+	first.SetLocation(time.UTC)
+	assert.NoError(t, first.AddCronJob("daily", "Daily job", "0 8 * * *", handler))
+
+	second := NewScheduler(&MockAlertService{}, nil)
+	second.SetLocation(time.UTC)
+	assert.NoError(t, second.AddCronJob("daily", "Daily job", "0 8 * * *", handler))
+
+	firstJob, _ := first.GetJobStatus("daily")
+	secondJob, _ := second.GetJobStatus("daily")
+	assert.Equal(t, firstJob.NextRun, secondJob.NextRun)
+}
+
+func TestScheduler_RunJobAdvancesCronNextRun(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	scheduler := NewScheduler(mockAlertService, nil)
+	scheduler.SetLocation(time.UTC)
+
+	assert.NoError(t, scheduler.AddCronJob("hourly", "Hourly job", "@hourly", func(ctx context.Context) error { return nil }))
+
+	scheduler.mu.RLock()
+	job := scheduler.jobs["hourly"]
+	scheduler.mu.RUnlock()
+
+	scheduler.runJob(job)
+
+	updated, _ := scheduler.GetJobStatus("hourly")
+	assert.Equal(t, 0, updated.NextRun.Minute())
+	assert.True(t, updated.NextRun.After(updated.LastRun))
+	assert.True(t, updated.NextRun.Sub(updated.LastRun) <= time.Hour)
+}
+
+func TestScheduler_RescheduleJob(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	scheduler := NewScheduler(mockAlertService, nil)
+	scheduler.SetLocation(time.UTC)
+
+	// Switch an interval job to a cron schedule
+	err := scheduler.RescheduleJob("overdue-alerts", "30 7 * * 1-5")
+	assert.NoError(t, err)
+
+	job, _ := scheduler.GetJobStatus("overdue-alerts")
+	assert.Equal(t, "30 7 * * 1-5", job.Cron)
+	assert.Equal(t, 7, job.NextRun.Hour())
+	assert.Equal(t, 30, job.NextRun.Minute())
+
+	// Switch back to an interval
+	err = scheduler.RescheduleJob("overdue-alerts", "12h")
+	assert.NoError(t, err)
+
+	job, _ = scheduler.GetJobStatus("overdue-alerts")
+	assert.Equal(t, "", job.Cron)
+	assert.Equal(t, 12*time.Hour, job.Schedule)
+
+	// Invalid schedules are rejected and leave the job untouched
+	err = scheduler.RescheduleJob("overdue-alerts", "61 * * * *")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid schedule")
+
+	err = scheduler.RescheduleJob("overdue-alerts", "-1h")
+	assert.Error(t, err)
+
+	job, _ = scheduler.GetJobStatus("overdue-alerts")
+	assert.Equal(t, 12*time.Hour, job.Schedule)
+
+	// Unknown jobs are reported
+	err = scheduler.RescheduleJob("missing", "1h")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestLoadLocation(t *testing.T) {
+	location, err := LoadLocation("")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Local, location)
+
+	location, err = LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Paris", location.String())
+
+	_, err = LoadLocation("Mars/Olympus_Mons")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid timezone")
+}
+
+func TestScheduler_SetExecutionStoreRestoresLastRun(t *testing.T) {
+	scheduler := NewScheduler(&MockAlertService{}, nil)
+	scheduler.AddJob("hourly-job", "Hourly job", time.Hour, func(ctx context.Context) error { return nil })
+
+	lastRun := time.Now().Add(-20 * time.Minute)
+	store := newMemoryExecutionStore()
+	store.lastRuns["hourly-job"] = lastRun
+
+	err := scheduler.SetExecutionStore(store)
+	assert.NoError(t, err)
+
+	job, _ := scheduler.GetJobStatus("hourly-job")
+	assert.True(t, job.LastRun.Equal(lastRun))
+	assert.True(t, job.NextRun.Equal(lastRun.Add(time.Hour)))
+
+	// Jobs added after the store is set are restored too
+	store.lastRuns["late-job"] = lastRun
+	scheduler.mu.Lock()
+	scheduler.lastRuns = store.lastRuns
+	scheduler.mu.Unlock()
+	scheduler.AddJob("late-job", "Late job", 2*time.Hour, func(ctx context.Context) error { return nil })
+
+	lateJob, _ := scheduler.GetJobStatus("late-job")
+	assert.True(t, lateJob.NextRun.Equal(lastRun.Add(2*time.Hour)))
+
+	// Jobs without history keep their default schedule
+	cleanupJob, _ := scheduler.GetJobStatus("cleanup-alerts")
+	assert.True(t, cleanupJob.LastRun.IsZero())
+}
+
+func TestScheduler_PersistsExecutions(t *testing.T) {
+	scheduler := NewScheduler(&MockAlertService{}, nil)
+	store := newMemoryExecutionStore()
+	assert.NoError(t, scheduler.SetExecutionStore(store))
+
+	scheduler.AddJob("ok-job", "Succeeds", time.Hour, func(ctx context.Context) error { return nil })
+	scheduler.AddJob("bad-job", "Fails", time.Hour, func(ctx context.Context) error { return errors.New("boom") })
+
+	scheduler.mu.RLock()
+	okJob := scheduler.jobs["ok-job"]
+	badJob := scheduler.jobs["bad-job"]
+	scheduler.mu.RUnlock()
+
+	scheduler.runJob(okJob)
+	scheduler.runJob(badJob)
+	scheduler.runJob(okJob)
+
+	assert.Len(t, store.runs, 3)
+	assert.Equal(t, "completed", store.runs[0].Status)
+	assert.NotNil(t, store.runs[0].FinishedAt)
+	assert.Equal(t, "failed", store.runs[1].Status)
+	assert.Equal(t, "boom", store.runs[1].Error)
+
+	// Paginated queries read from the store
+	executions, total, err := scheduler.GetJobExecutionsPage(context.Background(), "ok-job", 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, executions, 1)
+	assert.Equal(t, "3", executions[0].ID)
+	assert.Equal(t, JobStatusCompleted, executions[0].Status)
+
+	executions, total, err = scheduler.GetJobExecutionsPage(context.Background(), "", 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, executions, 2)
+	assert.Equal(t, "bad-job", executions[0].JobName)
+
+	recent := scheduler.GetJobExecutions(context.Background(), 2)
+	assert.Len(t, recent, 2)
+	assert.Equal(t, "ok-job", recent[0].JobName)
+}
+
+func TestScheduler_GetJobExecutionsPageInMemory(t *testing.T) {
+	scheduler := NewScheduler(&MockAlertService{}, nil)
+	scheduler.AddJob("a", "A", time.Hour, func(ctx context.Context) error { return nil })
+	scheduler.AddJob("b", "B", time.Hour, func(ctx context.Context) error { return nil })
+
+	scheduler.mu.RLock()
+	jobA := scheduler.jobs["a"]
+	jobB := scheduler.jobs["b"]
+	scheduler.mu.RUnlock()
+
+	scheduler.runJob(jobA)
+	scheduler.runJob(jobB)
+	scheduler.runJob(jobA)
+
+	executions, total, err := scheduler.GetJobExecutionsPage(context.Background(), "a", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, executions, 1)
+	assert.Equal(t, "a", executions[0].JobName)
+
+	executions, total, err = scheduler.GetJobExecutionsPage(context.Background(), "", 10, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Empty(t, executions)
+}
+
+func TestScheduler_ExecutionCountsAndPruning(t *testing.T) {
+	ctx := context.Background()
+	scheduler := NewScheduler(&MockAlertService{}, nil)
+	scheduler.AddJob("ok-job", "Succeeds", time.Hour, func(ctx context.Context) error { return nil })
+	scheduler.AddJob("bad-job", "Fails", time.Hour, func(ctx context.Context) error { return errors.New("boom") })
+
+	scheduler.mu.RLock()
+	okJob := scheduler.jobs["ok-job"]
+	badJob := scheduler.jobs["bad-job"]
+	scheduler.mu.RUnlock()
+
+	scheduler.runJob(okJob)
+	scheduler.runJob(badJob)
+	scheduler.runJob(okJob)
+
+	// Counted from the in-memory history without a store
+	counts, err := scheduler.GetExecutionCounts(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []models.JobRunCount{
+		{JobName: "ok-job", Status: "completed", Count: 2},
+		{JobName: "bad-job", Status: "failed", Count: 1},
+	}, counts)
+
+	removed, err := scheduler.PruneExecutions(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, removed)
+
+	store := newMemoryExecutionStore()
+	assert.NoError(t, scheduler.SetExecutionStore(store))
+	scheduler.runJob(okJob)
+	store.runs[0].StartedAt = time.Now().Add(-100 * 24 * time.Hour)
+	scheduler.runJob(badJob)
+
+	counts, err = scheduler.GetExecutionCounts(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []models.JobRunCount{
+		{JobName: "ok-job", Status: "completed", Count: 1},
+		{JobName: "bad-job", Status: "failed", Count: 1},
+	}, counts)
+
+	removed, err = scheduler.PruneExecutions(ctx, time.Now().Add(-90*24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Len(t, store.runs, 1)
+	assert.Equal(t, "bad-job", store.runs[0].JobName)
+}
@@ -1,7 +1,6 @@
 package models
 
 import (
-	"fmt"
 	"strings"
 	"time"
 )
@@ -18,7 +17,7 @@ type Alert struct {
 }
 
 // ValidAlertTypes defines the allowed alert types
-var ValidAlertTypes = []string{"overdue", "reminder", "custom"}
+var ValidAlertTypes = []string{"overdue", "reminder", "custom", "hold", "damage"}
 
 // ValidateAlert validates an Alert struct
 func ValidateAlert(alert *Alert) error {
@@ -44,7 +43,7 @@ func ValidateAlert(alert *Alert) error {
 // validateAlertUserID validates the user ID field
 func validateAlertUserID(userID int) error {
 	if userID <= 0 {
-		return fmt.Errorf("user ID must be a positive integer")
+		return Invalid("user_id", "user_id_not_positive")
 	}
 	
 	return nil
@@ -53,7 +52,7 @@ func validateAlertUserID(userID int) error {
 // validateAlertGameID validates the game ID field
 func validateAlertGameID(gameID int) error {
 	if gameID <= 0 {
-		return fmt.Errorf("game ID must be a positive integer")
+		return Invalid("game_id", "game_id_not_positive")
 	}
 	
 	return nil
@@ -63,7 +62,7 @@ func validateAlertGameID(gameID int) error {
 func validateAlertType(alertType string) error {
 	alertType = strings.TrimSpace(alertType)
 	if alertType == "" {
-		return fmt.Errorf("alert type is required")
+		return Invalid("type", "alert_type_required")
 	}
 	
 	// Check if type is in valid types list
@@ -73,22 +72,22 @@ func validateAlertType(alertType string) error {
 		}
 	}
 	
-	return fmt.Errorf("invalid alert type: must be one of %v", ValidAlertTypes)
+	return Invalid("type", "invalid_alert_type", ValidAlertTypes)
 }
 
 // validateAlertMessage validates the alert message field
 func validateAlertMessage(message string) error {
 	message = strings.TrimSpace(message)
 	if message == "" {
-		return fmt.Errorf("alert message is required")
+		return Invalid("message", "alert_message_required")
 	}
 	
 	if len(message) < 5 {
-		return fmt.Errorf("alert message must be at least 5 characters long")
+		return Invalid("message", "alert_message_too_short")
 	}
 	
 	if len(message) > 500 {
-		return fmt.Errorf("alert message must be less than 500 characters")
+		return Invalid("message", "alert_message_too_long")
 	}
 	
 	return nil
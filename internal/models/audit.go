@@ -0,0 +1,137 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Audit log actions
+const (
+	AuditActionCreate      = "create"
+	AuditActionUpdate      = "update"
+	AuditActionDelete      = "delete"
+	AuditActionBorrow      = "borrow"
+	AuditActionReturn      = "return"
+	AuditActionExtend      = "extend"
+	AuditActionMarkRead    = "mark_read"
+	AuditActionCancel      = "cancel"
+	AuditActionExpire      = "expire"
+	AuditActionCleanup     = "cleanup"
+	AuditActionSetRole     = "set_role"
+	AuditActionSetPassword = "set_password"
+	AuditActionRevoke      = "revoke"
+	AuditActionMerge       = "merge"
+	AuditActionImport      = "import"
+	AuditActionPay         = "pay"
+	AuditActionWaive       = "waive"
+)
+
+// Audit log entity types
+const (
+	AuditEntityGame        = "game"
+	AuditEntityGameCopy    = "game_copy"
+	AuditEntityUser        = "user"
+	AuditEntityBorrowing   = "borrowing"
+	AuditEntityAlert       = "alert"
+	AuditEntityReservation = "reservation"
+	AuditEntityLoanPolicy  = "loan_policy"
+	AuditEntityAPIToken    = "api_token"
+	AuditEntityTag         = "tag"
+	AuditEntityFine        = "fine"
+)
+
+// AuditSystemActor names the author of changes made without a signed-in
+// user, such as background jobs or a server running without authentication
+const AuditSystemActor = "system"
+
+// Audit log page sizes
+const (
+	DefaultAuditLimit = 50
+	MaxAuditLimit     = 500
+)
+
+// AuditEvent is one entry of the append-only audit log: who changed what,
+// with the state of the entity before and after the change
+type AuditEvent struct {
+	ID         int             `json:"id" db:"id"`
+	ActorID    *int            `json:"actor_id" db:"actor_id"`     // nil for system changes
+	ActorName  string          `json:"actor_name" db:"actor_name"` // kept even if the user is deleted
+	Action     string          `json:"action" db:"action"`
+	EntityType string          `json:"entity_type" db:"entity_type"`
+	EntityID   string          `json:"entity_id" db:"entity_id"`
+	Before     json.RawMessage `json:"before,omitempty" db:"before_json" swaggertype:"object"` // nil for creations
+	After      json.RawMessage `json:"after,omitempty" db:"after_json" swaggertype:"object"`   // nil for deletions
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditFilter selects audit events; zero fields match everything
+type AuditFilter struct {
+	ActorID    *int
+	Action     string
+	EntityType string
+	EntityID   string
+	Since      *time.Time
+	Until      *time.Time
+	Limit      int
+	Offset     int
+}
+
+// NewAuditEvent builds an audit event attributed to actor, or to the system
+// when actor is nil. before and after are stored as JSON and may be nil.
+func NewAuditEvent(actor *User, action, entityType, entityID string, before, after any) (*AuditEvent, error) {
+	event := &AuditEvent{
+		ActorName:  AuditSystemActor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		CreatedAt:  time.Now(),
+	}
+
+	if actor != nil {
+		actorID := actor.ID
+		event.ActorID = &actorID
+		event.ActorName = actor.Name
+	}
+
+	var err error
+	if event.Before, err = marshalAuditState(before); err != nil {
+		return nil, err
+	}
+	if event.After, err = marshalAuditState(after); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// marshalAuditState encodes an entity snapshot, keeping nil as nil
+func marshalAuditState(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit state: %w", err)
+	}
+
+	return data, nil
+}
+
+// ValidateAuditEvent validates an AuditEvent struct
+func ValidateAuditEvent(event *AuditEvent) error {
+	if event.Action == "" {
+		return fmt.Errorf("audit action is required")
+	}
+
+	if event.EntityType == "" {
+		return fmt.Errorf("audit entity type is required")
+	}
+
+	if event.ActorName == "" {
+		return fmt.Errorf("audit actor name is required")
+	}
+
+	return nil
+}
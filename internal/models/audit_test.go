@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestNewAuditEvent(t *testing.T) {
+	actor := &User{ID: 3, Name: "Alice"}
+	game := &Game{ID: 7, Name: "Catan"}
+
+	event, err := NewAuditEvent(actor, AuditActionDelete, AuditEntityGame, "7", game, nil)
+	if err != nil {
+		t.Fatalf("NewAuditEvent() error = %v", err)
+	}
+
+	if event.ActorID == nil || *event.ActorID != 3 || event.ActorName != "Alice" {
+		t.Errorf("Expected actor Alice (3), got %v %q", event.ActorID, event.ActorName)
+	}
+	if len(event.Before) == 0 || event.After != nil {
+		t.Errorf("Expected only a before state, got before=%s after=%s", event.Before, event.After)
+	}
+	if err := ValidateAuditEvent(event); err != nil {
+		t.Errorf("ValidateAuditEvent() error = %v", err)
+	}
+
+	// Changes made without a signed-in user belong to the system
+	event, err = NewAuditEvent(nil, AuditActionCleanup, AuditEntityAlert, "", nil, nil)
+	if err != nil {
+		t.Fatalf("NewAuditEvent() error = %v", err)
+	}
+	if event.ActorID != nil || event.ActorName != AuditSystemActor {
+		t.Errorf("Expected system actor, got %v %q", event.ActorID, event.ActorName)
+	}
+}
+
+func TestValidateAuditEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   AuditEvent
+		wantErr bool
+	}{
+		{"valid event", AuditEvent{Action: "update", EntityType: "game", ActorName: "system"}, false},
+		{"missing action", AuditEvent{EntityType: "game", ActorName: "system"}, true},
+		{"missing entity type", AuditEvent{Action: "update", ActorName: "system"}, true},
+		{"missing actor", AuditEvent{Action: "update", EntityType: "game"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAuditEvent(&tt.event)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAuditEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Password length limits; bcrypt ignores anything past 72 bytes
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// Session is a signed-in web browser. Only a hash of the session token is
+// stored; the token itself lives in the user's cookie.
+type Session struct {
+	TokenHash string    `json:"-" db:"token_hash"`
+	UserID    int       `json:"user_id" db:"user_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+}
+
+// IsExpired reports whether the session can no longer be used
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// APIToken lets scripts call the API on behalf of a user. Only a hash of the
+// token is stored; the token is shown once, when it is created.
+type APIToken struct {
+	ID         int        `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at" db:"last_used_at"`
+}
+
+// ValidatePassword checks that a password is acceptable for a local account
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return Invalid("password", "password_too_short", MinPasswordLength)
+	}
+
+	if len(password) > MaxPasswordLength {
+		return Invalid("password", "password_too_long", MaxPasswordLength)
+	}
+
+	return nil
+}
+
+// ValidateAPIToken validates an APIToken struct
+func ValidateAPIToken(token *APIToken) error {
+	if token.UserID <= 0 {
+		return Invalid("user_id", "user_id_not_positive")
+	}
+
+	name := strings.TrimSpace(token.Name)
+	if name == "" {
+		return Invalid("name", "token_name_required")
+	}
+
+	if len(name) > 100 {
+		return Invalid("name", "token_name_too_long")
+	}
+
+	return nil
+}
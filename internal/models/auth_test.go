@@ -0,0 +1,78 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{"valid password", "correct horse", false},
+		{"minimum length", "12345678", false},
+		{"too short", "1234567", true},
+		{"too long", strings.Repeat("a", 73), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePassword(tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateAPIToken(t *testing.T) {
+	if err := ValidateAPIToken(&APIToken{UserID: 1, Name: "backup script"}); err != nil {
+		t.Errorf("ValidateAPIToken() unexpected error = %v", err)
+	}
+
+	if err := ValidateAPIToken(&APIToken{UserID: 1, Name: "  "}); err == nil || err.Error() != "token name is required" {
+		t.Errorf("ValidateAPIToken() error = %v, want token name is required", err)
+	}
+
+	if err := ValidateAPIToken(&APIToken{Name: "script"}); err == nil {
+		t.Error("ValidateAPIToken() expected error for missing user")
+	}
+}
+
+func TestSession_IsExpired(t *testing.T) {
+	now := time.Now()
+	session := &Session{ExpiresAt: now.Add(time.Hour)}
+
+	if session.IsExpired(now) {
+		t.Error("Expected session to be valid before its expiry")
+	}
+	if !session.IsExpired(now.Add(time.Hour)) {
+		t.Error("Expected session to be expired at its expiry")
+	}
+}
+
+func TestUser_HasRole(t *testing.T) {
+	tests := []struct {
+		role     string
+		required string
+		expected bool
+	}{
+		{RoleMember, RoleMember, true},
+		{RoleMember, RoleLibrarian, false},
+		{RoleLibrarian, RoleMember, true},
+		{RoleLibrarian, RoleAdmin, false},
+		{RoleAdmin, RoleLibrarian, true},
+		{"", RoleMember, false},
+		{RoleAdmin, "owner", false},
+	}
+
+	for _, tt := range tests {
+		user := &User{Role: tt.role}
+		if got := user.HasRole(tt.required); got != tt.expected {
+			t.Errorf("User{Role: %q}.HasRole(%q) = %v, want %v", tt.role, tt.required, got, tt.expected)
+		}
+	}
+}
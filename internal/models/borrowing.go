@@ -1,19 +1,18 @@
 package models
 
-import (
-	"fmt"
-	"time"
-)
+import "time"
 
 // Borrowing represents a game borrowing record
 type Borrowing struct {
-	ID         int        `json:"id" db:"id"`
-	UserID     int        `json:"user_id" db:"user_id"`
-	GameID     int        `json:"game_id" db:"game_id"`
-	BorrowedAt time.Time  `json:"borrowed_at" db:"borrowed_at"`
-	DueDate    time.Time  `json:"due_date" db:"due_date"`
-	ReturnedAt *time.Time `json:"returned_at" db:"returned_at"`
-	IsOverdue  bool       `json:"is_overdue" db:"is_overdue"`
+	ID             int        `json:"id" db:"id"`
+	UserID         int        `json:"user_id" db:"user_id"`
+	GameID         int        `json:"game_id" db:"game_id"`
+	CopyID         *int       `json:"copy_id" db:"copy_id"`
+	BorrowedAt     time.Time  `json:"borrowed_at" db:"borrowed_at"`
+	DueDate        time.Time  `json:"due_date" db:"due_date"`
+	ReturnedAt     *time.Time `json:"returned_at" db:"returned_at"`
+	IsOverdue      bool       `json:"is_overdue" db:"is_overdue"`
+	ExtensionCount int        `json:"extension_count" db:"extension_count"`
 }
 
 // ValidateBorrowing validates a Borrowing struct
@@ -36,7 +35,7 @@ func ValidateBorrowing(borrowing *Borrowing) error {
 // validateBorrowingUserID validates the user ID field
 func validateBorrowingUserID(userID int) error {
 	if userID <= 0 {
-		return fmt.Errorf("user ID must be a positive integer")
+		return Invalid("user_id", "user_id_not_positive")
 	}
 	
 	return nil
@@ -45,7 +44,7 @@ func validateBorrowingUserID(userID int) error {
 // validateBorrowingGameID validates the game ID field
 func validateBorrowingGameID(gameID int) error {
 	if gameID <= 0 {
-		return fmt.Errorf("game ID must be a positive integer")
+		return Invalid("game_id", "game_id_not_positive")
 	}
 	
 	return nil
@@ -55,19 +54,20 @@ func validateBorrowingGameID(gameID int) error {
 func validateBorrowingDates(borrowedAt, dueDate time.Time, returnedAt *time.Time) error {
 	// Check if due date is after borrowed date
 	if !dueDate.After(borrowedAt) {
-		return fmt.Errorf("due date must be after borrowed date")
+		return Invalid("due_date", "due_date_before_borrowed_date")
 	}
 	
-	// Check if due date is not too far in the future (max 90 days)
-	maxDuration := 90 * 24 * time.Hour
+	// Check if due date is not too far in the future; the borrower's loan
+	// policy usually sets a shorter limit
+	maxDuration := MaxLoanDays * 24 * time.Hour
 	if dueDate.Sub(borrowedAt) > maxDuration {
-		return fmt.Errorf("due date cannot be more than 90 days from borrowed date")
+		return Invalid("due_date", "due_date_too_far", MaxLoanDays)
 	}
 	
 	// If returned, check that return date is after borrowed date
 	if returnedAt != nil {
 		if !returnedAt.After(borrowedAt) {
-			return fmt.Errorf("return date must be after borrowed date")
+			return Invalid("returned_at", "return_date_before_borrowed_date")
 		}
 	}
 	
@@ -93,4 +93,19 @@ func (b *Borrowing) DaysOverdue() int {
 	
 	duration := time.Since(b.DueDate)
 	return int(duration.Hours() / 24)
+}
+
+// DaysLate returns the number of whole days between the due date and the
+// return of the item, or now when it is not returned yet (0 if not late)
+func (b *Borrowing) DaysLate() int {
+	end := time.Now()
+	if b.ReturnedAt != nil {
+		end = *b.ReturnedAt
+	}
+
+	if !end.After(b.DueDate) {
+		return 0
+	}
+
+	return int(end.Sub(b.DueDate).Hours() / 24)
 }
\ No newline at end of file
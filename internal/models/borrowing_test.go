@@ -92,10 +92,10 @@ func TestValidateBorrowing(t *testing.T) {
 				UserID:     1,
 				GameID:     1,
 				BorrowedAt: now,
-				DueDate:    now.Add(91 * 24 * time.Hour),
+				DueDate:    now.Add(366 * 24 * time.Hour),
 			},
 			wantErr: true,
-			errMsg:  "due date cannot be more than 90 days from borrowed date",
+			errMsg:  "due date cannot be more than 365 days from borrowed date",
 		},
 		{
 			name: "return date before borrowed date",
@@ -278,4 +278,30 @@ func TestDaysOverdue(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestDaysLate(t *testing.T) {
+	dueDate := time.Date(2024, 3, 15, 18, 0, 0, 0, time.UTC)
+	early := dueDate.Add(-2 * time.Hour)
+	sameDay := dueDate.Add(5 * time.Hour)
+	fourDaysLate := dueDate.Add(4*24*time.Hour + time.Hour)
+
+	tests := []struct {
+		name       string
+		returnedAt *time.Time
+		want       int
+	}{
+		{"returned early", &early, 0},
+		{"less than a day late", &sameDay, 0},
+		{"four days late", &fourDaysLate, 4},
+		{"not returned", nil, int(time.Since(dueDate).Hours() / 24)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			borrowing := &Borrowing{DueDate: dueDate, ReturnedAt: tt.returnedAt}
+			if got := borrowing.DaysLate(); got != tt.want {
+				t.Errorf("Borrowing.DaysLate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
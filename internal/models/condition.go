@@ -0,0 +1,76 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxConditionNotesLength is the longest notes a condition report may give
+const MaxConditionNotesLength = 1000
+
+// ConditionReport is the condition of a copy found at its return or at an
+// inspection, with optional notes on missing pieces or damage
+type ConditionReport struct {
+	Condition string `json:"condition"`
+	Notes     string `json:"notes"`
+}
+
+// ConditionChange is an entry of the condition history of a game. Changes
+// of the game itself have no copy, and changes outside a return no
+// borrowing.
+type ConditionChange struct {
+	ID                int       `json:"id" db:"id"`
+	GameID            int       `json:"game_id" db:"game_id"`
+	CopyID            *int      `json:"copy_id,omitempty" db:"copy_id"`
+	BorrowingID       *int      `json:"borrowing_id,omitempty" db:"borrowing_id"`
+	PreviousCondition string    `json:"previous_condition" db:"previous_condition"`
+	Condition         string    `json:"condition" db:"condition"`
+	Notes             string    `json:"notes" db:"notes"`
+	RecordedAt        time.Time `json:"recorded_at" db:"recorded_at"`
+}
+
+// IsDowngrade reports whether the change leaves the game in a worse
+// condition than before. Nothing is worse than an unknown condition.
+func (c *ConditionChange) IsDowngrade() bool {
+	previous := conditionRank(c.PreviousCondition)
+	return previous >= 0 && conditionRank(c.Condition) > previous
+}
+
+// ValidateConditionReport validates a ConditionReport struct
+func ValidateConditionReport(report *ConditionReport) error {
+	if err := validateGameCondition(report.Condition); err != nil {
+		return err
+	}
+
+	if len(report.Notes) > MaxConditionNotesLength {
+		return Invalid("notes", "condition_notes_too_long", MaxConditionNotesLength)
+	}
+
+	return nil
+}
+
+// NormalizeCondition returns the condition as listed in ValidConditions,
+// which validation matches regardless of case
+func NormalizeCondition(condition string) string {
+	return strings.ToLower(strings.TrimSpace(condition))
+}
+
+// NeedsRepair reports whether a game in the given condition must be kept
+// from lending until it is repaired
+func NeedsRepair(condition string) bool {
+	condition = NormalizeCondition(condition)
+	return condition == ConditionPoor || condition == ConditionMissingPieces
+}
+
+// conditionRank orders the conditions from best (0) to worst, and returns -1
+// for unknown conditions
+func conditionRank(condition string) int {
+	condition = NormalizeCondition(condition)
+	for i, valid := range ValidConditions {
+		if condition == valid {
+			return i
+		}
+	}
+
+	return -1
+}
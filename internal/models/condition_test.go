@@ -0,0 +1,75 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateConditionReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		report  ConditionReport
+		wantErr string
+	}{
+		{"condition only", ConditionReport{Condition: "fair"}, ""},
+		{"missing pieces with notes", ConditionReport{Condition: "missing_pieces", Notes: "Two blue meeples missing"}, ""},
+		{"condition in upper case", ConditionReport{Condition: "POOR"}, ""},
+		{"unknown condition", ConditionReport{Condition: "broken"}, "invalid game condition: must be one of [excellent good fair poor missing_pieces]"},
+		{"no condition", ConditionReport{Notes: "Box corner torn"}, "game condition is required"},
+		{"notes too long", ConditionReport{Condition: "poor", Notes: strings.Repeat("a", MaxConditionNotesLength+1)}, "condition notes must be less than 1000 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConditionReport(&tt.report)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateConditionReport() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ValidateConditionReport() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConditionChangeIsDowngrade(t *testing.T) {
+	tests := []struct {
+		previous, condition string
+		want                bool
+	}{
+		{"excellent", "good", true},
+		{"good", "missing_pieces", true},
+		{"fair", "Poor", true},
+		{"poor", "good", false},
+		{"good", "good", false},
+		{"", "poor", false},
+		{"worn", "poor", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.previous+"->"+tt.condition, func(t *testing.T) {
+			change := &ConditionChange{PreviousCondition: tt.previous, Condition: tt.condition}
+			if got := change.IsDowngrade(); got != tt.want {
+				t.Errorf("IsDowngrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsRepair(t *testing.T) {
+	for condition, want := range map[string]bool{
+		"excellent":      false,
+		"good":           false,
+		"fair":           false,
+		"poor":           true,
+		" Poor ":         true,
+		"missing_pieces": true,
+	} {
+		if got := NeedsRepair(condition); got != want {
+			t.Errorf("NeedsRepair(%q) = %v, want %v", condition, got, want)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package models
+
+// errorMessage holds the message of an error code in each language, as a
+// format completed by the Args of the Error
+type errorMessage struct {
+	English string
+	French  string
+}
+
+// errorMessages holds the messages of the error codes. Codes are part of the
+// API: clients may rely on them, so they are never renamed.
+var errorMessages = map[string]errorMessage{
+	// Requests
+	"invalid_request":              {"invalid request: %s", "requête invalide : %s"},
+	"request_body_too_large":       {"request body too large", "corps de la requête trop volumineux"},
+	"request_timeout":              {"the request took too long, try again later", "la requête a pris trop de temps, réessayez plus tard"},
+	"internal_error":               {"an unexpected error occurred", "une erreur inattendue est survenue"},
+	"field_required":               {"%s is required", "%s est obligatoire"},
+	"field_invalid":                {"%s fails the %s rule", "%s ne respecte pas la règle %s"},
+	"field_type":                   {"%s must be a %s", "%s doit être de type %s"},
+	"invalid_integer":              {"%s must be an integer", "%s doit être un entier"},
+	"invalid_positive_integer":     {"%s must be a positive integer", "%s doit être un entier positif"},
+	"invalid_non_negative_integer": {"%s must be a non-negative integer", "%s doit être un entier positif ou nul"},
+	"invalid_number":               {"%s must be a number", "%s doit être un nombre"},
+	"invalid_boolean":              {"%s must be true or false", "%s doit valoir true ou false"},
+	"invalid_date":                 {"invalid %s date %q: use YYYY-MM-DD or RFC 3339", "date %s %q invalide : utilisez AAAA-MM-JJ ou RFC 3339"},
+	"invalid_date_format":          {"%s must be a date in format YYYY-MM-DD", "%s doit être une date au format AAAA-MM-JJ"},
+
+	// Identifiers
+	"invalid_user_id":        {"invalid user ID %d: must be a positive integer", "identifiant d'utilisateur %d invalide : doit être un entier positif"},
+	"invalid_game_id":        {"invalid game ID %d: must be a positive integer", "identifiant de jeu %d invalide : doit être un entier positif"},
+	"invalid_alert_id":       {"invalid alert ID %d: must be a positive integer", "identifiant d'alerte %d invalide : doit être un entier positif"},
+	"invalid_copy_id":        {"invalid copy ID %d: must be a positive integer", "identifiant d'exemplaire %d invalide : doit être un entier positif"},
+	"invalid_borrowing_id":   {"invalid borrowing ID %d: must be a positive integer", "identifiant d'emprunt %d invalide : doit être un entier positif"},
+	"invalid_reservation_id": {"invalid reservation ID %d: must be a positive integer", "identifiant de réservation %d invalide : doit être un entier positif"},
+	"invalid_tag_id":         {"invalid tag ID %d: must be a positive integer", "identifiant d'étiquette %d invalide : doit être un entier positif"},
+	"user_id_not_positive":   {"user ID must be a positive integer", "l'identifiant d'utilisateur doit être un entier positif"},
+	"game_id_not_positive":   {"game ID must be a positive integer", "l'identifiant de jeu doit être un entier positif"},
+
+	// Missing records
+	"user_not_found":           {"user with id %d not found", "utilisateur %d introuvable"},
+	"user_email_not_found":     {"user with email %s not found", "aucun utilisateur avec l'email %s"},
+	"game_not_found":           {"game with id %d not found", "jeu %d introuvable"},
+	"copy_not_found":           {"game copy with id %d not found", "exemplaire %d introuvable"},
+	"copy_barcode_not_found":   {"game copy with barcode %q not found", "aucun exemplaire avec le code-barres %q"},
+	"copy_not_in_game":         {"copy %d does not belong to game %d", "l'exemplaire %d n'appartient pas au jeu %d"},
+	"borrowing_not_found":      {"borrowing with id %d not found", "emprunt %d introuvable"},
+	"open_borrowing_not_found": {"borrowing with id %d not found or already returned", "emprunt %d introuvable ou déjà rendu"},
+	"alert_not_found":          {"alert with id %d not found", "alerte %d introuvable"},
+	"notification_not_found":   {"notification for alert %d not found", "notification de l'alerte %d introuvable"},
+	"reservation_not_found":    {"reservation with id %d not found", "réservation %d introuvable"},
+	"tag_not_found":            {"tag with id %d not found", "étiquette %d introuvable"},
+	"tag_slug_not_found":       {"tag %q not found", "étiquette %q introuvable"},
+	"library_not_found":        {"library with id %d not found", "bibliothèque %d introuvable"},
+	"library_slug_not_found":   {"library %q not found", "bibliothèque %q introuvable"},
+	"loan_policy_not_found":    {"loan policy for tier %q not found", "politique de prêt du niveau %q introuvable"},
+	"job_run_not_found":        {"job run with id %d not found", "exécution de tâche %d introuvable"},
+	"api_token_not_found":      {"API token with id %d not found", "jeton d'API %d introuvable"},
+	"unknown_api_token":        {"API token not found", "jeton d'API introuvable"},
+	"unknown_session":          {"session not found", "session introuvable"},
+
+	// Conflicts
+	"user_email_taken":             {"user with email %s already exists", "un utilisateur avec l'email %s existe déjà"},
+	"copy_barcode_taken":           {"a copy with barcode %q already exists", "un exemplaire avec le code-barres %q existe déjà"},
+	"tag_slug_taken":               {"tag %q already exists", "l'étiquette %q existe déjà"},
+	"library_slug_taken":           {"library %q already exists", "la bibliothèque %q existe déjà"},
+	"loan_policy_exists":           {"loan policy for tier %q already exists", "la politique de prêt du niveau %q existe déjà"},
+	"setup_completed":              {"setup has already been completed", "l'installation a déjà été effectuée"},
+	"last_administrator":           {"cannot remove the last administrator", "impossible de retirer le dernier administrateur"},
+	"game_not_available":           {"game is not available for borrowing", "le jeu n'est pas disponible à l'emprunt"},
+	"copy_not_available":           {"copy is not available for borrowing", "l'exemplaire n'est pas disponible à l'emprunt"},
+	"copy_in_repair":               {"copy is kept from lending until it is repaired", "l'exemplaire est retiré du prêt jusqu'à sa réparation"},
+	"already_returned":             {"game has already been returned", "le jeu a déjà été rendu"},
+	"returned_borrowing_extension": {"cannot extend due date for returned item", "impossible de prolonger un emprunt déjà rendu"},
+	"copy_borrowed":                {"cannot remove copy: currently borrowed", "impossible de retirer l'exemplaire : il est emprunté"},
+	"last_copy":                    {"cannot remove copy: it is the last copy of the game", "impossible de retirer l'exemplaire : c'est le dernier du jeu"},
+	"copy_has_history":             {"cannot remove copy: it has borrowing history", "impossible de retirer l'exemplaire : il a un historique d'emprunts"},
+	"game_borrowed":                {"cannot delete game: currently borrowed by user %d", "impossible de supprimer le jeu : il est emprunté par l'utilisateur %d"},
+	"game_has_history": {
+		"cannot delete game '%s': it has borrowing history (%d records). Games with borrowing history cannot be deleted to maintain data integrity",
+		"impossible de supprimer le jeu « %s » : il a un historique d'emprunts (%d enregistrements). Les jeux ayant un historique d'emprunts ne peuvent pas être supprimés afin de préserver l'intégrité des données",
+	},
+	"game_has_records": {
+		"cannot delete game '%s': it has associated records (borrowings or alerts) that prevent deletion",
+		"impossible de supprimer le jeu « %s » : des enregistrements associés (emprunts ou alertes) empêchent sa suppression",
+	},
+	"user_has_active_borrowings": {"cannot delete user: has %d active borrowing(s)", "impossible de supprimer l'utilisateur : %d emprunt(s) en cours"},
+	"user_has_history": {
+		"cannot delete user '%s': they have borrowing history (%d records). Users with borrowing history cannot be deleted to maintain data integrity",
+		"impossible de supprimer l'utilisateur « %s » : il a un historique d'emprunts (%d enregistrements). Les utilisateurs ayant un historique d'emprunts ne peuvent pas être supprimés afin de préserver l'intégrité des données",
+	},
+	"game_available":          {"game is available for borrowing, no hold needed", "le jeu est disponible à l'emprunt, aucune réservation n'est nécessaire"},
+	"already_borrowing":       {"user is already borrowing this game", "l'utilisateur emprunte déjà ce jeu"},
+	"already_on_hold":         {"user already has an active hold on this game", "l'utilisateur a déjà une réservation active sur ce jeu"},
+	"reservation_not_active":  {"reservation is not active", "la réservation n'est pas active"},
+	"borrowing_already_fined": {"borrowing %d has already been fined", "l'emprunt %d a déjà donné lieu à une amende"},
+
+	// Loan policy
+	PolicyRuleAccountInactive: {"user account is inactive", "le compte de l'utilisateur est inactif"},
+	PolicyRuleOverdueItems:    {"user has overdue items and cannot borrow", "l'utilisateur a des emprunts en retard et ne peut pas emprunter"},
+	PolicyRuleMaxLoans:        {"user has reached the limit of %d concurrent loan(s) for the %s tier", "l'utilisateur a atteint la limite de %d prêt(s) simultané(s) du niveau %s"},
+	PolicyRuleMaxLoanDays:     {"due date cannot be more than %d days from borrowed date", "la date de retour ne peut pas dépasser %d jours après la date d'emprunt"},
+	PolicyRuleMaxExtensions:   {"borrowing has reached the limit of %d extension(s) for the %s tier", "l'emprunt a atteint la limite de %d prolongation(s) du niveau %s"},
+	PolicyRuleFineBalance:     {"user owes %d cents in fines, more than the %s tier allows", "l'utilisateur doit %d centimes d'amendes, plus que le niveau %s ne le permet"},
+
+	// Authentication
+	"authentication_required":      {"authentication required", "authentification requise"},
+	"invalid_credentials":          {"invalid email or password", "email ou mot de passe incorrect"},
+	"invalid_session":              {"invalid session", "session invalide"},
+	"session_expired":              {"session expired", "session expirée"},
+	"invalid_api_token":            {"invalid API token", "jeton d'API invalide"},
+	"current_password_incorrect":   {"current password is incorrect", "le mot de passe actuel est incorrect"},
+	"invalid_authorization_scheme": {"Authorization header must use the Bearer scheme", "l'en-tête Authorization doit utiliser le schéma Bearer"},
+	"insufficient_role":            {"your role does not allow this operation", "votre rôle ne permet pas cette opération"},
+	"password_too_short":           {"password must be at least %d characters long", "le mot de passe doit contenir au moins %d caractères"},
+	"password_too_long":            {"password must be at most %d bytes long", "le mot de passe doit faire au plus %d octets"},
+	"token_name_required":          {"token name is required", "le nom du jeton est obligatoire"},
+	"token_name_too_long":          {"token name must be less than 100 characters", "le nom du jeton doit faire moins de 100 caractères"},
+
+	// Users
+	"user_name_required":      {"name is required", "le nom est obligatoire"},
+	"user_name_too_short":     {"name must be at least 2 characters long", "le nom doit contenir au moins 2 caractères"},
+	"user_name_too_long":      {"name must be less than 100 characters", "le nom doit faire moins de 100 caractères"},
+	"email_required":          {"email is required", "l'email est obligatoire"},
+	"email_too_long":          {"email must be less than 255 characters", "l'email doit faire moins de 255 caractères"},
+	"invalid_email":           {"invalid email format", "format d'email invalide"},
+	"invalid_role":            {"invalid role: must be one of %v", "rôle invalide : doit être l'un de %v"},
+	"unknown_membership_tier": {"unknown membership tier: %s", "niveau d'adhésion inconnu : %s"},
+	"invalid_language":        {"invalid language: must be one of %v", "langue invalide : doit être l'une de %v"},
+
+	// Games and copies
+	"game_name_required":        {"game name is required", "le nom du jeu est obligatoire"},
+	"game_name_too_short":       {"game name must be at least 2 characters long", "le nom du jeu doit contenir au moins 2 caractères"},
+	"game_name_too_long":        {"game name must be less than 200 characters", "le nom du jeu doit faire moins de 200 caractères"},
+	"game_description_too_long": {"game description must be less than 1000 characters", "la description du jeu doit faire moins de 1000 caractères"},
+	"game_condition_required":   {"game condition is required", "l'état du jeu est obligatoire"},
+	"invalid_game_condition":    {"invalid game condition: must be one of %v", "état du jeu invalide : doit être l'un de %v"},
+	"invalid_min_players":       {"min players must be between 0 and %d", "le nombre minimum de joueurs doit être compris entre 0 et %d"},
+	"invalid_max_players":       {"max players must be between 0 and %d", "le nombre maximum de joueurs doit être compris entre 0 et %d"},
+	"min_players_exceed_max":    {"min players cannot exceed max players", "le nombre minimum de joueurs ne peut pas dépasser le maximum"},
+	"invalid_play_time":         {"play time must be between 0 and %d minutes", "la durée de partie doit être comprise entre 0 et %d minutes"},
+	"invalid_min_age":           {"min age must be between 0 and %d", "l'âge minimum doit être compris entre 0 et %d"},
+	"publisher_too_long":        {"game publisher must be less than 200 characters", "l'éditeur du jeu doit faire moins de 200 caractères"},
+	"too_many_designers":        {"a game can have at most %d designers", "un jeu peut avoir au plus %d auteurs"},
+	"designer_name_empty":       {"designer names cannot be empty", "les noms d'auteurs ne peuvent pas être vides"},
+	"designer_name_too_long":    {"designer names must be less than 100 characters", "les noms d'auteurs doivent faire moins de 100 caractères"},
+	"invalid_year_published":    {"year published must be between %d and %d", "l'année de publication doit être comprise entre %d et %d"},
+	"invalid_complexity":        {"complexity must be between %.0f and %.0f", "la complexité doit être comprise entre %.0f et %.0f"},
+	"image_url_too_long":        {"image URL must be less than %d characters", "l'URL de l'image doit faire moins de %d caractères"},
+	"invalid_image_url":         {"image URL must start with http:// or https://", "l'URL de l'image doit commencer par http:// ou https://"},
+	"negative_bgg_id":           {"BoardGameGeek ID cannot be negative", "l'identifiant BoardGameGeek ne peut pas être négatif"},
+	"copy_barcode_spaces":       {"copy barcode must not start or end with spaces", "le code-barres ne doit ni commencer ni finir par des espaces"},
+	"copy_barcode_too_long":     {"copy barcode must be less than 64 characters", "le code-barres doit faire moins de 64 caractères"},
+
+	// BoardGameGeek
+	"bgg_disabled":          {"BoardGameGeek import is not enabled", "l'import depuis BoardGameGeek n'est pas activé"},
+	"bgg_busy":              {"BoardGameGeek is busy, try again later", "BoardGameGeek est surchargé, réessayez plus tard"},
+	"bgg_lookup_failed":     {"BoardGameGeek lookup failed", "la recherche sur BoardGameGeek a échoué"},
+	"bgg_game_not_found":    {"game %d not found on BoardGameGeek", "jeu %d introuvable sur BoardGameGeek"},
+	"invalid_bgg_id":        {"invalid BoardGameGeek ID: %d", "identifiant BoardGameGeek invalide : %d"},
+	"search_query_required": {"search query is required", "la recherche est obligatoire"},
+
+	// Borrowings
+	"due_date_before_borrowed_date":     {"due date must be after borrowed date", "la date de retour doit être postérieure à la date d'emprunt"},
+	"new_due_date_before_borrowed_date": {"new due date must be after borrowed date", "la nouvelle date de retour doit être postérieure à la date d'emprunt"},
+	"due_date_too_far":                  {"due date cannot be more than %d days from borrowed date", "la date de retour ne peut pas dépasser %d jours après la date d'emprunt"},
+	"return_date_before_borrowed_date":  {"return date must be after borrowed date", "la date de rendu doit être postérieure à la date d'emprunt"},
+	"invalid_days_ahead":                {"days ahead must be non-negative", "le nombre de jours ne peut pas être négatif"},
+
+	// Alerts and notifications
+	"alert_type_required":     {"alert type is required", "le type d'alerte est obligatoire"},
+	"invalid_alert_type":      {"invalid alert type: must be one of %v", "type d'alerte invalide : doit être l'un de %v"},
+	"alert_message_required":  {"alert message is required", "le message de l'alerte est obligatoire"},
+	"alert_message_too_short": {"alert message must be at least 5 characters long", "le message de l'alerte doit contenir au moins 5 caractères"},
+	"alert_message_too_long":  {"alert message must be less than 500 characters", "le message de l'alerte doit faire moins de 500 caractères"},
+
+	// Reservations
+	"invalid_reservation_status":       {"invalid reservation status: must be one of %v", "statut de réservation invalide : doit être l'un de %v"},
+	"ready_reservation_without_copy":   {"a ready reservation must hold a copy", "une réservation prête doit retenir un exemplaire"},
+	"ready_reservation_without_expiry": {"a ready reservation must have an expiry date", "une réservation prête doit avoir une date d'expiration"},
+
+	// Tags
+	"tag_name_required":      {"tag name is required", "le nom de l'étiquette est obligatoire"},
+	"tag_name_too_long":      {"tag name must be less than %d characters", "le nom de l'étiquette doit faire moins de %d caractères"},
+	"invalid_tag_name":       {"tag name must contain a letter or a digit", "le nom de l'étiquette doit contenir une lettre ou un chiffre"},
+	"too_many_tags":          {"a game can have at most %d tags", "un jeu peut avoir au plus %d étiquettes"},
+	"tag_merged_into_itself": {"cannot merge a tag into itself", "impossible de fusionner une étiquette avec elle-même"},
+
+	// Libraries and loan policies
+	"library_name_required":     {"library name is required", "le nom de la bibliothèque est obligatoire"},
+	"library_name_too_long":     {"library name must be less than %d characters", "le nom de la bibliothèque doit faire moins de %d caractères"},
+	"invalid_library_slug":      {"library slug must be 1 to 63 lowercase letters, digits or inner dashes", "l'identifiant de la bibliothèque doit compter 1 à 63 lettres minuscules, chiffres ou tirets intérieurs"},
+	"tier_required":             {"membership tier cannot be empty", "le niveau d'adhésion est obligatoire"},
+	"invalid_tier":              {"tier must be 1 to 32 lowercase letters, digits, '-' or '_', starting with a letter", "le niveau doit compter 1 à 32 lettres minuscules, chiffres, « - » ou « _ », en commençant par une lettre"},
+	"invalid_max_loans":         {"max loans must be at least 1", "le nombre maximum de prêts doit être d'au moins 1"},
+	"invalid_max_loan_days":     {"max loan days must be between 1 and %d", "la durée maximale de prêt doit être comprise entre 1 et %d jours"},
+	"invalid_default_loan_days": {"default loan days must be between 1 and max loan days", "la durée de prêt par défaut doit être comprise entre 1 jour et la durée maximale"},
+	"invalid_max_extensions":    {"max extensions cannot be negative", "le nombre maximum de prolongations ne peut pas être négatif"},
+	"invalid_fine_per_day":      {"fine per day cannot be negative", "l'amende par jour ne peut pas être négative"},
+	"invalid_fine_grace_days":   {"fine grace days cannot be negative", "le délai de grâce ne peut pas être négatif"},
+	"invalid_fine_cap":          {"fine cap cannot be negative", "le plafond des amendes ne peut pas être négatif"},
+	"invalid_max_fine_balance":  {"max fine balance cannot be negative", "le solde d'amendes maximum ne peut pas être négatif"},
+
+	// Fines
+	"invalid_fine_kind":      {"invalid fine entry kind: must be one of %v", "type d'opération invalide : doit être l'un de %v"},
+	"invalid_fine_amount":    {"amount must be a positive number of cents", "le montant doit être un nombre positif de centimes"},
+	"fine_reason_required":   {"a reason is required", "un motif est obligatoire"},
+	"fine_reason_too_long":   {"reason must be less than %d characters", "le motif doit faire moins de %d caractères"},
+	"amount_exceeds_balance": {"amount of %d cents exceeds the balance of %d cents", "le montant de %d centimes dépasse le solde de %d centimes"},
+
+	// Condition tracking
+	"condition_notes_too_long": {"condition notes must be less than %d characters", "les remarques sur l'état doivent faire moins de %d caractères"},
+
+	// Lists and filters
+	"invalid_sort_field":       {"invalid sort field: must be one of %v", "champ de tri invalide : doit être l'un de %v"},
+	"invalid_sort_order":       {"invalid sort order: must be %s or %s", "ordre de tri invalide : doit être %s ou %s"},
+	"invalid_condition":        {"invalid condition: must be one of %v", "état invalide : doit être l'un de %v"},
+	"too_many_filter_tags":     {"cannot filter on more than %d tags", "impossible de filtrer sur plus de %d étiquettes"},
+	"negative_game_filter":     {"players, max play time and age cannot be negative", "le nombre de joueurs, la durée maximale et l'âge ne peuvent pas être négatifs"},
+	"invalid_year_range":       {"year from cannot be after year to", "l'année de début ne peut pas être postérieure à l'année de fin"},
+	"invalid_complexity_range": {"min complexity cannot exceed max complexity", "la complexité minimale ne peut pas dépasser la complexité maximale"},
+	"invalid_filter_ids":       {"user and game IDs must be positive", "les identifiants d'utilisateur et de jeu doivent être positifs"},
+	"invalid_borrowing_status": {"invalid status: must be one of %v", "statut invalide : doit être l'un de %v"},
+	"invalid_alert_status":     {"status must be one of %s, %s or %s", "le statut doit être %s, %s ou %s"},
+
+	// Bulk transfers
+	"invalid_transfer_table":  {"invalid table %q: must be one of %v", "table %q invalide : doit être l'une de %v"},
+	"invalid_transfer_format": {"invalid format %q: must be one of %v", "format %q invalide : doit être l'un de %v"},
+	"import_file_required":    {"the file field is required", "le champ file est obligatoire"},
+	"import_format_required":  {"format is required: add ?format=csv or ?format=json", "le format est obligatoire : ajoutez ?format=csv ou ?format=json"},
+	"unreadable_import_file":  {"cannot read the import file: %v", "impossible de lire le fichier importé : %v"},
+	"import_rejected":         {"%d of %d rows are invalid, nothing was imported", "%d lignes sur %d sont invalides, rien n'a été importé"},
+	"invalid_import_row":      {"line %d: %s", "ligne %d : %s"},
+
+	// Background jobs
+	"job_not_found":             {"job '%s' not found", "tâche « %s » introuvable"},
+	"job_disabled":              {"job '%s' is disabled", "la tâche « %s » est désactivée"},
+	"job_running":               {"job '%s' is already running", "la tâche « %s » est déjà en cours"},
+	"scheduler_stopped":         {"the job scheduler is stopped", "le planificateur de tâches est arrêté"},
+	"schedule_required":         {"invalid schedule: schedule is empty", "planification invalide : la planification est vide"},
+	"invalid_schedule":          {"invalid schedule %q: %v", "planification %q invalide : %v"},
+	"invalid_schedule_interval": {"invalid schedule %q: interval must be positive", "planification %q invalide : l'intervalle doit être positif"},
+
+	// Backups
+	"backup_unsupported": {"online backups are only supported on SQLite: back up PostgreSQL databases with pg_dump", "les sauvegardes en ligne ne sont possibles qu'avec SQLite : sauvegardez PostgreSQL avec pg_dump"},
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kinds of the errors a caller can act on. The API answers each kind with
+// its own HTTP status; any other error is a failure of the server.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrValidation      = errors.New("validation failed")
+	ErrPolicyViolation = errors.New("policy violation")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrForbidden       = errors.New("forbidden")
+	ErrUnavailable     = errors.New("unavailable")
+	ErrUpstream        = errors.New("upstream failure")
+)
+
+// Error is an error of one of the kinds above. Code identifies it for API
+// clients and selects its message in errorMessages, which Args complete.
+// errors.Is reports whether an Error is of a kind.
+type Error struct {
+	Kind  error
+	Code  string
+	Field string // the JSON field at fault, for validation errors
+	Args  []interface{}
+	Err   error // the cause, if any
+}
+
+// NotFound returns an error about a missing resource
+func NotFound(code string, args ...interface{}) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Args: args}
+}
+
+// Conflict returns an error about a change the state of the library does
+// not allow, such as a duplicate or a game still lent
+func Conflict(code string, args ...interface{}) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Args: args}
+}
+
+// Invalid returns a validation error about field
+func Invalid(field, code string, args ...interface{}) *Error {
+	return &Error{Kind: ErrValidation, Code: code, Field: field, Args: args}
+}
+
+// PolicyViolation returns an error about a loan policy rule, such as the
+// limit of concurrent loans
+func PolicyViolation(code string, args ...interface{}) *Error {
+	return &Error{Kind: ErrPolicyViolation, Code: code, Args: args}
+}
+
+// Unauthorized returns an error about missing or wrong credentials
+func Unauthorized(code string, args ...interface{}) *Error {
+	return &Error{Kind: ErrUnauthorized, Code: code, Args: args}
+}
+
+// Forbidden returns an error about an action the caller may not take
+func Forbidden(code string, args ...interface{}) *Error {
+	return &Error{Kind: ErrForbidden, Code: code, Args: args}
+}
+
+// Unavailable returns an error about a feature that is turned off or a
+// service that cannot answer for now
+func Unavailable(code string, args ...interface{}) *Error {
+	return &Error{Kind: ErrUnavailable, Code: code, Args: args}
+}
+
+// Upstream returns an error about a failure of a service the library relies
+// on, such as BoardGameGeek
+func Upstream(code string, args ...interface{}) *Error {
+	return &Error{Kind: ErrUpstream, Code: code, Args: args}
+}
+
+// Error returns the English message of the error
+func (e *Error) Error() string {
+	return e.Message(LanguageEnglish)
+}
+
+// Message returns the message of the error in language, or in English when
+// it has no translation
+func (e *Error) Message(language string) string {
+	messages, ok := errorMessages[e.Code]
+	if !ok {
+		return strings.TrimSpace(fmt.Sprintln(append([]interface{}{e.Code}, e.Args...)...))
+	}
+
+	format := messages.English
+	if language == LanguageFrench && messages.French != "" {
+		format = messages.French
+	}
+	return fmt.Sprintf(format, e.Args...)
+}
+
+// Wrap sets the cause of the error and returns the error
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// Unwrap returns the cause of the error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the kind of the error
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// AsError returns the first Error in the chain of err
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+// ValidationErrors holds several validation errors, such as one for each
+// field of a request at fault
+type ValidationErrors []*Error
+
+// Error returns the English messages of the errors
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Is reports whether target is ErrValidation
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
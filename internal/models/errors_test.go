@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestErrorMessage(t *testing.T) {
+	err := NotFound("user_not_found", 42)
+
+	if got := err.Error(); got != "user with id 42 not found" {
+		t.Errorf("Error() = %q, want the English message", got)
+	}
+	if got := err.Message(LanguageFrench); got != "utilisateur 42 introuvable" {
+		t.Errorf("Message(fr) = %q, want the French message", got)
+	}
+	if got := err.Message("de"); got != err.Error() {
+		t.Errorf("Message(de) = %q, want the English message", got)
+	}
+
+	unknown := Conflict("no_such_code", 1)
+	if got := unknown.Error(); got != "no_such_code 1" {
+		t.Errorf("Error() for an unknown code = %q, want the code and its arguments", got)
+	}
+}
+
+func TestErrorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *Error
+		kind error
+	}{
+		{"not found", NotFound("game_not_found", 1), ErrNotFound},
+		{"conflict", Conflict("game_not_available"), ErrConflict},
+		{"validation", Invalid("name", "user_name_required"), ErrValidation},
+		{"policy violation", PolicyViolation(PolicyRuleOverdueItems), ErrPolicyViolation},
+		{"unauthorized", Unauthorized("session_expired"), ErrUnauthorized},
+		{"forbidden", Forbidden("insufficient_role"), ErrForbidden},
+		{"unavailable", Unavailable("bgg_disabled"), ErrUnavailable},
+		{"upstream", Upstream("bgg_lookup_failed"), ErrUpstream},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("failed to do something: %w", tt.err)
+			if !errors.Is(wrapped, tt.kind) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", wrapped, tt.kind)
+			}
+			if errors.Is(wrapped, ErrUpstream) != (tt.kind == ErrUpstream) {
+				t.Errorf("errors.Is(%v, ErrUpstream) matched another kind", wrapped)
+			}
+
+			domainErr, ok := AsError(wrapped)
+			if !ok || domainErr != tt.err {
+				t.Errorf("AsError() = %v, %v, want the wrapped error", domainErr, ok)
+			}
+		})
+	}
+
+	if _, ok := AsError(errors.New("database is locked")); ok {
+		t.Error("AsError() found an Error in a plain error")
+	}
+}
+
+func TestErrorWrap(t *testing.T) {
+	cause := errors.New("unexpected EOF")
+	err := Invalid("file", "unreadable_import_file", cause).Wrap(cause)
+
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is() did not find the cause of the error")
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Error("errors.Is() lost the kind of a wrapped error")
+	}
+	if got := err.Error(); got != "cannot read the import file: unexpected EOF" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	err := fmt.Errorf("validation failed: %w", ValidationErrors{
+		Invalid("name", "field_required", "name"),
+		Invalid("email", "field_required", "email"),
+	})
+
+	if !errors.Is(err, ErrValidation) {
+		t.Error("errors.Is(ValidationErrors, ErrValidation) = false, want true")
+	}
+	if got := err.Error(); got != "validation failed: name is required; email is required" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+// formatVerb matches the verbs of a message format, without the escaped %%
+var formatVerb = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+func TestErrorMessagesTranslated(t *testing.T) {
+	for code, messages := range errorMessages {
+		if messages.English == "" || messages.French == "" {
+			t.Errorf("%s: missing English or French message", code)
+			continue
+		}
+
+		english := formatVerb.FindAllString(messages.English, -1)
+		french := formatVerb.FindAllString(messages.French, -1)
+		if !slices.Equal(english, french) {
+			t.Errorf("%s: French verbs %v differ from English verbs %v", code, french, english)
+		}
+	}
+}
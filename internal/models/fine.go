@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Kinds of fines ledger entries. Fines are charged for late returns, and
+// payments and waivers settle them.
+const (
+	FineKindFine    = "fine"
+	FineKindPayment = "payment"
+	FineKindWaiver  = "waiver"
+)
+
+// ValidFineKinds lists the kinds of fines ledger entries
+var ValidFineKinds = []string{FineKindFine, FineKindPayment, FineKindWaiver}
+
+// MaxFineReasonLength is the longest reason an entry may give
+const MaxFineReasonLength = 500
+
+// FineEntry is an entry of the fines ledger of a user. Entries are never
+// changed once recorded.
+type FineEntry struct {
+	ID          int       `json:"id" db:"id"`
+	UserID      int       `json:"user_id" db:"user_id"`
+	BorrowingID *int      `json:"borrowing_id,omitempty" db:"borrowing_id"` // the late borrowing of a fine
+	Kind        string    `json:"kind" db:"kind"`
+	AmountCents int       `json:"amount_cents" db:"amount_cents"`
+	Reason      string    `json:"reason" db:"reason"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// FineAccount is the fines ledger of a user, newest entries first, with
+// the amount they owe
+type FineAccount struct {
+	UserID       int          `json:"user_id"`
+	BalanceCents int          `json:"balance_cents"`
+	Entries      []*FineEntry `json:"entries"`
+}
+
+// ValidateFineEntry validates a FineEntry struct. Payments and waivers must
+// give a reason.
+func ValidateFineEntry(entry *FineEntry) error {
+	if entry.UserID <= 0 {
+		return Invalid("user_id", "user_id_not_positive")
+	}
+
+	if !IsValidFineKind(entry.Kind) {
+		return Invalid("kind", "invalid_fine_kind", ValidFineKinds)
+	}
+
+	if entry.AmountCents <= 0 {
+		return Invalid("amount_cents", "invalid_fine_amount")
+	}
+
+	if entry.Kind != FineKindFine && entry.Reason == "" {
+		return Invalid("reason", "fine_reason_required")
+	}
+
+	if len(entry.Reason) > MaxFineReasonLength {
+		return Invalid("reason", "fine_reason_too_long", MaxFineReasonLength)
+	}
+
+	return nil
+}
+
+// IsValidFineKind reports whether kind is a kind of fines ledger entry
+func IsValidFineKind(kind string) bool {
+	for _, valid := range ValidFineKinds {
+		if kind == valid {
+			return true
+		}
+	}
+
+	return false
+}
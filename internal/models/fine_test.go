@@ -0,0 +1,37 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateFineEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   FineEntry
+		wantErr string
+	}{
+		{"fine without reason", FineEntry{UserID: 1, Kind: FineKindFine, AmountCents: 150}, ""},
+		{"payment", FineEntry{UserID: 1, Kind: FineKindPayment, AmountCents: 100, Reason: "Paid in cash"}, ""},
+		{"waiver without reason", FineEntry{UserID: 1, Kind: FineKindWaiver, AmountCents: 100}, "a reason is required"},
+		{"unknown kind", FineEntry{UserID: 1, Kind: "refund", AmountCents: 100, Reason: "Overpaid"}, "invalid fine entry kind: must be one of [fine payment waiver]"},
+		{"zero amount", FineEntry{UserID: 1, Kind: FineKindPayment, Reason: "Paid"}, "amount must be a positive number of cents"},
+		{"missing user", FineEntry{Kind: FineKindFine, AmountCents: 100}, "user ID must be a positive integer"},
+		{"reason too long", FineEntry{UserID: 1, Kind: FineKindWaiver, AmountCents: 100, Reason: strings.Repeat("a", MaxFineReasonLength+1)}, "reason must be less than 500 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFineEntry(&tt.entry)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateFineEntry() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ValidateFineEntry() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
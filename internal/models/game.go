@@ -1,24 +1,67 @@
 package models
 
 import (
-	"fmt"
 	"strings"
 	"time"
 )
 
-// Game represents a board game in the library
+// Game represents a board game in the library. A game owns one or more
+// physical copies; IsAvailable reports whether at least one of them is free.
 type Game struct {
-	ID          int       `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name"`
-	Description string    `json:"description" db:"description"`
-	Category    string    `json:"category" db:"category"`
-	EntryDate   time.Time `json:"entry_date" db:"entry_date"`
-	Condition   string    `json:"condition" db:"condition"`
-	IsAvailable bool      `json:"is_available" db:"is_available"`
+	ID              int       `json:"id" db:"id"`
+	Name            string    `json:"name" db:"name"`
+	Description     string    `json:"description" db:"description"`
+	EntryDate       time.Time `json:"entry_date" db:"entry_date"`
+	Condition       string    `json:"condition" db:"condition"`
+	IsAvailable     bool      `json:"is_available" db:"is_available"`
+	TotalCopies     int       `json:"total_copies" db:"total_copies"`
+	AvailableCopies int       `json:"available_copies" db:"available_copies"`
+	Tags            []string  `json:"tags" db:"-"` // tag names, see Tag
+	GameDetails
+
+	// Match is set on full-text search results only
+	Match *GameSearchMatch `json:"match,omitempty" db:"-"`
+}
+
+// GameDetails holds the structured metadata of a game used to pick one, such
+// as "2 players in under 30 minutes". Zero values mean unknown.
+type GameDetails struct {
+	MinPlayers    int      `json:"min_players" db:"min_players"`
+	MaxPlayers    int      `json:"max_players" db:"max_players"`
+	PlayTime      int      `json:"play_time" db:"play_time"` // minutes
+	MinAge        int      `json:"min_age" db:"min_age"`
+	Publisher     string   `json:"publisher" db:"publisher"`
+	Designers     []string `json:"designers" db:"designers"`
+	YearPublished int      `json:"year_published" db:"year_published"` // negative for BC
+	Complexity    float64  `json:"complexity" db:"complexity"`         // MinComplexity (light) to MaxComplexity (heavy)
+	ImageURL      string   `json:"image_url" db:"image_url"`           // picture of the box
+	BGGID         int      `json:"bgg_id" db:"bgg_id"`                 // BoardGameGeek ID of imported games
 }
 
-// ValidConditions defines the allowed condition values
-var ValidConditions = []string{"excellent", "good", "fair", "poor"}
+// Bounds of the game metadata
+const (
+	MaxPlayerCount    = 100
+	MaxPlayTime       = 10000 // minutes
+	MaxMinAge         = 99
+	MinYearPublished  = -5000
+	MinComplexity     = 1.0
+	MaxComplexity     = 5.0
+	MaxDesigners      = 20
+	MaxImageURLLength = 500
+)
+
+// Game conditions, from best to worst. Games in poor condition or with
+// missing pieces need repair, see NeedsRepair.
+const (
+	ConditionExcellent     = "excellent"
+	ConditionGood          = "good"
+	ConditionFair          = "fair"
+	ConditionPoor          = "poor"
+	ConditionMissingPieces = "missing_pieces"
+)
+
+// ValidConditions defines the allowed condition values, from best to worst
+var ValidConditions = []string{ConditionExcellent, ConditionGood, ConditionFair, ConditionPoor, ConditionMissingPieces}
 
 // ValidateGame validates a Game struct
 func ValidateGame(game *Game) error {
@@ -30,7 +73,7 @@ func ValidateGame(game *Game) error {
 		return err
 	}
 	
-	if err := validateGameCategory(game.Category); err != nil {
+	if err := validateGameTags(game.Tags); err != nil {
 		return err
 	}
 	
@@ -38,6 +81,67 @@ func ValidateGame(game *Game) error {
 		return err
 	}
 	
+	return ValidateGameDetails(&game.GameDetails)
+}
+
+// ValidateGameDetails validates the metadata of a game
+func ValidateGameDetails(details *GameDetails) error {
+	if details.MinPlayers < 0 || details.MinPlayers > MaxPlayerCount {
+		return Invalid("min_players", "invalid_min_players", MaxPlayerCount)
+	}
+	if details.MaxPlayers < 0 || details.MaxPlayers > MaxPlayerCount {
+		return Invalid("max_players", "invalid_max_players", MaxPlayerCount)
+	}
+	if details.MinPlayers > 0 && details.MaxPlayers > 0 && details.MinPlayers > details.MaxPlayers {
+		return Invalid("min_players", "min_players_exceed_max")
+	}
+
+	if details.PlayTime < 0 || details.PlayTime > MaxPlayTime {
+		return Invalid("play_time", "invalid_play_time", MaxPlayTime)
+	}
+
+	if details.MinAge < 0 || details.MinAge > MaxMinAge {
+		return Invalid("min_age", "invalid_min_age", MaxMinAge)
+	}
+
+	if len(strings.TrimSpace(details.Publisher)) > 200 {
+		return Invalid("publisher", "publisher_too_long")
+	}
+
+	if len(details.Designers) > MaxDesigners {
+		return Invalid("designers", "too_many_designers", MaxDesigners)
+	}
+	for _, designer := range details.Designers {
+		designer = strings.TrimSpace(designer)
+		if designer == "" {
+			return Invalid("designers", "designer_name_empty")
+		}
+		if len(designer) > 100 {
+			return Invalid("designers", "designer_name_too_long")
+		}
+	}
+
+	if maxYear := time.Now().Year() + 1; details.YearPublished < MinYearPublished || details.YearPublished > maxYear {
+		return Invalid("year_published", "invalid_year_published", MinYearPublished, maxYear)
+	}
+
+	if details.Complexity != 0 && (details.Complexity < MinComplexity || details.Complexity > MaxComplexity) {
+		return Invalid("complexity", "invalid_complexity", MinComplexity, MaxComplexity)
+	}
+
+	if details.ImageURL != "" {
+		if len(details.ImageURL) > MaxImageURLLength {
+			return Invalid("image_url", "image_url_too_long", MaxImageURLLength)
+		}
+		if !strings.HasPrefix(details.ImageURL, "https://") && !strings.HasPrefix(details.ImageURL, "http://") {
+			return Invalid("image_url", "invalid_image_url")
+		}
+	}
+
+	if details.BGGID < 0 {
+		return Invalid("bgg_id", "negative_bgg_id")
+	}
+
 	return nil
 }
 
@@ -45,15 +149,15 @@ func ValidateGame(game *Game) error {
 func validateGameName(name string) error {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return fmt.Errorf("game name is required")
+		return Invalid("name", "game_name_required")
 	}
 	
 	if len(name) < 2 {
-		return fmt.Errorf("game name must be at least 2 characters long")
+		return Invalid("name", "game_name_too_short")
 	}
 	
 	if len(name) > 200 {
-		return fmt.Errorf("game name must be less than 200 characters")
+		return Invalid("name", "game_name_too_long")
 	}
 	
 	return nil
@@ -64,18 +168,7 @@ func validateGameDescription(description string) error {
 	description = strings.TrimSpace(description)
 	
 	if len(description) > 1000 {
-		return fmt.Errorf("game description must be less than 1000 characters")
-	}
-	
-	return nil
-}
-
-// validateGameCategory validates the game category field
-func validateGameCategory(category string) error {
-	category = strings.TrimSpace(category)
-	
-	if len(category) > 100 {
-		return fmt.Errorf("game category must be less than 100 characters")
+		return Invalid("description", "game_description_too_long")
 	}
 	
 	return nil
@@ -85,7 +178,7 @@ func validateGameCategory(category string) error {
 func validateGameCondition(condition string) error {
 	condition = strings.TrimSpace(condition)
 	if condition == "" {
-		return fmt.Errorf("game condition is required")
+		return Invalid("condition", "game_condition_required")
 	}
 	
 	// Check if condition is in valid conditions list
@@ -95,5 +188,5 @@ func validateGameCondition(condition string) error {
 		}
 	}
 	
-	return fmt.Errorf("invalid game condition: must be one of %v", ValidConditions)
+	return Invalid("condition", "invalid_game_condition", ValidConditions)
 }
\ No newline at end of file
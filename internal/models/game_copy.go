@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// GameCopy represents a physical copy of a game owned by the library.
+// Copies in need of repair are kept from lending, see SetCondition.
+type GameCopy struct {
+	ID          int       `json:"id" db:"id"`
+	GameID      int       `json:"game_id" db:"game_id"`
+	Barcode     string    `json:"barcode" db:"barcode"`
+	Condition   string    `json:"condition" db:"condition"`
+	AcquiredAt  time.Time `json:"acquired_at" db:"acquired_at"`
+	IsAvailable bool      `json:"is_available" db:"is_available"`
+	InRepair    bool      `json:"in_repair" db:"in_repair"`
+}
+
+// SetCondition changes the condition of a copy. A copy on the shelf is taken
+// out of lending while it needs repair and lent again once repaired. A copy
+// out on loan keeps its availability until it comes back.
+func (c *GameCopy) SetCondition(condition string) {
+	c.Condition = NormalizeCondition(condition)
+	if c.IsAvailable || c.InRepair {
+		c.InRepair = NeedsRepair(c.Condition)
+		c.IsAvailable = !c.InRepair
+	}
+}
+
+// ValidateGameCopy validates a GameCopy struct
+func ValidateGameCopy(gameCopy *GameCopy) error {
+	if gameCopy.GameID <= 0 {
+		return Invalid("game_id", "game_id_not_positive")
+	}
+	
+	if err := validateCopyBarcode(gameCopy.Barcode); err != nil {
+		return err
+	}
+	
+	if err := validateGameCondition(gameCopy.Condition); err != nil {
+		return err
+	}
+	
+	return nil
+}
+
+// validateCopyBarcode validates the copy barcode field (optional)
+func validateCopyBarcode(barcode string) error {
+	if barcode != strings.TrimSpace(barcode) {
+		return Invalid("barcode", "copy_barcode_spaces")
+	}
+	
+	if len(barcode) > 64 {
+		return Invalid("barcode", "copy_barcode_too_long")
+	}
+	
+	return nil
+}
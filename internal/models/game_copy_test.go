@@ -0,0 +1,95 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateGameCopy(t *testing.T) {
+	tests := []struct {
+		name    string
+		copy    *GameCopy
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid copy",
+			copy:    &GameCopy{GameID: 1, Barcode: "BGL-0001", Condition: "good", IsAvailable: true},
+			wantErr: false,
+		},
+		{
+			name:    "valid copy without barcode",
+			copy:    &GameCopy{GameID: 1, Condition: "excellent"},
+			wantErr: false,
+		},
+		{
+			name:    "missing game",
+			copy:    &GameCopy{Condition: "good"},
+			wantErr: true,
+			errMsg:  "game ID must be a positive integer",
+		},
+		{
+			name:    "barcode with spaces",
+			copy:    &GameCopy{GameID: 1, Barcode: " BGL-0001", Condition: "good"},
+			wantErr: true,
+			errMsg:  "copy barcode must not start or end with spaces",
+		},
+		{
+			name:    "barcode too long",
+			copy:    &GameCopy{GameID: 1, Barcode: strings.Repeat("9", 65), Condition: "good"},
+			wantErr: true,
+			errMsg:  "copy barcode must be less than 64 characters",
+		},
+		{
+			name:    "invalid condition",
+			copy:    &GameCopy{GameID: 1, Condition: "broken"},
+			wantErr: true,
+			errMsg:  "invalid game condition: must be one of [excellent good fair poor missing_pieces]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGameCopy(tt.copy)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ValidateGameCopy() expected error but got none")
+					return
+				}
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateGameCopy() error = %v, want %v", err.Error(), tt.errMsg)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateGameCopy() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestGameCopySetCondition(t *testing.T) {
+	tests := []struct {
+		name          string
+		copy          GameCopy
+		condition     string
+		wantAvailable bool
+		wantInRepair  bool
+	}{
+		{"shelf copy damaged", GameCopy{IsAvailable: true}, "missing_pieces", false, true},
+		{"shelf copy worn", GameCopy{IsAvailable: true}, "Fair", true, false},
+		{"copy repaired", GameCopy{InRepair: true}, "good", true, false},
+		{"copy still in repair", GameCopy{InRepair: true}, "poor", false, true},
+		{"copy on loan", GameCopy{}, "poor", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.copy.SetCondition(tt.condition)
+			if tt.copy.Condition != NormalizeCondition(tt.condition) {
+				t.Errorf("Condition = %q, want %q", tt.copy.Condition, NormalizeCondition(tt.condition))
+			}
+			if tt.copy.IsAvailable != tt.wantAvailable || tt.copy.InRepair != tt.wantInRepair {
+				t.Errorf("IsAvailable, InRepair = %v, %v, want %v, %v", tt.copy.IsAvailable, tt.copy.InRepair, tt.wantAvailable, tt.wantInRepair)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strconv"
 	"testing"
 	"time"
 )
@@ -18,7 +19,7 @@ func TestValidateGame(t *testing.T) {
 				ID:          1,
 				Name:        "Monopoly",
 				Description: "Classic board game",
-				Category:    "Strategy",
+				Tags:        []string{"Strategy"},
 				EntryDate:   time.Now(),
 				Condition:   "good",
 				IsAvailable: true,
@@ -30,7 +31,7 @@ func TestValidateGame(t *testing.T) {
 			game: &Game{
 				Name:        "",
 				Description: "Classic board game",
-				Category:    "Strategy",
+				Tags:        []string{"Strategy"},
 				Condition:   "good",
 			},
 			wantErr: true,
@@ -41,18 +42,18 @@ func TestValidateGame(t *testing.T) {
 			game: &Game{
 				Name:        "Monopoly",
 				Description: "Classic board game",
-				Category:    "Strategy",
+				Tags:        []string{"Strategy"},
 				Condition:   "terrible",
 			},
 			wantErr: true,
-			errMsg:  "invalid game condition: must be one of [excellent good fair poor]",
+			errMsg:  "invalid game condition: must be one of [excellent good fair poor missing_pieces]",
 		},
 		{
 			name: "description too long",
 			game: &Game{
 				Name:        "Monopoly",
 				Description: string(make([]byte, 1001)), // 1001 characters
-				Category:    "Strategy",
+				Tags:        []string{"Strategy"},
 				Condition:   "good",
 			},
 			wantErr: true,
@@ -62,13 +63,13 @@ func TestValidateGame(t *testing.T) {
 			name: "valid with empty description",
 			game: &Game{
 				Name:      "Monopoly",
-				Category:  "Strategy",
+				Tags:      []string{"Strategy"},
 				Condition: "excellent",
 			},
 			wantErr: false,
 		},
 		{
-			name: "valid with empty category",
+			name: "valid without tags",
 			game: &Game{
 				Name:        "Monopoly",
 				Description: "Classic board game",
@@ -150,62 +151,72 @@ func TestValidateGameDescription(t *testing.T) {
 	}
 }
 
-func TestValidateGameCategory(t *testing.T) {
+func TestValidateGameCondition(t *testing.T) {
 	tests := []struct {
 		name    string
 		input   string
 		wantErr bool
 		errMsg  string
 	}{
-		{"valid category", "Strategy", false, ""},
-		{"empty category", "", false, ""},
-		{"whitespace only", "   ", false, ""},
-		{"exactly 100 characters", string(make([]byte, 100)), false, ""},
-		{"over 100 characters", string(make([]byte, 101)), true, "game category must be less than 100 characters"},
+		{"valid condition - excellent", "excellent", false, ""},
+		{"valid condition - good", "good", false, ""},
+		{"valid condition - fair", "fair", false, ""},
+		{"valid condition - poor", "poor", false, ""},
+		{"valid condition - case insensitive", "EXCELLENT", false, ""},
+		{"empty condition", "", true, "game condition is required"},
+		{"whitespace only", "   ", true, "game condition is required"},
+		{"invalid condition", "terrible", true, "invalid game condition: must be one of [excellent good fair poor missing_pieces]"},
+		{"invalid condition - partial match", "goo", true, "invalid game condition: must be one of [excellent good fair poor missing_pieces]"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateGameCategory(tt.input)
+			err := validateGameCondition(tt.input)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("validateGameCategory() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("validateGameCondition() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if tt.wantErr && err.Error() != tt.errMsg {
-				t.Errorf("validateGameCategory() error = %v, want %v", err.Error(), tt.errMsg)
+				t.Errorf("validateGameCondition() error = %v, want %v", err.Error(), tt.errMsg)
 			}
 		})
 	}
 }
-
-func TestValidateGameCondition(t *testing.T) {
+func TestValidateGameDetails(t *testing.T) {
 	tests := []struct {
 		name    string
-		input   string
-		wantErr bool
+		details GameDetails
 		errMsg  string
 	}{
-		{"valid condition - excellent", "excellent", false, ""},
-		{"valid condition - good", "good", false, ""},
-		{"valid condition - fair", "fair", false, ""},
-		{"valid condition - poor", "poor", false, ""},
-		{"valid condition - case insensitive", "EXCELLENT", false, ""},
-		{"empty condition", "", true, "game condition is required"},
-		{"whitespace only", "   ", true, "game condition is required"},
-		{"invalid condition", "terrible", true, "invalid game condition: must be one of [excellent good fair poor]"},
-		{"invalid condition - partial match", "goo", true, "invalid game condition: must be one of [excellent good fair poor]"},
+		{"unknown", GameDetails{}, ""},
+		{"complete", GameDetails{MinPlayers: 2, MaxPlayers: 4, PlayTime: 30, MinAge: 8, Publisher: "Plan B", Designers: []string{"Michael Kiesling"}, YearPublished: 2017, Complexity: 1.8}, ""},
+		{"ancient", GameDetails{YearPublished: -2200}, ""},
+		{"solo", GameDetails{MinPlayers: 1, MaxPlayers: 1}, ""},
+		{"min above max", GameDetails{MinPlayers: 5, MaxPlayers: 4}, "min players cannot exceed max players"},
+		{"negative players", GameDetails{MinPlayers: -1}, "min players must be between 0 and 100"},
+		{"too many players", GameDetails{MaxPlayers: 101}, "max players must be between 0 and 100"},
+		{"negative play time", GameDetails{PlayTime: -5}, "play time must be between 0 and 10000 minutes"},
+		{"age", GameDetails{MinAge: 120}, "min age must be between 0 and 99"},
+		{"empty designer", GameDetails{Designers: []string{"Uwe Rosenberg", " "}}, "designer names cannot be empty"},
+		{"future year", GameDetails{YearPublished: time.Now().Year() + 5}, "year published must be between -5000 and " + strconv.Itoa(time.Now().Year()+1)},
+		{"complexity", GameDetails{Complexity: 0.5}, "complexity must be between 1 and 5"},
+		{"imported", GameDetails{ImageURL: "https://cf.geekdo-images.com/azul.jpg", BGGID: 230802}, ""},
+		{"image URL", GameDetails{ImageURL: "javascript:alert(1)"}, "image URL must start with http:// or https://"},
+		{"negative BGG ID", GameDetails{BGGID: -1}, "BoardGameGeek ID cannot be negative"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateGameCondition(tt.input)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("validateGameCondition() error = %v, wantErr %v", err, tt.wantErr)
+			err := ValidateGameDetails(&tt.details)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("ValidateGameDetails() unexpected error = %v", err)
+				}
 				return
 			}
-			if tt.wantErr && err.Error() != tt.errMsg {
-				t.Errorf("validateGameCondition() error = %v, want %v", err.Error(), tt.errMsg)
+			if err == nil || err.Error() != tt.errMsg {
+				t.Errorf("ValidateGameDetails() error = %v, want %v", err, tt.errMsg)
 			}
 		})
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// JobRun represents a persisted execution of a background job
+type JobRun struct {
+	ID         int        `json:"id" db:"id"`
+	JobName    string     `json:"job_name" db:"job_name"`
+	Status     string     `json:"status" db:"status"`
+	StartedAt  time.Time  `json:"started_at" db:"started_at"`
+	FinishedAt *time.Time `json:"finished_at" db:"finished_at"`
+	DurationMs int64      `json:"duration_ms" db:"duration_ms"`
+	Error      string     `json:"error,omitempty" db:"error"`
+}
+
+// JobRunCount is the number of runs of a job that ended in a status
+type JobRunCount struct {
+	JobName string `json:"job_name" db:"job_name"`
+	Status  string `json:"status" db:"status"`
+	Count   int    `json:"count" db:"count"`
+}
+
+// ValidJobRunStatuses defines the allowed job run statuses
+var ValidJobRunStatuses = []string{"pending", "running", "completed", "failed"}
+
+// ValidateJobRun validates a JobRun struct
+func ValidateJobRun(run *JobRun) error {
+	if strings.TrimSpace(run.JobName) == "" {
+		return fmt.Errorf("job name is required")
+	}
+	
+	if len(run.JobName) > 100 {
+		return fmt.Errorf("job name must be less than 100 characters")
+	}
+	
+	validStatus := false
+	for _, status := range ValidJobRunStatuses {
+		if run.Status == status {
+			validStatus = true
+			break
+		}
+	}
+	if !validStatus {
+		return fmt.Errorf("invalid job run status: must be one of %v", ValidJobRunStatuses)
+	}
+	
+	if run.StartedAt.IsZero() {
+		return fmt.Errorf("start time is required")
+	}
+	
+	if run.FinishedAt != nil && run.FinishedAt.Before(run.StartedAt) {
+		return fmt.Errorf("finish time must not be before start time")
+	}
+	
+	return nil
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateJobRun(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		run     *JobRun
+		wantErr string
+	}{
+		{
+			name: "valid running job",
+			run:  &JobRun{JobName: "overdue-alerts", Status: "running", StartedAt: now},
+		},
+		{
+			name: "valid finished job",
+			run:  &JobRun{JobName: "overdue-alerts", Status: "completed", StartedAt: before, FinishedAt: &now},
+		},
+		{
+			name:    "missing job name",
+			run:     &JobRun{JobName: "  ", Status: "running", StartedAt: now},
+			wantErr: "job name is required",
+		},
+		{
+			name:    "invalid status",
+			run:     &JobRun{JobName: "overdue-alerts", Status: "done", StartedAt: now},
+			wantErr: "invalid job run status: must be one of [pending running completed failed]",
+		},
+		{
+			name:    "missing start time",
+			run:     &JobRun{JobName: "overdue-alerts", Status: "running"},
+			wantErr: "start time is required",
+		},
+		{
+			name:    "finished before start",
+			run:     &JobRun{JobName: "overdue-alerts", Status: "failed", StartedAt: now, FinishedAt: &before},
+			wantErr: "finish time must not be before start time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJobRun(tt.run)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateJobRun() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ValidateJobRun() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"board-game-library/pkg/slug"
+)
+
+// Library is one of the game libraries sharing a deployment, such as those
+// of several associations. Every user, game, borrowing and alert belongs to
+// exactly one library and is only visible from it. The slug selects the
+// library in URLs: as a subdomain or after the path prefix.
+type Library struct {
+	ID        int       `json:"id" db:"id"`
+	Slug      string    `json:"slug" db:"slug"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// MaxLibraryNameLength is the longest library name accepted
+const MaxLibraryNameLength = 100
+
+// librarySlugPattern matches the slugs usable as a DNS label: lower case
+// letters, digits and inner dashes, at most 63 characters
+var librarySlugPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// LibrarySlug returns the slug of a library named name, only keeping the
+// characters valid in a subdomain
+func LibrarySlug(name string) string {
+	var b strings.Builder
+	for _, r := range slug.Make(name) {
+		switch {
+		case r == '-' && (b.Len() == 0 || strings.HasSuffix(b.String(), "-")):
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// ValidateLibrary validates a Library struct
+func ValidateLibrary(library *Library) error {
+	name := strings.TrimSpace(library.Name)
+	if name == "" {
+		return Invalid("name", "library_name_required")
+	}
+
+	if len(name) > MaxLibraryNameLength {
+		return Invalid("name", "library_name_too_long", MaxLibraryNameLength)
+	}
+
+	if !librarySlugPattern.MatchString(library.Slug) {
+		return Invalid("slug", "invalid_library_slug")
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateLibrary(t *testing.T) {
+	tests := []struct {
+		name    string
+		library Library
+		errMsg  string
+	}{
+		{"valid library", Library{Name: "Ludothèque du Centre", Slug: "ludo-centre"}, ""},
+		{"digits only slug", Library{Name: "Club 42", Slug: "42"}, ""},
+		{"empty name", Library{Name: " ", Slug: "club"}, "library name is required"},
+		{"name too long", Library{Name: strings.Repeat("a", MaxLibraryNameLength+1), Slug: "club"}, "library name must be less than 100 characters"},
+		{"empty slug", Library{Name: "Club"}, "library slug must be 1 to 63 lowercase letters, digits or inner dashes"},
+		{"upper case slug", Library{Name: "Club", Slug: "Club"}, "library slug must be 1 to 63 lowercase letters, digits or inner dashes"},
+		{"trailing dash", Library{Name: "Club", Slug: "club-"}, "library slug must be 1 to 63 lowercase letters, digits or inner dashes"},
+		{"slug too long", Library{Name: "Club", Slug: strings.Repeat("a", 64)}, "library slug must be 1 to 63 lowercase letters, digits or inner dashes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLibrary(&tt.library)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("ValidateLibrary() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.errMsg {
+				t.Errorf("ValidateLibrary() error = %v, want %v", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestLibrarySlug(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Ludothèque du Centre", "ludotheque-du-centre"},
+		{"  Club  42 ", "club-42"},
+		{"Jeux 東京", "jeux"},
+		{"A 東 京 B", "a-b"},
+		{"!?", ""},
+	}
+
+	for _, tt := range tests {
+		if got := LibrarySlug(tt.input); got != tt.want {
+			t.Errorf("LibrarySlug(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package models
+
+import "time"
+
+// Sort orders
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// List page sizes
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+// Borrowing statuses used to filter borrowing lists
+const (
+	BorrowingStatusActive   = "active"
+	BorrowingStatusReturned = "returned"
+	BorrowingStatusOverdue  = "overdue"
+)
+
+// Sort fields accepted by each list, the first one being the default
+var (
+	GameSortFields      = []string{"name", "condition", "entry_date", "available_copies", "year_published", "play_time", "complexity"}
+	UserSortFields      = []string{"name", "email", "registered_at", "membership_tier"}
+	BorrowingSortFields = []string{"borrowed_at", "due_date", "returned_at"}
+	AlertSortFields     = []string{"created_at", "type"}
+)
+
+// ValidBorrowingStatuses defines the allowed borrowing status filters
+var ValidBorrowingStatuses = []string{BorrowingStatusActive, BorrowingStatusReturned, BorrowingStatusOverdue}
+
+// ListOptions selects one page of a sorted list
+type ListOptions struct {
+	Page    int    // 1-based
+	PerPage int    // capped at MaxPerPage
+	Sort    string // empty for the list's default order
+	Order   string // SortAsc or SortDesc, empty for the field's natural order
+}
+
+// Offset returns the number of rows before the selected page
+func (o ListOptions) Offset() int {
+	if o.Page < 1 {
+		return 0
+	}
+	return (o.Page - 1) * o.PerPage
+}
+
+// TotalPages returns the number of pages needed to show total rows
+func (o ListOptions) TotalPages(total int) int {
+	if o.PerPage <= 0 || total <= 0 {
+		return 1
+	}
+	return (total + o.PerPage - 1) / o.PerPage
+}
+
+// NormalizeListOptions validates o against a list's sort fields and fills in
+// the default page, page size and sort field
+func NormalizeListOptions(o *ListOptions, sortFields []string) error {
+	if o.Page < 1 {
+		o.Page = 1
+	}
+	if o.PerPage <= 0 {
+		o.PerPage = DefaultPerPage
+	}
+	if o.PerPage > MaxPerPage {
+		o.PerPage = MaxPerPage
+	}
+
+	if o.Sort == "" {
+		o.Sort = sortFields[0]
+	} else if !contains(sortFields, o.Sort) {
+		return Invalid("sort", "invalid_sort_field", sortFields)
+	}
+
+	if o.Order != "" && o.Order != SortAsc && o.Order != SortDesc {
+		return Invalid("order", "invalid_sort_order", SortAsc, SortDesc)
+	}
+
+	return nil
+}
+
+// GameFilter selects games; zero fields match everything. The metadata
+// filters skip games whose matching field is unknown.
+type GameFilter struct {
+	Search        string   // matched against name, description and tags
+	Tags          []string // slugs of tags the games all have
+	Condition     string
+	Available     *bool
+	Players       int    // playable with exactly this many players
+	MaxPlayTime   int    // minutes
+	Age           int    // suitable from this age
+	Publisher     string // substring of the publisher
+	Designer      string // substring of one of the designers
+	YearFrom      int    // published this year or later
+	YearTo        int    // published this year or earlier
+	MinComplexity float64
+	MaxComplexity float64
+	ListOptions
+}
+
+// UserFilter selects users; zero fields match everything
+type UserFilter struct {
+	Search         string // matched against name and email
+	MembershipTier string
+	Role           string
+	Active         *bool
+	HasLoans       *bool // whether the user has games not returned yet
+	ListOptions
+}
+
+// BorrowingFilter selects borrowings; zero fields match everything
+type BorrowingFilter struct {
+	Search       string // matched against the names of the user and game and the user's email
+	UserID       int
+	GameID       int
+	Status       string // one of ValidBorrowingStatuses
+	BorrowedFrom *time.Time
+	BorrowedTo   *time.Time // exclusive
+	DueFrom      *time.Time
+	DueTo        *time.Time // exclusive
+	ListOptions
+}
+
+// AlertFilter selects alerts; zero fields match everything
+type AlertFilter struct {
+	Search      string // matched against the message, the names of the user and game and the user's email
+	UserID      int
+	GameID      int
+	Type        string
+	Read        *bool
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time // exclusive
+	ListOptions
+}
+
+// ValidateGameFilter validates a GameFilter and fills in its list defaults
+func ValidateGameFilter(filter *GameFilter) error {
+	if filter.Condition != "" && !contains(ValidConditions, filter.Condition) {
+		return Invalid("condition", "invalid_condition", ValidConditions)
+	}
+	if len(filter.Tags) > MaxTagsPerGame {
+		return Invalid("tag", "too_many_filter_tags", MaxTagsPerGame)
+	}
+	if len(filter.Tags) > 0 {
+		var tags []string
+		for _, tag := range filter.Tags {
+			if tagSlug := TagSlug(tag); tagSlug != "" && !contains(tags, tagSlug) {
+				tags = append(tags, tagSlug)
+			}
+		}
+		filter.Tags = tags
+	}
+	if filter.Players < 0 || filter.MaxPlayTime < 0 || filter.Age < 0 {
+		return Invalid("players", "negative_game_filter")
+	}
+	if filter.YearFrom != 0 && filter.YearTo != 0 && filter.YearFrom > filter.YearTo {
+		return Invalid("year_from", "invalid_year_range")
+	}
+	for _, complexity := range []float64{filter.MinComplexity, filter.MaxComplexity} {
+		if complexity != 0 && (complexity < MinComplexity || complexity > MaxComplexity) {
+			return Invalid("min_complexity", "invalid_complexity", MinComplexity, MaxComplexity)
+		}
+	}
+	if filter.MaxComplexity != 0 && filter.MinComplexity > filter.MaxComplexity {
+		return Invalid("min_complexity", "invalid_complexity_range")
+	}
+
+	sortFields := GameSortFields
+	if filter.Search != "" {
+		sortFields = append([]string{GameSortRelevance}, GameSortFields...)
+	}
+
+	return NormalizeListOptions(&filter.ListOptions, sortFields)
+}
+
+// ValidateUserFilter validates a UserFilter and fills in its list defaults
+func ValidateUserFilter(filter *UserFilter) error {
+	if filter.Role != "" && !IsValidRole(filter.Role) {
+		return Invalid("role", "invalid_role", ValidRoles)
+	}
+
+	return NormalizeListOptions(&filter.ListOptions, UserSortFields)
+}
+
+// ValidateBorrowingFilter validates a BorrowingFilter and fills in its list defaults
+func ValidateBorrowingFilter(filter *BorrowingFilter) error {
+	if filter.UserID < 0 || filter.GameID < 0 {
+		return Invalid("user_id", "invalid_filter_ids")
+	}
+
+	if filter.Status != "" && !contains(ValidBorrowingStatuses, filter.Status) {
+		return Invalid("status", "invalid_borrowing_status", ValidBorrowingStatuses)
+	}
+
+	return NormalizeListOptions(&filter.ListOptions, BorrowingSortFields)
+}
+
+// ValidateAlertFilter validates an AlertFilter and fills in its list defaults
+func ValidateAlertFilter(filter *AlertFilter) error {
+	if filter.UserID < 0 || filter.GameID < 0 {
+		return Invalid("user_id", "invalid_filter_ids")
+	}
+
+	if filter.Type != "" && !contains(ValidAlertTypes, filter.Type) {
+		return Invalid("type", "invalid_alert_type", ValidAlertTypes)
+	}
+
+	return NormalizeListOptions(&filter.ListOptions, AlertSortFields)
+}
+
+// contains reports whether values holds value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
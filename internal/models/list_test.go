@@ -0,0 +1,94 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeListOptions(t *testing.T) {
+	options := ListOptions{}
+	if err := NormalizeListOptions(&options, GameSortFields); err != nil {
+		t.Fatalf("NormalizeListOptions() error = %v", err)
+	}
+	if options.Page != 1 || options.PerPage != DefaultPerPage || options.Sort != "name" {
+		t.Errorf("Expected defaults, got %+v", options)
+	}
+
+	options = ListOptions{Page: 3, PerPage: 1000, Sort: "entry_date", Order: SortDesc}
+	if err := NormalizeListOptions(&options, GameSortFields); err != nil {
+		t.Fatalf("NormalizeListOptions() error = %v", err)
+	}
+	if options.PerPage != MaxPerPage {
+		t.Errorf("Expected page size capped at %d, got %d", MaxPerPage, options.PerPage)
+	}
+	if options.Offset() != 2*MaxPerPage {
+		t.Errorf("Expected offset %d, got %d", 2*MaxPerPage, options.Offset())
+	}
+
+	if err := NormalizeListOptions(&ListOptions{Sort: "password"}, UserSortFields); err == nil {
+		t.Error("Expected an error for an unknown sort field")
+	}
+	if err := NormalizeListOptions(&ListOptions{Order: "up"}, UserSortFields); err == nil {
+		t.Error("Expected an error for an unknown sort order")
+	}
+}
+
+func TestListOptionsTotalPages(t *testing.T) {
+	tests := []struct {
+		total, perPage, want int
+	}{
+		{0, 20, 1},
+		{20, 20, 1},
+		{21, 20, 2},
+		{95, 10, 10},
+	}
+
+	for _, tt := range tests {
+		if got := (ListOptions{PerPage: tt.perPage}).TotalPages(tt.total); got != tt.want {
+			t.Errorf("TotalPages(%d) with %d per page = %d, want %d", tt.total, tt.perPage, got, tt.want)
+		}
+	}
+}
+
+func TestValidateFilters(t *testing.T) {
+	if err := ValidateGameFilter(&GameFilter{Condition: "mint"}); err == nil {
+		t.Error("Expected an error for an unknown condition")
+	}
+	if err := ValidateGameFilter(&GameFilter{YearFrom: 2020, YearTo: 2010}); err == nil {
+		t.Error("Expected an error for an empty year range")
+	}
+	if err := ValidateGameFilter(&GameFilter{MinComplexity: 4, MaxComplexity: 2}); err == nil {
+		t.Error("Expected an error for an empty complexity range")
+	}
+	if err := ValidateGameFilter(&GameFilter{MaxComplexity: 7}); err == nil {
+		t.Error("Expected an error for an out of range complexity")
+	}
+	if err := ValidateGameFilter(&GameFilter{Players: -2}); err == nil {
+		t.Error("Expected an error for a negative player count")
+	}
+	if err := ValidateUserFilter(&UserFilter{Role: "owner"}); err == nil {
+		t.Error("Expected an error for an unknown role")
+	}
+	if err := ValidateBorrowingFilter(&BorrowingFilter{Status: "lost"}); err == nil {
+		t.Error("Expected an error for an unknown status")
+	}
+	if err := ValidateAlertFilter(&AlertFilter{Type: "spam"}); err == nil {
+		t.Error("Expected an error for an unknown alert type")
+	}
+
+	gameFilter := GameFilter{Tags: []string{"Coopératif", "cooperatif", " ", "2 joueurs"}}
+	if err := ValidateGameFilter(&gameFilter); err != nil {
+		t.Errorf("ValidateGameFilter() error = %v", err)
+	}
+	if strings.Join(gameFilter.Tags, ",") != "cooperatif,2-joueurs" {
+		t.Errorf("Expected tag filter to be turned into slugs, got %v", gameFilter.Tags)
+	}
+
+	filter := BorrowingFilter{Status: BorrowingStatusOverdue}
+	if err := ValidateBorrowingFilter(&filter); err != nil {
+		t.Errorf("ValidateBorrowingFilter() error = %v", err)
+	}
+	if filter.Sort != "borrowed_at" {
+		t.Errorf("Expected default sort borrowed_at, got %s", filter.Sort)
+	}
+}
@@ -0,0 +1,205 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// DefaultMembershipTier is the tier assigned to users who were not given one
+const DefaultMembershipTier = "standard"
+
+// MaxLoanDays is the longest loan any membership tier may allow
+const MaxLoanDays = 365
+
+// Loan policy rules, reported when a policy blocks a user
+const (
+	PolicyRuleAccountInactive = "account_inactive"
+	PolicyRuleOverdueItems    = "overdue_items"
+	PolicyRuleMaxLoans        = "max_loans"
+	PolicyRuleMaxLoanDays     = "max_loan_days"
+	PolicyRuleMaxExtensions   = "max_extensions"
+	PolicyRuleFineBalance     = "fine_balance"
+)
+
+// LoanPolicy holds the borrowing limits of a membership tier
+type LoanPolicy struct {
+	Tier            string `json:"tier" db:"tier"`
+	MaxLoans        int    `json:"max_loans" db:"max_loans"`                 // concurrent loans
+	MaxLoanDays     int    `json:"max_loan_days" db:"max_loan_days"`         // from the borrowing date, extensions included
+	DefaultLoanDays int    `json:"default_loan_days" db:"default_loan_days"` // used when no due date is given
+	MaxExtensions   int    `json:"max_extensions" db:"max_extensions"`
+
+	// Late fees, in cents. A late return is charged FinePerDayCents for each
+	// day late beyond FineGraceDays, up to FineCapCents (0 for no cap).
+	FinePerDayCents int `json:"fine_per_day_cents" db:"fine_per_day_cents"`
+	FineGraceDays   int `json:"fine_grace_days" db:"fine_grace_days"`
+	FineCapCents    int `json:"fine_cap_cents" db:"fine_cap_cents"`
+	// Users owing more than MaxFineBalanceCents cannot borrow; nil for no limit
+	MaxFineBalanceCents *int `json:"max_fine_balance_cents" db:"max_fine_balance_cents"`
+}
+
+// BorrowEligibility describes whether a user may borrow another game and,
+// when not, which policy rule blocks them
+type BorrowEligibility struct {
+	CanBorrow    bool   `json:"can_borrow"`
+	Rule         string `json:"rule,omitempty"`
+	Reason       string `json:"reason"`
+	Tier         string `json:"tier"`
+	CurrentLoans int    `json:"current_loans"`
+	MaxLoans     int    `json:"max_loans"`
+	FineBalance  int    `json:"fine_balance_cents"`
+}
+
+var tierPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+// DefaultLoanPolicy returns the limits of the default membership tier
+func DefaultLoanPolicy() *LoanPolicy {
+	return &LoanPolicy{
+		Tier:            DefaultMembershipTier,
+		MaxLoans:        5,
+		MaxLoanDays:     90,
+		DefaultLoanDays: 14,
+		MaxExtensions:   3,
+	}
+}
+
+// ValidateLoanPolicy validates a LoanPolicy struct
+func ValidateLoanPolicy(policy *LoanPolicy) error {
+	if !tierPattern.MatchString(policy.Tier) {
+		return Invalid("tier", "invalid_tier")
+	}
+
+	if policy.MaxLoans < 1 {
+		return Invalid("max_loans", "invalid_max_loans")
+	}
+
+	if policy.MaxLoanDays < 1 || policy.MaxLoanDays > MaxLoanDays {
+		return Invalid("max_loan_days", "invalid_max_loan_days", MaxLoanDays)
+	}
+
+	if policy.DefaultLoanDays < 1 || policy.DefaultLoanDays > policy.MaxLoanDays {
+		return Invalid("default_loan_days", "invalid_default_loan_days")
+	}
+
+	if policy.MaxExtensions < 0 {
+		return Invalid("max_extensions", "invalid_max_extensions")
+	}
+
+	if policy.FinePerDayCents < 0 {
+		return Invalid("fine_per_day_cents", "invalid_fine_per_day")
+	}
+
+	if policy.FineGraceDays < 0 {
+		return Invalid("fine_grace_days", "invalid_fine_grace_days")
+	}
+
+	if policy.FineCapCents < 0 {
+		return Invalid("fine_cap_cents", "invalid_fine_cap")
+	}
+
+	if policy.MaxFineBalanceCents != nil && *policy.MaxFineBalanceCents < 0 {
+		return Invalid("max_fine_balance_cents", "invalid_max_fine_balance")
+	}
+
+	return nil
+}
+
+// CheckBorrow evaluates whether a user with the given active borrowings and
+// unpaid fines, in cents, may borrow another game
+func (p *LoanPolicy) CheckBorrow(user *User, activeBorrowings []*Borrowing, fineBalance int) *BorrowEligibility {
+	eligibility := &BorrowEligibility{
+		Tier:         p.Tier,
+		CurrentLoans: len(activeBorrowings),
+		MaxLoans:     p.MaxLoans,
+		FineBalance:  fineBalance,
+	}
+
+	if !user.IsActive {
+		eligibility.Rule = PolicyRuleAccountInactive
+		eligibility.Reason = "user account is inactive"
+		return eligibility
+	}
+
+	for _, borrowing := range activeBorrowings {
+		if borrowing.IsCurrentlyOverdue() {
+			eligibility.Rule = PolicyRuleOverdueItems
+			eligibility.Reason = "user has overdue items and cannot borrow"
+			return eligibility
+		}
+	}
+
+	if p.MaxFineBalanceCents != nil && fineBalance > *p.MaxFineBalanceCents {
+		eligibility.Rule = PolicyRuleFineBalance
+		eligibility.Reason = fmt.Sprintf("user owes %d cents in fines, more than the %s tier allows", fineBalance, p.Tier)
+		return eligibility
+	}
+
+	if len(activeBorrowings) >= p.MaxLoans {
+		eligibility.Rule = PolicyRuleMaxLoans
+		eligibility.Reason = fmt.Sprintf("user has reached the limit of %d concurrent loan(s) for the %s tier", p.MaxLoans, p.Tier)
+		return eligibility
+	}
+
+	eligibility.CanBorrow = true
+	eligibility.Reason = "User is eligible to borrow"
+	return eligibility
+}
+
+// Err returns the policy violation that keeps the user from borrowing, or
+// nil when the user may borrow
+func (e *BorrowEligibility) Err() error {
+	switch {
+	case e.CanBorrow:
+		return nil
+	case e.Rule == PolicyRuleMaxLoans:
+		return PolicyViolation(e.Rule, e.MaxLoans, e.Tier)
+	case e.Rule == PolicyRuleFineBalance:
+		return PolicyViolation(e.Rule, e.FineBalance, e.Tier)
+	default:
+		return PolicyViolation(e.Rule)
+	}
+}
+
+// CheckDueDate verifies that a due date stays within the maximum loan
+// duration. A due date beyond it is an invalid request rather than a
+// violation of the policy by the borrower.
+func (p *LoanPolicy) CheckDueDate(borrowedAt, dueDate time.Time) error {
+	maxDuration := time.Duration(p.MaxLoanDays) * 24 * time.Hour
+	if dueDate.Sub(borrowedAt) > maxDuration {
+		return Invalid("due_date", PolicyRuleMaxLoanDays, p.MaxLoanDays)
+	}
+
+	return nil
+}
+
+// CheckExtension verifies that a borrowing may be extended once more
+func (p *LoanPolicy) CheckExtension(borrowing *Borrowing) error {
+	if borrowing.ExtensionCount >= p.MaxExtensions {
+		return PolicyViolation(PolicyRuleMaxExtensions, p.MaxExtensions, p.Tier)
+	}
+
+	return nil
+}
+
+// LateFee returns the fine, in cents, for a return the given number of days
+// late: nothing within the grace period, then the daily fee for each further
+// day, up to the cap
+func (p *LoanPolicy) LateFee(daysLate int) int {
+	days := daysLate - p.FineGraceDays
+	if days <= 0 || p.FinePerDayCents <= 0 {
+		return 0
+	}
+
+	fee := days * p.FinePerDayCents
+	if p.FineCapCents > 0 && fee > p.FineCapCents {
+		fee = p.FineCapCents
+	}
+
+	return fee
+}
+
+// DefaultDueDate returns the due date of a loan starting at the given time
+func (p *LoanPolicy) DefaultDueDate(from time.Time) time.Time {
+	return from.Add(time.Duration(p.DefaultLoanDays) * 24 * time.Hour)
+}
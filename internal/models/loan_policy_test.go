@@ -0,0 +1,184 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateLoanPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *LoanPolicy
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "default policy",
+			policy:  DefaultLoanPolicy(),
+			wantErr: false,
+		},
+		{
+			name:    "invalid tier",
+			policy:  &LoanPolicy{Tier: "Gold Members", MaxLoans: 1, MaxLoanDays: 14, DefaultLoanDays: 7},
+			wantErr: true,
+			errMsg:  "tier must be 1 to 32 lowercase letters, digits, '-' or '_', starting with a letter",
+		},
+		{
+			name:    "no loans allowed",
+			policy:  &LoanPolicy{Tier: "basic", MaxLoans: 0, MaxLoanDays: 14, DefaultLoanDays: 7},
+			wantErr: true,
+			errMsg:  "max loans must be at least 1",
+		},
+		{
+			name:    "loan days out of range",
+			policy:  &LoanPolicy{Tier: "basic", MaxLoans: 1, MaxLoanDays: 400, DefaultLoanDays: 7},
+			wantErr: true,
+			errMsg:  "max loan days must be between 1 and 365",
+		},
+		{
+			name:    "default longer than maximum",
+			policy:  &LoanPolicy{Tier: "basic", MaxLoans: 1, MaxLoanDays: 14, DefaultLoanDays: 21},
+			wantErr: true,
+			errMsg:  "default loan days must be between 1 and max loan days",
+		},
+		{
+			name:    "negative fine per day",
+			policy:  &LoanPolicy{Tier: "basic", MaxLoans: 1, MaxLoanDays: 14, DefaultLoanDays: 7, FinePerDayCents: -10},
+			wantErr: true,
+			errMsg:  "fine per day cannot be negative",
+		},
+		{
+			name:    "no fines allowed",
+			policy:  &LoanPolicy{Tier: "basic", MaxLoans: 1, MaxLoanDays: 14, DefaultLoanDays: 7, MaxFineBalanceCents: new(int)},
+			wantErr: false,
+		},
+		{
+			name:    "negative extensions",
+			policy:  &LoanPolicy{Tier: "basic", MaxLoans: 1, MaxLoanDays: 14, DefaultLoanDays: 7, MaxExtensions: -1},
+			wantErr: true,
+			errMsg:  "max extensions cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLoanPolicy(tt.policy)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ValidateLoanPolicy() expected error but got none")
+					return
+				}
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateLoanPolicy() error = %v, want %v", err.Error(), tt.errMsg)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateLoanPolicy() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLoanPolicy_CheckBorrow(t *testing.T) {
+	maxBalance := 500
+	policy := &LoanPolicy{Tier: "basic", MaxLoans: 2, MaxLoanDays: 30, DefaultLoanDays: 14, MaxExtensions: 1, MaxFineBalanceCents: &maxBalance}
+	current := &Borrowing{DueDate: time.Now().Add(7 * 24 * time.Hour)}
+	overdue := &Borrowing{DueDate: time.Now().Add(-24 * time.Hour)}
+
+	tests := []struct {
+		name         string
+		user         *User
+		borrowings   []*Borrowing
+		fineBalance  int
+		expectedRule string
+	}{
+		{"eligible", &User{IsActive: true}, []*Borrowing{current}, 0, ""},
+		{"inactive", &User{IsActive: false}, nil, 0, PolicyRuleAccountInactive},
+		{"overdue", &User{IsActive: true}, []*Borrowing{overdue}, 0, PolicyRuleOverdueItems},
+		{"loan limit", &User{IsActive: true}, []*Borrowing{current, current}, 0, PolicyRuleMaxLoans},
+		{"fines up to the limit", &User{IsActive: true}, []*Borrowing{current}, 500, ""},
+		{"fines above the limit", &User{IsActive: true}, []*Borrowing{current}, 501, PolicyRuleFineBalance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eligibility := policy.CheckBorrow(tt.user, tt.borrowings, tt.fineBalance)
+
+			if eligibility.CanBorrow != (tt.expectedRule == "") {
+				t.Errorf("CheckBorrow() CanBorrow = %v, want %v", eligibility.CanBorrow, tt.expectedRule == "")
+			}
+			if eligibility.Rule != tt.expectedRule {
+				t.Errorf("CheckBorrow() Rule = %q, want %q", eligibility.Rule, tt.expectedRule)
+			}
+			if eligibility.Reason == "" {
+				t.Error("CheckBorrow() should always explain its decision")
+			}
+			if eligibility.CurrentLoans != len(tt.borrowings) || eligibility.MaxLoans != 2 {
+				t.Errorf("CheckBorrow() loans = %d/%d, want %d/2", eligibility.CurrentLoans, eligibility.MaxLoans, len(tt.borrowings))
+			}
+		})
+	}
+}
+
+func TestLoanPolicy_DueDatesAndExtensions(t *testing.T) {
+	policy := &LoanPolicy{Tier: "basic", MaxLoans: 2, MaxLoanDays: 30, DefaultLoanDays: 14, MaxExtensions: 1}
+	borrowedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	if due := policy.DefaultDueDate(borrowedAt); !due.Equal(borrowedAt.AddDate(0, 0, 14)) {
+		t.Errorf("DefaultDueDate() = %v, want 14 days later", due)
+	}
+
+	if err := policy.CheckDueDate(borrowedAt, borrowedAt.AddDate(0, 0, 30)); err != nil {
+		t.Errorf("CheckDueDate() unexpected error for the maximum duration: %v", err)
+	}
+
+	err := policy.CheckDueDate(borrowedAt, borrowedAt.AddDate(0, 0, 31))
+	if err == nil || err.Error() != "due date cannot be more than 30 days from borrowed date" {
+		t.Errorf("CheckDueDate() error = %v, want the maximum duration error", err)
+	}
+
+	if err := policy.CheckExtension(&Borrowing{ExtensionCount: 0}); err != nil {
+		t.Errorf("CheckExtension() unexpected error: %v", err)
+	}
+
+	err = policy.CheckExtension(&Borrowing{ExtensionCount: 1})
+	if err == nil || err.Error() != "borrowing has reached the limit of 1 extension(s) for the basic tier" {
+		t.Errorf("CheckExtension() error = %v, want the extension limit error", err)
+	}
+}
+
+func TestLoanPolicy_CheckBorrowWithoutFineLimit(t *testing.T) {
+	policy := DefaultLoanPolicy()
+
+	eligibility := policy.CheckBorrow(&User{IsActive: true}, nil, 100000)
+	if !eligibility.CanBorrow {
+		t.Errorf("CheckBorrow() Rule = %q, want no limit on fines", eligibility.Rule)
+	}
+	if eligibility.FineBalance != 100000 {
+		t.Errorf("CheckBorrow() FineBalance = %d, want 100000", eligibility.FineBalance)
+	}
+}
+
+func TestLoanPolicy_LateFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   LoanPolicy
+		daysLate int
+		want     int
+	}{
+		{"on time", LoanPolicy{FinePerDayCents: 50}, 0, 0},
+		{"per day", LoanPolicy{FinePerDayCents: 50}, 3, 150},
+		{"within grace", LoanPolicy{FinePerDayCents: 50, FineGraceDays: 2}, 2, 0},
+		{"after grace", LoanPolicy{FinePerDayCents: 50, FineGraceDays: 2}, 5, 150},
+		{"capped", LoanPolicy{FinePerDayCents: 50, FineCapCents: 400}, 30, 400},
+		{"below cap", LoanPolicy{FinePerDayCents: 50, FineCapCents: 400}, 4, 200},
+		{"no fee", LoanPolicy{}, 30, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.LateFee(tt.daysLate); got != tt.want {
+				t.Errorf("LateFee(%d) = %d, want %d", tt.daysLate, got, tt.want)
+			}
+		})
+	}
+}
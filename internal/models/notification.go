@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+// Languages of the messages sent to users
+const (
+	LanguageFrench  = "fr"
+	LanguageEnglish = "en"
+)
+
+// ValidLanguages lists the supported languages, the default first
+var ValidLanguages = []string{LanguageFrench, LanguageEnglish}
+
+// Delivery statuses of the email sent for an alert. A notification is
+// pending until it is sent, retried after each failure, and failed once
+// every attempt was used. Alerts of users who opted out, or raised before
+// notifications existed, are skipped.
+const (
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	NotificationFailed  = "failed"
+	NotificationSkipped = "skipped"
+)
+
+// NotificationMaxAge is the age after which an alert is no longer worth
+// emailing, e.g. when notifications were switched on long after it was raised
+const NotificationMaxAge = 7 * 24 * time.Hour
+
+// maxNotificationRetryDelay bounds the delay between two delivery attempts
+const maxNotificationRetryDelay = 24 * time.Hour
+
+// AlertNotification is the delivery status of the email sent for an alert
+type AlertNotification struct {
+	AlertID       int        `json:"alert_id" db:"alert_id"`
+	Status        string     `json:"status" db:"status"`
+	Attempts      int        `json:"attempts" db:"attempts"`
+	LastError     string     `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty" db:"sent_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// PendingNotification is an alert due to be emailed, with what is needed to
+// write to its user
+type PendingNotification struct {
+	Alert        Alert
+	UserName     string
+	Email        string
+	GameName     string
+	EmailEnabled bool   // false when the user opted out
+	Language     string // the user's language, empty for the default one
+	Attempts     int    // failed attempts so far
+}
+
+// NotificationPreferences are the choices of a user about the emails they
+// receive
+type NotificationPreferences struct {
+	UserID       int    `json:"user_id" db:"user_id"`
+	EmailEnabled bool   `json:"email_enabled" db:"email_enabled"`
+	Language     string `json:"language" db:"language"` // empty for the library's default language
+}
+
+// ValidateNotificationPreferences validates a NotificationPreferences struct
+func ValidateNotificationPreferences(preferences *NotificationPreferences) error {
+	if preferences.UserID <= 0 {
+		return Invalid("user_id", "user_id_not_positive")
+	}
+
+	if preferences.Language != "" && !IsValidLanguage(preferences.Language) {
+		return Invalid("language", "invalid_language", ValidLanguages)
+	}
+
+	return nil
+}
+
+// IsValidLanguage reports whether messages can be written in language
+func IsValidLanguage(language string) bool {
+	for _, valid := range ValidLanguages {
+		if language == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationRetryDelay returns how long to wait before the next delivery
+// attempt after attempts failed ones: base, then twice as long after each
+// failure, at most a day
+func NotificationRetryDelay(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxNotificationRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxNotificationRetryDelay)
+}
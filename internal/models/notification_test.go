@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateNotificationPreferences(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences NotificationPreferences
+		wantErr     bool
+	}{
+		{"default language", NotificationPreferences{UserID: 1, EmailEnabled: true}, false},
+		{"english", NotificationPreferences{UserID: 1, Language: LanguageEnglish}, false},
+		{"unknown language", NotificationPreferences{UserID: 1, Language: "de"}, true},
+		{"missing user", NotificationPreferences{Language: LanguageFrench}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNotificationPreferences(&tt.preferences)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNotificationPreferences() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 5 * time.Minute},
+		{2, 10 * time.Minute},
+		{3, 20 * time.Minute},
+		{10, 24 * time.Hour},
+		{100, 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := NotificationRetryDelay(5*time.Minute, tt.attempts); got != tt.want {
+			t.Errorf("NotificationRetryDelay(5m, %d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// Reservation statuses. A reservation waits in the game's queue until a copy
+// is returned, is then ready for pickup until it expires, and is closed once
+// the user borrows the copy, cancels or lets the hold expire.
+const (
+	ReservationWaiting   = "waiting"
+	ReservationReady     = "ready"
+	ReservationFulfilled = "fulfilled"
+	ReservationCancelled = "cancelled"
+	ReservationExpired   = "expired"
+)
+
+// Reservation represents a user's hold on a game that is currently borrowed
+type Reservation struct {
+	ID         int        `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	GameID     int        `json:"game_id" db:"game_id"`
+	CopyID     *int       `json:"copy_id" db:"copy_id"`
+	Status     string     `json:"status" db:"status"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	NotifiedAt *time.Time `json:"notified_at" db:"notified_at"`
+	ExpiresAt  *time.Time `json:"expires_at" db:"expires_at"`
+	ClosedAt   *time.Time `json:"closed_at" db:"closed_at"`
+	Position   int        `json:"position,omitempty" db:"-"` // 1-based place in the queue, set for active reservations
+}
+
+// ValidReservationStatuses defines the allowed reservation statuses
+var ValidReservationStatuses = []string{
+	ReservationWaiting, ReservationReady, ReservationFulfilled, ReservationCancelled, ReservationExpired,
+}
+
+// ValidateReservation validates a Reservation struct
+func ValidateReservation(reservation *Reservation) error {
+	if reservation.UserID <= 0 {
+		return Invalid("user_id", "user_id_not_positive")
+	}
+
+	if reservation.GameID <= 0 {
+		return Invalid("game_id", "game_id_not_positive")
+	}
+
+	validStatus := false
+	for _, status := range ValidReservationStatuses {
+		if reservation.Status == status {
+			validStatus = true
+			break
+		}
+	}
+	if !validStatus {
+		return Invalid("status", "invalid_reservation_status", ValidReservationStatuses)
+	}
+
+	if reservation.Status == ReservationReady {
+		if reservation.CopyID == nil {
+			return Invalid("copy_id", "ready_reservation_without_copy")
+		}
+		if reservation.ExpiresAt == nil {
+			return Invalid("expires_at", "ready_reservation_without_expiry")
+		}
+	}
+
+	return nil
+}
+
+// IsActive reports whether the reservation is still waiting or ready for pickup
+func (r *Reservation) IsActive() bool {
+	return r.Status == ReservationWaiting || r.Status == ReservationReady
+}
+
+// IsHoldExpired reports whether a ready reservation was not collected in time
+func (r *Reservation) IsHoldExpired(now time.Time) bool {
+	return r.Status == ReservationReady && r.ExpiresAt != nil && now.After(*r.ExpiresAt)
+}
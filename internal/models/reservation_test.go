@@ -0,0 +1,101 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateReservation(t *testing.T) {
+	copyID := 3
+	expiresAt := time.Now().Add(72 * time.Hour)
+
+	tests := []struct {
+		name        string
+		reservation *Reservation
+		wantErr     bool
+		errMsg      string
+	}{
+		{
+			name:        "valid waiting reservation",
+			reservation: &Reservation{UserID: 1, GameID: 2, Status: ReservationWaiting, CreatedAt: time.Now()},
+			wantErr:     false,
+		},
+		{
+			name:        "valid ready reservation",
+			reservation: &Reservation{UserID: 1, GameID: 2, CopyID: &copyID, Status: ReservationReady, ExpiresAt: &expiresAt},
+			wantErr:     false,
+		},
+		{
+			name:        "missing user",
+			reservation: &Reservation{GameID: 2, Status: ReservationWaiting},
+			wantErr:     true,
+			errMsg:      "user ID must be a positive integer",
+		},
+		{
+			name:        "missing game",
+			reservation: &Reservation{UserID: 1, Status: ReservationWaiting},
+			wantErr:     true,
+			errMsg:      "game ID must be a positive integer",
+		},
+		{
+			name:        "invalid status",
+			reservation: &Reservation{UserID: 1, GameID: 2, Status: "pending"},
+			wantErr:     true,
+			errMsg:      "invalid reservation status: must be one of [waiting ready fulfilled cancelled expired]",
+		},
+		{
+			name:        "ready without copy",
+			reservation: &Reservation{UserID: 1, GameID: 2, Status: ReservationReady, ExpiresAt: &expiresAt},
+			wantErr:     true,
+			errMsg:      "a ready reservation must hold a copy",
+		},
+		{
+			name:        "ready without expiry",
+			reservation: &Reservation{UserID: 1, GameID: 2, CopyID: &copyID, Status: ReservationReady},
+			wantErr:     true,
+			errMsg:      "a ready reservation must have an expiry date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReservation(tt.reservation)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ValidateReservation() expected error but got none")
+					return
+				}
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateReservation() error = %v, want %v", err.Error(), tt.errMsg)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateReservation() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestReservation_IsHoldExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		reservation *Reservation
+		expected    bool
+	}{
+		{"ready and past expiry", &Reservation{Status: ReservationReady, ExpiresAt: &past}, true},
+		{"ready before expiry", &Reservation{Status: ReservationReady, ExpiresAt: &future}, false},
+		{"waiting", &Reservation{Status: ReservationWaiting}, false},
+		{"fulfilled with past expiry", &Reservation{Status: ReservationFulfilled, ExpiresAt: &past}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.reservation.IsHoldExpired(now); got != tt.expected {
+				t.Errorf("IsHoldExpired() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
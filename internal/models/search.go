@@ -0,0 +1,79 @@
+package models
+
+import (
+	"html"
+	"strings"
+)
+
+// GameSortRelevance sorts search results best match first. It is only
+// accepted, and is the default, when the filter has a search term.
+const GameSortRelevance = "relevance"
+
+// Markers delimiting the matched terms in search highlights, chosen so that
+// they never occur in game text
+const (
+	HighlightStart = "\x02"
+	HighlightEnd   = "\x03"
+)
+
+// TextRange is a range of characters of a text, from Start included to End
+// excluded, counted in Unicode code points
+type TextRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// GameSearchMatch describes how a game matched a full-text search. Names and
+// snippets are plain text: clients highlight the ranges of the matched terms.
+type GameSearchMatch struct {
+	Rank              float64     `json:"rank"`               // lower is more relevant
+	Name              string      `json:"name"`               // name of the game
+	NameHighlights    []TextRange `json:"name_highlights"`    // matched terms of the name
+	Snippet           string      `json:"snippet"`            // excerpt of the description
+	SnippetHighlights []TextRange `json:"snippet_highlights"` // matched terms of the snippet
+}
+
+// SplitHighlights removes the highlight markers from text and returns the
+// ranges of the terms they delimited
+func SplitHighlights(text string) (string, []TextRange) {
+	var plain strings.Builder
+	var highlights []TextRange
+	start, length := -1, 0
+	for _, r := range text {
+		switch string(r) {
+		case HighlightStart:
+			start = length
+		case HighlightEnd:
+			if start >= 0 && start < length {
+				highlights = append(highlights, TextRange{Start: start, End: length})
+			}
+			start = -1
+		default:
+			plain.WriteRune(r)
+			length++
+		}
+	}
+
+	return plain.String(), highlights
+}
+
+// HighlightHTML escapes text for HTML and wraps the highlighted ranges in
+// <mark> elements
+func HighlightHTML(text string, highlights []TextRange) string {
+	var b strings.Builder
+	runes := []rune(text)
+	last := 0
+	for _, h := range highlights {
+		if h.Start < last || h.End > len(runes) || h.Start >= h.End {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[last:h.Start])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[h.Start:h.End])))
+		b.WriteString("</mark>")
+		last = h.End
+	}
+	b.WriteString(html.EscapeString(string(runes[last:])))
+
+	return b.String()
+}
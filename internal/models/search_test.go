@@ -0,0 +1,58 @@
+package models
+
+import "testing"
+
+func TestHighlightHTML(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Les " + HighlightStart + "Délices" + HighlightEnd + " de Paris", "Les <mark>Délices</mark> de Paris"},
+		{"<b>" + HighlightStart + "Azul" + HighlightEnd + "</b>", "&lt;b&gt;<mark>Azul</mark>&lt;/b&gt;"},
+		{HighlightStart + "Azul" + HighlightEnd + " et " + HighlightStart + "azulejos" + HighlightEnd, "<mark>Azul</mark> et <mark>azulejos</mark>"},
+		{"Rien à signaler", "Rien à signaler"},
+	}
+
+	for _, tt := range tests {
+		if got := HighlightHTML(SplitHighlights(tt.text)); got != tt.want {
+			t.Errorf("HighlightHTML(SplitHighlights(%q)) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSplitHighlights(t *testing.T) {
+	plain, highlights := SplitHighlights("Les " + HighlightStart + "Délices" + HighlightEnd + " de Paris")
+	if plain != "Les Délices de Paris" {
+		t.Errorf("plain text = %q", plain)
+	}
+	if len(highlights) != 1 || highlights[0] != (TextRange{Start: 4, End: 11}) {
+		t.Errorf("highlights = %v, want [{4 11}]", highlights)
+	}
+
+	if _, highlights := SplitHighlights("Rien à signaler"); highlights != nil {
+		t.Errorf("expected no highlights, got %v", highlights)
+	}
+}
+
+func TestValidateGameFilterRelevance(t *testing.T) {
+	filter := GameFilter{Search: "azul"}
+	if err := ValidateGameFilter(&filter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter.Sort != GameSortRelevance {
+		t.Errorf("expected searches to sort by relevance, got %q", filter.Sort)
+	}
+
+	filter = GameFilter{}
+	if err := ValidateGameFilter(&filter); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter.Sort != "name" {
+		t.Errorf("expected lists to sort by name, got %q", filter.Sort)
+	}
+
+	filter = GameFilter{ListOptions: ListOptions{Sort: GameSortRelevance}}
+	if err := ValidateGameFilter(&filter); err == nil {
+		t.Error("expected relevance sort without a search term to be rejected")
+	}
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"board-game-library/pkg/slug"
+)
+
+// Tag labels games, such as "Coopératif" or "Jeu de cartes". A game has any
+// number of tags and a tag any number of games. The slug identifies the tag
+// in URLs and makes names differing only by case or accents the same tag.
+type Tag struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Slug      string    `json:"slug" db:"slug"`
+	GameCount int       `json:"game_count" db:"game_count"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Tag limits
+const (
+	MaxTagsPerGame   = 20
+	MaxTagNameLength = 50
+	DefaultTagLimit  = 20
+	MaxTagLimit      = 500
+)
+
+// TagSlug returns the slug identifying the tag named name
+func TagSlug(name string) string {
+	return slug.Make(name)
+}
+
+// ValidateTagName validates the name of a tag
+func ValidateTagName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Invalid("name", "tag_name_required")
+	}
+
+	if len(name) > MaxTagNameLength {
+		return Invalid("name", "tag_name_too_long", MaxTagNameLength)
+	}
+
+	if TagSlug(name) == "" {
+		return Invalid("name", "invalid_tag_name")
+	}
+
+	return nil
+}
+
+// NormalizeTags trims tag names and drops blank and duplicate ones, keeping
+// the first spelling of names sharing a slug
+func NormalizeTags(names []string) []string {
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		tagSlug := TagSlug(name)
+		if name == "" || seen[tagSlug] {
+			continue
+		}
+		seen[tagSlug] = true
+		tags = append(tags, name)
+	}
+
+	return tags
+}
+
+// ParseTags splits a comma-separated list of tag names, as typed in forms
+func ParseTags(list string) []string {
+	return NormalizeTags(strings.Split(list, ","))
+}
+
+// validateGameTags validates the tags of a game
+func validateGameTags(tags []string) error {
+	if len(tags) > MaxTagsPerGame {
+		return Invalid("tags", "too_many_tags", MaxTagsPerGame)
+	}
+
+	for _, tag := range tags {
+		if err := ValidateTagName(tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateTagName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+		errMsg  string
+	}{
+		{"valid name", "Coopératif", false, ""},
+		{"name with spaces", "Jeu de cartes", false, ""},
+		{"empty name", "  ", true, "tag name is required"},
+		{"punctuation only", "!?", true, "tag name must contain a letter or a digit"},
+		{"too long", strings.Repeat("a", MaxTagNameLength+1), true, "tag name must be less than 50 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTagName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTagName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.errMsg {
+				t.Errorf("ValidateTagName() error = %v, want %v", err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	got := ParseTags(" Stratégie, strategie ,,Famille, STRATÉGIE")
+	want := []string{"Stratégie", "Famille"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTags() = %v, want %v", got, want)
+	}
+
+	if got := ParseTags(""); len(got) != 0 {
+		t.Errorf("Expected no tags from an empty list, got %v", got)
+	}
+}
+
+func TestValidateGameTags(t *testing.T) {
+	if err := validateGameTags([]string{"Famille", "Ambiance"}); err != nil {
+		t.Errorf("validateGameTags() error = %v", err)
+	}
+
+	tooMany := make([]string, MaxTagsPerGame+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("t", i+1)
+	}
+	if err := validateGameTags(tooMany); err == nil || err.Error() != "a game can have at most 20 tags" {
+		t.Errorf("Expected an error for too many tags, got %v", err)
+	}
+
+	if err := validateGameTags([]string{"Famille", ""}); err == nil {
+		t.Error("Expected an error for a blank tag")
+	}
+}
@@ -0,0 +1,70 @@
+package models
+
+// Bulk import and export file formats
+const (
+	TransferFormatCSV  = "csv"
+	TransferFormatJSON = "json"
+)
+
+// ValidTransferFormats contains the file formats of bulk imports and exports
+var ValidTransferFormats = []string{TransferFormatCSV, TransferFormatJSON}
+
+// Tables that can be imported and exported in bulk
+const (
+	TransferTableGames      = "games"
+	TransferTableUsers      = "users"
+	TransferTableBorrowings = "borrowings"
+)
+
+// ValidTransferTables contains the tables that can be imported and exported
+// in bulk. Users come before borrowings, which refer to them.
+var ValidTransferTables = []string{TransferTableGames, TransferTableUsers, TransferTableBorrowings}
+
+// MaxImportSize is the largest import file accepted over HTTP, in bytes
+const MaxImportSize = 10 << 20
+
+// ImportRowError reports why a row of an import file was rejected
+type ImportRowError struct {
+	Line    int    `json:"line"` // line of the file where the row starts
+	Message string `json:"message"`
+}
+
+// ImportResult reports the outcome of a bulk import. Imports are all or
+// nothing: rows are only saved when every row is valid and it is not a
+// dry run.
+type ImportResult struct {
+	Table     string           `json:"table"`
+	Format    string           `json:"format"`
+	DryRun    bool             `json:"dry_run"`
+	Rows      int              `json:"rows"`
+	Imported  int              `json:"imported"`
+	Committed bool             `json:"committed"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// Err returns the validation error rejecting the import, which carries the
+// error of each invalid row, or nil when every row is valid
+func (r *ImportResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+
+	rows := make(ValidationErrors, len(r.Errors))
+	for i, rowErr := range r.Errors {
+		rows[i] = Invalid("file", "invalid_import_row", rowErr.Line, rowErr.Message)
+	}
+	return Invalid("file", "import_rejected", len(r.Errors), r.Rows).Wrap(rows)
+}
+
+// ValidateTransfer checks the table and file format of a bulk import or export
+func ValidateTransfer(table, format string) error {
+	if !contains(ValidTransferTables, table) {
+		return Invalid("table", "invalid_transfer_table", table, ValidTransferTables)
+	}
+
+	if !contains(ValidTransferFormats, format) {
+		return Invalid("format", "invalid_transfer_format", format, ValidTransferFormats)
+	}
+
+	return nil
+}
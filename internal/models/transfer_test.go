@@ -0,0 +1,31 @@
+package models
+
+import "testing"
+
+func TestValidateTransfer(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   string
+		format  string
+		wantErr bool
+		errMsg  string
+	}{
+		{"games as CSV", "games", "csv", false, ""},
+		{"borrowings as JSON", "borrowings", "json", false, ""},
+		{"unknown table", "alerts", "csv", true, `invalid table "alerts": must be one of [games users borrowings]`},
+		{"unknown format", "users", "xlsx", true, `invalid format "xlsx": must be one of [csv json]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransfer(tt.table, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTransfer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.errMsg {
+				t.Errorf("ValidateTransfer() error = %v, want %v", err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package models
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -9,12 +8,46 @@ import (
 
 // User represents a library user
 type User struct {
-	ID           int       `json:"id" db:"id"`
-	Name         string    `json:"name" db:"name"`
-	Email        string    `json:"email" db:"email"`
-	RegisteredAt time.Time `json:"registered_at" db:"registered_at"`
-	IsActive     bool      `json:"is_active" db:"is_active"`
-	CurrentLoans int       `json:"current_loans" db:"-"` // Not stored in DB, calculated at runtime
+	ID             int       `json:"id" db:"id"`
+	Name           string    `json:"name" db:"name"`
+	Email          string    `json:"email" db:"email"`
+	RegisteredAt   time.Time `json:"registered_at" db:"registered_at"`
+	IsActive       bool      `json:"is_active" db:"is_active"`
+	MembershipTier string    `json:"membership_tier" db:"membership_tier"` // selects the user's loan policy
+	Role           string    `json:"role" db:"role"`                       // what the user may do, see ValidRoles
+	CurrentLoans   int       `json:"current_loans" db:"-"`                 // Not stored in DB, calculated at runtime
+}
+
+// User roles, from least to most privileged
+const (
+	RoleMember    = "member"
+	RoleLibrarian = "librarian"
+	RoleAdmin     = "admin"
+)
+
+// ValidRoles contains all valid user roles, from least to most privileged
+var ValidRoles = []string{RoleMember, RoleLibrarian, RoleAdmin}
+
+// roleRank returns the privilege level of a role, or -1 for an unknown role
+func roleRank(role string) int {
+	for i, valid := range ValidRoles {
+		if role == valid {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsValidRole reports whether role is one of ValidRoles
+func IsValidRole(role string) bool {
+	return roleRank(role) >= 0
+}
+
+// HasRole reports whether the user's role grants at least the privileges of
+// the given role; administrators can do everything librarians can
+func (u *User) HasRole(role string) bool {
+	rank := roleRank(role)
+	return rank >= 0 && roleRank(u.Role) >= rank
 }
 
 // ValidateUser validates a User struct
@@ -27,6 +60,10 @@ func ValidateUser(user *User) error {
 		return err
 	}
 	
+	if user.Role != "" && !IsValidRole(user.Role) {
+		return Invalid("role", "invalid_role", ValidRoles)
+	}
+	
 	return nil
 }
 
@@ -34,15 +71,15 @@ func ValidateUser(user *User) error {
 func validateUserName(name string) error {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return fmt.Errorf("name is required")
+		return Invalid("name", "user_name_required")
 	}
 	
 	if len(name) < 2 {
-		return fmt.Errorf("name must be at least 2 characters long")
+		return Invalid("name", "user_name_too_short")
 	}
 	
 	if len(name) > 100 {
-		return fmt.Errorf("name must be less than 100 characters")
+		return Invalid("name", "user_name_too_long")
 	}
 	
 	return nil
@@ -52,17 +89,17 @@ func validateUserName(name string) error {
 func validateUserEmail(email string) error {
 	email = strings.TrimSpace(email)
 	if email == "" {
-		return fmt.Errorf("email is required")
+		return Invalid("email", "email_required")
 	}
 	
 	if len(email) > 255 {
-		return fmt.Errorf("email must be less than 255 characters")
+		return Invalid("email", "email_too_long")
 	}
 	
 	// Basic email regex pattern
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	if !emailRegex.MatchString(email) {
-		return fmt.Errorf("invalid email format")
+		return Invalid("email", "invalid_email")
 	}
 	
 	return nil
@@ -3,13 +3,15 @@ package repositories
 import (
 	"board-game-library/internal/models"
 	"board-game-library/pkg/database"
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
 // SQLiteAlertRepository implements AlertRepository using SQLite
 type SQLiteAlertRepository struct {
-	db *database.DB
+	db database.Querier
 }
 
 // NewSQLiteAlertRepository creates a new SQLite alert repository
@@ -18,13 +20,13 @@ func NewSQLiteAlertRepository(db *database.DB) AlertRepository {
 }
 
 // Create inserts a new alert into the database
-func (r *SQLiteAlertRepository) Create(alert *models.Alert) error {
+func (r *SQLiteAlertRepository) Create(ctx context.Context, alert *models.Alert) error {
 	query := `
-		INSERT INTO alerts (user_id, game_id, type, message, created_at, is_read)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO alerts (library_id, user_id, game_id, type, message, created_at, is_read)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 		RETURNING id`
 	
-	err := r.db.QueryRow(query, alert.UserID, alert.GameID, alert.Type,
+	err := r.db.QueryRowContext(ctx, query, database.LibraryOf(r.db), alert.UserID, alert.GameID, alert.Type,
 		alert.Message, alert.CreatedAt, alert.IsRead).Scan(&alert.ID)
 	if err != nil {
 		return fmt.Errorf("failed to create alert: %w", err)
@@ -34,21 +36,21 @@ func (r *SQLiteAlertRepository) Create(alert *models.Alert) error {
 }
 
 // GetByID retrieves an alert by its ID
-func (r *SQLiteAlertRepository) GetByID(id int) (*models.Alert, error) {
+func (r *SQLiteAlertRepository) GetByID(ctx context.Context, id int) (*models.Alert, error) {
 	query := `
 		SELECT id, user_id, game_id, type, message, created_at, is_read
 		FROM alerts
-		WHERE id = ?`
+		WHERE id = ? AND library_id = ?`
 	
 	alert := &models.Alert{}
-	err := r.db.QueryRow(query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, database.LibraryOf(r.db)).Scan(
 		&alert.ID, &alert.UserID, &alert.GameID, &alert.Type,
 		&alert.Message, &alert.CreatedAt, &alert.IsRead,
 	)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("alert with id %d not found", id)
+			return nil, models.NotFound("alert_not_found", id)
 		}
 		return nil, fmt.Errorf("failed to get alert by id: %w", err)
 	}
@@ -57,14 +59,14 @@ func (r *SQLiteAlertRepository) GetByID(id int) (*models.Alert, error) {
 }
 
 // GetUnread retrieves all unread alerts
-func (r *SQLiteAlertRepository) GetUnread() ([]*models.Alert, error) {
+func (r *SQLiteAlertRepository) GetUnread(ctx context.Context) ([]*models.Alert, error) {
 	query := `
 		SELECT id, user_id, game_id, type, message, created_at, is_read
 		FROM alerts
-		WHERE is_read = FALSE
+		WHERE is_read = FALSE AND library_id = ?
 		ORDER BY created_at DESC`
 	
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get unread alerts: %w", err)
 	}
@@ -91,14 +93,14 @@ func (r *SQLiteAlertRepository) GetUnread() ([]*models.Alert, error) {
 }
 
 // GetByUser retrieves all alerts for a specific user
-func (r *SQLiteAlertRepository) GetByUser(userID int) ([]*models.Alert, error) {
+func (r *SQLiteAlertRepository) GetByUser(ctx context.Context, userID int) ([]*models.Alert, error) {
 	query := `
 		SELECT id, user_id, game_id, type, message, created_at, is_read
 		FROM alerts
-		WHERE user_id = ?
+		WHERE user_id = ? AND library_id = ?
 		ORDER BY created_at DESC`
 	
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.db.QueryContext(ctx, query, userID, database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get alerts by user: %w", err)
 	}
@@ -125,13 +127,14 @@ func (r *SQLiteAlertRepository) GetByUser(userID int) ([]*models.Alert, error) {
 }
 
 // GetAll retrieves all alerts from the database
-func (r *SQLiteAlertRepository) GetAll() ([]*models.Alert, error) {
+func (r *SQLiteAlertRepository) GetAll(ctx context.Context) ([]*models.Alert, error) {
 	query := `
 		SELECT id, user_id, game_id, type, message, created_at, is_read
 		FROM alerts
+		WHERE library_id = ?
 		ORDER BY created_at DESC`
 	
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all alerts: %w", err)
 	}
@@ -157,14 +160,95 @@ func (r *SQLiteAlertRepository) GetAll() ([]*models.Alert, error) {
 	return alerts, nil
 }
 
+// alertSortColumns maps the alert sort fields to SQL
+var alertSortColumns = map[string]sortColumn{
+	"created_at": {expr: "created_at", desc: true},
+	"type":       {expr: "type"},
+}
+
+// List retrieves one page of the alerts matching filter
+func (r *SQLiteAlertRepository) List(ctx context.Context, filter models.AlertFilter) ([]*models.Alert, error) {
+	where := alertWhere(r.db, filter)
+	query := `
+		SELECT id, user_id, game_id, type, message, created_at, is_read
+		FROM alerts` + where.String() +
+		orderBy(filter.ListOptions, alertSortColumns, "created_at") + `
+		LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, append(where.args, pageArgs(filter.ListOptions)...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []*models.Alert
+	for rows.Next() {
+		alert := &models.Alert{}
+		err := rows.Scan(
+			&alert.ID, &alert.UserID, &alert.GameID, &alert.Type,
+			&alert.Message, &alert.CreatedAt, &alert.IsRead,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// Count returns the number of alerts matching filter, ignoring its page
+func (r *SQLiteAlertRepository) Count(ctx context.Context, filter models.AlertFilter) (int, error) {
+	where := alertWhere(r.db, filter)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM alerts`+where.String(), where.args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count alerts: %w", err)
+	}
+
+	return count, nil
+}
+
+// alertWhere builds the conditions selecting the alerts matching filter
+func alertWhere(q database.Querier, filter models.AlertFilter) *sqlWhere {
+	where := libraryWhere(q, "library_id")
+	if filter.Search != "" {
+		term := "%" + strings.ToLower(filter.Search) + "%"
+		where.add("(LOWER(message) LIKE ? OR user_id IN (SELECT id FROM users WHERE LOWER(name) LIKE ? OR LOWER(email) LIKE ?) OR game_id IN (SELECT id FROM games WHERE LOWER(name) LIKE ?))", term, term, term, term)
+	}
+	if filter.UserID > 0 {
+		where.add("user_id = ?", filter.UserID)
+	}
+	if filter.GameID > 0 {
+		where.add("game_id = ?", filter.GameID)
+	}
+	if filter.Type != "" {
+		where.add("type = ?", filter.Type)
+	}
+	if filter.Read != nil {
+		where.add("is_read = ?", *filter.Read)
+	}
+	if filter.CreatedFrom != nil {
+		where.add("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		where.add("created_at < ?", *filter.CreatedTo)
+	}
+	return where
+}
+
 // MarkAsRead marks an alert as read
-func (r *SQLiteAlertRepository) MarkAsRead(id int) error {
+func (r *SQLiteAlertRepository) MarkAsRead(ctx context.Context, id int) error {
 	query := `
 		UPDATE alerts
 		SET is_read = TRUE
-		WHERE id = ?`
+		WHERE id = ? AND library_id = ?`
 	
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id, database.LibraryOf(r.db))
 	if err != nil {
 		return fmt.Errorf("failed to mark alert as read: %w", err)
 	}
@@ -175,17 +259,17 @@ func (r *SQLiteAlertRepository) MarkAsRead(id int) error {
 	}
 	
 	if rowsAffected == 0 {
-		return fmt.Errorf("alert with id %d not found", id)
+		return models.NotFound("alert_not_found", id)
 	}
 	
 	return nil
 }
 
 // Delete removes an alert from the database
-func (r *SQLiteAlertRepository) Delete(id int) error {
-	query := `DELETE FROM alerts WHERE id = ?`
+func (r *SQLiteAlertRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM alerts WHERE id = ? AND library_id = ?`
 	
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id, database.LibraryOf(r.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete alert: %w", err)
 	}
@@ -196,8 +280,8 @@ func (r *SQLiteAlertRepository) Delete(id int) error {
 	}
 	
 	if rowsAffected == 0 {
-		return fmt.Errorf("alert with id %d not found", id)
+		return models.NotFound("alert_not_found", id)
 	}
 	
 	return nil
-}
\ No newline at end of file
+}
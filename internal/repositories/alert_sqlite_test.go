@@ -2,11 +2,13 @@ package repositories
 
 import (
 	"board-game-library/internal/models"
+	"context"
 	"testing"
 	"time"
 )
 
 func TestSQLiteAlertRepository_Create(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -25,7 +27,7 @@ func TestSQLiteAlertRepository_Create(t *testing.T) {
 		IsRead:    false,
 	}
 
-	err := alertRepo.Create(alert)
+	err := alertRepo.Create(ctx, alert)
 	if err != nil {
 		t.Fatalf("Failed to create alert: %v", err)
 	}
@@ -36,6 +38,7 @@ func TestSQLiteAlertRepository_Create(t *testing.T) {
 }
 
 func TestSQLiteAlertRepository_GetByID(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -54,13 +57,13 @@ func TestSQLiteAlertRepository_GetByID(t *testing.T) {
 		IsRead:    false,
 	}
 
-	err := alertRepo.Create(alert)
+	err := alertRepo.Create(ctx, alert)
 	if err != nil {
 		t.Fatalf("Failed to create alert: %v", err)
 	}
 
 	// Retrieve the alert
-	retrieved, err := alertRepo.GetByID(alert.ID)
+	retrieved, err := alertRepo.GetByID(ctx, alert.ID)
 	if err != nil {
 		t.Fatalf("Failed to get alert by ID: %v", err)
 	}
@@ -80,6 +83,7 @@ func TestSQLiteAlertRepository_GetByID(t *testing.T) {
 }
 
 func TestSQLiteAlertRepository_GetUnread(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -106,19 +110,19 @@ func TestSQLiteAlertRepository_GetUnread(t *testing.T) {
 	}
 
 	for _, alert := range unreadAlerts {
-		err := alertRepo.Create(alert)
+		err := alertRepo.Create(ctx, alert)
 		if err != nil {
 			t.Fatalf("Failed to create unread alert: %v", err)
 		}
 	}
 
-	err := alertRepo.Create(readAlert)
+	err := alertRepo.Create(ctx, readAlert)
 	if err != nil {
 		t.Fatalf("Failed to create read alert: %v", err)
 	}
 
 	// Get unread alerts
-	unread, err := alertRepo.GetUnread()
+	unread, err := alertRepo.GetUnread(ctx)
 	if err != nil {
 		t.Fatalf("Failed to get unread alerts: %v", err)
 	}
@@ -136,6 +140,7 @@ func TestSQLiteAlertRepository_GetUnread(t *testing.T) {
 }
 
 func TestSQLiteAlertRepository_GetByUser(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -152,7 +157,7 @@ func TestSQLiteAlertRepository_GetByUser(t *testing.T) {
 		RegisteredAt: time.Now(),
 		IsActive:     true,
 	}
-	err := userRepo.Create(user2)
+	err := userRepo.Create(ctx, user2)
 	if err != nil {
 		t.Fatalf("Failed to create second user: %v", err)
 	}
@@ -173,19 +178,19 @@ func TestSQLiteAlertRepository_GetByUser(t *testing.T) {
 	}
 
 	for _, alert := range user1Alerts {
-		err := alertRepo.Create(alert)
+		err := alertRepo.Create(ctx, alert)
 		if err != nil {
 			t.Fatalf("Failed to create user1 alert: %v", err)
 		}
 	}
 
-	err = alertRepo.Create(user2Alert)
+	err = alertRepo.Create(ctx, user2Alert)
 	if err != nil {
 		t.Fatalf("Failed to create user2 alert: %v", err)
 	}
 
 	// Get alerts for user1
-	user1AlertsRetrieved, err := alertRepo.GetByUser(user1.ID)
+	user1AlertsRetrieved, err := alertRepo.GetByUser(ctx, user1.ID)
 	if err != nil {
 		t.Fatalf("Failed to get alerts by user: %v", err)
 	}
@@ -203,6 +208,7 @@ func TestSQLiteAlertRepository_GetByUser(t *testing.T) {
 }
 
 func TestSQLiteAlertRepository_GetAll(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -220,14 +226,14 @@ func TestSQLiteAlertRepository_GetAll(t *testing.T) {
 	}
 
 	for _, alert := range alerts {
-		err := alertRepo.Create(alert)
+		err := alertRepo.Create(ctx, alert)
 		if err != nil {
 			t.Fatalf("Failed to create alert: %v", err)
 		}
 	}
 
 	// Get all alerts
-	all, err := alertRepo.GetAll()
+	all, err := alertRepo.GetAll(ctx)
 	if err != nil {
 		t.Fatalf("Failed to get all alerts: %v", err)
 	}
@@ -238,6 +244,7 @@ func TestSQLiteAlertRepository_GetAll(t *testing.T) {
 }
 
 func TestSQLiteAlertRepository_MarkAsRead(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -256,19 +263,19 @@ func TestSQLiteAlertRepository_MarkAsRead(t *testing.T) {
 		IsRead:    false,
 	}
 
-	err := alertRepo.Create(alert)
+	err := alertRepo.Create(ctx, alert)
 	if err != nil {
 		t.Fatalf("Failed to create alert: %v", err)
 	}
 
 	// Mark as read
-	err = alertRepo.MarkAsRead(alert.ID)
+	err = alertRepo.MarkAsRead(ctx, alert.ID)
 	if err != nil {
 		t.Fatalf("Failed to mark alert as read: %v", err)
 	}
 
 	// Retrieve and verify
-	retrieved, err := alertRepo.GetByID(alert.ID)
+	retrieved, err := alertRepo.GetByID(ctx, alert.ID)
 	if err != nil {
 		t.Fatalf("Failed to get alert after marking as read: %v", err)
 	}
@@ -279,6 +286,7 @@ func TestSQLiteAlertRepository_MarkAsRead(t *testing.T) {
 }
 
 func TestSQLiteAlertRepository_Delete(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -297,20 +305,94 @@ func TestSQLiteAlertRepository_Delete(t *testing.T) {
 		IsRead:    false,
 	}
 
-	err := alertRepo.Create(alert)
+	err := alertRepo.Create(ctx, alert)
 	if err != nil {
 		t.Fatalf("Failed to create alert: %v", err)
 	}
 
 	// Delete the alert
-	err = alertRepo.Delete(alert.ID)
+	err = alertRepo.Delete(ctx, alert.ID)
 	if err != nil {
 		t.Fatalf("Failed to delete alert: %v", err)
 	}
 
 	// Verify the alert is deleted
-	_, err = alertRepo.GetByID(alert.ID)
+	_, err = alertRepo.GetByID(ctx, alert.ID)
 	if err == nil {
 		t.Error("Expected error when getting deleted alert, but got none")
 	}
-}
\ No newline at end of file
+}
+
+func TestSQLiteAlertRepository_List(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewSQLiteUserRepository(db)
+	gameRepo := NewSQLiteGameRepository(db)
+	alertRepo := NewSQLiteAlertRepository(db)
+
+	user, game := createTestUserAndGame(t, userRepo, gameRepo)
+
+	now := time.Now()
+	alerts := []*models.Alert{
+		{UserID: user.ID, GameID: game.ID, Type: "overdue", Message: "Overdue", CreatedAt: now.Add(-48 * time.Hour), IsRead: true},
+		{UserID: user.ID, GameID: game.ID, Type: "reminder", Message: "Due soon", CreatedAt: now.Add(-24 * time.Hour)},
+		{UserID: user.ID, GameID: game.ID, Type: "overdue", Message: "Still overdue", CreatedAt: now},
+	}
+	for _, alert := range alerts {
+		if err := alertRepo.Create(ctx, alert); err != nil {
+			t.Fatalf("Failed to create alert: %v", err)
+		}
+	}
+
+	unread := false
+	since := now.Add(-36 * time.Hour)
+	tests := []struct {
+		name   string
+		filter models.AlertFilter
+		want   []int
+		total  int
+	}{
+		{"default newest first", models.AlertFilter{}, []int{alerts[2].ID, alerts[1].ID, alerts[0].ID}, 3},
+		{"oldest first", models.AlertFilter{ListOptions: models.ListOptions{Order: models.SortAsc}}, []int{alerts[0].ID, alerts[1].ID, alerts[2].ID}, 3},
+		{"type", models.AlertFilter{Type: "overdue"}, []int{alerts[2].ID, alerts[0].ID}, 2},
+		{"unread", models.AlertFilter{Read: &unread}, []int{alerts[2].ID, alerts[1].ID}, 2},
+		{"created since", models.AlertFilter{CreatedFrom: &since}, []int{alerts[2].ID, alerts[1].ID}, 2},
+		{"search message", models.AlertFilter{Search: "still"}, []int{alerts[2].ID}, 1},
+		{"search user", models.AlertFilter{Search: "test user"}, []int{alerts[2].ID, alerts[1].ID, alerts[0].ID}, 3},
+		{"search game", models.AlertFilter{Search: "TEST GAME"}, []int{alerts[2].ID, alerts[1].ID, alerts[0].ID}, 3},
+		{"search nothing", models.AlertFilter{Search: "azul"}, []int{}, 0},
+		{"page", models.AlertFilter{ListOptions: models.ListOptions{Page: 2, PerPage: 2}}, []int{alerts[0].ID}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			if err := models.ValidateAlertFilter(&filter); err != nil {
+				t.Fatalf("Invalid filter: %v", err)
+			}
+
+			retrieved, err := alertRepo.List(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to list alerts: %v", err)
+			}
+			if len(retrieved) != len(tt.want) {
+				t.Fatalf("Expected %d alerts, got %d", len(tt.want), len(retrieved))
+			}
+			for i, alert := range retrieved {
+				if alert.ID != tt.want[i] {
+					t.Errorf("Expected alert %d at position %d, got %d", tt.want[i], i, alert.ID)
+				}
+			}
+
+			total, err := alertRepo.Count(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to count alerts: %v", err)
+			}
+			if total != tt.total {
+				t.Errorf("Expected total %d, got %d", tt.total, total)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// SQLiteAuditRepository implements AuditRepository using SQLite
+type SQLiteAuditRepository struct {
+	db database.Querier
+}
+
+// NewSQLiteAuditRepository creates a new SQLite audit repository
+func NewSQLiteAuditRepository(db *database.DB) AuditRepository {
+	return &SQLiteAuditRepository{db: db}
+}
+
+// Create appends an event to the audit log. Events are never updated or
+// deleted; the table rejects both.
+func (r *SQLiteAuditRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (library_id, actor_id, actor_name, action, entity_type, entity_id, before_json, after_json, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query, database.LibraryOf(r.db), event.ActorID, event.ActorName, event.Action, event.EntityType,
+		event.EntityID, nullableJSON(event.Before), nullableJSON(event.After),
+		event.CreatedAt.UTC()).Scan(&event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves the events matching filter, most recent first. A limit of
+// zero or less returns all matching events.
+func (r *SQLiteAuditRepository) List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	where, args := auditWhere(r.db, filter)
+	query := `
+		SELECT id, actor_id, actor_name, action, entity_type, entity_id, before_json, after_json, created_at
+		FROM audit_events` + where + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?`
+
+	limit, offset := filter.Limit, filter.Offset
+	if limit <= 0 {
+		limit = math.MaxInt64 // no limit, on both databases
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.AuditEvent
+	for rows.Next() {
+		event := &models.AuditEvent{}
+		var actorID sql.NullInt64
+		var before, after sql.NullString
+		err := rows.Scan(
+			&event.ID, &actorID, &event.ActorName, &event.Action, &event.EntityType,
+			&event.EntityID, &before, &after, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+
+		if actorID.Valid {
+			id := int(actorID.Int64)
+			event.ActorID = &id
+		}
+		if before.Valid {
+			event.Before = json.RawMessage(before.String)
+		}
+		if after.Valid {
+			event.After = json.RawMessage(after.String)
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit events: %w", err)
+	}
+
+	return events, nil
+}
+
+// Count returns the number of events matching filter, ignoring its limit and offset
+func (r *SQLiteAuditRepository) Count(ctx context.Context, filter models.AuditFilter) (int, error) {
+	where, args := auditWhere(r.db, filter)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_events`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	return count, nil
+}
+
+// auditWhere builds the WHERE clause selecting the events of the library of
+// q matching filter
+func auditWhere(q database.Querier, filter models.AuditFilter) (string, []any) {
+	conditions := []string{"library_id = ?"}
+	args := []any{database.LibraryOf(q)}
+
+	if filter.ActorID != nil {
+		conditions = append(conditions, "actor_id = ?")
+		args = append(args, *filter.ActorID)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.EntityType != "" {
+		conditions = append(conditions, "entity_type = ?")
+		args = append(args, filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		conditions = append(conditions, "entity_id = ?")
+		args = append(args, filter.EntityID)
+	}
+	if filter.Since != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.Since.UTC())
+	}
+	if filter.Until != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.Until.UTC())
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// nullableJSON stores an absent entity state as NULL
+func nullableJSON(data json.RawMessage) any {
+	if data == nil {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"testing"
+	"time"
+)
+
+func createTestAuditEvent(t *testing.T, repo AuditRepository, actor *models.User, action, entityType, entityID string, createdAt time.Time) *models.AuditEvent {
+	ctx := context.Background()
+	event, err := models.NewAuditEvent(actor, action, entityType, entityID,
+		map[string]string{"name": "before"}, map[string]string{"name": "after"})
+	if err != nil {
+		t.Fatalf("Failed to build audit event: %v", err)
+	}
+	event.CreatedAt = createdAt
+
+	if err := repo.Create(ctx, event); err != nil {
+		t.Fatalf("Failed to create audit event: %v", err)
+	}
+
+	return event
+}
+
+func TestSQLiteAuditRepository_CreateAndList(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteAuditRepository(db)
+	actor := &models.User{ID: 4, Name: "Alice"}
+
+	event := createTestAuditEvent(t, repo, actor, models.AuditActionUpdate, models.AuditEntityGame, "12", time.Now())
+	if event.ID == 0 {
+		t.Fatal("Expected audit event ID to be set after creation")
+	}
+	createTestAuditEvent(t, repo, nil, models.AuditActionCleanup, models.AuditEntityAlert, "", time.Now().Add(time.Second))
+
+	events, err := repo.List(ctx, models.AuditFilter{})
+	if err != nil {
+		t.Fatalf("Failed to list audit events: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 audit events, got %d", len(events))
+	}
+
+	// Most recent first
+	if events[0].ActorID != nil || events[0].ActorName != models.AuditSystemActor {
+		t.Errorf("Expected system event first, got %+v", events[0])
+	}
+
+	stored := events[1]
+	if stored.ActorID == nil || *stored.ActorID != 4 || stored.ActorName != "Alice" {
+		t.Errorf("Expected actor Alice (4), got %v %q", stored.ActorID, stored.ActorName)
+	}
+	if string(stored.Before) != `{"name":"before"}` || string(stored.After) != `{"name":"after"}` {
+		t.Errorf("Unexpected states: before=%s after=%s", stored.Before, stored.After)
+	}
+	if stored.EntityType != models.AuditEntityGame || stored.EntityID != "12" {
+		t.Errorf("Expected game 12, got %s %s", stored.EntityType, stored.EntityID)
+	}
+}
+
+func TestSQLiteAuditRepository_Filter(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteAuditRepository(db)
+	alice := &models.User{ID: 1, Name: "Alice"}
+	bob := &models.User{ID: 2, Name: "Bob"}
+	base := time.Now().Add(-time.Hour)
+
+	createTestAuditEvent(t, repo, alice, models.AuditActionCreate, models.AuditEntityGame, "1", base)
+	createTestAuditEvent(t, repo, alice, models.AuditActionDelete, models.AuditEntityGame, "1", base.Add(10*time.Minute))
+	createTestAuditEvent(t, repo, bob, models.AuditActionExtend, models.AuditEntityBorrowing, "5", base.Add(20*time.Minute))
+	createTestAuditEvent(t, repo, bob, models.AuditActionDelete, models.AuditEntityAlert, "9", base.Add(30*time.Minute))
+
+	bobID := bob.ID
+	since := base.Add(5 * time.Minute)
+	until := base.Add(25 * time.Minute)
+
+	tests := []struct {
+		name   string
+		filter models.AuditFilter
+		want   int
+	}{
+		{"all", models.AuditFilter{}, 4},
+		{"by actor", models.AuditFilter{ActorID: &bobID}, 2},
+		{"by action", models.AuditFilter{Action: models.AuditActionDelete}, 2},
+		{"by entity", models.AuditFilter{EntityType: models.AuditEntityGame, EntityID: "1"}, 2},
+		{"by period", models.AuditFilter{Since: &since, Until: &until}, 2},
+		{"combined", models.AuditFilter{ActorID: &bobID, Action: models.AuditActionDelete}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := repo.List(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("Failed to list audit events: %v", err)
+			}
+			if len(events) != tt.want {
+				t.Errorf("Expected %d events, got %d", tt.want, len(events))
+			}
+
+			count, err := repo.Count(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("Failed to count audit events: %v", err)
+			}
+			if count != tt.want {
+				t.Errorf("Expected count %d, got %d", tt.want, count)
+			}
+		})
+	}
+
+	page, err := repo.List(ctx, models.AuditFilter{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("Failed to list audit events: %v", err)
+	}
+	if len(page) != 2 || page[0].Action != models.AuditActionExtend {
+		t.Errorf("Expected second page to start with the extension, got %d events", len(page))
+	}
+}
+
+func TestSQLiteAuditRepository_AppendOnly(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteAuditRepository(db)
+	event := createTestAuditEvent(t, repo, nil, models.AuditActionCreate, models.AuditEntityUser, "1", time.Now())
+
+	if _, err := db.Exec(`UPDATE audit_events SET action = 'delete' WHERE id = ?`, event.ID); err == nil {
+		t.Error("Expected updating an audit event to fail")
+	}
+	if _, err := db.Exec(`DELETE FROM audit_events WHERE id = ?`, event.ID); err == nil {
+		t.Error("Expected deleting an audit event to fail")
+	}
+
+	count, err := repo.Count(ctx, models.AuditFilter{})
+	if err != nil {
+		t.Fatalf("Failed to count audit events: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected the event to be kept, got %d events", count)
+	}
+}
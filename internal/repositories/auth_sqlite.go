@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// libraryUsers restricts sessions and API tokens to those of the users of a
+// library
+const libraryUsers = `user_id IN (SELECT id FROM users WHERE library_id = ?)`
+
+// SQLiteAuthRepository implements AuthRepository using SQLite
+type SQLiteAuthRepository struct {
+	db database.Querier
+}
+
+// NewSQLiteAuthRepository creates a new SQLite auth repository on a
+// connection or a transaction
+func NewSQLiteAuthRepository(db database.Querier) AuthRepository {
+	return &SQLiteAuthRepository{db: db}
+}
+
+// SetPasswordHash stores the bcrypt hash of a user's password
+func (r *SQLiteAuthRepository) SetPasswordHash(ctx context.Context, userID int, hash string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET password_hash = ? WHERE id = ? AND library_id = ?`, hash, userID, database.LibraryOf(r.db))
+	if err != nil {
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return models.NotFound("user_not_found", userID)
+	}
+
+	return nil
+}
+
+// GetPasswordHash retrieves the password hash of a user, empty when no password is set
+func (r *SQLiteAuthRepository) GetPasswordHash(ctx context.Context, userID int) (string, error) {
+	var hash string
+	err := r.db.QueryRowContext(ctx, `SELECT password_hash FROM users WHERE id = ? AND library_id = ?`, userID, database.LibraryOf(r.db)).Scan(&hash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", models.NotFound("user_not_found", userID)
+		}
+		return "", fmt.Errorf("failed to get password: %w", err)
+	}
+
+	return hash, nil
+}
+
+// CountActiveByRole counts the active users holding a role
+func (r *SQLiteAuthRepository) CountActiveByRole(ctx context.Context, role string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE role = ? AND is_active = TRUE AND library_id = ?`,
+		role, database.LibraryOf(r.db)).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users by role: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateSession inserts a new web session
+func (r *SQLiteAuthRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	query := `
+		INSERT INTO sessions (token_hash, user_id, created_at, expires_at)
+		VALUES (?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query, session.TokenHash, session.UserID,
+		session.CreatedAt.UTC(), session.ExpiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// GetSession retrieves a session by the hash of its token
+func (r *SQLiteAuthRepository) GetSession(ctx context.Context, tokenHash string) (*models.Session, error) {
+	query := `
+		SELECT token_hash, user_id, created_at, expires_at
+		FROM sessions
+		WHERE token_hash = ? AND ` + libraryUsers
+
+	session := &models.Session{}
+	err := r.db.QueryRowContext(ctx, query, tokenHash, database.LibraryOf(r.db)).Scan(
+		&session.TokenHash, &session.UserID, &session.CreatedAt, &session.ExpiresAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("unknown_session")
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// DeleteSession removes a session; removing an unknown session is not an error
+func (r *SQLiteAuthRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE token_hash = ? AND `+libraryUsers, tokenHash, database.LibraryOf(r.db)); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUserSessions signs a user out of every browser
+func (r *SQLiteAuthRepository) DeleteUserSessions(ctx context.Context, userID int) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = ? AND `+libraryUsers, userID, database.LibraryOf(r.db)); err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredSessions removes the sessions that expired before now and
+// returns how many were removed
+func (r *SQLiteAuthRepository) DeleteExpiredSessions(ctx context.Context, now time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE expires_at <= ? AND `+libraryUsers, now.UTC(), database.LibraryOf(r.db))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+const apiTokenColumns = `id, user_id, name, token_hash, created_at, last_used_at`
+
+// CreateAPIToken inserts a new API token
+func (r *SQLiteAuthRepository) CreateAPIToken(ctx context.Context, token *models.APIToken) error {
+	query := `
+		INSERT INTO api_tokens (user_id, name, token_hash, created_at)
+		VALUES (?, ?, ?, ?)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query, token.UserID, token.Name, token.TokenHash, token.CreatedAt.UTC()).Scan(&token.ID)
+	if err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return models.NotFound("user_not_found", token.UserID)
+		}
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	return nil
+}
+
+// GetAPITokenByHash retrieves an API token by the hash of its value
+func (r *SQLiteAuthRepository) GetAPITokenByHash(ctx context.Context, tokenHash string) (*models.APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE token_hash = ? AND ` + libraryUsers
+
+	token, err := scanAPIToken(r.db.QueryRowContext(ctx, query, tokenHash, database.LibraryOf(r.db)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("unknown_api_token")
+		}
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetAPITokenByID retrieves an API token by its ID
+func (r *SQLiteAuthRepository) GetAPITokenByID(ctx context.Context, id int) (*models.APIToken, error) {
+	query := `SELECT ` + apiTokenColumns + ` FROM api_tokens WHERE id = ? AND ` + libraryUsers
+
+	token, err := scanAPIToken(r.db.QueryRowContext(ctx, query, id, database.LibraryOf(r.db)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("api_token_not_found", id)
+		}
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetAPITokensByUser retrieves the API tokens of a user, newest first
+func (r *SQLiteAuthRepository) GetAPITokensByUser(ctx context.Context, userID int) ([]*models.APIToken, error) {
+	query := `
+		SELECT ` + apiTokenColumns + `
+		FROM api_tokens
+		WHERE user_id = ? AND ` + libraryUsers + `
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, database.LibraryOf(r.db))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*models.APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating API tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// TouchAPIToken records when an API token was last used
+func (r *SQLiteAuthRepository) TouchAPIToken(ctx context.Context, id int, usedAt time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE api_tokens SET last_used_at = ? WHERE id = ? AND `+libraryUsers, usedAt.UTC(), id, database.LibraryOf(r.db)); err != nil {
+		return fmt.Errorf("failed to update API token: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAPIToken revokes an API token
+func (r *SQLiteAuthRepository) DeleteAPIToken(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM api_tokens WHERE id = ? AND `+libraryUsers, id, database.LibraryOf(r.db))
+	if err != nil {
+		return fmt.Errorf("failed to delete API token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return models.NotFound("api_token_not_found", id)
+	}
+
+	return nil
+}
+
+// scanAPIToken scans one api_tokens row selected with apiTokenColumns
+func scanAPIToken(row rowScanner) (*models.APIToken, error) {
+	token := &models.APIToken{}
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.TokenHash, &token.CreatedAt, &token.LastUsedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"testing"
+	"time"
+)
+
+func createAuthTestUser(t *testing.T, repo UserRepository, email, role string) *models.User {
+	ctx := context.Background()
+	t.Helper()
+
+	user := &models.User{Name: "Auth User", Email: email, RegisteredAt: time.Now(), IsActive: true, Role: role}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	return user
+}
+
+func TestSQLiteUserRepository_Role(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteUserRepository(db)
+
+	user := createAuthTestUser(t, repo, "member@example.com", "")
+	if user.Role != models.RoleMember {
+		t.Errorf("Expected default role %q, got %q", models.RoleMember, user.Role)
+	}
+
+	user.Role = models.RoleLibrarian
+	if err := repo.Update(ctx, user); err != nil {
+		t.Fatalf("Failed to update user: %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if retrieved.Role != models.RoleLibrarian {
+		t.Errorf("Expected role %q, got %q", models.RoleLibrarian, retrieved.Role)
+	}
+}
+
+func TestSQLiteAuthRepository_Passwords(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	users := NewSQLiteUserRepository(db)
+	repo := NewSQLiteAuthRepository(db)
+
+	user := createAuthTestUser(t, users, "admin@example.com", models.RoleAdmin)
+
+	hash, err := repo.GetPasswordHash(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Failed to get password hash: %v", err)
+	}
+	if hash != "" {
+		t.Errorf("Expected no password for a new user, got %q", hash)
+	}
+
+	if err := repo.SetPasswordHash(ctx, user.ID, "hashed"); err != nil {
+		t.Fatalf("Failed to set password hash: %v", err)
+	}
+	if hash, _ := repo.GetPasswordHash(ctx, user.ID); hash != "hashed" {
+		t.Errorf("Expected stored hash, got %q", hash)
+	}
+
+	if err := repo.SetPasswordHash(ctx, 999, "hashed"); err == nil {
+		t.Error("Expected error when setting the password of an unknown user")
+	}
+
+	count, err := repo.CountActiveByRole(ctx, models.RoleAdmin)
+	if err != nil {
+		t.Fatalf("Failed to count admins: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 admin, got %d", count)
+	}
+}
+
+func TestSQLiteAuthRepository_Sessions(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	users := NewSQLiteUserRepository(db)
+	repo := NewSQLiteAuthRepository(db)
+
+	user := createAuthTestUser(t, users, "session@example.com", models.RoleMember)
+	now := time.Now()
+
+	active := &models.Session{TokenHash: "active", UserID: user.ID, CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	expired := &models.Session{TokenHash: "expired", UserID: user.ID, CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour)}
+	for _, session := range []*models.Session{active, expired} {
+		if err := repo.CreateSession(ctx, session); err != nil {
+			t.Fatalf("Failed to create session: %v", err)
+		}
+	}
+
+	retrieved, err := repo.GetSession(ctx, "active")
+	if err != nil {
+		t.Fatalf("Failed to get session: %v", err)
+	}
+	if retrieved.UserID != user.ID || retrieved.IsExpired(now) {
+		t.Errorf("Unexpected session %+v", retrieved)
+	}
+
+	removed, err := repo.DeleteExpiredSessions(ctx, now)
+	if err != nil {
+		t.Fatalf("Failed to delete expired sessions: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 expired session removed, got %d", removed)
+	}
+
+	if err := repo.DeleteUserSessions(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to delete user sessions: %v", err)
+	}
+	if _, err := repo.GetSession(ctx, "active"); err == nil {
+		t.Error("Expected session to be gone after signing the user out")
+	}
+}
+
+func TestSQLiteAuthRepository_APITokens(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	users := NewSQLiteUserRepository(db)
+	repo := NewSQLiteAuthRepository(db)
+
+	user := createAuthTestUser(t, users, "script@example.com", models.RoleLibrarian)
+
+	token := &models.APIToken{UserID: user.ID, Name: "inventory script", TokenHash: "abc", CreatedAt: time.Now()}
+	if err := repo.CreateAPIToken(ctx, token); err != nil {
+		t.Fatalf("Failed to create API token: %v", err)
+	}
+	if token.ID == 0 {
+		t.Error("Expected token ID to be set after creation")
+	}
+
+	if err := repo.CreateAPIToken(ctx, &models.APIToken{UserID: 999, Name: "orphan", TokenHash: "def", CreatedAt: time.Now()}); err == nil {
+		t.Error("Expected error when creating a token for an unknown user")
+	}
+
+	retrieved, err := repo.GetAPITokenByHash(ctx, "abc")
+	if err != nil {
+		t.Fatalf("Failed to get API token: %v", err)
+	}
+	if retrieved.ID != token.ID || retrieved.LastUsedAt != nil {
+		t.Errorf("Unexpected token %+v", retrieved)
+	}
+
+	if err := repo.TouchAPIToken(ctx, token.ID, time.Now()); err != nil {
+		t.Fatalf("Failed to touch API token: %v", err)
+	}
+	retrieved, err = repo.GetAPITokenByID(ctx, token.ID)
+	if err != nil {
+		t.Fatalf("Failed to get API token by ID: %v", err)
+	}
+	if retrieved.LastUsedAt == nil {
+		t.Error("Expected last used time to be recorded")
+	}
+
+	tokens, err := repo.GetAPITokensByUser(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Failed to list API tokens: %v", err)
+	}
+	if len(tokens) != 1 {
+		t.Errorf("Expected 1 token, got %d", len(tokens))
+	}
+
+	if err := repo.DeleteAPIToken(ctx, token.ID); err != nil {
+		t.Fatalf("Failed to delete API token: %v", err)
+	}
+	if err := repo.DeleteAPIToken(ctx, token.ID); err == nil {
+		t.Error("Expected error when deleting a revoked token")
+	}
+}
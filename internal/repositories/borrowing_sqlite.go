@@ -3,14 +3,16 @@ package repositories
 import (
 	"board-game-library/internal/models"
 	"board-game-library/pkg/database"
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 )
 
 // SQLiteBorrowingRepository implements BorrowingRepository using SQLite
 type SQLiteBorrowingRepository struct {
-	db *database.DB
+	db database.Querier
 }
 
 // NewSQLiteBorrowingRepository creates a new SQLite borrowing repository
@@ -19,14 +21,14 @@ func NewSQLiteBorrowingRepository(db *database.DB) BorrowingRepository {
 }
 
 // Create inserts a new borrowing record into the database
-func (r *SQLiteBorrowingRepository) Create(borrowing *models.Borrowing) error {
+func (r *SQLiteBorrowingRepository) Create(ctx context.Context, borrowing *models.Borrowing) error {
 	query := `
-		INSERT INTO borrowings (user_id, game_id, borrowed_at, due_date, returned_at, is_overdue)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO borrowings (library_id, user_id, game_id, copy_id, borrowed_at, due_date, returned_at, is_overdue, extension_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 		RETURNING id`
 	
-	err := r.db.QueryRow(query, borrowing.UserID, borrowing.GameID, borrowing.BorrowedAt,
-		borrowing.DueDate, borrowing.ReturnedAt, borrowing.IsOverdue).Scan(&borrowing.ID)
+	err := r.db.QueryRowContext(ctx, query, database.LibraryOf(r.db), borrowing.UserID, borrowing.GameID, borrowing.CopyID, borrowing.BorrowedAt,
+		borrowing.DueDate, borrowing.ReturnedAt, borrowing.IsOverdue, borrowing.ExtensionCount).Scan(&borrowing.ID)
 	if err != nil {
 		return fmt.Errorf("failed to create borrowing: %w", err)
 	}
@@ -35,21 +37,21 @@ func (r *SQLiteBorrowingRepository) Create(borrowing *models.Borrowing) error {
 }
 
 // GetByID retrieves a borrowing record by its ID
-func (r *SQLiteBorrowingRepository) GetByID(id int) (*models.Borrowing, error) {
+func (r *SQLiteBorrowingRepository) GetByID(ctx context.Context, id int) (*models.Borrowing, error) {
 	query := `
-		SELECT id, user_id, game_id, borrowed_at, due_date, returned_at, is_overdue
+		SELECT id, user_id, game_id, copy_id, borrowed_at, due_date, returned_at, is_overdue, extension_count
 		FROM borrowings
-		WHERE id = ?`
+		WHERE id = ? AND library_id = ?`
 	
 	borrowing := &models.Borrowing{}
-	err := r.db.QueryRow(query, id).Scan(
-		&borrowing.ID, &borrowing.UserID, &borrowing.GameID,
-		&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue,
+	err := r.db.QueryRowContext(ctx, query, id, database.LibraryOf(r.db)).Scan(
+		&borrowing.ID, &borrowing.UserID, &borrowing.GameID, &borrowing.CopyID,
+		&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue, &borrowing.ExtensionCount,
 	)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("borrowing with id %d not found", id)
+			return nil, models.NotFound("borrowing_not_found", id)
 		}
 		return nil, fmt.Errorf("failed to get borrowing by id: %w", err)
 	}
@@ -58,14 +60,14 @@ func (r *SQLiteBorrowingRepository) GetByID(id int) (*models.Borrowing, error) {
 }
 
 // GetActiveByUser retrieves all active borrowings for a user
-func (r *SQLiteBorrowingRepository) GetActiveByUser(userID int) ([]*models.Borrowing, error) {
+func (r *SQLiteBorrowingRepository) GetActiveByUser(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	query := `
-		SELECT id, user_id, game_id, borrowed_at, due_date, returned_at, is_overdue
+		SELECT id, user_id, game_id, copy_id, borrowed_at, due_date, returned_at, is_overdue, extension_count
 		FROM borrowings
-		WHERE user_id = ? AND returned_at IS NULL
+		WHERE user_id = ? AND returned_at IS NULL AND library_id = ?
 		ORDER BY borrowed_at DESC`
 	
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.db.QueryContext(ctx, query, userID, database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active borrowings by user: %w", err)
 	}
@@ -75,8 +77,8 @@ func (r *SQLiteBorrowingRepository) GetActiveByUser(userID int) ([]*models.Borro
 	for rows.Next() {
 		borrowing := &models.Borrowing{}
 		err := rows.Scan(
-			&borrowing.ID, &borrowing.UserID, &borrowing.GameID,
-			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue,
+			&borrowing.ID, &borrowing.UserID, &borrowing.GameID, &borrowing.CopyID,
+			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue, &borrowing.ExtensionCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan borrowing: %w", err)
@@ -92,14 +94,14 @@ func (r *SQLiteBorrowingRepository) GetActiveByUser(userID int) ([]*models.Borro
 }
 
 // GetByGame retrieves all borrowings for a specific game
-func (r *SQLiteBorrowingRepository) GetByGame(gameID int) ([]*models.Borrowing, error) {
+func (r *SQLiteBorrowingRepository) GetByGame(ctx context.Context, gameID int) ([]*models.Borrowing, error) {
 	query := `
-		SELECT id, user_id, game_id, borrowed_at, due_date, returned_at, is_overdue
+		SELECT id, user_id, game_id, copy_id, borrowed_at, due_date, returned_at, is_overdue, extension_count
 		FROM borrowings
-		WHERE game_id = ?
+		WHERE game_id = ? AND library_id = ?
 		ORDER BY borrowed_at DESC`
 	
-	rows, err := r.db.Query(query, gameID)
+	rows, err := r.db.QueryContext(ctx, query, gameID, database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get borrowings by game: %w", err)
 	}
@@ -109,8 +111,8 @@ func (r *SQLiteBorrowingRepository) GetByGame(gameID int) ([]*models.Borrowing,
 	for rows.Next() {
 		borrowing := &models.Borrowing{}
 		err := rows.Scan(
-			&borrowing.ID, &borrowing.UserID, &borrowing.GameID,
-			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue,
+			&borrowing.ID, &borrowing.UserID, &borrowing.GameID, &borrowing.CopyID,
+			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue, &borrowing.ExtensionCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan borrowing: %w", err)
@@ -126,14 +128,14 @@ func (r *SQLiteBorrowingRepository) GetByGame(gameID int) ([]*models.Borrowing,
 }
 
 // GetOverdue retrieves all overdue borrowings
-func (r *SQLiteBorrowingRepository) GetOverdue() ([]*models.Borrowing, error) {
+func (r *SQLiteBorrowingRepository) GetOverdue(ctx context.Context) ([]*models.Borrowing, error) {
 	query := `
-		SELECT id, user_id, game_id, borrowed_at, due_date, returned_at, is_overdue
+		SELECT id, user_id, game_id, copy_id, borrowed_at, due_date, returned_at, is_overdue, extension_count
 		FROM borrowings
-		WHERE returned_at IS NULL AND due_date < ?
+		WHERE returned_at IS NULL AND due_date < ? AND library_id = ?
 		ORDER BY due_date ASC`
 	
-	rows, err := r.db.Query(query, time.Now())
+	rows, err := r.db.QueryContext(ctx, query, time.Now(), database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get overdue borrowings: %w", err)
 	}
@@ -143,8 +145,8 @@ func (r *SQLiteBorrowingRepository) GetOverdue() ([]*models.Borrowing, error) {
 	for rows.Next() {
 		borrowing := &models.Borrowing{}
 		err := rows.Scan(
-			&borrowing.ID, &borrowing.UserID, &borrowing.GameID,
-			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue,
+			&borrowing.ID, &borrowing.UserID, &borrowing.GameID, &borrowing.CopyID,
+			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue, &borrowing.ExtensionCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan borrowing: %w", err)
@@ -160,14 +162,14 @@ func (r *SQLiteBorrowingRepository) GetOverdue() ([]*models.Borrowing, error) {
 }
 
 // Update modifies an existing borrowing record
-func (r *SQLiteBorrowingRepository) Update(borrowing *models.Borrowing) error {
+func (r *SQLiteBorrowingRepository) Update(ctx context.Context, borrowing *models.Borrowing) error {
 	query := `
 		UPDATE borrowings
-		SET user_id = ?, game_id = ?, borrowed_at = ?, due_date = ?, returned_at = ?, is_overdue = ?
-		WHERE id = ?`
+		SET user_id = ?, game_id = ?, copy_id = ?, borrowed_at = ?, due_date = ?, returned_at = ?, is_overdue = ?, extension_count = ?
+		WHERE id = ? AND library_id = ?`
 	
-	result, err := r.db.Exec(query, borrowing.UserID, borrowing.GameID, borrowing.BorrowedAt,
-		borrowing.DueDate, borrowing.ReturnedAt, borrowing.IsOverdue, borrowing.ID)
+	result, err := r.db.ExecContext(ctx, query, borrowing.UserID, borrowing.GameID, borrowing.CopyID, borrowing.BorrowedAt,
+		borrowing.DueDate, borrowing.ReturnedAt, borrowing.IsOverdue, borrowing.ExtensionCount, borrowing.ID, database.LibraryOf(r.db))
 	if err != nil {
 		return fmt.Errorf("failed to update borrowing: %w", err)
 	}
@@ -178,20 +180,20 @@ func (r *SQLiteBorrowingRepository) Update(borrowing *models.Borrowing) error {
 	}
 	
 	if rowsAffected == 0 {
-		return fmt.Errorf("borrowing with id %d not found", borrowing.ID)
+		return models.NotFound("borrowing_not_found", borrowing.ID)
 	}
 	
 	return nil
 }
 
 // ReturnGame marks a borrowing as returned
-func (r *SQLiteBorrowingRepository) ReturnGame(borrowingID int) error {
+func (r *SQLiteBorrowingRepository) ReturnGame(ctx context.Context, borrowingID int) error {
 	query := `
 		UPDATE borrowings
 		SET returned_at = ?
-		WHERE id = ? AND returned_at IS NULL`
+		WHERE id = ? AND returned_at IS NULL AND library_id = ?`
 	
-	result, err := r.db.Exec(query, time.Now(), borrowingID)
+	result, err := r.db.ExecContext(ctx, query, time.Now(), borrowingID, database.LibraryOf(r.db))
 	if err != nil {
 		return fmt.Errorf("failed to return game: %w", err)
 	}
@@ -202,20 +204,21 @@ func (r *SQLiteBorrowingRepository) ReturnGame(borrowingID int) error {
 	}
 	
 	if rowsAffected == 0 {
-		return fmt.Errorf("borrowing with id %d not found or already returned", borrowingID)
+		return models.NotFound("open_borrowing_not_found", borrowingID)
 	}
 	
 	return nil
 }
 
 // GetAll retrieves all borrowing records
-func (r *SQLiteBorrowingRepository) GetAll() ([]*models.Borrowing, error) {
+func (r *SQLiteBorrowingRepository) GetAll(ctx context.Context) ([]*models.Borrowing, error) {
 	query := `
-		SELECT id, user_id, game_id, borrowed_at, due_date, returned_at, is_overdue
+		SELECT id, user_id, game_id, copy_id, borrowed_at, due_date, returned_at, is_overdue, extension_count
 		FROM borrowings
+		WHERE library_id = ?
 		ORDER BY borrowed_at DESC`
 	
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all borrowings: %w", err)
 	}
@@ -225,8 +228,8 @@ func (r *SQLiteBorrowingRepository) GetAll() ([]*models.Borrowing, error) {
 	for rows.Next() {
 		borrowing := &models.Borrowing{}
 		err := rows.Scan(
-			&borrowing.ID, &borrowing.UserID, &borrowing.GameID,
-			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue,
+			&borrowing.ID, &borrowing.UserID, &borrowing.GameID, &borrowing.CopyID,
+			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue, &borrowing.ExtensionCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan borrowing: %w", err)
@@ -239,4 +242,95 @@ func (r *SQLiteBorrowingRepository) GetAll() ([]*models.Borrowing, error) {
 	}
 	
 	return borrowings, nil
-}
\ No newline at end of file
+}
+
+// borrowingSortColumns maps the borrowing sort fields to SQL
+var borrowingSortColumns = map[string]sortColumn{
+	"borrowed_at": {expr: "borrowed_at", desc: true},
+	"due_date":    {expr: "due_date"},
+	"returned_at": {expr: "returned_at", desc: true},
+}
+
+// List retrieves one page of the borrowings matching filter
+func (r *SQLiteBorrowingRepository) List(ctx context.Context, filter models.BorrowingFilter) ([]*models.Borrowing, error) {
+	where := borrowingWhere(r.db, filter, time.Now())
+	query := `
+		SELECT id, user_id, game_id, copy_id, borrowed_at, due_date, returned_at, is_overdue, extension_count
+		FROM borrowings` + where.String() +
+		orderBy(filter.ListOptions, borrowingSortColumns, "borrowed_at") + `
+		LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, append(where.args, pageArgs(filter.ListOptions)...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list borrowings: %w", err)
+	}
+	defer rows.Close()
+
+	var borrowings []*models.Borrowing
+	for rows.Next() {
+		borrowing := &models.Borrowing{}
+		err := rows.Scan(
+			&borrowing.ID, &borrowing.UserID, &borrowing.GameID, &borrowing.CopyID,
+			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue, &borrowing.ExtensionCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan borrowing: %w", err)
+		}
+		borrowings = append(borrowings, borrowing)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating borrowings: %w", err)
+	}
+
+	return borrowings, nil
+}
+
+// Count returns the number of borrowings matching filter, ignoring its page
+func (r *SQLiteBorrowingRepository) Count(ctx context.Context, filter models.BorrowingFilter) (int, error) {
+	where := borrowingWhere(r.db, filter, time.Now())
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM borrowings`+where.String(), where.args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count borrowings: %w", err)
+	}
+
+	return count, nil
+}
+
+// borrowingWhere builds the conditions selecting the borrowings matching
+// filter; overdue means not returned and due before now
+func borrowingWhere(q database.Querier, filter models.BorrowingFilter, now time.Time) *sqlWhere {
+	where := libraryWhere(q, "library_id")
+	if filter.Search != "" {
+		term := "%" + strings.ToLower(filter.Search) + "%"
+		where.add("(user_id IN (SELECT id FROM users WHERE LOWER(name) LIKE ? OR LOWER(email) LIKE ?) OR game_id IN (SELECT id FROM games WHERE LOWER(name) LIKE ?))", term, term, term)
+	}
+	if filter.UserID > 0 {
+		where.add("user_id = ?", filter.UserID)
+	}
+	if filter.GameID > 0 {
+		where.add("game_id = ?", filter.GameID)
+	}
+	switch filter.Status {
+	case models.BorrowingStatusActive:
+		where.add("returned_at IS NULL")
+	case models.BorrowingStatusReturned:
+		where.add("returned_at IS NOT NULL")
+	case models.BorrowingStatusOverdue:
+		where.add("returned_at IS NULL AND due_date < ?", now)
+	}
+	if filter.BorrowedFrom != nil {
+		where.add("borrowed_at >= ?", *filter.BorrowedFrom)
+	}
+	if filter.BorrowedTo != nil {
+		where.add("borrowed_at < ?", *filter.BorrowedTo)
+	}
+	if filter.DueFrom != nil {
+		where.add("due_date >= ?", *filter.DueFrom)
+	}
+	if filter.DueTo != nil {
+		where.add("due_date < ?", *filter.DueTo)
+	}
+	return where
+}
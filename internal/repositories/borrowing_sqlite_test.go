@@ -2,18 +2,20 @@ package repositories
 
 import (
 	"board-game-library/internal/models"
+	"context"
 	"testing"
 	"time"
 )
 
 func createTestUserAndGame(t *testing.T, userRepo UserRepository, gameRepo GameRepository) (*models.User, *models.Game) {
+	ctx := context.Background()
 	user := &models.User{
 		Name:         "Test User",
 		Email:        "test@example.com",
 		RegisteredAt: time.Now(),
 		IsActive:     true,
 	}
-	err := userRepo.Create(user)
+	err := userRepo.Create(ctx, user)
 	if err != nil {
 		t.Fatalf("Failed to create test user: %v", err)
 	}
@@ -21,12 +23,12 @@ func createTestUserAndGame(t *testing.T, userRepo UserRepository, gameRepo GameR
 	game := &models.Game{
 		Name:        "Test Game",
 		Description: "A test game",
-		Category:    "Strategy",
+		Tags:        []string{"Strategy"},
 		EntryDate:   time.Now(),
 		Condition:   "good",
 		IsAvailable: true,
 	}
-	err = gameRepo.Create(game)
+	err = gameRepo.Create(ctx, game)
 	if err != nil {
 		t.Fatalf("Failed to create test game: %v", err)
 	}
@@ -35,6 +37,7 @@ func createTestUserAndGame(t *testing.T, userRepo UserRepository, gameRepo GameR
 }
 
 func TestSQLiteBorrowingRepository_Create(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -52,7 +55,7 @@ func TestSQLiteBorrowingRepository_Create(t *testing.T) {
 		IsOverdue:  false,
 	}
 
-	err := borrowingRepo.Create(borrowing)
+	err := borrowingRepo.Create(ctx, borrowing)
 	if err != nil {
 		t.Fatalf("Failed to create borrowing: %v", err)
 	}
@@ -63,6 +66,7 @@ func TestSQLiteBorrowingRepository_Create(t *testing.T) {
 }
 
 func TestSQLiteBorrowingRepository_GetByID(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -80,13 +84,13 @@ func TestSQLiteBorrowingRepository_GetByID(t *testing.T) {
 		IsOverdue:  false,
 	}
 
-	err := borrowingRepo.Create(borrowing)
+	err := borrowingRepo.Create(ctx, borrowing)
 	if err != nil {
 		t.Fatalf("Failed to create borrowing: %v", err)
 	}
 
 	// Retrieve the borrowing
-	retrieved, err := borrowingRepo.GetByID(borrowing.ID)
+	retrieved, err := borrowingRepo.GetByID(ctx, borrowing.ID)
 	if err != nil {
 		t.Fatalf("Failed to get borrowing by ID: %v", err)
 	}
@@ -100,6 +104,7 @@ func TestSQLiteBorrowingRepository_GetByID(t *testing.T) {
 }
 
 func TestSQLiteBorrowingRepository_GetActiveByUser(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -117,7 +122,7 @@ func TestSQLiteBorrowingRepository_GetActiveByUser(t *testing.T) {
 		DueDate:    time.Now().Add(14 * 24 * time.Hour),
 		IsOverdue:  false,
 	}
-	err := borrowingRepo.Create(activeBorrowing)
+	err := borrowingRepo.Create(ctx, activeBorrowing)
 	if err != nil {
 		t.Fatalf("Failed to create active borrowing: %v", err)
 	}
@@ -132,13 +137,13 @@ func TestSQLiteBorrowingRepository_GetActiveByUser(t *testing.T) {
 		ReturnedAt: &returnedTime,
 		IsOverdue:  false,
 	}
-	err = borrowingRepo.Create(returnedBorrowing)
+	err = borrowingRepo.Create(ctx, returnedBorrowing)
 	if err != nil {
 		t.Fatalf("Failed to create returned borrowing: %v", err)
 	}
 
 	// Get active borrowings
-	active, err := borrowingRepo.GetActiveByUser(user.ID)
+	active, err := borrowingRepo.GetActiveByUser(ctx, user.ID)
 	if err != nil {
 		t.Fatalf("Failed to get active borrowings: %v", err)
 	}
@@ -153,6 +158,7 @@ func TestSQLiteBorrowingRepository_GetActiveByUser(t *testing.T) {
 }
 
 func TestSQLiteBorrowingRepository_GetByGame(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -169,14 +175,14 @@ func TestSQLiteBorrowingRepository_GetByGame(t *testing.T) {
 	}
 
 	for _, borrowing := range borrowings {
-		err := borrowingRepo.Create(borrowing)
+		err := borrowingRepo.Create(ctx, borrowing)
 		if err != nil {
 			t.Fatalf("Failed to create borrowing: %v", err)
 		}
 	}
 
 	// Get borrowings by game
-	gameBorrowings, err := borrowingRepo.GetByGame(game.ID)
+	gameBorrowings, err := borrowingRepo.GetByGame(ctx, game.ID)
 	if err != nil {
 		t.Fatalf("Failed to get borrowings by game: %v", err)
 	}
@@ -187,6 +193,7 @@ func TestSQLiteBorrowingRepository_GetByGame(t *testing.T) {
 }
 
 func TestSQLiteBorrowingRepository_GetOverdue(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -204,7 +211,7 @@ func TestSQLiteBorrowingRepository_GetOverdue(t *testing.T) {
 		DueDate:    time.Now().Add(-5 * 24 * time.Hour), // 5 days overdue
 		IsOverdue:  true,
 	}
-	err := borrowingRepo.Create(overdueBorrowing)
+	err := borrowingRepo.Create(ctx, overdueBorrowing)
 	if err != nil {
 		t.Fatalf("Failed to create overdue borrowing: %v", err)
 	}
@@ -217,13 +224,13 @@ func TestSQLiteBorrowingRepository_GetOverdue(t *testing.T) {
 		DueDate:    time.Now().Add(14 * 24 * time.Hour), // Due in 14 days
 		IsOverdue:  false,
 	}
-	err = borrowingRepo.Create(currentBorrowing)
+	err = borrowingRepo.Create(ctx, currentBorrowing)
 	if err != nil {
 		t.Fatalf("Failed to create current borrowing: %v", err)
 	}
 
 	// Get overdue borrowings
-	overdue, err := borrowingRepo.GetOverdue()
+	overdue, err := borrowingRepo.GetOverdue(ctx)
 	if err != nil {
 		t.Fatalf("Failed to get overdue borrowings: %v", err)
 	}
@@ -238,6 +245,7 @@ func TestSQLiteBorrowingRepository_GetOverdue(t *testing.T) {
 }
 
 func TestSQLiteBorrowingRepository_Update(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -255,7 +263,7 @@ func TestSQLiteBorrowingRepository_Update(t *testing.T) {
 		IsOverdue:  false,
 	}
 
-	err := borrowingRepo.Create(borrowing)
+	err := borrowingRepo.Create(ctx, borrowing)
 	if err != nil {
 		t.Fatalf("Failed to create borrowing: %v", err)
 	}
@@ -264,13 +272,13 @@ func TestSQLiteBorrowingRepository_Update(t *testing.T) {
 	borrowing.IsOverdue = true
 	borrowing.DueDate = time.Now().Add(-1 * 24 * time.Hour) // Make it overdue
 
-	err = borrowingRepo.Update(borrowing)
+	err = borrowingRepo.Update(ctx, borrowing)
 	if err != nil {
 		t.Fatalf("Failed to update borrowing: %v", err)
 	}
 
 	// Retrieve and verify the update
-	retrieved, err := borrowingRepo.GetByID(borrowing.ID)
+	retrieved, err := borrowingRepo.GetByID(ctx, borrowing.ID)
 	if err != nil {
 		t.Fatalf("Failed to get updated borrowing: %v", err)
 	}
@@ -281,6 +289,7 @@ func TestSQLiteBorrowingRepository_Update(t *testing.T) {
 }
 
 func TestSQLiteBorrowingRepository_ReturnGame(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -298,19 +307,19 @@ func TestSQLiteBorrowingRepository_ReturnGame(t *testing.T) {
 		IsOverdue:  false,
 	}
 
-	err := borrowingRepo.Create(borrowing)
+	err := borrowingRepo.Create(ctx, borrowing)
 	if err != nil {
 		t.Fatalf("Failed to create borrowing: %v", err)
 	}
 
 	// Return the game
-	err = borrowingRepo.ReturnGame(borrowing.ID)
+	err = borrowingRepo.ReturnGame(ctx, borrowing.ID)
 	if err != nil {
 		t.Fatalf("Failed to return game: %v", err)
 	}
 
 	// Retrieve and verify the return
-	retrieved, err := borrowingRepo.GetByID(borrowing.ID)
+	retrieved, err := borrowingRepo.GetByID(ctx, borrowing.ID)
 	if err != nil {
 		t.Fatalf("Failed to get returned borrowing: %v", err)
 	}
@@ -321,6 +330,7 @@ func TestSQLiteBorrowingRepository_ReturnGame(t *testing.T) {
 }
 
 func TestSQLiteBorrowingRepository_GetAll(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -338,14 +348,14 @@ func TestSQLiteBorrowingRepository_GetAll(t *testing.T) {
 	}
 
 	for _, borrowing := range borrowings {
-		err := borrowingRepo.Create(borrowing)
+		err := borrowingRepo.Create(ctx, borrowing)
 		if err != nil {
 			t.Fatalf("Failed to create borrowing: %v", err)
 		}
 	}
 
 	// Get all borrowings
-	all, err := borrowingRepo.GetAll()
+	all, err := borrowingRepo.GetAll(ctx)
 	if err != nil {
 		t.Fatalf("Failed to get all borrowings: %v", err)
 	}
@@ -353,4 +363,80 @@ func TestSQLiteBorrowingRepository_GetAll(t *testing.T) {
 	if len(all) != len(borrowings) {
 		t.Errorf("Expected %d borrowings, got %d", len(borrowings), len(all))
 	}
-}
\ No newline at end of file
+}
+
+func TestSQLiteBorrowingRepository_List(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewSQLiteUserRepository(db)
+	gameRepo := NewSQLiteGameRepository(db)
+	borrowingRepo := NewSQLiteBorrowingRepository(db)
+
+	user, game := createTestUserAndGame(t, userRepo, gameRepo)
+
+	now := time.Now()
+	returnedAt := now.Add(-20 * 24 * time.Hour)
+	borrowings := []*models.Borrowing{
+		{UserID: user.ID, GameID: game.ID, BorrowedAt: now.Add(-30 * 24 * time.Hour), DueDate: now.Add(-16 * 24 * time.Hour), ReturnedAt: &returnedAt},
+		{UserID: user.ID, GameID: game.ID, BorrowedAt: now.Add(-20 * 24 * time.Hour), DueDate: now.Add(-6 * 24 * time.Hour)},
+		{UserID: user.ID, GameID: game.ID, BorrowedAt: now, DueDate: now.Add(14 * 24 * time.Hour)},
+	}
+	for _, borrowing := range borrowings {
+		if err := borrowingRepo.Create(ctx, borrowing); err != nil {
+			t.Fatalf("Failed to create borrowing: %v", err)
+		}
+	}
+
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+	tests := []struct {
+		name   string
+		filter models.BorrowingFilter
+		want   []int
+		total  int
+	}{
+		{"default newest first", models.BorrowingFilter{}, []int{borrowings[2].ID, borrowings[1].ID, borrowings[0].ID}, 3},
+		{"by due date", models.BorrowingFilter{ListOptions: models.ListOptions{Sort: "due_date"}}, []int{borrowings[0].ID, borrowings[1].ID, borrowings[2].ID}, 3},
+		{"active", models.BorrowingFilter{Status: models.BorrowingStatusActive}, []int{borrowings[2].ID, borrowings[1].ID}, 2},
+		{"returned", models.BorrowingFilter{Status: models.BorrowingStatusReturned}, []int{borrowings[0].ID}, 1},
+		{"overdue", models.BorrowingFilter{Status: models.BorrowingStatusOverdue}, []int{borrowings[1].ID}, 1},
+		{"borrowed since", models.BorrowingFilter{BorrowedFrom: &weekAgo}, []int{borrowings[2].ID}, 1},
+		{"due before", models.BorrowingFilter{DueTo: &weekAgo}, []int{borrowings[0].ID}, 1},
+		{"other user", models.BorrowingFilter{UserID: user.ID + 1}, nil, 0},
+		{"search user name", models.BorrowingFilter{Search: "test us", Status: models.BorrowingStatusReturned}, []int{borrowings[0].ID}, 1},
+		{"search game name", models.BorrowingFilter{Search: "GAME", Status: models.BorrowingStatusOverdue}, []int{borrowings[1].ID}, 1},
+		{"search email", models.BorrowingFilter{Search: "test@example"}, []int{borrowings[2].ID, borrowings[1].ID, borrowings[0].ID}, 3},
+		{"search no match", models.BorrowingFilter{Search: "azul"}, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			if err := models.ValidateBorrowingFilter(&filter); err != nil {
+				t.Fatalf("Invalid filter: %v", err)
+			}
+
+			retrieved, err := borrowingRepo.List(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to list borrowings: %v", err)
+			}
+			if len(retrieved) != len(tt.want) {
+				t.Fatalf("Expected %d borrowings, got %d", len(tt.want), len(retrieved))
+			}
+			for i, borrowing := range retrieved {
+				if borrowing.ID != tt.want[i] {
+					t.Errorf("Expected borrowing %d at position %d, got %d", tt.want[i], i, borrowing.ID)
+				}
+			}
+
+			total, err := borrowingRepo.Count(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to count borrowings: %v", err)
+			}
+			if total != tt.total {
+				t.Errorf("Expected total %d, got %d", tt.total, total)
+			}
+		})
+	}
+}
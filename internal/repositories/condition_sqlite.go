@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"fmt"
+)
+
+// SQLiteConditionRepository implements ConditionRepository using SQLite
+type SQLiteConditionRepository struct {
+	db database.Querier
+}
+
+// NewSQLiteConditionRepository creates a new SQLite condition repository
+func NewSQLiteConditionRepository(db *database.DB) ConditionRepository {
+	return &SQLiteConditionRepository{db: db}
+}
+
+// Create appends a change to the condition history of a game
+func (r *SQLiteConditionRepository) Create(ctx context.Context, change *models.ConditionChange) error {
+	query := `
+		INSERT INTO condition_changes (library_id, game_id, copy_id, borrowing_id, previous_condition, condition, notes, recorded_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query, database.LibraryOf(r.db), change.GameID, change.CopyID, change.BorrowingID,
+		change.PreviousCondition, change.Condition, change.Notes, change.RecordedAt.UTC()).Scan(&change.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create condition change: %w", err)
+	}
+
+	return nil
+}
+
+// GetByGame retrieves the condition history of a game and its copies,
+// newest first
+func (r *SQLiteConditionRepository) GetByGame(ctx context.Context, gameID int) ([]*models.ConditionChange, error) {
+	query := `
+		SELECT id, game_id, copy_id, borrowing_id, previous_condition, condition, notes, recorded_at
+		FROM condition_changes
+		WHERE library_id = ? AND game_id = ?
+		ORDER BY recorded_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db), gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get condition history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*models.ConditionChange{}
+	for rows.Next() {
+		change := &models.ConditionChange{}
+		err := rows.Scan(
+			&change.ID, &change.GameID, &change.CopyID, &change.BorrowingID,
+			&change.PreviousCondition, &change.Condition, &change.Notes, &change.RecordedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan condition change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating condition history: %w", err)
+	}
+
+	return changes, nil
+}
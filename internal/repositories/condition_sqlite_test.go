@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSQLiteConditionRepository_History(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteConditionRepository(db)
+	gameRepo := NewSQLiteGameRepository(db)
+	user, game := createTestUserAndGame(t, NewSQLiteUserRepository(db), gameRepo)
+
+	copies, err := gameRepo.GetCopies(ctx, game.ID)
+	if err != nil || len(copies) != 1 {
+		t.Fatalf("Failed to get the first copy: %v", err)
+	}
+
+	now := time.Now()
+	borrowing := &models.Borrowing{UserID: user.ID, GameID: game.ID, CopyID: &copies[0].ID, BorrowedAt: now.Add(-7 * 24 * time.Hour), DueDate: now}
+	if err := NewSQLiteBorrowingRepository(db).Create(ctx, borrowing); err != nil {
+		t.Fatalf("Failed to create borrowing: %v", err)
+	}
+
+	history, err := repo.GetByGame(ctx, game.ID)
+	if err != nil || history == nil || len(history) != 0 {
+		t.Fatalf("GetByGame() without changes = %v, %v, want an empty history", history, err)
+	}
+
+	returned := &models.ConditionChange{
+		GameID:            game.ID,
+		CopyID:            &copies[0].ID,
+		BorrowingID:       &borrowing.ID,
+		PreviousCondition: "good",
+		Condition:         "missing_pieces",
+		Notes:             "Two blue meeples missing",
+		RecordedAt:        now.Add(-time.Hour),
+	}
+	repaired := &models.ConditionChange{GameID: game.ID, CopyID: &copies[0].ID, PreviousCondition: "missing_pieces", Condition: "good", RecordedAt: now}
+	for _, change := range []*models.ConditionChange{returned, repaired} {
+		if err := repo.Create(ctx, change); err != nil {
+			t.Fatalf("Failed to create condition change: %v", err)
+		}
+		if change.ID == 0 {
+			t.Error("Expected condition change ID to be set after creation")
+		}
+	}
+
+	history, err = repo.GetByGame(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get condition history: %v", err)
+	}
+	if len(history) != 2 || history[0].ID != repaired.ID || history[1].ID != returned.ID {
+		t.Fatalf("Expected the repair then the return, got %+v", history)
+	}
+	if history[1].BorrowingID == nil || *history[1].BorrowingID != borrowing.ID || history[0].BorrowingID != nil {
+		t.Errorf("Expected only the return to reference the borrowing, got %+v", history)
+	}
+	if history[1].CopyID == nil || *history[1].CopyID != copies[0].ID {
+		t.Errorf("Expected the return to reference copy %d, got %v", copies[0].ID, history[1].CopyID)
+	}
+	if history[1].Notes != returned.Notes || history[1].PreviousCondition != "good" || history[1].Condition != "missing_pieces" {
+		t.Errorf("Expected the return to be stored as created, got %+v", history[1])
+	}
+
+	other := NewSQLiteConditionRepository(db.ForLibrary(2))
+	if history, err := other.GetByGame(ctx, game.ID); err != nil || len(history) != 0 {
+		t.Errorf("GetByGame() in another library = %d changes, %v, want none", len(history), err)
+	}
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"fmt"
+)
+
+// SQLiteExportRepository implements ExportRepository using SQLite
+type SQLiteExportRepository struct {
+	db database.Querier
+}
+
+// NewSQLiteExportRepository creates a new SQLite export repository
+func NewSQLiteExportRepository(db *database.DB) ExportRepository {
+	return &SQLiteExportRepository{db: db}
+}
+
+// EachGame calls fn for every game in ID order
+func (r *SQLiteExportRepository) EachGame(ctx context.Context, fn func(game *models.Game) error) error {
+	query := `
+		SELECT ` + gameColumns(r.db) + `
+		FROM games
+		WHERE library_id = ?
+		ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db))
+	if err != nil {
+		return fmt.Errorf("failed to export games: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		game, err := scanGame(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan game: %w", err)
+		}
+		if err := fn(game); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating games: %w", err)
+	}
+
+	return nil
+}
+
+// EachUser calls fn for every user in ID order
+func (r *SQLiteExportRepository) EachUser(ctx context.Context, fn func(user *models.User) error) error {
+	query := `
+		SELECT id, name, email, registered_at, is_active, membership_tier, role
+		FROM users
+		WHERE library_id = ?
+		ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db))
+	if err != nil {
+		return fmt.Errorf("failed to export users: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := &models.User{}
+		err := rows.Scan(
+			&user.ID, &user.Name, &user.Email, &user.RegisteredAt, &user.IsActive, &user.MembershipTier, &user.Role,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan user: %w", err)
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return nil
+}
+
+// EachBorrowing calls fn for every borrowing in ID order, with the borrower's
+// email and the game's name
+func (r *SQLiteExportRepository) EachBorrowing(ctx context.Context, fn func(borrowing *models.Borrowing, userEmail, gameName string) error) error {
+	query := `
+		SELECT b.id, b.user_id, b.game_id, b.copy_id, b.borrowed_at, b.due_date, b.returned_at,
+			b.is_overdue, b.extension_count, u.email, g.name
+		FROM borrowings b
+		JOIN users u ON u.id = b.user_id
+		JOIN games g ON g.id = b.game_id
+		WHERE b.library_id = ?
+		ORDER BY b.id`
+
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db))
+	if err != nil {
+		return fmt.Errorf("failed to export borrowings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		borrowing := &models.Borrowing{}
+		var userEmail, gameName string
+		err := rows.Scan(
+			&borrowing.ID, &borrowing.UserID, &borrowing.GameID, &borrowing.CopyID,
+			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue, &borrowing.ExtensionCount,
+			&userEmail, &gameName,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan borrowing: %w", err)
+		}
+		if err := fn(borrowing, userEmail, gameName); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating borrowings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSQLiteExportRepository(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	games := NewSQLiteGameRepository(db)
+	users := NewSQLiteUserRepository(db)
+	borrowings := NewSQLiteBorrowingRepository(db)
+	repo := NewSQLiteExportRepository(db)
+
+	for _, name := range []string{"Wingspan", "Azul"} {
+		game := &models.Game{Name: name, Tags: []string{"Famille"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true}
+		if err := games.Create(ctx, game); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+	}
+	user := &models.User{Name: "Alice", Email: "alice@example.com", RegisteredAt: time.Now(), IsActive: true}
+	if err := users.Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	borrowedAt := time.Now().Add(-48 * time.Hour)
+	if err := borrowings.Create(ctx, &models.Borrowing{UserID: user.ID, GameID: 2, BorrowedAt: borrowedAt, DueDate: borrowedAt.Add(14 * 24 * time.Hour)}); err != nil {
+		t.Fatalf("Failed to create borrowing: %v", err)
+	}
+
+	var names []string
+	err := repo.EachGame(ctx, func(game *models.Game) error {
+		names = append(names, game.Name)
+		if len(game.Tags) != 1 || game.TotalCopies != 1 {
+			t.Errorf("Expected %s with its tag and copy, got tags %v and %d copies", game.Name, game.Tags, game.TotalCopies)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to export games: %v", err)
+	}
+	if len(names) != 2 || names[0] != "Wingspan" || names[1] != "Azul" {
+		t.Errorf("Expected games in ID order, got %v", names)
+	}
+
+	var emails []string
+	if err := repo.EachUser(ctx, func(user *models.User) error {
+		emails = append(emails, user.Email)
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to export users: %v", err)
+	}
+	if len(emails) != 1 || emails[0] != "alice@example.com" {
+		t.Errorf("Expected alice@example.com, got %v", emails)
+	}
+
+	calls := 0
+	err = repo.EachBorrowing(ctx, func(borrowing *models.Borrowing, userEmail, gameName string) error {
+		calls++
+		if borrowing.UserID != user.ID || userEmail != "alice@example.com" || gameName != "Azul" {
+			t.Errorf("Expected Alice's borrowing of Azul, got user %d (%s) and game %q", borrowing.UserID, userEmail, gameName)
+		}
+		return nil
+	})
+	if err != nil || calls != 1 {
+		t.Fatalf("Expected one borrowing, got %d calls and error %v", calls, err)
+	}
+
+	stop := errors.New("stop")
+	calls = 0
+	err = repo.EachGame(ctx, func(game *models.Game) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Expected the export to stop at the first error, got %d calls and error %v", calls, err)
+	}
+}
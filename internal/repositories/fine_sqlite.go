@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"fmt"
+)
+
+// SQLiteFineRepository implements FineRepository using SQLite
+type SQLiteFineRepository struct {
+	db database.Querier
+}
+
+// NewSQLiteFineRepository creates a new SQLite fine repository
+func NewSQLiteFineRepository(db *database.DB) FineRepository {
+	return &SQLiteFineRepository{db: db}
+}
+
+// Create appends an entry to the fines ledger. A borrowing is fined once.
+func (r *SQLiteFineRepository) Create(ctx context.Context, entry *models.FineEntry) error {
+	query := `
+		INSERT INTO fine_entries (library_id, user_id, borrowing_id, kind, amount_cents, reason, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query, database.LibraryOf(r.db), entry.UserID, entry.BorrowingID, entry.Kind,
+		entry.AmountCents, entry.Reason, entry.CreatedAt.UTC()).Scan(&entry.ID)
+	if err != nil {
+		if database.IsUniqueViolation(err) && entry.BorrowingID != nil {
+			return models.Conflict("borrowing_already_fined", *entry.BorrowingID)
+		}
+		return fmt.Errorf("failed to create fine entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser retrieves the ledger entries of a user, newest first
+func (r *SQLiteFineRepository) GetByUser(ctx context.Context, userID int) ([]*models.FineEntry, error) {
+	query := `
+		SELECT id, user_id, borrowing_id, kind, amount_cents, reason, created_at
+		FROM fine_entries
+		WHERE library_id = ? AND user_id = ?
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fine entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.FineEntry{}
+	for rows.Next() {
+		entry := &models.FineEntry{}
+		err := rows.Scan(
+			&entry.ID, &entry.UserID, &entry.BorrowingID, &entry.Kind,
+			&entry.AmountCents, &entry.Reason, &entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fine entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating fine entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Balance returns the amount a user owes: their fines minus their payments
+// and waivers
+func (r *SQLiteFineRepository) Balance(ctx context.Context, userID int) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(CASE WHEN kind = ? THEN amount_cents ELSE -amount_cents END), 0)
+		FROM fine_entries
+		WHERE library_id = ? AND user_id = ?`
+
+	var balance int
+	err := r.db.QueryRowContext(ctx, query, models.FineKindFine, database.LibraryOf(r.db), userID).Scan(&balance)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fine balance: %w", err)
+	}
+
+	return balance, nil
+}
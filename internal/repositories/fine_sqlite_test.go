@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSQLiteFineRepository_LedgerAndBalance(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteFineRepository(db)
+	user, game := createTestUserAndGame(t, NewSQLiteUserRepository(db), NewSQLiteGameRepository(db))
+
+	now := time.Now()
+	borrowing := &models.Borrowing{
+		UserID:     user.ID,
+		GameID:     game.ID,
+		BorrowedAt: now.Add(-20 * 24 * time.Hour),
+		DueDate:    now.Add(-6 * 24 * time.Hour),
+	}
+	if err := NewSQLiteBorrowingRepository(db).Create(ctx, borrowing); err != nil {
+		t.Fatalf("Failed to create borrowing: %v", err)
+	}
+
+	balance, err := repo.Balance(ctx, user.ID)
+	if err != nil || balance != 0 {
+		t.Fatalf("Balance() without entries = %d, %v, want 0", balance, err)
+	}
+
+	fine := &models.FineEntry{UserID: user.ID, BorrowingID: &borrowing.ID, Kind: models.FineKindFine, AmountCents: 300, CreatedAt: now.Add(-time.Hour)}
+	if err := repo.Create(ctx, fine); err != nil {
+		t.Fatalf("Failed to create fine: %v", err)
+	}
+
+	again := &models.FineEntry{UserID: user.ID, BorrowingID: &borrowing.ID, Kind: models.FineKindFine, AmountCents: 300, CreatedAt: now}
+	if err := repo.Create(ctx, again); !errors.Is(err, models.ErrConflict) {
+		t.Errorf("Create() of a second fine for the borrowing error = %v, want a conflict", err)
+	}
+
+	for _, entry := range []*models.FineEntry{
+		{UserID: user.ID, Kind: models.FineKindPayment, AmountCents: 100, Reason: "Paid in cash", CreatedAt: now.Add(-30 * time.Minute)},
+		{UserID: user.ID, Kind: models.FineKindWaiver, AmountCents: 50, Reason: "First late return", CreatedAt: now},
+	} {
+		if err := repo.Create(ctx, entry); err != nil {
+			t.Fatalf("Failed to create %s: %v", entry.Kind, err)
+		}
+	}
+
+	balance, err = repo.Balance(ctx, user.ID)
+	if err != nil || balance != 150 {
+		t.Errorf("Balance() = %d, %v, want 150", balance, err)
+	}
+
+	entries, err := repo.GetByUser(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Failed to get fine entries: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Kind != models.FineKindWaiver || entries[2].ID != fine.ID {
+		t.Fatalf("Expected the waiver, the payment then the fine, got %+v", entries)
+	}
+	if entries[2].BorrowingID == nil || *entries[2].BorrowingID != borrowing.ID || entries[1].BorrowingID != nil {
+		t.Errorf("Expected only the fine to reference the borrowing, got %+v", entries)
+	}
+
+	other := NewSQLiteFineRepository(db.ForLibrary(2))
+	if balance, err := other.Balance(ctx, user.ID); err != nil || balance != 0 {
+		t.Errorf("Balance() in another library = %d, %v, want 0", balance, err)
+	}
+}
@@ -0,0 +1,217 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createTestGameWithCopies(t *testing.T, repo GameRepository, extraCopies int) *models.Game {
+	ctx := context.Background()
+	t.Helper()
+
+	game := &models.Game{
+		Name:        "Catan",
+		Description: "Trading and building",
+		Tags:        []string{"Strategy"},
+		EntryDate:   time.Now(),
+		Condition:   "good",
+		IsAvailable: true,
+	}
+	if err := repo.Create(ctx, game); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	for i := 0; i < extraCopies; i++ {
+		gameCopy := &models.GameCopy{
+			GameID:      game.ID,
+			Condition:   "excellent",
+			AcquiredAt:  time.Now(),
+			IsAvailable: true,
+		}
+		if err := repo.CreateCopy(ctx, gameCopy); err != nil {
+			t.Fatalf("Failed to create game copy: %v", err)
+		}
+	}
+
+	return game
+}
+
+func TestSQLiteGameRepository_CreateAddsFirstCopy(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteGameRepository(db)
+	game := createTestGameWithCopies(t, repo, 0)
+
+	if game.TotalCopies != 1 || game.AvailableCopies != 1 {
+		t.Errorf("Expected 1/1 copies after creation, got %d/%d", game.AvailableCopies, game.TotalCopies)
+	}
+
+	copies, err := repo.GetCopies(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get copies: %v", err)
+	}
+
+	if len(copies) != 1 {
+		t.Fatalf("Expected 1 copy, got %d", len(copies))
+	}
+	if copies[0].Condition != game.Condition || !copies[0].IsAvailable {
+		t.Errorf("Expected first copy to mirror the game, got %+v", copies[0])
+	}
+}
+
+func TestSQLiteGameRepository_CopyCounts(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteGameRepository(db)
+	game := createTestGameWithCopies(t, repo, 2)
+
+	copies, err := repo.GetCopies(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get copies: %v", err)
+	}
+
+	// Lend two of the three copies
+	for _, gameCopy := range copies[:2] {
+		gameCopy.IsAvailable = false
+		if err := repo.UpdateCopy(ctx, gameCopy); err != nil {
+			t.Fatalf("Failed to update copy: %v", err)
+		}
+	}
+
+	retrieved, err := repo.GetByID(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if retrieved.TotalCopies != 3 || retrieved.AvailableCopies != 1 {
+		t.Errorf("Expected 1/3 copies available, got %d/%d", retrieved.AvailableCopies, retrieved.TotalCopies)
+	}
+	if !retrieved.IsAvailable {
+		t.Error("Expected game to be available while a copy is free")
+	}
+
+	available, err := repo.GetAvailable(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get available games: %v", err)
+	}
+	if len(available) != 1 || available[0].AvailableCopies != 1 {
+		t.Errorf("Expected the game with 1 free copy, got %+v", available)
+	}
+
+	// Lend the last copy
+	copies[2].IsAvailable = false
+	if err := repo.UpdateCopy(ctx, copies[2]); err != nil {
+		t.Fatalf("Failed to update copy: %v", err)
+	}
+
+	retrieved, _ = repo.GetByID(ctx, game.ID)
+	if retrieved.IsAvailable || retrieved.AvailableCopies != 0 {
+		t.Errorf("Expected game to be unavailable, got available=%t with %d free copies", retrieved.IsAvailable, retrieved.AvailableCopies)
+	}
+
+	available, _ = repo.GetAvailable(ctx)
+	if len(available) != 0 {
+		t.Errorf("Expected no available games, got %d", len(available))
+	}
+}
+
+func TestSQLiteGameRepository_CopyBarcodes(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteGameRepository(db)
+	game := createTestGameWithCopies(t, repo, 0)
+
+	gameCopy := &models.GameCopy{GameID: game.ID, Barcode: "BGL-0001", Condition: "good", AcquiredAt: time.Now(), IsAvailable: true}
+	if err := repo.CreateCopy(ctx, gameCopy); err != nil {
+		t.Fatalf("Failed to create copy: %v", err)
+	}
+
+	found, err := repo.GetCopyByBarcode(ctx, "BGL-0001")
+	if err != nil {
+		t.Fatalf("Failed to get copy by barcode: %v", err)
+	}
+	if found.ID != gameCopy.ID {
+		t.Errorf("Expected copy %d, got %d", gameCopy.ID, found.ID)
+	}
+
+	duplicate := &models.GameCopy{GameID: game.ID, Barcode: "BGL-0001", Condition: "good", AcquiredAt: time.Now(), IsAvailable: true}
+	err = repo.CreateCopy(ctx, duplicate)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected duplicate barcode error, got %v", err)
+	}
+
+	// Copies without a barcode do not collide
+	if _, err := repo.GetCopyByBarcode(ctx, ""); err == nil {
+		t.Error("Expected no copy for an empty barcode")
+	}
+}
+
+func TestSQLiteGameRepository_DeleteCopy(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteGameRepository(db)
+	game := createTestGameWithCopies(t, repo, 1)
+
+	copies, _ := repo.GetCopies(ctx, game.ID)
+	if err := repo.DeleteCopy(ctx, copies[1].ID); err != nil {
+		t.Fatalf("Failed to delete copy: %v", err)
+	}
+
+	if _, err := repo.GetCopyByID(ctx, copies[1].ID); err == nil {
+		t.Error("Expected error when getting deleted copy")
+	}
+
+	if err := repo.DeleteCopy(ctx, 9999); err == nil {
+		t.Error("Expected error when deleting non-existent copy")
+	}
+
+	// Deleting the game removes its remaining copies
+	if err := repo.Delete(ctx, game.ID); err != nil {
+		t.Fatalf("Failed to delete game: %v", err)
+	}
+	if _, err := repo.GetCopyByID(ctx, copies[0].ID); err == nil {
+		t.Error("Expected copies to be deleted with their game")
+	}
+}
+
+func TestSQLiteGameRepository_CopyInRepair(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteGameRepository(db)
+	game := createTestGameWithCopies(t, repo, 1)
+
+	copies, err := repo.GetCopies(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get copies: %v", err)
+	}
+
+	copies[0].SetCondition(models.ConditionMissingPieces)
+	if err := repo.UpdateCopy(ctx, copies[0]); err != nil {
+		t.Fatalf("Failed to update copy: %v", err)
+	}
+
+	retrieved, err := repo.GetCopyByID(ctx, copies[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to get copy: %v", err)
+	}
+	if !retrieved.InRepair || retrieved.IsAvailable || retrieved.Condition != models.ConditionMissingPieces {
+		t.Errorf("Expected the copy to be kept from lending, got %+v", retrieved)
+	}
+
+	game, _ = repo.GetByID(ctx, game.ID)
+	if game.TotalCopies != 2 || game.AvailableCopies != 1 {
+		t.Errorf("Expected 1/2 copies available, got %d/%d", game.AvailableCopies, game.TotalCopies)
+	}
+}
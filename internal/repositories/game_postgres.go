@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"strings"
+)
+
+// The game queries whose SQL differs on PostgreSQL. The other queries of
+// SQLiteGameRepository are shared by both databases.
+
+// postgresGameTagNames selects the tag names of each game as a JSON array,
+// in alphabetical order
+const postgresGameTagNames = `COALESCE((SELECT json_agg(t.name ORDER BY LOWER(t.name))
+				FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+				WHERE gt.game_id = games.id)::text, '[]') AS tags`
+
+// postgresGameDesigners matches the designers of a game, stored as a JSON
+// array, against a LIKE pattern
+const postgresGameDesigners = `SELECT 1 FROM json_array_elements_text(games.designers::json) d WHERE LOWER(d) LIKE ?`
+
+// postgresGameDocument is the text searched in a game g, with names weighing
+// most and descriptions least
+const postgresGameDocument = `(setweight(to_tsvector('game_search', g.name), 'A') ||
+				setweight(to_tsvector('game_search', COALESCE((SELECT string_agg(t.name, ' ')
+					FROM game_tags gt JOIN tags t ON t.id = gt.tag_id WHERE gt.game_id = g.id), '')), 'B') ||
+				setweight(to_tsvector('game_search', COALESCE(g.description, '')), 'C'))`
+
+// postgresGameMatches joins the games to their full-text matches, best (lowest
+// relevance) first like the FTS5 ranking. Its arguments are the headline
+// options of the name and of the snippet, then the text search query.
+const postgresGameMatches = ` JOIN (
+			SELECT g.id AS match_id,
+				ts_headline('game_search', g.name, q, ?) AS match_name,
+				ts_headline('game_search', COALESCE(g.description, ''), q, ?) AS match_snippet,
+				-ts_rank(` + postgresGameDocument + `, q) AS relevance
+			FROM games g, to_tsquery('game_search', ?) q
+			WHERE ` + postgresGameDocument + ` @@ q
+		) m ON m.match_id = games.id`
+
+// Headline options marking the matched terms like the FTS5 highlights
+var (
+	postgresHeadlineName    = "StartSel=" + models.HighlightStart + ", StopSel=" + models.HighlightEnd + ", HighlightAll=true"
+	postgresHeadlineSnippet = "StartSel=" + models.HighlightStart + ", StopSel=" + models.HighlightEnd + ", MinWords=8, MaxWords=16"
+)
+
+// tsQuery turns a search term into a PostgreSQL text search query matching
+// every word of the term as a prefix. Like ftsQuery, punctuation only
+// separates words, so user input cannot inject operators.
+func tsQuery(term string) string {
+	words := searchWords(term)
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
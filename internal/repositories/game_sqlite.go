@@ -3,14 +3,41 @@ package repositories
 import (
 	"board-game-library/internal/models"
 	"board-game-library/pkg/database"
+	"board-game-library/pkg/slug"
+	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
+	"unicode"
 )
 
+// gameCopyCounts selects the number of copies and of free copies of each game
+const gameCopyCounts = `(SELECT COUNT(*) FROM game_copies c WHERE c.game_id = games.id) AS total_copies,
+			(SELECT COUNT(*) FROM game_copies c WHERE c.game_id = games.id AND c.is_available = TRUE) AS available_copies`
+
+// gameTagNames selects the tag names of each game as a JSON array, in
+// alphabetical order
+const gameTagNames = `(SELECT json_group_array(t.name ORDER BY t.name COLLATE NOCASE)
+				FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+				WHERE gt.game_id = games.id) AS tags`
+
+// gameColumns selects a game with its tags and copy counts, in the order read
+// by scanGame, in the dialect of q
+func gameColumns(q database.Querier) string {
+	tagNames := gameTagNames
+	if database.IsPostgres(q) {
+		tagNames = postgresGameTagNames
+	}
+	return `id, name, description, ` + tagNames + `, entry_date, condition, is_available,
+			min_players, max_players, play_time, min_age, publisher, designers, year_published, complexity,
+			image_url, bgg_id, ` + gameCopyCounts
+}
+
 // SQLiteGameRepository implements GameRepository using SQLite
 type SQLiteGameRepository struct {
-	db *database.DB
+	db database.Querier
 }
 
 // NewSQLiteGameRepository creates a new SQLite game repository
@@ -18,38 +45,65 @@ func NewSQLiteGameRepository(db *database.DB) GameRepository {
 	return &SQLiteGameRepository{db: db}
 }
 
-// Create inserts a new game into the database
-func (r *SQLiteGameRepository) Create(game *models.Game) error {
-	query := `
-		INSERT INTO games (name, description, category, entry_date, condition, is_available)
-		VALUES (?, ?, ?, ?, ?, ?)
-		RETURNING id`
-	
-	err := r.db.QueryRow(query, game.Name, game.Description, game.Category, 
-		game.EntryDate, game.Condition, game.IsAvailable).Scan(&game.ID)
+// Create inserts a new game into the database together with its first copy
+// and its tags, creating the tags not used yet
+func (r *SQLiteGameRepository) Create(ctx context.Context, game *models.Game) error {
+	err := database.InTxContext(ctx, r.db, func(tx database.Querier) error {
+		query := `
+			INSERT INTO games (library_id, name, description, entry_date, condition, is_available,
+				min_players, max_players, play_time, min_age, publisher, designers, year_published, complexity,
+				image_url, bgg_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id`
+
+		designers, err := encodeDesigners(game.Designers)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, query, database.LibraryOf(tx), game.Name, game.Description,
+			game.EntryDate, game.Condition, game.IsAvailable,
+			game.MinPlayers, game.MaxPlayers, game.PlayTime, game.MinAge, game.Publisher,
+			designers, game.YearPublished, game.Complexity, game.ImageURL, game.BGGID).Scan(&game.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create game: %w", err)
+		}
+
+		copyQuery := `
+			INSERT INTO game_copies (library_id, game_id, condition, acquired_at, is_available)
+			VALUES (?, ?, ?, ?, ?)`
+
+		if _, err := tx.ExecContext(ctx, copyQuery, database.LibraryOf(tx), game.ID, game.Condition, game.EntryDate, game.IsAvailable); err != nil {
+			return fmt.Errorf("failed to create game copy: %w", err)
+		}
+
+		return setGameTags(ctx, tx, game.ID, game.Tags)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create game: %w", err)
+		return err
+	}
+	
+	game.TotalCopies = 1
+	game.AvailableCopies = 0
+	if game.IsAvailable {
+		game.AvailableCopies = 1
 	}
 	
 	return nil
 }
 
 // GetByID retrieves a game by its ID
-func (r *SQLiteGameRepository) GetByID(id int) (*models.Game, error) {
+func (r *SQLiteGameRepository) GetByID(ctx context.Context, id int) (*models.Game, error) {
 	query := `
-		SELECT id, name, description, category, entry_date, condition, is_available
+		SELECT ` + gameColumns(r.db) + `
 		FROM games
-		WHERE id = ?`
+		WHERE id = ? AND library_id = ?`
 	
-	game := &models.Game{}
-	err := r.db.QueryRow(query, id).Scan(
-		&game.ID, &game.Name, &game.Description, &game.Category,
-		&game.EntryDate, &game.Condition, &game.IsAvailable,
-	)
+	game, err := scanGame(r.db.QueryRowContext(ctx, query, id, database.LibraryOf(r.db)))
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("game with id %d not found", id)
+			return nil, models.NotFound("game_not_found", id)
 		}
 		return nil, fmt.Errorf("failed to get game by id: %w", err)
 	}
@@ -58,13 +112,14 @@ func (r *SQLiteGameRepository) GetByID(id int) (*models.Game, error) {
 }
 
 // GetAll retrieves all games from the database
-func (r *SQLiteGameRepository) GetAll() ([]*models.Game, error) {
+func (r *SQLiteGameRepository) GetAll(ctx context.Context) ([]*models.Game, error) {
 	query := `
-		SELECT id, name, description, category, entry_date, condition, is_available
+		SELECT ` + gameColumns(r.db) + `
 		FROM games
+		WHERE library_id = ?
 		ORDER BY name`
 	
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all games: %w", err)
 	}
@@ -72,11 +127,7 @@ func (r *SQLiteGameRepository) GetAll() ([]*models.Game, error) {
 	
 	var games []*models.Game
 	for rows.Next() {
-		game := &models.Game{}
-		err := rows.Scan(
-			&game.ID, &game.Name, &game.Description, &game.Category,
-			&game.EntryDate, &game.Condition, &game.IsAvailable,
-		)
+		game, err := scanGame(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan game: %w", err)
 		}
@@ -90,71 +141,332 @@ func (r *SQLiteGameRepository) GetAll() ([]*models.Game, error) {
 	return games, nil
 }
 
-// Search finds games matching the query string
-func (r *SQLiteGameRepository) Search(query string) ([]*models.Game, error) {
-	searchQuery := `
-		SELECT id, name, description, category, entry_date, condition, is_available
-		FROM games
-		WHERE LOWER(name) LIKE ? OR LOWER(description) LIKE ? OR LOWER(category) LIKE ?
-		ORDER BY name`
-	
-	searchTerm := "%" + strings.ToLower(query) + "%"
-	rows, err := r.db.Query(searchQuery, searchTerm, searchTerm, searchTerm)
+// gameSortColumns maps the game sort fields to SQL
+var gameSortColumns = map[string]sortColumn{
+	"name":             {expr: "LOWER(name)"},
+	"condition":        {expr: "condition"},
+	"entry_date":       {expr: "entry_date", desc: true},
+	"available_copies": {expr: "available_copies", desc: true},
+	"year_published":   {expr: "year_published", desc: true},
+	"play_time":        {expr: "play_time"},
+	"complexity":       {expr: "complexity"},
+}
+
+// gameMatchSortColumns adds the relevance of full-text matches, best (lowest
+// bm25 score) first, to the game sort fields
+var gameMatchSortColumns = map[string]sortColumn{
+	models.GameSortRelevance: {expr: "relevance"},
+	"name":                   gameSortColumns["name"],
+	"condition":              gameSortColumns["condition"],
+	"entry_date":             gameSortColumns["entry_date"],
+	"available_copies":       gameSortColumns["available_copies"],
+	"year_published":         gameSortColumns["year_published"],
+	"play_time":              gameSortColumns["play_time"],
+	"complexity":             gameSortColumns["complexity"],
+}
+
+// gameMatches joins the games to their full-text matches, ranked with names
+// weighing most and descriptions least. Its arguments are the highlight
+// markers, twice, then the FTS5 query.
+const gameMatches = ` JOIN (
+			SELECT rowid AS match_id,
+				highlight(games_fts, 0, ?, ?) AS match_name,
+				snippet(games_fts, 1, ?, ?, '…', 16) AS match_snippet,
+				bm25(games_fts, 10.0, 2.0, 5.0) AS relevance
+			FROM games_fts WHERE games_fts MATCH ?
+		) m ON m.match_id = games.id`
+
+// List returns one page of the games matching filter. With the full-text
+// index available, a search ranks the games by relevance and highlights the
+// matched terms; otherwise it falls back to a substring match sorted by name.
+func (r *SQLiteGameRepository) List(ctx context.Context, filter models.GameFilter) ([]*models.Game, error) {
+	search, err := r.prepareSearch(filter.Search)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search games: %w", err)
+		return nil, fmt.Errorf("failed to list games: %w", err)
+	}
+	if search.empty {
+		return nil, nil
+	}
+
+	where := gameWhere(r.db, filter)
+	from, columns, sortColumns, args := "games", "", gameSortColumns, []any{}
+	if search.match != "" && database.IsPostgres(r.db) {
+		from += postgresGameMatches
+		columns = ", m.relevance, m.match_name, COALESCE(m.match_snippet, '')"
+		sortColumns = gameMatchSortColumns
+		args = append(args, postgresHeadlineName, postgresHeadlineSnippet, search.match)
+	} else if search.match != "" {
+		from += gameMatches
+		columns = ", m.relevance, m.match_name, COALESCE(m.match_snippet, '')"
+		sortColumns = gameMatchSortColumns
+		args = append(args, models.HighlightStart, models.HighlightEnd, models.HighlightStart, models.HighlightEnd, search.match)
+	} else if filter.Search != "" {
+		addGameLike(where, filter.Search)
+	}
+
+	query := `
+		SELECT ` + gameColumns(r.db) + columns + `
+		FROM ` + from + where.String() +
+		orderBy(filter.ListOptions, sortColumns, "name") + `
+		LIMIT ? OFFSET ?`
+
+	args = append(append(args, where.args...), pageArgs(filter.ListOptions)...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list games: %w", err)
 	}
 	defer rows.Close()
-	
+
 	var games []*models.Game
 	for rows.Next() {
-		game := &models.Game{}
-		err := rows.Scan(
-			&game.ID, &game.Name, &game.Description, &game.Category,
-			&game.EntryDate, &game.Condition, &game.IsAvailable,
-		)
+		var match models.GameSearchMatch
+		var extra []any
+		if search.match != "" {
+			extra = []any{&match.Rank, &match.Name, &match.Snippet}
+		}
+		game, err := scanGame(rows, extra...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan game: %w", err)
 		}
+		if search.match != "" {
+			match.Name, match.NameHighlights = models.SplitHighlights(match.Name)
+			match.Snippet, match.SnippetHighlights = models.SplitHighlights(match.Snippet)
+			game.Match = &match
+		}
 		games = append(games, game)
 	}
-	
+
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating games: %w", err)
 	}
-	
+
 	return games, nil
 }
 
-// Update modifies an existing game in the database
-func (r *SQLiteGameRepository) Update(game *models.Game) error {
+// Count returns the number of games matching filter, ignoring its page
+func (r *SQLiteGameRepository) Count(ctx context.Context, filter models.GameFilter) (int, error) {
+	search, err := r.prepareSearch(filter.Search)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count games: %w", err)
+	}
+	if search.empty {
+		return 0, nil
+	}
+
+	where := gameWhere(r.db, filter)
+	if search.match != "" && database.IsPostgres(r.db) {
+		where.add("id IN (SELECT g.id FROM games g WHERE "+postgresGameDocument+" @@ to_tsquery('game_search', ?))", search.match)
+	} else if search.match != "" {
+		where.add("id IN (SELECT rowid FROM games_fts WHERE games_fts MATCH ?)", search.match)
+	} else if filter.Search != "" {
+		addGameLike(where, filter.Search)
+	}
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM games`+where.String(), where.args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count games: %w", err)
+	}
+
+	return count, nil
+}
+
+// gameSearch tells how to run the search term of a game filter
+type gameSearch struct {
+	match string // FTS5 or PostgreSQL text search query, when full-text search is available
+	empty bool   // the term has no words, so nothing can match
+}
+
+// prepareSearch prepares a search term, using the full-text index when the
+// database has one. PostgreSQL always has full-text search.
+func (r *SQLiteGameRepository) prepareSearch(term string) (gameSearch, error) {
+	if term == "" {
+		return gameSearch{}, nil
+	}
+
+	if database.IsPostgres(r.db) {
+		match := tsQuery(term)
+		return gameSearch{match: match, empty: match == ""}, nil
+	}
+
+	indexed, err := database.HasTable(r.db, database.GamesSearchTable)
+	if err != nil || !indexed {
+		return gameSearch{}, err
+	}
+
+	match := ftsQuery(term)
+	return gameSearch{match: match, empty: match == ""}, nil
+}
+
+// ftsQuery turns a search term into an FTS5 query matching every word of the
+// term as a prefix. Punctuation only separates words, so user input cannot
+// inject FTS5 operators.
+func ftsQuery(term string) string {
+	words := searchWords(term)
+	for i, word := range words {
+		words[i] = `"` + word + `"*`
+	}
+	return strings.Join(words, " ")
+}
+
+// searchWords splits a search term into its words of letters and digits
+func searchWords(term string) []string {
+	return strings.FieldsFunc(term, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// addGameLike adds the substring match used to search games without the
+// full-text index. Like the index, it ignores case and accents: "delice"
+// matches "Délice".
+func addGameLike(where *sqlWhere, term string) {
+	pattern := "%" + strings.ToLower(slug.Unaccent(term)) + "%"
+	where.add(`(LOWER(unaccent(name)) LIKE ? OR LOWER(unaccent(description)) LIKE ? OR EXISTS (
+			SELECT 1 FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+			WHERE gt.game_id = games.id AND LOWER(unaccent(t.name)) LIKE ?))`, pattern, pattern, pattern)
+}
+
+// gameWhere builds the conditions selecting the games matching filter, apart
+// from its search term, in the dialect of q
+func gameWhere(q database.Querier, filter models.GameFilter) *sqlWhere {
+	where := libraryWhere(q, "library_id")
+	for _, tagSlug := range filter.Tags {
+		where.add(`EXISTS (SELECT 1 FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+			WHERE gt.game_id = games.id AND t.slug = ?)`, tagSlug)
+	}
+	if filter.Condition != "" {
+		where.add("condition = ?", filter.Condition)
+	}
+	if filter.Available != nil {
+		where.add("is_available = ?", *filter.Available)
+	}
+	if filter.Players > 0 {
+		where.add("min_players BETWEEN 1 AND ? AND max_players >= ?", filter.Players, filter.Players)
+	}
+	if filter.MaxPlayTime > 0 {
+		where.add("play_time BETWEEN 1 AND ?", filter.MaxPlayTime)
+	}
+	if filter.Age > 0 {
+		where.add("min_age BETWEEN 1 AND ?", filter.Age)
+	}
+	if filter.Publisher != "" {
+		where.add("LOWER(publisher) LIKE ?", "%"+strings.ToLower(filter.Publisher)+"%")
+	}
+	if filter.Designer != "" {
+		designers := "SELECT 1 FROM json_each(games.designers) d WHERE LOWER(d.value) LIKE ?"
+		if database.IsPostgres(q) {
+			designers = postgresGameDesigners
+		}
+		where.add("EXISTS ("+designers+")", "%"+strings.ToLower(filter.Designer)+"%")
+	}
+	if filter.YearFrom != 0 {
+		where.add("year_published != 0 AND year_published >= ?", filter.YearFrom)
+	}
+	if filter.YearTo != 0 {
+		where.add("year_published != 0 AND year_published <= ?", filter.YearTo)
+	}
+	if filter.MinComplexity > 0 {
+		where.add("complexity >= ?", filter.MinComplexity)
+	}
+	if filter.MaxComplexity > 0 {
+		where.add("complexity > 0 AND complexity <= ?", filter.MaxComplexity)
+	}
+	return where
+}
+
+// Search finds every game matching the query string, best match first
+func (r *SQLiteGameRepository) Search(ctx context.Context, query string) ([]*models.Game, error) {
+	games, err := r.List(ctx, models.GameFilter{
+		Search:      query,
+		ListOptions: models.ListOptions{Sort: models.GameSortRelevance},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search games: %w", err)
+	}
+
+	return games, nil
+}
+
+// Update modifies an existing game in the database and replaces its tags
+func (r *SQLiteGameRepository) Update(ctx context.Context, game *models.Game) error {
 	query := `
 		UPDATE games
-		SET name = ?, description = ?, category = ?, condition = ?, is_available = ?
-		WHERE id = ?`
-	
-	result, err := r.db.Exec(query, game.Name, game.Description, game.Category,
-		game.Condition, game.IsAvailable, game.ID)
+		SET name = ?, description = ?, condition = ?, is_available = ?,
+			min_players = ?, max_players = ?, play_time = ?, min_age = ?, publisher = ?,
+			designers = ?, year_published = ?, complexity = ?, image_url = ?, bgg_id = ?
+		WHERE id = ? AND library_id = ?`
+
+	designers, err := encodeDesigners(game.Designers)
 	if err != nil {
-		return fmt.Errorf("failed to update game: %w", err)
+		return err
 	}
-	
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+
+	return database.InTxContext(ctx, r.db, func(tx database.Querier) error {
+		result, err := tx.ExecContext(ctx, query, game.Name, game.Description,
+			game.Condition, game.IsAvailable,
+			game.MinPlayers, game.MaxPlayers, game.PlayTime, game.MinAge, game.Publisher,
+			designers, game.YearPublished, game.Complexity, game.ImageURL, game.BGGID, game.ID, database.LibraryOf(tx))
+		if err != nil {
+			return fmt.Errorf("failed to update game: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return models.NotFound("game_not_found", game.ID)
+		}
+
+		return setGameTags(ctx, tx, game.ID, game.Tags)
+	})
+}
+
+// setGameTags replaces the tags of a game, creating the tags not used yet.
+// Names are matched to existing tags by slug.
+func setGameTags(ctx context.Context, q database.Querier, gameID int, names []string) error {
+	if _, err := q.ExecContext(ctx, `DELETE FROM game_tags WHERE game_id = ?`, gameID); err != nil {
+		return fmt.Errorf("failed to clear game tags: %w", err)
 	}
-	
-	if rowsAffected == 0 {
-		return fmt.Errorf("game with id %d not found", game.ID)
+
+	for _, name := range names {
+		tagID, err := ensureTag(ctx, q, name)
+		if err != nil {
+			return err
+		}
+		if _, err := q.ExecContext(ctx, `INSERT INTO game_tags (game_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING`, gameID, tagID); err != nil {
+			return fmt.Errorf("failed to tag game: %w", err)
+		}
 	}
-	
+
 	return nil
 }
 
+// ensureTag returns the ID of the tag named name, creating it when no tag
+// has the same slug
+func ensureTag(ctx context.Context, q database.Querier, name string) (int, error) {
+	name = strings.TrimSpace(name)
+	tagSlug := models.TagSlug(name)
+
+	_, err := q.ExecContext(ctx, `INSERT INTO tags (library_id, name, slug, created_at) VALUES (?, ?, ?, ?) ON CONFLICT (library_id, slug) DO NOTHING`,
+		database.LibraryOf(q), name, tagSlug, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to create tag %q: %w", name, err)
+	}
+
+	var tagID int
+	if err := q.QueryRowContext(ctx, `SELECT id FROM tags WHERE library_id = ? AND slug = ?`, database.LibraryOf(q), tagSlug).Scan(&tagID); err != nil {
+		return 0, fmt.Errorf("failed to get tag %q: %w", name, err)
+	}
+
+	return tagID, nil
+}
+
 // Delete removes a game from the database
-func (r *SQLiteGameRepository) Delete(id int) error {
-	query := `DELETE FROM games WHERE id = ?`
+func (r *SQLiteGameRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM games WHERE id = ? AND library_id = ?`
 	
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id, database.LibraryOf(r.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete game: %w", err)
 	}
@@ -165,21 +477,21 @@ func (r *SQLiteGameRepository) Delete(id int) error {
 	}
 	
 	if rowsAffected == 0 {
-		return fmt.Errorf("game with id %d not found", id)
+		return models.NotFound("game_not_found", id)
 	}
 	
 	return nil
 }
 
-// GetAvailable retrieves all available games
-func (r *SQLiteGameRepository) GetAvailable() ([]*models.Game, error) {
+// GetAvailable retrieves all games with at least one free copy
+func (r *SQLiteGameRepository) GetAvailable(ctx context.Context) ([]*models.Game, error) {
 	query := `
-		SELECT id, name, description, category, entry_date, condition, is_available
+		SELECT ` + gameColumns(r.db) + `
 		FROM games
-		WHERE is_available = TRUE
+		WHERE is_available = TRUE AND library_id = ?
 		ORDER BY name`
 	
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get available games: %w", err)
 	}
@@ -187,11 +499,7 @@ func (r *SQLiteGameRepository) GetAvailable() ([]*models.Game, error) {
 	
 	var games []*models.Game
 	for rows.Next() {
-		game := &models.Game{}
-		err := rows.Scan(
-			&game.ID, &game.Name, &game.Description, &game.Category,
-			&game.EntryDate, &game.Condition, &game.IsAvailable,
-		)
+		game, err := scanGame(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan game: %w", err)
 		}
@@ -203,4 +511,201 @@ func (r *SQLiteGameRepository) GetAvailable() ([]*models.Game, error) {
 	}
 	
 	return games, nil
-}
\ No newline at end of file
+}
+
+// scanGame scans a single game row selected with gameColumns, followed by
+// the extra columns given
+func scanGame(row rowScanner, extra ...interface{}) (*models.Game, error) {
+	game := &models.Game{}
+	var tags, designers string
+	dest := append([]interface{}{
+		&game.ID, &game.Name, &game.Description, &tags,
+		&game.EntryDate, &game.Condition, &game.IsAvailable,
+		&game.MinPlayers, &game.MaxPlayers, &game.PlayTime, &game.MinAge, &game.Publisher,
+		&designers, &game.YearPublished, &game.Complexity,
+		&game.ImageURL, &game.BGGID, &game.TotalCopies, &game.AvailableCopies,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(tags), &game.Tags); err != nil {
+		return nil, fmt.Errorf("invalid tags of game %d: %w", game.ID, err)
+	}
+	if err := json.Unmarshal([]byte(designers), &game.Designers); err != nil {
+		return nil, fmt.Errorf("invalid designers of game %d: %w", game.ID, err)
+	}
+
+	return game, nil
+}
+
+// encodeDesigners stores the designers of a game as a JSON array
+func encodeDesigners(designers []string) (string, error) {
+	if designers == nil {
+		designers = []string{}
+	}
+
+	encoded, err := json.Marshal(designers)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode designers: %w", err)
+	}
+
+	return string(encoded), nil
+}
+
+// CreateCopy adds a physical copy to an existing game
+func (r *SQLiteGameRepository) CreateCopy(ctx context.Context, gameCopy *models.GameCopy) error {
+	query := `
+		INSERT INTO game_copies (library_id, game_id, barcode, condition, acquired_at, is_available, in_repair)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`
+	
+	err := r.db.QueryRowContext(ctx, query, database.LibraryOf(r.db), gameCopy.GameID, gameCopy.Barcode, gameCopy.Condition,
+		gameCopy.AcquiredAt, gameCopy.IsAvailable, gameCopy.InRepair).Scan(&gameCopy.ID)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return models.Conflict("copy_barcode_taken", gameCopy.Barcode)
+		}
+		return fmt.Errorf("failed to create game copy: %w", err)
+	}
+	
+	return r.refreshAvailability(ctx, gameCopy.GameID)
+}
+
+// GetCopyByID retrieves a game copy by its ID
+func (r *SQLiteGameRepository) GetCopyByID(ctx context.Context, id int) (*models.GameCopy, error) {
+	query := `
+		SELECT id, game_id, barcode, condition, acquired_at, is_available, in_repair
+		FROM game_copies
+		WHERE id = ? AND library_id = ?`
+	
+	gameCopy := &models.GameCopy{}
+	err := r.db.QueryRowContext(ctx, query, id, database.LibraryOf(r.db)).Scan(
+		&gameCopy.ID, &gameCopy.GameID, &gameCopy.Barcode, &gameCopy.Condition,
+		&gameCopy.AcquiredAt, &gameCopy.IsAvailable, &gameCopy.InRepair,
+	)
+	
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("copy_not_found", id)
+		}
+		return nil, fmt.Errorf("failed to get game copy by id: %w", err)
+	}
+	
+	return gameCopy, nil
+}
+
+// GetCopyByBarcode retrieves a game copy by its barcode
+func (r *SQLiteGameRepository) GetCopyByBarcode(ctx context.Context, barcode string) (*models.GameCopy, error) {
+	query := `
+		SELECT id, game_id, barcode, condition, acquired_at, is_available, in_repair
+		FROM game_copies
+		WHERE barcode = ? AND barcode <> '' AND library_id = ?`
+	
+	gameCopy := &models.GameCopy{}
+	err := r.db.QueryRowContext(ctx, query, barcode, database.LibraryOf(r.db)).Scan(
+		&gameCopy.ID, &gameCopy.GameID, &gameCopy.Barcode, &gameCopy.Condition,
+		&gameCopy.AcquiredAt, &gameCopy.IsAvailable, &gameCopy.InRepair,
+	)
+	
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("copy_barcode_not_found", barcode)
+		}
+		return nil, fmt.Errorf("failed to get game copy by barcode: %w", err)
+	}
+	
+	return gameCopy, nil
+}
+
+// GetCopies retrieves all copies of a game, oldest first
+func (r *SQLiteGameRepository) GetCopies(ctx context.Context, gameID int) ([]*models.GameCopy, error) {
+	query := `
+		SELECT id, game_id, barcode, condition, acquired_at, is_available, in_repair
+		FROM game_copies
+		WHERE game_id = ? AND library_id = ?
+		ORDER BY id`
+	
+	rows, err := r.db.QueryContext(ctx, query, gameID, database.LibraryOf(r.db))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game copies: %w", err)
+	}
+	defer rows.Close()
+	
+	var copies []*models.GameCopy
+	for rows.Next() {
+		gameCopy := &models.GameCopy{}
+		err := rows.Scan(
+			&gameCopy.ID, &gameCopy.GameID, &gameCopy.Barcode, &gameCopy.Condition,
+			&gameCopy.AcquiredAt, &gameCopy.IsAvailable, &gameCopy.InRepair,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan game copy: %w", err)
+		}
+		copies = append(copies, gameCopy)
+	}
+	
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating game copies: %w", err)
+	}
+	
+	return copies, nil
+}
+
+// UpdateCopy modifies an existing game copy
+func (r *SQLiteGameRepository) UpdateCopy(ctx context.Context, gameCopy *models.GameCopy) error {
+	query := `
+		UPDATE game_copies
+		SET barcode = ?, condition = ?, is_available = ?, in_repair = ?
+		WHERE id = ? AND library_id = ?`
+	
+	result, err := r.db.ExecContext(ctx, query, gameCopy.Barcode, gameCopy.Condition, gameCopy.IsAvailable, gameCopy.InRepair,
+		gameCopy.ID, database.LibraryOf(r.db))
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return models.Conflict("copy_barcode_taken", gameCopy.Barcode)
+		}
+		return fmt.Errorf("failed to update game copy: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return models.NotFound("copy_not_found", gameCopy.ID)
+	}
+	
+	return r.refreshAvailability(ctx, gameCopy.GameID)
+}
+
+// DeleteCopy removes a game copy from the database
+func (r *SQLiteGameRepository) DeleteCopy(ctx context.Context, id int) error {
+	gameCopy, err := r.GetCopyByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM game_copies WHERE id = ? AND library_id = ?`, id, database.LibraryOf(r.db)); err != nil {
+		return fmt.Errorf("failed to delete game copy: %w", err)
+	}
+	
+	return r.refreshAvailability(ctx, gameCopy.GameID)
+}
+
+// refreshAvailability keeps the game's availability flag in line with its copies
+func (r *SQLiteGameRepository) refreshAvailability(ctx context.Context, gameID int) error {
+	query := `
+		UPDATE games
+		SET is_available = EXISTS (
+			SELECT 1 FROM game_copies WHERE game_id = ? AND is_available = TRUE
+		)
+		WHERE id = ?`
+	
+	if _, err := r.db.ExecContext(ctx, query, gameID, gameID); err != nil {
+		return fmt.Errorf("failed to refresh game availability: %w", err)
+	}
+	
+	return nil
+}
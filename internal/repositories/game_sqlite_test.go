@@ -2,11 +2,17 @@ package repositories
 
 import (
 	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
 
 func TestSQLiteGameRepository_Create(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -15,13 +21,13 @@ func TestSQLiteGameRepository_Create(t *testing.T) {
 	game := &models.Game{
 		Name:        "Monopoly",
 		Description: "Classic board game",
-		Category:    "Strategy",
+		Tags:        []string{"Strategy"},
 		EntryDate:   time.Now(),
 		Condition:   "good",
 		IsAvailable: true,
 	}
 
-	err := repo.Create(game)
+	err := repo.Create(ctx, game)
 	if err != nil {
 		t.Fatalf("Failed to create game: %v", err)
 	}
@@ -32,6 +38,7 @@ func TestSQLiteGameRepository_Create(t *testing.T) {
 }
 
 func TestSQLiteGameRepository_GetByID(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -41,19 +48,19 @@ func TestSQLiteGameRepository_GetByID(t *testing.T) {
 	game := &models.Game{
 		Name:        "Scrabble",
 		Description: "Word game",
-		Category:    "Word",
+		Tags:        []string{"Word"},
 		EntryDate:   time.Now(),
 		Condition:   "excellent",
 		IsAvailable: true,
 	}
 	
-	err := repo.Create(game)
+	err := repo.Create(ctx, game)
 	if err != nil {
 		t.Fatalf("Failed to create game: %v", err)
 	}
 
 	// Retrieve the game
-	retrieved, err := repo.GetByID(game.ID)
+	retrieved, err := repo.GetByID(ctx, game.ID)
 	if err != nil {
 		t.Fatalf("Failed to get game by ID: %v", err)
 	}
@@ -64,12 +71,13 @@ func TestSQLiteGameRepository_GetByID(t *testing.T) {
 	if retrieved.Description != game.Description {
 		t.Errorf("Expected description %s, got %s", game.Description, retrieved.Description)
 	}
-	if retrieved.Category != game.Category {
-		t.Errorf("Expected category %s, got %s", game.Category, retrieved.Category)
+	if !reflect.DeepEqual(retrieved.Tags, game.Tags) {
+		t.Errorf("Expected tags %v, got %v", game.Tags, retrieved.Tags)
 	}
 }
 
 func TestSQLiteGameRepository_GetAll(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -77,20 +85,20 @@ func TestSQLiteGameRepository_GetAll(t *testing.T) {
 	
 	// Create multiple games
 	games := []*models.Game{
-		{Name: "Chess", Description: "Strategy game", Category: "Strategy", EntryDate: time.Now(), Condition: "good", IsAvailable: true},
-		{Name: "Checkers", Description: "Classic game", Category: "Strategy", EntryDate: time.Now(), Condition: "fair", IsAvailable: false},
-		{Name: "Risk", Description: "World domination", Category: "Strategy", EntryDate: time.Now(), Condition: "excellent", IsAvailable: true},
+		{Name: "Chess", Description: "Strategy game", Tags: []string{"Strategy"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Checkers", Description: "Classic game", Tags: []string{"Strategy"}, EntryDate: time.Now(), Condition: "fair", IsAvailable: false},
+		{Name: "Risk", Description: "World domination", Tags: []string{"Strategy"}, EntryDate: time.Now(), Condition: "excellent", IsAvailable: true},
 	}
 
 	for _, game := range games {
-		err := repo.Create(game)
+		err := repo.Create(ctx, game)
 		if err != nil {
 			t.Fatalf("Failed to create game: %v", err)
 		}
 	}
 
 	// Retrieve all games
-	retrieved, err := repo.GetAll()
+	retrieved, err := repo.GetAll(ctx)
 	if err != nil {
 		t.Fatalf("Failed to get all games: %v", err)
 	}
@@ -100,7 +108,27 @@ func TestSQLiteGameRepository_GetAll(t *testing.T) {
 	}
 }
 
+func TestSQLiteGameRepository_GetAllCancelled(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteGameRepository(db)
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := repo.Create(ctx, &models.Game{Name: "Chess", EntryDate: time.Now(), Condition: "good", IsAvailable: true}); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	cancel()
+	if _, err := repo.GetAll(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the query to be cancelled, got %v", err)
+	}
+	if err := repo.Create(ctx, &models.Game{Name: "Go", EntryDate: time.Now(), Condition: "good", IsAvailable: true}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the insert to be cancelled, got %v", err)
+	}
+}
+
 func TestSQLiteGameRepository_Search(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -108,20 +136,20 @@ func TestSQLiteGameRepository_Search(t *testing.T) {
 	
 	// Create games with different names and descriptions
 	games := []*models.Game{
-		{Name: "Monopoly", Description: "Property trading game", Category: "Strategy", EntryDate: time.Now(), Condition: "good", IsAvailable: true},
-		{Name: "Scrabble", Description: "Word building game", Category: "Word", EntryDate: time.Now(), Condition: "good", IsAvailable: true},
-		{Name: "Chess", Description: "Strategic board game", Category: "Strategy", EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Monopoly", Description: "Property trading game", Tags: []string{"Strategy"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Scrabble", Description: "Word building game", Tags: []string{"Word"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Chess", Description: "Strategic board game", Tags: []string{"Strategy"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
 	}
 
 	for _, game := range games {
-		err := repo.Create(game)
+		err := repo.Create(ctx, game)
 		if err != nil {
 			t.Fatalf("Failed to create game: %v", err)
 		}
 	}
 
 	// Search by name
-	results, err := repo.Search("Monopoly")
+	results, err := repo.Search(ctx, "Monopoly")
 	if err != nil {
 		t.Fatalf("Failed to search games: %v", err)
 	}
@@ -130,7 +158,7 @@ func TestSQLiteGameRepository_Search(t *testing.T) {
 	}
 
 	// Search by description
-	results, err = repo.Search("game")
+	results, err = repo.Search(ctx, "game")
 	if err != nil {
 		t.Fatalf("Failed to search games: %v", err)
 	}
@@ -138,8 +166,8 @@ func TestSQLiteGameRepository_Search(t *testing.T) {
 		t.Errorf("Expected 3 results for 'game' search, got %d", len(results))
 	}
 
-	// Search by category
-	results, err = repo.Search("Strategy")
+	// Search by tag
+	results, err = repo.Search(ctx, "Strategy")
 	if err != nil {
 		t.Fatalf("Failed to search games: %v", err)
 	}
@@ -149,6 +177,7 @@ func TestSQLiteGameRepository_Search(t *testing.T) {
 }
 
 func TestSQLiteGameRepository_Update(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -158,13 +187,13 @@ func TestSQLiteGameRepository_Update(t *testing.T) {
 	game := &models.Game{
 		Name:        "Original Name",
 		Description: "Original description",
-		Category:    "Original",
+		Tags:        []string{"Original"},
 		EntryDate:   time.Now(),
 		Condition:   "good",
 		IsAvailable: true,
 	}
 	
-	err := repo.Create(game)
+	err := repo.Create(ctx, game)
 	if err != nil {
 		t.Fatalf("Failed to create game: %v", err)
 	}
@@ -172,17 +201,17 @@ func TestSQLiteGameRepository_Update(t *testing.T) {
 	// Update the game
 	game.Name = "Updated Name"
 	game.Description = "Updated description"
-	game.Category = "Updated"
+	game.Tags = []string{"Updated", "Family"}
 	game.Condition = "excellent"
 	game.IsAvailable = false
 
-	err = repo.Update(game)
+	err = repo.Update(ctx, game)
 	if err != nil {
 		t.Fatalf("Failed to update game: %v", err)
 	}
 
 	// Retrieve and verify the update
-	retrieved, err := repo.GetByID(game.ID)
+	retrieved, err := repo.GetByID(ctx, game.ID)
 	if err != nil {
 		t.Fatalf("Failed to get updated game: %v", err)
 	}
@@ -196,9 +225,13 @@ func TestSQLiteGameRepository_Update(t *testing.T) {
 	if retrieved.IsAvailable != false {
 		t.Errorf("Expected IsAvailable false, got %t", retrieved.IsAvailable)
 	}
+	if strings.Join(retrieved.Tags, ",") != "Family,Updated" {
+		t.Errorf("Expected tags to be replaced and sorted by name, got %v", retrieved.Tags)
+	}
 }
 
 func TestSQLiteGameRepository_Delete(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -208,31 +241,32 @@ func TestSQLiteGameRepository_Delete(t *testing.T) {
 	game := &models.Game{
 		Name:        "To Delete",
 		Description: "Game to be deleted",
-		Category:    "Test",
+		Tags:        []string{"Test"},
 		EntryDate:   time.Now(),
 		Condition:   "good",
 		IsAvailable: true,
 	}
 	
-	err := repo.Create(game)
+	err := repo.Create(ctx, game)
 	if err != nil {
 		t.Fatalf("Failed to create game: %v", err)
 	}
 
 	// Delete the game
-	err = repo.Delete(game.ID)
+	err = repo.Delete(ctx, game.ID)
 	if err != nil {
 		t.Fatalf("Failed to delete game: %v", err)
 	}
 
 	// Verify the game is deleted
-	_, err = repo.GetByID(game.ID)
+	_, err = repo.GetByID(ctx, game.ID)
 	if err == nil {
 		t.Error("Expected error when getting deleted game, but got none")
 	}
 }
 
 func TestSQLiteGameRepository_GetAvailable(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -240,20 +274,20 @@ func TestSQLiteGameRepository_GetAvailable(t *testing.T) {
 	
 	// Create games with different availability
 	games := []*models.Game{
-		{Name: "Available Game 1", Description: "Available", Category: "Test", EntryDate: time.Now(), Condition: "good", IsAvailable: true},
-		{Name: "Unavailable Game", Description: "Not available", Category: "Test", EntryDate: time.Now(), Condition: "good", IsAvailable: false},
-		{Name: "Available Game 2", Description: "Available", Category: "Test", EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Available Game 1", Description: "Available", Tags: []string{"Test"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Unavailable Game", Description: "Not available", Tags: []string{"Test"}, EntryDate: time.Now(), Condition: "good", IsAvailable: false},
+		{Name: "Available Game 2", Description: "Available", Tags: []string{"Test"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
 	}
 
 	for _, game := range games {
-		err := repo.Create(game)
+		err := repo.Create(ctx, game)
 		if err != nil {
 			t.Fatalf("Failed to create game: %v", err)
 		}
 	}
 
 	// Get available games
-	available, err := repo.GetAvailable()
+	available, err := repo.GetAvailable(ctx)
 	if err != nil {
 		t.Fatalf("Failed to get available games: %v", err)
 	}
@@ -268,4 +302,327 @@ func TestSQLiteGameRepository_GetAvailable(t *testing.T) {
 			t.Errorf("Expected all games to be available, but found unavailable game: %s", game.Name)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestSQLiteGameRepository_List(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteGameRepository(db)
+
+	games := []*models.Game{
+		{Name: "Chess", Description: "Strategy game", Tags: []string{"Strategy", "Classic"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Azul", Description: "Tile drafting", Tags: []string{"Abstract"}, EntryDate: time.Now(), Condition: "excellent", IsAvailable: true},
+		{Name: "Risk", Description: "World domination", Tags: []string{"Strategy"}, EntryDate: time.Now(), Condition: "fair", IsAvailable: false},
+	}
+	for _, game := range games {
+		if err := repo.Create(ctx, game); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+	}
+
+	available := true
+	tests := []struct {
+		name   string
+		filter models.GameFilter
+		want   []string
+		total  int
+	}{
+		{"default sorts by name", models.GameFilter{}, []string{"Azul", "Chess", "Risk"}, 3},
+		{"descending", models.GameFilter{ListOptions: models.ListOptions{Sort: "name", Order: models.SortDesc}}, []string{"Risk", "Chess", "Azul"}, 3},
+		{"tag", models.GameFilter{Tags: []string{"STRATEGY"}}, []string{"Chess", "Risk"}, 2},
+		{"every tag", models.GameFilter{Tags: []string{"strategy", "classic"}}, []string{"Chess"}, 1},
+		{"condition", models.GameFilter{Condition: "fair"}, []string{"Risk"}, 1},
+		{"available", models.GameFilter{Available: &available}, []string{"Azul", "Chess"}, 2},
+		{"search", models.GameFilter{Search: "TILE"}, []string{"Azul"}, 1},
+		{"page", models.GameFilter{ListOptions: models.ListOptions{Page: 2, PerPage: 2}}, []string{"Risk"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			if err := models.ValidateGameFilter(&filter); err != nil {
+				t.Fatalf("Invalid filter: %v", err)
+			}
+
+			retrieved, err := repo.List(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to list games: %v", err)
+			}
+			var names []string
+			for _, game := range retrieved {
+				names = append(names, game.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected %v, got %v", tt.want, names)
+			}
+
+			total, err := repo.Count(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to count games: %v", err)
+			}
+			if total != tt.total {
+				t.Errorf("Expected total %d, got %d", tt.total, total)
+			}
+		})
+	}
+}
+
+func TestSQLiteGameRepository_FullTextSearch(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	if !database.SupportsFTS5(db) {
+		t.Skip("SQLite built without FTS5 (use -tags sqlite_fts5)")
+	}
+
+	repo := NewSQLiteGameRepository(db)
+	games := []*models.Game{
+		{Name: "Les Délices de Paris", Description: "Un jeu de <cuisine> gourmand", Tags: []string{"Famille"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Carcassonne", Description: "Tuiles et délices médiévaux", Tags: []string{"Stratégie"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Azul", Description: "Tile drafting", Tags: []string{"Abstract"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+	}
+	for _, game := range games {
+		if err := repo.Create(ctx, game); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+	}
+
+	search := func(term string) []*models.Game {
+		t.Helper()
+		filter := models.GameFilter{Search: term}
+		if err := models.ValidateGameFilter(&filter); err != nil {
+			t.Fatalf("Invalid filter: %v", err)
+		}
+		results, err := repo.List(ctx, filter)
+		if err != nil {
+			t.Fatalf("Failed to search %q: %v", term, err)
+		}
+		total, err := repo.Count(ctx, filter)
+		if err != nil {
+			t.Fatalf("Failed to count %q: %v", term, err)
+		}
+		if total != len(results) {
+			t.Errorf("Expected total %d for %q, got %d", len(results), term, total)
+		}
+		return results
+	}
+
+	// Accent- and case-insensitive, name matches ranked before descriptions
+	results := search("DELICE")
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Name != "Les Délices de Paris" || results[1].Name != "Carcassonne" {
+		t.Errorf("Expected name match first, got %s then %s", results[0].Name, results[1].Name)
+	}
+	if results[0].Match == nil || results[0].Match.Name != "Les Délices de Paris" ||
+		!reflect.DeepEqual(results[0].Match.NameHighlights, []models.TextRange{{Start: 4, End: 11}}) {
+		t.Errorf("Expected highlighted name, got %+v", results[0].Match)
+	}
+	if results[0].Match.Rank >= results[1].Match.Rank {
+		t.Errorf("Expected best match to have the lowest rank, got %f and %f", results[0].Match.Rank, results[1].Match.Rank)
+	}
+	if got := models.HighlightHTML(results[1].Match.Snippet, results[1].Match.SnippetHighlights); !strings.Contains(got, "<mark>délices</mark>") {
+		t.Errorf("Expected highlighted snippet, got %q", got)
+	}
+
+	// Prefix matching, with descriptions returned as plain text
+	results = search("cuis")
+	if len(results) != 1 || !strings.Contains(results[0].Match.Snippet, "<cuisine>") ||
+		!strings.Contains(models.HighlightHTML(results[0].Match.Snippet, results[0].Match.SnippetHighlights), "&lt;<mark>cuisine</mark>&gt;") {
+		t.Errorf("Expected escaped prefix match, got %+v", results)
+	}
+
+	// Every word must match, and FTS5 syntax is treated as text
+	if results = search("délices paris"); len(results) != 1 {
+		t.Errorf("Expected 1 result for both words, got %d", len(results))
+	}
+	if results = search(`azul" OR "carcassonne`); len(results) != 0 {
+		t.Errorf("Expected operators to be searched as words, got %d results", len(results))
+	}
+	if results = search("!!"); len(results) != 0 {
+		t.Errorf("Expected no results without words, got %d", len(results))
+	}
+
+	// Tags are searched, and combine with tag filters
+	if results = search("strategie"); len(results) != 1 || results[0].Name != "Carcassonne" {
+		t.Errorf("Expected tag match, got %+v", results)
+	}
+	filter := models.GameFilter{Search: "délices", Tags: []string{"famille"}}
+	if err := models.ValidateGameFilter(&filter); err != nil {
+		t.Fatalf("Invalid filter: %v", err)
+	}
+	if results, err := repo.List(ctx, filter); err != nil || len(results) != 1 || results[0].Name != "Les Délices de Paris" {
+		t.Errorf("Expected search restricted to the tag, got %+v (%v)", results, err)
+	}
+
+	// The index follows updates
+	games[2].Name = "Azul Pavillon d'été"
+	if err := repo.Update(ctx, games[2]); err != nil {
+		t.Fatalf("Failed to update game: %v", err)
+	}
+	if results = search("ete"); len(results) != 1 || results[0].ID != games[2].ID {
+		t.Errorf("Expected updated name to be searchable, got %+v", results)
+	}
+}
+
+func TestSQLiteGameRepository_SubstringSearch(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	if indexed, err := database.HasTable(db, database.GamesSearchTable); err != nil || indexed {
+		t.Skip("SQLite built with FTS5, the full-text index is searched instead")
+	}
+
+	repo := NewSQLiteGameRepository(db)
+	games := []*models.Game{
+		{Name: "Les Délices de Paris", Description: "Un jeu gourmand", Tags: []string{"Famille"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Carcassonne", Description: "Tuiles et DÉLICES médiévaux", Tags: []string{"Stratégie"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Azul", Tags: []string{"Abstract"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+	}
+	for _, game := range games {
+		if err := repo.Create(ctx, game); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+	}
+
+	tests := []struct {
+		term string
+		want []string
+	}{
+		{"delice", []string{"Carcassonne", "Les Délices de Paris"}},
+		{"Délices", []string{"Carcassonne", "Les Délices de Paris"}},
+		{"MEDIEVAUX", []string{"Carcassonne"}},
+		{"strategie", []string{"Carcassonne"}},
+		{"azu", []string{"Azul"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			filter := models.GameFilter{Search: tt.term}
+			if err := models.ValidateGameFilter(&filter); err != nil {
+				t.Fatalf("Invalid filter: %v", err)
+			}
+			results, err := repo.List(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to search %q: %v", tt.term, err)
+			}
+			var names []string
+			for _, game := range results {
+				names = append(names, game.Name)
+			}
+			if strings.Join(names, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("Search %q = %v, want %v", tt.term, names, tt.want)
+			}
+			if total, err := repo.Count(ctx, filter); err != nil || total != len(tt.want) {
+				t.Errorf("Count %q = %d, %v, want %d", tt.term, total, err, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestSQLiteGameRepository_Details(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteGameRepository(db)
+	games := []*models.Game{
+		{Name: "Azul", EntryDate: time.Now(), Condition: "good", IsAvailable: true, GameDetails: models.GameDetails{
+			MinPlayers: 2, MaxPlayers: 4, PlayTime: 45, MinAge: 8, Publisher: "Plan B Games",
+			Designers: []string{"Michael Kiesling"}, YearPublished: 2017, Complexity: 1.8,
+			ImageURL: "https://cf.geekdo-images.com/azul.jpg", BGGID: 230802,
+		}},
+		{Name: "Patchwork", EntryDate: time.Now(), Condition: "good", IsAvailable: true, GameDetails: models.GameDetails{
+			MinPlayers: 2, MaxPlayers: 2, PlayTime: 20, MinAge: 8, Publisher: "Lookout Games",
+			Designers: []string{"Uwe Rosenberg"}, YearPublished: 2014, Complexity: 1.6,
+		}},
+		{Name: "Agricola", EntryDate: time.Now(), Condition: "good", IsAvailable: true, GameDetails: models.GameDetails{
+			MinPlayers: 1, MaxPlayers: 5, PlayTime: 120, MinAge: 12, Publisher: "Lookout Games",
+			Designers: []string{"Uwe Rosenberg"}, YearPublished: 2007, Complexity: 3.6,
+		}},
+		{Name: "Mystery", EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+	}
+	for _, game := range games {
+		if err := repo.Create(ctx, game); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+	}
+
+	retrieved, err := repo.GetByID(ctx, games[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if !reflect.DeepEqual(retrieved.GameDetails, games[0].GameDetails) {
+		t.Errorf("Expected details %+v, got %+v", games[0].GameDetails, retrieved.GameDetails)
+	}
+
+	unknown, err := repo.GetByID(ctx, games[3].ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if unknown.Designers == nil || len(unknown.Designers) != 0 {
+		t.Errorf("Expected no designers, got %#v", unknown.Designers)
+	}
+
+	games[1].Designers = append(games[1].Designers, "Klemens Franz")
+	games[1].PlayTime = 30
+	if err := repo.Update(ctx, games[1]); err != nil {
+		t.Fatalf("Failed to update game: %v", err)
+	}
+	retrieved, err = repo.GetByID(ctx, games[1].ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if retrieved.PlayTime != 30 || len(retrieved.Designers) != 2 {
+		t.Errorf("Expected updated details, got %+v", retrieved.GameDetails)
+	}
+
+	tests := []struct {
+		name   string
+		filter models.GameFilter
+		want   []string
+	}{
+		{"two players under 30 minutes", models.GameFilter{Players: 2, MaxPlayTime: 30}, []string{"Patchwork"}},
+		{"solo", models.GameFilter{Players: 1}, []string{"Agricola"}},
+		{"age", models.GameFilter{Age: 10}, []string{"Azul", "Patchwork"}},
+		{"publisher", models.GameFilter{Publisher: "lookout"}, []string{"Agricola", "Patchwork"}},
+		{"designer", models.GameFilter{Designer: "rosenberg"}, []string{"Agricola", "Patchwork"}},
+		{"years", models.GameFilter{YearFrom: 2010, YearTo: 2015}, []string{"Patchwork"}},
+		{"complexity", models.GameFilter{MinComplexity: 1.7, MaxComplexity: 3}, []string{"Azul"}},
+		{"by year", models.GameFilter{ListOptions: models.ListOptions{Sort: "year_published"}}, []string{"Azul", "Patchwork", "Agricola", "Mystery"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			if err := models.ValidateGameFilter(&filter); err != nil {
+				t.Fatalf("Invalid filter: %v", err)
+			}
+
+			retrieved, err := repo.List(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to list games: %v", err)
+			}
+			var names []string
+			for _, game := range retrieved {
+				names = append(names, game.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected %v, got %v", tt.want, names)
+			}
+
+			total, err := repo.Count(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to count games: %v", err)
+			}
+			if total != len(tt.want) {
+				t.Errorf("Expected total %d, got %d", len(tt.want), total)
+			}
+		})
+	}
+}
@@ -2,49 +2,206 @@ package repositories
 
 import (
 	"board-game-library/internal/models"
+	"context"
+	"time"
 )
 
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
-	Create(user *models.User) error
-	GetByID(id int) (*models.User, error)
-	GetByEmail(email string) (*models.User, error)
-	GetAll() ([]*models.User, error)
-	Update(user *models.User) error
-	Delete(id int) error
-	GetBorrowingHistory(userID int) ([]*models.Borrowing, error)
+	Create(ctx context.Context, user *models.User) error
+	GetByID(ctx context.Context, id int) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetAll(ctx context.Context) ([]*models.User, error)
+	List(ctx context.Context, filter models.UserFilter) ([]*models.User, error)
+	Count(ctx context.Context, filter models.UserFilter) (int, error)
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, id int) error
+	GetBorrowingHistory(ctx context.Context, userID int) ([]*models.Borrowing, error)
 }
 
 // GameRepository defines the interface for game data operations
 type GameRepository interface {
-	Create(game *models.Game) error
-	GetByID(id int) (*models.Game, error)
-	GetAll() ([]*models.Game, error)
-	Search(query string) ([]*models.Game, error)
-	Update(game *models.Game) error
-	Delete(id int) error
-	GetAvailable() ([]*models.Game, error)
+	Create(ctx context.Context, game *models.Game) error
+	GetByID(ctx context.Context, id int) (*models.Game, error)
+	GetAll(ctx context.Context) ([]*models.Game, error)
+	List(ctx context.Context, filter models.GameFilter) ([]*models.Game, error)
+	Count(ctx context.Context, filter models.GameFilter) (int, error)
+	Search(ctx context.Context, query string) ([]*models.Game, error)
+	Update(ctx context.Context, game *models.Game) error
+	Delete(ctx context.Context, id int) error
+	GetAvailable(ctx context.Context) ([]*models.Game, error)
+	CreateCopy(ctx context.Context, copy *models.GameCopy) error
+	GetCopyByID(ctx context.Context, id int) (*models.GameCopy, error)
+	GetCopyByBarcode(ctx context.Context, barcode string) (*models.GameCopy, error)
+	GetCopies(ctx context.Context, gameID int) ([]*models.GameCopy, error)
+	UpdateCopy(ctx context.Context, copy *models.GameCopy) error
+	DeleteCopy(ctx context.Context, id int) error
 }
 
 // BorrowingRepository defines the interface for borrowing data operations
 type BorrowingRepository interface {
-	Create(borrowing *models.Borrowing) error
-	GetByID(id int) (*models.Borrowing, error)
-	GetActiveByUser(userID int) ([]*models.Borrowing, error)
-	GetByGame(gameID int) ([]*models.Borrowing, error)
-	GetOverdue() ([]*models.Borrowing, error)
-	Update(borrowing *models.Borrowing) error
-	ReturnGame(borrowingID int) error
-	GetAll() ([]*models.Borrowing, error)
+	Create(ctx context.Context, borrowing *models.Borrowing) error
+	GetByID(ctx context.Context, id int) (*models.Borrowing, error)
+	GetActiveByUser(ctx context.Context, userID int) ([]*models.Borrowing, error)
+	GetByGame(ctx context.Context, gameID int) ([]*models.Borrowing, error)
+	GetOverdue(ctx context.Context) ([]*models.Borrowing, error)
+	Update(ctx context.Context, borrowing *models.Borrowing) error
+	ReturnGame(ctx context.Context, borrowingID int) error
+	GetAll(ctx context.Context) ([]*models.Borrowing, error)
+	List(ctx context.Context, filter models.BorrowingFilter) ([]*models.Borrowing, error)
+	Count(ctx context.Context, filter models.BorrowingFilter) (int, error)
 }
 
 // AlertRepository defines the interface for alert data operations
 type AlertRepository interface {
-	Create(alert *models.Alert) error
-	GetByID(id int) (*models.Alert, error)
-	GetUnread() ([]*models.Alert, error)
-	GetByUser(userID int) ([]*models.Alert, error)
-	GetAll() ([]*models.Alert, error)
-	MarkAsRead(id int) error
-	Delete(id int) error
-}
\ No newline at end of file
+	Create(ctx context.Context, alert *models.Alert) error
+	GetByID(ctx context.Context, id int) (*models.Alert, error)
+	GetUnread(ctx context.Context) ([]*models.Alert, error)
+	GetByUser(ctx context.Context, userID int) ([]*models.Alert, error)
+	GetAll(ctx context.Context) ([]*models.Alert, error)
+	List(ctx context.Context, filter models.AlertFilter) ([]*models.Alert, error)
+	Count(ctx context.Context, filter models.AlertFilter) (int, error)
+	MarkAsRead(ctx context.Context, id int) error
+	Delete(ctx context.Context, id int) error
+}
+
+// ReservationRepository defines the interface for reservation data operations
+type ReservationRepository interface {
+	Create(ctx context.Context, reservation *models.Reservation) error
+	GetByID(ctx context.Context, id int) (*models.Reservation, error)
+	GetActiveByGame(ctx context.Context, gameID int) ([]*models.Reservation, error)
+	GetByUser(ctx context.Context, userID int) ([]*models.Reservation, error)
+	GetActive(ctx context.Context) ([]*models.Reservation, error)
+	GetExpiredHolds(ctx context.Context, now time.Time) ([]*models.Reservation, error)
+	Update(ctx context.Context, reservation *models.Reservation) error
+}
+
+// TagRepository defines the interface for game tag data operations
+type TagRepository interface {
+	Create(ctx context.Context, tag *models.Tag) error
+	GetByID(ctx context.Context, id int) (*models.Tag, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Tag, error)
+	List(ctx context.Context, search string, limit int) ([]*models.Tag, error)
+	Update(ctx context.Context, tag *models.Tag) error
+	Merge(ctx context.Context, sourceID, targetID int) error
+	Delete(ctx context.Context, id int) error
+}
+
+// LoanPolicyRepository defines the interface for membership tier loan policies
+type LoanPolicyRepository interface {
+	Create(ctx context.Context, policy *models.LoanPolicy) error
+	GetByTier(ctx context.Context, tier string) (*models.LoanPolicy, error)
+	GetAll(ctx context.Context) ([]*models.LoanPolicy, error)
+	Update(ctx context.Context, policy *models.LoanPolicy) error
+}
+
+// FineRepository defines the interface for the fines ledger of users
+type FineRepository interface {
+	Create(ctx context.Context, entry *models.FineEntry) error
+	GetByUser(ctx context.Context, userID int) ([]*models.FineEntry, error)
+	// Balance returns the amount, in cents, a user owes
+	Balance(ctx context.Context, userID int) (int, error)
+}
+
+// ConditionRepository defines the interface for the condition history of games
+type ConditionRepository interface {
+	Create(ctx context.Context, change *models.ConditionChange) error
+	GetByGame(ctx context.Context, gameID int) ([]*models.ConditionChange, error)
+}
+
+// AuthRepository defines the interface for credentials, sessions and API tokens
+type AuthRepository interface {
+	SetPasswordHash(ctx context.Context, userID int, hash string) error
+	GetPasswordHash(ctx context.Context, userID int) (string, error)
+	CountActiveByRole(ctx context.Context, role string) (int, error)
+	CreateSession(ctx context.Context, session *models.Session) error
+	GetSession(ctx context.Context, tokenHash string) (*models.Session, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteUserSessions(ctx context.Context, userID int) error
+	DeleteExpiredSessions(ctx context.Context, now time.Time) (int, error)
+	CreateAPIToken(ctx context.Context, token *models.APIToken) error
+	GetAPITokenByHash(ctx context.Context, tokenHash string) (*models.APIToken, error)
+	GetAPITokenByID(ctx context.Context, id int) (*models.APIToken, error)
+	GetAPITokensByUser(ctx context.Context, userID int) ([]*models.APIToken, error)
+	TouchAPIToken(ctx context.Context, id int, usedAt time.Time) error
+	DeleteAPIToken(ctx context.Context, id int) error
+}
+
+// AuditRepository defines the interface for the append-only audit log
+type AuditRepository interface {
+	Create(ctx context.Context, event *models.AuditEvent) error
+	List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, error)
+	Count(ctx context.Context, filter models.AuditFilter) (int, error)
+}
+
+// JobRunRepository defines the interface for background job execution history
+type JobRunRepository interface {
+	Create(ctx context.Context, run *models.JobRun) error
+	Update(ctx context.Context, run *models.JobRun) error
+	List(ctx context.Context, jobName string, limit, offset int) ([]*models.JobRun, error)
+	Count(ctx context.Context, jobName string) (int, error)
+	CountByStatus(ctx context.Context) ([]models.JobRunCount, error)
+	GetLastRuns(ctx context.Context) (map[string]time.Time, error)
+	// DeleteBefore removes the runs started before the given time and
+	// returns how many were removed
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// NotificationRepository defines the interface for the delivery of alerts
+// by email and the notification preferences of users
+type NotificationRepository interface {
+	// GetDue returns, oldest first, the unread alerts raised since since whose
+	// email was never attempted or is pending a retry due by now
+	GetDue(ctx context.Context, since, now time.Time, limit int) ([]*models.PendingNotification, error)
+	GetByAlert(ctx context.Context, alertID int) (*models.AlertNotification, error)
+	// Save creates or replaces the delivery status of an alert
+	Save(ctx context.Context, notification *models.AlertNotification) error
+	// GetPreferences returns the preferences of a user, the defaults when
+	// they never changed them
+	GetPreferences(ctx context.Context, userID int) (*models.NotificationPreferences, error)
+	SavePreferences(ctx context.Context, preferences *models.NotificationPreferences) error
+}
+
+// LibraryRepository defines the interface for the libraries of the
+// deployment. Unlike the other repositories, it is not scoped to a library.
+type LibraryRepository interface {
+	Create(ctx context.Context, library *models.Library) error
+	GetByID(ctx context.Context, id int) (*models.Library, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Library, error)
+	GetAll(ctx context.Context) ([]*models.Library, error)
+	Update(ctx context.Context, library *models.Library) error
+	// GetBySession returns the library of the user of an unexpired session
+	GetBySession(ctx context.Context, tokenHash string, now time.Time) (*models.Library, error)
+	// GetByAPIToken returns the library of the user of an API token
+	GetByAPIToken(ctx context.Context, tokenHash string) (*models.Library, error)
+}
+
+// ExportRepository streams whole tables, row by row, for bulk exports
+type ExportRepository interface {
+	// EachGame calls fn for every game in ID order, stopping at its first error
+	EachGame(ctx context.Context, fn func(game *models.Game) error) error
+	EachUser(ctx context.Context, fn func(user *models.User) error) error
+	// EachBorrowing also passes the borrower's email and the game's name,
+	// which identify them in another library
+	EachBorrowing(ctx context.Context, fn func(borrowing *models.Borrowing, userEmail, gameName string) error) error
+}
+
+// Store groups the repositories that can take part in a unit of work
+type Store struct {
+	Users        UserRepository
+	Games        GameRepository
+	Borrowings   BorrowingRepository
+	Alerts       AlertRepository
+	Reservations ReservationRepository
+	LoanPolicies LoanPolicyRepository
+	Fines        FineRepository
+	Conditions   ConditionRepository
+	Audit        AuditRepository
+}
+
+// UnitOfWork runs several repository operations atomically
+type UnitOfWork interface {
+	// Do calls fn with repositories bound to a single transaction, which is
+	// committed when fn returns nil and rolled back otherwise
+	Do(ctx context.Context, fn func(store *Store) error) error
+}
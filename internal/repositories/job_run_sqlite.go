@@ -0,0 +1,194 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// SQLiteJobRunRepository implements JobRunRepository using SQLite
+type SQLiteJobRunRepository struct {
+	db *database.DB
+}
+
+// NewSQLiteJobRunRepository creates a new SQLite job run repository
+func NewSQLiteJobRunRepository(db *database.DB) JobRunRepository {
+	return &SQLiteJobRunRepository{db: db}
+}
+
+// Create inserts a new job run into the database
+func (r *SQLiteJobRunRepository) Create(ctx context.Context, run *models.JobRun) error {
+	query := `
+		INSERT INTO job_runs (job_name, status, started_at, finished_at, duration_ms, error)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query, run.JobName, run.Status, run.StartedAt,
+		run.FinishedAt, run.DurationMs, run.Error).Scan(&run.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create job run: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates the status and outcome of an existing job run
+func (r *SQLiteJobRunRepository) Update(ctx context.Context, run *models.JobRun) error {
+	query := `
+		UPDATE job_runs
+		SET status = ?, finished_at = ?, duration_ms = ?, error = ?
+		WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, run.Status, run.FinishedAt, run.DurationMs, run.Error, run.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update job run: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return models.NotFound("job_run_not_found", run.ID)
+	}
+
+	return nil
+}
+
+// List retrieves job runs, most recent first. An empty job name returns runs
+// of every job and a limit of zero or less returns all matching runs.
+func (r *SQLiteJobRunRepository) List(ctx context.Context, jobName string, limit, offset int) ([]*models.JobRun, error) {
+	query := `
+		SELECT id, job_name, status, started_at, finished_at, duration_ms, error
+		FROM job_runs
+		WHERE (CAST(? AS TEXT) = '' OR job_name = ?)
+		ORDER BY started_at DESC, id DESC
+		LIMIT ? OFFSET ?`
+
+	if limit <= 0 {
+		limit = math.MaxInt64 // no limit, on both databases
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, jobName, jobName, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*models.JobRun
+	for rows.Next() {
+		run := &models.JobRun{}
+		err := rows.Scan(
+			&run.ID, &run.JobName, &run.Status, &run.StartedAt,
+			&run.FinishedAt, &run.DurationMs, &run.Error,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// Count returns the number of job runs, optionally restricted to one job
+func (r *SQLiteJobRunRepository) Count(ctx context.Context, jobName string) (int, error) {
+	query := `SELECT COUNT(*) FROM job_runs WHERE (? = '' OR job_name = ?)`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, jobName, jobName).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count job runs: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountByStatus returns the number of runs of each job in each status
+func (r *SQLiteJobRunRepository) CountByStatus(ctx context.Context) ([]models.JobRunCount, error) {
+	query := `
+		SELECT job_name, status, COUNT(*)
+		FROM job_runs
+		GROUP BY job_name, status
+		ORDER BY job_name, status`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count job runs by status: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []models.JobRunCount
+	for rows.Next() {
+		var count models.JobRunCount
+		if err := rows.Scan(&count.JobName, &count.Status, &count.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan job run count: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job run counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetLastRuns returns the finish time of the most recent finished run of each job
+func (r *SQLiteJobRunRepository) GetLastRuns(ctx context.Context) (map[string]time.Time, error) {
+	query := `
+		SELECT job_name, finished_at
+		FROM job_runs
+		WHERE id IN (
+			SELECT MAX(id) FROM job_runs
+			WHERE finished_at IS NOT NULL
+			GROUP BY job_name
+		)`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last job runs: %w", err)
+	}
+	defer rows.Close()
+
+	lastRuns := make(map[string]time.Time)
+	for rows.Next() {
+		var jobName string
+		var finishedAt time.Time
+		if err := rows.Scan(&jobName, &finishedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan last job run: %w", err)
+		}
+		lastRuns[jobName] = finishedAt
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating last job runs: %w", err)
+	}
+
+	return lastRuns, nil
+}
+
+// DeleteBefore removes the runs started before the given time
+func (r *SQLiteJobRunRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM job_runs WHERE started_at < ?`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete job runs: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
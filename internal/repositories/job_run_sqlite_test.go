@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"testing"
+	"time"
+)
+
+func createTestJobRun(t *testing.T, repo JobRunRepository, jobName string, startedAt time.Time, status string) *models.JobRun {
+	ctx := context.Background()
+	run := &models.JobRun{
+		JobName:   jobName,
+		Status:    "running",
+		StartedAt: startedAt,
+	}
+	if err := repo.Create(ctx, run); err != nil {
+		t.Fatalf("Failed to create job run: %v", err)
+	}
+
+	if status != "running" {
+		finishedAt := startedAt.Add(2 * time.Second)
+		run.Status = status
+		run.FinishedAt = &finishedAt
+		run.DurationMs = 2000
+		if status == "failed" {
+			run.Error = "something went wrong"
+		}
+		if err := repo.Update(ctx, run); err != nil {
+			t.Fatalf("Failed to update job run: %v", err)
+		}
+	}
+
+	return run
+}
+
+func TestSQLiteJobRunRepository_CreateAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteJobRunRepository(db)
+
+	run := createTestJobRun(t, repo, "overdue-alerts", time.Now().Add(-time.Minute), "failed")
+	if run.ID == 0 {
+		t.Fatal("Expected job run ID to be set after creation")
+	}
+
+	runs, err := repo.List(ctx, "overdue-alerts", 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list job runs: %v", err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("Expected 1 job run, got %d", len(runs))
+	}
+
+	stored := runs[0]
+	if stored.Status != "failed" {
+		t.Errorf("Expected status failed, got %s", stored.Status)
+	}
+	if stored.FinishedAt == nil {
+		t.Error("Expected finished_at to be set")
+	}
+	if stored.DurationMs != 2000 {
+		t.Errorf("Expected duration 2000ms, got %d", stored.DurationMs)
+	}
+	if stored.Error != "something went wrong" {
+		t.Errorf("Expected error message to be stored, got %q", stored.Error)
+	}
+}
+
+func TestSQLiteJobRunRepository_UpdateNotFound(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteJobRunRepository(db)
+
+	err := repo.Update(ctx, &models.JobRun{ID: 999, Status: "completed"})
+	if err == nil {
+		t.Error("Expected error when updating non-existent job run")
+	}
+}
+
+func TestSQLiteJobRunRepository_ListAndCount(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteJobRunRepository(db)
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		createTestJobRun(t, repo, "overdue-alerts", base.Add(time.Duration(i)*time.Minute), "completed")
+	}
+	createTestJobRun(t, repo, "cleanup-alerts", base.Add(10*time.Minute), "completed")
+
+	// Most recent first across all jobs
+	runs, err := repo.List(ctx, "", 2, 0)
+	if err != nil {
+		t.Fatalf("Failed to list job runs: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("Expected 2 job runs, got %d", len(runs))
+	}
+	if runs[0].JobName != "cleanup-alerts" {
+		t.Errorf("Expected most recent run first, got %s", runs[0].JobName)
+	}
+
+	// Pagination within a single job
+	page, err := repo.List(ctx, "overdue-alerts", 2, 2)
+	if err != nil {
+		t.Fatalf("Failed to list job runs: %v", err)
+	}
+	if len(page) != 2 {
+		t.Fatalf("Expected 2 job runs on second page, got %d", len(page))
+	}
+	if !page[0].StartedAt.Equal(base.Add(2 * time.Minute)) {
+		t.Errorf("Unexpected start time on second page: %v", page[0].StartedAt)
+	}
+
+	// No limit returns everything
+	all, err := repo.List(ctx, "overdue-alerts", 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to list job runs: %v", err)
+	}
+	if len(all) != 5 {
+		t.Errorf("Expected 5 job runs, got %d", len(all))
+	}
+
+	count, err := repo.Count(ctx, "overdue-alerts")
+	if err != nil {
+		t.Fatalf("Failed to count job runs: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("Expected count 5, got %d", count)
+	}
+
+	total, err := repo.Count(ctx, "")
+	if err != nil {
+		t.Fatalf("Failed to count job runs: %v", err)
+	}
+	if total != 6 {
+		t.Errorf("Expected total count 6, got %d", total)
+	}
+}
+
+func TestSQLiteJobRunRepository_GetLastRuns(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteJobRunRepository(db)
+
+	base := time.Now().Add(-time.Hour)
+	createTestJobRun(t, repo, "overdue-alerts", base, "completed")
+	latest := createTestJobRun(t, repo, "overdue-alerts", base.Add(30*time.Minute), "failed")
+	createTestJobRun(t, repo, "overdue-alerts", base.Add(40*time.Minute), "running")
+	createTestJobRun(t, repo, "reminder-alerts", base.Add(5*time.Minute), "running")
+
+	lastRuns, err := repo.GetLastRuns(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get last runs: %v", err)
+	}
+
+	if len(lastRuns) != 1 {
+		t.Fatalf("Expected last runs for 1 job, got %d", len(lastRuns))
+	}
+
+	lastRun, ok := lastRuns["overdue-alerts"]
+	if !ok {
+		t.Fatal("Expected last run for overdue-alerts")
+	}
+	if !lastRun.Equal(*latest.FinishedAt) {
+		t.Errorf("Expected last run %v, got %v", *latest.FinishedAt, lastRun)
+	}
+}
+
+func TestSQLiteJobRunRepository_CountByStatus(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteJobRunRepository(db)
+
+	base := time.Now().Add(-time.Hour)
+	createTestJobRun(t, repo, "overdue-alerts", base, "completed")
+	createTestJobRun(t, repo, "overdue-alerts", base.Add(time.Minute), "completed")
+	createTestJobRun(t, repo, "overdue-alerts", base.Add(2*time.Minute), "failed")
+	createTestJobRun(t, repo, "reminder-alerts", base.Add(3*time.Minute), "running")
+
+	counts, err := repo.CountByStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to count job runs by status: %v", err)
+	}
+
+	want := []models.JobRunCount{
+		{JobName: "overdue-alerts", Status: "completed", Count: 2},
+		{JobName: "overdue-alerts", Status: "failed", Count: 1},
+		{JobName: "reminder-alerts", Status: "running", Count: 1},
+	}
+	if len(counts) != len(want) {
+		t.Fatalf("Expected %d counts, got %+v", len(want), counts)
+	}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("Count %d = %+v, want %+v", i, counts[i], want[i])
+		}
+	}
+}
+
+func TestSQLiteJobRunRepository_DeleteBefore(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteJobRunRepository(db)
+
+	now := time.Now()
+	createTestJobRun(t, repo, "overdue-alerts", now.Add(-100*24*time.Hour), "completed")
+	createTestJobRun(t, repo, "cleanup-alerts", now.Add(-95*24*time.Hour), "failed")
+	recent := createTestJobRun(t, repo, "overdue-alerts", now.Add(-time.Hour), "completed")
+
+	removed, err := repo.DeleteBefore(ctx, now.Add(-90*24*time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to delete job runs: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 job runs removed, got %d", removed)
+	}
+
+	runs, err := repo.List(ctx, "", 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to list job runs: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != recent.ID {
+		t.Errorf("Expected only the recent run to be kept, got %+v", runs)
+	}
+}
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const libraryColumns = `l.id, l.slug, l.name, l.created_at`
+
+// SQLiteLibraryRepository implements LibraryRepository using SQLite
+type SQLiteLibraryRepository struct {
+	db database.Querier
+}
+
+// NewSQLiteLibraryRepository creates a new SQLite library repository on a
+// connection or a transaction
+func NewSQLiteLibraryRepository(db database.Querier) LibraryRepository {
+	return &SQLiteLibraryRepository{db: db}
+}
+
+// Create inserts a new library. It starts with the loan policies of the
+// default library, so that its members can borrow right away.
+func (r *SQLiteLibraryRepository) Create(ctx context.Context, library *models.Library) error {
+	return database.InTxContext(ctx, r.db, func(tx database.Querier) error {
+		query := `
+			INSERT INTO libraries (slug, name, created_at)
+			VALUES (?, ?, ?)
+			RETURNING id`
+
+		err := tx.QueryRowContext(ctx, query, library.Slug, library.Name, library.CreatedAt).Scan(&library.ID)
+		if err != nil {
+			if database.IsUniqueViolation(err) {
+				return models.Conflict("library_slug_taken", library.Slug)
+			}
+			return fmt.Errorf("failed to create library: %w", err)
+		}
+
+		policies := `
+			INSERT INTO loan_policies (library_id, tier, max_loans, max_loan_days, default_loan_days, max_extensions,
+				fine_per_day_cents, fine_grace_days, fine_cap_cents, max_fine_balance_cents)
+			SELECT ?, tier, max_loans, max_loan_days, default_loan_days, max_extensions,
+				fine_per_day_cents, fine_grace_days, fine_cap_cents, max_fine_balance_cents
+			FROM loan_policies
+			WHERE library_id = ?`
+
+		if _, err := tx.ExecContext(ctx, policies, library.ID, database.DefaultLibraryID); err != nil {
+			return fmt.Errorf("failed to create library loan policies: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetByID retrieves a library by its ID
+func (r *SQLiteLibraryRepository) GetByID(ctx context.Context, id int) (*models.Library, error) {
+	query := `SELECT ` + libraryColumns + ` FROM libraries l WHERE l.id = ?`
+
+	library, err := scanLibrary(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("library_not_found", id)
+		}
+		return nil, fmt.Errorf("failed to get library by id: %w", err)
+	}
+
+	return library, nil
+}
+
+// GetBySlug retrieves a library by its slug
+func (r *SQLiteLibraryRepository) GetBySlug(ctx context.Context, slug string) (*models.Library, error) {
+	query := `SELECT ` + libraryColumns + ` FROM libraries l WHERE l.slug = ?`
+
+	library, err := scanLibrary(r.db.QueryRowContext(ctx, query, slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("library_slug_not_found", slug)
+		}
+		return nil, fmt.Errorf("failed to get library by slug: %w", err)
+	}
+
+	return library, nil
+}
+
+// GetAll retrieves every library, in creation order
+func (r *SQLiteLibraryRepository) GetAll(ctx context.Context) ([]*models.Library, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+libraryColumns+` FROM libraries l ORDER BY l.id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get libraries: %w", err)
+	}
+	defer rows.Close()
+
+	var libraries []*models.Library
+	for rows.Next() {
+		library, err := scanLibrary(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan library: %w", err)
+		}
+		libraries = append(libraries, library)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating libraries: %w", err)
+	}
+
+	return libraries, nil
+}
+
+// Update renames an existing library or changes its slug
+func (r *SQLiteLibraryRepository) Update(ctx context.Context, library *models.Library) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE libraries SET slug = ?, name = ? WHERE id = ?`, library.Slug, library.Name, library.ID)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return models.Conflict("library_slug_taken", library.Slug)
+		}
+		return fmt.Errorf("failed to update library: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return models.NotFound("library_not_found", library.ID)
+	}
+
+	return nil
+}
+
+// GetBySession retrieves the library of the user signed in with a session
+func (r *SQLiteLibraryRepository) GetBySession(ctx context.Context, tokenHash string, now time.Time) (*models.Library, error) {
+	query := `
+		SELECT ` + libraryColumns + `
+		FROM sessions s
+		JOIN users u ON u.id = s.user_id
+		JOIN libraries l ON l.id = u.library_id
+		WHERE s.token_hash = ? AND s.expires_at > ?`
+
+	library, err := scanLibrary(r.db.QueryRowContext(ctx, query, tokenHash, now.UTC()))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("unknown_session")
+		}
+		return nil, fmt.Errorf("failed to get library by session: %w", err)
+	}
+
+	return library, nil
+}
+
+// GetByAPIToken retrieves the library of the user an API token belongs to
+func (r *SQLiteLibraryRepository) GetByAPIToken(ctx context.Context, tokenHash string) (*models.Library, error) {
+	query := `
+		SELECT ` + libraryColumns + `
+		FROM api_tokens t
+		JOIN users u ON u.id = t.user_id
+		JOIN libraries l ON l.id = u.library_id
+		WHERE t.token_hash = ?`
+
+	library, err := scanLibrary(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("unknown_api_token")
+		}
+		return nil, fmt.Errorf("failed to get library by API token: %w", err)
+	}
+
+	return library, nil
+}
+
+// scanLibrary scans a single library row selected with libraryColumns
+func scanLibrary(row rowScanner) (*models.Library, error) {
+	library := &models.Library{}
+	if err := row.Scan(&library.ID, &library.Slug, &library.Name, &library.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return library, nil
+}
@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSQLiteLibraryRepository_CreateAndGet(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteLibraryRepository(db)
+
+	defaultLibrary, err := repo.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("Failed to get the default library: %v", err)
+	}
+	if defaultLibrary.Slug != "default" {
+		t.Errorf("Expected the default library to be seeded, got %q", defaultLibrary.Slug)
+	}
+
+	library := &models.Library{Slug: "ludo-club", Name: "Ludo Club", CreatedAt: time.Now()}
+	if err := repo.Create(ctx, library); err != nil {
+		t.Fatalf("Failed to create library: %v", err)
+	}
+	if library.ID == 0 {
+		t.Error("Expected library ID to be set after creation")
+	}
+
+	retrieved, err := repo.GetBySlug(ctx, "ludo-club")
+	if err != nil {
+		t.Fatalf("Failed to get library by slug: %v", err)
+	}
+	if retrieved.ID != library.ID || retrieved.Name != "Ludo Club" {
+		t.Errorf("Expected library %d named Ludo Club, got %d named %s", library.ID, retrieved.ID, retrieved.Name)
+	}
+
+	policies, err := NewSQLiteLoanPolicyRepository(db.ForLibrary(library.ID)).GetAll(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get loan policies: %v", err)
+	}
+	if len(policies) != 3 {
+		t.Errorf("Expected the new library to start with the 3 default loan policies, got %d", len(policies))
+	}
+
+	duplicate := &models.Library{Slug: "ludo-club", Name: "Other", CreatedAt: time.Now()}
+	if err := repo.Create(ctx, duplicate); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected an already exists error, got %v", err)
+	}
+
+	library.Name = "Ludo Club de Nantes"
+	if err := repo.Update(ctx, library); err != nil {
+		t.Fatalf("Failed to update library: %v", err)
+	}
+
+	libraries, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get libraries: %v", err)
+	}
+	if len(libraries) != 2 || libraries[1].Name != "Ludo Club de Nantes" {
+		t.Errorf("Expected the default library and the renamed one, got %v", libraries)
+	}
+
+	if _, err := repo.GetBySlug(ctx, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+	if err := repo.Update(ctx, &models.Library{ID: 999, Slug: "missing", Name: "Missing"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestSQLiteLibraryRepository_Isolation(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	library := &models.Library{Slug: "ludo-club", Name: "Ludo Club", CreatedAt: time.Now()}
+	if err := NewSQLiteLibraryRepository(db).Create(ctx, library); err != nil {
+		t.Fatalf("Failed to create library: %v", err)
+	}
+	other := db.ForLibrary(library.ID)
+
+	user := &models.User{Name: "Alice", Email: "alice@example.com", RegisteredAt: time.Now(), IsActive: true, MembershipTier: "standard", Role: "member"}
+	if err := NewSQLiteUserRepository(db).Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	game := &models.Game{Name: "Hanabi", Tags: []string{"Coopératif"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true}
+	if err := NewSQLiteGameRepository(db).Create(ctx, game); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	if _, err := NewSQLiteUserRepository(other).GetByID(ctx, user.ID); err == nil {
+		t.Error("Expected the user of another library to be hidden")
+	}
+	if _, err := NewSQLiteGameRepository(other).GetByID(ctx, game.ID); err == nil {
+		t.Error("Expected the game of another library to be hidden")
+	}
+	games, err := NewSQLiteGameRepository(other).GetAll(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get games: %v", err)
+	}
+	if len(games) != 0 {
+		t.Errorf("Expected no game in the new library, got %d", len(games))
+	}
+
+	// Tags are per library, so the same tag can be created in both
+	if err := NewSQLiteGameRepository(other).Create(ctx, &models.Game{Name: "The Crew", Tags: []string{"Coopératif"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true}); err != nil {
+		t.Fatalf("Failed to create game in the new library: %v", err)
+	}
+	tags, err := NewSQLiteTagRepository(other).List(ctx, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to list tags: %v", err)
+	}
+	if len(tags) != 1 || tags[0].GameCount != 1 {
+		t.Errorf("Expected one tag used by one game in the new library, got %v", tags)
+	}
+}
+
+func TestSQLiteLibraryRepository_GetByCredentials(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteLibraryRepository(db)
+	library := &models.Library{Slug: "ludo-club", Name: "Ludo Club", CreatedAt: time.Now()}
+	if err := repo.Create(ctx, library); err != nil {
+		t.Fatalf("Failed to create library: %v", err)
+	}
+
+	other := db.ForLibrary(library.ID)
+	user := &models.User{Name: "Bob", Email: "bob@example.com", RegisteredAt: time.Now(), IsActive: true, MembershipTier: "standard", Role: "member"}
+	if err := NewSQLiteUserRepository(other).Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	auth := NewSQLiteAuthRepository(other)
+	now := time.Now()
+	if err := auth.CreateSession(ctx, &models.Session{TokenHash: "live", UserID: user.ID, CreatedAt: now, ExpiresAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := auth.CreateSession(ctx, &models.Session{TokenHash: "stale", UserID: user.ID, CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour)}); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := auth.CreateAPIToken(ctx, &models.APIToken{UserID: user.ID, Name: "script", TokenHash: "token", CreatedAt: now}); err != nil {
+		t.Fatalf("Failed to create API token: %v", err)
+	}
+
+	found, err := repo.GetBySession(ctx, "live", now)
+	if err != nil {
+		t.Fatalf("Failed to get library by session: %v", err)
+	}
+	if found.ID != library.ID {
+		t.Errorf("Expected library %d, got %d", library.ID, found.ID)
+	}
+
+	if _, err := repo.GetBySession(ctx, "stale", now); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected expired sessions to be ignored, got %v", err)
+	}
+
+	found, err = repo.GetByAPIToken(ctx, "token")
+	if err != nil {
+		t.Fatalf("Failed to get library by API token: %v", err)
+	}
+	if found.ID != library.ID {
+		t.Errorf("Expected library %d, got %d", library.ID, found.ID)
+	}
+
+	if _, err := repo.GetByAPIToken(ctx, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
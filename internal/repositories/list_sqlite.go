@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"math"
+	"strings"
+)
+
+// sqlWhere accumulates the conditions of a filtered query and their arguments
+type sqlWhere struct {
+	conditions []string
+	args       []any
+}
+
+// add appends a condition with its placeholders' arguments
+func (w *sqlWhere) add(condition string, args ...any) {
+	w.conditions = append(w.conditions, condition)
+	w.args = append(w.args, args...)
+}
+
+// libraryWhere starts the conditions of a query with the library q is scoped
+// to, so that the query only sees the rows of that library
+func libraryWhere(q database.Querier, column string) *sqlWhere {
+	where := &sqlWhere{}
+	where.add(column+" = ?", database.LibraryOf(q))
+	return where
+}
+
+// String returns the WHERE clause, or nothing when there are no conditions
+func (w *sqlWhere) String() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conditions, " AND ")
+}
+
+// sortColumn maps a list sort field to SQL
+type sortColumn struct {
+	expr string
+	desc bool // sorted most recent or largest first unless asked otherwise
+}
+
+// orderBy builds the ORDER BY clause of a list. Unknown sort fields fall back
+// to the first column given; ties are broken by id so that pages are stable.
+func orderBy(options models.ListOptions, columns map[string]sortColumn, defaultField string) string {
+	column, ok := columns[options.Sort]
+	if !ok {
+		column = columns[defaultField]
+	}
+
+	desc := column.desc
+	switch options.Order {
+	case models.SortAsc:
+		desc = false
+	case models.SortDesc:
+		desc = true
+	}
+
+	direction := " ASC"
+	if desc {
+		direction = " DESC"
+	}
+
+	return " ORDER BY " + column.expr + direction + ", id" + direction
+}
+
+// pageArgs returns the LIMIT and OFFSET arguments of a list. A page size of
+// zero or less returns every row.
+func pageArgs(options models.ListOptions) []any {
+	limit := options.PerPage
+	if limit <= 0 {
+		limit = math.MaxInt64 // no limit, on both databases
+	}
+	return []any{limit, options.Offset()}
+}
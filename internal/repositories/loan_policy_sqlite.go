@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// SQLiteLoanPolicyRepository implements LoanPolicyRepository using SQLite
+type SQLiteLoanPolicyRepository struct {
+	db database.Querier
+}
+
+// NewSQLiteLoanPolicyRepository creates a new SQLite loan policy repository
+func NewSQLiteLoanPolicyRepository(db *database.DB) LoanPolicyRepository {
+	return &SQLiteLoanPolicyRepository{db: db}
+}
+
+// Create inserts a new membership tier policy
+func (r *SQLiteLoanPolicyRepository) Create(ctx context.Context, policy *models.LoanPolicy) error {
+	query := `
+		INSERT INTO loan_policies (library_id, tier, max_loans, max_loan_days, default_loan_days, max_extensions,
+			fine_per_day_cents, fine_grace_days, fine_cap_cents, max_fine_balance_cents)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query, database.LibraryOf(r.db), policy.Tier, policy.MaxLoans, policy.MaxLoanDays,
+		policy.DefaultLoanDays, policy.MaxExtensions,
+		policy.FinePerDayCents, policy.FineGraceDays, policy.FineCapCents, policy.MaxFineBalanceCents)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return models.Conflict("loan_policy_exists", policy.Tier)
+		}
+		return fmt.Errorf("failed to create loan policy: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTier retrieves the policy of a membership tier
+func (r *SQLiteLoanPolicyRepository) GetByTier(ctx context.Context, tier string) (*models.LoanPolicy, error) {
+	query := `
+		SELECT tier, max_loans, max_loan_days, default_loan_days, max_extensions,
+			fine_per_day_cents, fine_grace_days, fine_cap_cents, max_fine_balance_cents
+		FROM loan_policies
+		WHERE library_id = ? AND tier = ?`
+
+	policy := &models.LoanPolicy{}
+	err := r.db.QueryRowContext(ctx, query, database.LibraryOf(r.db), tier).Scan(
+		&policy.Tier, &policy.MaxLoans, &policy.MaxLoanDays,
+		&policy.DefaultLoanDays, &policy.MaxExtensions,
+		&policy.FinePerDayCents, &policy.FineGraceDays, &policy.FineCapCents, &policy.MaxFineBalanceCents,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("loan_policy_not_found", tier)
+		}
+		return nil, fmt.Errorf("failed to get loan policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// GetAll retrieves every membership tier policy ordered by loan limit
+func (r *SQLiteLoanPolicyRepository) GetAll(ctx context.Context) ([]*models.LoanPolicy, error) {
+	query := `
+		SELECT tier, max_loans, max_loan_days, default_loan_days, max_extensions,
+			fine_per_day_cents, fine_grace_days, fine_cap_cents, max_fine_balance_cents
+		FROM loan_policies
+		WHERE library_id = ?
+		ORDER BY max_loans, tier`
+
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*models.LoanPolicy
+	for rows.Next() {
+		policy := &models.LoanPolicy{}
+		err := rows.Scan(
+			&policy.Tier, &policy.MaxLoans, &policy.MaxLoanDays,
+			&policy.DefaultLoanDays, &policy.MaxExtensions,
+			&policy.FinePerDayCents, &policy.FineGraceDays, &policy.FineCapCents, &policy.MaxFineBalanceCents,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan loan policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// Update modifies the limits of an existing membership tier
+func (r *SQLiteLoanPolicyRepository) Update(ctx context.Context, policy *models.LoanPolicy) error {
+	query := `
+		UPDATE loan_policies
+		SET max_loans = ?, max_loan_days = ?, default_loan_days = ?, max_extensions = ?,
+			fine_per_day_cents = ?, fine_grace_days = ?, fine_cap_cents = ?, max_fine_balance_cents = ?
+		WHERE library_id = ? AND tier = ?`
+
+	result, err := r.db.ExecContext(ctx, query, policy.MaxLoans, policy.MaxLoanDays,
+		policy.DefaultLoanDays, policy.MaxExtensions,
+		policy.FinePerDayCents, policy.FineGraceDays, policy.FineCapCents, policy.MaxFineBalanceCents,
+		database.LibraryOf(r.db), policy.Tier)
+	if err != nil {
+		return fmt.Errorf("failed to update loan policy: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return models.NotFound("loan_policy_not_found", policy.Tier)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSQLiteLoanPolicyRepository_SeededTiers(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteLoanPolicyRepository(db)
+
+	policies, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get loan policies: %v", err)
+	}
+
+	tiers := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		tiers = append(tiers, policy.Tier)
+		if err := models.ValidateLoanPolicy(policy); err != nil {
+			t.Errorf("Seeded policy %q is invalid: %v", policy.Tier, err)
+		}
+	}
+
+	expected := []string{"basic", "standard", "premium"}
+	if len(tiers) != len(expected) {
+		t.Fatalf("Expected tiers %v, got %v", expected, tiers)
+	}
+	for i := range expected {
+		if tiers[i] != expected[i] {
+			t.Errorf("Expected tiers %v, got %v", expected, tiers)
+			break
+		}
+	}
+
+	standard, err := repo.GetByTier(ctx, models.DefaultMembershipTier)
+	if err != nil {
+		t.Fatalf("Failed to get default tier: %v", err)
+	}
+	if *standard != *models.DefaultLoanPolicy() {
+		t.Errorf("Seeded default tier %+v does not match DefaultLoanPolicy %+v", standard, models.DefaultLoanPolicy())
+	}
+}
+
+func TestSQLiteLoanPolicyRepository_CreateAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteLoanPolicyRepository(db)
+
+	policy := &models.LoanPolicy{Tier: "staff", MaxLoans: 20, MaxLoanDays: 180, DefaultLoanDays: 30, MaxExtensions: 10}
+	if err := repo.Create(ctx, policy); err != nil {
+		t.Fatalf("Failed to create loan policy: %v", err)
+	}
+
+	if err := repo.Create(ctx, policy); err == nil {
+		t.Error("Expected error when creating a duplicate tier")
+	}
+
+	maxBalance := 1000
+	policy.MaxLoans = 15
+	policy.FinePerDayCents = 20
+	policy.FineGraceDays = 2
+	policy.FineCapCents = 500
+	policy.MaxFineBalanceCents = &maxBalance
+	if err := repo.Update(ctx, policy); err != nil {
+		t.Fatalf("Failed to update loan policy: %v", err)
+	}
+
+	retrieved, err := repo.GetByTier(ctx, "staff")
+	if err != nil {
+		t.Fatalf("Failed to get loan policy: %v", err)
+	}
+	if retrieved.MaxLoans != 15 {
+		t.Errorf("Expected max loans 15, got %d", retrieved.MaxLoans)
+	}
+	if retrieved.FinePerDayCents != 20 || retrieved.FineGraceDays != 2 || retrieved.FineCapCents != 500 ||
+		retrieved.MaxFineBalanceCents == nil || *retrieved.MaxFineBalanceCents != 1000 {
+		t.Errorf("Expected the late fees to be updated, got %+v", retrieved)
+	}
+
+	if err := repo.Update(ctx, &models.LoanPolicy{Tier: "unknown", MaxLoans: 1}); err == nil {
+		t.Error("Expected error when updating an unknown tier")
+	}
+	if _, err := repo.GetByTier(ctx, "unknown"); err == nil {
+		t.Error("Expected error when getting an unknown tier")
+	}
+}
+
+func TestSQLiteLoanPolicyRepository_UserTierAndExtensions(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewSQLiteUserRepository(db)
+	gameRepo := NewSQLiteGameRepository(db)
+	borrowingRepo := NewSQLiteBorrowingRepository(db)
+	user, game := createTestUserAndGame(t, userRepo, gameRepo)
+
+	if user.MembershipTier != models.DefaultMembershipTier {
+		t.Errorf("Expected new users to get the %q tier, got %q", models.DefaultMembershipTier, user.MembershipTier)
+	}
+
+	user.MembershipTier = "premium"
+	if err := userRepo.Update(ctx, user); err != nil {
+		t.Fatalf("Failed to update user: %v", err)
+	}
+
+	retrievedUser, err := userRepo.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	}
+	if retrievedUser.MembershipTier != "premium" {
+		t.Errorf("Expected tier premium, got %q", retrievedUser.MembershipTier)
+	}
+
+	borrowing := &models.Borrowing{
+		UserID:     user.ID,
+		GameID:     game.ID,
+		BorrowedAt: time.Now(),
+		DueDate:    time.Now().Add(14 * 24 * time.Hour),
+	}
+	if err := borrowingRepo.Create(ctx, borrowing); err != nil {
+		t.Fatalf("Failed to create borrowing: %v", err)
+	}
+
+	borrowing.ExtensionCount = 2
+	if err := borrowingRepo.Update(ctx, borrowing); err != nil {
+		t.Fatalf("Failed to update borrowing: %v", err)
+	}
+
+	retrievedBorrowing, err := borrowingRepo.GetByID(ctx, borrowing.ID)
+	if err != nil {
+		t.Fatalf("Failed to get borrowing: %v", err)
+	}
+	if retrievedBorrowing.ExtensionCount != 2 {
+		t.Errorf("Expected 2 extensions, got %d", retrievedBorrowing.ExtensionCount)
+	}
+}
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SQLiteNotificationRepository implements NotificationRepository using SQLite
+type SQLiteNotificationRepository struct {
+	db database.Querier
+}
+
+// NewSQLiteNotificationRepository creates a new SQLite notification repository
+func NewSQLiteNotificationRepository(db *database.DB) NotificationRepository {
+	return &SQLiteNotificationRepository{db: db}
+}
+
+// GetDue returns the alerts due to be emailed, with their user and game
+func (r *SQLiteNotificationRepository) GetDue(ctx context.Context, since, now time.Time, limit int) ([]*models.PendingNotification, error) {
+	query := `
+		SELECT a.id, a.user_id, a.game_id, a.type, a.message, a.created_at, a.is_read,
+			u.name, u.email, g.name,
+			COALESCE(p.email_enabled, TRUE), COALESCE(p.language, ''), COALESCE(n.attempts, 0)
+		FROM alerts a
+		JOIN users u ON u.id = a.user_id
+		JOIN games g ON g.id = a.game_id
+		LEFT JOIN alert_notifications n ON n.alert_id = a.id
+		LEFT JOIN notification_preferences p ON p.user_id = a.user_id
+		WHERE a.library_id = ? AND a.is_read = FALSE AND a.created_at >= ?
+			AND (n.alert_id IS NULL OR (n.status = 'pending' AND (n.next_attempt_at IS NULL OR n.next_attempt_at <= ?)))
+		ORDER BY a.created_at, a.id
+		LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db), since.UTC(), now.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []*models.PendingNotification
+	for rows.Next() {
+		p := &models.PendingNotification{}
+		err := rows.Scan(
+			&p.Alert.ID, &p.Alert.UserID, &p.Alert.GameID, &p.Alert.Type, &p.Alert.Message,
+			&p.Alert.CreatedAt, &p.Alert.IsRead,
+			&p.UserName, &p.Email, &p.GameName,
+			&p.EmailEnabled, &p.Language, &p.Attempts,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan due notification: %w", err)
+		}
+		pending = append(pending, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating due notifications: %w", err)
+	}
+
+	return pending, nil
+}
+
+// GetByAlert retrieves the delivery status of the email of an alert
+func (r *SQLiteNotificationRepository) GetByAlert(ctx context.Context, alertID int) (*models.AlertNotification, error) {
+	query := `
+		SELECT n.alert_id, n.status, n.attempts, n.last_error, n.next_attempt_at, n.sent_at, n.updated_at
+		FROM alert_notifications n
+		JOIN alerts a ON a.id = n.alert_id
+		WHERE n.alert_id = ? AND a.library_id = ?`
+
+	notification := &models.AlertNotification{}
+	err := r.db.QueryRowContext(ctx, query, alertID, database.LibraryOf(r.db)).Scan(
+		&notification.AlertID, &notification.Status, &notification.Attempts, &notification.LastError,
+		&notification.NextAttemptAt, &notification.SentAt, &notification.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("notification_not_found", alertID)
+		}
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+
+	return notification, nil
+}
+
+// Save creates or replaces the delivery status of the email of an alert
+func (r *SQLiteNotificationRepository) Save(ctx context.Context, notification *models.AlertNotification) error {
+	query := `
+		INSERT INTO alert_notifications (alert_id, status, attempts, last_error, next_attempt_at, sent_at, updated_at)
+		SELECT a.id, ?, ?, ?, ?, ?, ?
+		FROM alerts a
+		WHERE a.id = ? AND a.library_id = ?
+		ON CONFLICT (alert_id) DO UPDATE SET
+			status = excluded.status,
+			attempts = excluded.attempts,
+			last_error = excluded.last_error,
+			next_attempt_at = excluded.next_attempt_at,
+			sent_at = excluded.sent_at,
+			updated_at = excluded.updated_at`
+
+	result, err := r.db.ExecContext(ctx, query, notification.Status, notification.Attempts, notification.LastError,
+		utcOrNil(notification.NextAttemptAt), utcOrNil(notification.SentAt), notification.UpdatedAt.UTC(),
+		notification.AlertID, database.LibraryOf(r.db))
+	if err != nil {
+		return fmt.Errorf("failed to save notification: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return models.NotFound("alert_not_found", notification.AlertID)
+	}
+
+	return nil
+}
+
+// GetPreferences retrieves the notification preferences of a user
+func (r *SQLiteNotificationRepository) GetPreferences(ctx context.Context, userID int) (*models.NotificationPreferences, error) {
+	query := `
+		SELECT u.id, COALESCE(p.email_enabled, TRUE), COALESCE(p.language, '')
+		FROM users u
+		LEFT JOIN notification_preferences p ON p.user_id = u.id
+		WHERE u.id = ? AND u.library_id = ?`
+
+	preferences := &models.NotificationPreferences{}
+	err := r.db.QueryRowContext(ctx, query, userID, database.LibraryOf(r.db)).Scan(
+		&preferences.UserID, &preferences.EmailEnabled, &preferences.Language,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("user_not_found", userID)
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+// SavePreferences creates or replaces the notification preferences of a user
+func (r *SQLiteNotificationRepository) SavePreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	query := `
+		INSERT INTO notification_preferences (user_id, email_enabled, language)
+		SELECT u.id, ?, ?
+		FROM users u
+		WHERE u.id = ? AND u.library_id = ?
+		ON CONFLICT (user_id) DO UPDATE SET
+			email_enabled = excluded.email_enabled,
+			language = excluded.language`
+
+	result, err := r.db.ExecContext(ctx, query, preferences.EmailEnabled, preferences.Language,
+		preferences.UserID, database.LibraryOf(r.db))
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return models.NotFound("user_not_found", preferences.UserID)
+	}
+
+	return nil
+}
+
+// utcOrNil returns an optional time in UTC, so that it compares with the
+// times of the queries as stored
+func utcOrNil(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
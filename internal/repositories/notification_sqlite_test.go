@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"testing"
+	"time"
+)
+
+func createTestAlert(t *testing.T, repo AlertRepository, userID, gameID int, createdAt time.Time) *models.Alert {
+	ctx := context.Background()
+	t.Helper()
+
+	alert := &models.Alert{
+		UserID:    userID,
+		GameID:    gameID,
+		Type:      "overdue",
+		Message:   "Game is overdue",
+		CreatedAt: createdAt,
+	}
+	if err := repo.Create(ctx, alert); err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+
+	return alert
+}
+
+func TestSQLiteNotificationRepository_GetDue(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	alertRepo := NewSQLiteAlertRepository(db)
+	repo := NewSQLiteNotificationRepository(db)
+	user, game := createTestUserAndGame(t, NewSQLiteUserRepository(db), NewSQLiteGameRepository(db))
+
+	now := time.Now()
+	fresh := createTestAlert(t, alertRepo, user.ID, game.ID, now.Add(-time.Hour))
+	retry := createTestAlert(t, alertRepo, user.ID, game.ID, now.Add(-2*time.Hour))
+	later := createTestAlert(t, alertRepo, user.ID, game.ID, now.Add(-3*time.Hour))
+	sent := createTestAlert(t, alertRepo, user.ID, game.ID, now.Add(-4*time.Hour))
+	createTestAlert(t, alertRepo, user.ID, game.ID, now.Add(-30*24*time.Hour))
+	read := createTestAlert(t, alertRepo, user.ID, game.ID, now.Add(-time.Hour))
+	if err := alertRepo.MarkAsRead(ctx, read.ID); err != nil {
+		t.Fatalf("Failed to mark alert as read: %v", err)
+	}
+
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+	for _, n := range []*models.AlertNotification{
+		{AlertID: retry.ID, Status: models.NotificationPending, Attempts: 1, LastError: "timeout", NextAttemptAt: &past, UpdatedAt: now},
+		{AlertID: later.ID, Status: models.NotificationPending, Attempts: 2, NextAttemptAt: &future, UpdatedAt: now},
+		{AlertID: sent.ID, Status: models.NotificationSent, Attempts: 1, SentAt: &now, UpdatedAt: now},
+	} {
+		if err := repo.Save(ctx, n); err != nil {
+			t.Fatalf("Failed to save notification: %v", err)
+		}
+	}
+
+	if err := repo.SavePreferences(ctx, &models.NotificationPreferences{UserID: user.ID, EmailEnabled: false, Language: models.LanguageEnglish}); err != nil {
+		t.Fatalf("Failed to save preferences: %v", err)
+	}
+
+	due, err := repo.GetDue(ctx, now.Add(-models.NotificationMaxAge), now, 10)
+	if err != nil {
+		t.Fatalf("Failed to get due notifications: %v", err)
+	}
+
+	if len(due) != 2 || due[0].Alert.ID != retry.ID || due[1].Alert.ID != fresh.ID {
+		t.Fatalf("Expected the retry then the fresh alert, got %+v", due)
+	}
+	if due[0].Attempts != 1 || due[1].Attempts != 0 {
+		t.Errorf("Unexpected attempts: %d and %d", due[0].Attempts, due[1].Attempts)
+	}
+	if due[1].Email != user.Email || due[1].UserName != user.Name || due[1].GameName != game.Name {
+		t.Errorf("Unexpected recipient: %+v", due[1])
+	}
+	if due[1].EmailEnabled || due[1].Language != models.LanguageEnglish {
+		t.Errorf("Expected the user's preferences, got %+v", due[1])
+	}
+
+	limited, err := repo.GetDue(ctx, now.Add(-models.NotificationMaxAge), now, 1)
+	if err != nil {
+		t.Fatalf("Failed to get due notifications: %v", err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("Expected the limit to apply, got %d notifications", len(limited))
+	}
+}
+
+func TestSQLiteNotificationRepository_SaveAndGetByAlert(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteNotificationRepository(db)
+	user, game := createTestUserAndGame(t, NewSQLiteUserRepository(db), NewSQLiteGameRepository(db))
+	alert := createTestAlert(t, NewSQLiteAlertRepository(db), user.ID, game.ID, time.Now())
+
+	if _, err := repo.GetByAlert(ctx, alert.ID); err == nil {
+		t.Error("Expected error for an alert never notified")
+	}
+
+	next := time.Now().Add(5 * time.Minute)
+	notification := &models.AlertNotification{AlertID: alert.ID, Status: models.NotificationPending, Attempts: 1, LastError: "connection refused", NextAttemptAt: &next, UpdatedAt: time.Now()}
+	if err := repo.Save(ctx, notification); err != nil {
+		t.Fatalf("Failed to save notification: %v", err)
+	}
+
+	sentAt := time.Now()
+	notification = &models.AlertNotification{AlertID: alert.ID, Status: models.NotificationSent, Attempts: 2, SentAt: &sentAt, UpdatedAt: sentAt}
+	if err := repo.Save(ctx, notification); err != nil {
+		t.Fatalf("Failed to update notification: %v", err)
+	}
+
+	retrieved, err := repo.GetByAlert(ctx, alert.ID)
+	if err != nil {
+		t.Fatalf("Failed to get notification: %v", err)
+	}
+	if retrieved.Status != models.NotificationSent || retrieved.Attempts != 2 || retrieved.LastError != "" {
+		t.Errorf("Unexpected notification: %+v", retrieved)
+	}
+	if retrieved.NextAttemptAt != nil || retrieved.SentAt == nil {
+		t.Errorf("Expected only the sending time to be set, got %+v", retrieved)
+	}
+
+	if err := repo.Save(ctx, &models.AlertNotification{AlertID: 9999, Status: models.NotificationSent, UpdatedAt: time.Now()}); err == nil {
+		t.Error("Expected error for non-existent alert")
+	}
+}
+
+func TestSQLiteNotificationRepository_Preferences(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteNotificationRepository(db)
+	user, _ := createTestUserAndGame(t, NewSQLiteUserRepository(db), NewSQLiteGameRepository(db))
+
+	preferences, err := repo.GetPreferences(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Failed to get preferences: %v", err)
+	}
+	if !preferences.EmailEnabled || preferences.Language != "" {
+		t.Errorf("Expected the default preferences, got %+v", preferences)
+	}
+
+	for _, enabled := range []bool{false, true} {
+		if err := repo.SavePreferences(ctx, &models.NotificationPreferences{UserID: user.ID, EmailEnabled: enabled, Language: models.LanguageEnglish}); err != nil {
+			t.Fatalf("Failed to save preferences: %v", err)
+		}
+		preferences, err := repo.GetPreferences(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("Failed to get preferences: %v", err)
+		}
+		if preferences.EmailEnabled != enabled || preferences.Language != models.LanguageEnglish {
+			t.Errorf("Expected email enabled %v in English, got %+v", enabled, preferences)
+		}
+	}
+
+	if _, err := repo.GetPreferences(ctx, 9999); err == nil {
+		t.Error("Expected error for non-existent user")
+	}
+	if err := repo.SavePreferences(ctx, &models.NotificationPreferences{UserID: 9999, EmailEnabled: true}); err == nil {
+		t.Error("Expected error for non-existent user")
+	}
+
+	other := &models.Library{Slug: "other", Name: "Other", CreatedAt: time.Now()}
+	if err := NewSQLiteLibraryRepository(db).Create(ctx, other); err != nil {
+		t.Fatalf("Failed to create library: %v", err)
+	}
+	if _, err := NewSQLiteNotificationRepository(db.ForLibrary(other.ID)).GetPreferences(ctx, user.ID); err == nil {
+		t.Error("Expected the user to be hidden from other libraries")
+	}
+}
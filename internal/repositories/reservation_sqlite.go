@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const reservationColumns = `id, user_id, game_id, copy_id, status, created_at, notified_at, expires_at, closed_at`
+
+// SQLiteReservationRepository implements ReservationRepository using SQLite
+type SQLiteReservationRepository struct {
+	db database.Querier
+}
+
+// NewSQLiteReservationRepository creates a new SQLite reservation repository
+func NewSQLiteReservationRepository(db *database.DB) ReservationRepository {
+	return &SQLiteReservationRepository{db: db}
+}
+
+// Create inserts a new reservation into the database
+func (r *SQLiteReservationRepository) Create(ctx context.Context, reservation *models.Reservation) error {
+	query := `
+		INSERT INTO reservations (library_id, user_id, game_id, copy_id, status, created_at, notified_at, expires_at, closed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query, database.LibraryOf(r.db), reservation.UserID, reservation.GameID, reservation.CopyID,
+		reservation.Status, reservation.CreatedAt, reservation.NotifiedAt,
+		reservation.ExpiresAt, reservation.ClosedAt).Scan(&reservation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a reservation by its ID
+func (r *SQLiteReservationRepository) GetByID(ctx context.Context, id int) (*models.Reservation, error) {
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = ? AND library_id = ?`
+
+	reservation, err := scanReservation(r.db.QueryRowContext(ctx, query, id, database.LibraryOf(r.db)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("reservation_not_found", id)
+		}
+		return nil, fmt.Errorf("failed to get reservation by id: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// GetActiveByGame retrieves the waiting and ready reservations for a game in
+// queue order: holds already ready for pickup first, then first come, first served
+func (r *SQLiteReservationRepository) GetActiveByGame(ctx context.Context, gameID int) ([]*models.Reservation, error) {
+	query := `
+		SELECT ` + reservationColumns + `
+		FROM reservations
+		WHERE game_id = ? AND status IN ('waiting', 'ready') AND library_id = ?
+		ORDER BY CASE status WHEN 'ready' THEN 0 ELSE 1 END, created_at, id`
+
+	return r.queryReservations(ctx, query, "failed to get reservations by game", gameID, database.LibraryOf(r.db))
+}
+
+// GetByUser retrieves all reservations for a specific user, newest first
+func (r *SQLiteReservationRepository) GetByUser(ctx context.Context, userID int) ([]*models.Reservation, error) {
+	query := `
+		SELECT ` + reservationColumns + `
+		FROM reservations
+		WHERE user_id = ? AND library_id = ?
+		ORDER BY created_at DESC, id DESC`
+
+	return r.queryReservations(ctx, query, "failed to get reservations by user", userID, database.LibraryOf(r.db))
+}
+
+// GetActive retrieves all waiting and ready reservations grouped by game in queue order
+func (r *SQLiteReservationRepository) GetActive(ctx context.Context) ([]*models.Reservation, error) {
+	query := `
+		SELECT ` + reservationColumns + `
+		FROM reservations
+		WHERE status IN ('waiting', 'ready') AND library_id = ?
+		ORDER BY game_id, CASE status WHEN 'ready' THEN 0 ELSE 1 END, created_at, id`
+
+	return r.queryReservations(ctx, query, "failed to get active reservations", database.LibraryOf(r.db))
+}
+
+// GetExpiredHolds retrieves the ready reservations whose pickup deadline is before the given time
+func (r *SQLiteReservationRepository) GetExpiredHolds(ctx context.Context, now time.Time) ([]*models.Reservation, error) {
+	query := `
+		SELECT ` + reservationColumns + `
+		FROM reservations
+		WHERE status = 'ready' AND expires_at < ? AND library_id = ?
+		ORDER BY expires_at, id`
+
+	return r.queryReservations(ctx, query, "failed to get expired holds", now, database.LibraryOf(r.db))
+}
+
+// Update updates an existing reservation
+func (r *SQLiteReservationRepository) Update(ctx context.Context, reservation *models.Reservation) error {
+	query := `
+		UPDATE reservations
+		SET copy_id = ?, status = ?, notified_at = ?, expires_at = ?, closed_at = ?
+		WHERE id = ? AND library_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, reservation.CopyID, reservation.Status, reservation.NotifiedAt,
+		reservation.ExpiresAt, reservation.ClosedAt, reservation.ID, database.LibraryOf(r.db))
+	if err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return models.NotFound("reservation_not_found", reservation.ID)
+	}
+
+	return nil
+}
+
+// queryReservations runs a reservation query and scans every row
+func (r *SQLiteReservationRepository) queryReservations(ctx context.Context, query, errMsg string, args ...interface{}) ([]*models.Reservation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMsg, err)
+	}
+	defer rows.Close()
+
+	var reservations []*models.Reservation
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reservation: %w", err)
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reservations: %w", err)
+	}
+
+	return reservations, nil
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanReservation scans a single reservation row selected with reservationColumns
+func scanReservation(row rowScanner) (*models.Reservation, error) {
+	reservation := &models.Reservation{}
+	err := row.Scan(
+		&reservation.ID, &reservation.UserID, &reservation.GameID, &reservation.CopyID,
+		&reservation.Status, &reservation.CreatedAt, &reservation.NotifiedAt,
+		&reservation.ExpiresAt, &reservation.ClosedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
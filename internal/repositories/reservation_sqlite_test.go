@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"testing"
+	"time"
+)
+
+func createTestReservation(t *testing.T, repo ReservationRepository, userID, gameID int, createdAt time.Time) *models.Reservation {
+	ctx := context.Background()
+	t.Helper()
+
+	reservation := &models.Reservation{
+		UserID:    userID,
+		GameID:    gameID,
+		Status:    models.ReservationWaiting,
+		CreatedAt: createdAt,
+	}
+	if err := repo.Create(ctx, reservation); err != nil {
+		t.Fatalf("Failed to create reservation: %v", err)
+	}
+
+	return reservation
+}
+
+func TestSQLiteReservationRepository_CreateAndGetByID(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewSQLiteUserRepository(db)
+	gameRepo := NewSQLiteGameRepository(db)
+	repo := NewSQLiteReservationRepository(db)
+	user, game := createTestUserAndGame(t, userRepo, gameRepo)
+
+	reservation := createTestReservation(t, repo, user.ID, game.ID, time.Now())
+	if reservation.ID == 0 {
+		t.Fatal("Expected reservation ID to be set")
+	}
+
+	retrieved, err := repo.GetByID(ctx, reservation.ID)
+	if err != nil {
+		t.Fatalf("Failed to get reservation: %v", err)
+	}
+
+	if retrieved.UserID != user.ID || retrieved.GameID != game.ID || retrieved.Status != models.ReservationWaiting {
+		t.Errorf("Unexpected reservation: %+v", retrieved)
+	}
+	if retrieved.CopyID != nil || retrieved.ExpiresAt != nil || retrieved.ClosedAt != nil {
+		t.Errorf("Expected nullable fields to be nil, got %+v", retrieved)
+	}
+
+	if _, err := repo.GetByID(ctx, 9999); err == nil {
+		t.Error("Expected error for non-existent reservation")
+	}
+}
+
+func TestSQLiteReservationRepository_GetActiveByGameOrder(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewSQLiteUserRepository(db)
+	gameRepo := NewSQLiteGameRepository(db)
+	repo := NewSQLiteReservationRepository(db)
+	user, game := createTestUserAndGame(t, userRepo, gameRepo)
+
+	other := &models.User{Name: "Jane Doe", Email: "jane@example.com", RegisteredAt: time.Now(), IsActive: true}
+	if err := userRepo.Create(ctx, other); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	third := &models.User{Name: "Jim Doe", Email: "jim@example.com", RegisteredAt: time.Now(), IsActive: true}
+	if err := userRepo.Create(ctx, third); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	now := time.Now()
+	first := createTestReservation(t, repo, user.ID, game.ID, now.Add(-3*time.Hour))
+	second := createTestReservation(t, repo, other.ID, game.ID, now.Add(-2*time.Hour))
+	cancelled := createTestReservation(t, repo, third.ID, game.ID, now.Add(-time.Hour))
+
+	cancelled.Status = models.ReservationCancelled
+	cancelled.ClosedAt = &now
+	if err := repo.Update(ctx, cancelled); err != nil {
+		t.Fatalf("Failed to update reservation: %v", err)
+	}
+
+	queue, err := repo.GetActiveByGame(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get queue: %v", err)
+	}
+
+	if len(queue) != 2 {
+		t.Fatalf("Expected 2 active reservations, got %d", len(queue))
+	}
+	if queue[0].ID != first.ID || queue[1].ID != second.ID {
+		t.Errorf("Expected FIFO order [%d %d], got [%d %d]", first.ID, second.ID, queue[0].ID, queue[1].ID)
+	}
+
+	active, err := repo.GetActive(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get active reservations: %v", err)
+	}
+	if len(active) != 2 {
+		t.Errorf("Expected 2 active reservations, got %d", len(active))
+	}
+
+	byUser, err := repo.GetByUser(ctx, third.ID)
+	if err != nil {
+		t.Fatalf("Failed to get reservations by user: %v", err)
+	}
+	if len(byUser) != 1 || byUser[0].Status != models.ReservationCancelled {
+		t.Errorf("Expected the cancelled reservation for the third user, got %+v", byUser)
+	}
+}
+
+func TestSQLiteReservationRepository_GetExpiredHolds(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewSQLiteUserRepository(db)
+	gameRepo := NewSQLiteGameRepository(db)
+	repo := NewSQLiteReservationRepository(db)
+	user, game := createTestUserAndGame(t, userRepo, gameRepo)
+
+	copies, err := gameRepo.GetCopies(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("Failed to get copies: %v", err)
+	}
+
+	now := time.Now()
+	notifiedAt := now.Add(-4 * 24 * time.Hour)
+	expiresAt := now.Add(-24 * time.Hour)
+
+	reservation := createTestReservation(t, repo, user.ID, game.ID, now.Add(-5*24*time.Hour))
+	reservation.Status = models.ReservationReady
+	reservation.CopyID = &copies[0].ID
+	reservation.NotifiedAt = &notifiedAt
+	reservation.ExpiresAt = &expiresAt
+	if err := repo.Update(ctx, reservation); err != nil {
+		t.Fatalf("Failed to update reservation: %v", err)
+	}
+
+	expired, err := repo.GetExpiredHolds(ctx, now)
+	if err != nil {
+		t.Fatalf("Failed to get expired holds: %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != reservation.ID {
+		t.Fatalf("Expected reservation %d to be expired, got %+v", reservation.ID, expired)
+	}
+	if expired[0].CopyID == nil || *expired[0].CopyID != copies[0].ID {
+		t.Errorf("Expected held copy %d, got %v", copies[0].ID, expired[0].CopyID)
+	}
+
+	expired, err = repo.GetExpiredHolds(ctx, now.Add(-2*24*time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to get expired holds: %v", err)
+	}
+	if len(expired) != 0 {
+		t.Errorf("Expected no expired holds before the deadline, got %d", len(expired))
+	}
+}
+
+func TestSQLiteReservationRepository_UpdateNotFound(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteReservationRepository(db)
+	err := repo.Update(ctx, &models.Reservation{ID: 9999, Status: models.ReservationCancelled})
+	if err == nil {
+		t.Error("Expected error when updating non-existent reservation")
+	}
+}
@@ -0,0 +1,186 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/database"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// tagColumns selects a tag with the number of games it labels, in the order
+// read by scanTag
+const tagColumns = `t.id, t.name, t.slug, t.created_at,
+			(SELECT COUNT(*) FROM game_tags gt WHERE gt.tag_id = t.id) AS game_count`
+
+// SQLiteTagRepository implements TagRepository using SQLite
+type SQLiteTagRepository struct {
+	db database.Querier
+}
+
+// NewSQLiteTagRepository creates a new SQLite tag repository
+func NewSQLiteTagRepository(db *database.DB) TagRepository {
+	return &SQLiteTagRepository{db: db}
+}
+
+// Create inserts a new tag
+func (r *SQLiteTagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	query := `
+		INSERT INTO tags (library_id, name, slug, created_at)
+		VALUES (?, ?, ?, ?)
+		RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query, database.LibraryOf(r.db), tag.Name, tag.Slug, tag.CreatedAt).Scan(&tag.ID)
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return models.Conflict("tag_slug_taken", tag.Slug)
+		}
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a tag by its ID
+func (r *SQLiteTagRepository) GetByID(ctx context.Context, id int) (*models.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags t WHERE t.id = ? AND t.library_id = ?`
+
+	tag, err := scanTag(r.db.QueryRowContext(ctx, query, id, database.LibraryOf(r.db)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("tag_not_found", id)
+		}
+		return nil, fmt.Errorf("failed to get tag by id: %w", err)
+	}
+
+	return tag, nil
+}
+
+// GetBySlug retrieves a tag by its slug
+func (r *SQLiteTagRepository) GetBySlug(ctx context.Context, slug string) (*models.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags t WHERE t.slug = ? AND t.library_id = ?`
+
+	tag, err := scanTag(r.db.QueryRowContext(ctx, query, slug, database.LibraryOf(r.db)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.NotFound("tag_slug_not_found", slug)
+		}
+		return nil, fmt.Errorf("failed to get tag by slug: %w", err)
+	}
+
+	return tag, nil
+}
+
+// List returns at most limit tags whose slug contains the slug of search,
+// those starting with it and the most used first. Without search, every tag
+// is listed by name.
+func (r *SQLiteTagRepository) List(ctx context.Context, search string, limit int) ([]*models.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags t WHERE t.library_id = ?`
+	args := []any{database.LibraryOf(r.db)}
+
+	if search != "" {
+		searchSlug := models.TagSlug(search)
+		if searchSlug == "" {
+			return nil, nil
+		}
+		query += `
+		AND t.slug LIKE ?
+		ORDER BY t.slug LIKE ? DESC, game_count DESC, LOWER(t.name)`
+		// Slugs have no LIKE wildcards to escape
+		args = append(args, "%"+searchSlug+"%", searchSlug+"%")
+	} else {
+		query += ` ORDER BY LOWER(t.name)`
+	}
+	query += ` LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*models.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// Update renames an existing tag
+func (r *SQLiteTagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE tags SET name = ?, slug = ? WHERE id = ? AND library_id = ?`,
+		tag.Name, tag.Slug, tag.ID, database.LibraryOf(r.db))
+	if err != nil {
+		if database.IsUniqueViolation(err) {
+			return models.Conflict("tag_slug_taken", tag.Slug)
+		}
+		return fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return models.NotFound("tag_not_found", tag.ID)
+	}
+
+	return nil
+}
+
+// Merge moves the games of the source tag to the target tag, then deletes
+// the source tag
+func (r *SQLiteTagRepository) Merge(ctx context.Context, sourceID, targetID int) error {
+	return database.InTxContext(ctx, r.db, func(tx database.Querier) error {
+		query := `
+			INSERT INTO game_tags (game_id, tag_id)
+			SELECT game_id, ? FROM game_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`
+
+		if _, err := tx.ExecContext(ctx, query, targetID, sourceID); err != nil {
+			return fmt.Errorf("failed to merge tags: %w", err)
+		}
+
+		return (&SQLiteTagRepository{db: tx}).Delete(ctx, sourceID)
+	})
+}
+
+// Delete removes a tag from the database and from every game
+func (r *SQLiteTagRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tags WHERE id = ? AND library_id = ?`, id, database.LibraryOf(r.db))
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return models.NotFound("tag_not_found", id)
+	}
+
+	return nil
+}
+
+// scanTag scans a single tag row selected with tagColumns
+func scanTag(row rowScanner) (*models.Tag, error) {
+	tag := &models.Tag{}
+	if err := row.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.CreatedAt, &tag.GameCount); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSQLiteTagRepository_CreateAndGet(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteTagRepository(db)
+
+	tag := &models.Tag{Name: "Coopératif", Slug: models.TagSlug("Coopératif"), CreatedAt: time.Now()}
+	if err := repo.Create(ctx, tag); err != nil {
+		t.Fatalf("Failed to create tag: %v", err)
+	}
+	if tag.ID == 0 {
+		t.Error("Expected tag ID to be set after creation")
+	}
+
+	retrieved, err := repo.GetBySlug(ctx, "cooperatif")
+	if err != nil {
+		t.Fatalf("Failed to get tag by slug: %v", err)
+	}
+	if retrieved.ID != tag.ID || retrieved.Name != "Coopératif" {
+		t.Errorf("Expected tag %d named Coopératif, got %d named %s", tag.ID, retrieved.ID, retrieved.Name)
+	}
+
+	duplicate := &models.Tag{Name: "cooperatif", Slug: "cooperatif", CreatedAt: time.Now()}
+	if err := repo.Create(ctx, duplicate); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected an already exists error, got %v", err)
+	}
+
+	if _, err := repo.GetByID(ctx, 999); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestSQLiteTagRepository_List(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	games := NewSQLiteGameRepository(db)
+	repo := NewSQLiteTagRepository(db)
+
+	for _, game := range []*models.Game{
+		{Name: "Hanabi", Tags: []string{"Coopératif", "Cartes"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Pandemic", Tags: []string{"Coopératif"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Skull", Tags: []string{"Bluff"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+		{Name: "Ticket to Ride", Tags: []string{"Escape room coop"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true},
+	} {
+		if err := games.Create(ctx, game); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		search string
+		limit  int
+		want   string
+	}{
+		{"all by name", "", 10, "Bluff:1,Cartes:1,Coopératif:2,Escape room coop:1"},
+		{"prefix first", "COOP", 10, "Coopératif:2,Escape room coop:1"},
+		{"accents ignored", "coopé", 10, "Coopératif:2"},
+		{"limit", "", 2, "Bluff:1,Cartes:1"},
+		{"no match", "zzz", 10, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := repo.List(ctx, tt.search, tt.limit)
+			if err != nil {
+				t.Fatalf("Failed to list tags: %v", err)
+			}
+			var got []string
+			for _, tag := range tags {
+				got = append(got, fmt.Sprintf("%s:%d", tag.Name, tag.GameCount))
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, strings.Join(got, ","))
+			}
+		})
+	}
+}
+
+func TestSQLiteTagRepository_RenameMergeDelete(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	games := NewSQLiteGameRepository(db)
+	repo := NewSQLiteTagRepository(db)
+
+	hanabi := &models.Game{Name: "Hanabi", Tags: []string{"Coop", "Cartes"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true}
+	pandemic := &models.Game{Name: "Pandemic", Tags: []string{"Coopératif"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true}
+	for _, game := range []*models.Game{hanabi, pandemic} {
+		if err := games.Create(ctx, game); err != nil {
+			t.Fatalf("Failed to create game: %v", err)
+		}
+	}
+
+	coop, err := repo.GetBySlug(ctx, "coop")
+	if err != nil {
+		t.Fatalf("Failed to get tag: %v", err)
+	}
+	cooperatif, err := repo.GetBySlug(ctx, "cooperatif")
+	if err != nil {
+		t.Fatalf("Failed to get tag: %v", err)
+	}
+
+	coop.Name, coop.Slug = "Cooperatif", "cooperatif"
+	if err := repo.Update(ctx, coop); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected renaming to an existing slug to fail, got %v", err)
+	}
+
+	if err := repo.Merge(ctx, coop.ID, cooperatif.ID); err != nil {
+		t.Fatalf("Failed to merge tags: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, coop.ID); err == nil {
+		t.Error("Expected the merged tag to be deleted")
+	}
+	merged, err := repo.GetByID(ctx, cooperatif.ID)
+	if err != nil {
+		t.Fatalf("Failed to get merged tag: %v", err)
+	}
+	if merged.GameCount != 2 {
+		t.Errorf("Expected the target tag to label both games, got %d", merged.GameCount)
+	}
+
+	merged.Name, merged.Slug = "Coopération", models.TagSlug("Coopération")
+	if err := repo.Update(ctx, merged); err != nil {
+		t.Fatalf("Failed to rename tag: %v", err)
+	}
+	retrieved, err := games.GetByID(ctx, hanabi.ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if strings.Join(retrieved.Tags, ",") != "Cartes,Coopération" {
+		t.Errorf("Expected renamed tag on the game, got %v", retrieved.Tags)
+	}
+
+	if err := repo.Delete(ctx, merged.ID); err != nil {
+		t.Fatalf("Failed to delete tag: %v", err)
+	}
+	retrieved, err = games.GetByID(ctx, pandemic.ID)
+	if err != nil {
+		t.Fatalf("Failed to get game: %v", err)
+	}
+	if len(retrieved.Tags) != 0 {
+		t.Errorf("Expected deleted tag to be removed from the game, got %v", retrieved.Tags)
+	}
+	if err := repo.Delete(ctx, merged.ID); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"board-game-library/pkg/database"
+	"context"
+)
+
+// SQLiteUnitOfWork implements UnitOfWork using SQLite transactions
+type SQLiteUnitOfWork struct {
+	db *database.DB
+}
+
+// NewSQLiteUnitOfWork creates a new SQLite unit of work
+func NewSQLiteUnitOfWork(db *database.DB) UnitOfWork {
+	return &SQLiteUnitOfWork{db: db}
+}
+
+// Do runs fn inside a transaction with repositories bound to it
+func (u *SQLiteUnitOfWork) Do(ctx context.Context, fn func(store *Store) error) error {
+	return u.db.WithTxContext(ctx, func(tx database.Querier) error {
+		return fn(newSQLiteStore(tx))
+	})
+}
+
+// newSQLiteStore returns the SQLite repositories bound to q
+func newSQLiteStore(q database.Querier) *Store {
+	return &Store{
+		Users:        &SQLiteUserRepository{db: q},
+		Games:        &SQLiteGameRepository{db: q},
+		Borrowings:   &SQLiteBorrowingRepository{db: q},
+		Alerts:       &SQLiteAlertRepository{db: q},
+		Reservations: &SQLiteReservationRepository{db: q},
+		LoanPolicies: &SQLiteLoanPolicyRepository{db: q},
+		Fines:        &SQLiteFineRepository{db: q},
+		Conditions:   &SQLiteConditionRepository{db: q},
+		Audit:        &SQLiteAuditRepository{db: q},
+	}
+}
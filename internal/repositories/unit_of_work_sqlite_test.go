@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSQLiteUnitOfWork_Commit(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewSQLiteUserRepository(db)
+	gameRepo := NewSQLiteGameRepository(db)
+	borrowingRepo := NewSQLiteBorrowingRepository(db)
+	user, game := createTestUserAndGame(t, userRepo, gameRepo)
+	copies, _ := gameRepo.GetCopies(ctx, game.ID)
+
+	uow := NewSQLiteUnitOfWork(db)
+	err := uow.Do(ctx, func(store *Store) error {
+		borrowing := &models.Borrowing{
+			UserID:     user.ID,
+			GameID:     game.ID,
+			CopyID:     &copies[0].ID,
+			BorrowedAt: time.Now(),
+			DueDate:    time.Now().Add(14 * 24 * time.Hour),
+		}
+		if err := store.Borrowings.Create(ctx, borrowing); err != nil {
+			return err
+		}
+
+		copies[0].IsAvailable = false
+		return store.Games.UpdateCopy(ctx, copies[0])
+	})
+	if err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+
+	active, _ := borrowingRepo.GetActiveByUser(ctx, user.ID)
+	if len(active) != 1 {
+		t.Errorf("Expected 1 committed borrowing, got %d", len(active))
+	}
+	retrieved, _ := gameRepo.GetByID(ctx, game.ID)
+	if retrieved.IsAvailable {
+		t.Error("Expected the copy update to be committed")
+	}
+}
+
+func TestSQLiteUnitOfWork_Rollback(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	userRepo := NewSQLiteUserRepository(db)
+	gameRepo := NewSQLiteGameRepository(db)
+	borrowingRepo := NewSQLiteBorrowingRepository(db)
+	user, game := createTestUserAndGame(t, userRepo, gameRepo)
+
+	uow := NewSQLiteUnitOfWork(db)
+	failure := errors.New("copy update failed")
+	err := uow.Do(ctx, func(store *Store) error {
+		borrowing := &models.Borrowing{
+			UserID:     user.ID,
+			GameID:     game.ID,
+			BorrowedAt: time.Now(),
+			DueDate:    time.Now().Add(14 * 24 * time.Hour),
+		}
+		if err := store.Borrowings.Create(ctx, borrowing); err != nil {
+			return err
+		}
+
+		// Games created inside the unit of work join its transaction
+		extra := &models.Game{Name: "Azul", Description: "Tiles", Tags: []string{"Abstract"}, EntryDate: time.Now(), Condition: "good", IsAvailable: true}
+		if err := store.Games.Create(ctx, extra); err != nil {
+			return err
+		}
+
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Do() error = %v, want %v", err, failure)
+	}
+
+	active, _ := borrowingRepo.GetActiveByUser(ctx, user.ID)
+	if len(active) != 0 {
+		t.Errorf("Expected the borrowing to be rolled back, got %d", len(active))
+	}
+	games, _ := gameRepo.GetAll(ctx)
+	if len(games) != 1 {
+		t.Errorf("Expected the game creation to be rolled back, got %d games", len(games))
+	}
+}
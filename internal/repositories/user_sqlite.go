@@ -3,28 +3,38 @@ package repositories
 import (
 	"board-game-library/internal/models"
 	"board-game-library/pkg/database"
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
 // SQLiteUserRepository implements UserRepository using SQLite
 type SQLiteUserRepository struct {
-	db *database.DB
+	db database.Querier
 }
 
-// NewSQLiteUserRepository creates a new SQLite user repository
-func NewSQLiteUserRepository(db *database.DB) UserRepository {
+// NewSQLiteUserRepository creates a new SQLite user repository on a
+// connection or a transaction
+func NewSQLiteUserRepository(db database.Querier) UserRepository {
 	return &SQLiteUserRepository{db: db}
 }
 
 // Create inserts a new user into the database
-func (r *SQLiteUserRepository) Create(user *models.User) error {
+func (r *SQLiteUserRepository) Create(ctx context.Context, user *models.User) error {
+	if user.MembershipTier == "" {
+		user.MembershipTier = models.DefaultMembershipTier
+	}
+	if user.Role == "" {
+		user.Role = models.RoleMember
+	}
+
 	query := `
-		INSERT INTO users (name, email, registered_at, is_active)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO users (library_id, name, email, registered_at, is_active, membership_tier, role)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 		RETURNING id`
 	
-	err := r.db.QueryRow(query, user.Name, user.Email, user.RegisteredAt, user.IsActive).Scan(&user.ID)
+	err := r.db.QueryRowContext(ctx, query, database.LibraryOf(r.db), user.Name, user.Email, user.RegisteredAt, user.IsActive, user.MembershipTier, user.Role).Scan(&user.ID)
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -33,20 +43,20 @@ func (r *SQLiteUserRepository) Create(user *models.User) error {
 }
 
 // GetByID retrieves a user by their ID
-func (r *SQLiteUserRepository) GetByID(id int) (*models.User, error) {
+func (r *SQLiteUserRepository) GetByID(ctx context.Context, id int) (*models.User, error) {
 	query := `
-		SELECT id, name, email, registered_at, is_active
+		SELECT id, name, email, registered_at, is_active, membership_tier, role
 		FROM users
-		WHERE id = ?`
+		WHERE id = ? AND library_id = ?`
 	
 	user := &models.User{}
-	err := r.db.QueryRow(query, id).Scan(
-		&user.ID, &user.Name, &user.Email, &user.RegisteredAt, &user.IsActive,
+	err := r.db.QueryRowContext(ctx, query, id, database.LibraryOf(r.db)).Scan(
+		&user.ID, &user.Name, &user.Email, &user.RegisteredAt, &user.IsActive, &user.MembershipTier, &user.Role,
 	)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user with id %d not found", id)
+			return nil, models.NotFound("user_not_found", id)
 		}
 		return nil, fmt.Errorf("failed to get user by id: %w", err)
 	}
@@ -55,20 +65,20 @@ func (r *SQLiteUserRepository) GetByID(id int) (*models.User, error) {
 }
 
 // GetByEmail retrieves a user by their email address
-func (r *SQLiteUserRepository) GetByEmail(email string) (*models.User, error) {
+func (r *SQLiteUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, name, email, registered_at, is_active
+		SELECT id, name, email, registered_at, is_active, membership_tier, role
 		FROM users
-		WHERE email = ?`
+		WHERE email = ? AND library_id = ?`
 	
 	user := &models.User{}
-	err := r.db.QueryRow(query, email).Scan(
-		&user.ID, &user.Name, &user.Email, &user.RegisteredAt, &user.IsActive,
+	err := r.db.QueryRowContext(ctx, query, email, database.LibraryOf(r.db)).Scan(
+		&user.ID, &user.Name, &user.Email, &user.RegisteredAt, &user.IsActive, &user.MembershipTier, &user.Role,
 	)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("user with email %s not found", email)
+			return nil, models.NotFound("user_email_not_found", email)
 		}
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
@@ -77,13 +87,14 @@ func (r *SQLiteUserRepository) GetByEmail(email string) (*models.User, error) {
 }
 
 // GetAll retrieves all users from the database
-func (r *SQLiteUserRepository) GetAll() ([]*models.User, error) {
+func (r *SQLiteUserRepository) GetAll(ctx context.Context) ([]*models.User, error) {
 	query := `
-		SELECT id, name, email, registered_at, is_active
+		SELECT id, name, email, registered_at, is_active, membership_tier, role
 		FROM users
+		WHERE library_id = ?
 		ORDER BY name`
 	
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query, database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
@@ -93,7 +104,7 @@ func (r *SQLiteUserRepository) GetAll() ([]*models.User, error) {
 	for rows.Next() {
 		user := &models.User{}
 		err := rows.Scan(
-			&user.ID, &user.Name, &user.Email, &user.RegisteredAt, &user.IsActive,
+			&user.ID, &user.Name, &user.Email, &user.RegisteredAt, &user.IsActive, &user.MembershipTier, &user.Role,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
@@ -108,14 +119,101 @@ func (r *SQLiteUserRepository) GetAll() ([]*models.User, error) {
 	return users, nil
 }
 
+// userSortColumns maps the user sort fields to SQL
+var userSortColumns = map[string]sortColumn{
+	"name":            {expr: "LOWER(name)"},
+	"email":           {expr: "LOWER(email)"},
+	"registered_at":   {expr: "registered_at", desc: true},
+	"membership_tier": {expr: "membership_tier"},
+}
+
+// List retrieves one page of the users matching filter
+func (r *SQLiteUserRepository) List(ctx context.Context, filter models.UserFilter) ([]*models.User, error) {
+	where := userWhere(r.db, filter)
+	query := `
+		SELECT id, name, email, registered_at, is_active, membership_tier, role
+		FROM users` + where.String() +
+		orderBy(filter.ListOptions, userSortColumns, "name") + `
+		LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, append(where.args, pageArgs(filter.ListOptions)...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		user := &models.User{}
+		err := rows.Scan(
+			&user.ID, &user.Name, &user.Email, &user.RegisteredAt, &user.IsActive, &user.MembershipTier, &user.Role,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, nil
+}
+
+// Count returns the number of users matching filter, ignoring its page
+func (r *SQLiteUserRepository) Count(ctx context.Context, filter models.UserFilter) (int, error) {
+	where := userWhere(r.db, filter)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`+where.String(), where.args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return count, nil
+}
+
+// userWhere builds the conditions selecting the users matching filter
+func userWhere(q database.Querier, filter models.UserFilter) *sqlWhere {
+	where := libraryWhere(q, "library_id")
+	if filter.Search != "" {
+		term := "%" + strings.ToLower(filter.Search) + "%"
+		where.add("(LOWER(name) LIKE ? OR LOWER(email) LIKE ?)", term, term)
+	}
+	if filter.MembershipTier != "" {
+		where.add("membership_tier = ?", filter.MembershipTier)
+	}
+	if filter.Role != "" {
+		where.add("role = ?", filter.Role)
+	}
+	if filter.Active != nil {
+		where.add("is_active = ?", *filter.Active)
+	}
+	if filter.HasLoans != nil {
+		in := "IN"
+		if !*filter.HasLoans {
+			in = "NOT IN"
+		}
+		where.add("id " + in + " (SELECT user_id FROM borrowings WHERE returned_at IS NULL)")
+	}
+	return where
+}
+
 // Update modifies an existing user in the database
-func (r *SQLiteUserRepository) Update(user *models.User) error {
+func (r *SQLiteUserRepository) Update(ctx context.Context, user *models.User) error {
+	if user.MembershipTier == "" {
+		user.MembershipTier = models.DefaultMembershipTier
+	}
+	if user.Role == "" {
+		user.Role = models.RoleMember
+	}
+
 	query := `
 		UPDATE users
-		SET name = ?, email = ?, is_active = ?
-		WHERE id = ?`
+		SET name = ?, email = ?, is_active = ?, membership_tier = ?, role = ?
+		WHERE id = ? AND library_id = ?`
 	
-	result, err := r.db.Exec(query, user.Name, user.Email, user.IsActive, user.ID)
+	result, err := r.db.ExecContext(ctx, query, user.Name, user.Email, user.IsActive, user.MembershipTier, user.Role, user.ID, database.LibraryOf(r.db))
 	if err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
@@ -126,17 +224,17 @@ func (r *SQLiteUserRepository) Update(user *models.User) error {
 	}
 	
 	if rowsAffected == 0 {
-		return fmt.Errorf("user with id %d not found", user.ID)
+		return models.NotFound("user_not_found", user.ID)
 	}
 	
 	return nil
 }
 
 // Delete removes a user from the database
-func (r *SQLiteUserRepository) Delete(id int) error {
-	query := `DELETE FROM users WHERE id = ?`
+func (r *SQLiteUserRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM users WHERE id = ? AND library_id = ?`
 	
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id, database.LibraryOf(r.db))
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -147,21 +245,21 @@ func (r *SQLiteUserRepository) Delete(id int) error {
 	}
 	
 	if rowsAffected == 0 {
-		return fmt.Errorf("user with id %d not found", id)
+		return models.NotFound("user_not_found", id)
 	}
 	
 	return nil
 }
 
 // GetBorrowingHistory retrieves the borrowing history for a user
-func (r *SQLiteUserRepository) GetBorrowingHistory(userID int) ([]*models.Borrowing, error) {
+func (r *SQLiteUserRepository) GetBorrowingHistory(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	query := `
-		SELECT b.id, b.user_id, b.game_id, b.borrowed_at, b.due_date, b.returned_at, b.is_overdue
+		SELECT b.id, b.user_id, b.game_id, b.copy_id, b.borrowed_at, b.due_date, b.returned_at, b.is_overdue, b.extension_count
 		FROM borrowings b
-		WHERE b.user_id = ?
+		WHERE b.user_id = ? AND b.library_id = ?
 		ORDER BY b.borrowed_at DESC`
 	
-	rows, err := r.db.Query(query, userID)
+	rows, err := r.db.QueryContext(ctx, query, userID, database.LibraryOf(r.db))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get borrowing history: %w", err)
 	}
@@ -171,8 +269,8 @@ func (r *SQLiteUserRepository) GetBorrowingHistory(userID int) ([]*models.Borrow
 	for rows.Next() {
 		borrowing := &models.Borrowing{}
 		err := rows.Scan(
-			&borrowing.ID, &borrowing.UserID, &borrowing.GameID,
-			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue,
+			&borrowing.ID, &borrowing.UserID, &borrowing.GameID, &borrowing.CopyID,
+			&borrowing.BorrowedAt, &borrowing.DueDate, &borrowing.ReturnedAt, &borrowing.IsOverdue, &borrowing.ExtensionCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan borrowing: %w", err)
@@ -3,6 +3,8 @@ package repositories
 import (
 	"board-game-library/internal/models"
 	"board-game-library/pkg/database"
+	"context"
+	"strings"
 	"testing"
 	"time"
 )
@@ -16,6 +18,7 @@ func setupTestDB(t *testing.T) *database.DB {
 }
 
 func TestSQLiteUserRepository_Create(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -28,7 +31,7 @@ func TestSQLiteUserRepository_Create(t *testing.T) {
 		IsActive:     true,
 	}
 
-	err := repo.Create(user)
+	err := repo.Create(ctx, user)
 	if err != nil {
 		t.Fatalf("Failed to create user: %v", err)
 	}
@@ -39,6 +42,7 @@ func TestSQLiteUserRepository_Create(t *testing.T) {
 }
 
 func TestSQLiteUserRepository_GetByID(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -52,13 +56,13 @@ func TestSQLiteUserRepository_GetByID(t *testing.T) {
 		IsActive:     true,
 	}
 	
-	err := repo.Create(user)
+	err := repo.Create(ctx, user)
 	if err != nil {
 		t.Fatalf("Failed to create user: %v", err)
 	}
 
 	// Retrieve the user
-	retrieved, err := repo.GetByID(user.ID)
+	retrieved, err := repo.GetByID(ctx, user.ID)
 	if err != nil {
 		t.Fatalf("Failed to get user by ID: %v", err)
 	}
@@ -72,18 +76,20 @@ func TestSQLiteUserRepository_GetByID(t *testing.T) {
 }
 
 func TestSQLiteUserRepository_GetByID_NotFound(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
 	repo := NewSQLiteUserRepository(db)
 	
-	_, err := repo.GetByID(999)
+	_, err := repo.GetByID(ctx, 999)
 	if err == nil {
 		t.Error("Expected error for non-existent user, but got none")
 	}
 }
 
 func TestSQLiteUserRepository_GetByEmail(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -97,13 +103,13 @@ func TestSQLiteUserRepository_GetByEmail(t *testing.T) {
 		IsActive:     true,
 	}
 	
-	err := repo.Create(user)
+	err := repo.Create(ctx, user)
 	if err != nil {
 		t.Fatalf("Failed to create user: %v", err)
 	}
 
 	// Retrieve the user by email
-	retrieved, err := repo.GetByEmail(user.Email)
+	retrieved, err := repo.GetByEmail(ctx, user.Email)
 	if err != nil {
 		t.Fatalf("Failed to get user by email: %v", err)
 	}
@@ -117,6 +123,7 @@ func TestSQLiteUserRepository_GetByEmail(t *testing.T) {
 }
 
 func TestSQLiteUserRepository_GetAll(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -130,14 +137,14 @@ func TestSQLiteUserRepository_GetAll(t *testing.T) {
 	}
 
 	for _, user := range users {
-		err := repo.Create(user)
+		err := repo.Create(ctx, user)
 		if err != nil {
 			t.Fatalf("Failed to create user: %v", err)
 		}
 	}
 
 	// Retrieve all users
-	retrieved, err := repo.GetAll()
+	retrieved, err := repo.GetAll(ctx)
 	if err != nil {
 		t.Fatalf("Failed to get all users: %v", err)
 	}
@@ -148,6 +155,7 @@ func TestSQLiteUserRepository_GetAll(t *testing.T) {
 }
 
 func TestSQLiteUserRepository_Update(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -161,7 +169,7 @@ func TestSQLiteUserRepository_Update(t *testing.T) {
 		IsActive:     true,
 	}
 	
-	err := repo.Create(user)
+	err := repo.Create(ctx, user)
 	if err != nil {
 		t.Fatalf("Failed to create user: %v", err)
 	}
@@ -171,13 +179,13 @@ func TestSQLiteUserRepository_Update(t *testing.T) {
 	user.Email = "updated@example.com"
 	user.IsActive = false
 
-	err = repo.Update(user)
+	err = repo.Update(ctx, user)
 	if err != nil {
 		t.Fatalf("Failed to update user: %v", err)
 	}
 
 	// Retrieve and verify the update
-	retrieved, err := repo.GetByID(user.ID)
+	retrieved, err := repo.GetByID(ctx, user.ID)
 	if err != nil {
 		t.Fatalf("Failed to get updated user: %v", err)
 	}
@@ -194,6 +202,7 @@ func TestSQLiteUserRepository_Update(t *testing.T) {
 }
 
 func TestSQLiteUserRepository_Delete(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -207,25 +216,26 @@ func TestSQLiteUserRepository_Delete(t *testing.T) {
 		IsActive:     true,
 	}
 	
-	err := repo.Create(user)
+	err := repo.Create(ctx, user)
 	if err != nil {
 		t.Fatalf("Failed to create user: %v", err)
 	}
 
 	// Delete the user
-	err = repo.Delete(user.ID)
+	err = repo.Delete(ctx, user.ID)
 	if err != nil {
 		t.Fatalf("Failed to delete user: %v", err)
 	}
 
 	// Verify the user is deleted
-	_, err = repo.GetByID(user.ID)
+	_, err = repo.GetByID(ctx, user.ID)
 	if err == nil {
 		t.Error("Expected error when getting deleted user, but got none")
 	}
 }
 
 func TestSQLiteUserRepository_GetBorrowingHistory(t *testing.T) {
+	ctx := context.Background()
 	db := setupTestDB(t)
 	defer db.Close()
 
@@ -240,7 +250,7 @@ func TestSQLiteUserRepository_GetBorrowingHistory(t *testing.T) {
 		RegisteredAt: time.Now(),
 		IsActive:     true,
 	}
-	err := userRepo.Create(user)
+	err := userRepo.Create(ctx, user)
 	if err != nil {
 		t.Fatalf("Failed to create user: %v", err)
 	}
@@ -248,12 +258,12 @@ func TestSQLiteUserRepository_GetBorrowingHistory(t *testing.T) {
 	game := &models.Game{
 		Name:        "Test Game",
 		Description: "A test game",
-		Category:    "Strategy",
+		Tags:        []string{"Strategy"},
 		EntryDate:   time.Now(),
 		Condition:   "good",
 		IsAvailable: true,
 	}
-	err = gameRepo.Create(game)
+	err = gameRepo.Create(ctx, game)
 	if err != nil {
 		t.Fatalf("Failed to create game: %v", err)
 	}
@@ -266,13 +276,13 @@ func TestSQLiteUserRepository_GetBorrowingHistory(t *testing.T) {
 		DueDate:    time.Now().Add(14 * 24 * time.Hour),
 		IsOverdue:  false,
 	}
-	err = borrowingRepo.Create(borrowing)
+	err = borrowingRepo.Create(ctx, borrowing)
 	if err != nil {
 		t.Fatalf("Failed to create borrowing: %v", err)
 	}
 
 	// Get borrowing history
-	history, err := userRepo.GetBorrowingHistory(user.ID)
+	history, err := userRepo.GetBorrowingHistory(ctx, user.ID)
 	if err != nil {
 		t.Fatalf("Failed to get borrowing history: %v", err)
 	}
@@ -284,4 +294,80 @@ func TestSQLiteUserRepository_GetBorrowingHistory(t *testing.T) {
 	if history[0].GameID != game.ID {
 		t.Errorf("Expected game ID %d, got %d", game.ID, history[0].GameID)
 	}
-}
\ No newline at end of file
+}
+
+func TestSQLiteUserRepository_List(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteUserRepository(db)
+
+	users := []*models.User{
+		{Name: "Bob", Email: "bob@example.com", RegisteredAt: time.Now().Add(-48 * time.Hour), IsActive: true, MembershipTier: "premium"},
+		{Name: "alice", Email: "alice@example.com", RegisteredAt: time.Now().Add(-24 * time.Hour), IsActive: true},
+		{Name: "Carol", Email: "carol@example.org", RegisteredAt: time.Now(), IsActive: false},
+	}
+	for _, user := range users {
+		if err := repo.Create(ctx, user); err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	// Bob has a game out
+	game := &models.Game{Name: "Azul", EntryDate: time.Now(), Condition: "good", IsAvailable: true}
+	if err := NewSQLiteGameRepository(db).Create(ctx, game); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	borrowing := &models.Borrowing{UserID: users[0].ID, GameID: game.ID, BorrowedAt: time.Now(), DueDate: time.Now().Add(14 * 24 * time.Hour)}
+	if err := NewSQLiteBorrowingRepository(db).Create(ctx, borrowing); err != nil {
+		t.Fatalf("Failed to create borrowing: %v", err)
+	}
+
+	inactive := false
+	withLoans, withoutLoans := true, false
+	tests := []struct {
+		name   string
+		filter models.UserFilter
+		want   []string
+		total  int
+	}{
+		{"default sorts by name ignoring case", models.UserFilter{}, []string{"alice", "Bob", "Carol"}, 3},
+		{"newest first", models.UserFilter{ListOptions: models.ListOptions{Sort: "registered_at"}}, []string{"Carol", "alice", "Bob"}, 3},
+		{"search email", models.UserFilter{Search: "example.org"}, []string{"Carol"}, 1},
+		{"tier", models.UserFilter{MembershipTier: "premium"}, []string{"Bob"}, 1},
+		{"inactive", models.UserFilter{Active: &inactive}, []string{"Carol"}, 1},
+		{"with loans", models.UserFilter{HasLoans: &withLoans}, []string{"Bob"}, 1},
+		{"without loans", models.UserFilter{HasLoans: &withoutLoans}, []string{"alice", "Carol"}, 2},
+		{"page", models.UserFilter{ListOptions: models.ListOptions{Page: 1, PerPage: 1}}, []string{"alice"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := tt.filter
+			if err := models.ValidateUserFilter(&filter); err != nil {
+				t.Fatalf("Invalid filter: %v", err)
+			}
+
+			retrieved, err := repo.List(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to list users: %v", err)
+			}
+			var names []string
+			for _, user := range retrieved {
+				names = append(names, user.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected %v, got %v", tt.want, names)
+			}
+
+			total, err := repo.Count(ctx, filter)
+			if err != nil {
+				t.Fatalf("Failed to count users: %v", err)
+			}
+			if total != tt.total {
+				t.Errorf("Expected total %d, got %d", tt.total, total)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package routes
+
+import (
+	"board-game-library/internal/handlers"
+	"board-game-library/internal/models"
+)
+
+var (
+	public    = handlers.AccessRule{Public: true}
+	member    = handlers.AccessRule{Role: models.RoleMember}
+	librarian = handlers.AccessRule{Role: models.RoleLibrarian}
+	admin     = handlers.AccessRule{Role: models.RoleAdmin}
+
+	// Members may use these routes for their own user ID only
+	selfOrLibrarian = handlers.AccessRule{Role: models.RoleLibrarian, Self: true}
+	selfOrAdmin     = handlers.AccessRule{Role: models.RoleAdmin, Self: true}
+)
+
+// accessRules lists who may call each route. Members browse the catalogue
+// and see their own records, librarians run the lending desk, and
+// administrators delete data and configure the library. Routes missing
+// from this table are restricted to administrators.
+var accessRules = handlers.AccessRules{
+	// Sign-in, first-run setup and documentation
+	"GET /login":                     public,
+	"POST /login":                    public,
+	"GET /setup":                     public,
+	"POST /setup":                    public,
+	"POST /logout":                   member,
+	"GET /account":                   member,
+	"POST /account/password":         member,
+	"POST /account/notifications":    member,
+	"GET /static/*filepath":          public,
+	"HEAD /static/*filepath":         public,
+	"GET /swagger/*any":              public,
+	"POST /api/v1/auth/setup":        public,
+	"POST /api/v1/auth/login":        public,
+	"POST /api/v1/auth/logout":       member,
+	"GET /api/v1/auth/me":            member,
+	"GET /api/v1/auth/tokens":        member,
+	"POST /api/v1/auth/tokens":       member,
+	"DELETE /api/v1/auth/tokens/:id": member,
+
+	// Web pages and forms
+	"GET /":                           member,
+	"GET /guide":                      member,
+	"GET /games":                      member,
+	"POST /games/search":              member,
+	"POST /games/search-filter":       member,
+	"POST /games/filter":              member,
+	"POST /games/sort":                member,
+	"GET /games/:id":                  member,
+	"GET /games/:id/availability":     member,
+	"POST /games":                     librarian,
+	"GET /games/new":                  librarian,
+	"GET /games/:id/edit":             librarian,
+	"PUT /games/:id":                  librarian,
+	"POST /games/:id/history":         librarian,
+	"DELETE /games/:id":               admin,
+	"GET /games/bgg":                  librarian,
+	"POST /games/bgg/import":          librarian,
+	"GET /tags/suggest":               librarian,
+	"GET /users":                      librarian,
+	"POST /users":                     librarian,
+	"POST /users/search":              librarian,
+	"POST /users/search-filter":       librarian,
+	"POST /users/sort":                librarian,
+	"GET /users/new":                  librarian,
+	"GET /users/:id":                  librarian,
+	"PUT /users/:id":                  librarian,
+	"GET /users/:id/edit":             librarian,
+	"POST /users/:id/history":         librarian,
+	"DELETE /users/:id":               admin,
+	"GET /borrowings":                 librarian,
+	"POST /borrowings":                librarian,
+	"POST /borrowings/search":         librarian,
+	"POST /borrowings/filter":         librarian,
+	"POST /borrowings/sort":           librarian,
+	"GET /borrowings/new":             librarian,
+	"GET /borrowings/user-info":       librarian,
+	"GET /borrowings/game-info":       librarian,
+	"GET /borrowings/:id":             librarian,
+	"POST /borrowings/:id/return":     librarian,
+	"GET /borrowings/:id/extend":      librarian,
+	"POST /borrowings/:id/extend":     librarian,
+	"GET /alerts":                     librarian,
+	"POST /alerts":                    librarian,
+	"GET /alerts/new":                 librarian,
+	"POST /alerts/search":             librarian,
+	"POST /alerts/filter":             librarian,
+	"POST /alerts/mark-all-read":      librarian,
+	"POST /alerts/mark-user-read/:id": librarian,
+	"POST /alerts/:id/mark-read":      librarian,
+	"DELETE /alerts/:id":              librarian,
+	"POST /alerts/generate":           librarian,
+	"POST /alerts/cleanup":            admin,
+	"GET /alerts/count":               librarian,
+	"GET /dashboard":                  librarian,
+	"GET /dashboard/stats":            librarian,
+	"GET /dashboard/alerts":           librarian,
+	"GET /dashboard/content":          librarian,
+	"GET /reservations":               librarian,
+	"POST /reservations/create":       librarian,
+	"POST /reservations/:id/cancel":   librarian,
+	"POST /reservations/expire":       librarian,
+	"GET /audit":                      admin,
+
+	// Games API
+	"GET /api/v1/games":                       member,
+	"GET /api/v1/games/search":                member,
+	"GET /api/v1/games/:id":                   member,
+	"GET /api/v1/games/:id/availability":      member,
+	"GET /api/v1/games/:id/copies":            member,
+	"GET /api/v1/games/:id/borrowings":        librarian,
+	"GET /api/v1/games/bgg/search":            librarian,
+	"GET /api/v1/games/bgg/:bggId":            librarian,
+	"POST /api/v1/games/bgg/import":           librarian,
+	"POST /api/v1/games":                      librarian,
+	"PUT /api/v1/games/:id":                   librarian,
+	"POST /api/v1/games/:id/copies":           librarian,
+	"PUT /api/v1/games/:id/copies/:copyId":    librarian,
+	"DELETE /api/v1/games/:id":                admin,
+	"DELETE /api/v1/games/:id/copies/:copyId": admin,
+
+	// Game condition API
+	"GET /api/v1/games/:id/condition-history":         librarian,
+	"POST /api/v1/games/:id/copies/:copyId/condition": librarian,
+
+	// Users API
+	"GET /api/v1/users":                   librarian,
+	"POST /api/v1/users":                  librarian,
+	"GET /api/v1/users/:id":               selfOrLibrarian,
+	"PUT /api/v1/users/:id":               librarian,
+	"GET /api/v1/users/:id/borrowings":    selfOrLibrarian,
+	"GET /api/v1/users/:id/current-loans": selfOrLibrarian,
+	"GET /api/v1/users/:id/eligibility":   selfOrLibrarian,
+	"PUT /api/v1/users/:id/password":      selfOrAdmin,
+	"PUT /api/v1/users/:id/role":          admin,
+	"GET /api/v1/users/:id/notifications": selfOrLibrarian,
+	"PUT /api/v1/users/:id/notifications": selfOrLibrarian,
+
+	// Fines API
+	"GET /api/v1/users/:id/fines":           selfOrLibrarian,
+	"POST /api/v1/users/:id/fines/payments": librarian,
+	"POST /api/v1/users/:id/fines/waivers":  librarian,
+
+	// Borrowings API
+	"GET /api/v1/borrowings":                 librarian,
+	"POST /api/v1/borrowings":                librarian,
+	"GET /api/v1/borrowings/:id":             librarian,
+	"PUT /api/v1/borrowings/:id/return":      librarian,
+	"PUT /api/v1/borrowings/:id/extend":      librarian,
+	"GET /api/v1/borrowings/overdue":         librarian,
+	"GET /api/v1/borrowings/due-soon":        librarian,
+	"GET /api/v1/borrowings/user/:id":        selfOrLibrarian,
+	"GET /api/v1/borrowings/game/:id":        librarian,
+	"POST /api/v1/borrowings/update-overdue": librarian,
+
+	// Alerts API
+	"GET /api/v1/alerts":                   librarian,
+	"POST /api/v1/alerts":                  librarian,
+	"GET /api/v1/alerts/user/:id":          selfOrLibrarian,
+	"PUT /api/v1/alerts/:id/read":          librarian,
+	"PUT /api/v1/alerts/user/:id/read-all": selfOrLibrarian,
+	"DELETE /api/v1/alerts/:id":            librarian,
+	"GET /api/v1/alerts/summary":           librarian,
+	"GET /api/v1/alerts/dashboard":         librarian,
+	"GET /api/v1/alerts/:id/notification":  librarian,
+
+	// Reservations API
+	"POST /api/v1/reservations":           librarian,
+	"GET /api/v1/reservations":            librarian,
+	"POST /api/v1/reservations/expire":    librarian,
+	"GET /api/v1/reservations/game/:id":   librarian,
+	"GET /api/v1/reservations/user/:id":   selfOrLibrarian,
+	"GET /api/v1/reservations/:id":        librarian,
+	"PUT /api/v1/reservations/:id/cancel": librarian,
+
+	// Loan policies API
+	"GET /api/v1/loan-policies":       member,
+	"GET /api/v1/loan-policies/:tier": member,
+	"POST /api/v1/loan-policies":      admin,
+	"PUT /api/v1/loan-policies/:tier": admin,
+
+	// Tags API
+	"GET /api/v1/tags":            member,
+	"GET /api/v1/tags/:id":        member,
+	"POST /api/v1/tags":           librarian,
+	"PUT /api/v1/tags/:id":        librarian,
+	"POST /api/v1/tags/:id/merge": librarian,
+	"DELETE /api/v1/tags/:id":     admin,
+
+	// Background jobs API
+	"GET /api/v1/jobs":                admin,
+	"GET /api/v1/jobs/executions":     admin,
+	"GET /api/v1/jobs/statistics":     admin,
+	"GET /api/v1/jobs/:name":          admin,
+	"POST /api/v1/jobs/:name/run":     admin,
+	"PUT /api/v1/jobs/:name/enable":   admin,
+	"PUT /api/v1/jobs/:name/disable":  admin,
+	"PUT /api/v1/jobs/:name/schedule": admin,
+
+	// Audit log API
+	"GET /api/v1/audit": admin,
+
+	// Bulk import and export of whole tables, including every user's email
+	"POST /api/v1/import/:table": admin,
+	"GET /api/v1/export/:table":  admin,
+
+	// Snapshot of the whole database
+	"GET /api/v1/backup": admin,
+}
@@ -0,0 +1,52 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"board-game-library/internal/jobs"
+	"board-game-library/internal/repositories"
+	"board-game-library/internal/services"
+	"board-game-library/pkg/database"
+)
+
+func TestAccessRulesCoverEveryRoute(t *testing.T) {
+	db, err := database.InitializeForTesting()
+	if err != nil {
+		t.Fatalf("Failed to initialize test database: %v", err)
+	}
+	defer db.Close()
+
+	alertService := services.NewAlertService(
+		repositories.NewSQLiteAlertRepository(db),
+		repositories.NewSQLiteBorrowingRepository(db),
+		repositories.NewSQLiteUserRepository(db),
+		repositories.NewSQLiteGameRepository(db),
+	)
+	jobManager, err := jobs.NewManager(alertService, jobs.DefaultConfig())
+	if err != nil {
+		t.Fatalf("Failed to create job manager: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if err := SetupRoutes(router, db, jobManager, nil); err != nil {
+		t.Fatalf("SetupRoutes() error = %v", err)
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		if _, ok := accessRules[key]; !ok {
+			t.Errorf("Route %q has no access rule", key)
+		}
+	}
+
+	for key := range accessRules {
+		if !registered[key] {
+			t.Errorf("Access rule %q does not match any route", key)
+		}
+	}
+}
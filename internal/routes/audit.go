@@ -0,0 +1,299 @@
+package routes
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"board-game-library/internal/handlers"
+	"board-game-library/internal/models"
+	"board-game-library/internal/services"
+)
+
+// auditActionLabels names the audit log actions in French
+var auditActionLabels = map[string]string{
+	models.AuditActionCreate:      "Création",
+	models.AuditActionUpdate:      "Modification",
+	models.AuditActionDelete:      "Suppression",
+	models.AuditActionBorrow:      "Emprunt",
+	models.AuditActionReturn:      "Retour",
+	models.AuditActionExtend:      "Prolongation",
+	models.AuditActionMarkRead:    "Lecture",
+	models.AuditActionCancel:      "Annulation",
+	models.AuditActionExpire:      "Expiration",
+	models.AuditActionCleanup:     "Nettoyage",
+	models.AuditActionSetRole:     "Changement de rôle",
+	models.AuditActionSetPassword: "Changement de mot de passe",
+	models.AuditActionRevoke:      "Révocation",
+	models.AuditActionMerge:       "Fusion",
+	models.AuditActionImport:      "Import",
+	models.AuditActionPay:         "Paiement",
+	models.AuditActionWaive:       "Remise",
+}
+
+// auditEntityLabels names the audited entity types in French
+var auditEntityLabels = map[string]string{
+	models.AuditEntityGame:        "Jeu",
+	models.AuditEntityGameCopy:    "Exemplaire",
+	models.AuditEntityUser:        "Utilisateur",
+	models.AuditEntityBorrowing:   "Emprunt",
+	models.AuditEntityAlert:       "Alerte",
+	models.AuditEntityReservation: "Réservation",
+	models.AuditEntityLoanPolicy:  "Politique de prêt",
+	models.AuditEntityAPIToken:    "Jeton d'API",
+	models.AuditEntityTag:         "Étiquette",
+	models.AuditEntityFine:        "Amende",
+}
+
+// auditActionOrder and auditEntityOrder list the filter choices in display order
+var (
+	auditActionOrder = []string{
+		models.AuditActionCreate, models.AuditActionUpdate, models.AuditActionDelete,
+		models.AuditActionBorrow, models.AuditActionReturn, models.AuditActionExtend,
+		models.AuditActionMarkRead, models.AuditActionCancel, models.AuditActionExpire,
+		models.AuditActionCleanup, models.AuditActionSetRole, models.AuditActionSetPassword,
+		models.AuditActionRevoke, models.AuditActionMerge, models.AuditActionImport,
+		models.AuditActionPay, models.AuditActionWaive,
+	}
+	auditEntityOrder = []string{
+		models.AuditEntityGame, models.AuditEntityGameCopy, models.AuditEntityUser,
+		models.AuditEntityBorrowing, models.AuditEntityAlert, models.AuditEntityReservation,
+		models.AuditEntityLoanPolicy, models.AuditEntityAPIToken, models.AuditEntityTag,
+		models.AuditEntityFine,
+	}
+)
+
+// setupAuditWebRoutes configures the audit log page
+func setupAuditWebRoutes(router *gin.Engine, auditService *services.AuditService) {
+	router.GET("/audit", func(c *gin.Context) {
+		filter, err := handlers.AuditFilterFromQuery(c)
+		if err != nil {
+			renderAuditPage(c, http.StatusBadRequest, filter, nil, 0, err.Error())
+			return
+		}
+
+		events, total, err := auditService.ListEvents(c.Request.Context(), filter)
+		if err != nil {
+			renderAuditPage(c, http.StatusInternalServerError, filter, nil, 0,
+				fmt.Sprintf("Échec du chargement du journal : %s", err.Error()))
+			return
+		}
+
+		renderAuditPage(c, http.StatusOK, filter, events, total, "")
+	})
+}
+
+// renderAuditPage renders the audit log with its filter form and pagination
+func renderAuditPage(c *gin.Context, status int, filter models.AuditFilter, events []*models.AuditEvent, total int, errorMessage string) {
+	actionOptions := ""
+	for _, action := range auditActionOrder {
+		actionOptions += auditOption(action, auditActionLabels[action], filter.Action)
+	}
+	entityOptions := ""
+	for _, entity := range auditEntityOrder {
+		entityOptions += auditOption(entity, auditEntityLabels[entity], filter.EntityType)
+	}
+
+	actorID := ""
+	if filter.ActorID != nil {
+		actorID = strconv.Itoa(*filter.ActorID)
+	}
+
+	eventsHTML := `<p class="text-gray-500 text-center py-8">Aucun événement ne correspond à ces critères.</p>`
+	if len(events) > 0 {
+		eventsHTML = `
+				<table class="min-w-full divide-y divide-gray-200">
+					<thead class="bg-gray-50">
+						<tr>
+							<th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase">Date</th>
+							<th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase">Auteur</th>
+							<th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase">Action</th>
+							<th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase">Élément</th>
+							<th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase">Détails</th>
+						</tr>
+					</thead>
+					<tbody class="divide-y divide-gray-200">`
+		for _, event := range events {
+			actor := html.EscapeString(event.ActorName)
+			if event.ActorID != nil {
+				actor = fmt.Sprintf(`%s <span class="text-xs text-gray-400">#%d</span>`, actor, *event.ActorID)
+			}
+
+			entity := labelOr(auditEntityLabels, event.EntityType)
+			if event.EntityID != "" {
+				entity += " " + event.EntityID
+			}
+
+			eventsHTML += fmt.Sprintf(`
+						<tr class="align-top">
+							<td class="px-4 py-2 text-sm text-gray-500 whitespace-nowrap">%s</td>
+							<td class="px-4 py-2">%s</td>
+							<td class="px-4 py-2">%s</td>
+							<td class="px-4 py-2">%s</td>
+							<td class="px-4 py-2 text-xs">%s</td>
+						</tr>`, event.CreatedAt.Local().Format("2006-01-02 15:04:05"), actor,
+				html.EscapeString(labelOr(auditActionLabels, event.Action)), html.EscapeString(entity),
+				auditStates(event))
+		}
+		eventsHTML += `
+					</tbody>
+				</table>`
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(status, `
+<!DOCTYPE html>
+<html lang="fr">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Journal d'audit - Bibliothèque de Jeux de Société</title>
+    <script src="https://cdn.tailwindcss.com"></script>
+</head>
+<body class="bg-gray-100">
+    <div class="container mx-auto px-4 py-8">
+        <div class="max-w-6xl mx-auto space-y-6">
+            <!-- En-tête -->
+            <div class="bg-white rounded-lg shadow-lg p-6">
+                <div class="flex justify-between items-center">
+                    <h1 class="text-3xl font-bold text-slate-700">Journal d'audit</h1>
+                    <a href="/" class="bg-gray-500 hover:bg-gray-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
+                </div>
+                <p class="text-gray-600 mt-2">Événements correspondants : %d</p>
+                <p class="text-sm text-gray-500 mt-1">Chaque modification est enregistrée avec son auteur et l'état de l'élément avant et après. Le journal ne peut être ni modifié ni effacé.</p>
+            </div>
+
+            <!-- Filtres -->
+            <div class="bg-white rounded-lg shadow-lg p-6">
+                %s
+                <form action="/audit" method="GET" class="grid grid-cols-1 md:grid-cols-3 gap-4">
+                    <div>
+                        <label for="action" class="block text-sm font-medium text-gray-700 mb-1">Action</label>
+                        <select id="action" name="action" class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                            <option value="">Toutes</option>
+                            %s
+                        </select>
+                    </div>
+                    <div>
+                        <label for="entity_type" class="block text-sm font-medium text-gray-700 mb-1">Type d'élément</label>
+                        <select id="entity_type" name="entity_type" class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                            <option value="">Tous</option>
+                            %s
+                        </select>
+                    </div>
+                    <div>
+                        <label for="entity_id" class="block text-sm font-medium text-gray-700 mb-1">Identifiant de l'élément</label>
+                        <input type="text" id="entity_id" name="entity_id" value="%s" class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                    </div>
+                    <div>
+                        <label for="actor_id" class="block text-sm font-medium text-gray-700 mb-1">ID de l'auteur</label>
+                        <input type="number" id="actor_id" name="actor_id" min="1" value="%s" class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                    </div>
+                    <div>
+                        <label for="since" class="block text-sm font-medium text-gray-700 mb-1">Du</label>
+                        <input type="date" id="since" name="since" value="%s" class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                    </div>
+                    <div>
+                        <label for="until" class="block text-sm font-medium text-gray-700 mb-1">Au</label>
+                        <input type="date" id="until" name="until" value="%s" class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                    </div>
+                    <div class="md:col-span-3 flex gap-2">
+                        <button type="submit" class="bg-slate-600 hover:bg-slate-700 text-white px-4 py-2 rounded">🔍 Filtrer</button>
+                        <a href="/audit" class="bg-gray-200 hover:bg-gray-300 text-gray-700 px-4 py-2 rounded">Réinitialiser</a>
+                    </div>
+                </form>
+            </div>
+
+            <!-- Événements -->
+            <div class="bg-white rounded-lg shadow-lg p-6 overflow-x-auto">
+                %s
+                %s
+            </div>
+
+            <!-- Informations API -->
+            <div class="bg-gray-50 rounded-lg p-4 text-center">
+                <h3 class="text-lg font-semibold mb-2">Accès API</h3>
+                <div class="space-x-4 text-sm">
+                    <a href="/api/v1/audit" class="text-blue-600 hover:underline">Voir JSON</a>
+                    <span class="text-gray-400">|</span>
+                    <span class="text-gray-500">Mêmes filtres disponibles sur /api/v1/audit</span>
+                </div>
+            </div>
+        </div>
+    </div>
+</body>
+</html>`, total, errorBlock(errorMessage), actionOptions, entityOptions,
+		html.EscapeString(filter.EntityID), actorID,
+		html.EscapeString(c.Query("since")), html.EscapeString(c.Query("until")),
+		eventsHTML, auditPagination(c, filter, total))
+}
+
+// auditOption renders a filter choice, selected when it is the current value
+func auditOption(value, label, current string) string {
+	selected := ""
+	if value == current {
+		selected = " selected"
+	}
+	return fmt.Sprintf(`<option value="%s"%s>%s</option>`, html.EscapeString(value), selected, html.EscapeString(label))
+}
+
+// auditStates renders the before and after states of an event as collapsible JSON
+func auditStates(event *models.AuditEvent) string {
+	states := ""
+	if event.Before != nil {
+		states += fmt.Sprintf(`<details><summary class="cursor-pointer text-gray-600">Avant</summary><pre class="bg-gray-50 p-2 rounded whitespace-pre-wrap break-all">%s</pre></details>`,
+			html.EscapeString(string(event.Before)))
+	}
+	if event.After != nil {
+		states += fmt.Sprintf(`<details><summary class="cursor-pointer text-gray-600">Après</summary><pre class="bg-gray-50 p-2 rounded whitespace-pre-wrap break-all">%s</pre></details>`,
+			html.EscapeString(string(event.After)))
+	}
+	if states == "" {
+		return `<span class="text-gray-400">—</span>`
+	}
+	return states
+}
+
+// auditPagination renders links to the previous and next pages, keeping the filters
+func auditPagination(c *gin.Context, filter models.AuditFilter, total int) string {
+	pageLink := func(offset int, label string) string {
+		query := url.Values{}
+		for key, values := range c.Request.URL.Query() {
+			query[key] = values
+		}
+		query.Set("offset", strconv.Itoa(offset))
+		return fmt.Sprintf(`<a href="/audit?%s" class="text-blue-600 hover:underline">%s</a>`, html.EscapeString(query.Encode()), label)
+	}
+
+	links := ""
+	if filter.Offset > 0 {
+		previous := filter.Offset - filter.Limit
+		if previous < 0 {
+			previous = 0
+		}
+		links += pageLink(previous, "← Plus récents")
+	}
+	if filter.Offset+filter.Limit < total {
+		if links != "" {
+			links += ` <span class="text-gray-400">|</span> `
+		}
+		links += pageLink(filter.Offset+filter.Limit, "Plus anciens →")
+	}
+	if links == "" {
+		return ""
+	}
+
+	return `<div class="mt-4 text-center text-sm">` + links + `</div>`
+}
+
+// labelOr returns the label registered for a key, or the key itself when unknown
+func labelOr(labels map[string]string, key string) string {
+	if label, ok := labels[key]; ok {
+		return label
+	}
+	return key
+}
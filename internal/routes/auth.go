@@ -0,0 +1,336 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"board-game-library/internal/handlers"
+	"board-game-library/internal/models"
+	"board-game-library/internal/services"
+)
+
+// roleLabels gives the French name of each role
+var roleLabels = map[string]string{
+	models.RoleMember:    "Membre",
+	models.RoleLibrarian: "Bibliothécaire",
+	models.RoleAdmin:     "Administrateur",
+}
+
+// setupAuthWebRoutes configures the sign-in, first-run setup and account pages
+func setupAuthWebRoutes(router *gin.Engine, authService *services.AuthService, cookie handlers.SessionCookie, userService *services.UserService, alertService *services.AlertService, gameService *services.GameService, notificationService *services.NotificationService) {
+	// Sign-in page
+	router.GET("/login", func(c *gin.Context) {
+		if required, err := authService.SetupRequired(c.Request.Context()); err == nil && required {
+			c.Redirect(http.StatusSeeOther, "/setup")
+			return
+		}
+		renderLoginPage(c, http.StatusOK, c.Query("next"), "", "")
+	})
+
+	router.POST("/login", func(c *gin.Context) {
+		email := strings.TrimSpace(c.PostForm("email"))
+		next := c.PostForm("next")
+
+		token, _, err := authService.Login(c.Request.Context(), email, c.PostForm("password"))
+		if err != nil {
+			message := "Email ou mot de passe incorrect."
+			if errors.Is(err, models.ErrForbidden) {
+				message = "Ce compte est désactivé."
+			}
+			renderLoginPage(c, http.StatusUnauthorized, next, email, message)
+			return
+		}
+
+		cookie.Set(c, token)
+		c.Redirect(http.StatusSeeOther, safeRedirect(next))
+	})
+
+	// First-run setup: create the initial administrator
+	router.GET("/setup", func(c *gin.Context) {
+		if required, err := authService.SetupRequired(c.Request.Context()); err != nil || !required {
+			c.Redirect(http.StatusSeeOther, "/login")
+			return
+		}
+		renderSetupPage(c, http.StatusOK, "", "", "")
+	})
+
+	router.POST("/setup", func(c *gin.Context) {
+		name := strings.TrimSpace(c.PostForm("name"))
+		email := strings.TrimSpace(c.PostForm("email"))
+		password := c.PostForm("password")
+
+		if password != c.PostForm("password_confirm") {
+			renderSetupPage(c, http.StatusBadRequest, name, email, "Les mots de passe ne correspondent pas.")
+			return
+		}
+
+		if _, err := authService.CreateFirstAdmin(c.Request.Context(), name, email, password); err != nil {
+			if domainErr, ok := models.AsError(err); ok && domainErr.Code == "setup_completed" {
+				c.Redirect(http.StatusSeeOther, "/login")
+				return
+			}
+			renderSetupPage(c, handlers.ErrorStatus(err), name, email, fmt.Sprintf("Échec de la création du compte : %s", err.Error()))
+			return
+		}
+
+		token, _, err := authService.Login(c.Request.Context(), email, password)
+		if err != nil {
+			c.Redirect(http.StatusSeeOther, "/login")
+			return
+		}
+
+		cookie.Set(c, token)
+		c.Redirect(http.StatusSeeOther, "/")
+	})
+
+	// Sign out
+	router.POST("/logout", func(c *gin.Context) {
+		authService.Logout(c.Request.Context(), cookie.Token(c))
+		cookie.Clear(c)
+		c.Redirect(http.StatusSeeOther, "/login")
+	})
+
+	// Account page: the signed-in user's own loans and alerts
+	router.GET("/account", func(c *gin.Context) {
+		user := handlers.CurrentUser(c)
+		if user == nil {
+			c.Redirect(http.StatusSeeOther, "/login")
+			return
+		}
+		renderAccountPage(c, http.StatusOK, user, userService, alertService, gameService, notificationService, "")
+	})
+
+	router.POST("/account/password", func(c *gin.Context) {
+		user := handlers.CurrentUser(c)
+		if user == nil {
+			c.Redirect(http.StatusSeeOther, "/login")
+			return
+		}
+
+		if c.PostForm("password") != c.PostForm("password_confirm") {
+			renderAccountPage(c, http.StatusBadRequest, user, userService, alertService, gameService, notificationService,
+				"Les nouveaux mots de passe ne correspondent pas.")
+			return
+		}
+
+		err := authService.WithActor(handlers.CurrentUser(c)).ChangePassword(c.Request.Context(), user.ID, c.PostForm("current_password"), c.PostForm("password"))
+		if err != nil {
+			message := fmt.Sprintf("Échec du changement de mot de passe : %s", err.Error())
+			if errors.Is(err, models.ErrUnauthorized) {
+				message = "Le mot de passe actuel est incorrect."
+			}
+			renderAccountPage(c, handlers.ErrorStatus(err), user, userService, alertService, gameService, notificationService, message)
+			return
+		}
+
+		// Changing the password signs out every session, including this one
+		cookie.Clear(c)
+		c.Redirect(http.StatusSeeOther, "/login")
+	})
+
+	// Email alerts: opt out and choice of language
+	router.POST("/account/notifications", func(c *gin.Context) {
+		user := handlers.CurrentUser(c)
+		if user == nil {
+			c.Redirect(http.StatusSeeOther, "/login")
+			return
+		}
+
+		preferences := &models.NotificationPreferences{
+			UserID:       user.ID,
+			EmailEnabled: c.PostForm("email_enabled") == "on",
+			Language:     c.PostForm("language"),
+		}
+		if err := notificationService.UpdatePreferences(c.Request.Context(), preferences); err != nil {
+			renderAccountPage(c, http.StatusBadRequest, user, userService, alertService, gameService, notificationService,
+				fmt.Sprintf("Échec de l'enregistrement des notifications : %s", err.Error()))
+			return
+		}
+
+		c.Redirect(http.StatusSeeOther, "/account")
+	})
+}
+
+// safeRedirect returns next when it is a local path, and "/" otherwise
+func safeRedirect(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.Contains(next, "\\") {
+		return "/"
+	}
+	return next
+}
+
+// renderLoginPage renders the sign-in form
+func renderLoginPage(c *gin.Context, status int, next, email, errorMessage string) {
+	renderAuthPage(c, status, "Connexion", fmt.Sprintf(`
+            <h1 class="text-2xl font-bold text-blue-600 mb-6">Connexion</h1>
+            %s
+            <form action="/login" method="POST" class="space-y-4">
+                <input type="hidden" name="next" value="%s">
+                <div>
+                    <label class="block text-sm font-medium text-gray-700 mb-1">Email</label>
+                    <input type="email" name="email" value="%s" required class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700 mb-1">Mot de passe</label>
+                    <input type="password" name="password" required class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                </div>
+                <button type="submit" class="w-full bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">Se connecter</button>
+            </form>`, errorBlock(errorMessage), html.EscapeString(next), html.EscapeString(email)))
+}
+
+// renderSetupPage renders the form creating the first administrator
+func renderSetupPage(c *gin.Context, status int, name, email, errorMessage string) {
+	renderAuthPage(c, status, "Configuration initiale", fmt.Sprintf(`
+            <h1 class="text-2xl font-bold text-blue-600 mb-2">Configuration initiale</h1>
+            <p class="text-gray-600 mb-6">Créez le compte administrateur de la bibliothèque.</p>
+            %s
+            <form action="/setup" method="POST" class="space-y-4">
+                <div>
+                    <label class="block text-sm font-medium text-gray-700 mb-1">Nom complet</label>
+                    <input type="text" name="name" value="%s" required class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700 mb-1">Email</label>
+                    <input type="email" name="email" value="%s" required class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700 mb-1">Mot de passe (%d caractères minimum)</label>
+                    <input type="password" name="password" required minlength="%d" class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                </div>
+                <div>
+                    <label class="block text-sm font-medium text-gray-700 mb-1">Confirmer le mot de passe</label>
+                    <input type="password" name="password_confirm" required class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                </div>
+                <button type="submit" class="w-full bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">Créer l'administrateur</button>
+            </form>`, errorBlock(errorMessage), html.EscapeString(name), html.EscapeString(email),
+		models.MinPasswordLength, models.MinPasswordLength))
+}
+
+// renderAccountPage renders the signed-in user's loans, alerts, notification
+// preferences and password form
+func renderAccountPage(c *gin.Context, status int, user *models.User, userService *services.UserService, alertService *services.AlertService, gameService *services.GameService, notificationService *services.NotificationService, errorMessage string) {
+	ctx := c.Request.Context()
+	gameNames := make(map[int]string)
+	if games, err := gameService.GetAllGames(ctx); err == nil {
+		for _, game := range games {
+			gameNames[game.ID] = game.Name
+		}
+	}
+
+	loansHTML := `<p class="text-gray-500">Aucun emprunt en cours.</p>`
+	if loans, err := userService.GetActiveUserBorrowings(ctx, user.ID); err != nil {
+		loansHTML = fmt.Sprintf(`<p class="text-red-600">Échec du chargement des emprunts : %s</p>`, html.EscapeString(err.Error()))
+	} else if len(loans) > 0 {
+		loansHTML = `<ul class="divide-y divide-gray-200">`
+		for _, loan := range loans {
+			dueColor := "text-gray-600"
+			if loan.IsOverdue {
+				dueColor = "text-red-600 font-semibold"
+			}
+			loansHTML += fmt.Sprintf(`
+                    <li class="py-2 flex justify-between"><span>%s</span><span class="%s">À rendre le %s</span></li>`,
+				html.EscapeString(nameOrID(gameNames, loan.GameID)), dueColor, loan.DueDate.Format("02/01/2006"))
+		}
+		loansHTML += `</ul>`
+	}
+
+	alertsHTML := `<p class="text-gray-500">Aucune alerte.</p>`
+	if alerts, err := alertService.GetAlertsByUser(ctx, user.ID); err != nil {
+		alertsHTML = fmt.Sprintf(`<p class="text-red-600">Échec du chargement des alertes : %s</p>`, html.EscapeString(err.Error()))
+	} else if len(alerts) > 0 {
+		alertsHTML = `<ul class="divide-y divide-gray-200">`
+		for _, alert := range alerts {
+			alertsHTML += fmt.Sprintf(`
+                    <li class="py-2"><span class="text-xs text-gray-400">%s</span> %s</li>`,
+				alert.CreatedAt.Format("02/01/2006"), html.EscapeString(alert.Message))
+		}
+		alertsHTML += `</ul>`
+	}
+
+	preferences, err := notificationService.GetPreferences(ctx, user.ID)
+	if err != nil {
+		preferences = &models.NotificationPreferences{UserID: user.ID, EmailEnabled: true}
+	}
+	emailChecked := ""
+	if preferences.EmailEnabled {
+		emailChecked = " checked"
+	}
+	languageOptions := ""
+	for _, option := range []struct{ value, label string }{
+		{"", "Langue de la bibliothèque"},
+		{models.LanguageFrench, "Français"},
+		{models.LanguageEnglish, "English"},
+	} {
+		selected := ""
+		if preferences.Language == option.value {
+			selected = " selected"
+		}
+		languageOptions += fmt.Sprintf(`<option value="%s"%s>%s</option>`, option.value, selected, option.label)
+	}
+
+	renderAuthPage(c, status, "Mon compte", fmt.Sprintf(`
+            <div class="flex justify-between items-start mb-6">
+                <div>
+                    <h1 class="text-2xl font-bold text-blue-600">%s</h1>
+                    <p class="text-gray-600">%s · %s</p>
+                </div>
+                <form action="/logout" method="POST">
+                    <button type="submit" class="bg-gray-500 hover:bg-gray-600 text-white px-3 py-1 rounded text-sm">Se déconnecter</button>
+                </form>
+            </div>
+            %s
+            <h2 class="text-lg font-semibold mb-2">Mes emprunts</h2>
+            <div class="mb-6">%s</div>
+            <h2 class="text-lg font-semibold mb-2">Mes alertes</h2>
+            <div class="mb-6">%s</div>
+            <h2 class="text-lg font-semibold mb-2">Notifications</h2>
+            <form action="/account/notifications" method="POST" class="space-y-3 mb-6">
+                <label class="flex items-center gap-2"><input type="checkbox" name="email_enabled"%s> Recevoir les alertes par email</label>
+                <select name="language" class="w-full px-3 py-2 border border-gray-300 rounded-md">%s</select>
+                <button type="submit" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">Enregistrer</button>
+            </form>
+            <h2 class="text-lg font-semibold mb-2">Changer de mot de passe</h2>
+            <form action="/account/password" method="POST" class="space-y-3">
+                <input type="password" name="current_password" placeholder="Mot de passe actuel" required class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                <input type="password" name="password" placeholder="Nouveau mot de passe" required minlength="%d" class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                <input type="password" name="password_confirm" placeholder="Confirmer le nouveau mot de passe" required class="w-full px-3 py-2 border border-gray-300 rounded-md">
+                <button type="submit" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">Modifier</button>
+            </form>
+            <a href="/" class="inline-block mt-6 text-blue-600 hover:underline">← Retour à l'accueil</a>`,
+		html.EscapeString(user.Name), html.EscapeString(user.Email), roleLabels[user.Role],
+		errorBlock(errorMessage), loansHTML, alertsHTML, emailChecked, languageOptions, models.MinPasswordLength))
+}
+
+// errorBlock renders an error message box, or nothing when message is empty
+func errorBlock(message string) string {
+	if message == "" {
+		return ""
+	}
+	return fmt.Sprintf(`<div class="bg-red-50 border border-red-200 text-red-700 px-4 py-3 rounded mb-4">%s</div>`, html.EscapeString(message))
+}
+
+// renderAuthPage wraps the content of a sign-in or account page
+func renderAuthPage(c *gin.Context, status int, title, content string) {
+	c.Header("Content-Type", "text/html")
+	c.String(status, `
+<!DOCTYPE html>
+<html lang="fr">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>%s - Bibliothèque de Jeux de Société</title>
+    <script src="https://cdn.tailwindcss.com"></script>
+</head>
+<body class="bg-gray-100">
+    <div class="container mx-auto px-4 py-16">
+        <div class="max-w-md mx-auto bg-white rounded-lg shadow-lg p-8">%s
+        </div>
+    </div>
+</body>
+</html>`, title, content)
+}
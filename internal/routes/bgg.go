@@ -0,0 +1,147 @@
+package routes
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"board-game-library/internal/handlers"
+	"board-game-library/internal/models"
+	"board-game-library/internal/services"
+	"board-game-library/pkg/bgg"
+)
+
+// bggSearchForm renders the form looking up games on BoardGameGeek
+const bggSearchForm = `
+                <form action="/games/bgg" method="GET" class="flex gap-2 mb-4">
+                    <input type="text" name="q" value="%s" required
+                           class="flex-1 px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-blue-500"
+                           placeholder="Nom du jeu ou identifiant BoardGameGeek">
+                    <button type="submit" class="bg-orange-500 hover:bg-orange-600 text-white px-4 py-2 rounded">🌐 Chercher sur BoardGameGeek</button>
+                </form>`
+
+// setupBGGWebRoutes configures the pages importing games from BoardGameGeek
+func setupBGGWebRoutes(router *gin.Engine, gameService *services.GameService) {
+	// Search results, each with a form importing the game
+	router.GET("/games/bgg", func(c *gin.Context) {
+		query := strings.TrimSpace(c.Query("q"))
+		if query == "" {
+			renderBGGPage(c, http.StatusOK, query, nil, "")
+			return
+		}
+
+		results, err := gameService.SearchBGG(c.Request.Context(), query)
+		if err != nil {
+			renderBGGPage(c, handlers.ErrorStatus(err), query, nil, err.Error())
+			return
+		}
+		renderBGGPage(c, http.StatusOK, query, results, "")
+	})
+
+	// Import the chosen game
+	router.POST("/games/bgg/import", func(c *gin.Context) {
+		query := strings.TrimSpace(c.PostForm("q"))
+		bggID, err := strconv.Atoi(c.PostForm("bgg_id"))
+		if err != nil {
+			renderBGGPage(c, http.StatusBadRequest, query, nil, "Identifiant BoardGameGeek invalide")
+			return
+		}
+
+		game, err := gameService.WithActor(handlers.CurrentUser(c)).ImportBGGGame(c.Request.Context(), bggID, c.PostForm("condition"))
+		if err != nil {
+			renderBGGPage(c, handlers.ErrorStatus(err), query, nil, "Échec de l'import du jeu : "+err.Error())
+			return
+		}
+
+		c.Header("Content-Type", "text/html")
+		c.String(http.StatusOK, `
+<!DOCTYPE html>
+<html lang="fr">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Succès - Bibliothèque de Jeux de Société</title>
+    <script src="https://cdn.tailwindcss.com"></script>
+    <meta http-equiv="refresh" content="3;url=/games">
+</head>
+<body class="bg-gray-100">
+    <div class="container mx-auto px-4 py-8">
+        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
+            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Succès !</h1>
+            <p class="text-gray-600 mb-4">Le jeu "%s" a été importé depuis BoardGameGeek !</p>
+            <div class="bg-green-50 border border-green-200 rounded-lg p-4 mb-4 text-green-700">
+                <div class="mb-2">%s</div>
+                <div class="text-sm">%s</div>
+            </div>
+            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des jeux dans 3 secondes...</p>
+            <a href="/games" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Jeux</a>
+        </div>
+    </div>
+</body>
+</html>`, html.EscapeString(game.Name), gameTagLinks(game.Tags), gameDetailsSummary(game.GameDetails))
+	})
+}
+
+// renderBGGPage renders the BoardGameGeek search page with its results
+func renderBGGPage(c *gin.Context, status int, query string, results []bgg.SearchResult, errorMessage string) {
+	conditions := ""
+	for _, condition := range models.ValidConditions {
+		selected := ""
+		if condition == "good" {
+			selected = " selected"
+		}
+		conditions += fmt.Sprintf(`<option value="%s"%s>%s</option>`, condition, selected, labelOr(conditionLabels, condition))
+	}
+
+	resultsHTML := ""
+	if query != "" && errorMessage == "" && len(results) == 0 {
+		resultsHTML = `<p class="text-gray-500">Aucun jeu trouvé sur BoardGameGeek.</p>`
+	}
+	for _, result := range results {
+		year := ""
+		if result.YearPublished != 0 {
+			year = fmt.Sprintf(" (%d)", result.YearPublished)
+		}
+		resultsHTML += fmt.Sprintf(`
+                <div class="flex justify-between items-center bg-gray-50 p-4 rounded-lg border mb-2">
+                    <div>
+                        <span class="font-semibold">%s</span><span class="text-gray-500">%s</span>
+                        <a href="https://boardgamegeek.com/boardgame/%d" target="_blank" rel="noopener" class="ml-2 text-xs text-blue-600 hover:underline">Voir sur BoardGameGeek</a>
+                    </div>
+                    <form action="/games/bgg/import" method="POST" class="flex gap-2 items-center">
+                        <input type="hidden" name="bgg_id" value="%d">
+                        <input type="hidden" name="q" value="%s">
+                        <select name="condition" class="px-2 py-1 border border-gray-300 rounded-md text-sm">%s</select>
+                        <button type="submit" class="bg-green-500 hover:bg-green-600 text-white px-3 py-1 rounded text-sm">📥 Importer</button>
+                    </form>
+                </div>`, html.EscapeString(result.Name), year, result.ID, result.ID, html.EscapeString(query), conditions)
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(status, `
+<!DOCTYPE html>
+<html lang="fr">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Import BoardGameGeek - Bibliothèque de Jeux de Société</title>
+    <script src="https://cdn.tailwindcss.com"></script>
+</head>
+<body class="bg-gray-100">
+    <div class="container mx-auto px-4 py-8">
+        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
+            <div class="flex justify-between items-center mb-4">
+                <h1 class="text-3xl font-bold text-orange-600">🌐 Importer depuis BoardGameGeek</h1>
+                <a href="/games" class="bg-gray-500 hover:bg-gray-600 text-white px-4 py-2 rounded">Retour aux Jeux</a>
+            </div>
+            <p class="text-gray-600 mb-4">La description, le nombre de joueurs, la durée, l'année, l'image et les catégories du jeu sont repris de BoardGameGeek.</p>`+bggSearchForm+`
+            %s%s
+        </div>
+    </div>
+</body>
+</html>`, html.EscapeString(query), errorBlock(errorMessage), resultsHTML)
+}
@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"board-game-library/internal/models"
+	"board-game-library/internal/services"
+)
+
+// conditionLabels names the game conditions in French
+var conditionLabels = map[string]string{
+	"excellent":      "Excellent",
+	"good":           "Bon",
+	"fair":           "Correct",
+	"poor":           "Mauvais",
+	"missing_pieces": "Pièces manquantes",
+}
+
+// gameDetailsSummary describes the known metadata of a game in one line of
+// escaped HTML, or returns nothing when all of it is unknown
+func gameDetailsSummary(details models.GameDetails) string {
+	var parts []string
+
+	switch {
+	case details.MinPlayers > 0 && details.MaxPlayers > details.MinPlayers:
+		parts = append(parts, fmt.Sprintf("👥 %d à %d joueurs", details.MinPlayers, details.MaxPlayers))
+	case details.MinPlayers > 0 && details.MaxPlayers == details.MinPlayers:
+		parts = append(parts, fmt.Sprintf("👥 %d joueur%s", details.MinPlayers, plural(details.MinPlayers)))
+	case details.MinPlayers > 0:
+		parts = append(parts, fmt.Sprintf("👥 %d joueur%s et plus", details.MinPlayers, plural(details.MinPlayers)))
+	case details.MaxPlayers > 0:
+		parts = append(parts, fmt.Sprintf("👥 jusqu'à %d joueurs", details.MaxPlayers))
+	}
+	if details.PlayTime > 0 {
+		parts = append(parts, fmt.Sprintf("⏱️ %d min", details.PlayTime))
+	}
+	if details.MinAge > 0 {
+		parts = append(parts, fmt.Sprintf("🎂 %d+", details.MinAge))
+	}
+	if details.Complexity > 0 {
+		parts = append(parts, "Complexité "+strconv.FormatFloat(details.Complexity, 'f', 1, 64)+"/5")
+	}
+
+	edition := html.EscapeString(details.Publisher)
+	if details.YearPublished != 0 {
+		year := strconv.Itoa(details.YearPublished)
+		if details.YearPublished < 0 {
+			year = strconv.Itoa(-details.YearPublished) + " av. J.-C."
+		}
+		if edition != "" {
+			edition += " (" + year + ")"
+		} else {
+			edition = year
+		}
+	}
+	if edition != "" {
+		parts = append(parts, edition)
+	}
+	if len(details.Designers) > 0 {
+		parts = append(parts, "Par "+html.EscapeString(strings.Join(details.Designers, ", ")))
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// plural returns the French plural mark for count
+func plural(count int) string {
+	if count > 1 {
+		return "s"
+	}
+	return ""
+}
+
+// gameLabel names a game followed by its tags, if any
+func gameLabel(game *models.Game) string {
+	if len(game.Tags) == 0 {
+		return game.Name
+	}
+	return fmt.Sprintf("%s (%s)", game.Name, strings.Join(game.Tags, ", "))
+}
+
+// gameTagLinks renders the tags of a game as escaped links to the games
+// having that tag, or returns nothing for an untagged game
+func gameTagLinks(tags []string) string {
+	links := ""
+	for _, tag := range tags {
+		links += fmt.Sprintf(`<a href="/games?tag=%s" class="inline-block mr-1 mb-1 px-2 py-0.5 rounded-full bg-blue-100 text-blue-700 text-xs hover:bg-blue-200">%s</a>`,
+			url.QueryEscape(models.TagSlug(tag)), html.EscapeString(tag))
+	}
+	return links
+}
+
+// setupTagWebRoutes configures the tag suggestions of the game form
+func setupTagWebRoutes(router *gin.Engine, tagService *services.TagService) {
+	// Options completing the last tag typed in a comma-separated tags input
+	router.GET("/tags/suggest", func(c *gin.Context) {
+		c.Header("Content-Type", "text/html")
+		c.String(http.StatusOK, tagSuggestions(c.Request.Context(), c.Query("tags"), tagService))
+	})
+}
+
+// tagSuggestions lists, as escaped datalist options, the known tags
+// completing the last tag of typed. Each option repeats the tags typed
+// before it so that picking one keeps them.
+func tagSuggestions(ctx context.Context, typed string, tagService *services.TagService) string {
+	prefix, last := "", typed
+	if i := strings.LastIndex(typed, ","); i >= 0 {
+		prefix, last = strings.TrimSpace(typed[:i+1])+" ", typed[i+1:]
+	}
+	if strings.TrimSpace(last) == "" {
+		return ""
+	}
+
+	tags, err := tagService.ListTags(ctx, last, models.DefaultTagLimit)
+	if err != nil {
+		return ""
+	}
+
+	options := ""
+	for _, tag := range tags {
+		options += fmt.Sprintf(`<option value="%s">`, html.EscapeString(prefix+tag.Name))
+	}
+	return options
+}
@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"strings"
+	"testing"
+
+	"board-game-library/internal/models"
+)
+
+func TestGameDetailsSummary(t *testing.T) {
+	tests := []struct {
+		details models.GameDetails
+		want    string
+	}{
+		{models.GameDetails{}, ""},
+		{
+			models.GameDetails{MinPlayers: 2, MaxPlayers: 4, PlayTime: 45, MinAge: 8, Publisher: "Plan B", YearPublished: 2017, Designers: []string{"Michael Kiesling"}},
+			"👥 2 à 4 joueurs · ⏱️ 45 min · 🎂 8+ · Plan B (2017) · Par Michael Kiesling",
+		},
+		{models.GameDetails{MinPlayers: 1, MaxPlayers: 1, Complexity: 2.25}, "👥 1 joueur · Complexité 2.2/5"},
+		{models.GameDetails{MinPlayers: 3, YearPublished: -2200}, "👥 3 joueurs et plus · 2200 av. J.-C."},
+		{models.GameDetails{Publisher: "<Lookout>"}, "&lt;Lookout&gt;"},
+	}
+
+	for _, tt := range tests {
+		if got := gameDetailsSummary(tt.details); got != tt.want {
+			t.Errorf("gameDetailsSummary(%+v) = %q, want %q", tt.details, got, tt.want)
+		}
+	}
+}
+
+func TestGameTagLinks(t *testing.T) {
+	got := gameTagLinks([]string{"Coopératif", "<Bluff>"})
+	for _, want := range []string{`href="/games?tag=cooperatif"`, ">Coopératif</a>", `href="/games?tag=bluff"`, ">&lt;Bluff&gt;</a>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("gameTagLinks() = %q, want it to contain %q", got, want)
+		}
+	}
+	if got := gameTagLinks(nil); got != "" {
+		t.Errorf("gameTagLinks(nil) = %q, want nothing", got)
+	}
+}
+
+func TestGameLabel(t *testing.T) {
+	if got := gameLabel(&models.Game{Name: "Hanabi", Tags: []string{"Cartes", "Coopératif"}}); got != "Hanabi (Cartes, Coopératif)" {
+		t.Errorf("gameLabel() = %q", got)
+	}
+	if got := gameLabel(&models.Game{Name: "Hanabi"}); got != "Hanabi" {
+		t.Errorf("gameLabel() = %q", got)
+	}
+}
@@ -0,0 +1,193 @@
+package routes
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"board-game-library/internal/config"
+	"board-game-library/internal/handlers"
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"board-game-library/internal/services"
+	"board-game-library/pkg/database"
+)
+
+// LibraryCookieName is the cookie remembering the library last selected by
+// a path prefix or a subdomain, so that the absolute links and redirects of
+// its pages stay in the same library
+const LibraryCookieName = "bgl_library"
+
+// LibraryRouter serves each library of the deployment with its own router,
+// whose repositories only see the rows of that library. The library of a
+// request is, in order:
+//
+//   - the slug after the path prefix, as in /l/<slug>/games, which is
+//     stripped before routing
+//   - the subdomain of the base domain, as in <slug>.ludo.example.org
+//   - the library of the signed in user, from the session cookie or the
+//     API token
+//   - the library remembered by the library cookie
+//   - the default library
+type LibraryRouter struct {
+	db        *database.DB
+	libraries *services.LibraryService
+	config    config.LibrariesConfig
+	newRouter func(db *database.DB) (http.Handler, error)
+
+	mu      sync.Mutex
+	routers map[int]http.Handler
+}
+
+// NewLibraryRouter creates a LibraryRouter. newRouter builds the router of a
+// library from the database scoped to it; routers are built on first use.
+func NewLibraryRouter(db *database.DB, cfg config.LibrariesConfig, newRouter func(db *database.DB) (http.Handler, error)) *LibraryRouter {
+	return &LibraryRouter{
+		db:        db,
+		libraries: services.NewLibraryService(repositories.NewSQLiteLibraryRepository(db)),
+		config:    cfg,
+		newRouter: newRouter,
+		routers:   make(map[int]http.Handler),
+	}
+}
+
+// ServeHTTP dispatches a request to the router of its library
+func (r *LibraryRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	library, remember, ok := r.resolve(req)
+	if !ok {
+		http.Error(w, "Bibliothèque introuvable", http.StatusNotFound)
+		return
+	}
+
+	router, err := r.router(library.ID)
+	if err != nil {
+		http.Error(w, "Failed to load library: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if remember {
+		http.SetCookie(w, &http.Cookie{
+			Name:     LibraryCookieName,
+			Value:    library.Slug,
+			Path:     "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+
+	router.ServeHTTP(w, req)
+}
+
+// resolve returns the library of a request, whether it was named by the
+// request URL and should be remembered, and false when the URL names an
+// unknown library. A path prefix is removed from the request.
+func (r *LibraryRouter) resolve(req *http.Request) (*models.Library, bool, bool) {
+	if slug, rest, found := r.pathLibrary(req.URL.Path); found {
+		library, err := r.libraries.GetLibraryBySlug(req.Context(), slug)
+		if err != nil {
+			return nil, false, false
+		}
+		req.URL.Path = rest
+		req.URL.RawPath = ""
+		return library, true, true
+	}
+
+	if slug, found := r.hostLibrary(req.Host); found {
+		library, err := r.libraries.GetLibraryBySlug(req.Context(), slug)
+		if err != nil {
+			return nil, false, false
+		}
+		return library, true, true
+	}
+
+	if library := r.userLibrary(req); library != nil {
+		return library, false, true
+	}
+
+	if cookie, err := req.Cookie(LibraryCookieName); err == nil {
+		if library, err := r.libraries.GetLibraryBySlug(req.Context(), cookie.Value); err == nil {
+			return library, false, true
+		}
+	}
+
+	return &models.Library{ID: database.DefaultLibraryID}, false, true
+}
+
+// pathLibrary splits a path such as /l/<slug>/games into the slug and the
+// path within the library
+func (r *LibraryRouter) pathLibrary(path string) (string, string, bool) {
+	if r.config.PathPrefix == "" {
+		return "", "", false
+	}
+
+	rest, found := strings.CutPrefix(path, r.config.PathPrefix+"/")
+	if !found || rest == "" {
+		return "", "", false
+	}
+
+	slug, rest, _ := strings.Cut(rest, "/")
+	return slug, "/" + rest, true
+}
+
+// hostLibrary returns the slug of a host such as <slug>.ludo.example.org
+func (r *LibraryRouter) hostLibrary(host string) (string, bool) {
+	if r.config.BaseDomain == "" {
+		return "", false
+	}
+
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	slug, found := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(r.config.BaseDomain))
+	if !found || slug == "" || strings.Contains(slug, ".") {
+		return "", false
+	}
+
+	return slug, true
+}
+
+// userLibrary returns the library of the user authenticated by the request,
+// or nil when it carries no valid credentials
+func (r *LibraryRouter) userLibrary(req *http.Request) *models.Library {
+	if header := req.Header.Get("Authorization"); header != "" {
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found {
+			return nil
+		}
+		library, err := r.libraries.LibraryForAPIToken(req.Context(), strings.TrimSpace(token))
+		if err != nil {
+			return nil
+		}
+		return library
+	}
+
+	cookie, err := req.Cookie(handlers.DefaultSessionCookieName)
+	if err != nil {
+		return nil
+	}
+	library, err := r.libraries.LibraryForSession(req.Context(), cookie.Value)
+	if err != nil {
+		return nil
+	}
+	return library
+}
+
+// router returns the router of a library, building it on first use
+func (r *LibraryRouter) router(id int) (http.Handler, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if router, ok := r.routers[id]; ok {
+		return router, nil
+	}
+
+	router, err := r.newRouter(r.db.ForLibrary(id))
+	if err != nil {
+		return nil, err
+	}
+	r.routers[id] = router
+
+	return router, nil
+}
@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"board-game-library/internal/config"
+	"board-game-library/internal/handlers"
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"board-game-library/pkg/database"
+)
+
+// setupLibraryRouterTest returns a LibraryRouter over two libraries whose
+// routers answer with their library and the path they received, and the
+// ID of the second library
+func setupLibraryRouterTest(t *testing.T) (*LibraryRouter, *database.DB, int) {
+	ctx := context.Background()
+	t.Helper()
+	db, err := database.InitializeForTesting()
+	if err != nil {
+		t.Fatalf("Failed to initialize test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	library := &models.Library{Slug: "ludo", Name: "Ludo Club", CreatedAt: time.Now()}
+	if err := repositories.NewSQLiteLibraryRepository(db).Create(ctx, library); err != nil {
+		t.Fatalf("Failed to create library: %v", err)
+	}
+
+	cfg := config.LibrariesConfig{BaseDomain: "jeux.example.org", PathPrefix: "/l"}
+	router := NewLibraryRouter(db, cfg, func(db *database.DB) (http.Handler, error) {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%d %s", database.LibraryOf(db), r.URL.Path)
+		}), nil
+	})
+
+	return router, db, library.ID
+}
+
+func TestLibraryRouter(t *testing.T) {
+	ctx := context.Background()
+	router, db, ludo := setupLibraryRouterTest(t)
+
+	user := &models.User{Name: "Alice", Email: "alice@example.com", RegisteredAt: time.Now(), IsActive: true, MembershipTier: "standard", Role: "member"}
+	if err := repositories.NewSQLiteUserRepository(db.ForLibrary(ludo)).Create(ctx, user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	auth := repositories.NewSQLiteAuthRepository(db.ForLibrary(ludo))
+	if err := auth.CreateSession(ctx, &models.Session{TokenHash: sha256Hex("session"), UserID: user.ID, CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	if err := auth.CreateAPIToken(ctx, &models.APIToken{UserID: user.ID, Name: "script", TokenHash: sha256Hex("bgl_token"), CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Failed to create API token: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		host     string
+		path     string
+		header   map[string]string
+		cookies  []*http.Cookie
+		wantCode int
+		wantBody string
+		remember bool
+	}{
+		{name: "default library", path: "/games", wantCode: http.StatusOK, wantBody: "1 /games"},
+		{name: "path prefix", path: "/l/ludo/games/3", wantCode: http.StatusOK, wantBody: fmt.Sprintf("%d /games/3", ludo), remember: true},
+		{name: "path prefix of the home page", path: "/l/ludo", wantCode: http.StatusOK, wantBody: fmt.Sprintf("%d /", ludo), remember: true},
+		{name: "unknown library in the path", path: "/l/missing/games", wantCode: http.StatusNotFound},
+		{name: "paths merely starting like the prefix", path: "/login", wantCode: http.StatusOK, wantBody: "1 /login"},
+		{name: "subdomain", host: "Ludo.jeux.example.org:8080", path: "/games", wantCode: http.StatusOK, wantBody: fmt.Sprintf("%d /games", ludo), remember: true},
+		{name: "unknown subdomain", host: "missing.jeux.example.org", path: "/games", wantCode: http.StatusNotFound},
+		{name: "base domain", host: "jeux.example.org", path: "/games", wantCode: http.StatusOK, wantBody: "1 /games"},
+		{name: "session", path: "/games", cookies: []*http.Cookie{{Name: handlers.DefaultSessionCookieName, Value: "session"}}, wantCode: http.StatusOK, wantBody: fmt.Sprintf("%d /games", ludo)},
+		{name: "API token", path: "/api/v1/games", header: map[string]string{"Authorization": "Bearer bgl_token"}, wantCode: http.StatusOK, wantBody: fmt.Sprintf("%d /api/v1/games", ludo)},
+		{name: "invalid API token", path: "/api/v1/games", header: map[string]string{"Authorization": "Bearer bgl_other"}, wantCode: http.StatusOK, wantBody: "1 /api/v1/games"},
+		{name: "library cookie", path: "/login", cookies: []*http.Cookie{{Name: LibraryCookieName, Value: "ludo"}}, wantCode: http.StatusOK, wantBody: fmt.Sprintf("%d /login", ludo)},
+		{name: "path prefix over session", path: "/l/default/games", cookies: []*http.Cookie{{Name: handlers.DefaultSessionCookieName, Value: "session"}}, wantCode: http.StatusOK, wantBody: "1 /games", remember: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			for _, cookie := range tt.cookies {
+				req.AddCookie(cookie)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("Expected %q, got %q", tt.wantBody, w.Body.String())
+			}
+			if remembered := strings.Contains(w.Header().Get("Set-Cookie"), LibraryCookieName+"="); remembered != tt.remember {
+				t.Errorf("Expected the library to be remembered: %v, got Set-Cookie %q", tt.remember, w.Header().Get("Set-Cookie"))
+			}
+		})
+	}
+}
+
+func TestLibraryRouterBuildsOneRouterPerLibrary(t *testing.T) {
+	db, err := database.InitializeForTesting()
+	if err != nil {
+		t.Fatalf("Failed to initialize test database: %v", err)
+	}
+	defer db.Close()
+
+	built := map[int]int{}
+	router := NewLibraryRouter(db, config.LibrariesConfig{PathPrefix: "/l"}, func(db *database.DB) (http.Handler, error) {
+		built[database.LibraryOf(db)]++
+		return http.NotFoundHandler(), nil
+	})
+
+	for _, path := range []string{"/", "/games", "/l/default/games"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	if len(built) != 1 || built[database.DefaultLibraryID] != 1 {
+		t.Errorf("Expected the router of the default library to be built once, got %v", built)
+	}
+}
+
+// sha256Hex hashes a token the way the authentication service stores it
+func sha256Hex(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
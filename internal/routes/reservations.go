@@ -0,0 +1,276 @@
+package routes
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"board-game-library/internal/handlers"
+	"board-game-library/internal/models"
+	"board-game-library/internal/services"
+)
+
+// setupReservationWebRoutes configures the reservation queue page and its forms
+func setupReservationWebRoutes(router *gin.Engine, reservationService *services.ReservationService, gameService *services.GameService, userService *services.UserService) {
+	// Reservations route
+	router.GET("/reservations", func(c *gin.Context) {
+		reservations, err := reservationService.GetActiveReservations(c.Request.Context())
+		if err != nil {
+			renderReservationMessage(c, http.StatusInternalServerError, false, "Erreur",
+				fmt.Sprintf("Échec du chargement des réservations : %s", err.Error()))
+			return
+		}
+
+		users, _ := userService.GetAllUsers(c.Request.Context())
+		games, _ := gameService.GetAllGames(c.Request.Context())
+
+		userNames := make(map[int]string)
+		usersOptions := ""
+		for _, user := range users {
+			userNames[user.ID] = user.Name
+			if user.IsActive {
+				usersOptions += fmt.Sprintf(`<option value="%d">%s (%s)</option>`, user.ID, html.EscapeString(user.Name), html.EscapeString(user.Email))
+			}
+		}
+
+		// Only borrowed games can be reserved
+		gameNames := make(map[int]string)
+		gamesOptions := ""
+		for _, game := range games {
+			gameNames[game.ID] = game.Name
+			if !game.IsAvailable {
+				gamesOptions += fmt.Sprintf(`<option value="%d">%s</option>`, game.ID, html.EscapeString(gameLabel(game)))
+			}
+		}
+
+		reservationsHTML := ""
+		if len(reservations) == 0 {
+			reservationsHTML = `<p class="text-gray-500 text-center py-8">Aucune réservation en cours.</p>`
+		} else {
+			reservationsHTML = `
+				<table class="min-w-full divide-y divide-gray-200">
+					<thead class="bg-gray-50">
+						<tr>
+							<th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase">Jeu</th>
+							<th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase">Position</th>
+							<th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase">Utilisateur</th>
+							<th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase">Statut</th>
+							<th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase">Réservé le</th>
+							<th class="px-4 py-2"></th>
+						</tr>
+					</thead>
+					<tbody class="divide-y divide-gray-200">`
+			for _, reservation := range reservations {
+				status := `<span class="px-2 py-1 text-xs font-medium rounded-full bg-yellow-100 text-yellow-800">En attente</span>`
+				if reservation.Status == models.ReservationReady && reservation.ExpiresAt != nil {
+					status = fmt.Sprintf(`<span class="px-2 py-1 text-xs font-medium rounded-full bg-green-100 text-green-800">À retirer avant le %s</span>`,
+						reservation.ExpiresAt.Format("2006-01-02"))
+				}
+
+				reservationsHTML += fmt.Sprintf(`
+						<tr>
+							<td class="px-4 py-2 font-medium">%s</td>
+							<td class="px-4 py-2">#%d</td>
+							<td class="px-4 py-2">%s</td>
+							<td class="px-4 py-2">%s</td>
+							<td class="px-4 py-2 text-sm text-gray-500">%s</td>
+							<td class="px-4 py-2 text-right">
+								<form action="/reservations/%d/cancel" method="POST" style="display: inline;">
+									<button type="submit" class="text-xs px-2 py-1 bg-red-500 hover:bg-red-600 text-white rounded"
+											onclick="return confirm('Êtes-vous sûr de vouloir annuler cette réservation ?')">
+										✖ Annuler
+									</button>
+								</form>
+							</td>
+						</tr>`, html.EscapeString(nameOrID(gameNames, reservation.GameID)), reservation.Position,
+					html.EscapeString(nameOrID(userNames, reservation.UserID)), status,
+					reservation.CreatedAt.Format("2006-01-02 15:04"), reservation.ID)
+			}
+			reservationsHTML += `
+					</tbody>
+				</table>`
+		}
+
+		c.Header("Content-Type", "text/html")
+		c.String(http.StatusOK, `
+<!DOCTYPE html>
+<html lang="fr">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Réservations - Bibliothèque de Jeux de Société</title>
+    <script src="https://cdn.tailwindcss.com"></script>
+</head>
+<body class="bg-gray-100">
+    <div class="container mx-auto px-4 py-8">
+        <div class="max-w-6xl mx-auto space-y-6">
+            <!-- En-tête -->
+            <div class="bg-white rounded-lg shadow-lg p-6">
+                <div class="flex justify-between items-center">
+                    <h1 class="text-3xl font-bold text-indigo-600">Réservations</h1>
+                    <a href="/" class="bg-gray-500 hover:bg-gray-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
+                </div>
+                <p class="text-gray-600 mt-2">Réservations actives : %d</p>
+                <p class="text-sm text-gray-500 mt-1">Lorsqu'un jeu est rendu, la première personne de la file est prévenue par une alerte et le jeu lui est réservé pendant %d jour(s).</p>
+            </div>
+
+            <!-- Formulaire de réservation -->
+            <div class="bg-white rounded-lg shadow-lg p-6">
+                <h2 class="text-xl font-semibold text-indigo-600 mb-4">📌 Réserver un Jeu Emprunté</h2>
+                <form action="/reservations/create" method="POST" class="grid grid-cols-1 md:grid-cols-3 gap-4">
+                    <div>
+                        <label for="user_id" class="block text-sm font-medium text-gray-700 mb-1">Utilisateur *</label>
+                        <select id="user_id" name="user_id" required
+                                class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-indigo-500">
+                            <option value="">Sélectionner un utilisateur</option>
+                            %s
+                        </select>
+                    </div>
+                    <div>
+                        <label for="game_id" class="block text-sm font-medium text-gray-700 mb-1">Jeu emprunté *</label>
+                        <select id="game_id" name="game_id" required
+                                class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-indigo-500">
+                            <option value="">Sélectionner un jeu</option>
+                            %s
+                        </select>
+                    </div>
+                    <div class="flex items-end">
+                        <button type="submit"
+                                class="w-full bg-indigo-500 hover:bg-indigo-600 text-white font-medium py-2 px-4 rounded-md transition-colors">
+                            ➕ Rejoindre la File
+                        </button>
+                    </div>
+                </form>
+            </div>
+
+            <!-- Files d'attente -->
+            <div class="bg-white rounded-lg shadow-lg p-6">
+                <div class="flex justify-between items-center mb-4">
+                    <h2 class="text-xl font-semibold text-indigo-600">⏳ Files d'Attente</h2>
+                    <form action="/reservations/expire" method="POST">
+                        <button type="submit" class="text-sm px-3 py-1 bg-gray-500 hover:bg-gray-600 text-white rounded">
+                            🧹 Expirer les réservations non retirées
+                        </button>
+                    </form>
+                </div>
+                %s
+            </div>
+
+            <!-- Informations API -->
+            <div class="bg-gray-50 rounded-lg p-4 text-center">
+                <h3 class="text-lg font-semibold mb-2">Accès API</h3>
+                <div class="space-x-4 text-sm">
+                    <a href="/api/v1/reservations" class="text-blue-600 hover:underline">Voir JSON</a>
+                    <span class="text-gray-400">|</span>
+                    <span class="text-gray-500">API REST disponible pour les opérations avancées</span>
+                </div>
+            </div>
+        </div>
+    </div>
+</body>
+</html>`, len(reservations), reservationService.HoldDays(), usersOptions, gamesOptions, reservationsHTML)
+	})
+
+	// Create reservation
+	router.POST("/reservations/create", func(c *gin.Context) {
+		userID, err := strconv.Atoi(strings.TrimSpace(c.PostForm("user_id")))
+		if err != nil {
+			renderReservationMessage(c, http.StatusBadRequest, false, "❌ Erreur", "Veuillez sélectionner un utilisateur valide.")
+			return
+		}
+
+		gameID, err := strconv.Atoi(strings.TrimSpace(c.PostForm("game_id")))
+		if err != nil {
+			renderReservationMessage(c, http.StatusBadRequest, false, "❌ Erreur", "Veuillez sélectionner un jeu valide.")
+			return
+		}
+
+		reservation, err := reservationService.WithActor(handlers.CurrentUser(c)).PlaceHold(c.Request.Context(), userID, gameID)
+		if err != nil {
+			renderReservationMessage(c, handlers.ErrorStatus(err), false, "❌ Erreur",
+				fmt.Sprintf("Échec de la réservation : %s", err.Error()))
+			return
+		}
+
+		renderReservationMessage(c, http.StatusOK, true, "✅ Réservation Enregistrée !",
+			fmt.Sprintf("La réservation a été ajoutée à la file d'attente en position %d.", reservation.Position))
+	})
+
+	// Cancel reservation
+	router.POST("/reservations/:id/cancel", func(c *gin.Context) {
+		reservationID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			renderReservationMessage(c, http.StatusBadRequest, false, "❌ Erreur", "ID de réservation invalide.")
+			return
+		}
+
+		if err := reservationService.WithActor(handlers.CurrentUser(c)).CancelHold(c.Request.Context(), reservationID); err != nil {
+			renderReservationMessage(c, http.StatusInternalServerError, false, "❌ Erreur",
+				fmt.Sprintf("Échec de l'annulation de la réservation : %s", err.Error()))
+			return
+		}
+
+		renderReservationMessage(c, http.StatusOK, true, "✅ Réservation Annulée !",
+			"La réservation a été annulée. Un exemplaire mis de côté passe à la personne suivante dans la file.")
+	})
+
+	// Expire uncollected holds
+	router.POST("/reservations/expire", func(c *gin.Context) {
+		expired, err := reservationService.WithActor(handlers.CurrentUser(c)).ExpireHolds(c.Request.Context())
+		if err != nil {
+			renderReservationMessage(c, http.StatusInternalServerError, false, "❌ Erreur",
+				fmt.Sprintf("Échec de l'expiration des réservations : %s", err.Error()))
+			return
+		}
+
+		renderReservationMessage(c, http.StatusOK, true, "✅ Réservations Traitées !",
+			fmt.Sprintf("%d réservation(s) non retirée(s) ont expiré.", expired))
+	})
+}
+
+// nameOrID returns the name registered for an ID, or the ID itself when unknown
+func nameOrID(names map[int]string, id int) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("ID %d", id)
+}
+
+// renderReservationMessage renders a result page that links back to the
+// reservations page, redirecting there automatically after a success
+func renderReservationMessage(c *gin.Context, status int, success bool, title, message string) {
+	titleColor := "text-red-600"
+	pageTitle := "Erreur"
+	refresh := ""
+	if success {
+		titleColor = "text-green-600"
+		pageTitle = "Succès"
+		refresh = `
+    <meta http-equiv="refresh" content="3;url=/reservations">`
+	}
+
+	c.Header("Content-Type", "text/html")
+	c.String(status, `
+<!DOCTYPE html>
+<html lang="fr">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>%s - Bibliothèque de Jeux de Société</title>
+    <script src="https://cdn.tailwindcss.com"></script>%s
+</head>
+<body class="bg-gray-100">
+    <div class="container mx-auto px-4 py-8">
+        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
+            <h1 class="text-3xl font-bold %s mb-4">%s</h1>
+            <p class="text-gray-600 mb-4">%s</p>
+            <a href="/reservations" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Réservations</a>
+        </div>
+    </div>
+</body>
+</html>`, pageTitle, refresh, titleColor, title, html.EscapeString(message))
+}
@@ -1,48 +1,130 @@
 package routes
 
 import (
-	"fmt"
-	"html/template"
 	"net/http"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	swaggerFiles "github.com/swaggo/files"
 
+	"board-game-library/internal/assets"
+	"board-game-library/internal/config"
 	"board-game-library/internal/handlers"
+	"board-game-library/internal/jobs"
 	"board-game-library/internal/repositories"
 	"board-game-library/internal/services"
+	"board-game-library/pkg/bgg"
 	"board-game-library/pkg/database"
 )
 
-// SetupRoutes configures all application routes
-func SetupRoutes(router *gin.Engine, db *database.DB) error {
-	// Setup template functions
-	setupTemplateFunctions(router)
-	
-	// Serve static files
-	router.Static("/static", "./web/static")
+// SetupRoutes configures all application routes. The job manager is optional;
+// when nil, the /api/v1/jobs endpoints are not registered. A nil cfg uses the
+// default reservation, authentication and BoardGameGeek settings, with
+// authentication enabled.
+func SetupRoutes(router *gin.Engine, db *database.DB, jobManager *jobs.Manager, cfg *config.Config) error {
+	// Pages of the web interface, from the embedded templates
+	templates, err := handlers.LoadWebTemplates(assets.GetTemplatesFS())
+	if err != nil {
+		return err
+	}
+	router.HTMLRender = templates
 
 	// Initialize repositories
 	gameRepo := repositories.NewSQLiteGameRepository(db)
 	userRepo := repositories.NewSQLiteUserRepository(db)
 	borrowingRepo := repositories.NewSQLiteBorrowingRepository(db)
 	alertRepo := repositories.NewSQLiteAlertRepository(db)
+	reservationRepo := repositories.NewSQLiteReservationRepository(db)
+	loanPolicyRepo := repositories.NewSQLiteLoanPolicyRepository(db)
+	authRepo := repositories.NewSQLiteAuthRepository(db)
+	auditRepo := repositories.NewSQLiteAuditRepository(db)
+	tagRepo := repositories.NewSQLiteTagRepository(db)
+	notificationRepo := repositories.NewSQLiteNotificationRepository(db)
+	fineRepo := repositories.NewSQLiteFineRepository(db)
+	conditionRepo := repositories.NewSQLiteConditionRepository(db)
+
+	holdDays := services.DefaultHoldDays
+	authEnabled := true
+	cookie := handlers.SessionCookie{TTL: services.DefaultSessionTTL}
+	bggConfig := config.BGGConfig{BaseURL: bgg.DefaultBaseURL, Timeout: 10 * time.Second}
+	if cfg != nil {
+		holdDays = cfg.Reservations.HoldDays
+		authEnabled = cfg.Auth.Enabled
+		cookie.TTL = cfg.Auth.SessionTTL
+		cookie.Secure = cfg.Auth.SecureCookies
+		bggConfig = cfg.BGG
+	}
 
 	// Initialize services
 	gameService := services.NewGameService(gameRepo, borrowingRepo)
+	if bggConfig.BaseURL != "" {
+		gameService.SetCatalogue(bgg.NewClient(bggConfig.BaseURL, bggConfig.Token, bggConfig.Timeout))
+	}
 	userService := services.NewUserService(userRepo, borrowingRepo)
+	userService.SetUnitOfWork(repositories.NewSQLiteUnitOfWork(db))
 	borrowingService := services.NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+	borrowingService.SetUnitOfWork(repositories.NewSQLiteUnitOfWork(db))
 	alertService := services.NewAlertService(alertRepo, borrowingRepo, userRepo, gameRepo)
+	reservationService := services.NewReservationService(reservationRepo, userRepo, gameRepo, borrowingRepo, alertRepo, holdDays)
+	borrowingService.SetHoldQueue(reservationService)
+	loanPolicyService := services.NewLoanPolicyService(loanPolicyRepo)
+	borrowingService.SetLoanPolicies(loanPolicyService)
+	userService.SetLoanPolicies(loanPolicyService)
+	fineService := services.NewFineService(fineRepo, userRepo)
+	fineService.SetUnitOfWork(repositories.NewSQLiteUnitOfWork(db))
+	borrowingService.SetFines(fineService)
+	userService.SetFines(fineService)
+	conditionService := services.NewConditionService(conditionRepo, gameRepo, userRepo, alertRepo)
+	borrowingService.SetConditions(conditionService)
+	gameService.SetConditions(conditionService)
+	gameService.SetHoldQueue(reservationService)
+	gameService.SetUnitOfWork(repositories.NewSQLiteUnitOfWork(db))
+	authService := services.NewAuthService(userRepo, authRepo, cookie.TTL)
+	authService.SetUnitOfWork(repositories.NewSQLiteUnitOfWork(db))
+	tagService := services.NewTagService(tagRepo)
+	transferService := services.NewTransferService(repositories.NewSQLiteUnitOfWork(db), repositories.NewSQLiteExportRepository(db))
+	// Alerts are emailed by a background job; the routes only manage
+	// preferences and delivery statuses, so no notifier is needed
+	notificationService := services.NewNotificationService(notificationRepo, borrowingRepo, reservationRepo, nil, nil, services.NotificationSettings{})
+
+	// Record every change in the audit log
+	auditService := services.NewAuditService(auditRepo)
+	gameService.SetAuditor(auditService)
+	userService.SetAuditor(auditService)
+	borrowingService.SetAuditor(auditService)
+	alertService.SetAuditor(auditService)
+	reservationService.SetAuditor(auditService)
+	loanPolicyService.SetAuditor(auditService)
+	authService.SetAuditor(auditService)
+	tagService.SetAuditor(auditService)
+	transferService.SetAuditor(auditService)
+	fineService.SetAuditor(auditService)
+
+	// Error responses as problem details, for the errors handlers report
+	router.Use(handlers.Problems())
+
+	// Sign-in and role checks; must be installed before any route is registered
+	if authEnabled {
+		router.Use(handlers.NewAuthMiddleware(authService, accessRules, cookie).Handler())
+	}
+
+	// Serve static files
+	router.Static("/static", "./web/static")
 
 	// Initialize API handlers
 	gameHandler := handlers.NewGameHandler(gameService)
 	userHandler := handlers.NewUserHandler(userService)
 	borrowingHandler := handlers.NewBorrowingHandler(borrowingService)
 	alertHandler := handlers.NewAlertHandler(alertService)
+	reservationHandler := handlers.NewReservationHandler(reservationService)
+	loanPolicyHandler := handlers.NewLoanPolicyHandler(loanPolicyService)
+	authHandler := handlers.NewAuthHandler(authService, cookie)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	transferHandler := handlers.NewTransferHandler(transferService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	fineHandler := handlers.NewFineHandler(fineService)
 
 	// Guide utilisateur route
 	router.GET("/guide", func(c *gin.Context) {
@@ -117,7 +199,7 @@ func SetupRoutes(router *gin.Engine, db *database.DB) error {
                         <h3 class="text-xl font-semibold text-blue-600 mb-4">📚 Comment ajouter un jeu</h3>
                         <ol class="list-decimal list-inside space-y-2 text-sm text-gray-700">
                             <li>Remplir le nom du jeu (obligatoire)</li>
-                            <li>Sélectionner la catégorie (Stratégie, Famille, etc.)</li>
+                            <li>Ajouter des étiquettes séparées par des virgules (Stratégie, Famille, etc.)</li>
                             <li>Ajouter une description (optionnel)</li>
                             <li>Choisir l'état du jeu (Excellent, Bon, etc.)</li>
                             <li>Cliquer "Ajouter le Jeu"</li>
@@ -290,7 +372,7 @@ func SetupRoutes(router *gin.Engine, db *database.DB) error {
             <h2 class="text-2xl font-semibold mb-4">Bienvenue dans le Système de Gestion de la Bibliothèque de Jeux</h2>
             <p class="text-gray-600 mb-6">Gérez votre collection de jeux de société, suivez les emprunts et restez organisé.</p>
             
-            <div class="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-4 gap-4">
+            <div class="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-5 gap-4">
                 <a href="/games" class="bg-blue-500 hover:bg-blue-600 text-white p-4 rounded-lg text-center transition-colors">
                     <h3 class="font-semibold">Jeux</h3>
                     <p class="text-sm">Gérer la collection</p>
@@ -303,11 +385,19 @@ func SetupRoutes(router *gin.Engine, db *database.DB) error {
                     <h3 class="font-semibold">Emprunts</h3>
                     <p class="text-sm">Suivre les prêts</p>
                 </a>
+                <a href="/reservations" class="bg-indigo-500 hover:bg-indigo-600 text-white p-4 rounded-lg text-center transition-colors">
+                    <h3 class="font-semibold">Réservations</h3>
+                    <p class="text-sm">Files d'attente</p>
+                </a>
                 <a href="/alerts" class="bg-red-500 hover:bg-red-600 text-white p-4 rounded-lg text-center transition-colors">
                     <h3 class="font-semibold">Alertes</h3>
                     <p class="text-sm">Voir les notifications</p>
                 </a>
             </div>
+            <div class="mt-4 text-right space-x-4">
+                <a href="/audit" class="text-slate-600 hover:underline font-semibold">📜 Journal d'audit</a>
+                <a href="/account" class="text-blue-600 hover:underline font-semibold">👤 Mon compte</a>
+            </div>
             
             <!-- Section Guide Utilisateur -->
             <div class="mt-8 bg-gradient-to-r from-purple-50 to-blue-50 rounded-lg p-6 border border-purple-200">
@@ -352,1864 +442,181 @@ func SetupRoutes(router *gin.Engine, db *database.DB) error {
 </html>`)
 	})
 
-	// Simple web routes
-	setupSimpleWebRoutes(router, gameService, userService, borrowingService, alertService)
-	
-	// Form submission routes
-	setupFormRoutes(router, gameService, userService, borrowingService, alertService)
-
-	// Swagger documentation route
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-
-	// API routes
-	setupAPIRoutes(router, gameHandler, userHandler, borrowingHandler, alertHandler)
+	// Games, users, borrowings, alerts and dashboard pages
+	web := router.Group("/")
+	handlers.NewGameWebHandler(gameService, userService).RegisterWebRoutes(web)
+	handlers.NewUserWebHandler(userService, gameService).RegisterWebRoutes(web)
+	handlers.NewBorrowingWebHandler(borrowingService, userService, gameService).RegisterWebRoutes(web)
+	handlers.NewAlertWebHandler(alertService, borrowingService, userService, gameService).RegisterWebRoutes(web)
+	handlers.NewDashboardWebHandler(alertService, borrowingService, gameService, userService).RegisterWebRoutes(web)
 
-	return nil
-}
+	// Reservation queue pages
+	setupReservationWebRoutes(router, reservationService, gameService, userService)
 
-// setupSimpleWebRoutes configures simple web routes for testing
-func setupSimpleWebRoutes(router *gin.Engine, gameService *services.GameService, userService *services.UserService, borrowingService *services.BorrowingService, alertService *services.AlertService) {
-	// Games route
-	router.GET("/games", func(c *gin.Context) {
-		games, err := gameService.GetAllGames()
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Jeux - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">Erreur</h1>
-            <p class="text-gray-600">Échec du chargement des jeux : %s</p>
-            <a href="/" class="mt-4 inline-block bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		gamesHTML := ""
-		if len(games) == 0 {
-			gamesHTML = `<p class="text-gray-500 text-center py-8">Aucun jeu trouvé. Utilisez le formulaire ci-dessous pour ajouter votre premier jeu !</p>`
-		} else {
-			gamesHTML = `<div class="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-3 gap-4">`
-			for _, game := range games {
-				status := "Disponible"
-				statusColor := "text-green-600"
-				if !game.IsAvailable {
-					status = "Emprunté"
-					statusColor = "text-red-600"
-				}
-				gamesHTML += fmt.Sprintf(`
-					<div class="bg-gray-50 p-4 rounded-lg border">
-						<div class="flex justify-between items-start">
-							<div class="flex-1">
-								<h3 class="font-semibold text-lg mb-2">%s</h3>
-								<p class="text-gray-600 text-sm mb-2">%s</p>
-								<div class="flex justify-between items-center mb-2">
-									<span class="text-sm text-gray-500">Catégorie : %s</span>
-									<span class="text-sm %s font-medium">%s</span>
-								</div>
-								<div class="text-xs text-gray-400">
-									État : %s | Ajouté : %s
-								</div>
-							</div>
-							<div class="ml-4">
-								<form action="/games/%d/delete" method="POST" style="display: inline;">
-									<button type="submit" class="text-xs px-2 py-1 bg-red-500 hover:bg-red-600 text-white rounded" 
-											onclick="return confirm('Êtes-vous sûr de vouloir supprimer ce jeu ?')">
-										🗑️ Supprimer
-									</button>
-								</form>
-							</div>
-						</div>
-					</div>`, game.Name, game.Description, game.Category, statusColor, status, game.Condition, game.EntryDate.Format("2006-01-02"), game.ID)
-			}
-			gamesHTML += `</div>`
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Jeux - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-6xl mx-auto space-y-6">
-            <!-- En-tête -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <div class="flex justify-between items-center">
-                    <h1 class="text-3xl font-bold text-blue-600">Collection de Jeux</h1>
-                    <a href="/" class="bg-gray-500 hover:bg-gray-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
-                </div>
-                <p class="text-gray-600 mt-2">Total des jeux : %d</p>
-            </div>
+	// Sign-in, first-run setup and account pages
+	setupAuthWebRoutes(router, authService, cookie, userService, alertService, gameService, notificationService)
 
-            <!-- Formulaire d'ajout de nouveau jeu -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <h2 class="text-xl font-semibold text-blue-600 mb-4">📚 Ajouter un Nouveau Jeu</h2>
-                <form action="/games/create" method="POST" class="grid grid-cols-1 md:grid-cols-2 gap-4">
-                    <div>
-                        <label for="name" class="block text-sm font-medium text-gray-700 mb-1">Nom du Jeu *</label>
-                        <input type="text" id="name" name="name" required 
-                               class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-blue-500"
-                               placeholder="Saisir le nom du jeu">
-                    </div>
-                    <div>
-                        <label for="category" class="block text-sm font-medium text-gray-700 mb-1">Catégorie *</label>
-                        <input type="text" id="category" name="category" required 
-                               class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-blue-500"
-                               placeholder="ex: Stratégie, Famille, Cartes">
-                    </div>
-                    <div class="md:col-span-2">
-                        <label for="description" class="block text-sm font-medium text-gray-700 mb-1">Description</label>
-                        <textarea id="description" name="description" rows="3"
-                                  class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-blue-500"
-                                  placeholder="Brève description du jeu"></textarea>
-                    </div>
-                    <div>
-                        <label for="condition" class="block text-sm font-medium text-gray-700 mb-1">État *</label>
-                        <select id="condition" name="condition" required 
-                                class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-blue-500">
-                            <option value="">Sélectionner l'état</option>
-                            <option value="excellent">Excellent</option>
-                            <option value="good">Bon</option>
-                            <option value="fair">Correct</option>
-                            <option value="poor">Mauvais</option>
-                        </select>
-                    </div>
-                    <div class="flex items-end">
-                        <button type="submit" 
-                                class="w-full bg-blue-500 hover:bg-blue-600 text-white font-medium py-2 px-4 rounded-md transition-colors">
-                            ➕ Ajouter le Jeu
-                        </button>
-                    </div>
-                </form>
-            </div>
+	// Audit log page
+	setupAuditWebRoutes(router, auditService)
 
-            <!-- Liste des jeux -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <h2 class="text-xl font-semibold text-blue-600 mb-4">🎲 Jeux Actuels</h2>
-                %s
-            </div>
+	// Tag suggestions of the game form
+	setupTagWebRoutes(router, tagService)
 
-            <!-- Informations API -->
-            <div class="bg-gray-50 rounded-lg p-4 text-center">
-                <h3 class="text-lg font-semibold mb-2">Accès API</h3>
-                <div class="space-x-4 text-sm">
-                    <a href="/api/v1/games" class="text-blue-600 hover:underline">Voir JSON</a>
-                    <span class="text-gray-400">|</span>
-                    <span class="text-gray-500">API REST disponible pour les opérations avancées</span>
-                </div>
-            </div>
-        </div>
-    </div>
-</body>
-</html>`, len(games), gamesHTML)
-	})
-	
-	// Users route
-	router.GET("/users", func(c *gin.Context) {
-		users, err := userService.GetAllUsers()
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Utilisateurs - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">Erreur</h1>
-            <p class="text-gray-600">Échec du chargement des utilisateurs : %s</p>
-            <a href="/" class="mt-4 inline-block bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		usersHTML := ""
-		if len(users) == 0 {
-			usersHTML = `<p class="text-gray-500 text-center py-8">Aucun utilisateur trouvé. Utilisez le formulaire ci-dessous pour inscrire votre premier membre !</p>`
-		} else {
-			usersHTML = `<div class="grid grid-cols-1 md:grid-cols-2 lg:grid-cols-3 gap-4">`
-			for _, user := range users {
-				statusColor := "text-green-600"
-				status := "Actif"
-				if !user.IsActive {
-					statusColor = "text-red-600"
-					status = "Inactif"
-				}
-				usersHTML += fmt.Sprintf(`
-					<div class="bg-gray-50 p-4 rounded-lg border">
-						<div class="flex justify-between items-start">
-							<div class="flex-1">
-								<h3 class="font-semibold text-lg mb-2">%s</h3>
-								<p class="text-gray-600 text-sm mb-2">📧 %s</p>
-								<div class="flex justify-between items-center mb-2">
-									<span class="text-sm text-gray-500">ID : %d</span>
-									<span class="text-sm %s font-medium">%s</span>
-								</div>
-								<div class="text-xs text-gray-400">
-									Inscrit : %s
-								</div>
-							</div>
-							<div class="ml-4">
-								<form action="/users/%d/delete" method="POST" style="display: inline;">
-									<button type="submit" class="text-xs px-2 py-1 bg-red-500 hover:bg-red-600 text-white rounded" 
-											onclick="return confirm('Êtes-vous sûr de vouloir supprimer cet utilisateur ?')">
-										🗑️ Supprimer
-									</button>
-								</form>
-							</div>
-						</div>
-					</div>`, user.Name, user.Email, user.ID, statusColor, status, user.RegisteredAt.Format("2006-01-02"), user.ID)
-			}
-			usersHTML += `</div>`
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Utilisateurs - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-6xl mx-auto space-y-6">
-            <!-- En-tête -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <div class="flex justify-between items-center">
-                    <h1 class="text-3xl font-bold text-green-600">Membres de la Bibliothèque</h1>
-                    <a href="/" class="bg-gray-500 hover:bg-gray-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
-                </div>
-                <p class="text-gray-600 mt-2">Total des utilisateurs : %d</p>
-            </div>
+	// Import of games from BoardGameGeek
+	setupBGGWebRoutes(router, gameService)
 
-            <!-- Formulaire d'ajout de nouvel utilisateur -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <h2 class="text-xl font-semibold text-green-600 mb-4">👤 Inscrire un Nouveau Membre</h2>
-                <form action="/users/create" method="POST" class="grid grid-cols-1 md:grid-cols-2 gap-4">
-                    <div>
-                        <label for="name" class="block text-sm font-medium text-gray-700 mb-1">Nom Complet *</label>
-                        <input type="text" id="name" name="name" required 
-                               class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-green-500"
-                               placeholder="Saisir le nom complet">
-                    </div>
-                    <div>
-                        <label for="email" class="block text-sm font-medium text-gray-700 mb-1">Adresse Email *</label>
-                        <input type="email" id="email" name="email" required 
-                               class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-green-500"
-                               placeholder="Saisir l'adresse email">
-                    </div>
-                    <div class="md:col-span-2">
-                        <button type="submit" 
-                                class="w-full md:w-auto bg-green-500 hover:bg-green-600 text-white font-medium py-2 px-6 rounded-md transition-colors">
-                            ➕ Inscrire le Membre
-                        </button>
-                    </div>
-                </form>
-            </div>
+	// Swagger documentation route
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-            <!-- Liste des utilisateurs -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <h2 class="text-xl font-semibold text-green-600 mb-4">👥 Membres Actuels</h2>
-                %s
-            </div>
+	// API routes
+	setupAPIRoutes(router, gameHandler, userHandler, borrowingHandler, alertHandler, reservationHandler, loanPolicyHandler, authHandler, auditHandler, tagHandler, transferHandler, notificationHandler, fineHandler)
 
-            <!-- Informations API -->
-            <div class="bg-gray-50 rounded-lg p-4 text-center">
-                <h3 class="text-lg font-semibold mb-2">Accès API</h3>
-                <div class="space-x-4 text-sm">
-                    <a href="/api/v1/users" class="text-blue-600 hover:underline">Voir JSON</a>
-                    <span class="text-gray-400">|</span>
-                    <span class="text-gray-500">API REST disponible pour les opérations avancées</span>
-                </div>
-            </div>
-        </div>
-    </div>
-</body>
-</html>`, len(users), usersHTML)
-	})
-	
-	// Borrowings route
-	router.GET("/borrowings", func(c *gin.Context) {
-		borrowings, err := borrowingService.GetAllBorrowings()
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Emprunts - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">Erreur</h1>
-            <p class="text-gray-600">Échec du chargement des emprunts : %s</p>
-            <a href="/" class="mt-4 inline-block bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
+	// Backups and background jobs cover the whole deployment, so they are
+	// only administered from the default library
+	if database.LibraryOf(db) != database.DefaultLibraryID {
+		return nil
+	}
 
-		// Get all users and games for the form
-		users, _ := userService.GetAllUsers()
-		games, _ := gameService.GetAvailableGames()
+	// Database snapshot download
+	backupHandler := handlers.NewBackupHandler(db)
+	backupHandler.RegisterRoutes(router.Group("/api/v1"))
 
-		borrowingsHTML := ""
-		if len(borrowings) == 0 {
-			borrowingsHTML = `<p class="text-gray-500 text-center py-8">Aucun emprunt trouvé. Utilisez le formulaire ci-dessous pour créer votre premier emprunt !</p>`
-		} else {
-			borrowingsHTML = `<div class="space-y-4">`
-			for _, borrowing := range borrowings {
-				status := "En cours"
-				statusColor := "text-yellow-600"
-				statusBg := "bg-yellow-100"
-				actionButton := ""
-				
-				if borrowing.ReturnedAt != nil {
-					status = "Retourné"
-					statusColor = "text-green-600"
-					statusBg = "bg-green-100"
-				} else if borrowing.IsOverdue {
-					status = "En retard"
-					statusColor = "text-red-600"
-					statusBg = "bg-red-100"
-				} else {
-					actionButton = fmt.Sprintf(`
-						<form action="/borrowings/%d/return" method="POST" style="display: inline;">
-							<button type="submit" class="text-xs px-2 py-1 bg-green-500 hover:bg-green-600 text-white rounded">
-								✅ Retourner
-							</button>
-						</form>`, borrowing.ID)
-				}
+	// Background job administration routes
+	if jobManager != nil {
+		jobHandler := handlers.NewJobHandler(jobManager)
+		jobHandler.RegisterRoutes(router.Group("/api/v1"))
+	}
 
-				returnedText := ""
-				if borrowing.ReturnedAt != nil {
-					returnedText = fmt.Sprintf("| Retourné : %s", borrowing.ReturnedAt.Format("2006-01-02"))
-				}
+	return nil
+}
 
-				// Get user and game details
-				userName := fmt.Sprintf("ID %d", borrowing.UserID)
-				gameName := fmt.Sprintf("ID %d", borrowing.GameID)
-				
-				if user, err := userService.GetUser(borrowing.UserID); err == nil {
-					userName = fmt.Sprintf("%s (%s)", user.Name, user.Email)
-				}
-				
-				if game, err := gameService.GetGame(borrowing.GameID); err == nil {
-					gameName = fmt.Sprintf("%s (%s)", game.Name, game.Category)
-				}
+// setupAPIRoutes configures API routes
+func setupAPIRoutes(router *gin.Engine,
+	gameHandler *handlers.GameHandler,
+	userHandler *handlers.UserHandler,
+	borrowingHandler *handlers.BorrowingHandler,
+	alertHandler *handlers.AlertHandler,
+	reservationHandler *handlers.ReservationHandler,
+	loanPolicyHandler *handlers.LoanPolicyHandler,
+	authHandler *handlers.AuthHandler,
+	auditHandler *handlers.AuditHandler,
+	tagHandler *handlers.TagHandler,
+	transferHandler *handlers.TransferHandler,
+	notificationHandler *handlers.NotificationHandler,
+	fineHandler *handlers.FineHandler) {
 
-				borrowingsHTML += fmt.Sprintf(`
-					<div class="bg-gray-50 p-4 rounded-lg border">
-						<div class="flex justify-between items-start">
-							<div class="flex-1">
-								<div class="flex items-center mb-2">
-									<h3 class="font-semibold text-lg mr-3">Emprunt #%d</h3>
-									<span class="px-2 py-1 text-xs font-medium rounded-full %s %s">%s</span>
-								</div>
-								<div class="grid grid-cols-1 md:grid-cols-2 gap-2 text-sm text-gray-600 mb-2">
-									<p><strong>Utilisateur :</strong> %s</p>
-									<p><strong>Jeu :</strong> %s</p>
-									<p><strong>Emprunté :</strong> %s</p>
-									<p><strong>Échéance :</strong> %s</p>
-								</div>
-								<div class="text-xs text-gray-400">
-									%s
-								</div>
-							</div>
-							<div class="ml-4">
-								%s
-							</div>
-						</div>
-					</div>`, borrowing.ID, statusBg, statusColor, status, userName, gameName, 
-					borrowing.BorrowedAt.Format("2006-01-02"), borrowing.DueDate.Format("2006-01-02"), returnedText, actionButton)
-			}
-			borrowingsHTML += `</div>`
+	api := router.Group("/api/v1")
+	{
+		// Authentication API routes
+		auth := api.Group("/auth")
+		{
+			auth.POST("/setup", authHandler.Setup)
+			auth.POST("/login", authHandler.Login)
+			auth.POST("/logout", authHandler.Logout)
+			auth.GET("/me", authHandler.Me)
+			auth.GET("/tokens", authHandler.GetTokens)
+			auth.POST("/tokens", authHandler.CreateToken)
+			auth.DELETE("/tokens/:id", authHandler.RevokeToken)
 		}
 
-		// Build users options
-		usersOptions := ""
-		for _, user := range users {
-			usersOptions += fmt.Sprintf(`<option value="%d">%s (%s)</option>`, user.ID, user.Name, user.Email)
+		// Game API routes
+		games := api.Group("/games")
+		{
+			games.POST("", gameHandler.AddGame)
+			games.GET("", gameHandler.GetAllGames)
+			games.GET("/search", gameHandler.SearchGames)
+			games.GET("/bgg/search", gameHandler.SearchBGG)
+			games.GET("/bgg/:bggId", gameHandler.PreviewBGGGame)
+			games.POST("/bgg/import", gameHandler.ImportBGGGame)
+			games.GET("/:id", gameHandler.GetGame)
+			games.PUT("/:id", gameHandler.UpdateGame)
+			games.DELETE("/:id", gameHandler.DeleteGame)
+			games.GET("/:id/borrowings", gameHandler.GetGameBorrowingHistory)
+			games.GET("/:id/availability", gameHandler.GetGameAvailability)
+			games.GET("/:id/copies", gameHandler.GetGameCopies)
+			games.POST("/:id/copies", gameHandler.AddCopy)
+			games.PUT("/:id/copies/:copyId", gameHandler.UpdateCopy)
+			games.DELETE("/:id/copies/:copyId", gameHandler.RemoveCopy)
+			games.POST("/:id/copies/:copyId/condition", gameHandler.ReportCopyCondition)
+			games.GET("/:id/condition-history", gameHandler.GetConditionHistory)
 		}
 
-		// Build games options
-		gamesOptions := ""
-		for _, game := range games {
-			gamesOptions += fmt.Sprintf(`<option value="%d">%s (%s)</option>`, game.ID, game.Name, game.Category)
+		// User API routes
+		users := api.Group("/users")
+		{
+			users.POST("", userHandler.RegisterUser)
+			users.GET("", userHandler.GetAllUsers)
+			users.GET("/:id", userHandler.GetUser)
+			users.PUT("/:id", userHandler.UpdateUser)
+			users.GET("/:id/borrowings", userHandler.GetUserBorrowings)
+			users.GET("/:id/current-loans", userHandler.GetUserCurrentLoans)
+			users.GET("/:id/eligibility", userHandler.CheckUserEligibility)
+			users.PUT("/:id/password", authHandler.SetPassword)
+			users.PUT("/:id/role", authHandler.SetRole)
 		}
 
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Emprunts - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-6xl mx-auto space-y-6">
-            <!-- En-tête -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <div class="flex justify-between items-center">
-                    <h1 class="text-3xl font-bold text-yellow-600">Gestion des Emprunts</h1>
-                    <a href="/" class="bg-gray-500 hover:bg-gray-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
-                </div>
-                <p class="text-gray-600 mt-2">Total des emprunts : %d</p>
-            </div>
-
-            <!-- Formulaire de nouvel emprunt -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <h2 class="text-xl font-semibold text-yellow-600 mb-4">📚 Créer un Nouvel Emprunt</h2>
-                <form action="/borrowings/create" method="POST" class="grid grid-cols-1 md:grid-cols-3 gap-4">
-                    <div>
-                        <label for="user_id" class="block text-sm font-medium text-gray-700 mb-1">Utilisateur *</label>
-                        <select id="user_id" name="user_id" required 
-                                class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-yellow-500">
-                            <option value="">Sélectionner un utilisateur</option>
-                            %s
-                        </select>
-                    </div>
-                    <div>
-                        <label for="game_id" class="block text-sm font-medium text-gray-700 mb-1">Jeu Disponible *</label>
-                        <select id="game_id" name="game_id" required 
-                                class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-yellow-500">
-                            <option value="">Sélectionner un jeu</option>
-                            %s
-                        </select>
-                    </div>
-                    <div>
-                        <label for="duration_days" class="block text-sm font-medium text-gray-700 mb-1">Durée d'emprunt *</label>
-                        <select id="duration_days" name="duration_days" required 
-                                class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-yellow-500">
-                            <option value="7">7 jours (1 semaine)</option>
-                            <option value="14" selected>14 jours (2 semaines) - Défaut</option>
-                            <option value="21">21 jours (3 semaines)</option>
-                            <option value="30">30 jours (1 mois)</option>
-                            <option value="60">60 jours (2 mois)</option>
-                        </select>
-                    </div>
-                    <div class="flex items-end">
-                        <button type="submit" 
-                                class="w-full bg-yellow-500 hover:bg-yellow-600 text-white font-medium py-2 px-4 rounded-md transition-colors">
-                            ➕ Créer l'Emprunt
-                        </button>
-                    </div>
-                </form>
-            </div>
-
-            <!-- Liste des emprunts -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <h2 class="text-xl font-semibold text-yellow-600 mb-4">📋 Emprunts Actuels</h2>
-                %s
-            </div>
-
-            <!-- Informations API -->
-            <div class="bg-gray-50 rounded-lg p-4 text-center">
-                <h3 class="text-lg font-semibold mb-2">Accès API</h3>
-                <div class="space-x-4 text-sm">
-                    <span class="text-gray-500">API REST disponible pour les opérations avancées</span>
-                </div>
-            </div>
-        </div>
-    </div>
-</body>
-</html>`, len(borrowings), usersOptions, gamesOptions, borrowingsHTML)
-	})
-	
-	// Alerts route
-	router.GET("/alerts", func(c *gin.Context) {
-		alerts, err := alertService.GetActiveAlerts()
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">Erreur</h1>
-            <p class="text-gray-600">Échec du chargement des alertes : %s</p>
-            <a href="/" class="mt-4 inline-block bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
+		// Borrowing API routes
+		borrowings := api.Group("/borrowings")
+		{
+			borrowings.POST("", borrowingHandler.BorrowGame)
+			borrowings.GET("", borrowingHandler.ListBorrowings)
+			borrowings.GET("/:id", borrowingHandler.GetBorrowingDetails)
+			borrowings.PUT("/:id/return", borrowingHandler.ReturnGame)
+			borrowings.PUT("/:id/extend", borrowingHandler.ExtendDueDate)
+			borrowings.GET("/overdue", borrowingHandler.GetOverdueItems)
+			borrowings.GET("/due-soon", borrowingHandler.GetItemsDueSoon)
+			borrowings.GET("/user/:id", borrowingHandler.GetActiveBorrowingsByUser)
+			borrowings.GET("/game/:id", borrowingHandler.GetBorrowingsByGame)
+			borrowings.POST("/update-overdue", borrowingHandler.UpdateOverdueStatus)
 		}
 
-		// Get all users and games for the custom alert form
-		users, _ := userService.GetAllUsers()
-		games, _ := gameService.GetAllGames()
-
-		alertsHTML := ""
-		if len(alerts) == 0 {
-			alertsHTML = `<p class="text-gray-500 text-center py-8">Aucune alerte active. Toutes les notifications ont été traitées !</p>`
-		} else {
-			alertsHTML = `<div class="space-y-4">`
-			for _, alert := range alerts {
-				alertTypeColor := "text-blue-600"
-				alertTypeBg := "bg-blue-100"
-				alertIcon := "ℹ️"
-				
-				switch alert.Type {
-				case "overdue":
-					alertTypeColor = "text-red-600"
-					alertTypeBg = "bg-red-100"
-					alertIcon = "⚠️"
-				case "reminder":
-					alertTypeColor = "text-yellow-600"
-					alertTypeBg = "bg-yellow-100"
-					alertIcon = "⏰"
-				case "custom":
-					alertTypeColor = "text-purple-600"
-					alertTypeBg = "bg-purple-100"
-					alertIcon = "📝"
-				}
-
-				// Get user and game details
-				userName := fmt.Sprintf("ID %d", alert.UserID)
-				gameName := fmt.Sprintf("ID %d", alert.GameID)
-				
-				if user, err := userService.GetUser(alert.UserID); err == nil {
-					userName = fmt.Sprintf("%s (%s)", user.Name, user.Email)
-				}
-				
-				if game, err := gameService.GetGame(alert.GameID); err == nil {
-					gameName = fmt.Sprintf("%s (%s)", game.Name, game.Category)
-				}
-
-				alertsHTML += fmt.Sprintf(`
-					<div class="bg-gray-50 p-4 rounded-lg border border-l-4 border-l-red-500">
-						<div class="flex justify-between items-start">
-							<div class="flex-1">
-								<div class="flex items-center mb-2">
-									<span class="text-2xl mr-2">%s</span>
-									<h3 class="font-semibold text-lg mr-3">Alerte #%d</h3>
-									<span class="px-2 py-1 text-xs font-medium rounded-full %s %s">%s</span>
-								</div>
-								<div class="mb-3">
-									<p class="text-gray-800 font-medium">%s</p>
-								</div>
-								<div class="grid grid-cols-1 md:grid-cols-2 gap-2 text-sm text-gray-600 mb-2">
-									<p><strong>Utilisateur :</strong> %s</p>
-									<p><strong>Jeu :</strong> %s</p>
-									<p><strong>Créée :</strong> %s</p>
-									<p><strong>Type :</strong> %s</p>
-								</div>
-							</div>
-							<div class="ml-4 space-x-2">
-								<form action="/alerts/%d/read" method="POST" style="display: inline;">
-									<button type="submit" class="text-xs px-3 py-1 bg-green-500 hover:bg-green-600 text-white rounded">
-										✅ Marquer comme lue
-									</button>
-								</form>
-								<form action="/alerts/%d/delete" method="POST" style="display: inline;">
-									<button type="submit" class="text-xs px-3 py-1 bg-red-500 hover:bg-red-600 text-white rounded" 
-											onclick="return confirm('Êtes-vous sûr de vouloir supprimer cette alerte ?')">
-										🗑️ Supprimer
-									</button>
-								</form>
-							</div>
-						</div>
-					</div>`, alertIcon, alert.ID, alertTypeBg, alertTypeColor, alert.Type, alert.Message, userName, gameName, 
-					alert.CreatedAt.Format("2006-01-02 15:04"), alert.Type, alert.ID, alert.ID)
-			}
-			alertsHTML += `</div>`
+		// Alert API routes
+		alerts := api.Group("/alerts")
+		{
+			alerts.GET("", alertHandler.GetActiveAlerts)
+			alerts.GET("/user/:id", alertHandler.GetAlertsByUser)
+			alerts.PUT("/:id/read", alertHandler.MarkAlertAsRead)
+			alerts.PUT("/user/:id/read-all", alertHandler.MarkAllUserAlertsAsRead)
+			alerts.DELETE("/:id", alertHandler.DeleteAlert)
+			alerts.GET("/summary", alertHandler.GetAlertsSummary)
+			alerts.GET("/dashboard", alertHandler.GetDashboard)
+			alerts.POST("", alertHandler.CreateCustomAlert)
 		}
 
-		// Build users options
-		usersOptions := ""
-		for _, user := range users {
-			usersOptions += fmt.Sprintf(`<option value="%d">%s (%s)</option>`, user.ID, user.Name, user.Email)
+		// Reservation API routes
+		reservations := api.Group("/reservations")
+		{
+			reservations.POST("", reservationHandler.PlaceHold)
+			reservations.GET("", reservationHandler.GetActiveReservations)
+			reservations.POST("/expire", reservationHandler.ExpireHolds)
+			reservations.GET("/game/:id", reservationHandler.GetGameQueue)
+			reservations.GET("/user/:id", reservationHandler.GetUserReservations)
+			reservations.GET("/:id", reservationHandler.GetReservation)
+			reservations.PUT("/:id/cancel", reservationHandler.CancelHold)
 		}
 
-		// Build games options
-		gamesOptions := ""
-		for _, game := range games {
-			gamesOptions += fmt.Sprintf(`<option value="%d">%s (%s)</option>`, game.ID, game.Name, game.Category)
+		// Loan policy API routes
+		loanPolicies := api.Group("/loan-policies")
+		{
+			loanPolicies.GET("", loanPolicyHandler.GetPolicies)
+			loanPolicies.POST("", loanPolicyHandler.CreatePolicy)
+			loanPolicies.GET("/:tier", loanPolicyHandler.GetPolicy)
+			loanPolicies.PUT("/:tier", loanPolicyHandler.UpdatePolicy)
 		}
 
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Alertes - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-6xl mx-auto space-y-6">
-            <!-- En-tête -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <div class="flex justify-between items-center">
-                    <h1 class="text-3xl font-bold text-red-600">Gestion des Alertes</h1>
-                    <a href="/" class="bg-gray-500 hover:bg-gray-600 text-white px-4 py-2 rounded">Retour à l'accueil</a>
-                </div>
-                <p class="text-gray-600 mt-2">Alertes actives : %d</p>
-            </div>
+		// Audit log API routes
+		auditHandler.RegisterRoutes(api)
 
-            <!-- Actions rapides -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <h2 class="text-xl font-semibold text-red-600 mb-4">⚡ Actions Rapides</h2>
-                <div class="grid grid-cols-1 md:grid-cols-3 gap-4">
-                    <form action="/alerts/generate-overdue" method="POST" style="display: inline;">
-                        <button type="submit" class="w-full bg-red-500 hover:bg-red-600 text-white font-medium py-2 px-4 rounded-md transition-colors">
-                            ⚠️ Générer Alertes Retard
-                        </button>
-                    </form>
-                    <form action="/alerts/generate-reminders" method="POST" style="display: inline;">
-                        <button type="submit" class="w-full bg-yellow-500 hover:bg-yellow-600 text-white font-medium py-2 px-4 rounded-md transition-colors">
-                            ⏰ Générer Rappels
-                        </button>
-                    </form>
-                    <form action="/alerts/cleanup" method="POST" style="display: inline;">
-                        <button type="submit" class="w-full bg-blue-500 hover:bg-blue-600 text-white font-medium py-2 px-4 rounded-md transition-colors">
-                            🧹 Nettoyer Alertes Résolues
-                        </button>
-                    </form>
-                </div>
-            </div>
+		// Tag API routes
+		tagHandler.RegisterRoutes(api)
 
-            <!-- Formulaire de nouvelle alerte personnalisée -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <h2 class="text-xl font-semibold text-red-600 mb-4">📝 Créer une Alerte Personnalisée</h2>
-                <form action="/alerts/create" method="POST" class="grid grid-cols-1 md:grid-cols-2 gap-4">
-                    <div>
-                        <label for="user_id" class="block text-sm font-medium text-gray-700 mb-1">Utilisateur *</label>
-                        <select id="user_id" name="user_id" required 
-                                class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-red-500">
-                            <option value="">Sélectionner un utilisateur</option>
-                            %s
-                        </select>
-                    </div>
-                    <div>
-                        <label for="game_id" class="block text-sm font-medium text-gray-700 mb-1">Jeu *</label>
-                        <select id="game_id" name="game_id" required 
-                                class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-red-500">
-                            <option value="">Sélectionner un jeu</option>
-                            %s
-                        </select>
-                    </div>
-                    <div class="md:col-span-2">
-                        <label for="message" class="block text-sm font-medium text-gray-700 mb-1">Message *</label>
-                        <textarea id="message" name="message" rows="3" required
-                                  class="w-full px-3 py-2 border border-gray-300 rounded-md focus:outline-none focus:ring-2 focus:ring-red-500"
-                                  placeholder="Saisir le message de l'alerte personnalisée"></textarea>
-                    </div>
-                    <div class="md:col-span-2">
-                        <button type="submit" 
-                                class="w-full md:w-auto bg-red-500 hover:bg-red-600 text-white font-medium py-2 px-6 rounded-md transition-colors">
-                            ➕ Créer l'Alerte
-                        </button>
-                    </div>
-                </form>
-            </div>
+		// Bulk import and export API routes
+		transferHandler.RegisterRoutes(api)
 
-            <!-- Liste des alertes -->
-            <div class="bg-white rounded-lg shadow-lg p-6">
-                <h2 class="text-xl font-semibold text-red-600 mb-4">🚨 Alertes Actives</h2>
-                %s
-            </div>
+		// Email notification API routes
+		notificationHandler.RegisterRoutes(api)
 
-            <!-- Informations API -->
-            <div class="bg-gray-50 rounded-lg p-4 text-center">
-                <h3 class="text-lg font-semibold mb-2">Accès API</h3>
-                <div class="space-x-4 text-sm">
-                    <span class="text-gray-500">API REST disponible pour les opérations avancées</span>
-                </div>
-            </div>
-        </div>
-    </div>
-</body>
-</html>`, len(alerts), usersOptions, gamesOptions, alertsHTML)
-	})
+		// Fines API routes
+		fineHandler.RegisterRoutes(api)
+	}
 }
-
-// setupFormRoutes configures form submission routes
-func setupFormRoutes(router *gin.Engine, gameService *services.GameService, userService *services.UserService, borrowingService *services.BorrowingService, alertService *services.AlertService) {
-	// Create new game
-	router.POST("/games/create", func(c *gin.Context) {
-		name := strings.TrimSpace(c.PostForm("name"))
-		description := strings.TrimSpace(c.PostForm("description"))
-		category := strings.TrimSpace(c.PostForm("category"))
-		condition := strings.TrimSpace(c.PostForm("condition"))
-		
-		if name == "" || category == "" || condition == "" {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Veuillez remplir tous les champs obligatoires (Nom, Catégorie et État).</p>
-            <a href="/games" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Jeux</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-		
-		game, err := gameService.AddGame(name, description, category, condition)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec de la création du jeu : %s</p>
-            <a href="/games" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Jeux</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="3;url=/games">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Succès !</h1>
-            <p class="text-gray-600 mb-4">Le jeu "%s" a été ajouté avec succès à la bibliothèque !</p>
-            <div class="bg-green-50 border border-green-200 rounded-lg p-4 mb-4">
-                <h3 class="font-semibold text-green-800">Détails du Jeu :</h3>
-                <ul class="text-green-700 mt-2">
-                    <li><strong>Nom :</strong> %s</li>
-                    <li><strong>Catégorie :</strong> %s</li>
-                    <li><strong>État :</strong> %s</li>
-                    <li><strong>Description :</strong> %s</li>
-                </ul>
-            </div>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des jeux dans 3 secondes...</p>
-            <a href="/games" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Jeux</a>
-        </div>
-    </div>
-</body>
-</html>`, game.Name, game.Name, game.Category, game.Condition, game.Description)
-	})
-	
-	// Delete game
-	router.POST("/games/:id/delete", func(c *gin.Context) {
-		idParam := c.Param("id")
-		gameID, err := strconv.Atoi(idParam)
-		
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">ID de jeu invalide.</p>
-            <a href="/games" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Jeux</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-		
-		err = gameService.DeleteGame(gameID)
-		if err != nil {
-			// Determine the appropriate error message and styling
-			errorTitle := "❌ Erreur"
-			errorClass := "text-red-600"
-			
-			// Check if it's a constraint-related error
-			if strings.Contains(err.Error(), "borrowing history") || strings.Contains(err.Error(), "associated records") || strings.Contains(err.Error(), "currently borrowed") {
-				errorTitle = "⚠️ Suppression Impossible"
-				errorClass = "text-yellow-600"
-			}
-			
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusConflict, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold %s mb-4">%s</h1>
-            <div class="bg-yellow-50 border border-yellow-200 rounded-lg p-4 mb-4">
-                <p class="text-gray-800 mb-2"><strong>Raison :</strong></p>
-                <p class="text-gray-700">%s</p>
-            </div>
-            <div class="bg-blue-50 border border-blue-200 rounded-lg p-4 mb-4">
-                <h3 class="font-semibold text-blue-800 mb-2">💡 Que faire ?</h3>
-                <ul class="text-blue-700 space-y-1">
-                    <li>• Les jeux avec un historique d'emprunts ne peuvent pas être supprimés</li>
-                    <li>• Cela préserve l'intégrité des données et l'historique</li>
-                    <li>• Vous pouvez marquer le jeu comme indisponible si nécessaire</li>
-                </ul>
-            </div>
-            <a href="/games" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Jeux</a>
-        </div>
-    </div>
-</body>
-</html>`, errorClass, errorTitle, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="2;url=/games">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Jeu Supprimé !</h1>
-            <p class="text-gray-600 mb-4">Le jeu a été supprimé avec succès de la bibliothèque.</p>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des jeux dans 2 secondes...</p>
-            <a href="/games" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Jeux</a>
-        </div>
-    </div>
-</body>
-</html>`)
-	})
-	
-	// Create new user
-	router.POST("/users/create", func(c *gin.Context) {
-		name := strings.TrimSpace(c.PostForm("name"))
-		email := strings.TrimSpace(c.PostForm("email"))
-		
-		if name == "" || email == "" {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Veuillez remplir tous les champs obligatoires (Nom et Email).</p>
-            <a href="/users" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Utilisateurs</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-		
-		user, err := userService.RegisterUser(name, email)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec de la création de l'utilisateur : %s</p>
-            <a href="/users" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Utilisateurs</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="3;url=/users">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Succès !</h1>
-            <p class="text-gray-600 mb-4">L'utilisateur "%s" a été inscrit avec succès !</p>
-            <div class="bg-green-50 border border-green-200 rounded-lg p-4 mb-4">
-                <h3 class="font-semibold text-green-800">Détails de l'Utilisateur :</h3>
-                <ul class="text-green-700 mt-2">
-                    <li><strong>Nom :</strong> %s</li>
-                    <li><strong>Email :</strong> %s</li>
-                    <li><strong>ID Utilisateur :</strong> %d</li>
-                    <li><strong>Statut :</strong> Actif</li>
-                </ul>
-            </div>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des utilisateurs dans 3 secondes...</p>
-            <a href="/users" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Utilisateurs</a>
-        </div>
-    </div>
-</body>
-</html>`, user.Name, user.Name, user.Email, user.ID)
-	})
-	
-	// Delete user
-	router.POST("/users/:id/delete", func(c *gin.Context) {
-		idParam := c.Param("id")
-		userID, err := strconv.Atoi(idParam)
-		
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">ID d'utilisateur invalide.</p>
-            <a href="/users" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Utilisateurs</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-		
-		err = userService.DeleteUser(userID)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec de la suppression de l'utilisateur : %s</p>
-            <a href="/users" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Utilisateurs</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="2;url=/users">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Utilisateur Supprimé !</h1>
-            <p class="text-gray-600 mb-4">L'utilisateur a été supprimé avec succès du système.</p>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des utilisateurs dans 2 secondes...</p>
-            <a href="/users" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Utilisateurs</a>
-        </div>
-    </div>
-</body>
-</html>`)
-	})
-
-	// Create new borrowing
-	router.POST("/borrowings/create", func(c *gin.Context) {
-		userIDStr := strings.TrimSpace(c.PostForm("user_id"))
-		gameIDStr := strings.TrimSpace(c.PostForm("game_id"))
-		durationDaysStr := strings.TrimSpace(c.PostForm("duration_days"))
-		
-		if userIDStr == "" || gameIDStr == "" || durationDaysStr == "" {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Veuillez sélectionner un utilisateur, un jeu et une durée d'emprunt.</p>
-            <a href="/borrowings" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Emprunts</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-
-		userID, err := strconv.Atoi(userIDStr)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">ID utilisateur invalide.</p>
-            <a href="/borrowings" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Emprunts</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-
-		gameID, err := strconv.Atoi(gameIDStr)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">ID jeu invalide.</p>
-            <a href="/borrowings" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Emprunts</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-
-		durationDays, err := strconv.Atoi(durationDaysStr)
-		if err != nil || durationDays <= 0 {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Durée d'emprunt invalide.</p>
-            <a href="/borrowings" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Emprunts</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-
-		// Calculer la date d'échéance
-		dueDate := time.Now().Add(time.Duration(durationDays) * 24 * time.Hour)
-		borrowing, err := borrowingService.BorrowGame(userID, gameID, dueDate)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec de la création de l'emprunt : %s</p>
-            <a href="/borrowings" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Emprunts</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="3;url=/borrowings">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Emprunt Créé !</h1>
-            <p class="text-gray-600 mb-4">L'emprunt a été créé avec succès !</p>
-            <div class="bg-green-50 border border-green-200 rounded-lg p-4 mb-4">
-                <h3 class="font-semibold text-green-800">Détails de l'Emprunt :</h3>
-                <ul class="text-green-700 mt-2">
-                    <li><strong>ID Emprunt :</strong> %d</li>
-                    <li><strong>Utilisateur :</strong> %d</li>
-                    <li><strong>Jeu :</strong> %d</li>
-                    <li><strong>Date d'emprunt :</strong> %s</li>
-                    <li><strong>Date d'échéance :</strong> %s</li>
-                    <li><strong>Durée :</strong> %d jours</li>
-                </ul>
-            </div>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des emprunts dans 3 secondes...</p>
-            <a href="/borrowings" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Emprunts</a>
-        </div>
-    </div>
-</body>
-</html>`, borrowing.ID, borrowing.UserID, borrowing.GameID, borrowing.BorrowedAt.Format("2006-01-02 15:04"), borrowing.DueDate.Format("2006-01-02"), durationDays)
-	})
-
-	// Return borrowing
-	router.POST("/borrowings/:id/return", func(c *gin.Context) {
-		idParam := c.Param("id")
-		borrowingID, err := strconv.Atoi(idParam)
-		
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">ID d'emprunt invalide.</p>
-            <a href="/borrowings" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Emprunts</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-		
-		err = borrowingService.ReturnGame(borrowingID)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec du retour de l'emprunt : %s</p>
-            <a href="/borrowings" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Emprunts</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="2;url=/borrowings">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Jeu Retourné !</h1>
-            <p class="text-gray-600 mb-4">Le jeu a été retourné avec succès.</p>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des emprunts dans 2 secondes...</p>
-            <a href="/borrowings" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Emprunts</a>
-        </div>
-    </div>
-</body>
-</html>`)
-	})
-
-	// Create custom alert
-	router.POST("/alerts/create", func(c *gin.Context) {
-		userIDStr := strings.TrimSpace(c.PostForm("user_id"))
-		gameIDStr := strings.TrimSpace(c.PostForm("game_id"))
-		message := strings.TrimSpace(c.PostForm("message"))
-		
-		if userIDStr == "" || gameIDStr == "" || message == "" {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Veuillez remplir tous les champs obligatoires.</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-
-		userID, err := strconv.Atoi(userIDStr)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">ID utilisateur invalide.</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-
-		gameID, err := strconv.Atoi(gameIDStr)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">ID jeu invalide.</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-		
-		alert, err := alertService.CreateCustomAlert(userID, gameID, "custom", message)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec de la création de l'alerte : %s</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="3;url=/alerts">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Alerte Créée !</h1>
-            <p class="text-gray-600 mb-4">L'alerte personnalisée a été créée avec succès !</p>
-            <div class="bg-green-50 border border-green-200 rounded-lg p-4 mb-4">
-                <h3 class="font-semibold text-green-800">Détails de l'Alerte :</h3>
-                <ul class="text-green-700 mt-2">
-                    <li><strong>ID Alerte :</strong> %d</li>
-                    <li><strong>Utilisateur :</strong> %d</li>
-                    <li><strong>Jeu :</strong> %d</li>
-                    <li><strong>Type :</strong> %s</li>
-                    <li><strong>Message :</strong> %s</li>
-                </ul>
-            </div>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des alertes dans 3 secondes...</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`, alert.ID, alert.UserID, alert.GameID, alert.Type, alert.Message)
-	})
-
-	// Mark alert as read
-	router.POST("/alerts/:id/read", func(c *gin.Context) {
-		idParam := c.Param("id")
-		alertID, err := strconv.Atoi(idParam)
-		
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">ID d'alerte invalide.</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-		
-		err = alertService.MarkAlertAsRead(alertID)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec du marquage de l'alerte : %s</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="2;url=/alerts">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Alerte Marquée !</h1>
-            <p class="text-gray-600 mb-4">L'alerte a été marquée comme lue avec succès.</p>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des alertes dans 2 secondes...</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`)
-	})
-
-	// Delete alert
-	router.POST("/alerts/:id/delete", func(c *gin.Context) {
-		idParam := c.Param("id")
-		alertID, err := strconv.Atoi(idParam)
-		
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusBadRequest, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">ID d'alerte invalide.</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`)
-			return
-		}
-		
-		err = alertService.DeleteAlert(alertID)
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec de la suppression de l'alerte : %s</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="2;url=/alerts">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Alerte Supprimée !</h1>
-            <p class="text-gray-600 mb-4">L'alerte a été supprimée avec succès.</p>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des alertes dans 2 secondes...</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`)
-	})
-
-	// Generate overdue alerts
-	router.POST("/alerts/generate-overdue", func(c *gin.Context) {
-		err := alertService.GenerateOverdueAlerts()
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec de la génération des alertes de retard : %s</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="2;url=/alerts">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Alertes Générées !</h1>
-            <p class="text-gray-600 mb-4">Les alertes de retard ont été générées avec succès.</p>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des alertes dans 2 secondes...</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`)
-	})
-
-	// Generate reminder alerts
-	router.POST("/alerts/generate-reminders", func(c *gin.Context) {
-		err := alertService.GenerateReminderAlerts()
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec de la génération des rappels : %s</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="2;url=/alerts">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Rappels Générés !</h1>
-            <p class="text-gray-600 mb-4">Les rappels ont été générés avec succès.</p>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des alertes dans 2 secondes...</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`)
-	})
-
-	// Cleanup resolved alerts
-	router.POST("/alerts/cleanup", func(c *gin.Context) {
-		err := alertService.CleanupResolvedAlerts()
-		if err != nil {
-			c.Header("Content-Type", "text/html")
-			c.String(http.StatusInternalServerError, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Erreur - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-red-600 mb-4">❌ Erreur</h1>
-            <p class="text-gray-600 mb-4">Échec du nettoyage des alertes : %s</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`, err.Error())
-			return
-		}
-		
-		c.Header("Content-Type", "text/html")
-		c.String(http.StatusOK, `
-<!DOCTYPE html>
-<html lang="fr">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Succès - Bibliothèque de Jeux de Société</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <meta http-equiv="refresh" content="2;url=/alerts">
-</head>
-<body class="bg-gray-100">
-    <div class="container mx-auto px-4 py-8">
-        <div class="max-w-4xl mx-auto bg-white rounded-lg shadow-lg p-6">
-            <h1 class="text-3xl font-bold text-green-600 mb-4">✅ Nettoyage Effectué !</h1>
-            <p class="text-gray-600 mb-4">Les alertes résolues ont été nettoyées avec succès.</p>
-            <p class="text-sm text-gray-500 mb-4">Vous serez redirigé vers la page des alertes dans 2 secondes...</p>
-            <a href="/alerts" class="bg-blue-500 hover:bg-blue-600 text-white px-4 py-2 rounded">← Retour aux Alertes</a>
-        </div>
-    </div>
-</body>
-</html>`)
-	})
-}
-
-// setupAPIRoutes configures API routes
-func setupAPIRoutes(router *gin.Engine,
-	gameHandler *handlers.GameHandler,
-	userHandler *handlers.UserHandler,
-	borrowingHandler *handlers.BorrowingHandler,
-	alertHandler *handlers.AlertHandler) {
-
-	api := router.Group("/api/v1")
-	{
-		// Game API routes
-		games := api.Group("/games")
-		{
-			games.POST("", gameHandler.AddGame)
-			games.GET("", gameHandler.GetAllGames)
-			games.GET("/search", gameHandler.SearchGames)
-			games.GET("/:id", gameHandler.GetGame)
-			games.PUT("/:id", gameHandler.UpdateGame)
-			games.DELETE("/:id", gameHandler.DeleteGame)
-			games.GET("/:id/borrowings", gameHandler.GetGameBorrowingHistory)
-			games.GET("/:id/availability", gameHandler.GetGameAvailability)
-		}
-
-		// User API routes
-		users := api.Group("/users")
-		{
-			users.POST("", userHandler.RegisterUser)
-			users.GET("", userHandler.GetAllUsers)
-			users.GET("/:id", userHandler.GetUser)
-			users.PUT("/:id", userHandler.UpdateUser)
-			users.GET("/:id/borrowings", userHandler.GetUserBorrowings)
-			users.GET("/:id/current-loans", userHandler.GetUserCurrentLoans)
-			users.GET("/:id/eligibility", userHandler.CheckUserEligibility)
-		}
-
-		// Borrowing API routes
-		borrowings := api.Group("/borrowings")
-		{
-			borrowings.POST("", borrowingHandler.BorrowGame)
-			borrowings.GET("/:id", borrowingHandler.GetBorrowingDetails)
-			borrowings.PUT("/:id/return", borrowingHandler.ReturnGame)
-			borrowings.PUT("/:id/extend", borrowingHandler.ExtendDueDate)
-			borrowings.GET("/overdue", borrowingHandler.GetOverdueItems)
-			borrowings.GET("/due-soon", borrowingHandler.GetItemsDueSoon)
-			borrowings.GET("/user/:id", borrowingHandler.GetActiveBorrowingsByUser)
-			borrowings.GET("/game/:id", borrowingHandler.GetBorrowingsByGame)
-			borrowings.POST("/update-overdue", borrowingHandler.UpdateOverdueStatus)
-		}
-
-		// Alert API routes
-		alerts := api.Group("/alerts")
-		{
-			alerts.GET("", alertHandler.GetActiveAlerts)
-			alerts.GET("/user/:id", alertHandler.GetAlertsByUser)
-			alerts.PUT("/:id/read", alertHandler.MarkAlertAsRead)
-			alerts.PUT("/user/:id/read-all", alertHandler.MarkAllUserAlertsAsRead)
-			alerts.DELETE("/:id", alertHandler.DeleteAlert)
-			alerts.GET("/summary", alertHandler.GetAlertsSummary)
-			alerts.GET("/dashboard", alertHandler.GetDashboard)
-			alerts.POST("", alertHandler.CreateCustomAlert)
-		}
-	}
-}
-
-// setupTemplateFunctions configures template functions and loads templates
-func setupTemplateFunctions(router *gin.Engine) {
-	// Define custom template functions
-	funcMap := template.FuncMap{
-		"substr": func(s string, start, length int) string {
-			if start < 0 || start >= len(s) {
-				return ""
-			}
-			end := start + length
-			if end > len(s) {
-				end = len(s)
-			}
-			return s[start:end]
-		},
-		"title": strings.Title,
-		"upper": strings.ToUpper,
-		"lower": strings.ToLower,
-		"contains": strings.Contains,
-		"hasPrefix": strings.HasPrefix,
-		"hasSuffix": strings.HasSuffix,
-		"join": strings.Join,
-		"split": strings.Split,
-		"replace": strings.ReplaceAll,
-		"trim": strings.TrimSpace,
-	}
-
-	// Load templates with custom functions
-	tmpl := template.Must(template.New("").Funcs(funcMap).ParseGlob("web/templates/*/*.html"))
-	router.SetHTMLTemplate(tmpl)
-}
\ No newline at end of file
@@ -3,6 +3,7 @@ package services
 import (
 	"board-game-library/internal/models"
 	"board-game-library/internal/repositories"
+	"context"
 	"fmt"
 	"time"
 )
@@ -13,6 +14,7 @@ type AlertService struct {
 	borrowingRepo repositories.BorrowingRepository
 	userRepo      repositories.UserRepository
 	gameRepo      repositories.GameRepository
+	auditTrail
 }
 
 // NewAlertService creates a new AlertService instance
@@ -25,10 +27,18 @@ func NewAlertService(alertRepo repositories.AlertRepository, borrowingRepo repos
 	}
 }
 
+// WithActor returns a copy of the service that records actor as the author
+// of its changes in the audit log
+func (s *AlertService) WithActor(actor *models.User) *AlertService {
+	bound := *s
+	bound.actor = actor
+	return &bound
+}
+
 // GenerateOverdueAlerts creates alerts for all overdue items
-func (s *AlertService) GenerateOverdueAlerts() error {
+func (s *AlertService) GenerateOverdueAlerts(ctx context.Context) error {
 	// Get all overdue borrowings
-	overdueBorrowings, err := s.borrowingRepo.GetOverdue()
+	overdueBorrowings, err := s.borrowingRepo.GetOverdue(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get overdue borrowings: %w", err)
 	}
@@ -44,7 +54,7 @@ func (s *AlertService) GenerateOverdueAlerts() error {
 	// Create alerts for each overdue item
 	for _, borrowing := range activeOverdue {
 		// Check if alert already exists for this borrowing
-		existingAlerts, err := s.alertRepo.GetByUser(borrowing.UserID)
+		existingAlerts, err := s.alertRepo.GetByUser(ctx, borrowing.UserID)
 		if err != nil {
 			return fmt.Errorf("failed to check existing alerts for user %d: %w", borrowing.UserID, err)
 		}
@@ -60,7 +70,7 @@ func (s *AlertService) GenerateOverdueAlerts() error {
 
 		if !hasExistingAlert {
 			// Get game details for the alert message
-			game, err := s.gameRepo.GetByID(borrowing.GameID)
+			game, err := s.gameRepo.GetByID(ctx, borrowing.GameID)
 			if err != nil {
 				return fmt.Errorf("failed to get game details for alert: %w", err)
 			}
@@ -83,7 +93,7 @@ func (s *AlertService) GenerateOverdueAlerts() error {
 				return fmt.Errorf("alert validation failed: %w", err)
 			}
 
-			if err := s.alertRepo.Create(alert); err != nil {
+			if err := s.alertRepo.Create(ctx, alert); err != nil {
 				return fmt.Errorf("failed to create overdue alert: %w", err)
 			}
 		}
@@ -93,9 +103,9 @@ func (s *AlertService) GenerateOverdueAlerts() error {
 }
 
 // GenerateReminderAlerts creates reminder alerts for items due within 2 days
-func (s *AlertService) GenerateReminderAlerts() error {
+func (s *AlertService) GenerateReminderAlerts(ctx context.Context) error {
 	// Get all active borrowings
-	allBorrowings, err := s.borrowingRepo.GetAll()
+	allBorrowings, err := s.borrowingRepo.GetAll(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get all borrowings: %w", err)
 	}
@@ -115,7 +125,7 @@ func (s *AlertService) GenerateReminderAlerts() error {
 	// Create reminder alerts for each item due soon
 	for _, borrowing := range itemsDueSoon {
 		// Check if reminder alert already exists for this borrowing
-		existingAlerts, err := s.alertRepo.GetByUser(borrowing.UserID)
+		existingAlerts, err := s.alertRepo.GetByUser(ctx, borrowing.UserID)
 		if err != nil {
 			return fmt.Errorf("failed to check existing alerts for user %d: %w", borrowing.UserID, err)
 		}
@@ -131,7 +141,7 @@ func (s *AlertService) GenerateReminderAlerts() error {
 
 		if !hasExistingAlert {
 			// Get game details for the alert message
-			game, err := s.gameRepo.GetByID(borrowing.GameID)
+			game, err := s.gameRepo.GetByID(ctx, borrowing.GameID)
 			if err != nil {
 				return fmt.Errorf("failed to get game details for alert: %w", err)
 			}
@@ -163,7 +173,7 @@ func (s *AlertService) GenerateReminderAlerts() error {
 				return fmt.Errorf("alert validation failed: %w", err)
 			}
 
-			if err := s.alertRepo.Create(alert); err != nil {
+			if err := s.alertRepo.Create(ctx, alert); err != nil {
 				return fmt.Errorf("failed to create reminder alert: %w", err)
 			}
 		}
@@ -173,8 +183,8 @@ func (s *AlertService) GenerateReminderAlerts() error {
 }
 
 // GetActiveAlerts retrieves all unread alerts
-func (s *AlertService) GetActiveAlerts() ([]*models.Alert, error) {
-	alerts, err := s.alertRepo.GetUnread()
+func (s *AlertService) GetActiveAlerts(ctx context.Context) ([]*models.Alert, error) {
+	alerts, err := s.alertRepo.GetUnread(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active alerts: %w", err)
 	}
@@ -182,19 +192,43 @@ func (s *AlertService) GetActiveAlerts() ([]*models.Alert, error) {
 	return alerts, nil
 }
 
+// ListAlerts retrieves one page of the alerts matching filter along with the
+// number of matches across all pages
+func (s *AlertService) ListAlerts(ctx context.Context, filter models.AlertFilter) ([]*models.Alert, int, error) {
+	if err := models.ValidateAlertFilter(&filter); err != nil {
+		return nil, 0, fmt.Errorf("invalid alert filter: %w", err)
+	}
+
+	alerts, err := s.alertRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	total, err := s.alertRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count alerts: %w", err)
+	}
+
+	if alerts == nil {
+		alerts = []*models.Alert{}
+	}
+
+	return alerts, total, nil
+}
+
 // GetAlertsByUser retrieves all alerts for a specific user
-func (s *AlertService) GetAlertsByUser(userID int) ([]*models.Alert, error) {
+func (s *AlertService) GetAlertsByUser(ctx context.Context, userID int) ([]*models.Alert, error) {
 	if userID <= 0 {
-		return nil, fmt.Errorf("invalid user ID: %d", userID)
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
 	}
 
 	// Verify user exists
-	_, err := s.userRepo.GetByID(userID)
+	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	alerts, err := s.alertRepo.GetByUser(userID)
+	alerts, err := s.alertRepo.GetByUser(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user alerts: %w", err)
 	}
@@ -203,38 +237,38 @@ func (s *AlertService) GetAlertsByUser(userID int) ([]*models.Alert, error) {
 }
 
 // MarkAlertAsRead marks a specific alert as read
-func (s *AlertService) MarkAlertAsRead(alertID int) error {
+func (s *AlertService) MarkAlertAsRead(ctx context.Context, alertID int) error {
 	if alertID <= 0 {
-		return fmt.Errorf("invalid alert ID: %d", alertID)
+		return models.Invalid("alert_id", "invalid_alert_id", alertID)
 	}
 
 	// Verify alert exists
-	_, err := s.alertRepo.GetByID(alertID)
+	alert, err := s.alertRepo.GetByID(ctx, alertID)
 	if err != nil {
 		return fmt.Errorf("alert not found: %w", err)
 	}
 
-	if err := s.alertRepo.MarkAsRead(alertID); err != nil {
+	if err := s.alertRepo.MarkAsRead(ctx, alertID); err != nil {
 		return fmt.Errorf("failed to mark alert as read: %w", err)
 	}
 
-	return nil
+	return s.recordRead(ctx, alert)
 }
 
 // MarkAllUserAlertsAsRead marks all alerts for a user as read
-func (s *AlertService) MarkAllUserAlertsAsRead(userID int) error {
+func (s *AlertService) MarkAllUserAlertsAsRead(ctx context.Context, userID int) error {
 	if userID <= 0 {
-		return fmt.Errorf("invalid user ID: %d", userID)
+		return models.Invalid("user_id", "invalid_user_id", userID)
 	}
 
 	// Verify user exists
-	_, err := s.userRepo.GetByID(userID)
+	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("user not found: %w", err)
 	}
 
 	// Get all user alerts
-	alerts, err := s.alertRepo.GetByUser(userID)
+	alerts, err := s.alertRepo.GetByUser(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("failed to get user alerts: %w", err)
 	}
@@ -242,46 +276,66 @@ func (s *AlertService) MarkAllUserAlertsAsRead(userID int) error {
 	// Mark each unread alert as read
 	for _, alert := range alerts {
 		if !alert.IsRead {
-			if err := s.alertRepo.MarkAsRead(alert.ID); err != nil {
+			if err := s.alertRepo.MarkAsRead(ctx, alert.ID); err != nil {
 				return fmt.Errorf("failed to mark alert %d as read: %w", alert.ID, err)
 			}
+			if err := s.recordRead(ctx, alert); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// recordRead records that an alert was marked as read
+func (s *AlertService) recordRead(ctx context.Context, alert *models.Alert) error {
+	if alert == nil {
+		return nil
+	}
+
+	read := *alert
+	read.IsRead = true
+	return s.record(ctx, models.AuditActionMarkRead, models.AuditEntityAlert, alert.ID, alert, &read)
+}
+
 // DeleteAlert removes an alert
-func (s *AlertService) DeleteAlert(alertID int) error {
+func (s *AlertService) DeleteAlert(ctx context.Context, alertID int) error {
 	if alertID <= 0 {
-		return fmt.Errorf("invalid alert ID: %d", alertID)
+		return models.Invalid("alert_id", "invalid_alert_id", alertID)
 	}
 
 	// Verify alert exists
-	_, err := s.alertRepo.GetByID(alertID)
+	alert, err := s.alertRepo.GetByID(ctx, alertID)
 	if err != nil {
 		return fmt.Errorf("alert not found: %w", err)
 	}
 
-	if err := s.alertRepo.Delete(alertID); err != nil {
+	if err := s.alertRepo.Delete(ctx, alertID); err != nil {
 		return fmt.Errorf("failed to delete alert: %w", err)
 	}
 
-	return nil
+	return s.record(ctx, models.AuditActionDelete, models.AuditEntityAlert, alertID, alert, nil)
 }
 
 // CleanupResolvedAlerts removes alerts for items that have been returned
-func (s *AlertService) CleanupResolvedAlerts() error {
+func (s *AlertService) CleanupResolvedAlerts(ctx context.Context) error {
 	// Get all alerts
-	allAlerts, err := s.alertRepo.GetAll()
+	allAlerts, err := s.alertRepo.GetAll(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get all alerts: %w", err)
 	}
 
 	// Check each alert to see if the associated borrowing has been resolved
 	for _, alert := range allAlerts {
+		// Hold alerts are about a reservation and damage alerts about the
+		// condition of a game, not a borrowing
+		if alert.Type == "hold" || alert.Type == "damage" {
+			continue
+		}
+
 		// Get borrowings for this game by this user
-		borrowings, err := s.borrowingRepo.GetByGame(alert.GameID)
+		borrowings, err := s.borrowingRepo.GetByGame(ctx, alert.GameID)
 		if err != nil {
 			continue // Skip this alert if we can't check borrowings
 		}
@@ -297,9 +351,12 @@ func (s *AlertService) CleanupResolvedAlerts() error {
 
 		// If no active borrowing, delete the alert
 		if !hasActiveBorrowing {
-			if err := s.alertRepo.Delete(alert.ID); err != nil {
+			if err := s.alertRepo.Delete(ctx, alert.ID); err != nil {
 				return fmt.Errorf("failed to delete resolved alert %d: %w", alert.ID, err)
 			}
+			if err := s.record(ctx, models.AuditActionCleanup, models.AuditEntityAlert, alert.ID, alert, nil); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -307,9 +364,9 @@ func (s *AlertService) CleanupResolvedAlerts() error {
 }
 
 // GetAlertsSummaryByUser returns a summary of alerts grouped by user
-func (s *AlertService) GetAlertsSummaryByUser() (map[int]AlertSummary, error) {
+func (s *AlertService) GetAlertsSummaryByUser(ctx context.Context) (map[int]AlertSummary, error) {
 	// Get all unread alerts
-	alerts, err := s.alertRepo.GetUnread()
+	alerts, err := s.alertRepo.GetUnread(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get unread alerts: %w", err)
 	}
@@ -344,22 +401,22 @@ type AlertSummary struct {
 }
 
 // CreateCustomAlert creates a custom alert for a user
-func (s *AlertService) CreateCustomAlert(userID, gameID int, alertType, message string) (*models.Alert, error) {
+func (s *AlertService) CreateCustomAlert(ctx context.Context, userID, gameID int, alertType, message string) (*models.Alert, error) {
 	if userID <= 0 {
-		return nil, fmt.Errorf("invalid user ID: %d", userID)
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
 	}
 	if gameID <= 0 {
-		return nil, fmt.Errorf("invalid game ID: %d", gameID)
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
 	}
 
 	// Verify user exists
-	_, err := s.userRepo.GetByID(userID)
+	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
 	// Verify game exists
-	_, err = s.gameRepo.GetByID(gameID)
+	_, err = s.gameRepo.GetByID(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("game not found: %w", err)
 	}
@@ -380,9 +437,13 @@ func (s *AlertService) CreateCustomAlert(userID, gameID int, alertType, message
 	}
 
 	// Create alert in repository
-	if err := s.alertRepo.Create(alert); err != nil {
+	if err := s.alertRepo.Create(ctx, alert); err != nil {
 		return nil, fmt.Errorf("failed to create alert: %w", err)
 	}
 
+	if err := s.record(ctx, models.AuditActionCreate, models.AuditEntityAlert, alert.ID, nil, alert); err != nil {
+		return nil, err
+	}
+
 	return alert, nil
 }
\ No newline at end of file
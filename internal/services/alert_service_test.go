@@ -2,6 +2,7 @@ package services
 
 import (
 	"board-game-library/internal/models"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -15,12 +16,12 @@ type MockAlertRepository struct {
 	mock.Mock
 }
 
-func (m *MockAlertRepository) Create(alert *models.Alert) error {
+func (m *MockAlertRepository) Create(ctx context.Context, alert *models.Alert) error {
 	args := m.Called(alert)
 	return args.Error(0)
 }
 
-func (m *MockAlertRepository) GetByID(id int) (*models.Alert, error) {
+func (m *MockAlertRepository) GetByID(ctx context.Context, id int) (*models.Alert, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -28,7 +29,7 @@ func (m *MockAlertRepository) GetByID(id int) (*models.Alert, error) {
 	return args.Get(0).(*models.Alert), args.Error(1)
 }
 
-func (m *MockAlertRepository) GetUnread() ([]*models.Alert, error) {
+func (m *MockAlertRepository) GetUnread(ctx context.Context) ([]*models.Alert, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -36,7 +37,7 @@ func (m *MockAlertRepository) GetUnread() ([]*models.Alert, error) {
 	return args.Get(0).([]*models.Alert), args.Error(1)
 }
 
-func (m *MockAlertRepository) GetByUser(userID int) ([]*models.Alert, error) {
+func (m *MockAlertRepository) GetByUser(ctx context.Context, userID int) ([]*models.Alert, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -44,7 +45,7 @@ func (m *MockAlertRepository) GetByUser(userID int) ([]*models.Alert, error) {
 	return args.Get(0).([]*models.Alert), args.Error(1)
 }
 
-func (m *MockAlertRepository) GetAll() ([]*models.Alert, error) {
+func (m *MockAlertRepository) GetAll(ctx context.Context) ([]*models.Alert, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -52,12 +53,25 @@ func (m *MockAlertRepository) GetAll() ([]*models.Alert, error) {
 	return args.Get(0).([]*models.Alert), args.Error(1)
 }
 
-func (m *MockAlertRepository) MarkAsRead(id int) error {
+func (m *MockAlertRepository) List(ctx context.Context, filter models.AlertFilter) ([]*models.Alert, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Alert), args.Error(1)
+}
+
+func (m *MockAlertRepository) Count(ctx context.Context, filter models.AlertFilter) (int, error) {
+	args := m.Called(filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAlertRepository) MarkAsRead(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockAlertRepository) Delete(id int) error {
+func (m *MockAlertRepository) Delete(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
@@ -78,6 +92,7 @@ func TestNewAlertService(t *testing.T) {
 }
 
 func TestAlertService_GenerateOverdueAlerts(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		setupMocks    func(*MockAlertRepository, *MockBorrowingRepository, *MockUserRepository, *MockGameRepository)
@@ -162,7 +177,7 @@ func TestAlertService_GenerateOverdueAlerts(t *testing.T) {
 			tt.setupMocks(alertRepo, borrowingRepo, userRepo, gameRepo)
 
 			service := NewAlertService(alertRepo, borrowingRepo, userRepo, gameRepo)
-			err := service.GenerateOverdueAlerts()
+			err := service.GenerateOverdueAlerts(ctx)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -180,6 +195,7 @@ func TestAlertService_GenerateOverdueAlerts(t *testing.T) {
 }
 
 func TestAlertService_GenerateReminderAlerts(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		setupMocks    func(*MockAlertRepository, *MockBorrowingRepository, *MockUserRepository, *MockGameRepository)
@@ -247,7 +263,7 @@ func TestAlertService_GenerateReminderAlerts(t *testing.T) {
 			tt.setupMocks(alertRepo, borrowingRepo, userRepo, gameRepo)
 
 			service := NewAlertService(alertRepo, borrowingRepo, userRepo, gameRepo)
-			err := service.GenerateReminderAlerts()
+			err := service.GenerateReminderAlerts(ctx)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -265,6 +281,7 @@ func TestAlertService_GenerateReminderAlerts(t *testing.T) {
 }
 
 func TestAlertService_GetActiveAlerts(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		setupMocks    func(*MockAlertRepository, *MockBorrowingRepository, *MockUserRepository, *MockGameRepository)
@@ -302,7 +319,7 @@ func TestAlertService_GetActiveAlerts(t *testing.T) {
 			tt.setupMocks(alertRepo, borrowingRepo, userRepo, gameRepo)
 
 			service := NewAlertService(alertRepo, borrowingRepo, userRepo, gameRepo)
-			alerts, err := service.GetActiveAlerts()
+			alerts, err := service.GetActiveAlerts(ctx)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -323,6 +340,7 @@ func TestAlertService_GetActiveAlerts(t *testing.T) {
 }
 
 func TestAlertService_MarkAlertAsRead(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		alertID       int
@@ -366,7 +384,7 @@ func TestAlertService_MarkAlertAsRead(t *testing.T) {
 			tt.setupMocks(alertRepo, borrowingRepo, userRepo, gameRepo)
 
 			service := NewAlertService(alertRepo, borrowingRepo, userRepo, gameRepo)
-			err := service.MarkAlertAsRead(tt.alertID)
+			err := service.MarkAlertAsRead(ctx, tt.alertID)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -384,6 +402,7 @@ func TestAlertService_MarkAlertAsRead(t *testing.T) {
 }
 
 func TestAlertService_GetAlertsSummaryByUser(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		setupMocks    func(*MockAlertRepository, *MockBorrowingRepository, *MockUserRepository, *MockGameRepository)
@@ -430,7 +449,7 @@ func TestAlertService_GetAlertsSummaryByUser(t *testing.T) {
 			tt.setupMocks(alertRepo, borrowingRepo, userRepo, gameRepo)
 
 			service := NewAlertService(alertRepo, borrowingRepo, userRepo, gameRepo)
-			summary, err := service.GetAlertsSummaryByUser()
+			summary, err := service.GetAlertsSummaryByUser(ctx)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -466,6 +485,7 @@ func TestAlertService_GetAlertsSummaryByUser(t *testing.T) {
 }
 
 func TestAlertService_CreateCustomAlert(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		userID        int
@@ -551,7 +571,7 @@ func TestAlertService_CreateCustomAlert(t *testing.T) {
 			tt.setupMocks(alertRepo, borrowingRepo, userRepo, gameRepo)
 
 			service := NewAlertService(alertRepo, borrowingRepo, userRepo, gameRepo)
-			alert, err := service.CreateCustomAlert(tt.userID, tt.gameID, tt.alertType, tt.message)
+			alert, err := service.CreateCustomAlert(ctx, tt.userID, tt.gameID, tt.alertType, tt.message)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -573,4 +593,24 @@ func TestAlertService_CreateCustomAlert(t *testing.T) {
 			gameRepo.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestAlertService_ListAlerts(t *testing.T) {
+	ctx := context.Background()
+	alertRepo := &MockAlertRepository{}
+	read := false
+	filter := models.AlertFilter{Read: &read, ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage, Sort: "created_at"}}
+	alertRepo.On("List", filter).Return(nil, errors.New("database error"))
+
+	service := NewAlertService(alertRepo, &MockBorrowingRepository{}, &MockUserRepository{}, &MockGameRepository{})
+	alerts, _, err := service.ListAlerts(ctx, models.AlertFilter{Read: &read})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list alerts")
+	assert.Nil(t, alerts)
+	alertRepo.AssertExpectations(t)
+
+	_, _, err = service.ListAlerts(ctx, models.AlertFilter{Type: "unknown"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid alert filter")
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Auditor records state changes in the audit log. It is implemented by
+// AuditService.
+type Auditor interface {
+	Record(ctx context.Context, event *models.AuditEvent) error
+	// WithStore returns an auditor writing to the given repositories
+	WithStore(store *repositories.Store) Auditor
+}
+
+// auditTrail is embedded by the services whose changes are audited. It holds
+// the auditor and the user on whose behalf the service acts.
+type auditTrail struct {
+	auditor Auditor
+	actor   *models.User
+}
+
+// SetAuditor records every change made through the service in the audit log.
+// Without it nothing is recorded.
+func (a *auditTrail) SetAuditor(auditor Auditor) {
+	a.auditor = auditor
+}
+
+// record appends an event to the audit log. before and after are snapshots
+// of the entity, encoded to JSON right away; either may be nil.
+func (a *auditTrail) record(ctx context.Context, action, entityType string, entityID int, before, after any) error {
+	if a.auditor == nil {
+		return nil
+	}
+
+	id := ""
+	if entityID > 0 {
+		id = strconv.Itoa(entityID)
+	}
+
+	return a.recordKey(ctx, action, entityType, id, before, after)
+}
+
+// recordKey is record for entities identified by something other than an ID
+func (a *auditTrail) recordKey(ctx context.Context, action, entityType, entityID string, before, after any) error {
+	if a.auditor == nil {
+		return nil
+	}
+
+	event, err := models.NewAuditEvent(a.actor, action, entityType, entityID, before, after)
+	if err == nil {
+		err = a.auditor.Record(ctx, event)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	return nil
+}
+
+// withStore returns the audit trail of a copy of the service bound to store
+func (a auditTrail) withStore(store *repositories.Store) auditTrail {
+	if a.auditor != nil {
+		a.auditor = a.auditor.WithStore(store)
+	}
+	return a
+}
+
+// AuditService handles the append-only audit log
+type AuditService struct {
+	auditRepo repositories.AuditRepository
+}
+
+// NewAuditService creates a new AuditService instance
+func NewAuditService(auditRepo repositories.AuditRepository) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// Record appends an event to the audit log
+func (s *AuditService) Record(ctx context.Context, event *models.AuditEvent) error {
+	if event == nil {
+		return fmt.Errorf("audit event cannot be nil")
+	}
+
+	if err := models.ValidateAuditEvent(event); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.auditRepo.Create(ctx, event); err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return nil
+}
+
+// WithStore returns an AuditService writing to the given repositories, so
+// that events are committed together with the change they describe
+func (s *AuditService) WithStore(store *repositories.Store) Auditor {
+	return NewAuditService(store.Audit)
+}
+
+// ListEvents retrieves one page of the events matching filter, most recent
+// first, along with the total number of matching events
+func (s *AuditService) ListEvents(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = models.DefaultAuditLimit
+	}
+	if filter.Limit > models.MaxAuditLimit {
+		filter.Limit = models.MaxAuditLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	events, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit events: %w", err)
+	}
+
+	total, err := s.auditRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit events: %w", err)
+	}
+
+	if events == nil {
+		events = []*models.AuditEvent{}
+	}
+
+	return events, total, nil
+}
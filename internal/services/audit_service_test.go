@@ -0,0 +1,162 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditRepository is a mock implementation of AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.AuditEvent), args.Error(1)
+}
+
+func (m *MockAuditRepository) Count(ctx context.Context, filter models.AuditFilter) (int, error) {
+	args := m.Called(filter)
+	return args.Int(0), args.Error(1)
+}
+
+func TestAuditService_Record(t *testing.T) {
+	ctx := context.Background()
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("Create", mock.AnythingOfType("*models.AuditEvent")).Return(nil).Once()
+
+	service := NewAuditService(auditRepo)
+
+	err := service.Record(ctx, &models.AuditEvent{ActorName: "system", Action: "delete", EntityType: "game", EntityID: "1"})
+	assert.NoError(t, err)
+
+	err = service.Record(ctx, &models.AuditEvent{ActorName: "system", EntityType: "game"})
+	assert.EqualError(t, err, "validation failed: audit action is required")
+
+	auditRepo.AssertExpectations(t)
+}
+
+func TestAuditService_ListEvents(t *testing.T) {
+	ctx := context.Background()
+	auditRepo := &MockAuditRepository{}
+	events := []*models.AuditEvent{{ID: 2, Action: "update"}, {ID: 1, Action: "create"}}
+	auditRepo.On("List", models.AuditFilter{Action: "update", Limit: models.DefaultAuditLimit}).Return(events, nil)
+	auditRepo.On("Count", models.AuditFilter{Action: "update", Limit: models.DefaultAuditLimit}).Return(12, nil)
+	auditRepo.On("List", models.AuditFilter{Limit: models.MaxAuditLimit}).Return(nil, nil)
+	auditRepo.On("Count", models.AuditFilter{Limit: models.MaxAuditLimit}).Return(0, nil)
+
+	service := NewAuditService(auditRepo)
+
+	result, total, err := service.ListEvents(ctx, models.AuditFilter{Action: "update"})
+	assert.NoError(t, err)
+	assert.Equal(t, events, result)
+	assert.Equal(t, 12, total)
+
+	// Oversized pages are capped and an empty log is an empty list
+	result, total, err = service.ListEvents(ctx, models.AuditFilter{Limit: 10000, Offset: -5})
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+	assert.NotNil(t, result)
+	assert.Equal(t, 0, total)
+
+	auditRepo.AssertExpectations(t)
+}
+
+func TestAuditTrail_RecordsActorAndStates(t *testing.T) {
+	ctx := context.Background()
+	gameRepo := &MockGameRepository{}
+	gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, Name: "Catan", Tags: []string{"strategy"}, Condition: "good"}, nil)
+	gameRepo.On("Update", mock.AnythingOfType("*models.Game")).Return(nil)
+
+	var recorded []*models.AuditEvent
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("Create", mock.AnythingOfType("*models.AuditEvent")).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(0).(*models.AuditEvent))
+	}).Return(nil)
+
+	service := NewGameService(gameRepo, &MockBorrowingRepository{})
+	service.SetAuditor(NewAuditService(auditRepo))
+
+	alice := &models.User{ID: 7, Name: "Alice"}
+	updated := &models.Game{ID: 1, Name: "Catan 5e", Tags: []string{"strategy"}, Condition: "good"}
+	assert.NoError(t, service.WithActor(alice).UpdateGame(ctx, updated))
+	assert.NoError(t, service.SetGameAvailability(ctx, 1, false))
+
+	if assert.Len(t, recorded, 2) {
+		event := recorded[0]
+		assert.Equal(t, models.AuditActionUpdate, event.Action)
+		assert.Equal(t, models.AuditEntityGame, event.EntityType)
+		assert.Equal(t, "1", event.EntityID)
+		assert.Equal(t, 7, *event.ActorID)
+		assert.Equal(t, "Alice", event.ActorName)
+		assert.Contains(t, string(event.Before), `"name":"Catan"`)
+		assert.Contains(t, string(event.After), `"name":"Catan 5e"`)
+
+		// The service itself stays unbound
+		assert.Nil(t, recorded[1].ActorID)
+		assert.Equal(t, models.AuditSystemActor, recorded[1].ActorName)
+	}
+}
+
+func TestAuditTrail_FailureIsReported(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByID", 3).Return(&models.User{ID: 3, Name: "Bob"}, nil)
+	borrowingRepo := &MockBorrowingRepository{}
+	borrowingRepo.On("GetActiveByUser", 3).Return([]*models.Borrowing{}, nil)
+	userRepo.On("GetBorrowingHistory", 3).Return([]*models.Borrowing{}, nil)
+	userRepo.On("Delete", 3).Return(nil)
+
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("Create", mock.AnythingOfType("*models.AuditEvent")).Return(errors.New("disk full"))
+
+	service := NewUserService(userRepo, borrowingRepo)
+	service.SetAuditor(NewAuditService(auditRepo))
+
+	err := service.DeleteUser(ctx, 3)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to record audit event")
+}
+
+func TestAuditTrail_UnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	borrowingRepo := &MockBorrowingRepository{}
+	userRepo := &MockUserRepository{}
+	txAuditRepo := &MockAuditRepository{}
+	store := &repositories.Store{Borrowings: borrowingRepo, Users: userRepo, Games: &MockGameRepository{}, Audit: txAuditRepo}
+
+	borrowing := &models.Borrowing{ID: 4, UserID: 1, GameID: 1, BorrowedAt: time.Now().Add(-7 * 24 * time.Hour), DueDate: time.Now().Add(7 * 24 * time.Hour)}
+	borrowingRepo.On("GetByID", 4).Return(borrowing, nil)
+	userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
+	borrowingRepo.On("Update", mock.AnythingOfType("*models.Borrowing")).Return(nil)
+	txAuditRepo.On("Create", mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == models.AuditActionExtend && event.ActorName == "Alice" && event.EntityID == "4"
+	})).Return(nil)
+
+	// The service's own audit repository has no expectations and must not be used
+	service := NewBorrowingService(&MockBorrowingRepository{}, &MockUserRepository{}, &MockGameRepository{})
+	service.SetAuditor(NewAuditService(&MockAuditRepository{}))
+	uow := &fakeUnitOfWork{store: store}
+	service.SetUnitOfWork(uow)
+
+	err := service.WithActor(&models.User{ID: 2, Name: "Alice"}).ExtendDueDate(ctx, 4, time.Now().Add(14*24*time.Hour))
+
+	assert.NoError(t, err)
+	assert.True(t, uow.committed)
+	txAuditRepo.AssertExpectations(t)
+}
@@ -0,0 +1,416 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// APITokenPrefix starts every API token so that leaked tokens are easy to spot
+const APITokenPrefix = "bgl_"
+
+// DefaultSessionTTL is how long a web session lasts when no TTL is configured
+const DefaultSessionTTL = 24 * time.Hour
+
+// AuthService handles local accounts, web sessions, API tokens and roles
+type AuthService struct {
+	userRepo   repositories.UserRepository
+	authRepo   repositories.AuthRepository
+	sessionTTL time.Duration
+	bcryptCost int
+	uow        repositories.UnitOfWork
+	auditTrail
+}
+
+// NewAuthService creates a new AuthService instance
+func NewAuthService(userRepo repositories.UserRepository, authRepo repositories.AuthRepository, sessionTTL time.Duration) *AuthService {
+	if sessionTTL <= 0 {
+		sessionTTL = DefaultSessionTTL
+	}
+
+	return &AuthService{
+		userRepo:   userRepo,
+		authRepo:   authRepo,
+		sessionTTL: sessionTTL,
+		bcryptCost: bcrypt.DefaultCost,
+	}
+}
+
+// WithActor returns a copy of the service that records actor as the author
+// of its changes in the audit log
+func (s *AuthService) WithActor(actor *models.User) *AuthService {
+	bound := *s
+	bound.actor = actor
+	return &bound
+}
+
+// SetUnitOfWork makes role changes check the remaining administrators and
+// write the user in a single transaction
+func (s *AuthService) SetUnitOfWork(uow repositories.UnitOfWork) {
+	s.uow = uow
+}
+
+// atomically runs fn with a copy of the service whose repositories are bound
+// to a single unit of work
+func (s *AuthService) atomically(ctx context.Context, fn func(tx *AuthService) error) error {
+	if s.uow == nil {
+		return fn(s)
+	}
+
+	return s.uow.Do(ctx, func(store *repositories.Store) error {
+		bound := *s
+		bound.userRepo = store.Users
+		bound.uow = nil
+		bound.auditTrail = s.auditTrail.withStore(store)
+		return fn(&bound)
+	})
+}
+
+// SetupRequired reports whether no active administrator exists yet, in which
+// case the first one can be created without signing in
+func (s *AuthService) SetupRequired(ctx context.Context) (bool, error) {
+	count, err := s.authRepo.CountActiveByRole(ctx, models.RoleAdmin)
+	if err != nil {
+		return false, fmt.Errorf("failed to check setup: %w", err)
+	}
+
+	return count == 0, nil
+}
+
+// CreateFirstAdmin creates the initial administrator account. It only works
+// while SetupRequired is true.
+func (s *AuthService) CreateFirstAdmin(ctx context.Context, name, email, password string) (*models.User, error) {
+	required, err := s.SetupRequired(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !required {
+		return nil, models.Conflict("setup_completed")
+	}
+
+	if err := models.ValidatePassword(password); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	user := &models.User{
+		Name:         name,
+		Email:        email,
+		RegisteredAt: time.Now(),
+		IsActive:     true,
+		Role:         models.RoleAdmin,
+	}
+
+	if err := models.ValidateUser(user); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if existing, err := s.userRepo.GetByEmail(ctx, email); err == nil && existing != nil {
+		return nil, models.Conflict("user_email_taken", email)
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	if err := s.record(ctx, models.AuditActionCreate, models.AuditEntityUser, user.ID, nil, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.SetPassword(ctx, user.ID, password); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Login checks a user's credentials and opens a web session. It returns the
+// session token to store in the browser cookie.
+func (s *AuthService) Login(ctx context.Context, email, password string) (string, *models.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		// Compare anyway so unknown emails take as long as wrong passwords
+		bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+		return "", nil, models.Unauthorized("invalid_credentials")
+	}
+
+	hash, err := s.authRepo.GetPasswordHash(ctx, user.ID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+	if hash == "" || bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return "", nil, models.Unauthorized("invalid_credentials")
+	}
+
+	if !user.IsActive {
+		return "", nil, models.Forbidden("account_inactive")
+	}
+
+	token, err := newToken("")
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
+	session := &models.Session{
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.sessionTTL),
+	}
+	if err := s.authRepo.CreateSession(ctx, session); err != nil {
+		return "", nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return token, user, nil
+}
+
+// Logout closes the web session of the given token
+func (s *AuthService) Logout(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+
+	if err := s.authRepo.DeleteSession(ctx, hashToken(token)); err != nil {
+		return fmt.Errorf("failed to close session: %w", err)
+	}
+
+	return nil
+}
+
+// AuthenticateSession returns the user signed in with a session token
+func (s *AuthService) AuthenticateSession(ctx context.Context, token string) (*models.User, error) {
+	if token == "" {
+		return nil, models.Unauthorized("authentication_required")
+	}
+
+	session, err := s.authRepo.GetSession(ctx, hashToken(token))
+	if err != nil {
+		return nil, models.Unauthorized("invalid_session")
+	}
+
+	if session.IsExpired(time.Now()) {
+		s.authRepo.DeleteSession(ctx, session.TokenHash)
+		return nil, models.Unauthorized("session_expired")
+	}
+
+	return s.activeUser(ctx, session.UserID)
+}
+
+// AuthenticateToken returns the user an API token belongs to and records its use
+func (s *AuthService) AuthenticateToken(ctx context.Context, token string) (*models.User, error) {
+	if !strings.HasPrefix(token, APITokenPrefix) {
+		return nil, models.Unauthorized("invalid_api_token")
+	}
+
+	apiToken, err := s.authRepo.GetAPITokenByHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, models.Unauthorized("invalid_api_token")
+	}
+
+	user, err := s.activeUser(ctx, apiToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authRepo.TouchAPIToken(ctx, apiToken.ID, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to record API token use: %w", err)
+	}
+
+	return user, nil
+}
+
+// CleanupExpiredSessions removes expired web sessions and returns how many were removed
+func (s *AuthService) CleanupExpiredSessions(ctx context.Context) (int, error) {
+	removed, err := s.authRepo.DeleteExpiredSessions(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to clean up sessions: %w", err)
+	}
+
+	return removed, nil
+}
+
+// SetPassword replaces a user's password and signs them out everywhere
+func (s *AuthService) SetPassword(ctx context.Context, userID int, password string) error {
+	if err := models.ValidatePassword(password); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), s.bcryptCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.authRepo.SetPasswordHash(ctx, userID, string(hash)); err != nil {
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+
+	if err := s.authRepo.DeleteUserSessions(ctx, userID); err != nil {
+		return fmt.Errorf("failed to close sessions: %w", err)
+	}
+
+	// The hash is never written to the audit log
+	return s.record(ctx, models.AuditActionSetPassword, models.AuditEntityUser, userID, nil, nil)
+}
+
+// ChangePassword replaces a user's password after checking the current one
+func (s *AuthService) ChangePassword(ctx context.Context, userID int, currentPassword, newPassword string) error {
+	hash, err := s.authRepo.GetPasswordHash(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get credentials: %w", err)
+	}
+
+	if hash == "" || bcrypt.CompareHashAndPassword([]byte(hash), []byte(currentPassword)) != nil {
+		return models.Unauthorized("current_password_incorrect")
+	}
+
+	return s.SetPassword(ctx, userID, newPassword)
+}
+
+// SetRole changes a user's role. The last active administrator cannot be demoted.
+func (s *AuthService) SetRole(ctx context.Context, userID int, role string) (*models.User, error) {
+	if !models.IsValidRole(role) {
+		return nil, models.Invalid("role", "invalid_role", models.ValidRoles)
+	}
+
+	var user *models.User
+	err := s.atomically(ctx, func(tx *AuthService) error {
+		var err error
+		user, err = tx.setRole(ctx, userID, role)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// setRole implements SetRole
+func (s *AuthService) setRole(ctx context.Context, userID int, role string) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	if role != models.RoleAdmin {
+		if err := keepsAnAdministrator(ctx, s.userRepo, user); err != nil {
+			return nil, err
+		}
+	}
+
+	before := *user
+	user.Role = role
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if err := s.record(ctx, models.AuditActionSetRole, models.AuditEntityUser, userID, &before, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// CreateAPIToken issues a new API token for a user. The returned token value
+// is not stored and cannot be retrieved again.
+func (s *AuthService) CreateAPIToken(ctx context.Context, userID int, name string) (string, *models.APIToken, error) {
+	apiToken := &models.APIToken{
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		CreatedAt: time.Now(),
+	}
+
+	if err := models.ValidateAPIToken(apiToken); err != nil {
+		return "", nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	token, err := newToken(APITokenPrefix)
+	if err != nil {
+		return "", nil, err
+	}
+	apiToken.TokenHash = hashToken(token)
+
+	if err := s.authRepo.CreateAPIToken(ctx, apiToken); err != nil {
+		return "", nil, fmt.Errorf("failed to create API token: %w", err)
+	}
+
+	if err := s.record(ctx, models.AuditActionCreate, models.AuditEntityAPIToken, apiToken.ID, nil, apiToken); err != nil {
+		return "", nil, err
+	}
+
+	return token, apiToken, nil
+}
+
+// ListAPITokens retrieves the API tokens of a user
+func (s *AuthService) ListAPITokens(ctx context.Context, userID int) ([]*models.APIToken, error) {
+	tokens, err := s.authRepo.GetAPITokensByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// RevokeAPIToken deletes an API token. Users can revoke their own tokens and
+// administrators can revoke anyone's.
+func (s *AuthService) RevokeAPIToken(ctx context.Context, actor *models.User, tokenID int) error {
+	if actor == nil {
+		return models.Unauthorized("authentication_required")
+	}
+
+	token, err := s.authRepo.GetAPITokenByID(ctx, tokenID)
+	if err != nil || (token.UserID != actor.ID && !actor.HasRole(models.RoleAdmin)) {
+		return models.NotFound("api_token_not_found", tokenID)
+	}
+
+	if err := s.authRepo.DeleteAPIToken(ctx, tokenID); err != nil {
+		return fmt.Errorf("failed to revoke API token: %w", err)
+	}
+
+	return s.record(ctx, models.AuditActionRevoke, models.AuditEntityAPIToken, tokenID, token, nil)
+}
+
+// activeUser loads the user behind a session or token, rejecting inactive accounts
+func (s *AuthService) activeUser(ctx context.Context, userID int) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	if !user.IsActive {
+		return nil, models.Forbidden("account_inactive")
+	}
+
+	return user, nil
+}
+
+// dummyPasswordHash is compared against when the email is unknown
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("not a real password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// newToken returns prefix followed by 32 random bytes in hex
+func newToken(prefix string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return prefix + hex.EncodeToString(buf), nil
+}
+
+// hashToken returns the SHA-256 of a token, which is what gets stored
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,396 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MockAuthRepository is a mock implementation of AuthRepository
+type MockAuthRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuthRepository) SetPasswordHash(ctx context.Context, userID int, hash string) error {
+	args := m.Called(userID, hash)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) GetPasswordHash(ctx context.Context, userID int) (string, error) {
+	args := m.Called(userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAuthRepository) CountActiveByRole(ctx context.Context, role string) (int, error) {
+	args := m.Called(role)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAuthRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) GetSession(ctx context.Context, tokenHash string) (*models.Session, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Session), args.Error(1)
+}
+
+func (m *MockAuthRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	args := m.Called(tokenHash)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) DeleteUserSessions(ctx context.Context, userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) DeleteExpiredSessions(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(now)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAuthRepository) CreateAPIToken(ctx context.Context, token *models.APIToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) GetAPITokenByHash(ctx context.Context, tokenHash string) (*models.APIToken, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIToken), args.Error(1)
+}
+
+func (m *MockAuthRepository) GetAPITokenByID(ctx context.Context, id int) (*models.APIToken, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIToken), args.Error(1)
+}
+
+func (m *MockAuthRepository) GetAPITokensByUser(ctx context.Context, userID int) ([]*models.APIToken, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.APIToken), args.Error(1)
+}
+
+func (m *MockAuthRepository) TouchAPIToken(ctx context.Context, id int, usedAt time.Time) error {
+	args := m.Called(id, usedAt)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) DeleteAPIToken(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func newTestAuthService() (*AuthService, *MockUserRepository, *MockAuthRepository) {
+	userRepo := new(MockUserRepository)
+	authRepo := new(MockAuthRepository)
+	service := NewAuthService(userRepo, authRepo, time.Hour)
+	service.bcryptCost = bcrypt.MinCost
+	return service, userRepo, authRepo
+}
+
+func testPasswordHash(t *testing.T, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	assert.NoError(t, err)
+	return string(hash)
+}
+
+func TestAuthService_CreateFirstAdmin(t *testing.T) {
+	ctx := context.Background()
+	t.Run("creates admin while none exists", func(t *testing.T) {
+		service, userRepo, authRepo := newTestAuthService()
+
+		authRepo.On("CountActiveByRole", models.RoleAdmin).Return(0, nil)
+		userRepo.On("GetByEmail", "admin@example.com").Return(nil, errors.New("not found"))
+		userRepo.On("Create", mock.MatchedBy(func(u *models.User) bool {
+			return u.Role == models.RoleAdmin && u.IsActive
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.User).ID = 1
+		}).Return(nil)
+		authRepo.On("SetPasswordHash", 1, mock.AnythingOfType("string")).Return(nil)
+		authRepo.On("DeleteUserSessions", 1).Return(nil)
+
+		user, err := service.CreateFirstAdmin(ctx, "Admin", "admin@example.com", "correct horse")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.RoleAdmin, user.Role)
+		userRepo.AssertExpectations(t)
+		authRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejected once an admin exists", func(t *testing.T) {
+		service, _, authRepo := newTestAuthService()
+
+		authRepo.On("CountActiveByRole", models.RoleAdmin).Return(1, nil)
+
+		_, err := service.CreateFirstAdmin(ctx, "Admin", "admin@example.com", "correct horse")
+
+		assert.EqualError(t, err, "setup has already been completed")
+	})
+
+	t.Run("rejects short password", func(t *testing.T) {
+		service, _, authRepo := newTestAuthService()
+
+		authRepo.On("CountActiveByRole", models.RoleAdmin).Return(0, nil)
+
+		_, err := service.CreateFirstAdmin(ctx, "Admin", "admin@example.com", "short")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed")
+	})
+}
+
+func TestAuthService_Login(t *testing.T) {
+	ctx := context.Background()
+	user := &models.User{ID: 1, Name: "Alice", Email: "alice@example.com", IsActive: true, Role: models.RoleMember}
+
+	t.Run("valid credentials open a session", func(t *testing.T) {
+		service, userRepo, authRepo := newTestAuthService()
+
+		userRepo.On("GetByEmail", "alice@example.com").Return(user, nil)
+		authRepo.On("GetPasswordHash", 1).Return(testPasswordHash(t, "correct horse"), nil)
+		authRepo.On("CreateSession", mock.MatchedBy(func(s *models.Session) bool {
+			return s.UserID == 1 && s.ExpiresAt.Sub(s.CreatedAt) == time.Hour && len(s.TokenHash) == 64
+		})).Return(nil)
+
+		token, loggedIn, err := service.Login(ctx, "alice@example.com", "correct horse")
+
+		assert.NoError(t, err)
+		assert.Len(t, token, 64)
+		assert.Equal(t, user, loggedIn)
+		authRepo.AssertExpectations(t)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		service, userRepo, authRepo := newTestAuthService()
+
+		userRepo.On("GetByEmail", "alice@example.com").Return(user, nil)
+		authRepo.On("GetPasswordHash", 1).Return(testPasswordHash(t, "correct horse"), nil)
+
+		_, _, err := service.Login(ctx, "alice@example.com", "battery staple")
+
+		assert.EqualError(t, err, "invalid email or password")
+		authRepo.AssertNotCalled(t, "CreateSession", mock.Anything)
+	})
+
+	t.Run("account without password", func(t *testing.T) {
+		service, userRepo, authRepo := newTestAuthService()
+
+		userRepo.On("GetByEmail", "alice@example.com").Return(user, nil)
+		authRepo.On("GetPasswordHash", 1).Return("", nil)
+
+		_, _, err := service.Login(ctx, "alice@example.com", "")
+
+		assert.EqualError(t, err, "invalid email or password")
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		service, userRepo, _ := newTestAuthService()
+
+		userRepo.On("GetByEmail", "nobody@example.com").Return(nil, errors.New("not found"))
+
+		_, _, err := service.Login(ctx, "nobody@example.com", "correct horse")
+
+		assert.EqualError(t, err, "invalid email or password")
+	})
+
+	t.Run("inactive account", func(t *testing.T) {
+		service, userRepo, authRepo := newTestAuthService()
+
+		inactive := *user
+		inactive.IsActive = false
+		userRepo.On("GetByEmail", "alice@example.com").Return(&inactive, nil)
+		authRepo.On("GetPasswordHash", 1).Return(testPasswordHash(t, "correct horse"), nil)
+
+		_, _, err := service.Login(ctx, "alice@example.com", "correct horse")
+
+		assert.EqualError(t, err, "user account is inactive")
+	})
+}
+
+func TestAuthService_AuthenticateSession(t *testing.T) {
+	ctx := context.Background()
+	user := &models.User{ID: 1, IsActive: true, Role: models.RoleMember}
+
+	t.Run("valid session", func(t *testing.T) {
+		service, userRepo, authRepo := newTestAuthService()
+
+		authRepo.On("GetSession", hashToken("token")).Return(&models.Session{UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		userRepo.On("GetByID", 1).Return(user, nil)
+
+		authenticated, err := service.AuthenticateSession(ctx, "token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, user, authenticated)
+	})
+
+	t.Run("expired session is removed", func(t *testing.T) {
+		service, _, authRepo := newTestAuthService()
+
+		authRepo.On("GetSession", hashToken("token")).Return(&models.Session{TokenHash: hashToken("token"), UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+		authRepo.On("DeleteSession", hashToken("token")).Return(nil)
+
+		_, err := service.AuthenticateSession(ctx, "token")
+
+		assert.EqualError(t, err, "session expired")
+		authRepo.AssertExpectations(t)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		service, _, _ := newTestAuthService()
+
+		_, err := service.AuthenticateSession(ctx, "")
+
+		assert.EqualError(t, err, "authentication required")
+	})
+}
+
+func TestAuthService_APITokens(t *testing.T) {
+	ctx := context.Background()
+	user := &models.User{ID: 1, IsActive: true, Role: models.RoleLibrarian}
+
+	t.Run("create and authenticate", func(t *testing.T) {
+		service, userRepo, authRepo := newTestAuthService()
+
+		var stored *models.APIToken
+		authRepo.On("CreateAPIToken", mock.AnythingOfType("*models.APIToken")).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(*models.APIToken)
+			stored.ID = 7
+		}).Return(nil)
+
+		token, apiToken, err := service.CreateAPIToken(ctx, 1, " nightly export ")
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(token, APITokenPrefix))
+		assert.Equal(t, "nightly export", apiToken.Name)
+		assert.Equal(t, hashToken(token), stored.TokenHash)
+
+		authRepo.On("GetAPITokenByHash", hashToken(token)).Return(stored, nil)
+		authRepo.On("TouchAPIToken", 7, mock.AnythingOfType("time.Time")).Return(nil)
+		userRepo.On("GetByID", 1).Return(user, nil)
+
+		authenticated, err := service.AuthenticateToken(ctx, token)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user, authenticated)
+		authRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects values without the token prefix", func(t *testing.T) {
+		service, _, authRepo := newTestAuthService()
+
+		_, err := service.AuthenticateToken(ctx, "not-a-token")
+
+		assert.EqualError(t, err, "invalid API token")
+		authRepo.AssertNotCalled(t, "GetAPITokenByHash", mock.Anything)
+	})
+
+	t.Run("members cannot revoke other users' tokens", func(t *testing.T) {
+		service, _, authRepo := newTestAuthService()
+
+		authRepo.On("GetAPITokenByID", 7).Return(&models.APIToken{ID: 7, UserID: 2}, nil)
+
+		err := service.RevokeAPIToken(ctx, user, 7)
+
+		assert.EqualError(t, err, "API token with id 7 not found")
+		authRepo.AssertNotCalled(t, "DeleteAPIToken", mock.Anything)
+	})
+
+	t.Run("admins can revoke any token", func(t *testing.T) {
+		service, _, authRepo := newTestAuthService()
+
+		authRepo.On("GetAPITokenByID", 7).Return(&models.APIToken{ID: 7, UserID: 2}, nil)
+		authRepo.On("DeleteAPIToken", 7).Return(nil)
+
+		err := service.RevokeAPIToken(ctx, &models.User{ID: 1, Role: models.RoleAdmin}, 7)
+
+		assert.NoError(t, err)
+		authRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	t.Run("requires the current password", func(t *testing.T) {
+		service, _, authRepo := newTestAuthService()
+
+		authRepo.On("GetPasswordHash", 1).Return(testPasswordHash(t, "correct horse"), nil)
+
+		err := service.ChangePassword(ctx, 1, "wrong password", "battery staple")
+
+		assert.EqualError(t, err, "current password is incorrect")
+		authRepo.AssertNotCalled(t, "SetPasswordHash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("signs the user out everywhere", func(t *testing.T) {
+		service, _, authRepo := newTestAuthService()
+
+		authRepo.On("GetPasswordHash", 1).Return(testPasswordHash(t, "correct horse"), nil)
+		authRepo.On("SetPasswordHash", 1, mock.MatchedBy(func(hash string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte("battery staple")) == nil
+		})).Return(nil)
+		authRepo.On("DeleteUserSessions", 1).Return(nil)
+
+		err := service.ChangePassword(ctx, 1, "correct horse", "battery staple")
+
+		assert.NoError(t, err)
+		authRepo.AssertExpectations(t)
+	})
+}
+
+func TestAuthService_SetRole(t *testing.T) {
+	ctx := context.Background()
+	t.Run("promotes a member", func(t *testing.T) {
+		service, userRepo, _ := newTestAuthService()
+
+		userRepo.On("GetByID", 2).Return(&models.User{ID: 2, IsActive: true, Role: models.RoleMember}, nil)
+		userRepo.On("Update", mock.MatchedBy(func(u *models.User) bool { return u.Role == models.RoleLibrarian })).Return(nil)
+
+		user, err := service.SetRole(ctx, 2, models.RoleLibrarian)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.RoleLibrarian, user.Role)
+	})
+
+	t.Run("keeps the last administrator", func(t *testing.T) {
+		service, userRepo, _ := newTestAuthService()
+
+		userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true, Role: models.RoleAdmin}, nil)
+		userRepo.On("Count", mock.MatchedBy(func(f models.UserFilter) bool {
+			return f.Role == models.RoleAdmin && f.Active != nil && *f.Active
+		})).Return(1, nil)
+
+		_, err := service.SetRole(ctx, 1, models.RoleMember)
+
+		assert.EqualError(t, err, "cannot remove the last administrator")
+		userRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("rejects unknown roles", func(t *testing.T) {
+		service, _, _ := newTestAuthService()
+
+		_, err := service.SetRole(ctx, 1, "owner")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid role")
+	})
+}
@@ -3,7 +3,9 @@ package services
 import (
 	"board-game-library/internal/models"
 	"board-game-library/internal/repositories"
+	"context"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -12,6 +14,22 @@ type BorrowingService struct {
 	borrowingRepo repositories.BorrowingRepository
 	userRepo      repositories.UserRepository
 	gameRepo      repositories.GameRepository
+	holds         HoldQueue
+	policies      LoanPolicies
+	fines         FineLedger
+	conditions    ConditionLog
+	uow           repositories.UnitOfWork
+	auditTrail
+}
+
+// HoldQueue hands returned copies to users waiting for them. It is
+// implemented by ReservationService.
+type HoldQueue interface {
+	HoldReturnedCopy(ctx context.Context, gameCopy *models.GameCopy) (bool, error)
+	ClaimHold(ctx context.Context, userID, gameID int) (*models.GameCopy, error)
+	FulfilHold(ctx context.Context, userID, gameID int) error
+	// WithStore returns a hold queue working on the given repositories
+	WithStore(store *repositories.Store) HoldQueue
 }
 
 // NewBorrowingService creates a new BorrowingService instance
@@ -23,49 +41,264 @@ func NewBorrowingService(borrowingRepo repositories.BorrowingRepository, userRep
 	}
 }
 
-// BorrowGame creates a new borrowing record
-func (s *BorrowingService) BorrowGame(userID, gameID int, dueDate time.Time) (*models.Borrowing, error) {
+// SetHoldQueue enables reservations: returned copies are held for the first
+// user in line and only that user can borrow them
+func (s *BorrowingService) SetHoldQueue(holds HoldQueue) {
+	s.holds = holds
+}
+
+// SetUnitOfWork makes borrows, returns and extensions atomic: all their reads
+// and writes run in one transaction. Without it the repositories are used
+// directly.
+func (s *BorrowingService) SetUnitOfWork(uow repositories.UnitOfWork) {
+	s.uow = uow
+}
+
+// SetLoanPolicies applies the limits of each user's membership tier. Without
+// it every user gets models.DefaultLoanPolicy.
+func (s *BorrowingService) SetLoanPolicies(policies LoanPolicies) {
+	s.policies = policies
+}
+
+// SetFines charges late returns the fees of the borrower's loan policy and
+// keeps users owing more than their policy allows from borrowing. Without it
+// late returns cost nothing.
+func (s *BorrowingService) SetFines(fines FineLedger) {
+	s.fines = fines
+}
+
+// SetConditions keeps the condition games come back in, reported at their
+// return, in their condition history. Without it the reported condition
+// only updates the game.
+func (s *BorrowingService) SetConditions(conditions ConditionLog) {
+	s.conditions = conditions
+}
+
+// WithActor returns a copy of the service that records actor as the author
+// of its changes in the audit log
+func (s *BorrowingService) WithActor(actor *models.User) *BorrowingService {
+	bound := *s
+	bound.actor = actor
+	return &bound
+}
+
+// atomically runs fn with a copy of the service whose repositories and
+// collaborators are bound to a single unit of work
+func (s *BorrowingService) atomically(ctx context.Context, fn func(tx *BorrowingService) error) error {
+	if s.uow == nil {
+		return fn(s)
+	}
+
+	return s.uow.Do(ctx, func(store *repositories.Store) error {
+		tx := &BorrowingService{
+			borrowingRepo: store.Borrowings,
+			userRepo:      store.Users,
+			gameRepo:      store.Games,
+			auditTrail:    s.auditTrail.withStore(store),
+		}
+		if s.holds != nil {
+			tx.holds = s.holds.WithStore(store)
+		}
+		if s.policies != nil {
+			tx.policies = s.policies.WithStore(store)
+		}
+		if s.fines != nil {
+			tx.fines = s.fines.WithStore(store)
+		}
+		if s.conditions != nil {
+			tx.conditions = s.conditions.WithStore(store)
+		}
+		return fn(tx)
+	})
+}
+
+// BorrowGame lends the first available copy of a game, or the copy held for
+// the user when one of their reservations is ready
+func (s *BorrowingService) BorrowGame(ctx context.Context, userID, gameID int, dueDate time.Time) (*models.Borrowing, error) {
+	var borrowing *models.Borrowing
+	err := s.atomically(ctx, func(tx *BorrowingService) error {
+		var err error
+		borrowing, err = tx.borrowGame(ctx, userID, gameID, dueDate)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return borrowing, nil
+}
+
+// borrowGame implements BorrowGame on repositories bound to one unit of work
+func (s *BorrowingService) borrowGame(ctx context.Context, userID, gameID int, dueDate time.Time) (*models.Borrowing, error) {
 	// Validate input parameters
 	if userID <= 0 {
-		return nil, fmt.Errorf("invalid user ID: %d", userID)
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
 	}
 	if gameID <= 0 {
-		return nil, fmt.Errorf("invalid game ID: %d", gameID)
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
 	}
 
-	// Check if user exists and is active
-	user, err := s.userRepo.GetByID(userID)
+	policy, err := s.checkBorrower(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, err
+	}
+	if dueDate.IsZero() {
+		dueDate = policy.DefaultDueDate(time.Now())
 	}
-	if !user.IsActive {
-		return nil, fmt.Errorf("user account is inactive")
+	if err := policy.CheckDueDate(time.Now(), dueDate); err != nil {
+		return nil, err
 	}
 
-	// Check if user has any overdue items
-	activeBorrowings, err := s.borrowingRepo.GetActiveByUser(userID)
+	// Check if game exists and has a free copy
+	if _, err := s.gameRepo.GetByID(ctx, gameID); err != nil {
+		return nil, fmt.Errorf("game not found: %w", err)
+	}
+
+	if s.holds != nil {
+		heldCopy, err := s.holds.ClaimHold(ctx, userID, gameID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check reservations: %w", err)
+		}
+		if heldCopy != nil {
+			return s.lendCopy(ctx, userID, heldCopy, dueDate)
+		}
+	}
+
+	copies, err := s.gameRepo.GetCopies(ctx, gameID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check user borrowings: %w", err)
+		return nil, fmt.Errorf("failed to get game copies: %w", err)
 	}
-	for _, borrowing := range activeBorrowings {
-		if borrowing.IsCurrentlyOverdue() {
-			return nil, fmt.Errorf("user has overdue items and cannot borrow")
+
+	var gameCopy *models.GameCopy
+	for _, candidate := range copies {
+		if candidate.IsAvailable {
+			gameCopy = candidate
+			break
 		}
 	}
+	if gameCopy == nil {
+		return nil, models.Conflict("game_not_available")
+	}
 
-	// Check if game exists and is available
-	game, err := s.gameRepo.GetByID(gameID)
+	return s.lendCopy(ctx, userID, gameCopy, dueDate)
+}
+
+// BorrowCopy lends a specific copy of a game, e.g. the one whose barcode was scanned
+func (s *BorrowingService) BorrowCopy(ctx context.Context, userID, gameID, copyID int, dueDate time.Time) (*models.Borrowing, error) {
+	var borrowing *models.Borrowing
+	err := s.atomically(ctx, func(tx *BorrowingService) error {
+		var err error
+		borrowing, err = tx.borrowCopy(ctx, userID, gameID, copyID, dueDate)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("game not found: %w", err)
+		return nil, err
+	}
+
+	return borrowing, nil
+}
+
+// borrowCopy implements BorrowCopy on repositories bound to one unit of work
+func (s *BorrowingService) borrowCopy(ctx context.Context, userID, gameID, copyID int, dueDate time.Time) (*models.Borrowing, error) {
+	// Validate input parameters
+	if userID <= 0 {
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
+	}
+	if gameID <= 0 {
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
+	}
+	if copyID <= 0 {
+		return nil, models.Invalid("copy_id", "invalid_copy_id", copyID)
+	}
+
+	policy, err := s.checkBorrower(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if dueDate.IsZero() {
+		dueDate = policy.DefaultDueDate(time.Now())
+	}
+	if err := policy.CheckDueDate(time.Now(), dueDate); err != nil {
+		return nil, err
+	}
+
+	// Check if the copy exists, belongs to the game and is available
+	gameCopy, err := s.gameRepo.GetCopyByID(ctx, copyID)
+	if err != nil {
+		return nil, fmt.Errorf("copy not found: %w", err)
+	}
+	if gameCopy.GameID != gameID {
+		return nil, models.NotFound("copy_not_in_game", copyID, gameID)
+	}
+	if gameCopy.InRepair {
+		return nil, models.Conflict("copy_in_repair")
+	}
+	if !gameCopy.IsAvailable {
+		heldForUser, err := s.isHeldFor(ctx, userID, gameCopy)
+		if err != nil {
+			return nil, err
+		}
+		if !heldForUser {
+			return nil, models.Conflict("copy_not_available")
+		}
+	}
+
+	return s.lendCopy(ctx, userID, gameCopy, dueDate)
+}
+
+// checkBorrower verifies that a user exists and that their loan policy lets
+// them borrow another game, and returns that policy
+func (s *BorrowingService) checkBorrower(ctx context.Context, userID int) (*models.LoanPolicy, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	policy, err := policyFor(ctx, s.policies, user)
+	if err != nil {
+		return nil, err
 	}
-	if !game.IsAvailable {
-		return nil, fmt.Errorf("game is not available for borrowing")
+
+	activeBorrowings, err := s.borrowingRepo.GetActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user borrowings: %w", err)
 	}
 
+	balance, err := fineBalance(ctx, s.fines, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := policy.CheckBorrow(user, activeBorrowings, balance).Err(); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// isHeldFor reports whether an unavailable copy is held for the user's reservation
+func (s *BorrowingService) isHeldFor(ctx context.Context, userID int, gameCopy *models.GameCopy) (bool, error) {
+	if s.holds == nil {
+		return false, nil
+	}
+
+	heldCopy, err := s.holds.ClaimHold(ctx, userID, gameCopy.GameID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check reservations: %w", err)
+	}
+
+	return heldCopy != nil && heldCopy.ID == gameCopy.ID, nil
+}
+
+// lendCopy records the borrowing of a copy and marks the copy as unavailable
+func (s *BorrowingService) lendCopy(ctx context.Context, userID int, gameCopy *models.GameCopy, dueDate time.Time) (*models.Borrowing, error) {
+	copyID := gameCopy.ID
+
 	// Create borrowing record
 	borrowing := &models.Borrowing{
 		UserID:     userID,
-		GameID:     gameID,
+		GameID:     gameCopy.GameID,
+		CopyID:     &copyID,
 		BorrowedAt: time.Now(),
 		DueDate:    dueDate,
 		ReturnedAt: nil,
@@ -78,68 +311,196 @@ func (s *BorrowingService) BorrowGame(userID, gameID int, dueDate time.Time) (*m
 	}
 
 	// Create borrowing in repository
-	if err := s.borrowingRepo.Create(borrowing); err != nil {
+	if err := s.borrowingRepo.Create(ctx, borrowing); err != nil {
 		return nil, fmt.Errorf("failed to create borrowing: %w", err)
 	}
 
-	// Update game availability
-	game.IsAvailable = false
-	if err := s.gameRepo.Update(game); err != nil {
-		return nil, fmt.Errorf("failed to update game availability: %w", err)
+	// Update copy availability
+	gameCopy.IsAvailable = false
+	if err := s.gameRepo.UpdateCopy(ctx, gameCopy); err != nil {
+		return nil, fmt.Errorf("failed to update copy availability: %w", err)
+	}
+
+	// Close the user's reservation now that they have the game
+	if s.holds != nil {
+		if err := s.holds.FulfilHold(ctx, userID, gameCopy.GameID); err != nil {
+			return nil, fmt.Errorf("failed to fulfil reservation: %w", err)
+		}
+	}
+
+	if err := s.record(ctx, models.AuditActionBorrow, models.AuditEntityBorrowing, borrowing.ID, nil, borrowing); err != nil {
+		return nil, err
 	}
 
 	return borrowing, nil
 }
 
-// BorrowGameWithDefaultDueDate creates a borrowing with default 14-day due date
-func (s *BorrowingService) BorrowGameWithDefaultDueDate(userID, gameID int) (*models.Borrowing, error) {
-	dueDate := time.Now().Add(14 * 24 * time.Hour) // 14 days from now
-	return s.BorrowGame(userID, gameID, dueDate)
+// BorrowGameWithDefaultDueDate creates a borrowing due after the default loan
+// duration of the user's membership tier
+func (s *BorrowingService) BorrowGameWithDefaultDueDate(ctx context.Context, userID, gameID int) (*models.Borrowing, error) {
+	return s.BorrowGame(ctx, userID, gameID, time.Time{})
+}
+
+// ReturnGame processes the return of a borrowed game. The condition the game
+// came back in may be reported, nil leaving its condition unchanged. Copies
+// in poor condition or with missing pieces are kept from lending until
+// repaired.
+func (s *BorrowingService) ReturnGame(ctx context.Context, borrowingID int, report *models.ConditionReport) error {
+	return s.atomically(ctx, func(tx *BorrowingService) error {
+		return tx.returnGame(ctx, borrowingID, report)
+	})
 }
 
-// ReturnGame processes the return of a borrowed game
-func (s *BorrowingService) ReturnGame(borrowingID int) error {
+// returnGame implements ReturnGame on repositories bound to one unit of work
+func (s *BorrowingService) returnGame(ctx context.Context, borrowingID int, report *models.ConditionReport) error {
 	if borrowingID <= 0 {
-		return fmt.Errorf("invalid borrowing ID: %d", borrowingID)
+		return models.Invalid("borrowing_id", "invalid_borrowing_id", borrowingID)
+	}
+	if report != nil {
+		if err := models.ValidateConditionReport(report); err != nil {
+			return fmt.Errorf("validation failed: %w", err)
+		}
 	}
 
 	// Get the borrowing record
-	borrowing, err := s.borrowingRepo.GetByID(borrowingID)
+	borrowing, err := s.borrowingRepo.GetByID(ctx, borrowingID)
 	if err != nil {
 		return fmt.Errorf("borrowing not found: %w", err)
 	}
 
 	// Check if already returned
 	if borrowing.ReturnedAt != nil {
-		return fmt.Errorf("game has already been returned")
+		return models.Conflict("already_returned")
 	}
 
 	// Update borrowing record with return date
+	before := *borrowing
 	now := time.Now()
 	borrowing.ReturnedAt = &now
 	borrowing.IsOverdue = false // Clear overdue status on return
 
-	if err := s.borrowingRepo.Update(borrowing); err != nil {
+	if err := s.borrowingRepo.Update(ctx, borrowing); err != nil {
 		return fmt.Errorf("failed to update borrowing record: %w", err)
 	}
 
-	// Update game availability
-	game, err := s.gameRepo.GetByID(borrowing.GameID)
+	if err := s.record(ctx, models.AuditActionReturn, models.AuditEntityBorrowing, borrowingID, &before, borrowing); err != nil {
+		return err
+	}
+
+	if err := s.chargeLateReturn(ctx, borrowing); err != nil {
+		return err
+	}
+
+	// Borrowings recorded before copies were tracked only carry the game
+	if borrowing.CopyID == nil {
+		game, err := s.gameRepo.GetByID(ctx, borrowing.GameID)
+		if err != nil {
+			return fmt.Errorf("failed to get game: %w", err)
+		}
+
+		if game.Condition, err = s.returnedCondition(ctx, borrowing, game.Condition, report); err != nil {
+			return err
+		}
+		game.IsAvailable = !models.NeedsRepair(game.Condition)
+		if err := s.gameRepo.Update(ctx, game); err != nil {
+			return fmt.Errorf("failed to update game availability: %w", err)
+		}
+
+		return nil
+	}
+
+	// Update copy availability
+	gameCopy, err := s.gameRepo.GetCopyByID(ctx, *borrowing.CopyID)
+	if err != nil {
+		return fmt.Errorf("failed to get game copy: %w", err)
+	}
+
+	condition, err := s.returnedCondition(ctx, borrowing, gameCopy.Condition, report)
+	if err != nil {
+		return err
+	}
+	changed := condition != gameCopy.Condition
+	gameCopy.Condition = condition
+
+	// Copies in need of repair stay off the shelf, even for users waiting for them
+	held := false
+	if models.NeedsRepair(gameCopy.Condition) {
+		gameCopy.InRepair = true
+	} else if s.holds != nil {
+		// Keep the copy for the first user waiting for it
+		if held, err = s.holds.HoldReturnedCopy(ctx, gameCopy); err != nil {
+			return fmt.Errorf("failed to process reservations: %w", err)
+		}
+		if held && !changed {
+			return nil
+		}
+	}
+
+	gameCopy.IsAvailable = !held && !gameCopy.InRepair
+	if err := s.gameRepo.UpdateCopy(ctx, gameCopy); err != nil {
+		return fmt.Errorf("failed to update copy availability: %w", err)
+	}
+
+	return nil
+}
+
+// returnedCondition returns the condition a borrowed game came back in,
+// given its condition when lent. A reported change, or a report with notes,
+// is kept in the condition history of the game.
+func (s *BorrowingService) returnedCondition(ctx context.Context, borrowing *models.Borrowing, previous string, report *models.ConditionReport) (string, error) {
+	if report == nil {
+		return previous, nil
+	}
+
+	condition := models.NormalizeCondition(report.Condition)
+	notes := strings.TrimSpace(report.Notes)
+	if s.conditions == nil || (condition == models.NormalizeCondition(previous) && notes == "") {
+		return condition, nil
+	}
+
+	borrowingID := borrowing.ID
+	change := &models.ConditionChange{
+		GameID:            borrowing.GameID,
+		CopyID:            borrowing.CopyID,
+		BorrowingID:       &borrowingID,
+		PreviousCondition: previous,
+		Condition:         condition,
+		Notes:             notes,
+	}
+	if err := s.conditions.Record(ctx, change); err != nil {
+		return "", fmt.Errorf("failed to record returned condition: %w", err)
+	}
+
+	return condition, nil
+}
+
+// chargeLateReturn fines the borrower of a late return according to their
+// loan policy
+func (s *BorrowingService) chargeLateReturn(ctx context.Context, borrowing *models.Borrowing) error {
+	if s.fines == nil || borrowing.DaysLate() == 0 {
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, borrowing.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get borrower: %w", err)
+	}
+
+	policy, err := policyFor(ctx, s.policies, user)
 	if err != nil {
-		return fmt.Errorf("failed to get game: %w", err)
+		return err
 	}
 
-	game.IsAvailable = true
-	if err := s.gameRepo.Update(game); err != nil {
-		return fmt.Errorf("failed to update game availability: %w", err)
+	if _, err := s.fines.ChargeLateReturn(ctx, borrowing, policy); err != nil {
+		return fmt.Errorf("failed to charge late return: %w", err)
 	}
 
 	return nil
 }
 
 // GetOverdueItems retrieves all overdue borrowings
-func (s *BorrowingService) GetOverdueItems() ([]*models.Borrowing, error) {
-	borrowings, err := s.borrowingRepo.GetOverdue()
+func (s *BorrowingService) GetOverdueItems(ctx context.Context) ([]*models.Borrowing, error) {
+	borrowings, err := s.borrowingRepo.GetOverdue(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get overdue items: %w", err)
 	}
@@ -156,51 +517,70 @@ func (s *BorrowingService) GetOverdueItems() ([]*models.Borrowing, error) {
 }
 
 // ExtendDueDate extends the due date for a borrowing
-func (s *BorrowingService) ExtendDueDate(borrowingID int, newDueDate time.Time) error {
+func (s *BorrowingService) ExtendDueDate(ctx context.Context, borrowingID int, newDueDate time.Time) error {
+	return s.atomically(ctx, func(tx *BorrowingService) error {
+		return tx.extendDueDate(ctx, borrowingID, newDueDate)
+	})
+}
+
+// extendDueDate implements ExtendDueDate on repositories bound to one unit of work
+func (s *BorrowingService) extendDueDate(ctx context.Context, borrowingID int, newDueDate time.Time) error {
 	if borrowingID <= 0 {
-		return fmt.Errorf("invalid borrowing ID: %d", borrowingID)
+		return models.Invalid("borrowing_id", "invalid_borrowing_id", borrowingID)
 	}
 
 	// Get the borrowing record
-	borrowing, err := s.borrowingRepo.GetByID(borrowingID)
+	borrowing, err := s.borrowingRepo.GetByID(ctx, borrowingID)
 	if err != nil {
 		return fmt.Errorf("borrowing not found: %w", err)
 	}
 
 	// Check if already returned
 	if borrowing.ReturnedAt != nil {
-		return fmt.Errorf("cannot extend due date for returned item")
+		return models.Conflict("returned_borrowing_extension")
 	}
 
 	// Validate new due date
 	if !newDueDate.After(borrowing.BorrowedAt) {
-		return fmt.Errorf("new due date must be after borrowed date")
+		return models.Invalid("due_date", "new_due_date_before_borrowed_date")
 	}
 
-	// Check if new due date is not too far in the future (max 90 days from borrowed date)
-	maxDuration := 90 * 24 * time.Hour
-	if newDueDate.Sub(borrowing.BorrowedAt) > maxDuration {
-		return fmt.Errorf("due date cannot be more than 90 days from borrowed date")
+	// Apply the borrower's loan policy
+	user, err := s.userRepo.GetByID(ctx, borrowing.UserID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+	policy, err := policyFor(ctx, s.policies, user)
+	if err != nil {
+		return err
+	}
+	if err := policy.CheckExtension(borrowing); err != nil {
+		return err
+	}
+	if err := policy.CheckDueDate(borrowing.BorrowedAt, newDueDate); err != nil {
+		return err
 	}
 
 	// Update due date
+	before := *borrowing
 	borrowing.DueDate = newDueDate
+	borrowing.ExtensionCount++
 	borrowing.IsOverdue = borrowing.IsCurrentlyOverdue() // Recalculate overdue status
 
-	if err := s.borrowingRepo.Update(borrowing); err != nil {
+	if err := s.borrowingRepo.Update(ctx, borrowing); err != nil {
 		return fmt.Errorf("failed to update borrowing: %w", err)
 	}
 
-	return nil
+	return s.record(ctx, models.AuditActionExtend, models.AuditEntityBorrowing, borrowingID, &before, borrowing)
 }
 
 // GetBorrowingDetails retrieves detailed information about a borrowing
-func (s *BorrowingService) GetBorrowingDetails(borrowingID int) (*models.Borrowing, error) {
+func (s *BorrowingService) GetBorrowingDetails(ctx context.Context, borrowingID int) (*models.Borrowing, error) {
 	if borrowingID <= 0 {
-		return nil, fmt.Errorf("invalid borrowing ID: %d", borrowingID)
+		return nil, models.Invalid("borrowing_id", "invalid_borrowing_id", borrowingID)
 	}
 
-	borrowing, err := s.borrowingRepo.GetByID(borrowingID)
+	borrowing, err := s.borrowingRepo.GetByID(ctx, borrowingID)
 	if err != nil {
 		return nil, fmt.Errorf("borrowing not found: %w", err)
 	}
@@ -209,18 +589,18 @@ func (s *BorrowingService) GetBorrowingDetails(borrowingID int) (*models.Borrowi
 }
 
 // GetActiveBorrowingsByUser retrieves all active borrowings for a user
-func (s *BorrowingService) GetActiveBorrowingsByUser(userID int) ([]*models.Borrowing, error) {
+func (s *BorrowingService) GetActiveBorrowingsByUser(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	if userID <= 0 {
-		return nil, fmt.Errorf("invalid user ID: %d", userID)
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
 	}
 
 	// Verify user exists
-	_, err := s.userRepo.GetByID(userID)
+	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	activeBorrowings, err := s.borrowingRepo.GetActiveByUser(userID)
+	activeBorrowings, err := s.borrowingRepo.GetActiveByUser(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active borrowings: %w", err)
 	}
@@ -229,18 +609,18 @@ func (s *BorrowingService) GetActiveBorrowingsByUser(userID int) ([]*models.Borr
 }
 
 // GetBorrowingsByGame retrieves all borrowings for a specific game
-func (s *BorrowingService) GetBorrowingsByGame(gameID int) ([]*models.Borrowing, error) {
+func (s *BorrowingService) GetBorrowingsByGame(ctx context.Context, gameID int) ([]*models.Borrowing, error) {
 	if gameID <= 0 {
-		return nil, fmt.Errorf("invalid game ID: %d", gameID)
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
 	}
 
 	// Verify game exists
-	_, err := s.gameRepo.GetByID(gameID)
+	_, err := s.gameRepo.GetByID(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("game not found: %w", err)
 	}
 
-	borrowings, err := s.borrowingRepo.GetByGame(gameID)
+	borrowings, err := s.borrowingRepo.GetByGame(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get game borrowings: %w", err)
 	}
@@ -249,9 +629,9 @@ func (s *BorrowingService) GetBorrowingsByGame(gameID int) ([]*models.Borrowing,
 }
 
 // UpdateOverdueStatus updates the overdue status for all active borrowings
-func (s *BorrowingService) UpdateOverdueStatus() error {
+func (s *BorrowingService) UpdateOverdueStatus(ctx context.Context) error {
 	// Get all active borrowings
-	allBorrowings, err := s.borrowingRepo.GetAll()
+	allBorrowings, err := s.borrowingRepo.GetAll(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get all borrowings: %w", err)
 	}
@@ -262,7 +642,7 @@ func (s *BorrowingService) UpdateOverdueStatus() error {
 			currentlyOverdue := borrowing.IsCurrentlyOverdue()
 			if borrowing.IsOverdue != currentlyOverdue {
 				borrowing.IsOverdue = currentlyOverdue
-				if err := s.borrowingRepo.Update(borrowing); err != nil {
+				if err := s.borrowingRepo.Update(ctx, borrowing); err != nil {
 					return fmt.Errorf("failed to update borrowing %d overdue status: %w", borrowing.ID, err)
 				}
 			}
@@ -273,22 +653,46 @@ func (s *BorrowingService) UpdateOverdueStatus() error {
 }
 
 // GetAllBorrowings retrieves all borrowings
-func (s *BorrowingService) GetAllBorrowings() ([]*models.Borrowing, error) {
-	borrowings, err := s.borrowingRepo.GetAll()
+func (s *BorrowingService) GetAllBorrowings(ctx context.Context) ([]*models.Borrowing, error) {
+	borrowings, err := s.borrowingRepo.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all borrowings: %w", err)
 	}
 	return borrowings, nil
 }
 
+// ListBorrowings retrieves one page of the borrowings matching filter along with the
+// number of matches across all pages
+func (s *BorrowingService) ListBorrowings(ctx context.Context, filter models.BorrowingFilter) ([]*models.Borrowing, int, error) {
+	if err := models.ValidateBorrowingFilter(&filter); err != nil {
+		return nil, 0, fmt.Errorf("invalid borrowing filter: %w", err)
+	}
+
+	borrowings, err := s.borrowingRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list borrowings: %w", err)
+	}
+
+	total, err := s.borrowingRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count borrowings: %w", err)
+	}
+
+	if borrowings == nil {
+		borrowings = []*models.Borrowing{}
+	}
+
+	return borrowings, total, nil
+}
+
 // GetItemsDueSoon retrieves items that are due within the specified number of days
-func (s *BorrowingService) GetItemsDueSoon(daysAhead int) ([]*models.Borrowing, error) {
+func (s *BorrowingService) GetItemsDueSoon(ctx context.Context, daysAhead int) ([]*models.Borrowing, error) {
 	if daysAhead < 0 {
-		return nil, fmt.Errorf("days ahead must be non-negative")
+		return nil, models.Invalid("days", "invalid_days_ahead")
 	}
 
 	// Get all active borrowings
-	allBorrowings, err := s.borrowingRepo.GetAll()
+	allBorrowings, err := s.borrowingRepo.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all borrowings: %w", err)
 	}
@@ -2,6 +2,8 @@ package services
 
 import (
 	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -10,6 +12,52 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+// MockHoldQueue is a mock implementation of HoldQueue
+type MockHoldQueue struct {
+	mock.Mock
+}
+
+func (m *MockHoldQueue) HoldReturnedCopy(ctx context.Context, gameCopy *models.GameCopy) (bool, error) {
+	args := m.Called(gameCopy)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHoldQueue) ClaimHold(ctx context.Context, userID, gameID int) (*models.GameCopy, error) {
+	args := m.Called(userID, gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameCopy), args.Error(1)
+}
+
+func (m *MockHoldQueue) FulfilHold(ctx context.Context, userID, gameID int) error {
+	args := m.Called(userID, gameID)
+	return args.Error(0)
+}
+
+func (m *MockHoldQueue) WithStore(store *repositories.Store) HoldQueue {
+	args := m.Called(store)
+	return args.Get(0).(HoldQueue)
+}
+
+// MockLoanPolicies is a mock implementation of LoanPolicies
+type MockLoanPolicies struct {
+	mock.Mock
+}
+
+func (m *MockLoanPolicies) PolicyForUser(ctx context.Context, user *models.User) (*models.LoanPolicy, error) {
+	args := m.Called(user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LoanPolicy), args.Error(1)
+}
+
+func (m *MockLoanPolicies) WithStore(store *repositories.Store) LoanPolicies {
+	args := m.Called(store)
+	return args.Get(0).(LoanPolicies)
+}
+
 func TestNewBorrowingService(t *testing.T) {
 	borrowingRepo := &MockBorrowingRepository{}
 	userRepo := &MockUserRepository{}
@@ -24,6 +72,7 @@ func TestNewBorrowingService(t *testing.T) {
 }
 
 func TestBorrowingService_BorrowGame(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		userID        int
@@ -44,9 +93,15 @@ func TestBorrowingService_BorrowGame(t *testing.T) {
 				
 				game := &models.Game{ID: 1, Name: "Monopoly", IsAvailable: true}
 				gameRepo.On("GetByID", 1).Return(game, nil)
-				borrowingRepo.On("Create", mock.AnythingOfType("*models.Borrowing")).Return(nil)
-				gameRepo.On("Update", mock.MatchedBy(func(g *models.Game) bool {
-					return g.ID == 1 && !g.IsAvailable
+				gameRepo.On("GetCopies", 1).Return([]*models.GameCopy{
+					{ID: 10, GameID: 1, Condition: "good", IsAvailable: false},
+					{ID: 11, GameID: 1, Condition: "good", IsAvailable: true},
+				}, nil)
+				borrowingRepo.On("Create", mock.MatchedBy(func(b *models.Borrowing) bool {
+					return b.CopyID != nil && *b.CopyID == 11
+				})).Return(nil)
+				gameRepo.On("UpdateCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+					return c.ID == 11 && !c.IsAvailable
 				})).Return(nil)
 			},
 			expectedError: "",
@@ -89,6 +144,7 @@ func TestBorrowingService_BorrowGame(t *testing.T) {
 			setupMocks: func(borrowingRepo *MockBorrowingRepository, userRepo *MockUserRepository, gameRepo *MockGameRepository) {
 				user := &models.User{ID: 1, Name: "John Doe", IsActive: false}
 				userRepo.On("GetByID", 1).Return(user, nil)
+				borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
 			},
 			expectedError: "user account is inactive",
 		},
@@ -127,6 +183,9 @@ func TestBorrowingService_BorrowGame(t *testing.T) {
 				
 				game := &models.Game{ID: 1, Name: "Monopoly", IsAvailable: false}
 				gameRepo.On("GetByID", 1).Return(game, nil)
+				gameRepo.On("GetCopies", 1).Return([]*models.GameCopy{
+					{ID: 10, GameID: 1, Condition: "good", IsAvailable: false},
+				}, nil)
 			},
 			expectedError: "game is not available for borrowing",
 		},
@@ -140,7 +199,7 @@ func TestBorrowingService_BorrowGame(t *testing.T) {
 			tt.setupMocks(borrowingRepo, userRepo, gameRepo)
 
 			service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
-			borrowing, err := service.BorrowGame(tt.userID, tt.gameID, tt.dueDate)
+			borrowing, err := service.BorrowGame(ctx, tt.userID, tt.gameID, tt.dueDate)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -162,6 +221,7 @@ func TestBorrowingService_BorrowGame(t *testing.T) {
 }
 
 func TestBorrowingService_BorrowGameWithDefaultDueDate(t *testing.T) {
+	ctx := context.Background()
 	borrowingRepo := &MockBorrowingRepository{}
 	userRepo := &MockUserRepository{}
 	gameRepo := &MockGameRepository{}
@@ -172,11 +232,12 @@ func TestBorrowingService_BorrowGameWithDefaultDueDate(t *testing.T) {
 	
 	game := &models.Game{ID: 1, Name: "Monopoly", IsAvailable: true}
 	gameRepo.On("GetByID", 1).Return(game, nil)
+	gameRepo.On("GetCopies", 1).Return([]*models.GameCopy{{ID: 10, GameID: 1, IsAvailable: true}}, nil)
 	borrowingRepo.On("Create", mock.AnythingOfType("*models.Borrowing")).Return(nil)
-	gameRepo.On("Update", mock.AnythingOfType("*models.Game")).Return(nil)
+	gameRepo.On("UpdateCopy", mock.AnythingOfType("*models.GameCopy")).Return(nil)
 
 	service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
-	borrowing, err := service.BorrowGameWithDefaultDueDate(1, 1)
+	borrowing, err := service.BorrowGameWithDefaultDueDate(ctx, 1, 1)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, borrowing)
@@ -190,7 +251,544 @@ func TestBorrowingService_BorrowGameWithDefaultDueDate(t *testing.T) {
 	gameRepo.AssertExpectations(t)
 }
 
+func TestBorrowingService_BorrowWithLoanPolicy(t *testing.T) {
+	ctx := context.Background()
+	basic := &models.LoanPolicy{Tier: "basic", MaxLoans: 2, MaxLoanDays: 30, DefaultLoanDays: 7, MaxExtensions: 1}
+	user := &models.User{ID: 1, Name: "John Doe", IsActive: true, MembershipTier: "basic"}
+	current := &models.Borrowing{ID: 1, UserID: 1, GameID: 2, DueDate: time.Now().Add(5 * 24 * time.Hour)}
+
+	t.Run("default due date follows the tier", func(t *testing.T) {
+		borrowingRepo := &MockBorrowingRepository{}
+		userRepo := &MockUserRepository{}
+		gameRepo := &MockGameRepository{}
+		policies := &MockLoanPolicies{}
+
+		userRepo.On("GetByID", 1).Return(user, nil)
+		policies.On("PolicyForUser", user).Return(basic, nil)
+		borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{current}, nil)
+		gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, Name: "Monopoly", IsAvailable: true}, nil)
+		gameRepo.On("GetCopies", 1).Return([]*models.GameCopy{{ID: 10, GameID: 1, IsAvailable: true}}, nil)
+		borrowingRepo.On("Create", mock.AnythingOfType("*models.Borrowing")).Return(nil)
+		gameRepo.On("UpdateCopy", mock.AnythingOfType("*models.GameCopy")).Return(nil)
+
+		service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+		service.SetLoanPolicies(policies)
+		borrowing, err := service.BorrowGameWithDefaultDueDate(ctx, 1, 1)
+
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), borrowing.DueDate, time.Minute)
+
+		borrowingRepo.AssertExpectations(t)
+		gameRepo.AssertExpectations(t)
+		policies.AssertExpectations(t)
+	})
+
+	t.Run("concurrent loan limit", func(t *testing.T) {
+		borrowingRepo := &MockBorrowingRepository{}
+		userRepo := &MockUserRepository{}
+		gameRepo := &MockGameRepository{}
+		policies := &MockLoanPolicies{}
+
+		userRepo.On("GetByID", 1).Return(user, nil)
+		policies.On("PolicyForUser", user).Return(basic, nil)
+		borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{current, current}, nil)
+
+		service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+		service.SetLoanPolicies(policies)
+		borrowing, err := service.BorrowGame(ctx, 1, 1, time.Now().Add(7*24*time.Hour))
+
+		assert.EqualError(t, err, "user has reached the limit of 2 concurrent loan(s) for the basic tier")
+		assert.Nil(t, borrowing)
+		gameRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+	})
+
+	t.Run("maximum loan duration", func(t *testing.T) {
+		borrowingRepo := &MockBorrowingRepository{}
+		userRepo := &MockUserRepository{}
+		gameRepo := &MockGameRepository{}
+		policies := &MockLoanPolicies{}
+
+		userRepo.On("GetByID", 1).Return(user, nil)
+		policies.On("PolicyForUser", user).Return(basic, nil)
+		borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+
+		service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+		service.SetLoanPolicies(policies)
+		borrowing, err := service.BorrowCopy(ctx, 1, 1, 10, time.Now().Add(45*24*time.Hour))
+
+		assert.EqualError(t, err, "due date cannot be more than 30 days from borrowed date")
+		assert.Nil(t, borrowing)
+	})
+
+	t.Run("unknown tier", func(t *testing.T) {
+		borrowingRepo := &MockBorrowingRepository{}
+		userRepo := &MockUserRepository{}
+		gameRepo := &MockGameRepository{}
+		policies := &MockLoanPolicies{}
+
+		userRepo.On("GetByID", 1).Return(user, nil)
+		policies.On("PolicyForUser", user).Return(nil, errors.New("failed to get loan policy: loan policy for tier \"basic\" not found"))
+
+		service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+		service.SetLoanPolicies(policies)
+		_, err := service.BorrowGame(ctx, 1, 1, time.Now().Add(7*24*time.Hour))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+		borrowingRepo.AssertNotCalled(t, "GetActiveByUser", mock.Anything)
+	})
+
+	t.Run("fines above the tier limit", func(t *testing.T) {
+		borrowingRepo := &MockBorrowingRepository{}
+		userRepo := &MockUserRepository{}
+		gameRepo := &MockGameRepository{}
+		policies := &MockLoanPolicies{}
+		fines := &MockFineLedger{}
+
+		maxBalance := 500
+		limited := *basic
+		limited.MaxFineBalanceCents = &maxBalance
+		userRepo.On("GetByID", 1).Return(user, nil)
+		policies.On("PolicyForUser", user).Return(&limited, nil)
+		borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+		fines.On("Balance", 1).Return(750, nil)
+
+		service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+		service.SetLoanPolicies(policies)
+		service.SetFines(fines)
+		_, err := service.BorrowGame(ctx, 1, 1, time.Now().Add(7*24*time.Hour))
+
+		assert.ErrorIs(t, err, models.ErrPolicyViolation)
+		assert.EqualError(t, err, "user owes 750 cents in fines, more than the basic tier allows")
+		gameRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+	})
+}
+
+// fakeUnitOfWork runs units of work on a fixed store and records whether the
+// last one would have been committed
+type fakeUnitOfWork struct {
+	store     *repositories.Store
+	calls     int
+	committed bool
+}
+
+func (u *fakeUnitOfWork) Do(ctx context.Context, fn func(store *repositories.Store) error) error {
+	u.calls++
+	err := fn(u.store)
+	u.committed = err == nil
+	return err
+}
+
+func TestBorrowingService_UnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	newTxStore := func() (*repositories.Store, *MockBorrowingRepository, *MockUserRepository, *MockGameRepository) {
+		borrowingRepo := &MockBorrowingRepository{}
+		userRepo := &MockUserRepository{}
+		gameRepo := &MockGameRepository{}
+		return &repositories.Store{Borrowings: borrowingRepo, Users: userRepo, Games: gameRepo}, borrowingRepo, userRepo, gameRepo
+	}
+
+	t.Run("borrow runs on the transaction's repositories", func(t *testing.T) {
+		store, borrowingRepo, userRepo, gameRepo := newTxStore()
+		user := &models.User{ID: 1, IsActive: true}
+		userRepo.On("GetByID", 1).Return(user, nil)
+		borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+		gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, IsAvailable: true}, nil)
+		gameRepo.On("GetCopies", 1).Return([]*models.GameCopy{{ID: 10, GameID: 1, IsAvailable: true}}, nil)
+		borrowingRepo.On("Create", mock.AnythingOfType("*models.Borrowing")).Return(nil)
+		gameRepo.On("UpdateCopy", mock.AnythingOfType("*models.GameCopy")).Return(nil)
+
+		txHolds := &MockHoldQueue{}
+		txHolds.On("ClaimHold", 1, 1).Return(nil, nil)
+		txHolds.On("FulfilHold", 1, 1).Return(nil)
+		holds := &MockHoldQueue{}
+		holds.On("WithStore", store).Return(txHolds)
+
+		txPolicies := &MockLoanPolicies{}
+		txPolicies.On("PolicyForUser", user).Return(models.DefaultLoanPolicy(), nil)
+		policies := &MockLoanPolicies{}
+		policies.On("WithStore", store).Return(txPolicies)
+
+		// The service's own repositories have no expectations and must not be used
+		service := NewBorrowingService(&MockBorrowingRepository{}, &MockUserRepository{}, &MockGameRepository{})
+		service.SetHoldQueue(holds)
+		service.SetLoanPolicies(policies)
+		uow := &fakeUnitOfWork{store: store}
+		service.SetUnitOfWork(uow)
+
+		borrowing, err := service.BorrowGame(ctx, 1, 1, time.Now().Add(7*24*time.Hour))
+
+		assert.NoError(t, err)
+		assert.NotNil(t, borrowing)
+		assert.Equal(t, 1, uow.calls)
+		assert.True(t, uow.committed)
+		borrowingRepo.AssertExpectations(t)
+		gameRepo.AssertExpectations(t)
+		txHolds.AssertExpectations(t)
+		txPolicies.AssertExpectations(t)
+	})
+
+	t.Run("failed copy update rolls the borrow back", func(t *testing.T) {
+		store, borrowingRepo, userRepo, gameRepo := newTxStore()
+		userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
+		borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+		gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, IsAvailable: true}, nil)
+		gameRepo.On("GetCopies", 1).Return([]*models.GameCopy{{ID: 10, GameID: 1, IsAvailable: true}}, nil)
+		borrowingRepo.On("Create", mock.AnythingOfType("*models.Borrowing")).Return(nil)
+		gameRepo.On("UpdateCopy", mock.AnythingOfType("*models.GameCopy")).Return(errors.New("disk I/O error"))
+
+		service := NewBorrowingService(&MockBorrowingRepository{}, &MockUserRepository{}, &MockGameRepository{})
+		uow := &fakeUnitOfWork{store: store}
+		service.SetUnitOfWork(uow)
+
+		borrowing, err := service.BorrowGame(ctx, 1, 1, time.Now().Add(7*24*time.Hour))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update copy availability")
+		assert.Nil(t, borrowing)
+		assert.False(t, uow.committed)
+	})
+
+	t.Run("failed copy update rolls the return back", func(t *testing.T) {
+		store, borrowingRepo, _, gameRepo := newTxStore()
+		copyID := 10
+		borrowingRepo.On("GetByID", 5).Return(&models.Borrowing{ID: 5, UserID: 1, GameID: 1, CopyID: &copyID}, nil)
+		borrowingRepo.On("Update", mock.AnythingOfType("*models.Borrowing")).Return(nil)
+		gameRepo.On("GetCopyByID", 10).Return(&models.GameCopy{ID: 10, GameID: 1}, nil)
+		gameRepo.On("UpdateCopy", mock.AnythingOfType("*models.GameCopy")).Return(errors.New("disk I/O error"))
+
+		service := NewBorrowingService(&MockBorrowingRepository{}, &MockUserRepository{}, &MockGameRepository{})
+		uow := &fakeUnitOfWork{store: store}
+		service.SetUnitOfWork(uow)
+
+		err := service.ReturnGame(ctx, 5, nil)
+
+		assert.Error(t, err)
+		assert.False(t, uow.committed)
+		borrowingRepo.AssertExpectations(t)
+	})
+}
+
+func TestBorrowingService_BorrowCopy(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		copyID        int
+		setupMocks    func(*MockBorrowingRepository, *MockUserRepository, *MockGameRepository)
+		expectedError string
+	}{
+		{
+			name:   "successful borrowing of a specific copy",
+			copyID: 12,
+			setupMocks: func(borrowingRepo *MockBorrowingRepository, userRepo *MockUserRepository, gameRepo *MockGameRepository) {
+				userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
+				borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+				gameRepo.On("GetCopyByID", 12).Return(&models.GameCopy{ID: 12, GameID: 1, Condition: "good", IsAvailable: true}, nil)
+				borrowingRepo.On("Create", mock.MatchedBy(func(b *models.Borrowing) bool {
+					return b.GameID == 1 && b.CopyID != nil && *b.CopyID == 12
+				})).Return(nil)
+				gameRepo.On("UpdateCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+					return c.ID == 12 && !c.IsAvailable
+				})).Return(nil)
+			},
+		},
+		{
+			name:   "invalid copy ID",
+			copyID: 0,
+			setupMocks: func(borrowingRepo *MockBorrowingRepository, userRepo *MockUserRepository, gameRepo *MockGameRepository) {
+			},
+			expectedError: "invalid copy ID",
+		},
+		{
+			name:   "copy of another game",
+			copyID: 12,
+			setupMocks: func(borrowingRepo *MockBorrowingRepository, userRepo *MockUserRepository, gameRepo *MockGameRepository) {
+				userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
+				borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+				gameRepo.On("GetCopyByID", 12).Return(&models.GameCopy{ID: 12, GameID: 2, IsAvailable: true}, nil)
+			},
+			expectedError: "copy 12 does not belong to game 1",
+		},
+		{
+			name:   "copy already borrowed",
+			copyID: 12,
+			setupMocks: func(borrowingRepo *MockBorrowingRepository, userRepo *MockUserRepository, gameRepo *MockGameRepository) {
+				userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
+				borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+				gameRepo.On("GetCopyByID", 12).Return(&models.GameCopy{ID: 12, GameID: 1, IsAvailable: false}, nil)
+			},
+			expectedError: "copy is not available for borrowing",
+		},
+		{
+			name:   "copy in repair",
+			copyID: 12,
+			setupMocks: func(borrowingRepo *MockBorrowingRepository, userRepo *MockUserRepository, gameRepo *MockGameRepository) {
+				userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
+				borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+				gameRepo.On("GetCopyByID", 12).Return(&models.GameCopy{ID: 12, GameID: 1, Condition: "poor", InRepair: true}, nil)
+			},
+			expectedError: "copy is kept from lending until it is repaired",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			borrowingRepo := &MockBorrowingRepository{}
+			userRepo := &MockUserRepository{}
+			gameRepo := &MockGameRepository{}
+			tt.setupMocks(borrowingRepo, userRepo, gameRepo)
+
+			service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+			borrowing, err := service.BorrowCopy(ctx, 1, 1, tt.copyID, time.Now().Add(7*24*time.Hour))
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, borrowing)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 12, *borrowing.CopyID)
+			}
+
+			borrowingRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+			gameRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBorrowingService_BorrowWithHolds(t *testing.T) {
+	ctx := context.Background()
+	t.Run("borrowing the game lends the copy held for the user", func(t *testing.T) {
+		borrowingRepo := &MockBorrowingRepository{}
+		userRepo := &MockUserRepository{}
+		gameRepo := &MockGameRepository{}
+		holds := &MockHoldQueue{}
+
+		userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
+		borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+		gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, IsAvailable: false}, nil)
+		holds.On("ClaimHold", 1, 1).Return(&models.GameCopy{ID: 10, GameID: 1, IsAvailable: false}, nil)
+		borrowingRepo.On("Create", mock.MatchedBy(func(b *models.Borrowing) bool {
+			return b.CopyID != nil && *b.CopyID == 10
+		})).Return(nil)
+		gameRepo.On("UpdateCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+			return c.ID == 10 && !c.IsAvailable
+		})).Return(nil)
+		holds.On("FulfilHold", 1, 1).Return(nil)
+
+		service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+		service.SetHoldQueue(holds)
+		borrowing, err := service.BorrowGame(ctx, 1, 1, time.Now().Add(7*24*time.Hour))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, *borrowing.CopyID)
+		gameRepo.AssertNotCalled(t, "GetCopies", mock.Anything)
+		holds.AssertExpectations(t)
+		borrowingRepo.AssertExpectations(t)
+	})
+
+	t.Run("a copy held for someone else cannot be borrowed", func(t *testing.T) {
+		borrowingRepo := &MockBorrowingRepository{}
+		userRepo := &MockUserRepository{}
+		gameRepo := &MockGameRepository{}
+		holds := &MockHoldQueue{}
+
+		userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
+		borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+		gameRepo.On("GetCopyByID", 10).Return(&models.GameCopy{ID: 10, GameID: 1, IsAvailable: false}, nil)
+		holds.On("ClaimHold", 1, 1).Return(nil, nil)
+
+		service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+		service.SetHoldQueue(holds)
+		borrowing, err := service.BorrowCopy(ctx, 1, 1, 10, time.Now().Add(7*24*time.Hour))
+
+		assert.EqualError(t, err, "copy is not available for borrowing")
+		assert.Nil(t, borrowing)
+		borrowingRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestBorrowingService_ReturnGameWithHolds(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		held          bool
+		expectRelease bool
+	}{
+		{name: "copy held for the next user stays unavailable", held: true, expectRelease: false},
+		{name: "copy goes back on the shelf when nobody waits", held: false, expectRelease: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			borrowingRepo := &MockBorrowingRepository{}
+			userRepo := &MockUserRepository{}
+			gameRepo := &MockGameRepository{}
+			holds := &MockHoldQueue{}
+
+			copyID := 10
+			borrowingRepo.On("GetByID", 1).Return(&models.Borrowing{
+				ID: 1, UserID: 1, GameID: 1, CopyID: &copyID,
+				BorrowedAt: time.Now().Add(-7 * 24 * time.Hour), DueDate: time.Now().Add(7 * 24 * time.Hour),
+			}, nil)
+			borrowingRepo.On("Update", mock.Anything).Return(nil)
+			gameCopy := &models.GameCopy{ID: 10, GameID: 1, IsAvailable: false}
+			gameRepo.On("GetCopyByID", 10).Return(gameCopy, nil)
+			holds.On("HoldReturnedCopy", gameCopy).Return(tt.held, nil)
+			if tt.expectRelease {
+				gameRepo.On("UpdateCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+					return c.ID == 10 && c.IsAvailable
+				})).Return(nil)
+			}
+
+			service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+			service.SetHoldQueue(holds)
+			err := service.ReturnGame(ctx, 1, nil)
+
+			assert.NoError(t, err)
+			holds.AssertExpectations(t)
+			gameRepo.AssertExpectations(t)
+			if !tt.expectRelease {
+				gameRepo.AssertNotCalled(t, "UpdateCopy", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestBorrowingService_ReturnGameChargesLateFees(t *testing.T) {
+	ctx := context.Background()
+	policy := &models.LoanPolicy{Tier: "standard", MaxLoans: 5, MaxLoanDays: 90, DefaultLoanDays: 14, FinePerDayCents: 50}
+	user := &models.User{ID: 1, IsActive: true, MembershipTier: "standard"}
+
+	tests := []struct {
+		name       string
+		dueDate    time.Time
+		wantCharge bool
+	}{
+		{name: "late return is charged", dueDate: time.Now().Add(-3*24*time.Hour - time.Hour), wantCharge: true},
+		{name: "return on time costs nothing", dueDate: time.Now().Add(24 * time.Hour), wantCharge: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			borrowingRepo := &MockBorrowingRepository{}
+			userRepo := &MockUserRepository{}
+			gameRepo := &MockGameRepository{}
+			policies := &MockLoanPolicies{}
+			fines := &MockFineLedger{}
+
+			borrowingRepo.On("GetByID", 1).Return(&models.Borrowing{
+				ID: 1, UserID: 1, GameID: 1, BorrowedAt: tt.dueDate.Add(-14 * 24 * time.Hour), DueDate: tt.dueDate,
+			}, nil)
+			borrowingRepo.On("Update", mock.Anything).Return(nil)
+			gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, IsAvailable: false}, nil)
+			gameRepo.On("Update", mock.Anything).Return(nil)
+			if tt.wantCharge {
+				userRepo.On("GetByID", 1).Return(user, nil)
+				policies.On("PolicyForUser", user).Return(policy, nil)
+				fines.On("ChargeLateReturn", mock.MatchedBy(func(b *models.Borrowing) bool {
+					return b.ID == 1 && b.ReturnedAt != nil && b.DaysLate() == 3
+				}), policy).Return(&models.FineEntry{AmountCents: 150}, nil)
+			}
+
+			service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+			service.SetLoanPolicies(policies)
+			service.SetFines(fines)
+			err := service.ReturnGame(ctx, 1, nil)
+
+			assert.NoError(t, err)
+			fines.AssertExpectations(t)
+			if !tt.wantCharge {
+				fines.AssertNotCalled(t, "ChargeLateReturn", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestBorrowingService_ReturnGameWithCondition(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		report        *models.ConditionReport
+		expectRecord  bool
+		expectHold    bool
+		wantInRepair  bool
+		expectedError string
+	}{
+		{
+			name:         "copy with missing pieces is kept from lending",
+			report:       &models.ConditionReport{Condition: "missing_pieces", Notes: " Two blue meeples missing "},
+			expectRecord: true,
+			wantInRepair: true,
+		},
+		{
+			name:       "unchanged condition is not recorded",
+			report:     &models.ConditionReport{Condition: "Good"},
+			expectHold: true,
+		},
+		{
+			name:          "invalid condition",
+			report:        &models.ConditionReport{Condition: "broken"},
+			expectedError: "invalid game condition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			borrowingRepo := &MockBorrowingRepository{}
+			gameRepo := &MockGameRepository{}
+			holds := &MockHoldQueue{}
+			conditions := &MockConditionLog{}
+
+			copyID := 10
+			borrowingRepo.On("GetByID", 1).Return(&models.Borrowing{
+				ID: 1, UserID: 1, GameID: 1, CopyID: &copyID,
+				BorrowedAt: time.Now().Add(-7 * 24 * time.Hour), DueDate: time.Now().Add(7 * 24 * time.Hour),
+			}, nil)
+			borrowingRepo.On("Update", mock.Anything).Return(nil)
+			gameCopy := &models.GameCopy{ID: 10, GameID: 1, Condition: "good", IsAvailable: false}
+			gameRepo.On("GetCopyByID", 10).Return(gameCopy, nil)
+			if tt.expectRecord {
+				conditions.On("Record", mock.MatchedBy(func(c *models.ConditionChange) bool {
+					return c.GameID == 1 && *c.CopyID == 10 && *c.BorrowingID == 1 &&
+						c.PreviousCondition == "good" && c.Condition == "missing_pieces" && c.Notes == "Two blue meeples missing"
+				})).Return(nil)
+			}
+			if tt.expectHold {
+				holds.On("HoldReturnedCopy", gameCopy).Return(false, nil)
+			}
+			gameRepo.On("UpdateCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+				return c.ID == 10 && c.InRepair == tt.wantInRepair && c.IsAvailable == !tt.wantInRepair
+			})).Return(nil)
+
+			service := NewBorrowingService(borrowingRepo, &MockUserRepository{}, gameRepo)
+			service.SetHoldQueue(holds)
+			service.SetConditions(conditions)
+			err := service.ReturnGame(ctx, 1, tt.report)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				borrowingRepo.AssertNotCalled(t, "Update", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			conditions.AssertExpectations(t)
+			holds.AssertExpectations(t)
+			gameRepo.AssertExpectations(t)
+			if !tt.expectRecord {
+				conditions.AssertNotCalled(t, "Record", mock.Anything)
+			}
+			if !tt.expectHold {
+				holds.AssertNotCalled(t, "HoldReturnedCopy", mock.Anything)
+			}
+		})
+	}
+}
+
 func TestBorrowingService_ReturnGame(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		borrowingID   int
@@ -200,6 +798,33 @@ func TestBorrowingService_ReturnGame(t *testing.T) {
 		{
 			name:        "successful return",
 			borrowingID: 1,
+			setupMocks: func(borrowingRepo *MockBorrowingRepository, userRepo *MockUserRepository, gameRepo *MockGameRepository) {
+				copyID := 10
+				borrowing := &models.Borrowing{
+					ID:         1,
+					UserID:     1,
+					GameID:     1,
+					CopyID:     &copyID,
+					BorrowedAt: time.Now().Add(-7 * 24 * time.Hour),
+					DueDate:    time.Now().Add(7 * 24 * time.Hour),
+					ReturnedAt: nil,
+				}
+				borrowingRepo.On("GetByID", 1).Return(borrowing, nil)
+				borrowingRepo.On("Update", mock.MatchedBy(func(b *models.Borrowing) bool {
+					return b.ID == 1 && b.ReturnedAt != nil
+				})).Return(nil)
+				
+				gameCopy := &models.GameCopy{ID: 10, GameID: 1, Condition: "good", IsAvailable: false}
+				gameRepo.On("GetCopyByID", 10).Return(gameCopy, nil)
+				gameRepo.On("UpdateCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+					return c.ID == 10 && c.IsAvailable
+				})).Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name:        "successful return of borrowing without copy",
+			borrowingID: 1,
 			setupMocks: func(borrowingRepo *MockBorrowingRepository, userRepo *MockUserRepository, gameRepo *MockGameRepository) {
 				borrowing := &models.Borrowing{
 					ID:         1,
@@ -265,7 +890,7 @@ func TestBorrowingService_ReturnGame(t *testing.T) {
 			tt.setupMocks(borrowingRepo, userRepo, gameRepo)
 
 			service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
-			err := service.ReturnGame(tt.borrowingID)
+			err := service.ReturnGame(ctx, tt.borrowingID, nil)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -282,6 +907,7 @@ func TestBorrowingService_ReturnGame(t *testing.T) {
 }
 
 func TestBorrowingService_GetOverdueItems(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		setupMocks    func(*MockBorrowingRepository, *MockUserRepository, *MockGameRepository)
@@ -323,7 +949,7 @@ func TestBorrowingService_GetOverdueItems(t *testing.T) {
 			tt.setupMocks(borrowingRepo, userRepo, gameRepo)
 
 			service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
-			items, err := service.GetOverdueItems()
+			items, err := service.GetOverdueItems(ctx)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -343,6 +969,7 @@ func TestBorrowingService_GetOverdueItems(t *testing.T) {
 }
 
 func TestBorrowingService_ExtendDueDate(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		borrowingID   int
@@ -364,7 +991,10 @@ func TestBorrowingService_ExtendDueDate(t *testing.T) {
 					ReturnedAt: nil,
 				}
 				borrowingRepo.On("GetByID", 1).Return(borrowing, nil)
-				borrowingRepo.On("Update", mock.AnythingOfType("*models.Borrowing")).Return(nil)
+				userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
+				borrowingRepo.On("Update", mock.MatchedBy(func(b *models.Borrowing) bool {
+					return b.ExtensionCount == 1
+				})).Return(nil)
 			},
 			expectedError: "",
 		},
@@ -426,9 +1056,28 @@ func TestBorrowingService_ExtendDueDate(t *testing.T) {
 					ReturnedAt: nil,
 				}
 				borrowingRepo.On("GetByID", 1).Return(borrowing, nil)
+				userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
 			},
 			expectedError: "due date cannot be more than 90 days from borrowed date",
 		},
+		{
+			name:        "extension limit reached",
+			borrowingID: 1,
+			newDueDate:  time.Now().Add(21 * 24 * time.Hour),
+			setupMocks: func(borrowingRepo *MockBorrowingRepository, userRepo *MockUserRepository, gameRepo *MockGameRepository) {
+				borrowing := &models.Borrowing{
+					ID:             1,
+					UserID:         1,
+					GameID:         1,
+					BorrowedAt:     time.Now().Add(-7 * 24 * time.Hour),
+					DueDate:        time.Now().Add(7 * 24 * time.Hour),
+					ExtensionCount: 3,
+				}
+				borrowingRepo.On("GetByID", 1).Return(borrowing, nil)
+				userRepo.On("GetByID", 1).Return(&models.User{ID: 1, IsActive: true}, nil)
+			},
+			expectedError: "borrowing has reached the limit of 3 extension(s) for the standard tier",
+		},
 	}
 
 	for _, tt := range tests {
@@ -439,7 +1088,7 @@ func TestBorrowingService_ExtendDueDate(t *testing.T) {
 			tt.setupMocks(borrowingRepo, userRepo, gameRepo)
 
 			service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
-			err := service.ExtendDueDate(tt.borrowingID, tt.newDueDate)
+			err := service.ExtendDueDate(ctx, tt.borrowingID, tt.newDueDate)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -456,6 +1105,7 @@ func TestBorrowingService_ExtendDueDate(t *testing.T) {
 }
 
 func TestBorrowingService_GetItemsDueSoon(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		daysAhead     int
@@ -498,7 +1148,7 @@ func TestBorrowingService_GetItemsDueSoon(t *testing.T) {
 			tt.setupMocks(borrowingRepo, userRepo, gameRepo)
 
 			service := NewBorrowingService(borrowingRepo, userRepo, gameRepo)
-			items, err := service.GetItemsDueSoon(tt.daysAhead)
+			items, err := service.GetItemsDueSoon(ctx, tt.daysAhead)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -515,4 +1165,24 @@ func TestBorrowingService_GetItemsDueSoon(t *testing.T) {
 			gameRepo.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestBorrowingService_ListBorrowings(t *testing.T) {
+	ctx := context.Background()
+	borrowingRepo := &MockBorrowingRepository{}
+	filter := models.BorrowingFilter{UserID: 3, Status: models.BorrowingStatusOverdue, ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage, Sort: "borrowed_at"}}
+	borrowingRepo.On("List", filter).Return([]*models.Borrowing{{ID: 1, UserID: 3}, {ID: 2, UserID: 3}}, nil)
+	borrowingRepo.On("Count", filter).Return(2, nil)
+
+	service := NewBorrowingService(borrowingRepo, &MockUserRepository{}, &MockGameRepository{})
+	borrowings, total, err := service.ListBorrowings(ctx, models.BorrowingFilter{UserID: 3, Status: models.BorrowingStatusOverdue})
+
+	assert.NoError(t, err)
+	assert.Len(t, borrowings, 2)
+	assert.Equal(t, 2, total)
+	borrowingRepo.AssertExpectations(t)
+
+	_, _, err = service.ListBorrowings(ctx, models.BorrowingFilter{Status: "lost"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid borrowing filter")
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"fmt"
+	"time"
+)
+
+// ConditionLog keeps the condition history of games and warns librarians
+// when a game is damaged. It is implemented by ConditionService.
+type ConditionLog interface {
+	// Record adds a change to the condition history of its game
+	Record(ctx context.Context, change *models.ConditionChange) error
+	GetHistory(ctx context.Context, gameID int) ([]*models.ConditionChange, error)
+	// WithStore returns a condition log working on the given repositories
+	WithStore(store *repositories.Store) ConditionLog
+}
+
+// ConditionService keeps the condition history of games and raises damage
+// alerts for librarians
+type ConditionService struct {
+	conditionRepo repositories.ConditionRepository
+	gameRepo      repositories.GameRepository
+	userRepo      repositories.UserRepository
+	alertRepo     repositories.AlertRepository
+}
+
+// NewConditionService creates a new ConditionService instance
+func NewConditionService(conditionRepo repositories.ConditionRepository, gameRepo repositories.GameRepository, userRepo repositories.UserRepository, alertRepo repositories.AlertRepository) *ConditionService {
+	return &ConditionService{
+		conditionRepo: conditionRepo,
+		gameRepo:      gameRepo,
+		userRepo:      userRepo,
+		alertRepo:     alertRepo,
+	}
+}
+
+// WithStore returns a copy of the service working on the given repositories
+func (s *ConditionService) WithStore(store *repositories.Store) ConditionLog {
+	return &ConditionService{
+		conditionRepo: store.Conditions,
+		gameRepo:      store.Games,
+		userRepo:      store.Users,
+		alertRepo:     store.Alerts,
+	}
+}
+
+// Record adds a change to the condition history of its game. A downgrade
+// raises a damage alert for every active librarian and administrator.
+func (s *ConditionService) Record(ctx context.Context, change *models.ConditionChange) error {
+	if change.RecordedAt.IsZero() {
+		change.RecordedAt = time.Now()
+	}
+
+	if err := s.conditionRepo.Create(ctx, change); err != nil {
+		return fmt.Errorf("failed to record condition change: %w", err)
+	}
+
+	if !change.IsDowngrade() {
+		return nil
+	}
+
+	return s.alertLibrarians(ctx, change)
+}
+
+// GetHistory retrieves the condition history of a game, newest first
+func (s *ConditionService) GetHistory(ctx context.Context, gameID int) ([]*models.ConditionChange, error) {
+	changes, err := s.conditionRepo.GetByGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get condition history: %w", err)
+	}
+
+	return changes, nil
+}
+
+// alertLibrarians creates the damage alert of a downgrade for the users who
+// can inspect and repair the game
+func (s *ConditionService) alertLibrarians(ctx context.Context, change *models.ConditionChange) error {
+	game, err := s.gameRepo.GetByID(ctx, change.GameID)
+	if err != nil {
+		return fmt.Errorf("failed to get game details for alert: %w", err)
+	}
+
+	message := damageMessage(game.Name, change)
+	active := true
+	for _, role := range []string{models.RoleLibrarian, models.RoleAdmin} {
+		librarians, err := s.userRepo.List(ctx, models.UserFilter{Role: role, Active: &active})
+		if err != nil {
+			return fmt.Errorf("failed to get librarians: %w", err)
+		}
+
+		for _, librarian := range librarians {
+			alert := &models.Alert{
+				UserID:    librarian.ID,
+				GameID:    change.GameID,
+				Type:      "damage",
+				Message:   message,
+				CreatedAt: change.RecordedAt,
+				IsRead:    false,
+			}
+			if err := s.alertRepo.Create(ctx, alert); err != nil {
+				return fmt.Errorf("failed to create damage alert: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// damageMessage describes a downgrade in a message short enough for an alert
+func damageMessage(gameName string, change *models.ConditionChange) string {
+	subject := fmt.Sprintf("'%s'", gameName)
+	if change.CopyID != nil {
+		subject = fmt.Sprintf("'%s' (copy #%d)", gameName, *change.CopyID)
+	}
+
+	message := fmt.Sprintf("Condition of %s went from %s to %s", subject, change.PreviousCondition, change.Condition)
+	if change.BorrowingID != nil {
+		message = fmt.Sprintf("%s at the return of borrowing #%d", message, *change.BorrowingID)
+	}
+	if change.CopyID != nil && models.NeedsRepair(change.Condition) {
+		message += ", it is kept from lending until repaired"
+	}
+	if change.Notes != "" {
+		message += ": " + change.Notes
+	}
+
+	return truncateText(message, 500) // longest alert message
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockConditionRepository is a mock implementation of ConditionRepository
+type MockConditionRepository struct {
+	mock.Mock
+}
+
+func (m *MockConditionRepository) Create(ctx context.Context, change *models.ConditionChange) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+func (m *MockConditionRepository) GetByGame(ctx context.Context, gameID int) ([]*models.ConditionChange, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ConditionChange), args.Error(1)
+}
+
+// MockConditionLog is a mock implementation of ConditionLog
+type MockConditionLog struct {
+	mock.Mock
+}
+
+func (m *MockConditionLog) Record(ctx context.Context, change *models.ConditionChange) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+func (m *MockConditionLog) GetHistory(ctx context.Context, gameID int) ([]*models.ConditionChange, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ConditionChange), args.Error(1)
+}
+
+func (m *MockConditionLog) WithStore(store *repositories.Store) ConditionLog {
+	args := m.Called(store)
+	return args.Get(0).(ConditionLog)
+}
+
+func TestConditionService_RecordDowngradeAlertsLibrarians(t *testing.T) {
+	ctx := context.Background()
+	conditionRepo := &MockConditionRepository{}
+	gameRepo := &MockGameRepository{}
+	userRepo := &MockUserRepository{}
+	alertRepo := &MockAlertRepository{}
+
+	active := true
+	copyID, borrowingID := 10, 4
+	change := &models.ConditionChange{
+		GameID: 1, CopyID: &copyID, BorrowingID: &borrowingID,
+		PreviousCondition: "good", Condition: "missing_pieces", Notes: "Two blue meeples missing",
+	}
+	conditionRepo.On("Create", change).Return(nil)
+	gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, Name: "Carcassonne"}, nil)
+	userRepo.On("List", models.UserFilter{Role: models.RoleLibrarian, Active: &active}).Return([]*models.User{{ID: 2}}, nil)
+	userRepo.On("List", models.UserFilter{Role: models.RoleAdmin, Active: &active}).Return([]*models.User{{ID: 3}}, nil)
+	var alerted []int
+	alertRepo.On("Create", mock.MatchedBy(func(a *models.Alert) bool {
+		return a.Type == "damage" && a.GameID == 1 && !a.IsRead &&
+			a.Message == "Condition of 'Carcassonne' (copy #10) went from good to missing_pieces at the return of borrowing #4, it is kept from lending until repaired: Two blue meeples missing"
+	})).Run(func(args mock.Arguments) {
+		alerted = append(alerted, args.Get(0).(*models.Alert).UserID)
+	}).Return(nil)
+
+	service := NewConditionService(conditionRepo, gameRepo, userRepo, alertRepo)
+	err := service.Record(ctx, change)
+
+	assert.NoError(t, err)
+	assert.False(t, change.RecordedAt.IsZero())
+	assert.Equal(t, []int{2, 3}, alerted)
+	conditionRepo.AssertExpectations(t)
+	alertRepo.AssertExpectations(t)
+}
+
+func TestConditionService_Record(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		change        *models.ConditionChange
+		createErr     error
+		expectedError string
+	}{
+		{
+			name:   "repair raises no alert",
+			change: &models.ConditionChange{GameID: 1, PreviousCondition: "poor", Condition: "good"},
+		},
+		{
+			name:   "notes without a change raise no alert",
+			change: &models.ConditionChange{GameID: 1, PreviousCondition: "fair", Condition: "fair", Notes: "Box corner torn"},
+		},
+		{
+			name:          "repository error",
+			change:        &models.ConditionChange{GameID: 1, PreviousCondition: "good", Condition: "poor"},
+			createErr:     errors.New("database error"),
+			expectedError: "failed to record condition change",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditionRepo := &MockConditionRepository{}
+			alertRepo := &MockAlertRepository{}
+			conditionRepo.On("Create", tt.change).Return(tt.createErr)
+
+			service := NewConditionService(conditionRepo, &MockGameRepository{}, &MockUserRepository{}, alertRepo)
+			err := service.Record(ctx, tt.change)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			alertRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestDamageMessage(t *testing.T) {
+	copyID := 7
+	tests := []struct {
+		name   string
+		change *models.ConditionChange
+		want   string
+	}{
+		{
+			name:   "game without copy",
+			change: &models.ConditionChange{GameID: 1, PreviousCondition: "excellent", Condition: "fair"},
+			want:   "Condition of 'Azul' went from excellent to fair",
+		},
+		{
+			name:   "worn copy stays on the shelf",
+			change: &models.ConditionChange{GameID: 1, CopyID: &copyID, PreviousCondition: "good", Condition: "fair", Notes: "Scratched box"},
+			want:   "Condition of 'Azul' (copy #7) went from good to fair: Scratched box",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, damageMessage("Azul", tt.change))
+		})
+	}
+
+	long := damageMessage("Azul", &models.ConditionChange{PreviousCondition: "good", Condition: "poor", Notes: strings.Repeat("a", models.MaxConditionNotesLength)})
+	assert.LessOrEqual(t, len(long), 500)
+}
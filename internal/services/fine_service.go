@@ -0,0 +1,224 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FineLedger charges late returns and tells how much users owe. It is
+// implemented by FineService.
+type FineLedger interface {
+	Balance(ctx context.Context, userID int) (int, error)
+	// ChargeLateReturn fines the borrower of a returned borrowing according
+	// to policy, returning nil when the return costs nothing
+	ChargeLateReturn(ctx context.Context, borrowing *models.Borrowing, policy *models.LoanPolicy) (*models.FineEntry, error)
+	// WithStore returns a ledger working on the given repositories
+	WithStore(store *repositories.Store) FineLedger
+}
+
+// fineBalance returns the amount a user owes, nothing when fines are not
+// tracked
+func fineBalance(ctx context.Context, fines FineLedger, userID int) (int, error) {
+	if fines == nil {
+		return 0, nil
+	}
+
+	balance, err := fines.Balance(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check fines: %w", err)
+	}
+
+	return balance, nil
+}
+
+// FineService handles the fines charged for late returns and their
+// settlement by payments and waivers
+type FineService struct {
+	fineRepo repositories.FineRepository
+	userRepo repositories.UserRepository
+	uow      repositories.UnitOfWork
+	auditTrail
+}
+
+// NewFineService creates a new FineService instance
+func NewFineService(fineRepo repositories.FineRepository, userRepo repositories.UserRepository) *FineService {
+	return &FineService{
+		fineRepo: fineRepo,
+		userRepo: userRepo,
+	}
+}
+
+// SetUnitOfWork makes payments and waivers atomic: the balance they are
+// checked against, their entry and its audit record are read and written in
+// one transaction. Without it the repositories are used directly.
+func (s *FineService) SetUnitOfWork(uow repositories.UnitOfWork) {
+	s.uow = uow
+}
+
+// WithActor returns a copy of the service that records actor as the author
+// of its changes in the audit log
+func (s *FineService) WithActor(actor *models.User) *FineService {
+	bound := *s
+	bound.actor = actor
+	return &bound
+}
+
+// WithStore returns a copy of the service working on the given repositories
+func (s *FineService) WithStore(store *repositories.Store) FineLedger {
+	return s.withStore(store)
+}
+
+// withStore implements WithStore
+func (s *FineService) withStore(store *repositories.Store) *FineService {
+	return &FineService{
+		fineRepo:   store.Fines,
+		userRepo:   store.Users,
+		auditTrail: s.auditTrail.withStore(store),
+	}
+}
+
+// atomically runs fn with a copy of the service whose repositories are bound
+// to a single unit of work
+func (s *FineService) atomically(ctx context.Context, fn func(tx *FineService) error) error {
+	if s.uow == nil {
+		return fn(s)
+	}
+
+	return s.uow.Do(ctx, func(store *repositories.Store) error {
+		return fn(s.withStore(store))
+	})
+}
+
+// GetAccount retrieves the fines ledger of a user with the amount they owe
+func (s *FineService) GetAccount(ctx context.Context, userID int) (*models.FineAccount, error) {
+	if userID <= 0 {
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	entries, err := s.fineRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fines: %w", err)
+	}
+
+	balance, err := s.fineRepo.Balance(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fine balance: %w", err)
+	}
+
+	return &models.FineAccount{
+		UserID:       userID,
+		BalanceCents: balance,
+		Entries:      entries,
+	}, nil
+}
+
+// Balance returns the amount, in cents, a user owes
+func (s *FineService) Balance(ctx context.Context, userID int) (int, error) {
+	balance, err := s.fineRepo.Balance(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fine balance: %w", err)
+	}
+
+	return balance, nil
+}
+
+// ChargeLateReturn fines the borrower of a late return the fee of their loan
+// policy for the days it was late
+func (s *FineService) ChargeLateReturn(ctx context.Context, borrowing *models.Borrowing, policy *models.LoanPolicy) (*models.FineEntry, error) {
+	daysLate := borrowing.DaysLate()
+	fee := policy.LateFee(daysLate)
+	if fee == 0 {
+		return nil, nil
+	}
+
+	borrowingID := borrowing.ID
+	entry := &models.FineEntry{
+		UserID:      borrowing.UserID,
+		BorrowingID: &borrowingID,
+		Kind:        models.FineKindFine,
+		AmountCents: fee,
+		Reason:      fmt.Sprintf("Returned %d day(s) late", daysLate),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.fineRepo.Create(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to create fine: %w", err)
+	}
+
+	if err := s.record(ctx, models.AuditActionCreate, models.AuditEntityFine, entry.ID, nil, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// PayFine records a payment of a user towards their fines
+func (s *FineService) PayFine(ctx context.Context, userID, amountCents int, reason string) (*models.FineEntry, error) {
+	return s.settle(ctx, models.FineKindPayment, models.AuditActionPay, userID, amountCents, reason)
+}
+
+// WaiveFine forgives part or all of the fines of a user
+func (s *FineService) WaiveFine(ctx context.Context, userID, amountCents int, reason string) (*models.FineEntry, error) {
+	return s.settle(ctx, models.FineKindWaiver, models.AuditActionWaive, userID, amountCents, reason)
+}
+
+// settle records a payment or a waiver, which cannot exceed what the user owes
+func (s *FineService) settle(ctx context.Context, kind, action string, userID, amountCents int, reason string) (*models.FineEntry, error) {
+	var entry *models.FineEntry
+	err := s.atomically(ctx, func(tx *FineService) error {
+		var err error
+		entry, err = tx.settleEntry(ctx, kind, action, userID, amountCents, reason)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// settleEntry implements settle on repositories bound to one unit of work
+func (s *FineService) settleEntry(ctx context.Context, kind, action string, userID, amountCents int, reason string) (*models.FineEntry, error) {
+	entry := &models.FineEntry{
+		UserID:      userID,
+		Kind:        kind,
+		AmountCents: amountCents,
+		Reason:      strings.TrimSpace(reason),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := models.ValidateFineEntry(entry); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	balance, err := s.fineRepo.Balance(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fine balance: %w", err)
+	}
+
+	if amountCents > balance {
+		return nil, models.Invalid("amount_cents", "amount_exceeds_balance", amountCents, balance)
+	}
+
+	if err := s.fineRepo.Create(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to record %s: %w", kind, err)
+	}
+
+	if err := s.record(ctx, action, models.AuditEntityFine, entry.ID, nil, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
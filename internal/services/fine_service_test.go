@@ -0,0 +1,217 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockFineRepository is a mock implementation of FineRepository
+type MockFineRepository struct {
+	mock.Mock
+}
+
+func (m *MockFineRepository) Create(ctx context.Context, entry *models.FineEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockFineRepository) GetByUser(ctx context.Context, userID int) ([]*models.FineEntry, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.FineEntry), args.Error(1)
+}
+
+func (m *MockFineRepository) Balance(ctx context.Context, userID int) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+// MockFineLedger is a mock implementation of FineLedger
+type MockFineLedger struct {
+	mock.Mock
+}
+
+func (m *MockFineLedger) Balance(ctx context.Context, userID int) (int, error) {
+	args := m.Called(userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockFineLedger) ChargeLateReturn(ctx context.Context, borrowing *models.Borrowing, policy *models.LoanPolicy) (*models.FineEntry, error) {
+	args := m.Called(borrowing, policy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FineEntry), args.Error(1)
+}
+
+func (m *MockFineLedger) WithStore(store *repositories.Store) FineLedger {
+	args := m.Called(store)
+	return args.Get(0).(FineLedger)
+}
+
+func TestFineService_GetAccount(t *testing.T) {
+	ctx := context.Background()
+	fineRepo := &MockFineRepository{}
+	userRepo := &MockUserRepository{}
+	entries := []*models.FineEntry{{ID: 1, UserID: 3, Kind: models.FineKindFine, AmountCents: 200}}
+	userRepo.On("GetByID", 3).Return(&models.User{ID: 3}, nil)
+	fineRepo.On("GetByUser", 3).Return(entries, nil)
+	fineRepo.On("Balance", 3).Return(200, nil)
+
+	account, err := NewFineService(fineRepo, userRepo).GetAccount(ctx, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, account.BalanceCents)
+	assert.Equal(t, entries, account.Entries)
+
+	userRepo.On("GetByID", 99).Return(nil, models.NotFound("user_not_found", 99))
+	_, err = NewFineService(fineRepo, userRepo).GetAccount(ctx, 99)
+	assert.ErrorIs(t, err, models.ErrNotFound)
+}
+
+func TestFineService_ChargeLateReturn(t *testing.T) {
+	ctx := context.Background()
+	policy := &models.LoanPolicy{Tier: "standard", FinePerDayCents: 50, FineGraceDays: 1, FineCapCents: 1000}
+	dueDate := time.Now().Add(-10 * 24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		returnedAt time.Time
+		wantFee    int
+	}{
+		{"on time", dueDate.Add(-time.Hour), 0},
+		{"within grace", dueDate.Add(25 * time.Hour), 0},
+		{"four days late", dueDate.Add(4*24*time.Hour + time.Hour), 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fineRepo := &MockFineRepository{}
+			auditRepo := &MockAuditRepository{}
+			if tt.wantFee > 0 {
+				fineRepo.On("Create", mock.MatchedBy(func(e *models.FineEntry) bool {
+					return e.UserID == 3 && *e.BorrowingID == 7 && e.Kind == models.FineKindFine && e.AmountCents == tt.wantFee
+				})).Return(nil)
+				auditRepo.On("Create", mock.MatchedBy(func(e *models.AuditEvent) bool {
+					return e.Action == models.AuditActionCreate && e.EntityType == models.AuditEntityFine
+				})).Return(nil)
+			}
+
+			service := NewFineService(fineRepo, &MockUserRepository{})
+			service.SetAuditor(NewAuditService(auditRepo))
+			borrowing := &models.Borrowing{ID: 7, UserID: 3, DueDate: dueDate, ReturnedAt: &tt.returnedAt}
+			entry, err := service.ChargeLateReturn(ctx, borrowing, policy)
+
+			assert.NoError(t, err)
+			if tt.wantFee == 0 {
+				assert.Nil(t, entry)
+				fineRepo.AssertNotCalled(t, "Create", mock.Anything)
+			} else {
+				assert.Equal(t, tt.wantFee, entry.AmountCents)
+			}
+			fineRepo.AssertExpectations(t)
+			auditRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFineService_Settle(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		waive     bool
+		amount    int
+		reason    string
+		balance   int
+		wantError error
+		wantCode  string
+	}{
+		{name: "payment", amount: 100, reason: "Paid in cash", balance: 250},
+		{name: "waiver of the whole balance", waive: true, amount: 250, reason: "  First late return  ", balance: 250},
+		{name: "more than owed", amount: 300, reason: "Paid in cash", balance: 250, wantError: models.ErrValidation, wantCode: "amount_exceeds_balance"},
+		{name: "missing reason", waive: true, amount: 100, reason: " ", wantError: models.ErrValidation, wantCode: "fine_reason_required"},
+		{name: "negative amount", amount: -5, reason: "Refund", wantError: models.ErrValidation, wantCode: "invalid_fine_amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fineRepo := &MockFineRepository{}
+			userRepo := &MockUserRepository{}
+			userRepo.On("GetByID", 3).Return(&models.User{ID: 3}, nil)
+			fineRepo.On("Balance", 3).Return(tt.balance, nil)
+			kind := models.FineKindPayment
+			if tt.waive {
+				kind = models.FineKindWaiver
+			}
+			fineRepo.On("Create", mock.MatchedBy(func(e *models.FineEntry) bool {
+				return e.Kind == kind && e.AmountCents == tt.amount && e.Reason != "" && e.Reason[0] != ' '
+			})).Return(nil)
+
+			service := NewFineService(fineRepo, userRepo)
+			var err error
+			if tt.waive {
+				_, err = service.WaiveFine(ctx, 3, tt.amount, tt.reason)
+			} else {
+				_, err = service.PayFine(ctx, 3, tt.amount, tt.reason)
+			}
+
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+				domainErr, ok := models.AsError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, domainErr.Code)
+				fineRepo.AssertNotCalled(t, "Create", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			fineRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFineService_SettleUnknownUser(t *testing.T) {
+	fineRepo := &MockFineRepository{}
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByID", 99).Return(nil, models.NotFound("user_not_found", 99))
+
+	_, err := NewFineService(fineRepo, userRepo).PayFine(context.Background(), 99, 100, "Paid in cash")
+
+	assert.ErrorIs(t, err, models.ErrNotFound)
+	fineRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestFineService_SettleUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	fineRepo := &MockFineRepository{}
+	userRepo := &MockUserRepository{}
+	txAuditRepo := &MockAuditRepository{}
+	store := &repositories.Store{Fines: fineRepo, Users: userRepo, Audit: txAuditRepo}
+
+	userRepo.On("GetByID", 3).Return(&models.User{ID: 3}, nil)
+	fineRepo.On("Balance", 3).Return(250, nil)
+	fineRepo.On("Create", mock.AnythingOfType("*models.FineEntry")).Return(nil)
+	txAuditRepo.On("Create", mock.AnythingOfType("*models.AuditEvent")).Return(errors.New("disk I/O error"))
+
+	// The service's own repositories have no expectations and must not be used
+	service := NewFineService(&MockFineRepository{}, &MockUserRepository{})
+	service.SetAuditor(NewAuditService(&MockAuditRepository{}))
+	uow := &fakeUnitOfWork{store: store}
+	service.SetUnitOfWork(uow)
+
+	entry, err := service.PayFine(ctx, 3, 100, "Paid in cash")
+
+	assert.Error(t, err)
+	assert.Nil(t, entry)
+	assert.Equal(t, 1, uow.calls)
+	assert.False(t, uow.committed, "an unaudited payment is rolled back")
+	fineRepo.AssertExpectations(t)
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/bgg"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// GameCatalogue looks up board games in an online catalogue. It is
+// implemented by the BoardGameGeek client of package bgg.
+type GameCatalogue interface {
+	Search(ctx context.Context, query string) ([]bgg.SearchResult, error)
+	Game(ctx context.Context, id int) (*bgg.Game, error)
+}
+
+// SetCatalogue enables importing games from BoardGameGeek
+func (s *GameService) SetCatalogue(catalogue GameCatalogue) {
+	s.catalogue = catalogue
+}
+
+// SearchBGG looks up games on BoardGameGeek by name, or by BoardGameGeek ID
+// when query is a number
+func (s *GameService) SearchBGG(ctx context.Context, query string) ([]bgg.SearchResult, error) {
+	if s.catalogue == nil {
+		return nil, models.Unavailable("bgg_disabled")
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("validation failed: %w", models.Invalid("q", "search_query_required"))
+	}
+
+	if id, err := strconv.Atoi(query); err == nil && id > 0 {
+		game, err := s.catalogue.Game(ctx, id)
+		if err != nil {
+			if errors.Is(err, bgg.ErrNotFound) {
+				return []bgg.SearchResult{}, nil
+			}
+			return nil, bggLookupError(id, err)
+		}
+		return []bgg.SearchResult{{ID: game.ID, Name: game.Name, YearPublished: game.YearPublished}}, nil
+	}
+
+	results, err := s.catalogue.Search(ctx, query)
+	if err != nil {
+		return nil, bggLookupError(0, err)
+	}
+
+	return results, nil
+}
+
+// PreviewBGGGame returns the game BoardGameGeek describes under bggID as it
+// would be imported, without adding it to the library
+func (s *GameService) PreviewBGGGame(ctx context.Context, bggID int) (*models.Game, error) {
+	if s.catalogue == nil {
+		return nil, models.Unavailable("bgg_disabled")
+	}
+	if bggID <= 0 {
+		return nil, fmt.Errorf("validation failed: %w", models.Invalid("bggId", "invalid_bgg_id", bggID))
+	}
+
+	found, err := s.catalogue.Game(ctx, bggID)
+	if err != nil {
+		return nil, bggLookupError(bggID, err)
+	}
+
+	return gameFromBGG(found), nil
+}
+
+// bggLookupError returns the error of a failed BoardGameGeek lookup of the
+// game bggID, or of a search when bggID is zero
+func bggLookupError(bggID int, err error) error {
+	switch {
+	case bggID > 0 && errors.Is(err, bgg.ErrNotFound):
+		return fmt.Errorf("BoardGameGeek lookup failed: %w", models.NotFound("bgg_game_not_found", bggID))
+	case errors.Is(err, bgg.ErrBusy):
+		return fmt.Errorf("BoardGameGeek lookup failed: %w", models.Unavailable("bgg_busy"))
+	}
+	return fmt.Errorf("%w: %w", models.Upstream("bgg_lookup_failed"), err)
+}
+
+// ImportBGGGame adds the game BoardGameGeek describes under bggID to the
+// library, with one copy in the given condition. Its categories become tags.
+func (s *GameService) ImportBGGGame(ctx context.Context, bggID int, condition string) (*models.Game, error) {
+	game, err := s.PreviewBGGGame(ctx, bggID)
+	if err != nil {
+		return nil, err
+	}
+
+	game.Condition = condition
+	if err := s.CreateGame(ctx, game); err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+// gameFromBGG maps a BoardGameGeek game to a library game, dropping or
+// shortening the values the library does not accept
+func gameFromBGG(found *bgg.Game) *models.Game {
+	game := &models.Game{
+		Name:        truncateText(found.Name, 200),
+		Description: truncateText(found.Description, 1000),
+		GameDetails: models.GameDetails{
+			MinPlayers: boundedCount(found.MinPlayers, models.MaxPlayerCount),
+			MaxPlayers: boundedCount(found.MaxPlayers, models.MaxPlayerCount),
+			PlayTime:   boundedCount(found.PlayingTime, models.MaxPlayTime),
+			MinAge:     boundedCount(found.MinAge, models.MaxMinAge),
+			Designers:  []string{},
+			BGGID:      found.ID,
+		},
+	}
+
+	if game.MaxPlayers != 0 && game.MaxPlayers < game.MinPlayers {
+		game.MaxPlayers = 0
+	}
+	if year := found.YearPublished; year >= models.MinYearPublished && year <= time.Now().Year()+1 {
+		game.YearPublished = year
+	}
+	if found.AverageWeight >= models.MinComplexity {
+		game.Complexity = math.Min(math.Round(found.AverageWeight*10)/10, models.MaxComplexity)
+	}
+	if len(found.Publishers) > 0 {
+		game.Publisher = truncateText(found.Publishers[0], 200)
+	}
+	for _, designer := range found.Designers {
+		if designer == "(Uncredited)" || len(game.Designers) == models.MaxDesigners {
+			continue
+		}
+		game.Designers = append(game.Designers, truncateText(designer, 100))
+	}
+	if url := found.ImageURL; len(url) <= models.MaxImageURLLength && (strings.HasPrefix(url, "https://") || strings.HasPrefix(url, "http://")) {
+		game.ImageURL = url
+	}
+
+	game.Tags = []string{}
+	for _, tag := range models.NormalizeTags(found.Categories) {
+		if models.ValidateTagName(tag) == nil && len(game.Tags) < models.MaxTagsPerGame {
+			game.Tags = append(game.Tags, tag)
+		}
+	}
+
+	return game
+}
+
+// boundedCount returns count, or 0 (unknown) when it is negative or above max
+func boundedCount(count, max int) int {
+	if count < 0 || count > max {
+		return 0
+	}
+	return count
+}
+
+// truncateText shortens text to at most max bytes, cutting between runes and
+// ending with an ellipsis
+func truncateText(text string, max int) string {
+	text = strings.TrimSpace(text)
+	if len(text) <= max {
+		return text
+	}
+
+	const ellipsis = "…"
+	cut := max - len(ellipsis)
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return strings.TrimSpace(text[:cut]) + ellipsis
+}
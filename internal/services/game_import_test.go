@@ -0,0 +1,153 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/pkg/bgg"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGameCatalogue is a mock implementation of GameCatalogue
+type MockGameCatalogue struct {
+	mock.Mock
+}
+
+func (m *MockGameCatalogue) Search(ctx context.Context, query string) ([]bgg.SearchResult, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]bgg.SearchResult), args.Error(1)
+}
+
+func (m *MockGameCatalogue) Game(ctx context.Context, id int) (*bgg.Game, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bgg.Game), args.Error(1)
+}
+
+var catan = &bgg.Game{
+	ID:            13,
+	Name:          "CATAN",
+	Description:   "Trade, build and settle the island of Catan.",
+	ImageURL:      "https://cf.geekdo-images.com/catan.jpg",
+	MinPlayers:    3,
+	MaxPlayers:    4,
+	PlayingTime:   120,
+	MinAge:        10,
+	YearPublished: 1995,
+	Categories:    []string{"Economic", "Negotiation", "economic"},
+	Designers:     []string{"Klaus Teuber"},
+	Publishers:    []string{"KOSMOS", "Filosofia Éditions"},
+	AverageWeight: 2.2973,
+}
+
+func TestGameService_SearchBGG(t *testing.T) {
+	ctx := context.Background()
+	catalogue := &MockGameCatalogue{}
+	catalogue.On("Search", "catan").Return([]bgg.SearchResult{{ID: 13, Name: "CATAN", YearPublished: 1995}}, nil)
+	catalogue.On("Game", 13).Return(catan, nil)
+	catalogue.On("Game", 999).Return(nil, fmt.Errorf("game 999 %w", bgg.ErrNotFound))
+	catalogue.On("Search", "busy").Return(nil, fmt.Errorf("%w (HTTP 202)", bgg.ErrBusy))
+
+	service := NewGameService(&MockGameRepository{}, &MockBorrowingRepository{})
+	_, err := service.SearchBGG(ctx, "catan")
+	assert.ErrorContains(t, err, "not enabled")
+
+	service.SetCatalogue(catalogue)
+
+	results, err := service.SearchBGG(ctx, " catan ")
+	assert.NoError(t, err)
+	assert.Equal(t, []bgg.SearchResult{{ID: 13, Name: "CATAN", YearPublished: 1995}}, results)
+
+	results, err = service.SearchBGG(ctx, "13")
+	assert.NoError(t, err)
+	assert.Equal(t, []bgg.SearchResult{{ID: 13, Name: "CATAN", YearPublished: 1995}}, results)
+
+	results, err = service.SearchBGG(ctx, "999")
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = service.SearchBGG(ctx, "busy")
+	assert.ErrorContains(t, err, "BoardGameGeek lookup failed")
+
+	_, err = service.SearchBGG(ctx, "  ")
+	assert.ErrorContains(t, err, "validation failed")
+
+	catalogue.AssertExpectations(t)
+}
+
+func TestGameService_ImportBGGGame(t *testing.T) {
+	ctx := context.Background()
+	gameRepo := &MockGameRepository{}
+	catalogue := &MockGameCatalogue{}
+	catalogue.On("Game", 13).Return(catan, nil)
+	catalogue.On("Game", 999).Return(nil, fmt.Errorf("game 999 %w", bgg.ErrNotFound))
+	gameRepo.On("Create", mock.MatchedBy(func(game *models.Game) bool {
+		return game.Name == "CATAN" && game.Condition == "good" && game.BGGID == 13
+	})).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.Game).ID = 7
+	})
+
+	service := NewGameService(gameRepo, &MockBorrowingRepository{})
+	service.SetCatalogue(catalogue)
+
+	game, err := service.ImportBGGGame(ctx, 13, "good")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, game.ID)
+	assert.Equal(t, []string{"Economic", "Negotiation"}, game.Tags)
+	assert.Equal(t, models.GameDetails{
+		MinPlayers: 3, MaxPlayers: 4, PlayTime: 120, MinAge: 10,
+		Publisher: "KOSMOS", Designers: []string{"Klaus Teuber"}, YearPublished: 1995, Complexity: 2.3,
+		ImageURL: "https://cf.geekdo-images.com/catan.jpg", BGGID: 13,
+	}, game.GameDetails)
+
+	_, err = service.ImportBGGGame(ctx, 13, "")
+	assert.ErrorContains(t, err, "validation failed: game condition is required")
+
+	_, err = service.ImportBGGGame(ctx, 999, "good")
+	assert.ErrorContains(t, err, "not found")
+
+	_, err = service.ImportBGGGame(ctx, 0, "good")
+	assert.ErrorContains(t, err, "invalid BoardGameGeek ID")
+
+	gameRepo.AssertNumberOfCalls(t, "Create", 1)
+	catalogue.AssertExpectations(t)
+}
+
+func TestGameFromBGG(t *testing.T) {
+	long := strings.Repeat("é", 600)
+	game := gameFromBGG(&bgg.Game{
+		ID:            1,
+		Name:          "Jeu",
+		Description:   long,
+		ImageURL:      "ftp://example.com/box.jpg",
+		MinPlayers:    4,
+		MaxPlayers:    2,
+		PlayingTime:   -1,
+		MinAge:        150,
+		YearPublished: 9999,
+		Categories:    []string{strings.Repeat("x", 60), "Card Game"},
+		Designers:     []string{"(Uncredited)"},
+		AverageWeight: 0,
+	})
+
+	assert.NoError(t, models.ValidateGameDetails(&game.GameDetails))
+	assert.LessOrEqual(t, len(game.Description), 1000)
+	assert.True(t, strings.HasSuffix(game.Description, "é…"))
+	assert.Equal(t, 4, game.MinPlayers)
+	assert.Zero(t, game.MaxPlayers)
+	assert.Zero(t, game.PlayTime)
+	assert.Zero(t, game.MinAge)
+	assert.Zero(t, game.YearPublished)
+	assert.Empty(t, game.ImageURL)
+	assert.Empty(t, game.Designers)
+	assert.Equal(t, []string{"Card Game"}, game.Tags)
+}
@@ -3,6 +3,8 @@ package services
 import (
 	"board-game-library/internal/models"
 	"board-game-library/internal/repositories"
+	"board-game-library/pkg/database"
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -12,6 +14,11 @@ import (
 type GameService struct {
 	gameRepo      repositories.GameRepository
 	borrowingRepo repositories.BorrowingRepository
+	catalogue     GameCatalogue
+	conditions    ConditionLog
+	holds         HoldQueue
+	uow           repositories.UnitOfWork
+	auditTrail
 }
 
 // NewGameService creates a new GameService instance
@@ -22,38 +29,105 @@ func NewGameService(gameRepo repositories.GameRepository, borrowingRepo reposito
 	}
 }
 
-// AddGame creates a new game in the library
-func (s *GameService) AddGame(name, description, category, condition string) (*models.Game, error) {
-	// Create game model
+// SetConditions keeps the changes of the condition of games and copies in
+// their condition history
+func (s *GameService) SetConditions(conditions ConditionLog) {
+	s.conditions = conditions
+}
+
+// SetHoldQueue hands repaired copies to the first user waiting for them, like
+// returned ones
+func (s *GameService) SetHoldQueue(holds HoldQueue) {
+	s.holds = holds
+}
+
+// SetUnitOfWork makes copy updates atomic: the copy, the hold a repaired
+// copy goes to and its condition history are written in a single transaction
+func (s *GameService) SetUnitOfWork(uow repositories.UnitOfWork) {
+	s.uow = uow
+}
+
+// atomically runs fn with a copy of the service whose repositories are bound
+// to a single unit of work
+func (s *GameService) atomically(ctx context.Context, fn func(tx *GameService) error) error {
+	if s.uow == nil {
+		return fn(s)
+	}
+
+	return s.uow.Do(ctx, func(store *repositories.Store) error {
+		bound := *s
+		bound.gameRepo = store.Games
+		bound.borrowingRepo = store.Borrowings
+		bound.uow = nil
+		bound.auditTrail = s.auditTrail.withStore(store)
+		if s.holds != nil {
+			bound.holds = s.holds.WithStore(store)
+		}
+		if s.conditions != nil {
+			bound.conditions = s.conditions.WithStore(store)
+		}
+		return fn(&bound)
+	})
+}
+
+// WithActor returns a copy of the service that records actor as the author
+// of its changes in the audit log
+func (s *GameService) WithActor(actor *models.User) *GameService {
+	bound := *s
+	bound.actor = actor
+	return &bound
+}
+
+// AddGame creates a new game in the library, with tag as its only tag when
+// not empty. CreateGame takes games with several tags and their metadata.
+func (s *GameService) AddGame(ctx context.Context, name, description, tag, condition string) (*models.Game, error) {
 	game := &models.Game{
 		Name:        name,
 		Description: description,
-		Category:    category,
-		EntryDate:   time.Now(),
+		Tags:        models.ParseTags(tag),
 		Condition:   condition,
-		IsAvailable: true,
 	}
 
+	if err := s.CreateGame(ctx, game); err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+// CreateGame adds a game, with its metadata, to the library. The entry date
+// defaults to now and the game starts with one free copy.
+func (s *GameService) CreateGame(ctx context.Context, game *models.Game) error {
+	if game == nil {
+		return fmt.Errorf("game cannot be nil")
+	}
+
+	if game.EntryDate.IsZero() {
+		game.EntryDate = time.Now()
+	}
+	game.IsAvailable = true
+	game.Tags = models.NormalizeTags(game.Tags)
+
 	// Validate game data
 	if err := models.ValidateGame(game); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return fmt.Errorf("validation failed: %w", err)
 	}
 
 	// Create game in repository
-	if err := s.gameRepo.Create(game); err != nil {
-		return nil, fmt.Errorf("failed to create game: %w", err)
+	if err := s.gameRepo.Create(ctx, game); err != nil {
+		return fmt.Errorf("failed to create game: %w", err)
 	}
 
-	return game, nil
+	return s.record(ctx, models.AuditActionCreate, models.AuditEntityGame, game.ID, nil, game)
 }
 
 // GetGame retrieves a game by ID
-func (s *GameService) GetGame(id int) (*models.Game, error) {
+func (s *GameService) GetGame(ctx context.Context, id int) (*models.Game, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("invalid game ID: %d", id)
+		return nil, models.Invalid("game_id", "invalid_game_id", id)
 	}
 
-	game, err := s.gameRepo.GetByID(id)
+	game, err := s.gameRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get game: %w", err)
 	}
@@ -62,8 +136,8 @@ func (s *GameService) GetGame(id int) (*models.Game, error) {
 }
 
 // GetAllGames retrieves all games
-func (s *GameService) GetAllGames() ([]*models.Game, error) {
-	games, err := s.gameRepo.GetAll()
+func (s *GameService) GetAllGames(ctx context.Context) ([]*models.Game, error) {
+	games, err := s.gameRepo.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all games: %w", err)
 	}
@@ -71,9 +145,33 @@ func (s *GameService) GetAllGames() ([]*models.Game, error) {
 	return games, nil
 }
 
+// ListGames retrieves one page of the games matching filter along with the
+// number of matches across all pages
+func (s *GameService) ListGames(ctx context.Context, filter models.GameFilter) ([]*models.Game, int, error) {
+	if err := models.ValidateGameFilter(&filter); err != nil {
+		return nil, 0, fmt.Errorf("invalid game filter: %w", err)
+	}
+
+	games, err := s.gameRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list games: %w", err)
+	}
+
+	total, err := s.gameRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count games: %w", err)
+	}
+
+	if games == nil {
+		games = []*models.Game{}
+	}
+
+	return games, total, nil
+}
+
 // GetAvailableGames retrieves all available games
-func (s *GameService) GetAvailableGames() ([]*models.Game, error) {
-	games, err := s.gameRepo.GetAvailable()
+func (s *GameService) GetAvailableGames(ctx context.Context) ([]*models.Game, error) {
+	games, err := s.gameRepo.GetAvailable(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get available games: %w", err)
 	}
@@ -82,12 +180,12 @@ func (s *GameService) GetAvailableGames() ([]*models.Game, error) {
 }
 
 // SearchGames searches for games by query string
-func (s *GameService) SearchGames(query string) ([]*models.Game, error) {
+func (s *GameService) SearchGames(ctx context.Context, query string) ([]*models.Game, error) {
 	if query == "" {
-		return s.GetAllGames()
+		return s.GetAllGames(ctx)
 	}
 
-	games, err := s.gameRepo.Search(query)
+	games, err := s.gameRepo.Search(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search games: %w", err)
 	}
@@ -96,14 +194,15 @@ func (s *GameService) SearchGames(query string) ([]*models.Game, error) {
 }
 
 // UpdateGame updates game information
-func (s *GameService) UpdateGame(game *models.Game) error {
+func (s *GameService) UpdateGame(ctx context.Context, game *models.Game) error {
 	if game == nil {
 		return fmt.Errorf("game cannot be nil")
 	}
 
 	if game.ID <= 0 {
-		return fmt.Errorf("invalid game ID: %d", game.ID)
+		return models.Invalid("game_id", "invalid_game_id", game.ID)
 	}
+	game.Tags = models.NormalizeTags(game.Tags)
 
 	// Validate game data
 	if err := models.ValidateGame(game); err != nil {
@@ -111,55 +210,67 @@ func (s *GameService) UpdateGame(game *models.Game) error {
 	}
 
 	// Check if game exists
-	_, err := s.gameRepo.GetByID(game.ID)
+	existing, err := s.gameRepo.GetByID(ctx, game.ID)
 	if err != nil {
 		return fmt.Errorf("game not found: %w", err)
 	}
 
 	// Update game in repository
-	if err := s.gameRepo.Update(game); err != nil {
+	if err := s.gameRepo.Update(ctx, game); err != nil {
 		return fmt.Errorf("failed to update game: %w", err)
 	}
 
-	return nil
+	if s.conditions != nil && models.NormalizeCondition(game.Condition) != models.NormalizeCondition(existing.Condition) {
+		change := &models.ConditionChange{
+			GameID:            game.ID,
+			PreviousCondition: existing.Condition,
+			Condition:         models.NormalizeCondition(game.Condition),
+		}
+		if err := s.conditions.Record(ctx, change); err != nil {
+			return fmt.Errorf("failed to record condition: %w", err)
+		}
+	}
+
+	return s.record(ctx, models.AuditActionUpdate, models.AuditEntityGame, game.ID, existing, game)
 }
 
 // SetGameAvailability updates the availability status of a game
-func (s *GameService) SetGameAvailability(gameID int, isAvailable bool) error {
+func (s *GameService) SetGameAvailability(ctx context.Context, gameID int, isAvailable bool) error {
 	if gameID <= 0 {
-		return fmt.Errorf("invalid game ID: %d", gameID)
+		return models.Invalid("game_id", "invalid_game_id", gameID)
 	}
 
 	// Get the current game
-	game, err := s.gameRepo.GetByID(gameID)
+	game, err := s.gameRepo.GetByID(ctx, gameID)
 	if err != nil {
 		return fmt.Errorf("game not found: %w", err)
 	}
 
 	// Update availability status
+	before := *game
 	game.IsAvailable = isAvailable
 
 	// Update game in repository
-	if err := s.gameRepo.Update(game); err != nil {
+	if err := s.gameRepo.Update(ctx, game); err != nil {
 		return fmt.Errorf("failed to update game availability: %w", err)
 	}
 
-	return nil
+	return s.record(ctx, models.AuditActionUpdate, models.AuditEntityGame, gameID, &before, game)
 }
 
 // GetGameBorrowingHistory retrieves the borrowing history for a specific game
-func (s *GameService) GetGameBorrowingHistory(gameID int) ([]*models.Borrowing, error) {
+func (s *GameService) GetGameBorrowingHistory(ctx context.Context, gameID int) ([]*models.Borrowing, error) {
 	if gameID <= 0 {
-		return nil, fmt.Errorf("invalid game ID: %d", gameID)
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
 	}
 
 	// Verify game exists
-	_, err := s.gameRepo.GetByID(gameID)
+	_, err := s.gameRepo.GetByID(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("game not found: %w", err)
 	}
 
-	borrowings, err := s.borrowingRepo.GetByGame(gameID)
+	borrowings, err := s.borrowingRepo.GetByGame(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get game borrowing history: %w", err)
 	}
@@ -168,12 +279,12 @@ func (s *GameService) GetGameBorrowingHistory(gameID int) ([]*models.Borrowing,
 }
 
 // IsGameAvailable checks if a game is currently available for borrowing
-func (s *GameService) IsGameAvailable(gameID int) (bool, error) {
+func (s *GameService) IsGameAvailable(ctx context.Context, gameID int) (bool, error) {
 	if gameID <= 0 {
-		return false, fmt.Errorf("invalid game ID: %d", gameID)
+		return false, models.Invalid("game_id", "invalid_game_id", gameID)
 	}
 
-	game, err := s.gameRepo.GetByID(gameID)
+	game, err := s.gameRepo.GetByID(ctx, gameID)
 	if err != nil {
 		return false, fmt.Errorf("game not found: %w", err)
 	}
@@ -182,19 +293,19 @@ func (s *GameService) IsGameAvailable(gameID int) (bool, error) {
 }
 
 // GetCurrentBorrower returns the current borrower of a game (if any)
-func (s *GameService) GetCurrentBorrower(gameID int) (*models.Borrowing, error) {
+func (s *GameService) GetCurrentBorrower(ctx context.Context, gameID int) (*models.Borrowing, error) {
 	if gameID <= 0 {
-		return nil, fmt.Errorf("invalid game ID: %d", gameID)
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
 	}
 
 	// Verify game exists
-	_, err := s.gameRepo.GetByID(gameID)
+	_, err := s.gameRepo.GetByID(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("game not found: %w", err)
 	}
 
 	// Get all borrowings for this game
-	borrowings, err := s.borrowingRepo.GetByGame(gameID)
+	borrowings, err := s.borrowingRepo.GetByGame(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get game borrowings: %w", err)
 	}
@@ -209,46 +320,294 @@ func (s *GameService) GetCurrentBorrower(gameID int) (*models.Borrowing, error)
 	return nil, nil // No current borrower
 }
 
+// GetCurrentBorrowers returns the active borrowings of all copies of a game
+func (s *GameService) GetCurrentBorrowers(ctx context.Context, gameID int) ([]*models.Borrowing, error) {
+	if gameID <= 0 {
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
+	}
+
+	// Verify game exists
+	_, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("game not found: %w", err)
+	}
+
+	borrowings, err := s.borrowingRepo.GetByGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game borrowings: %w", err)
+	}
+
+	currentBorrowers := []*models.Borrowing{}
+	for _, borrowing := range borrowings {
+		if borrowing.ReturnedAt == nil {
+			currentBorrowers = append(currentBorrowers, borrowing)
+		}
+	}
+
+	return currentBorrowers, nil
+}
+
+// GetGameCopies retrieves the physical copies of a game
+func (s *GameService) GetGameCopies(ctx context.Context, gameID int) ([]*models.GameCopy, error) {
+	if gameID <= 0 {
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
+	}
+
+	// Verify game exists
+	_, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("game not found: %w", err)
+	}
+
+	copies, err := s.gameRepo.GetCopies(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game copies: %w", err)
+	}
+
+	return copies, nil
+}
+
+// AddCopy registers an additional physical copy of a game
+func (s *GameService) AddCopy(ctx context.Context, gameID int, barcode, condition string) (*models.GameCopy, error) {
+	if gameID <= 0 {
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
+	}
+
+	// Verify game exists
+	_, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("game not found: %w", err)
+	}
+
+	gameCopy := &models.GameCopy{
+		GameID:      gameID,
+		Barcode:     barcode,
+		Condition:   condition,
+		AcquiredAt:  time.Now(),
+		IsAvailable: true,
+	}
+
+	// Validate copy data
+	if err := models.ValidateGameCopy(gameCopy); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.gameRepo.CreateCopy(ctx, gameCopy); err != nil {
+		return nil, fmt.Errorf("failed to create game copy: %w", err)
+	}
+
+	if err := s.record(ctx, models.AuditActionCreate, models.AuditEntityGameCopy, gameCopy.ID, nil, gameCopy); err != nil {
+		return nil, err
+	}
+
+	return gameCopy, nil
+}
+
+// UpdateCopy updates the barcode and condition of a game copy
+func (s *GameService) UpdateCopy(ctx context.Context, gameID, copyID int, barcode, condition string) (*models.GameCopy, error) {
+	gameCopy, err := s.getGameCopy(ctx, gameID, copyID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *gameCopy
+	gameCopy.Barcode = barcode
+	gameCopy.SetCondition(condition)
+
+	// Validate copy data
+	if err := models.ValidateGameCopy(gameCopy); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.saveCopy(ctx, &before, gameCopy, ""); err != nil {
+		return nil, err
+	}
+
+	return gameCopy, nil
+}
+
+// ReportCopyCondition records the condition a librarian found a copy in,
+// with optional notes on missing pieces or damage. A copy in poor condition
+// or with missing pieces is kept from lending until a report finds it
+// repaired.
+func (s *GameService) ReportCopyCondition(ctx context.Context, gameID, copyID int, report *models.ConditionReport) (*models.GameCopy, error) {
+	if report == nil {
+		return nil, models.Invalid("condition", "game_condition_required")
+	}
+	if err := models.ValidateConditionReport(report); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	gameCopy, err := s.getGameCopy(ctx, gameID, copyID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *gameCopy
+	gameCopy.SetCondition(report.Condition)
+	if err := s.saveCopy(ctx, &before, gameCopy, strings.TrimSpace(report.Notes)); err != nil {
+		return nil, err
+	}
+
+	return gameCopy, nil
+}
+
+// saveCopy saves, in a single unit of work, a copy whose condition may have
+// changed from before. A repaired copy goes to the first user waiting for it,
+// and the change, or a report with notes, is kept in the condition history of
+// the game.
+func (s *GameService) saveCopy(ctx context.Context, before, gameCopy *models.GameCopy, notes string) error {
+	return s.atomically(ctx, func(tx *GameService) error {
+		return tx.writeCopy(ctx, before, gameCopy, notes)
+	})
+}
+
+// writeCopy writes the changes saveCopy makes, on the repositories of s
+func (s *GameService) writeCopy(ctx context.Context, before, gameCopy *models.GameCopy, notes string) error {
+	if before.InRepair && gameCopy.IsAvailable && s.holds != nil {
+		held, err := s.holds.HoldReturnedCopy(ctx, gameCopy)
+		if err != nil {
+			return fmt.Errorf("failed to process reservations: %w", err)
+		}
+		gameCopy.IsAvailable = !held
+	}
+
+	if err := s.gameRepo.UpdateCopy(ctx, gameCopy); err != nil {
+		return fmt.Errorf("failed to update game copy: %w", err)
+	}
+
+	if err := s.record(ctx, models.AuditActionUpdate, models.AuditEntityGameCopy, gameCopy.ID, before, gameCopy); err != nil {
+		return err
+	}
+
+	if s.conditions == nil || (gameCopy.Condition == models.NormalizeCondition(before.Condition) && notes == "") {
+		return nil
+	}
+
+	copyID := gameCopy.ID
+	change := &models.ConditionChange{
+		GameID:            gameCopy.GameID,
+		CopyID:            &copyID,
+		PreviousCondition: before.Condition,
+		Condition:         gameCopy.Condition,
+		Notes:             notes,
+	}
+	if err := s.conditions.Record(ctx, change); err != nil {
+		return fmt.Errorf("failed to record condition: %w", err)
+	}
+
+	return nil
+}
+
+// GetConditionHistory retrieves the condition history of a game and its
+// copies, newest first
+func (s *GameService) GetConditionHistory(ctx context.Context, gameID int) ([]*models.ConditionChange, error) {
+	if gameID <= 0 {
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
+	}
+
+	// Verify game exists
+	if _, err := s.gameRepo.GetByID(ctx, gameID); err != nil {
+		return nil, fmt.Errorf("game not found: %w", err)
+	}
+
+	if s.conditions == nil {
+		return []*models.ConditionChange{}, nil
+	}
+
+	return s.conditions.GetHistory(ctx, gameID)
+}
+
+// RemoveCopy removes a copy from the library. Borrowed copies and the last
+// copy of a game cannot be removed; copies kept from lending for repair can.
+func (s *GameService) RemoveCopy(ctx context.Context, gameID, copyID int) error {
+	gameCopy, err := s.getGameCopy(ctx, gameID, copyID)
+	if err != nil {
+		return err
+	}
+
+	if !gameCopy.IsAvailable && !gameCopy.InRepair {
+		return models.Conflict("copy_borrowed")
+	}
+
+	copies, err := s.gameRepo.GetCopies(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to get game copies: %w", err)
+	}
+	if len(copies) <= 1 {
+		return models.Conflict("last_copy")
+	}
+
+	if err := s.gameRepo.DeleteCopy(ctx, copyID); err != nil {
+		if database.IsForeignKeyViolation(err) {
+			return models.Conflict("copy_has_history")
+		}
+		return fmt.Errorf("failed to remove game copy: %w", err)
+	}
+
+	return s.record(ctx, models.AuditActionDelete, models.AuditEntityGameCopy, copyID, gameCopy, nil)
+}
+
+// getGameCopy retrieves a copy and checks that it belongs to the given game
+func (s *GameService) getGameCopy(ctx context.Context, gameID, copyID int) (*models.GameCopy, error) {
+	if gameID <= 0 {
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
+	}
+	if copyID <= 0 {
+		return nil, models.Invalid("copy_id", "invalid_copy_id", copyID)
+	}
+
+	gameCopy, err := s.gameRepo.GetCopyByID(ctx, copyID)
+	if err != nil {
+		return nil, fmt.Errorf("copy not found: %w", err)
+	}
+	if gameCopy.GameID != gameID {
+		return nil, fmt.Errorf("copy not found: %w", models.NotFound("copy_not_in_game", copyID, gameID))
+	}
+
+	return gameCopy, nil
+}
+
 // DeleteGame removes a game from the library
-func (s *GameService) DeleteGame(gameID int) error {
+func (s *GameService) DeleteGame(ctx context.Context, gameID int) error {
 	if gameID <= 0 {
-		return fmt.Errorf("invalid game ID: %d", gameID)
+		return models.Invalid("game_id", "invalid_game_id", gameID)
 	}
 
 	// Check if game exists
-	game, err := s.gameRepo.GetByID(gameID)
+	game, err := s.gameRepo.GetByID(ctx, gameID)
 	if err != nil {
 		return fmt.Errorf("game not found: %w", err)
 	}
 
 	// Check if game has any active borrowings
-	currentBorrower, err := s.GetCurrentBorrower(gameID)
+	currentBorrower, err := s.GetCurrentBorrower(ctx, gameID)
 	if err != nil {
 		return fmt.Errorf("failed to check current borrower: %w", err)
 	}
 
 	if currentBorrower != nil {
-		return fmt.Errorf("cannot delete game: currently borrowed by user %d", currentBorrower.UserID)
+		return models.Conflict("game_borrowed", currentBorrower.UserID)
 	}
 
 	// Check if game has any borrowing history
-	borrowingHistory, err := s.GetGameBorrowingHistory(gameID)
+	borrowingHistory, err := s.GetGameBorrowingHistory(ctx, gameID)
 	if err != nil {
 		return fmt.Errorf("failed to check borrowing history: %w", err)
 	}
 
 	if len(borrowingHistory) > 0 {
-		return fmt.Errorf("cannot delete game '%s': it has borrowing history (%d records). Games with borrowing history cannot be deleted to maintain data integrity", game.Name, len(borrowingHistory))
+		return models.Conflict("game_has_history", game.Name, len(borrowingHistory))
 	}
 
 	// Delete game from repository
-	if err := s.gameRepo.Delete(gameID); err != nil {
+	if err := s.gameRepo.Delete(ctx, gameID); err != nil {
 		// Check if it's a foreign key constraint error
-		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
-			return fmt.Errorf("cannot delete game '%s': it has associated records (borrowings or alerts) that prevent deletion", game.Name)
+		if database.IsForeignKeyViolation(err) {
+			return models.Conflict("game_has_records", game.Name)
 		}
 		return fmt.Errorf("failed to delete game: %w", err)
 	}
 
-	return nil
+	return s.record(ctx, models.AuditActionDelete, models.AuditEntityGame, gameID, game, nil)
 }
\ No newline at end of file
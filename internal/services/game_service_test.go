@@ -2,6 +2,8 @@ package services
 
 import (
 	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -15,12 +17,12 @@ type MockGameRepository struct {
 	mock.Mock
 }
 
-func (m *MockGameRepository) Create(game *models.Game) error {
+func (m *MockGameRepository) Create(ctx context.Context, game *models.Game) error {
 	args := m.Called(game)
 	return args.Error(0)
 }
 
-func (m *MockGameRepository) GetByID(id int) (*models.Game, error) {
+func (m *MockGameRepository) GetByID(ctx context.Context, id int) (*models.Game, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -28,7 +30,7 @@ func (m *MockGameRepository) GetByID(id int) (*models.Game, error) {
 	return args.Get(0).(*models.Game), args.Error(1)
 }
 
-func (m *MockGameRepository) GetAll() ([]*models.Game, error) {
+func (m *MockGameRepository) GetAll(ctx context.Context) ([]*models.Game, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -36,7 +38,20 @@ func (m *MockGameRepository) GetAll() ([]*models.Game, error) {
 	return args.Get(0).([]*models.Game), args.Error(1)
 }
 
-func (m *MockGameRepository) Search(query string) ([]*models.Game, error) {
+func (m *MockGameRepository) List(ctx context.Context, filter models.GameFilter) ([]*models.Game, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) Count(ctx context.Context, filter models.GameFilter) (int, error) {
+	args := m.Called(filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockGameRepository) Search(ctx context.Context, query string) ([]*models.Game, error) {
 	args := m.Called(query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -44,17 +59,17 @@ func (m *MockGameRepository) Search(query string) ([]*models.Game, error) {
 	return args.Get(0).([]*models.Game), args.Error(1)
 }
 
-func (m *MockGameRepository) Update(game *models.Game) error {
+func (m *MockGameRepository) Update(ctx context.Context, game *models.Game) error {
 	args := m.Called(game)
 	return args.Error(0)
 }
 
-func (m *MockGameRepository) Delete(id int) error {
+func (m *MockGameRepository) Delete(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockGameRepository) GetAvailable() ([]*models.Game, error) {
+func (m *MockGameRepository) GetAvailable(ctx context.Context) ([]*models.Game, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -62,6 +77,45 @@ func (m *MockGameRepository) GetAvailable() ([]*models.Game, error) {
 	return args.Get(0).([]*models.Game), args.Error(1)
 }
 
+func (m *MockGameRepository) CreateCopy(ctx context.Context, gameCopy *models.GameCopy) error {
+	args := m.Called(gameCopy)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) GetCopyByID(ctx context.Context, id int) (*models.GameCopy, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameRepository) GetCopyByBarcode(ctx context.Context, barcode string) (*models.GameCopy, error) {
+	args := m.Called(barcode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameRepository) GetCopies(ctx context.Context, gameID int) ([]*models.GameCopy, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GameCopy), args.Error(1)
+}
+
+func (m *MockGameRepository) UpdateCopy(ctx context.Context, gameCopy *models.GameCopy) error {
+	args := m.Called(gameCopy)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) DeleteCopy(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 func TestNewGameService(t *testing.T) {
 	gameRepo := &MockGameRepository{}
 	borrowingRepo := &MockBorrowingRepository{}
@@ -74,11 +128,12 @@ func TestNewGameService(t *testing.T) {
 }
 
 func TestGameService_AddGame(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		inputName     string
 		inputDesc     string
-		inputCategory string
+		inputTag      string
 		inputCondition string
 		setupMocks    func(*MockGameRepository, *MockBorrowingRepository)
 		expectedError string
@@ -87,7 +142,7 @@ func TestGameService_AddGame(t *testing.T) {
 			name:           "successful game creation",
 			inputName:      "Monopoly",
 			inputDesc:      "Classic board game",
-			inputCategory:  "Strategy",
+			inputTag:       "Strategy",
 			inputCondition: "good",
 			setupMocks: func(gameRepo *MockGameRepository, borrowingRepo *MockBorrowingRepository) {
 				gameRepo.On("Create", mock.AnythingOfType("*models.Game")).Return(nil)
@@ -98,7 +153,7 @@ func TestGameService_AddGame(t *testing.T) {
 			name:           "invalid game name",
 			inputName:      "",
 			inputDesc:      "Classic board game",
-			inputCategory:  "Strategy",
+			inputTag:       "Strategy",
 			inputCondition: "good",
 			setupMocks: func(gameRepo *MockGameRepository, borrowingRepo *MockBorrowingRepository) {
 				// No mocks needed as validation fails first
@@ -109,7 +164,7 @@ func TestGameService_AddGame(t *testing.T) {
 			name:           "invalid condition",
 			inputName:      "Monopoly",
 			inputDesc:      "Classic board game",
-			inputCategory:  "Strategy",
+			inputTag:       "Strategy",
 			inputCondition: "invalid",
 			setupMocks: func(gameRepo *MockGameRepository, borrowingRepo *MockBorrowingRepository) {
 				// No mocks needed as validation fails first
@@ -120,7 +175,7 @@ func TestGameService_AddGame(t *testing.T) {
 			name:           "repository error",
 			inputName:      "Monopoly",
 			inputDesc:      "Classic board game",
-			inputCategory:  "Strategy",
+			inputTag:       "Strategy",
 			inputCondition: "good",
 			setupMocks: func(gameRepo *MockGameRepository, borrowingRepo *MockBorrowingRepository) {
 				gameRepo.On("Create", mock.AnythingOfType("*models.Game")).Return(errors.New("database error"))
@@ -136,7 +191,7 @@ func TestGameService_AddGame(t *testing.T) {
 			tt.setupMocks(gameRepo, borrowingRepo)
 
 			service := NewGameService(gameRepo, borrowingRepo)
-			game, err := service.AddGame(tt.inputName, tt.inputDesc, tt.inputCategory, tt.inputCondition)
+			game, err := service.AddGame(ctx, tt.inputName, tt.inputDesc, tt.inputTag, tt.inputCondition)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -147,7 +202,7 @@ func TestGameService_AddGame(t *testing.T) {
 				assert.NotNil(t, game)
 				assert.Equal(t, tt.inputName, game.Name)
 				assert.Equal(t, tt.inputDesc, game.Description)
-				assert.Equal(t, tt.inputCategory, game.Category)
+				assert.Equal(t, []string{tt.inputTag}, game.Tags)
 				assert.Equal(t, tt.inputCondition, game.Condition)
 				assert.True(t, game.IsAvailable)
 			}
@@ -158,7 +213,40 @@ func TestGameService_AddGame(t *testing.T) {
 	}
 }
 
+func TestGameService_CreateGame(t *testing.T) {
+	ctx := context.Background()
+	t.Run("stores metadata", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		borrowingRepo := &MockBorrowingRepository{}
+		details := models.GameDetails{MinPlayers: 2, MaxPlayers: 2, PlayTime: 30, Designers: []string{"Uwe Rosenberg"}}
+		gameRepo.On("Create", mock.MatchedBy(func(game *models.Game) bool {
+			return game.Name == "Patchwork" && game.PlayTime == 30 && game.IsAvailable && !game.EntryDate.IsZero()
+		})).Return(nil)
+
+		service := NewGameService(gameRepo, borrowingRepo)
+		game := &models.Game{Name: "Patchwork", Condition: "good", GameDetails: details}
+		err := service.CreateGame(ctx, game)
+
+		assert.NoError(t, err)
+		assert.Equal(t, details, game.GameDetails)
+		gameRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		borrowingRepo := &MockBorrowingRepository{}
+
+		service := NewGameService(gameRepo, borrowingRepo)
+		err := service.CreateGame(ctx, &models.Game{Name: "Patchwork", Condition: "good", GameDetails: models.GameDetails{MinPlayers: 3, MaxPlayers: 2}})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "min players cannot exceed max players")
+		gameRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
 func TestGameService_GetGame(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		gameID        int
@@ -199,7 +287,7 @@ func TestGameService_GetGame(t *testing.T) {
 			tt.setupMocks(gameRepo, borrowingRepo)
 
 			service := NewGameService(gameRepo, borrowingRepo)
-			game, err := service.GetGame(tt.gameID)
+			game, err := service.GetGame(ctx, tt.gameID)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -218,6 +306,7 @@ func TestGameService_GetGame(t *testing.T) {
 }
 
 func TestGameService_SearchGames(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		query         string
@@ -268,7 +357,7 @@ func TestGameService_SearchGames(t *testing.T) {
 			tt.setupMocks(gameRepo, borrowingRepo)
 
 			service := NewGameService(gameRepo, borrowingRepo)
-			games, err := service.SearchGames(tt.query)
+			games, err := service.SearchGames(ctx, tt.query)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -287,6 +376,7 @@ func TestGameService_SearchGames(t *testing.T) {
 }
 
 func TestGameService_SetGameAvailability(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		gameID        int
@@ -334,7 +424,7 @@ func TestGameService_SetGameAvailability(t *testing.T) {
 			tt.setupMocks(gameRepo, borrowingRepo)
 
 			service := NewGameService(gameRepo, borrowingRepo)
-			err := service.SetGameAvailability(tt.gameID, tt.isAvailable)
+			err := service.SetGameAvailability(ctx, tt.gameID, tt.isAvailable)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -350,6 +440,7 @@ func TestGameService_SetGameAvailability(t *testing.T) {
 }
 
 func TestGameService_GetCurrentBorrower(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		gameID        int
@@ -416,7 +507,7 @@ func TestGameService_GetCurrentBorrower(t *testing.T) {
 			tt.setupMocks(gameRepo, borrowingRepo)
 
 			service := NewGameService(gameRepo, borrowingRepo)
-			borrower, err := service.GetCurrentBorrower(tt.gameID)
+			borrower, err := service.GetCurrentBorrower(ctx, tt.gameID)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -440,6 +531,7 @@ func TestGameService_GetCurrentBorrower(t *testing.T) {
 }
 
 func TestGameService_DeleteGame(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		gameID        int
@@ -501,7 +593,7 @@ func TestGameService_DeleteGame(t *testing.T) {
 			tt.setupMocks(gameRepo, borrowingRepo)
 
 			service := NewGameService(gameRepo, borrowingRepo)
-			err := service.DeleteGame(tt.gameID)
+			err := service.DeleteGame(ctx, tt.gameID)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -514,4 +606,346 @@ func TestGameService_DeleteGame(t *testing.T) {
 			borrowingRepo.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestGameService_AddCopy(t *testing.T) {
+	ctx := context.Background()
+	t.Run("successful add", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		borrowingRepo := &MockBorrowingRepository{}
+		service := NewGameService(gameRepo, borrowingRepo)
+
+		gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, Name: "Catan"}, nil)
+		gameRepo.On("CreateCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+			return c.GameID == 1 && c.Barcode == "BGL-0002" && c.IsAvailable
+		})).Return(nil)
+
+		gameCopy, err := service.AddCopy(ctx, 1, "BGL-0002", "excellent")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "excellent", gameCopy.Condition)
+		gameRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid condition", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		service := NewGameService(gameRepo, &MockBorrowingRepository{})
+
+		gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, Name: "Catan"}, nil)
+
+		_, err := service.AddCopy(ctx, 1, "", "broken")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed")
+		gameRepo.AssertNotCalled(t, "CreateCopy", mock.Anything)
+	})
+
+	t.Run("game not found", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		service := NewGameService(gameRepo, &MockBorrowingRepository{})
+
+		gameRepo.On("GetByID", 99).Return(nil, errors.New("game with id 99 not found"))
+
+		_, err := service.AddCopy(ctx, 99, "", "good")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "game not found")
+	})
+}
+
+func TestGameService_RemoveCopy(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		setupMocks    func(*MockGameRepository)
+		expectedError string
+	}{
+		{
+			name: "successful removal",
+			setupMocks: func(gameRepo *MockGameRepository) {
+				gameRepo.On("GetCopyByID", 5).Return(&models.GameCopy{ID: 5, GameID: 1, IsAvailable: true}, nil)
+				gameRepo.On("GetCopies", 1).Return([]*models.GameCopy{{ID: 4, GameID: 1}, {ID: 5, GameID: 1}}, nil)
+				gameRepo.On("DeleteCopy", 5).Return(nil)
+			},
+		},
+		{
+			name: "copy currently borrowed",
+			setupMocks: func(gameRepo *MockGameRepository) {
+				gameRepo.On("GetCopyByID", 5).Return(&models.GameCopy{ID: 5, GameID: 1, IsAvailable: false}, nil)
+			},
+			expectedError: "cannot remove copy: currently borrowed",
+		},
+		{
+			name: "copy in repair",
+			setupMocks: func(gameRepo *MockGameRepository) {
+				gameRepo.On("GetCopyByID", 5).Return(&models.GameCopy{ID: 5, GameID: 1, Condition: "poor", InRepair: true}, nil)
+				gameRepo.On("GetCopies", 1).Return([]*models.GameCopy{{ID: 4, GameID: 1}, {ID: 5, GameID: 1}}, nil)
+				gameRepo.On("DeleteCopy", 5).Return(nil)
+			},
+		},
+		{
+			name: "last copy",
+			setupMocks: func(gameRepo *MockGameRepository) {
+				gameRepo.On("GetCopyByID", 5).Return(&models.GameCopy{ID: 5, GameID: 1, IsAvailable: true}, nil)
+				gameRepo.On("GetCopies", 1).Return([]*models.GameCopy{{ID: 5, GameID: 1}}, nil)
+			},
+			expectedError: "cannot remove copy: it is the last copy of the game",
+		},
+		{
+			name: "copy of another game",
+			setupMocks: func(gameRepo *MockGameRepository) {
+				gameRepo.On("GetCopyByID", 5).Return(&models.GameCopy{ID: 5, GameID: 2, IsAvailable: true}, nil)
+			},
+			expectedError: "copy not found",
+		},
+		{
+			name: "copy with borrowing history",
+			setupMocks: func(gameRepo *MockGameRepository) {
+				gameRepo.On("GetCopyByID", 5).Return(&models.GameCopy{ID: 5, GameID: 1, IsAvailable: true}, nil)
+				gameRepo.On("GetCopies", 1).Return([]*models.GameCopy{{ID: 4, GameID: 1}, {ID: 5, GameID: 1}}, nil)
+				gameRepo.On("DeleteCopy", 5).Return(errors.New("failed to delete game copy: FOREIGN KEY constraint failed"))
+			},
+			expectedError: "cannot remove copy: it has borrowing history",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameRepo := &MockGameRepository{}
+			tt.setupMocks(gameRepo)
+			service := NewGameService(gameRepo, &MockBorrowingRepository{})
+
+			err := service.RemoveCopy(ctx, 1, 5)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			gameRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGameService_GetCurrentBorrowers(t *testing.T) {
+	ctx := context.Background()
+	gameRepo := &MockGameRepository{}
+	borrowingRepo := &MockBorrowingRepository{}
+	service := NewGameService(gameRepo, borrowingRepo)
+
+	returnedAt := time.Now()
+	gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, Name: "Catan"}, nil)
+	borrowingRepo.On("GetByGame", 1).Return([]*models.Borrowing{
+		{ID: 1, UserID: 1, GameID: 1},
+		{ID: 2, UserID: 2, GameID: 1, ReturnedAt: &returnedAt},
+		{ID: 3, UserID: 3, GameID: 1},
+	}, nil)
+
+	borrowers, err := service.GetCurrentBorrowers(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, borrowers, 2)
+	assert.Equal(t, 1, borrowers[0].UserID)
+	assert.Equal(t, 3, borrowers[1].UserID)
+}
+
+func TestGameService_ListGames(t *testing.T) {
+	ctx := context.Background()
+	normalized := models.GameFilter{Tags: []string{"strategy"}, ListOptions: models.ListOptions{Page: 1, PerPage: models.DefaultPerPage, Sort: "name"}}
+
+	tests := []struct {
+		name          string
+		filter        models.GameFilter
+		setupMocks    func(*MockGameRepository)
+		expectedError string
+		expectedCount int
+		expectedTotal int
+	}{
+		{
+			name:   "fills in list defaults",
+			filter: models.GameFilter{Tags: []string{"Strategy"}},
+			setupMocks: func(gameRepo *MockGameRepository) {
+				gameRepo.On("List", normalized).Return([]*models.Game{{ID: 1, Name: "Chess"}}, nil)
+				gameRepo.On("Count", normalized).Return(42, nil)
+			},
+			expectedCount: 1,
+			expectedTotal: 42,
+		},
+		{
+			name:   "empty page is not nil",
+			filter: models.GameFilter{Tags: []string{"Strategy"}},
+			setupMocks: func(gameRepo *MockGameRepository) {
+				gameRepo.On("List", normalized).Return(nil, nil)
+				gameRepo.On("Count", normalized).Return(0, nil)
+			},
+		},
+		{
+			name:          "invalid sort field",
+			filter:        models.GameFilter{ListOptions: models.ListOptions{Sort: "price"}},
+			setupMocks:    func(gameRepo *MockGameRepository) {},
+			expectedError: "invalid game filter",
+		},
+		{
+			name:          "invalid condition",
+			filter:        models.GameFilter{Condition: "mint"},
+			setupMocks:    func(gameRepo *MockGameRepository) {},
+			expectedError: "invalid game filter",
+		},
+		{
+			name:   "repository error",
+			filter: models.GameFilter{Tags: []string{"Strategy"}},
+			setupMocks: func(gameRepo *MockGameRepository) {
+				gameRepo.On("List", normalized).Return(nil, errors.New("database error"))
+			},
+			expectedError: "failed to list games",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameRepo := &MockGameRepository{}
+			tt.setupMocks(gameRepo)
+
+			service := NewGameService(gameRepo, &MockBorrowingRepository{})
+			games, total, err := service.ListGames(ctx, tt.filter)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, games)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, games)
+				assert.Len(t, games, tt.expectedCount)
+				assert.Equal(t, tt.expectedTotal, total)
+			}
+
+			gameRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGameService_ReportCopyCondition(t *testing.T) {
+	ctx := context.Background()
+	t.Run("damaged copy is kept from lending", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		holds := &MockHoldQueue{}
+		conditions := &MockConditionLog{}
+		service := NewGameService(gameRepo, &MockBorrowingRepository{})
+		service.SetHoldQueue(holds)
+		service.SetConditions(conditions)
+
+		gameRepo.On("GetCopyByID", 5).Return(&models.GameCopy{ID: 5, GameID: 1, Condition: "good", IsAvailable: true}, nil)
+		gameRepo.On("UpdateCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+			return c.ID == 5 && c.InRepair && !c.IsAvailable
+		})).Return(nil)
+		conditions.On("Record", mock.MatchedBy(func(c *models.ConditionChange) bool {
+			return *c.CopyID == 5 && c.BorrowingID == nil && c.PreviousCondition == "good" && c.Condition == "poor" && c.Notes == "Water damage"
+		})).Return(nil)
+
+		gameCopy, err := service.ReportCopyCondition(ctx, 1, 5, &models.ConditionReport{Condition: "poor", Notes: "Water damage "})
+
+		assert.NoError(t, err)
+		assert.True(t, gameCopy.InRepair)
+		gameRepo.AssertExpectations(t)
+		conditions.AssertExpectations(t)
+		holds.AssertNotCalled(t, "HoldReturnedCopy", mock.Anything)
+	})
+
+	t.Run("repaired copy goes to the hold queue", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		holds := &MockHoldQueue{}
+		conditions := &MockConditionLog{}
+		service := NewGameService(gameRepo, &MockBorrowingRepository{})
+		service.SetHoldQueue(holds)
+		service.SetConditions(conditions)
+
+		gameRepo.On("GetCopyByID", 5).Return(&models.GameCopy{ID: 5, GameID: 1, Condition: "missing_pieces", InRepair: true}, nil)
+		holds.On("HoldReturnedCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+			return c.ID == 5 && !c.InRepair
+		})).Return(true, nil)
+		gameRepo.On("UpdateCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+			return c.ID == 5 && !c.InRepair && !c.IsAvailable && c.Condition == "good"
+		})).Return(nil)
+		conditions.On("Record", mock.MatchedBy(func(c *models.ConditionChange) bool {
+			return c.PreviousCondition == "missing_pieces" && c.Condition == "good"
+		})).Return(nil)
+
+		_, err := service.ReportCopyCondition(ctx, 1, 5, &models.ConditionReport{Condition: "good"})
+
+		assert.NoError(t, err)
+		gameRepo.AssertExpectations(t)
+		holds.AssertExpectations(t)
+		conditions.AssertExpectations(t)
+	})
+
+	t.Run("repair is written in a single unit of work", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		holds := &MockHoldQueue{}
+		service := NewGameService(gameRepo, &MockBorrowingRepository{})
+		service.SetHoldQueue(holds)
+
+		txGames := &MockGameRepository{}
+		txHolds := &MockHoldQueue{}
+		store := &repositories.Store{Games: txGames, Borrowings: &MockBorrowingRepository{}}
+		holds.On("WithStore", store).Return(txHolds)
+		uow := &fakeUnitOfWork{store: store}
+		service.SetUnitOfWork(uow)
+
+		gameRepo.On("GetCopyByID", 5).Return(&models.GameCopy{ID: 5, GameID: 1, Condition: "poor", InRepair: true}, nil)
+		txHolds.On("HoldReturnedCopy", mock.AnythingOfType("*models.GameCopy")).Return(true, nil)
+		txGames.On("UpdateCopy", mock.AnythingOfType("*models.GameCopy")).Return(errors.New("disk full"))
+
+		_, err := service.ReportCopyCondition(ctx, 1, 5, &models.ConditionReport{Condition: "good"})
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, uow.calls)
+		assert.False(t, uow.committed)
+		txHolds.AssertExpectations(t)
+		txGames.AssertExpectations(t)
+		holds.AssertNotCalled(t, "HoldReturnedCopy", mock.Anything)
+		gameRepo.AssertNotCalled(t, "UpdateCopy", mock.Anything)
+	})
+
+	t.Run("invalid report", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		service := NewGameService(gameRepo, &MockBorrowingRepository{})
+
+		_, err := service.ReportCopyCondition(ctx, 1, 5, &models.ConditionReport{Condition: "broken"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed")
+		gameRepo.AssertNotCalled(t, "GetCopyByID", mock.Anything)
+	})
+}
+
+func TestGameService_GetConditionHistory(t *testing.T) {
+	ctx := context.Background()
+	t.Run("history of the game", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		conditions := &MockConditionLog{}
+		service := NewGameService(gameRepo, &MockBorrowingRepository{})
+		service.SetConditions(conditions)
+
+		history := []*models.ConditionChange{{ID: 2, GameID: 1, PreviousCondition: "good", Condition: "fair"}}
+		gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, Name: "Catan"}, nil)
+		conditions.On("GetHistory", 1).Return(history, nil)
+
+		result, err := service.GetConditionHistory(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, history, result)
+	})
+
+	t.Run("game not found", func(t *testing.T) {
+		gameRepo := &MockGameRepository{}
+		service := NewGameService(gameRepo, &MockBorrowingRepository{})
+
+		gameRepo.On("GetByID", 99).Return(nil, errors.New("game with id 99 not found"))
+
+		_, err := service.GetConditionHistory(ctx, 99)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "game not found")
+	})
+}
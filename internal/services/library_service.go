@@ -0,0 +1,114 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LibraryService handles the libraries sharing a deployment and finds the
+// library a signed in user belongs to
+type LibraryService struct {
+	libraryRepo repositories.LibraryRepository
+}
+
+// NewLibraryService creates a new LibraryService instance
+func NewLibraryService(libraryRepo repositories.LibraryRepository) *LibraryService {
+	return &LibraryService{
+		libraryRepo: libraryRepo,
+	}
+}
+
+// ListLibraries returns every library, in creation order
+func (s *LibraryService) ListLibraries(ctx context.Context) ([]*models.Library, error) {
+	libraries, err := s.libraryRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list libraries: %w", err)
+	}
+
+	return libraries, nil
+}
+
+// GetLibraryBySlug retrieves a library by the slug used in its URLs
+func (s *LibraryService) GetLibraryBySlug(ctx context.Context, slug string) (*models.Library, error) {
+	library, err := s.libraryRepo.GetBySlug(ctx, strings.ToLower(slug))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get library: %w", err)
+	}
+
+	return library, nil
+}
+
+// CreateLibrary adds a library. The slug is derived from the name when empty.
+func (s *LibraryService) CreateLibrary(ctx context.Context, name, slug string) (*models.Library, error) {
+	name = strings.TrimSpace(name)
+	if slug == "" {
+		slug = models.LibrarySlug(name)
+	}
+
+	library := &models.Library{Name: name, Slug: slug, CreatedAt: time.Now()}
+	if err := models.ValidateLibrary(library); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.libraryRepo.Create(ctx, library); err != nil {
+		return nil, fmt.Errorf("failed to create library: %w", err)
+	}
+
+	return library, nil
+}
+
+// RenameLibrary changes the name of a library and, when slug is not empty,
+// the slug of its URLs
+func (s *LibraryService) RenameLibrary(ctx context.Context, id int, name, slug string) (*models.Library, error) {
+	library, err := s.libraryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get library: %w", err)
+	}
+
+	library.Name = strings.TrimSpace(name)
+	if slug != "" {
+		library.Slug = slug
+	}
+	if err := models.ValidateLibrary(library); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.libraryRepo.Update(ctx, library); err != nil {
+		return nil, fmt.Errorf("failed to update library: %w", err)
+	}
+
+	return library, nil
+}
+
+// LibraryForSession returns the library of the user signed in with a web
+// session token
+func (s *LibraryService) LibraryForSession(ctx context.Context, token string) (*models.Library, error) {
+	if token == "" {
+		return nil, models.Unauthorized("authentication_required")
+	}
+
+	library, err := s.libraryRepo.GetBySession(ctx, hashToken(token), time.Now())
+	if err != nil {
+		return nil, models.Unauthorized("invalid_session")
+	}
+
+	return library, nil
+}
+
+// LibraryForAPIToken returns the library of the user an API token belongs to
+func (s *LibraryService) LibraryForAPIToken(ctx context.Context, token string) (*models.Library, error) {
+	if !strings.HasPrefix(token, APITokenPrefix) {
+		return nil, models.Unauthorized("invalid_api_token")
+	}
+
+	library, err := s.libraryRepo.GetByAPIToken(ctx, hashToken(token))
+	if err != nil {
+		return nil, models.Unauthorized("invalid_api_token")
+	}
+
+	return library, nil
+}
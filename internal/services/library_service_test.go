@@ -0,0 +1,160 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLibraryRepository is a mock implementation of LibraryRepository
+type MockLibraryRepository struct {
+	mock.Mock
+}
+
+func (m *MockLibraryRepository) Create(ctx context.Context, library *models.Library) error {
+	args := m.Called(library)
+	return args.Error(0)
+}
+
+func (m *MockLibraryRepository) GetByID(ctx context.Context, id int) (*models.Library, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Library), args.Error(1)
+}
+
+func (m *MockLibraryRepository) GetBySlug(ctx context.Context, slug string) (*models.Library, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Library), args.Error(1)
+}
+
+func (m *MockLibraryRepository) GetAll(ctx context.Context) ([]*models.Library, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Library), args.Error(1)
+}
+
+func (m *MockLibraryRepository) Update(ctx context.Context, library *models.Library) error {
+	args := m.Called(library)
+	return args.Error(0)
+}
+
+func (m *MockLibraryRepository) GetBySession(ctx context.Context, tokenHash string, now time.Time) (*models.Library, error) {
+	args := m.Called(tokenHash, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Library), args.Error(1)
+}
+
+func (m *MockLibraryRepository) GetByAPIToken(ctx context.Context, tokenHash string) (*models.Library, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Library), args.Error(1)
+}
+
+func TestLibraryService_CreateLibrary(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		libraryName   string
+		slug          string
+		expectedSlug  string
+		repoError     error
+		expectedError string
+	}{
+		{name: "slug derived from the name", libraryName: " Ludo Club de Nantes ", expectedSlug: "ludo-club-de-nantes"},
+		{name: "explicit slug", libraryName: "Ludothèque", slug: "ludo", expectedSlug: "ludo"},
+		{name: "missing name", libraryName: "  ", expectedError: "library name is required"},
+		{name: "invalid slug", libraryName: "Ludothèque", slug: "Ludo Club", expectedError: "library slug must be"},
+		{name: "duplicate slug", libraryName: "Ludo", expectedSlug: "ludo", repoError: errors.New(`library "ludo" already exists`), expectedError: "already exists"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockLibraryRepository{}
+			if tt.expectedSlug != "" {
+				repo.On("Create", mock.MatchedBy(func(library *models.Library) bool {
+					return library.Slug == tt.expectedSlug
+				})).Return(tt.repoError)
+			}
+
+			service := NewLibraryService(repo)
+			library, err := service.CreateLibrary(ctx, tt.libraryName, tt.slug)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, library)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSlug, library.Slug)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLibraryService_RenameLibrary(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockLibraryRepository{}
+	repo.On("GetByID", 2).Return(&models.Library{ID: 2, Slug: "ludo", Name: "Ludo"}, nil)
+	repo.On("GetByID", 9).Return(nil, errors.New("library with id 9 not found"))
+	repo.On("Update", mock.MatchedBy(func(library *models.Library) bool {
+		return library.Name == "Ludo Club" && library.Slug == "ludo"
+	})).Return(nil)
+
+	service := NewLibraryService(repo)
+
+	library, err := service.RenameLibrary(ctx, 2, "Ludo Club", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Ludo Club", library.Name)
+
+	_, err = service.RenameLibrary(ctx, 9, "Missing", "")
+	assert.ErrorContains(t, err, "not found")
+
+	repo.AssertExpectations(t)
+}
+
+func TestLibraryService_LibraryForCredentials(t *testing.T) {
+	ctx := context.Background()
+	library := &models.Library{ID: 2, Slug: "ludo", Name: "Ludo"}
+
+	repo := &MockLibraryRepository{}
+	repo.On("GetBySession", hashToken("session"), mock.AnythingOfType("time.Time")).Return(library, nil)
+	repo.On("GetBySession", hashToken("stale"), mock.AnythingOfType("time.Time")).Return(nil, errors.New("session not found"))
+	repo.On("GetByAPIToken", hashToken("bgl_token")).Return(library, nil)
+
+	service := NewLibraryService(repo)
+
+	found, err := service.LibraryForSession(ctx, "session")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, found.ID)
+
+	_, err = service.LibraryForSession(ctx, "stale")
+	assert.EqualError(t, err, "invalid session")
+
+	_, err = service.LibraryForSession(ctx, "")
+	assert.EqualError(t, err, "authentication required")
+
+	found, err = service.LibraryForAPIToken(ctx, "bgl_token")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, found.ID)
+
+	_, err = service.LibraryForAPIToken(ctx, "token")
+	assert.EqualError(t, err, "invalid API token")
+
+	repo.AssertExpectations(t)
+}
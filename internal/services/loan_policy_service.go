@@ -0,0 +1,130 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"fmt"
+)
+
+// LoanPolicies resolves the borrowing limits that apply to a user. It is
+// implemented by LoanPolicyService.
+type LoanPolicies interface {
+	PolicyForUser(ctx context.Context, user *models.User) (*models.LoanPolicy, error)
+	// WithStore returns loan policies read from the given repositories
+	WithStore(store *repositories.Store) LoanPolicies
+}
+
+// policyFor returns the loan policy that applies to a user, falling back to
+// models.DefaultLoanPolicy when no policies are configured
+func policyFor(ctx context.Context, policies LoanPolicies, user *models.User) (*models.LoanPolicy, error) {
+	if policies == nil {
+		return models.DefaultLoanPolicy(), nil
+	}
+
+	return policies.PolicyForUser(ctx, user)
+}
+
+// LoanPolicyService handles membership tiers and their borrowing limits
+type LoanPolicyService struct {
+	policyRepo repositories.LoanPolicyRepository
+	auditTrail
+}
+
+// NewLoanPolicyService creates a new LoanPolicyService instance
+func NewLoanPolicyService(policyRepo repositories.LoanPolicyRepository) *LoanPolicyService {
+	return &LoanPolicyService{
+		policyRepo: policyRepo,
+	}
+}
+
+// WithActor returns a copy of the service that records actor as the author
+// of its changes in the audit log
+func (s *LoanPolicyService) WithActor(actor *models.User) *LoanPolicyService {
+	bound := *s
+	bound.actor = actor
+	return &bound
+}
+
+// GetPolicies retrieves the loan policies of all membership tiers
+func (s *LoanPolicyService) GetPolicies(ctx context.Context) ([]*models.LoanPolicy, error) {
+	policies, err := s.policyRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// GetPolicy retrieves the loan policy of a membership tier
+func (s *LoanPolicyService) GetPolicy(ctx context.Context, tier string) (*models.LoanPolicy, error) {
+	if tier == "" {
+		return nil, models.Invalid("tier", "tier_required")
+	}
+
+	policy, err := s.policyRepo.GetByTier(ctx, tier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// CreatePolicy adds a membership tier with its borrowing limits
+func (s *LoanPolicyService) CreatePolicy(ctx context.Context, policy *models.LoanPolicy) error {
+	if policy == nil {
+		return fmt.Errorf("loan policy cannot be nil")
+	}
+
+	if err := models.ValidateLoanPolicy(policy); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.policyRepo.Create(ctx, policy); err != nil {
+		return fmt.Errorf("failed to create loan policy: %w", err)
+	}
+
+	return s.recordKey(ctx, models.AuditActionCreate, models.AuditEntityLoanPolicy, policy.Tier, nil, policy)
+}
+
+// UpdatePolicy changes the borrowing limits of an existing membership tier
+func (s *LoanPolicyService) UpdatePolicy(ctx context.Context, policy *models.LoanPolicy) error {
+	if policy == nil {
+		return fmt.Errorf("loan policy cannot be nil")
+	}
+
+	if err := models.ValidateLoanPolicy(policy); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	existing, err := s.policyRepo.GetByTier(ctx, policy.Tier)
+	if err != nil {
+		return fmt.Errorf("failed to update loan policy: %w", err)
+	}
+
+	if err := s.policyRepo.Update(ctx, policy); err != nil {
+		return fmt.Errorf("failed to update loan policy: %w", err)
+	}
+
+	return s.recordKey(ctx, models.AuditActionUpdate, models.AuditEntityLoanPolicy, policy.Tier, existing, policy)
+}
+
+// PolicyForUser returns the loan policy of the user's membership tier
+func (s *LoanPolicyService) PolicyForUser(ctx context.Context, user *models.User) (*models.LoanPolicy, error) {
+	tier := user.MembershipTier
+	if tier == "" {
+		tier = models.DefaultMembershipTier
+	}
+
+	policy, err := s.policyRepo.GetByTier(ctx, tier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// WithStore returns a LoanPolicyService reading from the given repositories
+func (s *LoanPolicyService) WithStore(store *repositories.Store) LoanPolicies {
+	return NewLoanPolicyService(store.LoanPolicies)
+}
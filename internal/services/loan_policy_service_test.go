@@ -0,0 +1,154 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLoanPolicyRepository is a mock implementation of LoanPolicyRepository
+type MockLoanPolicyRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoanPolicyRepository) Create(ctx context.Context, policy *models.LoanPolicy) error {
+	args := m.Called(policy)
+	return args.Error(0)
+}
+
+func (m *MockLoanPolicyRepository) GetByTier(ctx context.Context, tier string) (*models.LoanPolicy, error) {
+	args := m.Called(tier)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LoanPolicy), args.Error(1)
+}
+
+func (m *MockLoanPolicyRepository) GetAll(ctx context.Context) ([]*models.LoanPolicy, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LoanPolicy), args.Error(1)
+}
+
+func (m *MockLoanPolicyRepository) Update(ctx context.Context, policy *models.LoanPolicy) error {
+	args := m.Called(policy)
+	return args.Error(0)
+}
+
+func TestLoanPolicyService_CreatePolicy(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		policy        *models.LoanPolicy
+		setupMocks    func(*MockLoanPolicyRepository)
+		expectedError string
+	}{
+		{
+			name:   "successful creation",
+			policy: &models.LoanPolicy{Tier: "staff", MaxLoans: 20, MaxLoanDays: 180, DefaultLoanDays: 30, MaxExtensions: 10},
+			setupMocks: func(repo *MockLoanPolicyRepository) {
+				repo.On("Create", mock.AnythingOfType("*models.LoanPolicy")).Return(nil)
+			},
+		},
+		{
+			name:          "nil policy",
+			policy:        nil,
+			setupMocks:    func(repo *MockLoanPolicyRepository) {},
+			expectedError: "loan policy cannot be nil",
+		},
+		{
+			name:          "invalid limits",
+			policy:        &models.LoanPolicy{Tier: "staff", MaxLoans: 0, MaxLoanDays: 180, DefaultLoanDays: 30},
+			setupMocks:    func(repo *MockLoanPolicyRepository) {},
+			expectedError: "validation failed: max loans must be at least 1",
+		},
+		{
+			name:   "duplicate tier",
+			policy: &models.LoanPolicy{Tier: "basic", MaxLoans: 2, MaxLoanDays: 30, DefaultLoanDays: 14},
+			setupMocks: func(repo *MockLoanPolicyRepository) {
+				repo.On("Create", mock.AnythingOfType("*models.LoanPolicy")).Return(errors.New(`loan policy for tier "basic" already exists`))
+			},
+			expectedError: "already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockLoanPolicyRepository{}
+			tt.setupMocks(repo)
+
+			service := NewLoanPolicyService(repo)
+			err := service.CreatePolicy(ctx, tt.policy)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLoanPolicyService_UpdatePolicy(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockLoanPolicyRepository{}
+	repo.On("GetByTier", "gold").Return(nil, errors.New(`loan policy for tier "gold" not found`))
+
+	service := NewLoanPolicyService(repo)
+
+	err := service.UpdatePolicy(ctx, &models.LoanPolicy{Tier: "gold", MaxLoans: 3, MaxLoanDays: 30, DefaultLoanDays: 14})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	err = service.UpdatePolicy(ctx, &models.LoanPolicy{Tier: "gold", MaxLoans: 3, MaxLoanDays: 30, DefaultLoanDays: 60})
+	assert.EqualError(t, err, "validation failed: default loan days must be between 1 and max loan days")
+
+	repo.AssertExpectations(t)
+}
+
+func TestLoanPolicyService_PolicyForUser(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockLoanPolicyRepository{}
+	premium := &models.LoanPolicy{Tier: "premium", MaxLoans: 10, MaxLoanDays: 120, DefaultLoanDays: 21, MaxExtensions: 5}
+	repo.On("GetByTier", "premium").Return(premium, nil)
+	repo.On("GetByTier", models.DefaultMembershipTier).Return(models.DefaultLoanPolicy(), nil)
+
+	service := NewLoanPolicyService(repo)
+
+	policy, err := service.PolicyForUser(ctx, &models.User{ID: 1, MembershipTier: "premium"})
+	assert.NoError(t, err)
+	assert.Equal(t, premium, policy)
+
+	// Users created before tiers existed fall back to the default tier
+	policy, err = service.PolicyForUser(ctx, &models.User{ID: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, models.DefaultMembershipTier, policy.Tier)
+
+	repo.AssertExpectations(t)
+}
+
+func TestLoanPolicyService_GetPolicy(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockLoanPolicyRepository{}
+	repo.On("GetByTier", "gold").Return(nil, errors.New(`loan policy for tier "gold" not found`))
+
+	service := NewLoanPolicyService(repo)
+
+	_, err := service.GetPolicy(ctx, "")
+	assert.EqualError(t, err, "membership tier cannot be empty")
+
+	_, err = service.GetPolicy(ctx, "gold")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	repo.AssertExpectations(t)
+}
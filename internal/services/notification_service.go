@@ -0,0 +1,291 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"board-game-library/pkg/mailer"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/mail"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Defaults of the delivery of alerts by email
+const (
+	DefaultNotificationMaxAttempts  = 5
+	DefaultNotificationRetryBackoff = 5 * time.Minute
+	DefaultNotificationBatchSize    = 100
+)
+
+// Notifier delivers a message to a user. mailer.SMTPSender is the
+// implementation used in production.
+type Notifier interface {
+	Send(ctx context.Context, msg mailer.Message) error
+}
+
+// NotificationSettings configures the delivery of the alerts of a library
+type NotificationSettings struct {
+	Library      string        // name of the library, signing the messages
+	Language     string        // language of the users who did not choose one
+	MaxAttempts  int           // attempts before a notification is marked failed
+	RetryBackoff time.Duration // delay before the first retry, doubled after each failure
+	BatchSize    int           // notifications sent per delivery run
+}
+
+// NotificationDelivery counts the outcome of a delivery run
+type NotificationDelivery struct {
+	Sent    int
+	Skipped int
+	Retried int
+	Failed  int
+}
+
+// NotificationData is what the message templates of an alert can use
+type NotificationData struct {
+	Library     string
+	UserName    string
+	GameName    string
+	Message     string    // the text of the alert
+	DueDate     time.Time // due date of the loan of an overdue or reminder alert, zero when unknown
+	DaysOverdue int
+	HoldUntil   time.Time // end of the hold of a hold alert, zero when unknown
+}
+
+// NotificationTemplates holds the message templates of every language. Each
+// template defines "<type>.subject" and "<type>.body" for the alert types;
+// alerts of other types use the "custom" ones.
+type NotificationTemplates struct {
+	languages map[string]*template.Template
+}
+
+// notificationDateFormats is how each language writes dates in messages
+var notificationDateFormats = map[string]string{
+	models.LanguageFrench:  "02/01/2006",
+	models.LanguageEnglish: "January 2, 2006",
+}
+
+// LoadNotificationTemplates parses the message templates emails/<language>.tmpl
+// of every supported language from fsys
+func LoadNotificationTemplates(fsys fs.FS) (*NotificationTemplates, error) {
+	templates := &NotificationTemplates{languages: make(map[string]*template.Template)}
+
+	for _, language := range models.ValidLanguages {
+		layout := notificationDateFormats[language]
+		funcs := template.FuncMap{
+			"date": func(t time.Time) string { return t.Format(layout) },
+		}
+
+		tmpl, err := template.New(language).Funcs(funcs).ParseFS(fsys, "emails/"+language+".tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s notification templates: %w", language, err)
+		}
+		templates.languages[language] = tmpl
+	}
+
+	return templates, nil
+}
+
+// Render returns the subject and body of the message of an alert type
+func (t *NotificationTemplates) Render(language, alertType string, data NotificationData) (string, string, error) {
+	tmpl, ok := t.languages[language]
+	if !ok {
+		return "", "", fmt.Errorf("no notification templates for language %q", language)
+	}
+
+	if tmpl.Lookup(alertType+".subject") == nil || tmpl.Lookup(alertType+".body") == nil {
+		alertType = "custom"
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, alertType+".subject", data); err != nil {
+		return "", "", fmt.Errorf("failed to render notification subject: %w", err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, alertType+".body", data); err != nil {
+		return "", "", fmt.Errorf("failed to render notification body: %w", err)
+	}
+
+	return strings.TrimSpace(subject.String()), strings.TrimSpace(body.String()) + "\n", nil
+}
+
+// NotificationService emails alerts to their users, keeps the delivery
+// status of each alert and the users' notification preferences
+type NotificationService struct {
+	notificationRepo repositories.NotificationRepository
+	borrowingRepo    repositories.BorrowingRepository
+	reservationRepo  repositories.ReservationRepository
+	notifier         Notifier
+	templates        *NotificationTemplates
+	settings         NotificationSettings
+}
+
+// NewNotificationService creates a new NotificationService instance. Unset
+// settings take their default values.
+func NewNotificationService(notificationRepo repositories.NotificationRepository, borrowingRepo repositories.BorrowingRepository, reservationRepo repositories.ReservationRepository, notifier Notifier, templates *NotificationTemplates, settings NotificationSettings) *NotificationService {
+	if settings.Language == "" {
+		settings.Language = models.ValidLanguages[0]
+	}
+	if settings.MaxAttempts < 1 {
+		settings.MaxAttempts = DefaultNotificationMaxAttempts
+	}
+	if settings.RetryBackoff <= 0 {
+		settings.RetryBackoff = DefaultNotificationRetryBackoff
+	}
+	if settings.BatchSize < 1 {
+		settings.BatchSize = DefaultNotificationBatchSize
+	}
+
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		borrowingRepo:    borrowingRepo,
+		reservationRepo:  reservationRepo,
+		notifier:         notifier,
+		templates:        templates,
+		settings:         settings,
+	}
+}
+
+// DeliverDue emails the unread alerts that were never sent and those whose
+// retry is due. A failed message is retried with an exponential backoff until
+// every attempt is used. Alerts of users who opted out or have no email
+// address are skipped. The error reports the messages that could not be sent.
+func (s *NotificationService) DeliverDue(ctx context.Context) (*NotificationDelivery, error) {
+	now := time.Now()
+	due, err := s.notificationRepo.GetDue(ctx, now.Add(-models.NotificationMaxAge), now, s.settings.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due notifications: %w", err)
+	}
+
+	delivery := &NotificationDelivery{}
+	var errs []error
+	for _, pending := range due {
+		notification := &models.AlertNotification{
+			AlertID:   pending.Alert.ID,
+			Attempts:  pending.Attempts,
+			UpdatedAt: now,
+		}
+
+		if !pending.EmailEnabled || pending.Email == "" {
+			notification.Status = models.NotificationSkipped
+			delivery.Skipped++
+		} else if sendErr := s.send(ctx, pending); sendErr != nil {
+			notification.Attempts++
+			notification.LastError = sendErr.Error()
+			if notification.Attempts >= s.settings.MaxAttempts {
+				notification.Status = models.NotificationFailed
+				delivery.Failed++
+			} else {
+				next := now.Add(models.NotificationRetryDelay(s.settings.RetryBackoff, notification.Attempts))
+				notification.Status = models.NotificationPending
+				notification.NextAttemptAt = &next
+				delivery.Retried++
+			}
+			errs = append(errs, fmt.Errorf("alert %d: %w", pending.Alert.ID, sendErr))
+		} else {
+			notification.Attempts++
+			notification.Status = models.NotificationSent
+			notification.SentAt = &now
+			delivery.Sent++
+		}
+
+		if err := s.notificationRepo.Save(ctx, notification); err != nil {
+			return delivery, fmt.Errorf("failed to save notification of alert %d: %w", pending.Alert.ID, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return delivery, fmt.Errorf("failed to send %d notification(s): %w", len(errs), errors.Join(errs...))
+	}
+
+	return delivery, nil
+}
+
+// send renders and sends the message of an alert in the language of its user
+func (s *NotificationService) send(ctx context.Context, pending *models.PendingNotification) error {
+	language := pending.Language
+	if language == "" {
+		language = s.settings.Language
+	}
+
+	subject, body, err := s.templates.Render(language, pending.Alert.Type, s.messageData(ctx, pending))
+	if err != nil {
+		return err
+	}
+
+	to := &mail.Address{Name: pending.UserName, Address: pending.Email}
+	return s.notifier.Send(ctx, mailer.Message{To: to.String(), Subject: subject, Body: body})
+}
+
+// messageData gathers what the message of an alert tells: the due date of the
+// loan of the game, or the end of its hold. Details that cannot be found are
+// left out of the message rather than holding it back.
+func (s *NotificationService) messageData(ctx context.Context, pending *models.PendingNotification) NotificationData {
+	data := NotificationData{
+		Library:  s.settings.Library,
+		UserName: pending.UserName,
+		GameName: pending.GameName,
+		Message:  pending.Alert.Message,
+	}
+
+	switch pending.Alert.Type {
+	case "overdue", "reminder":
+		if borrowings, err := s.borrowingRepo.GetActiveByUser(ctx, pending.Alert.UserID); err == nil {
+			for _, borrowing := range borrowings {
+				if borrowing.GameID == pending.Alert.GameID {
+					data.DueDate = borrowing.DueDate
+					data.DaysOverdue = borrowing.DaysOverdue()
+					break
+				}
+			}
+		}
+	case "hold":
+		if reservations, err := s.reservationRepo.GetByUser(ctx, pending.Alert.UserID); err == nil {
+			for _, reservation := range reservations {
+				if reservation.GameID == pending.Alert.GameID && reservation.Status == models.ReservationReady && reservation.ExpiresAt != nil {
+					data.HoldUntil = *reservation.ExpiresAt
+					break
+				}
+			}
+		}
+	}
+
+	return data
+}
+
+// GetAlertNotification retrieves the delivery status of the email of an alert
+func (s *NotificationService) GetAlertNotification(ctx context.Context, alertID int) (*models.AlertNotification, error) {
+	notification, err := s.notificationRepo.GetByAlert(ctx, alertID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+
+	return notification, nil
+}
+
+// GetPreferences retrieves the notification preferences of a user
+func (s *NotificationService) GetPreferences(ctx context.Context, userID int) (*models.NotificationPreferences, error) {
+	preferences, err := s.notificationRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+// UpdatePreferences replaces the notification preferences of a user. Opting
+// out applies to the alerts not sent yet.
+func (s *NotificationService) UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	if err := models.ValidateNotificationPreferences(preferences); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.notificationRepo.SavePreferences(ctx, preferences); err != nil {
+		return fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"board-game-library/internal/assets"
+	"board-game-library/internal/models"
+	"board-game-library/pkg/mailer"
+	"board-game-library/pkg/mailer/mailertest"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockNotificationRepository is a mock implementation of NotificationRepository
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) GetDue(ctx context.Context, since, now time.Time, limit int) ([]*models.PendingNotification, error) {
+	args := m.Called(since, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PendingNotification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) GetByAlert(ctx context.Context, alertID int) (*models.AlertNotification, error) {
+	args := m.Called(alertID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AlertNotification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) Save(ctx context.Context, notification *models.AlertNotification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetPreferences(ctx context.Context, userID int) (*models.NotificationPreferences, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockNotificationRepository) SavePreferences(ctx context.Context, preferences *models.NotificationPreferences) error {
+	args := m.Called(preferences)
+	return args.Error(0)
+}
+
+// newSMTPTestSender returns a sender delivering to a local test server
+func newSMTPTestSender(server *mailertest.Server) *mailer.SMTPSender {
+	return mailer.NewSMTPSender(mailer.Config{
+		Host:     server.Host,
+		Port:     server.Port,
+		From:     "ludo@example.org",
+		Security: mailer.SecurityNone,
+		Timeout:  5 * time.Second,
+	})
+}
+
+// setupNotificationService returns a NotificationService sending through notifier
+func setupNotificationService(t *testing.T, notifier Notifier) (*NotificationService, *MockNotificationRepository, *MockBorrowingRepository, *MockReservationRepository) {
+	t.Helper()
+
+	templates, err := LoadNotificationTemplates(assets.GetTemplatesFS())
+	require.NoError(t, err)
+
+	notificationRepo := new(MockNotificationRepository)
+	borrowingRepo := new(MockBorrowingRepository)
+	reservationRepo := new(MockReservationRepository)
+	service := NewNotificationService(notificationRepo, borrowingRepo, reservationRepo, notifier, templates, NotificationSettings{
+		Library:      "Ludothèque du Centre",
+		MaxAttempts:  3,
+		RetryBackoff: time.Minute,
+	})
+
+	return service, notificationRepo, borrowingRepo, reservationRepo
+}
+
+func TestNotificationTemplates_Render(t *testing.T) {
+	templates, err := LoadNotificationTemplates(assets.GetTemplatesFS())
+	require.NoError(t, err)
+
+	data := NotificationData{
+		Library:     "Ludothèque du Centre",
+		UserName:    "Alice",
+		GameName:    "Azul",
+		Message:     "Merci de ranger les tuiles",
+		DueDate:     time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC),
+		DaysOverdue: 3,
+		HoldUntil:   time.Date(2026, 3, 12, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		language, alertType string
+		subject             string
+		body                []string
+	}{
+		{models.LanguageFrench, "overdue", "Retard de retour : Azul", []string{"Bonjour Alice", "le 09/03/2026, il y a 3 jours", "Mon compte"}},
+		{models.LanguageEnglish, "overdue", "Overdue: Azul", []string{"Hello Alice", "on March 9, 2026, 3 days ago", "My account"}},
+		{models.LanguageFrench, "reminder", "Rappel : Azul est à rendre bientôt", []string{"au plus tard le 09/03/2026"}},
+		{models.LanguageEnglish, "hold", "Azul is waiting for you", []string{"until March 12, 2026"}},
+		{models.LanguageEnglish, "damage", "Damaged: Azul", []string{"got worse", "Merci de ranger les tuiles"}},
+		{models.LanguageFrench, "damage", "Jeu abîmé : Azul", []string{"s'est dégradé", "Merci de ranger les tuiles"}},
+		{models.LanguageFrench, "custom", "Message de Ludothèque du Centre : Azul", []string{"Merci de ranger les tuiles"}},
+		{models.LanguageEnglish, "unknown", "Message from Ludothèque du Centre: Azul", []string{"Merci de ranger les tuiles"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language+"/"+tt.alertType, func(t *testing.T) {
+			subject, body, err := templates.Render(tt.language, tt.alertType, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.subject, subject)
+			for _, want := range tt.body {
+				assert.Contains(t, body, want)
+			}
+		})
+	}
+
+	_, body, err := templates.Render(models.LanguageFrench, "overdue", NotificationData{UserName: "Alice", GameName: "Azul"})
+	require.NoError(t, err)
+	assert.Contains(t, body, "n'a pas été rendu à temps.", "details that could not be found are left out")
+
+	_, _, err = templates.Render("de", "overdue", data)
+	assert.Error(t, err)
+}
+
+func TestNotificationService_DeliverDue(t *testing.T) {
+	ctx := context.Background()
+	server := mailertest.NewServer()
+	defer server.Close()
+	service, notificationRepo, borrowingRepo, reservationRepo := setupNotificationService(t, newSMTPTestSender(server))
+
+	dueDate := time.Now().Add(-3 * 24 * time.Hour)
+	expiresAt := time.Now().Add(48 * time.Hour)
+	due := []*models.PendingNotification{
+		{Alert: models.Alert{ID: 1, UserID: 10, GameID: 20, Type: "overdue"}, UserName: "Bob", Email: "bob@example.org", GameName: "Catan", EmailEnabled: true, Attempts: 1},
+		{Alert: models.Alert{ID: 2, UserID: 11, GameID: 21, Type: "overdue"}, UserName: "Alice", Email: "alice@example.org", GameName: "Azul", EmailEnabled: true, Language: models.LanguageEnglish},
+		{Alert: models.Alert{ID: 3, UserID: 12, GameID: 22, Type: "hold"}, UserName: "Chloé", Email: "chloe@example.org", GameName: "Dixit", EmailEnabled: true},
+		{Alert: models.Alert{ID: 4, UserID: 13, GameID: 23, Type: "reminder"}, UserName: "Denis", Email: "denis@example.org", GameName: "Skyjo", EmailEnabled: false},
+	}
+	notificationRepo.On("GetDue", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time"), DefaultNotificationBatchSize).Return(due, nil)
+	borrowingRepo.On("GetActiveByUser", 10).Return([]*models.Borrowing{}, nil)
+	borrowingRepo.On("GetActiveByUser", 11).Return([]*models.Borrowing{{UserID: 11, GameID: 21, DueDate: dueDate}}, nil)
+	reservationRepo.On("GetByUser", 12).Return([]*models.Reservation{{UserID: 12, GameID: 22, Status: models.ReservationReady, ExpiresAt: &expiresAt}}, nil)
+
+	var saved []*models.AlertNotification
+	notificationRepo.On("Save", mock.AnythingOfType("*models.AlertNotification")).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(0).(*models.AlertNotification))
+	}).Return(nil)
+
+	server.Reject(1, "451 4.3.0 Mailbox busy")
+	delivery, err := service.DeliverDue(ctx)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Mailbox busy")
+	assert.Equal(t, &NotificationDelivery{Sent: 2, Skipped: 1, Retried: 1}, delivery)
+
+	require.Len(t, saved, 4)
+	retried := saved[0]
+	assert.Equal(t, models.NotificationPending, retried.Status)
+	assert.Equal(t, 2, retried.Attempts)
+	assert.Contains(t, retried.LastError, "Mailbox busy")
+	require.NotNil(t, retried.NextAttemptAt)
+	assert.WithinDuration(t, time.Now().Add(2*time.Minute), *retried.NextAttemptAt, 5*time.Second, "the backoff doubles after the second failure")
+
+	assert.Equal(t, models.NotificationSent, saved[1].Status)
+	assert.NotNil(t, saved[1].SentAt)
+	assert.Equal(t, models.NotificationSent, saved[2].Status)
+	assert.Equal(t, models.NotificationSkipped, saved[3].Status)
+	assert.Equal(t, 0, saved[3].Attempts)
+
+	messages := server.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, []string{"alice@example.org"}, messages[0].To)
+	assert.Equal(t, "Overdue: Azul", messages[0].Subject)
+	assert.Contains(t, messages[0].Body, "was due back on "+dueDate.Format("January 2, 2006")+", 3 days ago")
+	assert.Equal(t, "Dixit vous attend", messages[1].Subject)
+	assert.Contains(t, messages[1].Body, "jusqu'au "+expiresAt.Format("02/01/2006"))
+	assert.Contains(t, messages[1].Body, "Ludothèque du Centre")
+}
+
+func TestNotificationService_DeliverDueGivesUp(t *testing.T) {
+	ctx := context.Background()
+	server := mailertest.NewServer()
+	defer server.Close()
+	service, notificationRepo, _, _ := setupNotificationService(t, newSMTPTestSender(server))
+
+	due := []*models.PendingNotification{
+		{Alert: models.Alert{ID: 1, UserID: 10, GameID: 20, Type: "custom", Message: "Atelier samedi"}, UserName: "Bob", Email: "bob@example.org", GameName: "Catan", EmailEnabled: true, Attempts: 2},
+	}
+	notificationRepo.On("GetDue", mock.Anything, mock.Anything, mock.Anything).Return(due, nil)
+	notificationRepo.On("Save", mock.MatchedBy(func(n *models.AlertNotification) bool {
+		return n.AlertID == 1 && n.Status == models.NotificationFailed && n.Attempts == 3 && n.NextAttemptAt == nil
+	})).Return(nil)
+
+	server.Reject(1, "550 5.1.1 No such user")
+	delivery, err := service.DeliverDue(ctx)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, delivery.Failed)
+	notificationRepo.AssertExpectations(t)
+}
+
+func TestNotificationService_DeliverDueErrors(t *testing.T) {
+	ctx := context.Background()
+	service, notificationRepo, _, _ := setupNotificationService(t, mailer.NewSMTPSender(mailer.Config{}))
+
+	notificationRepo.On("GetDue", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+
+	delivery, err := service.DeliverDue(ctx)
+
+	assert.Nil(t, delivery)
+	assert.Contains(t, err.Error(), "failed to get due notifications")
+}
+
+func TestNotificationService_UpdatePreferences(t *testing.T) {
+	ctx := context.Background()
+	service, notificationRepo, _, _ := setupNotificationService(t, nil)
+
+	preferences := &models.NotificationPreferences{UserID: 1, EmailEnabled: false, Language: models.LanguageEnglish}
+	notificationRepo.On("SavePreferences", preferences).Return(nil)
+
+	assert.NoError(t, service.UpdatePreferences(ctx, preferences))
+
+	err := service.UpdatePreferences(ctx, &models.NotificationPreferences{UserID: 1, Language: "de"})
+	assert.Contains(t, err.Error(), "validation failed")
+
+	notificationRepo.On("SavePreferences", mock.MatchedBy(func(p *models.NotificationPreferences) bool { return p.UserID == 99 })).
+		Return(errors.New("user with id 99 not found"))
+	err = service.UpdatePreferences(ctx, &models.NotificationPreferences{UserID: 99, EmailEnabled: true})
+	assert.Contains(t, err.Error(), "user with id 99 not found")
+
+	notificationRepo.AssertExpectations(t)
+}
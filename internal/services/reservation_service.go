@@ -0,0 +1,433 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultHoldDays is how long a returned copy is kept for a user when no hold period is configured
+const DefaultHoldDays = 3
+
+// ReservationService handles the per-game reservation queues. When a borrowed
+// copy comes back, the first user in line is notified through an alert and the
+// copy is held for them for a limited number of days.
+type ReservationService struct {
+	reservationRepo repositories.ReservationRepository
+	userRepo        repositories.UserRepository
+	gameRepo        repositories.GameRepository
+	borrowingRepo   repositories.BorrowingRepository
+	alertRepo       repositories.AlertRepository
+	holdDays        int
+	auditTrail
+}
+
+// NewReservationService creates a new ReservationService instance. A holdDays
+// value below 1 falls back to DefaultHoldDays.
+func NewReservationService(reservationRepo repositories.ReservationRepository, userRepo repositories.UserRepository, gameRepo repositories.GameRepository, borrowingRepo repositories.BorrowingRepository, alertRepo repositories.AlertRepository, holdDays int) *ReservationService {
+	if holdDays < 1 {
+		holdDays = DefaultHoldDays
+	}
+
+	return &ReservationService{
+		reservationRepo: reservationRepo,
+		userRepo:        userRepo,
+		gameRepo:        gameRepo,
+		borrowingRepo:   borrowingRepo,
+		alertRepo:       alertRepo,
+		holdDays:        holdDays,
+	}
+}
+
+// HoldDays returns the number of days a returned copy is held for pickup
+func (s *ReservationService) HoldDays() int {
+	return s.holdDays
+}
+
+// WithStore returns a ReservationService working on the given repositories,
+// e.g. to take part in a borrowing's unit of work
+func (s *ReservationService) WithStore(store *repositories.Store) HoldQueue {
+	bound := NewReservationService(store.Reservations, store.Users, store.Games, store.Borrowings, store.Alerts, s.holdDays)
+	bound.auditTrail = s.auditTrail.withStore(store)
+	return bound
+}
+
+// WithActor returns a copy of the service that records actor as the author
+// of its changes in the audit log
+func (s *ReservationService) WithActor(actor *models.User) *ReservationService {
+	bound := *s
+	bound.actor = actor
+	return &bound
+}
+
+// PlaceHold adds a user to the end of a game's reservation queue
+func (s *ReservationService) PlaceHold(ctx context.Context, userID, gameID int) (*models.Reservation, error) {
+	if userID <= 0 {
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
+	}
+	if gameID <= 0 {
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
+	}
+
+	// Check if user exists and is active
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if !user.IsActive {
+		return nil, models.PolicyViolation(models.PolicyRuleAccountInactive)
+	}
+
+	// Holds are only needed while every copy is out
+	game, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("game not found: %w", err)
+	}
+	if game.IsAvailable {
+		return nil, models.Conflict("game_available")
+	}
+
+	activeBorrowings, err := s.borrowingRepo.GetActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user borrowings: %w", err)
+	}
+	for _, borrowing := range activeBorrowings {
+		if borrowing.GameID == gameID {
+			return nil, models.Conflict("already_borrowing")
+		}
+	}
+
+	queue, err := s.reservationRepo.GetActiveByGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation queue: %w", err)
+	}
+	for _, reservation := range queue {
+		if reservation.UserID == userID {
+			return nil, models.Conflict("already_on_hold")
+		}
+	}
+
+	reservation := &models.Reservation{
+		UserID:    userID,
+		GameID:    gameID,
+		Status:    models.ReservationWaiting,
+		CreatedAt: time.Now(),
+	}
+
+	if err := models.ValidateReservation(reservation); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.reservationRepo.Create(ctx, reservation); err != nil {
+		return nil, fmt.Errorf("failed to create reservation: %w", err)
+	}
+
+	reservation.Position = len(queue) + 1
+	if err := s.record(ctx, models.AuditActionCreate, models.AuditEntityReservation, reservation.ID, nil, reservation); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// CancelHold removes a reservation from its queue. A copy held for the
+// reservation is passed on to the next user in line.
+func (s *ReservationService) CancelHold(ctx context.Context, reservationID int) error {
+	if reservationID <= 0 {
+		return models.Invalid("reservation_id", "invalid_reservation_id", reservationID)
+	}
+
+	reservation, err := s.reservationRepo.GetByID(ctx, reservationID)
+	if err != nil {
+		return fmt.Errorf("reservation not found: %w", err)
+	}
+
+	if !reservation.IsActive() {
+		return models.Conflict("reservation_not_active")
+	}
+
+	before := *reservation
+	if err := s.close(ctx, reservation, models.ReservationCancelled); err != nil {
+		return err
+	}
+
+	return s.record(ctx, models.AuditActionCancel, models.AuditEntityReservation, reservationID, &before, reservation)
+}
+
+// GetReservation retrieves a reservation by ID
+func (s *ReservationService) GetReservation(ctx context.Context, reservationID int) (*models.Reservation, error) {
+	if reservationID <= 0 {
+		return nil, models.Invalid("reservation_id", "invalid_reservation_id", reservationID)
+	}
+
+	reservation, err := s.reservationRepo.GetByID(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservation not found: %w", err)
+	}
+
+	if reservation.IsActive() {
+		queue, err := s.GetQueue(ctx, reservation.GameID)
+		if err != nil {
+			return nil, err
+		}
+		for _, queued := range queue {
+			if queued.ID == reservation.ID {
+				reservation.Position = queued.Position
+			}
+		}
+	}
+
+	return reservation, nil
+}
+
+// GetQueue retrieves the active reservations for a game in queue order
+func (s *ReservationService) GetQueue(ctx context.Context, gameID int) ([]*models.Reservation, error) {
+	if gameID <= 0 {
+		return nil, models.Invalid("game_id", "invalid_game_id", gameID)
+	}
+
+	queue, err := s.reservationRepo.GetActiveByGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation queue: %w", err)
+	}
+
+	for i, reservation := range queue {
+		reservation.Position = i + 1
+	}
+
+	return queue, nil
+}
+
+// GetUserReservations retrieves all reservations of a user, with the queue
+// position of the active ones
+func (s *ReservationService) GetUserReservations(ctx context.Context, userID int) ([]*models.Reservation, error) {
+	if userID <= 0 {
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
+	}
+
+	// Check if user exists
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	reservations, err := s.reservationRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user reservations: %w", err)
+	}
+
+	for _, reservation := range reservations {
+		if !reservation.IsActive() {
+			continue
+		}
+
+		queue, err := s.GetQueue(ctx, reservation.GameID)
+		if err != nil {
+			return nil, err
+		}
+		for _, queued := range queue {
+			if queued.ID == reservation.ID {
+				reservation.Position = queued.Position
+			}
+		}
+	}
+
+	return reservations, nil
+}
+
+// GetActiveReservations retrieves every waiting or ready reservation, grouped
+// by game in queue order
+func (s *ReservationService) GetActiveReservations(ctx context.Context) ([]*models.Reservation, error) {
+	reservations, err := s.reservationRepo.GetActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active reservations: %w", err)
+	}
+
+	positions := make(map[int]int)
+	for _, reservation := range reservations {
+		positions[reservation.GameID]++
+		reservation.Position = positions[reservation.GameID]
+	}
+
+	return reservations, nil
+}
+
+// HoldReturnedCopy hands a returned copy to the first waiting user in the
+// game's queue. It reports whether the copy is now held; when it is, the copy
+// must stay unavailable to other borrowers.
+func (s *ReservationService) HoldReturnedCopy(ctx context.Context, gameCopy *models.GameCopy) (bool, error) {
+	queue, err := s.reservationRepo.GetActiveByGame(ctx, gameCopy.GameID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get reservation queue: %w", err)
+	}
+
+	var next *models.Reservation
+	for _, reservation := range queue {
+		if reservation.Status == models.ReservationWaiting {
+			next = reservation
+			break
+		}
+	}
+	if next == nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(s.holdDays) * 24 * time.Hour)
+	copyID := gameCopy.ID
+
+	next.Status = models.ReservationReady
+	next.CopyID = &copyID
+	next.NotifiedAt = &now
+	next.ExpiresAt = &expiresAt
+
+	if err := s.reservationRepo.Update(ctx, next); err != nil {
+		return false, fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	if err := s.notifyHoldReady(ctx, next); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// ClaimHold returns the copy held for a user's ready reservation on a game,
+// or nil when nothing is held for them
+func (s *ReservationService) ClaimHold(ctx context.Context, userID, gameID int) (*models.GameCopy, error) {
+	queue, err := s.reservationRepo.GetActiveByGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation queue: %w", err)
+	}
+
+	for _, reservation := range queue {
+		if reservation.UserID == userID && reservation.Status == models.ReservationReady && reservation.CopyID != nil {
+			gameCopy, err := s.gameRepo.GetCopyByID(ctx, *reservation.CopyID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get held copy: %w", err)
+			}
+			return gameCopy, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FulfilHold closes a user's active reservation on a game once they borrowed it
+func (s *ReservationService) FulfilHold(ctx context.Context, userID, gameID int) error {
+	queue, err := s.reservationRepo.GetActiveByGame(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to get reservation queue: %w", err)
+	}
+
+	for _, reservation := range queue {
+		if reservation.UserID == userID {
+			now := time.Now()
+			reservation.Status = models.ReservationFulfilled
+			reservation.ClosedAt = &now
+			if err := s.reservationRepo.Update(ctx, reservation); err != nil {
+				return fmt.Errorf("failed to update reservation: %w", err)
+			}
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// ExpireHolds expires the ready reservations that were not collected in time
+// and passes their copies on. It returns the number of expired holds.
+func (s *ReservationService) ExpireHolds(ctx context.Context) (int, error) {
+	expired, err := s.reservationRepo.GetExpiredHolds(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired holds: %w", err)
+	}
+
+	for _, reservation := range expired {
+		before := *reservation
+		if err := s.close(ctx, reservation, models.ReservationExpired); err != nil {
+			return 0, fmt.Errorf("failed to expire reservation %d: %w", reservation.ID, err)
+		}
+		if err := s.record(ctx, models.AuditActionExpire, models.AuditEntityReservation, reservation.ID, &before, reservation); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(expired), nil
+}
+
+// close ends a reservation with the given status and releases its held copy
+func (s *ReservationService) close(ctx context.Context, reservation *models.Reservation, status string) error {
+	heldCopyID := reservation.CopyID
+	wasReady := reservation.Status == models.ReservationReady
+
+	now := time.Now()
+	reservation.Status = status
+	reservation.ClosedAt = &now
+
+	if err := s.reservationRepo.Update(ctx, reservation); err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+
+	if !wasReady || heldCopyID == nil {
+		return nil
+	}
+
+	gameCopy, err := s.gameRepo.GetCopyByID(ctx, *heldCopyID)
+	if err != nil {
+		return fmt.Errorf("failed to get held copy: %w", err)
+	}
+
+	return s.releaseCopy(ctx, gameCopy)
+}
+
+// releaseCopy passes a copy that is no longer held to the next user in line,
+// or puts it back on the shelf when the queue is empty. A copy found in need
+// of repair while held is kept from lending instead.
+func (s *ReservationService) releaseCopy(ctx context.Context, gameCopy *models.GameCopy) error {
+	if models.NeedsRepair(gameCopy.Condition) {
+		gameCopy.InRepair = true
+	} else {
+		held, err := s.HoldReturnedCopy(ctx, gameCopy)
+		if err != nil {
+			return err
+		}
+		if held {
+			return nil
+		}
+		gameCopy.IsAvailable = true
+	}
+
+	if err := s.gameRepo.UpdateCopy(ctx, gameCopy); err != nil {
+		return fmt.Errorf("failed to update copy availability: %w", err)
+	}
+
+	return nil
+}
+
+// notifyHoldReady creates the alert telling a user their hold can be collected
+func (s *ReservationService) notifyHoldReady(ctx context.Context, reservation *models.Reservation) error {
+	game, err := s.gameRepo.GetByID(ctx, reservation.GameID)
+	if err != nil {
+		return fmt.Errorf("failed to get game details for alert: %w", err)
+	}
+
+	message := fmt.Sprintf("Game '%s' is on hold for you. Please pick it up before %s.",
+		game.Name, reservation.ExpiresAt.Format("2006-01-02"))
+
+	alert := &models.Alert{
+		UserID:    reservation.UserID,
+		GameID:    reservation.GameID,
+		Type:      "hold",
+		Message:   message,
+		CreatedAt: time.Now(),
+		IsRead:    false,
+	}
+
+	if err := s.alertRepo.Create(ctx, alert); err != nil {
+		return fmt.Errorf("failed to create hold alert: %w", err)
+	}
+
+	return nil
+}
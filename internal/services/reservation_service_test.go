@@ -0,0 +1,372 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReservationRepository is a mock implementation of ReservationRepository
+type MockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReservationRepository) Create(ctx context.Context, reservation *models.Reservation) error {
+	args := m.Called(reservation)
+	if args.Error(0) == nil {
+		reservation.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *MockReservationRepository) GetByID(ctx context.Context, id int) (*models.Reservation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationRepository) GetActiveByGame(ctx context.Context, gameID int) ([]*models.Reservation, error) {
+	args := m.Called(gameID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationRepository) GetByUser(ctx context.Context, userID int) ([]*models.Reservation, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationRepository) GetActive(ctx context.Context) ([]*models.Reservation, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationRepository) GetExpiredHolds(ctx context.Context, now time.Time) ([]*models.Reservation, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Reservation), args.Error(1)
+}
+
+func (m *MockReservationRepository) Update(ctx context.Context, reservation *models.Reservation) error {
+	args := m.Called(reservation)
+	return args.Error(0)
+}
+
+type reservationMocks struct {
+	reservationRepo *MockReservationRepository
+	userRepo        *MockUserRepository
+	gameRepo        *MockGameRepository
+	borrowingRepo   *MockBorrowingRepository
+	alertRepo       *MockAlertRepository
+}
+
+func newReservationTestService(holdDays int) (*ReservationService, reservationMocks) {
+	mocks := reservationMocks{
+		reservationRepo: &MockReservationRepository{},
+		userRepo:        &MockUserRepository{},
+		gameRepo:        &MockGameRepository{},
+		borrowingRepo:   &MockBorrowingRepository{},
+		alertRepo:       &MockAlertRepository{},
+	}
+	service := NewReservationService(mocks.reservationRepo, mocks.userRepo, mocks.gameRepo,
+		mocks.borrowingRepo, mocks.alertRepo, holdDays)
+	return service, mocks
+}
+
+func TestNewReservationService(t *testing.T) {
+	service, _ := newReservationTestService(0)
+	assert.Equal(t, DefaultHoldDays, service.HoldDays())
+
+	service, _ = newReservationTestService(5)
+	assert.Equal(t, 5, service.HoldDays())
+}
+
+func TestReservationService_PlaceHold(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name             string
+		userID           int
+		gameID           int
+		setupMocks       func(reservationMocks)
+		expectedError    string
+		expectedPosition int
+	}{
+		{
+			name:   "joins the end of the queue",
+			userID: 3,
+			gameID: 1,
+			setupMocks: func(m reservationMocks) {
+				m.userRepo.On("GetByID", 3).Return(&models.User{ID: 3, IsActive: true}, nil)
+				m.gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, Name: "Catan", IsAvailable: false}, nil)
+				m.borrowingRepo.On("GetActiveByUser", 3).Return([]*models.Borrowing{}, nil)
+				m.reservationRepo.On("GetActiveByGame", 1).Return([]*models.Reservation{
+					{ID: 7, UserID: 2, GameID: 1, Status: models.ReservationWaiting},
+				}, nil)
+				m.reservationRepo.On("Create", mock.MatchedBy(func(r *models.Reservation) bool {
+					return r.UserID == 3 && r.GameID == 1 && r.Status == models.ReservationWaiting
+				})).Return(nil)
+			},
+			expectedPosition: 2,
+		},
+		{
+			name:          "invalid user ID",
+			userID:        0,
+			gameID:        1,
+			setupMocks:    func(m reservationMocks) {},
+			expectedError: "invalid user ID",
+		},
+		{
+			name:   "inactive user",
+			userID: 3,
+			gameID: 1,
+			setupMocks: func(m reservationMocks) {
+				m.userRepo.On("GetByID", 3).Return(&models.User{ID: 3, IsActive: false}, nil)
+			},
+			expectedError: "user account is inactive",
+		},
+		{
+			name:   "game not found",
+			userID: 3,
+			gameID: 99,
+			setupMocks: func(m reservationMocks) {
+				m.userRepo.On("GetByID", 3).Return(&models.User{ID: 3, IsActive: true}, nil)
+				m.gameRepo.On("GetByID", 99).Return(nil, errors.New("game with id 99 not found"))
+			},
+			expectedError: "game not found",
+		},
+		{
+			name:   "game available",
+			userID: 3,
+			gameID: 1,
+			setupMocks: func(m reservationMocks) {
+				m.userRepo.On("GetByID", 3).Return(&models.User{ID: 3, IsActive: true}, nil)
+				m.gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, IsAvailable: true}, nil)
+			},
+			expectedError: "game is available for borrowing, no hold needed",
+		},
+		{
+			name:   "already borrowing the game",
+			userID: 3,
+			gameID: 1,
+			setupMocks: func(m reservationMocks) {
+				m.userRepo.On("GetByID", 3).Return(&models.User{ID: 3, IsActive: true}, nil)
+				m.gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, IsAvailable: false}, nil)
+				m.borrowingRepo.On("GetActiveByUser", 3).Return([]*models.Borrowing{
+					{ID: 4, UserID: 3, GameID: 1, DueDate: time.Now().Add(24 * time.Hour)},
+				}, nil)
+			},
+			expectedError: "user is already borrowing this game",
+		},
+		{
+			name:   "duplicate hold",
+			userID: 3,
+			gameID: 1,
+			setupMocks: func(m reservationMocks) {
+				m.userRepo.On("GetByID", 3).Return(&models.User{ID: 3, IsActive: true}, nil)
+				m.gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, IsAvailable: false}, nil)
+				m.borrowingRepo.On("GetActiveByUser", 3).Return([]*models.Borrowing{}, nil)
+				m.reservationRepo.On("GetActiveByGame", 1).Return([]*models.Reservation{
+					{ID: 7, UserID: 3, GameID: 1, Status: models.ReservationWaiting},
+				}, nil)
+			},
+			expectedError: "user already has an active hold on this game",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mocks := newReservationTestService(3)
+			tt.setupMocks(mocks)
+
+			reservation, err := service.PlaceHold(ctx, tt.userID, tt.gameID)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, reservation)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, reservation)
+				assert.Equal(t, tt.expectedPosition, reservation.Position)
+			}
+
+			mocks.reservationRepo.AssertExpectations(t)
+			mocks.userRepo.AssertExpectations(t)
+			mocks.gameRepo.AssertExpectations(t)
+			mocks.borrowingRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReservationService_HoldReturnedCopy(t *testing.T) {
+	ctx := context.Background()
+	t.Run("holds the copy for the first waiting user", func(t *testing.T) {
+		service, mocks := newReservationTestService(3)
+		gameCopy := &models.GameCopy{ID: 10, GameID: 1, IsAvailable: false}
+
+		mocks.reservationRepo.On("GetActiveByGame", 1).Return([]*models.Reservation{
+			{ID: 5, UserID: 2, GameID: 1, Status: models.ReservationWaiting, CreatedAt: time.Now().Add(-48 * time.Hour)},
+			{ID: 6, UserID: 3, GameID: 1, Status: models.ReservationWaiting, CreatedAt: time.Now().Add(-24 * time.Hour)},
+		}, nil)
+		mocks.reservationRepo.On("Update", mock.MatchedBy(func(r *models.Reservation) bool {
+			return r.ID == 5 && r.Status == models.ReservationReady && r.CopyID != nil && *r.CopyID == 10 &&
+				r.ExpiresAt != nil && r.ExpiresAt.Sub(*r.NotifiedAt) == 3*24*time.Hour
+		})).Return(nil)
+		mocks.gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, Name: "Catan"}, nil)
+		mocks.alertRepo.On("Create", mock.MatchedBy(func(a *models.Alert) bool {
+			return a.UserID == 2 && a.GameID == 1 && a.Type == "hold" && !a.IsRead
+		})).Return(nil)
+
+		held, err := service.HoldReturnedCopy(ctx, gameCopy)
+
+		assert.NoError(t, err)
+		assert.True(t, held)
+		mocks.reservationRepo.AssertExpectations(t)
+		mocks.alertRepo.AssertExpectations(t)
+	})
+
+	t.Run("nobody waiting", func(t *testing.T) {
+		service, mocks := newReservationTestService(3)
+		gameCopy := &models.GameCopy{ID: 10, GameID: 1, IsAvailable: false}
+
+		mocks.reservationRepo.On("GetActiveByGame", 1).Return([]*models.Reservation{}, nil)
+
+		held, err := service.HoldReturnedCopy(ctx, gameCopy)
+
+		assert.NoError(t, err)
+		assert.False(t, held)
+		mocks.reservationRepo.AssertNotCalled(t, "Update", mock.Anything)
+		mocks.alertRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestReservationService_CancelHold(t *testing.T) {
+	ctx := context.Background()
+	t.Run("cancelling a waiting hold", func(t *testing.T) {
+		service, mocks := newReservationTestService(3)
+
+		mocks.reservationRepo.On("GetByID", 5).Return(&models.Reservation{ID: 5, UserID: 2, GameID: 1, Status: models.ReservationWaiting}, nil)
+		mocks.reservationRepo.On("Update", mock.MatchedBy(func(r *models.Reservation) bool {
+			return r.ID == 5 && r.Status == models.ReservationCancelled && r.ClosedAt != nil
+		})).Return(nil)
+
+		err := service.CancelHold(ctx, 5)
+
+		assert.NoError(t, err)
+		mocks.reservationRepo.AssertExpectations(t)
+		mocks.gameRepo.AssertNotCalled(t, "UpdateCopy", mock.Anything)
+	})
+
+	t.Run("cancelling a ready hold frees the copy when nobody waits", func(t *testing.T) {
+		service, mocks := newReservationTestService(3)
+		copyID := 10
+		expiresAt := time.Now().Add(48 * time.Hour)
+
+		mocks.reservationRepo.On("GetByID", 5).Return(&models.Reservation{
+			ID: 5, UserID: 2, GameID: 1, CopyID: &copyID, Status: models.ReservationReady, ExpiresAt: &expiresAt,
+		}, nil)
+		mocks.reservationRepo.On("Update", mock.MatchedBy(func(r *models.Reservation) bool {
+			return r.ID == 5 && r.Status == models.ReservationCancelled
+		})).Return(nil)
+		mocks.gameRepo.On("GetCopyByID", 10).Return(&models.GameCopy{ID: 10, GameID: 1, IsAvailable: false}, nil)
+		mocks.reservationRepo.On("GetActiveByGame", 1).Return([]*models.Reservation{}, nil)
+		mocks.gameRepo.On("UpdateCopy", mock.MatchedBy(func(c *models.GameCopy) bool {
+			return c.ID == 10 && c.IsAvailable
+		})).Return(nil)
+
+		err := service.CancelHold(ctx, 5)
+
+		assert.NoError(t, err)
+		mocks.reservationRepo.AssertExpectations(t)
+		mocks.gameRepo.AssertExpectations(t)
+	})
+
+	t.Run("closed reservation", func(t *testing.T) {
+		service, mocks := newReservationTestService(3)
+
+		mocks.reservationRepo.On("GetByID", 5).Return(&models.Reservation{ID: 5, Status: models.ReservationFulfilled}, nil)
+
+		err := service.CancelHold(ctx, 5)
+
+		assert.EqualError(t, err, "reservation is not active")
+	})
+
+	t.Run("reservation not found", func(t *testing.T) {
+		service, mocks := newReservationTestService(3)
+
+		mocks.reservationRepo.On("GetByID", 99).Return(nil, errors.New("reservation with id 99 not found"))
+
+		err := service.CancelHold(ctx, 99)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "reservation not found")
+	})
+}
+
+func TestReservationService_ExpireHolds(t *testing.T) {
+	ctx := context.Background()
+	service, mocks := newReservationTestService(3)
+	copyID := 10
+	expiresAt := time.Now().Add(-time.Hour)
+
+	mocks.reservationRepo.On("GetExpiredHolds", mock.AnythingOfType("time.Time")).Return([]*models.Reservation{
+		{ID: 5, UserID: 2, GameID: 1, CopyID: &copyID, Status: models.ReservationReady, ExpiresAt: &expiresAt},
+	}, nil)
+	mocks.reservationRepo.On("Update", mock.MatchedBy(func(r *models.Reservation) bool {
+		return r.ID == 5 && r.Status == models.ReservationExpired && r.ClosedAt != nil
+	})).Return(nil)
+	mocks.gameRepo.On("GetCopyByID", 10).Return(&models.GameCopy{ID: 10, GameID: 1, IsAvailable: false}, nil)
+
+	// The copy passes to the next user in line
+	mocks.reservationRepo.On("GetActiveByGame", 1).Return([]*models.Reservation{
+		{ID: 6, UserID: 3, GameID: 1, Status: models.ReservationWaiting},
+	}, nil)
+	mocks.reservationRepo.On("Update", mock.MatchedBy(func(r *models.Reservation) bool {
+		return r.ID == 6 && r.Status == models.ReservationReady && r.CopyID != nil && *r.CopyID == 10
+	})).Return(nil)
+	mocks.gameRepo.On("GetByID", 1).Return(&models.Game{ID: 1, Name: "Catan"}, nil)
+	mocks.alertRepo.On("Create", mock.MatchedBy(func(a *models.Alert) bool {
+		return a.UserID == 3 && a.Type == "hold"
+	})).Return(nil)
+
+	expired, err := service.ExpireHolds(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	mocks.reservationRepo.AssertExpectations(t)
+	mocks.alertRepo.AssertExpectations(t)
+	mocks.gameRepo.AssertNotCalled(t, "UpdateCopy", mock.Anything)
+}
+
+func TestReservationService_GetActiveReservationsPositions(t *testing.T) {
+	ctx := context.Background()
+	service, mocks := newReservationTestService(3)
+
+	mocks.reservationRepo.On("GetActive").Return([]*models.Reservation{
+		{ID: 1, GameID: 1, Status: models.ReservationReady},
+		{ID: 2, GameID: 1, Status: models.ReservationWaiting},
+		{ID: 3, GameID: 2, Status: models.ReservationWaiting},
+	}, nil)
+
+	reservations, err := service.GetActiveReservations(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 1}, []int{reservations[0].Position, reservations[1].Position, reservations[2].Position})
+}
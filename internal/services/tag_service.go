@@ -0,0 +1,154 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TagService handles the tags that label games
+type TagService struct {
+	tagRepo repositories.TagRepository
+	auditTrail
+}
+
+// NewTagService creates a new TagService instance
+func NewTagService(tagRepo repositories.TagRepository) *TagService {
+	return &TagService{
+		tagRepo: tagRepo,
+	}
+}
+
+// WithActor returns a copy of the service that records actor as the author
+// of its changes in the audit log
+func (s *TagService) WithActor(actor *models.User) *TagService {
+	bound := *s
+	bound.actor = actor
+	return &bound
+}
+
+// ListTags returns at most limit tags matching search, as typed in a tag
+// input, or every tag by name when search is empty. The limit defaults to
+// models.DefaultTagLimit and is capped at models.MaxTagLimit.
+func (s *TagService) ListTags(ctx context.Context, search string, limit int) ([]*models.Tag, error) {
+	if limit <= 0 {
+		limit = models.DefaultTagLimit
+	}
+	if limit > models.MaxTagLimit {
+		limit = models.MaxTagLimit
+	}
+
+	tags, err := s.tagRepo.List(ctx, strings.TrimSpace(search), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// GetTag retrieves a tag by ID
+func (s *TagService) GetTag(ctx context.Context, id int) (*models.Tag, error) {
+	if id <= 0 {
+		return nil, models.Invalid("tag_id", "invalid_tag_id", id)
+	}
+
+	tag, err := s.tagRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// CreateTag adds a tag not used by any game yet
+func (s *TagService) CreateTag(ctx context.Context, name string) (*models.Tag, error) {
+	if err := models.ValidateTagName(name); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	name = strings.TrimSpace(name)
+	tag := &models.Tag{Name: name, Slug: models.TagSlug(name), CreatedAt: time.Now()}
+	if err := s.tagRepo.Create(ctx, tag); err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	if err := s.record(ctx, models.AuditActionCreate, models.AuditEntityTag, tag.ID, nil, tag); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// RenameTag renames a tag on every game it labels. Renaming a tag to the
+// name of another one fails; MergeTags combines them instead.
+func (s *TagService) RenameTag(ctx context.Context, id int, name string) (*models.Tag, error) {
+	if err := models.ValidateTagName(name); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	existing, err := s.GetTag(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	renamed := *existing
+	renamed.Name = strings.TrimSpace(name)
+	renamed.Slug = models.TagSlug(renamed.Name)
+	if err := s.tagRepo.Update(ctx, &renamed); err != nil {
+		return nil, fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	if err := s.record(ctx, models.AuditActionUpdate, models.AuditEntityTag, id, existing, &renamed); err != nil {
+		return nil, err
+	}
+
+	return &renamed, nil
+}
+
+// MergeTags moves every game of the source tag to the target tag and deletes
+// the source tag, returning the target tag
+func (s *TagService) MergeTags(ctx context.Context, sourceID, targetID int) (*models.Tag, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("validation failed: %w", models.Invalid("target_id", "tag_merged_into_itself"))
+	}
+
+	source, err := s.GetTag(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetTag(ctx, targetID); err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.Merge(ctx, sourceID, targetID); err != nil {
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	target, err := s.GetTag(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, models.AuditActionMerge, models.AuditEntityTag, sourceID, source, target); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+// DeleteTag removes a tag from every game and deletes it
+func (s *TagService) DeleteTag(ctx context.Context, id int) error {
+	existing, err := s.GetTag(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tagRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return s.record(ctx, models.AuditActionDelete, models.AuditEntityTag, id, existing, nil)
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTagRepository is a mock implementation of TagRepository
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) GetByID(ctx context.Context, id int) (*models.Tag, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetBySlug(ctx context.Context, slug string) (*models.Tag, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) List(ctx context.Context, search string, limit int) ([]*models.Tag, error) {
+	args := m.Called(search, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Merge(ctx context.Context, sourceID, targetID int) error {
+	args := m.Called(sourceID, targetID)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Delete(ctx context.Context, id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestTagService_ListTags(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockTagRepository{}
+	repo.On("List", "coop", models.DefaultTagLimit).Return([]*models.Tag{{ID: 1, Name: "Coopératif"}}, nil)
+	repo.On("List", "", models.MaxTagLimit).Return([]*models.Tag{}, nil)
+
+	service := NewTagService(repo)
+	tags, err := service.ListTags(ctx, " coop ", 0)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 1)
+
+	_, err = service.ListTags(ctx, "", models.MaxTagLimit+1)
+	assert.NoError(t, err)
+
+	repo.AssertExpectations(t)
+}
+
+func TestTagService_CreateTag(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		input         string
+		setupMocks    func(*MockTagRepository)
+		expectedError string
+	}{
+		{
+			name:  "successful creation",
+			input: " Coopératif ",
+			setupMocks: func(repo *MockTagRepository) {
+				repo.On("Create", mock.MatchedBy(func(tag *models.Tag) bool {
+					return tag.Name == "Coopératif" && tag.Slug == "cooperatif" && !tag.CreatedAt.IsZero()
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "blank name",
+			input:         "  ",
+			setupMocks:    func(repo *MockTagRepository) {},
+			expectedError: "validation failed: tag name is required",
+		},
+		{
+			name:  "duplicate slug",
+			input: "cooperatif",
+			setupMocks: func(repo *MockTagRepository) {
+				repo.On("Create", mock.AnythingOfType("*models.Tag")).Return(errors.New(`tag "cooperatif" already exists`))
+			},
+			expectedError: "already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockTagRepository{}
+			tt.setupMocks(repo)
+
+			tag, err := NewTagService(repo).CreateTag(ctx, tt.input)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, tag)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Coopératif", tag.Name)
+			}
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTagService_RenameTag(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockTagRepository{}
+	repo.On("GetByID", 1).Return(&models.Tag{ID: 1, Name: "Coop", Slug: "coop", GameCount: 3}, nil)
+	repo.On("Update", mock.MatchedBy(func(tag *models.Tag) bool {
+		return tag.ID == 1 && tag.Name == "Coopératif" && tag.Slug == "cooperatif"
+	})).Return(nil)
+	repo.On("GetByID", 9).Return(nil, errors.New("tag with id 9 not found"))
+
+	service := NewTagService(repo)
+	tag, err := service.RenameTag(ctx, 1, "Coopératif")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, tag.GameCount)
+
+	_, err = service.RenameTag(ctx, 9, "Coopératif")
+	assert.ErrorContains(t, err, "not found")
+
+	_, err = service.RenameTag(ctx, 1, "")
+	assert.ErrorContains(t, err, "validation failed")
+
+	repo.AssertExpectations(t)
+}
+
+func TestTagService_MergeTags(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockTagRepository{}
+	repo.On("GetByID", 1).Return(&models.Tag{ID: 1, Name: "Coop", Slug: "coop", GameCount: 1}, nil)
+	repo.On("GetByID", 2).Return(&models.Tag{ID: 2, Name: "Coopératif", Slug: "cooperatif", GameCount: 2}, nil).Once()
+	repo.On("Merge", 1, 2).Return(nil)
+	repo.On("GetByID", 2).Return(&models.Tag{ID: 2, Name: "Coopératif", Slug: "cooperatif", GameCount: 3}, nil).Once()
+
+	service := NewTagService(repo)
+	target, err := service.MergeTags(ctx, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, target.GameCount)
+
+	_, err = service.MergeTags(ctx, 2, 2)
+	assert.ErrorContains(t, err, "cannot merge a tag into itself")
+
+	repo.AssertExpectations(t)
+}
+
+func TestTagService_DeleteTag(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockTagRepository{}
+	repo.On("GetByID", 1).Return(&models.Tag{ID: 1, Name: "Coop", Slug: "coop"}, nil)
+	repo.On("Delete", 1).Return(nil)
+
+	service := NewTagService(repo)
+	assert.NoError(t, service.DeleteTag(ctx, 1))
+	assert.ErrorContains(t, service.DeleteTag(ctx, 0), "invalid tag ID")
+
+	repo.AssertExpectations(t)
+}
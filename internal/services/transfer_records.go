@@ -0,0 +1,479 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Bulk import and export files hold one record per row. A CSV file has a
+// header naming its columns; a JSON file is an array of objects whose keys
+// are the same column names. Lists such as tags are comma-separated in CSV.
+
+// columnKind is the type of the values of a column
+type columnKind int
+
+const (
+	columnText columnKind = iota
+	columnInteger
+	columnNumber
+	columnBoolean
+	columnList
+	columnTime
+)
+
+// transferColumn describes a column of a bulk import or export file
+type transferColumn struct {
+	name string
+	kind columnKind
+}
+
+// gameRecord is a row of a games file. ID and TotalCopies are exported only.
+// Availability is not part of it: it follows from the current borrowings.
+type gameRecord struct {
+	ID          int         `json:"id,omitempty"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Condition   string      `json:"condition"`
+	EntryDate   *importTime `json:"entry_date"`
+	Tags        []string    `json:"tags"`
+	models.GameDetails
+	TotalCopies int `json:"total_copies,omitempty"`
+}
+
+var gameTransferColumns = []transferColumn{
+	{"id", columnInteger}, {"name", columnText}, {"description", columnText},
+	{"condition", columnText}, {"entry_date", columnTime},
+	{"tags", columnList}, {"min_players", columnInteger}, {"max_players", columnInteger},
+	{"play_time", columnInteger}, {"min_age", columnInteger}, {"publisher", columnText},
+	{"designers", columnList}, {"year_published", columnInteger}, {"complexity", columnNumber},
+	{"image_url", columnText}, {"bgg_id", columnInteger}, {"total_copies", columnInteger},
+}
+
+// userRecord is a row of a users file. ID is exported only.
+type userRecord struct {
+	ID             int         `json:"id,omitempty"`
+	Name           string      `json:"name"`
+	Email          string      `json:"email"`
+	RegisteredAt   *importTime `json:"registered_at"`
+	IsActive       *bool       `json:"is_active"`
+	MembershipTier string      `json:"membership_tier"`
+	Role           string      `json:"role"`
+}
+
+var userTransferColumns = []transferColumn{
+	{"id", columnInteger}, {"name", columnText}, {"email", columnText},
+	{"registered_at", columnTime}, {"is_active", columnBoolean},
+	{"membership_tier", columnText}, {"role", columnText},
+}
+
+// borrowingRecord is a row of a borrowings file. The borrower is found by
+// email, or by ID when no email is given. ID and GameName are exported only.
+type borrowingRecord struct {
+	ID             int         `json:"id,omitempty"`
+	UserID         int         `json:"user_id"`
+	UserEmail      string      `json:"user_email"`
+	GameID         int         `json:"game_id"`
+	GameName       string      `json:"game_name,omitempty"`
+	CopyID         *int        `json:"copy_id"`
+	BorrowedAt     *importTime `json:"borrowed_at"`
+	DueDate        *importTime `json:"due_date"`
+	ReturnedAt     *importTime `json:"returned_at"`
+	ExtensionCount int         `json:"extension_count"`
+}
+
+var borrowingTransferColumns = []transferColumn{
+	{"id", columnInteger}, {"user_id", columnInteger}, {"user_email", columnText},
+	{"game_id", columnInteger}, {"game_name", columnText}, {"copy_id", columnInteger},
+	{"borrowed_at", columnTime}, {"due_date", columnTime}, {"returned_at", columnTime},
+	{"extension_count", columnInteger},
+}
+
+// transferColumns returns the columns of a table and a new empty record
+func transferColumns(table string) ([]transferColumn, func() any) {
+	switch table {
+	case models.TransferTableGames:
+		return gameTransferColumns, func() any { return &gameRecord{} }
+	case models.TransferTableUsers:
+		return userTransferColumns, func() any { return &userRecord{} }
+	default:
+		return borrowingTransferColumns, func() any { return &borrowingRecord{} }
+	}
+}
+
+// importTime is a timestamp of an import file: RFC 3339, or a date and an
+// optional time of day in the server's time zone, as spreadsheets write them
+type importTime struct {
+	time.Time
+}
+
+// importTimeLayouts are the accepted formats of importTime, besides RFC 3339
+var importTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// parseImportTime parses a timestamp of an import file
+func parseImportTime(text string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, text); err == nil {
+		return parsed, nil
+	}
+	for _, layout := range importTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, text, time.Local); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or RFC 3339", text)
+}
+
+// UnmarshalJSON reads a timestamp written as a string
+func (t *importTime) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("invalid date %s, expected a string", data)
+	}
+
+	parsed, err := parseImportTime(text)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// timeOrNow returns the time of t, or now when t is missing
+func timeOrNow(t *importTime) time.Time {
+	if t == nil || t.IsZero() {
+		return time.Now()
+	}
+	return t.Time
+}
+
+// exportTime returns the importTime of a timestamp
+func exportTime(t *time.Time) *importTime {
+	if t == nil {
+		return nil
+	}
+	return &importTime{Time: *t}
+}
+
+// importRow is a decoded row of an import file
+type importRow struct {
+	line   int
+	record any
+	err    error // why the row could not be decoded
+}
+
+// decodeImport reads the rows of an import file. Rows whose values have the
+// wrong type are returned with an error; a file that cannot be read at all,
+// such as malformed CSV or JSON, fails the whole import.
+func decodeImport(table, format string, r io.Reader) ([]importRow, error) {
+	columns, newRecord := transferColumns(table)
+	if format == models.TransferFormatJSON {
+		return decodeJSONImport(r, newRecord)
+	}
+	return decodeCSVImport(r, columns, newRecord)
+}
+
+// decodeCSVImport reads the rows of a CSV import file
+func decodeCSVImport(r io.Reader, columns []transferColumn, newRecord func() any) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	kinds := make(map[string]columnKind, len(columns))
+	for _, column := range columns {
+		kinds[column.name] = column.kind
+	}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := kinds[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		header[i] = name
+	}
+	reader.FieldsPerRecord = len(header)
+
+	var rows []importRow
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := importRow{line: line, record: newRecord()}
+		row.err = decodeCSVRow(header, fields, kinds, row.record)
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// decodeCSVRow converts the text fields of a CSV row to typed values and
+// decodes them into record, as if they came from a JSON file
+func decodeCSVRow(header, fields []string, kinds map[string]columnKind, record any) error {
+	values := make(map[string]any, len(fields))
+	for i := range fields {
+		name, field := header[i], strings.TrimSpace(fields[i])
+		if field == "" {
+			continue
+		}
+
+		switch kinds[name] {
+		case columnInteger:
+			value, err := strconv.Atoi(field)
+			if err != nil {
+				return fmt.Errorf("%s must be an integer", name)
+			}
+			values[name] = value
+		case columnNumber:
+			value, err := strconv.ParseFloat(strings.Replace(field, ",", ".", 1), 64)
+			if err != nil {
+				return fmt.Errorf("%s must be a number", name)
+			}
+			values[name] = value
+		case columnBoolean:
+			value, err := parseImportBool(field)
+			if err != nil {
+				return fmt.Errorf("%s must be true or false", name)
+			}
+			values[name] = value
+		case columnList:
+			list := []string{}
+			for _, item := range strings.Split(field, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+			values[name] = list
+		case columnTime:
+			if _, err := parseImportTime(field); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			values[name] = field
+		default:
+			values[name] = field
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return decodeRecord(data, record)
+}
+
+// parseImportBool parses a boolean of a CSV file, in English or French
+func parseImportBool(field string) (bool, error) {
+	switch strings.ToLower(field) {
+	case "yes", "oui", "y", "o":
+		return true, nil
+	case "no", "non", "n":
+		return false, nil
+	}
+	return strconv.ParseBool(field)
+}
+
+// decodeJSONImport reads the rows of a JSON import file, an array of objects
+func decodeJSONImport(r io.Reader, newRecord func() any) ([]importRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the file: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the file is empty")
+	}
+	if delim, ok := token.(json.Delim); err != nil || !ok || delim != '[' {
+		return nil, fmt.Errorf("invalid JSON: expected an array of objects")
+	}
+
+	var rows []importRow
+	for decoder.More() {
+		line := lineAt(data, decoder.InputOffset())
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("invalid JSON at line %d: %w", line, err)
+		}
+
+		row := importRow{line: line, record: newRecord()}
+		row.err = decodeRecord(raw, row.record)
+		rows = append(rows, row)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return rows, nil
+}
+
+// lineAt returns the line of the first value at or after offset in data,
+// skipping the separators between array elements
+func lineAt(data []byte, offset int64) int {
+	for offset < int64(len(data)) && strings.ContainsRune(" \t\r\n,", rune(data[offset])) {
+		offset++
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// decodeRecord decodes a JSON object into record, rejecting unknown columns
+// and explaining values of the wrong type by their column
+func decodeRecord(data []byte, record any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(record)
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &typeErr):
+		return fmt.Errorf("%s must be %s", typeErr.Field, describeKind(typeErr.Type.String()))
+	case strings.HasPrefix(err.Error(), "json: unknown field"):
+		return fmt.Errorf("unknown column %s", strings.TrimPrefix(err.Error(), "json: unknown field "))
+	}
+	return fmt.Errorf("invalid row: %w", err)
+}
+
+// describeKind names a Go type for the error messages of decodeRecord
+func describeKind(goType string) string {
+	switch strings.TrimPrefix(goType, "*") {
+	case "int":
+		return "an integer"
+	case "float64":
+		return "a number"
+	case "bool":
+		return "true or false"
+	case "[]string":
+		return "a list of strings"
+	case "services.importTime":
+		return "a date"
+	case "services.gameRecord", "services.userRecord", "services.borrowingRecord":
+		return "an object"
+	}
+	return "a string"
+}
+
+// recordWriter writes the rows of an export file
+type recordWriter interface {
+	Write(record any) error
+	// Close ends the file; it does not close the underlying writer
+	Close() error
+}
+
+// newRecordWriter returns a writer of export files of the given format
+func newRecordWriter(format string, columns []transferColumn, w io.Writer) (recordWriter, error) {
+	if format == models.TransferFormatJSON {
+		return &jsonRecordWriter{w: w}, nil
+	}
+
+	writer := &csvRecordWriter{w: csv.NewWriter(w), columns: columns}
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	if err := writer.w.Write(header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// csvRecordWriter writes records as the rows of a CSV file
+type csvRecordWriter struct {
+	w       *csv.Writer
+	columns []transferColumn
+}
+
+// Write writes record as a CSV row
+func (c *csvRecordWriter) Write(record any) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var values map[string]any
+	if err := decoder.Decode(&values); err != nil {
+		return err
+	}
+
+	fields := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		switch value := values[column.name].(type) {
+		case nil:
+		case string:
+			fields[i] = value
+		case json.Number:
+			fields[i] = value.String()
+		case bool:
+			fields[i] = strconv.FormatBool(value)
+		case []any:
+			items := make([]string, len(value))
+			for j, item := range value {
+				items[j] = fmt.Sprint(item)
+			}
+			fields[i] = strings.Join(items, ", ")
+		}
+	}
+
+	return c.w.Write(fields)
+}
+
+// Close flushes the rows not yet written
+func (c *csvRecordWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonRecordWriter writes records as the elements of a JSON array, one per
+// line, so that the file can be written as rows are read
+type jsonRecordWriter struct {
+	w     io.Writer
+	count int
+}
+
+// Write writes record as the next element of the array
+func (j *jsonRecordWriter) Write(record any) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	prefix := ",\n"
+	if j.count == 0 {
+		prefix = "[\n"
+	}
+	j.count++
+
+	_, err = j.w.Write(append([]byte(prefix), data...))
+	return err
+}
+
+// Close ends the array
+func (j *jsonRecordWriter) Close() error {
+	end := "\n]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// TransferService imports and exports games, users and borrowings in bulk,
+// as CSV or JSON files
+type TransferService struct {
+	uow     repositories.UnitOfWork
+	exports repositories.ExportRepository
+	auditTrail
+}
+
+// NewTransferService creates a new TransferService instance
+func NewTransferService(uow repositories.UnitOfWork, exports repositories.ExportRepository) *TransferService {
+	return &TransferService{
+		uow:     uow,
+		exports: exports,
+	}
+}
+
+// WithActor returns a copy of the service that records actor as the author
+// of its changes in the audit log
+func (s *TransferService) WithActor(actor *models.User) *TransferService {
+	bound := *s
+	bound.actor = actor
+	return &bound
+}
+
+// errImportRolledBack ends the transaction of dry runs and rejected imports
+var errImportRolledBack = errors.New("import rolled back")
+
+// Import reads the rows of a CSV or JSON file and adds them to table. Each
+// row is validated and saved in a single transaction, which is only
+// committed when every row is valid and dryRun is false; otherwise the
+// result lists the errors of each rejected row and nothing is saved.
+func (s *TransferService) Import(ctx context.Context, table, format string, r io.Reader, dryRun bool) (*models.ImportResult, error) {
+	if err := models.ValidateTransfer(table, format); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	rows, err := decodeImport(table, format, r)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", models.Invalid("file", "unreadable_import_file", err).Wrap(err))
+	}
+
+	result := &models.ImportResult{
+		Table:  table,
+		Format: format,
+		DryRun: dryRun,
+		Rows:   len(rows),
+		Errors: []models.ImportRowError{},
+	}
+
+	err = s.uow.Do(ctx, func(store *repositories.Store) error {
+		tx := &transferImport{store: store, auditTrail: s.auditTrail.withStore(store)}
+		for _, row := range rows {
+			err := row.err
+			if err == nil {
+				err = tx.importRecord(ctx, row.record)
+			}
+			if err != nil {
+				result.Errors = append(result.Errors, models.ImportRowError{Line: row.line, Message: err.Error()})
+			}
+		}
+
+		if len(result.Errors) > 0 || dryRun {
+			return errImportRolledBack
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		return nil, fmt.Errorf("failed to import %s: %w", table, err)
+	}
+
+	if err == nil {
+		result.Imported = len(rows)
+		result.Committed = true
+	}
+
+	return result, nil
+}
+
+// Export writes every row of table to w as a CSV or JSON file that Import
+// reads back. Rows are written as they are read from the database.
+func (s *TransferService) Export(ctx context.Context, table, format string, w io.Writer) error {
+	if err := models.ValidateTransfer(table, format); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	columns, _ := transferColumns(table)
+	writer, err := newRecordWriter(format, columns, w)
+	if err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	switch table {
+	case models.TransferTableGames:
+		err = s.exports.EachGame(ctx, func(game *models.Game) error {
+			return writer.Write(gameToRecord(game))
+		})
+	case models.TransferTableUsers:
+		err = s.exports.EachUser(ctx, func(user *models.User) error {
+			return writer.Write(userToRecord(user))
+		})
+	default:
+		err = s.exports.EachBorrowing(ctx, func(borrowing *models.Borrowing, userEmail, gameName string) error {
+			return writer.Write(borrowingToRecord(borrowing, userEmail, gameName))
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return nil
+}
+
+// transferImport adds the rows of an import to repositories bound to its
+// transaction
+type transferImport struct {
+	store *repositories.Store
+	auditTrail
+}
+
+// importRecord validates and saves one row of an import
+func (t *transferImport) importRecord(ctx context.Context, record any) error {
+	switch record := record.(type) {
+	case *gameRecord:
+		return t.importGame(ctx, record)
+	case *userRecord:
+		return t.importUser(ctx, record)
+	case *borrowingRecord:
+		return t.importBorrowing(ctx, record)
+	}
+	return fmt.Errorf("unsupported record %T", record)
+}
+
+// importGame adds a game with one available copy
+func (t *transferImport) importGame(ctx context.Context, record *gameRecord) error {
+	game := &models.Game{
+		Name:        strings.TrimSpace(record.Name),
+		Description: strings.TrimSpace(record.Description),
+		Condition:   strings.TrimSpace(record.Condition),
+		EntryDate:   timeOrNow(record.EntryDate),
+		IsAvailable: true,
+		Tags:        models.NormalizeTags(record.Tags),
+		GameDetails: record.GameDetails,
+	}
+	if game.Designers == nil {
+		game.Designers = []string{}
+	}
+
+	if err := models.ValidateGame(game); err != nil {
+		return err
+	}
+
+	if err := t.store.Games.Create(ctx, game); err != nil {
+		return err
+	}
+
+	return t.record(ctx, models.AuditActionImport, models.AuditEntityGame, game.ID, nil, game)
+}
+
+// importUser adds a user. Users are active members of the default tier
+// unless the row says otherwise.
+func (t *transferImport) importUser(ctx context.Context, record *userRecord) error {
+	user := &models.User{
+		Name:           strings.TrimSpace(record.Name),
+		Email:          strings.TrimSpace(record.Email),
+		RegisteredAt:   timeOrNow(record.RegisteredAt),
+		IsActive:       record.IsActive == nil || *record.IsActive,
+		MembershipTier: strings.TrimSpace(record.MembershipTier),
+		Role:           strings.TrimSpace(record.Role),
+	}
+
+	if err := models.ValidateUser(user); err != nil {
+		return err
+	}
+
+	if existing, err := t.store.Users.GetByEmail(ctx, user.Email); err == nil && existing != nil {
+		return models.Conflict("user_email_taken", user.Email)
+	}
+
+	if user.MembershipTier != "" && user.MembershipTier != models.DefaultMembershipTier {
+		if _, err := t.store.LoanPolicies.GetByTier(ctx, user.MembershipTier); err != nil {
+			return models.Invalid("membership_tier", "unknown_membership_tier", user.MembershipTier)
+		}
+	}
+
+	if err := t.store.Users.Create(ctx, user); err != nil {
+		return err
+	}
+
+	return t.record(ctx, models.AuditActionImport, models.AuditEntityUser, user.ID, nil, user)
+}
+
+// importBorrowing adds a past or current borrowing. A current borrowing,
+// without a return date, lends the given copy or the first available one.
+func (t *transferImport) importBorrowing(ctx context.Context, record *borrowingRecord) error {
+	if record.BorrowedAt == nil {
+		return fmt.Errorf("borrowed_at is required")
+	}
+	if record.DueDate == nil {
+		return fmt.Errorf("due_date is required")
+	}
+	if record.ExtensionCount < 0 {
+		return fmt.Errorf("extension count cannot be negative")
+	}
+
+	borrowing := &models.Borrowing{
+		UserID:         record.UserID,
+		GameID:         record.GameID,
+		BorrowedAt:     record.BorrowedAt.Time,
+		DueDate:        record.DueDate.Time,
+		ExtensionCount: record.ExtensionCount,
+	}
+	if record.ReturnedAt != nil {
+		returnedAt := record.ReturnedAt.Time
+		borrowing.ReturnedAt = &returnedAt
+	}
+
+	if email := strings.TrimSpace(record.UserEmail); email != "" {
+		user, err := t.store.Users.GetByEmail(ctx, email)
+		if err != nil {
+			return fmt.Errorf("user not found: %w", err)
+		}
+		borrowing.UserID = user.ID
+	}
+
+	if err := models.ValidateBorrowing(borrowing); err != nil {
+		return err
+	}
+
+	if _, err := t.store.Users.GetByID(ctx, borrowing.UserID); err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+	if _, err := t.store.Games.GetByID(ctx, borrowing.GameID); err != nil {
+		return fmt.Errorf("game not found: %w", err)
+	}
+
+	gameCopy, err := t.borrowedCopy(ctx, borrowing, record.CopyID)
+	if err != nil {
+		return err
+	}
+	if gameCopy != nil {
+		borrowing.CopyID = &gameCopy.ID
+	}
+	borrowing.IsOverdue = borrowing.ReturnedAt == nil && time.Now().After(borrowing.DueDate)
+
+	if err := t.store.Borrowings.Create(ctx, borrowing); err != nil {
+		return err
+	}
+
+	if borrowing.ReturnedAt == nil {
+		gameCopy.IsAvailable = false
+		if err := t.store.Games.UpdateCopy(ctx, gameCopy); err != nil {
+			return err
+		}
+	}
+
+	return t.record(ctx, models.AuditActionImport, models.AuditEntityBorrowing, borrowing.ID, nil, borrowing)
+}
+
+// borrowedCopy returns the copy of an imported borrowing: the one the row
+// names, or for a current borrowing the first available copy of the game
+func (t *transferImport) borrowedCopy(ctx context.Context, borrowing *models.Borrowing, copyID *int) (*models.GameCopy, error) {
+	active := borrowing.ReturnedAt == nil
+
+	if copyID != nil {
+		gameCopy, err := t.store.Games.GetCopyByID(ctx, *copyID)
+		if err != nil {
+			return nil, fmt.Errorf("copy not found: %w", err)
+		}
+		if gameCopy.GameID != borrowing.GameID {
+			return nil, models.NotFound("copy_not_in_game", gameCopy.ID, borrowing.GameID)
+		}
+		if active && !gameCopy.IsAvailable {
+			return nil, fmt.Errorf("copy %d is already lent", gameCopy.ID)
+		}
+		return gameCopy, nil
+	}
+
+	if !active {
+		return nil, nil
+	}
+
+	copies, err := t.store.Games.GetCopies(ctx, borrowing.GameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get game copies: %w", err)
+	}
+	for _, gameCopy := range copies {
+		if gameCopy.IsAvailable {
+			return gameCopy, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no copy of game %d is available", borrowing.GameID)
+}
+
+// gameToRecord returns the export row of a game
+func gameToRecord(game *models.Game) *gameRecord {
+	return &gameRecord{
+		ID:          game.ID,
+		Name:        game.Name,
+		Description: game.Description,
+		Condition:   game.Condition,
+		EntryDate:   exportTime(&game.EntryDate),
+		Tags:        game.Tags,
+		GameDetails: game.GameDetails,
+		TotalCopies: game.TotalCopies,
+	}
+}
+
+// userToRecord returns the export row of a user
+func userToRecord(user *models.User) *userRecord {
+	return &userRecord{
+		ID:             user.ID,
+		Name:           user.Name,
+		Email:          user.Email,
+		RegisteredAt:   exportTime(&user.RegisteredAt),
+		IsActive:       &user.IsActive,
+		MembershipTier: user.MembershipTier,
+		Role:           user.Role,
+	}
+}
+
+// borrowingToRecord returns the export row of a borrowing
+func borrowingToRecord(borrowing *models.Borrowing, userEmail, gameName string) *borrowingRecord {
+	return &borrowingRecord{
+		ID:             borrowing.ID,
+		UserID:         borrowing.UserID,
+		UserEmail:      userEmail,
+		GameID:         borrowing.GameID,
+		GameName:       gameName,
+		CopyID:         borrowing.CopyID,
+		BorrowedAt:     exportTime(&borrowing.BorrowedAt),
+		DueDate:        exportTime(&borrowing.DueDate),
+		ReturnedAt:     exportTime(borrowing.ReturnedAt),
+		ExtensionCount: borrowing.ExtensionCount,
+	}
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExportRepository streams fixed rows
+type fakeExportRepository struct {
+	games      []*models.Game
+	users      []*models.User
+	borrowings []*models.Borrowing
+}
+
+func (f *fakeExportRepository) EachGame(ctx context.Context, fn func(game *models.Game) error) error {
+	for _, game := range f.games {
+		if err := fn(game); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeExportRepository) EachUser(ctx context.Context, fn func(user *models.User) error) error {
+	for _, user := range f.users {
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeExportRepository) EachBorrowing(ctx context.Context, fn func(borrowing *models.Borrowing, userEmail, gameName string) error) error {
+	for _, borrowing := range f.borrowings {
+		if err := fn(borrowing, "alice@example.com", "Azul"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestDecodeImport_CSV(t *testing.T) {
+	file := "\ufeffName,condition,tags,min_players,complexity,entry_date\n" +
+		"Azul,good,\"Abstrait, Famille\",2,\"1,8\",2023-05-01\n" +
+		"\"Multi\nline\",fair,,deux,,\n" +
+		"Skull,good,,,,hier\n"
+
+	rows, err := decodeImport(models.TransferTableGames, models.TransferFormatCSV, strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 2, rows[0].line)
+	require.NoError(t, rows[0].err)
+	game := rows[0].record.(*gameRecord)
+	assert.Equal(t, "Azul", game.Name)
+	assert.Equal(t, []string{"Abstrait", "Famille"}, game.Tags)
+	assert.Equal(t, 2, game.MinPlayers)
+	assert.Equal(t, 1.8, game.Complexity)
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.Local), game.EntryDate.Time)
+
+	assert.Equal(t, 3, rows[1].line)
+	assert.EqualError(t, rows[1].err, "min_players must be an integer")
+	assert.Equal(t, 5, rows[2].line)
+	assert.ErrorContains(t, rows[2].err, `entry_date: invalid date "hier"`)
+
+	_, err = decodeImport(models.TransferTableGames, models.TransferFormatCSV, strings.NewReader("name,colour\nAzul,blue\n"))
+	assert.EqualError(t, err, `unknown column "colour"`)
+
+	_, err = decodeImport(models.TransferTableUsers, models.TransferFormatCSV, strings.NewReader("name,email\nAlice\n"))
+	assert.ErrorContains(t, err, "invalid CSV")
+
+	_, err = decodeImport(models.TransferTableUsers, models.TransferFormatCSV, strings.NewReader(""))
+	assert.EqualError(t, err, "the file is empty")
+}
+
+func TestDecodeImport_JSON(t *testing.T) {
+	file := `[
+  {"name": "Alice", "email": "alice@example.com", "registered_at": "2024-01-15T10:00:00Z", "is_active": false},
+  {"name": "Bob", "email": "bob@example.com", "is_active": "yes"},
+  {"name": "Carol", "email": "carol@example.com", "nickname": "C"}
+]`
+
+	rows, err := decodeImport(models.TransferTableUsers, models.TransferFormatJSON, strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 2, rows[0].line)
+	require.NoError(t, rows[0].err)
+	user := rows[0].record.(*userRecord)
+	assert.Equal(t, "Alice", user.Name)
+	assert.False(t, *user.IsActive)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), user.RegisteredAt.UTC())
+
+	assert.Equal(t, 3, rows[1].line)
+	assert.EqualError(t, rows[1].err, "is_active must be true or false")
+	assert.Equal(t, 4, rows[2].line)
+	assert.EqualError(t, rows[2].err, `unknown column "nickname"`)
+
+	_, err = decodeImport(models.TransferTableUsers, models.TransferFormatJSON, strings.NewReader(`{"name": "Alice"}`))
+	assert.EqualError(t, err, "invalid JSON: expected an array of objects")
+
+	_, err = decodeImport(models.TransferTableUsers, models.TransferFormatJSON, strings.NewReader(`[{"name": "Alice"},`))
+	assert.ErrorContains(t, err, "invalid JSON")
+}
+
+func TestTransferService_Import(t *testing.T) {
+	ctx := context.Background()
+	newService := func() (*TransferService, *MockGameRepository, *fakeUnitOfWork) {
+		gameRepo := &MockGameRepository{}
+		uow := &fakeUnitOfWork{store: &repositories.Store{Games: gameRepo}}
+		return NewTransferService(uow, &fakeExportRepository{}), gameRepo, uow
+	}
+	file := "name,condition,tags\nAzul,good,Famille\nWingspan,good,\n"
+
+	t.Run("commits valid rows", func(t *testing.T) {
+		service, gameRepo, uow := newService()
+		gameRepo.On("Create", mock.AnythingOfType("*models.Game")).Return(nil).Twice()
+
+		result, err := service.Import(ctx, "games", "csv", strings.NewReader(file), false)
+		require.NoError(t, err)
+		assert.Equal(t, &models.ImportResult{
+			Table: "games", Format: "csv", Rows: 2, Imported: 2, Committed: true, Errors: []models.ImportRowError{},
+		}, result)
+		assert.True(t, uow.committed)
+		gameRepo.AssertExpectations(t)
+	})
+
+	t.Run("dry run rolls back", func(t *testing.T) {
+		service, gameRepo, uow := newService()
+		gameRepo.On("Create", mock.AnythingOfType("*models.Game")).Return(nil).Twice()
+
+		result, err := service.Import(ctx, "games", "csv", strings.NewReader(file), true)
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.False(t, result.Committed)
+		assert.Zero(t, result.Imported)
+		assert.Empty(t, result.Errors)
+		assert.False(t, uow.committed)
+	})
+
+	t.Run("invalid rows reject the whole file", func(t *testing.T) {
+		service, gameRepo, uow := newService()
+		gameRepo.On("Create", mock.AnythingOfType("*models.Game")).Return(nil).Once()
+		invalid := "name,condition,min_players\nAzul,good,2\n,good,\nSkull,mint,\nHive,good,x\n"
+
+		result, err := service.Import(ctx, "games", "csv", strings.NewReader(invalid), false)
+		require.NoError(t, err)
+		assert.False(t, result.Committed)
+		assert.Zero(t, result.Imported)
+		assert.Equal(t, []models.ImportRowError{
+			{Line: 3, Message: "game name is required"},
+			{Line: 4, Message: "invalid game condition: must be one of [excellent good fair poor missing_pieces]"},
+			{Line: 5, Message: "min_players must be an integer"},
+		}, result.Errors)
+		assert.False(t, uow.committed)
+		gameRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository errors are reported on their row", func(t *testing.T) {
+		service, gameRepo, _ := newService()
+		gameRepo.On("Create", mock.AnythingOfType("*models.Game")).Return(errors.New("database is locked")).Twice()
+
+		result, err := service.Import(ctx, "games", "csv", strings.NewReader(file), false)
+		require.NoError(t, err)
+		assert.Len(t, result.Errors, 2)
+		assert.Equal(t, "database is locked", result.Errors[0].Message)
+	})
+
+	t.Run("rejects unreadable files", func(t *testing.T) {
+		service, _, uow := newService()
+
+		_, err := service.Import(ctx, "games", "xml", strings.NewReader(file), false)
+		assert.ErrorContains(t, err, "validation failed: invalid format")
+
+		_, err = service.Import(ctx, "games", "json", strings.NewReader(file), false)
+		assert.ErrorIs(t, err, models.ErrValidation)
+		assert.ErrorContains(t, err, "invalid JSON")
+		assert.Zero(t, uow.calls)
+	})
+}
+
+func TestTransferService_ImportUsers(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &MockUserRepository{}
+	policyRepo := &MockLoanPolicyRepository{}
+	uow := &fakeUnitOfWork{store: &repositories.Store{Users: userRepo, LoanPolicies: policyRepo}}
+	service := NewTransferService(uow, &fakeExportRepository{})
+
+	userRepo.On("GetByEmail", "alice@example.com").Return(nil, errors.New("user not found"))
+	userRepo.On("GetByEmail", "bob@example.com").Return(&models.User{ID: 1, Email: "bob@example.com"}, nil)
+	userRepo.On("GetByEmail", "carol@example.com").Return(nil, errors.New("user not found"))
+	policyRepo.On("GetByTier", "premium").Return(&models.LoanPolicy{Tier: "premium"}, nil)
+	policyRepo.On("GetByTier", "gold").Return(nil, errors.New("loan policy not found"))
+	userRepo.On("Create", mock.MatchedBy(func(user *models.User) bool {
+		return user.Email == "alice@example.com" && user.IsActive && user.MembershipTier == "premium"
+	})).Return(nil)
+
+	file := "name,email,membership_tier\nAlice,alice@example.com,premium\nBob,bob@example.com,\nCarol,carol@example.com,gold\nD,d@example.com,\n"
+	result, err := service.Import(ctx, "users", "csv", strings.NewReader(file), false)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ImportRowError{
+		{Line: 3, Message: "user with email bob@example.com already exists"},
+		{Line: 4, Message: "unknown membership tier: gold"},
+		{Line: 5, Message: "name must be at least 2 characters long"},
+	}, result.Errors)
+	assert.False(t, uow.committed)
+	userRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestTransferService_Export(t *testing.T) {
+	ctx := context.Background()
+	entryDate := time.Date(2023, 5, 1, 9, 30, 0, 0, time.UTC)
+	exports := &fakeExportRepository{
+		games: []*models.Game{{
+			ID: 3, Name: "Azul", Condition: "good", IsAvailable: true, EntryDate: entryDate, TotalCopies: 2,
+			Tags:        []string{"Abstrait", "Famille"},
+			GameDetails: models.GameDetails{MinPlayers: 2, MaxPlayers: 4, Designers: []string{"Michael Kiesling"}, Complexity: 1.8},
+		}},
+		borrowings: []*models.Borrowing{{ID: 1, UserID: 2, GameID: 3, BorrowedAt: entryDate, DueDate: entryDate.AddDate(0, 0, 14)}},
+	}
+	service := NewTransferService(&fakeUnitOfWork{}, exports)
+
+	var csvFile bytes.Buffer
+	require.NoError(t, service.Export(ctx, "games", "csv", &csvFile))
+	assert.Equal(t, "id,name,description,condition,entry_date,tags,min_players,max_players,play_time,min_age,publisher,designers,year_published,complexity,image_url,bgg_id,total_copies\n"+
+		"3,Azul,,good,2023-05-01T09:30:00Z,\"Abstrait, Famille\",2,4,0,0,,Michael Kiesling,0,1.8,,0,2\n", csvFile.String())
+
+	rows, err := decodeImport("games", "csv", &csvFile)
+	require.NoError(t, err)
+	require.NoError(t, rows[0].err)
+	assert.Equal(t, []string{"Abstrait", "Famille"}, rows[0].record.(*gameRecord).Tags)
+
+	var jsonFile bytes.Buffer
+	require.NoError(t, service.Export(ctx, "borrowings", "json", &jsonFile))
+	assert.Equal(t, `[
+{"id":1,"user_id":2,"user_email":"alice@example.com","game_id":3,"game_name":"Azul","copy_id":null,"borrowed_at":"2023-05-01T09:30:00Z","due_date":"2023-05-15T09:30:00Z","returned_at":null,"extension_count":0}
+]
+`, jsonFile.String())
+
+	rows, err = decodeImport("borrowings", "json", &jsonFile)
+	require.NoError(t, err)
+	require.NoError(t, rows[0].err)
+	assert.Equal(t, "alice@example.com", rows[0].record.(*borrowingRecord).UserEmail)
+
+	var empty bytes.Buffer
+	require.NoError(t, service.Export(ctx, "users", "json", &empty))
+	assert.Equal(t, "[]\n", empty.String())
+
+	assert.ErrorContains(t, service.Export(ctx, "alerts", "csv", &empty), "validation failed")
+}
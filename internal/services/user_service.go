@@ -3,6 +3,8 @@ package services
 import (
 	"board-game-library/internal/models"
 	"board-game-library/internal/repositories"
+	"board-game-library/pkg/database"
+	"context"
 	"fmt"
 	"time"
 )
@@ -11,6 +13,10 @@ import (
 type UserService struct {
 	userRepo      repositories.UserRepository
 	borrowingRepo repositories.BorrowingRepository
+	policies      LoanPolicies
+	fines         FineLedger
+	uow           repositories.UnitOfWork
+	auditTrail
 }
 
 // NewUserService creates a new UserService instance
@@ -21,8 +27,71 @@ func NewUserService(userRepo repositories.UserRepository, borrowingRepo reposito
 	}
 }
 
+// WithActor returns a copy of the service that records actor as the author
+// of its changes in the audit log
+func (s *UserService) WithActor(actor *models.User) *UserService {
+	bound := *s
+	bound.actor = actor
+	return &bound
+}
+
+// SetLoanPolicies applies the limits of each user's membership tier when
+// checking eligibility. Without it every user gets models.DefaultLoanPolicy.
+func (s *UserService) SetLoanPolicies(policies LoanPolicies) {
+	s.policies = policies
+}
+
+// SetFines takes the fines users owe into account when checking eligibility
+// and reporting their balance. Without it users owe nothing.
+func (s *UserService) SetFines(fines FineLedger) {
+	s.fines = fines
+}
+
+// SetUnitOfWork makes updates and deletions check the remaining
+// administrators and write the user in a single transaction
+func (s *UserService) SetUnitOfWork(uow repositories.UnitOfWork) {
+	s.uow = uow
+}
+
+// atomically runs fn with a copy of the service whose repositories are bound
+// to a single unit of work
+func (s *UserService) atomically(ctx context.Context, fn func(tx *UserService) error) error {
+	if s.uow == nil {
+		return fn(s)
+	}
+
+	return s.uow.Do(ctx, func(store *repositories.Store) error {
+		bound := *s
+		bound.userRepo = store.Users
+		bound.borrowingRepo = store.Borrowings
+		bound.uow = nil
+		bound.auditTrail = s.auditTrail.withStore(store)
+		return fn(&bound)
+	})
+}
+
+// keepsAnAdministrator checks that taking user out of the active
+// administrators, by deactivating, deleting or demoting them, leaves at
+// least one: otherwise anyone could run the setup of the library again.
+func keepsAnAdministrator(ctx context.Context, users repositories.UserRepository, user *models.User) error {
+	if user.Role != models.RoleAdmin || !user.IsActive {
+		return nil
+	}
+
+	active := true
+	count, err := users.Count(ctx, models.UserFilter{Role: models.RoleAdmin, Active: &active})
+	if err != nil {
+		return fmt.Errorf("failed to count administrators: %w", err)
+	}
+	if count <= 1 {
+		return models.Conflict("last_administrator")
+	}
+
+	return nil
+}
+
 // RegisterUser creates a new user account
-func (s *UserService) RegisterUser(name, email string) (*models.User, error) {
+func (s *UserService) RegisterUser(ctx context.Context, name, email string) (*models.User, error) {
 	// Create user model
 	user := &models.User{
 		Name:         name,
@@ -37,26 +106,35 @@ func (s *UserService) RegisterUser(name, email string) (*models.User, error) {
 	}
 
 	// Check if email already exists
-	existingUser, err := s.userRepo.GetByEmail(email)
+	existingUser, err := s.userRepo.GetByEmail(ctx, email)
 	if err == nil && existingUser != nil {
-		return nil, fmt.Errorf("user with email %s already exists", email)
+		return nil, models.Conflict("user_email_taken", email)
 	}
 
 	// Create user in repository
-	if err := s.userRepo.Create(user); err != nil {
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		// Emails are unique across libraries, so the address may belong
+		// to a member of another library
+		if database.IsUniqueViolation(err) {
+			return nil, models.Conflict("user_email_taken", email)
+		}
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	if err := s.record(ctx, models.AuditActionCreate, models.AuditEntityUser, user.ID, nil, user); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
 // GetUser retrieves a user by ID
-func (s *UserService) GetUser(id int) (*models.User, error) {
+func (s *UserService) GetUser(ctx context.Context, id int) (*models.User, error) {
 	if id <= 0 {
-		return nil, fmt.Errorf("invalid user ID: %d", id)
+		return nil, models.Invalid("user_id", "invalid_user_id", id)
 	}
 
-	user, err := s.userRepo.GetByID(id)
+	user, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -65,8 +143,8 @@ func (s *UserService) GetUser(id int) (*models.User, error) {
 }
 
 // GetAllUsers retrieves all users
-func (s *UserService) GetAllUsers() ([]*models.User, error) {
-	users, err := s.userRepo.GetAll()
+func (s *UserService) GetAllUsers(ctx context.Context) ([]*models.User, error) {
+	users, err := s.userRepo.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
@@ -74,19 +152,43 @@ func (s *UserService) GetAllUsers() ([]*models.User, error) {
 	return users, nil
 }
 
+// ListUsers retrieves one page of the users matching filter along with the
+// number of matches across all pages
+func (s *UserService) ListUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, int, error) {
+	if err := models.ValidateUserFilter(&filter); err != nil {
+		return nil, 0, fmt.Errorf("invalid user filter: %w", err)
+	}
+
+	users, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	total, err := s.userRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	if users == nil {
+		users = []*models.User{}
+	}
+
+	return users, total, nil
+}
+
 // GetUserBorrowings retrieves borrowing history for a user
-func (s *UserService) GetUserBorrowings(userID int) ([]*models.Borrowing, error) {
+func (s *UserService) GetUserBorrowings(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	if userID <= 0 {
-		return nil, fmt.Errorf("invalid user ID: %d", userID)
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
 	}
 
 	// Verify user exists
-	_, err := s.userRepo.GetByID(userID)
+	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	borrowings, err := s.userRepo.GetBorrowingHistory(userID)
+	borrowings, err := s.userRepo.GetBorrowingHistory(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user borrowings: %w", err)
 	}
@@ -95,50 +197,71 @@ func (s *UserService) GetUserBorrowings(userID int) ([]*models.Borrowing, error)
 }
 
 // CanUserBorrow checks if a user is eligible to borrow games
-func (s *UserService) CanUserBorrow(userID int) (bool, error) {
+func (s *UserService) CanUserBorrow(ctx context.Context, userID int) (bool, error) {
+	eligibility, err := s.CheckEligibility(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := eligibility.Err(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CheckEligibility evaluates the user's loan policy and reports which rule,
+// if any, prevents them from borrowing another game
+func (s *UserService) CheckEligibility(ctx context.Context, userID int) (*models.BorrowEligibility, error) {
 	if userID <= 0 {
-		return false, fmt.Errorf("invalid user ID: %d", userID)
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
 	}
 
-	// Verify user exists and is active
-	user, err := s.userRepo.GetByID(userID)
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return false, fmt.Errorf("user not found: %w", err)
+		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	if !user.IsActive {
-		return false, fmt.Errorf("user account is inactive")
+	policy, err := policyFor(ctx, s.policies, user)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check for active borrowings
-	activeBorrowings, err := s.borrowingRepo.GetActiveByUser(userID)
+	activeBorrowings, err := s.borrowingRepo.GetActiveByUser(ctx, userID)
 	if err != nil {
-		return false, fmt.Errorf("failed to check active borrowings: %w", err)
+		return nil, fmt.Errorf("failed to check active borrowings: %w", err)
 	}
 
-	// Check if any active borrowings are overdue
-	for _, borrowing := range activeBorrowings {
-		if borrowing.IsCurrentlyOverdue() {
-			return false, fmt.Errorf("user has overdue items")
-		}
+	balance, err := fineBalance(ctx, s.fines, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	return true, nil
+	return policy.CheckBorrow(user, activeBorrowings, balance), nil
+}
+
+// GetFineBalance returns the amount, in cents, a user owes in fines
+func (s *UserService) GetFineBalance(ctx context.Context, userID int) (int, error) {
+	if userID <= 0 {
+		return 0, models.Invalid("user_id", "invalid_user_id", userID)
+	}
+
+	return fineBalance(ctx, s.fines, userID)
 }
 
 // GetActiveUserBorrowings retrieves current active borrowings for a user
-func (s *UserService) GetActiveUserBorrowings(userID int) ([]*models.Borrowing, error) {
+func (s *UserService) GetActiveUserBorrowings(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	if userID <= 0 {
-		return nil, fmt.Errorf("invalid user ID: %d", userID)
+		return nil, models.Invalid("user_id", "invalid_user_id", userID)
 	}
 
 	// Verify user exists
-	_, err := s.userRepo.GetByID(userID)
+	_, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
 
-	activeBorrowings, err := s.borrowingRepo.GetActiveByUser(userID)
+	activeBorrowings, err := s.borrowingRepo.GetActiveByUser(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active borrowings: %w", err)
 	}
@@ -147,13 +270,13 @@ func (s *UserService) GetActiveUserBorrowings(userID int) ([]*models.Borrowing,
 }
 
 // UpdateUser updates user information
-func (s *UserService) UpdateUser(user *models.User) error {
+func (s *UserService) UpdateUser(ctx context.Context, user *models.User) error {
 	if user == nil {
 		return fmt.Errorf("user cannot be nil")
 	}
 
 	if user.ID <= 0 {
-		return fmt.Errorf("invalid user ID: %d", user.ID)
+		return models.Invalid("user_id", "invalid_user_id", user.ID)
 	}
 
 	// Validate user data
@@ -161,46 +284,92 @@ func (s *UserService) UpdateUser(user *models.User) error {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	return s.atomically(ctx, func(tx *UserService) error {
+		return tx.updateUser(ctx, user)
+	})
+}
+
+// updateUser implements UpdateUser
+func (s *UserService) updateUser(ctx context.Context, user *models.User) error {
 	// Check if user exists
-	_, err := s.userRepo.GetByID(user.ID)
+	existing, err := s.userRepo.GetByID(ctx, user.ID)
 	if err != nil {
 		return fmt.Errorf("user not found: %w", err)
 	}
 
+	// Roles are only changed through AuthService.SetRole
+	user.Role = existing.Role
+
+	if !user.IsActive {
+		if err := keepsAnAdministrator(ctx, s.userRepo, existing); err != nil {
+			return err
+		}
+	}
+
+	// Keep the current tier unless a new one is given, and make sure it has a policy
+	if user.MembershipTier == "" {
+		user.MembershipTier = existing.MembershipTier
+	} else if user.MembershipTier != existing.MembershipTier && s.policies != nil {
+		if _, err := s.policies.PolicyForUser(ctx, user); err != nil {
+			return models.Invalid("membership_tier", "unknown_membership_tier", user.MembershipTier)
+		}
+	}
+
 	// Update user in repository
-	if err := s.userRepo.Update(user); err != nil {
+	if err := s.userRepo.Update(ctx, user); err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
-	return nil
+	return s.record(ctx, models.AuditActionUpdate, models.AuditEntityUser, user.ID, existing, user)
 }
 
 // DeleteUser removes a user from the system
-func (s *UserService) DeleteUser(userID int) error {
+func (s *UserService) DeleteUser(ctx context.Context, userID int) error {
 	if userID <= 0 {
-		return fmt.Errorf("invalid user ID: %d", userID)
+		return models.Invalid("user_id", "invalid_user_id", userID)
 	}
-	
+
+	return s.atomically(ctx, func(tx *UserService) error {
+		return tx.deleteUser(ctx, userID)
+	})
+}
+
+// deleteUser implements DeleteUser
+func (s *UserService) deleteUser(ctx context.Context, userID int) error {
 	// Check if user exists
-	_, err := s.userRepo.GetByID(userID)
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("user not found: %w", err)
 	}
+
+	if err := keepsAnAdministrator(ctx, s.userRepo, user); err != nil {
+		return err
+	}
 	
 	// Check if user has active borrowings
-	activeBorrowings, err := s.GetActiveUserBorrowings(userID)
+	activeBorrowings, err := s.GetActiveUserBorrowings(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("failed to check user borrowings: %w", err)
 	}
 	
 	if len(activeBorrowings) > 0 {
-		return fmt.Errorf("cannot delete user: has %d active borrowing(s)", len(activeBorrowings))
+		return models.Conflict("user_has_active_borrowings", len(activeBorrowings))
+	}
+	
+	// Check if user has any borrowing history
+	borrowingHistory, err := s.userRepo.GetBorrowingHistory(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check borrowing history: %w", err)
+	}
+	
+	if len(borrowingHistory) > 0 {
+		return models.Conflict("user_has_history", user.Name, len(borrowingHistory))
 	}
 	
 	// Delete user from repository
-	if err := s.userRepo.Delete(userID); err != nil {
+	if err := s.userRepo.Delete(ctx, userID); err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
 	
-	return nil
+	return s.record(ctx, models.AuditActionDelete, models.AuditEntityUser, userID, user, nil)
 }
\ No newline at end of file
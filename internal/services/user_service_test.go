@@ -2,6 +2,8 @@ package services
 
 import (
 	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -15,12 +17,12 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) Create(user *models.User) error {
+func (m *MockUserRepository) Create(ctx context.Context, user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) GetByID(id int) (*models.User, error) {
+func (m *MockUserRepository) GetByID(ctx context.Context, id int) (*models.User, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -28,7 +30,7 @@ func (m *MockUserRepository) GetByID(id int) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserRepository) GetByEmail(email string) (*models.User, error) {
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	args := m.Called(email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -36,7 +38,7 @@ func (m *MockUserRepository) GetByEmail(email string) (*models.User, error) {
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserRepository) GetAll() ([]*models.User, error) {
+func (m *MockUserRepository) GetAll(ctx context.Context) ([]*models.User, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -44,17 +46,30 @@ func (m *MockUserRepository) GetAll() ([]*models.User, error) {
 	return args.Get(0).([]*models.User), args.Error(1)
 }
 
-func (m *MockUserRepository) Update(user *models.User) error {
+func (m *MockUserRepository) List(ctx context.Context, filter models.UserFilter) ([]*models.User, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Count(ctx context.Context, filter models.UserFilter) (int, error) {
+	args := m.Called(filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) Delete(id int) error {
+func (m *MockUserRepository) Delete(ctx context.Context, id int) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) GetBorrowingHistory(userID int) ([]*models.Borrowing, error) {
+func (m *MockUserRepository) GetBorrowingHistory(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -67,12 +82,12 @@ type MockBorrowingRepository struct {
 	mock.Mock
 }
 
-func (m *MockBorrowingRepository) Create(borrowing *models.Borrowing) error {
+func (m *MockBorrowingRepository) Create(ctx context.Context, borrowing *models.Borrowing) error {
 	args := m.Called(borrowing)
 	return args.Error(0)
 }
 
-func (m *MockBorrowingRepository) GetByID(id int) (*models.Borrowing, error) {
+func (m *MockBorrowingRepository) GetByID(ctx context.Context, id int) (*models.Borrowing, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -80,7 +95,7 @@ func (m *MockBorrowingRepository) GetByID(id int) (*models.Borrowing, error) {
 	return args.Get(0).(*models.Borrowing), args.Error(1)
 }
 
-func (m *MockBorrowingRepository) GetActiveByUser(userID int) ([]*models.Borrowing, error) {
+func (m *MockBorrowingRepository) GetActiveByUser(ctx context.Context, userID int) ([]*models.Borrowing, error) {
 	args := m.Called(userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -88,7 +103,7 @@ func (m *MockBorrowingRepository) GetActiveByUser(userID int) ([]*models.Borrowi
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockBorrowingRepository) GetByGame(gameID int) ([]*models.Borrowing, error) {
+func (m *MockBorrowingRepository) GetByGame(ctx context.Context, gameID int) ([]*models.Borrowing, error) {
 	args := m.Called(gameID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -96,7 +111,7 @@ func (m *MockBorrowingRepository) GetByGame(gameID int) ([]*models.Borrowing, er
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockBorrowingRepository) GetOverdue() ([]*models.Borrowing, error) {
+func (m *MockBorrowingRepository) GetOverdue(ctx context.Context) ([]*models.Borrowing, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -104,17 +119,17 @@ func (m *MockBorrowingRepository) GetOverdue() ([]*models.Borrowing, error) {
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
-func (m *MockBorrowingRepository) Update(borrowing *models.Borrowing) error {
+func (m *MockBorrowingRepository) Update(ctx context.Context, borrowing *models.Borrowing) error {
 	args := m.Called(borrowing)
 	return args.Error(0)
 }
 
-func (m *MockBorrowingRepository) ReturnGame(borrowingID int) error {
+func (m *MockBorrowingRepository) ReturnGame(ctx context.Context, borrowingID int) error {
 	args := m.Called(borrowingID)
 	return args.Error(0)
 }
 
-func (m *MockBorrowingRepository) GetAll() ([]*models.Borrowing, error) {
+func (m *MockBorrowingRepository) GetAll(ctx context.Context) ([]*models.Borrowing, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -122,6 +137,19 @@ func (m *MockBorrowingRepository) GetAll() ([]*models.Borrowing, error) {
 	return args.Get(0).([]*models.Borrowing), args.Error(1)
 }
 
+func (m *MockBorrowingRepository) List(ctx context.Context, filter models.BorrowingFilter) ([]*models.Borrowing, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Borrowing), args.Error(1)
+}
+
+func (m *MockBorrowingRepository) Count(ctx context.Context, filter models.BorrowingFilter) (int, error) {
+	args := m.Called(filter)
+	return args.Int(0), args.Error(1)
+}
+
 func TestNewUserService(t *testing.T) {
 	userRepo := &MockUserRepository{}
 	borrowingRepo := &MockBorrowingRepository{}
@@ -134,6 +162,7 @@ func TestNewUserService(t *testing.T) {
 }
 
 func TestUserService_RegisterUser(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		inputName     string
@@ -198,7 +227,7 @@ func TestUserService_RegisterUser(t *testing.T) {
 			tt.setupMocks(userRepo, borrowingRepo)
 
 			service := NewUserService(userRepo, borrowingRepo)
-			user, err := service.RegisterUser(tt.inputName, tt.inputEmail)
+			user, err := service.RegisterUser(ctx, tt.inputName, tt.inputEmail)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -219,6 +248,7 @@ func TestUserService_RegisterUser(t *testing.T) {
 }
 
 func TestUserService_GetUser(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		userID        int
@@ -259,7 +289,7 @@ func TestUserService_GetUser(t *testing.T) {
 			tt.setupMocks(userRepo, borrowingRepo)
 
 			service := NewUserService(userRepo, borrowingRepo)
-			user, err := service.GetUser(tt.userID)
+			user, err := service.GetUser(ctx, tt.userID)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -278,6 +308,7 @@ func TestUserService_GetUser(t *testing.T) {
 }
 
 func TestUserService_CanUserBorrow(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		userID        int
@@ -346,6 +377,7 @@ func TestUserService_CanUserBorrow(t *testing.T) {
 			setupMocks: func(userRepo *MockUserRepository, borrowingRepo *MockBorrowingRepository) {
 				user := &models.User{ID: 1, Name: "John Doe", IsActive: false}
 				userRepo.On("GetByID", 1).Return(user, nil)
+				borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
 			},
 			expectedCan:   false,
 			expectedError: "user account is inactive",
@@ -377,7 +409,7 @@ func TestUserService_CanUserBorrow(t *testing.T) {
 			tt.setupMocks(userRepo, borrowingRepo)
 
 			service := NewUserService(userRepo, borrowingRepo)
-			canBorrow, err := service.CanUserBorrow(tt.userID)
+			canBorrow, err := service.CanUserBorrow(ctx, tt.userID)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -394,7 +426,171 @@ func TestUserService_CanUserBorrow(t *testing.T) {
 	}
 }
 
+func TestUserService_CheckEligibility(t *testing.T) {
+	ctx := context.Background()
+	basic := &models.LoanPolicy{Tier: "basic", MaxLoans: 2, MaxLoanDays: 30, DefaultLoanDays: 14, MaxExtensions: 1}
+	user := &models.User{ID: 1, Name: "John Doe", IsActive: true, MembershipTier: "basic"}
+	current := &models.Borrowing{ID: 1, UserID: 1, GameID: 1, DueDate: time.Now().Add(7 * 24 * time.Hour)}
+
+	userRepo := &MockUserRepository{}
+	borrowingRepo := &MockBorrowingRepository{}
+	policies := &MockLoanPolicies{}
+	userRepo.On("GetByID", 1).Return(user, nil)
+	policies.On("PolicyForUser", user).Return(basic, nil)
+	borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{current, current}, nil)
+
+	service := NewUserService(userRepo, borrowingRepo)
+	service.SetLoanPolicies(policies)
+
+	eligibility, err := service.CheckEligibility(ctx, 1)
+	assert.NoError(t, err)
+	assert.False(t, eligibility.CanBorrow)
+	assert.Equal(t, models.PolicyRuleMaxLoans, eligibility.Rule)
+	assert.Equal(t, "basic", eligibility.Tier)
+	assert.Equal(t, 2, eligibility.CurrentLoans)
+	assert.Equal(t, 2, eligibility.MaxLoans)
+
+	canBorrow, err := service.CanUserBorrow(ctx, 1)
+	assert.False(t, canBorrow)
+	assert.EqualError(t, err, "user has reached the limit of 2 concurrent loan(s) for the basic tier")
+
+	userRepo.AssertExpectations(t)
+	borrowingRepo.AssertExpectations(t)
+	policies.AssertExpectations(t)
+}
+
+func TestUserService_FineBalance(t *testing.T) {
+	ctx := context.Background()
+	maxBalance := 0
+	strict := &models.LoanPolicy{Tier: "basic", MaxLoans: 2, MaxLoanDays: 30, DefaultLoanDays: 14, MaxFineBalanceCents: &maxBalance}
+	user := &models.User{ID: 1, Name: "John Doe", IsActive: true, MembershipTier: "basic"}
+
+	userRepo := &MockUserRepository{}
+	borrowingRepo := &MockBorrowingRepository{}
+	policies := &MockLoanPolicies{}
+	fines := &MockFineLedger{}
+	userRepo.On("GetByID", 1).Return(user, nil)
+	policies.On("PolicyForUser", user).Return(strict, nil)
+	borrowingRepo.On("GetActiveByUser", 1).Return([]*models.Borrowing{}, nil)
+	fines.On("Balance", 1).Return(120, nil)
+
+	service := NewUserService(userRepo, borrowingRepo)
+	balance, err := service.GetFineBalance(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, balance, "users owe nothing when fines are not tracked")
+
+	service.SetLoanPolicies(policies)
+	service.SetFines(fines)
+	balance, err = service.GetFineBalance(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 120, balance)
+
+	eligibility, err := service.CheckEligibility(ctx, 1)
+	assert.NoError(t, err)
+	assert.False(t, eligibility.CanBorrow)
+	assert.Equal(t, models.PolicyRuleFineBalance, eligibility.Rule)
+	assert.Equal(t, 120, eligibility.FineBalance)
+}
+
+func TestUserService_UpdateUserMembershipTier(t *testing.T) {
+	ctx := context.Background()
+	existing := &models.User{ID: 1, Name: "John Doe", Email: "john@example.com", IsActive: true, MembershipTier: "standard"}
+
+	t.Run("unknown tier", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		policies := &MockLoanPolicies{}
+		userRepo.On("GetByID", 1).Return(existing, nil)
+		policies.On("PolicyForUser", mock.AnythingOfType("*models.User")).Return(nil, errors.New("not found"))
+
+		service := NewUserService(userRepo, &MockBorrowingRepository{})
+		service.SetLoanPolicies(policies)
+
+		err := service.UpdateUser(ctx, &models.User{ID: 1, Name: "John Doe", Email: "john@example.com", IsActive: true, MembershipTier: "gold"})
+		assert.EqualError(t, err, "unknown membership tier: gold")
+		userRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("tier kept when omitted", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("GetByID", 1).Return(existing, nil)
+		userRepo.On("Update", mock.MatchedBy(func(u *models.User) bool {
+			return u.MembershipTier == "standard"
+		})).Return(nil)
+
+		service := NewUserService(userRepo, &MockBorrowingRepository{})
+		service.SetLoanPolicies(&MockLoanPolicies{})
+
+		err := service.UpdateUser(ctx, &models.User{ID: 1, Name: "Johnny", Email: "john@example.com", IsActive: true})
+		assert.NoError(t, err)
+		userRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_LastAdministrator(t *testing.T) {
+	ctx := context.Background()
+	activeAdmins := mock.MatchedBy(func(f models.UserFilter) bool {
+		return f.Role == models.RoleAdmin && f.Active != nil && *f.Active
+	})
+	newAdmin := func() *models.User {
+		return &models.User{ID: 1, Name: "Admin", Email: "admin@example.com", IsActive: true, Role: models.RoleAdmin}
+	}
+
+	t.Run("deactivation refused in the transaction", func(t *testing.T) {
+		txUsers := &MockUserRepository{}
+		txUsers.On("GetByID", 1).Return(newAdmin(), nil)
+		txUsers.On("Count", activeAdmins).Return(1, nil)
+		uow := &fakeUnitOfWork{store: &repositories.Store{Users: txUsers, Borrowings: &MockBorrowingRepository{}}}
+
+		service := NewUserService(&MockUserRepository{}, &MockBorrowingRepository{})
+		service.SetUnitOfWork(uow)
+
+		update := newAdmin()
+		update.IsActive = false
+		err := service.UpdateUser(ctx, update)
+		assert.EqualError(t, err, "cannot remove the last administrator")
+		assert.Equal(t, 1, uow.calls)
+		assert.False(t, uow.committed)
+		txUsers.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("deactivation allowed with another administrator", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("GetByID", 1).Return(newAdmin(), nil)
+		userRepo.On("Count", activeAdmins).Return(2, nil)
+		userRepo.On("Update", mock.MatchedBy(func(u *models.User) bool { return !u.IsActive })).Return(nil)
+
+		service := NewUserService(userRepo, &MockBorrowingRepository{})
+		update := newAdmin()
+		update.IsActive = false
+		assert.NoError(t, service.UpdateUser(ctx, update))
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("deletion refused", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		borrowingRepo := &MockBorrowingRepository{}
+		userRepo.On("GetByID", 1).Return(newAdmin(), nil)
+		userRepo.On("Count", activeAdmins).Return(1, nil)
+
+		service := NewUserService(userRepo, borrowingRepo)
+		err := service.DeleteUser(ctx, 1)
+		assert.EqualError(t, err, "cannot remove the last administrator")
+		userRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("members are not counted", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("GetByID", 2).Return(&models.User{ID: 2, Name: "Member", Email: "m@example.com", IsActive: true, Role: models.RoleMember}, nil)
+		userRepo.On("Update", mock.AnythingOfType("*models.User")).Return(nil)
+
+		service := NewUserService(userRepo, &MockBorrowingRepository{})
+		assert.NoError(t, service.UpdateUser(ctx, &models.User{ID: 2, Name: "Member", Email: "m@example.com"}))
+		userRepo.AssertNotCalled(t, "Count", mock.Anything)
+	})
+}
+
 func TestUserService_GetUserBorrowings(t *testing.T) {
+	ctx := context.Background()
 	tests := []struct {
 		name          string
 		userID        int
@@ -441,7 +637,7 @@ func TestUserService_GetUserBorrowings(t *testing.T) {
 			tt.setupMocks(userRepo, borrowingRepo)
 
 			service := NewUserService(userRepo, borrowingRepo)
-			borrowings, err := service.GetUserBorrowings(tt.userID)
+			borrowings, err := service.GetUserBorrowings(ctx, tt.userID)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -457,4 +653,24 @@ func TestUserService_GetUserBorrowings(t *testing.T) {
 			borrowingRepo.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestUserService_ListUsers(t *testing.T) {
+	ctx := context.Background()
+	userRepo := &MockUserRepository{}
+	filter := models.UserFilter{Search: "ali", ListOptions: models.ListOptions{Page: 2, PerPage: 10, Sort: "email", Order: models.SortDesc}}
+	userRepo.On("List", filter).Return([]*models.User{{ID: 11, Name: "Alice"}}, nil)
+	userRepo.On("Count", filter).Return(11, nil)
+
+	service := NewUserService(userRepo, &MockBorrowingRepository{})
+	users, total, err := service.ListUsers(ctx, filter)
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, 11, total)
+	userRepo.AssertExpectations(t)
+
+	_, _, err = service.ListUsers(ctx, models.UserFilter{Role: "owner"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid user filter")
+}
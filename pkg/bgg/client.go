@@ -0,0 +1,241 @@
+// Package bgg is a client of the BoardGameGeek XML API2, used to look up
+// the details of board games by name or BoardGameGeek ID.
+package bgg
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the address of the public XML API2
+const DefaultBaseURL = "https://boardgamegeek.com/xmlapi2"
+
+// MaxSearchResults bounds the number of results returned by Search
+const MaxSearchResults = 50
+
+// Errors wrapped in those of lookups, telling an unknown game and a busy
+// API apart from other failures
+var (
+	ErrNotFound = errors.New("not found on BoardGameGeek")
+	ErrBusy     = errors.New("BoardGameGeek is busy, try again later")
+)
+
+// SearchResult is a board game matching a search
+type SearchResult struct {
+	ID            int    `json:"bgg_id"`
+	Name          string `json:"name"`
+	YearPublished int    `json:"year_published"` // zero when unknown
+}
+
+// Game holds the details of a board game. Zero values mean unknown.
+type Game struct {
+	ID            int
+	Name          string
+	Description   string // plain text
+	ImageURL      string
+	MinPlayers    int
+	MaxPlayers    int
+	PlayingTime   int // minutes
+	MinAge        int
+	YearPublished int
+	Categories    []string
+	Designers     []string
+	Publishers    []string
+	AverageWeight float64 // complexity voted by users, 1 (light) to 5 (heavy)
+}
+
+// Client calls the XML API2. Its zero value is not usable; see NewClient.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient creates a client of the XML API2 at baseURL, DefaultBaseURL for
+// BoardGameGeek itself. The token, when not empty, is sent as a bearer token
+// as BoardGameGeek requires for registered applications.
+func NewClient(baseURL, token string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Search looks up board games whose name contains query, the exact matches
+// first
+func (c *Client) Search(ctx context.Context, query string) ([]SearchResult, error) {
+	params := url.Values{"query": {query}, "type": {"boardgame"}}
+
+	var response struct {
+		Items []struct {
+			ID   int `xml:"id,attr"`
+			Name struct {
+				Value string `xml:"value,attr"`
+			} `xml:"name"`
+			YearPublished intValue `xml:"yearpublished"`
+		} `xml:"item"`
+	}
+	if err := c.get(ctx, "search", params, &response); err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, 0, len(response.Items))
+	for _, item := range response.Items {
+		if len(results) == MaxSearchResults {
+			break
+		}
+		results = append(results, SearchResult{
+			ID:            item.ID,
+			Name:          item.Name.Value,
+			YearPublished: item.YearPublished.Value,
+		})
+	}
+
+	return results, nil
+}
+
+// Game retrieves the details of the board game with the given ID
+func (c *Client) Game(ctx context.Context, id int) (*Game, error) {
+	params := url.Values{"id": {strconv.Itoa(id)}, "type": {"boardgame"}, "stats": {"1"}}
+
+	var response struct {
+		Items []struct {
+			ID    int    `xml:"id,attr"`
+			Image string `xml:"image"`
+			Names []struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:"value,attr"`
+			} `xml:"name"`
+			Description   string   `xml:"description"`
+			YearPublished intValue `xml:"yearpublished"`
+			MinPlayers    intValue `xml:"minplayers"`
+			MaxPlayers    intValue `xml:"maxplayers"`
+			PlayingTime   intValue `xml:"playingtime"`
+			MinAge        intValue `xml:"minage"`
+			Links         []struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:"value,attr"`
+			} `xml:"link"`
+			AverageWeight struct {
+				Value float64 `xml:"value,attr"`
+			} `xml:"statistics>ratings>averageweight"`
+		} `xml:"item"`
+	}
+	if err := c.get(ctx, "thing", params, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Items) == 0 {
+		return nil, fmt.Errorf("game %d %w", id, ErrNotFound)
+	}
+
+	item := response.Items[0]
+	game := &Game{
+		ID:            item.ID,
+		Description:   plainText(item.Description),
+		ImageURL:      strings.TrimSpace(item.Image),
+		MinPlayers:    item.MinPlayers.Value,
+		MaxPlayers:    item.MaxPlayers.Value,
+		PlayingTime:   item.PlayingTime.Value,
+		MinAge:        item.MinAge.Value,
+		YearPublished: item.YearPublished.Value,
+		AverageWeight: item.AverageWeight.Value,
+	}
+	for _, name := range item.Names {
+		if name.Type == "primary" || game.Name == "" {
+			game.Name = name.Value
+		}
+	}
+	for _, link := range item.Links {
+		switch link.Type {
+		case "boardgamecategory":
+			game.Categories = append(game.Categories, link.Value)
+		case "boardgamedesigner":
+			game.Designers = append(game.Designers, link.Value)
+		case "boardgamepublisher":
+			game.Publishers = append(game.Publishers, link.Value)
+		}
+	}
+
+	return game, nil
+}
+
+// get calls an API endpoint and decodes its XML response into v. The call
+// is abandoned when ctx is done.
+func (c *Client) get(ctx context.Context, endpoint string, params url.Values, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/"+endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build BoardGameGeek request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach BoardGameGeek: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusAccepted, http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		// The API queues slow requests and throttles busy clients
+		return fmt.Errorf("%w (HTTP %d)", ErrBusy, resp.StatusCode)
+	default:
+		return fmt.Errorf("BoardGameGeek returned HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read BoardGameGeek response: %w", err)
+	}
+	if err := xml.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("invalid BoardGameGeek response: %w", err)
+	}
+
+	return nil
+}
+
+// intValue is a number given in the value attribute of an element, as in
+// <minplayers value="2"/>. Missing or malformed values read as zero.
+type intValue struct {
+	Value int
+}
+
+// UnmarshalXML implements xml.Unmarshaler
+func (v *intValue) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "value" {
+			v.Value, _ = strconv.Atoi(strings.TrimSpace(attr.Value))
+		}
+	}
+	return d.Skip()
+}
+
+// plainText turns a description into plain text. BoardGameGeek escapes
+// descriptions twice, so entities such as &amp;#10; remain once the XML is
+// decoded.
+func plainText(description string) string {
+	text := html.UnescapeString(description)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = strings.Join(lines, "\n")
+	for strings.Contains(text, "\n\n\n") {
+		text = strings.ReplaceAll(text, "\n\n\n", "\n\n")
+	}
+
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,174 @@
+package bgg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const searchResponse = `<?xml version="1.0" encoding="utf-8"?>
+<items total="2" termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+	<item type="boardgame" id="13">
+		<name type="primary" value="CATAN"/>
+		<yearpublished value="1995" />
+	</item>
+	<item type="boardgame" id="27710">
+		<name type="primary" value="Catan Dice Game"/>
+	</item>
+</items>`
+
+const thingResponse = `<?xml version="1.0" encoding="utf-8"?>
+<items termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+	<item type="boardgame" id="13">
+		<thumbnail>https://cf.geekdo-images.com/thumb.jpg</thumbnail>
+		<image>https://cf.geekdo-images.com/catan.jpg</image>
+		<name type="alternate" sortindex="1" value="Les Colons de Catane" />
+		<name type="primary" sortindex="1" value="CATAN" />
+		<description>In CATAN, players try to be the dominant force&amp;#10;&amp;#10;&amp;#10;   on the island &amp;quot;Catan&amp;quot;.</description>
+		<yearpublished value="1995" />
+		<minplayers value="3" />
+		<maxplayers value="4" />
+		<playingtime value="120" />
+		<minage value="10" />
+		<link type="boardgamecategory" id="1021" value="Economic" />
+		<link type="boardgamecategory" id="1026" value="Negotiation" />
+		<link type="boardgamemechanic" id="2072" value="Dice Rolling" />
+		<link type="boardgamedesigner" id="11" value="Klaus Teuber" />
+		<link type="boardgamepublisher" id="37" value="KOSMOS" />
+		<link type="boardgamepublisher" id="4304" value="Filosofia Éditions" />
+		<statistics page="1">
+			<ratings>
+				<averageweight value="2.2973" />
+			</ratings>
+		</statistics>
+	</item>
+</items>`
+
+// fakeBGG serves canned XML API2 responses and records the requests made
+func fakeBGG(t *testing.T, requests *[]*http.Request) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r)
+		switch {
+		case r.URL.Path == "/xmlapi2/search":
+			w.Write([]byte(searchResponse))
+		case r.URL.Path == "/xmlapi2/thing" && r.URL.Query().Get("id") == "13":
+			w.Write([]byte(thingResponse))
+		case r.URL.Path == "/xmlapi2/thing" && r.URL.Query().Get("id") == "202":
+			w.WriteHeader(http.StatusAccepted)
+		case r.URL.Path == "/xmlapi2/thing":
+			w.Write([]byte(`<items termsofuse="https://boardgamegeek.com/xmlapi/termsofuse"></items>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Search(t *testing.T) {
+	var requests []*http.Request
+	server := fakeBGG(t, &requests)
+
+	client := NewClient(server.URL+"/xmlapi2/", "secret", time.Second)
+	results, err := client.Search(context.Background(), "catan")
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	want := []SearchResult{{ID: 13, Name: "CATAN", YearPublished: 1995}, {ID: 27710, Name: "Catan Dice Game"}}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Search() = %+v, want %+v", results, want)
+	}
+
+	query := requests[0].URL.Query()
+	if query.Get("query") != "catan" || query.Get("type") != "boardgame" {
+		t.Errorf("unexpected search parameters: %s", requests[0].URL.RawQuery)
+	}
+	if got := requests[0].Header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization header = %q, want the bearer token", got)
+	}
+}
+
+func TestClient_Game(t *testing.T) {
+	var requests []*http.Request
+	server := fakeBGG(t, &requests)
+
+	client := NewClient(server.URL+"/xmlapi2", "", time.Second)
+	game, err := client.Game(context.Background(), 13)
+	if err != nil {
+		t.Fatalf("Game() error = %v", err)
+	}
+
+	want := &Game{
+		ID:            13,
+		Name:          "CATAN",
+		Description:   "In CATAN, players try to be the dominant force\n\non the island \"Catan\".",
+		ImageURL:      "https://cf.geekdo-images.com/catan.jpg",
+		MinPlayers:    3,
+		MaxPlayers:    4,
+		PlayingTime:   120,
+		MinAge:        10,
+		YearPublished: 1995,
+		Categories:    []string{"Economic", "Negotiation"},
+		Designers:     []string{"Klaus Teuber"},
+		Publishers:    []string{"KOSMOS", "Filosofia Éditions"},
+		AverageWeight: 2.2973,
+	}
+	if !reflect.DeepEqual(game, want) {
+		t.Errorf("Game() = %+v, want %+v", game, want)
+	}
+	if requests[0].URL.Query().Get("stats") != "1" {
+		t.Errorf("expected the statistics to be requested, got %s", requests[0].URL.RawQuery)
+	}
+	if got := requests[0].Header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization header = %q, want none without a token", got)
+	}
+}
+
+func TestClient_GameErrors(t *testing.T) {
+	var requests []*http.Request
+	server := fakeBGG(t, &requests)
+
+	tests := []struct {
+		name    string
+		baseURL string
+		id      int
+		want    string
+	}{
+		{"unknown game", server.URL + "/xmlapi2", 999, "not found"},
+		{"queued request", server.URL + "/xmlapi2", 202, "try again later"},
+		{"wrong base URL", server.URL + "/nowhere", 13, "HTTP 404"},
+		{"unreachable", "http://127.0.0.1:1", 13, "failed to reach BoardGameGeek"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(tt.baseURL, "", time.Second).Game(context.Background(), tt.id)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Game(%d) error = %v, want it to contain %q", tt.id, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_Cancelled(t *testing.T) {
+	var requests []*http.Request
+	server := fakeBGG(t, &requests)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewClient(server.URL+"/xmlapi2", "", time.Second).Search(ctx, "catan")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Search() error = %v, want %v", err, context.Canceled)
+	}
+	if len(requests) != 0 {
+		t.Errorf("Expected no request once cancelled, got %d", len(requests))
+	}
+}
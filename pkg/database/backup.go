@@ -0,0 +1,296 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BackupFileFormat names the snapshots kept in a backup directory
+const BackupFileFormat = "library-20060102-150405.db"
+
+// Errors of Backup
+var (
+	ErrBackupUnsupported = errors.New("online backups are only supported on SQLite: back up PostgreSQL databases with pg_dump")
+	ErrBackupExists      = errors.New("backup file already exists")
+)
+
+// BackupFile describes a snapshot kept in a backup directory
+type BackupFile struct {
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Backup writes a consistent copy of the database to path with VACUUM INTO,
+// while other connections keep reading and writing. The copy is written
+// next to path and linked to it once complete, so path never holds a
+// partial file. An existing file at path is never overwritten: two
+// snapshots taken in the same second fail with ErrBackupExists.
+func (db *DB) Backup(path string) error {
+	if db.Driver() != DriverSQLite {
+		return ErrBackupUnsupported
+	}
+
+	if err := EnsureDirectoryExists(path); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	partial := path + ".partial"
+	os.Remove(partial)
+	if _, err := db.Exec("VACUUM INTO ?", partial); err != nil {
+		os.Remove(partial)
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	defer os.Remove(partial)
+	if err := os.Link(partial, path); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%w: %s", ErrBackupExists, path)
+		}
+		return fmt.Errorf("failed to save backup: %w", err)
+	}
+
+	return nil
+}
+
+// SchemaVersion returns the highest migration version applied to a database
+func SchemaVersion(q Querier) (int, error) {
+	var version sql.NullInt64
+	if err := q.QueryRow("SELECT MAX(version) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+// LatestSchemaVersion returns the version of the newest migration known to
+// this build
+func LatestSchemaVersion() int {
+	latest := 0
+	for _, migration := range getInitialMigrations() {
+		if migration.Version > latest {
+			latest = migration.Version
+		}
+	}
+	return latest
+}
+
+// CheckBackup opens a backup read-only and checks that it is an intact
+// library database this build can open: its schema version must not be
+// newer than the latest migration, and every applied migration must be
+// supported. It returns the schema version of the backup; older versions are
+// migrated when the restored database is next opened.
+func CheckBackup(path string) (int, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, fmt.Errorf("backup not found: %w", err)
+	}
+
+	sqlDB, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return 0, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer sqlDB.Close()
+	backup := &DB{DB: sqlDB}
+
+	var integrity string
+	if err := backup.QueryRow("PRAGMA integrity_check").Scan(&integrity); err != nil {
+		return 0, fmt.Errorf("invalid backup: %w", err)
+	}
+	if integrity != "ok" {
+		return 0, fmt.Errorf("invalid backup: integrity check failed: %s", integrity)
+	}
+
+	var tables int
+	if err := backup.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'").Scan(&tables); err != nil {
+		return 0, fmt.Errorf("invalid backup: %w", err)
+	}
+	if tables == 0 {
+		return 0, fmt.Errorf("invalid backup: not a board game library database")
+	}
+
+	version, err := SchemaVersion(backup)
+	if err != nil {
+		return 0, err
+	}
+	if version == 0 {
+		return 0, fmt.Errorf("invalid backup: no migration applied")
+	}
+	if latest := LatestSchemaVersion(); version > latest {
+		return 0, fmt.Errorf("backup schema version %d is newer than this build (version %d)", version, latest)
+	}
+
+	applied, err := getApplied(backup)
+	if err != nil {
+		return 0, fmt.Errorf("invalid backup: %w", err)
+	}
+	for _, migration := range getInitialMigrations() {
+		if _, ok := applied[migration.Version]; ok && migration.Requires != nil && !migration.Requires(backup) {
+			return 0, fmt.Errorf("backup uses migration %d (%s), which is not supported by this build",
+				migration.Version, migration.Name)
+		}
+	}
+
+	return version, nil
+}
+
+// Restore checks a backup and replaces the database at databasePath with a
+// copy of it. The server must not be running: open connections would keep
+// using the replaced file.
+func Restore(backupPath, databasePath string) (int, error) {
+	version, err := CheckBackup(backupPath)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := EnsureDirectoryExists(databasePath); err != nil {
+		return 0, fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	partial := databasePath + ".restore"
+	if err := copyFile(backupPath, partial); err != nil {
+		os.Remove(partial)
+		return 0, fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	// A journal left by the replaced database would be replayed into the
+	// restored one
+	for _, suffix := range []string{"-journal", "-wal", "-shm"} {
+		if err := os.Remove(databasePath + suffix); err != nil && !os.IsNotExist(err) {
+			os.Remove(partial)
+			return 0, fmt.Errorf("failed to remove %s: %w", databasePath+suffix, err)
+		}
+	}
+
+	if err := os.Rename(partial, databasePath); err != nil {
+		os.Remove(partial)
+		return 0, fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	return version, nil
+}
+
+// copyFile copies src to dst and flushes dst to disk
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// BackupRotation takes snapshots of a database into a directory and keeps
+// only the most recent ones
+type BackupRotation struct {
+	db        *DB
+	dir       string
+	retention int
+}
+
+// NewBackupRotation creates a rotation keeping retention snapshots in dir
+func NewBackupRotation(db *DB, dir string, retention int) *BackupRotation {
+	if retention < 1 {
+		retention = 1
+	}
+	return &BackupRotation{
+		db:        db,
+		dir:       dir,
+		retention: retention,
+	}
+}
+
+// Create takes a snapshot, then removes the oldest ones beyond the retention
+func (r *BackupRotation) Create() (*BackupFile, error) {
+	now := time.Now()
+	path := filepath.Join(r.dir, now.Format(BackupFileFormat))
+	if err := r.db.Backup(path); err != nil {
+		return nil, err
+	}
+
+	if err := r.Prune(); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	return &BackupFile{Name: info.Name(), Path: path, Size: info.Size(), CreatedAt: now}, nil
+}
+
+// List returns the snapshots of the directory, most recent first. Files not
+// named like snapshots are ignored.
+func (r *BackupRotation) List() ([]*BackupFile, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*BackupFile{}, nil
+		}
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	backups := make([]*BackupFile, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".db") {
+			continue
+		}
+		createdAt, err := time.ParseInLocation(BackupFileFormat, entry.Name(), time.Local)
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backups: %w", err)
+		}
+		backups = append(backups, &BackupFile{
+			Name:      entry.Name(),
+			Path:      filepath.Join(r.dir, entry.Name()),
+			Size:      info.Size(),
+			CreatedAt: createdAt,
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	return backups, nil
+}
+
+// Prune removes the oldest snapshots beyond the retention
+func (r *BackupRotation) Prune() error {
+	backups, err := r.List()
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range backups[min(r.retention, len(backups)):] {
+		if err := os.Remove(backup.Path); err != nil {
+			return fmt.Errorf("failed to remove old backup %s: %w", backup.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package database
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Initialize(Config{DatabasePath: filepath.Join(dir, "library.db")})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec("INSERT INTO users (name, email) VALUES ('Alice', 'alice@example.com')"); err != nil {
+		t.Fatalf("Failed to insert user: %v", err)
+	}
+
+	backupPath := filepath.Join(dir, "backups", "snapshot.db")
+	if err := db.Backup(backupPath); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+	if _, err := os.Stat(backupPath + ".partial"); !os.IsNotExist(err) {
+		t.Errorf("Expected no partial file after the backup, got %v", err)
+	}
+
+	version, err := CheckBackup(backupPath)
+	if err != nil {
+		t.Fatalf("CheckBackup() error = %v", err)
+	}
+	if version != LatestSchemaVersion() {
+		t.Errorf("CheckBackup() version = %d, want %d", version, LatestSchemaVersion())
+	}
+
+	// The backup is restored into another library and keeps its data
+	restoredPath := filepath.Join(dir, "restored", "library.db")
+	if _, err := Restore(backupPath, restoredPath); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	restored, err := Initialize(Config{DatabasePath: restoredPath})
+	if err != nil {
+		t.Fatalf("Failed to open restored database: %v", err)
+	}
+	defer restored.Close()
+
+	var name string
+	if err := restored.QueryRow("SELECT name FROM users WHERE email = 'alice@example.com'").Scan(&name); err != nil || name != "Alice" {
+		t.Errorf("Expected the restored database to contain Alice, got %q (%v)", name, err)
+	}
+}
+
+func TestCheckBackupWithoutChecksums(t *testing.T) {
+	// A backup of a database migrated before checksums were recorded
+	path := filepath.Join(t.TempDir(), "legacy.db")
+	legacy, err := Initialize(Config{DatabasePath: path})
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	if _, err := legacy.Exec("ALTER TABLE schema_migrations DROP COLUMN checksum"); err != nil {
+		t.Fatalf("Failed to drop the checksums: %v", err)
+	}
+	legacy.Close()
+
+	version, err := CheckBackup(path)
+	if err != nil {
+		t.Fatalf("CheckBackup() error = %v", err)
+	}
+	if version != LatestSchemaVersion() {
+		t.Errorf("CheckBackup() version = %d, want %d", version, LatestSchemaVersion())
+	}
+}
+
+func TestCheckBackupRejectsInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	notDatabase := filepath.Join(dir, "notes.db")
+	os.WriteFile(notDatabase, []byte("not a database"), 0644)
+
+	otherDatabase := filepath.Join(dir, "other.db")
+	other, err := NewConnection(Config{DatabasePath: otherDatabase})
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	other.Exec("CREATE TABLE notes (id INTEGER PRIMARY KEY)")
+	other.Close()
+
+	newerDatabase := filepath.Join(dir, "newer.db")
+	newer, err := Initialize(Config{DatabasePath: newerDatabase})
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	newer.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, 'from_the_future')", LatestSchemaVersion()+1)
+	newer.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr string
+	}{
+		{"missing file", filepath.Join(dir, "missing.db"), "backup not found"},
+		{"not a database", notDatabase, "invalid backup"},
+		{"another application", otherDatabase, "not a board game library database"},
+		{"newer schema", newerDatabase, "is newer than this build"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CheckBackup(tt.path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckBackup() error = %v, want %q", err, tt.wantErr)
+			}
+
+			target := filepath.Join(dir, "target.db")
+			if _, err := Restore(tt.path, target); err == nil {
+				t.Error("Restore() expected an error")
+			}
+			if _, err := os.Stat(target); !os.IsNotExist(err) {
+				t.Error("Expected a rejected backup not to be restored")
+			}
+		})
+	}
+}
+
+func TestBackupDoesNotOverwrite(t *testing.T) {
+	db, err := InitializeForTesting()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	path := filepath.Join(t.TempDir(), time.Now().Format(BackupFileFormat))
+	if err := db.Backup(path); err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+	if err := db.Backup(path); !errors.Is(err, ErrBackupExists) {
+		t.Errorf("Backup() to an existing file error = %v, want ErrBackupExists", err)
+	}
+	if _, err := os.Stat(path + ".partial"); !os.IsNotExist(err) {
+		t.Error("Expected the partial copy to be removed")
+	}
+	if _, err := CheckBackup(path); err != nil {
+		t.Errorf("Expected the first snapshot to be kept, got %v", err)
+	}
+}
+
+func TestBackupRotation(t *testing.T) {
+	db, err := InitializeForTesting()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	dir := t.TempDir()
+	for i := 1; i <= 4; i++ {
+		name := time.Date(2026, 1, i, 3, 0, 0, 0, time.Local).Format(BackupFileFormat)
+		os.WriteFile(filepath.Join(dir, name), []byte("old"), 0644)
+	}
+	os.WriteFile(filepath.Join(dir, "keep-me.db"), []byte("not a snapshot"), 0644)
+
+	rotation := NewBackupRotation(db, dir, 3)
+	created, err := rotation.Create()
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.Size == 0 {
+		t.Error("Expected a non-empty snapshot")
+	}
+
+	backups, err := rotation.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	var names []string
+	for _, backup := range backups {
+		names = append(names, backup.Name)
+	}
+	want := []string{
+		created.Name,
+		time.Date(2026, 1, 4, 3, 0, 0, 0, time.Local).Format(BackupFileFormat),
+		time.Date(2026, 1, 3, 3, 0, 0, 0, time.Local).Format(BackupFileFormat),
+	}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("List() = %v, want %v", names, want)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "keep-me.db")); err != nil {
+		t.Error("Expected files that are not snapshots to be kept")
+	}
+}
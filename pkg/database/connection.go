@@ -5,30 +5,48 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+)
 
-	_ "github.com/mattn/go-sqlite3"
+// Database drivers
+const (
+	DriverSQLite   = "sqlite3"
+	DriverPostgres = "postgres"
 )
 
 // DB holds the database connection
 type DB struct {
 	*sql.DB
+	driver  string
+	library int    // the library the handle is scoped to, see ForLibrary
+	cleanup func() // run once the connection is closed, by test databases
 }
 
 // Config holds database configuration
 type Config struct {
-	DatabasePath string
+	Driver       string // DriverSQLite when empty
+	DatabasePath string // SQLite database file
+	URL          string // PostgreSQL connection string
 }
 
 // NewConnection creates a new database connection
 func NewConnection(config Config) (*DB, error) {
+	switch config.Driver {
+	case "", DriverSQLite:
+	case DriverPostgres:
+		return newPostgresConnection(config.URL)
+	default:
+		return nil, fmt.Errorf("unknown database driver: %s", config.Driver)
+	}
+
 	// Ensure the directory exists
 	dir := filepath.Dir(config.DatabasePath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create database directory: %w", err)
 	}
 
-	// Open database connection
-	sqlDB, err := sql.Open("sqlite3", config.DatabasePath+"?_foreign_keys=on")
+	// Open database connection. Transactions take the write lock when they
+	// begin, so concurrent read-then-write transactions cannot interleave.
+	sqlDB, err := sql.Open(sqliteDriverName, config.DatabasePath+"?_foreign_keys=on&_txlock=immediate")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -43,14 +61,27 @@ func NewConnection(config Config) (*DB, error) {
 	sqlDB.SetMaxOpenConns(1) // SQLite works best with single connection
 	sqlDB.SetMaxIdleConns(1)
 
-	db := &DB{DB: sqlDB}
+	db := &DB{DB: sqlDB, driver: DriverSQLite}
 	return db, nil
 }
 
+// Driver returns the driver of the database, DriverSQLite or DriverPostgres
+func (db *DB) Driver() string {
+	if db.driver == "" {
+		return DriverSQLite
+	}
+	return db.driver
+}
+
 // Close closes the database connection
 func (db *DB) Close() error {
-	if db.DB != nil {
-		return db.DB.Close()
+	if db.DB == nil {
+		return nil
+	}
+	err := db.DB.Close()
+	if db.cleanup != nil {
+		db.cleanup()
+		db.cleanup = nil
 	}
-	return nil
+	return err
 }
\ No newline at end of file
@@ -0,0 +1,73 @@
+package database
+
+import "fmt"
+
+// GamesSearchTable is the FTS5 index of game names, descriptions and tags,
+// kept in sync with the games and tags tables by triggers
+const GamesSearchTable = "games_fts"
+
+// SupportsFTS5 reports whether SQLite was compiled with the FTS5 full-text
+// search extension, which go-sqlite3 only includes when built with
+// -tags sqlite_fts5
+func SupportsFTS5(q Querier) bool {
+	var used bool
+	err := q.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&used)
+	return err == nil && used
+}
+
+// HasTable reports whether the database has a table (or virtual table) of
+// the given name
+func HasTable(q Querier, name string) (bool, error) {
+	query := "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+	if IsPostgres(q) {
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?"
+	}
+
+	var count int
+	err := q.QueryRow(query, name).Scan(&count)
+	return count > 0, err
+}
+
+// tagSearchTriggers keep the tags column of the search index in sync with
+// the game_tags and tags tables, as created by migration 15
+var tagSearchTriggers = []string{
+	`CREATE TRIGGER game_tags_fts_insert AFTER INSERT ON game_tags
+BEGIN
+	UPDATE games_fts SET tags = COALESCE((SELECT group_concat(t.name, ' ') FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+		WHERE gt.game_id = new.game_id), '')
+	WHERE rowid = new.game_id;
+END`,
+	`CREATE TRIGGER game_tags_fts_delete AFTER DELETE ON game_tags
+BEGIN
+	UPDATE games_fts SET tags = COALESCE((SELECT group_concat(t.name, ' ') FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+		WHERE gt.game_id = old.game_id), '')
+	WHERE rowid = old.game_id;
+END`,
+	`CREATE TRIGGER tags_fts_update AFTER UPDATE OF name ON tags
+BEGIN
+	UPDATE games_fts SET tags = COALESCE((SELECT group_concat(t.name, ' ') FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+		WHERE gt.game_id = games_fts.rowid), '')
+	WHERE rowid IN (SELECT game_id FROM game_tags WHERE tag_id = new.id);
+END`,
+}
+
+// restoreTagSearchTriggers recreates the triggers of tagSearchTriggers after
+// a migration rebuilt the game_tags and tags tables, dropping them. Databases
+// whose search index has no tags column never had them.
+func restoreTagSearchTriggers(tx Querier) error {
+	var indexed int
+	if err := tx.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = 'tags'", GamesSearchTable).Scan(&indexed); err != nil {
+		return fmt.Errorf("failed to read search index: %w", err)
+	}
+	if indexed == 0 {
+		return nil
+	}
+
+	for _, trigger := range tagSearchTriggers {
+		if _, err := tx.Exec(trigger); err != nil {
+			return fmt.Errorf("failed to restore search index trigger: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package database
+
+import "testing"
+
+func TestGamesSearchIndex(t *testing.T) {
+	db, err := InitializeForTesting()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	exists, err := HasTable(db, GamesSearchTable)
+	if err != nil {
+		t.Fatalf("Failed to look up search index: %v", err)
+	}
+	if !SupportsFTS5(db) {
+		if exists {
+			t.Error("Expected no search index without FTS5")
+		}
+		t.Skip("SQLite built without FTS5 (use -tags sqlite_fts5)")
+	}
+	if !exists {
+		t.Fatal("Expected the search index to be created")
+	}
+
+	match := func(query string) int {
+		t.Helper()
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM games_fts WHERE games_fts MATCH ?", query).Scan(&count); err != nil {
+			t.Fatalf("Failed to search %q: %v", query, err)
+		}
+		return count
+	}
+
+	if _, err := db.Exec("INSERT INTO games (id, name, description, condition) VALUES (1, 'Les Délices', 'Un jeu de cuisine', 'good')"); err != nil {
+		t.Fatalf("Failed to insert game: %v", err)
+	}
+	if got := match("delice*"); got != 1 {
+		t.Errorf("Expected accent-insensitive prefix match, got %d", got)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO tags (id, name, slug, created_at) VALUES (1, 'Famille', 'famille', CURRENT_TIMESTAMP);
+		INSERT INTO game_tags (game_id, tag_id) VALUES (1, 1);`)
+	if err != nil {
+		t.Fatalf("Failed to tag game: %v", err)
+	}
+	if got := match("tags:famille"); got != 1 {
+		t.Errorf("Expected tag to be indexed, got %d", got)
+	}
+	if _, err := db.Exec("UPDATE tags SET name = 'Ambiance' WHERE id = 1"); err != nil {
+		t.Fatalf("Failed to rename tag: %v", err)
+	}
+	if got := match("tags:famille"); got != 0 {
+		t.Errorf("Expected old tag name to be removed from the index, got %d", got)
+	}
+	if got := match("tags:ambiance"); got != 1 {
+		t.Errorf("Expected renamed tag to be indexed, got %d", got)
+	}
+	if _, err := db.Exec("DELETE FROM tags WHERE id = 1"); err != nil {
+		t.Fatalf("Failed to delete tag: %v", err)
+	}
+	if got := match("tags:ambiance"); got != 0 {
+		t.Errorf("Expected deleted tag to be removed from the index, got %d", got)
+	}
+
+	if _, err := db.Exec("UPDATE games SET name = 'Azul' WHERE id = 1"); err != nil {
+		t.Fatalf("Failed to update game: %v", err)
+	}
+	if got := match("delice*"); got != 0 {
+		t.Errorf("Expected old name to be removed from the index, got %d", got)
+	}
+	if got := match("azul"); got != 1 {
+		t.Errorf("Expected new name to be indexed, got %d", got)
+	}
+
+	if _, err := db.Exec("DELETE FROM games WHERE id = 1"); err != nil {
+		t.Fatalf("Failed to delete game: %v", err)
+	}
+	if got := match("azul OR cuisine"); got != 0 {
+		t.Errorf("Expected deleted game to be removed from the index, got %d", got)
+	}
+}
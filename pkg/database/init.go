@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"log"
+	"os"
 )
 
 // Initialize sets up the database with all required tables and indexes
@@ -20,12 +21,23 @@ func Initialize(config Config) (*DB, error) {
 		return nil, fmt.Errorf("failed to run database migrations: %w", err)
 	}
 
-	log.Printf("Database initialized successfully at: %s", config.DatabasePath)
+	if db.Driver() == DriverPostgres {
+		log.Printf("PostgreSQL database initialized successfully")
+	} else {
+		log.Printf("Database initialized successfully at: %s", config.DatabasePath)
+	}
 	return db, nil
 }
 
-// InitializeForTesting creates an in-memory database for testing
+// InitializeForTesting creates an in-memory database for testing. When
+// TEST_POSTGRES_URL is set, it creates an empty PostgreSQL schema on that
+// server instead, dropped when the database is closed, so that the same
+// tests run on both backends.
 func InitializeForTesting() (*DB, error) {
+	if url := os.Getenv(TestPostgresURLEnv); url != "" {
+		return initializePostgresForTesting(url)
+	}
+
 	config := Config{
 		DatabasePath: ":memory:",
 	}
@@ -0,0 +1,48 @@
+package database
+
+// DefaultLibraryID is the library owning the data of single-library
+// deployments and of databases created before libraries existed
+const DefaultLibraryID = 1
+
+// ForLibrary returns a handle on the same connection pool scoped to the
+// library id: the repositories built on it only see and only create the data
+// of that library, and so do its transactions. Closing any handle closes the
+// shared pool.
+func (db *DB) ForLibrary(id int) *DB {
+	return &DB{DB: db.DB, driver: db.driver, library: id}
+}
+
+// Library returns the library the handle is scoped to
+func (db *DB) Library() int {
+	if db.library == 0 {
+		return DefaultLibraryID
+	}
+	return db.library
+}
+
+// InLibrary returns q scoped to the library id: a transaction stays the same
+// transaction, so a library and its first rows can be created together
+func InLibrary(q Querier, id int) Querier {
+	switch q := q.(type) {
+	case *DB:
+		return q.ForLibrary(id)
+	case dbTx:
+		q.library = id
+		return q
+	}
+	return q
+}
+
+// LibraryOf returns the library a connection or transaction is scoped to, so
+// that repositories can filter their queries by library
+func LibraryOf(q Querier) int {
+	switch q := q.(type) {
+	case *DB:
+		return q.Library()
+	case dbTx:
+		if q.library != 0 {
+			return q.library
+		}
+	}
+	return DefaultLibraryID
+}
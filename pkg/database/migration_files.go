@@ -0,0 +1,89 @@
+package database
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// migrationFileName matches the files of LoadMigrations, such as
+// 0001_create_users_table.up.sql
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// LoadMigrations reads the migrations of the .sql files in dir. A migration
+// is a <version>_<name>.up.sql file, with an optional <version>_<name>.down.sql
+// file rolling it back. Lines starting with -- are comments and are not part
+// of the statements or of their checksum.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	hasUp := map[int]bool{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q: expected <version>_<name>.up.sql or <version>_<name>.down.sql", entry.Name())
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid migration file name %q: the version must be a positive number", entry.Name())
+		}
+		name, direction := match[2], match[3]
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, name)
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+		if direction == "up" {
+			migration.Up = stripComments(string(data))
+			hasUp[version] = true
+		} else {
+			migration.Down = stripComments(string(data))
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for version, migration := range byVersion {
+		if !hasUp[version] {
+			return nil, fmt.Errorf("migration %d (%s) has no up file", version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// stripComments removes the lines of a script starting with --
+func stripComments(script string) string {
+	lines := strings.Split(script, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
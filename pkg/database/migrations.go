@@ -1,9 +1,12 @@
 package database
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Migration represents a database migration
@@ -12,6 +15,52 @@ type Migration struct {
 	Name    string
 	Up      string
 	Down    string
+
+	// Requires, when set, reports whether the database supports the
+	// migration. Unsupported migrations stay pending until a build that
+	// supports them runs.
+	Requires func(q Querier) bool
+
+	// Run, when set, converts existing data that SQL alone cannot. It runs
+	// after the Up statements, in the same transaction.
+	Run func(tx Querier) error
+
+	// Revert, when set, runs after the Down statements, in the same
+	// transaction
+	Revert func(tx Querier) error
+}
+
+// Checksum identifies the Up statements of the migration. It is recorded
+// when the migration is applied, so that later changes to an applied
+// migration are detected.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// Reversible reports whether the migration can be rolled back
+func (m Migration) Reversible() bool {
+	return strings.TrimSpace(m.Down) != ""
+}
+
+// MigrationStatus describes a migration and whether it is applied
+type MigrationStatus struct {
+	Version    int        `json:"version"`
+	Name       string     `json:"name"`
+	Applied    bool       `json:"applied"`
+	AppliedAt  *time.Time `json:"applied_at,omitempty"`
+	Known      bool       `json:"known"`     // false for migrations applied by a newer build
+	Supported  bool       `json:"supported"` // false when Requires rejects the database
+	Reversible bool       `json:"reversible"`
+	Modified   bool       `json:"modified"` // applied with other statements than the current ones
+}
+
+// appliedMigration is a row of the schema_migrations table
+type appliedMigration struct {
+	version   int
+	name      string
+	checksum  string
+	appliedAt time.Time
 }
 
 // MigrationManager handles database migrations
@@ -24,7 +73,7 @@ type MigrationManager struct {
 func NewMigrationManager(db *DB) *MigrationManager {
 	return &MigrationManager{
 		db:         db,
-		migrations: getInitialMigrations(),
+		migrations: getMigrations(db.Driver()),
 	}
 }
 
@@ -34,40 +83,213 @@ func (mm *MigrationManager) Initialize() error {
 	CREATE TABLE IF NOT EXISTS schema_migrations (
 		version INTEGER PRIMARY KEY,
 		name TEXT NOT NULL,
-		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		checksum TEXT NOT NULL DEFAULT ''
 	);`
+	if mm.db.Driver() == DriverPostgres {
+		query = strings.Replace(query, "DATETIME", "TIMESTAMPTZ", 1)
+	}
 
 	_, err := mm.db.Exec(query)
 	if err != nil {
 		return fmt.Errorf("failed to create schema_migrations table: %w", err)
 	}
 
+	// PostgreSQL databases always recorded checksums
+	if mm.db.Driver() == DriverPostgres {
+		return nil
+	}
+
+	// Databases created before checksums were recorded; their checksums are
+	// filled in by the next migration run
+	hasChecksum, err := hasChecksumColumn(mm.db)
+	if err != nil {
+		return err
+	}
+	if !hasChecksum {
+		if _, err := mm.db.Exec("ALTER TABLE schema_migrations ADD COLUMN checksum TEXT NOT NULL DEFAULT ''"); err != nil {
+			return fmt.Errorf("failed to add checksums to schema_migrations table: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// Migrate runs all pending migrations
+// Migrate runs all pending migrations in a single transaction
 func (mm *MigrationManager) Migrate() error {
+	return mm.run(func(tx Querier, applied map[int]appliedMigration) error {
+		return mm.applyPending(tx, applied, mm.latestVersion())
+	})
+}
+
+// MigrateTo applies or rolls back migrations, in a single transaction, until
+// the database is at version. Version 0 rolls back every migration.
+func (mm *MigrationManager) MigrateTo(version int) error {
+	if version != 0 {
+		if _, ok := mm.migration(version); !ok {
+			return fmt.Errorf("unknown migration version %d", version)
+		}
+	}
+
+	return mm.run(func(tx Querier, applied map[int]appliedMigration) error {
+		for _, v := range appliedVersionsDesc(applied) {
+			if v <= version {
+				break
+			}
+			if err := mm.rollbackMigration(tx, v); err != nil {
+				return err
+			}
+		}
+		return mm.applyPending(tx, applied, version)
+	})
+}
+
+// Rollback rolls back the n most recently applied migrations, in a single
+// transaction
+func (mm *MigrationManager) Rollback(n int) error {
+	if n < 1 {
+		return fmt.Errorf("number of migrations to roll back must be at least 1: %d", n)
+	}
+
+	return mm.run(func(tx Querier, applied map[int]appliedMigration) error {
+		versions := appliedVersionsDesc(applied)
+		if n > len(versions) {
+			return fmt.Errorf("cannot roll back %d migrations: only %d are applied", n, len(versions))
+		}
+		for _, v := range versions[:n] {
+			if err := mm.rollbackMigration(tx, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Status lists the known migrations and the applied ones this build does not
+// know, by version
+func (mm *MigrationManager) Status() ([]MigrationStatus, error) {
 	if err := mm.Initialize(); err != nil {
-		return err
+		return nil, err
 	}
 
-	appliedVersions, err := mm.getAppliedVersions()
+	var statuses []MigrationStatus
+	err := mm.db.WithTx(func(tx Querier) error {
+		applied, err := getApplied(tx)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]MigrationStatus, 0, len(mm.migrations))
+		for _, migration := range mm.migrations {
+			status := MigrationStatus{
+				Version:    migration.Version,
+				Name:       migration.Name,
+				Known:      true,
+				Supported:  migration.Requires == nil || migration.Requires(tx),
+				Reversible: migration.Reversible(),
+			}
+			if record, ok := applied[migration.Version]; ok {
+				appliedAt := record.appliedAt
+				status.Applied = true
+				status.AppliedAt = &appliedAt
+				status.Modified = record.checksum != "" && record.checksum != migration.Checksum()
+			}
+			statuses = append(statuses, status)
+		}
+
+		for _, record := range applied {
+			if _, ok := mm.migration(record.version); !ok {
+				appliedAt := record.appliedAt
+				statuses = append(statuses, MigrationStatus{
+					Version:   record.version,
+					Name:      record.name,
+					Applied:   true,
+					AppliedAt: &appliedAt,
+				})
+			}
+		}
+		return nil
+	})
 	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// run sets up the tracking table, then calls fn in a transaction once the
+// applied migrations are verified
+func (mm *MigrationManager) run(fn func(tx Querier, applied map[int]appliedMigration) error) error {
+	if err := mm.Initialize(); err != nil {
 		return err
 	}
 
-	// Sort migrations by version
 	sort.Slice(mm.migrations, func(i, j int) bool {
 		return mm.migrations[i].Version < mm.migrations[j].Version
 	})
 
+	return mm.db.WithTx(func(tx Querier) error {
+		applied, err := getApplied(tx)
+		if err != nil {
+			return err
+		}
+		if err := mm.verify(tx, applied); err != nil {
+			return err
+		}
+		return fn(tx, applied)
+	})
+}
+
+// verify checks that every applied migration is known and supported by this
+// build and was not modified since it was applied. Migrations applied before
+// checksums were recorded get the checksum of their current statements.
+func (mm *MigrationManager) verify(tx Querier, applied map[int]appliedMigration) error {
+	for _, version := range appliedVersionsDesc(applied) {
+		record := applied[version]
+		migration, ok := mm.migration(version)
+		if !ok {
+			return fmt.Errorf("migration %d (%s) is applied but unknown to this build", version, record.name)
+		}
+
+		if migration.Requires != nil && !migration.Requires(tx) {
+			return fmt.Errorf("migration %d (%s) is applied but not supported by this build",
+				migration.Version, migration.Name)
+		}
+
+		switch record.checksum {
+		case migration.Checksum():
+		case "":
+			if _, err := tx.Exec("UPDATE schema_migrations SET checksum = ? WHERE version = ?", migration.Checksum(), version); err != nil {
+				return fmt.Errorf("failed to record checksum of migration %d: %w", version, err)
+			}
+		default:
+			return fmt.Errorf("migration %d (%s) was modified after it was applied: checksum %s, applied with %s",
+				migration.Version, migration.Name, migration.Checksum(), record.checksum)
+		}
+	}
+
+	return nil
+}
+
+// applyPending applies the supported migrations up to version that are not
+// applied yet, in order
+func (mm *MigrationManager) applyPending(tx Querier, applied map[int]appliedMigration, version int) error {
 	for _, migration := range mm.migrations {
-		if _, applied := appliedVersions[migration.Version]; applied {
+		if migration.Version > version {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if migration.Requires != nil && !migration.Requires(tx) {
 			continue
 		}
 
-		if err := mm.applyMigration(migration); err != nil {
-			return fmt.Errorf("failed to apply migration %d (%s): %w", 
+		if err := applyMigration(tx, migration); err != nil {
+			return fmt.Errorf("failed to apply migration %d (%s): %w",
 				migration.Version, migration.Name, err)
 		}
 	}
@@ -75,55 +297,161 @@ func (mm *MigrationManager) Migrate() error {
 	return nil
 }
 
-// getAppliedVersions returns a map of applied migration versions
-func (mm *MigrationManager) getAppliedVersions() (map[int]bool, error) {
-	query := "SELECT version FROM schema_migrations"
-	rows, err := mm.db.Query(query)
+// rollbackMigration runs the Down statements of an applied migration
+func (mm *MigrationManager) rollbackMigration(tx Querier, version int) error {
+	migration, _ := mm.migration(version)
+	if !migration.Reversible() {
+		return fmt.Errorf("migration %d (%s) cannot be rolled back", migration.Version, migration.Name)
+	}
+
+	for _, stmt := range splitStatements(migration.Down) {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to roll back migration %d (%s): statement '%s': %w",
+				migration.Version, migration.Name, stmt, err)
+		}
+	}
+
+	if migration.Revert != nil {
+		if err := migration.Revert(tx); err != nil {
+			return fmt.Errorf("failed to roll back migration %d (%s): %w", migration.Version, migration.Name, err)
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM schema_migrations WHERE version = ?", version); err != nil {
+		return fmt.Errorf("failed to roll back migration %d (%s): %w", migration.Version, migration.Name, err)
+	}
+
+	return nil
+}
+
+// migration returns the known migration of a version
+func (mm *MigrationManager) migration(version int) (Migration, bool) {
+	for _, migration := range mm.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+// latestVersion returns the version of the newest known migration
+func (mm *MigrationManager) latestVersion() int {
+	latest := 0
+	for _, migration := range mm.migrations {
+		if migration.Version > latest {
+			latest = migration.Version
+		}
+	}
+	return latest
+}
+
+// hasChecksumColumn reports whether the schema_migrations table records
+// checksums. SQLite databases created before checksums were recorded lack the
+// column until Initialize adds it; PostgreSQL databases always have it.
+func hasChecksumColumn(q Querier) (bool, error) {
+	if IsPostgres(q) {
+		return true, nil
+	}
+
+	var count int
+	err := q.QueryRow("SELECT COUNT(*) FROM pragma_table_info('schema_migrations') WHERE name = 'checksum'").Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to read schema_migrations table: %w", err)
+	}
+	return count > 0, nil
+}
+
+// getApplied returns the applied migrations by version. Migrations of
+// databases that do not record checksums yet, such as old backups opened
+// read-only, have an empty checksum.
+func getApplied(q Querier) (map[int]appliedMigration, error) {
+	hasChecksum, err := hasChecksumColumn(q)
 	if err != nil {
 		return nil, err
 	}
+	checksum := "checksum"
+	if !hasChecksum {
+		checksum = "''"
+	}
+
+	rows, err := q.Query("SELECT version, name, " + checksum + ", applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
 	defer rows.Close()
 
-	versions := make(map[int]bool)
+	applied := make(map[int]appliedMigration)
 	for rows.Next() {
-		var version int
-		if err := rows.Scan(&version); err != nil {
-			return nil, err
+		var record appliedMigration
+		if err := rows.Scan(&record.version, &record.name, &record.checksum, &record.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan applied migration: %w", err)
 		}
-		versions[version] = true
+		applied[record.version] = record
 	}
 
-	return versions, rows.Err()
+	return applied, rows.Err()
 }
 
-// applyMigration applies a single migration
-func (mm *MigrationManager) applyMigration(migration Migration) error {
-	tx, err := mm.db.Begin()
-	if err != nil {
-		return err
+// appliedVersionsDesc returns the applied versions, most recent first
+func appliedVersionsDesc(applied map[int]appliedMigration) []int {
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
 	}
-	defer tx.Rollback()
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+	return versions
+}
 
+// applyMigration applies a single migration and records it with its checksum
+func applyMigration(tx Querier, migration Migration) error {
 	// Execute migration statements
-	statements := strings.Split(migration.Up, ";")
-	for _, stmt := range statements {
-		stmt = strings.TrimSpace(stmt)
-		if stmt == "" {
-			continue
-		}
+	for _, stmt := range splitStatements(migration.Up) {
 		if _, err := tx.Exec(stmt); err != nil {
 			return fmt.Errorf("failed to execute statement '%s': %w", stmt, err)
 		}
 	}
 
+	if migration.Run != nil {
+		if err := migration.Run(tx); err != nil {
+			return err
+		}
+	}
+
 	// Record migration as applied
-	_, err = tx.Exec(
-		"INSERT INTO schema_migrations (version, name) VALUES (?, ?)",
-		migration.Version, migration.Name,
+	_, err := tx.Exec(
+		"INSERT INTO schema_migrations (version, name, checksum) VALUES (?, ?, ?)",
+		migration.Version, migration.Name, migration.Checksum(),
 	)
-	if err != nil {
-		return err
+	return err
+}
+
+// splitStatements splits a migration script on semicolons, keeping the body
+// of a SQLite CREATE TRIGGER ... BEGIN ... END statement, and of a
+// PostgreSQL function quoted with $$, in one piece
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	for _, part := range strings.Split(script, ";") {
+		current.WriteString(part)
+
+		stmt := strings.TrimSpace(current.String())
+		upper := strings.ToUpper(stmt)
+		inTrigger := strings.HasPrefix(upper, "CREATE TRIGGER") && strings.Contains(upper, "BEGIN") && !strings.HasSuffix(upper, "END")
+		if inTrigger || strings.Count(stmt, "$$")%2 == 1 {
+			current.WriteString(";")
+			continue
+		}
+
+		if stmt != "" {
+			statements = append(statements, stmt)
+		}
+		current.Reset()
 	}
 
-	return tx.Commit()
-}
\ No newline at end of file
+	if stmt := strings.TrimSpace(strings.TrimSuffix(current.String(), ";")); stmt != "" {
+		statements = append(statements, stmt)
+	}
+
+	return statements
+}
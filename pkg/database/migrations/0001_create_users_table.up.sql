@@ -0,0 +1,7 @@
+CREATE TABLE users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	email TEXT UNIQUE NOT NULL,
+	registered_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	is_active BOOLEAN DEFAULT TRUE
+);
@@ -0,0 +1,9 @@
+CREATE TABLE games (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	description TEXT,
+	category TEXT,
+	entry_date DATETIME DEFAULT CURRENT_TIMESTAMP,
+	condition TEXT DEFAULT 'good',
+	is_available BOOLEAN DEFAULT TRUE
+);
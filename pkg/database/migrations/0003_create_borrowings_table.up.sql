@@ -0,0 +1,11 @@
+CREATE TABLE borrowings (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	game_id INTEGER NOT NULL,
+	borrowed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	due_date DATETIME NOT NULL,
+	returned_at DATETIME,
+	is_overdue BOOLEAN DEFAULT FALSE,
+	FOREIGN KEY (user_id) REFERENCES users(id),
+	FOREIGN KEY (game_id) REFERENCES games(id)
+);
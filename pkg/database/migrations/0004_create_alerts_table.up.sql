@@ -0,0 +1,11 @@
+CREATE TABLE alerts (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	game_id INTEGER NOT NULL,
+	type TEXT NOT NULL,
+	message TEXT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	is_read BOOLEAN DEFAULT FALSE,
+	FOREIGN KEY (user_id) REFERENCES users(id),
+	FOREIGN KEY (game_id) REFERENCES games(id)
+);
@@ -0,0 +1,5 @@
+DROP INDEX idx_borrowings_user_id;
+DROP INDEX idx_borrowings_game_id;
+DROP INDEX idx_borrowings_due_date;
+DROP INDEX idx_alerts_user_id;
+DROP INDEX idx_alerts_is_read;
@@ -0,0 +1,5 @@
+CREATE INDEX idx_borrowings_user_id ON borrowings(user_id);
+CREATE INDEX idx_borrowings_game_id ON borrowings(game_id);
+CREATE INDEX idx_borrowings_due_date ON borrowings(due_date);
+CREATE INDEX idx_alerts_user_id ON alerts(user_id);
+CREATE INDEX idx_alerts_is_read ON alerts(is_read);
@@ -0,0 +1,2 @@
+DROP INDEX idx_job_runs_job_name;
+DROP TABLE job_runs;
@@ -0,0 +1,10 @@
+CREATE TABLE job_runs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	job_name TEXT NOT NULL,
+	status TEXT NOT NULL,
+	started_at DATETIME NOT NULL,
+	finished_at DATETIME,
+	duration_ms INTEGER DEFAULT 0,
+	error TEXT DEFAULT ''
+);
+CREATE INDEX idx_job_runs_job_name ON job_runs(job_name, started_at);
@@ -0,0 +1,5 @@
+DROP INDEX idx_borrowings_copy_id;
+ALTER TABLE borrowings DROP COLUMN copy_id;
+DROP INDEX idx_game_copies_barcode;
+DROP INDEX idx_game_copies_game_id;
+DROP TABLE game_copies;
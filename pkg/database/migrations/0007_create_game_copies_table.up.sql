@@ -0,0 +1,18 @@
+CREATE TABLE game_copies (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	game_id INTEGER NOT NULL,
+	barcode TEXT NOT NULL DEFAULT '',
+	condition TEXT DEFAULT 'good',
+	acquired_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	is_available BOOLEAN DEFAULT TRUE,
+	FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_game_copies_game_id ON game_copies(game_id);
+CREATE UNIQUE INDEX idx_game_copies_barcode ON game_copies(barcode) WHERE barcode <> '';
+INSERT INTO game_copies (game_id, condition, acquired_at, is_available)
+	SELECT id, COALESCE(condition, 'good'), COALESCE(entry_date, CURRENT_TIMESTAMP), COALESCE(is_available, TRUE) FROM games;
+ALTER TABLE borrowings ADD COLUMN copy_id INTEGER REFERENCES game_copies(id);
+UPDATE borrowings SET copy_id = (
+	SELECT game_copies.id FROM game_copies WHERE game_copies.game_id = borrowings.game_id
+);
+CREATE INDEX idx_borrowings_copy_id ON borrowings(copy_id);
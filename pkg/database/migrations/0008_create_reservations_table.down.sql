@@ -0,0 +1,3 @@
+DROP INDEX idx_reservations_user_id;
+DROP INDEX idx_reservations_game_status;
+DROP TABLE reservations;
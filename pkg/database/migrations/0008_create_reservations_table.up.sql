@@ -0,0 +1,16 @@
+CREATE TABLE reservations (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL,
+	game_id INTEGER NOT NULL,
+	copy_id INTEGER,
+	status TEXT NOT NULL DEFAULT 'waiting',
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	notified_at DATETIME,
+	expires_at DATETIME,
+	closed_at DATETIME,
+	FOREIGN KEY (user_id) REFERENCES users(id),
+	FOREIGN KEY (game_id) REFERENCES games(id),
+	FOREIGN KEY (copy_id) REFERENCES game_copies(id)
+);
+CREATE INDEX idx_reservations_game_status ON reservations(game_id, status, created_at);
+CREATE INDEX idx_reservations_user_id ON reservations(user_id);
@@ -0,0 +1,3 @@
+ALTER TABLE borrowings DROP COLUMN extension_count;
+ALTER TABLE users DROP COLUMN membership_tier;
+DROP TABLE loan_policies;
@@ -0,0 +1,13 @@
+CREATE TABLE loan_policies (
+	tier TEXT PRIMARY KEY,
+	max_loans INTEGER NOT NULL,
+	max_loan_days INTEGER NOT NULL,
+	default_loan_days INTEGER NOT NULL,
+	max_extensions INTEGER NOT NULL
+);
+INSERT INTO loan_policies (tier, max_loans, max_loan_days, default_loan_days, max_extensions) VALUES
+	('basic', 2, 30, 14, 1),
+	('standard', 5, 90, 14, 3),
+	('premium', 10, 120, 21, 5);
+ALTER TABLE users ADD COLUMN membership_tier TEXT NOT NULL DEFAULT 'standard';
+ALTER TABLE borrowings ADD COLUMN extension_count INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+DROP TABLE api_tokens;
+DROP TABLE sessions;
+ALTER TABLE users DROP COLUMN password_hash;
+ALTER TABLE users DROP COLUMN role;
@@ -0,0 +1,22 @@
+CREATE TABLE audit_events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	actor_id INTEGER,
+	actor_name TEXT NOT NULL,
+	action TEXT NOT NULL,
+	entity_type TEXT NOT NULL,
+	entity_id TEXT NOT NULL DEFAULT '',
+	before_json TEXT,
+	after_json TEXT,
+	created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_audit_events_created ON audit_events(created_at);
+CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_id);
+CREATE INDEX idx_audit_events_actor ON audit_events(actor_id);
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+BEGIN
+	SELECT RAISE(ABORT, 'audit events are append-only');
+END;
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+BEGIN
+	SELECT RAISE(ABORT, 'audit events are append-only');
+END;
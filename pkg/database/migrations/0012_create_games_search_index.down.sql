@@ -0,0 +1,4 @@
+DROP TRIGGER games_fts_update;
+DROP TRIGGER games_fts_delete;
+DROP TRIGGER games_fts_insert;
+DROP TABLE games_fts;
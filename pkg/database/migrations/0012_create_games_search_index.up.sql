@@ -0,0 +1,24 @@
+CREATE VIRTUAL TABLE games_fts USING fts5(
+	name, description, category,
+	content='games', content_rowid='id',
+	tokenize='unicode61 remove_diacritics 2',
+	prefix='2 3'
+);
+INSERT INTO games_fts(games_fts) VALUES ('rebuild');
+CREATE TRIGGER games_fts_insert AFTER INSERT ON games
+BEGIN
+	INSERT INTO games_fts(rowid, name, description, category)
+	VALUES (new.id, new.name, new.description, new.category);
+END;
+CREATE TRIGGER games_fts_delete AFTER DELETE ON games
+BEGIN
+	INSERT INTO games_fts(games_fts, rowid, name, description, category)
+	VALUES ('delete', old.id, old.name, old.description, old.category);
+END;
+CREATE TRIGGER games_fts_update AFTER UPDATE OF name, description, category ON games
+BEGIN
+	INSERT INTO games_fts(games_fts, rowid, name, description, category)
+	VALUES ('delete', old.id, old.name, old.description, old.category);
+	INSERT INTO games_fts(rowid, name, description, category)
+	VALUES (new.id, new.name, new.description, new.category);
+END;
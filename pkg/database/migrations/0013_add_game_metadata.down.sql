@@ -0,0 +1,10 @@
+DROP INDEX idx_games_play_time;
+DROP INDEX idx_games_players;
+ALTER TABLE games DROP COLUMN complexity;
+ALTER TABLE games DROP COLUMN year_published;
+ALTER TABLE games DROP COLUMN designers;
+ALTER TABLE games DROP COLUMN publisher;
+ALTER TABLE games DROP COLUMN min_age;
+ALTER TABLE games DROP COLUMN play_time;
+ALTER TABLE games DROP COLUMN max_players;
+ALTER TABLE games DROP COLUMN min_players;
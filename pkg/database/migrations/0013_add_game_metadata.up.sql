@@ -0,0 +1,10 @@
+ALTER TABLE games ADD COLUMN min_players INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE games ADD COLUMN max_players INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE games ADD COLUMN play_time INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE games ADD COLUMN min_age INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE games ADD COLUMN publisher TEXT NOT NULL DEFAULT '';
+ALTER TABLE games ADD COLUMN designers TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE games ADD COLUMN year_published INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE games ADD COLUMN complexity REAL NOT NULL DEFAULT 0;
+CREATE INDEX idx_games_players ON games(min_players, max_players);
+CREATE INDEX idx_games_play_time ON games(play_time);
@@ -0,0 +1,7 @@
+UPDATE games SET category = COALESCE((
+	SELECT t.name FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+	WHERE gt.game_id = games.id
+	ORDER BY t.name COLLATE NOCASE LIMIT 1
+), '');
+DROP TABLE game_tags;
+DROP TABLE tags;
@@ -0,0 +1,14 @@
+CREATE TABLE tags (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	slug TEXT NOT NULL UNIQUE,
+	created_at DATETIME NOT NULL
+);
+CREATE TABLE game_tags (
+	game_id INTEGER NOT NULL,
+	tag_id INTEGER NOT NULL,
+	PRIMARY KEY (game_id, tag_id),
+	FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+	FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_game_tags_tag ON game_tags(tag_id);
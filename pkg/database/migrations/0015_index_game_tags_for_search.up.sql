@@ -0,0 +1,45 @@
+DROP TRIGGER games_fts_update;
+DROP TRIGGER games_fts_delete;
+DROP TRIGGER games_fts_insert;
+DROP TABLE games_fts;
+
+CREATE VIRTUAL TABLE games_fts USING fts5(
+	name, description, tags,
+	tokenize='unicode61 remove_diacritics 2',
+	prefix='2 3'
+);
+INSERT INTO games_fts(rowid, name, description, tags)
+SELECT id, name, description, COALESCE((SELECT group_concat(t.name, ' ') FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+		WHERE gt.game_id = games.id), '') FROM games;
+CREATE TRIGGER games_fts_insert AFTER INSERT ON games
+BEGIN
+	INSERT INTO games_fts(rowid, name, description, tags)
+	VALUES (new.id, new.name, new.description, '');
+END;
+CREATE TRIGGER games_fts_delete AFTER DELETE ON games
+BEGIN
+	DELETE FROM games_fts WHERE rowid = old.id;
+END;
+CREATE TRIGGER games_fts_update AFTER UPDATE OF name, description ON games
+BEGIN
+	UPDATE games_fts SET name = new.name, description = new.description
+	WHERE rowid = new.id;
+END;
+CREATE TRIGGER game_tags_fts_insert AFTER INSERT ON game_tags
+BEGIN
+	UPDATE games_fts SET tags = COALESCE((SELECT group_concat(t.name, ' ') FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+		WHERE gt.game_id = new.game_id), '')
+	WHERE rowid = new.game_id;
+END;
+CREATE TRIGGER game_tags_fts_delete AFTER DELETE ON game_tags
+BEGIN
+	UPDATE games_fts SET tags = COALESCE((SELECT group_concat(t.name, ' ') FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+		WHERE gt.game_id = old.game_id), '')
+	WHERE rowid = old.game_id;
+END;
+CREATE TRIGGER tags_fts_update AFTER UPDATE OF name ON tags
+BEGIN
+	UPDATE games_fts SET tags = COALESCE((SELECT group_concat(t.name, ' ') FROM game_tags gt JOIN tags t ON t.id = gt.tag_id
+		WHERE gt.game_id = games_fts.rowid), '')
+	WHERE rowid IN (SELECT game_id FROM game_tags WHERE tag_id = new.id);
+END;
@@ -0,0 +1,2 @@
+ALTER TABLE games DROP COLUMN bgg_id;
+ALTER TABLE games DROP COLUMN image_url;
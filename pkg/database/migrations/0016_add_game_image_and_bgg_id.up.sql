@@ -0,0 +1,2 @@
+ALTER TABLE games ADD COLUMN image_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE games ADD COLUMN bgg_id INTEGER NOT NULL DEFAULT 0;
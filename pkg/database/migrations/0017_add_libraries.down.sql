@@ -0,0 +1,53 @@
+-- Rolling back merges every library into one. It fails when two libraries
+-- share a tag slug or a barcode; the loan policies of the first library are
+-- kept.
+CREATE TABLE single_loan_policies (
+	tier TEXT PRIMARY KEY,
+	max_loans INTEGER NOT NULL,
+	max_loan_days INTEGER NOT NULL,
+	default_loan_days INTEGER NOT NULL,
+	max_extensions INTEGER NOT NULL
+);
+INSERT INTO single_loan_policies (tier, max_loans, max_loan_days, default_loan_days, max_extensions)
+	SELECT tier, max_loans, max_loan_days, default_loan_days, max_extensions FROM loan_policies WHERE library_id = 1;
+DROP TABLE loan_policies;
+ALTER TABLE single_loan_policies RENAME TO loan_policies;
+
+CREATE TABLE single_tags (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	slug TEXT NOT NULL UNIQUE,
+	created_at DATETIME NOT NULL
+);
+INSERT INTO single_tags (id, name, slug, created_at) SELECT id, name, slug, created_at FROM tags;
+CREATE TABLE single_game_tags (
+	game_id INTEGER NOT NULL,
+	tag_id INTEGER NOT NULL,
+	PRIMARY KEY (game_id, tag_id),
+	FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+	FOREIGN KEY (tag_id) REFERENCES single_tags(id) ON DELETE CASCADE
+);
+INSERT INTO single_game_tags (game_id, tag_id) SELECT game_id, tag_id FROM game_tags;
+DROP TABLE game_tags;
+DROP TABLE tags;
+ALTER TABLE single_tags RENAME TO tags;
+ALTER TABLE single_game_tags RENAME TO game_tags;
+CREATE INDEX idx_game_tags_tag ON game_tags(tag_id);
+
+DROP INDEX idx_game_copies_barcode;
+CREATE UNIQUE INDEX idx_game_copies_barcode ON game_copies(barcode) WHERE barcode <> '';
+
+DROP INDEX idx_audit_events_library;
+DROP INDEX idx_reservations_library;
+DROP INDEX idx_alerts_library;
+DROP INDEX idx_borrowings_library;
+DROP INDEX idx_games_library;
+DROP INDEX idx_users_library;
+ALTER TABLE audit_events DROP COLUMN library_id;
+ALTER TABLE reservations DROP COLUMN library_id;
+ALTER TABLE alerts DROP COLUMN library_id;
+ALTER TABLE borrowings DROP COLUMN library_id;
+ALTER TABLE game_copies DROP COLUMN library_id;
+ALTER TABLE games DROP COLUMN library_id;
+ALTER TABLE users DROP COLUMN library_id;
+DROP TABLE libraries;
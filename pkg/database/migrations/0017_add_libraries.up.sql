@@ -0,0 +1,69 @@
+-- Several libraries share one deployment. The rows created before libraries
+-- existed belong to the first library. Sessions, API tokens and game tags
+-- belong to the library of their user or game; job runs to the deployment.
+CREATE TABLE libraries (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	slug TEXT NOT NULL UNIQUE,
+	name TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
+INSERT INTO libraries (id, slug, name, created_at) VALUES (1, 'default', 'Bibliothèque', CURRENT_TIMESTAMP);
+
+-- SQLite cannot add a column referencing another table with a default value,
+-- so these columns have no foreign key
+ALTER TABLE users ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE games ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE game_copies ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE borrowings ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE alerts ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE reservations ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE audit_events ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1;
+CREATE INDEX idx_users_library ON users(library_id);
+CREATE INDEX idx_games_library ON games(library_id);
+CREATE INDEX idx_borrowings_library ON borrowings(library_id);
+CREATE INDEX idx_alerts_library ON alerts(library_id);
+CREATE INDEX idx_reservations_library ON reservations(library_id);
+CREATE INDEX idx_audit_events_library ON audit_events(library_id, created_at);
+
+-- Barcodes, tag slugs and membership tiers are unique within a library
+DROP INDEX idx_game_copies_barcode;
+CREATE UNIQUE INDEX idx_game_copies_barcode ON game_copies(library_id, barcode) WHERE barcode <> '';
+
+CREATE TABLE library_tags (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	library_id INTEGER NOT NULL DEFAULT 1,
+	name TEXT NOT NULL,
+	slug TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	UNIQUE (library_id, slug),
+	FOREIGN KEY (library_id) REFERENCES libraries(id)
+);
+INSERT INTO library_tags (id, name, slug, created_at) SELECT id, name, slug, created_at FROM tags;
+CREATE TABLE library_game_tags (
+	game_id INTEGER NOT NULL,
+	tag_id INTEGER NOT NULL,
+	PRIMARY KEY (game_id, tag_id),
+	FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+	FOREIGN KEY (tag_id) REFERENCES library_tags(id) ON DELETE CASCADE
+);
+INSERT INTO library_game_tags (game_id, tag_id) SELECT game_id, tag_id FROM game_tags;
+DROP TABLE game_tags;
+DROP TABLE tags;
+ALTER TABLE library_tags RENAME TO tags;
+ALTER TABLE library_game_tags RENAME TO game_tags;
+CREATE INDEX idx_game_tags_tag ON game_tags(tag_id);
+
+CREATE TABLE library_loan_policies (
+	library_id INTEGER NOT NULL DEFAULT 1,
+	tier TEXT NOT NULL,
+	max_loans INTEGER NOT NULL,
+	max_loan_days INTEGER NOT NULL,
+	default_loan_days INTEGER NOT NULL,
+	max_extensions INTEGER NOT NULL,
+	PRIMARY KEY (library_id, tier),
+	FOREIGN KEY (library_id) REFERENCES libraries(id)
+);
+INSERT INTO library_loan_policies (tier, max_loans, max_loan_days, default_loan_days, max_extensions)
+	SELECT tier, max_loans, max_loan_days, default_loan_days, max_extensions FROM loan_policies;
+DROP TABLE loan_policies;
+ALTER TABLE library_loan_policies RENAME TO loan_policies;
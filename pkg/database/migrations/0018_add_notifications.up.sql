@@ -0,0 +1,21 @@
+-- Delivery status of the email sent for each alert. Alerts raised before
+-- notifications existed are not sent.
+CREATE TABLE alert_notifications (
+	alert_id INTEGER PRIMARY KEY,
+	status TEXT NOT NULL DEFAULT 'pending',
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	next_attempt_at DATETIME,
+	sent_at DATETIME,
+	updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (alert_id) REFERENCES alerts(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_alert_notifications_status ON alert_notifications(status, next_attempt_at);
+INSERT INTO alert_notifications (alert_id, status) SELECT id, 'skipped' FROM alerts;
+
+CREATE TABLE notification_preferences (
+	user_id INTEGER PRIMARY KEY,
+	email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+	language TEXT NOT NULL DEFAULT '',
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
@@ -0,0 +1,26 @@
+-- Late fees of each membership tier, in cents. Tiers charge nothing until
+-- configured, and a NULL balance limit never keeps a user from borrowing.
+ALTER TABLE loan_policies ADD COLUMN fine_per_day_cents INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE loan_policies ADD COLUMN fine_grace_days INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE loan_policies ADD COLUMN fine_cap_cents INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE loan_policies ADD COLUMN max_fine_balance_cents INTEGER;
+
+-- Ledger of the fines charged to users, and of the payments and waivers
+-- settling them. Entries are never changed; the balance of a user is the
+-- sum of their fines minus their payments and waivers.
+CREATE TABLE fine_entries (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	library_id INTEGER NOT NULL DEFAULT 1,
+	user_id INTEGER NOT NULL,
+	borrowing_id INTEGER,
+	kind TEXT NOT NULL,
+	amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+	reason TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL,
+	FOREIGN KEY (library_id) REFERENCES libraries(id),
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	FOREIGN KEY (borrowing_id) REFERENCES borrowings(id) ON DELETE SET NULL
+);
+CREATE INDEX idx_fine_entries_user ON fine_entries(library_id, user_id, created_at);
+-- A late return is charged once
+CREATE UNIQUE INDEX idx_fine_entries_borrowing ON fine_entries(borrowing_id) WHERE kind = 'fine';
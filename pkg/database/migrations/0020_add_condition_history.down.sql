@@ -0,0 +1,2 @@
+DROP TABLE condition_changes;
+ALTER TABLE game_copies DROP COLUMN in_repair;
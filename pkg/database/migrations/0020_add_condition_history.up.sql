@@ -0,0 +1,23 @@
+-- Copies returned in poor condition or with missing pieces are kept from
+-- lending until a librarian records their repair
+ALTER TABLE game_copies ADD COLUMN in_repair BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- History of the condition of each game, recorded at returns and when a
+-- librarian inspects or repairs a copy. Changes of the game itself have no
+-- copy, and changes outside a return no borrowing.
+CREATE TABLE condition_changes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	library_id INTEGER NOT NULL DEFAULT 1,
+	game_id INTEGER NOT NULL,
+	copy_id INTEGER,
+	borrowing_id INTEGER,
+	previous_condition TEXT NOT NULL DEFAULT '',
+	condition TEXT NOT NULL,
+	notes TEXT NOT NULL DEFAULT '',
+	recorded_at DATETIME NOT NULL,
+	FOREIGN KEY (library_id) REFERENCES libraries(id),
+	FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+	FOREIGN KEY (copy_id) REFERENCES game_copies(id) ON DELETE SET NULL,
+	FOREIGN KEY (borrowing_id) REFERENCES borrowings(id) ON DELETE SET NULL
+);
+CREATE INDEX idx_condition_changes_game ON condition_changes(library_id, game_id, recorded_at);
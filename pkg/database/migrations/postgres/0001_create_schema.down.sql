@@ -0,0 +1,14 @@
+DROP TABLE game_tags;
+DROP TABLE tags;
+DROP TABLE audit_events;
+DROP FUNCTION audit_events_append_only;
+DROP TABLE api_tokens;
+DROP TABLE sessions;
+DROP TABLE loan_policies;
+DROP TABLE reservations;
+DROP TABLE job_runs;
+DROP TABLE alerts;
+DROP TABLE borrowings;
+DROP TABLE game_copies;
+DROP TABLE games;
+DROP TABLE users;
@@ -0,0 +1,166 @@
+-- The schema of SQLite migrations 1 to 16. Games are searched with the
+-- built-in full-text search of PostgreSQL, so there is no search table.
+CREATE TABLE users (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	email TEXT UNIQUE NOT NULL,
+	registered_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	is_active BOOLEAN DEFAULT TRUE,
+	membership_tier TEXT NOT NULL DEFAULT 'standard',
+	role TEXT NOT NULL DEFAULT 'member',
+	password_hash TEXT NOT NULL DEFAULT ''
+);
+CREATE TABLE games (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	description TEXT,
+	entry_date TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	condition TEXT DEFAULT 'good',
+	is_available BOOLEAN DEFAULT TRUE,
+	min_players INTEGER NOT NULL DEFAULT 0,
+	max_players INTEGER NOT NULL DEFAULT 0,
+	play_time INTEGER NOT NULL DEFAULT 0,
+	min_age INTEGER NOT NULL DEFAULT 0,
+	publisher TEXT NOT NULL DEFAULT '',
+	designers TEXT NOT NULL DEFAULT '[]',
+	year_published INTEGER NOT NULL DEFAULT 0,
+	complexity DOUBLE PRECISION NOT NULL DEFAULT 0,
+	image_url TEXT NOT NULL DEFAULT '',
+	bgg_id INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_games_players ON games(min_players, max_players);
+CREATE INDEX idx_games_play_time ON games(play_time);
+CREATE TABLE game_copies (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	game_id INTEGER NOT NULL,
+	barcode TEXT NOT NULL DEFAULT '',
+	condition TEXT DEFAULT 'good',
+	acquired_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	is_available BOOLEAN DEFAULT TRUE,
+	FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_game_copies_game_id ON game_copies(game_id);
+CREATE UNIQUE INDEX idx_game_copies_barcode ON game_copies(barcode) WHERE barcode <> '';
+CREATE TABLE borrowings (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	game_id INTEGER NOT NULL,
+	borrowed_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	due_date TIMESTAMPTZ NOT NULL,
+	returned_at TIMESTAMPTZ,
+	is_overdue BOOLEAN DEFAULT FALSE,
+	copy_id INTEGER REFERENCES game_copies(id),
+	extension_count INTEGER NOT NULL DEFAULT 0,
+	FOREIGN KEY (user_id) REFERENCES users(id),
+	FOREIGN KEY (game_id) REFERENCES games(id)
+);
+CREATE INDEX idx_borrowings_user_id ON borrowings(user_id);
+CREATE INDEX idx_borrowings_game_id ON borrowings(game_id);
+CREATE INDEX idx_borrowings_due_date ON borrowings(due_date);
+CREATE INDEX idx_borrowings_copy_id ON borrowings(copy_id);
+CREATE TABLE alerts (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	game_id INTEGER NOT NULL,
+	type TEXT NOT NULL,
+	message TEXT NOT NULL,
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	is_read BOOLEAN DEFAULT FALSE,
+	FOREIGN KEY (user_id) REFERENCES users(id),
+	FOREIGN KEY (game_id) REFERENCES games(id)
+);
+CREATE INDEX idx_alerts_user_id ON alerts(user_id);
+CREATE INDEX idx_alerts_is_read ON alerts(is_read);
+CREATE TABLE job_runs (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	job_name TEXT NOT NULL,
+	status TEXT NOT NULL,
+	started_at TIMESTAMPTZ NOT NULL,
+	finished_at TIMESTAMPTZ,
+	duration_ms BIGINT DEFAULT 0,
+	error TEXT DEFAULT ''
+);
+CREATE INDEX idx_job_runs_job_name ON job_runs(job_name, started_at);
+CREATE TABLE reservations (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	game_id INTEGER NOT NULL,
+	copy_id INTEGER,
+	status TEXT NOT NULL DEFAULT 'waiting',
+	created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+	notified_at TIMESTAMPTZ,
+	expires_at TIMESTAMPTZ,
+	closed_at TIMESTAMPTZ,
+	FOREIGN KEY (user_id) REFERENCES users(id),
+	FOREIGN KEY (game_id) REFERENCES games(id),
+	FOREIGN KEY (copy_id) REFERENCES game_copies(id)
+);
+CREATE INDEX idx_reservations_game_status ON reservations(game_id, status, created_at);
+CREATE INDEX idx_reservations_user_id ON reservations(user_id);
+CREATE TABLE loan_policies (
+	tier TEXT PRIMARY KEY,
+	max_loans INTEGER NOT NULL,
+	max_loan_days INTEGER NOT NULL,
+	default_loan_days INTEGER NOT NULL,
+	max_extensions INTEGER NOT NULL
+);
+INSERT INTO loan_policies (tier, max_loans, max_loan_days, default_loan_days, max_extensions) VALUES
+	('basic', 2, 30, 14, 1),
+	('standard', 5, 90, 14, 3),
+	('premium', 10, 120, 21, 5);
+CREATE TABLE sessions (
+	token_hash TEXT PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_sessions_user ON sessions(user_id);
+CREATE INDEX idx_sessions_expires ON sessions(expires_at);
+CREATE TABLE api_tokens (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	last_used_at TIMESTAMPTZ,
+	FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_api_tokens_user ON api_tokens(user_id);
+CREATE TABLE audit_events (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	actor_id INTEGER,
+	actor_name TEXT NOT NULL,
+	action TEXT NOT NULL,
+	entity_type TEXT NOT NULL,
+	entity_id TEXT NOT NULL DEFAULT '',
+	before_json TEXT,
+	after_json TEXT,
+	created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_audit_events_created ON audit_events(created_at);
+CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_id);
+CREATE INDEX idx_audit_events_actor ON audit_events(actor_id);
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit events are append-only';
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+	FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+	FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+CREATE TABLE tags (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	slug TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL
+);
+CREATE TABLE game_tags (
+	game_id INTEGER NOT NULL,
+	tag_id INTEGER NOT NULL,
+	PRIMARY KEY (game_id, tag_id),
+	FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE,
+	FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
+CREATE INDEX idx_game_tags_tag ON game_tags(tag_id);
@@ -0,0 +1,19 @@
+-- Rolling back merges every library into one, as in SQLite migration 17
+DELETE FROM loan_policies WHERE library_id <> 1;
+ALTER TABLE loan_policies DROP CONSTRAINT loan_policies_pkey;
+ALTER TABLE loan_policies ADD PRIMARY KEY (tier);
+ALTER TABLE tags DROP CONSTRAINT tags_library_slug_key;
+ALTER TABLE tags ADD CONSTRAINT tags_slug_key UNIQUE (slug);
+DROP INDEX idx_game_copies_barcode;
+CREATE UNIQUE INDEX idx_game_copies_barcode ON game_copies(barcode) WHERE barcode <> '';
+
+ALTER TABLE loan_policies DROP COLUMN library_id;
+ALTER TABLE tags DROP COLUMN library_id;
+ALTER TABLE audit_events DROP COLUMN library_id;
+ALTER TABLE reservations DROP COLUMN library_id;
+ALTER TABLE alerts DROP COLUMN library_id;
+ALTER TABLE borrowings DROP COLUMN library_id;
+ALTER TABLE game_copies DROP COLUMN library_id;
+ALTER TABLE games DROP COLUMN library_id;
+ALTER TABLE users DROP COLUMN library_id;
+DROP TABLE libraries;
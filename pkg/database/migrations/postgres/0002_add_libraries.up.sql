@@ -0,0 +1,34 @@
+-- Several libraries share one deployment, as in SQLite migration 17. The
+-- rows created before libraries existed belong to the first library.
+CREATE TABLE libraries (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	slug TEXT NOT NULL UNIQUE,
+	name TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL
+);
+INSERT INTO libraries (id, slug, name, created_at) VALUES (1, 'default', 'Bibliothèque', CURRENT_TIMESTAMP);
+SELECT setval(pg_get_serial_sequence('libraries', 'id'), 1);
+
+ALTER TABLE users ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id);
+ALTER TABLE games ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id);
+ALTER TABLE game_copies ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id);
+ALTER TABLE borrowings ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id);
+ALTER TABLE alerts ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id);
+ALTER TABLE reservations ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id);
+ALTER TABLE audit_events ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id);
+ALTER TABLE tags ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id);
+ALTER TABLE loan_policies ADD COLUMN library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id);
+CREATE INDEX idx_users_library ON users(library_id);
+CREATE INDEX idx_games_library ON games(library_id);
+CREATE INDEX idx_borrowings_library ON borrowings(library_id);
+CREATE INDEX idx_alerts_library ON alerts(library_id);
+CREATE INDEX idx_reservations_library ON reservations(library_id);
+CREATE INDEX idx_audit_events_library ON audit_events(library_id, created_at);
+
+-- Barcodes, tag slugs and membership tiers are unique within a library
+DROP INDEX idx_game_copies_barcode;
+CREATE UNIQUE INDEX idx_game_copies_barcode ON game_copies(library_id, barcode) WHERE barcode <> '';
+ALTER TABLE tags DROP CONSTRAINT tags_slug_key;
+ALTER TABLE tags ADD CONSTRAINT tags_library_slug_key UNIQUE (library_id, slug);
+ALTER TABLE loan_policies DROP CONSTRAINT loan_policies_pkey;
+ALTER TABLE loan_policies ADD PRIMARY KEY (library_id, tier);
@@ -0,0 +1,19 @@
+-- Delivery status of the email sent for each alert, as in SQLite migration
+-- 18. Alerts raised before notifications existed are not sent.
+CREATE TABLE alert_notifications (
+	alert_id INTEGER PRIMARY KEY REFERENCES alerts(id) ON DELETE CASCADE,
+	status TEXT NOT NULL DEFAULT 'pending',
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	next_attempt_at TIMESTAMPTZ,
+	sent_at TIMESTAMPTZ,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_alert_notifications_status ON alert_notifications(status, next_attempt_at);
+INSERT INTO alert_notifications (alert_id, status) SELECT id, 'skipped' FROM alerts;
+
+CREATE TABLE notification_preferences (
+	user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+	email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+	language TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,5 @@
+DROP TABLE fine_entries;
+ALTER TABLE loan_policies DROP COLUMN max_fine_balance_cents;
+ALTER TABLE loan_policies DROP COLUMN fine_cap_cents;
+ALTER TABLE loan_policies DROP COLUMN fine_grace_days;
+ALTER TABLE loan_policies DROP COLUMN fine_per_day_cents;
@@ -0,0 +1,19 @@
+-- Late fees of each membership tier and the fines ledger, as in SQLite
+-- migration 19
+ALTER TABLE loan_policies ADD COLUMN fine_per_day_cents INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE loan_policies ADD COLUMN fine_grace_days INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE loan_policies ADD COLUMN fine_cap_cents INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE loan_policies ADD COLUMN max_fine_balance_cents INTEGER;
+
+CREATE TABLE fine_entries (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id),
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	borrowing_id INTEGER REFERENCES borrowings(id) ON DELETE SET NULL,
+	kind TEXT NOT NULL,
+	amount_cents INTEGER NOT NULL CHECK (amount_cents > 0),
+	reason TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_fine_entries_user ON fine_entries(library_id, user_id, created_at);
+CREATE UNIQUE INDEX idx_fine_entries_borrowing ON fine_entries(borrowing_id) WHERE kind = 'fine';
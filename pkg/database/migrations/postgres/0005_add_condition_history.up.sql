@@ -0,0 +1,16 @@
+-- Copies kept from lending until repaired and the condition history of
+-- games, as in SQLite migration 20
+ALTER TABLE game_copies ADD COLUMN in_repair BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE condition_changes (
+	id INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	library_id INTEGER NOT NULL DEFAULT 1 REFERENCES libraries(id),
+	game_id INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+	copy_id INTEGER REFERENCES game_copies(id) ON DELETE SET NULL,
+	borrowing_id INTEGER REFERENCES borrowings(id) ON DELETE SET NULL,
+	previous_condition TEXT NOT NULL DEFAULT '',
+	condition TEXT NOT NULL,
+	notes TEXT NOT NULL DEFAULT '',
+	recorded_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_condition_changes_game ON condition_changes(library_id, game_id, recorded_at);
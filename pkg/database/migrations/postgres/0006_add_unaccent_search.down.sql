@@ -0,0 +1,2 @@
+-- The unaccent extension is kept: other schemas of the database may use it
+DROP TEXT SEARCH CONFIGURATION game_search;
@@ -0,0 +1,8 @@
+-- Accent-insensitive game search, matching the FTS5 index of SQLite: the
+-- game_search text search configuration removes the accents of words
+-- before indexing them like the simple configuration
+CREATE EXTENSION IF NOT EXISTS unaccent SCHEMA public;
+
+CREATE TEXT SEARCH CONFIGURATION game_search (COPY = simple);
+ALTER TEXT SEARCH CONFIGURATION game_search
+	ALTER MAPPING FOR hword, hword_part, word WITH public.unaccent, simple;
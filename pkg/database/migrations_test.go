@@ -1,7 +1,9 @@
 package database
 
 import (
+	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestMigrationManager_Initialize(t *testing.T) {
@@ -64,7 +66,7 @@ func TestMigrationManager_Migrate(t *testing.T) {
 		t.Fatalf("Failed to count migrations: %v", err)
 	}
 	
-	expectedMigrations := len(getInitialMigrations())
+	expectedMigrations := supportedMigrations(db)
 	if migrationCount != expectedMigrations {
 		t.Errorf("Expected %d migrations to be recorded, but got %d", expectedMigrations, migrationCount)
 	}
@@ -95,8 +97,484 @@ func TestMigrationManager_MigrateIdempotent(t *testing.T) {
 		t.Fatalf("Failed to count migrations: %v", err)
 	}
 	
-	expectedMigrations := len(getInitialMigrations())
+	expectedMigrations := supportedMigrations(db)
 	if migrationCount != expectedMigrations {
 		t.Errorf("Expected %d migrations to be recorded after running twice, but got %d", expectedMigrations, migrationCount)
 	}
-}
\ No newline at end of file
+}
+func TestMigrationManager_GameCopiesBackfill(t *testing.T) {
+	db, err := NewConnection(Config{DatabasePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Migrate to the schema before copies were introduced
+	mm := NewMigrationManager(db)
+	var before []Migration
+	for _, migration := range getInitialMigrations() {
+		if migration.Version < 7 {
+			before = append(before, migration)
+		}
+	}
+	mm.migrations = before
+	if err := mm.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO users (id, name, email) VALUES (1, 'Alice', 'alice@example.com');
+		INSERT INTO games (id, name, condition, is_available) VALUES (1, 'Catan', 'fair', FALSE);
+		INSERT INTO games (id, name, condition, is_available) VALUES (2, 'Azul', 'good', TRUE);
+		INSERT INTO borrowings (user_id, game_id, due_date) VALUES (1, 1, '2030-01-01');`)
+	if err != nil {
+		t.Fatalf("Failed to insert legacy data: %v", err)
+	}
+
+	mm.migrations = getInitialMigrations()
+	if err := mm.Migrate(); err != nil {
+		t.Fatalf("Failed to run copies migration: %v", err)
+	}
+
+	var copyCount int
+	if err := db.QueryRow("SELECT COUNT(*) FROM game_copies").Scan(&copyCount); err != nil {
+		t.Fatalf("Failed to count copies: %v", err)
+	}
+	if copyCount != 2 {
+		t.Errorf("Expected one copy per existing game, got %d", copyCount)
+	}
+
+	var condition string
+	var isAvailable bool
+	err = db.QueryRow("SELECT condition, is_available FROM game_copies WHERE game_id = 1").Scan(&condition, &isAvailable)
+	if err != nil {
+		t.Fatalf("Failed to query copy: %v", err)
+	}
+	if condition != "fair" || isAvailable {
+		t.Errorf("Expected borrowed copy in fair condition, got %s available=%t", condition, isAvailable)
+	}
+
+	var linked int
+	err = db.QueryRow(`
+		SELECT COUNT(*) FROM borrowings b
+		JOIN game_copies c ON c.id = b.copy_id AND c.game_id = b.game_id`).Scan(&linked)
+	if err != nil {
+		t.Fatalf("Failed to query borrowings: %v", err)
+	}
+	if linked != 1 {
+		t.Errorf("Expected existing borrowing to be linked to its copy, got %d", linked)
+	}
+}
+
+func TestMigrationManager_CategoriesToTags(t *testing.T) {
+	db, err := NewConnection(Config{DatabasePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// Migrate to the schema before tags were introduced
+	mm := NewMigrationManager(db)
+	var before []Migration
+	for _, migration := range getInitialMigrations() {
+		if migration.Version < 14 {
+			before = append(before, migration)
+		}
+	}
+	mm.migrations = before
+	if err := mm.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO games (id, name, category, condition) VALUES (1, 'Catan', 'Stratégie', 'good');
+		INSERT INTO games (id, name, category, condition) VALUES (2, 'Risk', ' strategie ', 'good');
+		INSERT INTO games (id, name, category, condition) VALUES (3, 'Dixit', 'Famille', 'good');
+		INSERT INTO games (id, name, category, condition) VALUES (4, 'Go', '', 'good');`)
+	if err != nil {
+		t.Fatalf("Failed to insert legacy data: %v", err)
+	}
+
+	mm.migrations = getInitialMigrations()
+	if err := mm.Migrate(); err != nil {
+		t.Fatalf("Failed to run tags migration: %v", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT g.name, t.name, t.slug FROM game_tags gt
+		JOIN games g ON g.id = gt.game_id JOIN tags t ON t.id = gt.tag_id
+		ORDER BY g.id`)
+	if err != nil {
+		t.Fatalf("Failed to query game tags: %v", err)
+	}
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var game, tag, tagSlug string
+		if err := rows.Scan(&game, &tag, &tagSlug); err != nil {
+			t.Fatalf("Failed to scan game tag: %v", err)
+		}
+		got = append(got, game+":"+tag+":"+tagSlug)
+	}
+	want := []string{"Catan:Stratégie:strategie", "Risk:Stratégie:strategie", "Dixit:Famille:famille"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected game tags %v, got %v", want, got)
+	}
+
+	var categorized int
+	if err := db.QueryRow("SELECT COUNT(*) FROM games WHERE category <> ''").Scan(&categorized); err != nil {
+		t.Fatalf("Failed to count categories: %v", err)
+	}
+	if categorized != 0 {
+		t.Errorf("Expected categories to be cleared, got %d", categorized)
+	}
+}
+
+func TestMigrationManager_Libraries(t *testing.T) {
+	db, err := NewConnection(Config{DatabasePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	mm := NewMigrationManager(db)
+	if err := mm.MigrateTo(16); err != nil {
+		t.Fatalf("MigrateTo(16) error = %v", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO users (id, name, email) VALUES (1, 'Alice', 'alice@example.com');
+		INSERT INTO games (id, name, condition) VALUES (1, 'Catan', 'good');
+		INSERT INTO game_copies (game_id, barcode) VALUES (1, 'LIB-1');
+		INSERT INTO tags (id, name, slug, created_at) VALUES (1, 'Stratégie', 'strategie', CURRENT_TIMESTAMP);
+		INSERT INTO game_tags (game_id, tag_id) VALUES (1, 1);`)
+	if err != nil {
+		t.Fatalf("Failed to insert legacy data: %v", err)
+	}
+
+	if err := mm.Migrate(); err != nil {
+		t.Fatalf("Failed to run libraries migration: %v", err)
+	}
+
+	// Existing rows belong to the default library
+	var users, games, tagged int
+	err = db.QueryRow(`SELECT
+		(SELECT COUNT(*) FROM users WHERE library_id = 1),
+		(SELECT COUNT(*) FROM games WHERE library_id = 1),
+		(SELECT COUNT(*) FROM game_tags gt JOIN tags t ON t.id = gt.tag_id WHERE t.library_id = 1)`).Scan(&users, &games, &tagged)
+	if err != nil {
+		t.Fatalf("Failed to count migrated rows: %v", err)
+	}
+	if users != 1 || games != 1 || tagged != 1 {
+		t.Errorf("Expected the rows to belong to the default library, got %d users, %d games and %d tagged games", users, games, tagged)
+	}
+
+	// Another library may reuse tag slugs, barcodes and membership tiers
+	_, err = db.Exec(`
+		INSERT INTO libraries (id, slug, name, created_at) VALUES (2, 'ludo', 'Ludothèque', CURRENT_TIMESTAMP);
+		INSERT INTO games (id, name, condition, library_id) VALUES (2, 'Catan', 'good', 2);
+		INSERT INTO game_copies (game_id, barcode, library_id) VALUES (2, 'LIB-1', 2);
+		INSERT INTO tags (name, slug, created_at, library_id) VALUES ('Stratégie', 'strategie', CURRENT_TIMESTAMP, 2);
+		INSERT INTO loan_policies (library_id, tier, max_loans, max_loan_days, default_loan_days, max_extensions)
+			VALUES (2, 'standard', 1, 7, 7, 0);`)
+	if err != nil {
+		t.Errorf("Expected libraries to have their own slugs, barcodes and tiers: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO tags (name, slug, created_at) VALUES ('Strategie', 'strategie', CURRENT_TIMESTAMP)"); err == nil {
+		t.Error("Expected tag slugs to stay unique within a library")
+	}
+
+	if SupportsFTS5(db) {
+		var triggers int
+		if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name IN ('game_tags_fts_insert', 'game_tags_fts_delete', 'tags_fts_update')").Scan(&triggers); err != nil || triggers != 3 {
+			t.Errorf("Expected the tag search triggers to be restored, got %d (%v)", triggers, err)
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `
+		CREATE TABLE t (id INTEGER);
+		CREATE TRIGGER t_no_delete BEFORE DELETE ON t
+		BEGIN
+			SELECT RAISE(ABORT, 'read-only');
+		END;
+		CREATE INDEX idx_t ON t(id);
+	`
+
+	statements := splitStatements(script)
+	if len(statements) != 3 {
+		t.Fatalf("Expected 3 statements, got %d: %q", len(statements), statements)
+	}
+
+	trigger := statements[1]
+	if !strings.HasPrefix(trigger, "CREATE TRIGGER") || !strings.HasSuffix(trigger, "END") {
+		t.Errorf("Expected the whole trigger in one statement, got %q", trigger)
+	}
+}
+
+// supportedMigrations counts the migrations this build can apply
+func supportedMigrations(q Querier) int {
+	count := 0
+	for _, migration := range getInitialMigrations() {
+		if migration.Requires == nil || migration.Requires(q) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestMigrationManager_Requires(t *testing.T) {
+	db, err := NewConnection(Config{DatabasePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	supported := false
+	mm := NewMigrationManager(db)
+	mm.migrations = []Migration{
+		{Version: 1, Name: "create_t", Up: "CREATE TABLE t (id INTEGER)"},
+		{
+			Version:  2,
+			Name:     "create_optional",
+			Up:       "CREATE TABLE optional (id INTEGER)",
+			Requires: func(q Querier) bool { return supported },
+		},
+	}
+
+	if err := mm.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	if exists, _ := HasTable(db, "optional"); exists {
+		t.Error("Expected unsupported migration to stay pending")
+	}
+
+	supported = true
+	if err := mm.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations once supported: %v", err)
+	}
+	if exists, _ := HasTable(db, "optional"); !exists {
+		t.Error("Expected pending migration to be applied once supported")
+	}
+
+	supported = false
+	if err := mm.Migrate(); err == nil {
+		t.Error("Expected an error when an applied migration is not supported by this build")
+	}
+}
+
+func TestMigrationManager_RollbackAndMigrateTo(t *testing.T) {
+	db, err := InitializeForTesting()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		INSERT INTO users (id, name, email) VALUES (1, 'Alice', 'alice@example.com');
+		INSERT INTO games (id, name, condition) VALUES (1, 'Catan', 'good');
+		INSERT INTO game_copies (game_id) VALUES (1);
+		INSERT INTO tags (id, name, slug, created_at) VALUES (1, 'Stratégie', 'strategie', CURRENT_TIMESTAMP);
+		INSERT INTO game_tags (game_id, tag_id) VALUES (1, 1);
+		INSERT INTO borrowings (user_id, game_id, copy_id, due_date) VALUES (1, 1, 1, '2030-01-01');`)
+	if err != nil {
+		t.Fatalf("Failed to insert data: %v", err)
+	}
+
+	mm := NewMigrationManager(db)
+	if err := mm.MigrateTo(13); err != nil {
+		t.Fatalf("MigrateTo(13) error = %v", err)
+	}
+	if version, _ := SchemaVersion(db); version != 13 {
+		t.Errorf("Expected schema version 13, got %d", version)
+	}
+
+	// Rolling back the tags gives each game its first tag as category
+	var category string
+	if err := db.QueryRow("SELECT category FROM games WHERE id = 1").Scan(&category); err != nil || category != "Stratégie" {
+		t.Errorf("Expected the tag to become the category, got %q (%v)", category, err)
+	}
+
+	if err := mm.Rollback(1); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if version, _ := SchemaVersion(db); version >= 13 {
+		t.Errorf("Expected migration 13 to be rolled back, got version %d", version)
+	}
+
+	// Every migration can be rolled back and applied again
+	if err := mm.MigrateTo(0); err != nil {
+		t.Fatalf("MigrateTo(0) error = %v", err)
+	}
+	if exists, _ := HasTable(db, "users"); exists {
+		t.Error("Expected every table to be dropped at version 0")
+	}
+
+	if err := mm.MigrateTo(9); err != nil {
+		t.Fatalf("MigrateTo(9) error = %v", err)
+	}
+	if version, _ := SchemaVersion(db); version != 9 {
+		t.Errorf("Expected schema version 9, got %d", version)
+	}
+
+	if err := mm.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if version, _ := SchemaVersion(db); version != LatestSchemaVersion() {
+		t.Errorf("Expected the latest schema version, got %d", version)
+	}
+
+	if err := mm.MigrateTo(LatestSchemaVersion() + 1); err == nil || !strings.Contains(err.Error(), "unknown migration version") {
+		t.Errorf("Expected an unknown version error, got %v", err)
+	}
+	if err := mm.Rollback(100); err == nil || !strings.Contains(err.Error(), "only") {
+		t.Errorf("Expected an error rolling back more migrations than applied, got %v", err)
+	}
+}
+
+func TestMigrationManager_RollbackIsAtomic(t *testing.T) {
+	db, err := NewConnection(Config{DatabasePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	mm := NewMigrationManager(db)
+	mm.migrations = []Migration{
+		{Version: 1, Name: "create_t", Up: "CREATE TABLE t (id INTEGER)"},
+		{Version: 2, Name: "create_u", Up: "CREATE TABLE u (id INTEGER)", Down: "DROP TABLE u"},
+		{Version: 3, Name: "create_v", Up: "CREATE TABLE v (id INTEGER)", Down: "DROP TABLE v"},
+	}
+	if err := mm.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	// Migration 1 has no Down: the rollback of 3 and 2 is undone too
+	err = mm.Rollback(3)
+	if err == nil || !strings.Contains(err.Error(), "migration 1 (create_t) cannot be rolled back") {
+		t.Fatalf("Expected an irreversible migration error, got %v", err)
+	}
+	if exists, _ := HasTable(db, "v"); !exists {
+		t.Error("Expected the failed rollback to leave every migration applied")
+	}
+}
+
+func TestMigrationManager_Checksums(t *testing.T) {
+	db, err := NewConnection(Config{DatabasePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	// A database migrated before checksums were recorded
+	_, err = db.Exec(`
+		CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, name TEXT NOT NULL, applied_at DATETIME DEFAULT CURRENT_TIMESTAMP);
+		CREATE TABLE t (id INTEGER);
+		INSERT INTO schema_migrations (version, name) VALUES (1, 'create_t');`)
+	if err != nil {
+		t.Fatalf("Failed to create legacy database: %v", err)
+	}
+
+	mm := NewMigrationManager(db)
+	mm.migrations = []Migration{
+		{Version: 1, Name: "create_t", Up: "CREATE TABLE t (id INTEGER)", Down: "DROP TABLE t"},
+	}
+	if err := mm.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	var checksum string
+	if err := db.QueryRow("SELECT checksum FROM schema_migrations WHERE version = 1").Scan(&checksum); err != nil || checksum != mm.migrations[0].Checksum() {
+		t.Errorf("Expected the checksum to be recorded, got %q (%v)", checksum, err)
+	}
+
+	// The applied migration is changed afterwards
+	mm.migrations[0].Up = "CREATE TABLE t (id INTEGER, name TEXT)"
+	if err := mm.Migrate(); err == nil || !strings.Contains(err.Error(), "was modified after it was applied") {
+		t.Errorf("Expected a checksum error, got %v", err)
+	}
+	if err := mm.Rollback(1); err == nil {
+		t.Error("Expected rollbacks to be refused with a modified migration")
+	}
+
+	statuses, err := mm.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if len(statuses) != 1 || !statuses[0].Applied || !statuses[0].Modified {
+		t.Errorf("Expected the migration to be reported as modified, got %+v", statuses)
+	}
+}
+
+func TestMigrationManager_Status(t *testing.T) {
+	db, err := NewConnection(Config{DatabasePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	mm := NewMigrationManager(db)
+	if err := mm.MigrateTo(2); err != nil {
+		t.Fatalf("MigrateTo(2) error = %v", err)
+	}
+	db.Exec("INSERT INTO schema_migrations (version, name) VALUES (999, 'from_a_newer_build')")
+
+	statuses, err := mm.Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if len(statuses) != LatestSchemaVersion()+1 {
+		t.Fatalf("Expected every known migration and the unknown one, got %d", len(statuses))
+	}
+	if !statuses[1].Applied || statuses[1].AppliedAt == nil || statuses[2].Applied || !statuses[2].Reversible {
+		t.Errorf("Expected migrations 1 and 2 applied and 3 pending, got %+v %+v", statuses[1], statuses[2])
+	}
+	if last := statuses[len(statuses)-1]; last.Version != 999 || last.Known || !last.Applied {
+		t.Errorf("Expected the unknown applied migration last, got %+v", last)
+	}
+
+	if err := mm.Migrate(); err == nil || !strings.Contains(err.Error(), "unknown to this build") {
+		t.Errorf("Expected migrations to be refused on a database of a newer build, got %v", err)
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0002_add_name.up.sql":        {Data: []byte("-- Names are optional\nALTER TABLE t ADD COLUMN name TEXT;\n")},
+		"sql/0002_add_name.down.sql":      {Data: []byte("ALTER TABLE t DROP COLUMN name;\n")},
+		"sql/0001_create_t.up.sql":        {Data: []byte("CREATE TABLE t (id INTEGER);\n")},
+		"sql/README.md":                   {Data: []byte("not a migration")},
+		"other/0003_elsewhere.up.sql":     {Data: []byte("CREATE TABLE x (id INTEGER);\n")},
+		"broken/create_t.up.sql":          {Data: []byte("CREATE TABLE t (id INTEGER);\n")},
+		"renamed/0001_create_t.up.sql":    {Data: []byte("CREATE TABLE t (id INTEGER);\n")},
+		"renamed/0001_drop_t.down.sql":    {Data: []byte("DROP TABLE t;\n")},
+		"downonly/0001_create_t.down.sql": {Data: []byte("DROP TABLE t;\n")},
+	}
+
+	migrations, err := LoadMigrations(fsys, "sql")
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Name != "create_t" || migrations[1].Version != 2 {
+		t.Fatalf("Expected migrations 1 and 2 in order, got %+v", migrations)
+	}
+	if strings.Contains(migrations[1].Up, "optional") || !strings.Contains(migrations[1].Up, "ADD COLUMN name") {
+		t.Errorf("Expected comments to be removed, got %q", migrations[1].Up)
+	}
+	if migrations[0].Reversible() || !migrations[1].Reversible() {
+		t.Error("Expected only the migration with a down file to be reversible")
+	}
+
+	for dir, wantErr := range map[string]string{
+		"broken":   "invalid migration file name",
+		"renamed":  "has two names",
+		"downonly": "has no up file",
+		"missing":  "failed to read migrations",
+	} {
+		if _, err := LoadMigrations(fsys, dir); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("LoadMigrations(%s) error = %v, want %q", dir, err, wantErr)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+//go:build postgres
+
+package database
+
+// The pgx driver is only linked into builds made with -tags postgres, so
+// that SQLite-only builds do not depend on it. Add it to the module with
+//
+//	go get github.com/jackc/pgx/v5
+import _ "github.com/jackc/pgx/v5/stdlib"
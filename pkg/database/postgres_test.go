@@ -0,0 +1,142 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT 1", "SELECT 1"},
+		{"SELECT * FROM users WHERE id = ?", "SELECT * FROM users WHERE id = $1"},
+		{"INSERT INTO t (a, b) VALUES (?, ?)", "INSERT INTO t (a, b) VALUES ($1, $2)"},
+		{"SELECT '?' AS q, \"col?\" FROM t WHERE a = ?", "SELECT '?' AS q, \"col?\" FROM t WHERE a = $1"},
+		{"SELECT 'it''s' WHERE a = ? AND b = ?", "SELECT 'it''s' WHERE a = $1 AND b = $2"},
+	}
+
+	for _, tt := range tests {
+		if got := Rebind(tt.query); got != tt.want {
+			t.Errorf("Rebind(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestDriverOf(t *testing.T) {
+	db, err := NewConnection(Config{DatabasePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	if db.Driver() != DriverSQLite || IsPostgres(db) {
+		t.Errorf("Expected a SQLite database, got %s", db.Driver())
+	}
+
+	err = db.WithTx(func(tx Querier) error {
+		if IsPostgres(tx) {
+			t.Error("Expected a SQLite transaction")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error = %v", err)
+	}
+
+	if !IsPostgres(dbTx{driver: DriverPostgres}) || !IsPostgres(&DB{driver: DriverPostgres}) {
+		t.Error("Expected PostgreSQL connections and transactions to be recognised")
+	}
+}
+
+func TestNewConnectionPostgres(t *testing.T) {
+	if _, err := NewConnection(Config{Driver: DriverPostgres}); err == nil || !strings.Contains(err.Error(), "URL cannot be empty") {
+		t.Errorf("Expected an error without URL, got %v", err)
+	}
+
+	if _, err := NewConnection(Config{Driver: "mysql"}); err == nil || !strings.Contains(err.Error(), "unknown database driver") {
+		t.Errorf("Expected an unknown driver error, got %v", err)
+	}
+
+	if !slices.Contains(sql.Drivers(), postgresDriverName) {
+		_, err := NewConnection(Config{Driver: DriverPostgres, URL: "postgres://localhost/library"})
+		if err == nil || !strings.Contains(err.Error(), "-tags postgres") {
+			t.Errorf("Expected an error telling how to build PostgreSQL support, got %v", err)
+		}
+	}
+}
+
+func TestConstraintViolations(t *testing.T) {
+	db, err := InitializeForTesting()
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec("INSERT INTO users (name, email) VALUES ('Alice', 'alice@example.com')"); err != nil {
+		t.Fatalf("Failed to insert user: %v", err)
+	}
+
+	_, err = db.Exec("INSERT INTO users (name, email) VALUES ('Alice', 'alice@example.com')")
+	if !IsUniqueViolation(err) || IsForeignKeyViolation(err) {
+		t.Errorf("Expected a unique violation, got %v", err)
+	}
+
+	_, err = db.Exec("INSERT INTO alerts (user_id, game_id, type, message) VALUES (999, 999, 'overdue', 'Late')")
+	if !IsForeignKeyViolation(err) || IsUniqueViolation(err) {
+		t.Errorf("Expected a foreign key violation, got %v", err)
+	}
+
+	// The messages of PostgreSQL
+	if !IsUniqueViolation(errors.New(`ERROR: duplicate key value violates unique constraint "users_email_key" (SQLSTATE 23505)`)) {
+		t.Error("Expected PostgreSQL unique violations to be recognised")
+	}
+	if !IsForeignKeyViolation(errors.New(`ERROR: insert or update on table "alerts" violates foreign key constraint "alerts_user_id_fkey" (SQLSTATE 23503)`)) {
+		t.Error("Expected PostgreSQL foreign key violations to be recognised")
+	}
+	if IsUniqueViolation(nil) || IsForeignKeyViolation(nil) {
+		t.Error("Expected no violation without error")
+	}
+}
+
+func TestWithSearchPath(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"postgres://localhost/library", "postgres://localhost/library?search_path=test_1"},
+		{"postgres://localhost/library?sslmode=disable", "postgres://localhost/library?sslmode=disable&search_path=test_1"},
+		{"host=localhost dbname=library", "host=localhost dbname=library search_path=test_1"},
+	}
+
+	for _, tt := range tests {
+		if got := withSearchPath(tt.url, "test_1"); got != tt.want {
+			t.Errorf("withSearchPath(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestPostgresMigrations(t *testing.T) {
+	migrations := getMigrations(DriverPostgres)
+	if len(migrations) == 0 {
+		t.Fatal("Expected PostgreSQL migrations")
+	}
+
+	for _, migration := range migrations {
+		if !migration.Reversible() {
+			t.Errorf("Expected migration %d (%s) to be reversible", migration.Version, migration.Name)
+		}
+		if migration.Requires != nil || migration.Run != nil {
+			t.Errorf("Expected migration %d (%s) to be plain SQL", migration.Version, migration.Name)
+		}
+		for _, stmt := range splitStatements(migration.Up) {
+			if strings.Count(stmt, "$$")%2 != 0 {
+				t.Errorf("Expected function bodies to stay in one statement, got %q", stmt)
+			}
+		}
+	}
+}
@@ -1,91 +1,119 @@
 package database
 
-// getInitialMigrations returns the initial database schema migrations
+import (
+	"embed"
+	"fmt"
+	"time"
+
+	"board-game-library/pkg/slug"
+)
+
+// migrationFiles holds the SQL of the schema migrations: the SQLite ones in
+// migrations and the PostgreSQL ones in migrations/postgres
+//
+//go:embed migrations/*.sql migrations/postgres/*.sql
+var migrationFiles embed.FS
+
+// migrationHooks adds Go code to the migrations loaded from migrationFiles,
+// by version
+var migrationHooks = map[int]Migration{
+	12: {Requires: SupportsFTS5},
+	// The category column stays, empty, because the search index of
+	// migration 12 is built from it on databases upgraded without FTS5
+	14: {Run: convertCategoriesToTags},
+	15: {Requires: SupportsFTS5},
+	// Rebuilding the tags tables drops the triggers of migration 15
+	17: {Run: restoreTagSearchTriggers, Revert: restoreTagSearchTriggers},
+}
+
+// getInitialMigrations returns the database schema migrations
 func getInitialMigrations() []Migration {
-	return []Migration{
-		{
-			Version: 1,
-			Name:    "create_users_table",
-			Up: `
-				CREATE TABLE users (
-					id INTEGER PRIMARY KEY AUTOINCREMENT,
-					name TEXT NOT NULL,
-					email TEXT UNIQUE NOT NULL,
-					registered_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-					is_active BOOLEAN DEFAULT TRUE
-				);
-			`,
-			Down: "DROP TABLE users;",
-		},
-		{
-			Version: 2,
-			Name:    "create_games_table",
-			Up: `
-				CREATE TABLE games (
-					id INTEGER PRIMARY KEY AUTOINCREMENT,
-					name TEXT NOT NULL,
-					description TEXT,
-					category TEXT,
-					entry_date DATETIME DEFAULT CURRENT_TIMESTAMP,
-					condition TEXT DEFAULT 'good',
-					is_available BOOLEAN DEFAULT TRUE
-				);
-			`,
-			Down: "DROP TABLE games;",
-		},
-		{
-			Version: 3,
-			Name:    "create_borrowings_table",
-			Up: `
-				CREATE TABLE borrowings (
-					id INTEGER PRIMARY KEY AUTOINCREMENT,
-					user_id INTEGER NOT NULL,
-					game_id INTEGER NOT NULL,
-					borrowed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-					due_date DATETIME NOT NULL,
-					returned_at DATETIME,
-					is_overdue BOOLEAN DEFAULT FALSE,
-					FOREIGN KEY (user_id) REFERENCES users(id),
-					FOREIGN KEY (game_id) REFERENCES games(id)
-				);
-			`,
-			Down: "DROP TABLE borrowings;",
-		},
-		{
-			Version: 4,
-			Name:    "create_alerts_table",
-			Up: `
-				CREATE TABLE alerts (
-					id INTEGER PRIMARY KEY AUTOINCREMENT,
-					user_id INTEGER NOT NULL,
-					game_id INTEGER NOT NULL,
-					type TEXT NOT NULL,
-					message TEXT NOT NULL,
-					created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-					is_read BOOLEAN DEFAULT FALSE,
-					FOREIGN KEY (user_id) REFERENCES users(id),
-					FOREIGN KEY (game_id) REFERENCES games(id)
-				);
-			`,
-			Down: "DROP TABLE alerts;",
-		},
-		{
-			Version: 5,
-			Name:    "create_indexes",
-			Up: `
-				CREATE INDEX idx_borrowings_user_id ON borrowings(user_id);
-				CREATE INDEX idx_borrowings_game_id ON borrowings(game_id);
-				CREATE INDEX idx_borrowings_due_date ON borrowings(due_date);
-				CREATE INDEX idx_alerts_user_id ON alerts(user_id);
-				CREATE INDEX idx_alerts_is_read ON alerts(is_read);
-			`,
-			Down: `
-				DROP INDEX idx_borrowings_user_id;
-				DROP INDEX idx_borrowings_game_id;
-				DROP INDEX idx_borrowings_due_date;
-				DROP INDEX idx_alerts_user_id;
-				DROP INDEX idx_alerts_is_read;
-			`,
-		},
+	migrations := loadEmbeddedMigrations("migrations")
+
+	for i, migration := range migrations {
+		hook := migrationHooks[migration.Version]
+		migrations[i].Requires = hook.Requires
+		migrations[i].Run = hook.Run
+		migrations[i].Revert = hook.Revert
 	}
-}
\ No newline at end of file
+
+	return migrations
+}
+
+// getMigrations returns the schema migrations of a database driver.
+// PostgreSQL databases have their own migrations, starting from the schema
+// SQLite databases reached with migration 16.
+func getMigrations(driver string) []Migration {
+	if driver == DriverPostgres {
+		return loadEmbeddedMigrations("migrations/postgres")
+	}
+	return getInitialMigrations()
+}
+
+// loadEmbeddedMigrations loads the migrations of a directory of migrationFiles
+func loadEmbeddedMigrations(dir string) []Migration {
+	migrations, err := LoadMigrations(migrationFiles, dir)
+	if err != nil {
+		// The files are part of the binary, so this is a build error
+		panic(err)
+	}
+	return migrations
+}
+
+// convertCategoriesToTags gives each game a tag named after its category.
+// Categories spelled differently but sharing a slug, such as "Stratégie" and
+// "strategie", become one tag named after the first spelling found.
+func convertCategoriesToTags(tx Querier) error {
+	rows, err := tx.Query("SELECT id, TRIM(category) FROM games WHERE TRIM(category) <> '' ORDER BY id")
+	if err != nil {
+		return fmt.Errorf("failed to read game categories: %w", err)
+	}
+	categories := map[int]string{}
+	var gameIDs []int
+	for rows.Next() {
+		var id int
+		var category string
+		if err := rows.Scan(&id, &category); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan game category: %w", err)
+		}
+		categories[id] = category
+		gameIDs = append(gameIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating game categories: %w", err)
+	}
+
+	tagIDs := map[string]int64{}
+	now := time.Now()
+	for _, gameID := range gameIDs {
+		tagSlug := slug.Make(categories[gameID])
+		if tagSlug == "" {
+			continue
+		}
+
+		tagID, ok := tagIDs[tagSlug]
+		if !ok {
+			result, err := tx.Exec("INSERT INTO tags (name, slug, created_at) VALUES (?, ?, ?)",
+				categories[gameID], tagSlug, now)
+			if err != nil {
+				return fmt.Errorf("failed to create tag %q: %w", categories[gameID], err)
+			}
+			if tagID, err = result.LastInsertId(); err != nil {
+				return fmt.Errorf("failed to create tag %q: %w", categories[gameID], err)
+			}
+			tagIDs[tagSlug] = tagID
+		}
+
+		if _, err := tx.Exec("INSERT INTO game_tags (game_id, tag_id) VALUES (?, ?)", gameID, tagID); err != nil {
+			return fmt.Errorf("failed to tag game %d: %w", gameID, err)
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE games SET category = ''"); err != nil {
+		return fmt.Errorf("failed to clear game categories: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"database/sql"
+
+	"board-game-library/pkg/slug"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriverName is the database/sql driver used for SQLite: go-sqlite3
+// with the SQL functions the queries share with PostgreSQL
+const sqliteDriverName = "sqlite3_library"
+
+func init() {
+	sql.Register(sqliteDriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			return conn.RegisterFunc("unaccent", unaccent, true)
+		},
+	})
+}
+
+// unaccent removes the diacritics of a text value, like the PostgreSQL
+// unaccent extension. NULL and other values give NULL.
+func unaccent(value any) any {
+	text, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	return slug.Unaccent(text)
+}
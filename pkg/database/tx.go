@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Querier is implemented by both *DB and its transactions, so repositories can
+// run the same queries on the connection or inside a transaction. The
+// Context variants stop the query when the context is cancelled or its
+// deadline passes.
+type Querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// WithTx runs fn inside a transaction that cannot be cancelled, see
+// WithTxContext
+func (db *DB) WithTx(fn func(tx Querier) error) error {
+	return db.WithTxContext(context.Background(), fn)
+}
+
+// txRetries is how many times a transaction refused by PostgreSQL because of
+// a concurrent one is started again
+const txRetries = 5
+
+// WithTxContext runs fn inside a transaction. The transaction is committed
+// when fn returns nil and rolled back when it returns an error or panics, or
+// when ctx is done. Like the connection, the transaction takes queries
+// written with ? placeholders and belongs to the library of the connection.
+//
+// SQLite transactions take the write lock when they start. PostgreSQL ones
+// are serializable instead, so the checks of a borrowing or a payment cannot
+// interleave with another, and are run again when PostgreSQL aborts them for
+// a conflict: fn must therefore not have effects outside the transaction.
+func (db *DB) WithTxContext(ctx context.Context, fn func(tx Querier) error) error {
+	if db.Driver() != DriverPostgres {
+		return db.runTx(ctx, nil, fn)
+	}
+
+	opts := &sql.TxOptions{Isolation: sql.LevelSerializable}
+	for attempt := 1; ; attempt++ {
+		err := db.runTx(ctx, opts, fn)
+		if err == nil || attempt == txRetries || !isSerializationFailure(err) {
+			return err
+		}
+	}
+}
+
+// runTx runs fn inside one transaction started with opts
+func (db *DB) runTx(ctx context.Context, opts *sql.TxOptions, fn func(tx Querier) error) (err error) {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = fn(dbTx{Tx: tx, driver: db.Driver(), library: db.Library()}); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// isSerializationFailure reports whether PostgreSQL aborted a transaction
+// because of a concurrent one, in which case it can be run again
+func isSerializationFailure(err error) bool {
+	var pgErr interface{ SQLState() string }
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	switch pgErr.SQLState() {
+	case "40001", "40P01": // serialization_failure, deadlock_detected
+		return true
+	}
+	return false
+}
+
+// InTx runs fn inside a transaction on q. When q is already a transaction fn
+// joins it, so the caller's commit or rollback decides the outcome.
+func InTx(q Querier, fn func(tx Querier) error) error {
+	return InTxContext(context.Background(), q, fn)
+}
+
+// InTxContext is InTx with a transaction started for ctx
+func InTxContext(ctx context.Context, q Querier, fn func(tx Querier) error) error {
+	db, ok := q.(*DB)
+	if !ok {
+		return fn(q)
+	}
+
+	return db.WithTxContext(ctx, fn)
+}
+
+// dbTx is a transaction remembering the driver and the library of the
+// connection it was started from
+type dbTx struct {
+	*sql.Tx
+	driver  string
+	library int
+}
+
+// Exec runs a statement written with ? placeholders
+func (tx dbTx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.Exec(tx.rebind(query), args...)
+}
+
+// Query runs a query written with ? placeholders
+func (tx dbTx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.Query(tx.rebind(query), args...)
+}
+
+// QueryRow runs a query written with ? placeholders that returns one row
+func (tx dbTx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRow(tx.rebind(query), args...)
+}
+
+// ExecContext runs a statement written with ? placeholders
+func (tx dbTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.ExecContext(ctx, tx.rebind(query), args...)
+}
+
+// QueryContext runs a query written with ? placeholders
+func (tx dbTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, tx.rebind(query), args...)
+}
+
+// QueryRowContext runs a query written with ? placeholders that returns one row
+func (tx dbTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRowContext(ctx, tx.rebind(query), args...)
+}
+
+// rebind turns the ? placeholders of a query into those of the driver
+func (tx dbTx) rebind(query string) string {
+	if tx.driver != DriverPostgres {
+		return query
+	}
+	return Rebind(query)
+}
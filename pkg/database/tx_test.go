@@ -0,0 +1,146 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func setupTxTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	db, err := NewConnection(Config{DatabasePath: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create database connection: %v", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE items (name TEXT NOT NULL)`); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	return db
+}
+
+func countItems(t *testing.T, db *DB) int {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM items`).Scan(&count); err != nil {
+		t.Fatalf("Failed to count items: %v", err)
+	}
+	return count
+}
+
+func TestWithTxCommits(t *testing.T) {
+	db := setupTxTestDB(t)
+	defer db.Close()
+
+	err := db.WithTx(func(tx Querier) error {
+		_, err := tx.Exec(`INSERT INTO items (name) VALUES ('a'), ('b')`)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithTx() unexpected error: %v", err)
+	}
+
+	if count := countItems(t, db); count != 2 {
+		t.Errorf("Expected 2 committed items, got %d", count)
+	}
+}
+
+func TestWithTxRollsBackOnError(t *testing.T) {
+	db := setupTxTestDB(t)
+	defer db.Close()
+
+	failure := errors.New("second write failed")
+	err := db.WithTx(func(tx Querier) error {
+		if _, err := tx.Exec(`INSERT INTO items (name) VALUES ('a')`); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("WithTx() error = %v, want %v", err, failure)
+	}
+
+	if count := countItems(t, db); count != 0 {
+		t.Errorf("Expected the insert to be rolled back, got %d items", count)
+	}
+}
+
+func TestWithTxRollsBackOnPanic(t *testing.T) {
+	db := setupTxTestDB(t)
+	defer db.Close()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected the panic to propagate")
+			}
+		}()
+		db.WithTx(func(tx Querier) error {
+			tx.Exec(`INSERT INTO items (name) VALUES ('a')`)
+			panic("boom")
+		})
+	}()
+
+	if count := countItems(t, db); count != 0 {
+		t.Errorf("Expected the insert to be rolled back, got %d items", count)
+	}
+}
+
+func TestInTxJoinsExistingTransaction(t *testing.T) {
+	db := setupTxTestDB(t)
+	defer db.Close()
+
+	db.WithTx(func(tx Querier) error {
+		err := InTx(tx, func(inner Querier) error {
+			_, err := inner.Exec(`INSERT INTO items (name) VALUES ('a')`)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("InTx() unexpected error: %v", err)
+		}
+		return errors.New("outer transaction fails")
+	})
+
+	if count := countItems(t, db); count != 0 {
+		t.Errorf("Expected the joined insert to be rolled back with the outer transaction, got %d items", count)
+	}
+
+	if err := InTx(db, func(tx Querier) error {
+		_, err := tx.Exec(`INSERT INTO items (name) VALUES ('b')`)
+		return err
+	}); err != nil {
+		t.Fatalf("InTx() unexpected error: %v", err)
+	}
+
+	if count := countItems(t, db); count != 1 {
+		t.Errorf("Expected 1 committed item, got %d", count)
+	}
+}
+
+// sqlStateError is an error carrying a PostgreSQL SQLSTATE code, like the
+// errors of the pgx driver
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "sqlstate " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+func TestIsSerializationFailure(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{sqlStateError("40001"), true},
+		{fmt.Errorf("failed to commit transaction: %w", sqlStateError("40001")), true},
+		{sqlStateError("40P01"), true},
+		{sqlStateError("23505"), false},
+		{errors.New("database is locked"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isSerializationFailure(tt.err); got != tt.want {
+			t.Errorf("isSerializationFailure(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Package mailer sends plain text emails through an SMTP server.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Security modes of the connection to the SMTP server
+const (
+	SecurityStartTLS = "starttls" // upgrade a plain connection, usually on port 587
+	SecurityTLS      = "tls"      // TLS from the first byte, usually on port 465
+	SecurityNone     = "none"     // no encryption, for relays on the local network
+)
+
+// ValidSecurityModes lists the accepted security modes
+var ValidSecurityModes = []string{SecurityStartTLS, SecurityTLS, SecurityNone}
+
+// Message is a plain text email to one recipient
+type Message struct {
+	To      string // address, optionally with a name: "Alice <alice@example.org>"
+	Subject string
+	Body    string
+}
+
+// Config holds the SMTP server and the sender of the messages
+type Config struct {
+	Host     string
+	Port     int
+	Username string // empty to send without authentication
+	Password string
+	From     string // address, optionally with a name
+	Security string // one of the Security modes; empty means SecurityStartTLS
+	Timeout  time.Duration
+}
+
+// SMTPSender sends messages through an SMTP server, one connection per
+// message
+type SMTPSender struct {
+	config    Config
+	tlsConfig *tls.Config
+}
+
+// NewSMTPSender creates a sender using the SMTP server of config
+func NewSMTPSender(config Config) *SMTPSender {
+	if config.Security == "" {
+		config.Security = SecurityStartTLS
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &SMTPSender{
+		config:    config,
+		tlsConfig: &tls.Config{ServerName: config.Host},
+	}
+}
+
+// Send delivers a message to the SMTP server. Cancelling ctx aborts the
+// connection, whatever step of the transaction it is at.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	from, err := mail.ParseAddress(s.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", s.config.From, err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address %q: %w", msg.To, err)
+	}
+
+	data, err := Compose(from, to, msg.Subject, msg.Body, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	stop := context.AfterFunc(ctx, func() { client.Close() })
+	defer stop()
+
+	if s.config.Security == SecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("SMTP server %s does not support STARTTLS", s.config.Host)
+		}
+		if err := client.StartTLS(s.tlsConfig); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if s.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("SMTP server refused sender %s: %w", from.Address, err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("SMTP server refused recipient %s: %w", to.Address, err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server refused message: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// dial connects to the SMTP server, with TLS from the start when required.
+// The connection ends at the timeout or at the deadline of ctx, whichever
+// comes first.
+func (s *SMTPSender) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	dialer := &net.Dialer{Timeout: s.config.Timeout}
+
+	var conn net.Conn
+	var err error
+	if s.config.Security == SecurityTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+
+	deadline := time.Now().Add(s.config.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+
+	return client, nil
+}
+
+// Compose formats a plain text message in UTF-8, ready to be sent as the
+// DATA of an SMTP transaction
+func Compose(from, to *mail.Address, subject, body string, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+
+	header("From", from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", strings.Join(strings.Fields(subject), " ")))
+	header("Date", date.Format(time.RFC1123Z))
+	header("Message-ID", messageID(from.Address))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=UTF-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(strings.ReplaceAll(body, "\r\n", "\n"))); err != nil {
+		return nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// messageID returns a unique Message-ID in the domain of the sender
+func messageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+
+	id := make([]byte, 16)
+	rand.Read(id)
+	return "<" + hex.EncodeToString(id) + "@" + domain + ">"
+}
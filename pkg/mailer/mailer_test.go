@@ -0,0 +1,128 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"board-game-library/pkg/mailer/mailertest"
+)
+
+// newTestSender returns a sender using a local test server
+func newTestSender(t *testing.T, server *mailertest.Server) *SMTPSender {
+	t.Helper()
+	return NewSMTPSender(Config{
+		Host:     server.Host,
+		Port:     server.Port,
+		Username: "library",
+		Password: "secret",
+		From:     "Ludothèque <ludo@example.org>",
+		Security: SecurityNone,
+		Timeout:  5 * time.Second,
+	})
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	ctx := context.Background()
+	server := mailertest.NewServer()
+	defer server.Close()
+
+	sender := newTestSender(t, server)
+	err := sender.Send(ctx, Message{
+		To:      "Aurélie <aurelie@example.org>",
+		Subject: "Rappel : Les Aventuriers du Rail",
+		Body:    "Bonjour Aurélie,\n\nLe jeu est à rendre le 02/01/2026.\n.\nUne ligne commençant par un point.",
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	messages := server.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	message := messages[0]
+
+	if message.From != "ludo@example.org" || len(message.To) != 1 || message.To[0] != "aurelie@example.org" {
+		t.Errorf("Expected an envelope from ludo@example.org to aurelie@example.org, got %s to %v", message.From, message.To)
+	}
+	if message.Subject != "Rappel : Les Aventuriers du Rail" {
+		t.Errorf("Expected the subject to be decoded, got %q", message.Subject)
+	}
+	if want := "Bonjour Aurélie,\n\nLe jeu est à rendre le 02/01/2026.\n.\nUne ligne commençant par un point."; message.Body != want {
+		t.Errorf("Expected body %q, got %q", want, message.Body)
+	}
+	if !strings.Contains(message.Header.Get("Content-Type"), "charset=UTF-8") || message.Header.Get("Message-Id") == "" {
+		t.Errorf("Expected UTF-8 content and a Message-ID, got %v", message.Header)
+	}
+}
+
+func TestSMTPSender_Errors(t *testing.T) {
+	ctx := context.Background()
+	server := mailertest.NewServer()
+	defer server.Close()
+	sender := newTestSender(t, server)
+
+	server.Reject(1, "451 4.3.0 Try again later")
+	err := sender.Send(ctx, Message{To: "bob@example.org", Subject: "Test", Body: "Test"})
+	if err == nil || !strings.Contains(err.Error(), "Try again later") {
+		t.Errorf("Expected the rejection of the server, got %v", err)
+	}
+
+	if err := sender.Send(ctx, Message{To: "bob@example.org", Subject: "Test", Body: "Test"}); err != nil {
+		t.Errorf("Expected the next message to be accepted, got %v", err)
+	}
+
+	if err := sender.Send(ctx, Message{To: "not an address", Subject: "Test", Body: "Test"}); err == nil || !strings.Contains(err.Error(), "invalid recipient") {
+		t.Errorf("Expected an invalid recipient error, got %v", err)
+	}
+
+	startTLS := NewSMTPSender(Config{Host: server.Host, Port: server.Port, From: "ludo@example.org"})
+	if err := startTLS.Send(ctx, Message{To: "bob@example.org", Subject: "Test", Body: "Test"}); err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Errorf("Expected STARTTLS to be required by default, got %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := sender.Send(cancelled, Message{To: "bob@example.org", Subject: "Test", Body: "Test"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancelled send to fail, got %v", err)
+	}
+
+	addr := server.Addr()
+	server.Close()
+	closed := NewSMTPSender(Config{Host: server.Host, Port: server.Port, From: "ludo@example.org", Security: SecurityNone, Timeout: time.Second})
+	if err := closed.Send(ctx, Message{To: "bob@example.org", Subject: "Test", Body: "Test"}); err == nil || !strings.Contains(err.Error(), addr) {
+		t.Errorf("Expected a connection error, got %v", err)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	from := &mail.Address{Name: "Ludothèque", Address: "ludo@example.org"}
+	to := &mail.Address{Address: "bob@example.org"}
+	date := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	data, err := Compose(from, to, "Jeu en retard :\r\nBcc: evil@example.org", "Ligne 1\r\nLigne 2", date)
+	if err != nil {
+		t.Fatalf("Compose() error = %v", err)
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("Failed to parse composed message: %v", err)
+	}
+	if message.Header.Get("Bcc") != "" {
+		t.Error("Expected line breaks in the subject not to inject headers")
+	}
+	if message.Header.Get("Date") != "Fri, 02 Jan 2026 15:04:05 +0000" {
+		t.Errorf("Unexpected date header %q", message.Header.Get("Date"))
+	}
+	if !strings.HasPrefix(message.Header.Get("From"), "=?utf-8?q?Ludoth=C3=A8que?=") {
+		t.Errorf("Expected the sender name to be encoded, got %q", message.Header.Get("From"))
+	}
+	if !strings.Contains(string(data), "\r\n\r\nLigne 1\r\nLigne 2") {
+		t.Errorf("Expected CRLF line breaks in the body, got %q", data)
+	}
+}
@@ -0,0 +1,240 @@
+// Package mailertest provides a local SMTP server for tests, which keeps the
+// messages it receives instead of delivering them.
+package mailertest
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Message is a message received by the server
+type Message struct {
+	From    string   // envelope sender
+	To      []string // envelope recipients
+	Header  mail.Header
+	Subject string // decoded
+	Body    string // decoded, with \n line breaks but not the one ending the message
+	Raw     []byte
+}
+
+// Server is an SMTP server listening on a local port. It accepts any
+// credentials and never offers STARTTLS, so clients must connect without
+// encryption.
+type Server struct {
+	Host string
+	Port int
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []Message
+	rejects  int
+	reply    string
+	conns    map[net.Conn]bool
+}
+
+// NewServer starts a server on a free port of the loopback interface. It
+// panics if it cannot listen, like httptest.NewServer.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic("mailertest: failed to listen: " + err.Error())
+	}
+
+	addr := listener.Addr().(*net.TCPAddr)
+	s := &Server{
+		Host:     addr.IP.String(),
+		Port:     addr.Port,
+		listener: listener,
+		conns:    make(map[net.Conn]bool),
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s
+}
+
+// Addr returns the host:port address of the server
+func (s *Server) Addr() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+// Close stops the server and waits for its connections to end
+func (s *Server) Close() {
+	s.listener.Close()
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+// Messages returns the messages received so far
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Reject makes the server refuse the recipients of the next n messages
+// with reply, such as "451 4.3.0 Try again later"
+func (s *Server) Reject(n int, reply string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rejects = n
+	s.reply = reply
+}
+
+// serve accepts connections until the server is closed
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = true
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// handle runs the SMTP session of one connection
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+
+	reply := func(lines ...string) bool {
+		for _, line := range lines {
+			if err := text.PrintfLine("%s", line); err != nil {
+				return false
+			}
+		}
+		return true
+	}
+
+	var from string
+	var to []string
+	if !reply("220 mailertest ESMTP") {
+		return
+	}
+
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply("250-mailertest", "250-8BITMIME", "250 AUTH PLAIN")
+		case "AUTH":
+			if !strings.Contains(arg, " ") {
+				// The credentials follow on their own line
+				reply("334 ")
+				if _, err := text.ReadLine(); err != nil {
+					return
+				}
+			}
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			from, to = address(arg), nil
+			reply("250 2.1.0 OK")
+		case "RCPT":
+			if rejection := s.rejection(); rejection != "" {
+				reply(rejection)
+				continue
+			}
+			to = append(to, address(arg))
+			reply("250 2.1.5 OK")
+		case "DATA":
+			if len(to) == 0 {
+				reply("503 5.5.1 No valid recipients")
+				continue
+			}
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			data, err := io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+			s.store(from, to, data)
+			from, to = "", nil
+			reply("250 2.0.0 OK")
+		case "RSET":
+			from, to = "", nil
+			reply("250 2.0.0 OK")
+		case "NOOP":
+			reply("250 2.0.0 OK")
+		case "QUIT":
+			reply("221 2.0.0 Bye")
+			return
+		default:
+			reply("502 5.5.2 Command not recognized")
+		}
+	}
+}
+
+// rejection returns the reply refusing the current recipient, or "" to
+// accept it
+func (s *Server) rejection() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rejects == 0 {
+		return ""
+	}
+	s.rejects--
+	return s.reply
+}
+
+// store keeps a received message, decoding its subject and body
+func (s *Server) store(from string, to []string, data []byte) {
+	message := Message{From: from, To: to, Raw: data}
+
+	if parsed, err := mail.ReadMessage(bytes.NewReader(data)); err == nil {
+		message.Header = parsed.Header
+		message.Subject, _ = new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+
+		var body io.Reader = parsed.Body
+		if strings.EqualFold(parsed.Header.Get("Content-Transfer-Encoding"), "quoted-printable") {
+			body = quotedprintable.NewReader(body)
+		}
+		decoded, _ := io.ReadAll(bufio.NewReader(body))
+		message.Body = strings.TrimSuffix(strings.ReplaceAll(string(decoded), "\r\n", "\n"), "\n")
+	}
+
+	s.mu.Lock()
+	s.messages = append(s.messages, message)
+	s.mu.Unlock()
+}
+
+// address extracts the address of a "FROM:<address>" or "TO:<address>"
+// argument
+func address(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(strings.TrimSpace(addr), " ")
+	return strings.Trim(addr, "<>")
+}
@@ -0,0 +1,43 @@
+// Package slug turns labels into stable URL-friendly identifiers
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Make returns the slug of s: lower case letters and digits without accents,
+// with every other run of characters replaced by a single dash. Labels that
+// differ only by case, accents or punctuation share the same slug, e.g.
+// "Stratégie" and "strategie".
+func Make(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(Unaccent(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+
+	return b.String()
+}
+
+// Unaccent returns s without the diacritics of its letters, e.g. "Délice"
+// becomes "Delice". The case of s is kept.
+func Unaccent(s string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		return s
+	}
+	return folded
+}
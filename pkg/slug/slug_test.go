@@ -0,0 +1,47 @@
+package slug
+
+import "testing"
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Strategy", "strategy"},
+		{"Stratégie", "strategie"},
+		{"  Jeux de  cartes ", "jeux-de-cartes"},
+		{"Deck-building / Draft", "deck-building-draft"},
+		{"2 joueurs", "2-joueurs"},
+		{"Œuvre Çà", "œuvre-ca"},
+		{"!!!", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Make(tt.input); got != tt.want {
+				t.Errorf("Make(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnaccent(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Délice", "Delice"},
+		{"ÉLAN à Noël", "ELAN a Noel"},
+		{"Œuvre", "Œuvre"},
+		{"Azul", "Azul"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Unaccent(tt.input); got != tt.want {
+				t.Errorf("Unaccent(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
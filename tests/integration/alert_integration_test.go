@@ -5,6 +5,7 @@ import (
 	"board-game-library/internal/repositories"
 	"board-game-library/internal/services"
 	"board-game-library/pkg/database"
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 
 // TestAlertGenerationIntegration tests the complete alert generation and processing workflow
 func TestAlertGenerationIntegration(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -34,28 +36,28 @@ func TestAlertGenerationIntegration(t *testing.T) {
 
 	t.Run("Overdue Alert Generation and Processing", func(t *testing.T) {
 		// Step 1: Create test data
-		user, err := userService.RegisterUser("Alert Test User", "alert@example.com")
+		user, err := userService.RegisterUser(ctx, "Alert Test User", "alert@example.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Alert Test Game", "A game for alert testing", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Alert Test Game", "A game for alert testing", "Test", "good")
 		require.NoError(t, err)
 
 		// Step 2: Create an overdue borrowing
-		borrowing, err := borrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		borrowing, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 		
 		// Manually update to be overdue (simulating time passage)
 		borrowing.DueDate = time.Now().Add(-3 * 24 * time.Hour) // 3 days overdue
 		borrowing.IsOverdue = true
-		err = borrowingRepo.Update(borrowing)
+		err = borrowingRepo.Update(ctx, borrowing)
 		require.NoError(t, err)
 
 		// Step 3: Generate overdue alerts
-		err = alertService.GenerateOverdueAlerts()
+		err = alertService.GenerateOverdueAlerts(ctx)
 		require.NoError(t, err)
 
 		// Step 4: Verify alert was created
-		alerts, err := alertService.GetActiveAlerts()
+		alerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		assert.Len(t, alerts, 1)
 
@@ -67,27 +69,27 @@ func TestAlertGenerationIntegration(t *testing.T) {
 		assert.Contains(t, alert.Message, "overdue")
 
 		// Step 5: Test alert retrieval by user
-		userAlerts, err := alertRepo.GetByUser(user.ID)
+		userAlerts, err := alertRepo.GetByUser(ctx, user.ID)
 		require.NoError(t, err)
 		assert.Len(t, userAlerts, 1)
 		assert.Equal(t, alerts[0].ID, userAlerts[0].ID)
 
 		// Step 6: Mark alert as read
-		err = alertService.MarkAlertAsRead(alerts[0].ID)
+		err = alertService.MarkAlertAsRead(ctx, alerts[0].ID)
 		require.NoError(t, err)
 
 		// Step 7: Verify alert is marked as read by checking user alerts
-		userAlertsAfterRead, err := alertService.GetAlertsByUser(user.ID)
+		userAlertsAfterRead, err := alertService.GetAlertsByUser(ctx, user.ID)
 		require.NoError(t, err)
 		assert.Len(t, userAlertsAfterRead, 1)
 		assert.True(t, userAlertsAfterRead[0].IsRead)
 
 		// Step 8: Return the game and verify alert behavior
-		err = borrowingService.ReturnGame(borrowing.ID)
+		err = borrowingService.ReturnGame(ctx, borrowing.ID, nil)
 		require.NoError(t, err)
 
 		// The alert should still exist but be marked as read
-		finalUserAlerts, err := alertService.GetAlertsByUser(user.ID)
+		finalUserAlerts, err := alertService.GetAlertsByUser(ctx, user.ID)
 		require.NoError(t, err)
 		assert.Len(t, finalUserAlerts, 1)
 		assert.True(t, finalUserAlerts[0].IsRead)
@@ -95,23 +97,23 @@ func TestAlertGenerationIntegration(t *testing.T) {
 
 	t.Run("Reminder Alert Generation", func(t *testing.T) {
 		// Step 1: Create test data
-		user, err := userService.RegisterUser("Reminder User", "reminder@example.com")
+		user, err := userService.RegisterUser(ctx, "Reminder User", "reminder@example.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Reminder Game", "A game for reminder testing", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Reminder Game", "A game for reminder testing", "Test", "good")
 		require.NoError(t, err)
 
 		// Step 2: Create a borrowing due in 1 day (should trigger reminder)
 		dueSoon := time.Now().Add(1 * 24 * time.Hour)
-		_, err = borrowingService.BorrowGame(user.ID, game.ID, dueSoon)
+		_, err = borrowingService.BorrowGame(ctx, user.ID, game.ID, dueSoon)
 		require.NoError(t, err)
 
 		// Step 3: Generate reminder alerts
-		err = alertService.GenerateReminderAlerts()
+		err = alertService.GenerateReminderAlerts(ctx)
 		require.NoError(t, err)
 
 		// Step 4: Verify reminder alert was created
-		alerts, err := alertService.GetActiveAlerts()
+		alerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		
 		var reminderAlert *models.Alert
@@ -130,10 +132,10 @@ func TestAlertGenerationIntegration(t *testing.T) {
 		assert.Contains(t, reminderAlert.Message, "due")
 
 		// Step 5: Test that reminder is not generated again for same borrowing
-		err = alertService.GenerateReminderAlerts()
+		err = alertService.GenerateReminderAlerts(ctx)
 		require.NoError(t, err)
 
-		allAlerts, err := alertService.GetActiveAlerts()
+		allAlerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		
 		reminderCount := 0
@@ -155,14 +157,14 @@ func TestAlertGenerationIntegration(t *testing.T) {
 
 		// Create users and games
 		for i := 0; i < numUsers; i++ {
-			user, err := userService.RegisterUser(
+			user, err := userService.RegisterUser(ctx, 
 				fmt.Sprintf("Multi User %d", i),
 				fmt.Sprintf("multi%d@example.com", i),
 			)
 			require.NoError(t, err)
 			users = append(users, user)
 
-			game, err := gameService.AddGame(
+			game, err := gameService.AddGame(ctx, 
 				fmt.Sprintf("Multi Game %d", i),
 				fmt.Sprintf("Game %d for multi-user testing", i),
 				"Multi",
@@ -174,24 +176,24 @@ func TestAlertGenerationIntegration(t *testing.T) {
 
 		// Create overdue borrowings for all users
 		for i := 0; i < numUsers; i++ {
-			borrowing, err := borrowingService.BorrowGame(users[i].ID, games[i].ID, time.Now().Add(14*24*time.Hour))
+			borrowing, err := borrowingService.BorrowGame(ctx, users[i].ID, games[i].ID, time.Now().Add(14*24*time.Hour))
 			require.NoError(t, err)
 
 			// Manually update to be overdue (simulating time passage)
 			borrowing.DueDate = time.Now().Add(-time.Duration(i+1) * 24 * time.Hour) // Different overdue periods
 			borrowing.IsOverdue = true
-			err = borrowingRepo.Update(borrowing)
+			err = borrowingRepo.Update(ctx, borrowing)
 			require.NoError(t, err)
 
 			borrowings = append(borrowings, borrowing)
 		}
 
 		// Generate overdue alerts
-		err = alertService.GenerateOverdueAlerts()
+		err = alertService.GenerateOverdueAlerts(ctx)
 		require.NoError(t, err)
 
 		// Verify alerts were created for all users
-		alerts, err := alertService.GetActiveAlerts()
+		alerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		
 		overdueAlerts := 0
@@ -204,7 +206,7 @@ func TestAlertGenerationIntegration(t *testing.T) {
 
 		// Test alert retrieval by specific user
 		for i, user := range users {
-			userAlerts, err := alertRepo.GetByUser(user.ID)
+			userAlerts, err := alertRepo.GetByUser(ctx, user.ID)
 			require.NoError(t, err)
 			assert.Len(t, userAlerts, 1, "Each user should have exactly one alert")
 			assert.Equal(t, games[i].ID, userAlerts[0].GameID)
@@ -213,13 +215,13 @@ func TestAlertGenerationIntegration(t *testing.T) {
 		// Test marking multiple alerts as read
 		for _, alert := range alerts {
 			if alert.Type == "overdue" {
-				err = alertService.MarkAlertAsRead(alert.ID)
+				err = alertService.MarkAlertAsRead(ctx, alert.ID)
 				require.NoError(t, err)
 			}
 		}
 
 		// Verify all alerts are marked as read
-		updatedAlerts, err := alertService.GetActiveAlerts()
+		updatedAlerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		
 		for _, alert := range updatedAlerts {
@@ -258,14 +260,14 @@ func TestAlertGenerationIntegration(t *testing.T) {
 
 		// Create users and games
 		for i := 0; i < numBorrowings; i++ {
-			user, err := perfUserService.RegisterUser(
+			user, err := perfUserService.RegisterUser(ctx, 
 				fmt.Sprintf("Perf User %d", i),
 				fmt.Sprintf("perf%d@example.com", i),
 			)
 			require.NoError(t, err)
 			users = append(users, user)
 
-			game, err := perfGameService.AddGame(
+			game, err := perfGameService.AddGame(ctx, 
 				fmt.Sprintf("Perf Game %d", i),
 				fmt.Sprintf("Performance game %d", i),
 				"Performance",
@@ -277,26 +279,26 @@ func TestAlertGenerationIntegration(t *testing.T) {
 
 		// Create overdue borrowings
 		for i := 0; i < numBorrowings; i++ {
-			borrowing, err := perfBorrowingService.BorrowGame(users[i].ID, games[i].ID, time.Now().Add(14*24*time.Hour))
+			borrowing, err := perfBorrowingService.BorrowGame(ctx, users[i].ID, games[i].ID, time.Now().Add(14*24*time.Hour))
 			require.NoError(t, err)
 
 			// Manually update to be overdue (simulating time passage)
 			borrowing.DueDate = time.Now().Add(-2 * 24 * time.Hour)
 			borrowing.IsOverdue = true
-			err = perfBorrowingRepo.Update(borrowing)
+			err = perfBorrowingRepo.Update(ctx, borrowing)
 			require.NoError(t, err)
 		}
 
 		// Test alert generation performance
 		start := time.Now()
-		err = perfAlertService.GenerateOverdueAlerts()
+		err = perfAlertService.GenerateOverdueAlerts(ctx)
 		duration := time.Since(start)
 		require.NoError(t, err)
 
 		t.Logf("Generated alerts for %d overdue borrowings in %v", numBorrowings, duration)
 
 		// Verify all alerts were created
-		alerts, err := perfAlertService.GetActiveAlerts()
+		alerts, err := perfAlertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		
 		overdueAlerts := 0
@@ -330,45 +332,45 @@ func TestAlertGenerationIntegration(t *testing.T) {
 		cleanupAlertService := services.NewAlertService(cleanupAlertRepo, cleanupBorrowingRepo, cleanupUserRepo, cleanupGameRepo)
 
 		// Create test data
-		user, err := cleanupUserService.RegisterUser("Cleanup User", "cleanup@example.com")
+		user, err := cleanupUserService.RegisterUser(ctx, "Cleanup User", "cleanup@example.com")
 		require.NoError(t, err)
 
-		game, err := cleanupGameService.AddGame("Cleanup Game", "A game for cleanup testing", "Test", "good")
+		game, err := cleanupGameService.AddGame(ctx, "Cleanup Game", "A game for cleanup testing", "Test", "good")
 		require.NoError(t, err)
 
 		// Create and return a borrowing to test alert lifecycle
-		borrowing, err := cleanupBorrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		borrowing, err := cleanupBorrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 		
 		// Manually update to be overdue
 		borrowing.DueDate = time.Now().Add(-1 * 24 * time.Hour)
 		borrowing.IsOverdue = true
-		err = cleanupBorrowingRepo.Update(borrowing)
+		err = cleanupBorrowingRepo.Update(ctx, borrowing)
 		require.NoError(t, err)
 
-		err = cleanupAlertService.GenerateOverdueAlerts()
+		err = cleanupAlertService.GenerateOverdueAlerts(ctx)
 		require.NoError(t, err)
 
 		// Verify alert exists
-		alerts, err := cleanupAlertService.GetActiveAlerts()
+		alerts, err := cleanupAlertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		assert.Len(t, alerts, 1)
 
 		// Return the game
-		err = cleanupBorrowingService.ReturnGame(borrowing.ID)
+		err = cleanupBorrowingService.ReturnGame(ctx, borrowing.ID, nil)
 		require.NoError(t, err)
 
 		// Alert should still exist but can be cleaned up if needed
-		finalAlerts, err := cleanupAlertService.GetActiveAlerts()
+		finalAlerts, err := cleanupAlertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		assert.Len(t, finalAlerts, 1)
 
 		// Test manual alert deletion
-		err = cleanupAlertRepo.Delete(finalAlerts[0].ID)
+		err = cleanupAlertRepo.Delete(ctx, finalAlerts[0].ID)
 		require.NoError(t, err)
 
 		// Verify alert is deleted
-		deletedAlerts, err := cleanupAlertService.GetActiveAlerts()
+		deletedAlerts, err := cleanupAlertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		assert.Len(t, deletedAlerts, 0)
 	})
@@ -376,6 +378,7 @@ func TestAlertGenerationIntegration(t *testing.T) {
 
 // TestAdvancedAlertScenarios tests complex alert generation scenarios
 func TestAdvancedAlertScenarios(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -412,52 +415,52 @@ func TestAdvancedAlertScenarios(t *testing.T) {
 		mixedAlertService := services.NewAlertService(mixedAlertRepo, mixedBorrowingRepo, mixedUserRepo, mixedGameRepo)
 
 		// Create users and games
-		user1, err := mixedUserService.RegisterUser("Overdue Alert User", "overdue@example.com")
+		user1, err := mixedUserService.RegisterUser(ctx, "Overdue Alert User", "overdue@example.com")
 		require.NoError(t, err)
 
-		user2, err := mixedUserService.RegisterUser("Reminder Alert User", "reminder@example.com")
+		user2, err := mixedUserService.RegisterUser(ctx, "Reminder Alert User", "reminder@example.com")
 		require.NoError(t, err)
 
-		game1, err := mixedGameService.AddGame("Overdue Game", "Game that will be overdue", "Test", "good")
+		game1, err := mixedGameService.AddGame(ctx, "Overdue Game", "Game that will be overdue", "Test", "good")
 		require.NoError(t, err)
 
-		game2, err := mixedGameService.AddGame("Reminder Game", "Game that will need reminder", "Test", "good")
+		game2, err := mixedGameService.AddGame(ctx, "Reminder Game", "Game that will need reminder", "Test", "good")
 		require.NoError(t, err)
 
 		// Create overdue borrowing for user1
-		overdueBorrowing, err := mixedBorrowingService.BorrowGame(user1.ID, game1.ID, time.Now().Add(14*24*time.Hour))
+		overdueBorrowing, err := mixedBorrowingService.BorrowGame(ctx, user1.ID, game1.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 		
 		// Manually update to be overdue
 		overdueBorrowing.DueDate = time.Now().Add(-3 * 24 * time.Hour)
 		overdueBorrowing.IsOverdue = true
-		err = mixedBorrowingRepo.Update(overdueBorrowing)
+		err = mixedBorrowingRepo.Update(ctx, overdueBorrowing)
 		require.NoError(t, err)
 
 		// Create reminder borrowing for user2 (due in 1 day)
-		_, err = mixedBorrowingService.BorrowGame(user2.ID, game2.ID, time.Now().Add(1*24*time.Hour))
+		_, err = mixedBorrowingService.BorrowGame(ctx, user2.ID, game2.ID, time.Now().Add(1*24*time.Hour))
 		require.NoError(t, err)
 
 		// Generate both types of alerts
-		err = mixedAlertService.GenerateOverdueAlerts()
+		err = mixedAlertService.GenerateOverdueAlerts(ctx)
 		require.NoError(t, err)
 
-		err = mixedAlertService.GenerateReminderAlerts()
+		err = mixedAlertService.GenerateReminderAlerts(ctx)
 		require.NoError(t, err)
 
 		// Verify alerts exist for both users
-		user1Alerts, err := mixedAlertService.GetAlertsByUser(user1.ID)
+		user1Alerts, err := mixedAlertService.GetAlertsByUser(ctx, user1.ID)
 		require.NoError(t, err)
 		assert.Len(t, user1Alerts, 1)
 		assert.Equal(t, "overdue", user1Alerts[0].Type)
 
-		user2Alerts, err := mixedAlertService.GetAlertsByUser(user2.ID)
+		user2Alerts, err := mixedAlertService.GetAlertsByUser(ctx, user2.ID)
 		require.NoError(t, err)
 		assert.Len(t, user2Alerts, 1)
 		assert.Equal(t, "reminder", user2Alerts[0].Type)
 
 		// Test alert summary
-		summary, err := mixedAlertService.GetAlertsSummaryByUser()
+		summary, err := mixedAlertService.GetAlertsSummaryByUser(ctx)
 		require.NoError(t, err)
 		
 		user1Summary := summary[user1.ID]
@@ -473,29 +476,29 @@ func TestAdvancedAlertScenarios(t *testing.T) {
 
 	t.Run("Alert Generation Edge Cases", func(t *testing.T) {
 		// Test alert generation with various edge cases
-		user, err := userService.RegisterUser("Edge Case User", "edge@example.com")
+		user, err := userService.RegisterUser(ctx, "Edge Case User", "edge@example.com")
 		require.NoError(t, err)
 
 		// Case 1: Borrowing due exactly now
-		game1, err := gameService.AddGame("Due Now Game", "Game due exactly now", "Test", "good")
+		game1, err := gameService.AddGame(ctx, "Due Now Game", "Game due exactly now", "Test", "good")
 		require.NoError(t, err)
 
-		_, err = borrowingService.BorrowGame(user.ID, game1.ID, time.Now().Add(1*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user.ID, game1.ID, time.Now().Add(1*time.Hour))
 		require.NoError(t, err)
 
 		// Case 2: Borrowing due in exactly 2 days (boundary for reminders)
-		game2, err := gameService.AddGame("Due In 2 Days Game", "Game due in exactly 2 days", "Test", "good")
+		game2, err := gameService.AddGame(ctx, "Due In 2 Days Game", "Game due in exactly 2 days", "Test", "good")
 		require.NoError(t, err)
 
-		_, err = borrowingService.BorrowGame(user.ID, game2.ID, time.Now().Add(2*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user.ID, game2.ID, time.Now().Add(2*24*time.Hour))
 		require.NoError(t, err)
 
 		// Generate alerts
-		err = alertService.GenerateReminderAlerts()
+		err = alertService.GenerateReminderAlerts(ctx)
 		require.NoError(t, err)
 
 		// Verify appropriate alerts are generated
-		alerts, err := alertService.GetAlertsByUser(user.ID)
+		alerts, err := alertService.GetAlertsByUser(ctx, user.ID)
 		require.NoError(t, err)
 		
 		// Should have alerts for both games (both within 2-day window)
@@ -510,30 +513,30 @@ func TestAdvancedAlertScenarios(t *testing.T) {
 
 	t.Run("Alert Deduplication", func(t *testing.T) {
 		// Test that duplicate alerts are not created
-		user, err := userService.RegisterUser("Dedup User", "dedup@example.com")
+		user, err := userService.RegisterUser(ctx, "Dedup User", "dedup@example.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Dedup Game", "Game for deduplication test", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Dedup Game", "Game for deduplication test", "Test", "good")
 		require.NoError(t, err)
 
 		// Create overdue borrowing
-		borrowing, err := borrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		borrowing, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 		
 		// Manually update to be overdue
 		borrowing.DueDate = time.Now().Add(-1 * 24 * time.Hour)
 		borrowing.IsOverdue = true
-		err = borrowingRepo.Update(borrowing)
+		err = borrowingRepo.Update(ctx, borrowing)
 		require.NoError(t, err)
 
 		// Generate alerts multiple times
 		for i := 0; i < 3; i++ {
-			err = alertService.GenerateOverdueAlerts()
+			err = alertService.GenerateOverdueAlerts(ctx)
 			require.NoError(t, err)
 		}
 
 		// Should only have one alert
-		alerts, err := alertService.GetAlertsByUser(user.ID)
+		alerts, err := alertService.GetAlertsByUser(ctx, user.ID)
 		require.NoError(t, err)
 		assert.Len(t, alerts, 1)
 		assert.Equal(t, "overdue", alerts[0].Type)
@@ -541,14 +544,14 @@ func TestAdvancedAlertScenarios(t *testing.T) {
 
 	t.Run("Custom Alert Creation", func(t *testing.T) {
 		// Test custom alert creation functionality
-		user, err := userService.RegisterUser("Custom Alert User", "custom@example.com")
+		user, err := userService.RegisterUser(ctx, "Custom Alert User", "custom@example.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Custom Alert Game", "Game for custom alerts", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Custom Alert Game", "Game for custom alerts", "Test", "good")
 		require.NoError(t, err)
 
 		// Create custom alert
-		customAlert, err := alertService.CreateCustomAlert(
+		customAlert, err := alertService.CreateCustomAlert(ctx, 
 			user.ID,
 			game.ID,
 			"reminder",
@@ -562,7 +565,7 @@ func TestAdvancedAlertScenarios(t *testing.T) {
 		assert.False(t, customAlert.IsRead)
 
 		// Verify custom alert appears in user alerts
-		userAlerts, err := alertService.GetAlertsByUser(user.ID)
+		userAlerts, err := alertService.GetAlertsByUser(ctx, user.ID)
 		require.NoError(t, err)
 		assert.Len(t, userAlerts, 1)
 		assert.Equal(t, customAlert.ID, userAlerts[0].ID)
@@ -594,14 +597,14 @@ func TestAdvancedAlertScenarios(t *testing.T) {
 
 		for i := 0; i < numGames; i++ {
 			// Create separate user for each borrowing to avoid overdue conflicts
-			user, err := bulkUserService.RegisterUser(
+			user, err := bulkUserService.RegisterUser(ctx, 
 				fmt.Sprintf("Bulk Alert User %d", i),
 				fmt.Sprintf("bulk%d@example.com", i),
 			)
 			require.NoError(t, err)
 			users[i] = user
 
-			game, err := bulkGameService.AddGame(
+			game, err := bulkGameService.AddGame(ctx, 
 				fmt.Sprintf("Bulk Game %d", i),
 				fmt.Sprintf("Game %d for bulk testing", i),
 				"Bulk",
@@ -611,39 +614,39 @@ func TestAdvancedAlertScenarios(t *testing.T) {
 			games[i] = game
 
 			// Create overdue borrowing
-			borrowing, err := bulkBorrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+			borrowing, err := bulkBorrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 			require.NoError(t, err)
 			
 			// Manually update to be overdue
 			borrowing.DueDate = time.Now().Add(-time.Duration(i+1) * 24 * time.Hour)
 			borrowing.IsOverdue = true
-			err = bulkBorrowingRepo.Update(borrowing)
+			err = bulkBorrowingRepo.Update(ctx, borrowing)
 			require.NoError(t, err)
 			borrowings[i] = borrowing
 		}
 
 		// Generate alerts for all overdue items
-		err = bulkAlertService.GenerateOverdueAlerts()
+		err = bulkAlertService.GenerateOverdueAlerts(ctx)
 		require.NoError(t, err)
 
 		// Verify all alerts were created
-		allAlerts, err := bulkAlertService.GetActiveAlerts()
+		allAlerts, err := bulkAlertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		assert.Len(t, allAlerts, numGames)
 
 		// Test bulk mark as read for first user
 		firstUser := users[0]
-		err = bulkAlertService.MarkAllUserAlertsAsRead(firstUser.ID)
+		err = bulkAlertService.MarkAllUserAlertsAsRead(ctx, firstUser.ID)
 		require.NoError(t, err)
 
 		// Verify first user's alerts are marked as read
-		firstUserAlerts, err := bulkAlertService.GetAlertsByUser(firstUser.ID)
+		firstUserAlerts, err := bulkAlertService.GetAlertsByUser(ctx, firstUser.ID)
 		require.NoError(t, err)
 		assert.Len(t, firstUserAlerts, 1)
 		assert.True(t, firstUserAlerts[0].IsRead, "Alert should be marked as read")
 
 		// Verify other users' alerts are still unread
-		secondUserAlerts, err := bulkAlertService.GetAlertsByUser(users[1].ID)
+		secondUserAlerts, err := bulkAlertService.GetAlertsByUser(ctx, users[1].ID)
 		require.NoError(t, err)
 		assert.Len(t, secondUserAlerts, 1)
 		assert.False(t, secondUserAlerts[0].IsRead, "Alert should still be unread")
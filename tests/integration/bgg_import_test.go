@@ -0,0 +1,86 @@
+package integration
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"board-game-library/internal/services"
+	"board-game-library/pkg/bgg"
+	"board-game-library/pkg/database"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBGGServer stands in for the BoardGameGeek XML API2
+func fakeBGGServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/xmlapi2/search" && r.URL.Query().Get("query") == "azul":
+			w.Write([]byte(`<items total="1"><item type="boardgame" id="230802"><name type="primary" value="Azul"/><yearpublished value="2017"/></item></items>`))
+		case r.URL.Path == "/xmlapi2/thing" && r.URL.Query().Get("id") == "230802":
+			w.Write([]byte(`<items><item type="boardgame" id="230802">
+				<image>https://cf.geekdo-images.com/azul.jpg</image>
+				<name type="primary" sortindex="1" value="Azul"/>
+				<description>Introduced by the Moors, azulejos&amp;#10;were white and blue tiles.</description>
+				<yearpublished value="2017"/><minplayers value="2"/><maxplayers value="4"/>
+				<playingtime value="45"/><minage value="8"/>
+				<link type="boardgamecategory" id="1009" value="Abstract Strategy"/>
+				<link type="boardgamecategory" id="1070" value="Renaissance"/>
+				<link type="boardgamedesigner" id="6651" value="Michael Kiesling"/>
+				<link type="boardgamepublisher" id="5407" value="Plan B Games"/>
+				<statistics page="1"><ratings><averageweight value="1.7578"/></ratings></statistics>
+			</item></items>`))
+		default:
+			w.Write([]byte(`<items></items>`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestBGGImportWorkflow searches BoardGameGeek, imports a game and finds it
+// in the catalogue by its imported tags and metadata
+func TestBGGImportWorkflow(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.InitializeForTesting()
+	require.NoError(t, err)
+	defer db.Close()
+
+	gameRepo := repositories.NewSQLiteGameRepository(db)
+	borrowingRepo := repositories.NewSQLiteBorrowingRepository(db)
+	gameService := services.NewGameService(gameRepo, borrowingRepo)
+	gameService.SetCatalogue(bgg.NewClient(fakeBGGServer(t).URL+"/xmlapi2", "", 5*time.Second))
+
+	results, err := gameService.SearchBGG(ctx, "azul")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, bgg.SearchResult{ID: 230802, Name: "Azul", YearPublished: 2017}, results[0])
+
+	imported, err := gameService.ImportBGGGame(ctx, results[0].ID, "excellent")
+	require.NoError(t, err)
+
+	game, err := gameService.GetGame(ctx, imported.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Azul", game.Name)
+	assert.Equal(t, "Introduced by the Moors, azulejos\nwere white and blue tiles.", game.Description)
+	assert.Equal(t, []string{"Abstract Strategy", "Renaissance"}, game.Tags)
+	assert.Equal(t, models.GameDetails{
+		MinPlayers: 2, MaxPlayers: 4, PlayTime: 45, MinAge: 8,
+		Publisher: "Plan B Games", Designers: []string{"Michael Kiesling"}, YearPublished: 2017, Complexity: 1.8,
+		ImageURL: "https://cf.geekdo-images.com/azul.jpg", BGGID: 230802,
+	}, game.GameDetails)
+
+	games, total, err := gameService.ListGames(ctx, models.GameFilter{Tags: []string{"abstract-strategy"}, Players: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, imported.ID, games[0].ID)
+
+	_, err = gameService.ImportBGGGame(ctx, 1, "good")
+	assert.ErrorContains(t, err, "not found")
+}
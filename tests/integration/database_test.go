@@ -4,6 +4,7 @@ import (
 	"board-game-library/internal/models"
 	"board-game-library/internal/repositories"
 	"board-game-library/pkg/database"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 
 // TestDatabaseIntegration tests database operations with real SQLite database
 func TestDatabaseIntegration(t *testing.T) {
+	ctx := context.Background()
 	// Create temporary directory for test database
 	tempDir := t.TempDir()
 	dbPath := filepath.Join(tempDir, "integration_test.db")
@@ -74,18 +76,18 @@ func TestDatabaseIntegration(t *testing.T) {
 			RegisteredAt: time.Now(),
 			IsActive:     true,
 		}
-		err := userRepo.Create(user)
+		err := userRepo.Create(ctx, user)
 		require.NoError(t, err)
 
 		game := &models.Game{
 			Name:        "FK Test Game",
 			Description: "A game for FK testing",
-			Category:    "Test",
+			Tags:        []string{"Test"},
 			EntryDate:   time.Now(),
 			Condition:   "good",
 			IsAvailable: true,
 		}
-		err = gameRepo.Create(game)
+		err = gameRepo.Create(ctx, game)
 		require.NoError(t, err)
 
 		// Test valid foreign key references
@@ -96,7 +98,7 @@ func TestDatabaseIntegration(t *testing.T) {
 			DueDate:    time.Now().Add(14 * 24 * time.Hour),
 			IsOverdue:  false,
 		}
-		err = borrowingRepo.Create(borrowing)
+		err = borrowingRepo.Create(ctx, borrowing)
 		require.NoError(t, err)
 
 		// Test invalid foreign key reference (should fail)
@@ -107,7 +109,7 @@ func TestDatabaseIntegration(t *testing.T) {
 			DueDate:    time.Now().Add(14 * 24 * time.Hour),
 			IsOverdue:  false,
 		}
-		err = borrowingRepo.Create(invalidBorrowing)
+		err = borrowingRepo.Create(ctx, invalidBorrowing)
 		assert.Error(t, err, "Should fail due to foreign key constraint")
 	})
 
@@ -138,7 +140,7 @@ func TestDatabaseIntegration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify user was not created (due to rollback)
-		_, err = userRepo.GetByEmail("transaction@example.com")
+		_, err = userRepo.GetByEmail(ctx, "transaction@example.com")
 		assert.Error(t, err, "User should not exist after rollback")
 
 		// Now test successful transaction
@@ -159,7 +161,7 @@ func TestDatabaseIntegration(t *testing.T) {
 		id, err := result.LastInsertId()
 		require.NoError(t, err)
 		
-		createdUser, err := userRepo.GetByID(int(id))
+		createdUser, err := userRepo.GetByID(ctx, int(id))
 		require.NoError(t, err)
 		assert.Equal(t, user.Name, createdUser.Name)
 		assert.Equal(t, user.Email, createdUser.Email)
@@ -182,7 +184,7 @@ func TestDatabaseIntegration(t *testing.T) {
 					IsActive:     true,
 				}
 				
-				err := userRepo.Create(user)
+				err := userRepo.Create(ctx, user)
 				assert.NoError(t, err)
 			}(i)
 		}
@@ -193,7 +195,7 @@ func TestDatabaseIntegration(t *testing.T) {
 		}
 
 		// Verify all users were created
-		users, err := userRepo.GetAll()
+		users, err := userRepo.GetAll(ctx)
 		require.NoError(t, err)
 		
 		concurrentUsers := 0
@@ -215,7 +217,7 @@ func TestDatabaseIntegration(t *testing.T) {
 			RegisteredAt: time.Now(),
 			IsActive:     true,
 		}
-		err := userRepo.Create(user)
+		err := userRepo.Create(ctx, user)
 		require.NoError(t, err)
 
 		// Close the database connection
@@ -229,7 +231,7 @@ func TestDatabaseIntegration(t *testing.T) {
 
 		// Verify the user still exists
 		userRepo2 := repositories.NewSQLiteUserRepository(db2)
-		retrievedUser, err := userRepo2.GetByEmail("persistence@example.com")
+		retrievedUser, err := userRepo2.GetByEmail(ctx, "persistence@example.com")
 		require.NoError(t, err)
 		assert.Equal(t, user.Name, retrievedUser.Name)
 		assert.Equal(t, user.Email, retrievedUser.Email)
@@ -282,6 +284,7 @@ func TestDatabaseMigrations(t *testing.T) {
 
 // TestDatabasePerformance tests database performance characteristics
 func TestDatabasePerformance(t *testing.T) {
+	ctx := context.Background()
 	if testing.Short() {
 		t.Skip("Skipping performance tests in short mode")
 	}
@@ -304,7 +307,7 @@ func TestDatabasePerformance(t *testing.T) {
 				RegisteredAt: time.Now(),
 				IsActive:     true,
 			}
-			err := userRepo.Create(user)
+			err := userRepo.Create(ctx, user)
 			require.NoError(t, err)
 		}
 
@@ -321,18 +324,18 @@ func TestDatabasePerformance(t *testing.T) {
 			game := &models.Game{
 				Name:        fmt.Sprintf("Performance Game %d", i),
 				Description: fmt.Sprintf("Description for game %d", i),
-				Category:    "Performance",
+				Tags:        []string{"Performance"},
 				EntryDate:   time.Now(),
 				Condition:   "good",
 				IsAvailable: true,
 			}
-			err := gameRepo.Create(game)
+			err := gameRepo.Create(ctx, game)
 			require.NoError(t, err)
 		}
 
 		// Test query performance
 		start := time.Now()
-		games, err := gameRepo.GetAll()
+		games, err := gameRepo.GetAll(ctx)
 		duration := time.Since(start)
 
 		require.NoError(t, err)
@@ -345,7 +348,7 @@ func TestDatabasePerformance(t *testing.T) {
 
 	t.Run("Search Performance", func(t *testing.T) {
 		start := time.Now()
-		games, err := gameRepo.Search("Performance Game")
+		games, err := gameRepo.Search(ctx, "Performance Game")
 		duration := time.Since(start)
 
 		require.NoError(t, err)
@@ -359,6 +362,7 @@ func TestDatabasePerformance(t *testing.T) {
 
 // TestDatabaseConcurrency tests database operations under concurrent access
 func TestDatabaseConcurrency(t *testing.T) {
+	ctx := context.Background()
 	if testing.Short() {
 		t.Skip("Skipping concurrency tests in short mode")
 	}
@@ -391,7 +395,7 @@ func TestDatabaseConcurrency(t *testing.T) {
 						IsActive:     true,
 					}
 					
-					if createErr := userRepo.Create(user); createErr != nil {
+					if createErr := userRepo.Create(ctx, user); createErr != nil {
 						err = createErr
 						return
 					}
@@ -411,7 +415,7 @@ func TestDatabaseConcurrency(t *testing.T) {
 		assert.Equal(t, 0, errorCount, "Should have no errors in concurrent user creation")
 
 		// Verify all users were created
-		users, err := userRepo.GetAll()
+		users, err := userRepo.GetAll(ctx)
 		require.NoError(t, err)
 		
 		concurrentUsers := 0
@@ -428,12 +432,12 @@ func TestDatabaseConcurrency(t *testing.T) {
 		game := &models.Game{
 			Name:        "Race Condition Game",
 			Description: "Game for testing race conditions",
-			Category:    "Test",
+			Tags:        []string{"Test"},
 			EntryDate:   time.Now(),
 			Condition:   "good",
 			IsAvailable: true,
 		}
-		err := gameRepo.Create(game)
+		err := gameRepo.Create(ctx, game)
 		require.NoError(t, err)
 
 		// Create multiple users
@@ -446,7 +450,7 @@ func TestDatabaseConcurrency(t *testing.T) {
 				RegisteredAt: time.Now(),
 				IsActive:     true,
 			}
-			err := userRepo.Create(user)
+			err := userRepo.Create(ctx, user)
 			require.NoError(t, err)
 			users[i] = user
 		}
@@ -521,7 +525,7 @@ func TestDatabaseConcurrency(t *testing.T) {
 		assert.Equal(t, numUsers-1, errorCount, "All other attempts should fail")
 
 		// Verify game is not available
-		updatedGame, err := gameRepo.GetByID(game.ID)
+		updatedGame, err := gameRepo.GetByID(ctx, game.ID)
 		require.NoError(t, err)
 		assert.False(t, updatedGame.IsAvailable)
 	})
@@ -546,24 +550,24 @@ func TestDatabaseConcurrency(t *testing.T) {
 						RegisteredAt: time.Now(),
 						IsActive:     true,
 					}
-					err = userRepo.Create(user)
+					err = userRepo.Create(ctx, user)
 				case 1:
 					// Read all users
-					_, err = userRepo.GetAll()
+					_, err = userRepo.GetAll(ctx)
 				case 2:
 					// Create game
 					game := &models.Game{
 						Name:        fmt.Sprintf("Lock Test Game %d", opIndex),
 						Description: "Game for lock testing",
-						Category:    "Test",
+						Tags:        []string{"Test"},
 						EntryDate:   time.Now(),
 						Condition:   "good",
 						IsAvailable: true,
 					}
-					err = gameRepo.Create(game)
+					err = gameRepo.Create(ctx, game)
 				case 3:
 					// Read all games
-					_, err = gameRepo.GetAll()
+					_, err = gameRepo.GetAll(ctx)
 				}
 			}(i)
 		}
@@ -584,6 +588,7 @@ func TestDatabaseConcurrency(t *testing.T) {
 
 // TestDatabaseRecovery tests database recovery scenarios
 func TestDatabaseRecovery(t *testing.T) {
+	ctx := context.Background()
 	tempDir := t.TempDir()
 	dbPath := filepath.Join(tempDir, "recovery_test.db")
 
@@ -605,7 +610,7 @@ func TestDatabaseRecovery(t *testing.T) {
 			RegisteredAt: time.Now(),
 			IsActive:     true,
 		}
-		err = userRepo.Create(user)
+		err = userRepo.Create(ctx, user)
 		require.NoError(t, err)
 
 		// Close database properly
@@ -618,7 +623,7 @@ func TestDatabaseRecovery(t *testing.T) {
 		defer db2.Close()
 
 		userRepo2 := repositories.NewSQLiteUserRepository(db2)
-		users, err := userRepo2.GetAll()
+		users, err := userRepo2.GetAll(ctx)
 		require.NoError(t, err)
 		assert.Len(t, users, 1)
 		assert.Equal(t, "Recovery Test User", users[0].Name)
@@ -654,16 +659,16 @@ func TestDatabaseRecovery(t *testing.T) {
 
 // TestDatabaseBackupAndRestore tests backup and restore functionality
 func TestDatabaseBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
 	if testing.Short() {
 		t.Skip("Skipping backup/restore tests in short mode")
 	}
 
 	tempDir := t.TempDir()
 	originalDBPath := filepath.Join(tempDir, "original.db")
-	backupDBPath := filepath.Join(tempDir, "backup.db")
+	backupDBPath := filepath.Join(tempDir, "backups", "backup.db")
 
 	originalConfig := database.Config{DatabasePath: originalDBPath}
-	backupConfig := database.Config{DatabasePath: backupDBPath}
 
 	t.Run("Database Backup and Restore", func(t *testing.T) {
 		// Create original database with data
@@ -680,31 +685,39 @@ func TestDatabaseBackupAndRestore(t *testing.T) {
 			RegisteredAt: time.Now(),
 			IsActive:     true,
 		}
-		err = userRepo.Create(user)
+		err = userRepo.Create(ctx, user)
 		require.NoError(t, err)
 
 		game := &models.Game{
 			Name:        "Backup Test Game",
 			Description: "Game for backup testing",
-			Category:    "Test",
+			Tags:        []string{"Test"},
 			EntryDate:   time.Now(),
 			Condition:   "good",
 			IsAvailable: true,
 		}
-		err = gameRepo.Create(game)
+		err = gameRepo.Create(ctx, game)
 		require.NoError(t, err)
 
+		// Back up while the database is open, then keep writing
+		require.NoError(t, originalDB.Backup(backupDBPath))
+
+		lateUser := &models.User{
+			Name:         "Registered After Backup",
+			Email:        "late@test.com",
+			RegisteredAt: time.Now(),
+			IsActive:     true,
+		}
+		require.NoError(t, userRepo.Create(ctx, lateUser))
 		originalDB.Close()
 
-		// Copy database file (simulate backup)
-		originalData, err := os.ReadFile(originalDBPath)
-		require.NoError(t, err)
-		
-		err = os.WriteFile(backupDBPath, originalData, 0644)
+		// Restore the backup over the original database
+		version, err := database.Restore(backupDBPath, originalDBPath)
 		require.NoError(t, err)
+		assert.Equal(t, database.LatestSchemaVersion(), version)
 
-		// Open backup database and verify data
-		backupDB, err := database.Initialize(backupConfig)
+		// Open restored database and verify data
+		backupDB, err := database.Initialize(originalConfig)
 		require.NoError(t, err)
 		defer backupDB.Close()
 
@@ -712,13 +725,13 @@ func TestDatabaseBackupAndRestore(t *testing.T) {
 		backupGameRepo := repositories.NewSQLiteGameRepository(backupDB)
 
 		// Verify users
-		users, err := backupUserRepo.GetAll()
+		users, err := backupUserRepo.GetAll(ctx)
 		require.NoError(t, err)
 		assert.Len(t, users, 1)
 		assert.Equal(t, "Backup Test User", users[0].Name)
 
 		// Verify games
-		games, err := backupGameRepo.GetAll()
+		games, err := backupGameRepo.GetAll(ctx)
 		require.NoError(t, err)
 		assert.Len(t, games, 1)
 		assert.Equal(t, "Backup Test Game", games[0].Name)
@@ -5,6 +5,7 @@ import (
 	"board-game-library/internal/repositories"
 	"board-game-library/internal/services"
 	"board-game-library/pkg/database"
+	"context"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 
 // TestCompleteLibraryLifecycle tests a complete library management lifecycle
 func TestCompleteLibraryLifecycle(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -36,14 +38,14 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		t.Log("Phase 1: Setting up library with users and games")
 		
 		// Register library members
-		_, err := userService.RegisterUser("Alice Librarian", "alice@library.com")
+		_, err := userService.RegisterUser(ctx, "Alice Librarian", "alice@library.com")
 		require.NoError(t, err)
 		
 		members := make([]*models.User, 5)
 		memberNames := []string{"Bob Reader", "Carol Gamer", "Dave Player", "Eve Collector", "Frank Enthusiast"}
 		memberEmails := []string{"bob@email.com", "carol@email.com", "dave@email.com", "eve@email.com", "frank@email.com"}
 		for i, name := range memberNames {
-			member, err := userService.RegisterUser(name, memberEmails[i])
+			member, err := userService.RegisterUser(ctx, name, memberEmails[i])
 			require.NoError(t, err)
 			members[i] = member
 		}
@@ -66,21 +68,21 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		}
 
 		for i, data := range gameData {
-			game, err := gameService.AddGame(data.name, data.description, data.category, data.condition)
+			game, err := gameService.AddGame(ctx, data.name, data.description, data.category, data.condition)
 			require.NoError(t, err)
 			games[i] = game
 		}
 
 		// Verify initial state
-		allUsers, err := userService.GetAllUsers()
+		allUsers, err := userService.GetAllUsers(ctx)
 		require.NoError(t, err)
 		assert.Len(t, allUsers, 6) // 1 librarian + 5 members
 
-		allGames, err := gameService.GetAllGames()
+		allGames, err := gameService.GetAllGames(ctx)
 		require.NoError(t, err)
 		assert.Len(t, allGames, 10)
 
-		availableGames, err := gameService.GetAvailableGames()
+		availableGames, err := gameService.GetAvailableGames(ctx)
 		require.NoError(t, err)
 		assert.Len(t, availableGames, 10) // All games should be available initially
 
@@ -88,32 +90,32 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		t.Log("Phase 2: Active borrowing period with multiple users")
 
 		// Bob borrows strategy games
-		bobBorrowing1, err := borrowingService.BorrowGame(members[0].ID, games[1].ID, time.Now().Add(14*24*time.Hour)) // Catan
+		bobBorrowing1, err := borrowingService.BorrowGame(ctx, members[0].ID, games[1].ID, time.Now().Add(14*24*time.Hour)) // Catan
 		require.NoError(t, err)
 		
-		_, err = borrowingService.BorrowGame(members[0].ID, games[3].ID, time.Now().Add(14*24*time.Hour)) // Risk
+		_, err = borrowingService.BorrowGame(ctx, members[0].ID, games[3].ID, time.Now().Add(14*24*time.Hour)) // Risk
 		require.NoError(t, err)
 
 		// Carol borrows family games
-		carolBorrowing, err := borrowingService.BorrowGame(members[1].ID, games[0].ID, time.Now().Add(14*24*time.Hour)) // Monopoly
+		carolBorrowing, err := borrowingService.BorrowGame(ctx, members[1].ID, games[0].ID, time.Now().Add(14*24*time.Hour)) // Monopoly
 		require.NoError(t, err)
 
 		// Dave borrows word games
-		_, err = borrowingService.BorrowGame(members[2].ID, games[2].ID, time.Now().Add(14*24*time.Hour)) // Scrabble
+		_, err = borrowingService.BorrowGame(ctx, members[2].ID, games[2].ID, time.Now().Add(14*24*time.Hour)) // Scrabble
 		require.NoError(t, err)
 
 		// Verify borrowing state
-		bobActiveBorrowings, err := userService.GetActiveUserBorrowings(members[0].ID)
+		bobActiveBorrowings, err := userService.GetActiveUserBorrowings(ctx, members[0].ID)
 		require.NoError(t, err)
 		assert.Len(t, bobActiveBorrowings, 2)
 
 		// Verify game availability
-		availableGames, err = gameService.GetAvailableGames()
+		availableGames, err = gameService.GetAvailableGames(ctx)
 		require.NoError(t, err)
 		assert.Len(t, availableGames, 6) // 4 games borrowed, 6 available
 
 		// Test search functionality during active period
-		strategyGames, err := gameService.SearchGames("Strategy")
+		strategyGames, err := gameService.SearchGames(ctx, "Strategy")
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(strategyGames), 2) // Should find strategy games (some borrowed, some available)
 
@@ -122,11 +124,11 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 
 		// Extend Bob's Catan borrowing
 		newDueDate := time.Now().Add(21 * 24 * time.Hour)
-		err = borrowingService.ExtendDueDate(bobBorrowing1.ID, newDueDate)
+		err = borrowingService.ExtendDueDate(ctx, bobBorrowing1.ID, newDueDate)
 		require.NoError(t, err)
 
 		// Verify extension
-		extendedBorrowing, err := borrowingService.GetBorrowingDetails(bobBorrowing1.ID)
+		extendedBorrowing, err := borrowingService.GetBorrowingDetails(ctx, bobBorrowing1.ID)
 		require.NoError(t, err)
 		assert.True(t, extendedBorrowing.DueDate.After(time.Now().Add(20*24*time.Hour)))
 
@@ -134,21 +136,21 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		t.Log("Phase 4: Processing returns and new borrowings")
 
 		// Carol returns Monopoly
-		err = borrowingService.ReturnGame(carolBorrowing.ID)
+		err = borrowingService.ReturnGame(ctx, carolBorrowing.ID, nil)
 		require.NoError(t, err)
 
 		// Verify Monopoly is available again
-		monopolyGame, err := gameService.GetGame(games[0].ID)
+		monopolyGame, err := gameService.GetGame(ctx, games[0].ID)
 		require.NoError(t, err)
 		assert.True(t, monopolyGame.IsAvailable)
 
 		// Eve borrows the returned Monopoly
-		eveBorrowing, err := borrowingService.BorrowGame(members[3].ID, games[0].ID, time.Now().Add(14*24*time.Hour))
+		eveBorrowing, err := borrowingService.BorrowGame(ctx, members[3].ID, games[0].ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 		assert.NotZero(t, eveBorrowing.ID)
 
 		// Frank tries to borrow an unavailable game (should fail)
-		_, err = borrowingService.BorrowGame(members[4].ID, games[1].ID, time.Now().Add(14*24*time.Hour)) // Catan (borrowed by Bob)
+		_, err = borrowingService.BorrowGame(ctx, members[4].ID, games[1].ID, time.Now().Add(14*24*time.Hour)) // Catan (borrowed by Bob)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "not available")
 
@@ -156,7 +158,7 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		t.Log("Phase 5: Handling overdue items and alerts")
 
 		// Create an overdue scenario by creating a normal borrowing then backdating it
-		overdueBorrowing, err := borrowingService.BorrowGame(members[4].ID, games[4].ID, time.Now().Add(14*24*time.Hour)) // Clue, initially normal
+		overdueBorrowing, err := borrowingService.BorrowGame(ctx, members[4].ID, games[4].ID, time.Now().Add(14*24*time.Hour)) // Clue, initially normal
 		require.NoError(t, err)
 		
 		// Manually update to be overdue (simulating time passage)
@@ -164,22 +166,22 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 
 		// Mark as overdue (simulating time passage)
 		overdueBorrowing.IsOverdue = true
-		err = borrowingRepo.Update(overdueBorrowing)
+		err = borrowingRepo.Update(ctx, overdueBorrowing)
 		require.NoError(t, err)
 
 		// Generate overdue alerts
-		err = alertService.GenerateOverdueAlerts()
+		err = alertService.GenerateOverdueAlerts(ctx)
 		require.NoError(t, err)
 
 		// Verify alert was created
-		alerts, err := alertService.GetActiveAlerts()
+		alerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		assert.Len(t, alerts, 1)
 		assert.Equal(t, "overdue", alerts[0].Type)
 		assert.Equal(t, members[4].ID, alerts[0].UserID) // Frank has overdue item
 
 		// Verify Frank cannot borrow while having overdue items
-		canBorrow, err := userService.CanUserBorrow(members[4].ID)
+		canBorrow, err := userService.CanUserBorrow(ctx, members[4].ID)
 		require.Error(t, err)
 		assert.False(t, canBorrow)
 
@@ -187,15 +189,15 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		t.Log("Phase 6: Testing reminder system")
 
 		// Create a borrowing due soon (1 day)
-		_, err = borrowingService.BorrowGame(members[3].ID, games[5].ID, time.Now().Add(1*24*time.Hour)) // Eve borrows Chess
+		_, err = borrowingService.BorrowGame(ctx, members[3].ID, games[5].ID, time.Now().Add(1*24*time.Hour)) // Eve borrows Chess
 		require.NoError(t, err)
 
 		// Generate reminder alerts
-		err = alertService.GenerateReminderAlerts()
+		err = alertService.GenerateReminderAlerts(ctx)
 		require.NoError(t, err)
 
 		// Verify reminder alert was created
-		allAlerts, err := alertService.GetActiveAlerts()
+		allAlerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		
 		var reminderAlert *models.Alert
@@ -212,17 +214,17 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		t.Log("Phase 7: Managing alerts and notifications")
 
 		// Get alert summary
-		summary, err := alertService.GetAlertsSummaryByUser()
+		summary, err := alertService.GetAlertsSummaryByUser(ctx)
 		require.NoError(t, err)
 		assert.Len(t, summary, 2) // Frank (overdue) and Eve (reminder)
 
 		// Mark Frank's overdue alert as read
 		frankSummary := summary[members[4].ID]
-		err = alertService.MarkAlertAsRead(frankSummary.Alerts[0].ID)
+		err = alertService.MarkAlertAsRead(ctx, frankSummary.Alerts[0].ID)
 		require.NoError(t, err)
 
 		// Verify alert is marked as read
-		updatedAlerts, err := alertService.GetActiveAlerts()
+		updatedAlerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		for _, alert := range updatedAlerts {
 			if alert.ID == frankSummary.Alerts[0].ID {
@@ -234,16 +236,16 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		t.Log("Phase 8: Resolving overdue items and final cleanup")
 
 		// Frank returns overdue game
-		err = borrowingService.ReturnGame(overdueBorrowing.ID)
+		err = borrowingService.ReturnGame(ctx, overdueBorrowing.ID, nil)
 		require.NoError(t, err)
 
 		// Verify Frank can now borrow again
-		canBorrow, err = userService.CanUserBorrow(members[4].ID)
+		canBorrow, err = userService.CanUserBorrow(ctx, members[4].ID)
 		require.NoError(t, err)
 		assert.True(t, canBorrow)
 
 		// Frank borrows an available game
-		frankBorrowing, err := borrowingService.BorrowGame(members[4].ID, games[6].ID, time.Now().Add(14*24*time.Hour)) // Checkers
+		frankBorrowing, err := borrowingService.BorrowGame(ctx, members[4].ID, games[6].ID, time.Now().Add(14*24*time.Hour)) // Checkers
 		require.NoError(t, err)
 		assert.NotZero(t, frankBorrowing.ID)
 
@@ -251,13 +253,13 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		t.Log("Phase 9: Final state verification and reporting")
 
 		// Check final game availability
-		finalAvailableGames, err := gameService.GetAvailableGames()
+		finalAvailableGames, err := gameService.GetAvailableGames(ctx)
 		require.NoError(t, err)
 		t.Logf("Final available games: %d", len(finalAvailableGames))
 
 		// Check all user borrowing histories
 		for i, member := range members {
-			history, err := userService.GetUserBorrowings(member.ID)
+			history, err := userService.GetUserBorrowings(ctx, member.ID)
 			require.NoError(t, err)
 			t.Logf("Member %d (%s) borrowing history: %d items", i+1, member.Name, len(history))
 		}
@@ -265,14 +267,14 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		// Verify all active borrowings
 		totalActiveBorrowings := 0
 		for _, member := range members {
-			activeBorrowings, err := userService.GetActiveUserBorrowings(member.ID)
+			activeBorrowings, err := userService.GetActiveUserBorrowings(ctx, member.ID)
 			require.NoError(t, err)
 			totalActiveBorrowings += len(activeBorrowings)
 		}
 		t.Logf("Total active borrowings: %d", totalActiveBorrowings)
 
 		// Verify data consistency
-		allBorrowings, err := borrowingRepo.GetAll()
+		allBorrowings, err := borrowingRepo.GetAll(ctx)
 		require.NoError(t, err)
 		t.Logf("Total borrowings in system: %d", len(allBorrowings))
 
@@ -294,12 +296,12 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 		assert.Greater(t, activeCount, 0, "Should have some active borrowings")
 		
 		// Verify all users can be retrieved
-		finalUsers, err := userService.GetAllUsers()
+		finalUsers, err := userService.GetAllUsers(ctx)
 		require.NoError(t, err)
 		assert.Len(t, finalUsers, 6)
 
 		// Verify all games can be retrieved
-		finalGames, err := gameService.GetAllGames()
+		finalGames, err := gameService.GetAllGames(ctx)
 		require.NoError(t, err)
 		assert.Len(t, finalGames, 10)
 	})
@@ -307,6 +309,7 @@ func TestCompleteLibraryLifecycle(t *testing.T) {
 
 // TestBusinessRuleEnforcement tests that business rules are properly enforced
 func TestBusinessRuleEnforcement(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -324,64 +327,64 @@ func TestBusinessRuleEnforcement(t *testing.T) {
 
 	t.Run("Business Rule Enforcement", func(t *testing.T) {
 		// Create test data
-		user, err := userService.RegisterUser("Rule Test User", "rules@test.com")
+		user, err := userService.RegisterUser(ctx, "Rule Test User", "rules@test.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Rule Test Game", "Game for testing rules", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Rule Test Game", "Game for testing rules", "Test", "good")
 		require.NoError(t, err)
 
 		// Rule 1: Cannot borrow unavailable game
-		borrowing1, err := borrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		borrowing1, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 
 		// Try to borrow same game again (should fail)
-		_, err = borrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "not available")
 
 		// Rule 2: Cannot return already returned item
-		err = borrowingService.ReturnGame(borrowing1.ID)
+		err = borrowingService.ReturnGame(ctx, borrowing1.ID, nil)
 		require.NoError(t, err)
 
 		// Try to return again (should fail)
-		err = borrowingService.ReturnGame(borrowing1.ID)
+		err = borrowingService.ReturnGame(ctx, borrowing1.ID, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "already been returned")
 
 		// Rule 3: Cannot extend due date for returned item
-		err = borrowingService.ExtendDueDate(borrowing1.ID, time.Now().Add(21*24*time.Hour))
+		err = borrowingService.ExtendDueDate(ctx, borrowing1.ID, time.Now().Add(21*24*time.Hour))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "returned item")
 
 		// Rule 4: Due date cannot be too far in future
-		borrowing2, err := borrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		borrowing2, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 
 		// Try to extend beyond 90 days
 		farFuture := time.Now().Add(100 * 24 * time.Hour)
-		err = borrowingService.ExtendDueDate(borrowing2.ID, farFuture)
+		err = borrowingService.ExtendDueDate(ctx, borrowing2.ID, farFuture)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "90 days")
 
 		// Rule 5: Cannot borrow with overdue items
 		// Create overdue borrowing
-		game2, err := gameService.AddGame("Overdue Test Game", "Game for overdue testing", "Test", "good")
+		game2, err := gameService.AddGame(ctx, "Overdue Test Game", "Game for overdue testing", "Test", "good")
 		require.NoError(t, err)
 
-		overdueBorrowing, err := borrowingService.BorrowGame(user.ID, game2.ID, time.Now().Add(14*24*time.Hour))
+		overdueBorrowing, err := borrowingService.BorrowGame(ctx, user.ID, game2.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 
 		// Manually update to be overdue
 		overdueBorrowing.DueDate = time.Now().Add(-24 * time.Hour)
 		overdueBorrowing.IsOverdue = true
-		err = borrowingRepo.Update(overdueBorrowing)
+		err = borrowingRepo.Update(ctx, overdueBorrowing)
 		require.NoError(t, err)
 
 		// Try to borrow another game (should fail)
-		game3, err := gameService.AddGame("Another Test Game", "Another game", "Test", "good")
+		game3, err := gameService.AddGame(ctx, "Another Test Game", "Another game", "Test", "good")
 		require.NoError(t, err)
 
-		_, err = borrowingService.BorrowGame(user.ID, game3.ID, time.Now().Add(14*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user.ID, game3.ID, time.Now().Add(14*24*time.Hour))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "overdue")
 	})
@@ -389,6 +392,7 @@ func TestBusinessRuleEnforcement(t *testing.T) {
 
 // TestDataValidationIntegration tests data validation across the system
 func TestDataValidationIntegration(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -408,107 +412,107 @@ func TestDataValidationIntegration(t *testing.T) {
 
 	t.Run("User Validation", func(t *testing.T) {
 		// Invalid email format
-		_, err := userService.RegisterUser("Test User", "invalid-email")
+		_, err := userService.RegisterUser(ctx, "Test User", "invalid-email")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 
 		// Empty name
-		_, err = userService.RegisterUser("", "valid@email.com")
+		_, err = userService.RegisterUser(ctx, "", "valid@email.com")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 
 		// Name too short
-		_, err = userService.RegisterUser("A", "valid@email.com")
+		_, err = userService.RegisterUser(ctx, "A", "valid@email.com")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 
 		// Valid user
-		user, err := userService.RegisterUser("Valid User", "valid@email.com")
+		user, err := userService.RegisterUser(ctx, "Valid User", "valid@email.com")
 		require.NoError(t, err)
 		assert.NotZero(t, user.ID)
 	})
 
 	t.Run("Game Validation", func(t *testing.T) {
 		// Empty name
-		_, err := gameService.AddGame("", "Description", "Category", "good")
+		_, err := gameService.AddGame(ctx, "", "Description", "Category", "good")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 
 		// Invalid condition
-		_, err = gameService.AddGame("Test Game", "Description", "Category", "invalid-condition")
+		_, err = gameService.AddGame(ctx, "Test Game", "Description", "Category", "invalid-condition")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 
 		// Name too short
-		_, err = gameService.AddGame("A", "Description", "Category", "good")
+		_, err = gameService.AddGame(ctx, "A", "Description", "Category", "good")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 
 		// Valid game
-		game, err := gameService.AddGame("Valid Game", "Valid description", "Valid Category", "good")
+		game, err := gameService.AddGame(ctx, "Valid Game", "Valid description", "Valid Category", "good")
 		require.NoError(t, err)
 		assert.NotZero(t, game.ID)
 	})
 
 	t.Run("Borrowing Validation", func(t *testing.T) {
 		// Create valid user and game first
-		user, err := userService.RegisterUser("Borrowing User", "borrowing@test.com")
+		user, err := userService.RegisterUser(ctx, "Borrowing User", "borrowing@test.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Borrowing Game", "Game for borrowing", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Borrowing Game", "Game for borrowing", "Test", "good")
 		require.NoError(t, err)
 
 		// Invalid user ID
-		_, err = borrowingService.BorrowGame(0, game.ID, time.Now().Add(14*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, 0, game.ID, time.Now().Add(14*24*time.Hour))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid user ID")
 
 		// Invalid game ID
-		_, err = borrowingService.BorrowGame(user.ID, 0, time.Now().Add(14*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user.ID, 0, time.Now().Add(14*24*time.Hour))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid game ID")
 
 		// Due date in the past
-		_, err = borrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(-24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(-24*time.Hour))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 
 		// Valid borrowing
-		borrowing, err := borrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		borrowing, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 		assert.NotZero(t, borrowing.ID)
 	})
 
 	t.Run("Alert Validation", func(t *testing.T) {
 		// Create valid user and game first
-		user, err := userService.RegisterUser("Alert User", "alert@test.com")
+		user, err := userService.RegisterUser(ctx, "Alert User", "alert@test.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Alert Game", "Game for alerts", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Alert Game", "Game for alerts", "Test", "good")
 		require.NoError(t, err)
 
 		// Invalid user ID
-		_, err = alertService.CreateCustomAlert(0, game.ID, "reminder", "Test message")
+		_, err = alertService.CreateCustomAlert(ctx, 0, game.ID, "reminder", "Test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid user ID")
 
 		// Invalid game ID
-		_, err = alertService.CreateCustomAlert(user.ID, 0, "reminder", "Test message")
+		_, err = alertService.CreateCustomAlert(ctx, user.ID, 0, "reminder", "Test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid game ID")
 
 		// Invalid alert type
-		_, err = alertService.CreateCustomAlert(user.ID, game.ID, "invalid-type", "Test message")
+		_, err = alertService.CreateCustomAlert(ctx, user.ID, game.ID, "invalid-type", "Test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 
 		// Empty message
-		_, err = alertService.CreateCustomAlert(user.ID, game.ID, "reminder", "")
+		_, err = alertService.CreateCustomAlert(ctx, user.ID, game.ID, "reminder", "")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 
 		// Valid alert
-		alert, err := alertService.CreateCustomAlert(user.ID, game.ID, "reminder", "Valid test message")
+		alert, err := alertService.CreateCustomAlert(ctx, user.ID, game.ID, "reminder", "Valid test message")
 		require.NoError(t, err)
 		assert.NotZero(t, alert.ID)
 	})
@@ -0,0 +1,150 @@
+package integration
+
+import (
+	"board-game-library/internal/config"
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"board-game-library/internal/routes"
+	"board-game-library/internal/services"
+	"board-game-library/pkg/database"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// libraryServices are the services of one library of a shared database
+type libraryServices struct {
+	users      *services.UserService
+	games      *services.GameService
+	borrowings *services.BorrowingService
+	alerts     *services.AlertService
+}
+
+func newLibraryServices(db *database.DB) *libraryServices {
+	gameRepo := repositories.NewSQLiteGameRepository(db)
+	userRepo := repositories.NewSQLiteUserRepository(db)
+	borrowingRepo := repositories.NewSQLiteBorrowingRepository(db)
+
+	borrowings := services.NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+	borrowings.SetUnitOfWork(repositories.NewSQLiteUnitOfWork(db))
+
+	return &libraryServices{
+		users:      services.NewUserService(userRepo, borrowingRepo),
+		games:      services.NewGameService(gameRepo, borrowingRepo),
+		borrowings: borrowings,
+		alerts:     services.NewAlertService(repositories.NewSQLiteAlertRepository(db), borrowingRepo, userRepo, gameRepo),
+	}
+}
+
+// TestLibraryIsolation runs two libraries on one database and checks that
+// neither sees nor touches the users, games, borrowings and alerts of the
+// other, through the services and through the HTTP API
+func TestLibraryIsolation(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.InitializeForTesting()
+	require.NoError(t, err)
+	defer db.Close()
+
+	libraries := services.NewLibraryService(repositories.NewSQLiteLibraryRepository(db))
+	ludo, err := libraries.CreateLibrary(ctx, "Ludo Club", "ludo")
+	require.NoError(t, err)
+
+	main := newLibraryServices(db)
+	club := newLibraryServices(db.ForLibrary(ludo.ID))
+
+	alice, err := main.users.RegisterUser(ctx, "Alice", "alice@example.com")
+	require.NoError(t, err)
+	azul, err := main.games.AddGame(ctx, "Azul", "", "Abstrait", "good")
+	require.NoError(t, err)
+
+	bob, err := club.users.RegisterUser(ctx, "Bob", "bob@example.com")
+	require.NoError(t, err)
+	hanabi, err := club.games.AddGame(ctx, "Hanabi", "", "Coopératif", "good")
+	require.NoError(t, err)
+
+	// Emails are unique across libraries
+	_, err = club.users.RegisterUser(ctx, "Alice", "alice@example.com")
+	assert.ErrorContains(t, err, "already exists")
+
+	// Nothing crosses libraries
+	_, err = club.users.GetUser(ctx, alice.ID)
+	assert.Error(t, err)
+	_, err = club.games.GetGame(ctx, azul.ID)
+	assert.Error(t, err)
+	_, err = club.borrowings.BorrowGame(ctx, bob.ID, azul.ID, time.Now().AddDate(0, 0, 7))
+	assert.Error(t, err, "members cannot borrow the games of another library")
+	_, err = main.borrowings.BorrowGame(ctx, alice.ID, hanabi.ID, time.Now().AddDate(0, 0, 7))
+	assert.Error(t, err)
+
+	// Overdue alerts are generated for each library's own borrowings
+	_, err = club.borrowings.BorrowGame(ctx, bob.ID, hanabi.ID, time.Now().AddDate(0, 0, 7))
+	require.NoError(t, err)
+	_, err = db.Exec("UPDATE borrowings SET due_date = ?", time.Now().AddDate(0, 0, -3))
+	require.NoError(t, err)
+
+	require.NoError(t, main.alerts.GenerateOverdueAlerts(ctx))
+	mainAlerts, err := main.alerts.GetActiveAlerts(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, mainAlerts)
+
+	require.NoError(t, club.alerts.GenerateOverdueAlerts(ctx))
+	clubAlerts, err := club.alerts.GetActiveAlerts(ctx)
+	require.NoError(t, err)
+	require.Len(t, clubAlerts, 1)
+	assert.Equal(t, bob.ID, clubAlerts[0].UserID)
+
+	mainBorrowings, err := main.borrowings.GetAllBorrowings(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, mainBorrowings)
+
+	// The HTTP API of each library, selected by path prefix
+	gin.SetMode(gin.TestMode)
+	cfg := &config.Config{
+		Reservations: config.ReservationsConfig{HoldDays: 3},
+		Auth:         config.AuthConfig{Enabled: false, SessionTTL: time.Hour},
+	}
+	router := routes.NewLibraryRouter(db, config.LibrariesConfig{PathPrefix: "/l"}, func(db *database.DB) (http.Handler, error) {
+		engine := gin.New()
+		if err := routes.SetupRoutes(engine, db, nil, cfg); err != nil {
+			return nil, err
+		}
+		return engine, nil
+	})
+
+	listGames := func(path string) []string {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response struct {
+			Games []models.Game `json:"games"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		names := make([]string, 0, len(response.Games))
+		for _, game := range response.Games {
+			names = append(names, game.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"Azul"}, listGames("/api/v1/games"))
+	assert.Equal(t, []string{"Azul"}, listGames("/l/default/api/v1/games"))
+	assert.Equal(t, []string{"Hanabi"}, listGames("/l/ludo/api/v1/games"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", fmt.Sprintf("/l/ludo/api/v1/users/%d", alice.ID), nil))
+	assert.NotEqual(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), fmt.Sprintf("user with id %d not found", alice.ID))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/l/missing/api/v1/games", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
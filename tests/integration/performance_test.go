@@ -5,6 +5,7 @@ import (
 	"board-game-library/internal/repositories"
 	"board-game-library/internal/services"
 	"board-game-library/pkg/database"
+	"context"
 	"fmt"
 	"runtime"
 	"sync"
@@ -17,6 +18,7 @@ import (
 
 // TestConcurrentUserAccess tests the system's ability to handle multiple concurrent users
 func TestConcurrentUserAccess(t *testing.T) {
+	ctx := context.Background()
 	if testing.Short() {
 		t.Skip("Skipping performance tests in short mode")
 	}
@@ -48,7 +50,7 @@ func TestConcurrentUserAccess(t *testing.T) {
 			go func(index int) {
 				defer wg.Done()
 				
-				user, err := userService.RegisterUser(
+				user, err := userService.RegisterUser(ctx, 
 					fmt.Sprintf("Concurrent User %d", index),
 					fmt.Sprintf("concurrent%d@example.com", index),
 				)
@@ -84,7 +86,7 @@ func TestConcurrentUserAccess(t *testing.T) {
 		assert.Equal(t, numUsers, successCount, "All users should be registered successfully")
 
 		// Verify all users exist in database
-		allUsers, err := userService.GetAllUsers()
+		allUsers, err := userService.GetAllUsers(ctx)
 		require.NoError(t, err)
 		
 		concurrentUsers := 0
@@ -106,7 +108,7 @@ func TestConcurrentUserAccess(t *testing.T) {
 
 		// Create users
 		for i := 0; i < numUsers; i++ {
-			user, err := userService.RegisterUser(
+			user, err := userService.RegisterUser(ctx, 
 				fmt.Sprintf("Borrowing User %d", i),
 				fmt.Sprintf("borrowing%d@example.com", i),
 			)
@@ -116,7 +118,7 @@ func TestConcurrentUserAccess(t *testing.T) {
 
 		// Create games
 		for i := 0; i < numGames; i++ {
-			game, err := gameService.AddGame(
+			game, err := gameService.AddGame(ctx, 
 				fmt.Sprintf("Concurrent Game %d", i),
 				fmt.Sprintf("Description for game %d", i),
 				"Concurrent",
@@ -140,7 +142,7 @@ func TestConcurrentUserAccess(t *testing.T) {
 				user := users[userIndex]
 				game := games[userIndex] // Each user gets a different game
 				
-				_, err := borrowingService.BorrowGame(
+				_, err := borrowingService.BorrowGame(ctx, 
 					user.ID,
 					game.ID,
 					time.Now().Add(14*24*time.Hour),
@@ -168,7 +170,7 @@ func TestConcurrentUserAccess(t *testing.T) {
 
 		// Verify all games are borrowed
 		for _, game := range games {
-			updatedGame, err := gameService.GetGame(game.ID)
+			updatedGame, err := gameService.GetGame(ctx, game.ID)
 			require.NoError(t, err)
 			assert.False(t, updatedGame.IsAvailable, "Game %d should be borrowed", game.ID)
 		}
@@ -178,13 +180,13 @@ func TestConcurrentUserAccess(t *testing.T) {
 		const numUsers = 10
 
 		// Create one game that multiple users will try to borrow
-		game, err := gameService.AddGame("Race Condition Game", "Only one can borrow", "Race", "good")
+		game, err := gameService.AddGame(ctx, "Race Condition Game", "Only one can borrow", "Race", "good")
 		require.NoError(t, err)
 
 		// Create multiple users
 		var users []*models.User
 		for i := 0; i < numUsers; i++ {
-			user, err := userService.RegisterUser(
+			user, err := userService.RegisterUser(ctx, 
 				fmt.Sprintf("Race User %d", i),
 				fmt.Sprintf("race%d@example.com", i),
 			)
@@ -204,7 +206,7 @@ func TestConcurrentUserAccess(t *testing.T) {
 				defer wg.Done()
 				
 				user := users[userIndex]
-				_, err := borrowingService.BorrowGame(
+				_, err := borrowingService.BorrowGame(ctx, 
 					user.ID,
 					game.ID,
 					time.Now().Add(14*24*time.Hour),
@@ -235,7 +237,7 @@ func TestConcurrentUserAccess(t *testing.T) {
 		assert.Equal(t, numUsers-1, errorCount, "All other attempts should fail")
 
 		// Verify game is not available
-		updatedGame, err := gameService.GetGame(game.ID)
+		updatedGame, err := gameService.GetGame(ctx, game.ID)
 		require.NoError(t, err)
 		assert.False(t, updatedGame.IsAvailable, "Game should be borrowed")
 	})
@@ -243,6 +245,7 @@ func TestConcurrentUserAccess(t *testing.T) {
 
 // TestLargeDatasetPerformance tests system performance with large amounts of data
 func TestLargeDatasetPerformance(t *testing.T) {
+	ctx := context.Background()
 	if testing.Short() {
 		t.Skip("Skipping performance tests in short mode")
 	}
@@ -269,7 +272,7 @@ func TestLargeDatasetPerformance(t *testing.T) {
 		// Create users
 		start := time.Now()
 		for i := 0; i < numUsers; i++ {
-			_, err := userService.RegisterUser(
+			_, err := userService.RegisterUser(ctx, 
 				fmt.Sprintf("Large Dataset User %d", i),
 				fmt.Sprintf("large%d@example.com", i),
 			)
@@ -281,7 +284,7 @@ func TestLargeDatasetPerformance(t *testing.T) {
 		// Create games
 		start = time.Now()
 		for i := 0; i < numGames; i++ {
-			_, err := gameService.AddGame(
+			_, err := gameService.AddGame(ctx, 
 				fmt.Sprintf("Large Dataset Game %d", i),
 				fmt.Sprintf("Description for large dataset game %d", i),
 				"Large",
@@ -300,14 +303,14 @@ func TestLargeDatasetPerformance(t *testing.T) {
 	t.Run("Large Dataset Queries", func(t *testing.T) {
 		// Test retrieving all users
 		start := time.Now()
-		users, err := userService.GetAllUsers()
+		users, err := userService.GetAllUsers(ctx)
 		userQueryTime := time.Since(start)
 		require.NoError(t, err)
 		t.Logf("Retrieved %d users in %v", len(users), userQueryTime)
 
 		// Test retrieving all games
 		start = time.Now()
-		games, err := gameService.GetAllGames()
+		games, err := gameService.GetAllGames(ctx)
 		gameQueryTime := time.Since(start)
 		require.NoError(t, err)
 		t.Logf("Retrieved %d games in %v", len(games), gameQueryTime)
@@ -322,7 +325,7 @@ func TestLargeDatasetPerformance(t *testing.T) {
 	t.Run("Large Dataset Search Performance", func(t *testing.T) {
 		// Test game search with large dataset
 		start := time.Now()
-		searchResults, err := gameService.SearchGames("Large Dataset Game 1")
+		searchResults, err := gameService.SearchGames(ctx, "Large Dataset Game 1")
 		searchTime := time.Since(start)
 		require.NoError(t, err)
 		t.Logf("Search returned %d results in %v", len(searchResults), searchTime)
@@ -334,11 +337,11 @@ func TestLargeDatasetPerformance(t *testing.T) {
 
 	t.Run("Large Dataset Borrowing Operations", func(t *testing.T) {
 		// Get some users and games for borrowing
-		users, err := userService.GetAllUsers()
+		users, err := userService.GetAllUsers(ctx)
 		require.NoError(t, err)
 		require.Greater(t, len(users), 100, "Need at least 100 users for test")
 
-		games, err := gameService.GetAllGames()
+		games, err := gameService.GetAllGames(ctx)
 		require.NoError(t, err)
 		require.Greater(t, len(games), 100, "Need at least 100 games for test")
 
@@ -350,7 +353,7 @@ func TestLargeDatasetPerformance(t *testing.T) {
 			user := users[i%len(users)]
 			game := games[i] // Use different games to avoid conflicts
 			
-			_, err := borrowingService.BorrowGame(
+			_, err := borrowingService.BorrowGame(ctx, 
 				user.ID,
 				game.ID,
 				time.Now().Add(14*24*time.Hour),
@@ -366,7 +369,7 @@ func TestLargeDatasetPerformance(t *testing.T) {
 
 		// Test querying borrowings
 		start = time.Now()
-		_, err = userService.GetUserBorrowings(users[0].ID)
+		_, err = userService.GetUserBorrowings(ctx, users[0].ID)
 		queryTime := time.Since(start)
 		require.NoError(t, err)
 		t.Logf("Retrieved user borrowings in %v", queryTime)
@@ -380,10 +383,10 @@ func TestLargeDatasetPerformance(t *testing.T) {
 		runtime.ReadMemStats(&m1)
 
 		// Perform memory-intensive operations
-		users, err := userService.GetAllUsers()
+		users, err := userService.GetAllUsers(ctx)
 		require.NoError(t, err)
 
-		games, err := gameService.GetAllGames()
+		games, err := gameService.GetAllGames(ctx)
 		require.NoError(t, err)
 
 		// Force garbage collection and read memory stats
@@ -401,6 +404,7 @@ func TestLargeDatasetPerformance(t *testing.T) {
 
 // TestSystemStressTest performs stress testing on the system
 func TestSystemStressTest(t *testing.T) {
+	ctx := context.Background()
 	if testing.Short() {
 		t.Skip("Skipping stress tests in short mode")
 	}
@@ -445,7 +449,7 @@ func TestSystemStressTest(t *testing.T) {
 						switch operationCount % 4 {
 						case 0:
 							// Create user
-							_, err := userService.RegisterUser(
+							_, err := userService.RegisterUser(ctx, 
 								fmt.Sprintf("Stress User %d-%d", workerID, operationCount),
 								fmt.Sprintf("stress%d-%d@example.com", workerID, operationCount),
 							)
@@ -454,7 +458,7 @@ func TestSystemStressTest(t *testing.T) {
 							}
 						case 1:
 							// Create game
-							_, err := gameService.AddGame(
+							_, err := gameService.AddGame(ctx, 
 								fmt.Sprintf("Stress Game %d-%d", workerID, operationCount),
 								"Stress test game",
 								"Stress",
@@ -465,13 +469,13 @@ func TestSystemStressTest(t *testing.T) {
 							}
 						case 2:
 							// Query users
-							_, err := userService.GetAllUsers()
+							_, err := userService.GetAllUsers(ctx)
 							if err == nil {
 								operationCount++
 							}
 						case 3:
 							// Query games
-							_, err := gameService.GetAllGames()
+							_, err := gameService.GetAllGames(ctx)
 							if err == nil {
 								operationCount++
 							}
@@ -509,7 +513,7 @@ func TestSystemStressTest(t *testing.T) {
 		var games []*models.Game
 
 		for i := 0; i < numUsers; i++ {
-			user, err := userService.RegisterUser(
+			user, err := userService.RegisterUser(ctx, 
 				fmt.Sprintf("Alert User %d", i),
 				fmt.Sprintf("alert%d@example.com", i),
 			)
@@ -518,7 +522,7 @@ func TestSystemStressTest(t *testing.T) {
 		}
 
 		for i := 0; i < numGames; i++ {
-			game, err := gameService.AddGame(
+			game, err := gameService.AddGame(ctx, 
 				fmt.Sprintf("Alert Game %d", i),
 				"Alert test game",
 				"Alert",
@@ -530,7 +534,7 @@ func TestSystemStressTest(t *testing.T) {
 
 		// Create overdue borrowings
 		for i := 0; i < numUsers; i++ {
-			_, err := borrowingService.BorrowGame(
+			_, err := borrowingService.BorrowGame(ctx, 
 				users[i].ID,
 				games[i].ID,
 				time.Now().Add(-24*time.Hour), // Overdue by 1 day
@@ -540,14 +544,14 @@ func TestSystemStressTest(t *testing.T) {
 
 		// Test alert generation performance
 		start := time.Now()
-		err = alertService.GenerateOverdueAlerts()
+		err = alertService.GenerateOverdueAlerts(ctx)
 		alertGenerationTime := time.Since(start)
 		require.NoError(t, err)
 
 		t.Logf("Generated overdue alerts in %v", alertGenerationTime)
 
 		// Verify alerts were created
-		alerts, err := alertService.GetActiveAlerts()
+		alerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		t.Logf("Generated %d alerts", len(alerts))
 
@@ -559,6 +563,7 @@ func TestSystemStressTest(t *testing.T) {
 
 // TestMemoryLeakDetection tests for memory leaks during extended operations
 func TestMemoryLeakDetection(t *testing.T) {
+	ctx := context.Background()
 	if testing.Short() {
 		t.Skip("Skipping memory leak tests in short mode")
 	}
@@ -585,14 +590,14 @@ func TestMemoryLeakDetection(t *testing.T) {
 		const iterations = 1000
 		for i := 0; i < iterations; i++ {
 			// Create user
-			user, err := userService.RegisterUser(
+			user, err := userService.RegisterUser(ctx, 
 				fmt.Sprintf("Memory Test User %d", i),
 				fmt.Sprintf("memory%d@test.com", i),
 			)
 			require.NoError(t, err)
 
 			// Create game
-			game, err := gameService.AddGame(
+			game, err := gameService.AddGame(ctx, 
 				fmt.Sprintf("Memory Test Game %d", i),
 				"Memory test game",
 				"Memory",
@@ -601,10 +606,10 @@ func TestMemoryLeakDetection(t *testing.T) {
 			require.NoError(t, err)
 
 			// Borrow and return
-			borrowing, err := borrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+			borrowing, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 			require.NoError(t, err)
 
-			err = borrowingService.ReturnGame(borrowing.ID)
+			err = borrowingService.ReturnGame(ctx, borrowing.ID, nil)
 			require.NoError(t, err)
 
 			// Periodic garbage collection
@@ -645,20 +650,20 @@ func TestMemoryLeakDetection(t *testing.T) {
 						IsActive:     true,
 					}
 
-					if err := userRepo.Create(user); err != nil {
+					if err := userRepo.Create(ctx, user); err != nil {
 						errors <- err
 						continue
 					}
 
 					// Read operation
-					if _, err := userRepo.GetByID(user.ID); err != nil {
+					if _, err := userRepo.GetByID(ctx, user.ID); err != nil {
 						errors <- err
 						continue
 					}
 
 					// Update operation
 					user.Name = fmt.Sprintf("Updated %s", user.Name)
-					if err := userRepo.Update(user); err != nil {
+					if err := userRepo.Update(ctx, user); err != nil {
 						errors <- err
 						continue
 					}
@@ -687,6 +692,7 @@ func TestMemoryLeakDetection(t *testing.T) {
 
 // TestScalabilityLimits tests system behavior at scale limits
 func TestScalabilityLimits(t *testing.T) {
+	ctx := context.Background()
 	if testing.Short() {
 		t.Skip("Skipping scalability tests in short mode")
 	}
@@ -715,7 +721,7 @@ func TestScalabilityLimits(t *testing.T) {
 		start := time.Now()
 		users := make([]*models.User, numUsers)
 		for i := 0; i < numUsers; i++ {
-			user, err := userService.RegisterUser(
+			user, err := userService.RegisterUser(ctx, 
 				fmt.Sprintf("Scale User %d", i),
 				fmt.Sprintf("scale%d@test.com", i),
 			)
@@ -729,7 +735,7 @@ func TestScalabilityLimits(t *testing.T) {
 		start = time.Now()
 		games := make([]*models.Game, numGames)
 		for i := 0; i < numGames; i++ {
-			game, err := gameService.AddGame(
+			game, err := gameService.AddGame(ctx, 
 				fmt.Sprintf("Scale Game %d", i),
 				fmt.Sprintf("Description for scale game %d", i),
 				"Scale",
@@ -747,7 +753,7 @@ func TestScalabilityLimits(t *testing.T) {
 			userIndex := i % numUsers
 			gameIndex := i % numGames
 			
-			_, err := borrowingService.BorrowGame(
+			_, err := borrowingService.BorrowGame(ctx, 
 				users[userIndex].ID,
 				games[gameIndex].ID,
 				time.Now().Add(14*24*time.Hour),
@@ -759,14 +765,14 @@ func TestScalabilityLimits(t *testing.T) {
 
 		// Test query performance with large dataset
 		start = time.Now()
-		allUsers, err := userService.GetAllUsers()
+		allUsers, err := userService.GetAllUsers(ctx)
 		userQueryTime := time.Since(start)
 		require.NoError(t, err)
 		assert.Len(t, allUsers, numUsers)
 		t.Logf("Queried %d users in %v", len(allUsers), userQueryTime)
 
 		start = time.Now()
-		allGames, err := gameService.GetAllGames()
+		allGames, err := gameService.GetAllGames(ctx)
 		gameQueryTime := time.Since(start)
 		require.NoError(t, err)
 		assert.Len(t, allGames, numGames)
@@ -789,14 +795,14 @@ func TestScalabilityLimits(t *testing.T) {
 
 		// Create users and games
 		for i := 0; i < numOverdueItems; i++ {
-			user, err := userService.RegisterUser(
+			user, err := userService.RegisterUser(ctx, 
 				fmt.Sprintf("Alert Scale User %d", i),
 				fmt.Sprintf("alertscale%d@test.com", i),
 			)
 			require.NoError(t, err)
 			users[i] = user
 
-			game, err := gameService.AddGame(
+			game, err := gameService.AddGame(ctx, 
 				fmt.Sprintf("Alert Scale Game %d", i),
 				"Game for alert scalability testing",
 				"AlertScale",
@@ -808,7 +814,7 @@ func TestScalabilityLimits(t *testing.T) {
 
 		// Create overdue borrowings
 		for i := 0; i < numOverdueItems; i++ {
-			borrowing, err := borrowingService.BorrowGame(
+			borrowing, err := borrowingService.BorrowGame(ctx, 
 				users[i].ID,
 				games[i].ID,
 				time.Now().Add(-time.Duration(i%10+1)*24*time.Hour), // Various overdue periods
@@ -817,25 +823,25 @@ func TestScalabilityLimits(t *testing.T) {
 
 			// Mark as overdue
 			borrowing.IsOverdue = true
-			err = borrowingRepo.Update(borrowing)
+			err = borrowingRepo.Update(ctx, borrowing)
 			require.NoError(t, err)
 		}
 
 		// Test alert generation performance
 		start := time.Now()
-		err = alertService.GenerateOverdueAlerts()
+		err = alertService.GenerateOverdueAlerts(ctx)
 		alertGenerationTime := time.Since(start)
 		require.NoError(t, err)
 		t.Logf("Generated alerts for %d overdue items in %v", numOverdueItems, alertGenerationTime)
 
 		// Verify alerts were created
-		alerts, err := alertService.GetActiveAlerts()
+		alerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		assert.Len(t, alerts, numOverdueItems)
 
 		// Test alert query performance
 		start = time.Now()
-		summary, err := alertService.GetAlertsSummaryByUser()
+		summary, err := alertService.GetAlertsSummaryByUser(ctx)
 		summaryTime := time.Since(start)
 		require.NoError(t, err)
 		assert.Len(t, summary, numOverdueItems)
@@ -854,7 +860,7 @@ func TestScalabilityLimits(t *testing.T) {
 		categories := []string{"Strategy", "Family", "Card", "Board", "Puzzle"}
 		for i := 0; i < numSearchGames; i++ {
 			category := categories[i%len(categories)]
-			_, err := gameService.AddGame(
+			_, err := gameService.AddGame(ctx, 
 				fmt.Sprintf("Search %s Game %d", category, i),
 				fmt.Sprintf("Searchable game %d in %s category", i, category),
 				category,
@@ -868,7 +874,7 @@ func TestScalabilityLimits(t *testing.T) {
 		
 		for _, query := range searchQueries {
 			start := time.Now()
-			results, err := gameService.SearchGames(query)
+			results, err := gameService.SearchGames(ctx, query)
 			searchTime := time.Since(start)
 			require.NoError(t, err)
 			
@@ -0,0 +1,93 @@
+package integration
+
+import (
+	"board-game-library/internal/models"
+	"board-game-library/internal/repositories"
+	"board-game-library/internal/services"
+	"board-game-library/pkg/database"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTransferLibrary returns an empty library and its bulk transfer service
+func newTransferLibrary(t *testing.T) (*database.DB, *services.TransferService) {
+	t.Helper()
+	db, err := database.InitializeForTesting()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db, services.NewTransferService(repositories.NewSQLiteUnitOfWork(db), repositories.NewSQLiteExportRepository(db))
+}
+
+// TestBulkTransferWorkflow migrates a spreadsheet into a library, then moves
+// the whole library to another one through its exports
+func TestBulkTransferWorkflow(t *testing.T) {
+	ctx := context.Background()
+	db, transfer := newTransferLibrary(t)
+
+	imports := []struct {
+		table, format, file string
+	}{
+		{"users", "csv", "name,email,registered_at\nAlice Martin,alice@example.com,2023-09-01\nBob Durand,bob@example.com,2024-02-15\n"},
+		{"games", "json", `[
+			{"name": "Azul", "condition": "good", "tags": ["Abstrait"], "min_players": 2, "max_players": 4},
+			{"name": "Hanabi", "condition": "fair", "designers": ["Antoine Bauza"]}
+		]`},
+		{"borrowings", "csv", "user_email,game_id,borrowed_at,due_date,returned_at\n" +
+			"alice@example.com,1,2024-03-01,2024-03-15,2024-03-10\n" +
+			"bob@example.com,2,2024-04-01,2024-04-15,\n"},
+	}
+	for _, file := range imports {
+		result, err := transfer.Import(ctx, file.table, file.format, strings.NewReader(file.file), false)
+		require.NoError(t, err)
+		require.Empty(t, result.Errors, file.table)
+		assert.True(t, result.Committed)
+	}
+
+	gameRepo := repositories.NewSQLiteGameRepository(db)
+	hanabi, err := gameRepo.GetByID(ctx, 2)
+	require.NoError(t, err)
+	assert.False(t, hanabi.IsAvailable, "the copy of a current borrowing is lent")
+
+	// A second current borrowing of the only copy is rejected, and with it
+	// the whole file
+	result, err := transfer.Import(ctx, "borrowings", "csv", strings.NewReader("user_id,game_id,borrowed_at,due_date\n1,1,2024-05-01,2024-05-10\n1,2,2024-05-01,2024-05-10\n"), false)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ImportRowError{{Line: 3, Message: "no copy of game 2 is available"}}, result.Errors)
+	azul, err := gameRepo.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, azul.IsAvailable, "rejected imports are rolled back")
+
+	// Move everything to a new library, users before the borrowings that
+	// refer to them; CSV exports are checked with a dry run
+	target, targetTransfer := newTransferLibrary(t)
+	for _, table := range models.ValidTransferTables {
+		var csvFile, jsonFile bytes.Buffer
+		require.NoError(t, transfer.Export(ctx, table, "csv", &csvFile))
+		require.NoError(t, transfer.Export(ctx, table, "json", &jsonFile))
+
+		result, err := targetTransfer.Import(ctx, table, "csv", &csvFile, true)
+		require.NoError(t, err)
+		require.Empty(t, result.Errors, table)
+
+		result, err = targetTransfer.Import(ctx, table, "json", &jsonFile, false)
+		require.NoError(t, err)
+		require.Empty(t, result.Errors, table)
+	}
+
+	var exported, copied bytes.Buffer
+	require.NoError(t, transfer.Export(ctx, "borrowings", "csv", &exported))
+	require.NoError(t, targetTransfer.Export(ctx, "borrowings", "csv", &copied))
+	assert.Equal(t, exported.String(), copied.String())
+
+	borrowings, err := repositories.NewSQLiteUserRepository(target).GetBorrowingHistory(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, borrowings, 1)
+	assert.Nil(t, borrowings[0].ReturnedAt)
+	assert.True(t, borrowings[0].IsOverdue)
+}
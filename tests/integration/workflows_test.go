@@ -5,7 +5,10 @@ import (
 	"board-game-library/internal/repositories"
 	"board-game-library/internal/services"
 	"board-game-library/pkg/database"
+	"context"
 	"fmt"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 // TestUserRegistrationAndBorrowingWorkflow tests the complete end-to-end workflow
 // from user registration through game borrowing and return
 func TestUserRegistrationAndBorrowingWorkflow(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -35,7 +39,7 @@ func TestUserRegistrationAndBorrowingWorkflow(t *testing.T) {
 
 	t.Run("Complete User Registration and Borrowing Workflow", func(t *testing.T) {
 		// Step 1: Register a new user
-		user, err := userService.RegisterUser("John Doe", "john.doe@example.com")
+		user, err := userService.RegisterUser(ctx, "John Doe", "john.doe@example.com")
 		require.NoError(t, err)
 		assert.NotZero(t, user.ID)
 		assert.Equal(t, "John Doe", user.Name)
@@ -43,19 +47,19 @@ func TestUserRegistrationAndBorrowingWorkflow(t *testing.T) {
 		assert.True(t, user.IsActive)
 
 		// Step 2: Add a game to the library
-		game, err := gameService.AddGame("Settlers of Catan", "A strategy board game", "Strategy", "excellent")
+		game, err := gameService.AddGame(ctx, "Settlers of Catan", "A strategy board game", "Strategy", "excellent")
 		require.NoError(t, err)
 		assert.NotZero(t, game.ID)
 		assert.True(t, game.IsAvailable)
 
 		// Step 3: Verify user can borrow
-		canBorrow, err := userService.CanUserBorrow(user.ID)
+		canBorrow, err := userService.CanUserBorrow(ctx, user.ID)
 		require.NoError(t, err)
 		assert.True(t, canBorrow)
 
 		// Step 4: Borrow the game
 		dueDate := time.Now().Add(14 * 24 * time.Hour)
-		borrowing, err := borrowingService.BorrowGame(user.ID, game.ID, dueDate)
+		borrowing, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, dueDate)
 		require.NoError(t, err)
 		assert.NotZero(t, borrowing.ID)
 		assert.Equal(t, user.ID, borrowing.UserID)
@@ -63,82 +67,82 @@ func TestUserRegistrationAndBorrowingWorkflow(t *testing.T) {
 		assert.False(t, borrowing.IsOverdue)
 
 		// Step 5: Verify game is no longer available
-		updatedGame, err := gameService.GetGame(game.ID)
+		updatedGame, err := gameService.GetGame(ctx, game.ID)
 		require.NoError(t, err)
 		assert.False(t, updatedGame.IsAvailable)
 
 		// Step 6: Verify user has active borrowing
-		activeBorrowings, err := userService.GetActiveUserBorrowings(user.ID)
+		activeBorrowings, err := userService.GetActiveUserBorrowings(ctx, user.ID)
 		require.NoError(t, err)
 		assert.Len(t, activeBorrowings, 1)
 		assert.Equal(t, borrowing.ID, activeBorrowings[0].ID)
 
 		// Step 7: Verify user cannot borrow another game while having active borrowing
-		game2, err := gameService.AddGame("Ticket to Ride", "A railway-themed board game", "Strategy", "good")
+		game2, err := gameService.AddGame(ctx, "Ticket to Ride", "A railway-themed board game", "Strategy", "good")
 		require.NoError(t, err)
 
 		// Step 8: Return the game
-		err = borrowingService.ReturnGame(borrowing.ID)
+		err = borrowingService.ReturnGame(ctx, borrowing.ID, nil)
 		require.NoError(t, err)
 
 		// Step 9: Verify game is available again
-		updatedGame, err = gameService.GetGame(game.ID)
+		updatedGame, err = gameService.GetGame(ctx, game.ID)
 		require.NoError(t, err)
 		assert.True(t, updatedGame.IsAvailable)
 
 		// Step 10: Verify borrowing is marked as returned
-		returnedBorrowings, err := userService.GetUserBorrowings(user.ID)
+		returnedBorrowings, err := userService.GetUserBorrowings(ctx, user.ID)
 		require.NoError(t, err)
 		assert.Len(t, returnedBorrowings, 1)
 		assert.NotNil(t, returnedBorrowings[0].ReturnedAt)
 
 		// Step 11: Verify user can now borrow the second game
-		canBorrow, err = userService.CanUserBorrow(user.ID)
+		canBorrow, err = userService.CanUserBorrow(ctx, user.ID)
 		require.NoError(t, err)
 		assert.True(t, canBorrow)
 
-		borrowing2, err := borrowingService.BorrowGame(user.ID, game2.ID, time.Now().Add(14*24*time.Hour))
+		borrowing2, err := borrowingService.BorrowGame(ctx, user.ID, game2.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 		assert.NotZero(t, borrowing2.ID)
 	})
 
 	t.Run("Multiple Users Borrowing Different Games", func(t *testing.T) {
 		// Register multiple users
-		user1, err := userService.RegisterUser("Alice Smith", "alice@example.com")
+		user1, err := userService.RegisterUser(ctx, "Alice Smith", "alice@example.com")
 		require.NoError(t, err)
 
-		user2, err := userService.RegisterUser("Bob Johnson", "bob@example.com")
+		user2, err := userService.RegisterUser(ctx, "Bob Johnson", "bob@example.com")
 		require.NoError(t, err)
 
 		// Add multiple games
-		game1, err := gameService.AddGame("Monopoly", "Classic property trading game", "Family", "good")
+		game1, err := gameService.AddGame(ctx, "Monopoly", "Classic property trading game", "Family", "good")
 		require.NoError(t, err)
 
-		game2, err := gameService.AddGame("Scrabble", "Word formation game", "Word", "excellent")
+		game2, err := gameService.AddGame(ctx, "Scrabble", "Word formation game", "Word", "excellent")
 		require.NoError(t, err)
 
 		// Both users borrow different games
-		_, err = borrowingService.BorrowGame(user1.ID, game1.ID, time.Now().Add(14*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user1.ID, game1.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 
-		_, err = borrowingService.BorrowGame(user2.ID, game2.ID, time.Now().Add(14*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user2.ID, game2.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 
 		// Verify both borrowings are active
-		activeBorrowings1, err := userService.GetActiveUserBorrowings(user1.ID)
+		activeBorrowings1, err := userService.GetActiveUserBorrowings(ctx, user1.ID)
 		require.NoError(t, err)
 		assert.Len(t, activeBorrowings1, 1)
 
-		activeBorrowings2, err := userService.GetActiveUserBorrowings(user2.ID)
+		activeBorrowings2, err := userService.GetActiveUserBorrowings(ctx, user2.ID)
 		require.NoError(t, err)
 		assert.Len(t, activeBorrowings2, 1)
 
 		// Verify games are not available
-		updatedGame1, err := gameService.GetGame(game1.ID)
+		updatedGame1, err := gameService.GetGame(ctx, game1.ID)
 		require.NoError(t, err)
 		assert.False(t, updatedGame1.IsAvailable)
 
-		updatedGame2, err := gameService.GetGame(game2.ID)
+		updatedGame2, err := gameService.GetGame(ctx, game2.ID)
 		require.NoError(t, err)
 		assert.False(t, updatedGame2.IsAvailable)
 	})
@@ -146,6 +150,7 @@ func TestUserRegistrationAndBorrowingWorkflow(t *testing.T) {
 
 // TestOverdueWorkflow tests the complete overdue item workflow
 func TestOverdueWorkflow(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -165,29 +170,29 @@ func TestOverdueWorkflow(t *testing.T) {
 
 	t.Run("Overdue Item Processing and Alert Generation", func(t *testing.T) {
 		// Step 1: Create user and game
-		user, err := userService.RegisterUser("Test User", "test@example.com")
+		user, err := userService.RegisterUser(ctx, "Test User", "test@example.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Test Game", "A test game", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Test Game", "A test game", "Test", "good")
 		require.NoError(t, err)
 
 		// Step 2: Create a borrowing with future due date, then make it overdue
 		futureDueDate := time.Now().Add(14 * 24 * time.Hour) // Initially 14 days in future
-		borrowing, err := borrowingService.BorrowGame(user.ID, game.ID, futureDueDate)
+		borrowing, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, futureDueDate)
 		require.NoError(t, err)
 
 		// Step 3: Manually update the borrowing to be overdue (simulating time passage)
 		borrowing.DueDate = time.Now().Add(-2 * 24 * time.Hour) // 2 days overdue
 		borrowing.IsOverdue = true
-		err = borrowingRepo.Update(borrowing)
+		err = borrowingRepo.Update(ctx, borrowing)
 		require.NoError(t, err)
 
 		// Step 4: Generate overdue alerts
-		err = alertService.GenerateOverdueAlerts()
+		err = alertService.GenerateOverdueAlerts(ctx)
 		require.NoError(t, err)
 
 		// Step 5: Verify alert was created
-		alerts, err := alertService.GetActiveAlerts()
+		alerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		assert.Len(t, alerts, 1)
 		assert.Equal(t, "overdue", alerts[0].Type)
@@ -196,40 +201,40 @@ func TestOverdueWorkflow(t *testing.T) {
 		assert.False(t, alerts[0].IsRead)
 
 		// Step 6: Verify user cannot borrow while having overdue items
-		canBorrow, err := userService.CanUserBorrow(user.ID)
+		canBorrow, err := userService.CanUserBorrow(ctx, user.ID)
 		require.Error(t, err)
 		assert.False(t, canBorrow)
 		assert.Contains(t, err.Error(), "overdue")
 
 		// Step 7: Return the overdue game
-		err = borrowingService.ReturnGame(borrowing.ID)
+		err = borrowingService.ReturnGame(ctx, borrowing.ID, nil)
 		require.NoError(t, err)
 
 		// Step 8: Verify user can now borrow again
-		canBorrow, err = userService.CanUserBorrow(user.ID)
+		canBorrow, err = userService.CanUserBorrow(ctx, user.ID)
 		require.NoError(t, err)
 		assert.True(t, canBorrow)
 	})
 
 	t.Run("Reminder Alert Generation", func(t *testing.T) {
 		// Step 1: Create user and game
-		user, err := userService.RegisterUser("Reminder User", "reminder@example.com")
+		user, err := userService.RegisterUser(ctx, "Reminder User", "reminder@example.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Reminder Game", "A game for reminder testing", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Reminder Game", "A game for reminder testing", "Test", "good")
 		require.NoError(t, err)
 
 		// Step 2: Create a borrowing due in 1 day (should trigger reminder)
 		dueSoon := time.Now().Add(1 * 24 * time.Hour)
-		_, err = borrowingService.BorrowGame(user.ID, game.ID, dueSoon)
+		_, err = borrowingService.BorrowGame(ctx, user.ID, game.ID, dueSoon)
 		require.NoError(t, err)
 
 		// Step 3: Generate reminder alerts
-		err = alertService.GenerateReminderAlerts()
+		err = alertService.GenerateReminderAlerts(ctx)
 		require.NoError(t, err)
 
 		// Step 4: Verify reminder alert was created
-		alerts, err := alertService.GetActiveAlerts()
+		alerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		
 		var reminderAlert *models.Alert
@@ -246,11 +251,11 @@ func TestOverdueWorkflow(t *testing.T) {
 		assert.False(t, reminderAlert.IsRead)
 
 		// Step 5: Mark alert as read
-		err = alertService.MarkAlertAsRead(reminderAlert.ID)
+		err = alertService.MarkAlertAsRead(ctx, reminderAlert.ID)
 		require.NoError(t, err)
 
 		// Step 6: Verify alert is marked as read
-		updatedAlerts, err := alertService.GetActiveAlerts()
+		updatedAlerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		
 		for _, alert := range updatedAlerts {
@@ -262,8 +267,178 @@ func TestOverdueWorkflow(t *testing.T) {
 	})
 }
 
+// TestLateFeeWorkflow tests the fines charged for a late return, the
+// borrowing limit on unpaid fines and their settlement
+func TestLateFeeWorkflow(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.InitializeForTesting()
+	require.NoError(t, err)
+	defer db.Close()
+
+	userRepo := repositories.NewSQLiteUserRepository(db)
+	gameRepo := repositories.NewSQLiteGameRepository(db)
+	borrowingRepo := repositories.NewSQLiteBorrowingRepository(db)
+
+	userService := services.NewUserService(userRepo, borrowingRepo)
+	gameService := services.NewGameService(gameRepo, borrowingRepo)
+	borrowingService := services.NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+	borrowingService.SetUnitOfWork(repositories.NewSQLiteUnitOfWork(db))
+	policyService := services.NewLoanPolicyService(repositories.NewSQLiteLoanPolicyRepository(db))
+	fineService := services.NewFineService(repositories.NewSQLiteFineRepository(db), userRepo)
+	borrowingService.SetLoanPolicies(policyService)
+	borrowingService.SetFines(fineService)
+	userService.SetLoanPolicies(policyService)
+	userService.SetFines(fineService)
+
+	// Step 1: Charge 50 cents a day after one day of grace, up to 5 euros,
+	// and stop lending to members owing more than 1 euro
+	policy, err := policyService.GetPolicy(ctx, models.DefaultMembershipTier)
+	require.NoError(t, err)
+	maxBalance := 100
+	policy.FinePerDayCents, policy.FineGraceDays, policy.FineCapCents = 50, 1, 500
+	policy.MaxFineBalanceCents = &maxBalance
+	require.NoError(t, policyService.UpdatePolicy(ctx, policy))
+
+	user, err := userService.RegisterUser(ctx, "Late Larry", "larry@example.com")
+	require.NoError(t, err)
+	game, err := gameService.AddGame(ctx, "Carcassonne", "Tile placement", "Strategy", "good")
+	require.NoError(t, err)
+
+	// Step 2: Return a game four days late
+	borrowing, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(7*24*time.Hour))
+	require.NoError(t, err)
+	borrowing.BorrowedAt = time.Now().Add(-20 * 24 * time.Hour)
+	borrowing.DueDate = time.Now().Add(-4*24*time.Hour - time.Hour)
+	require.NoError(t, borrowingRepo.Update(ctx, borrowing))
+	require.NoError(t, borrowingService.ReturnGame(ctx, borrowing.ID, nil))
+
+	account, err := fineService.GetAccount(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 150, account.BalanceCents)
+	require.Len(t, account.Entries, 1)
+	assert.Equal(t, models.FineKindFine, account.Entries[0].Kind)
+	assert.Equal(t, borrowing.ID, *account.Entries[0].BorrowingID)
+
+	// Step 3: The balance is above the limit of the tier
+	_, err = borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(7*24*time.Hour))
+	require.ErrorIs(t, err, models.ErrPolicyViolation)
+	eligibility, err := userService.CheckEligibility(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PolicyRuleFineBalance, eligibility.Rule)
+
+	// Step 4: Settlements cannot exceed what is owed
+	_, err = fineService.PayFine(ctx, user.ID, 200, "Paid in cash")
+	require.ErrorIs(t, err, models.ErrValidation)
+	_, err = fineService.PayFine(ctx, user.ID, 100, "Paid in cash")
+	require.NoError(t, err)
+	_, err = fineService.WaiveFine(ctx, user.ID, 50, "First late return")
+	require.NoError(t, err)
+
+	balance, err := userService.GetFineBalance(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance)
+
+	// Step 5: The member can borrow again
+	_, err = borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(7*24*time.Hour))
+	require.NoError(t, err)
+}
+
+// TestConditionTrackingWorkflow tests a copy returned with missing pieces:
+// its history, the damage alert for librarians and its repair
+func TestConditionTrackingWorkflow(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.InitializeForTesting()
+	require.NoError(t, err)
+	defer db.Close()
+
+	userRepo := repositories.NewSQLiteUserRepository(db)
+	gameRepo := repositories.NewSQLiteGameRepository(db)
+	borrowingRepo := repositories.NewSQLiteBorrowingRepository(db)
+	alertRepo := repositories.NewSQLiteAlertRepository(db)
+
+	userService := services.NewUserService(userRepo, borrowingRepo)
+	gameService := services.NewGameService(gameRepo, borrowingRepo)
+	borrowingService := services.NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+	borrowingService.SetUnitOfWork(repositories.NewSQLiteUnitOfWork(db))
+	reservationService := services.NewReservationService(repositories.NewSQLiteReservationRepository(db), userRepo, gameRepo, borrowingRepo, alertRepo, 3)
+	conditionService := services.NewConditionService(repositories.NewSQLiteConditionRepository(db), gameRepo, userRepo, alertRepo)
+	borrowingService.SetHoldQueue(reservationService)
+	borrowingService.SetConditions(conditionService)
+	gameService.SetHoldQueue(reservationService)
+	gameService.SetConditions(conditionService)
+
+	librarian, err := userService.RegisterUser(ctx, "Libby Librarian", "libby@example.com")
+	require.NoError(t, err)
+	librarian.Role = models.RoleLibrarian
+	require.NoError(t, userRepo.Update(ctx, librarian))
+	borrower, err := userService.RegisterUser(ctx, "Careless Carl", "carl@example.com")
+	require.NoError(t, err)
+	waiting, err := userService.RegisterUser(ctx, "Patient Pat", "pat@example.com")
+	require.NoError(t, err)
+	game, err := gameService.AddGame(ctx, "Carcassonne", "Tile placement", "Strategy", "good")
+	require.NoError(t, err)
+
+	// Step 1: Lend the only copy while another member waits for it
+	borrowing, err := borrowingService.BorrowGame(ctx, borrower.ID, game.ID, time.Now().Add(7*24*time.Hour))
+	require.NoError(t, err)
+	_, err = reservationService.PlaceHold(ctx, waiting.ID, game.ID)
+	require.NoError(t, err)
+
+	// Step 2: The copy comes back with missing pieces
+	report := &models.ConditionReport{Condition: "missing_pieces", Notes: "Two blue meeples missing"}
+	require.NoError(t, borrowingService.ReturnGame(ctx, borrowing.ID, report))
+
+	gameCopy, err := gameRepo.GetCopyByID(ctx, *borrowing.CopyID)
+	require.NoError(t, err)
+	assert.True(t, gameCopy.InRepair)
+	assert.False(t, gameCopy.IsAvailable)
+	assert.Equal(t, models.ConditionMissingPieces, gameCopy.Condition)
+
+	queue, err := reservationService.GetQueue(ctx, game.ID)
+	require.NoError(t, err)
+	require.Len(t, queue, 1)
+	assert.Equal(t, models.ReservationWaiting, queue[0].Status, "a copy in repair is not held")
+
+	history, err := gameService.GetConditionHistory(ctx, game.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "good", history[0].PreviousCondition)
+	assert.Equal(t, borrowing.ID, *history[0].BorrowingID)
+	assert.Equal(t, report.Notes, history[0].Notes)
+
+	alerts, err := alertRepo.GetByUser(ctx, librarian.ID)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "damage", alerts[0].Type)
+	assert.Contains(t, alerts[0].Message, "Two blue meeples missing")
+
+	// Step 3: Nobody can borrow the copy until it is repaired
+	_, err = borrowingService.BorrowCopy(ctx, borrower.ID, game.ID, gameCopy.ID, time.Now().Add(7*24*time.Hour))
+	require.ErrorIs(t, err, models.ErrConflict)
+
+	// Step 4: The repaired copy goes to the waiting member
+	repaired, err := gameService.ReportCopyCondition(ctx, game.ID, gameCopy.ID, &models.ConditionReport{Condition: "good", Notes: "Meeples replaced"})
+	require.NoError(t, err)
+	assert.False(t, repaired.InRepair)
+	assert.False(t, repaired.IsAvailable, "the repaired copy is held")
+
+	history, err = gameService.GetConditionHistory(ctx, game.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "good", history[0].Condition)
+	assert.Nil(t, history[0].BorrowingID)
+
+	alerts, err = alertRepo.GetByUser(ctx, librarian.ID)
+	require.NoError(t, err)
+	assert.Len(t, alerts, 1, "a repair raises no damage alert")
+
+	_, err = borrowingService.BorrowGame(ctx, waiting.ID, game.ID, time.Now().Add(7*24*time.Hour))
+	require.NoError(t, err)
+}
+
 // TestDueDateExtensionWorkflow tests the due date extension functionality
 func TestDueDateExtensionWorkflow(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -281,24 +456,24 @@ func TestDueDateExtensionWorkflow(t *testing.T) {
 
 	t.Run("Extend Due Date for Active Borrowing", func(t *testing.T) {
 		// Step 1: Create user and game
-		user, err := userService.RegisterUser("Extension User", "extension@example.com")
+		user, err := userService.RegisterUser(ctx, "Extension User", "extension@example.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Extension Game", "A game for extension testing", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Extension Game", "A game for extension testing", "Test", "good")
 		require.NoError(t, err)
 
 		// Step 2: Borrow the game
 		originalDueDate := time.Now().Add(14 * 24 * time.Hour)
-		borrowing, err := borrowingService.BorrowGame(user.ID, game.ID, originalDueDate)
+		borrowing, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, originalDueDate)
 		require.NoError(t, err)
 
 		// Step 3: Extend the due date
 		newDueDate := originalDueDate.Add(7 * 24 * time.Hour) // Extend by 7 days
-		err = borrowingService.ExtendDueDate(borrowing.ID, newDueDate)
+		err = borrowingService.ExtendDueDate(ctx, borrowing.ID, newDueDate)
 		require.NoError(t, err)
 
 		// Step 4: Verify the due date was updated
-		updatedBorrowing, err := borrowingRepo.GetByID(borrowing.ID)
+		updatedBorrowing, err := borrowingRepo.GetByID(ctx, borrowing.ID)
 		require.NoError(t, err)
 		assert.True(t, updatedBorrowing.DueDate.After(originalDueDate))
 		assert.WithinDuration(t, newDueDate, updatedBorrowing.DueDate, time.Minute)
@@ -307,6 +482,7 @@ func TestDueDateExtensionWorkflow(t *testing.T) {
 
 // TestErrorHandlingWorkflows tests various error conditions in workflows
 func TestErrorHandlingWorkflows(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -323,31 +499,31 @@ func TestErrorHandlingWorkflows(t *testing.T) {
 	borrowingService := services.NewBorrowingService(borrowingRepo, userRepo, gameRepo)
 
 	t.Run("Attempt to Borrow Non-existent Game", func(t *testing.T) {
-		user, err := userService.RegisterUser("Error User", "error@example.com")
+		user, err := userService.RegisterUser(ctx, "Error User", "error@example.com")
 		require.NoError(t, err)
 
 		// Try to borrow a game that doesn't exist
-		_, err = borrowingService.BorrowGame(user.ID, 99999, time.Now().Add(14*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user.ID, 99999, time.Now().Add(14*24*time.Hour))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "game not found")
 	})
 
 	t.Run("Attempt to Borrow Already Borrowed Game", func(t *testing.T) {
-		user1, err := userService.RegisterUser("User One", "user1@example.com")
+		user1, err := userService.RegisterUser(ctx, "User One", "user1@example.com")
 		require.NoError(t, err)
 
-		user2, err := userService.RegisterUser("User Two", "user2@example.com")
+		user2, err := userService.RegisterUser(ctx, "User Two", "user2@example.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Popular Game", "A very popular game", "Strategy", "good")
+		game, err := gameService.AddGame(ctx, "Popular Game", "A very popular game", "Strategy", "good")
 		require.NoError(t, err)
 
 		// User1 borrows the game
-		_, err = borrowingService.BorrowGame(user1.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user1.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 
 		// User2 tries to borrow the same game
-		_, err = borrowingService.BorrowGame(user2.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, user2.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "not available")
 	})
@@ -356,18 +532,18 @@ func TestErrorHandlingWorkflows(t *testing.T) {
 		email := "duplicate@example.com"
 		
 		// Register first user
-		_, err := userService.RegisterUser("First User", email)
+		_, err := userService.RegisterUser(ctx, "First User", email)
 		require.NoError(t, err)
 
 		// Try to register second user with same email
-		_, err = userService.RegisterUser("Second User", email)
+		_, err = userService.RegisterUser(ctx, "Second User", email)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "already exists")
 	})
 
 	t.Run("Attempt to Return Non-existent Borrowing", func(t *testing.T) {
 		// Try to return a borrowing that doesn't exist
-		err := borrowingService.ReturnGame(99999)
+		err := borrowingService.ReturnGame(ctx, 99999, nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "borrowing not found")
 	})
@@ -375,6 +551,7 @@ func TestErrorHandlingWorkflows(t *testing.T) {
 
 // TestCompleteLibraryWorkflow tests a comprehensive end-to-end library workflow
 func TestCompleteLibraryWorkflow(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -397,7 +574,7 @@ func TestCompleteLibraryWorkflow(t *testing.T) {
 		// Register multiple users
 		users := make([]*models.User, 3)
 		for i := 0; i < 3; i++ {
-			user, err := userService.RegisterUser(
+			user, err := userService.RegisterUser(ctx, 
 				fmt.Sprintf("Library User %d", i+1),
 				fmt.Sprintf("user%d@library.com", i+1),
 			)
@@ -411,7 +588,7 @@ func TestCompleteLibraryWorkflow(t *testing.T) {
 		categories := []string{"Family", "Word", "Strategy", "Strategy", "Strategy"}
 		
 		for i, name := range gameNames {
-			game, err := gameService.AddGame(
+			game, err := gameService.AddGame(ctx, 
 				name,
 				fmt.Sprintf("Description for %s", name),
 				categories[i],
@@ -423,140 +600,140 @@ func TestCompleteLibraryWorkflow(t *testing.T) {
 
 		// Phase 2: Initial Borrowing Activity
 		// User 1 borrows 2 games
-		borrowing1, err := borrowingService.BorrowGame(users[0].ID, games[0].ID, time.Now().Add(14*24*time.Hour))
+		borrowing1, err := borrowingService.BorrowGame(ctx, users[0].ID, games[0].ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 		
-		borrowing2, err := borrowingService.BorrowGame(users[0].ID, games[1].ID, time.Now().Add(14*24*time.Hour))
+		borrowing2, err := borrowingService.BorrowGame(ctx, users[0].ID, games[1].ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 
 		// User 2 borrows 1 game
-		_, err = borrowingService.BorrowGame(users[1].ID, games[2].ID, time.Now().Add(14*24*time.Hour))
+		_, err = borrowingService.BorrowGame(ctx, users[1].ID, games[2].ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 
 		// Verify borrowing state
-		activeBorrowings1, err := userService.GetActiveUserBorrowings(users[0].ID)
+		activeBorrowings1, err := userService.GetActiveUserBorrowings(ctx, users[0].ID)
 		require.NoError(t, err)
 		assert.Len(t, activeBorrowings1, 2)
 
-		activeBorrowings2, err := userService.GetActiveUserBorrowings(users[1].ID)
+		activeBorrowings2, err := userService.GetActiveUserBorrowings(ctx, users[1].ID)
 		require.NoError(t, err)
 		assert.Len(t, activeBorrowings2, 1)
 
 		// Phase 3: Game Availability and Search
 		// Verify borrowed games are not available
 		for i := 0; i < 3; i++ {
-			game, err := gameService.GetGame(games[i].ID)
+			game, err := gameService.GetGame(ctx, games[i].ID)
 			require.NoError(t, err)
 			assert.False(t, game.IsAvailable, "Game %s should not be available", game.Name)
 		}
 
 		// Verify available games can be found
-		availableGames, err := gameService.GetAvailableGames()
+		availableGames, err := gameService.GetAvailableGames(ctx)
 		require.NoError(t, err)
 		assert.Len(t, availableGames, 2) // games[3] and games[4] should be available
 
 		// Test search functionality
-		strategyGames, err := gameService.SearchGames("Strategy")
+		strategyGames, err := gameService.SearchGames(ctx, "Strategy")
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(strategyGames), 2) // Should find strategy games
 
 		// Phase 4: Due Date Management
 		// Extend due date for one borrowing
 		newDueDate := time.Now().Add(21 * 24 * time.Hour)
-		err = borrowingService.ExtendDueDate(borrowing1.ID, newDueDate)
+		err = borrowingService.ExtendDueDate(ctx, borrowing1.ID, newDueDate)
 		require.NoError(t, err)
 
 		// Verify extension
-		updatedBorrowing, err := borrowingService.GetBorrowingDetails(borrowing1.ID)
+		updatedBorrowing, err := borrowingService.GetBorrowingDetails(ctx, borrowing1.ID)
 		require.NoError(t, err)
 		assert.True(t, updatedBorrowing.DueDate.After(time.Now().Add(20*24*time.Hour)))
 
 		// Phase 5: Return Process
 		// User 1 returns one game
-		err = borrowingService.ReturnGame(borrowing2.ID)
+		err = borrowingService.ReturnGame(ctx, borrowing2.ID, nil)
 		require.NoError(t, err)
 
 		// Verify game is available again
-		returnedGame, err := gameService.GetGame(games[1].ID)
+		returnedGame, err := gameService.GetGame(ctx, games[1].ID)
 		require.NoError(t, err)
 		assert.True(t, returnedGame.IsAvailable)
 
 		// Verify user's active borrowings updated
-		activeBorrowings1, err = userService.GetActiveUserBorrowings(users[0].ID)
+		activeBorrowings1, err = userService.GetActiveUserBorrowings(ctx, users[0].ID)
 		require.NoError(t, err)
 		assert.Len(t, activeBorrowings1, 1)
 
 		// Phase 6: Overdue Scenario Simulation
 		// Create a borrowing with future due date, then make it overdue
 		futureDueDate := time.Now().Add(14 * 24 * time.Hour)
-		overdueBorrowing, err := borrowingService.BorrowGame(users[2].ID, games[4].ID, futureDueDate)
+		overdueBorrowing, err := borrowingService.BorrowGame(ctx, users[2].ID, games[4].ID, futureDueDate)
 		require.NoError(t, err)
 
 		// Manually update to be overdue (simulating time passage)
 		overdueBorrowing.DueDate = time.Now().Add(-2 * 24 * time.Hour) // 2 days overdue
 		overdueBorrowing.IsOverdue = true
-		err = borrowingRepo.Update(overdueBorrowing)
+		err = borrowingRepo.Update(ctx, overdueBorrowing)
 		require.NoError(t, err)
 
 		// Generate overdue alerts
-		err = alertService.GenerateOverdueAlerts()
+		err = alertService.GenerateOverdueAlerts(ctx)
 		require.NoError(t, err)
 
 		// Verify alert was created
-		alerts, err := alertService.GetActiveAlerts()
+		alerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		require.Len(t, alerts, 1, "Should have exactly one overdue alert")
 		assert.Equal(t, "overdue", alerts[0].Type)
 		assert.Equal(t, users[2].ID, alerts[0].UserID)
 
 		// Verify user with overdue items cannot borrow
-		canBorrow, err := userService.CanUserBorrow(users[2].ID)
+		canBorrow, err := userService.CanUserBorrow(ctx, users[2].ID)
 		require.Error(t, err)
 		assert.False(t, canBorrow)
 
 		// Phase 7: Alert Management
 		// Mark alert as read
-		err = alertService.MarkAlertAsRead(alerts[0].ID)
+		err = alertService.MarkAlertAsRead(ctx, alerts[0].ID)
 		require.NoError(t, err)
 
 		// Verify alert is marked as read by checking user's alerts
-		userAlerts, err := alertService.GetAlertsByUser(users[2].ID)
+		userAlerts, err := alertService.GetAlertsByUser(ctx, users[2].ID)
 		require.NoError(t, err)
 		require.Len(t, userAlerts, 1, "Should have exactly one alert for user")
 		assert.True(t, userAlerts[0].IsRead)
 
 		// Phase 8: Resolution and Cleanup
 		// Return overdue game
-		err = borrowingService.ReturnGame(overdueBorrowing.ID)
+		err = borrowingService.ReturnGame(ctx, overdueBorrowing.ID, nil)
 		require.NoError(t, err)
 
 		// Verify user can now borrow again
-		canBorrow, err = userService.CanUserBorrow(users[2].ID)
+		canBorrow, err = userService.CanUserBorrow(ctx, users[2].ID)
 		require.NoError(t, err)
 		assert.True(t, canBorrow)
 
 		// Phase 9: Final State Verification
 		// Check final game availability
-		finalAvailableGames, err := gameService.GetAvailableGames()
+		finalAvailableGames, err := gameService.GetAvailableGames(ctx)
 		require.NoError(t, err)
 		assert.Len(t, finalAvailableGames, 3) // 3 games should be available now
 
 		// Check borrowing history
-		user1History, err := userService.GetUserBorrowings(users[0].ID)
+		user1History, err := userService.GetUserBorrowings(ctx, users[0].ID)
 		require.NoError(t, err)
 		assert.Len(t, user1History, 2) // User 1 had 2 borrowings
 
-		user2History, err := userService.GetUserBorrowings(users[1].ID)
+		user2History, err := userService.GetUserBorrowings(ctx, users[1].ID)
 		require.NoError(t, err)
 		assert.Len(t, user2History, 1) // User 2 had 1 borrowing
 
-		user3History, err := userService.GetUserBorrowings(users[2].ID)
+		user3History, err := userService.GetUserBorrowings(ctx, users[2].ID)
 		require.NoError(t, err)
 		assert.Len(t, user3History, 1) // User 3 had 1 borrowing
 
 		// Verify all users can borrow
 		for i, user := range users {
-			canBorrow, err := userService.CanUserBorrow(user.ID)
+			canBorrow, err := userService.CanUserBorrow(ctx, user.ID)
 			require.NoError(t, err, "User %d should be able to borrow", i+1)
 			assert.True(t, canBorrow, "User %d should be able to borrow", i+1)
 		}
@@ -565,6 +742,7 @@ func TestCompleteLibraryWorkflow(t *testing.T) {
 
 // TestDataIntegrityWorkflow tests data consistency across operations
 func TestDataIntegrityWorkflow(t *testing.T) {
+	ctx := context.Background()
 	// Setup test database
 	db, err := database.InitializeForTesting()
 	require.NoError(t, err)
@@ -584,86 +762,220 @@ func TestDataIntegrityWorkflow(t *testing.T) {
 
 	t.Run("Data Consistency Across Operations", func(t *testing.T) {
 		// Create test data
-		user, err := userService.RegisterUser("Integrity User", "integrity@test.com")
+		user, err := userService.RegisterUser(ctx, "Integrity User", "integrity@test.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Integrity Game", "Test game", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Integrity Game", "Test game", "Test", "good")
 		require.NoError(t, err)
 
 		// Test borrowing creates consistent state
-		borrowing, err := borrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		borrowing, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 
 		// Verify game state consistency
-		updatedGame, err := gameService.GetGame(game.ID)
+		updatedGame, err := gameService.GetGame(ctx, game.ID)
 		require.NoError(t, err)
 		assert.False(t, updatedGame.IsAvailable, "Game should be marked as unavailable")
 
 		// Verify user borrowing state
-		userBorrowings, err := userService.GetActiveUserBorrowings(user.ID)
+		userBorrowings, err := userService.GetActiveUserBorrowings(ctx, user.ID)
 		require.NoError(t, err)
 		assert.Len(t, userBorrowings, 1)
 		assert.Equal(t, borrowing.ID, userBorrowings[0].ID)
 
 		// Test return creates consistent state
-		err = borrowingService.ReturnGame(borrowing.ID)
+		err = borrowingService.ReturnGame(ctx, borrowing.ID, nil)
 		require.NoError(t, err)
 
 		// Verify game is available again
-		returnedGame, err := gameService.GetGame(game.ID)
+		returnedGame, err := gameService.GetGame(ctx, game.ID)
 		require.NoError(t, err)
 		assert.True(t, returnedGame.IsAvailable, "Game should be marked as available")
 
 		// Verify user has no active borrowings
-		activeBorrowings, err := userService.GetActiveUserBorrowings(user.ID)
+		activeBorrowings, err := userService.GetActiveUserBorrowings(ctx, user.ID)
 		require.NoError(t, err)
 		assert.Len(t, activeBorrowings, 0)
 
 		// Verify borrowing record is updated
-		returnedBorrowing, err := borrowingService.GetBorrowingDetails(borrowing.ID)
+		returnedBorrowing, err := borrowingService.GetBorrowingDetails(ctx, borrowing.ID)
 		require.NoError(t, err)
 		assert.NotNil(t, returnedBorrowing.ReturnedAt, "Borrowing should have return date")
 	})
 
 	t.Run("Alert Data Consistency", func(t *testing.T) {
 		// Create test data
-		user, err := userService.RegisterUser("Alert User", "alert@test.com")
+		user, err := userService.RegisterUser(ctx, "Alert User", "alert@test.com")
 		require.NoError(t, err)
 
-		game, err := gameService.AddGame("Alert Game", "Test game", "Test", "good")
+		game, err := gameService.AddGame(ctx, "Alert Game", "Test game", "Test", "good")
 		require.NoError(t, err)
 
 		// Create borrowing with future due date, then make it overdue
-		overdueBorrowing, err := borrowingService.BorrowGame(user.ID, game.ID, time.Now().Add(14*24*time.Hour))
+		overdueBorrowing, err := borrowingService.BorrowGame(ctx, user.ID, game.ID, time.Now().Add(14*24*time.Hour))
 		require.NoError(t, err)
 
 		// Manually update to be overdue (simulating time passage)
 		overdueBorrowing.DueDate = time.Now().Add(-24 * time.Hour) // 1 day overdue
 		overdueBorrowing.IsOverdue = true
-		err = borrowingRepo.Update(overdueBorrowing)
+		err = borrowingRepo.Update(ctx, overdueBorrowing)
 		require.NoError(t, err)
 
 		// Generate alerts
-		err = alertService.GenerateOverdueAlerts()
+		err = alertService.GenerateOverdueAlerts(ctx)
 		require.NoError(t, err)
 
 		// Verify alert exists
-		alerts, err := alertService.GetActiveAlerts()
+		alerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		assert.Len(t, alerts, 1)
 
 		// Return the game
-		err = borrowingService.ReturnGame(overdueBorrowing.ID)
+		err = borrowingService.ReturnGame(ctx, overdueBorrowing.ID, nil)
 		require.NoError(t, err)
 
 		// Verify alert still exists but borrowing is resolved
-		finalAlerts, err := alertService.GetActiveAlerts()
+		finalAlerts, err := alertService.GetActiveAlerts(ctx)
 		require.NoError(t, err)
 		assert.Len(t, finalAlerts, 1) // Alert should still exist
 
 		// Verify borrowing is resolved
-		resolvedBorrowing, err := borrowingService.GetBorrowingDetails(overdueBorrowing.ID)
+		resolvedBorrowing, err := borrowingService.GetBorrowingDetails(ctx, overdueBorrowing.ID)
 		require.NoError(t, err)
 		assert.NotNil(t, resolvedBorrowing.ReturnedAt)
 	})
-}
\ No newline at end of file
+}
+// TestConcurrentBorrowWorkflow checks that borrows and returns running through
+// the unit of work cannot lend the same copy twice or return a game twice
+func TestConcurrentBorrowWorkflow(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Initialize(database.Config{DatabasePath: filepath.Join(t.TempDir(), "concurrent.db")})
+	require.NoError(t, err)
+	defer db.Close()
+
+	userRepo := repositories.NewSQLiteUserRepository(db)
+	gameRepo := repositories.NewSQLiteGameRepository(db)
+	borrowingRepo := repositories.NewSQLiteBorrowingRepository(db)
+
+	userService := services.NewUserService(userRepo, borrowingRepo)
+	gameService := services.NewGameService(gameRepo, borrowingRepo)
+	borrowingService := services.NewBorrowingService(borrowingRepo, userRepo, gameRepo)
+	borrowingService.SetUnitOfWork(repositories.NewSQLiteUnitOfWork(db))
+
+	game, err := gameService.AddGame(ctx, "Catan", "Trading and building", "Strategy", "good")
+	require.NoError(t, err)
+
+	const numUsers = 20
+	users := make([]*models.User, numUsers)
+	for i := range users {
+		users[i], err = userService.RegisterUser(ctx, fmt.Sprintf("Racer %d", i), fmt.Sprintf("racer%d@example.com", i))
+		require.NoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+	borrowings := make(chan *models.Borrowing, numUsers)
+	failures := make(chan error, numUsers)
+	for _, user := range users {
+		wg.Add(1)
+		go func(userID int) {
+			defer wg.Done()
+			borrowing, err := borrowingService.BorrowGame(ctx, userID, game.ID, time.Now().Add(14*24*time.Hour))
+			if err != nil {
+				failures <- err
+				return
+			}
+			borrowings <- borrowing
+		}(user.ID)
+	}
+	wg.Wait()
+	close(borrowings)
+	close(failures)
+
+	require.Len(t, borrowings, 1, "Exactly one user should get the only copy")
+	for err := range failures {
+		assert.Equal(t, "game is not available for borrowing", err.Error())
+	}
+
+	all, err := borrowingRepo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	// Returning the same borrowing twice at once succeeds only once
+	borrowing := <-borrowings
+	results := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- borrowingService.ReturnGame(ctx, borrowing.ID, nil)
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var succeeded int
+	for err := range results {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.Contains(t, err.Error(), "already been returned")
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+
+	updatedGame, err := gameService.GetGame(ctx, game.ID)
+	require.NoError(t, err)
+	assert.True(t, updatedGame.IsAvailable)
+	assert.Equal(t, 1, updatedGame.AvailableCopies)
+}
+
+// TestConcurrentFinePaymentsWorkflow tests that the unit of work keeps
+// payments made at once from settling more than the member owes
+func TestConcurrentFinePaymentsWorkflow(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Initialize(database.Config{DatabasePath: filepath.Join(t.TempDir(), "fines.db")})
+	require.NoError(t, err)
+	defer db.Close()
+
+	userRepo := repositories.NewSQLiteUserRepository(db)
+	fineRepo := repositories.NewSQLiteFineRepository(db)
+	userService := services.NewUserService(userRepo, repositories.NewSQLiteBorrowingRepository(db))
+	fineService := services.NewFineService(fineRepo, userRepo)
+	fineService.SetUnitOfWork(repositories.NewSQLiteUnitOfWork(db))
+
+	user, err := userService.RegisterUser(ctx, "Owing Olive", "olive@example.com")
+	require.NoError(t, err)
+	require.NoError(t, fineRepo.Create(ctx, &models.FineEntry{
+		UserID: user.ID, Kind: models.FineKindFine, AmountCents: 300, Reason: "Returned 6 day(s) late", CreatedAt: time.Now(),
+	}))
+
+	// Ten payments of 1 euro for a balance of 3 euros: only three fit
+	const payments = 10
+	var wg sync.WaitGroup
+	results := make(chan error, payments)
+	for i := 0; i < payments; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := fineService.PayFine(ctx, user.ID, 100, "Paid in cash")
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	var succeeded int
+	for err := range results {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.ErrorIs(t, err, models.ErrValidation)
+		}
+	}
+	assert.Equal(t, 3, succeeded)
+
+	account, err := fineService.GetAccount(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, account.BalanceCents)
+	assert.Len(t, account.Entries, 4)
+}
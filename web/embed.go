@@ -0,0 +1,19 @@
+// Package web holds the HTML templates and static assets served by the web UI.
+package web
+
+import "embed"
+
+// FS contains the templates and the static assets
+//
+//go:embed templates static
+var FS embed.FS
+
+// Templates contains all HTML templates
+//
+//go:embed templates
+var Templates embed.FS
+
+// Static contains all static assets (CSS, JS, images)
+//
+//go:embed static
+var Static embed.FS